@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/settings"
+	"github.com/foxcool/greedy-eye/internal/store"
+)
+
+// createAPIKey issues a read/write API key for the user with the given email,
+// creating the user first if needed, and prints it. Every RPC requires
+// credentials, so this is how the first key is obtained. An admin key can also
+// reach other users' data and create users.
+func createAPIKey(ctx context.Context, users settings.Store, keys auth.Store, email string, admin bool) error {
+	u, err := users.GetUserByEmail(ctx, email)
+	if errors.Is(err, store.ErrNotFound) {
+		name, _, _ := strings.Cut(email, "@")
+		u, err = users.CreateUser(ctx, &entity.User{Email: email, Name: name})
+	}
+	if err != nil {
+		return fmt.Errorf("get or create user %s: %w", email, err)
+	}
+
+	scopes := []string{auth.ScopeRead, auth.ScopeWrite}
+	if admin {
+		scopes = append(scopes, auth.ScopeAdmin)
+	}
+
+	key, prefix, hash := auth.GenerateAPIKey()
+	if _, err := keys.CreateAPIKey(ctx, &entity.APIKey{
+		UserID: u.ID,
+		Name:   "bootstrap",
+		Prefix: prefix,
+		Hash:   hash,
+		Scopes: scopes,
+	}); err != nil {
+		return fmt.Errorf("create API key: %w", err)
+	}
+
+	fmt.Println(key)
+	return nil
+}
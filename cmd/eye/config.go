@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/foxcool/greedy-eye/internal/ratelimit"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/foxcool/greedy-eye/internal/service/portfolio"
 	"github.com/knadh/koanf"
 	"github.com/knadh/koanf/parsers/json"
 	"github.com/knadh/koanf/parsers/toml"
@@ -29,8 +33,76 @@ type Config struct {
 	} `koanf:"db"`
 	Server struct {
 		Port int `koanf:"port"`
+		// TrustedProxies are comma-separated addresses and CIDR ranges of
+		// reverse proxies whose X-Forwarded-For header gives the client
+		// address. Without any, the connection's address is used.
+		TrustedProxies string `koanf:"trustedProxies"`
 	} `koanf:"server"`
+	Automation struct {
+		Workers           int           `koanf:"workers"`
+		PollInterval      time.Duration `koanf:"pollInterval"`
+		HeartbeatInterval time.Duration `koanf:"heartbeatInterval"`
+		StaleAfter        time.Duration `koanf:"staleAfter"`
+	} `koanf:"automation"`
+	Auth struct {
+		// TokenTTL is the longest lifetime of a session token.
+		TokenTTL time.Duration `koanf:"tokenTTL"`
+		// KeyRotation is how long a key signs session tokens before it is replaced.
+		KeyRotation time.Duration `koanf:"keyRotation"`
+	} `koanf:"auth"`
+	Secrets struct {
+		// MasterKeys are "version:base64key" pairs separated by commas; the
+		// highest version encrypts new secrets.
+		MasterKeys string `koanf:"masterKeys"`
+		// MasterKeyFile holds more keys in the same format, one per line.
+		MasterKeyFile string `koanf:"masterKeyFile"`
+	} `koanf:"secrets"`
+	// RateLimit sets per-caller limits for each class of procedure; a zero
+	// rate disables a class's limit.
+	RateLimit struct {
+		ratelimit.Config `koanf:",squash"`
+		// Postgres shares limits between replicas.
+		Postgres bool `koanf:"postgres"`
+	} `koanf:"ratelimit"`
+	MarketData struct {
+		// Enrichment ranks the sources of asset metadata.
+		Enrichment marketdata.EnrichConfig `koanf:"enrichment"`
+		// Quality sets the checks that quarantine suspect prices and when
+		// a price source counts as stale.
+		Quality   marketdata.QualityConfig `koanf:"quality"`
+		CoinGecko struct {
+			// Enabled makes CoinGecko a metadata provider.
+			Enabled bool   `koanf:"enabled"`
+			APIKey  string `koanf:"apiKey"`
+			Pro     bool   `koanf:"pro"`
+		} `koanf:"coingecko"`
+		Yahoo struct {
+			// Enabled makes Yahoo Finance a source of stock and fund
+			// prices, dividends and metadata.
+			Enabled bool   `koanf:"enabled"`
+			BaseURL string `koanf:"baseURL"`
+		} `koanf:"yahoo"`
+		ECB struct {
+			// Enabled makes the ECB euro reference rates a source of fiat
+			// exchange rates.
+			Enabled bool   `koanf:"enabled"`
+			BaseURL string `koanf:"baseURL"`
+		} `koanf:"ecb"`
+	} `koanf:"marketdata"`
+	// Tax sets the holding periods and tax years of tax reports.
+	Tax portfolio.TaxConfig `koanf:"tax"`
+	// Import bounds transaction imports.
+	Import portfolio.ImportConfig `koanf:"import"`
+	PubSub struct {
+		// Postgres relays events between replicas with LISTEN/NOTIFY.
+		Postgres bool `koanf:"postgres"`
+	} `koanf:"pubsub"`
 	Services []ServiceConfig `koanf:"services"`
+
+	// CreateAPIKey is set by the --create-api-key flag.
+	CreateAPIKey string `koanf:"-"`
+	// Admin grants the created key the admin scope.
+	Admin bool `koanf:"-"`
 }
 
 // ServiceConfig is a config for a service
@@ -54,8 +126,34 @@ func getConfig() (*Config, error) {
 	// Default values
 
 	defaults := map[string]interface{}{
-		"sentry.tracesSampleRate": 1.0,
-		"server.port":             8080,
+		"sentry.tracesSampleRate":                1.0,
+		"server.port":                            8080,
+		"automation.workers":                     2,
+		"automation.pollInterval":                "1s",
+		"automation.heartbeatInterval":           "10s",
+		"automation.staleAfter":                  "2m",
+		"auth.tokenTTL":                          "1h",
+		"auth.keyRotation":                       "24h",
+		"ratelimit.read.rate":                    20,
+		"ratelimit.read.burst":                   40,
+		"ratelimit.write.rate":                   5,
+		"ratelimit.write.burst":                  10,
+		"ratelimit.expensive.rate":               0.2,
+		"ratelimit.expensive.burst":              3,
+		"ratelimit.ip.rate":                      50,
+		"ratelimit.ip.burst":                     100,
+		"marketdata.quality.maxJumpPercent":      50,
+		"marketdata.quality.maxDeviationPercent": 10,
+		"marketdata.quality.deviationWindow":     "24h",
+		"marketdata.quality.staleAfter":          "96h",
+		"tax.defaultJurisdiction":                "us",
+		"tax.jurisdictions.us.longTermMonths":    12,
+		"tax.jurisdictions.de.longTermMonths":    12,
+		"tax.jurisdictions.de.timezone":          "Europe/Berlin",
+		"tax.jurisdictions.uk.yearStart":         "04-06",
+		"tax.jurisdictions.uk.timezone":          "Europe/London",
+		"tax.jurisdictions.uk.lotMethod":         "average",
+		"import.maxBytes":                        10 << 20,
 	}
 	err = k.Load(confmap.Provider(defaults, "."), nil)
 	if err != nil {
@@ -70,6 +168,8 @@ func getConfig() (*Config, error) {
 		os.Exit(0)
 	}
 	f.String("c", "", "Path to config file")
+	f.String("create-api-key", "", "Create an API key for the user with this email, print it and exit")
+	f.Bool("admin", false, "With --create-api-key, grant access to every user's data")
 	err = f.Parse(os.Args[1:])
 	if err != nil {
 		return nil, fmt.Errorf("can't parse command line arguments: %w", err)
@@ -109,5 +209,8 @@ func getConfig() (*Config, error) {
 		return nil, fmt.Errorf("can't unmarshal config: %w", err)
 	}
 
+	config.CreateAPIKey, _ = f.GetString("create-api-key")
+	config.Admin, _ = f.GetBool("admin")
+
 	return &config, nil
 }
@@ -12,9 +12,19 @@ import (
 	"time"
 
 	"connectrpc.com/connect"
+	"github.com/foxcool/greedy-eye/internal/adapter/coingecko"
+	"github.com/foxcool/greedy-eye/internal/adapter/ecb"
+	"github.com/foxcool/greedy-eye/internal/adapter/yahoo"
 	"github.com/foxcool/greedy-eye/internal/api/v1/apiv1connect"
+	"github.com/foxcool/greedy-eye/internal/audit"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/pubsub"
+	"github.com/foxcool/greedy-eye/internal/ratelimit"
+	"github.com/foxcool/greedy-eye/internal/secrets"
+	"github.com/foxcool/greedy-eye/internal/service/automation"
 	"github.com/foxcool/greedy-eye/internal/service/marketdata"
 	"github.com/foxcool/greedy-eye/internal/service/portfolio"
+	"github.com/foxcool/greedy-eye/internal/service/settings"
 	"github.com/foxcool/greedy-eye/internal/store/postgres"
 	"github.com/getsentry/sentry-go"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -54,6 +64,12 @@ func run() error {
 	if config.DB.URL == "" {
 		return fmt.Errorf("database URL cannot be empty")
 	}
+	if err := config.Tax.Validate(); err != nil {
+		return fmt.Errorf("tax config: %w", err)
+	}
+	if err := config.MarketData.Quality.Validate(); err != nil {
+		return fmt.Errorf("market data quality config: %w", err)
+	}
 
 	pool, err := pgxpool.New(context.Background(), config.DB.URL)
 	if err != nil {
@@ -67,13 +83,139 @@ func run() error {
 		log.Info("Bye")
 	}()
 
+	// Load master keys for account secrets
+	secretKeys, err := secrets.LoadKeyring(config.Secrets.MasterKeys, config.Secrets.MasterKeyFile)
+	if err != nil {
+		return fmt.Errorf("load master keys: %w", err)
+	}
+	if secretKeys == nil {
+		log.Warn("No master key configured, accounts cannot store secrets")
+	}
+
 	// Create stores
 	marketDataStore := postgres.NewMarketDataStore(pool)
-	portfolioStore := postgres.NewPortfolioStore(pool)
+	portfolioStore := postgres.NewPortfolioStore(pool, secretKeys)
+	automationStore := postgres.NewAutomationStore(pool)
+	settingsStore := postgres.NewSettingsStore(pool)
+	authStore := postgres.NewAuthStore(pool)
+	auditStore := postgres.NewAuditStore(pool)
+
+	if config.CreateAPIKey != "" {
+		return createAPIKey(context.Background(), settingsStore, authStore, config.CreateAPIKey, config.Admin)
+	}
+
+	// Load session signing keys, creating the first one if needed
+	keyRing := auth.NewKeyRing(authStore, auth.KeyRingConfig{
+		RotateEvery: config.Auth.KeyRotation,
+		TokenTTL:    config.Auth.TokenTTL,
+	}, log)
+	if err := keyRing.Refresh(context.Background()); err != nil {
+		return fmt.Errorf("load signing keys: %w", err)
+	}
+
+	// Move account secrets to the newest master key
+	if n, err := portfolioStore.RotateAccountSecrets(context.Background()); err != nil {
+		return fmt.Errorf("rotate account secrets: %w", err)
+	} else if n > 0 {
+		log.Info("Rewrapped account secrets with the current master key", "accounts", n)
+	}
+
+	// Encrypt secret fields left in plain account data
+	if n, err := portfolioStore.SealPlaintextSecrets(context.Background()); err != nil {
+		return fmt.Errorf("seal plaintext account secrets: %w", err)
+	} else if n > 0 {
+		log.Info("Encrypted secrets stored in plain account data", "accounts", n)
+	}
+
+	// Create the event hub that feeds streaming RPCs
+	events := pubsub.NewHub(log)
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+	go keyRing.Run(relayCtx, time.Minute)
+	if config.PubSub.Postgres {
+		go func() {
+			if err := events.Run(relayCtx, postgres.NewNotifyBroker(pool, "eye_events"), 5*time.Second); err != nil {
+				log.Error("Event relay stopped", slog.Any("error", err))
+			}
+		}()
+	}
+
+	// Limit calls per caller, across replicas if configured
+	proxies, err := auth.ParseProxies(config.Server.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("parse trusted proxies: %w", err)
+	}
+	var rateLimitStore ratelimit.Store = ratelimit.NewMemory()
+	if config.RateLimit.Postgres {
+		rateLimitStore = postgres.NewRateLimitStore(pool)
+	}
+	rateLimiter := ratelimit.NewInterceptor(rateLimitStore, config.RateLimit.Config, proxies, log)
+	ipLimiter := ratelimit.NewIPInterceptor(rateLimitStore, config.RateLimit.Config, proxies, log)
+	go rateLimiter.Run(relayCtx, time.Minute)
+
+	// Collect asset metadata providers, searchers and price providers
+	var metadataProviders []marketdata.MetadataProvider
+	var assetSearchers []marketdata.AssetSearcher
+	var priceProviders []marketdata.PriceProvider
+	if config.MarketData.CoinGecko.Enabled {
+		gecko := marketdata.NewCoinGeckoProvider(coingecko.NewClient(coingecko.Config{
+			APIKey: config.MarketData.CoinGecko.APIKey,
+			Pro:    config.MarketData.CoinGecko.Pro,
+		}), marketDataStore)
+		metadataProviders = append(metadataProviders, gecko)
+		assetSearchers = append(assetSearchers, gecko)
+	}
+	if config.MarketData.Yahoo.Enabled {
+		yahooProvider := marketdata.NewYahooProvider(yahoo.NewClient(yahoo.Config{
+			BaseURL: config.MarketData.Yahoo.BaseURL,
+		}), marketDataStore)
+		metadataProviders = append(metadataProviders, yahooProvider)
+		assetSearchers = append(assetSearchers, yahooProvider)
+		priceProviders = append(priceProviders, yahooProvider)
+	}
+	if config.MarketData.ECB.Enabled {
+		priceProviders = append(priceProviders, marketdata.NewECBProvider(ecb.NewClient(ecb.Config{
+			BaseURL: config.MarketData.ECB.BaseURL,
+		}), marketDataStore))
+	}
+	enricher := marketdata.NewEnricher(config.MarketData.Enrichment, metadataProviders, log)
 
 	// Create handlers
-	marketDataHandler := marketdata.NewHandler(marketDataStore, log)
-	portfolioHandler := portfolio.NewHandler(portfolioStore, log)
+	authHandler := auth.NewHandler(authStore, keyRing, log)
+	auditHandler := audit.NewHandler(auditStore, log)
+	marketDataHandler := marketdata.NewHandler(marketDataStore, events, enricher, assetSearchers, priceProviders, config.MarketData.Quality, log)
+	portfolioHandler := portfolio.NewHandler(portfolioStore, marketDataStore, settingsStore, config.Tax, config.Import, log)
+	settingsHandler := settings.NewHandler(settingsStore, marketDataStore, log)
+	automationHandler := automation.NewHandler(automationStore, portfolioStore, marketDataStore, events, log)
+
+	// Start rule execution workers; they finish running executions before the DB closes.
+	// Their changes are audited as made by the automation system actor.
+	workerCtx, stopWorkers := context.WithCancel(
+		audit.WithSystemActor(context.Background(), auditStore, log, audit.ActorAutomation))
+	workersDone := make(chan struct{})
+	go func() {
+		defer close(workersDone)
+		if err := automationHandler.RunWorkers(workerCtx, automation.WorkerConfig{
+			Workers:           config.Automation.Workers,
+			PollInterval:      config.Automation.PollInterval,
+			HeartbeatInterval: config.Automation.HeartbeatInterval,
+			StaleAfter:        config.Automation.StaleAfter,
+		}); err != nil {
+			log.Error("Rule execution workers stopped", slog.Any("error", err))
+		}
+	}()
+	defer func() {
+		stopWorkers()
+		<-workersDone
+		log.Info("Rule execution workers stopped")
+	}()
+
+	// Turn matured bonds into cash, also as the automation actor
+	go portfolioHandler.RunBondMaturities(workerCtx, time.Hour)
+
+	// Record income from fetched dividends in every user's accounts
+	go portfolioHandler.RunDividendIncome(
+		audit.WithSystemActor(workerCtx, auditStore, log, audit.ActorDividends), time.Hour)
 
 	// Setup HTTP mux
 	mux := http.NewServeMux()
@@ -87,16 +229,57 @@ func run() error {
 		}
 	})
 
-	// Register Connect handlers
-	path, handler := apiv1connect.NewMarketDataServiceHandler(
+	// Register Connect handlers; every procedure is rate limited per client
+	// address, requires authentication, is rate limited per caller, and is
+	// audited if it changes anything
+	authInterceptor := auth.NewInterceptor(auth.NewAuthenticator(authStore, keyRing, log))
+	interceptors := connect.WithInterceptors(
+		loggingInterceptor(log),
+		ipLimiter,
+		authInterceptor,
+		rateLimiter,
+		audit.NewInterceptor(auditStore, proxies, log),
+	)
+
+	path, handler := apiv1connect.NewAuthServiceHandler(
+		authHandler,
+		interceptors,
+	)
+	mux.Handle(path, handler)
+
+	path, handler = apiv1connect.NewAuditServiceHandler(
+		auditHandler,
+		interceptors,
+	)
+	mux.Handle(path, handler)
+
+	path, handler = apiv1connect.NewMarketDataServiceHandler(
 		marketDataHandler,
-		connect.WithInterceptors(loggingInterceptor(log)),
+		interceptors,
 	)
 	mux.Handle(path, handler)
 
 	path, handler = apiv1connect.NewPortfolioServiceHandler(
 		portfolioHandler,
-		connect.WithInterceptors(loggingInterceptor(log)),
+		interceptors,
+	)
+	mux.Handle(path, handler)
+
+	// Portfolio exports are also served as plain file downloads
+	exportProcedure := apiv1connect.PortfolioServiceExportPortfolioProcedure
+	mux.Handle("GET /api/v1/portfolios/{portfolio_id}/export", ipLimiter.Wrap(exportProcedure,
+		authInterceptor.Wrap(exportProcedure,
+			rateLimiter.Wrap(exportProcedure, http.HandlerFunc(portfolioHandler.ServeExport)))))
+
+	path, handler = apiv1connect.NewAutomationServiceHandler(
+		automationHandler,
+		interceptors,
+	)
+	mux.Handle(path, handler)
+
+	path, handler = apiv1connect.NewSettingsServiceHandler(
+		settingsHandler,
+		interceptors,
 	)
 	mux.Handle(path, handler)
 
@@ -105,6 +288,8 @@ func run() error {
 		Addr:    fmt.Sprintf(":%d", config.Server.Port),
 		Handler: h2c.NewHandler(mux, &http2.Server{}),
 	}
+	// End streaming RPCs so they do not hold up graceful shutdown
+	server.RegisterOnShutdown(events.Close)
 
 	// Start server in background
 	errCh := make(chan error, 1)
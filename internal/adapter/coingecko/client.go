@@ -2,6 +2,12 @@ package coingecko
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -10,15 +16,49 @@ import (
 
 // Client implements PriceProvider interface for CoinGecko
 type Client struct {
-	apiKey  string
-	baseURL string
-	rateLimit time.Duration
+	apiKey     string
+	apiKeyName string // Header the API key is sent in
+	baseURL    string
+	rateLimit  time.Duration
+	httpClient *http.Client
 }
 
 // Config holds CoinGecko client configuration
 type Config struct {
 	APIKey string
 	Pro    bool // Use Pro API endpoint
+	// BaseURL overrides the API endpoint.
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// ErrNotFound is returned for coins CoinGecko does not know.
+var ErrNotFound = errors.New("coingecko: not found")
+
+// AssetDetails is descriptive data about a coin.
+type AssetDetails struct {
+	ID            string // CoinGecko coin ID, e.g. "bitcoin"
+	Symbol        string
+	Name          string
+	Description   string // English
+	Categories    []string
+	Homepage      string
+	ImageURL      string
+	MarketCapRank int // Zero if unranked
+	// AssetPlatformID is the chain a token is issued on, empty for a chain's
+	// native coin.
+	AssetPlatformID string
+	// Platforms maps chains to the coin's contract address on them.
+	Platforms map[string]string
+}
+
+// SearchResult is a coin found by a search.
+type SearchResult struct {
+	ID            string // CoinGecko coin ID
+	Symbol        string
+	Name          string
+	MarketCapRank int // Zero if unranked
+	ImageURL      string
 }
 
 // PriceData represents price information for an asset
@@ -45,17 +85,28 @@ type HistoricalPrice struct {
 // NewClient creates a new CoinGecko price data client
 func NewClient(cfg Config) *Client {
 	baseURL := "https://api.coingecko.com/api/v3"
+	apiKeyName := "x-cg-demo-api-key"
 	rateLimit := 50 * time.Millisecond // Free tier: 10-30 calls/minute
 
 	if cfg.Pro {
 		baseURL = "https://pro-api.coingecko.com/api/v3"
+		apiKeyName = "x-cg-pro-api-key"
 		rateLimit = 10 * time.Millisecond // Pro tier: higher rate limits
 	}
+	if cfg.BaseURL != "" {
+		baseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
 
 	return &Client{
-		apiKey:    cfg.APIKey,
-		baseURL:   baseURL,
-		rateLimit: rateLimit,
+		apiKey:     cfg.APIKey,
+		apiKeyName: apiKeyName,
+		baseURL:    baseURL,
+		rateLimit:  rateLimit,
+		httpClient: httpClient,
 	}
 }
 
@@ -79,14 +130,127 @@ func (c *Client) GetMarketChart(ctx context.Context, assetID string, currency st
 	return nil, status.Error(codes.Unimplemented, "GetMarketChart not implemented")
 }
 
-// SearchAssets searches for assets by name or symbol
-func (c *Client) SearchAssets(ctx context.Context, query string) ([]interface{}, error) {
-	return nil, status.Error(codes.Unimplemented, "SearchAssets not implemented")
+// SearchAssets finds coins by name or symbol, best match first.
+func (c *Client) SearchAssets(ctx context.Context, query string) ([]SearchResult, error) {
+	var resp struct {
+		Coins []struct {
+			ID            string `json:"id"`
+			Name          string `json:"name"`
+			Symbol        string `json:"symbol"`
+			MarketCapRank *int   `json:"market_cap_rank"`
+			Large         string `json:"large"`
+		} `json:"coins"`
+	}
+	if err := c.get(ctx, "/search", url.Values{"query": {query}}, &resp); err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, len(resp.Coins))
+	for i, coin := range resp.Coins {
+		results[i] = SearchResult{
+			ID:       coin.ID,
+			Symbol:   strings.ToUpper(coin.Symbol),
+			Name:     coin.Name,
+			ImageURL: coin.Large,
+		}
+		if coin.MarketCapRank != nil {
+			results[i].MarketCapRank = *coin.MarketCapRank
+		}
+	}
+	return results, nil
 }
 
-// GetAssetDetails retrieves detailed information about an asset
-func (c *Client) GetAssetDetails(ctx context.Context, assetID string) (interface{}, error) {
-	return nil, status.Error(codes.Unimplemented, "GetAssetDetails not implemented")
+// GetAssetDetails retrieves detailed information about a coin by its
+// CoinGecko ID.
+func (c *Client) GetAssetDetails(ctx context.Context, assetID string) (*AssetDetails, error) {
+	query := url.Values{}
+	for _, section := range []string{"localization", "tickers", "market_data", "community_data", "developer_data", "sparkline"} {
+		query.Set(section, "false")
+	}
+	var coin struct {
+		ID              string            `json:"id"`
+		Symbol          string            `json:"symbol"`
+		Name            string            `json:"name"`
+		AssetPlatformID *string           `json:"asset_platform_id"`
+		Platforms       map[string]string `json:"platforms"`
+		Categories      []string          `json:"categories"`
+		Description     map[string]string `json:"description"`
+		Links           struct {
+			Homepage []string `json:"homepage"`
+		} `json:"links"`
+		Image struct {
+			Large string `json:"large"`
+			Small string `json:"small"`
+		} `json:"image"`
+		MarketCapRank *int `json:"market_cap_rank"`
+	}
+	if err := c.get(ctx, "/coins/"+url.PathEscape(assetID), query, &coin); err != nil {
+		return nil, err
+	}
+
+	details := &AssetDetails{
+		ID:          coin.ID,
+		Symbol:      strings.ToUpper(coin.Symbol),
+		Name:        coin.Name,
+		Description: strings.TrimSpace(coin.Description["en"]),
+		ImageURL:    coin.Image.Large,
+		Platforms:   map[string]string{},
+	}
+	if details.ImageURL == "" {
+		details.ImageURL = coin.Image.Small
+	}
+	for _, category := range coin.Categories {
+		if category != "" {
+			details.Categories = append(details.Categories, category)
+		}
+	}
+	for _, homepage := range coin.Links.Homepage {
+		if homepage != "" {
+			details.Homepage = homepage
+			break
+		}
+	}
+	if coin.MarketCapRank != nil {
+		details.MarketCapRank = *coin.MarketCapRank
+	}
+	if coin.AssetPlatformID != nil {
+		details.AssetPlatformID = *coin.AssetPlatformID
+	}
+	for chain, address := range coin.Platforms {
+		if chain != "" && address != "" {
+			details.Platforms[chain] = address
+		}
+	}
+	return details, nil
+}
+
+// get fetches path and decodes its JSON response into v.
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set(c.apiKeyName, c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("coingecko: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("coingecko: %s: %s", path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("coingecko: decode %s: %w", path, err)
+	}
+	return nil
 }
 
 // GetSupportedCurrencies retrieves list of supported vs currencies
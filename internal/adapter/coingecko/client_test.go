@@ -2,10 +2,13 @@ package coingecko
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -59,18 +62,24 @@ func TestCoinGeckoClient_GetHistoricalPrices(t *testing.T) {
 }
 
 func TestCoinGeckoClient_SearchAssets(t *testing.T) {
-	client := NewClient(Config{
-		APIKey: "test-api-key",
-		Pro:    false,
-	})
-
-	t.Run("should return unimplemented error", func(t *testing.T) {
-		results, err := client.SearchAssets(context.Background(), "bitcoin")
-
-		assert.Nil(t, results)
-		assert.Error(t, err)
-		assert.Equal(t, codes.Unimplemented, status.Code(err))
-	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/search", r.URL.Path)
+		assert.Equal(t, "wrapped bit", r.URL.Query().Get("query"))
+		_, _ = w.Write([]byte(`{"coins": [
+			{"id": "wrapped-bitcoin", "name": "Wrapped Bitcoin", "symbol": "wbtc", "market_cap_rank": 15, "large": "https://img/wbtc.png"},
+			{"id": "wrapped-bitcoin-sollet", "name": "Wrapped Bitcoin (Sollet)", "symbol": "SOBTC", "market_cap_rank": null}
+		], "exchanges": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL})
+
+	results, err := client.SearchAssets(context.Background(), "wrapped bit")
+	require.NoError(t, err)
+	assert.Equal(t, []SearchResult{
+		{ID: "wrapped-bitcoin", Symbol: "WBTC", Name: "Wrapped Bitcoin", MarketCapRank: 15, ImageURL: "https://img/wbtc.png"},
+		{ID: "wrapped-bitcoin-sollet", Symbol: "SOBTC", Name: "Wrapped Bitcoin (Sollet)"},
+	}, results)
 }
 
 func TestCoinGeckoClient_Ping(t *testing.T) {
@@ -86,3 +95,46 @@ func TestCoinGeckoClient_Ping(t *testing.T) {
 		assert.Equal(t, codes.Unimplemented, status.Code(err))
 	})
 }
+
+func TestCoinGeckoClient_GetAssetDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-api-key", r.Header.Get("x-cg-demo-api-key"))
+		assert.Equal(t, "false", r.URL.Query().Get("tickers"))
+		switch r.URL.Path {
+		case "/coins/uniswap":
+			_, _ = w.Write([]byte(`{
+				"id": "uniswap", "symbol": "uni", "name": "Uniswap",
+				"asset_platform_id": "ethereum",
+				"platforms": {"ethereum": "0x1f98", "": ""},
+				"categories": ["Decentralized Exchange (DEX)", ""],
+				"description": {"en": " Uniswap is a DEX. "},
+				"links": {"homepage": ["", "https://uniswap.org/"]},
+				"image": {"small": "https://img/small.png", "large": "https://img/large.png"},
+				"market_cap_rank": 25
+			}`))
+		default:
+			http.Error(w, `{"error":"coin not found"}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-api-key", BaseURL: server.URL})
+
+	details, err := client.GetAssetDetails(context.Background(), "uniswap")
+	require.NoError(t, err)
+	assert.Equal(t, &AssetDetails{
+		ID:              "uniswap",
+		Symbol:          "UNI",
+		Name:            "Uniswap",
+		Description:     "Uniswap is a DEX.",
+		Categories:      []string{"Decentralized Exchange (DEX)"},
+		Homepage:        "https://uniswap.org/",
+		ImageURL:        "https://img/large.png",
+		MarketCapRank:   25,
+		AssetPlatformID: "ethereum",
+		Platforms:       map[string]string{"ethereum": "0x1f98"},
+	}, details)
+
+	_, err = client.GetAssetDetails(context.Background(), "unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,126 @@
+// Package ecb reads the euro foreign exchange reference rates the European
+// Central Bank publishes each working day.
+package ecb
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Config holds ECB client configuration.
+type Config struct {
+	// BaseURL overrides the directory the rate files are read from.
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// Client reads reference rate files.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewClient creates a new ECB client.
+func NewClient(cfg Config) *Client {
+	baseURL := "https://www.ecb.europa.eu/stats/eurofxref"
+	if cfg.BaseURL != "" {
+		baseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Client{baseURL: baseURL, httpClient: httpClient, now: time.Now}
+}
+
+// DailyRates are the rates of one day: how much of each currency, by ISO
+// 4217 code, one euro buys.
+type DailyRates struct {
+	Date  time.Time // Midnight UTC
+	Rates map[string]decimal.Decimal
+}
+
+// envelope is the layout all rate files share: a cube per day holding a
+// cube per currency.
+type envelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// Rates retrieves the rates of the days from since's date on, oldest first.
+// It reads the smallest file that reaches back to since: the latest day,
+// the last 90 days or the whole history since 1999.
+func (c *Client) Rates(ctx context.Context, since time.Time) ([]DailyRates, error) {
+	file := "eurofxref-hist.xml"
+	switch age := c.now().Sub(since); {
+	case age <= 24*time.Hour:
+		// Rates are published once a day, so any since then are the latest.
+		file = "eurofxref-daily.xml"
+	case age <= 89*24*time.Hour:
+		file = "eurofxref-hist-90d.xml"
+	}
+	var env envelope
+	if err := c.get(ctx, file, &env); err != nil {
+		return nil, err
+	}
+
+	y, m, d := since.UTC().Date()
+	first := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	days := make([]DailyRates, 0, len(env.Days))
+	for _, day := range env.Days {
+		date, err := time.Parse(time.DateOnly, day.Time)
+		if err != nil {
+			return nil, fmt.Errorf("ecb: %s: invalid date %q", file, day.Time)
+		}
+		if date.Before(first) {
+			continue
+		}
+		rates := make(map[string]decimal.Decimal, len(day.Rates))
+		for _, r := range day.Rates {
+			rate, err := decimal.NewFromString(r.Rate)
+			if err != nil || !rate.IsPositive() {
+				return nil, fmt.Errorf("ecb: %s: invalid %s rate %q on %s", file, r.Currency, r.Rate, day.Time)
+			}
+			rates[strings.ToUpper(r.Currency)] = rate
+		}
+		days = append(days, DailyRates{Date: date, Rates: rates})
+	}
+	slices.SortFunc(days, func(a, b DailyRates) int { return a.Date.Compare(b.Date) })
+	return days, nil
+}
+
+// get fetches file and decodes it into v.
+func (c *Client) get(ctx context.Context, file string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/"+file, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/xml")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("ecb: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ecb: %s: %s", file, resp.Status)
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("ecb: decode %s: %w", file, err)
+	}
+	return nil
+}
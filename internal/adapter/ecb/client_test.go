@@ -0,0 +1,49 @@
+package ecb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRates(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		if r.URL.Path == "/eurofxref-hist.xml" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		http.ServeFile(w, r, "testdata"+r.URL.Path)
+	}))
+	defer server.Close()
+	client := NewClient(Config{BaseURL: server.URL})
+	client.now = func() time.Time { return time.Date(2025, time.April, 18, 9, 0, 0, 0, time.UTC) }
+	day := func(d int) time.Time { return time.Date(2025, time.April, d, 0, 0, 0, 0, time.UTC) }
+
+	days, err := client.Rates(context.Background(), day(17).Add(10*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, days, 1)
+	assert.Equal(t, day(17), days[0].Date)
+	assert.Len(t, days[0].Rates, 4)
+	assert.Equal(t, "0.85755", days[0].Rates["GBP"].String())
+
+	// Days before since's date are left out; the rest come oldest first.
+	days, err = client.Rates(context.Background(), day(15).Add(12*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, days, 3)
+	for i, want := range []time.Time{day(15), day(16), day(17)} {
+		assert.Equal(t, want, days[i].Date)
+	}
+	assert.Equal(t, "1.1285", days[0].Rates["USD"].String())
+	assert.Equal(t, "161.49", days[1].Rates["JPY"].String())
+
+	_, err = client.Rates(context.Background(), day(1).AddDate(-1, 0, 0))
+	assert.ErrorContains(t, err, "503")
+	assert.Equal(t, []string{"/eurofxref-daily.xml", "/eurofxref-hist-90d.xml", "/eurofxref-hist.xml"}, requested)
+}
@@ -0,0 +1,253 @@
+// Package yahoo reads quotes of stocks and funds from a Yahoo
+// Finance-style chart and search API.
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrNotFound is returned for symbols the API does not know.
+var ErrNotFound = errors.New("yahoo: not found")
+
+// Config holds Yahoo client configuration.
+type Config struct {
+	// BaseURL overrides the API endpoint.
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// Client reads charts and searches symbols.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a new Yahoo client.
+func NewClient(cfg Config) *Client {
+	baseURL := "https://query1.finance.yahoo.com"
+	if cfg.BaseURL != "" {
+		baseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Client{baseURL: baseURL, httpClient: httpClient}
+}
+
+// Chart is a symbol's quote and daily history. Prices are in Currency.
+type Chart struct {
+	Symbol    string
+	Name      string
+	Type      string // Instrument type, e.g. "EQUITY" or "ETF"
+	Exchange  string // Yahoo exchange code, e.g. "NMS"
+	Currency  string // ISO 4217 code
+	Price     decimal.Decimal
+	PriceTime time.Time
+	Bars      []Bar // Oldest first
+	Dividends []Dividend
+}
+
+// Bar is a day of trading; Time is when it opened.
+type Bar struct {
+	Time            time.Time
+	Open, High, Low decimal.NullDecimal
+	Close           decimal.Decimal
+	Volume          *int64
+}
+
+// Dividend is a cash dividend per share, in the chart's currency.
+type Dividend struct {
+	ExDate time.Time
+	Amount decimal.Decimal
+}
+
+// Quote is a symbol found by a search.
+type Quote struct {
+	Symbol   string
+	Name     string
+	Type     string // e.g. "EQUITY" or "ETF"
+	Exchange string
+}
+
+// minorUnits maps currency codes quoted in hundredths to their ISO code.
+var minorUnits = map[string]string{"GBp": "GBP", "GBX": "GBP", "ZAc": "ZAR", "ILA": "ILS"}
+
+// GetChart retrieves the daily bars and dividends of symbol from from to to.
+func (c *Client) GetChart(ctx context.Context, symbol string, from, to time.Time) (*Chart, error) {
+	query := url.Values{
+		"period1":  {strconv.FormatInt(from.Unix(), 10)},
+		"period2":  {strconv.FormatInt(to.Unix(), 10)},
+		"interval": {"1d"},
+		"events":   {"div"},
+	}
+	var resp struct {
+		Chart struct {
+			Result []struct {
+				Meta struct {
+					Symbol             string          `json:"symbol"`
+					LongName           string          `json:"longName"`
+					ShortName          string          `json:"shortName"`
+					InstrumentType     string          `json:"instrumentType"`
+					ExchangeName       string          `json:"exchangeName"`
+					Currency           string          `json:"currency"`
+					RegularMarketPrice decimal.Decimal `json:"regularMarketPrice"`
+					RegularMarketTime  int64           `json:"regularMarketTime"`
+				} `json:"meta"`
+				Timestamp []int64 `json:"timestamp"`
+				Events    struct {
+					Dividends map[string]struct {
+						Amount decimal.Decimal `json:"amount"`
+						Date   int64           `json:"date"`
+					} `json:"dividends"`
+				} `json:"events"`
+				Indicators struct {
+					Quote []struct {
+						Open   []decimal.NullDecimal `json:"open"`
+						High   []decimal.NullDecimal `json:"high"`
+						Low    []decimal.NullDecimal `json:"low"`
+						Close  []decimal.NullDecimal `json:"close"`
+						Volume []*int64              `json:"volume"`
+					} `json:"quote"`
+				} `json:"indicators"`
+			} `json:"result"`
+		} `json:"chart"`
+	}
+	if err := c.get(ctx, "/v8/finance/chart/"+url.PathEscape(symbol), query, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Chart.Result) == 0 {
+		return nil, ErrNotFound
+	}
+	result := resp.Chart.Result[0]
+	meta := result.Meta
+
+	// Minor units are scaled to the currency's major unit.
+	scale := func(d decimal.Decimal) decimal.Decimal { return d }
+	currency := meta.Currency
+	if major, ok := minorUnits[currency]; ok {
+		currency = major
+		scale = func(d decimal.Decimal) decimal.Decimal { return d.Shift(-2) }
+	}
+	scaleNull := func(d decimal.NullDecimal) decimal.NullDecimal {
+		if d.Valid {
+			d.Decimal = scale(d.Decimal)
+		}
+		return d
+	}
+
+	chart := &Chart{
+		Symbol:    meta.Symbol,
+		Name:      meta.LongName,
+		Type:      meta.InstrumentType,
+		Exchange:  meta.ExchangeName,
+		Currency:  strings.ToUpper(currency),
+		Price:     scale(meta.RegularMarketPrice),
+		PriceTime: time.Unix(meta.RegularMarketTime, 0).UTC(),
+	}
+	if chart.Name == "" {
+		chart.Name = meta.ShortName
+	}
+	if len(result.Indicators.Quote) > 0 {
+		q := result.Indicators.Quote[0]
+		at := func(values []decimal.NullDecimal, i int) decimal.NullDecimal {
+			if i < len(values) {
+				return scaleNull(values[i])
+			}
+			return decimal.NullDecimal{}
+		}
+		for i, ts := range result.Timestamp {
+			close := at(q.Close, i)
+			if !close.Valid {
+				continue
+			}
+			bar := Bar{
+				Time:  time.Unix(ts, 0).UTC(),
+				Open:  at(q.Open, i),
+				High:  at(q.High, i),
+				Low:   at(q.Low, i),
+				Close: close.Decimal,
+			}
+			if i < len(q.Volume) {
+				bar.Volume = q.Volume[i]
+			}
+			chart.Bars = append(chart.Bars, bar)
+		}
+	}
+	for _, d := range result.Events.Dividends {
+		chart.Dividends = append(chart.Dividends, Dividend{
+			ExDate: time.Unix(d.Date, 0).UTC(),
+			Amount: scale(d.Amount),
+		})
+	}
+	slices.SortFunc(chart.Dividends, func(a, b Dividend) int { return a.ExDate.Compare(b.ExDate) })
+	return chart, nil
+}
+
+// Search finds symbols by name, ticker or ISIN, best match first.
+func (c *Client) Search(ctx context.Context, query string) ([]Quote, error) {
+	var resp struct {
+		Quotes []struct {
+			Symbol    string `json:"symbol"`
+			ShortName string `json:"shortname"`
+			LongName  string `json:"longname"`
+			QuoteType string `json:"quoteType"`
+			Exchange  string `json:"exchange"`
+		} `json:"quotes"`
+	}
+	values := url.Values{"q": {query}, "quotesCount": {"10"}, "newsCount": {"0"}}
+	if err := c.get(ctx, "/v1/finance/search", values, &resp); err != nil {
+		return nil, err
+	}
+
+	quotes := make([]Quote, 0, len(resp.Quotes))
+	for _, q := range resp.Quotes {
+		// Search also finds news topics and the like, without symbols.
+		if q.Symbol == "" {
+			continue
+		}
+		name := q.LongName
+		if name == "" {
+			name = q.ShortName
+		}
+		quotes = append(quotes, Quote{Symbol: q.Symbol, Name: name, Type: q.QuoteType, Exchange: q.Exchange})
+	}
+	return quotes, nil
+}
+
+// get fetches path and decodes its JSON response into v.
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("yahoo: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("yahoo: %s: %s", path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("yahoo: decode %s: %w", path, err)
+	}
+	return nil
+}
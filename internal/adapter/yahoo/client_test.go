@@ -0,0 +1,80 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v8/finance/chart/GONE" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"chart": {"result": null, "error": {"code": "Not Found", "description": "No data found, symbol may be delisted"}}}`))
+			return
+		}
+		assert.Equal(t, "/v8/finance/chart/VOD.L", r.URL.Path)
+		assert.Equal(t, "1d", r.URL.Query().Get("interval"))
+		assert.Equal(t, "div", r.URL.Query().Get("events"))
+		assert.Equal(t, "1735689600", r.URL.Query().Get("period1"))
+		_, _ = w.Write([]byte(`{"chart": {"result": [{
+			"meta": {"currency": "GBp", "symbol": "VOD.L", "exchangeName": "LSE", "instrumentType": "EQUITY",
+				"regularMarketPrice": 71.5, "regularMarketTime": 1736526600, "longName": "Vodafone Group Public Limited Company"},
+			"timestamp": [1736409600, 1736496000, 1736510000],
+			"events": {"dividends": {"1736409600": {"amount": 2.25, "date": 1736409600}}},
+			"indicators": {"quote": [{
+				"open": [70.1, null, 71.0], "high": [71.2, null, 72.0], "low": [69.9, null, 70.5],
+				"close": [70.8, null, 71.5], "volume": [1200000, null, null]
+			}]}
+		}], "error": null}}`))
+	}))
+	defer server.Close()
+	client := NewClient(Config{BaseURL: server.URL})
+
+	chart, err := client.GetChart(context.Background(), "VOD.L", time.Unix(1735689600, 0), time.Unix(1736600000, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "Vodafone Group Public Limited Company", chart.Name)
+	assert.Equal(t, "LSE", chart.Exchange)
+	// Pence are converted to pounds.
+	assert.Equal(t, "GBP", chart.Currency)
+	assert.Equal(t, "0.715", chart.Price.String())
+	assert.Equal(t, time.Unix(1736526600, 0).UTC(), chart.PriceTime)
+
+	// The day without a close is skipped.
+	require.Len(t, chart.Bars, 2)
+	assert.Equal(t, "0.708", chart.Bars[0].Close.String())
+	assert.Equal(t, decimal.NewNullDecimal(decimal.RequireFromString("0.701")), chart.Bars[0].Open)
+	assert.Equal(t, int64(1200000), *chart.Bars[0].Volume)
+	assert.Nil(t, chart.Bars[1].Volume)
+	assert.Equal(t, []Dividend{{ExDate: time.Unix(1736409600, 0).UTC(), Amount: decimal.RequireFromString("0.0225")}}, chart.Dividends)
+
+	_, err = client.GetChart(context.Background(), "GONE", time.Now(), time.Now())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/finance/search", r.URL.Path)
+		assert.Equal(t, "US0378331005", r.URL.Query().Get("q"))
+		_, _ = w.Write([]byte(`{"quotes": [
+			{"exchange": "NMS", "shortname": "Apple Inc.", "quoteType": "EQUITY", "symbol": "AAPL", "longname": "Apple Inc."},
+			{"exchange": "GER", "shortname": "APPLE INC", "quoteType": "EQUITY", "symbol": "APC.DE"},
+			{"type": "news", "title": "Apple earnings"}
+		], "news": []}`))
+	}))
+	defer server.Close()
+	client := NewClient(Config{BaseURL: server.URL})
+
+	quotes, err := client.Search(context.Background(), "US0378331005")
+	require.NoError(t, err)
+	assert.Equal(t, []Quote{
+		{Symbol: "AAPL", Name: "Apple Inc.", Type: "EQUITY", Exchange: "NMS"},
+		{Symbol: "APC.DE", Name: "APPLE INC", Type: "EQUITY", Exchange: "GER"},
+	}, quotes)
+}
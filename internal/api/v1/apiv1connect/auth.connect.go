@@ -0,0 +1,200 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: v1/auth.proto
+
+package apiv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// AuthServiceName is the fully-qualified name of the AuthService service.
+	AuthServiceName = "greedy_eye.v1.AuthService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AuthServiceCreateSessionProcedure is the fully-qualified name of the AuthService's CreateSession
+	// RPC.
+	AuthServiceCreateSessionProcedure = "/greedy_eye.v1.AuthService/CreateSession"
+	// AuthServiceCreateAPIKeyProcedure is the fully-qualified name of the AuthService's CreateAPIKey
+	// RPC.
+	AuthServiceCreateAPIKeyProcedure = "/greedy_eye.v1.AuthService/CreateAPIKey"
+	// AuthServiceListAPIKeysProcedure is the fully-qualified name of the AuthService's ListAPIKeys RPC.
+	AuthServiceListAPIKeysProcedure = "/greedy_eye.v1.AuthService/ListAPIKeys"
+	// AuthServiceRevokeAPIKeyProcedure is the fully-qualified name of the AuthService's RevokeAPIKey
+	// RPC.
+	AuthServiceRevokeAPIKeyProcedure = "/greedy_eye.v1.AuthService/RevokeAPIKey"
+)
+
+// AuthServiceClient is a client for the greedy_eye.v1.AuthService service.
+type AuthServiceClient interface {
+	// CreateSession exchanges the API key the call is authenticated with for
+	// a session token.
+	CreateSession(context.Context, *connect.Request[v1.CreateSessionRequest]) (*connect.Response[v1.Session], error)
+	CreateAPIKey(context.Context, *connect.Request[v1.CreateAPIKeyRequest]) (*connect.Response[v1.CreateAPIKeyResponse], error)
+	ListAPIKeys(context.Context, *connect.Request[v1.ListAPIKeysRequest]) (*connect.Response[v1.ListAPIKeysResponse], error)
+	RevokeAPIKey(context.Context, *connect.Request[v1.RevokeAPIKeyRequest]) (*connect.Response[emptypb.Empty], error)
+}
+
+// NewAuthServiceClient constructs a client for the greedy_eye.v1.AuthService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAuthServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AuthServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	authServiceMethods := v1.File_v1_auth_proto.Services().ByName("AuthService").Methods()
+	return &authServiceClient{
+		createSession: connect.NewClient[v1.CreateSessionRequest, v1.Session](
+			httpClient,
+			baseURL+AuthServiceCreateSessionProcedure,
+			connect.WithSchema(authServiceMethods.ByName("CreateSession")),
+			connect.WithClientOptions(opts...),
+		),
+		createAPIKey: connect.NewClient[v1.CreateAPIKeyRequest, v1.CreateAPIKeyResponse](
+			httpClient,
+			baseURL+AuthServiceCreateAPIKeyProcedure,
+			connect.WithSchema(authServiceMethods.ByName("CreateAPIKey")),
+			connect.WithClientOptions(opts...),
+		),
+		listAPIKeys: connect.NewClient[v1.ListAPIKeysRequest, v1.ListAPIKeysResponse](
+			httpClient,
+			baseURL+AuthServiceListAPIKeysProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ListAPIKeys")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeAPIKey: connect.NewClient[v1.RevokeAPIKeyRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AuthServiceRevokeAPIKeyProcedure,
+			connect.WithSchema(authServiceMethods.ByName("RevokeAPIKey")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// authServiceClient implements AuthServiceClient.
+type authServiceClient struct {
+	createSession *connect.Client[v1.CreateSessionRequest, v1.Session]
+	createAPIKey  *connect.Client[v1.CreateAPIKeyRequest, v1.CreateAPIKeyResponse]
+	listAPIKeys   *connect.Client[v1.ListAPIKeysRequest, v1.ListAPIKeysResponse]
+	revokeAPIKey  *connect.Client[v1.RevokeAPIKeyRequest, emptypb.Empty]
+}
+
+// CreateSession calls greedy_eye.v1.AuthService.CreateSession.
+func (c *authServiceClient) CreateSession(ctx context.Context, req *connect.Request[v1.CreateSessionRequest]) (*connect.Response[v1.Session], error) {
+	return c.createSession.CallUnary(ctx, req)
+}
+
+// CreateAPIKey calls greedy_eye.v1.AuthService.CreateAPIKey.
+func (c *authServiceClient) CreateAPIKey(ctx context.Context, req *connect.Request[v1.CreateAPIKeyRequest]) (*connect.Response[v1.CreateAPIKeyResponse], error) {
+	return c.createAPIKey.CallUnary(ctx, req)
+}
+
+// ListAPIKeys calls greedy_eye.v1.AuthService.ListAPIKeys.
+func (c *authServiceClient) ListAPIKeys(ctx context.Context, req *connect.Request[v1.ListAPIKeysRequest]) (*connect.Response[v1.ListAPIKeysResponse], error) {
+	return c.listAPIKeys.CallUnary(ctx, req)
+}
+
+// RevokeAPIKey calls greedy_eye.v1.AuthService.RevokeAPIKey.
+func (c *authServiceClient) RevokeAPIKey(ctx context.Context, req *connect.Request[v1.RevokeAPIKeyRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.revokeAPIKey.CallUnary(ctx, req)
+}
+
+// AuthServiceHandler is an implementation of the greedy_eye.v1.AuthService service.
+type AuthServiceHandler interface {
+	// CreateSession exchanges the API key the call is authenticated with for
+	// a session token.
+	CreateSession(context.Context, *connect.Request[v1.CreateSessionRequest]) (*connect.Response[v1.Session], error)
+	CreateAPIKey(context.Context, *connect.Request[v1.CreateAPIKeyRequest]) (*connect.Response[v1.CreateAPIKeyResponse], error)
+	ListAPIKeys(context.Context, *connect.Request[v1.ListAPIKeysRequest]) (*connect.Response[v1.ListAPIKeysResponse], error)
+	RevokeAPIKey(context.Context, *connect.Request[v1.RevokeAPIKeyRequest]) (*connect.Response[emptypb.Empty], error)
+}
+
+// NewAuthServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAuthServiceHandler(svc AuthServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	authServiceMethods := v1.File_v1_auth_proto.Services().ByName("AuthService").Methods()
+	authServiceCreateSessionHandler := connect.NewUnaryHandler(
+		AuthServiceCreateSessionProcedure,
+		svc.CreateSession,
+		connect.WithSchema(authServiceMethods.ByName("CreateSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceCreateAPIKeyHandler := connect.NewUnaryHandler(
+		AuthServiceCreateAPIKeyProcedure,
+		svc.CreateAPIKey,
+		connect.WithSchema(authServiceMethods.ByName("CreateAPIKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceListAPIKeysHandler := connect.NewUnaryHandler(
+		AuthServiceListAPIKeysProcedure,
+		svc.ListAPIKeys,
+		connect.WithSchema(authServiceMethods.ByName("ListAPIKeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRevokeAPIKeyHandler := connect.NewUnaryHandler(
+		AuthServiceRevokeAPIKeyProcedure,
+		svc.RevokeAPIKey,
+		connect.WithSchema(authServiceMethods.ByName("RevokeAPIKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/greedy_eye.v1.AuthService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AuthServiceCreateSessionProcedure:
+			authServiceCreateSessionHandler.ServeHTTP(w, r)
+		case AuthServiceCreateAPIKeyProcedure:
+			authServiceCreateAPIKeyHandler.ServeHTTP(w, r)
+		case AuthServiceListAPIKeysProcedure:
+			authServiceListAPIKeysHandler.ServeHTTP(w, r)
+		case AuthServiceRevokeAPIKeyProcedure:
+			authServiceRevokeAPIKeyHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAuthServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAuthServiceHandler struct{}
+
+func (UnimplementedAuthServiceHandler) CreateSession(context.Context, *connect.Request[v1.CreateSessionRequest]) (*connect.Response[v1.Session], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.AuthService.CreateSession is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) CreateAPIKey(context.Context, *connect.Request[v1.CreateAPIKeyRequest]) (*connect.Response[v1.CreateAPIKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.AuthService.CreateAPIKey is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ListAPIKeys(context.Context, *connect.Request[v1.ListAPIKeysRequest]) (*connect.Response[v1.ListAPIKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.AuthService.ListAPIKeys is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RevokeAPIKey(context.Context, *connect.Request[v1.RevokeAPIKeyRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.AuthService.RevokeAPIKey is not implemented"))
+}
@@ -61,6 +61,12 @@ const (
 	// AutomationServiceValidateRuleProcedure is the fully-qualified name of the AutomationService's
 	// ValidateRule RPC.
 	AutomationServiceValidateRuleProcedure = "/greedy_eye.v1.AutomationService/ValidateRule"
+	// AutomationServiceListRuleTypesProcedure is the fully-qualified name of the AutomationService's
+	// ListRuleTypes RPC.
+	AutomationServiceListRuleTypesProcedure = "/greedy_eye.v1.AutomationService/ListRuleTypes"
+	// AutomationServiceBacktestRuleProcedure is the fully-qualified name of the AutomationService's
+	// BacktestRule RPC.
+	AutomationServiceBacktestRuleProcedure = "/greedy_eye.v1.AutomationService/BacktestRule"
 	// AutomationServiceSimulateRuleProcedure is the fully-qualified name of the AutomationService's
 	// SimulateRule RPC.
 	AutomationServiceSimulateRuleProcedure = "/greedy_eye.v1.AutomationService/SimulateRule"
@@ -88,6 +94,9 @@ const (
 	// AutomationServiceListRuleExecutionsProcedure is the fully-qualified name of the
 	// AutomationService's ListRuleExecutions RPC.
 	AutomationServiceListRuleExecutionsProcedure = "/greedy_eye.v1.AutomationService/ListRuleExecutions"
+	// AutomationServiceWatchRuleExecutionProcedure is the fully-qualified name of the
+	// AutomationService's WatchRuleExecution RPC.
+	AutomationServiceWatchRuleExecutionProcedure = "/greedy_eye.v1.AutomationService/WatchRuleExecution"
 )
 
 // AutomationServiceClient is a client for the greedy_eye.v1.AutomationService service.
@@ -104,6 +113,9 @@ type AutomationServiceClient interface {
 	CancelRuleExecution(context.Context, *connect.Request[v1.CancelRuleExecutionRequest]) (*connect.Response[emptypb.Empty], error)
 	// --- Rule validation and simulation ---
 	ValidateRule(context.Context, *connect.Request[v1.ValidateRuleRequest]) (*connect.Response[v1.ValidateRuleResponse], error)
+	ListRuleTypes(context.Context, *connect.Request[v1.ListRuleTypesRequest]) (*connect.Response[v1.ListRuleTypesResponse], error)
+	// BacktestRule replays a rule against stored price history.
+	BacktestRule(context.Context, *connect.Request[v1.BacktestRuleRequest]) (*connect.Response[v1.BacktestRuleResponse], error)
 	SimulateRule(context.Context, *connect.Request[v1.SimulateRuleRequest]) (*connect.Response[v1.SimulateRuleResponse], error)
 	// --- Rule status management ---
 	EnableRule(context.Context, *connect.Request[v1.EnableRuleRequest]) (*connect.Response[v1.Rule], error)
@@ -115,6 +127,9 @@ type AutomationServiceClient interface {
 	GetRuleExecution(context.Context, *connect.Request[v1.GetRuleExecutionRequest]) (*connect.Response[v1.RuleExecution], error)
 	UpdateRuleExecution(context.Context, *connect.Request[v1.UpdateRuleExecutionRequest]) (*connect.Response[v1.RuleExecution], error)
 	ListRuleExecutions(context.Context, *connect.Request[v1.ListRuleExecutionsRequest]) (*connect.Response[v1.ListRuleExecutionsResponse], error)
+	// WatchRuleExecution streams the execution whenever its status or progress
+	// changes, ending once it reaches a terminal status.
+	WatchRuleExecution(context.Context, *connect.Request[v1.WatchRuleExecutionRequest]) (*connect.ServerStreamForClient[v1.RuleExecution], error)
 }
 
 // NewAutomationServiceClient constructs a client for the greedy_eye.v1.AutomationService service.
@@ -182,6 +197,18 @@ func NewAutomationServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(automationServiceMethods.ByName("ValidateRule")),
 			connect.WithClientOptions(opts...),
 		),
+		listRuleTypes: connect.NewClient[v1.ListRuleTypesRequest, v1.ListRuleTypesResponse](
+			httpClient,
+			baseURL+AutomationServiceListRuleTypesProcedure,
+			connect.WithSchema(automationServiceMethods.ByName("ListRuleTypes")),
+			connect.WithClientOptions(opts...),
+		),
+		backtestRule: connect.NewClient[v1.BacktestRuleRequest, v1.BacktestRuleResponse](
+			httpClient,
+			baseURL+AutomationServiceBacktestRuleProcedure,
+			connect.WithSchema(automationServiceMethods.ByName("BacktestRule")),
+			connect.WithClientOptions(opts...),
+		),
 		simulateRule: connect.NewClient[v1.SimulateRuleRequest, v1.SimulateRuleResponse](
 			httpClient,
 			baseURL+AutomationServiceSimulateRuleProcedure,
@@ -236,6 +263,12 @@ func NewAutomationServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(automationServiceMethods.ByName("ListRuleExecutions")),
 			connect.WithClientOptions(opts...),
 		),
+		watchRuleExecution: connect.NewClient[v1.WatchRuleExecutionRequest, v1.RuleExecution](
+			httpClient,
+			baseURL+AutomationServiceWatchRuleExecutionProcedure,
+			connect.WithSchema(automationServiceMethods.ByName("WatchRuleExecution")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -250,6 +283,8 @@ type automationServiceClient struct {
 	executeRuleAsync    *connect.Client[v1.ExecuteRuleAsyncRequest, v1.ExecuteRuleAsyncResponse]
 	cancelRuleExecution *connect.Client[v1.CancelRuleExecutionRequest, emptypb.Empty]
 	validateRule        *connect.Client[v1.ValidateRuleRequest, v1.ValidateRuleResponse]
+	listRuleTypes       *connect.Client[v1.ListRuleTypesRequest, v1.ListRuleTypesResponse]
+	backtestRule        *connect.Client[v1.BacktestRuleRequest, v1.BacktestRuleResponse]
 	simulateRule        *connect.Client[v1.SimulateRuleRequest, v1.SimulateRuleResponse]
 	enableRule          *connect.Client[v1.EnableRuleRequest, v1.Rule]
 	disableRule         *connect.Client[v1.DisableRuleRequest, v1.Rule]
@@ -259,6 +294,7 @@ type automationServiceClient struct {
 	getRuleExecution    *connect.Client[v1.GetRuleExecutionRequest, v1.RuleExecution]
 	updateRuleExecution *connect.Client[v1.UpdateRuleExecutionRequest, v1.RuleExecution]
 	listRuleExecutions  *connect.Client[v1.ListRuleExecutionsRequest, v1.ListRuleExecutionsResponse]
+	watchRuleExecution  *connect.Client[v1.WatchRuleExecutionRequest, v1.RuleExecution]
 }
 
 // CreateRule calls greedy_eye.v1.AutomationService.CreateRule.
@@ -306,6 +342,16 @@ func (c *automationServiceClient) ValidateRule(ctx context.Context, req *connect
 	return c.validateRule.CallUnary(ctx, req)
 }
 
+// ListRuleTypes calls greedy_eye.v1.AutomationService.ListRuleTypes.
+func (c *automationServiceClient) ListRuleTypes(ctx context.Context, req *connect.Request[v1.ListRuleTypesRequest]) (*connect.Response[v1.ListRuleTypesResponse], error) {
+	return c.listRuleTypes.CallUnary(ctx, req)
+}
+
+// BacktestRule calls greedy_eye.v1.AutomationService.BacktestRule.
+func (c *automationServiceClient) BacktestRule(ctx context.Context, req *connect.Request[v1.BacktestRuleRequest]) (*connect.Response[v1.BacktestRuleResponse], error) {
+	return c.backtestRule.CallUnary(ctx, req)
+}
+
 // SimulateRule calls greedy_eye.v1.AutomationService.SimulateRule.
 func (c *automationServiceClient) SimulateRule(ctx context.Context, req *connect.Request[v1.SimulateRuleRequest]) (*connect.Response[v1.SimulateRuleResponse], error) {
 	return c.simulateRule.CallUnary(ctx, req)
@@ -351,6 +397,11 @@ func (c *automationServiceClient) ListRuleExecutions(ctx context.Context, req *c
 	return c.listRuleExecutions.CallUnary(ctx, req)
 }
 
+// WatchRuleExecution calls greedy_eye.v1.AutomationService.WatchRuleExecution.
+func (c *automationServiceClient) WatchRuleExecution(ctx context.Context, req *connect.Request[v1.WatchRuleExecutionRequest]) (*connect.ServerStreamForClient[v1.RuleExecution], error) {
+	return c.watchRuleExecution.CallServerStream(ctx, req)
+}
+
 // AutomationServiceHandler is an implementation of the greedy_eye.v1.AutomationService service.
 type AutomationServiceHandler interface {
 	// --- Rule CRUD ---
@@ -365,6 +416,9 @@ type AutomationServiceHandler interface {
 	CancelRuleExecution(context.Context, *connect.Request[v1.CancelRuleExecutionRequest]) (*connect.Response[emptypb.Empty], error)
 	// --- Rule validation and simulation ---
 	ValidateRule(context.Context, *connect.Request[v1.ValidateRuleRequest]) (*connect.Response[v1.ValidateRuleResponse], error)
+	ListRuleTypes(context.Context, *connect.Request[v1.ListRuleTypesRequest]) (*connect.Response[v1.ListRuleTypesResponse], error)
+	// BacktestRule replays a rule against stored price history.
+	BacktestRule(context.Context, *connect.Request[v1.BacktestRuleRequest]) (*connect.Response[v1.BacktestRuleResponse], error)
 	SimulateRule(context.Context, *connect.Request[v1.SimulateRuleRequest]) (*connect.Response[v1.SimulateRuleResponse], error)
 	// --- Rule status management ---
 	EnableRule(context.Context, *connect.Request[v1.EnableRuleRequest]) (*connect.Response[v1.Rule], error)
@@ -376,6 +430,9 @@ type AutomationServiceHandler interface {
 	GetRuleExecution(context.Context, *connect.Request[v1.GetRuleExecutionRequest]) (*connect.Response[v1.RuleExecution], error)
 	UpdateRuleExecution(context.Context, *connect.Request[v1.UpdateRuleExecutionRequest]) (*connect.Response[v1.RuleExecution], error)
 	ListRuleExecutions(context.Context, *connect.Request[v1.ListRuleExecutionsRequest]) (*connect.Response[v1.ListRuleExecutionsResponse], error)
+	// WatchRuleExecution streams the execution whenever its status or progress
+	// changes, ending once it reaches a terminal status.
+	WatchRuleExecution(context.Context, *connect.Request[v1.WatchRuleExecutionRequest], *connect.ServerStream[v1.RuleExecution]) error
 }
 
 // NewAutomationServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -439,6 +496,18 @@ func NewAutomationServiceHandler(svc AutomationServiceHandler, opts ...connect.H
 		connect.WithSchema(automationServiceMethods.ByName("ValidateRule")),
 		connect.WithHandlerOptions(opts...),
 	)
+	automationServiceListRuleTypesHandler := connect.NewUnaryHandler(
+		AutomationServiceListRuleTypesProcedure,
+		svc.ListRuleTypes,
+		connect.WithSchema(automationServiceMethods.ByName("ListRuleTypes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	automationServiceBacktestRuleHandler := connect.NewUnaryHandler(
+		AutomationServiceBacktestRuleProcedure,
+		svc.BacktestRule,
+		connect.WithSchema(automationServiceMethods.ByName("BacktestRule")),
+		connect.WithHandlerOptions(opts...),
+	)
 	automationServiceSimulateRuleHandler := connect.NewUnaryHandler(
 		AutomationServiceSimulateRuleProcedure,
 		svc.SimulateRule,
@@ -493,6 +562,12 @@ func NewAutomationServiceHandler(svc AutomationServiceHandler, opts ...connect.H
 		connect.WithSchema(automationServiceMethods.ByName("ListRuleExecutions")),
 		connect.WithHandlerOptions(opts...),
 	)
+	automationServiceWatchRuleExecutionHandler := connect.NewServerStreamHandler(
+		AutomationServiceWatchRuleExecutionProcedure,
+		svc.WatchRuleExecution,
+		connect.WithSchema(automationServiceMethods.ByName("WatchRuleExecution")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/greedy_eye.v1.AutomationService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AutomationServiceCreateRuleProcedure:
@@ -513,6 +588,10 @@ func NewAutomationServiceHandler(svc AutomationServiceHandler, opts ...connect.H
 			automationServiceCancelRuleExecutionHandler.ServeHTTP(w, r)
 		case AutomationServiceValidateRuleProcedure:
 			automationServiceValidateRuleHandler.ServeHTTP(w, r)
+		case AutomationServiceListRuleTypesProcedure:
+			automationServiceListRuleTypesHandler.ServeHTTP(w, r)
+		case AutomationServiceBacktestRuleProcedure:
+			automationServiceBacktestRuleHandler.ServeHTTP(w, r)
 		case AutomationServiceSimulateRuleProcedure:
 			automationServiceSimulateRuleHandler.ServeHTTP(w, r)
 		case AutomationServiceEnableRuleProcedure:
@@ -531,6 +610,8 @@ func NewAutomationServiceHandler(svc AutomationServiceHandler, opts ...connect.H
 			automationServiceUpdateRuleExecutionHandler.ServeHTTP(w, r)
 		case AutomationServiceListRuleExecutionsProcedure:
 			automationServiceListRuleExecutionsHandler.ServeHTTP(w, r)
+		case AutomationServiceWatchRuleExecutionProcedure:
+			automationServiceWatchRuleExecutionHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -576,6 +657,14 @@ func (UnimplementedAutomationServiceHandler) ValidateRule(context.Context, *conn
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.AutomationService.ValidateRule is not implemented"))
 }
 
+func (UnimplementedAutomationServiceHandler) ListRuleTypes(context.Context, *connect.Request[v1.ListRuleTypesRequest]) (*connect.Response[v1.ListRuleTypesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.AutomationService.ListRuleTypes is not implemented"))
+}
+
+func (UnimplementedAutomationServiceHandler) BacktestRule(context.Context, *connect.Request[v1.BacktestRuleRequest]) (*connect.Response[v1.BacktestRuleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.AutomationService.BacktestRule is not implemented"))
+}
+
 func (UnimplementedAutomationServiceHandler) SimulateRule(context.Context, *connect.Request[v1.SimulateRuleRequest]) (*connect.Response[v1.SimulateRuleResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.AutomationService.SimulateRule is not implemented"))
 }
@@ -611,3 +700,7 @@ func (UnimplementedAutomationServiceHandler) UpdateRuleExecution(context.Context
 func (UnimplementedAutomationServiceHandler) ListRuleExecutions(context.Context, *connect.Request[v1.ListRuleExecutionsRequest]) (*connect.Response[v1.ListRuleExecutionsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.AutomationService.ListRuleExecutions is not implemented"))
 }
+
+func (UnimplementedAutomationServiceHandler) WatchRuleExecution(context.Context, *connect.Request[v1.WatchRuleExecutionRequest], *connect.ServerStream[v1.RuleExecution]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.AutomationService.WatchRuleExecution is not implemented"))
+}
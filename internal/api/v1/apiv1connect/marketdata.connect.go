@@ -49,12 +49,51 @@ const (
 	// MarketDataServiceListAssetsProcedure is the fully-qualified name of the MarketDataService's
 	// ListAssets RPC.
 	MarketDataServiceListAssetsProcedure = "/greedy_eye.v1.MarketDataService/ListAssets"
+	// MarketDataServiceSearchAssetsProcedure is the fully-qualified name of the MarketDataService's
+	// SearchAssets RPC.
+	MarketDataServiceSearchAssetsProcedure = "/greedy_eye.v1.MarketDataService/SearchAssets"
+	// MarketDataServiceImportExternalAssetProcedure is the fully-qualified name of the
+	// MarketDataService's ImportExternalAsset RPC.
+	MarketDataServiceImportExternalAssetProcedure = "/greedy_eye.v1.MarketDataService/ImportExternalAsset"
 	// MarketDataServiceEnrichAssetDataProcedure is the fully-qualified name of the MarketDataService's
 	// EnrichAssetData RPC.
 	MarketDataServiceEnrichAssetDataProcedure = "/greedy_eye.v1.MarketDataService/EnrichAssetData"
 	// MarketDataServiceFindSimilarAssetsProcedure is the fully-qualified name of the
 	// MarketDataService's FindSimilarAssets RPC.
 	MarketDataServiceFindSimilarAssetsProcedure = "/greedy_eye.v1.MarketDataService/FindSimilarAssets"
+	// MarketDataServiceGetBondAnalyticsProcedure is the fully-qualified name of the MarketDataService's
+	// GetBondAnalytics RPC.
+	MarketDataServiceGetBondAnalyticsProcedure = "/greedy_eye.v1.MarketDataService/GetBondAnalytics"
+	// MarketDataServiceCreateAssetIdentifierProcedure is the fully-qualified name of the
+	// MarketDataService's CreateAssetIdentifier RPC.
+	MarketDataServiceCreateAssetIdentifierProcedure = "/greedy_eye.v1.MarketDataService/CreateAssetIdentifier"
+	// MarketDataServiceDeleteAssetIdentifierProcedure is the fully-qualified name of the
+	// MarketDataService's DeleteAssetIdentifier RPC.
+	MarketDataServiceDeleteAssetIdentifierProcedure = "/greedy_eye.v1.MarketDataService/DeleteAssetIdentifier"
+	// MarketDataServiceListAssetIdentifiersProcedure is the fully-qualified name of the
+	// MarketDataService's ListAssetIdentifiers RPC.
+	MarketDataServiceListAssetIdentifiersProcedure = "/greedy_eye.v1.MarketDataService/ListAssetIdentifiers"
+	// MarketDataServiceResolveAssetProcedure is the fully-qualified name of the MarketDataService's
+	// ResolveAsset RPC.
+	MarketDataServiceResolveAssetProcedure = "/greedy_eye.v1.MarketDataService/ResolveAsset"
+	// MarketDataServiceCreateCorporateActionProcedure is the fully-qualified name of the
+	// MarketDataService's CreateCorporateAction RPC.
+	MarketDataServiceCreateCorporateActionProcedure = "/greedy_eye.v1.MarketDataService/CreateCorporateAction"
+	// MarketDataServiceGetCorporateActionProcedure is the fully-qualified name of the
+	// MarketDataService's GetCorporateAction RPC.
+	MarketDataServiceGetCorporateActionProcedure = "/greedy_eye.v1.MarketDataService/GetCorporateAction"
+	// MarketDataServiceListCorporateActionsProcedure is the fully-qualified name of the
+	// MarketDataService's ListCorporateActions RPC.
+	MarketDataServiceListCorporateActionsProcedure = "/greedy_eye.v1.MarketDataService/ListCorporateActions"
+	// MarketDataServiceDeleteCorporateActionProcedure is the fully-qualified name of the
+	// MarketDataService's DeleteCorporateAction RPC.
+	MarketDataServiceDeleteCorporateActionProcedure = "/greedy_eye.v1.MarketDataService/DeleteCorporateAction"
+	// MarketDataServiceApplyCorporateActionProcedure is the fully-qualified name of the
+	// MarketDataService's ApplyCorporateAction RPC.
+	MarketDataServiceApplyCorporateActionProcedure = "/greedy_eye.v1.MarketDataService/ApplyCorporateAction"
+	// MarketDataServiceRevertCorporateActionProcedure is the fully-qualified name of the
+	// MarketDataService's RevertCorporateAction RPC.
+	MarketDataServiceRevertCorporateActionProcedure = "/greedy_eye.v1.MarketDataService/RevertCorporateAction"
 	// MarketDataServiceCreatePriceProcedure is the fully-qualified name of the MarketDataService's
 	// CreatePrice RPC.
 	MarketDataServiceCreatePriceProcedure = "/greedy_eye.v1.MarketDataService/CreatePrice"
@@ -76,9 +115,15 @@ const (
 	// MarketDataServiceDeletePricesProcedure is the fully-qualified name of the MarketDataService's
 	// DeletePrices RPC.
 	MarketDataServiceDeletePricesProcedure = "/greedy_eye.v1.MarketDataService/DeletePrices"
+	// MarketDataServiceWatchPricesProcedure is the fully-qualified name of the MarketDataService's
+	// WatchPrices RPC.
+	MarketDataServiceWatchPricesProcedure = "/greedy_eye.v1.MarketDataService/WatchPrices"
 	// MarketDataServiceFetchExternalPricesProcedure is the fully-qualified name of the
 	// MarketDataService's FetchExternalPrices RPC.
 	MarketDataServiceFetchExternalPricesProcedure = "/greedy_eye.v1.MarketDataService/FetchExternalPrices"
+	// MarketDataServiceGetPriceDataQualityProcedure is the fully-qualified name of the
+	// MarketDataService's GetPriceDataQuality RPC.
+	MarketDataServiceGetPriceDataQualityProcedure = "/greedy_eye.v1.MarketDataService/GetPriceDataQuality"
 )
 
 // MarketDataServiceClient is a client for the greedy_eye.v1.MarketDataService service.
@@ -90,8 +135,48 @@ type MarketDataServiceClient interface {
 	DeleteAsset(context.Context, *connect.Request[v1.DeleteAssetRequest]) (*connect.Response[emptypb.Empty], error)
 	ListAssets(context.Context, *connect.Request[v1.ListAssetsRequest]) (*connect.Response[v1.ListAssetsResponse], error)
 	// --- Asset business logic ---
+	// SearchAssets finds assets by symbol, identifier or words of their name
+	// for type-ahead: an exact symbol first, then an exact identifier, a
+	// symbol prefix, and names by relevance. When nothing matches, it can ask
+	// external providers, whose results ImportExternalAsset adds.
+	SearchAssets(context.Context, *connect.Request[v1.SearchAssetsRequest]) (*connect.Response[v1.SearchAssetsResponse], error)
+	// ImportExternalAsset creates an asset from a provider's search result,
+	// mapped to the provider's ID. An asset already mapped is returned as is.
+	ImportExternalAsset(context.Context, *connect.Request[v1.ImportExternalAssetRequest]) (*connect.Response[v1.Asset], error)
+	// EnrichAssetData merges metadata from the named sources, or from every
+	// configured provider, into the asset. A field is replaced only by a
+	// source of equal or higher precedence.
 	EnrichAssetData(context.Context, *connect.Request[v1.EnrichAssetDataRequest]) (*connect.Response[v1.Asset], error)
-	FindSimilarAssets(context.Context, *connect.Request[v1.FindSimilarAssetsRequest]) (*connect.Response[v1.ListAssetsResponse], error)
+	// FindSimilarAssets ranks assets by shared tags, type and correlation of
+	// daily returns, to spot duplicates such as wrapped tokens or to
+	// diversify.
+	FindSimilarAssets(context.Context, *connect.Request[v1.FindSimilarAssetsRequest]) (*connect.Response[v1.FindSimilarAssetsResponse], error)
+	// GetBondAnalytics computes a bond's accrued interest, its remaining
+	// coupon and principal payments and, given a price, its yield to
+	// maturity, at a settlement date.
+	GetBondAnalytics(context.Context, *connect.Request[v1.GetBondAnalyticsRequest]) (*connect.Response[v1.GetBondAnalyticsResponse], error)
+	// --- Asset identifiers ---
+	CreateAssetIdentifier(context.Context, *connect.Request[v1.CreateAssetIdentifierRequest]) (*connect.Response[v1.AssetIdentifier], error)
+	DeleteAssetIdentifier(context.Context, *connect.Request[v1.DeleteAssetIdentifierRequest]) (*connect.Response[emptypb.Empty], error)
+	ListAssetIdentifiers(context.Context, *connect.Request[v1.ListAssetIdentifiersRequest]) (*connect.Response[v1.ListAssetIdentifiersResponse], error)
+	// ResolveAsset finds the asset an identifier maps to. Without a kind, it
+	// tries every kind the value is valid for and then the asset symbol.
+	// When several assets match, none is picked and all are listed.
+	ResolveAsset(context.Context, *connect.Request[v1.ResolveAssetRequest]) (*connect.Response[v1.ResolveAssetResponse], error)
+	// --- Corporate actions ---
+	CreateCorporateAction(context.Context, *connect.Request[v1.CreateCorporateActionRequest]) (*connect.Response[v1.CorporateAction], error)
+	GetCorporateAction(context.Context, *connect.Request[v1.GetCorporateActionRequest]) (*connect.Response[v1.CorporateAction], error)
+	ListCorporateActions(context.Context, *connect.Request[v1.ListCorporateActionsRequest]) (*connect.Response[v1.ListCorporateActionsResponse], error)
+	// DeleteCorporateAction deletes an action that is not applied.
+	DeleteCorporateAction(context.Context, *connect.Request[v1.DeleteCorporateActionRequest]) (*connect.Response[emptypb.Empty], error)
+	// ApplyCorporateAction adjusts every user's holdings and lots of the
+	// asset and, for splits, its prices before the effective date, recording
+	// each change so that it can be reverted. Requires the admin scope.
+	ApplyCorporateAction(context.Context, *connect.Request[v1.ApplyCorporateActionRequest]) (*connect.Response[v1.ApplyCorporateActionResponse], error)
+	// RevertCorporateAction restores what applying the action changed. It
+	// fails if any of it changed since, such as a holding traded after a
+	// split. Requires the admin scope.
+	RevertCorporateAction(context.Context, *connect.Request[v1.RevertCorporateActionRequest]) (*connect.Response[v1.CorporateAction], error)
 	// --- Price CRUD ---
 	CreatePrice(context.Context, *connect.Request[v1.CreatePriceRequest]) (*connect.Response[v1.Price], error)
 	CreatePrices(context.Context, *connect.Request[v1.CreatePricesRequest]) (*connect.Response[v1.CreatePricesResponse], error)
@@ -100,8 +185,14 @@ type MarketDataServiceClient interface {
 	ListPricesByInterval(context.Context, *connect.Request[v1.ListPricesByIntervalRequest]) (*connect.Response[v1.ListPriceHistoryResponse], error)
 	DeletePrice(context.Context, *connect.Request[v1.DeletePriceRequest]) (*connect.Response[emptypb.Empty], error)
 	DeletePrices(context.Context, *connect.Request[v1.DeletePricesRequest]) (*connect.Response[emptypb.Empty], error)
+	// WatchPrices streams new prices for the requested asset pairs as they are stored.
+	WatchPrices(context.Context, *connect.Request[v1.WatchPricesRequest]) (*connect.ServerStreamForClient[v1.Price], error)
 	// --- Price business logic ---
 	FetchExternalPrices(context.Context, *connect.Request[v1.FetchExternalPricesRequest]) (*connect.Response[v1.FetchExternalPricesResponse], error)
+	// GetPriceDataQuality reports, for each asset pair and interval with
+	// prices, the gaps between them, the quarantined prices and the sources
+	// that stopped sending them.
+	GetPriceDataQuality(context.Context, *connect.Request[v1.GetPriceDataQualityRequest]) (*connect.Response[v1.GetPriceDataQualityResponse], error)
 }
 
 // NewMarketDataServiceClient constructs a client for the greedy_eye.v1.MarketDataService service.
@@ -145,18 +236,96 @@ func NewMarketDataServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(marketDataServiceMethods.ByName("ListAssets")),
 			connect.WithClientOptions(opts...),
 		),
+		searchAssets: connect.NewClient[v1.SearchAssetsRequest, v1.SearchAssetsResponse](
+			httpClient,
+			baseURL+MarketDataServiceSearchAssetsProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("SearchAssets")),
+			connect.WithClientOptions(opts...),
+		),
+		importExternalAsset: connect.NewClient[v1.ImportExternalAssetRequest, v1.Asset](
+			httpClient,
+			baseURL+MarketDataServiceImportExternalAssetProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("ImportExternalAsset")),
+			connect.WithClientOptions(opts...),
+		),
 		enrichAssetData: connect.NewClient[v1.EnrichAssetDataRequest, v1.Asset](
 			httpClient,
 			baseURL+MarketDataServiceEnrichAssetDataProcedure,
 			connect.WithSchema(marketDataServiceMethods.ByName("EnrichAssetData")),
 			connect.WithClientOptions(opts...),
 		),
-		findSimilarAssets: connect.NewClient[v1.FindSimilarAssetsRequest, v1.ListAssetsResponse](
+		findSimilarAssets: connect.NewClient[v1.FindSimilarAssetsRequest, v1.FindSimilarAssetsResponse](
 			httpClient,
 			baseURL+MarketDataServiceFindSimilarAssetsProcedure,
 			connect.WithSchema(marketDataServiceMethods.ByName("FindSimilarAssets")),
 			connect.WithClientOptions(opts...),
 		),
+		getBondAnalytics: connect.NewClient[v1.GetBondAnalyticsRequest, v1.GetBondAnalyticsResponse](
+			httpClient,
+			baseURL+MarketDataServiceGetBondAnalyticsProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("GetBondAnalytics")),
+			connect.WithClientOptions(opts...),
+		),
+		createAssetIdentifier: connect.NewClient[v1.CreateAssetIdentifierRequest, v1.AssetIdentifier](
+			httpClient,
+			baseURL+MarketDataServiceCreateAssetIdentifierProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("CreateAssetIdentifier")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteAssetIdentifier: connect.NewClient[v1.DeleteAssetIdentifierRequest, emptypb.Empty](
+			httpClient,
+			baseURL+MarketDataServiceDeleteAssetIdentifierProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("DeleteAssetIdentifier")),
+			connect.WithClientOptions(opts...),
+		),
+		listAssetIdentifiers: connect.NewClient[v1.ListAssetIdentifiersRequest, v1.ListAssetIdentifiersResponse](
+			httpClient,
+			baseURL+MarketDataServiceListAssetIdentifiersProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("ListAssetIdentifiers")),
+			connect.WithClientOptions(opts...),
+		),
+		resolveAsset: connect.NewClient[v1.ResolveAssetRequest, v1.ResolveAssetResponse](
+			httpClient,
+			baseURL+MarketDataServiceResolveAssetProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("ResolveAsset")),
+			connect.WithClientOptions(opts...),
+		),
+		createCorporateAction: connect.NewClient[v1.CreateCorporateActionRequest, v1.CorporateAction](
+			httpClient,
+			baseURL+MarketDataServiceCreateCorporateActionProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("CreateCorporateAction")),
+			connect.WithClientOptions(opts...),
+		),
+		getCorporateAction: connect.NewClient[v1.GetCorporateActionRequest, v1.CorporateAction](
+			httpClient,
+			baseURL+MarketDataServiceGetCorporateActionProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("GetCorporateAction")),
+			connect.WithClientOptions(opts...),
+		),
+		listCorporateActions: connect.NewClient[v1.ListCorporateActionsRequest, v1.ListCorporateActionsResponse](
+			httpClient,
+			baseURL+MarketDataServiceListCorporateActionsProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("ListCorporateActions")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteCorporateAction: connect.NewClient[v1.DeleteCorporateActionRequest, emptypb.Empty](
+			httpClient,
+			baseURL+MarketDataServiceDeleteCorporateActionProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("DeleteCorporateAction")),
+			connect.WithClientOptions(opts...),
+		),
+		applyCorporateAction: connect.NewClient[v1.ApplyCorporateActionRequest, v1.ApplyCorporateActionResponse](
+			httpClient,
+			baseURL+MarketDataServiceApplyCorporateActionProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("ApplyCorporateAction")),
+			connect.WithClientOptions(opts...),
+		),
+		revertCorporateAction: connect.NewClient[v1.RevertCorporateActionRequest, v1.CorporateAction](
+			httpClient,
+			baseURL+MarketDataServiceRevertCorporateActionProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("RevertCorporateAction")),
+			connect.WithClientOptions(opts...),
+		),
 		createPrice: connect.NewClient[v1.CreatePriceRequest, v1.Price](
 			httpClient,
 			baseURL+MarketDataServiceCreatePriceProcedure,
@@ -199,32 +368,59 @@ func NewMarketDataServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(marketDataServiceMethods.ByName("DeletePrices")),
 			connect.WithClientOptions(opts...),
 		),
+		watchPrices: connect.NewClient[v1.WatchPricesRequest, v1.Price](
+			httpClient,
+			baseURL+MarketDataServiceWatchPricesProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("WatchPrices")),
+			connect.WithClientOptions(opts...),
+		),
 		fetchExternalPrices: connect.NewClient[v1.FetchExternalPricesRequest, v1.FetchExternalPricesResponse](
 			httpClient,
 			baseURL+MarketDataServiceFetchExternalPricesProcedure,
 			connect.WithSchema(marketDataServiceMethods.ByName("FetchExternalPrices")),
 			connect.WithClientOptions(opts...),
 		),
+		getPriceDataQuality: connect.NewClient[v1.GetPriceDataQualityRequest, v1.GetPriceDataQualityResponse](
+			httpClient,
+			baseURL+MarketDataServiceGetPriceDataQualityProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("GetPriceDataQuality")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // marketDataServiceClient implements MarketDataServiceClient.
 type marketDataServiceClient struct {
-	createAsset          *connect.Client[v1.CreateAssetRequest, v1.Asset]
-	getAsset             *connect.Client[v1.GetAssetRequest, v1.Asset]
-	updateAsset          *connect.Client[v1.UpdateAssetRequest, v1.Asset]
-	deleteAsset          *connect.Client[v1.DeleteAssetRequest, emptypb.Empty]
-	listAssets           *connect.Client[v1.ListAssetsRequest, v1.ListAssetsResponse]
-	enrichAssetData      *connect.Client[v1.EnrichAssetDataRequest, v1.Asset]
-	findSimilarAssets    *connect.Client[v1.FindSimilarAssetsRequest, v1.ListAssetsResponse]
-	createPrice          *connect.Client[v1.CreatePriceRequest, v1.Price]
-	createPrices         *connect.Client[v1.CreatePricesRequest, v1.CreatePricesResponse]
-	getLatestPrice       *connect.Client[v1.GetLatestPriceRequest, v1.Price]
-	listPriceHistory     *connect.Client[v1.ListPriceHistoryRequest, v1.ListPriceHistoryResponse]
-	listPricesByInterval *connect.Client[v1.ListPricesByIntervalRequest, v1.ListPriceHistoryResponse]
-	deletePrice          *connect.Client[v1.DeletePriceRequest, emptypb.Empty]
-	deletePrices         *connect.Client[v1.DeletePricesRequest, emptypb.Empty]
-	fetchExternalPrices  *connect.Client[v1.FetchExternalPricesRequest, v1.FetchExternalPricesResponse]
+	createAsset           *connect.Client[v1.CreateAssetRequest, v1.Asset]
+	getAsset              *connect.Client[v1.GetAssetRequest, v1.Asset]
+	updateAsset           *connect.Client[v1.UpdateAssetRequest, v1.Asset]
+	deleteAsset           *connect.Client[v1.DeleteAssetRequest, emptypb.Empty]
+	listAssets            *connect.Client[v1.ListAssetsRequest, v1.ListAssetsResponse]
+	searchAssets          *connect.Client[v1.SearchAssetsRequest, v1.SearchAssetsResponse]
+	importExternalAsset   *connect.Client[v1.ImportExternalAssetRequest, v1.Asset]
+	enrichAssetData       *connect.Client[v1.EnrichAssetDataRequest, v1.Asset]
+	findSimilarAssets     *connect.Client[v1.FindSimilarAssetsRequest, v1.FindSimilarAssetsResponse]
+	getBondAnalytics      *connect.Client[v1.GetBondAnalyticsRequest, v1.GetBondAnalyticsResponse]
+	createAssetIdentifier *connect.Client[v1.CreateAssetIdentifierRequest, v1.AssetIdentifier]
+	deleteAssetIdentifier *connect.Client[v1.DeleteAssetIdentifierRequest, emptypb.Empty]
+	listAssetIdentifiers  *connect.Client[v1.ListAssetIdentifiersRequest, v1.ListAssetIdentifiersResponse]
+	resolveAsset          *connect.Client[v1.ResolveAssetRequest, v1.ResolveAssetResponse]
+	createCorporateAction *connect.Client[v1.CreateCorporateActionRequest, v1.CorporateAction]
+	getCorporateAction    *connect.Client[v1.GetCorporateActionRequest, v1.CorporateAction]
+	listCorporateActions  *connect.Client[v1.ListCorporateActionsRequest, v1.ListCorporateActionsResponse]
+	deleteCorporateAction *connect.Client[v1.DeleteCorporateActionRequest, emptypb.Empty]
+	applyCorporateAction  *connect.Client[v1.ApplyCorporateActionRequest, v1.ApplyCorporateActionResponse]
+	revertCorporateAction *connect.Client[v1.RevertCorporateActionRequest, v1.CorporateAction]
+	createPrice           *connect.Client[v1.CreatePriceRequest, v1.Price]
+	createPrices          *connect.Client[v1.CreatePricesRequest, v1.CreatePricesResponse]
+	getLatestPrice        *connect.Client[v1.GetLatestPriceRequest, v1.Price]
+	listPriceHistory      *connect.Client[v1.ListPriceHistoryRequest, v1.ListPriceHistoryResponse]
+	listPricesByInterval  *connect.Client[v1.ListPricesByIntervalRequest, v1.ListPriceHistoryResponse]
+	deletePrice           *connect.Client[v1.DeletePriceRequest, emptypb.Empty]
+	deletePrices          *connect.Client[v1.DeletePricesRequest, emptypb.Empty]
+	watchPrices           *connect.Client[v1.WatchPricesRequest, v1.Price]
+	fetchExternalPrices   *connect.Client[v1.FetchExternalPricesRequest, v1.FetchExternalPricesResponse]
+	getPriceDataQuality   *connect.Client[v1.GetPriceDataQualityRequest, v1.GetPriceDataQualityResponse]
 }
 
 // CreateAsset calls greedy_eye.v1.MarketDataService.CreateAsset.
@@ -252,16 +448,81 @@ func (c *marketDataServiceClient) ListAssets(ctx context.Context, req *connect.R
 	return c.listAssets.CallUnary(ctx, req)
 }
 
+// SearchAssets calls greedy_eye.v1.MarketDataService.SearchAssets.
+func (c *marketDataServiceClient) SearchAssets(ctx context.Context, req *connect.Request[v1.SearchAssetsRequest]) (*connect.Response[v1.SearchAssetsResponse], error) {
+	return c.searchAssets.CallUnary(ctx, req)
+}
+
+// ImportExternalAsset calls greedy_eye.v1.MarketDataService.ImportExternalAsset.
+func (c *marketDataServiceClient) ImportExternalAsset(ctx context.Context, req *connect.Request[v1.ImportExternalAssetRequest]) (*connect.Response[v1.Asset], error) {
+	return c.importExternalAsset.CallUnary(ctx, req)
+}
+
 // EnrichAssetData calls greedy_eye.v1.MarketDataService.EnrichAssetData.
 func (c *marketDataServiceClient) EnrichAssetData(ctx context.Context, req *connect.Request[v1.EnrichAssetDataRequest]) (*connect.Response[v1.Asset], error) {
 	return c.enrichAssetData.CallUnary(ctx, req)
 }
 
 // FindSimilarAssets calls greedy_eye.v1.MarketDataService.FindSimilarAssets.
-func (c *marketDataServiceClient) FindSimilarAssets(ctx context.Context, req *connect.Request[v1.FindSimilarAssetsRequest]) (*connect.Response[v1.ListAssetsResponse], error) {
+func (c *marketDataServiceClient) FindSimilarAssets(ctx context.Context, req *connect.Request[v1.FindSimilarAssetsRequest]) (*connect.Response[v1.FindSimilarAssetsResponse], error) {
 	return c.findSimilarAssets.CallUnary(ctx, req)
 }
 
+// GetBondAnalytics calls greedy_eye.v1.MarketDataService.GetBondAnalytics.
+func (c *marketDataServiceClient) GetBondAnalytics(ctx context.Context, req *connect.Request[v1.GetBondAnalyticsRequest]) (*connect.Response[v1.GetBondAnalyticsResponse], error) {
+	return c.getBondAnalytics.CallUnary(ctx, req)
+}
+
+// CreateAssetIdentifier calls greedy_eye.v1.MarketDataService.CreateAssetIdentifier.
+func (c *marketDataServiceClient) CreateAssetIdentifier(ctx context.Context, req *connect.Request[v1.CreateAssetIdentifierRequest]) (*connect.Response[v1.AssetIdentifier], error) {
+	return c.createAssetIdentifier.CallUnary(ctx, req)
+}
+
+// DeleteAssetIdentifier calls greedy_eye.v1.MarketDataService.DeleteAssetIdentifier.
+func (c *marketDataServiceClient) DeleteAssetIdentifier(ctx context.Context, req *connect.Request[v1.DeleteAssetIdentifierRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteAssetIdentifier.CallUnary(ctx, req)
+}
+
+// ListAssetIdentifiers calls greedy_eye.v1.MarketDataService.ListAssetIdentifiers.
+func (c *marketDataServiceClient) ListAssetIdentifiers(ctx context.Context, req *connect.Request[v1.ListAssetIdentifiersRequest]) (*connect.Response[v1.ListAssetIdentifiersResponse], error) {
+	return c.listAssetIdentifiers.CallUnary(ctx, req)
+}
+
+// ResolveAsset calls greedy_eye.v1.MarketDataService.ResolveAsset.
+func (c *marketDataServiceClient) ResolveAsset(ctx context.Context, req *connect.Request[v1.ResolveAssetRequest]) (*connect.Response[v1.ResolveAssetResponse], error) {
+	return c.resolveAsset.CallUnary(ctx, req)
+}
+
+// CreateCorporateAction calls greedy_eye.v1.MarketDataService.CreateCorporateAction.
+func (c *marketDataServiceClient) CreateCorporateAction(ctx context.Context, req *connect.Request[v1.CreateCorporateActionRequest]) (*connect.Response[v1.CorporateAction], error) {
+	return c.createCorporateAction.CallUnary(ctx, req)
+}
+
+// GetCorporateAction calls greedy_eye.v1.MarketDataService.GetCorporateAction.
+func (c *marketDataServiceClient) GetCorporateAction(ctx context.Context, req *connect.Request[v1.GetCorporateActionRequest]) (*connect.Response[v1.CorporateAction], error) {
+	return c.getCorporateAction.CallUnary(ctx, req)
+}
+
+// ListCorporateActions calls greedy_eye.v1.MarketDataService.ListCorporateActions.
+func (c *marketDataServiceClient) ListCorporateActions(ctx context.Context, req *connect.Request[v1.ListCorporateActionsRequest]) (*connect.Response[v1.ListCorporateActionsResponse], error) {
+	return c.listCorporateActions.CallUnary(ctx, req)
+}
+
+// DeleteCorporateAction calls greedy_eye.v1.MarketDataService.DeleteCorporateAction.
+func (c *marketDataServiceClient) DeleteCorporateAction(ctx context.Context, req *connect.Request[v1.DeleteCorporateActionRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteCorporateAction.CallUnary(ctx, req)
+}
+
+// ApplyCorporateAction calls greedy_eye.v1.MarketDataService.ApplyCorporateAction.
+func (c *marketDataServiceClient) ApplyCorporateAction(ctx context.Context, req *connect.Request[v1.ApplyCorporateActionRequest]) (*connect.Response[v1.ApplyCorporateActionResponse], error) {
+	return c.applyCorporateAction.CallUnary(ctx, req)
+}
+
+// RevertCorporateAction calls greedy_eye.v1.MarketDataService.RevertCorporateAction.
+func (c *marketDataServiceClient) RevertCorporateAction(ctx context.Context, req *connect.Request[v1.RevertCorporateActionRequest]) (*connect.Response[v1.CorporateAction], error) {
+	return c.revertCorporateAction.CallUnary(ctx, req)
+}
+
 // CreatePrice calls greedy_eye.v1.MarketDataService.CreatePrice.
 func (c *marketDataServiceClient) CreatePrice(ctx context.Context, req *connect.Request[v1.CreatePriceRequest]) (*connect.Response[v1.Price], error) {
 	return c.createPrice.CallUnary(ctx, req)
@@ -297,11 +558,21 @@ func (c *marketDataServiceClient) DeletePrices(ctx context.Context, req *connect
 	return c.deletePrices.CallUnary(ctx, req)
 }
 
+// WatchPrices calls greedy_eye.v1.MarketDataService.WatchPrices.
+func (c *marketDataServiceClient) WatchPrices(ctx context.Context, req *connect.Request[v1.WatchPricesRequest]) (*connect.ServerStreamForClient[v1.Price], error) {
+	return c.watchPrices.CallServerStream(ctx, req)
+}
+
 // FetchExternalPrices calls greedy_eye.v1.MarketDataService.FetchExternalPrices.
 func (c *marketDataServiceClient) FetchExternalPrices(ctx context.Context, req *connect.Request[v1.FetchExternalPricesRequest]) (*connect.Response[v1.FetchExternalPricesResponse], error) {
 	return c.fetchExternalPrices.CallUnary(ctx, req)
 }
 
+// GetPriceDataQuality calls greedy_eye.v1.MarketDataService.GetPriceDataQuality.
+func (c *marketDataServiceClient) GetPriceDataQuality(ctx context.Context, req *connect.Request[v1.GetPriceDataQualityRequest]) (*connect.Response[v1.GetPriceDataQualityResponse], error) {
+	return c.getPriceDataQuality.CallUnary(ctx, req)
+}
+
 // MarketDataServiceHandler is an implementation of the greedy_eye.v1.MarketDataService service.
 type MarketDataServiceHandler interface {
 	// --- Asset CRUD ---
@@ -311,8 +582,48 @@ type MarketDataServiceHandler interface {
 	DeleteAsset(context.Context, *connect.Request[v1.DeleteAssetRequest]) (*connect.Response[emptypb.Empty], error)
 	ListAssets(context.Context, *connect.Request[v1.ListAssetsRequest]) (*connect.Response[v1.ListAssetsResponse], error)
 	// --- Asset business logic ---
+	// SearchAssets finds assets by symbol, identifier or words of their name
+	// for type-ahead: an exact symbol first, then an exact identifier, a
+	// symbol prefix, and names by relevance. When nothing matches, it can ask
+	// external providers, whose results ImportExternalAsset adds.
+	SearchAssets(context.Context, *connect.Request[v1.SearchAssetsRequest]) (*connect.Response[v1.SearchAssetsResponse], error)
+	// ImportExternalAsset creates an asset from a provider's search result,
+	// mapped to the provider's ID. An asset already mapped is returned as is.
+	ImportExternalAsset(context.Context, *connect.Request[v1.ImportExternalAssetRequest]) (*connect.Response[v1.Asset], error)
+	// EnrichAssetData merges metadata from the named sources, or from every
+	// configured provider, into the asset. A field is replaced only by a
+	// source of equal or higher precedence.
 	EnrichAssetData(context.Context, *connect.Request[v1.EnrichAssetDataRequest]) (*connect.Response[v1.Asset], error)
-	FindSimilarAssets(context.Context, *connect.Request[v1.FindSimilarAssetsRequest]) (*connect.Response[v1.ListAssetsResponse], error)
+	// FindSimilarAssets ranks assets by shared tags, type and correlation of
+	// daily returns, to spot duplicates such as wrapped tokens or to
+	// diversify.
+	FindSimilarAssets(context.Context, *connect.Request[v1.FindSimilarAssetsRequest]) (*connect.Response[v1.FindSimilarAssetsResponse], error)
+	// GetBondAnalytics computes a bond's accrued interest, its remaining
+	// coupon and principal payments and, given a price, its yield to
+	// maturity, at a settlement date.
+	GetBondAnalytics(context.Context, *connect.Request[v1.GetBondAnalyticsRequest]) (*connect.Response[v1.GetBondAnalyticsResponse], error)
+	// --- Asset identifiers ---
+	CreateAssetIdentifier(context.Context, *connect.Request[v1.CreateAssetIdentifierRequest]) (*connect.Response[v1.AssetIdentifier], error)
+	DeleteAssetIdentifier(context.Context, *connect.Request[v1.DeleteAssetIdentifierRequest]) (*connect.Response[emptypb.Empty], error)
+	ListAssetIdentifiers(context.Context, *connect.Request[v1.ListAssetIdentifiersRequest]) (*connect.Response[v1.ListAssetIdentifiersResponse], error)
+	// ResolveAsset finds the asset an identifier maps to. Without a kind, it
+	// tries every kind the value is valid for and then the asset symbol.
+	// When several assets match, none is picked and all are listed.
+	ResolveAsset(context.Context, *connect.Request[v1.ResolveAssetRequest]) (*connect.Response[v1.ResolveAssetResponse], error)
+	// --- Corporate actions ---
+	CreateCorporateAction(context.Context, *connect.Request[v1.CreateCorporateActionRequest]) (*connect.Response[v1.CorporateAction], error)
+	GetCorporateAction(context.Context, *connect.Request[v1.GetCorporateActionRequest]) (*connect.Response[v1.CorporateAction], error)
+	ListCorporateActions(context.Context, *connect.Request[v1.ListCorporateActionsRequest]) (*connect.Response[v1.ListCorporateActionsResponse], error)
+	// DeleteCorporateAction deletes an action that is not applied.
+	DeleteCorporateAction(context.Context, *connect.Request[v1.DeleteCorporateActionRequest]) (*connect.Response[emptypb.Empty], error)
+	// ApplyCorporateAction adjusts every user's holdings and lots of the
+	// asset and, for splits, its prices before the effective date, recording
+	// each change so that it can be reverted. Requires the admin scope.
+	ApplyCorporateAction(context.Context, *connect.Request[v1.ApplyCorporateActionRequest]) (*connect.Response[v1.ApplyCorporateActionResponse], error)
+	// RevertCorporateAction restores what applying the action changed. It
+	// fails if any of it changed since, such as a holding traded after a
+	// split. Requires the admin scope.
+	RevertCorporateAction(context.Context, *connect.Request[v1.RevertCorporateActionRequest]) (*connect.Response[v1.CorporateAction], error)
 	// --- Price CRUD ---
 	CreatePrice(context.Context, *connect.Request[v1.CreatePriceRequest]) (*connect.Response[v1.Price], error)
 	CreatePrices(context.Context, *connect.Request[v1.CreatePricesRequest]) (*connect.Response[v1.CreatePricesResponse], error)
@@ -321,8 +632,14 @@ type MarketDataServiceHandler interface {
 	ListPricesByInterval(context.Context, *connect.Request[v1.ListPricesByIntervalRequest]) (*connect.Response[v1.ListPriceHistoryResponse], error)
 	DeletePrice(context.Context, *connect.Request[v1.DeletePriceRequest]) (*connect.Response[emptypb.Empty], error)
 	DeletePrices(context.Context, *connect.Request[v1.DeletePricesRequest]) (*connect.Response[emptypb.Empty], error)
+	// WatchPrices streams new prices for the requested asset pairs as they are stored.
+	WatchPrices(context.Context, *connect.Request[v1.WatchPricesRequest], *connect.ServerStream[v1.Price]) error
 	// --- Price business logic ---
 	FetchExternalPrices(context.Context, *connect.Request[v1.FetchExternalPricesRequest]) (*connect.Response[v1.FetchExternalPricesResponse], error)
+	// GetPriceDataQuality reports, for each asset pair and interval with
+	// prices, the gaps between them, the quarantined prices and the sources
+	// that stopped sending them.
+	GetPriceDataQuality(context.Context, *connect.Request[v1.GetPriceDataQualityRequest]) (*connect.Response[v1.GetPriceDataQualityResponse], error)
 }
 
 // NewMarketDataServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -362,6 +679,18 @@ func NewMarketDataServiceHandler(svc MarketDataServiceHandler, opts ...connect.H
 		connect.WithSchema(marketDataServiceMethods.ByName("ListAssets")),
 		connect.WithHandlerOptions(opts...),
 	)
+	marketDataServiceSearchAssetsHandler := connect.NewUnaryHandler(
+		MarketDataServiceSearchAssetsProcedure,
+		svc.SearchAssets,
+		connect.WithSchema(marketDataServiceMethods.ByName("SearchAssets")),
+		connect.WithHandlerOptions(opts...),
+	)
+	marketDataServiceImportExternalAssetHandler := connect.NewUnaryHandler(
+		MarketDataServiceImportExternalAssetProcedure,
+		svc.ImportExternalAsset,
+		connect.WithSchema(marketDataServiceMethods.ByName("ImportExternalAsset")),
+		connect.WithHandlerOptions(opts...),
+	)
 	marketDataServiceEnrichAssetDataHandler := connect.NewUnaryHandler(
 		MarketDataServiceEnrichAssetDataProcedure,
 		svc.EnrichAssetData,
@@ -374,6 +703,72 @@ func NewMarketDataServiceHandler(svc MarketDataServiceHandler, opts ...connect.H
 		connect.WithSchema(marketDataServiceMethods.ByName("FindSimilarAssets")),
 		connect.WithHandlerOptions(opts...),
 	)
+	marketDataServiceGetBondAnalyticsHandler := connect.NewUnaryHandler(
+		MarketDataServiceGetBondAnalyticsProcedure,
+		svc.GetBondAnalytics,
+		connect.WithSchema(marketDataServiceMethods.ByName("GetBondAnalytics")),
+		connect.WithHandlerOptions(opts...),
+	)
+	marketDataServiceCreateAssetIdentifierHandler := connect.NewUnaryHandler(
+		MarketDataServiceCreateAssetIdentifierProcedure,
+		svc.CreateAssetIdentifier,
+		connect.WithSchema(marketDataServiceMethods.ByName("CreateAssetIdentifier")),
+		connect.WithHandlerOptions(opts...),
+	)
+	marketDataServiceDeleteAssetIdentifierHandler := connect.NewUnaryHandler(
+		MarketDataServiceDeleteAssetIdentifierProcedure,
+		svc.DeleteAssetIdentifier,
+		connect.WithSchema(marketDataServiceMethods.ByName("DeleteAssetIdentifier")),
+		connect.WithHandlerOptions(opts...),
+	)
+	marketDataServiceListAssetIdentifiersHandler := connect.NewUnaryHandler(
+		MarketDataServiceListAssetIdentifiersProcedure,
+		svc.ListAssetIdentifiers,
+		connect.WithSchema(marketDataServiceMethods.ByName("ListAssetIdentifiers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	marketDataServiceResolveAssetHandler := connect.NewUnaryHandler(
+		MarketDataServiceResolveAssetProcedure,
+		svc.ResolveAsset,
+		connect.WithSchema(marketDataServiceMethods.ByName("ResolveAsset")),
+		connect.WithHandlerOptions(opts...),
+	)
+	marketDataServiceCreateCorporateActionHandler := connect.NewUnaryHandler(
+		MarketDataServiceCreateCorporateActionProcedure,
+		svc.CreateCorporateAction,
+		connect.WithSchema(marketDataServiceMethods.ByName("CreateCorporateAction")),
+		connect.WithHandlerOptions(opts...),
+	)
+	marketDataServiceGetCorporateActionHandler := connect.NewUnaryHandler(
+		MarketDataServiceGetCorporateActionProcedure,
+		svc.GetCorporateAction,
+		connect.WithSchema(marketDataServiceMethods.ByName("GetCorporateAction")),
+		connect.WithHandlerOptions(opts...),
+	)
+	marketDataServiceListCorporateActionsHandler := connect.NewUnaryHandler(
+		MarketDataServiceListCorporateActionsProcedure,
+		svc.ListCorporateActions,
+		connect.WithSchema(marketDataServiceMethods.ByName("ListCorporateActions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	marketDataServiceDeleteCorporateActionHandler := connect.NewUnaryHandler(
+		MarketDataServiceDeleteCorporateActionProcedure,
+		svc.DeleteCorporateAction,
+		connect.WithSchema(marketDataServiceMethods.ByName("DeleteCorporateAction")),
+		connect.WithHandlerOptions(opts...),
+	)
+	marketDataServiceApplyCorporateActionHandler := connect.NewUnaryHandler(
+		MarketDataServiceApplyCorporateActionProcedure,
+		svc.ApplyCorporateAction,
+		connect.WithSchema(marketDataServiceMethods.ByName("ApplyCorporateAction")),
+		connect.WithHandlerOptions(opts...),
+	)
+	marketDataServiceRevertCorporateActionHandler := connect.NewUnaryHandler(
+		MarketDataServiceRevertCorporateActionProcedure,
+		svc.RevertCorporateAction,
+		connect.WithSchema(marketDataServiceMethods.ByName("RevertCorporateAction")),
+		connect.WithHandlerOptions(opts...),
+	)
 	marketDataServiceCreatePriceHandler := connect.NewUnaryHandler(
 		MarketDataServiceCreatePriceProcedure,
 		svc.CreatePrice,
@@ -416,12 +811,24 @@ func NewMarketDataServiceHandler(svc MarketDataServiceHandler, opts ...connect.H
 		connect.WithSchema(marketDataServiceMethods.ByName("DeletePrices")),
 		connect.WithHandlerOptions(opts...),
 	)
+	marketDataServiceWatchPricesHandler := connect.NewServerStreamHandler(
+		MarketDataServiceWatchPricesProcedure,
+		svc.WatchPrices,
+		connect.WithSchema(marketDataServiceMethods.ByName("WatchPrices")),
+		connect.WithHandlerOptions(opts...),
+	)
 	marketDataServiceFetchExternalPricesHandler := connect.NewUnaryHandler(
 		MarketDataServiceFetchExternalPricesProcedure,
 		svc.FetchExternalPrices,
 		connect.WithSchema(marketDataServiceMethods.ByName("FetchExternalPrices")),
 		connect.WithHandlerOptions(opts...),
 	)
+	marketDataServiceGetPriceDataQualityHandler := connect.NewUnaryHandler(
+		MarketDataServiceGetPriceDataQualityProcedure,
+		svc.GetPriceDataQuality,
+		connect.WithSchema(marketDataServiceMethods.ByName("GetPriceDataQuality")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/greedy_eye.v1.MarketDataService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case MarketDataServiceCreateAssetProcedure:
@@ -434,10 +841,36 @@ func NewMarketDataServiceHandler(svc MarketDataServiceHandler, opts ...connect.H
 			marketDataServiceDeleteAssetHandler.ServeHTTP(w, r)
 		case MarketDataServiceListAssetsProcedure:
 			marketDataServiceListAssetsHandler.ServeHTTP(w, r)
+		case MarketDataServiceSearchAssetsProcedure:
+			marketDataServiceSearchAssetsHandler.ServeHTTP(w, r)
+		case MarketDataServiceImportExternalAssetProcedure:
+			marketDataServiceImportExternalAssetHandler.ServeHTTP(w, r)
 		case MarketDataServiceEnrichAssetDataProcedure:
 			marketDataServiceEnrichAssetDataHandler.ServeHTTP(w, r)
 		case MarketDataServiceFindSimilarAssetsProcedure:
 			marketDataServiceFindSimilarAssetsHandler.ServeHTTP(w, r)
+		case MarketDataServiceGetBondAnalyticsProcedure:
+			marketDataServiceGetBondAnalyticsHandler.ServeHTTP(w, r)
+		case MarketDataServiceCreateAssetIdentifierProcedure:
+			marketDataServiceCreateAssetIdentifierHandler.ServeHTTP(w, r)
+		case MarketDataServiceDeleteAssetIdentifierProcedure:
+			marketDataServiceDeleteAssetIdentifierHandler.ServeHTTP(w, r)
+		case MarketDataServiceListAssetIdentifiersProcedure:
+			marketDataServiceListAssetIdentifiersHandler.ServeHTTP(w, r)
+		case MarketDataServiceResolveAssetProcedure:
+			marketDataServiceResolveAssetHandler.ServeHTTP(w, r)
+		case MarketDataServiceCreateCorporateActionProcedure:
+			marketDataServiceCreateCorporateActionHandler.ServeHTTP(w, r)
+		case MarketDataServiceGetCorporateActionProcedure:
+			marketDataServiceGetCorporateActionHandler.ServeHTTP(w, r)
+		case MarketDataServiceListCorporateActionsProcedure:
+			marketDataServiceListCorporateActionsHandler.ServeHTTP(w, r)
+		case MarketDataServiceDeleteCorporateActionProcedure:
+			marketDataServiceDeleteCorporateActionHandler.ServeHTTP(w, r)
+		case MarketDataServiceApplyCorporateActionProcedure:
+			marketDataServiceApplyCorporateActionHandler.ServeHTTP(w, r)
+		case MarketDataServiceRevertCorporateActionProcedure:
+			marketDataServiceRevertCorporateActionHandler.ServeHTTP(w, r)
 		case MarketDataServiceCreatePriceProcedure:
 			marketDataServiceCreatePriceHandler.ServeHTTP(w, r)
 		case MarketDataServiceCreatePricesProcedure:
@@ -452,8 +885,12 @@ func NewMarketDataServiceHandler(svc MarketDataServiceHandler, opts ...connect.H
 			marketDataServiceDeletePriceHandler.ServeHTTP(w, r)
 		case MarketDataServiceDeletePricesProcedure:
 			marketDataServiceDeletePricesHandler.ServeHTTP(w, r)
+		case MarketDataServiceWatchPricesProcedure:
+			marketDataServiceWatchPricesHandler.ServeHTTP(w, r)
 		case MarketDataServiceFetchExternalPricesProcedure:
 			marketDataServiceFetchExternalPricesHandler.ServeHTTP(w, r)
+		case MarketDataServiceGetPriceDataQualityProcedure:
+			marketDataServiceGetPriceDataQualityHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -483,14 +920,66 @@ func (UnimplementedMarketDataServiceHandler) ListAssets(context.Context, *connec
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.ListAssets is not implemented"))
 }
 
+func (UnimplementedMarketDataServiceHandler) SearchAssets(context.Context, *connect.Request[v1.SearchAssetsRequest]) (*connect.Response[v1.SearchAssetsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.SearchAssets is not implemented"))
+}
+
+func (UnimplementedMarketDataServiceHandler) ImportExternalAsset(context.Context, *connect.Request[v1.ImportExternalAssetRequest]) (*connect.Response[v1.Asset], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.ImportExternalAsset is not implemented"))
+}
+
 func (UnimplementedMarketDataServiceHandler) EnrichAssetData(context.Context, *connect.Request[v1.EnrichAssetDataRequest]) (*connect.Response[v1.Asset], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.EnrichAssetData is not implemented"))
 }
 
-func (UnimplementedMarketDataServiceHandler) FindSimilarAssets(context.Context, *connect.Request[v1.FindSimilarAssetsRequest]) (*connect.Response[v1.ListAssetsResponse], error) {
+func (UnimplementedMarketDataServiceHandler) FindSimilarAssets(context.Context, *connect.Request[v1.FindSimilarAssetsRequest]) (*connect.Response[v1.FindSimilarAssetsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.FindSimilarAssets is not implemented"))
 }
 
+func (UnimplementedMarketDataServiceHandler) GetBondAnalytics(context.Context, *connect.Request[v1.GetBondAnalyticsRequest]) (*connect.Response[v1.GetBondAnalyticsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.GetBondAnalytics is not implemented"))
+}
+
+func (UnimplementedMarketDataServiceHandler) CreateAssetIdentifier(context.Context, *connect.Request[v1.CreateAssetIdentifierRequest]) (*connect.Response[v1.AssetIdentifier], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.CreateAssetIdentifier is not implemented"))
+}
+
+func (UnimplementedMarketDataServiceHandler) DeleteAssetIdentifier(context.Context, *connect.Request[v1.DeleteAssetIdentifierRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.DeleteAssetIdentifier is not implemented"))
+}
+
+func (UnimplementedMarketDataServiceHandler) ListAssetIdentifiers(context.Context, *connect.Request[v1.ListAssetIdentifiersRequest]) (*connect.Response[v1.ListAssetIdentifiersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.ListAssetIdentifiers is not implemented"))
+}
+
+func (UnimplementedMarketDataServiceHandler) ResolveAsset(context.Context, *connect.Request[v1.ResolveAssetRequest]) (*connect.Response[v1.ResolveAssetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.ResolveAsset is not implemented"))
+}
+
+func (UnimplementedMarketDataServiceHandler) CreateCorporateAction(context.Context, *connect.Request[v1.CreateCorporateActionRequest]) (*connect.Response[v1.CorporateAction], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.CreateCorporateAction is not implemented"))
+}
+
+func (UnimplementedMarketDataServiceHandler) GetCorporateAction(context.Context, *connect.Request[v1.GetCorporateActionRequest]) (*connect.Response[v1.CorporateAction], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.GetCorporateAction is not implemented"))
+}
+
+func (UnimplementedMarketDataServiceHandler) ListCorporateActions(context.Context, *connect.Request[v1.ListCorporateActionsRequest]) (*connect.Response[v1.ListCorporateActionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.ListCorporateActions is not implemented"))
+}
+
+func (UnimplementedMarketDataServiceHandler) DeleteCorporateAction(context.Context, *connect.Request[v1.DeleteCorporateActionRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.DeleteCorporateAction is not implemented"))
+}
+
+func (UnimplementedMarketDataServiceHandler) ApplyCorporateAction(context.Context, *connect.Request[v1.ApplyCorporateActionRequest]) (*connect.Response[v1.ApplyCorporateActionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.ApplyCorporateAction is not implemented"))
+}
+
+func (UnimplementedMarketDataServiceHandler) RevertCorporateAction(context.Context, *connect.Request[v1.RevertCorporateActionRequest]) (*connect.Response[v1.CorporateAction], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.RevertCorporateAction is not implemented"))
+}
+
 func (UnimplementedMarketDataServiceHandler) CreatePrice(context.Context, *connect.Request[v1.CreatePriceRequest]) (*connect.Response[v1.Price], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.CreatePrice is not implemented"))
 }
@@ -519,6 +1008,14 @@ func (UnimplementedMarketDataServiceHandler) DeletePrices(context.Context, *conn
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.DeletePrices is not implemented"))
 }
 
+func (UnimplementedMarketDataServiceHandler) WatchPrices(context.Context, *connect.Request[v1.WatchPricesRequest], *connect.ServerStream[v1.Price]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.WatchPrices is not implemented"))
+}
+
 func (UnimplementedMarketDataServiceHandler) FetchExternalPrices(context.Context, *connect.Request[v1.FetchExternalPricesRequest]) (*connect.Response[v1.FetchExternalPricesResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.FetchExternalPrices is not implemented"))
 }
+
+func (UnimplementedMarketDataServiceHandler) GetPriceDataQuality(context.Context, *connect.Request[v1.GetPriceDataQualityRequest]) (*connect.Response[v1.GetPriceDataQualityResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.MarketDataService.GetPriceDataQuality is not implemented"))
+}
@@ -49,12 +49,27 @@ const (
 	// PortfolioServiceListPortfoliosProcedure is the fully-qualified name of the PortfolioService's
 	// ListPortfolios RPC.
 	PortfolioServiceListPortfoliosProcedure = "/greedy_eye.v1.PortfolioService/ListPortfolios"
+	// PortfolioServiceInvitePortfolioMemberProcedure is the fully-qualified name of the
+	// PortfolioService's InvitePortfolioMember RPC.
+	PortfolioServiceInvitePortfolioMemberProcedure = "/greedy_eye.v1.PortfolioService/InvitePortfolioMember"
+	// PortfolioServiceAcceptPortfolioInvitationProcedure is the fully-qualified name of the
+	// PortfolioService's AcceptPortfolioInvitation RPC.
+	PortfolioServiceAcceptPortfolioInvitationProcedure = "/greedy_eye.v1.PortfolioService/AcceptPortfolioInvitation"
+	// PortfolioServiceRevokePortfolioMemberProcedure is the fully-qualified name of the
+	// PortfolioService's RevokePortfolioMember RPC.
+	PortfolioServiceRevokePortfolioMemberProcedure = "/greedy_eye.v1.PortfolioService/RevokePortfolioMember"
+	// PortfolioServiceListPortfolioMembersProcedure is the fully-qualified name of the
+	// PortfolioService's ListPortfolioMembers RPC.
+	PortfolioServiceListPortfolioMembersProcedure = "/greedy_eye.v1.PortfolioService/ListPortfolioMembers"
 	// PortfolioServiceCalculatePortfolioValueProcedure is the fully-qualified name of the
 	// PortfolioService's CalculatePortfolioValue RPC.
 	PortfolioServiceCalculatePortfolioValueProcedure = "/greedy_eye.v1.PortfolioService/CalculatePortfolioValue"
 	// PortfolioServiceGetPortfolioPerformanceProcedure is the fully-qualified name of the
 	// PortfolioService's GetPortfolioPerformance RPC.
 	PortfolioServiceGetPortfolioPerformanceProcedure = "/greedy_eye.v1.PortfolioService/GetPortfolioPerformance"
+	// PortfolioServiceGetIncomeSummaryProcedure is the fully-qualified name of the PortfolioService's
+	// GetIncomeSummary RPC.
+	PortfolioServiceGetIncomeSummaryProcedure = "/greedy_eye.v1.PortfolioService/GetIncomeSummary"
 	// PortfolioServiceCreateHoldingProcedure is the fully-qualified name of the PortfolioService's
 	// CreateHolding RPC.
 	PortfolioServiceCreateHoldingProcedure = "/greedy_eye.v1.PortfolioService/CreateHolding"
@@ -67,6 +82,17 @@ const (
 	// PortfolioServiceListHoldingsProcedure is the fully-qualified name of the PortfolioService's
 	// ListHoldings RPC.
 	PortfolioServiceListHoldingsProcedure = "/greedy_eye.v1.PortfolioService/ListHoldings"
+	// PortfolioServiceCreateLotProcedure is the fully-qualified name of the PortfolioService's
+	// CreateLot RPC.
+	PortfolioServiceCreateLotProcedure = "/greedy_eye.v1.PortfolioService/CreateLot"
+	// PortfolioServiceGetLotProcedure is the fully-qualified name of the PortfolioService's GetLot RPC.
+	PortfolioServiceGetLotProcedure = "/greedy_eye.v1.PortfolioService/GetLot"
+	// PortfolioServiceUpdateLotProcedure is the fully-qualified name of the PortfolioService's
+	// UpdateLot RPC.
+	PortfolioServiceUpdateLotProcedure = "/greedy_eye.v1.PortfolioService/UpdateLot"
+	// PortfolioServiceListLotsProcedure is the fully-qualified name of the PortfolioService's ListLots
+	// RPC.
+	PortfolioServiceListLotsProcedure = "/greedy_eye.v1.PortfolioService/ListLots"
 	// PortfolioServiceCreateAccountProcedure is the fully-qualified name of the PortfolioService's
 	// CreateAccount RPC.
 	PortfolioServiceCreateAccountProcedure = "/greedy_eye.v1.PortfolioService/CreateAccount"
@@ -94,6 +120,15 @@ const (
 	// PortfolioServiceListTransactionsProcedure is the fully-qualified name of the PortfolioService's
 	// ListTransactions RPC.
 	PortfolioServiceListTransactionsProcedure = "/greedy_eye.v1.PortfolioService/ListTransactions"
+	// PortfolioServiceImportTransactionsProcedure is the fully-qualified name of the PortfolioService's
+	// ImportTransactions RPC.
+	PortfolioServiceImportTransactionsProcedure = "/greedy_eye.v1.PortfolioService/ImportTransactions"
+	// PortfolioServiceExportPortfolioProcedure is the fully-qualified name of the PortfolioService's
+	// ExportPortfolio RPC.
+	PortfolioServiceExportPortfolioProcedure = "/greedy_eye.v1.PortfolioService/ExportPortfolio"
+	// PortfolioServiceGenerateTaxReportProcedure is the fully-qualified name of the PortfolioService's
+	// GenerateTaxReport RPC.
+	PortfolioServiceGenerateTaxReportProcedure = "/greedy_eye.v1.PortfolioService/GenerateTaxReport"
 )
 
 // PortfolioServiceClient is a client for the greedy_eye.v1.PortfolioService service.
@@ -104,14 +139,35 @@ type PortfolioServiceClient interface {
 	UpdatePortfolio(context.Context, *connect.Request[v1.UpdatePortfolioRequest]) (*connect.Response[v1.Portfolio], error)
 	DeletePortfolio(context.Context, *connect.Request[v1.DeletePortfolioRequest]) (*connect.Response[emptypb.Empty], error)
 	ListPortfolios(context.Context, *connect.Request[v1.ListPortfoliosRequest]) (*connect.Response[v1.ListPortfoliosResponse], error)
+	// --- Portfolio sharing ---
+	// InvitePortfolioMember invites a user by email, or changes the role of an
+	// existing member. Requires the owner role.
+	InvitePortfolioMember(context.Context, *connect.Request[v1.InvitePortfolioMemberRequest]) (*connect.Response[v1.PortfolioMember], error)
+	// AcceptPortfolioInvitation activates the caller's pending membership.
+	AcceptPortfolioInvitation(context.Context, *connect.Request[v1.AcceptPortfolioInvitationRequest]) (*connect.Response[v1.PortfolioMember], error)
+	// RevokePortfolioMember removes a member or invitation. Owners may remove
+	// anyone; members may remove themselves to leave or decline.
+	RevokePortfolioMember(context.Context, *connect.Request[v1.RevokePortfolioMemberRequest]) (*connect.Response[emptypb.Empty], error)
+	// ListPortfolioMembers lists the members of a portfolio the caller can
+	// view, or the memberships and invitations of the caller.
+	ListPortfolioMembers(context.Context, *connect.Request[v1.ListPortfolioMembersRequest]) (*connect.Response[v1.ListPortfolioMembersResponse], error)
 	// --- Portfolio business logic ---
 	CalculatePortfolioValue(context.Context, *connect.Request[v1.CalculatePortfolioValueRequest]) (*connect.Response[v1.PortfolioValueResponse], error)
 	GetPortfolioPerformance(context.Context, *connect.Request[v1.GetPortfolioPerformanceRequest]) (*connect.Response[v1.PortfolioPerformanceResponse], error)
+	// GetIncomeSummary totals the income of a portfolio's accounts by asset,
+	// account and month, and projects a year's income and yield from recent
+	// history.
+	GetIncomeSummary(context.Context, *connect.Request[v1.GetIncomeSummaryRequest]) (*connect.Response[v1.IncomeSummary], error)
 	// --- Holding CRUD ---
 	CreateHolding(context.Context, *connect.Request[v1.CreateHoldingRequest]) (*connect.Response[v1.Holding], error)
 	GetHolding(context.Context, *connect.Request[v1.GetHoldingRequest]) (*connect.Response[v1.Holding], error)
 	UpdateHolding(context.Context, *connect.Request[v1.UpdateHoldingRequest]) (*connect.Response[v1.Holding], error)
 	ListHoldings(context.Context, *connect.Request[v1.ListHoldingsRequest]) (*connect.Response[v1.ListHoldingsResponse], error)
+	// --- Lot CRUD ---
+	CreateLot(context.Context, *connect.Request[v1.CreateLotRequest]) (*connect.Response[v1.Lot], error)
+	GetLot(context.Context, *connect.Request[v1.GetLotRequest]) (*connect.Response[v1.Lot], error)
+	UpdateLot(context.Context, *connect.Request[v1.UpdateLotRequest]) (*connect.Response[v1.Lot], error)
+	ListLots(context.Context, *connect.Request[v1.ListLotsRequest]) (*connect.Response[v1.ListLotsResponse], error)
 	// --- Account CRUD ---
 	CreateAccount(context.Context, *connect.Request[v1.CreateAccountRequest]) (*connect.Response[v1.Account], error)
 	GetAccount(context.Context, *connect.Request[v1.GetAccountRequest]) (*connect.Response[v1.Account], error)
@@ -123,6 +179,17 @@ type PortfolioServiceClient interface {
 	GetTransaction(context.Context, *connect.Request[v1.GetTransactionRequest]) (*connect.Response[v1.Transaction], error)
 	UpdateTransaction(context.Context, *connect.Request[v1.UpdateTransactionRequest]) (*connect.Response[v1.Transaction], error)
 	ListTransactions(context.Context, *connect.Request[v1.ListTransactionsRequest]) (*connect.Response[v1.ListTransactionsResponse], error)
+	// ImportTransactions parses a CSV export into transactions of an account.
+	// A dry run returns the report without recording anything.
+	ImportTransactions(context.Context, *connect.Request[v1.ImportTransactionsRequest]) (*connect.Response[v1.ImportTransactionsResponse], error)
+	// ExportPortfolio streams a file with the portfolio's holdings, valued in
+	// the quote asset, and the transactions in the date range that touch them:
+	// those in a holding's account of its asset, quoted in it or paid on it.
+	// The same file is served as a download at the HTTP path.
+	ExportPortfolio(context.Context, *connect.Request[v1.ExportPortfolioRequest]) (*connect.ServerStreamForClient[v1.ExportChunk], error)
+	// GenerateTaxReport lists a user's disposals and income events in a tax
+	// year, with gains classified by the jurisdiction's holding period.
+	GenerateTaxReport(context.Context, *connect.Request[v1.GenerateTaxReportRequest]) (*connect.Response[v1.TaxReport], error)
 }
 
 // NewPortfolioServiceClient constructs a client for the greedy_eye.v1.PortfolioService service. By
@@ -166,6 +233,30 @@ func NewPortfolioServiceClient(httpClient connect.HTTPClient, baseURL string, op
 			connect.WithSchema(portfolioServiceMethods.ByName("ListPortfolios")),
 			connect.WithClientOptions(opts...),
 		),
+		invitePortfolioMember: connect.NewClient[v1.InvitePortfolioMemberRequest, v1.PortfolioMember](
+			httpClient,
+			baseURL+PortfolioServiceInvitePortfolioMemberProcedure,
+			connect.WithSchema(portfolioServiceMethods.ByName("InvitePortfolioMember")),
+			connect.WithClientOptions(opts...),
+		),
+		acceptPortfolioInvitation: connect.NewClient[v1.AcceptPortfolioInvitationRequest, v1.PortfolioMember](
+			httpClient,
+			baseURL+PortfolioServiceAcceptPortfolioInvitationProcedure,
+			connect.WithSchema(portfolioServiceMethods.ByName("AcceptPortfolioInvitation")),
+			connect.WithClientOptions(opts...),
+		),
+		revokePortfolioMember: connect.NewClient[v1.RevokePortfolioMemberRequest, emptypb.Empty](
+			httpClient,
+			baseURL+PortfolioServiceRevokePortfolioMemberProcedure,
+			connect.WithSchema(portfolioServiceMethods.ByName("RevokePortfolioMember")),
+			connect.WithClientOptions(opts...),
+		),
+		listPortfolioMembers: connect.NewClient[v1.ListPortfolioMembersRequest, v1.ListPortfolioMembersResponse](
+			httpClient,
+			baseURL+PortfolioServiceListPortfolioMembersProcedure,
+			connect.WithSchema(portfolioServiceMethods.ByName("ListPortfolioMembers")),
+			connect.WithClientOptions(opts...),
+		),
 		calculatePortfolioValue: connect.NewClient[v1.CalculatePortfolioValueRequest, v1.PortfolioValueResponse](
 			httpClient,
 			baseURL+PortfolioServiceCalculatePortfolioValueProcedure,
@@ -178,6 +269,12 @@ func NewPortfolioServiceClient(httpClient connect.HTTPClient, baseURL string, op
 			connect.WithSchema(portfolioServiceMethods.ByName("GetPortfolioPerformance")),
 			connect.WithClientOptions(opts...),
 		),
+		getIncomeSummary: connect.NewClient[v1.GetIncomeSummaryRequest, v1.IncomeSummary](
+			httpClient,
+			baseURL+PortfolioServiceGetIncomeSummaryProcedure,
+			connect.WithSchema(portfolioServiceMethods.ByName("GetIncomeSummary")),
+			connect.WithClientOptions(opts...),
+		),
 		createHolding: connect.NewClient[v1.CreateHoldingRequest, v1.Holding](
 			httpClient,
 			baseURL+PortfolioServiceCreateHoldingProcedure,
@@ -202,6 +299,30 @@ func NewPortfolioServiceClient(httpClient connect.HTTPClient, baseURL string, op
 			connect.WithSchema(portfolioServiceMethods.ByName("ListHoldings")),
 			connect.WithClientOptions(opts...),
 		),
+		createLot: connect.NewClient[v1.CreateLotRequest, v1.Lot](
+			httpClient,
+			baseURL+PortfolioServiceCreateLotProcedure,
+			connect.WithSchema(portfolioServiceMethods.ByName("CreateLot")),
+			connect.WithClientOptions(opts...),
+		),
+		getLot: connect.NewClient[v1.GetLotRequest, v1.Lot](
+			httpClient,
+			baseURL+PortfolioServiceGetLotProcedure,
+			connect.WithSchema(portfolioServiceMethods.ByName("GetLot")),
+			connect.WithClientOptions(opts...),
+		),
+		updateLot: connect.NewClient[v1.UpdateLotRequest, v1.Lot](
+			httpClient,
+			baseURL+PortfolioServiceUpdateLotProcedure,
+			connect.WithSchema(portfolioServiceMethods.ByName("UpdateLot")),
+			connect.WithClientOptions(opts...),
+		),
+		listLots: connect.NewClient[v1.ListLotsRequest, v1.ListLotsResponse](
+			httpClient,
+			baseURL+PortfolioServiceListLotsProcedure,
+			connect.WithSchema(portfolioServiceMethods.ByName("ListLots")),
+			connect.WithClientOptions(opts...),
+		),
 		createAccount: connect.NewClient[v1.CreateAccountRequest, v1.Account](
 			httpClient,
 			baseURL+PortfolioServiceCreateAccountProcedure,
@@ -256,31 +377,61 @@ func NewPortfolioServiceClient(httpClient connect.HTTPClient, baseURL string, op
 			connect.WithSchema(portfolioServiceMethods.ByName("ListTransactions")),
 			connect.WithClientOptions(opts...),
 		),
+		importTransactions: connect.NewClient[v1.ImportTransactionsRequest, v1.ImportTransactionsResponse](
+			httpClient,
+			baseURL+PortfolioServiceImportTransactionsProcedure,
+			connect.WithSchema(portfolioServiceMethods.ByName("ImportTransactions")),
+			connect.WithClientOptions(opts...),
+		),
+		exportPortfolio: connect.NewClient[v1.ExportPortfolioRequest, v1.ExportChunk](
+			httpClient,
+			baseURL+PortfolioServiceExportPortfolioProcedure,
+			connect.WithSchema(portfolioServiceMethods.ByName("ExportPortfolio")),
+			connect.WithClientOptions(opts...),
+		),
+		generateTaxReport: connect.NewClient[v1.GenerateTaxReportRequest, v1.TaxReport](
+			httpClient,
+			baseURL+PortfolioServiceGenerateTaxReportProcedure,
+			connect.WithSchema(portfolioServiceMethods.ByName("GenerateTaxReport")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // portfolioServiceClient implements PortfolioServiceClient.
 type portfolioServiceClient struct {
-	createPortfolio         *connect.Client[v1.CreatePortfolioRequest, v1.Portfolio]
-	getPortfolio            *connect.Client[v1.GetPortfolioRequest, v1.Portfolio]
-	updatePortfolio         *connect.Client[v1.UpdatePortfolioRequest, v1.Portfolio]
-	deletePortfolio         *connect.Client[v1.DeletePortfolioRequest, emptypb.Empty]
-	listPortfolios          *connect.Client[v1.ListPortfoliosRequest, v1.ListPortfoliosResponse]
-	calculatePortfolioValue *connect.Client[v1.CalculatePortfolioValueRequest, v1.PortfolioValueResponse]
-	getPortfolioPerformance *connect.Client[v1.GetPortfolioPerformanceRequest, v1.PortfolioPerformanceResponse]
-	createHolding           *connect.Client[v1.CreateHoldingRequest, v1.Holding]
-	getHolding              *connect.Client[v1.GetHoldingRequest, v1.Holding]
-	updateHolding           *connect.Client[v1.UpdateHoldingRequest, v1.Holding]
-	listHoldings            *connect.Client[v1.ListHoldingsRequest, v1.ListHoldingsResponse]
-	createAccount           *connect.Client[v1.CreateAccountRequest, v1.Account]
-	getAccount              *connect.Client[v1.GetAccountRequest, v1.Account]
-	updateAccount           *connect.Client[v1.UpdateAccountRequest, v1.Account]
-	deleteAccount           *connect.Client[v1.DeleteAccountRequest, emptypb.Empty]
-	listAccounts            *connect.Client[v1.ListAccountsRequest, v1.ListAccountsResponse]
-	createTransaction       *connect.Client[v1.CreateTransactionRequest, v1.Transaction]
-	getTransaction          *connect.Client[v1.GetTransactionRequest, v1.Transaction]
-	updateTransaction       *connect.Client[v1.UpdateTransactionRequest, v1.Transaction]
-	listTransactions        *connect.Client[v1.ListTransactionsRequest, v1.ListTransactionsResponse]
+	createPortfolio           *connect.Client[v1.CreatePortfolioRequest, v1.Portfolio]
+	getPortfolio              *connect.Client[v1.GetPortfolioRequest, v1.Portfolio]
+	updatePortfolio           *connect.Client[v1.UpdatePortfolioRequest, v1.Portfolio]
+	deletePortfolio           *connect.Client[v1.DeletePortfolioRequest, emptypb.Empty]
+	listPortfolios            *connect.Client[v1.ListPortfoliosRequest, v1.ListPortfoliosResponse]
+	invitePortfolioMember     *connect.Client[v1.InvitePortfolioMemberRequest, v1.PortfolioMember]
+	acceptPortfolioInvitation *connect.Client[v1.AcceptPortfolioInvitationRequest, v1.PortfolioMember]
+	revokePortfolioMember     *connect.Client[v1.RevokePortfolioMemberRequest, emptypb.Empty]
+	listPortfolioMembers      *connect.Client[v1.ListPortfolioMembersRequest, v1.ListPortfolioMembersResponse]
+	calculatePortfolioValue   *connect.Client[v1.CalculatePortfolioValueRequest, v1.PortfolioValueResponse]
+	getPortfolioPerformance   *connect.Client[v1.GetPortfolioPerformanceRequest, v1.PortfolioPerformanceResponse]
+	getIncomeSummary          *connect.Client[v1.GetIncomeSummaryRequest, v1.IncomeSummary]
+	createHolding             *connect.Client[v1.CreateHoldingRequest, v1.Holding]
+	getHolding                *connect.Client[v1.GetHoldingRequest, v1.Holding]
+	updateHolding             *connect.Client[v1.UpdateHoldingRequest, v1.Holding]
+	listHoldings              *connect.Client[v1.ListHoldingsRequest, v1.ListHoldingsResponse]
+	createLot                 *connect.Client[v1.CreateLotRequest, v1.Lot]
+	getLot                    *connect.Client[v1.GetLotRequest, v1.Lot]
+	updateLot                 *connect.Client[v1.UpdateLotRequest, v1.Lot]
+	listLots                  *connect.Client[v1.ListLotsRequest, v1.ListLotsResponse]
+	createAccount             *connect.Client[v1.CreateAccountRequest, v1.Account]
+	getAccount                *connect.Client[v1.GetAccountRequest, v1.Account]
+	updateAccount             *connect.Client[v1.UpdateAccountRequest, v1.Account]
+	deleteAccount             *connect.Client[v1.DeleteAccountRequest, emptypb.Empty]
+	listAccounts              *connect.Client[v1.ListAccountsRequest, v1.ListAccountsResponse]
+	createTransaction         *connect.Client[v1.CreateTransactionRequest, v1.Transaction]
+	getTransaction            *connect.Client[v1.GetTransactionRequest, v1.Transaction]
+	updateTransaction         *connect.Client[v1.UpdateTransactionRequest, v1.Transaction]
+	listTransactions          *connect.Client[v1.ListTransactionsRequest, v1.ListTransactionsResponse]
+	importTransactions        *connect.Client[v1.ImportTransactionsRequest, v1.ImportTransactionsResponse]
+	exportPortfolio           *connect.Client[v1.ExportPortfolioRequest, v1.ExportChunk]
+	generateTaxReport         *connect.Client[v1.GenerateTaxReportRequest, v1.TaxReport]
 }
 
 // CreatePortfolio calls greedy_eye.v1.PortfolioService.CreatePortfolio.
@@ -308,6 +459,26 @@ func (c *portfolioServiceClient) ListPortfolios(ctx context.Context, req *connec
 	return c.listPortfolios.CallUnary(ctx, req)
 }
 
+// InvitePortfolioMember calls greedy_eye.v1.PortfolioService.InvitePortfolioMember.
+func (c *portfolioServiceClient) InvitePortfolioMember(ctx context.Context, req *connect.Request[v1.InvitePortfolioMemberRequest]) (*connect.Response[v1.PortfolioMember], error) {
+	return c.invitePortfolioMember.CallUnary(ctx, req)
+}
+
+// AcceptPortfolioInvitation calls greedy_eye.v1.PortfolioService.AcceptPortfolioInvitation.
+func (c *portfolioServiceClient) AcceptPortfolioInvitation(ctx context.Context, req *connect.Request[v1.AcceptPortfolioInvitationRequest]) (*connect.Response[v1.PortfolioMember], error) {
+	return c.acceptPortfolioInvitation.CallUnary(ctx, req)
+}
+
+// RevokePortfolioMember calls greedy_eye.v1.PortfolioService.RevokePortfolioMember.
+func (c *portfolioServiceClient) RevokePortfolioMember(ctx context.Context, req *connect.Request[v1.RevokePortfolioMemberRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.revokePortfolioMember.CallUnary(ctx, req)
+}
+
+// ListPortfolioMembers calls greedy_eye.v1.PortfolioService.ListPortfolioMembers.
+func (c *portfolioServiceClient) ListPortfolioMembers(ctx context.Context, req *connect.Request[v1.ListPortfolioMembersRequest]) (*connect.Response[v1.ListPortfolioMembersResponse], error) {
+	return c.listPortfolioMembers.CallUnary(ctx, req)
+}
+
 // CalculatePortfolioValue calls greedy_eye.v1.PortfolioService.CalculatePortfolioValue.
 func (c *portfolioServiceClient) CalculatePortfolioValue(ctx context.Context, req *connect.Request[v1.CalculatePortfolioValueRequest]) (*connect.Response[v1.PortfolioValueResponse], error) {
 	return c.calculatePortfolioValue.CallUnary(ctx, req)
@@ -318,6 +489,11 @@ func (c *portfolioServiceClient) GetPortfolioPerformance(ctx context.Context, re
 	return c.getPortfolioPerformance.CallUnary(ctx, req)
 }
 
+// GetIncomeSummary calls greedy_eye.v1.PortfolioService.GetIncomeSummary.
+func (c *portfolioServiceClient) GetIncomeSummary(ctx context.Context, req *connect.Request[v1.GetIncomeSummaryRequest]) (*connect.Response[v1.IncomeSummary], error) {
+	return c.getIncomeSummary.CallUnary(ctx, req)
+}
+
 // CreateHolding calls greedy_eye.v1.PortfolioService.CreateHolding.
 func (c *portfolioServiceClient) CreateHolding(ctx context.Context, req *connect.Request[v1.CreateHoldingRequest]) (*connect.Response[v1.Holding], error) {
 	return c.createHolding.CallUnary(ctx, req)
@@ -338,6 +514,26 @@ func (c *portfolioServiceClient) ListHoldings(ctx context.Context, req *connect.
 	return c.listHoldings.CallUnary(ctx, req)
 }
 
+// CreateLot calls greedy_eye.v1.PortfolioService.CreateLot.
+func (c *portfolioServiceClient) CreateLot(ctx context.Context, req *connect.Request[v1.CreateLotRequest]) (*connect.Response[v1.Lot], error) {
+	return c.createLot.CallUnary(ctx, req)
+}
+
+// GetLot calls greedy_eye.v1.PortfolioService.GetLot.
+func (c *portfolioServiceClient) GetLot(ctx context.Context, req *connect.Request[v1.GetLotRequest]) (*connect.Response[v1.Lot], error) {
+	return c.getLot.CallUnary(ctx, req)
+}
+
+// UpdateLot calls greedy_eye.v1.PortfolioService.UpdateLot.
+func (c *portfolioServiceClient) UpdateLot(ctx context.Context, req *connect.Request[v1.UpdateLotRequest]) (*connect.Response[v1.Lot], error) {
+	return c.updateLot.CallUnary(ctx, req)
+}
+
+// ListLots calls greedy_eye.v1.PortfolioService.ListLots.
+func (c *portfolioServiceClient) ListLots(ctx context.Context, req *connect.Request[v1.ListLotsRequest]) (*connect.Response[v1.ListLotsResponse], error) {
+	return c.listLots.CallUnary(ctx, req)
+}
+
 // CreateAccount calls greedy_eye.v1.PortfolioService.CreateAccount.
 func (c *portfolioServiceClient) CreateAccount(ctx context.Context, req *connect.Request[v1.CreateAccountRequest]) (*connect.Response[v1.Account], error) {
 	return c.createAccount.CallUnary(ctx, req)
@@ -383,6 +579,21 @@ func (c *portfolioServiceClient) ListTransactions(ctx context.Context, req *conn
 	return c.listTransactions.CallUnary(ctx, req)
 }
 
+// ImportTransactions calls greedy_eye.v1.PortfolioService.ImportTransactions.
+func (c *portfolioServiceClient) ImportTransactions(ctx context.Context, req *connect.Request[v1.ImportTransactionsRequest]) (*connect.Response[v1.ImportTransactionsResponse], error) {
+	return c.importTransactions.CallUnary(ctx, req)
+}
+
+// ExportPortfolio calls greedy_eye.v1.PortfolioService.ExportPortfolio.
+func (c *portfolioServiceClient) ExportPortfolio(ctx context.Context, req *connect.Request[v1.ExportPortfolioRequest]) (*connect.ServerStreamForClient[v1.ExportChunk], error) {
+	return c.exportPortfolio.CallServerStream(ctx, req)
+}
+
+// GenerateTaxReport calls greedy_eye.v1.PortfolioService.GenerateTaxReport.
+func (c *portfolioServiceClient) GenerateTaxReport(ctx context.Context, req *connect.Request[v1.GenerateTaxReportRequest]) (*connect.Response[v1.TaxReport], error) {
+	return c.generateTaxReport.CallUnary(ctx, req)
+}
+
 // PortfolioServiceHandler is an implementation of the greedy_eye.v1.PortfolioService service.
 type PortfolioServiceHandler interface {
 	// --- Portfolio CRUD ---
@@ -391,14 +602,35 @@ type PortfolioServiceHandler interface {
 	UpdatePortfolio(context.Context, *connect.Request[v1.UpdatePortfolioRequest]) (*connect.Response[v1.Portfolio], error)
 	DeletePortfolio(context.Context, *connect.Request[v1.DeletePortfolioRequest]) (*connect.Response[emptypb.Empty], error)
 	ListPortfolios(context.Context, *connect.Request[v1.ListPortfoliosRequest]) (*connect.Response[v1.ListPortfoliosResponse], error)
+	// --- Portfolio sharing ---
+	// InvitePortfolioMember invites a user by email, or changes the role of an
+	// existing member. Requires the owner role.
+	InvitePortfolioMember(context.Context, *connect.Request[v1.InvitePortfolioMemberRequest]) (*connect.Response[v1.PortfolioMember], error)
+	// AcceptPortfolioInvitation activates the caller's pending membership.
+	AcceptPortfolioInvitation(context.Context, *connect.Request[v1.AcceptPortfolioInvitationRequest]) (*connect.Response[v1.PortfolioMember], error)
+	// RevokePortfolioMember removes a member or invitation. Owners may remove
+	// anyone; members may remove themselves to leave or decline.
+	RevokePortfolioMember(context.Context, *connect.Request[v1.RevokePortfolioMemberRequest]) (*connect.Response[emptypb.Empty], error)
+	// ListPortfolioMembers lists the members of a portfolio the caller can
+	// view, or the memberships and invitations of the caller.
+	ListPortfolioMembers(context.Context, *connect.Request[v1.ListPortfolioMembersRequest]) (*connect.Response[v1.ListPortfolioMembersResponse], error)
 	// --- Portfolio business logic ---
 	CalculatePortfolioValue(context.Context, *connect.Request[v1.CalculatePortfolioValueRequest]) (*connect.Response[v1.PortfolioValueResponse], error)
 	GetPortfolioPerformance(context.Context, *connect.Request[v1.GetPortfolioPerformanceRequest]) (*connect.Response[v1.PortfolioPerformanceResponse], error)
+	// GetIncomeSummary totals the income of a portfolio's accounts by asset,
+	// account and month, and projects a year's income and yield from recent
+	// history.
+	GetIncomeSummary(context.Context, *connect.Request[v1.GetIncomeSummaryRequest]) (*connect.Response[v1.IncomeSummary], error)
 	// --- Holding CRUD ---
 	CreateHolding(context.Context, *connect.Request[v1.CreateHoldingRequest]) (*connect.Response[v1.Holding], error)
 	GetHolding(context.Context, *connect.Request[v1.GetHoldingRequest]) (*connect.Response[v1.Holding], error)
 	UpdateHolding(context.Context, *connect.Request[v1.UpdateHoldingRequest]) (*connect.Response[v1.Holding], error)
 	ListHoldings(context.Context, *connect.Request[v1.ListHoldingsRequest]) (*connect.Response[v1.ListHoldingsResponse], error)
+	// --- Lot CRUD ---
+	CreateLot(context.Context, *connect.Request[v1.CreateLotRequest]) (*connect.Response[v1.Lot], error)
+	GetLot(context.Context, *connect.Request[v1.GetLotRequest]) (*connect.Response[v1.Lot], error)
+	UpdateLot(context.Context, *connect.Request[v1.UpdateLotRequest]) (*connect.Response[v1.Lot], error)
+	ListLots(context.Context, *connect.Request[v1.ListLotsRequest]) (*connect.Response[v1.ListLotsResponse], error)
 	// --- Account CRUD ---
 	CreateAccount(context.Context, *connect.Request[v1.CreateAccountRequest]) (*connect.Response[v1.Account], error)
 	GetAccount(context.Context, *connect.Request[v1.GetAccountRequest]) (*connect.Response[v1.Account], error)
@@ -410,6 +642,17 @@ type PortfolioServiceHandler interface {
 	GetTransaction(context.Context, *connect.Request[v1.GetTransactionRequest]) (*connect.Response[v1.Transaction], error)
 	UpdateTransaction(context.Context, *connect.Request[v1.UpdateTransactionRequest]) (*connect.Response[v1.Transaction], error)
 	ListTransactions(context.Context, *connect.Request[v1.ListTransactionsRequest]) (*connect.Response[v1.ListTransactionsResponse], error)
+	// ImportTransactions parses a CSV export into transactions of an account.
+	// A dry run returns the report without recording anything.
+	ImportTransactions(context.Context, *connect.Request[v1.ImportTransactionsRequest]) (*connect.Response[v1.ImportTransactionsResponse], error)
+	// ExportPortfolio streams a file with the portfolio's holdings, valued in
+	// the quote asset, and the transactions in the date range that touch them:
+	// those in a holding's account of its asset, quoted in it or paid on it.
+	// The same file is served as a download at the HTTP path.
+	ExportPortfolio(context.Context, *connect.Request[v1.ExportPortfolioRequest], *connect.ServerStream[v1.ExportChunk]) error
+	// GenerateTaxReport lists a user's disposals and income events in a tax
+	// year, with gains classified by the jurisdiction's holding period.
+	GenerateTaxReport(context.Context, *connect.Request[v1.GenerateTaxReportRequest]) (*connect.Response[v1.TaxReport], error)
 }
 
 // NewPortfolioServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -449,6 +692,30 @@ func NewPortfolioServiceHandler(svc PortfolioServiceHandler, opts ...connect.Han
 		connect.WithSchema(portfolioServiceMethods.ByName("ListPortfolios")),
 		connect.WithHandlerOptions(opts...),
 	)
+	portfolioServiceInvitePortfolioMemberHandler := connect.NewUnaryHandler(
+		PortfolioServiceInvitePortfolioMemberProcedure,
+		svc.InvitePortfolioMember,
+		connect.WithSchema(portfolioServiceMethods.ByName("InvitePortfolioMember")),
+		connect.WithHandlerOptions(opts...),
+	)
+	portfolioServiceAcceptPortfolioInvitationHandler := connect.NewUnaryHandler(
+		PortfolioServiceAcceptPortfolioInvitationProcedure,
+		svc.AcceptPortfolioInvitation,
+		connect.WithSchema(portfolioServiceMethods.ByName("AcceptPortfolioInvitation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	portfolioServiceRevokePortfolioMemberHandler := connect.NewUnaryHandler(
+		PortfolioServiceRevokePortfolioMemberProcedure,
+		svc.RevokePortfolioMember,
+		connect.WithSchema(portfolioServiceMethods.ByName("RevokePortfolioMember")),
+		connect.WithHandlerOptions(opts...),
+	)
+	portfolioServiceListPortfolioMembersHandler := connect.NewUnaryHandler(
+		PortfolioServiceListPortfolioMembersProcedure,
+		svc.ListPortfolioMembers,
+		connect.WithSchema(portfolioServiceMethods.ByName("ListPortfolioMembers")),
+		connect.WithHandlerOptions(opts...),
+	)
 	portfolioServiceCalculatePortfolioValueHandler := connect.NewUnaryHandler(
 		PortfolioServiceCalculatePortfolioValueProcedure,
 		svc.CalculatePortfolioValue,
@@ -461,6 +728,12 @@ func NewPortfolioServiceHandler(svc PortfolioServiceHandler, opts ...connect.Han
 		connect.WithSchema(portfolioServiceMethods.ByName("GetPortfolioPerformance")),
 		connect.WithHandlerOptions(opts...),
 	)
+	portfolioServiceGetIncomeSummaryHandler := connect.NewUnaryHandler(
+		PortfolioServiceGetIncomeSummaryProcedure,
+		svc.GetIncomeSummary,
+		connect.WithSchema(portfolioServiceMethods.ByName("GetIncomeSummary")),
+		connect.WithHandlerOptions(opts...),
+	)
 	portfolioServiceCreateHoldingHandler := connect.NewUnaryHandler(
 		PortfolioServiceCreateHoldingProcedure,
 		svc.CreateHolding,
@@ -485,6 +758,30 @@ func NewPortfolioServiceHandler(svc PortfolioServiceHandler, opts ...connect.Han
 		connect.WithSchema(portfolioServiceMethods.ByName("ListHoldings")),
 		connect.WithHandlerOptions(opts...),
 	)
+	portfolioServiceCreateLotHandler := connect.NewUnaryHandler(
+		PortfolioServiceCreateLotProcedure,
+		svc.CreateLot,
+		connect.WithSchema(portfolioServiceMethods.ByName("CreateLot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	portfolioServiceGetLotHandler := connect.NewUnaryHandler(
+		PortfolioServiceGetLotProcedure,
+		svc.GetLot,
+		connect.WithSchema(portfolioServiceMethods.ByName("GetLot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	portfolioServiceUpdateLotHandler := connect.NewUnaryHandler(
+		PortfolioServiceUpdateLotProcedure,
+		svc.UpdateLot,
+		connect.WithSchema(portfolioServiceMethods.ByName("UpdateLot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	portfolioServiceListLotsHandler := connect.NewUnaryHandler(
+		PortfolioServiceListLotsProcedure,
+		svc.ListLots,
+		connect.WithSchema(portfolioServiceMethods.ByName("ListLots")),
+		connect.WithHandlerOptions(opts...),
+	)
 	portfolioServiceCreateAccountHandler := connect.NewUnaryHandler(
 		PortfolioServiceCreateAccountProcedure,
 		svc.CreateAccount,
@@ -539,6 +836,24 @@ func NewPortfolioServiceHandler(svc PortfolioServiceHandler, opts ...connect.Han
 		connect.WithSchema(portfolioServiceMethods.ByName("ListTransactions")),
 		connect.WithHandlerOptions(opts...),
 	)
+	portfolioServiceImportTransactionsHandler := connect.NewUnaryHandler(
+		PortfolioServiceImportTransactionsProcedure,
+		svc.ImportTransactions,
+		connect.WithSchema(portfolioServiceMethods.ByName("ImportTransactions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	portfolioServiceExportPortfolioHandler := connect.NewServerStreamHandler(
+		PortfolioServiceExportPortfolioProcedure,
+		svc.ExportPortfolio,
+		connect.WithSchema(portfolioServiceMethods.ByName("ExportPortfolio")),
+		connect.WithHandlerOptions(opts...),
+	)
+	portfolioServiceGenerateTaxReportHandler := connect.NewUnaryHandler(
+		PortfolioServiceGenerateTaxReportProcedure,
+		svc.GenerateTaxReport,
+		connect.WithSchema(portfolioServiceMethods.ByName("GenerateTaxReport")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/greedy_eye.v1.PortfolioService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case PortfolioServiceCreatePortfolioProcedure:
@@ -551,10 +866,20 @@ func NewPortfolioServiceHandler(svc PortfolioServiceHandler, opts ...connect.Han
 			portfolioServiceDeletePortfolioHandler.ServeHTTP(w, r)
 		case PortfolioServiceListPortfoliosProcedure:
 			portfolioServiceListPortfoliosHandler.ServeHTTP(w, r)
+		case PortfolioServiceInvitePortfolioMemberProcedure:
+			portfolioServiceInvitePortfolioMemberHandler.ServeHTTP(w, r)
+		case PortfolioServiceAcceptPortfolioInvitationProcedure:
+			portfolioServiceAcceptPortfolioInvitationHandler.ServeHTTP(w, r)
+		case PortfolioServiceRevokePortfolioMemberProcedure:
+			portfolioServiceRevokePortfolioMemberHandler.ServeHTTP(w, r)
+		case PortfolioServiceListPortfolioMembersProcedure:
+			portfolioServiceListPortfolioMembersHandler.ServeHTTP(w, r)
 		case PortfolioServiceCalculatePortfolioValueProcedure:
 			portfolioServiceCalculatePortfolioValueHandler.ServeHTTP(w, r)
 		case PortfolioServiceGetPortfolioPerformanceProcedure:
 			portfolioServiceGetPortfolioPerformanceHandler.ServeHTTP(w, r)
+		case PortfolioServiceGetIncomeSummaryProcedure:
+			portfolioServiceGetIncomeSummaryHandler.ServeHTTP(w, r)
 		case PortfolioServiceCreateHoldingProcedure:
 			portfolioServiceCreateHoldingHandler.ServeHTTP(w, r)
 		case PortfolioServiceGetHoldingProcedure:
@@ -563,6 +888,14 @@ func NewPortfolioServiceHandler(svc PortfolioServiceHandler, opts ...connect.Han
 			portfolioServiceUpdateHoldingHandler.ServeHTTP(w, r)
 		case PortfolioServiceListHoldingsProcedure:
 			portfolioServiceListHoldingsHandler.ServeHTTP(w, r)
+		case PortfolioServiceCreateLotProcedure:
+			portfolioServiceCreateLotHandler.ServeHTTP(w, r)
+		case PortfolioServiceGetLotProcedure:
+			portfolioServiceGetLotHandler.ServeHTTP(w, r)
+		case PortfolioServiceUpdateLotProcedure:
+			portfolioServiceUpdateLotHandler.ServeHTTP(w, r)
+		case PortfolioServiceListLotsProcedure:
+			portfolioServiceListLotsHandler.ServeHTTP(w, r)
 		case PortfolioServiceCreateAccountProcedure:
 			portfolioServiceCreateAccountHandler.ServeHTTP(w, r)
 		case PortfolioServiceGetAccountProcedure:
@@ -581,6 +914,12 @@ func NewPortfolioServiceHandler(svc PortfolioServiceHandler, opts ...connect.Han
 			portfolioServiceUpdateTransactionHandler.ServeHTTP(w, r)
 		case PortfolioServiceListTransactionsProcedure:
 			portfolioServiceListTransactionsHandler.ServeHTTP(w, r)
+		case PortfolioServiceImportTransactionsProcedure:
+			portfolioServiceImportTransactionsHandler.ServeHTTP(w, r)
+		case PortfolioServiceExportPortfolioProcedure:
+			portfolioServiceExportPortfolioHandler.ServeHTTP(w, r)
+		case PortfolioServiceGenerateTaxReportProcedure:
+			portfolioServiceGenerateTaxReportHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -610,6 +949,22 @@ func (UnimplementedPortfolioServiceHandler) ListPortfolios(context.Context, *con
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.ListPortfolios is not implemented"))
 }
 
+func (UnimplementedPortfolioServiceHandler) InvitePortfolioMember(context.Context, *connect.Request[v1.InvitePortfolioMemberRequest]) (*connect.Response[v1.PortfolioMember], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.InvitePortfolioMember is not implemented"))
+}
+
+func (UnimplementedPortfolioServiceHandler) AcceptPortfolioInvitation(context.Context, *connect.Request[v1.AcceptPortfolioInvitationRequest]) (*connect.Response[v1.PortfolioMember], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.AcceptPortfolioInvitation is not implemented"))
+}
+
+func (UnimplementedPortfolioServiceHandler) RevokePortfolioMember(context.Context, *connect.Request[v1.RevokePortfolioMemberRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.RevokePortfolioMember is not implemented"))
+}
+
+func (UnimplementedPortfolioServiceHandler) ListPortfolioMembers(context.Context, *connect.Request[v1.ListPortfolioMembersRequest]) (*connect.Response[v1.ListPortfolioMembersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.ListPortfolioMembers is not implemented"))
+}
+
 func (UnimplementedPortfolioServiceHandler) CalculatePortfolioValue(context.Context, *connect.Request[v1.CalculatePortfolioValueRequest]) (*connect.Response[v1.PortfolioValueResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.CalculatePortfolioValue is not implemented"))
 }
@@ -618,6 +973,10 @@ func (UnimplementedPortfolioServiceHandler) GetPortfolioPerformance(context.Cont
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.GetPortfolioPerformance is not implemented"))
 }
 
+func (UnimplementedPortfolioServiceHandler) GetIncomeSummary(context.Context, *connect.Request[v1.GetIncomeSummaryRequest]) (*connect.Response[v1.IncomeSummary], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.GetIncomeSummary is not implemented"))
+}
+
 func (UnimplementedPortfolioServiceHandler) CreateHolding(context.Context, *connect.Request[v1.CreateHoldingRequest]) (*connect.Response[v1.Holding], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.CreateHolding is not implemented"))
 }
@@ -634,6 +993,22 @@ func (UnimplementedPortfolioServiceHandler) ListHoldings(context.Context, *conne
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.ListHoldings is not implemented"))
 }
 
+func (UnimplementedPortfolioServiceHandler) CreateLot(context.Context, *connect.Request[v1.CreateLotRequest]) (*connect.Response[v1.Lot], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.CreateLot is not implemented"))
+}
+
+func (UnimplementedPortfolioServiceHandler) GetLot(context.Context, *connect.Request[v1.GetLotRequest]) (*connect.Response[v1.Lot], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.GetLot is not implemented"))
+}
+
+func (UnimplementedPortfolioServiceHandler) UpdateLot(context.Context, *connect.Request[v1.UpdateLotRequest]) (*connect.Response[v1.Lot], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.UpdateLot is not implemented"))
+}
+
+func (UnimplementedPortfolioServiceHandler) ListLots(context.Context, *connect.Request[v1.ListLotsRequest]) (*connect.Response[v1.ListLotsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.ListLots is not implemented"))
+}
+
 func (UnimplementedPortfolioServiceHandler) CreateAccount(context.Context, *connect.Request[v1.CreateAccountRequest]) (*connect.Response[v1.Account], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.CreateAccount is not implemented"))
 }
@@ -669,3 +1044,15 @@ func (UnimplementedPortfolioServiceHandler) UpdateTransaction(context.Context, *
 func (UnimplementedPortfolioServiceHandler) ListTransactions(context.Context, *connect.Request[v1.ListTransactionsRequest]) (*connect.Response[v1.ListTransactionsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.ListTransactions is not implemented"))
 }
+
+func (UnimplementedPortfolioServiceHandler) ImportTransactions(context.Context, *connect.Request[v1.ImportTransactionsRequest]) (*connect.Response[v1.ImportTransactionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.ImportTransactions is not implemented"))
+}
+
+func (UnimplementedPortfolioServiceHandler) ExportPortfolio(context.Context, *connect.Request[v1.ExportPortfolioRequest], *connect.ServerStream[v1.ExportChunk]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.ExportPortfolio is not implemented"))
+}
+
+func (UnimplementedPortfolioServiceHandler) GenerateTaxReport(context.Context, *connect.Request[v1.GenerateTaxReportRequest]) (*connect.Response[v1.TaxReport], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.PortfolioService.GenerateTaxReport is not implemented"))
+}
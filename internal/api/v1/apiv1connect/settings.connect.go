@@ -0,0 +1,289 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: v1/settings.proto
+
+package apiv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// SettingsServiceName is the fully-qualified name of the SettingsService service.
+	SettingsServiceName = "greedy_eye.v1.SettingsService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// SettingsServiceCreateUserProcedure is the fully-qualified name of the SettingsService's
+	// CreateUser RPC.
+	SettingsServiceCreateUserProcedure = "/greedy_eye.v1.SettingsService/CreateUser"
+	// SettingsServiceGetUserProcedure is the fully-qualified name of the SettingsService's GetUser RPC.
+	SettingsServiceGetUserProcedure = "/greedy_eye.v1.SettingsService/GetUser"
+	// SettingsServiceGetUserByEmailProcedure is the fully-qualified name of the SettingsService's
+	// GetUserByEmail RPC.
+	SettingsServiceGetUserByEmailProcedure = "/greedy_eye.v1.SettingsService/GetUserByEmail"
+	// SettingsServiceUpdateUserProcedure is the fully-qualified name of the SettingsService's
+	// UpdateUser RPC.
+	SettingsServiceUpdateUserProcedure = "/greedy_eye.v1.SettingsService/UpdateUser"
+	// SettingsServiceDeleteUserProcedure is the fully-qualified name of the SettingsService's
+	// DeleteUser RPC.
+	SettingsServiceDeleteUserProcedure = "/greedy_eye.v1.SettingsService/DeleteUser"
+	// SettingsServiceListUsersProcedure is the fully-qualified name of the SettingsService's ListUsers
+	// RPC.
+	SettingsServiceListUsersProcedure = "/greedy_eye.v1.SettingsService/ListUsers"
+	// SettingsServiceUpdateUserPreferencesProcedure is the fully-qualified name of the
+	// SettingsService's UpdateUserPreferences RPC.
+	SettingsServiceUpdateUserPreferencesProcedure = "/greedy_eye.v1.SettingsService/UpdateUserPreferences"
+)
+
+// SettingsServiceClient is a client for the greedy_eye.v1.SettingsService service.
+type SettingsServiceClient interface {
+	// --- User CRUD ---
+	CreateUser(context.Context, *connect.Request[v1.CreateUserRequest]) (*connect.Response[v1.User], error)
+	GetUser(context.Context, *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.User], error)
+	GetUserByEmail(context.Context, *connect.Request[v1.GetUserByEmailRequest]) (*connect.Response[v1.User], error)
+	UpdateUser(context.Context, *connect.Request[v1.UpdateUserRequest]) (*connect.Response[v1.User], error)
+	DeleteUser(context.Context, *connect.Request[v1.DeleteUserRequest]) (*connect.Response[emptypb.Empty], error)
+	ListUsers(context.Context, *connect.Request[v1.ListUsersRequest]) (*connect.Response[v1.ListUsersResponse], error)
+	// UpdateUserPreferences validates and merges preferences into the user's
+	// existing ones. Without an update mask only the fields set are changed.
+	UpdateUserPreferences(context.Context, *connect.Request[v1.UpdateUserPreferencesRequest]) (*connect.Response[v1.User], error)
+}
+
+// NewSettingsServiceClient constructs a client for the greedy_eye.v1.SettingsService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewSettingsServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) SettingsServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	settingsServiceMethods := v1.File_v1_settings_proto.Services().ByName("SettingsService").Methods()
+	return &settingsServiceClient{
+		createUser: connect.NewClient[v1.CreateUserRequest, v1.User](
+			httpClient,
+			baseURL+SettingsServiceCreateUserProcedure,
+			connect.WithSchema(settingsServiceMethods.ByName("CreateUser")),
+			connect.WithClientOptions(opts...),
+		),
+		getUser: connect.NewClient[v1.GetUserRequest, v1.User](
+			httpClient,
+			baseURL+SettingsServiceGetUserProcedure,
+			connect.WithSchema(settingsServiceMethods.ByName("GetUser")),
+			connect.WithClientOptions(opts...),
+		),
+		getUserByEmail: connect.NewClient[v1.GetUserByEmailRequest, v1.User](
+			httpClient,
+			baseURL+SettingsServiceGetUserByEmailProcedure,
+			connect.WithSchema(settingsServiceMethods.ByName("GetUserByEmail")),
+			connect.WithClientOptions(opts...),
+		),
+		updateUser: connect.NewClient[v1.UpdateUserRequest, v1.User](
+			httpClient,
+			baseURL+SettingsServiceUpdateUserProcedure,
+			connect.WithSchema(settingsServiceMethods.ByName("UpdateUser")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteUser: connect.NewClient[v1.DeleteUserRequest, emptypb.Empty](
+			httpClient,
+			baseURL+SettingsServiceDeleteUserProcedure,
+			connect.WithSchema(settingsServiceMethods.ByName("DeleteUser")),
+			connect.WithClientOptions(opts...),
+		),
+		listUsers: connect.NewClient[v1.ListUsersRequest, v1.ListUsersResponse](
+			httpClient,
+			baseURL+SettingsServiceListUsersProcedure,
+			connect.WithSchema(settingsServiceMethods.ByName("ListUsers")),
+			connect.WithClientOptions(opts...),
+		),
+		updateUserPreferences: connect.NewClient[v1.UpdateUserPreferencesRequest, v1.User](
+			httpClient,
+			baseURL+SettingsServiceUpdateUserPreferencesProcedure,
+			connect.WithSchema(settingsServiceMethods.ByName("UpdateUserPreferences")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// settingsServiceClient implements SettingsServiceClient.
+type settingsServiceClient struct {
+	createUser            *connect.Client[v1.CreateUserRequest, v1.User]
+	getUser               *connect.Client[v1.GetUserRequest, v1.User]
+	getUserByEmail        *connect.Client[v1.GetUserByEmailRequest, v1.User]
+	updateUser            *connect.Client[v1.UpdateUserRequest, v1.User]
+	deleteUser            *connect.Client[v1.DeleteUserRequest, emptypb.Empty]
+	listUsers             *connect.Client[v1.ListUsersRequest, v1.ListUsersResponse]
+	updateUserPreferences *connect.Client[v1.UpdateUserPreferencesRequest, v1.User]
+}
+
+// CreateUser calls greedy_eye.v1.SettingsService.CreateUser.
+func (c *settingsServiceClient) CreateUser(ctx context.Context, req *connect.Request[v1.CreateUserRequest]) (*connect.Response[v1.User], error) {
+	return c.createUser.CallUnary(ctx, req)
+}
+
+// GetUser calls greedy_eye.v1.SettingsService.GetUser.
+func (c *settingsServiceClient) GetUser(ctx context.Context, req *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.User], error) {
+	return c.getUser.CallUnary(ctx, req)
+}
+
+// GetUserByEmail calls greedy_eye.v1.SettingsService.GetUserByEmail.
+func (c *settingsServiceClient) GetUserByEmail(ctx context.Context, req *connect.Request[v1.GetUserByEmailRequest]) (*connect.Response[v1.User], error) {
+	return c.getUserByEmail.CallUnary(ctx, req)
+}
+
+// UpdateUser calls greedy_eye.v1.SettingsService.UpdateUser.
+func (c *settingsServiceClient) UpdateUser(ctx context.Context, req *connect.Request[v1.UpdateUserRequest]) (*connect.Response[v1.User], error) {
+	return c.updateUser.CallUnary(ctx, req)
+}
+
+// DeleteUser calls greedy_eye.v1.SettingsService.DeleteUser.
+func (c *settingsServiceClient) DeleteUser(ctx context.Context, req *connect.Request[v1.DeleteUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteUser.CallUnary(ctx, req)
+}
+
+// ListUsers calls greedy_eye.v1.SettingsService.ListUsers.
+func (c *settingsServiceClient) ListUsers(ctx context.Context, req *connect.Request[v1.ListUsersRequest]) (*connect.Response[v1.ListUsersResponse], error) {
+	return c.listUsers.CallUnary(ctx, req)
+}
+
+// UpdateUserPreferences calls greedy_eye.v1.SettingsService.UpdateUserPreferences.
+func (c *settingsServiceClient) UpdateUserPreferences(ctx context.Context, req *connect.Request[v1.UpdateUserPreferencesRequest]) (*connect.Response[v1.User], error) {
+	return c.updateUserPreferences.CallUnary(ctx, req)
+}
+
+// SettingsServiceHandler is an implementation of the greedy_eye.v1.SettingsService service.
+type SettingsServiceHandler interface {
+	// --- User CRUD ---
+	CreateUser(context.Context, *connect.Request[v1.CreateUserRequest]) (*connect.Response[v1.User], error)
+	GetUser(context.Context, *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.User], error)
+	GetUserByEmail(context.Context, *connect.Request[v1.GetUserByEmailRequest]) (*connect.Response[v1.User], error)
+	UpdateUser(context.Context, *connect.Request[v1.UpdateUserRequest]) (*connect.Response[v1.User], error)
+	DeleteUser(context.Context, *connect.Request[v1.DeleteUserRequest]) (*connect.Response[emptypb.Empty], error)
+	ListUsers(context.Context, *connect.Request[v1.ListUsersRequest]) (*connect.Response[v1.ListUsersResponse], error)
+	// UpdateUserPreferences validates and merges preferences into the user's
+	// existing ones. Without an update mask only the fields set are changed.
+	UpdateUserPreferences(context.Context, *connect.Request[v1.UpdateUserPreferencesRequest]) (*connect.Response[v1.User], error)
+}
+
+// NewSettingsServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewSettingsServiceHandler(svc SettingsServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	settingsServiceMethods := v1.File_v1_settings_proto.Services().ByName("SettingsService").Methods()
+	settingsServiceCreateUserHandler := connect.NewUnaryHandler(
+		SettingsServiceCreateUserProcedure,
+		svc.CreateUser,
+		connect.WithSchema(settingsServiceMethods.ByName("CreateUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	settingsServiceGetUserHandler := connect.NewUnaryHandler(
+		SettingsServiceGetUserProcedure,
+		svc.GetUser,
+		connect.WithSchema(settingsServiceMethods.ByName("GetUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	settingsServiceGetUserByEmailHandler := connect.NewUnaryHandler(
+		SettingsServiceGetUserByEmailProcedure,
+		svc.GetUserByEmail,
+		connect.WithSchema(settingsServiceMethods.ByName("GetUserByEmail")),
+		connect.WithHandlerOptions(opts...),
+	)
+	settingsServiceUpdateUserHandler := connect.NewUnaryHandler(
+		SettingsServiceUpdateUserProcedure,
+		svc.UpdateUser,
+		connect.WithSchema(settingsServiceMethods.ByName("UpdateUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	settingsServiceDeleteUserHandler := connect.NewUnaryHandler(
+		SettingsServiceDeleteUserProcedure,
+		svc.DeleteUser,
+		connect.WithSchema(settingsServiceMethods.ByName("DeleteUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	settingsServiceListUsersHandler := connect.NewUnaryHandler(
+		SettingsServiceListUsersProcedure,
+		svc.ListUsers,
+		connect.WithSchema(settingsServiceMethods.ByName("ListUsers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	settingsServiceUpdateUserPreferencesHandler := connect.NewUnaryHandler(
+		SettingsServiceUpdateUserPreferencesProcedure,
+		svc.UpdateUserPreferences,
+		connect.WithSchema(settingsServiceMethods.ByName("UpdateUserPreferences")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/greedy_eye.v1.SettingsService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SettingsServiceCreateUserProcedure:
+			settingsServiceCreateUserHandler.ServeHTTP(w, r)
+		case SettingsServiceGetUserProcedure:
+			settingsServiceGetUserHandler.ServeHTTP(w, r)
+		case SettingsServiceGetUserByEmailProcedure:
+			settingsServiceGetUserByEmailHandler.ServeHTTP(w, r)
+		case SettingsServiceUpdateUserProcedure:
+			settingsServiceUpdateUserHandler.ServeHTTP(w, r)
+		case SettingsServiceDeleteUserProcedure:
+			settingsServiceDeleteUserHandler.ServeHTTP(w, r)
+		case SettingsServiceListUsersProcedure:
+			settingsServiceListUsersHandler.ServeHTTP(w, r)
+		case SettingsServiceUpdateUserPreferencesProcedure:
+			settingsServiceUpdateUserPreferencesHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedSettingsServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedSettingsServiceHandler struct{}
+
+func (UnimplementedSettingsServiceHandler) CreateUser(context.Context, *connect.Request[v1.CreateUserRequest]) (*connect.Response[v1.User], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.SettingsService.CreateUser is not implemented"))
+}
+
+func (UnimplementedSettingsServiceHandler) GetUser(context.Context, *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.User], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.SettingsService.GetUser is not implemented"))
+}
+
+func (UnimplementedSettingsServiceHandler) GetUserByEmail(context.Context, *connect.Request[v1.GetUserByEmailRequest]) (*connect.Response[v1.User], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.SettingsService.GetUserByEmail is not implemented"))
+}
+
+func (UnimplementedSettingsServiceHandler) UpdateUser(context.Context, *connect.Request[v1.UpdateUserRequest]) (*connect.Response[v1.User], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.SettingsService.UpdateUser is not implemented"))
+}
+
+func (UnimplementedSettingsServiceHandler) DeleteUser(context.Context, *connect.Request[v1.DeleteUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.SettingsService.DeleteUser is not implemented"))
+}
+
+func (UnimplementedSettingsServiceHandler) ListUsers(context.Context, *connect.Request[v1.ListUsersRequest]) (*connect.Response[v1.ListUsersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.SettingsService.ListUsers is not implemented"))
+}
+
+func (UnimplementedSettingsServiceHandler) UpdateUserPreferences(context.Context, *connect.Request[v1.UpdateUserPreferencesRequest]) (*connect.Response[v1.User], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greedy_eye.v1.SettingsService.UpdateUserPreferences is not implemented"))
+}
@@ -0,0 +1,445 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: v1/audit.proto
+
+package apiv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AuditEvent records one change to one resource, or a mutating call that
+// changed no stored resource. Events are append-only.
+type AuditEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// "user" for API callers, "system" for background processes such as
+	// "automation".
+	ActorType string `protobuf:"bytes,3,opt,name=actor_type,json=actorType,proto3" json:"actor_type,omitempty"`
+	// User ID, or the name of the background process.
+	ActorId string `protobuf:"bytes,4,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	// API key the caller authenticated with.
+	CredentialId string `protobuf:"bytes,5,opt,name=credential_id,json=credentialId,proto3" json:"credential_id,omitempty"`
+	// User whose data changed, when known.
+	OwnerId string `protobuf:"bytes,6,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	// Connect procedure, e.g. "/greedy_eye.v1.PortfolioService/UpdateAccount".
+	Procedure    string `protobuf:"bytes,7,opt,name=procedure,proto3" json:"procedure,omitempty"`
+	ResourceType string `protobuf:"bytes,8,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceId   string `protobuf:"bytes,9,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	// Field mask paths of update calls.
+	FieldPaths []string `protobuf:"bytes,10,rep,name=field_paths,json=fieldPaths,proto3" json:"field_paths,omitempty"`
+	// Changed fields, each as {"before": ..., "after": ...}. Secrets are
+	// replaced with "********".
+	Changes *structpb.Struct `protobuf:"bytes,11,opt,name=changes,proto3" json:"changes,omitempty"`
+	// Request ID of the call, or the ID of the task a background process ran.
+	RequestId     string `protobuf:"bytes,12,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	ClientIp      string `protobuf:"bytes,13,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_v1_audit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_audit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_v1_audit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetActorType() string {
+	if x != nil {
+		return x.ActorType
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCredentialId() string {
+	if x != nil {
+		return x.CredentialId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetProcedure() string {
+	if x != nil {
+		return x.Procedure
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetFieldPaths() []string {
+	if x != nil {
+		return x.FieldPaths
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetChanges() *structpb.Struct {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+type ListAuditEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       *string                `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3,oneof" json:"actor_id,omitempty"`
+	ResourceType  *string                `protobuf:"bytes,2,opt,name=resource_type,json=resourceType,proto3,oneof" json:"resource_type,omitempty"`
+	ResourceId    *string                `protobuf:"bytes,3,opt,name=resource_id,json=resourceId,proto3,oneof" json:"resource_id,omitempty"`
+	Procedure     *string                `protobuf:"bytes,4,opt,name=procedure,proto3,oneof" json:"procedure,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=from,proto3,oneof" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=to,proto3,oneof" json:"to,omitempty"`
+	PageSize      *int32                 `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	PageToken     *string                `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_v1_audit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_audit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_audit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListAuditEventsRequest) GetActorId() string {
+	if x != nil && x.ActorId != nil {
+		return *x.ActorId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetResourceType() string {
+	if x != nil && x.ResourceType != nil {
+		return *x.ResourceType
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetResourceId() string {
+	if x != nil && x.ResourceId != nil {
+		return *x.ResourceId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetProcedure() string {
+	if x != nil && x.Procedure != nil {
+		return *x.Procedure
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	AuditEvents   []*AuditEvent `protobuf:"bytes,1,rep,name=audit_events,json=auditEvents,proto3" json:"audit_events,omitempty"`
+	NextPageToken string        `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_v1_audit_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_audit_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_audit_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListAuditEventsResponse) GetAuditEvents() []*AuditEvent {
+	if x != nil {
+		return x.AuditEvents
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_v1_audit_proto protoreflect.FileDescriptor
+
+const file_v1_audit_proto_rawDesc = "" +
+	"\n" +
+	"\x0ev1/audit.proto\x12\rgreedy_eye.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\"\xc5\x03\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"actor_type\x18\x03 \x01(\tR\tactorType\x12\x19\n" +
+	"\bactor_id\x18\x04 \x01(\tR\aactorId\x12#\n" +
+	"\rcredential_id\x18\x05 \x01(\tR\fcredentialId\x12\x19\n" +
+	"\bowner_id\x18\x06 \x01(\tR\aownerId\x12\x1c\n" +
+	"\tprocedure\x18\a \x01(\tR\tprocedure\x12#\n" +
+	"\rresource_type\x18\b \x01(\tR\fresourceType\x12\x1f\n" +
+	"\vresource_id\x18\t \x01(\tR\n" +
+	"resourceId\x12\x1f\n" +
+	"\vfield_paths\x18\n" +
+	" \x03(\tR\n" +
+	"fieldPaths\x121\n" +
+	"\achanges\x18\v \x01(\v2\x17.google.protobuf.StructR\achanges\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\f \x01(\tR\trequestId\x12\x1b\n" +
+	"\tclient_ip\x18\r \x01(\tR\bclientIp\"\xc1\x03\n" +
+	"\x16ListAuditEventsRequest\x12\x1e\n" +
+	"\bactor_id\x18\x01 \x01(\tH\x00R\aactorId\x88\x01\x01\x12(\n" +
+	"\rresource_type\x18\x02 \x01(\tH\x01R\fresourceType\x88\x01\x01\x12$\n" +
+	"\vresource_id\x18\x03 \x01(\tH\x02R\n" +
+	"resourceId\x88\x01\x01\x12!\n" +
+	"\tprocedure\x18\x04 \x01(\tH\x03R\tprocedure\x88\x01\x01\x123\n" +
+	"\x04from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x04R\x04from\x88\x01\x01\x12/\n" +
+	"\x02to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x05R\x02to\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\a \x01(\x05H\x06R\bpageSize\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tH\aR\tpageToken\x88\x01\x01B\v\n" +
+	"\t_actor_idB\x10\n" +
+	"\x0e_resource_typeB\x0e\n" +
+	"\f_resource_idB\f\n" +
+	"\n" +
+	"_procedureB\a\n" +
+	"\x05_fromB\x05\n" +
+	"\x03_toB\f\n" +
+	"\n" +
+	"_page_sizeB\r\n" +
+	"\v_page_token\"\x7f\n" +
+	"\x17ListAuditEventsResponse\x12<\n" +
+	"\faudit_events\x18\x01 \x03(\v2\x19.greedy_eye.v1.AuditEventR\vauditEvents\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken2\x8e\x01\n" +
+	"\fAuditService\x12~\n" +
+	"\x0fListAuditEvents\x12%.greedy_eye.v1.ListAuditEventsRequest\x1a&.greedy_eye.v1.ListAuditEventsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/audit-eventsB\xa5\x01\n" +
+	"\x11com.greedy_eye.v1B\n" +
+	"AuditProtoP\x01Z3github.com/foxcool/greedy-eye/internal/api/v1;apiv1\xa2\x02\x03GXX\xaa\x02\fGreedyEye.V1\xca\x02\fGreedyEye\\V1\xe2\x02\x18GreedyEye\\V1\\GPBMetadata\xea\x02\rGreedyEye::V1b\x06proto3"
+
+var (
+	file_v1_audit_proto_rawDescOnce sync.Once
+	file_v1_audit_proto_rawDescData []byte
+)
+
+func file_v1_audit_proto_rawDescGZIP() []byte {
+	file_v1_audit_proto_rawDescOnce.Do(func() {
+		file_v1_audit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_v1_audit_proto_rawDesc), len(file_v1_audit_proto_rawDesc)))
+	})
+	return file_v1_audit_proto_rawDescData
+}
+
+var file_v1_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_v1_audit_proto_goTypes = []any{
+	(*AuditEvent)(nil),              // 0: greedy_eye.v1.AuditEvent
+	(*ListAuditEventsRequest)(nil),  // 1: greedy_eye.v1.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil), // 2: greedy_eye.v1.ListAuditEventsResponse
+	(*timestamppb.Timestamp)(nil),   // 3: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),         // 4: google.protobuf.Struct
+}
+var file_v1_audit_proto_depIdxs = []int32{
+	3, // 0: greedy_eye.v1.AuditEvent.created_at:type_name -> google.protobuf.Timestamp
+	4, // 1: greedy_eye.v1.AuditEvent.changes:type_name -> google.protobuf.Struct
+	3, // 2: greedy_eye.v1.ListAuditEventsRequest.from:type_name -> google.protobuf.Timestamp
+	3, // 3: greedy_eye.v1.ListAuditEventsRequest.to:type_name -> google.protobuf.Timestamp
+	0, // 4: greedy_eye.v1.ListAuditEventsResponse.audit_events:type_name -> greedy_eye.v1.AuditEvent
+	1, // 5: greedy_eye.v1.AuditService.ListAuditEvents:input_type -> greedy_eye.v1.ListAuditEventsRequest
+	2, // 6: greedy_eye.v1.AuditService.ListAuditEvents:output_type -> greedy_eye.v1.ListAuditEventsResponse
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_v1_audit_proto_init() }
+func file_v1_audit_proto_init() {
+	if File_v1_audit_proto != nil {
+		return
+	}
+	file_v1_audit_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_audit_proto_rawDesc), len(file_v1_audit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_v1_audit_proto_goTypes,
+		DependencyIndexes: file_v1_audit_proto_depIdxs,
+		MessageInfos:      file_v1_audit_proto_msgTypes,
+	}.Build()
+	File_v1_audit_proto = out.File
+	file_v1_audit_proto_goTypes = nil
+	file_v1_audit_proto_depIdxs = nil
+}
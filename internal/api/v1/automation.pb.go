@@ -348,8 +348,14 @@ type RuleExecution struct {
 	AffectedHoldingIds    []string               `protobuf:"bytes,10,rep,name=affected_holding_ids,json=affectedHoldingIds,proto3" json:"affected_holding_ids,omitempty"`
 	TransactionsCreated   int32                  `protobuf:"varint,11,opt,name=transactions_created,json=transactionsCreated,proto3" json:"transactions_created,omitempty"`
 	ExecutionSummary      *structpb.Struct       `protobuf:"bytes,12,opt,name=execution_summary,json=executionSummary,proto3" json:"execution_summary,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	DryRun                bool                   `protobuf:"varint,13,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	ExecutionContext      *string                `protobuf:"bytes,14,opt,name=execution_context,json=executionContext,proto3,oneof" json:"execution_context,omitempty"`
+	CancelRequested       bool                   `protobuf:"varint,15,opt,name=cancel_requested,json=cancelRequested,proto3" json:"cancel_requested,omitempty"`
+	// User who requested the execution, such as a member of a shared
+	// portfolio. Unset for executions the system created.
+	CreatedBy     *string `protobuf:"bytes,16,opt,name=created_by,json=createdBy,proto3,oneof" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RuleExecution) Reset() {
@@ -466,6 +472,34 @@ func (x *RuleExecution) GetExecutionSummary() *structpb.Struct {
 	return nil
 }
 
+func (x *RuleExecution) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *RuleExecution) GetExecutionContext() string {
+	if x != nil && x.ExecutionContext != nil {
+		return *x.ExecutionContext
+	}
+	return ""
+}
+
+func (x *RuleExecution) GetCancelRequested() bool {
+	if x != nil {
+		return x.CancelRequested
+	}
+	return false
+}
+
+func (x *RuleExecution) GetCreatedBy() string {
+	if x != nil && x.CreatedBy != nil {
+		return *x.CreatedBy
+	}
+	return ""
+}
+
 type CreateRuleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Rule          *Rule                  `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
@@ -1158,6 +1192,157 @@ func (x *ValidateRuleResponse) GetWarnings() []string {
 	return nil
 }
 
+// RuleType describes a supported rule_type and the JSON Schema of its configuration.
+type RuleType struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	RuleType            string                 `protobuf:"bytes,1,opt,name=rule_type,json=ruleType,proto3" json:"rule_type,omitempty"`
+	Description         string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	ConfigurationSchema *structpb.Struct       `protobuf:"bytes,3,opt,name=configuration_schema,json=configurationSchema,proto3" json:"configuration_schema,omitempty"`
+	// Whether rules of the type can be executed and simulated. Rules of other
+	// types can only be backtested, and cannot be active.
+	Executable    bool `protobuf:"varint,4,opt,name=executable,proto3" json:"executable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleType) Reset() {
+	*x = RuleType{}
+	mi := &file_v1_automation_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleType) ProtoMessage() {}
+
+func (x *RuleType) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_automation_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleType.ProtoReflect.Descriptor instead.
+func (*RuleType) Descriptor() ([]byte, []int) {
+	return file_v1_automation_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RuleType) GetRuleType() string {
+	if x != nil {
+		return x.RuleType
+	}
+	return ""
+}
+
+func (x *RuleType) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RuleType) GetConfigurationSchema() *structpb.Struct {
+	if x != nil {
+		return x.ConfigurationSchema
+	}
+	return nil
+}
+
+func (x *RuleType) GetExecutable() bool {
+	if x != nil {
+		return x.Executable
+	}
+	return false
+}
+
+type ListRuleTypesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRuleTypesRequest) Reset() {
+	*x = ListRuleTypesRequest{}
+	mi := &file_v1_automation_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRuleTypesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRuleTypesRequest) ProtoMessage() {}
+
+func (x *ListRuleTypesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_automation_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRuleTypesRequest.ProtoReflect.Descriptor instead.
+func (*ListRuleTypesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_automation_proto_rawDescGZIP(), []int{17}
+}
+
+type ListRuleTypesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleTypes     []*RuleType            `protobuf:"bytes,1,rep,name=rule_types,json=ruleTypes,proto3" json:"rule_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRuleTypesResponse) Reset() {
+	*x = ListRuleTypesResponse{}
+	mi := &file_v1_automation_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRuleTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRuleTypesResponse) ProtoMessage() {}
+
+func (x *ListRuleTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_automation_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRuleTypesResponse.ProtoReflect.Descriptor instead.
+func (*ListRuleTypesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_automation_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListRuleTypesResponse) GetRuleTypes() []*RuleType {
+	if x != nil {
+		return x.RuleTypes
+	}
+	return nil
+}
+
 type SimulateRuleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
@@ -1169,7 +1354,7 @@ type SimulateRuleRequest struct {
 
 func (x *SimulateRuleRequest) Reset() {
 	*x = SimulateRuleRequest{}
-	mi := &file_v1_automation_proto_msgTypes[16]
+	mi := &file_v1_automation_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1181,7 +1366,7 @@ func (x *SimulateRuleRequest) String() string {
 func (*SimulateRuleRequest) ProtoMessage() {}
 
 func (x *SimulateRuleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[16]
+	mi := &file_v1_automation_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1194,7 +1379,7 @@ func (x *SimulateRuleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SimulateRuleRequest.ProtoReflect.Descriptor instead.
 func (*SimulateRuleRequest) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{16}
+	return file_v1_automation_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *SimulateRuleRequest) GetRuleId() string {
@@ -1230,7 +1415,7 @@ type SimulateRuleResponse struct {
 
 func (x *SimulateRuleResponse) Reset() {
 	*x = SimulateRuleResponse{}
-	mi := &file_v1_automation_proto_msgTypes[17]
+	mi := &file_v1_automation_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1242,7 +1427,7 @@ func (x *SimulateRuleResponse) String() string {
 func (*SimulateRuleResponse) ProtoMessage() {}
 
 func (x *SimulateRuleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[17]
+	mi := &file_v1_automation_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1255,7 +1440,7 @@ func (x *SimulateRuleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SimulateRuleResponse.ProtoReflect.Descriptor instead.
 func (*SimulateRuleResponse) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{17}
+	return file_v1_automation_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *SimulateRuleResponse) GetSuccess() bool {
@@ -1304,7 +1489,7 @@ type SimulationResult struct {
 
 func (x *SimulationResult) Reset() {
 	*x = SimulationResult{}
-	mi := &file_v1_automation_proto_msgTypes[18]
+	mi := &file_v1_automation_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1316,7 +1501,7 @@ func (x *SimulationResult) String() string {
 func (*SimulationResult) ProtoMessage() {}
 
 func (x *SimulationResult) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[18]
+	mi := &file_v1_automation_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1329,7 +1514,7 @@ func (x *SimulationResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SimulationResult.ProtoReflect.Descriptor instead.
 func (*SimulationResult) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{18}
+	return file_v1_automation_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *SimulationResult) GetEstimatedCost() float64 {
@@ -1437,7 +1622,7 @@ type RebalancingSimulation struct {
 
 func (x *RebalancingSimulation) Reset() {
 	*x = RebalancingSimulation{}
-	mi := &file_v1_automation_proto_msgTypes[19]
+	mi := &file_v1_automation_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1449,7 +1634,7 @@ func (x *RebalancingSimulation) String() string {
 func (*RebalancingSimulation) ProtoMessage() {}
 
 func (x *RebalancingSimulation) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[19]
+	mi := &file_v1_automation_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1462,7 +1647,7 @@ func (x *RebalancingSimulation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RebalancingSimulation.ProtoReflect.Descriptor instead.
 func (*RebalancingSimulation) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{19}
+	return file_v1_automation_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *RebalancingSimulation) GetCurrentTotalValue() float64 {
@@ -1513,7 +1698,7 @@ type AssetAllocation struct {
 
 func (x *AssetAllocation) Reset() {
 	*x = AssetAllocation{}
-	mi := &file_v1_automation_proto_msgTypes[20]
+	mi := &file_v1_automation_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1525,7 +1710,7 @@ func (x *AssetAllocation) String() string {
 func (*AssetAllocation) ProtoMessage() {}
 
 func (x *AssetAllocation) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[20]
+	mi := &file_v1_automation_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1538,7 +1723,7 @@ func (x *AssetAllocation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssetAllocation.ProtoReflect.Descriptor instead.
 func (*AssetAllocation) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{20}
+	return file_v1_automation_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *AssetAllocation) GetAssetId() string {
@@ -1589,7 +1774,7 @@ type PlannedTrade struct {
 
 func (x *PlannedTrade) Reset() {
 	*x = PlannedTrade{}
-	mi := &file_v1_automation_proto_msgTypes[21]
+	mi := &file_v1_automation_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1601,7 +1786,7 @@ func (x *PlannedTrade) String() string {
 func (*PlannedTrade) ProtoMessage() {}
 
 func (x *PlannedTrade) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[21]
+	mi := &file_v1_automation_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1614,7 +1799,7 @@ func (x *PlannedTrade) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlannedTrade.ProtoReflect.Descriptor instead.
 func (*PlannedTrade) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{21}
+	return file_v1_automation_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *PlannedTrade) GetAssetId() string {
@@ -1664,7 +1849,7 @@ type WithdrawalSimulation struct {
 
 func (x *WithdrawalSimulation) Reset() {
 	*x = WithdrawalSimulation{}
-	mi := &file_v1_automation_proto_msgTypes[22]
+	mi := &file_v1_automation_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1676,7 +1861,7 @@ func (x *WithdrawalSimulation) String() string {
 func (*WithdrawalSimulation) ProtoMessage() {}
 
 func (x *WithdrawalSimulation) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[22]
+	mi := &file_v1_automation_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1689,7 +1874,7 @@ func (x *WithdrawalSimulation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WithdrawalSimulation.ProtoReflect.Descriptor instead.
 func (*WithdrawalSimulation) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{22}
+	return file_v1_automation_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *WithdrawalSimulation) GetAvailableBalance() float64 {
@@ -1726,13 +1911,17 @@ type AssetWithdrawalPlan struct {
 	AmountToWithdraw float64                `protobuf:"fixed64,2,opt,name=amount_to_withdraw,json=amountToWithdraw,proto3" json:"amount_to_withdraw,omitempty"`
 	EstimatedValue   float64                `protobuf:"fixed64,3,opt,name=estimated_value,json=estimatedValue,proto3" json:"estimated_value,omitempty"`
 	NeedsSelling     bool                   `protobuf:"varint,4,opt,name=needs_selling,json=needsSelling,proto3" json:"needs_selling,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	HoldingId        string                 `protobuf:"bytes,5,opt,name=holding_id,json=holdingId,proto3" json:"holding_id,omitempty"`
+	LotId            *string                `protobuf:"bytes,6,opt,name=lot_id,json=lotId,proto3,oneof" json:"lot_id,omitempty"`
+	// Unrealized gain (negative for loss) realized by this step, in the withdrawal currency.
+	EstimatedGain *float64 `protobuf:"fixed64,7,opt,name=estimated_gain,json=estimatedGain,proto3,oneof" json:"estimated_gain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AssetWithdrawalPlan) Reset() {
 	*x = AssetWithdrawalPlan{}
-	mi := &file_v1_automation_proto_msgTypes[23]
+	mi := &file_v1_automation_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1744,7 +1933,7 @@ func (x *AssetWithdrawalPlan) String() string {
 func (*AssetWithdrawalPlan) ProtoMessage() {}
 
 func (x *AssetWithdrawalPlan) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[23]
+	mi := &file_v1_automation_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1757,7 +1946,7 @@ func (x *AssetWithdrawalPlan) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssetWithdrawalPlan.ProtoReflect.Descriptor instead.
 func (*AssetWithdrawalPlan) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{23}
+	return file_v1_automation_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *AssetWithdrawalPlan) GetAssetId() string {
@@ -1788,6 +1977,27 @@ func (x *AssetWithdrawalPlan) GetNeedsSelling() bool {
 	return false
 }
 
+func (x *AssetWithdrawalPlan) GetHoldingId() string {
+	if x != nil {
+		return x.HoldingId
+	}
+	return ""
+}
+
+func (x *AssetWithdrawalPlan) GetLotId() string {
+	if x != nil && x.LotId != nil {
+		return *x.LotId
+	}
+	return ""
+}
+
+func (x *AssetWithdrawalPlan) GetEstimatedGain() float64 {
+	if x != nil && x.EstimatedGain != nil {
+		return *x.EstimatedGain
+	}
+	return 0
+}
+
 type StopLossSimulation struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	CurrentPortfolioValue float64                `protobuf:"fixed64,1,opt,name=current_portfolio_value,json=currentPortfolioValue,proto3" json:"current_portfolio_value,omitempty"`
@@ -1801,7 +2011,7 @@ type StopLossSimulation struct {
 
 func (x *StopLossSimulation) Reset() {
 	*x = StopLossSimulation{}
-	mi := &file_v1_automation_proto_msgTypes[24]
+	mi := &file_v1_automation_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1813,7 +2023,7 @@ func (x *StopLossSimulation) String() string {
 func (*StopLossSimulation) ProtoMessage() {}
 
 func (x *StopLossSimulation) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[24]
+	mi := &file_v1_automation_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1826,7 +2036,7 @@ func (x *StopLossSimulation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopLossSimulation.ProtoReflect.Descriptor instead.
 func (*StopLossSimulation) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{24}
+	return file_v1_automation_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *StopLossSimulation) GetCurrentPortfolioValue() float64 {
@@ -1876,7 +2086,7 @@ type AssetStopLoss struct {
 
 func (x *AssetStopLoss) Reset() {
 	*x = AssetStopLoss{}
-	mi := &file_v1_automation_proto_msgTypes[25]
+	mi := &file_v1_automation_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1888,7 +2098,7 @@ func (x *AssetStopLoss) String() string {
 func (*AssetStopLoss) ProtoMessage() {}
 
 func (x *AssetStopLoss) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[25]
+	mi := &file_v1_automation_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1901,7 +2111,7 @@ func (x *AssetStopLoss) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssetStopLoss.ProtoReflect.Descriptor instead.
 func (*AssetStopLoss) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{25}
+	return file_v1_automation_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *AssetStopLoss) GetAssetId() string {
@@ -1947,7 +2157,7 @@ type DCASimulation struct {
 
 func (x *DCASimulation) Reset() {
 	*x = DCASimulation{}
-	mi := &file_v1_automation_proto_msgTypes[26]
+	mi := &file_v1_automation_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1959,7 +2169,7 @@ func (x *DCASimulation) String() string {
 func (*DCASimulation) ProtoMessage() {}
 
 func (x *DCASimulation) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[26]
+	mi := &file_v1_automation_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1972,7 +2182,7 @@ func (x *DCASimulation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DCASimulation.ProtoReflect.Descriptor instead.
 func (*DCASimulation) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{26}
+	return file_v1_automation_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *DCASimulation) GetAvailableBalance() float64 {
@@ -2024,28 +2234,38 @@ func (x *DCASimulation) GetSufficientFunds() bool {
 	return false
 }
 
-type EnableRuleRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+type BacktestRuleRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RuleId string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	From   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Starting cash in the rule's currency asset, added to initial_holdings.
+	// When neither is set, the portfolio's current holdings are used.
+	InitialCash     *float64           `protobuf:"fixed64,4,opt,name=initial_cash,json=initialCash,proto3,oneof" json:"initial_cash,omitempty"`
+	InitialHoldings []*BacktestHolding `protobuf:"bytes,5,rep,name=initial_holdings,json=initialHoldings,proto3" json:"initial_holdings,omitempty"`
+	Costs           *BacktestCosts     `protobuf:"bytes,6,opt,name=costs,proto3" json:"costs,omitempty"`
+	// Price interval to replay, e.g. "1h" or "1d". Defaults to "1d".
+	Interval      *string `protobuf:"bytes,7,opt,name=interval,proto3,oneof" json:"interval,omitempty"`
+	SourceId      *string `protobuf:"bytes,8,opt,name=source_id,json=sourceId,proto3,oneof" json:"source_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EnableRuleRequest) Reset() {
-	*x = EnableRuleRequest{}
-	mi := &file_v1_automation_proto_msgTypes[27]
+func (x *BacktestRuleRequest) Reset() {
+	*x = BacktestRuleRequest{}
+	mi := &file_v1_automation_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EnableRuleRequest) String() string {
+func (x *BacktestRuleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EnableRuleRequest) ProtoMessage() {}
+func (*BacktestRuleRequest) ProtoMessage() {}
 
-func (x *EnableRuleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[27]
+func (x *BacktestRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_automation_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2056,40 +2276,601 @@ func (x *EnableRuleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EnableRuleRequest.ProtoReflect.Descriptor instead.
-func (*EnableRuleRequest) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use BacktestRuleRequest.ProtoReflect.Descriptor instead.
+func (*BacktestRuleRequest) Descriptor() ([]byte, []int) {
+	return file_v1_automation_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *EnableRuleRequest) GetRuleId() string {
+func (x *BacktestRuleRequest) GetRuleId() string {
 	if x != nil {
 		return x.RuleId
 	}
 	return ""
 }
 
-type DisableRuleRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *BacktestRuleRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
 }
 
-func (x *DisableRuleRequest) Reset() {
-	*x = DisableRuleRequest{}
-	mi := &file_v1_automation_proto_msgTypes[28]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *BacktestRuleRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
 }
 
-func (x *DisableRuleRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *BacktestRuleRequest) GetInitialCash() float64 {
+	if x != nil && x.InitialCash != nil {
+		return *x.InitialCash
+	}
+	return 0
+}
+
+func (x *BacktestRuleRequest) GetInitialHoldings() []*BacktestHolding {
+	if x != nil {
+		return x.InitialHoldings
+	}
+	return nil
+}
+
+func (x *BacktestRuleRequest) GetCosts() *BacktestCosts {
+	if x != nil {
+		return x.Costs
+	}
+	return nil
+}
+
+func (x *BacktestRuleRequest) GetInterval() string {
+	if x != nil && x.Interval != nil {
+		return *x.Interval
+	}
+	return ""
+}
+
+func (x *BacktestRuleRequest) GetSourceId() string {
+	if x != nil && x.SourceId != nil {
+		return *x.SourceId
+	}
+	return ""
+}
+
+type BacktestCosts struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Fee as a percentage of each trade's value.
+	FeePercent float64 `protobuf:"fixed64,1,opt,name=fee_percent,json=feePercent,proto3" json:"fee_percent,omitempty"`
+	// Fixed fee per trade, in the rule's currency asset.
+	FixedFee float64 `protobuf:"fixed64,2,opt,name=fixed_fee,json=fixedFee,proto3" json:"fixed_fee,omitempty"`
+	// Slippage in basis points; buys fill above and sells below the stored price.
+	SlippageBps   float64 `protobuf:"fixed64,3,opt,name=slippage_bps,json=slippageBps,proto3" json:"slippage_bps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BacktestCosts) Reset() {
+	*x = BacktestCosts{}
+	mi := &file_v1_automation_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BacktestCosts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BacktestCosts) ProtoMessage() {}
+
+func (x *BacktestCosts) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_automation_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BacktestCosts.ProtoReflect.Descriptor instead.
+func (*BacktestCosts) Descriptor() ([]byte, []int) {
+	return file_v1_automation_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *BacktestCosts) GetFeePercent() float64 {
+	if x != nil {
+		return x.FeePercent
+	}
+	return 0
+}
+
+func (x *BacktestCosts) GetFixedFee() float64 {
+	if x != nil {
+		return x.FixedFee
+	}
+	return 0
+}
+
+func (x *BacktestCosts) GetSlippageBps() float64 {
+	if x != nil {
+		return x.SlippageBps
+	}
+	return 0
+}
+
+type BacktestHolding struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AssetId string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Amount  float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Value in the rule's currency asset at the last price (output only).
+	Value         *float64 `protobuf:"fixed64,3,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BacktestHolding) Reset() {
+	*x = BacktestHolding{}
+	mi := &file_v1_automation_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BacktestHolding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BacktestHolding) ProtoMessage() {}
+
+func (x *BacktestHolding) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_automation_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BacktestHolding.ProtoReflect.Descriptor instead.
+func (*BacktestHolding) Descriptor() ([]byte, []int) {
+	return file_v1_automation_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *BacktestHolding) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *BacktestHolding) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *BacktestHolding) GetValue() float64 {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return 0
+}
+
+type BacktestRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EquityCurve   []*EquityPoint         `protobuf:"bytes,1,rep,name=equity_curve,json=equityCurve,proto3" json:"equity_curve,omitempty"`
+	Trades        []*BacktestTrade       `protobuf:"bytes,2,rep,name=trades,proto3" json:"trades,omitempty"`
+	FinalHoldings []*BacktestHolding     `protobuf:"bytes,3,rep,name=final_holdings,json=finalHoldings,proto3" json:"final_holdings,omitempty"`
+	Stats         *BacktestStats         `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
+	Warnings      []string               `protobuf:"bytes,5,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BacktestRuleResponse) Reset() {
+	*x = BacktestRuleResponse{}
+	mi := &file_v1_automation_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BacktestRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BacktestRuleResponse) ProtoMessage() {}
+
+func (x *BacktestRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_automation_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BacktestRuleResponse.ProtoReflect.Descriptor instead.
+func (*BacktestRuleResponse) Descriptor() ([]byte, []int) {
+	return file_v1_automation_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *BacktestRuleResponse) GetEquityCurve() []*EquityPoint {
+	if x != nil {
+		return x.EquityCurve
+	}
+	return nil
+}
+
+func (x *BacktestRuleResponse) GetTrades() []*BacktestTrade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+func (x *BacktestRuleResponse) GetFinalHoldings() []*BacktestHolding {
+	if x != nil {
+		return x.FinalHoldings
+	}
+	return nil
+}
+
+func (x *BacktestRuleResponse) GetStats() *BacktestStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *BacktestRuleResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type EquityPoint struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Value           float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	BuyAndHoldValue float64                `protobuf:"fixed64,3,opt,name=buy_and_hold_value,json=buyAndHoldValue,proto3" json:"buy_and_hold_value,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EquityPoint) Reset() {
+	*x = EquityPoint{}
+	mi := &file_v1_automation_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EquityPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EquityPoint) ProtoMessage() {}
+
+func (x *EquityPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_automation_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EquityPoint.ProtoReflect.Descriptor instead.
+func (*EquityPoint) Descriptor() ([]byte, []int) {
+	return file_v1_automation_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *EquityPoint) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *EquityPoint) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *EquityPoint) GetBuyAndHoldValue() float64 {
+	if x != nil {
+		return x.BuyAndHoldValue
+	}
+	return 0
+}
+
+type BacktestTrade struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	AssetId       string                 `protobuf:"bytes,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"` // "buy" or "sell"
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"` // fill price after slippage
+	Value         float64                `protobuf:"fixed64,6,opt,name=value,proto3" json:"value,omitempty"`
+	Fee           float64                `protobuf:"fixed64,7,opt,name=fee,proto3" json:"fee,omitempty"`
+	Reason        string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BacktestTrade) Reset() {
+	*x = BacktestTrade{}
+	mi := &file_v1_automation_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BacktestTrade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BacktestTrade) ProtoMessage() {}
+
+func (x *BacktestTrade) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_automation_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BacktestTrade.ProtoReflect.Descriptor instead.
+func (*BacktestTrade) Descriptor() ([]byte, []int) {
+	return file_v1_automation_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *BacktestTrade) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *BacktestTrade) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *BacktestTrade) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *BacktestTrade) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *BacktestTrade) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *BacktestTrade) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *BacktestTrade) GetFee() float64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *BacktestTrade) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type BacktestStats struct {
+	state                        protoimpl.MessageState `protogen:"open.v1"`
+	InitialValue                 float64                `protobuf:"fixed64,1,opt,name=initial_value,json=initialValue,proto3" json:"initial_value,omitempty"`
+	FinalValue                   float64                `protobuf:"fixed64,2,opt,name=final_value,json=finalValue,proto3" json:"final_value,omitempty"`
+	TotalReturnPercent           float64                `protobuf:"fixed64,3,opt,name=total_return_percent,json=totalReturnPercent,proto3" json:"total_return_percent,omitempty"`
+	MaxDrawdownPercent           float64                `protobuf:"fixed64,4,opt,name=max_drawdown_percent,json=maxDrawdownPercent,proto3" json:"max_drawdown_percent,omitempty"`
+	BuyAndHoldFinalValue         float64                `protobuf:"fixed64,5,opt,name=buy_and_hold_final_value,json=buyAndHoldFinalValue,proto3" json:"buy_and_hold_final_value,omitempty"`
+	BuyAndHoldReturnPercent      float64                `protobuf:"fixed64,6,opt,name=buy_and_hold_return_percent,json=buyAndHoldReturnPercent,proto3" json:"buy_and_hold_return_percent,omitempty"`
+	BuyAndHoldMaxDrawdownPercent float64                `protobuf:"fixed64,7,opt,name=buy_and_hold_max_drawdown_percent,json=buyAndHoldMaxDrawdownPercent,proto3" json:"buy_and_hold_max_drawdown_percent,omitempty"`
+	TotalFees                    float64                `protobuf:"fixed64,8,opt,name=total_fees,json=totalFees,proto3" json:"total_fees,omitempty"`
+	TradeCount                   int32                  `protobuf:"varint,9,opt,name=trade_count,json=tradeCount,proto3" json:"trade_count,omitempty"`
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *BacktestStats) Reset() {
+	*x = BacktestStats{}
+	mi := &file_v1_automation_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BacktestStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BacktestStats) ProtoMessage() {}
+
+func (x *BacktestStats) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_automation_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BacktestStats.ProtoReflect.Descriptor instead.
+func (*BacktestStats) Descriptor() ([]byte, []int) {
+	return file_v1_automation_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *BacktestStats) GetInitialValue() float64 {
+	if x != nil {
+		return x.InitialValue
+	}
+	return 0
+}
+
+func (x *BacktestStats) GetFinalValue() float64 {
+	if x != nil {
+		return x.FinalValue
+	}
+	return 0
+}
+
+func (x *BacktestStats) GetTotalReturnPercent() float64 {
+	if x != nil {
+		return x.TotalReturnPercent
+	}
+	return 0
+}
+
+func (x *BacktestStats) GetMaxDrawdownPercent() float64 {
+	if x != nil {
+		return x.MaxDrawdownPercent
+	}
+	return 0
+}
+
+func (x *BacktestStats) GetBuyAndHoldFinalValue() float64 {
+	if x != nil {
+		return x.BuyAndHoldFinalValue
+	}
+	return 0
+}
+
+func (x *BacktestStats) GetBuyAndHoldReturnPercent() float64 {
+	if x != nil {
+		return x.BuyAndHoldReturnPercent
+	}
+	return 0
+}
+
+func (x *BacktestStats) GetBuyAndHoldMaxDrawdownPercent() float64 {
+	if x != nil {
+		return x.BuyAndHoldMaxDrawdownPercent
+	}
+	return 0
+}
+
+func (x *BacktestStats) GetTotalFees() float64 {
+	if x != nil {
+		return x.TotalFees
+	}
+	return 0
+}
+
+func (x *BacktestStats) GetTradeCount() int32 {
+	if x != nil {
+		return x.TradeCount
+	}
+	return 0
+}
+
+type EnableRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableRuleRequest) Reset() {
+	*x = EnableRuleRequest{}
+	mi := &file_v1_automation_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableRuleRequest) ProtoMessage() {}
+
+func (x *EnableRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_automation_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableRuleRequest.ProtoReflect.Descriptor instead.
+func (*EnableRuleRequest) Descriptor() ([]byte, []int) {
+	return file_v1_automation_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *EnableRuleRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+type DisableRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableRuleRequest) Reset() {
+	*x = DisableRuleRequest{}
+	mi := &file_v1_automation_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
 func (*DisableRuleRequest) ProtoMessage() {}
 
 func (x *DisableRuleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[28]
+	mi := &file_v1_automation_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2102,7 +2883,7 @@ func (x *DisableRuleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DisableRuleRequest.ProtoReflect.Descriptor instead.
 func (*DisableRuleRequest) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{28}
+	return file_v1_automation_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *DisableRuleRequest) GetRuleId() string {
@@ -2122,7 +2903,7 @@ type PauseRuleRequest struct {
 
 func (x *PauseRuleRequest) Reset() {
 	*x = PauseRuleRequest{}
-	mi := &file_v1_automation_proto_msgTypes[29]
+	mi := &file_v1_automation_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2134,7 +2915,7 @@ func (x *PauseRuleRequest) String() string {
 func (*PauseRuleRequest) ProtoMessage() {}
 
 func (x *PauseRuleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[29]
+	mi := &file_v1_automation_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2147,7 +2928,7 @@ func (x *PauseRuleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PauseRuleRequest.ProtoReflect.Descriptor instead.
 func (*PauseRuleRequest) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{29}
+	return file_v1_automation_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *PauseRuleRequest) GetRuleId() string {
@@ -2173,7 +2954,7 @@ type ResumeRuleRequest struct {
 
 func (x *ResumeRuleRequest) Reset() {
 	*x = ResumeRuleRequest{}
-	mi := &file_v1_automation_proto_msgTypes[30]
+	mi := &file_v1_automation_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2185,7 +2966,7 @@ func (x *ResumeRuleRequest) String() string {
 func (*ResumeRuleRequest) ProtoMessage() {}
 
 func (x *ResumeRuleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[30]
+	mi := &file_v1_automation_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2198,7 +2979,7 @@ func (x *ResumeRuleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResumeRuleRequest.ProtoReflect.Descriptor instead.
 func (*ResumeRuleRequest) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{30}
+	return file_v1_automation_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *ResumeRuleRequest) GetRuleId() string {
@@ -2217,7 +2998,7 @@ type CreateRuleExecutionRequest struct {
 
 func (x *CreateRuleExecutionRequest) Reset() {
 	*x = CreateRuleExecutionRequest{}
-	mi := &file_v1_automation_proto_msgTypes[31]
+	mi := &file_v1_automation_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2229,7 +3010,7 @@ func (x *CreateRuleExecutionRequest) String() string {
 func (*CreateRuleExecutionRequest) ProtoMessage() {}
 
 func (x *CreateRuleExecutionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[31]
+	mi := &file_v1_automation_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2242,7 +3023,7 @@ func (x *CreateRuleExecutionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRuleExecutionRequest.ProtoReflect.Descriptor instead.
 func (*CreateRuleExecutionRequest) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{31}
+	return file_v1_automation_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *CreateRuleExecutionRequest) GetRuleExecution() *RuleExecution {
@@ -2261,7 +3042,7 @@ type GetRuleExecutionRequest struct {
 
 func (x *GetRuleExecutionRequest) Reset() {
 	*x = GetRuleExecutionRequest{}
-	mi := &file_v1_automation_proto_msgTypes[32]
+	mi := &file_v1_automation_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2273,7 +3054,7 @@ func (x *GetRuleExecutionRequest) String() string {
 func (*GetRuleExecutionRequest) ProtoMessage() {}
 
 func (x *GetRuleExecutionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[32]
+	mi := &file_v1_automation_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2286,7 +3067,7 @@ func (x *GetRuleExecutionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRuleExecutionRequest.ProtoReflect.Descriptor instead.
 func (*GetRuleExecutionRequest) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{32}
+	return file_v1_automation_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *GetRuleExecutionRequest) GetId() string {
@@ -2306,7 +3087,7 @@ type UpdateRuleExecutionRequest struct {
 
 func (x *UpdateRuleExecutionRequest) Reset() {
 	*x = UpdateRuleExecutionRequest{}
-	mi := &file_v1_automation_proto_msgTypes[33]
+	mi := &file_v1_automation_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2318,7 +3099,7 @@ func (x *UpdateRuleExecutionRequest) String() string {
 func (*UpdateRuleExecutionRequest) ProtoMessage() {}
 
 func (x *UpdateRuleExecutionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[33]
+	mi := &file_v1_automation_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2331,7 +3112,7 @@ func (x *UpdateRuleExecutionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRuleExecutionRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRuleExecutionRequest) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{33}
+	return file_v1_automation_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *UpdateRuleExecutionRequest) GetRuleExecution() *RuleExecution {
@@ -2364,7 +3145,7 @@ type ListRuleExecutionsRequest struct {
 
 func (x *ListRuleExecutionsRequest) Reset() {
 	*x = ListRuleExecutionsRequest{}
-	mi := &file_v1_automation_proto_msgTypes[34]
+	mi := &file_v1_automation_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2376,7 +3157,7 @@ func (x *ListRuleExecutionsRequest) String() string {
 func (*ListRuleExecutionsRequest) ProtoMessage() {}
 
 func (x *ListRuleExecutionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[34]
+	mi := &file_v1_automation_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2389,7 +3170,7 @@ func (x *ListRuleExecutionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRuleExecutionsRequest.ProtoReflect.Descriptor instead.
 func (*ListRuleExecutionsRequest) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{34}
+	return file_v1_automation_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *ListRuleExecutionsRequest) GetRuleId() string {
@@ -2458,7 +3239,7 @@ type ListRuleExecutionsResponse struct {
 
 func (x *ListRuleExecutionsResponse) Reset() {
 	*x = ListRuleExecutionsResponse{}
-	mi := &file_v1_automation_proto_msgTypes[35]
+	mi := &file_v1_automation_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2470,7 +3251,7 @@ func (x *ListRuleExecutionsResponse) String() string {
 func (*ListRuleExecutionsResponse) ProtoMessage() {}
 
 func (x *ListRuleExecutionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_automation_proto_msgTypes[35]
+	mi := &file_v1_automation_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2483,7 +3264,7 @@ func (x *ListRuleExecutionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRuleExecutionsResponse.ProtoReflect.Descriptor instead.
 func (*ListRuleExecutionsResponse) Descriptor() ([]byte, []int) {
-	return file_v1_automation_proto_rawDescGZIP(), []int{35}
+	return file_v1_automation_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *ListRuleExecutionsResponse) GetRuleExecutions() []*RuleExecution {
@@ -2500,6 +3281,50 @@ func (x *ListRuleExecutionsResponse) GetNextPageToken() string {
 	return ""
 }
 
+type WatchRuleExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRuleExecutionRequest) Reset() {
+	*x = WatchRuleExecutionRequest{}
+	mi := &file_v1_automation_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRuleExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRuleExecutionRequest) ProtoMessage() {}
+
+func (x *WatchRuleExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_automation_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRuleExecutionRequest.ProtoReflect.Descriptor instead.
+func (*WatchRuleExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_v1_automation_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *WatchRuleExecutionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 var File_v1_automation_proto protoreflect.FileDescriptor
 
 const file_v1_automation_proto_rawDesc = "" +
@@ -2524,7 +3349,7 @@ const file_v1_automation_proto_rawDesc = "" +
 	"\x0fcron_expression\x18\x01 \x01(\tR\x0ecronExpression\x12\x1a\n" +
 	"\btimezone\x18\x02 \x01(\tR\btimezone\x12\x19\n" +
 	"\bone_time\x18\x03 \x01(\bR\aoneTime\x12?\n" +
-	"\rexecute_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fexecuteAfter\"\xec\x04\n" +
+	"\rexecute_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fexecuteAfter\"\xab\x06\n" +
 	"\rRuleExecution\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\arule_id\x18\x02 \x01(\tR\x06ruleId\x12&\n" +
@@ -2539,11 +3364,18 @@ const file_v1_automation_proto_rawDesc = "" +
 	"\x14affected_holding_ids\x18\n" +
 	" \x03(\tR\x12affectedHoldingIds\x121\n" +
 	"\x14transactions_created\x18\v \x01(\x05R\x13transactionsCreated\x12D\n" +
-	"\x11execution_summary\x18\f \x01(\v2\x17.google.protobuf.StructR\x10executionSummaryB\x0f\n" +
+	"\x11execution_summary\x18\f \x01(\v2\x17.google.protobuf.StructR\x10executionSummary\x12\x17\n" +
+	"\adry_run\x18\r \x01(\bR\x06dryRun\x120\n" +
+	"\x11execution_context\x18\x0e \x01(\tH\x03R\x10executionContext\x88\x01\x01\x12)\n" +
+	"\x10cancel_requested\x18\x0f \x01(\bR\x0fcancelRequested\x12\"\n" +
+	"\n" +
+	"created_by\x18\x10 \x01(\tH\x04R\tcreatedBy\x88\x01\x01B\x0f\n" +
 	"\r_portfolio_idB\n" +
 	"\n" +
 	"\b_user_idB\x10\n" +
-	"\x0e_error_message\"<\n" +
+	"\x0e_error_messageB\x14\n" +
+	"\x12_execution_contextB\r\n" +
+	"\v_created_by\"<\n" +
 	"\x11CreateRuleRequest\x12'\n" +
 	"\x04rule\x18\x01 \x01(\v2\x13.greedy_eye.v1.RuleR\x04rule\" \n" +
 	"\x0eGetRuleRequest\x12\x0e\n" +
@@ -2597,7 +3429,18 @@ const file_v1_automation_proto_rawDesc = "" +
 	"\x14ValidateRuleResponse\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x12+\n" +
 	"\x11validation_errors\x18\x02 \x03(\tR\x10validationErrors\x12\x1a\n" +
-	"\bwarnings\x18\x03 \x03(\tR\bwarnings\"\xa5\x01\n" +
+	"\bwarnings\x18\x03 \x03(\tR\bwarnings\"\xb5\x01\n" +
+	"\bRuleType\x12\x1b\n" +
+	"\trule_type\x18\x01 \x01(\tR\bruleType\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12J\n" +
+	"\x14configuration_schema\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x13configurationSchema\x12\x1e\n" +
+	"\n" +
+	"executable\x18\x04 \x01(\bR\n" +
+	"executable\"\x16\n" +
+	"\x14ListRuleTypesRequest\"O\n" +
+	"\x15ListRuleTypesResponse\x126\n" +
+	"\n" +
+	"rule_types\x18\x01 \x03(\v2\x17.greedy_eye.v1.RuleTypeR\truleTypes\"\xa5\x01\n" +
 	"\x13SimulateRuleRequest\x12\x17\n" +
 	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12@\n" +
 	"\vsimulate_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\n" +
@@ -2644,12 +3487,18 @@ const file_v1_automation_proto_rawDesc = "" +
 	"\x11available_balance\x18\x01 \x01(\x01R\x10availableBalance\x12)\n" +
 	"\x10requested_amount\x18\x02 \x01(\x01R\x0frequestedAmount\x12)\n" +
 	"\x10sufficient_funds\x18\x03 \x01(\bR\x0fsufficientFunds\x12K\n" +
-	"\x0fwithdrawal_plan\x18\x04 \x03(\v2\".greedy_eye.v1.AssetWithdrawalPlanR\x0ewithdrawalPlan\"\xac\x01\n" +
+	"\x0fwithdrawal_plan\x18\x04 \x03(\v2\".greedy_eye.v1.AssetWithdrawalPlanR\x0ewithdrawalPlan\"\xb1\x02\n" +
 	"\x13AssetWithdrawalPlan\x12\x19\n" +
 	"\basset_id\x18\x01 \x01(\tR\aassetId\x12,\n" +
 	"\x12amount_to_withdraw\x18\x02 \x01(\x01R\x10amountToWithdraw\x12'\n" +
 	"\x0festimated_value\x18\x03 \x01(\x01R\x0eestimatedValue\x12#\n" +
-	"\rneeds_selling\x18\x04 \x01(\bR\fneedsSelling\"\x9c\x02\n" +
+	"\rneeds_selling\x18\x04 \x01(\bR\fneedsSelling\x12\x1d\n" +
+	"\n" +
+	"holding_id\x18\x05 \x01(\tR\tholdingId\x12\x1a\n" +
+	"\x06lot_id\x18\x06 \x01(\tH\x00R\x05lotId\x88\x01\x01\x12*\n" +
+	"\x0eestimated_gain\x18\a \x01(\x01H\x01R\restimatedGain\x88\x01\x01B\t\n" +
+	"\a_lot_idB\x11\n" +
+	"\x0f_estimated_gain\"\x9c\x02\n" +
 	"\x12StopLossSimulation\x126\n" +
 	"\x17current_portfolio_value\x18\x01 \x01(\x01R\x15currentPortfolioValue\x12.\n" +
 	"\x13stop_loss_threshold\x18\x02 \x01(\x01R\x11stopLossThreshold\x126\n" +
@@ -2669,7 +3518,62 @@ const file_v1_automation_proto_rawDesc = "" +
 	"\x19estimated_purchase_amount\x18\x04 \x01(\x01R\x17estimatedPurchaseAmount\x12'\n" +
 	"\x0festimated_price\x18\x05 \x01(\x01R\x0eestimatedPrice\x12#\n" +
 	"\restimated_fee\x18\x06 \x01(\x01R\festimatedFee\x12)\n" +
-	"\x10sufficient_funds\x18\a \x01(\bR\x0fsufficientFunds\",\n" +
+	"\x10sufficient_funds\x18\a \x01(\bR\x0fsufficientFunds\"\xa0\x03\n" +
+	"\x13BacktestRuleRequest\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12&\n" +
+	"\finitial_cash\x18\x04 \x01(\x01H\x00R\vinitialCash\x88\x01\x01\x12I\n" +
+	"\x10initial_holdings\x18\x05 \x03(\v2\x1e.greedy_eye.v1.BacktestHoldingR\x0finitialHoldings\x122\n" +
+	"\x05costs\x18\x06 \x01(\v2\x1c.greedy_eye.v1.BacktestCostsR\x05costs\x12\x1f\n" +
+	"\binterval\x18\a \x01(\tH\x01R\binterval\x88\x01\x01\x12 \n" +
+	"\tsource_id\x18\b \x01(\tH\x02R\bsourceId\x88\x01\x01B\x0f\n" +
+	"\r_initial_cashB\v\n" +
+	"\t_intervalB\f\n" +
+	"\n" +
+	"_source_id\"p\n" +
+	"\rBacktestCosts\x12\x1f\n" +
+	"\vfee_percent\x18\x01 \x01(\x01R\n" +
+	"feePercent\x12\x1b\n" +
+	"\tfixed_fee\x18\x02 \x01(\x01R\bfixedFee\x12!\n" +
+	"\fslippage_bps\x18\x03 \x01(\x01R\vslippageBps\"i\n" +
+	"\x0fBacktestHolding\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x19\n" +
+	"\x05value\x18\x03 \x01(\x01H\x00R\x05value\x88\x01\x01B\b\n" +
+	"\x06_value\"\xa2\x02\n" +
+	"\x14BacktestRuleResponse\x12=\n" +
+	"\fequity_curve\x18\x01 \x03(\v2\x1a.greedy_eye.v1.EquityPointR\vequityCurve\x124\n" +
+	"\x06trades\x18\x02 \x03(\v2\x1c.greedy_eye.v1.BacktestTradeR\x06trades\x12E\n" +
+	"\x0efinal_holdings\x18\x03 \x03(\v2\x1e.greedy_eye.v1.BacktestHoldingR\rfinalHoldings\x122\n" +
+	"\x05stats\x18\x04 \x01(\v2\x1c.greedy_eye.v1.BacktestStatsR\x05stats\x12\x1a\n" +
+	"\bwarnings\x18\x05 \x03(\tR\bwarnings\"\x8a\x01\n" +
+	"\vEquityPoint\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\x12+\n" +
+	"\x12buy_and_hold_value\x18\x03 \x01(\x01R\x0fbuyAndHoldValue\"\xea\x01\n" +
+	"\rBacktestTrade\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
+	"\basset_id\x18\x02 \x01(\tR\aassetId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x14\n" +
+	"\x05value\x18\x06 \x01(\x01R\x05value\x12\x10\n" +
+	"\x03fee\x18\a \x01(\x01R\x03fee\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\"\xb8\x03\n" +
+	"\rBacktestStats\x12#\n" +
+	"\rinitial_value\x18\x01 \x01(\x01R\finitialValue\x12\x1f\n" +
+	"\vfinal_value\x18\x02 \x01(\x01R\n" +
+	"finalValue\x120\n" +
+	"\x14total_return_percent\x18\x03 \x01(\x01R\x12totalReturnPercent\x120\n" +
+	"\x14max_drawdown_percent\x18\x04 \x01(\x01R\x12maxDrawdownPercent\x126\n" +
+	"\x18buy_and_hold_final_value\x18\x05 \x01(\x01R\x14buyAndHoldFinalValue\x12<\n" +
+	"\x1bbuy_and_hold_return_percent\x18\x06 \x01(\x01R\x17buyAndHoldReturnPercent\x12G\n" +
+	"!buy_and_hold_max_drawdown_percent\x18\a \x01(\x01R\x1cbuyAndHoldMaxDrawdownPercent\x12\x1d\n" +
+	"\n" +
+	"total_fees\x18\b \x01(\x01R\ttotalFees\x12\x1f\n" +
+	"\vtrade_count\x18\t \x01(\x05R\n" +
+	"tradeCount\",\n" +
 	"\x11EnableRuleRequest\x12\x17\n" +
 	"\arule_id\x18\x01 \x01(\tR\x06ruleId\"-\n" +
 	"\x12DisableRuleRequest\x12\x17\n" +
@@ -2710,7 +3614,9 @@ const file_v1_automation_proto_rawDesc = "" +
 	"\v_page_token\"\x8b\x01\n" +
 	"\x1aListRuleExecutionsResponse\x12E\n" +
 	"\x0frule_executions\x18\x01 \x03(\v2\x1c.greedy_eye.v1.RuleExecutionR\x0eruleExecutions\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*\x86\x01\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"+\n" +
+	"\x19WatchRuleExecutionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id*\x86\x01\n" +
 	"\n" +
 	"RuleStatus\x12\x17\n" +
 	"\x13RULE_STATUS_UNKNOWN\x10\x00\x12\x16\n" +
@@ -2724,7 +3630,7 @@ const file_v1_automation_proto_rawDesc = "" +
 	"\x1cEXECUTION_STATUS_IN_PROGRESS\x10\x02\x12\x1e\n" +
 	"\x1aEXECUTION_STATUS_COMPLETED\x10\x03\x12\x1b\n" +
 	"\x17EXECUTION_STATUS_FAILED\x10\x04\x12\x1e\n" +
-	"\x1aEXECUTION_STATUS_CANCELLED\x10\x052\xc2\x11\n" +
+	"\x1aEXECUTION_STATUS_CANCELLED\x10\x052\xce\x14\n" +
 	"\x11AutomationService\x12`\n" +
 	"\n" +
 	"CreateRule\x12 .greedy_eye.v1.CreateRuleRequest\x1a\x13.greedy_eye.v1.Rule\"\x1b\x82\xd3\xe4\x93\x02\x15:\x04rule\"\r/api/v1/rules\x12Y\n" +
@@ -2737,7 +3643,9 @@ const file_v1_automation_proto_rawDesc = "" +
 	"\vExecuteRule\x12!.greedy_eye.v1.ExecuteRuleRequest\x1a\".greedy_eye.v1.ExecuteRuleResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/rules/{rule_id}/execute\x12\x95\x01\n" +
 	"\x10ExecuteRuleAsync\x12&.greedy_eye.v1.ExecuteRuleAsyncRequest\x1a'.greedy_eye.v1.ExecuteRuleAsyncResponse\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/api/v1/rules/{rule_id}/execute-async\x12\x92\x01\n" +
 	"\x13CancelRuleExecution\x12).greedy_eye.v1.CancelRuleExecutionRequest\x1a\x16.google.protobuf.Empty\"8\x82\xd3\xe4\x93\x022:\x01*\"-/api/v1/rule-executions/{execution_id}/cancel\x12}\n" +
-	"\fValidateRule\x12\".greedy_eye.v1.ValidateRuleRequest\x1a#.greedy_eye.v1.ValidateRuleResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x04rule\"\x16/api/v1/rules/validate\x12\x84\x01\n" +
+	"\fValidateRule\x12\".greedy_eye.v1.ValidateRuleRequest\x1a#.greedy_eye.v1.ValidateRuleResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x04rule\"\x16/api/v1/rules/validate\x12v\n" +
+	"\rListRuleTypes\x12#.greedy_eye.v1.ListRuleTypesRequest\x1a$.greedy_eye.v1.ListRuleTypesResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/rule-types\x12\x84\x01\n" +
+	"\fBacktestRule\x12\".greedy_eye.v1.BacktestRuleRequest\x1a#.greedy_eye.v1.BacktestRuleResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/rules/{rule_id}/backtest\x12\x84\x01\n" +
 	"\fSimulateRule\x12\".greedy_eye.v1.SimulateRuleRequest\x1a#.greedy_eye.v1.SimulateRuleResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/rules/{rule_id}/simulate\x12n\n" +
 	"\n" +
 	"EnableRule\x12 .greedy_eye.v1.EnableRuleRequest\x1a\x13.greedy_eye.v1.Rule\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/api/v1/rules/{rule_id}/enable\x12q\n" +
@@ -2748,7 +3656,8 @@ const file_v1_automation_proto_rawDesc = "" +
 	"\x13CreateRuleExecution\x12).greedy_eye.v1.CreateRuleExecutionRequest\x1a\x1c.greedy_eye.v1.RuleExecution\"/\x82\xd3\xe4\x93\x02):\x0erule_execution\"\x17/api/v1/rule-executions\x12~\n" +
 	"\x10GetRuleExecution\x12&.greedy_eye.v1.GetRuleExecutionRequest\x1a\x1c.greedy_eye.v1.RuleExecution\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/rule-executions/{id}\x12\xa3\x01\n" +
 	"\x13UpdateRuleExecution\x12).greedy_eye.v1.UpdateRuleExecutionRequest\x1a\x1c.greedy_eye.v1.RuleExecution\"C\x82\xd3\xe4\x93\x02=:\x0erule_execution\x1a+/api/v1/rule-executions/{rule_execution.id}\x12\x8a\x01\n" +
-	"\x12ListRuleExecutions\x12(.greedy_eye.v1.ListRuleExecutionsRequest\x1a).greedy_eye.v1.ListRuleExecutionsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/rule-executionsB\xaa\x01\n" +
+	"\x12ListRuleExecutions\x12(.greedy_eye.v1.ListRuleExecutionsRequest\x1a).greedy_eye.v1.ListRuleExecutionsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/rule-executions\x12\x8a\x01\n" +
+	"\x12WatchRuleExecution\x12(.greedy_eye.v1.WatchRuleExecutionRequest\x1a\x1c.greedy_eye.v1.RuleExecution\"*\x82\xd3\xe4\x93\x02$\x12\"/api/v1/rule-executions/{id}/watch0\x01B\xaa\x01\n" +
 	"\x11com.greedy_eye.v1B\x0fAutomationProtoP\x01Z3github.com/foxcool/greedy-eye/internal/api/v1;apiv1\xa2\x02\x03GXX\xaa\x02\fGreedyEye.V1\xca\x02\fGreedyEye\\V1\xe2\x02\x18GreedyEye\\V1\\GPBMetadata\xea\x02\rGreedyEye::V1b\x06proto3"
 
 var (
@@ -2764,7 +3673,7 @@ func file_v1_automation_proto_rawDescGZIP() []byte {
 }
 
 var file_v1_automation_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_v1_automation_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
+var file_v1_automation_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
 var file_v1_automation_proto_goTypes = []any{
 	(RuleStatus)(0),                    // 0: greedy_eye.v1.RuleStatus
 	(ExecutionStatus)(0),               // 1: greedy_eye.v1.ExecutionStatus
@@ -2784,108 +3693,137 @@ var file_v1_automation_proto_goTypes = []any{
 	(*CancelRuleExecutionRequest)(nil), // 15: greedy_eye.v1.CancelRuleExecutionRequest
 	(*ValidateRuleRequest)(nil),        // 16: greedy_eye.v1.ValidateRuleRequest
 	(*ValidateRuleResponse)(nil),       // 17: greedy_eye.v1.ValidateRuleResponse
-	(*SimulateRuleRequest)(nil),        // 18: greedy_eye.v1.SimulateRuleRequest
-	(*SimulateRuleResponse)(nil),       // 19: greedy_eye.v1.SimulateRuleResponse
-	(*SimulationResult)(nil),           // 20: greedy_eye.v1.SimulationResult
-	(*RebalancingSimulation)(nil),      // 21: greedy_eye.v1.RebalancingSimulation
-	(*AssetAllocation)(nil),            // 22: greedy_eye.v1.AssetAllocation
-	(*PlannedTrade)(nil),               // 23: greedy_eye.v1.PlannedTrade
-	(*WithdrawalSimulation)(nil),       // 24: greedy_eye.v1.WithdrawalSimulation
-	(*AssetWithdrawalPlan)(nil),        // 25: greedy_eye.v1.AssetWithdrawalPlan
-	(*StopLossSimulation)(nil),         // 26: greedy_eye.v1.StopLossSimulation
-	(*AssetStopLoss)(nil),              // 27: greedy_eye.v1.AssetStopLoss
-	(*DCASimulation)(nil),              // 28: greedy_eye.v1.DCASimulation
-	(*EnableRuleRequest)(nil),          // 29: greedy_eye.v1.EnableRuleRequest
-	(*DisableRuleRequest)(nil),         // 30: greedy_eye.v1.DisableRuleRequest
-	(*PauseRuleRequest)(nil),           // 31: greedy_eye.v1.PauseRuleRequest
-	(*ResumeRuleRequest)(nil),          // 32: greedy_eye.v1.ResumeRuleRequest
-	(*CreateRuleExecutionRequest)(nil), // 33: greedy_eye.v1.CreateRuleExecutionRequest
-	(*GetRuleExecutionRequest)(nil),    // 34: greedy_eye.v1.GetRuleExecutionRequest
-	(*UpdateRuleExecutionRequest)(nil), // 35: greedy_eye.v1.UpdateRuleExecutionRequest
-	(*ListRuleExecutionsRequest)(nil),  // 36: greedy_eye.v1.ListRuleExecutionsRequest
-	(*ListRuleExecutionsResponse)(nil), // 37: greedy_eye.v1.ListRuleExecutionsResponse
-	(*structpb.Struct)(nil),            // 38: google.protobuf.Struct
-	(*timestamppb.Timestamp)(nil),      // 39: google.protobuf.Timestamp
-	(*fieldmaskpb.FieldMask)(nil),      // 40: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),              // 41: google.protobuf.Empty
+	(*RuleType)(nil),                   // 18: greedy_eye.v1.RuleType
+	(*ListRuleTypesRequest)(nil),       // 19: greedy_eye.v1.ListRuleTypesRequest
+	(*ListRuleTypesResponse)(nil),      // 20: greedy_eye.v1.ListRuleTypesResponse
+	(*SimulateRuleRequest)(nil),        // 21: greedy_eye.v1.SimulateRuleRequest
+	(*SimulateRuleResponse)(nil),       // 22: greedy_eye.v1.SimulateRuleResponse
+	(*SimulationResult)(nil),           // 23: greedy_eye.v1.SimulationResult
+	(*RebalancingSimulation)(nil),      // 24: greedy_eye.v1.RebalancingSimulation
+	(*AssetAllocation)(nil),            // 25: greedy_eye.v1.AssetAllocation
+	(*PlannedTrade)(nil),               // 26: greedy_eye.v1.PlannedTrade
+	(*WithdrawalSimulation)(nil),       // 27: greedy_eye.v1.WithdrawalSimulation
+	(*AssetWithdrawalPlan)(nil),        // 28: greedy_eye.v1.AssetWithdrawalPlan
+	(*StopLossSimulation)(nil),         // 29: greedy_eye.v1.StopLossSimulation
+	(*AssetStopLoss)(nil),              // 30: greedy_eye.v1.AssetStopLoss
+	(*DCASimulation)(nil),              // 31: greedy_eye.v1.DCASimulation
+	(*BacktestRuleRequest)(nil),        // 32: greedy_eye.v1.BacktestRuleRequest
+	(*BacktestCosts)(nil),              // 33: greedy_eye.v1.BacktestCosts
+	(*BacktestHolding)(nil),            // 34: greedy_eye.v1.BacktestHolding
+	(*BacktestRuleResponse)(nil),       // 35: greedy_eye.v1.BacktestRuleResponse
+	(*EquityPoint)(nil),                // 36: greedy_eye.v1.EquityPoint
+	(*BacktestTrade)(nil),              // 37: greedy_eye.v1.BacktestTrade
+	(*BacktestStats)(nil),              // 38: greedy_eye.v1.BacktestStats
+	(*EnableRuleRequest)(nil),          // 39: greedy_eye.v1.EnableRuleRequest
+	(*DisableRuleRequest)(nil),         // 40: greedy_eye.v1.DisableRuleRequest
+	(*PauseRuleRequest)(nil),           // 41: greedy_eye.v1.PauseRuleRequest
+	(*ResumeRuleRequest)(nil),          // 42: greedy_eye.v1.ResumeRuleRequest
+	(*CreateRuleExecutionRequest)(nil), // 43: greedy_eye.v1.CreateRuleExecutionRequest
+	(*GetRuleExecutionRequest)(nil),    // 44: greedy_eye.v1.GetRuleExecutionRequest
+	(*UpdateRuleExecutionRequest)(nil), // 45: greedy_eye.v1.UpdateRuleExecutionRequest
+	(*ListRuleExecutionsRequest)(nil),  // 46: greedy_eye.v1.ListRuleExecutionsRequest
+	(*ListRuleExecutionsResponse)(nil), // 47: greedy_eye.v1.ListRuleExecutionsResponse
+	(*WatchRuleExecutionRequest)(nil),  // 48: greedy_eye.v1.WatchRuleExecutionRequest
+	(*structpb.Struct)(nil),            // 49: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),      // 50: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),      // 51: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),              // 52: google.protobuf.Empty
 }
 var file_v1_automation_proto_depIdxs = []int32{
 	0,  // 0: greedy_eye.v1.Rule.status:type_name -> greedy_eye.v1.RuleStatus
-	38, // 1: greedy_eye.v1.Rule.configuration:type_name -> google.protobuf.Struct
+	49, // 1: greedy_eye.v1.Rule.configuration:type_name -> google.protobuf.Struct
 	3,  // 2: greedy_eye.v1.Rule.schedule:type_name -> greedy_eye.v1.RuleSchedule
-	39, // 3: greedy_eye.v1.Rule.created_at:type_name -> google.protobuf.Timestamp
-	39, // 4: greedy_eye.v1.Rule.updated_at:type_name -> google.protobuf.Timestamp
-	39, // 5: greedy_eye.v1.RuleSchedule.execute_after:type_name -> google.protobuf.Timestamp
-	39, // 6: greedy_eye.v1.RuleExecution.started_at:type_name -> google.protobuf.Timestamp
-	39, // 7: greedy_eye.v1.RuleExecution.completed_at:type_name -> google.protobuf.Timestamp
+	50, // 3: greedy_eye.v1.Rule.created_at:type_name -> google.protobuf.Timestamp
+	50, // 4: greedy_eye.v1.Rule.updated_at:type_name -> google.protobuf.Timestamp
+	50, // 5: greedy_eye.v1.RuleSchedule.execute_after:type_name -> google.protobuf.Timestamp
+	50, // 6: greedy_eye.v1.RuleExecution.started_at:type_name -> google.protobuf.Timestamp
+	50, // 7: greedy_eye.v1.RuleExecution.completed_at:type_name -> google.protobuf.Timestamp
 	1,  // 8: greedy_eye.v1.RuleExecution.status:type_name -> greedy_eye.v1.ExecutionStatus
-	38, // 9: greedy_eye.v1.RuleExecution.execution_summary:type_name -> google.protobuf.Struct
+	49, // 9: greedy_eye.v1.RuleExecution.execution_summary:type_name -> google.protobuf.Struct
 	2,  // 10: greedy_eye.v1.CreateRuleRequest.rule:type_name -> greedy_eye.v1.Rule
 	2,  // 11: greedy_eye.v1.UpdateRuleRequest.rule:type_name -> greedy_eye.v1.Rule
-	40, // 12: greedy_eye.v1.UpdateRuleRequest.update_mask:type_name -> google.protobuf.FieldMask
+	51, // 12: greedy_eye.v1.UpdateRuleRequest.update_mask:type_name -> google.protobuf.FieldMask
 	0,  // 13: greedy_eye.v1.ListRulesRequest.status:type_name -> greedy_eye.v1.RuleStatus
 	2,  // 14: greedy_eye.v1.ListRulesResponse.rules:type_name -> greedy_eye.v1.Rule
 	4,  // 15: greedy_eye.v1.ExecuteRuleResponse.execution:type_name -> greedy_eye.v1.RuleExecution
 	2,  // 16: greedy_eye.v1.ValidateRuleRequest.rule:type_name -> greedy_eye.v1.Rule
-	39, // 17: greedy_eye.v1.SimulateRuleRequest.simulate_at:type_name -> google.protobuf.Timestamp
-	20, // 18: greedy_eye.v1.SimulateRuleResponse.result:type_name -> greedy_eye.v1.SimulationResult
-	21, // 19: greedy_eye.v1.SimulationResult.rebalancing:type_name -> greedy_eye.v1.RebalancingSimulation
-	24, // 20: greedy_eye.v1.SimulationResult.withdrawal:type_name -> greedy_eye.v1.WithdrawalSimulation
-	26, // 21: greedy_eye.v1.SimulationResult.stop_loss:type_name -> greedy_eye.v1.StopLossSimulation
-	28, // 22: greedy_eye.v1.SimulationResult.dca:type_name -> greedy_eye.v1.DCASimulation
-	22, // 23: greedy_eye.v1.RebalancingSimulation.current_allocations:type_name -> greedy_eye.v1.AssetAllocation
-	22, // 24: greedy_eye.v1.RebalancingSimulation.target_allocations:type_name -> greedy_eye.v1.AssetAllocation
-	23, // 25: greedy_eye.v1.RebalancingSimulation.planned_trades:type_name -> greedy_eye.v1.PlannedTrade
-	25, // 26: greedy_eye.v1.WithdrawalSimulation.withdrawal_plan:type_name -> greedy_eye.v1.AssetWithdrawalPlan
-	27, // 27: greedy_eye.v1.StopLossSimulation.asset_actions:type_name -> greedy_eye.v1.AssetStopLoss
-	4,  // 28: greedy_eye.v1.CreateRuleExecutionRequest.rule_execution:type_name -> greedy_eye.v1.RuleExecution
-	4,  // 29: greedy_eye.v1.UpdateRuleExecutionRequest.rule_execution:type_name -> greedy_eye.v1.RuleExecution
-	40, // 30: greedy_eye.v1.UpdateRuleExecutionRequest.update_mask:type_name -> google.protobuf.FieldMask
-	1,  // 31: greedy_eye.v1.ListRuleExecutionsRequest.status:type_name -> greedy_eye.v1.ExecutionStatus
-	39, // 32: greedy_eye.v1.ListRuleExecutionsRequest.from:type_name -> google.protobuf.Timestamp
-	39, // 33: greedy_eye.v1.ListRuleExecutionsRequest.to:type_name -> google.protobuf.Timestamp
-	4,  // 34: greedy_eye.v1.ListRuleExecutionsResponse.rule_executions:type_name -> greedy_eye.v1.RuleExecution
-	5,  // 35: greedy_eye.v1.AutomationService.CreateRule:input_type -> greedy_eye.v1.CreateRuleRequest
-	6,  // 36: greedy_eye.v1.AutomationService.GetRule:input_type -> greedy_eye.v1.GetRuleRequest
-	7,  // 37: greedy_eye.v1.AutomationService.UpdateRule:input_type -> greedy_eye.v1.UpdateRuleRequest
-	8,  // 38: greedy_eye.v1.AutomationService.DeleteRule:input_type -> greedy_eye.v1.DeleteRuleRequest
-	9,  // 39: greedy_eye.v1.AutomationService.ListRules:input_type -> greedy_eye.v1.ListRulesRequest
-	11, // 40: greedy_eye.v1.AutomationService.ExecuteRule:input_type -> greedy_eye.v1.ExecuteRuleRequest
-	13, // 41: greedy_eye.v1.AutomationService.ExecuteRuleAsync:input_type -> greedy_eye.v1.ExecuteRuleAsyncRequest
-	15, // 42: greedy_eye.v1.AutomationService.CancelRuleExecution:input_type -> greedy_eye.v1.CancelRuleExecutionRequest
-	16, // 43: greedy_eye.v1.AutomationService.ValidateRule:input_type -> greedy_eye.v1.ValidateRuleRequest
-	18, // 44: greedy_eye.v1.AutomationService.SimulateRule:input_type -> greedy_eye.v1.SimulateRuleRequest
-	29, // 45: greedy_eye.v1.AutomationService.EnableRule:input_type -> greedy_eye.v1.EnableRuleRequest
-	30, // 46: greedy_eye.v1.AutomationService.DisableRule:input_type -> greedy_eye.v1.DisableRuleRequest
-	31, // 47: greedy_eye.v1.AutomationService.PauseRule:input_type -> greedy_eye.v1.PauseRuleRequest
-	32, // 48: greedy_eye.v1.AutomationService.ResumeRule:input_type -> greedy_eye.v1.ResumeRuleRequest
-	33, // 49: greedy_eye.v1.AutomationService.CreateRuleExecution:input_type -> greedy_eye.v1.CreateRuleExecutionRequest
-	34, // 50: greedy_eye.v1.AutomationService.GetRuleExecution:input_type -> greedy_eye.v1.GetRuleExecutionRequest
-	35, // 51: greedy_eye.v1.AutomationService.UpdateRuleExecution:input_type -> greedy_eye.v1.UpdateRuleExecutionRequest
-	36, // 52: greedy_eye.v1.AutomationService.ListRuleExecutions:input_type -> greedy_eye.v1.ListRuleExecutionsRequest
-	2,  // 53: greedy_eye.v1.AutomationService.CreateRule:output_type -> greedy_eye.v1.Rule
-	2,  // 54: greedy_eye.v1.AutomationService.GetRule:output_type -> greedy_eye.v1.Rule
-	2,  // 55: greedy_eye.v1.AutomationService.UpdateRule:output_type -> greedy_eye.v1.Rule
-	41, // 56: greedy_eye.v1.AutomationService.DeleteRule:output_type -> google.protobuf.Empty
-	10, // 57: greedy_eye.v1.AutomationService.ListRules:output_type -> greedy_eye.v1.ListRulesResponse
-	12, // 58: greedy_eye.v1.AutomationService.ExecuteRule:output_type -> greedy_eye.v1.ExecuteRuleResponse
-	14, // 59: greedy_eye.v1.AutomationService.ExecuteRuleAsync:output_type -> greedy_eye.v1.ExecuteRuleAsyncResponse
-	41, // 60: greedy_eye.v1.AutomationService.CancelRuleExecution:output_type -> google.protobuf.Empty
-	17, // 61: greedy_eye.v1.AutomationService.ValidateRule:output_type -> greedy_eye.v1.ValidateRuleResponse
-	19, // 62: greedy_eye.v1.AutomationService.SimulateRule:output_type -> greedy_eye.v1.SimulateRuleResponse
-	2,  // 63: greedy_eye.v1.AutomationService.EnableRule:output_type -> greedy_eye.v1.Rule
-	2,  // 64: greedy_eye.v1.AutomationService.DisableRule:output_type -> greedy_eye.v1.Rule
-	2,  // 65: greedy_eye.v1.AutomationService.PauseRule:output_type -> greedy_eye.v1.Rule
-	2,  // 66: greedy_eye.v1.AutomationService.ResumeRule:output_type -> greedy_eye.v1.Rule
-	4,  // 67: greedy_eye.v1.AutomationService.CreateRuleExecution:output_type -> greedy_eye.v1.RuleExecution
-	4,  // 68: greedy_eye.v1.AutomationService.GetRuleExecution:output_type -> greedy_eye.v1.RuleExecution
-	4,  // 69: greedy_eye.v1.AutomationService.UpdateRuleExecution:output_type -> greedy_eye.v1.RuleExecution
-	37, // 70: greedy_eye.v1.AutomationService.ListRuleExecutions:output_type -> greedy_eye.v1.ListRuleExecutionsResponse
-	53, // [53:71] is the sub-list for method output_type
-	35, // [35:53] is the sub-list for method input_type
-	35, // [35:35] is the sub-list for extension type_name
-	35, // [35:35] is the sub-list for extension extendee
-	0,  // [0:35] is the sub-list for field type_name
+	49, // 17: greedy_eye.v1.RuleType.configuration_schema:type_name -> google.protobuf.Struct
+	18, // 18: greedy_eye.v1.ListRuleTypesResponse.rule_types:type_name -> greedy_eye.v1.RuleType
+	50, // 19: greedy_eye.v1.SimulateRuleRequest.simulate_at:type_name -> google.protobuf.Timestamp
+	23, // 20: greedy_eye.v1.SimulateRuleResponse.result:type_name -> greedy_eye.v1.SimulationResult
+	24, // 21: greedy_eye.v1.SimulationResult.rebalancing:type_name -> greedy_eye.v1.RebalancingSimulation
+	27, // 22: greedy_eye.v1.SimulationResult.withdrawal:type_name -> greedy_eye.v1.WithdrawalSimulation
+	29, // 23: greedy_eye.v1.SimulationResult.stop_loss:type_name -> greedy_eye.v1.StopLossSimulation
+	31, // 24: greedy_eye.v1.SimulationResult.dca:type_name -> greedy_eye.v1.DCASimulation
+	25, // 25: greedy_eye.v1.RebalancingSimulation.current_allocations:type_name -> greedy_eye.v1.AssetAllocation
+	25, // 26: greedy_eye.v1.RebalancingSimulation.target_allocations:type_name -> greedy_eye.v1.AssetAllocation
+	26, // 27: greedy_eye.v1.RebalancingSimulation.planned_trades:type_name -> greedy_eye.v1.PlannedTrade
+	28, // 28: greedy_eye.v1.WithdrawalSimulation.withdrawal_plan:type_name -> greedy_eye.v1.AssetWithdrawalPlan
+	30, // 29: greedy_eye.v1.StopLossSimulation.asset_actions:type_name -> greedy_eye.v1.AssetStopLoss
+	50, // 30: greedy_eye.v1.BacktestRuleRequest.from:type_name -> google.protobuf.Timestamp
+	50, // 31: greedy_eye.v1.BacktestRuleRequest.to:type_name -> google.protobuf.Timestamp
+	34, // 32: greedy_eye.v1.BacktestRuleRequest.initial_holdings:type_name -> greedy_eye.v1.BacktestHolding
+	33, // 33: greedy_eye.v1.BacktestRuleRequest.costs:type_name -> greedy_eye.v1.BacktestCosts
+	36, // 34: greedy_eye.v1.BacktestRuleResponse.equity_curve:type_name -> greedy_eye.v1.EquityPoint
+	37, // 35: greedy_eye.v1.BacktestRuleResponse.trades:type_name -> greedy_eye.v1.BacktestTrade
+	34, // 36: greedy_eye.v1.BacktestRuleResponse.final_holdings:type_name -> greedy_eye.v1.BacktestHolding
+	38, // 37: greedy_eye.v1.BacktestRuleResponse.stats:type_name -> greedy_eye.v1.BacktestStats
+	50, // 38: greedy_eye.v1.EquityPoint.timestamp:type_name -> google.protobuf.Timestamp
+	50, // 39: greedy_eye.v1.BacktestTrade.timestamp:type_name -> google.protobuf.Timestamp
+	4,  // 40: greedy_eye.v1.CreateRuleExecutionRequest.rule_execution:type_name -> greedy_eye.v1.RuleExecution
+	4,  // 41: greedy_eye.v1.UpdateRuleExecutionRequest.rule_execution:type_name -> greedy_eye.v1.RuleExecution
+	51, // 42: greedy_eye.v1.UpdateRuleExecutionRequest.update_mask:type_name -> google.protobuf.FieldMask
+	1,  // 43: greedy_eye.v1.ListRuleExecutionsRequest.status:type_name -> greedy_eye.v1.ExecutionStatus
+	50, // 44: greedy_eye.v1.ListRuleExecutionsRequest.from:type_name -> google.protobuf.Timestamp
+	50, // 45: greedy_eye.v1.ListRuleExecutionsRequest.to:type_name -> google.protobuf.Timestamp
+	4,  // 46: greedy_eye.v1.ListRuleExecutionsResponse.rule_executions:type_name -> greedy_eye.v1.RuleExecution
+	5,  // 47: greedy_eye.v1.AutomationService.CreateRule:input_type -> greedy_eye.v1.CreateRuleRequest
+	6,  // 48: greedy_eye.v1.AutomationService.GetRule:input_type -> greedy_eye.v1.GetRuleRequest
+	7,  // 49: greedy_eye.v1.AutomationService.UpdateRule:input_type -> greedy_eye.v1.UpdateRuleRequest
+	8,  // 50: greedy_eye.v1.AutomationService.DeleteRule:input_type -> greedy_eye.v1.DeleteRuleRequest
+	9,  // 51: greedy_eye.v1.AutomationService.ListRules:input_type -> greedy_eye.v1.ListRulesRequest
+	11, // 52: greedy_eye.v1.AutomationService.ExecuteRule:input_type -> greedy_eye.v1.ExecuteRuleRequest
+	13, // 53: greedy_eye.v1.AutomationService.ExecuteRuleAsync:input_type -> greedy_eye.v1.ExecuteRuleAsyncRequest
+	15, // 54: greedy_eye.v1.AutomationService.CancelRuleExecution:input_type -> greedy_eye.v1.CancelRuleExecutionRequest
+	16, // 55: greedy_eye.v1.AutomationService.ValidateRule:input_type -> greedy_eye.v1.ValidateRuleRequest
+	19, // 56: greedy_eye.v1.AutomationService.ListRuleTypes:input_type -> greedy_eye.v1.ListRuleTypesRequest
+	32, // 57: greedy_eye.v1.AutomationService.BacktestRule:input_type -> greedy_eye.v1.BacktestRuleRequest
+	21, // 58: greedy_eye.v1.AutomationService.SimulateRule:input_type -> greedy_eye.v1.SimulateRuleRequest
+	39, // 59: greedy_eye.v1.AutomationService.EnableRule:input_type -> greedy_eye.v1.EnableRuleRequest
+	40, // 60: greedy_eye.v1.AutomationService.DisableRule:input_type -> greedy_eye.v1.DisableRuleRequest
+	41, // 61: greedy_eye.v1.AutomationService.PauseRule:input_type -> greedy_eye.v1.PauseRuleRequest
+	42, // 62: greedy_eye.v1.AutomationService.ResumeRule:input_type -> greedy_eye.v1.ResumeRuleRequest
+	43, // 63: greedy_eye.v1.AutomationService.CreateRuleExecution:input_type -> greedy_eye.v1.CreateRuleExecutionRequest
+	44, // 64: greedy_eye.v1.AutomationService.GetRuleExecution:input_type -> greedy_eye.v1.GetRuleExecutionRequest
+	45, // 65: greedy_eye.v1.AutomationService.UpdateRuleExecution:input_type -> greedy_eye.v1.UpdateRuleExecutionRequest
+	46, // 66: greedy_eye.v1.AutomationService.ListRuleExecutions:input_type -> greedy_eye.v1.ListRuleExecutionsRequest
+	48, // 67: greedy_eye.v1.AutomationService.WatchRuleExecution:input_type -> greedy_eye.v1.WatchRuleExecutionRequest
+	2,  // 68: greedy_eye.v1.AutomationService.CreateRule:output_type -> greedy_eye.v1.Rule
+	2,  // 69: greedy_eye.v1.AutomationService.GetRule:output_type -> greedy_eye.v1.Rule
+	2,  // 70: greedy_eye.v1.AutomationService.UpdateRule:output_type -> greedy_eye.v1.Rule
+	52, // 71: greedy_eye.v1.AutomationService.DeleteRule:output_type -> google.protobuf.Empty
+	10, // 72: greedy_eye.v1.AutomationService.ListRules:output_type -> greedy_eye.v1.ListRulesResponse
+	12, // 73: greedy_eye.v1.AutomationService.ExecuteRule:output_type -> greedy_eye.v1.ExecuteRuleResponse
+	14, // 74: greedy_eye.v1.AutomationService.ExecuteRuleAsync:output_type -> greedy_eye.v1.ExecuteRuleAsyncResponse
+	52, // 75: greedy_eye.v1.AutomationService.CancelRuleExecution:output_type -> google.protobuf.Empty
+	17, // 76: greedy_eye.v1.AutomationService.ValidateRule:output_type -> greedy_eye.v1.ValidateRuleResponse
+	20, // 77: greedy_eye.v1.AutomationService.ListRuleTypes:output_type -> greedy_eye.v1.ListRuleTypesResponse
+	35, // 78: greedy_eye.v1.AutomationService.BacktestRule:output_type -> greedy_eye.v1.BacktestRuleResponse
+	22, // 79: greedy_eye.v1.AutomationService.SimulateRule:output_type -> greedy_eye.v1.SimulateRuleResponse
+	2,  // 80: greedy_eye.v1.AutomationService.EnableRule:output_type -> greedy_eye.v1.Rule
+	2,  // 81: greedy_eye.v1.AutomationService.DisableRule:output_type -> greedy_eye.v1.Rule
+	2,  // 82: greedy_eye.v1.AutomationService.PauseRule:output_type -> greedy_eye.v1.Rule
+	2,  // 83: greedy_eye.v1.AutomationService.ResumeRule:output_type -> greedy_eye.v1.Rule
+	4,  // 84: greedy_eye.v1.AutomationService.CreateRuleExecution:output_type -> greedy_eye.v1.RuleExecution
+	4,  // 85: greedy_eye.v1.AutomationService.GetRuleExecution:output_type -> greedy_eye.v1.RuleExecution
+	4,  // 86: greedy_eye.v1.AutomationService.UpdateRuleExecution:output_type -> greedy_eye.v1.RuleExecution
+	47, // 87: greedy_eye.v1.AutomationService.ListRuleExecutions:output_type -> greedy_eye.v1.ListRuleExecutionsResponse
+	4,  // 88: greedy_eye.v1.AutomationService.WatchRuleExecution:output_type -> greedy_eye.v1.RuleExecution
+	68, // [68:89] is the sub-list for method output_type
+	47, // [47:68] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_v1_automation_proto_init() }
@@ -2897,21 +3835,24 @@ func file_v1_automation_proto_init() {
 	file_v1_automation_proto_msgTypes[7].OneofWrappers = []any{}
 	file_v1_automation_proto_msgTypes[9].OneofWrappers = []any{}
 	file_v1_automation_proto_msgTypes[11].OneofWrappers = []any{}
-	file_v1_automation_proto_msgTypes[16].OneofWrappers = []any{}
-	file_v1_automation_proto_msgTypes[18].OneofWrappers = []any{
+	file_v1_automation_proto_msgTypes[19].OneofWrappers = []any{}
+	file_v1_automation_proto_msgTypes[21].OneofWrappers = []any{
 		(*SimulationResult_Rebalancing)(nil),
 		(*SimulationResult_Withdrawal)(nil),
 		(*SimulationResult_StopLoss)(nil),
 		(*SimulationResult_Dca)(nil),
 	}
-	file_v1_automation_proto_msgTypes[34].OneofWrappers = []any{}
+	file_v1_automation_proto_msgTypes[26].OneofWrappers = []any{}
+	file_v1_automation_proto_msgTypes[30].OneofWrappers = []any{}
+	file_v1_automation_proto_msgTypes[32].OneofWrappers = []any{}
+	file_v1_automation_proto_msgTypes[44].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_automation_proto_rawDesc), len(file_v1_automation_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   36,
+			NumMessages:   47,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -86,16 +86,261 @@ func (AssetType) EnumDescriptor() ([]byte, []int) {
 	return file_v1_marketdata_proto_rawDescGZIP(), []int{0}
 }
 
+// DayCountConvention is how a bond counts the time interest accrues over.
+type DayCountConvention int32
+
+const (
+	DayCountConvention_DAY_COUNT_CONVENTION_UNSPECIFIED DayCountConvention = 0
+	// 30/360 US bond basis: every month counts 30 days.
+	DayCountConvention_DAY_COUNT_CONVENTION_THIRTY_360 DayCountConvention = 1
+	// Actual/Actual ICMA: actual days over those of the coupon period.
+	DayCountConvention_DAY_COUNT_CONVENTION_ACTUAL_ACTUAL    DayCountConvention = 2
+	DayCountConvention_DAY_COUNT_CONVENTION_ACTUAL_360       DayCountConvention = 3
+	DayCountConvention_DAY_COUNT_CONVENTION_ACTUAL_365_FIXED DayCountConvention = 4
+)
+
+// Enum value maps for DayCountConvention.
+var (
+	DayCountConvention_name = map[int32]string{
+		0: "DAY_COUNT_CONVENTION_UNSPECIFIED",
+		1: "DAY_COUNT_CONVENTION_THIRTY_360",
+		2: "DAY_COUNT_CONVENTION_ACTUAL_ACTUAL",
+		3: "DAY_COUNT_CONVENTION_ACTUAL_360",
+		4: "DAY_COUNT_CONVENTION_ACTUAL_365_FIXED",
+	}
+	DayCountConvention_value = map[string]int32{
+		"DAY_COUNT_CONVENTION_UNSPECIFIED":      0,
+		"DAY_COUNT_CONVENTION_THIRTY_360":       1,
+		"DAY_COUNT_CONVENTION_ACTUAL_ACTUAL":    2,
+		"DAY_COUNT_CONVENTION_ACTUAL_360":       3,
+		"DAY_COUNT_CONVENTION_ACTUAL_365_FIXED": 4,
+	}
+)
+
+func (x DayCountConvention) Enum() *DayCountConvention {
+	p := new(DayCountConvention)
+	*p = x
+	return p
+}
+
+func (x DayCountConvention) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DayCountConvention) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_marketdata_proto_enumTypes[1].Descriptor()
+}
+
+func (DayCountConvention) Type() protoreflect.EnumType {
+	return &file_v1_marketdata_proto_enumTypes[1]
+}
+
+func (x DayCountConvention) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DayCountConvention.Descriptor instead.
+func (DayCountConvention) EnumDescriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{1}
+}
+
+type AssetIdentifierKind int32
+
+const (
+	AssetIdentifierKind_ASSET_IDENTIFIER_KIND_UNSPECIFIED AssetIdentifierKind = 0
+	// CoinGecko coin ID, e.g. "bitcoin".
+	AssetIdentifierKind_ASSET_IDENTIFIER_KIND_COINGECKO AssetIdentifierKind = 1
+	// Binance asset code, e.g. "BTC".
+	AssetIdentifierKind_ASSET_IDENTIFIER_KIND_BINANCE AssetIdentifierKind = 2
+	AssetIdentifierKind_ASSET_IDENTIFIER_KIND_ISIN    AssetIdentifierKind = 3
+	AssetIdentifierKind_ASSET_IDENTIFIER_KIND_CUSIP   AssetIdentifierKind = 4
+	AssetIdentifierKind_ASSET_IDENTIFIER_KIND_FIGI    AssetIdentifierKind = 5
+	// Token contract address; needs a chain.
+	AssetIdentifierKind_ASSET_IDENTIFIER_KIND_CONTRACT AssetIdentifierKind = 6
+	// Exchange ticker with the suffix of its exchange, e.g. "AAPL" or "VOD.L".
+	AssetIdentifierKind_ASSET_IDENTIFIER_KIND_TICKER AssetIdentifierKind = 7
+)
+
+// Enum value maps for AssetIdentifierKind.
+var (
+	AssetIdentifierKind_name = map[int32]string{
+		0: "ASSET_IDENTIFIER_KIND_UNSPECIFIED",
+		1: "ASSET_IDENTIFIER_KIND_COINGECKO",
+		2: "ASSET_IDENTIFIER_KIND_BINANCE",
+		3: "ASSET_IDENTIFIER_KIND_ISIN",
+		4: "ASSET_IDENTIFIER_KIND_CUSIP",
+		5: "ASSET_IDENTIFIER_KIND_FIGI",
+		6: "ASSET_IDENTIFIER_KIND_CONTRACT",
+		7: "ASSET_IDENTIFIER_KIND_TICKER",
+	}
+	AssetIdentifierKind_value = map[string]int32{
+		"ASSET_IDENTIFIER_KIND_UNSPECIFIED": 0,
+		"ASSET_IDENTIFIER_KIND_COINGECKO":   1,
+		"ASSET_IDENTIFIER_KIND_BINANCE":     2,
+		"ASSET_IDENTIFIER_KIND_ISIN":        3,
+		"ASSET_IDENTIFIER_KIND_CUSIP":       4,
+		"ASSET_IDENTIFIER_KIND_FIGI":        5,
+		"ASSET_IDENTIFIER_KIND_CONTRACT":    6,
+		"ASSET_IDENTIFIER_KIND_TICKER":      7,
+	}
+)
+
+func (x AssetIdentifierKind) Enum() *AssetIdentifierKind {
+	p := new(AssetIdentifierKind)
+	*p = x
+	return p
+}
+
+func (x AssetIdentifierKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AssetIdentifierKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_marketdata_proto_enumTypes[2].Descriptor()
+}
+
+func (AssetIdentifierKind) Type() protoreflect.EnumType {
+	return &file_v1_marketdata_proto_enumTypes[2]
+}
+
+func (x AssetIdentifierKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AssetIdentifierKind.Descriptor instead.
+func (AssetIdentifierKind) EnumDescriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{2}
+}
+
+type CorporateActionType int32
+
+const (
+	CorporateActionType_CORPORATE_ACTION_TYPE_UNSPECIFIED CorporateActionType = 0
+	// Units of the asset multiply by the ratio, as in a stock split, a
+	// reverse split or a redenomination.
+	CorporateActionType_CORPORATE_ACTION_TYPE_SPLIT CorporateActionType = 1
+	// Holdings move to another asset at the ratio, as in a token migration
+	// such as MATIC to POL, or a merger.
+	CorporateActionType_CORPORATE_ACTION_TYPE_MIGRATION CorporateActionType = 2
+	// The asset stops trading; holdings stay as they are.
+	CorporateActionType_CORPORATE_ACTION_TYPE_DELISTING CorporateActionType = 3
+)
+
+// Enum value maps for CorporateActionType.
+var (
+	CorporateActionType_name = map[int32]string{
+		0: "CORPORATE_ACTION_TYPE_UNSPECIFIED",
+		1: "CORPORATE_ACTION_TYPE_SPLIT",
+		2: "CORPORATE_ACTION_TYPE_MIGRATION",
+		3: "CORPORATE_ACTION_TYPE_DELISTING",
+	}
+	CorporateActionType_value = map[string]int32{
+		"CORPORATE_ACTION_TYPE_UNSPECIFIED": 0,
+		"CORPORATE_ACTION_TYPE_SPLIT":       1,
+		"CORPORATE_ACTION_TYPE_MIGRATION":   2,
+		"CORPORATE_ACTION_TYPE_DELISTING":   3,
+	}
+)
+
+func (x CorporateActionType) Enum() *CorporateActionType {
+	p := new(CorporateActionType)
+	*p = x
+	return p
+}
+
+func (x CorporateActionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CorporateActionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_marketdata_proto_enumTypes[3].Descriptor()
+}
+
+func (CorporateActionType) Type() protoreflect.EnumType {
+	return &file_v1_marketdata_proto_enumTypes[3]
+}
+
+func (x CorporateActionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CorporateActionType.Descriptor instead.
+func (CorporateActionType) EnumDescriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{3}
+}
+
+type CorporateActionStatus int32
+
+const (
+	CorporateActionStatus_CORPORATE_ACTION_STATUS_UNSPECIFIED CorporateActionStatus = 0
+	CorporateActionStatus_CORPORATE_ACTION_STATUS_PENDING     CorporateActionStatus = 1
+	CorporateActionStatus_CORPORATE_ACTION_STATUS_APPLIED     CorporateActionStatus = 2
+	CorporateActionStatus_CORPORATE_ACTION_STATUS_REVERTED    CorporateActionStatus = 3
+)
+
+// Enum value maps for CorporateActionStatus.
+var (
+	CorporateActionStatus_name = map[int32]string{
+		0: "CORPORATE_ACTION_STATUS_UNSPECIFIED",
+		1: "CORPORATE_ACTION_STATUS_PENDING",
+		2: "CORPORATE_ACTION_STATUS_APPLIED",
+		3: "CORPORATE_ACTION_STATUS_REVERTED",
+	}
+	CorporateActionStatus_value = map[string]int32{
+		"CORPORATE_ACTION_STATUS_UNSPECIFIED": 0,
+		"CORPORATE_ACTION_STATUS_PENDING":     1,
+		"CORPORATE_ACTION_STATUS_APPLIED":     2,
+		"CORPORATE_ACTION_STATUS_REVERTED":    3,
+	}
+)
+
+func (x CorporateActionStatus) Enum() *CorporateActionStatus {
+	p := new(CorporateActionStatus)
+	*p = x
+	return p
+}
+
+func (x CorporateActionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CorporateActionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_marketdata_proto_enumTypes[4].Descriptor()
+}
+
+func (CorporateActionStatus) Type() protoreflect.EnumType {
+	return &file_v1_marketdata_proto_enumTypes[4]
+}
+
+func (x CorporateActionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CorporateActionStatus.Descriptor instead.
+func (CorporateActionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{4}
+}
+
 // Asset represents financial instrument (crypto, stock, etc.).
 type Asset struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Type          AssetType              `protobuf:"varint,3,opt,name=type,proto3,enum=greedy_eye.v1.AssetType" json:"type,omitempty"`
-	Symbol        *string                `protobuf:"bytes,4,opt,name=symbol,proto3,oneof" json:"symbol,omitempty"`
-	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type      AssetType              `protobuf:"varint,3,opt,name=type,proto3,enum=greedy_eye.v1.AssetType" json:"type,omitempty"`
+	Symbol    *string                `protobuf:"bytes,4,opt,name=symbol,proto3,oneof" json:"symbol,omitempty"`
+	Tags      []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Descriptive fields by key, such as "description", "logo_url",
+	// "website", "category", "chain", "market_cap_rank" and
+	// "contract:<chain>". Entries set without a source are recorded as
+	// "manual".
+	Metadata map[string]*AssetMetadata `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// When metadata providers were last consulted by EnrichAssetData.
+	LastEnrichedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_enriched_at,json=lastEnrichedAt,proto3" json:"last_enriched_at,omitempty"`
+	// Terms of a bond; only assets of type ASSET_TYPE_BOND have them. Prices
+	// of a bond are clean, per unit, in its currency.
+	Bond          *BondTerms `protobuf:"bytes,10,opt,name=bond,proto3" json:"bond,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -179,44 +424,52 @@ func (x *Asset) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-// Price represents the price action of an Asset against a base Asset
-// over a specific interval (candle/OHLCV) or as a latest snapshot.
-type Price struct {
-	state       protoimpl.MessageState `protogen:"open.v1"`
-	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	SourceId    string                 `protobuf:"bytes,2,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`            // Identifier for the source (e.g., "coingecko", "binance")
-	AssetId     string                 `protobuf:"bytes,3,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`               // ID of the Asset whose price is represented
-	BaseAssetId string                 `protobuf:"bytes,4,opt,name=base_asset_id,json=baseAssetId,proto3" json:"base_asset_id,omitempty"` // ID of the Asset against which the price is quoted
-	// Interval defines the time period this price data represents.
-	// Examples: "1m", "5m", "1h", "4h", "1d", "tick", "latest".
-	Interval string `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"`
-	Decimals uint32 `protobuf:"varint,6,opt,name=decimals,proto3" json:"decimals,omitempty"`
-	Last     int64  `protobuf:"varint,7,opt,name=last,proto3" json:"last,omitempty"`
-	// OHLCV data - applicable when 'interval' represents a standard candle type.
-	Open          *int64                 `protobuf:"varint,8,opt,name=open,proto3,oneof" json:"open,omitempty"`
-	High          *int64                 `protobuf:"varint,9,opt,name=high,proto3,oneof" json:"high,omitempty"`
-	Low           *int64                 `protobuf:"varint,10,opt,name=low,proto3,oneof" json:"low,omitempty"`
-	Close         *int64                 `protobuf:"varint,11,opt,name=close,proto3,oneof" json:"close,omitempty"`
-	Volume        *int64                 `protobuf:"varint,12,opt,name=volume,proto3,oneof" json:"volume,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+func (x *Asset) GetMetadata() map[string]*AssetMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Asset) GetLastEnrichedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastEnrichedAt
+	}
+	return nil
+}
+
+func (x *Asset) GetBond() *BondTerms {
+	if x != nil {
+		return x.Bond
+	}
+	return nil
+}
+
+// AssetMetadata is a descriptive field of an asset and where it came from.
+type AssetMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Value string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// Provider the value came from, e.g. "coingecko", or "manual".
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Price) Reset() {
-	*x = Price{}
+func (x *AssetMetadata) Reset() {
+	*x = AssetMetadata{}
 	mi := &file_v1_marketdata_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Price) String() string {
+func (x *AssetMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Price) ProtoMessage() {}
+func (*AssetMetadata) ProtoMessage() {}
 
-func (x *Price) ProtoReflect() protoreflect.Message {
+func (x *AssetMetadata) ProtoReflect() protoreflect.Message {
 	mi := &file_v1_marketdata_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -228,124 +481,166 @@ func (x *Price) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Price.ProtoReflect.Descriptor instead.
-func (*Price) Descriptor() ([]byte, []int) {
+// Deprecated: Use AssetMetadata.ProtoReflect.Descriptor instead.
+func (*AssetMetadata) Descriptor() ([]byte, []int) {
 	return file_v1_marketdata_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Price) GetId() string {
+func (x *AssetMetadata) GetValue() string {
 	if x != nil {
-		return x.Id
+		return x.Value
 	}
 	return ""
 }
 
-func (x *Price) GetSourceId() string {
+func (x *AssetMetadata) GetSource() string {
 	if x != nil {
-		return x.SourceId
+		return x.Source
 	}
 	return ""
 }
 
-func (x *Price) GetAssetId() string {
+func (x *AssetMetadata) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.AssetId
+		return x.UpdatedAt
 	}
-	return ""
+	return nil
 }
 
-func (x *Price) GetBaseAssetId() string {
-	if x != nil {
-		return x.BaseAssetId
-	}
-	return ""
+// BondTerms are the terms of a fixed-rate bond. A unit is one bond. Amounts
+// are decimal strings.
+type BondTerms struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Repaid per unit at maturity.
+	FaceValue string `protobuf:"bytes,1,opt,name=face_value,json=faceValue,proto3" json:"face_value,omitempty"`
+	// Asset the bond pays in.
+	CurrencyAssetId string `protobuf:"bytes,2,opt,name=currency_asset_id,json=currencyAssetId,proto3" json:"currency_asset_id,omitempty"`
+	// Annual coupon as a fraction of face value, e.g. "0.045"; "0" or unset
+	// for zero-coupon bonds.
+	CouponRate string `protobuf:"bytes,3,opt,name=coupon_rate,json=couponRate,proto3" json:"coupon_rate,omitempty"`
+	// Coupons a year: 1, 2, 4 or 12; 0 for zero-coupon bonds. Coupons fall
+	// on the day of the month of maturity.
+	CouponFrequency uint32                 `protobuf:"varint,4,opt,name=coupon_frequency,json=couponFrequency,proto3" json:"coupon_frequency,omitempty"`
+	MaturityDate    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=maturity_date,json=maturityDate,proto3" json:"maturity_date,omitempty"`
+	// Interest accrues from the issue date in a first period it cuts short.
+	IssueDate *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=issue_date,json=issueDate,proto3" json:"issue_date,omitempty"`
+	// Required when the bond pays coupons.
+	DayCount      DayCountConvention `protobuf:"varint,7,opt,name=day_count,json=dayCount,proto3,enum=greedy_eye.v1.DayCountConvention" json:"day_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Price) GetInterval() string {
-	if x != nil {
-		return x.Interval
-	}
-	return ""
+func (x *BondTerms) Reset() {
+	*x = BondTerms{}
+	mi := &file_v1_marketdata_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Price) GetDecimals() uint32 {
-	if x != nil {
-		return x.Decimals
-	}
-	return 0
+func (x *BondTerms) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Price) GetLast() int64 {
+func (*BondTerms) ProtoMessage() {}
+
+func (x *BondTerms) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[2]
 	if x != nil {
-		return x.Last
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Price) GetOpen() int64 {
-	if x != nil && x.Open != nil {
-		return *x.Open
+// Deprecated: Use BondTerms.ProtoReflect.Descriptor instead.
+func (*BondTerms) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BondTerms) GetFaceValue() string {
+	if x != nil {
+		return x.FaceValue
 	}
-	return 0
+	return ""
 }
 
-func (x *Price) GetHigh() int64 {
-	if x != nil && x.High != nil {
-		return *x.High
+func (x *BondTerms) GetCurrencyAssetId() string {
+	if x != nil {
+		return x.CurrencyAssetId
 	}
-	return 0
+	return ""
 }
 
-func (x *Price) GetLow() int64 {
-	if x != nil && x.Low != nil {
-		return *x.Low
+func (x *BondTerms) GetCouponRate() string {
+	if x != nil {
+		return x.CouponRate
 	}
-	return 0
+	return ""
 }
 
-func (x *Price) GetClose() int64 {
-	if x != nil && x.Close != nil {
-		return *x.Close
+func (x *BondTerms) GetCouponFrequency() uint32 {
+	if x != nil {
+		return x.CouponFrequency
 	}
 	return 0
 }
 
-func (x *Price) GetVolume() int64 {
-	if x != nil && x.Volume != nil {
-		return *x.Volume
+func (x *BondTerms) GetMaturityDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MaturityDate
 	}
-	return 0
+	return nil
 }
 
-func (x *Price) GetTimestamp() *timestamppb.Timestamp {
+func (x *BondTerms) GetIssueDate() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Timestamp
+		return x.IssueDate
 	}
 	return nil
 }
 
-type CreateAssetRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Asset         *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+func (x *BondTerms) GetDayCount() DayCountConvention {
+	if x != nil {
+		return x.DayCount
+	}
+	return DayCountConvention_DAY_COUNT_CONVENTION_UNSPECIFIED
+}
+
+// AssetIdentifier maps an asset to an ID a provider or registry knows it
+// by. An identifier belongs to one asset.
+type AssetIdentifier struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AssetId string                 `protobuf:"bytes,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Kind    AssetIdentifierKind    `protobuf:"varint,3,opt,name=kind,proto3,enum=greedy_eye.v1.AssetIdentifierKind" json:"kind,omitempty"`
+	// Stored normalized: ISIN, CUSIP, FIGI and Binance codes upper case,
+	// CoinGecko IDs and 0x addresses lower case.
+	Value string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// Chain of a contract, e.g. "ethereum"; empty for other kinds.
+	Chain         string                 `protobuf:"bytes,5,opt,name=chain,proto3" json:"chain,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAssetRequest) Reset() {
-	*x = CreateAssetRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[2]
+func (x *AssetIdentifier) Reset() {
+	*x = AssetIdentifier{}
+	mi := &file_v1_marketdata_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAssetRequest) String() string {
+func (x *AssetIdentifier) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAssetRequest) ProtoMessage() {}
+func (*AssetIdentifier) ProtoMessage() {}
 
-func (x *CreateAssetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[2]
+func (x *AssetIdentifier) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -356,40 +651,2373 @@ func (x *CreateAssetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAssetRequest.ProtoReflect.Descriptor instead.
-func (*CreateAssetRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use AssetIdentifier.ProtoReflect.Descriptor instead.
+func (*AssetIdentifier) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *CreateAssetRequest) GetAsset() *Asset {
+func (x *AssetIdentifier) GetId() string {
 	if x != nil {
-		return x.Asset
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type GetAssetRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AssetIdentifier) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
 }
 
-func (x *GetAssetRequest) Reset() {
-	*x = GetAssetRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
+func (x *AssetIdentifier) GetKind() AssetIdentifierKind {
+	if x != nil {
+		return x.Kind
+	}
+	return AssetIdentifierKind_ASSET_IDENTIFIER_KIND_UNSPECIFIED
+}
+
+func (x *AssetIdentifier) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *AssetIdentifier) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *AssetIdentifier) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// CorporateAction is a change to an asset that holdings, lots and prices
+// follow once applied. Holders get new_units of the resulting asset for
+// every old_units they held: a 2-for-1 split is 2:1, a 1-for-10 reverse
+// split 1:10.
+type CorporateAction struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AssetId     string                 `protobuf:"bytes,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Type        CorporateActionType    `protobuf:"varint,3,opt,name=type,proto3,enum=greedy_eye.v1.CorporateActionType" json:"type,omitempty"`
+	Status      CorporateActionStatus  `protobuf:"varint,4,opt,name=status,proto3,enum=greedy_eye.v1.CorporateActionStatus" json:"status,omitempty"`
+	EffectiveAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=effective_at,json=effectiveAt,proto3" json:"effective_at,omitempty"`
+	// Ratio of splits and migrations; migrations default to 1:1.
+	NewUnits int64 `protobuf:"varint,6,opt,name=new_units,json=newUnits,proto3" json:"new_units,omitempty"`
+	OldUnits int64 `protobuf:"varint,7,opt,name=old_units,json=oldUnits,proto3" json:"old_units,omitempty"`
+	// Asset holdings migrate to.
+	NewAssetId    *string                `protobuf:"bytes,8,opt,name=new_asset_id,json=newAssetId,proto3,oneof" json:"new_asset_id,omitempty"`
+	Note          string                 `protobuf:"bytes,9,opt,name=note,proto3" json:"note,omitempty"`
+	AppliedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=applied_at,json=appliedAt,proto3,oneof" json:"applied_at,omitempty"`
+	RevertedAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=reverted_at,json=revertedAt,proto3,oneof" json:"reverted_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CorporateAction) Reset() {
+	*x = CorporateAction{}
+	mi := &file_v1_marketdata_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CorporateAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorporateAction) ProtoMessage() {}
+
+func (x *CorporateAction) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorporateAction.ProtoReflect.Descriptor instead.
+func (*CorporateAction) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CorporateAction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CorporateAction) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *CorporateAction) GetType() CorporateActionType {
+	if x != nil {
+		return x.Type
+	}
+	return CorporateActionType_CORPORATE_ACTION_TYPE_UNSPECIFIED
+}
+
+func (x *CorporateAction) GetStatus() CorporateActionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CorporateActionStatus_CORPORATE_ACTION_STATUS_UNSPECIFIED
+}
+
+func (x *CorporateAction) GetEffectiveAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveAt
+	}
+	return nil
+}
+
+func (x *CorporateAction) GetNewUnits() int64 {
+	if x != nil {
+		return x.NewUnits
+	}
+	return 0
+}
+
+func (x *CorporateAction) GetOldUnits() int64 {
+	if x != nil {
+		return x.OldUnits
+	}
+	return 0
+}
+
+func (x *CorporateAction) GetNewAssetId() string {
+	if x != nil && x.NewAssetId != nil {
+		return *x.NewAssetId
+	}
+	return ""
+}
+
+func (x *CorporateAction) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *CorporateAction) GetAppliedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AppliedAt
+	}
+	return nil
+}
+
+func (x *CorporateAction) GetRevertedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevertedAt
+	}
+	return nil
+}
+
+func (x *CorporateAction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CorporateAction) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Price represents the price action of an Asset against a base Asset
+// over a specific interval (candle/OHLCV) or as a latest snapshot.
+type Price struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SourceId    string                 `protobuf:"bytes,2,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`            // Identifier for the source (e.g., "coingecko", "binance")
+	AssetId     string                 `protobuf:"bytes,3,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`               // ID of the Asset whose price is represented
+	BaseAssetId string                 `protobuf:"bytes,4,opt,name=base_asset_id,json=baseAssetId,proto3" json:"base_asset_id,omitempty"` // ID of the Asset against which the price is quoted
+	// Interval defines the time period this price data represents.
+	// Examples: "1m", "5m", "1h", "4h", "1d", "tick", "latest".
+	Interval string `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"`
+	Decimals uint32 `protobuf:"varint,6,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	Last     int64  `protobuf:"varint,7,opt,name=last,proto3" json:"last,omitempty"`
+	// OHLCV data - applicable when 'interval' represents a standard candle type.
+	Open      *int64                 `protobuf:"varint,8,opt,name=open,proto3,oneof" json:"open,omitempty"`
+	High      *int64                 `protobuf:"varint,9,opt,name=high,proto3,oneof" json:"high,omitempty"`
+	Low       *int64                 `protobuf:"varint,10,opt,name=low,proto3,oneof" json:"low,omitempty"`
+	Close     *int64                 `protobuf:"varint,11,opt,name=close,proto3,oneof" json:"close,omitempty"`
+	Volume    *int64                 `protobuf:"varint,12,opt,name=volume,proto3,oneof" json:"volume,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Quarantine is why the price failed the checks it went through when it
+	// was stored, empty if it passed: "jump" when it moved too far from the
+	// last price of the pair, "deviation" when it was too far from other
+	// sources. Quarantined prices are kept but not used for valuations.
+	Quarantine    string `protobuf:"bytes,14,opt,name=quarantine,proto3" json:"quarantine,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Price) Reset() {
+	*x = Price{}
+	mi := &file_v1_marketdata_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Price) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Price) ProtoMessage() {}
+
+func (x *Price) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Price.ProtoReflect.Descriptor instead.
+func (*Price) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Price) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Price) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *Price) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *Price) GetBaseAssetId() string {
+	if x != nil {
+		return x.BaseAssetId
+	}
+	return ""
+}
+
+func (x *Price) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *Price) GetDecimals() uint32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *Price) GetLast() int64 {
+	if x != nil {
+		return x.Last
+	}
+	return 0
+}
+
+func (x *Price) GetOpen() int64 {
+	if x != nil && x.Open != nil {
+		return *x.Open
+	}
+	return 0
+}
+
+func (x *Price) GetHigh() int64 {
+	if x != nil && x.High != nil {
+		return *x.High
+	}
+	return 0
+}
+
+func (x *Price) GetLow() int64 {
+	if x != nil && x.Low != nil {
+		return *x.Low
+	}
+	return 0
+}
+
+func (x *Price) GetClose() int64 {
+	if x != nil && x.Close != nil {
+		return *x.Close
+	}
+	return 0
+}
+
+func (x *Price) GetVolume() int64 {
+	if x != nil && x.Volume != nil {
+		return *x.Volume
+	}
+	return 0
+}
+
+func (x *Price) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Price) GetQuarantine() string {
+	if x != nil {
+		return x.Quarantine
+	}
+	return ""
+}
+
+type CreateAssetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAssetRequest) Reset() {
+	*x = CreateAssetRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAssetRequest) ProtoMessage() {}
+
+func (x *CreateAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAssetRequest.ProtoReflect.Descriptor instead.
+func (*CreateAssetRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateAssetRequest) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+type GetAssetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssetRequest) Reset() {
+	*x = GetAssetRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssetRequest) ProtoMessage() {}
+
+func (x *GetAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssetRequest.ProtoReflect.Descriptor instead.
+func (*GetAssetRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetAssetRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateAssetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAssetRequest) Reset() {
+	*x = UpdateAssetRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAssetRequest) ProtoMessage() {}
+
+func (x *UpdateAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAssetRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAssetRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateAssetRequest) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+func (x *UpdateAssetRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteAssetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAssetRequest) Reset() {
+	*x = DeleteAssetRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAssetRequest) ProtoMessage() {}
+
+func (x *DeleteAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAssetRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAssetRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteAssetRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListAssetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      *int32                 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	PageToken     *string                `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetsRequest) Reset() {
+	*x = ListAssetsRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetsRequest) ProtoMessage() {}
+
+func (x *ListAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetsRequest.ProtoReflect.Descriptor instead.
+func (*ListAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListAssetsRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAssetsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+func (x *ListAssetsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type ListAssetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assets        []*Asset               `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetsResponse) Reset() {
+	*x = ListAssetsResponse{}
+	mi := &file_v1_marketdata_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetsResponse) ProtoMessage() {}
+
+func (x *ListAssetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetsResponse.ProtoReflect.Descriptor instead.
+func (*ListAssetsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListAssetsResponse) GetAssets() []*Asset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+func (x *ListAssetsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SearchAssetsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matched against symbols and identifiers whole, and against the words
+	// of names and symbols as prefixes.
+	Query string     `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Type  *AssetType `protobuf:"varint,2,opt,name=type,proto3,enum=greedy_eye.v1.AssetType,oneof" json:"type,omitempty"`
+	// Only assets with all of these tags.
+	Tags []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Defaults to 20, at most 100.
+	PageSize  *int32  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	PageToken *string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	// Ask external providers when no stored asset matches; otherwise the
+	// stored assets most similar to the query are returned.
+	IncludeExternal bool `protobuf:"varint,6,opt,name=include_external,json=includeExternal,proto3" json:"include_external,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SearchAssetsRequest) Reset() {
+	*x = SearchAssetsRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAssetsRequest) ProtoMessage() {}
+
+func (x *SearchAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAssetsRequest.ProtoReflect.Descriptor instead.
+func (*SearchAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchAssetsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchAssetsRequest) GetType() AssetType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return AssetType_ASSET_TYPE_UNSPECIFIED
+}
+
+func (x *SearchAssetsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SearchAssetsRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchAssetsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchAssetsRequest) GetIncludeExternal() bool {
+	if x != nil {
+		return x.IncludeExternal
+	}
+	return false
+}
+
+type SearchAssetsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Best match first.
+	Assets        []*Asset `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	NextPageToken string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Provider results, only when no stored asset matched.
+	ExternalAssets []*ExternalAsset `protobuf:"bytes,3,rep,name=external_assets,json=externalAssets,proto3" json:"external_assets,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchAssetsResponse) Reset() {
+	*x = SearchAssetsResponse{}
+	mi := &file_v1_marketdata_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchAssetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAssetsResponse) ProtoMessage() {}
+
+func (x *SearchAssetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAssetsResponse.ProtoReflect.Descriptor instead.
+func (*SearchAssetsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SearchAssetsResponse) GetAssets() []*Asset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+func (x *SearchAssetsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *SearchAssetsResponse) GetExternalAssets() []*ExternalAsset {
+	if x != nil {
+		return x.ExternalAssets
+	}
+	return nil
+}
+
+// ExternalAsset is an asset a provider knows.
+type ExternalAsset struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Provider, e.g. "coingecko".
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// The provider's ID for the asset, to import it by.
+	ExternalId    string    `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Symbol        string    `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Name          string    `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Type          AssetType `protobuf:"varint,5,opt,name=type,proto3,enum=greedy_eye.v1.AssetType" json:"type,omitempty"`
+	MarketCapRank *int32    `protobuf:"varint,6,opt,name=market_cap_rank,json=marketCapRank,proto3,oneof" json:"market_cap_rank,omitempty"`
+	ImageUrl      string    `protobuf:"bytes,7,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	// Stored asset already mapped to the external ID.
+	AssetId       *string `protobuf:"bytes,8,opt,name=asset_id,json=assetId,proto3,oneof" json:"asset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExternalAsset) Reset() {
+	*x = ExternalAsset{}
+	mi := &file_v1_marketdata_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExternalAsset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalAsset) ProtoMessage() {}
+
+func (x *ExternalAsset) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalAsset.ProtoReflect.Descriptor instead.
+func (*ExternalAsset) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExternalAsset) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ExternalAsset) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *ExternalAsset) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ExternalAsset) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExternalAsset) GetType() AssetType {
+	if x != nil {
+		return x.Type
+	}
+	return AssetType_ASSET_TYPE_UNSPECIFIED
+}
+
+func (x *ExternalAsset) GetMarketCapRank() int32 {
+	if x != nil && x.MarketCapRank != nil {
+		return *x.MarketCapRank
+	}
+	return 0
+}
+
+func (x *ExternalAsset) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *ExternalAsset) GetAssetId() string {
+	if x != nil && x.AssetId != nil {
+		return *x.AssetId
+	}
+	return ""
+}
+
+type ImportExternalAssetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	ExternalId    string                 `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportExternalAssetRequest) Reset() {
+	*x = ImportExternalAssetRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportExternalAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportExternalAssetRequest) ProtoMessage() {}
+
+func (x *ImportExternalAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportExternalAssetRequest.ProtoReflect.Descriptor instead.
+func (*ImportExternalAssetRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ImportExternalAssetRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ImportExternalAssetRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+type EnrichAssetDataRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AssetId string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	// Metadata providers to consult, e.g. "coingecko". Defaults to all.
+	Sources       []string `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrichAssetDataRequest) Reset() {
+	*x = EnrichAssetDataRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrichAssetDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrichAssetDataRequest) ProtoMessage() {}
+
+func (x *EnrichAssetDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrichAssetDataRequest.ProtoReflect.Descriptor instead.
+func (*EnrichAssetDataRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EnrichAssetDataRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *EnrichAssetDataRequest) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+type FindSimilarAssetsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AssetId string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	// Defaults to 10, at most 100.
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Days of daily returns to correlate. Defaults to 90.
+	LookbackDays *int32 `protobuf:"varint,3,opt,name=lookback_days,json=lookbackDays,proto3,oneof" json:"lookback_days,omitempty"`
+	// Asset the correlated prices are quoted in. Defaults to the one most of
+	// the asset's prices in the lookback window use.
+	BaseAssetId   *string `protobuf:"bytes,4,opt,name=base_asset_id,json=baseAssetId,proto3,oneof" json:"base_asset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSimilarAssetsRequest) Reset() {
+	*x = FindSimilarAssetsRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSimilarAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSimilarAssetsRequest) ProtoMessage() {}
+
+func (x *FindSimilarAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSimilarAssetsRequest.ProtoReflect.Descriptor instead.
+func (*FindSimilarAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FindSimilarAssetsRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *FindSimilarAssetsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *FindSimilarAssetsRequest) GetLookbackDays() int32 {
+	if x != nil && x.LookbackDays != nil {
+		return *x.LookbackDays
+	}
+	return 0
+}
+
+func (x *FindSimilarAssetsRequest) GetBaseAssetId() string {
+	if x != nil && x.BaseAssetId != nil {
+		return *x.BaseAssetId
+	}
+	return ""
+}
+
+// SimilarAsset is a candidate with its score and how it was reached.
+type SimilarAsset struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Asset *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	// Weighted sum of tag similarity (0.4), same type (0.2) and positive
+	// correlation (0.4), from 0 to 1.
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// Jaccard index of the two assets' tags.
+	TagSimilarity float64 `protobuf:"fixed64,3,opt,name=tag_similarity,json=tagSimilarity,proto3" json:"tag_similarity,omitempty"`
+	SameType      bool    `protobuf:"varint,4,opt,name=same_type,json=sameType,proto3" json:"same_type,omitempty"`
+	// Pearson correlation of daily returns, if enough days overlap.
+	Correlation *float64 `protobuf:"fixed64,5,opt,name=correlation,proto3,oneof" json:"correlation,omitempty"`
+	// Number of daily returns correlated.
+	CorrelationDays int32 `protobuf:"varint,6,opt,name=correlation_days,json=correlationDays,proto3" json:"correlation_days,omitempty"`
+	// Why the asset matched, for display.
+	Reasons       []string `protobuf:"bytes,7,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarAsset) Reset() {
+	*x = SimilarAsset{}
+	mi := &file_v1_marketdata_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarAsset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarAsset) ProtoMessage() {}
+
+func (x *SimilarAsset) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarAsset.ProtoReflect.Descriptor instead.
+func (*SimilarAsset) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SimilarAsset) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+func (x *SimilarAsset) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SimilarAsset) GetTagSimilarity() float64 {
+	if x != nil {
+		return x.TagSimilarity
+	}
+	return 0
+}
+
+func (x *SimilarAsset) GetSameType() bool {
+	if x != nil {
+		return x.SameType
+	}
+	return false
+}
+
+func (x *SimilarAsset) GetCorrelation() float64 {
+	if x != nil && x.Correlation != nil {
+		return *x.Correlation
+	}
+	return 0
+}
+
+func (x *SimilarAsset) GetCorrelationDays() int32 {
+	if x != nil {
+		return x.CorrelationDays
+	}
+	return 0
+}
+
+func (x *SimilarAsset) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+type FindSimilarAssetsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Best first.
+	Assets []*SimilarAsset `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	// Asset the correlated prices are quoted in; unset without prices.
+	BaseAssetId   *string `protobuf:"bytes,2,opt,name=base_asset_id,json=baseAssetId,proto3,oneof" json:"base_asset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSimilarAssetsResponse) Reset() {
+	*x = FindSimilarAssetsResponse{}
+	mi := &file_v1_marketdata_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSimilarAssetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSimilarAssetsResponse) ProtoMessage() {}
+
+func (x *FindSimilarAssetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSimilarAssetsResponse.ProtoReflect.Descriptor instead.
+func (*FindSimilarAssetsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FindSimilarAssetsResponse) GetAssets() []*SimilarAsset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+func (x *FindSimilarAssetsResponse) GetBaseAssetId() string {
+	if x != nil && x.BaseAssetId != nil {
+		return *x.BaseAssetId
+	}
+	return ""
+}
+
+type GetBondAnalyticsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AssetId string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	// Defaults to now.
+	SettlementTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=settlement_time,json=settlementTime,proto3" json:"settlement_time,omitempty"`
+	// Clean price per unit in the bond's currency, as a decimal string.
+	// Defaults to the last price stored in that currency at settlement.
+	CleanPrice    *string `protobuf:"bytes,3,opt,name=clean_price,json=cleanPrice,proto3,oneof" json:"clean_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBondAnalyticsRequest) Reset() {
+	*x = GetBondAnalyticsRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBondAnalyticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBondAnalyticsRequest) ProtoMessage() {}
+
+func (x *GetBondAnalyticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBondAnalyticsRequest.ProtoReflect.Descriptor instead.
+func (*GetBondAnalyticsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetBondAnalyticsRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *GetBondAnalyticsRequest) GetSettlementTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SettlementTime
+	}
+	return nil
+}
+
+func (x *GetBondAnalyticsRequest) GetCleanPrice() string {
+	if x != nil && x.CleanPrice != nil {
+		return *x.CleanPrice
+	}
+	return ""
+}
+
+// BondCashFlow is a payment per unit of a bond.
+type BondCashFlow struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PaymentDate *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=payment_date,json=paymentDate,proto3" json:"payment_date,omitempty"`
+	Coupon      string                 `protobuf:"bytes,2,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	// Face value on the maturity date, "0" before.
+	Principal     string `protobuf:"bytes,3,opt,name=principal,proto3" json:"principal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BondCashFlow) Reset() {
+	*x = BondCashFlow{}
+	mi := &file_v1_marketdata_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BondCashFlow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BondCashFlow) ProtoMessage() {}
+
+func (x *BondCashFlow) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BondCashFlow.ProtoReflect.Descriptor instead.
+func (*BondCashFlow) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BondCashFlow) GetPaymentDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PaymentDate
+	}
+	return nil
+}
+
+func (x *BondCashFlow) GetCoupon() string {
+	if x != nil {
+		return x.Coupon
+	}
+	return ""
+}
+
+func (x *BondCashFlow) GetPrincipal() string {
+	if x != nil {
+		return x.Principal
+	}
+	return ""
+}
+
+// GetBondAnalyticsResponse holds amounts per unit, in the bond's currency,
+// as decimal strings.
+type GetBondAnalyticsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AssetId         string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	CurrencyAssetId string                 `protobuf:"bytes,2,opt,name=currency_asset_id,json=currencyAssetId,proto3" json:"currency_asset_id,omitempty"`
+	SettlementTime  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=settlement_time,json=settlementTime,proto3" json:"settlement_time,omitempty"`
+	Matured         bool                   `protobuf:"varint,4,opt,name=matured,proto3" json:"matured,omitempty"`
+	AccruedInterest string                 `protobuf:"bytes,5,opt,name=accrued_interest,json=accruedInterest,proto3" json:"accrued_interest,omitempty"`
+	// Unset before the first coupon.
+	PreviousCouponDate *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=previous_coupon_date,json=previousCouponDate,proto3" json:"previous_coupon_date,omitempty"`
+	// Unset once matured.
+	NextCouponDate *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=next_coupon_date,json=nextCouponDate,proto3" json:"next_coupon_date,omitempty"`
+	// Payments after settlement, through maturity.
+	CashFlows []*BondCashFlow `protobuf:"bytes,8,rep,name=cash_flows,json=cashFlows,proto3" json:"cash_flows,omitempty"`
+	// Unset without a price.
+	CleanPrice *string `protobuf:"bytes,9,opt,name=clean_price,json=cleanPrice,proto3,oneof" json:"clean_price,omitempty"`
+	// Clean price plus accrued interest.
+	DirtyPrice *string `protobuf:"bytes,10,opt,name=dirty_price,json=dirtyPrice,proto3,oneof" json:"dirty_price,omitempty"`
+	// Annual yield, compounded at the coupon frequency (yearly for
+	// zero-coupon bonds), as a fraction, e.g. "0.0525". Unset without a
+	// price or once matured.
+	YieldToMaturity *string `protobuf:"bytes,11,opt,name=yield_to_maturity,json=yieldToMaturity,proto3,oneof" json:"yield_to_maturity,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetBondAnalyticsResponse) Reset() {
+	*x = GetBondAnalyticsResponse{}
+	mi := &file_v1_marketdata_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBondAnalyticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBondAnalyticsResponse) ProtoMessage() {}
+
+func (x *GetBondAnalyticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBondAnalyticsResponse.ProtoReflect.Descriptor instead.
+func (*GetBondAnalyticsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetBondAnalyticsResponse) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *GetBondAnalyticsResponse) GetCurrencyAssetId() string {
+	if x != nil {
+		return x.CurrencyAssetId
+	}
+	return ""
+}
+
+func (x *GetBondAnalyticsResponse) GetSettlementTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SettlementTime
+	}
+	return nil
+}
+
+func (x *GetBondAnalyticsResponse) GetMatured() bool {
+	if x != nil {
+		return x.Matured
+	}
+	return false
+}
+
+func (x *GetBondAnalyticsResponse) GetAccruedInterest() string {
+	if x != nil {
+		return x.AccruedInterest
+	}
+	return ""
+}
+
+func (x *GetBondAnalyticsResponse) GetPreviousCouponDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PreviousCouponDate
+	}
+	return nil
+}
+
+func (x *GetBondAnalyticsResponse) GetNextCouponDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextCouponDate
+	}
+	return nil
+}
+
+func (x *GetBondAnalyticsResponse) GetCashFlows() []*BondCashFlow {
+	if x != nil {
+		return x.CashFlows
+	}
+	return nil
+}
+
+func (x *GetBondAnalyticsResponse) GetCleanPrice() string {
+	if x != nil && x.CleanPrice != nil {
+		return *x.CleanPrice
+	}
+	return ""
+}
+
+func (x *GetBondAnalyticsResponse) GetDirtyPrice() string {
+	if x != nil && x.DirtyPrice != nil {
+		return *x.DirtyPrice
+	}
+	return ""
+}
+
+func (x *GetBondAnalyticsResponse) GetYieldToMaturity() string {
+	if x != nil && x.YieldToMaturity != nil {
+		return *x.YieldToMaturity
+	}
+	return ""
+}
+
+type CreateAssetIdentifierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *AssetIdentifier       `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAssetIdentifierRequest) Reset() {
+	*x = CreateAssetIdentifierRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAssetIdentifierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAssetIdentifierRequest) ProtoMessage() {}
+
+func (x *CreateAssetIdentifierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAssetIdentifierRequest.ProtoReflect.Descriptor instead.
+func (*CreateAssetIdentifierRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateAssetIdentifierRequest) GetIdentifier() *AssetIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+type DeleteAssetIdentifierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAssetIdentifierRequest) Reset() {
+	*x = DeleteAssetIdentifierRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAssetIdentifierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAssetIdentifierRequest) ProtoMessage() {}
+
+func (x *DeleteAssetIdentifierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAssetIdentifierRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAssetIdentifierRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteAssetIdentifierRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListAssetIdentifiersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetIdentifiersRequest) Reset() {
+	*x = ListAssetIdentifiersRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetIdentifiersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetIdentifiersRequest) ProtoMessage() {}
+
+func (x *ListAssetIdentifiersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetIdentifiersRequest.ProtoReflect.Descriptor instead.
+func (*ListAssetIdentifiersRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListAssetIdentifiersRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+type ListAssetIdentifiersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifiers   []*AssetIdentifier     `protobuf:"bytes,1,rep,name=identifiers,proto3" json:"identifiers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetIdentifiersResponse) Reset() {
+	*x = ListAssetIdentifiersResponse{}
+	mi := &file_v1_marketdata_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetIdentifiersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetIdentifiersResponse) ProtoMessage() {}
+
+func (x *ListAssetIdentifiersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetIdentifiersResponse.ProtoReflect.Descriptor instead.
+func (*ListAssetIdentifiersResponse) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListAssetIdentifiersResponse) GetIdentifiers() []*AssetIdentifier {
+	if x != nil {
+		return x.Identifiers
+	}
+	return nil
+}
+
+type ResolveAssetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unspecified tries every kind, then the symbol.
+	Kind  AssetIdentifierKind `protobuf:"varint,1,opt,name=kind,proto3,enum=greedy_eye.v1.AssetIdentifierKind" json:"kind,omitempty"`
+	Value string              `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Narrows contract addresses to one chain.
+	Chain         *string `protobuf:"bytes,3,opt,name=chain,proto3,oneof" json:"chain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveAssetRequest) Reset() {
+	*x = ResolveAssetRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveAssetRequest) ProtoMessage() {}
+
+func (x *ResolveAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveAssetRequest.ProtoReflect.Descriptor instead.
+func (*ResolveAssetRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ResolveAssetRequest) GetKind() AssetIdentifierKind {
+	if x != nil {
+		return x.Kind
+	}
+	return AssetIdentifierKind_ASSET_IDENTIFIER_KIND_UNSPECIFIED
+}
+
+func (x *ResolveAssetRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ResolveAssetRequest) GetChain() string {
+	if x != nil && x.Chain != nil {
+		return *x.Chain
+	}
+	return ""
+}
+
+// AssetMatch is an asset an identifier resolved to.
+type AssetMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Asset *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	// The identifier that matched; unset if the asset matched by symbol.
+	Identifier    *AssetIdentifier `protobuf:"bytes,2,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetMatch) Reset() {
+	*x = AssetMatch{}
+	mi := &file_v1_marketdata_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetMatch) ProtoMessage() {}
+
+func (x *AssetMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetMatch.ProtoReflect.Descriptor instead.
+func (*AssetMatch) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AssetMatch) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+func (x *AssetMatch) GetIdentifier() *AssetIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+type ResolveAssetResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The asset, unless the value is ambiguous.
+	Asset     *Asset `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Ambiguous bool   `protobuf:"varint,2,opt,name=ambiguous,proto3" json:"ambiguous,omitempty"`
+	// Every asset that matched, at most 10.
+	Matches       []*AssetMatch `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveAssetResponse) Reset() {
+	*x = ResolveAssetResponse{}
+	mi := &file_v1_marketdata_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveAssetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveAssetResponse) ProtoMessage() {}
+
+func (x *ResolveAssetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveAssetResponse.ProtoReflect.Descriptor instead.
+func (*ResolveAssetResponse) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ResolveAssetResponse) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+func (x *ResolveAssetResponse) GetAmbiguous() bool {
+	if x != nil {
+		return x.Ambiguous
+	}
+	return false
+}
+
+func (x *ResolveAssetResponse) GetMatches() []*AssetMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type CreateCorporateActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        *CorporateAction       `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCorporateActionRequest) Reset() {
+	*x = CreateCorporateActionRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCorporateActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCorporateActionRequest) ProtoMessage() {}
+
+func (x *CreateCorporateActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCorporateActionRequest.ProtoReflect.Descriptor instead.
+func (*CreateCorporateActionRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateCorporateActionRequest) GetAction() *CorporateAction {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+type GetCorporateActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCorporateActionRequest) Reset() {
+	*x = GetCorporateActionRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCorporateActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCorporateActionRequest) ProtoMessage() {}
+
+func (x *GetCorporateActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCorporateActionRequest.ProtoReflect.Descriptor instead.
+func (*GetCorporateActionRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetCorporateActionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListCorporateActionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Actions on the asset or migrating to it.
+	AssetId       *string                `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3,oneof" json:"asset_id,omitempty"`
+	Status        *CorporateActionStatus `protobuf:"varint,2,opt,name=status,proto3,enum=greedy_eye.v1.CorporateActionStatus,oneof" json:"status,omitempty"`
+	PageSize      *int32                 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	PageToken     *string                `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCorporateActionsRequest) Reset() {
+	*x = ListCorporateActionsRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCorporateActionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCorporateActionsRequest) ProtoMessage() {}
+
+func (x *ListCorporateActionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCorporateActionsRequest.ProtoReflect.Descriptor instead.
+func (*ListCorporateActionsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListCorporateActionsRequest) GetAssetId() string {
+	if x != nil && x.AssetId != nil {
+		return *x.AssetId
+	}
+	return ""
+}
+
+func (x *ListCorporateActionsRequest) GetStatus() CorporateActionStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return CorporateActionStatus_CORPORATE_ACTION_STATUS_UNSPECIFIED
+}
+
+func (x *ListCorporateActionsRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCorporateActionsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+type ListCorporateActionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	Actions       []*CorporateAction `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
+	NextPageToken string             `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCorporateActionsResponse) Reset() {
+	*x = ListCorporateActionsResponse{}
+	mi := &file_v1_marketdata_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCorporateActionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (x *GetAssetRequest) String() string {
+func (*ListCorporateActionsResponse) ProtoMessage() {}
+
+func (x *ListCorporateActionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCorporateActionsResponse.ProtoReflect.Descriptor instead.
+func (*ListCorporateActionsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListCorporateActionsResponse) GetActions() []*CorporateAction {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *ListCorporateActionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DeleteCorporateActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCorporateActionRequest) Reset() {
+	*x = DeleteCorporateActionRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCorporateActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCorporateActionRequest) ProtoMessage() {}
+
+func (x *DeleteCorporateActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCorporateActionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCorporateActionRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteCorporateActionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ApplyCorporateActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyCorporateActionRequest) Reset() {
+	*x = ApplyCorporateActionRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyCorporateActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyCorporateActionRequest) ProtoMessage() {}
+
+func (x *ApplyCorporateActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyCorporateActionRequest.ProtoReflect.Descriptor instead.
+func (*ApplyCorporateActionRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ApplyCorporateActionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ApplyCorporateActionResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Action           *CorporateAction       `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	HoldingsAdjusted int32                  `protobuf:"varint,2,opt,name=holdings_adjusted,json=holdingsAdjusted,proto3" json:"holdings_adjusted,omitempty"`
+	LotsAdjusted     int32                  `protobuf:"varint,3,opt,name=lots_adjusted,json=lotsAdjusted,proto3" json:"lots_adjusted,omitempty"`
+	PricesAdjusted   int32                  `protobuf:"varint,4,opt,name=prices_adjusted,json=pricesAdjusted,proto3" json:"prices_adjusted,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ApplyCorporateActionResponse) Reset() {
+	*x = ApplyCorporateActionResponse{}
+	mi := &file_v1_marketdata_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyCorporateActionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyCorporateActionResponse) ProtoMessage() {}
+
+func (x *ApplyCorporateActionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyCorporateActionResponse.ProtoReflect.Descriptor instead.
+func (*ApplyCorporateActionResponse) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ApplyCorporateActionResponse) GetAction() *CorporateAction {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *ApplyCorporateActionResponse) GetHoldingsAdjusted() int32 {
+	if x != nil {
+		return x.HoldingsAdjusted
+	}
+	return 0
+}
+
+func (x *ApplyCorporateActionResponse) GetLotsAdjusted() int32 {
+	if x != nil {
+		return x.LotsAdjusted
+	}
+	return 0
+}
+
+func (x *ApplyCorporateActionResponse) GetPricesAdjusted() int32 {
+	if x != nil {
+		return x.PricesAdjusted
+	}
+	return 0
+}
+
+type RevertCorporateActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertCorporateActionRequest) Reset() {
+	*x = RevertCorporateActionRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertCorporateActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertCorporateActionRequest) ProtoMessage() {}
+
+func (x *RevertCorporateActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertCorporateActionRequest.ProtoReflect.Descriptor instead.
+func (*RevertCorporateActionRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RevertCorporateActionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreatePriceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Price         *Price                 `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePriceRequest) Reset() {
+	*x = CreatePriceRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePriceRequest) ProtoMessage() {}
+
+func (x *CreatePriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePriceRequest.ProtoReflect.Descriptor instead.
+func (*CreatePriceRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreatePriceRequest) GetPrice() *Price {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type CreatePricesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prices        []*Price               `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePricesRequest) Reset() {
+	*x = CreatePricesRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePricesRequest) ProtoMessage() {}
+
+func (x *CreatePricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePricesRequest.ProtoReflect.Descriptor instead.
+func (*CreatePricesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreatePricesRequest) GetPrices() []*Price {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+type CreatePricesResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	CreatedCount int32                  `protobuf:"varint,1,opt,name=created_count,json=createdCount,proto3" json:"created_count,omitempty"`
+	// Prices that failed the ingestion checks; those stored are quarantined.
+	QuarantinedCount int32 `protobuf:"varint,2,opt,name=quarantined_count,json=quarantinedCount,proto3" json:"quarantined_count,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreatePricesResponse) Reset() {
+	*x = CreatePricesResponse{}
+	mi := &file_v1_marketdata_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePricesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAssetRequest) ProtoMessage() {}
+func (*CreatePricesResponse) ProtoMessage() {}
 
-func (x *GetAssetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[3]
+func (x *CreatePricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -400,41 +3028,49 @@ func (x *GetAssetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAssetRequest.ProtoReflect.Descriptor instead.
-func (*GetAssetRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use CreatePricesResponse.ProtoReflect.Descriptor instead.
+func (*CreatePricesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *GetAssetRequest) GetId() string {
+func (x *CreatePricesResponse) GetCreatedCount() int32 {
 	if x != nil {
-		return x.Id
+		return x.CreatedCount
 	}
-	return ""
+	return 0
 }
 
-type UpdateAssetRequest struct {
+func (x *CreatePricesResponse) GetQuarantinedCount() int32 {
+	if x != nil {
+		return x.QuarantinedCount
+	}
+	return 0
+}
+
+type GetLatestPriceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Asset         *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
-	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	BaseAssetId   string                 `protobuf:"bytes,2,opt,name=base_asset_id,json=baseAssetId,proto3" json:"base_asset_id,omitempty"`
+	SourceId      *string                `protobuf:"bytes,3,opt,name=source_id,json=sourceId,proto3,oneof" json:"source_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAssetRequest) Reset() {
-	*x = UpdateAssetRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[4]
+func (x *GetLatestPriceRequest) Reset() {
+	*x = GetLatestPriceRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAssetRequest) String() string {
+func (x *GetLatestPriceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAssetRequest) ProtoMessage() {}
+func (*GetLatestPriceRequest) ProtoMessage() {}
 
-func (x *UpdateAssetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[4]
+func (x *GetLatestPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -445,47 +3081,62 @@ func (x *UpdateAssetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAssetRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAssetRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetLatestPriceRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestPriceRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *UpdateAssetRequest) GetAsset() *Asset {
+func (x *GetLatestPriceRequest) GetAssetId() string {
 	if x != nil {
-		return x.Asset
+		return x.AssetId
 	}
-	return nil
+	return ""
 }
 
-func (x *UpdateAssetRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+func (x *GetLatestPriceRequest) GetBaseAssetId() string {
 	if x != nil {
-		return x.UpdateMask
+		return x.BaseAssetId
 	}
-	return nil
+	return ""
 }
 
-type DeleteAssetRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetLatestPriceRequest) GetSourceId() string {
+	if x != nil && x.SourceId != nil {
+		return *x.SourceId
+	}
+	return ""
 }
 
-func (x *DeleteAssetRequest) Reset() {
-	*x = DeleteAssetRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[5]
+type ListPriceHistoryRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AssetId     string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	BaseAssetId string                 `protobuf:"bytes,2,opt,name=base_asset_id,json=baseAssetId,proto3" json:"base_asset_id,omitempty"`
+	From        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3,oneof" json:"from,omitempty"`
+	To          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3,oneof" json:"to,omitempty"`
+	SourceId    *string                `protobuf:"bytes,5,opt,name=source_id,json=sourceId,proto3,oneof" json:"source_id,omitempty"`
+	PageSize    *int32                 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	PageToken   *string                `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	// Include quarantined prices, which are left out by default.
+	IncludeQuarantined bool `protobuf:"varint,8,opt,name=include_quarantined,json=includeQuarantined,proto3" json:"include_quarantined,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListPriceHistoryRequest) Reset() {
+	*x = ListPriceHistoryRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAssetRequest) String() string {
+func (x *ListPriceHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAssetRequest) ProtoMessage() {}
+func (*ListPriceHistoryRequest) ProtoMessage() {}
 
-func (x *DeleteAssetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[5]
+func (x *ListPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -496,101 +3147,90 @@ func (x *DeleteAssetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAssetRequest.ProtoReflect.Descriptor instead.
-func (*DeleteAssetRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *DeleteAssetRequest) GetId() string {
+func (x *ListPriceHistoryRequest) GetAssetId() string {
 	if x != nil {
-		return x.Id
+		return x.AssetId
 	}
 	return ""
 }
 
-type ListAssetsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PageSize      *int32                 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
-	PageToken     *string                `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
-	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListAssetsRequest) Reset() {
-	*x = ListAssetsRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ListPriceHistoryRequest) GetBaseAssetId() string {
+	if x != nil {
+		return x.BaseAssetId
+	}
+	return ""
 }
 
-func (x *ListAssetsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ListPriceHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
 }
 
-func (*ListAssetsRequest) ProtoMessage() {}
-
-func (x *ListAssetsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[6]
+func (x *ListPriceHistoryRequest) GetTo() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.To
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ListAssetsRequest.ProtoReflect.Descriptor instead.
-func (*ListAssetsRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{6}
+func (x *ListPriceHistoryRequest) GetSourceId() string {
+	if x != nil && x.SourceId != nil {
+		return *x.SourceId
+	}
+	return ""
 }
 
-func (x *ListAssetsRequest) GetPageSize() int32 {
+func (x *ListPriceHistoryRequest) GetPageSize() int32 {
 	if x != nil && x.PageSize != nil {
 		return *x.PageSize
 	}
 	return 0
 }
 
-func (x *ListAssetsRequest) GetPageToken() string {
+func (x *ListPriceHistoryRequest) GetPageToken() string {
 	if x != nil && x.PageToken != nil {
 		return *x.PageToken
 	}
 	return ""
 }
 
-func (x *ListAssetsRequest) GetTags() []string {
+func (x *ListPriceHistoryRequest) GetIncludeQuarantined() bool {
 	if x != nil {
-		return x.Tags
+		return x.IncludeQuarantined
 	}
-	return nil
+	return false
 }
 
-type ListAssetsResponse struct {
+type ListPriceHistoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Assets        []*Asset               `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	Prices        []*Price               `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
 	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAssetsResponse) Reset() {
-	*x = ListAssetsResponse{}
-	mi := &file_v1_marketdata_proto_msgTypes[7]
+func (x *ListPriceHistoryResponse) Reset() {
+	*x = ListPriceHistoryResponse{}
+	mi := &file_v1_marketdata_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAssetsResponse) String() string {
+func (x *ListPriceHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAssetsResponse) ProtoMessage() {}
+func (*ListPriceHistoryResponse) ProtoMessage() {}
 
-func (x *ListAssetsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[7]
+func (x *ListPriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -601,48 +3241,54 @@ func (x *ListAssetsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAssetsResponse.ProtoReflect.Descriptor instead.
-func (*ListAssetsResponse) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListPriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListPriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *ListAssetsResponse) GetAssets() []*Asset {
+func (x *ListPriceHistoryResponse) GetPrices() []*Price {
 	if x != nil {
-		return x.Assets
+		return x.Prices
 	}
 	return nil
 }
 
-func (x *ListAssetsResponse) GetNextPageToken() string {
+func (x *ListPriceHistoryResponse) GetNextPageToken() string {
 	if x != nil {
 		return x.NextPageToken
 	}
 	return ""
 }
 
-type EnrichAssetDataRequest struct {
+type ListPricesByIntervalRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
-	Sources       []string               `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources,omitempty"`
+	BaseAssetId   string                 `protobuf:"bytes,2,opt,name=base_asset_id,json=baseAssetId,proto3" json:"base_asset_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3,oneof" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3,oneof" json:"to,omitempty"`
+	Interval      string                 `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"`
+	SourceId      *string                `protobuf:"bytes,6,opt,name=source_id,json=sourceId,proto3,oneof" json:"source_id,omitempty"`
+	PageSize      *int32                 `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	PageToken     *string                `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EnrichAssetDataRequest) Reset() {
-	*x = EnrichAssetDataRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[8]
+func (x *ListPricesByIntervalRequest) Reset() {
+	*x = ListPricesByIntervalRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EnrichAssetDataRequest) String() string {
+func (x *ListPricesByIntervalRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EnrichAssetDataRequest) ProtoMessage() {}
+func (*ListPricesByIntervalRequest) ProtoMessage() {}
 
-func (x *EnrichAssetDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[8]
+func (x *ListPricesByIntervalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -653,99 +3299,89 @@ func (x *EnrichAssetDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EnrichAssetDataRequest.ProtoReflect.Descriptor instead.
-func (*EnrichAssetDataRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ListPricesByIntervalRequest.ProtoReflect.Descriptor instead.
+func (*ListPricesByIntervalRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *EnrichAssetDataRequest) GetAssetId() string {
+func (x *ListPricesByIntervalRequest) GetAssetId() string {
 	if x != nil {
 		return x.AssetId
 	}
 	return ""
 }
 
-func (x *EnrichAssetDataRequest) GetSources() []string {
+func (x *ListPricesByIntervalRequest) GetBaseAssetId() string {
 	if x != nil {
-		return x.Sources
+		return x.BaseAssetId
 	}
-	return nil
-}
-
-type FindSimilarAssetsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return ""
 }
 
-func (x *FindSimilarAssetsRequest) Reset() {
-	*x = FindSimilarAssetsRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ListPricesByIntervalRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
 }
 
-func (x *FindSimilarAssetsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ListPricesByIntervalRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
 }
 
-func (*FindSimilarAssetsRequest) ProtoMessage() {}
-
-func (x *FindSimilarAssetsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[9]
+func (x *ListPricesByIntervalRequest) GetInterval() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Interval
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use FindSimilarAssetsRequest.ProtoReflect.Descriptor instead.
-func (*FindSimilarAssetsRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{9}
+func (x *ListPricesByIntervalRequest) GetSourceId() string {
+	if x != nil && x.SourceId != nil {
+		return *x.SourceId
+	}
+	return ""
 }
 
-func (x *FindSimilarAssetsRequest) GetAssetId() string {
-	if x != nil {
-		return x.AssetId
+func (x *ListPricesByIntervalRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
 	}
-	return ""
+	return 0
 }
 
-func (x *FindSimilarAssetsRequest) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
+func (x *ListPricesByIntervalRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
 	}
-	return 0
+	return ""
 }
 
-type CreatePriceRequest struct {
+type DeletePriceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Price         *Price                 `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreatePriceRequest) Reset() {
-	*x = CreatePriceRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[10]
+func (x *DeletePriceRequest) Reset() {
+	*x = DeletePriceRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePriceRequest) String() string {
+func (x *DeletePriceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePriceRequest) ProtoMessage() {}
+func (*DeletePriceRequest) ProtoMessage() {}
 
-func (x *CreatePriceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[10]
+func (x *DeletePriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -756,40 +3392,44 @@ func (x *CreatePriceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePriceRequest.ProtoReflect.Descriptor instead.
-func (*CreatePriceRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use DeletePriceRequest.ProtoReflect.Descriptor instead.
+func (*DeletePriceRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *CreatePriceRequest) GetPrice() *Price {
+func (x *DeletePriceRequest) GetId() string {
 	if x != nil {
-		return x.Price
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type CreatePricesRequest struct {
+type DeletePricesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Prices        []*Price               `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
+	AssetId       *string                `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3,oneof" json:"asset_id,omitempty"`
+	BaseAssetId   *string                `protobuf:"bytes,2,opt,name=base_asset_id,json=baseAssetId,proto3,oneof" json:"base_asset_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3,oneof" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3,oneof" json:"to,omitempty"`
+	SourceId      *string                `protobuf:"bytes,5,opt,name=source_id,json=sourceId,proto3,oneof" json:"source_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreatePricesRequest) Reset() {
-	*x = CreatePricesRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[11]
+func (x *DeletePricesRequest) Reset() {
+	*x = DeletePricesRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePricesRequest) String() string {
+func (x *DeletePricesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePricesRequest) ProtoMessage() {}
+func (*DeletePricesRequest) ProtoMessage() {}
 
-func (x *CreatePricesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[11]
+func (x *DeletePricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -800,40 +3440,69 @@ func (x *CreatePricesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePricesRequest.ProtoReflect.Descriptor instead.
-func (*CreatePricesRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use DeletePricesRequest.ProtoReflect.Descriptor instead.
+func (*DeletePricesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *CreatePricesRequest) GetPrices() []*Price {
+func (x *DeletePricesRequest) GetAssetId() string {
+	if x != nil && x.AssetId != nil {
+		return *x.AssetId
+	}
+	return ""
+}
+
+func (x *DeletePricesRequest) GetBaseAssetId() string {
+	if x != nil && x.BaseAssetId != nil {
+		return *x.BaseAssetId
+	}
+	return ""
+}
+
+func (x *DeletePricesRequest) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Prices
+		return x.From
 	}
 	return nil
 }
 
-type CreatePricesResponse struct {
+func (x *DeletePricesRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *DeletePricesRequest) GetSourceId() string {
+	if x != nil && x.SourceId != nil {
+		return *x.SourceId
+	}
+	return ""
+}
+
+type AssetPair struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CreatedCount  int32                  `protobuf:"varint,1,opt,name=created_count,json=createdCount,proto3" json:"created_count,omitempty"`
+	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	BaseAssetId   string                 `protobuf:"bytes,2,opt,name=base_asset_id,json=baseAssetId,proto3" json:"base_asset_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreatePricesResponse) Reset() {
-	*x = CreatePricesResponse{}
-	mi := &file_v1_marketdata_proto_msgTypes[12]
+func (x *AssetPair) Reset() {
+	*x = AssetPair{}
+	mi := &file_v1_marketdata_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePricesResponse) String() string {
+func (x *AssetPair) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePricesResponse) ProtoMessage() {}
+func (*AssetPair) ProtoMessage() {}
 
-func (x *CreatePricesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[12]
+func (x *AssetPair) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -844,42 +3513,50 @@ func (x *CreatePricesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePricesResponse.ProtoReflect.Descriptor instead.
-func (*CreatePricesResponse) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use AssetPair.ProtoReflect.Descriptor instead.
+func (*AssetPair) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *CreatePricesResponse) GetCreatedCount() int32 {
+func (x *AssetPair) GetAssetId() string {
 	if x != nil {
-		return x.CreatedCount
+		return x.AssetId
 	}
-	return 0
+	return ""
 }
 
-type GetLatestPriceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
-	BaseAssetId   string                 `protobuf:"bytes,2,opt,name=base_asset_id,json=baseAssetId,proto3" json:"base_asset_id,omitempty"`
-	SourceId      *string                `protobuf:"bytes,3,opt,name=source_id,json=sourceId,proto3,oneof" json:"source_id,omitempty"`
+func (x *AssetPair) GetBaseAssetId() string {
+	if x != nil {
+		return x.BaseAssetId
+	}
+	return ""
+}
+
+type WatchPricesRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Pairs    []*AssetPair           `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	SourceId *string                `protobuf:"bytes,2,opt,name=source_id,json=sourceId,proto3,oneof" json:"source_id,omitempty"`
+	// Send the latest stored price of each pair before live updates.
+	IncludeLatest bool `protobuf:"varint,3,opt,name=include_latest,json=includeLatest,proto3" json:"include_latest,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLatestPriceRequest) Reset() {
-	*x = GetLatestPriceRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[13]
+func (x *WatchPricesRequest) Reset() {
+	*x = WatchPricesRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLatestPriceRequest) String() string {
+func (x *WatchPricesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLatestPriceRequest) ProtoMessage() {}
+func (*WatchPricesRequest) ProtoMessage() {}
 
-func (x *GetLatestPriceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[13]
+func (x *WatchPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -890,60 +3567,59 @@ func (x *GetLatestPriceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLatestPriceRequest.ProtoReflect.Descriptor instead.
-func (*GetLatestPriceRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use WatchPricesRequest.ProtoReflect.Descriptor instead.
+func (*WatchPricesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *GetLatestPriceRequest) GetAssetId() string {
+func (x *WatchPricesRequest) GetPairs() []*AssetPair {
 	if x != nil {
-		return x.AssetId
+		return x.Pairs
 	}
-	return ""
+	return nil
 }
 
-func (x *GetLatestPriceRequest) GetBaseAssetId() string {
-	if x != nil {
-		return x.BaseAssetId
+func (x *WatchPricesRequest) GetSourceId() string {
+	if x != nil && x.SourceId != nil {
+		return *x.SourceId
 	}
 	return ""
 }
 
-func (x *GetLatestPriceRequest) GetSourceId() string {
-	if x != nil && x.SourceId != nil {
-		return *x.SourceId
+func (x *WatchPricesRequest) GetIncludeLatest() bool {
+	if x != nil {
+		return x.IncludeLatest
 	}
-	return ""
+	return false
 }
 
-type ListPriceHistoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
-	BaseAssetId   string                 `protobuf:"bytes,2,opt,name=base_asset_id,json=baseAssetId,proto3" json:"base_asset_id,omitempty"`
-	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3,oneof" json:"from,omitempty"`
-	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3,oneof" json:"to,omitempty"`
-	SourceId      *string                `protobuf:"bytes,5,opt,name=source_id,json=sourceId,proto3,oneof" json:"source_id,omitempty"`
-	PageSize      *int32                 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
-	PageToken     *string                `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+type FetchExternalPricesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sources to fetch from; all when empty.
+	SourceIds []string `protobuf:"bytes,1,rep,name=source_ids,json=sourceIds,proto3" json:"source_ids,omitempty"`
+	// Assets to fetch; all when empty.
+	AssetIds []string `protobuf:"bytes,2,rep,name=asset_ids,json=assetIds,proto3" json:"asset_ids,omitempty"`
+	// Fetch prices from this time on; defaults to a week ago.
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3,oneof" json:"since,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPriceHistoryRequest) Reset() {
-	*x = ListPriceHistoryRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[14]
+func (x *FetchExternalPricesRequest) Reset() {
+	*x = FetchExternalPricesRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPriceHistoryRequest) String() string {
+func (x *FetchExternalPricesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPriceHistoryRequest) ProtoMessage() {}
+func (*FetchExternalPricesRequest) ProtoMessage() {}
 
-func (x *ListPriceHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[14]
+func (x *FetchExternalPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -954,83 +3630,62 @@ func (x *ListPriceHistoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPriceHistoryRequest.ProtoReflect.Descriptor instead.
-func (*ListPriceHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{14}
-}
-
-func (x *ListPriceHistoryRequest) GetAssetId() string {
-	if x != nil {
-		return x.AssetId
-	}
-	return ""
+// Deprecated: Use FetchExternalPricesRequest.ProtoReflect.Descriptor instead.
+func (*FetchExternalPricesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *ListPriceHistoryRequest) GetBaseAssetId() string {
+func (x *FetchExternalPricesRequest) GetSourceIds() []string {
 	if x != nil {
-		return x.BaseAssetId
+		return x.SourceIds
 	}
-	return ""
+	return nil
 }
 
-func (x *ListPriceHistoryRequest) GetFrom() *timestamppb.Timestamp {
+func (x *FetchExternalPricesRequest) GetAssetIds() []string {
 	if x != nil {
-		return x.From
+		return x.AssetIds
 	}
 	return nil
 }
 
-func (x *ListPriceHistoryRequest) GetTo() *timestamppb.Timestamp {
+func (x *FetchExternalPricesRequest) GetSince() *timestamppb.Timestamp {
 	if x != nil {
-		return x.To
+		return x.Since
 	}
 	return nil
 }
 
-func (x *ListPriceHistoryRequest) GetSourceId() string {
-	if x != nil && x.SourceId != nil {
-		return *x.SourceId
-	}
-	return ""
-}
-
-func (x *ListPriceHistoryRequest) GetPageSize() int32 {
-	if x != nil && x.PageSize != nil {
-		return *x.PageSize
-	}
-	return 0
-}
-
-func (x *ListPriceHistoryRequest) GetPageToken() string {
-	if x != nil && x.PageToken != nil {
-		return *x.PageToken
-	}
-	return ""
-}
-
-type ListPriceHistoryResponse struct {
+type FetchExternalPricesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Prices        []*Price               `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	PricesFetched int32                  `protobuf:"varint,1,opt,name=prices_fetched,json=pricesFetched,proto3" json:"prices_fetched,omitempty"`
+	// Prices not stored before; daily prices are only stored once a session
+	// has closed.
+	PricesStored int32    `protobuf:"varint,2,opt,name=prices_stored,json=pricesStored,proto3" json:"prices_stored,omitempty"`
+	Errors       []string `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	// Dividends not stored before. Their income is recorded in the background.
+	DividendsStored int32 `protobuf:"varint,4,opt,name=dividends_stored,json=dividendsStored,proto3" json:"dividends_stored,omitempty"`
+	// Fetched prices that failed the ingestion checks, stored before or not.
+	PricesQuarantined int32 `protobuf:"varint,5,opt,name=prices_quarantined,json=pricesQuarantined,proto3" json:"prices_quarantined,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *ListPriceHistoryResponse) Reset() {
-	*x = ListPriceHistoryResponse{}
-	mi := &file_v1_marketdata_proto_msgTypes[15]
+func (x *FetchExternalPricesResponse) Reset() {
+	*x = FetchExternalPricesResponse{}
+	mi := &file_v1_marketdata_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPriceHistoryResponse) String() string {
+func (x *FetchExternalPricesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPriceHistoryResponse) ProtoMessage() {}
+func (*FetchExternalPricesResponse) ProtoMessage() {}
 
-func (x *ListPriceHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[15]
+func (x *FetchExternalPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1041,54 +3696,74 @@ func (x *ListPriceHistoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPriceHistoryResponse.ProtoReflect.Descriptor instead.
-func (*ListPriceHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use FetchExternalPricesResponse.ProtoReflect.Descriptor instead.
+func (*FetchExternalPricesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *FetchExternalPricesResponse) GetPricesFetched() int32 {
+	if x != nil {
+		return x.PricesFetched
+	}
+	return 0
+}
+
+func (x *FetchExternalPricesResponse) GetPricesStored() int32 {
+	if x != nil {
+		return x.PricesStored
+	}
+	return 0
+}
+
+func (x *FetchExternalPricesResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
 }
 
-func (x *ListPriceHistoryResponse) GetPrices() []*Price {
+func (x *FetchExternalPricesResponse) GetDividendsStored() int32 {
 	if x != nil {
-		return x.Prices
+		return x.DividendsStored
 	}
-	return nil
+	return 0
 }
 
-func (x *ListPriceHistoryResponse) GetNextPageToken() string {
+func (x *FetchExternalPricesResponse) GetPricesQuarantined() int32 {
 	if x != nil {
-		return x.NextPageToken
+		return x.PricesQuarantined
 	}
-	return ""
+	return 0
 }
 
-type ListPricesByIntervalRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
-	BaseAssetId   string                 `protobuf:"bytes,2,opt,name=base_asset_id,json=baseAssetId,proto3" json:"base_asset_id,omitempty"`
-	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3,oneof" json:"from,omitempty"`
-	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3,oneof" json:"to,omitempty"`
-	Interval      string                 `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"`
-	SourceId      *string                `protobuf:"bytes,6,opt,name=source_id,json=sourceId,proto3,oneof" json:"source_id,omitempty"`
-	PageSize      *int32                 `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
-	PageToken     *string                `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+type GetPriceDataQualityRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AssetId     *string                `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3,oneof" json:"asset_id,omitempty"`
+	BaseAssetId *string                `protobuf:"bytes,2,opt,name=base_asset_id,json=baseAssetId,proto3,oneof" json:"base_asset_id,omitempty"`
+	Interval    *string                `protobuf:"bytes,3,opt,name=interval,proto3,oneof" json:"interval,omitempty"`
+	// Defaults to 30 days before to.
+	From *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3,oneof" json:"from,omitempty"`
+	// Defaults to now.
+	To            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3,oneof" json:"to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPricesByIntervalRequest) Reset() {
-	*x = ListPricesByIntervalRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[16]
+func (x *GetPriceDataQualityRequest) Reset() {
+	*x = GetPriceDataQualityRequest{}
+	mi := &file_v1_marketdata_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPricesByIntervalRequest) String() string {
+func (x *GetPriceDataQualityRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPricesByIntervalRequest) ProtoMessage() {}
+func (*GetPriceDataQualityRequest) ProtoMessage() {}
 
-func (x *ListPricesByIntervalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[16]
+func (x *GetPriceDataQualityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1099,89 +3774,75 @@ func (x *ListPricesByIntervalRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPricesByIntervalRequest.ProtoReflect.Descriptor instead.
-func (*ListPricesByIntervalRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use GetPriceDataQualityRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceDataQualityRequest) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *ListPricesByIntervalRequest) GetAssetId() string {
-	if x != nil {
-		return x.AssetId
+func (x *GetPriceDataQualityRequest) GetAssetId() string {
+	if x != nil && x.AssetId != nil {
+		return *x.AssetId
 	}
 	return ""
 }
 
-func (x *ListPricesByIntervalRequest) GetBaseAssetId() string {
-	if x != nil {
-		return x.BaseAssetId
+func (x *GetPriceDataQualityRequest) GetBaseAssetId() string {
+	if x != nil && x.BaseAssetId != nil {
+		return *x.BaseAssetId
 	}
 	return ""
 }
 
-func (x *ListPricesByIntervalRequest) GetFrom() *timestamppb.Timestamp {
-	if x != nil {
-		return x.From
+func (x *GetPriceDataQualityRequest) GetInterval() string {
+	if x != nil && x.Interval != nil {
+		return *x.Interval
 	}
-	return nil
+	return ""
 }
 
-func (x *ListPricesByIntervalRequest) GetTo() *timestamppb.Timestamp {
+func (x *GetPriceDataQualityRequest) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.To
+		return x.From
 	}
 	return nil
 }
 
-func (x *ListPricesByIntervalRequest) GetInterval() string {
+func (x *GetPriceDataQualityRequest) GetTo() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Interval
-	}
-	return ""
-}
-
-func (x *ListPricesByIntervalRequest) GetSourceId() string {
-	if x != nil && x.SourceId != nil {
-		return *x.SourceId
-	}
-	return ""
-}
-
-func (x *ListPricesByIntervalRequest) GetPageSize() int32 {
-	if x != nil && x.PageSize != nil {
-		return *x.PageSize
-	}
-	return 0
-}
-
-func (x *ListPricesByIntervalRequest) GetPageToken() string {
-	if x != nil && x.PageToken != nil {
-		return *x.PageToken
+		return x.To
 	}
-	return ""
+	return nil
 }
 
-type DeletePriceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+// PriceGap is a span between two consecutive unquarantined prices of a
+// series longer than its interval allows.
+type PriceGap struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Prices expected in between. Assets other than cryptocurrencies are only
+	// expected to have daily prices on weekdays, and their intraday gaps are
+	// not reported.
+	Missing       int32 `protobuf:"varint,3,opt,name=missing,proto3" json:"missing,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeletePriceRequest) Reset() {
-	*x = DeletePriceRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[17]
+func (x *PriceGap) Reset() {
+	*x = PriceGap{}
+	mi := &file_v1_marketdata_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeletePriceRequest) String() string {
+func (x *PriceGap) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeletePriceRequest) ProtoMessage() {}
+func (*PriceGap) ProtoMessage() {}
 
-func (x *DeletePriceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[17]
+func (x *PriceGap) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1192,44 +3853,62 @@ func (x *DeletePriceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeletePriceRequest.ProtoReflect.Descriptor instead.
-func (*DeletePriceRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use PriceGap.ProtoReflect.Descriptor instead.
+func (*PriceGap) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *DeletePriceRequest) GetId() string {
+func (x *PriceGap) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Id
+		return x.From
 	}
-	return ""
+	return nil
 }
 
-type DeletePricesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AssetId       *string                `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3,oneof" json:"asset_id,omitempty"`
-	BaseAssetId   *string                `protobuf:"bytes,2,opt,name=base_asset_id,json=baseAssetId,proto3,oneof" json:"base_asset_id,omitempty"`
-	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3,oneof" json:"from,omitempty"`
-	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3,oneof" json:"to,omitempty"`
-	SourceId      *string                `protobuf:"bytes,5,opt,name=source_id,json=sourceId,proto3,oneof" json:"source_id,omitempty"`
+func (x *PriceGap) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *PriceGap) GetMissing() int32 {
+	if x != nil {
+		return x.Missing
+	}
+	return 0
+}
+
+type PriceSourceQuality struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	SourceId string                 `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	// Prices in the range, and how many of them are quarantined.
+	PriceCount       int32 `protobuf:"varint,2,opt,name=price_count,json=priceCount,proto3" json:"price_count,omitempty"`
+	QuarantinedCount int32 `protobuf:"varint,3,opt,name=quarantined_count,json=quarantinedCount,proto3" json:"quarantined_count,omitempty"`
+	// Time of the last unquarantined price before the end of the range.
+	LastPriceTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_price_time,json=lastPriceTime,proto3,oneof" json:"last_price_time,omitempty"`
+	// Whether the source sent no unquarantined price in the stale period
+	// before the end of the range.
+	Stale         bool `protobuf:"varint,5,opt,name=stale,proto3" json:"stale,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeletePricesRequest) Reset() {
-	*x = DeletePricesRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[18]
+func (x *PriceSourceQuality) Reset() {
+	*x = PriceSourceQuality{}
+	mi := &file_v1_marketdata_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeletePricesRequest) String() string {
+func (x *PriceSourceQuality) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeletePricesRequest) ProtoMessage() {}
+func (*PriceSourceQuality) ProtoMessage() {}
 
-func (x *DeletePricesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[18]
+func (x *PriceSourceQuality) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1240,69 +3919,74 @@ func (x *DeletePricesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeletePricesRequest.ProtoReflect.Descriptor instead.
-func (*DeletePricesRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use PriceSourceQuality.ProtoReflect.Descriptor instead.
+func (*PriceSourceQuality) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *DeletePricesRequest) GetAssetId() string {
-	if x != nil && x.AssetId != nil {
-		return *x.AssetId
+func (x *PriceSourceQuality) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
 	}
 	return ""
 }
 
-func (x *DeletePricesRequest) GetBaseAssetId() string {
-	if x != nil && x.BaseAssetId != nil {
-		return *x.BaseAssetId
+func (x *PriceSourceQuality) GetPriceCount() int32 {
+	if x != nil {
+		return x.PriceCount
 	}
-	return ""
+	return 0
 }
 
-func (x *DeletePricesRequest) GetFrom() *timestamppb.Timestamp {
+func (x *PriceSourceQuality) GetQuarantinedCount() int32 {
 	if x != nil {
-		return x.From
+		return x.QuarantinedCount
 	}
-	return nil
+	return 0
 }
 
-func (x *DeletePricesRequest) GetTo() *timestamppb.Timestamp {
+func (x *PriceSourceQuality) GetLastPriceTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.To
+		return x.LastPriceTime
 	}
 	return nil
 }
 
-func (x *DeletePricesRequest) GetSourceId() string {
-	if x != nil && x.SourceId != nil {
-		return *x.SourceId
+func (x *PriceSourceQuality) GetStale() bool {
+	if x != nil {
+		return x.Stale
 	}
-	return ""
+	return false
 }
 
-type FetchExternalPricesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SourceIds     []string               `protobuf:"bytes,1,rep,name=source_ids,json=sourceIds,proto3" json:"source_ids,omitempty"`
-	AssetIds      []string               `protobuf:"bytes,2,rep,name=asset_ids,json=assetIds,proto3" json:"asset_ids,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type PriceSeriesQuality struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AssetId          string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	BaseAssetId      string                 `protobuf:"bytes,2,opt,name=base_asset_id,json=baseAssetId,proto3" json:"base_asset_id,omitempty"`
+	Interval         string                 `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	PriceCount       int32                  `protobuf:"varint,4,opt,name=price_count,json=priceCount,proto3" json:"price_count,omitempty"`
+	QuarantinedCount int32                  `protobuf:"varint,5,opt,name=quarantined_count,json=quarantinedCount,proto3" json:"quarantined_count,omitempty"`
+	Gaps             []*PriceGap            `protobuf:"bytes,6,rep,name=gaps,proto3" json:"gaps,omitempty"`
+	Sources          []*PriceSourceQuality  `protobuf:"bytes,7,rep,name=sources,proto3" json:"sources,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *FetchExternalPricesRequest) Reset() {
-	*x = FetchExternalPricesRequest{}
-	mi := &file_v1_marketdata_proto_msgTypes[19]
+func (x *PriceSeriesQuality) Reset() {
+	*x = PriceSeriesQuality{}
+	mi := &file_v1_marketdata_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FetchExternalPricesRequest) String() string {
+func (x *PriceSeriesQuality) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FetchExternalPricesRequest) ProtoMessage() {}
+func (*PriceSeriesQuality) ProtoMessage() {}
 
-func (x *FetchExternalPricesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[19]
+func (x *PriceSeriesQuality) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1313,49 +3997,87 @@ func (x *FetchExternalPricesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FetchExternalPricesRequest.ProtoReflect.Descriptor instead.
-func (*FetchExternalPricesRequest) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use PriceSeriesQuality.ProtoReflect.Descriptor instead.
+func (*PriceSeriesQuality) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *FetchExternalPricesRequest) GetSourceIds() []string {
+func (x *PriceSeriesQuality) GetAssetId() string {
 	if x != nil {
-		return x.SourceIds
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *PriceSeriesQuality) GetBaseAssetId() string {
+	if x != nil {
+		return x.BaseAssetId
+	}
+	return ""
+}
+
+func (x *PriceSeriesQuality) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *PriceSeriesQuality) GetPriceCount() int32 {
+	if x != nil {
+		return x.PriceCount
+	}
+	return 0
+}
+
+func (x *PriceSeriesQuality) GetQuarantinedCount() int32 {
+	if x != nil {
+		return x.QuarantinedCount
+	}
+	return 0
+}
+
+func (x *PriceSeriesQuality) GetGaps() []*PriceGap {
+	if x != nil {
+		return x.Gaps
 	}
 	return nil
 }
 
-func (x *FetchExternalPricesRequest) GetAssetIds() []string {
+func (x *PriceSeriesQuality) GetSources() []*PriceSourceQuality {
 	if x != nil {
-		return x.AssetIds
+		return x.Sources
 	}
 	return nil
 }
 
-type FetchExternalPricesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PricesFetched int32                  `protobuf:"varint,1,opt,name=prices_fetched,json=pricesFetched,proto3" json:"prices_fetched,omitempty"`
-	PricesStored  int32                  `protobuf:"varint,2,opt,name=prices_stored,json=pricesStored,proto3" json:"prices_stored,omitempty"`
-	Errors        []string               `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GetPriceDataQualityResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	From             *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To               *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Series           []*PriceSeriesQuality  `protobuf:"bytes,3,rep,name=series,proto3" json:"series,omitempty"`
+	GapCount         int32                  `protobuf:"varint,4,opt,name=gap_count,json=gapCount,proto3" json:"gap_count,omitempty"`
+	QuarantinedCount int32                  `protobuf:"varint,5,opt,name=quarantined_count,json=quarantinedCount,proto3" json:"quarantined_count,omitempty"`
+	StaleSourceCount int32                  `protobuf:"varint,6,opt,name=stale_source_count,json=staleSourceCount,proto3" json:"stale_source_count,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *FetchExternalPricesResponse) Reset() {
-	*x = FetchExternalPricesResponse{}
-	mi := &file_v1_marketdata_proto_msgTypes[20]
+func (x *GetPriceDataQualityResponse) Reset() {
+	*x = GetPriceDataQualityResponse{}
+	mi := &file_v1_marketdata_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FetchExternalPricesResponse) String() string {
+func (x *GetPriceDataQualityResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FetchExternalPricesResponse) ProtoMessage() {}
+func (*GetPriceDataQualityResponse) ProtoMessage() {}
 
-func (x *FetchExternalPricesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_marketdata_proto_msgTypes[20]
+func (x *GetPriceDataQualityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_marketdata_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1366,37 +4088,58 @@ func (x *FetchExternalPricesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FetchExternalPricesResponse.ProtoReflect.Descriptor instead.
-func (*FetchExternalPricesResponse) Descriptor() ([]byte, []int) {
-	return file_v1_marketdata_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use GetPriceDataQualityResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceDataQualityResponse) Descriptor() ([]byte, []int) {
+	return file_v1_marketdata_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *FetchExternalPricesResponse) GetPricesFetched() int32 {
+func (x *GetPriceDataQualityResponse) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.PricesFetched
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetPriceDataQualityResponse) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetPriceDataQualityResponse) GetSeries() []*PriceSeriesQuality {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+func (x *GetPriceDataQualityResponse) GetGapCount() int32 {
+	if x != nil {
+		return x.GapCount
 	}
 	return 0
 }
 
-func (x *FetchExternalPricesResponse) GetPricesStored() int32 {
+func (x *GetPriceDataQualityResponse) GetQuarantinedCount() int32 {
 	if x != nil {
-		return x.PricesStored
+		return x.QuarantinedCount
 	}
 	return 0
 }
 
-func (x *FetchExternalPricesResponse) GetErrors() []string {
+func (x *GetPriceDataQualityResponse) GetStaleSourceCount() int32 {
 	if x != nil {
-		return x.Errors
+		return x.StaleSourceCount
 	}
-	return nil
+	return 0
 }
 
 var File_v1_marketdata_proto protoreflect.FileDescriptor
 
 const file_v1_marketdata_proto_rawDesc = "" +
 	"\n" +
-	"\x13v1/marketdata.proto\x12\rgreedy_eye.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/api/annotations.proto\"\x8b\x02\n" +
+	"\x13v1/marketdata.proto\x12\rgreedy_eye.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/api/annotations.proto\"\x9a\x04\n" +
 	"\x05Asset\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12,\n" +
@@ -1406,8 +4149,62 @@ const file_v1_marketdata_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\t\n" +
-	"\a_symbol\"\xa9\x03\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12>\n" +
+	"\bmetadata\x18\b \x03(\v2\".greedy_eye.v1.Asset.MetadataEntryR\bmetadata\x12D\n" +
+	"\x10last_enriched_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0elastEnrichedAt\x12,\n" +
+	"\x04bond\x18\n" +
+	" \x01(\v2\x18.greedy_eye.v1.BondTermsR\x04bond\x1aY\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x122\n" +
+	"\x05value\x18\x02 \x01(\v2\x1c.greedy_eye.v1.AssetMetadataR\x05value:\x028\x01B\t\n" +
+	"\a_symbol\"x\n" +
+	"\rAssetMetadata\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xde\x02\n" +
+	"\tBondTerms\x12\x1d\n" +
+	"\n" +
+	"face_value\x18\x01 \x01(\tR\tfaceValue\x12*\n" +
+	"\x11currency_asset_id\x18\x02 \x01(\tR\x0fcurrencyAssetId\x12\x1f\n" +
+	"\vcoupon_rate\x18\x03 \x01(\tR\n" +
+	"couponRate\x12)\n" +
+	"\x10coupon_frequency\x18\x04 \x01(\rR\x0fcouponFrequency\x12?\n" +
+	"\rmaturity_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fmaturityDate\x129\n" +
+	"\n" +
+	"issue_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tissueDate\x12>\n" +
+	"\tday_count\x18\a \x01(\x0e2!.greedy_eye.v1.DayCountConventionR\bdayCount\"\xdb\x01\n" +
+	"\x0fAssetIdentifier\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\basset_id\x18\x02 \x01(\tR\aassetId\x126\n" +
+	"\x04kind\x18\x03 \x01(\x0e2\".greedy_eye.v1.AssetIdentifierKindR\x04kind\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x12\x14\n" +
+	"\x05chain\x18\x05 \x01(\tR\x05chain\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x8e\x05\n" +
+	"\x0fCorporateAction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\basset_id\x18\x02 \x01(\tR\aassetId\x126\n" +
+	"\x04type\x18\x03 \x01(\x0e2\".greedy_eye.v1.CorporateActionTypeR\x04type\x12<\n" +
+	"\x06status\x18\x04 \x01(\x0e2$.greedy_eye.v1.CorporateActionStatusR\x06status\x12=\n" +
+	"\feffective_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\veffectiveAt\x12\x1b\n" +
+	"\tnew_units\x18\x06 \x01(\x03R\bnewUnits\x12\x1b\n" +
+	"\told_units\x18\a \x01(\x03R\boldUnits\x12%\n" +
+	"\fnew_asset_id\x18\b \x01(\tH\x00R\n" +
+	"newAssetId\x88\x01\x01\x12\x12\n" +
+	"\x04note\x18\t \x01(\tR\x04note\x12>\n" +
+	"\n" +
+	"applied_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampH\x01R\tappliedAt\x88\x01\x01\x12@\n" +
+	"\vreverted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampH\x02R\n" +
+	"revertedAt\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x0f\n" +
+	"\r_new_asset_idB\r\n" +
+	"\v_applied_atB\x0e\n" +
+	"\f_reverted_at\"\xc9\x03\n" +
 	"\x05Price\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tsource_id\x18\x02 \x01(\tR\bsourceId\x12\x19\n" +
@@ -1422,7 +4219,10 @@ const file_v1_marketdata_proto_rawDesc = "" +
 	" \x01(\x03H\x02R\x03low\x88\x01\x01\x12\x19\n" +
 	"\x05close\x18\v \x01(\x03H\x03R\x05close\x88\x01\x01\x12\x1b\n" +
 	"\x06volume\x18\f \x01(\x03H\x04R\x06volume\x88\x01\x01\x128\n" +
-	"\ttimestamp\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\ttimestampB\a\n" +
+	"\ttimestamp\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1e\n" +
+	"\n" +
+	"quarantine\x18\x0e \x01(\tR\n" +
+	"quarantineB\a\n" +
 	"\x05_openB\a\n" +
 	"\x05_highB\x06\n" +
 	"\x04_lowB\b\n" +
@@ -1448,25 +4248,158 @@ const file_v1_marketdata_proto_rawDesc = "" +
 	"\v_page_token\"j\n" +
 	"\x12ListAssetsResponse\x12,\n" +
 	"\x06assets\x18\x01 \x03(\v2\x14.greedy_eye.v1.AssetR\x06assets\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"M\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x89\x02\n" +
+	"\x13SearchAssetsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x121\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x18.greedy_eye.v1.AssetTypeH\x00R\x04type\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12 \n" +
+	"\tpage_size\x18\x04 \x01(\x05H\x01R\bpageSize\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tH\x02R\tpageToken\x88\x01\x01\x12)\n" +
+	"\x10include_external\x18\x06 \x01(\bR\x0fincludeExternalB\a\n" +
+	"\x05_typeB\f\n" +
+	"\n" +
+	"_page_sizeB\r\n" +
+	"\v_page_token\"\xb3\x01\n" +
+	"\x14SearchAssetsResponse\x12,\n" +
+	"\x06assets\x18\x01 \x03(\v2\x14.greedy_eye.v1.AssetR\x06assets\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12E\n" +
+	"\x0fexternal_assets\x18\x03 \x03(\v2\x1c.greedy_eye.v1.ExternalAssetR\x0eexternalAssets\"\xad\x02\n" +
+	"\rExternalAsset\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x1f\n" +
+	"\vexternal_id\x18\x02 \x01(\tR\n" +
+	"externalId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12,\n" +
+	"\x04type\x18\x05 \x01(\x0e2\x18.greedy_eye.v1.AssetTypeR\x04type\x12+\n" +
+	"\x0fmarket_cap_rank\x18\x06 \x01(\x05H\x00R\rmarketCapRank\x88\x01\x01\x12\x1b\n" +
+	"\timage_url\x18\a \x01(\tR\bimageUrl\x12\x1e\n" +
+	"\basset_id\x18\b \x01(\tH\x01R\aassetId\x88\x01\x01B\x12\n" +
+	"\x10_market_cap_rankB\v\n" +
+	"\t_asset_id\"U\n" +
+	"\x1aImportExternalAssetRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x1f\n" +
+	"\vexternal_id\x18\x02 \x01(\tR\n" +
+	"externalId\"M\n" +
 	"\x16EnrichAssetDataRequest\x12\x19\n" +
 	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\x18\n" +
-	"\asources\x18\x02 \x03(\tR\asources\"K\n" +
+	"\asources\x18\x02 \x03(\tR\asources\"\xc2\x01\n" +
 	"\x18FindSimilarAssetsRequest\x12\x19\n" +
 	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\"@\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12(\n" +
+	"\rlookback_days\x18\x03 \x01(\x05H\x00R\flookbackDays\x88\x01\x01\x12'\n" +
+	"\rbase_asset_id\x18\x04 \x01(\tH\x01R\vbaseAssetId\x88\x01\x01B\x10\n" +
+	"\x0e_lookback_daysB\x10\n" +
+	"\x0e_base_asset_id\"\x90\x02\n" +
+	"\fSimilarAsset\x12*\n" +
+	"\x05asset\x18\x01 \x01(\v2\x14.greedy_eye.v1.AssetR\x05asset\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12%\n" +
+	"\x0etag_similarity\x18\x03 \x01(\x01R\rtagSimilarity\x12\x1b\n" +
+	"\tsame_type\x18\x04 \x01(\bR\bsameType\x12%\n" +
+	"\vcorrelation\x18\x05 \x01(\x01H\x00R\vcorrelation\x88\x01\x01\x12)\n" +
+	"\x10correlation_days\x18\x06 \x01(\x05R\x0fcorrelationDays\x12\x18\n" +
+	"\areasons\x18\a \x03(\tR\areasonsB\x0e\n" +
+	"\f_correlation\"\x8b\x01\n" +
+	"\x19FindSimilarAssetsResponse\x123\n" +
+	"\x06assets\x18\x01 \x03(\v2\x1b.greedy_eye.v1.SimilarAssetR\x06assets\x12'\n" +
+	"\rbase_asset_id\x18\x02 \x01(\tH\x00R\vbaseAssetId\x88\x01\x01B\x10\n" +
+	"\x0e_base_asset_id\"\xaf\x01\n" +
+	"\x17GetBondAnalyticsRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12C\n" +
+	"\x0fsettlement_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x0esettlementTime\x12$\n" +
+	"\vclean_price\x18\x03 \x01(\tH\x00R\n" +
+	"cleanPrice\x88\x01\x01B\x0e\n" +
+	"\f_clean_price\"\x83\x01\n" +
+	"\fBondCashFlow\x12=\n" +
+	"\fpayment_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vpaymentDate\x12\x16\n" +
+	"\x06coupon\x18\x02 \x01(\tR\x06coupon\x12\x1c\n" +
+	"\tprincipal\x18\x03 \x01(\tR\tprincipal\"\xee\x04\n" +
+	"\x18GetBondAnalyticsResponse\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12*\n" +
+	"\x11currency_asset_id\x18\x02 \x01(\tR\x0fcurrencyAssetId\x12C\n" +
+	"\x0fsettlement_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0esettlementTime\x12\x18\n" +
+	"\amatured\x18\x04 \x01(\bR\amatured\x12)\n" +
+	"\x10accrued_interest\x18\x05 \x01(\tR\x0faccruedInterest\x12L\n" +
+	"\x14previous_coupon_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x12previousCouponDate\x12D\n" +
+	"\x10next_coupon_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x0enextCouponDate\x12:\n" +
+	"\n" +
+	"cash_flows\x18\b \x03(\v2\x1b.greedy_eye.v1.BondCashFlowR\tcashFlows\x12$\n" +
+	"\vclean_price\x18\t \x01(\tH\x00R\n" +
+	"cleanPrice\x88\x01\x01\x12$\n" +
+	"\vdirty_price\x18\n" +
+	" \x01(\tH\x01R\n" +
+	"dirtyPrice\x88\x01\x01\x12/\n" +
+	"\x11yield_to_maturity\x18\v \x01(\tH\x02R\x0fyieldToMaturity\x88\x01\x01B\x0e\n" +
+	"\f_clean_priceB\x0e\n" +
+	"\f_dirty_priceB\x14\n" +
+	"\x12_yield_to_maturity\"^\n" +
+	"\x1cCreateAssetIdentifierRequest\x12>\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x1e.greedy_eye.v1.AssetIdentifierR\n" +
+	"identifier\".\n" +
+	"\x1cDeleteAssetIdentifierRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"8\n" +
+	"\x1bListAssetIdentifiersRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\"`\n" +
+	"\x1cListAssetIdentifiersResponse\x12@\n" +
+	"\videntifiers\x18\x01 \x03(\v2\x1e.greedy_eye.v1.AssetIdentifierR\videntifiers\"\x88\x01\n" +
+	"\x13ResolveAssetRequest\x126\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\".greedy_eye.v1.AssetIdentifierKindR\x04kind\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x19\n" +
+	"\x05chain\x18\x03 \x01(\tH\x00R\x05chain\x88\x01\x01B\b\n" +
+	"\x06_chain\"x\n" +
+	"\n" +
+	"AssetMatch\x12*\n" +
+	"\x05asset\x18\x01 \x01(\v2\x14.greedy_eye.v1.AssetR\x05asset\x12>\n" +
+	"\n" +
+	"identifier\x18\x02 \x01(\v2\x1e.greedy_eye.v1.AssetIdentifierR\n" +
+	"identifier\"\x95\x01\n" +
+	"\x14ResolveAssetResponse\x12*\n" +
+	"\x05asset\x18\x01 \x01(\v2\x14.greedy_eye.v1.AssetR\x05asset\x12\x1c\n" +
+	"\tambiguous\x18\x02 \x01(\bR\tambiguous\x123\n" +
+	"\amatches\x18\x03 \x03(\v2\x19.greedy_eye.v1.AssetMatchR\amatches\"V\n" +
+	"\x1cCreateCorporateActionRequest\x126\n" +
+	"\x06action\x18\x01 \x01(\v2\x1e.greedy_eye.v1.CorporateActionR\x06action\"+\n" +
+	"\x19GetCorporateActionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xfb\x01\n" +
+	"\x1bListCorporateActionsRequest\x12\x1e\n" +
+	"\basset_id\x18\x01 \x01(\tH\x00R\aassetId\x88\x01\x01\x12A\n" +
+	"\x06status\x18\x02 \x01(\x0e2$.greedy_eye.v1.CorporateActionStatusH\x01R\x06status\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x03 \x01(\x05H\x02R\bpageSize\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tH\x03R\tpageToken\x88\x01\x01B\v\n" +
+	"\t_asset_idB\t\n" +
+	"\a_statusB\f\n" +
+	"\n" +
+	"_page_sizeB\r\n" +
+	"\v_page_token\"\x80\x01\n" +
+	"\x1cListCorporateActionsResponse\x128\n" +
+	"\aactions\x18\x01 \x03(\v2\x1e.greedy_eye.v1.CorporateActionR\aactions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\".\n" +
+	"\x1cDeleteCorporateActionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"-\n" +
+	"\x1bApplyCorporateActionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xd1\x01\n" +
+	"\x1cApplyCorporateActionResponse\x126\n" +
+	"\x06action\x18\x01 \x01(\v2\x1e.greedy_eye.v1.CorporateActionR\x06action\x12+\n" +
+	"\x11holdings_adjusted\x18\x02 \x01(\x05R\x10holdingsAdjusted\x12#\n" +
+	"\rlots_adjusted\x18\x03 \x01(\x05R\flotsAdjusted\x12'\n" +
+	"\x0fprices_adjusted\x18\x04 \x01(\x05R\x0epricesAdjusted\".\n" +
+	"\x1cRevertCorporateActionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"@\n" +
 	"\x12CreatePriceRequest\x12*\n" +
 	"\x05price\x18\x01 \x01(\v2\x14.greedy_eye.v1.PriceR\x05price\"C\n" +
 	"\x13CreatePricesRequest\x12,\n" +
-	"\x06prices\x18\x01 \x03(\v2\x14.greedy_eye.v1.PriceR\x06prices\";\n" +
+	"\x06prices\x18\x01 \x03(\v2\x14.greedy_eye.v1.PriceR\x06prices\"h\n" +
 	"\x14CreatePricesResponse\x12#\n" +
-	"\rcreated_count\x18\x01 \x01(\x05R\fcreatedCount\"\x86\x01\n" +
+	"\rcreated_count\x18\x01 \x01(\x05R\fcreatedCount\x12+\n" +
+	"\x11quarantined_count\x18\x02 \x01(\x05R\x10quarantinedCount\"\x86\x01\n" +
 	"\x15GetLatestPriceRequest\x12\x19\n" +
 	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\"\n" +
 	"\rbase_asset_id\x18\x02 \x01(\tR\vbaseAssetId\x12 \n" +
 	"\tsource_id\x18\x03 \x01(\tH\x00R\bsourceId\x88\x01\x01B\f\n" +
 	"\n" +
-	"_source_id\"\xe1\x02\n" +
+	"_source_id\"\x92\x03\n" +
 	"\x17ListPriceHistoryRequest\x12\x19\n" +
 	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\"\n" +
 	"\rbase_asset_id\x18\x02 \x01(\tR\vbaseAssetId\x123\n" +
@@ -1475,7 +4408,8 @@ const file_v1_marketdata_proto_rawDesc = "" +
 	"\tsource_id\x18\x05 \x01(\tH\x02R\bsourceId\x88\x01\x01\x12 \n" +
 	"\tpage_size\x18\x06 \x01(\x05H\x03R\bpageSize\x88\x01\x01\x12\"\n" +
 	"\n" +
-	"page_token\x18\a \x01(\tH\x04R\tpageToken\x88\x01\x01B\a\n" +
+	"page_token\x18\a \x01(\tH\x04R\tpageToken\x88\x01\x01\x12/\n" +
+	"\x13include_quarantined\x18\b \x01(\bR\x12includeQuarantinedB\a\n" +
 	"\x05_fromB\x05\n" +
 	"\x03_toB\f\n" +
 	"\n" +
@@ -1516,15 +4450,67 @@ const file_v1_marketdata_proto_rawDesc = "" +
 	"\x05_fromB\x05\n" +
 	"\x03_toB\f\n" +
 	"\n" +
-	"_source_id\"X\n" +
+	"_source_id\"J\n" +
+	"\tAssetPair\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\"\n" +
+	"\rbase_asset_id\x18\x02 \x01(\tR\vbaseAssetId\"\x9b\x01\n" +
+	"\x12WatchPricesRequest\x12.\n" +
+	"\x05pairs\x18\x01 \x03(\v2\x18.greedy_eye.v1.AssetPairR\x05pairs\x12 \n" +
+	"\tsource_id\x18\x02 \x01(\tH\x00R\bsourceId\x88\x01\x01\x12%\n" +
+	"\x0einclude_latest\x18\x03 \x01(\bR\rincludeLatestB\f\n" +
+	"\n" +
+	"_source_id\"\x99\x01\n" +
 	"\x1aFetchExternalPricesRequest\x12\x1d\n" +
 	"\n" +
 	"source_ids\x18\x01 \x03(\tR\tsourceIds\x12\x1b\n" +
-	"\tasset_ids\x18\x02 \x03(\tR\bassetIds\"\x81\x01\n" +
+	"\tasset_ids\x18\x02 \x03(\tR\bassetIds\x125\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x05since\x88\x01\x01B\b\n" +
+	"\x06_since\"\xdb\x01\n" +
 	"\x1bFetchExternalPricesResponse\x12%\n" +
 	"\x0eprices_fetched\x18\x01 \x01(\x05R\rpricesFetched\x12#\n" +
 	"\rprices_stored\x18\x02 \x01(\x05R\fpricesStored\x12\x16\n" +
-	"\x06errors\x18\x03 \x03(\tR\x06errors*\xb6\x01\n" +
+	"\x06errors\x18\x03 \x03(\tR\x06errors\x12)\n" +
+	"\x10dividends_stored\x18\x04 \x01(\x05R\x0fdividendsStored\x12-\n" +
+	"\x12prices_quarantined\x18\x05 \x01(\x05R\x11pricesQuarantined\"\xa8\x02\n" +
+	"\x1aGetPriceDataQualityRequest\x12\x1e\n" +
+	"\basset_id\x18\x01 \x01(\tH\x00R\aassetId\x88\x01\x01\x12'\n" +
+	"\rbase_asset_id\x18\x02 \x01(\tH\x01R\vbaseAssetId\x88\x01\x01\x12\x1f\n" +
+	"\binterval\x18\x03 \x01(\tH\x02R\binterval\x88\x01\x01\x123\n" +
+	"\x04from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\x04from\x88\x01\x01\x12/\n" +
+	"\x02to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x04R\x02to\x88\x01\x01B\v\n" +
+	"\t_asset_idB\x10\n" +
+	"\x0e_base_asset_idB\v\n" +
+	"\t_intervalB\a\n" +
+	"\x05_fromB\x05\n" +
+	"\x03_to\"\x80\x01\n" +
+	"\bPriceGap\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x18\n" +
+	"\amissing\x18\x03 \x01(\x05R\amissing\"\xf2\x01\n" +
+	"\x12PriceSourceQuality\x12\x1b\n" +
+	"\tsource_id\x18\x01 \x01(\tR\bsourceId\x12\x1f\n" +
+	"\vprice_count\x18\x02 \x01(\x05R\n" +
+	"priceCount\x12+\n" +
+	"\x11quarantined_count\x18\x03 \x01(\x05R\x10quarantinedCount\x12G\n" +
+	"\x0flast_price_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\rlastPriceTime\x88\x01\x01\x12\x14\n" +
+	"\x05stale\x18\x05 \x01(\bR\x05staleB\x12\n" +
+	"\x10_last_price_time\"\xa7\x02\n" +
+	"\x12PriceSeriesQuality\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\"\n" +
+	"\rbase_asset_id\x18\x02 \x01(\tR\vbaseAssetId\x12\x1a\n" +
+	"\binterval\x18\x03 \x01(\tR\binterval\x12\x1f\n" +
+	"\vprice_count\x18\x04 \x01(\x05R\n" +
+	"priceCount\x12+\n" +
+	"\x11quarantined_count\x18\x05 \x01(\x05R\x10quarantinedCount\x12+\n" +
+	"\x04gaps\x18\x06 \x03(\v2\x17.greedy_eye.v1.PriceGapR\x04gaps\x12;\n" +
+	"\asources\x18\a \x03(\v2!.greedy_eye.v1.PriceSourceQualityR\asources\"\xac\x02\n" +
+	"\x1bGetPriceDataQualityResponse\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x129\n" +
+	"\x06series\x18\x03 \x03(\v2!.greedy_eye.v1.PriceSeriesQualityR\x06series\x12\x1b\n" +
+	"\tgap_count\x18\x04 \x01(\x05R\bgapCount\x12+\n" +
+	"\x11quarantined_count\x18\x05 \x01(\x05R\x10quarantinedCount\x12,\n" +
+	"\x12stale_source_count\x18\x06 \x01(\x05R\x10staleSourceCount*\xb6\x01\n" +
 	"\tAssetType\x12\x1a\n" +
 	"\x16ASSET_TYPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
 	"\x19ASSET_TYPE_CRYPTOCURRENCY\x10\x01\x12\x14\n" +
@@ -1532,24 +4518,65 @@ const file_v1_marketdata_proto_rawDesc = "" +
 	"\x0fASSET_TYPE_BOND\x10\x03\x12\x18\n" +
 	"\x14ASSET_TYPE_COMMODITY\x10\x04\x12\x14\n" +
 	"\x10ASSET_TYPE_FOREX\x10\x05\x12\x13\n" +
-	"\x0fASSET_TYPE_FUND\x10\x062\xc5\x0e\n" +
+	"\x0fASSET_TYPE_FUND\x10\x06*\xd7\x01\n" +
+	"\x12DayCountConvention\x12$\n" +
+	" DAY_COUNT_CONVENTION_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fDAY_COUNT_CONVENTION_THIRTY_360\x10\x01\x12&\n" +
+	"\"DAY_COUNT_CONVENTION_ACTUAL_ACTUAL\x10\x02\x12#\n" +
+	"\x1fDAY_COUNT_CONVENTION_ACTUAL_360\x10\x03\x12)\n" +
+	"%DAY_COUNT_CONVENTION_ACTUAL_365_FIXED\x10\x04*\xab\x02\n" +
+	"\x13AssetIdentifierKind\x12%\n" +
+	"!ASSET_IDENTIFIER_KIND_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fASSET_IDENTIFIER_KIND_COINGECKO\x10\x01\x12!\n" +
+	"\x1dASSET_IDENTIFIER_KIND_BINANCE\x10\x02\x12\x1e\n" +
+	"\x1aASSET_IDENTIFIER_KIND_ISIN\x10\x03\x12\x1f\n" +
+	"\x1bASSET_IDENTIFIER_KIND_CUSIP\x10\x04\x12\x1e\n" +
+	"\x1aASSET_IDENTIFIER_KIND_FIGI\x10\x05\x12\"\n" +
+	"\x1eASSET_IDENTIFIER_KIND_CONTRACT\x10\x06\x12 \n" +
+	"\x1cASSET_IDENTIFIER_KIND_TICKER\x10\a*\xa7\x01\n" +
+	"\x13CorporateActionType\x12%\n" +
+	"!CORPORATE_ACTION_TYPE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bCORPORATE_ACTION_TYPE_SPLIT\x10\x01\x12#\n" +
+	"\x1fCORPORATE_ACTION_TYPE_MIGRATION\x10\x02\x12#\n" +
+	"\x1fCORPORATE_ACTION_TYPE_DELISTING\x10\x03*\xb0\x01\n" +
+	"\x15CorporateActionStatus\x12'\n" +
+	"#CORPORATE_ACTION_STATUS_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fCORPORATE_ACTION_STATUS_PENDING\x10\x01\x12#\n" +
+	"\x1fCORPORATE_ACTION_STATUS_APPLIED\x10\x02\x12$\n" +
+	" CORPORATE_ACTION_STATUS_REVERTED\x10\x032\xb8\x1f\n" +
 	"\x11MarketDataService\x12e\n" +
 	"\vCreateAsset\x12!.greedy_eye.v1.CreateAssetRequest\x1a\x14.greedy_eye.v1.Asset\"\x1d\x82\xd3\xe4\x93\x02\x17:\x05asset\"\x0e/api/v1/assets\x12]\n" +
 	"\bGetAsset\x12\x1e.greedy_eye.v1.GetAssetRequest\x1a\x14.greedy_eye.v1.Asset\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/api/v1/assets/{id}\x12p\n" +
 	"\vUpdateAsset\x12!.greedy_eye.v1.UpdateAssetRequest\x1a\x14.greedy_eye.v1.Asset\"(\x82\xd3\xe4\x93\x02\":\x05asset\x1a\x19/api/v1/assets/{asset.id}\x12e\n" +
 	"\vDeleteAsset\x12!.greedy_eye.v1.DeleteAssetRequest\x1a\x16.google.protobuf.Empty\"\x1b\x82\xd3\xe4\x93\x02\x15*\x13/api/v1/assets/{id}\x12i\n" +
 	"\n" +
-	"ListAssets\x12 .greedy_eye.v1.ListAssetsRequest\x1a!.greedy_eye.v1.ListAssetsResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/api/v1/assets\x12{\n" +
-	"\x0fEnrichAssetData\x12%.greedy_eye.v1.EnrichAssetDataRequest\x1a\x14.greedy_eye.v1.Asset\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/assets/{asset_id}/enrich\x12\x8a\x01\n" +
-	"\x11FindSimilarAssets\x12'.greedy_eye.v1.FindSimilarAssetsRequest\x1a!.greedy_eye.v1.ListAssetsResponse\")\x82\xd3\xe4\x93\x02#\x12!/api/v1/assets/{asset_id}/similar\x12e\n" +
+	"ListAssets\x12 .greedy_eye.v1.ListAssetsRequest\x1a!.greedy_eye.v1.ListAssetsResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/api/v1/assets\x12u\n" +
+	"\fSearchAssets\x12\".greedy_eye.v1.SearchAssetsRequest\x1a#.greedy_eye.v1.SearchAssetsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/asset-search\x12~\n" +
+	"\x13ImportExternalAsset\x12).greedy_eye.v1.ImportExternalAssetRequest\x1a\x14.greedy_eye.v1.Asset\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/asset-search/import\x12{\n" +
+	"\x0fEnrichAssetData\x12%.greedy_eye.v1.EnrichAssetDataRequest\x1a\x14.greedy_eye.v1.Asset\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/assets/{asset_id}/enrich\x12\x91\x01\n" +
+	"\x11FindSimilarAssets\x12'.greedy_eye.v1.FindSimilarAssetsRequest\x1a(.greedy_eye.v1.FindSimilarAssetsResponse\")\x82\xd3\xe4\x93\x02#\x12!/api/v1/assets/{asset_id}/similar\x12\x95\x01\n" +
+	"\x10GetBondAnalytics\x12&.greedy_eye.v1.GetBondAnalyticsRequest\x1a'.greedy_eye.v1.GetBondAnalyticsResponse\"0\x82\xd3\xe4\x93\x02*\x12(/api/v1/assets/{asset_id}/bond-analytics\x12\xaa\x01\n" +
+	"\x15CreateAssetIdentifier\x12+.greedy_eye.v1.CreateAssetIdentifierRequest\x1a\x1e.greedy_eye.v1.AssetIdentifier\"D\x82\xd3\xe4\x93\x02>:\n" +
+	"identifier\"0/api/v1/assets/{identifier.asset_id}/identifiers\x12\x84\x01\n" +
+	"\x15DeleteAssetIdentifier\x12+.greedy_eye.v1.DeleteAssetIdentifierRequest\x1a\x16.google.protobuf.Empty\"&\x82\xd3\xe4\x93\x02 *\x1e/api/v1/asset-identifiers/{id}\x12\x9e\x01\n" +
+	"\x14ListAssetIdentifiers\x12*.greedy_eye.v1.ListAssetIdentifiersRequest\x1a+.greedy_eye.v1.ListAssetIdentifiersResponse\"-\x82\xd3\xe4\x93\x02'\x12%/api/v1/assets/{asset_id}/identifiers\x12\x82\x01\n" +
+	"\fResolveAsset\x12\".greedy_eye.v1.ResolveAssetRequest\x1a#.greedy_eye.v1.ResolveAssetResponse\")\x82\xd3\xe4\x93\x02#\x12!/api/v1/asset-identifiers/resolve\x12\xa8\x01\n" +
+	"\x15CreateCorporateAction\x12+.greedy_eye.v1.CreateCorporateActionRequest\x1a\x1e.greedy_eye.v1.CorporateAction\"B\x82\xd3\xe4\x93\x02<:\x06action\"2/api/v1/assets/{action.asset_id}/corporate-actions\x12\x86\x01\n" +
+	"\x12GetCorporateAction\x12(.greedy_eye.v1.GetCorporateActionRequest\x1a\x1e.greedy_eye.v1.CorporateAction\"&\x82\xd3\xe4\x93\x02 \x12\x1e/api/v1/corporate-actions/{id}\x12\x92\x01\n" +
+	"\x14ListCorporateActions\x12*.greedy_eye.v1.ListCorporateActionsRequest\x1a+.greedy_eye.v1.ListCorporateActionsResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/corporate-actions\x12\x84\x01\n" +
+	"\x15DeleteCorporateAction\x12+.greedy_eye.v1.DeleteCorporateActionRequest\x1a\x16.google.protobuf.Empty\"&\x82\xd3\xe4\x93\x02 *\x1e/api/v1/corporate-actions/{id}\x12\xa0\x01\n" +
+	"\x14ApplyCorporateAction\x12*.greedy_eye.v1.ApplyCorporateActionRequest\x1a+.greedy_eye.v1.ApplyCorporateActionResponse\"/\x82\xd3\xe4\x93\x02):\x01*\"$/api/v1/corporate-actions/{id}/apply\x12\x96\x01\n" +
+	"\x15RevertCorporateAction\x12+.greedy_eye.v1.RevertCorporateActionRequest\x1a\x1e.greedy_eye.v1.CorporateAction\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/api/v1/corporate-actions/{id}/revert\x12e\n" +
 	"\vCreatePrice\x12!.greedy_eye.v1.CreatePriceRequest\x1a\x14.greedy_eye.v1.Price\"\x1d\x82\xd3\xe4\x93\x02\x17:\x05price\"\x0e/api/v1/prices\x12|\n" +
 	"\fCreatePrices\x12\".greedy_eye.v1.CreatePricesRequest\x1a#.greedy_eye.v1.CreatePricesResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x06prices\"\x13/api/v1/prices/bulk\x12\x86\x01\n" +
 	"\x0eGetLatestPrice\x12$.greedy_eye.v1.GetLatestPriceRequest\x1a\x14.greedy_eye.v1.Price\"8\x82\xd3\xe4\x93\x022\x120/api/v1/prices/{asset_id}/{base_asset_id}/latest\x12\x9e\x01\n" +
 	"\x10ListPriceHistory\x12&.greedy_eye.v1.ListPriceHistoryRequest\x1a'.greedy_eye.v1.ListPriceHistoryResponse\"9\x82\xd3\xe4\x93\x023\x121/api/v1/prices/{asset_id}/{base_asset_id}/history\x12\xa8\x01\n" +
 	"\x14ListPricesByInterval\x12*.greedy_eye.v1.ListPricesByIntervalRequest\x1a'.greedy_eye.v1.ListPriceHistoryResponse\";\x82\xd3\xe4\x93\x025\x123/api/v1/prices/{asset_id}/{base_asset_id}/intervals\x12e\n" +
 	"\vDeletePrice\x12!.greedy_eye.v1.DeletePriceRequest\x1a\x16.google.protobuf.Empty\"\x1b\x82\xd3\xe4\x93\x02\x15*\x13/api/v1/prices/{id}\x12b\n" +
-	"\fDeletePrices\x12\".greedy_eye.v1.DeletePricesRequest\x1a\x16.google.protobuf.Empty\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/api/v1/prices\x12\x96\x01\n" +
-	"\x13FetchExternalPrices\x12).greedy_eye.v1.FetchExternalPricesRequest\x1a*.greedy_eye.v1.FetchExternalPricesResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/prices/fetch-externalB\xaa\x01\n" +
+	"\fDeletePrices\x12\".greedy_eye.v1.DeletePricesRequest\x1a\x16.google.protobuf.Empty\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/api/v1/prices\x12f\n" +
+	"\vWatchPrices\x12!.greedy_eye.v1.WatchPricesRequest\x1a\x14.greedy_eye.v1.Price\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/prices/watch0\x01\x12\x96\x01\n" +
+	"\x13FetchExternalPrices\x12).greedy_eye.v1.FetchExternalPricesRequest\x1a*.greedy_eye.v1.FetchExternalPricesResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/prices/fetch-external\x12\x8c\x01\n" +
+	"\x13GetPriceDataQuality\x12).greedy_eye.v1.GetPriceDataQualityRequest\x1a*.greedy_eye.v1.GetPriceDataQualityResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/api/v1/prices/qualityB\xaa\x01\n" +
 	"\x11com.greedy_eye.v1B\x0fMarketdataProtoP\x01Z3github.com/foxcool/greedy-eye/internal/api/v1;apiv1\xa2\x02\x03GXX\xaa\x02\fGreedyEye.V1\xca\x02\fGreedyEye\\V1\xe2\x02\x18GreedyEye\\V1\\GPBMetadata\xea\x02\rGreedyEye::V1b\x06proto3"
 
 var (
@@ -1564,88 +4591,210 @@ func file_v1_marketdata_proto_rawDescGZIP() []byte {
 	return file_v1_marketdata_proto_rawDescData
 }
 
-var file_v1_marketdata_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_v1_marketdata_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_v1_marketdata_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_v1_marketdata_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
 var file_v1_marketdata_proto_goTypes = []any{
-	(AssetType)(0),                      // 0: greedy_eye.v1.AssetType
-	(*Asset)(nil),                       // 1: greedy_eye.v1.Asset
-	(*Price)(nil),                       // 2: greedy_eye.v1.Price
-	(*CreateAssetRequest)(nil),          // 3: greedy_eye.v1.CreateAssetRequest
-	(*GetAssetRequest)(nil),             // 4: greedy_eye.v1.GetAssetRequest
-	(*UpdateAssetRequest)(nil),          // 5: greedy_eye.v1.UpdateAssetRequest
-	(*DeleteAssetRequest)(nil),          // 6: greedy_eye.v1.DeleteAssetRequest
-	(*ListAssetsRequest)(nil),           // 7: greedy_eye.v1.ListAssetsRequest
-	(*ListAssetsResponse)(nil),          // 8: greedy_eye.v1.ListAssetsResponse
-	(*EnrichAssetDataRequest)(nil),      // 9: greedy_eye.v1.EnrichAssetDataRequest
-	(*FindSimilarAssetsRequest)(nil),    // 10: greedy_eye.v1.FindSimilarAssetsRequest
-	(*CreatePriceRequest)(nil),          // 11: greedy_eye.v1.CreatePriceRequest
-	(*CreatePricesRequest)(nil),         // 12: greedy_eye.v1.CreatePricesRequest
-	(*CreatePricesResponse)(nil),        // 13: greedy_eye.v1.CreatePricesResponse
-	(*GetLatestPriceRequest)(nil),       // 14: greedy_eye.v1.GetLatestPriceRequest
-	(*ListPriceHistoryRequest)(nil),     // 15: greedy_eye.v1.ListPriceHistoryRequest
-	(*ListPriceHistoryResponse)(nil),    // 16: greedy_eye.v1.ListPriceHistoryResponse
-	(*ListPricesByIntervalRequest)(nil), // 17: greedy_eye.v1.ListPricesByIntervalRequest
-	(*DeletePriceRequest)(nil),          // 18: greedy_eye.v1.DeletePriceRequest
-	(*DeletePricesRequest)(nil),         // 19: greedy_eye.v1.DeletePricesRequest
-	(*FetchExternalPricesRequest)(nil),  // 20: greedy_eye.v1.FetchExternalPricesRequest
-	(*FetchExternalPricesResponse)(nil), // 21: greedy_eye.v1.FetchExternalPricesResponse
-	(*timestamppb.Timestamp)(nil),       // 22: google.protobuf.Timestamp
-	(*fieldmaskpb.FieldMask)(nil),       // 23: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),               // 24: google.protobuf.Empty
+	(AssetType)(0),                       // 0: greedy_eye.v1.AssetType
+	(DayCountConvention)(0),              // 1: greedy_eye.v1.DayCountConvention
+	(AssetIdentifierKind)(0),             // 2: greedy_eye.v1.AssetIdentifierKind
+	(CorporateActionType)(0),             // 3: greedy_eye.v1.CorporateActionType
+	(CorporateActionStatus)(0),           // 4: greedy_eye.v1.CorporateActionStatus
+	(*Asset)(nil),                        // 5: greedy_eye.v1.Asset
+	(*AssetMetadata)(nil),                // 6: greedy_eye.v1.AssetMetadata
+	(*BondTerms)(nil),                    // 7: greedy_eye.v1.BondTerms
+	(*AssetIdentifier)(nil),              // 8: greedy_eye.v1.AssetIdentifier
+	(*CorporateAction)(nil),              // 9: greedy_eye.v1.CorporateAction
+	(*Price)(nil),                        // 10: greedy_eye.v1.Price
+	(*CreateAssetRequest)(nil),           // 11: greedy_eye.v1.CreateAssetRequest
+	(*GetAssetRequest)(nil),              // 12: greedy_eye.v1.GetAssetRequest
+	(*UpdateAssetRequest)(nil),           // 13: greedy_eye.v1.UpdateAssetRequest
+	(*DeleteAssetRequest)(nil),           // 14: greedy_eye.v1.DeleteAssetRequest
+	(*ListAssetsRequest)(nil),            // 15: greedy_eye.v1.ListAssetsRequest
+	(*ListAssetsResponse)(nil),           // 16: greedy_eye.v1.ListAssetsResponse
+	(*SearchAssetsRequest)(nil),          // 17: greedy_eye.v1.SearchAssetsRequest
+	(*SearchAssetsResponse)(nil),         // 18: greedy_eye.v1.SearchAssetsResponse
+	(*ExternalAsset)(nil),                // 19: greedy_eye.v1.ExternalAsset
+	(*ImportExternalAssetRequest)(nil),   // 20: greedy_eye.v1.ImportExternalAssetRequest
+	(*EnrichAssetDataRequest)(nil),       // 21: greedy_eye.v1.EnrichAssetDataRequest
+	(*FindSimilarAssetsRequest)(nil),     // 22: greedy_eye.v1.FindSimilarAssetsRequest
+	(*SimilarAsset)(nil),                 // 23: greedy_eye.v1.SimilarAsset
+	(*FindSimilarAssetsResponse)(nil),    // 24: greedy_eye.v1.FindSimilarAssetsResponse
+	(*GetBondAnalyticsRequest)(nil),      // 25: greedy_eye.v1.GetBondAnalyticsRequest
+	(*BondCashFlow)(nil),                 // 26: greedy_eye.v1.BondCashFlow
+	(*GetBondAnalyticsResponse)(nil),     // 27: greedy_eye.v1.GetBondAnalyticsResponse
+	(*CreateAssetIdentifierRequest)(nil), // 28: greedy_eye.v1.CreateAssetIdentifierRequest
+	(*DeleteAssetIdentifierRequest)(nil), // 29: greedy_eye.v1.DeleteAssetIdentifierRequest
+	(*ListAssetIdentifiersRequest)(nil),  // 30: greedy_eye.v1.ListAssetIdentifiersRequest
+	(*ListAssetIdentifiersResponse)(nil), // 31: greedy_eye.v1.ListAssetIdentifiersResponse
+	(*ResolveAssetRequest)(nil),          // 32: greedy_eye.v1.ResolveAssetRequest
+	(*AssetMatch)(nil),                   // 33: greedy_eye.v1.AssetMatch
+	(*ResolveAssetResponse)(nil),         // 34: greedy_eye.v1.ResolveAssetResponse
+	(*CreateCorporateActionRequest)(nil), // 35: greedy_eye.v1.CreateCorporateActionRequest
+	(*GetCorporateActionRequest)(nil),    // 36: greedy_eye.v1.GetCorporateActionRequest
+	(*ListCorporateActionsRequest)(nil),  // 37: greedy_eye.v1.ListCorporateActionsRequest
+	(*ListCorporateActionsResponse)(nil), // 38: greedy_eye.v1.ListCorporateActionsResponse
+	(*DeleteCorporateActionRequest)(nil), // 39: greedy_eye.v1.DeleteCorporateActionRequest
+	(*ApplyCorporateActionRequest)(nil),  // 40: greedy_eye.v1.ApplyCorporateActionRequest
+	(*ApplyCorporateActionResponse)(nil), // 41: greedy_eye.v1.ApplyCorporateActionResponse
+	(*RevertCorporateActionRequest)(nil), // 42: greedy_eye.v1.RevertCorporateActionRequest
+	(*CreatePriceRequest)(nil),           // 43: greedy_eye.v1.CreatePriceRequest
+	(*CreatePricesRequest)(nil),          // 44: greedy_eye.v1.CreatePricesRequest
+	(*CreatePricesResponse)(nil),         // 45: greedy_eye.v1.CreatePricesResponse
+	(*GetLatestPriceRequest)(nil),        // 46: greedy_eye.v1.GetLatestPriceRequest
+	(*ListPriceHistoryRequest)(nil),      // 47: greedy_eye.v1.ListPriceHistoryRequest
+	(*ListPriceHistoryResponse)(nil),     // 48: greedy_eye.v1.ListPriceHistoryResponse
+	(*ListPricesByIntervalRequest)(nil),  // 49: greedy_eye.v1.ListPricesByIntervalRequest
+	(*DeletePriceRequest)(nil),           // 50: greedy_eye.v1.DeletePriceRequest
+	(*DeletePricesRequest)(nil),          // 51: greedy_eye.v1.DeletePricesRequest
+	(*AssetPair)(nil),                    // 52: greedy_eye.v1.AssetPair
+	(*WatchPricesRequest)(nil),           // 53: greedy_eye.v1.WatchPricesRequest
+	(*FetchExternalPricesRequest)(nil),   // 54: greedy_eye.v1.FetchExternalPricesRequest
+	(*FetchExternalPricesResponse)(nil),  // 55: greedy_eye.v1.FetchExternalPricesResponse
+	(*GetPriceDataQualityRequest)(nil),   // 56: greedy_eye.v1.GetPriceDataQualityRequest
+	(*PriceGap)(nil),                     // 57: greedy_eye.v1.PriceGap
+	(*PriceSourceQuality)(nil),           // 58: greedy_eye.v1.PriceSourceQuality
+	(*PriceSeriesQuality)(nil),           // 59: greedy_eye.v1.PriceSeriesQuality
+	(*GetPriceDataQualityResponse)(nil),  // 60: greedy_eye.v1.GetPriceDataQualityResponse
+	nil,                                  // 61: greedy_eye.v1.Asset.MetadataEntry
+	(*timestamppb.Timestamp)(nil),        // 62: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),        // 63: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),                // 64: google.protobuf.Empty
 }
 var file_v1_marketdata_proto_depIdxs = []int32{
 	0,  // 0: greedy_eye.v1.Asset.type:type_name -> greedy_eye.v1.AssetType
-	22, // 1: greedy_eye.v1.Asset.created_at:type_name -> google.protobuf.Timestamp
-	22, // 2: greedy_eye.v1.Asset.updated_at:type_name -> google.protobuf.Timestamp
-	22, // 3: greedy_eye.v1.Price.timestamp:type_name -> google.protobuf.Timestamp
-	1,  // 4: greedy_eye.v1.CreateAssetRequest.asset:type_name -> greedy_eye.v1.Asset
-	1,  // 5: greedy_eye.v1.UpdateAssetRequest.asset:type_name -> greedy_eye.v1.Asset
-	23, // 6: greedy_eye.v1.UpdateAssetRequest.update_mask:type_name -> google.protobuf.FieldMask
-	1,  // 7: greedy_eye.v1.ListAssetsResponse.assets:type_name -> greedy_eye.v1.Asset
-	2,  // 8: greedy_eye.v1.CreatePriceRequest.price:type_name -> greedy_eye.v1.Price
-	2,  // 9: greedy_eye.v1.CreatePricesRequest.prices:type_name -> greedy_eye.v1.Price
-	22, // 10: greedy_eye.v1.ListPriceHistoryRequest.from:type_name -> google.protobuf.Timestamp
-	22, // 11: greedy_eye.v1.ListPriceHistoryRequest.to:type_name -> google.protobuf.Timestamp
-	2,  // 12: greedy_eye.v1.ListPriceHistoryResponse.prices:type_name -> greedy_eye.v1.Price
-	22, // 13: greedy_eye.v1.ListPricesByIntervalRequest.from:type_name -> google.protobuf.Timestamp
-	22, // 14: greedy_eye.v1.ListPricesByIntervalRequest.to:type_name -> google.protobuf.Timestamp
-	22, // 15: greedy_eye.v1.DeletePricesRequest.from:type_name -> google.protobuf.Timestamp
-	22, // 16: greedy_eye.v1.DeletePricesRequest.to:type_name -> google.protobuf.Timestamp
-	3,  // 17: greedy_eye.v1.MarketDataService.CreateAsset:input_type -> greedy_eye.v1.CreateAssetRequest
-	4,  // 18: greedy_eye.v1.MarketDataService.GetAsset:input_type -> greedy_eye.v1.GetAssetRequest
-	5,  // 19: greedy_eye.v1.MarketDataService.UpdateAsset:input_type -> greedy_eye.v1.UpdateAssetRequest
-	6,  // 20: greedy_eye.v1.MarketDataService.DeleteAsset:input_type -> greedy_eye.v1.DeleteAssetRequest
-	7,  // 21: greedy_eye.v1.MarketDataService.ListAssets:input_type -> greedy_eye.v1.ListAssetsRequest
-	9,  // 22: greedy_eye.v1.MarketDataService.EnrichAssetData:input_type -> greedy_eye.v1.EnrichAssetDataRequest
-	10, // 23: greedy_eye.v1.MarketDataService.FindSimilarAssets:input_type -> greedy_eye.v1.FindSimilarAssetsRequest
-	11, // 24: greedy_eye.v1.MarketDataService.CreatePrice:input_type -> greedy_eye.v1.CreatePriceRequest
-	12, // 25: greedy_eye.v1.MarketDataService.CreatePrices:input_type -> greedy_eye.v1.CreatePricesRequest
-	14, // 26: greedy_eye.v1.MarketDataService.GetLatestPrice:input_type -> greedy_eye.v1.GetLatestPriceRequest
-	15, // 27: greedy_eye.v1.MarketDataService.ListPriceHistory:input_type -> greedy_eye.v1.ListPriceHistoryRequest
-	17, // 28: greedy_eye.v1.MarketDataService.ListPricesByInterval:input_type -> greedy_eye.v1.ListPricesByIntervalRequest
-	18, // 29: greedy_eye.v1.MarketDataService.DeletePrice:input_type -> greedy_eye.v1.DeletePriceRequest
-	19, // 30: greedy_eye.v1.MarketDataService.DeletePrices:input_type -> greedy_eye.v1.DeletePricesRequest
-	20, // 31: greedy_eye.v1.MarketDataService.FetchExternalPrices:input_type -> greedy_eye.v1.FetchExternalPricesRequest
-	1,  // 32: greedy_eye.v1.MarketDataService.CreateAsset:output_type -> greedy_eye.v1.Asset
-	1,  // 33: greedy_eye.v1.MarketDataService.GetAsset:output_type -> greedy_eye.v1.Asset
-	1,  // 34: greedy_eye.v1.MarketDataService.UpdateAsset:output_type -> greedy_eye.v1.Asset
-	24, // 35: greedy_eye.v1.MarketDataService.DeleteAsset:output_type -> google.protobuf.Empty
-	8,  // 36: greedy_eye.v1.MarketDataService.ListAssets:output_type -> greedy_eye.v1.ListAssetsResponse
-	1,  // 37: greedy_eye.v1.MarketDataService.EnrichAssetData:output_type -> greedy_eye.v1.Asset
-	8,  // 38: greedy_eye.v1.MarketDataService.FindSimilarAssets:output_type -> greedy_eye.v1.ListAssetsResponse
-	2,  // 39: greedy_eye.v1.MarketDataService.CreatePrice:output_type -> greedy_eye.v1.Price
-	13, // 40: greedy_eye.v1.MarketDataService.CreatePrices:output_type -> greedy_eye.v1.CreatePricesResponse
-	2,  // 41: greedy_eye.v1.MarketDataService.GetLatestPrice:output_type -> greedy_eye.v1.Price
-	16, // 42: greedy_eye.v1.MarketDataService.ListPriceHistory:output_type -> greedy_eye.v1.ListPriceHistoryResponse
-	16, // 43: greedy_eye.v1.MarketDataService.ListPricesByInterval:output_type -> greedy_eye.v1.ListPriceHistoryResponse
-	24, // 44: greedy_eye.v1.MarketDataService.DeletePrice:output_type -> google.protobuf.Empty
-	24, // 45: greedy_eye.v1.MarketDataService.DeletePrices:output_type -> google.protobuf.Empty
-	21, // 46: greedy_eye.v1.MarketDataService.FetchExternalPrices:output_type -> greedy_eye.v1.FetchExternalPricesResponse
-	32, // [32:47] is the sub-list for method output_type
-	17, // [17:32] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	62, // 1: greedy_eye.v1.Asset.created_at:type_name -> google.protobuf.Timestamp
+	62, // 2: greedy_eye.v1.Asset.updated_at:type_name -> google.protobuf.Timestamp
+	61, // 3: greedy_eye.v1.Asset.metadata:type_name -> greedy_eye.v1.Asset.MetadataEntry
+	62, // 4: greedy_eye.v1.Asset.last_enriched_at:type_name -> google.protobuf.Timestamp
+	7,  // 5: greedy_eye.v1.Asset.bond:type_name -> greedy_eye.v1.BondTerms
+	62, // 6: greedy_eye.v1.AssetMetadata.updated_at:type_name -> google.protobuf.Timestamp
+	62, // 7: greedy_eye.v1.BondTerms.maturity_date:type_name -> google.protobuf.Timestamp
+	62, // 8: greedy_eye.v1.BondTerms.issue_date:type_name -> google.protobuf.Timestamp
+	1,  // 9: greedy_eye.v1.BondTerms.day_count:type_name -> greedy_eye.v1.DayCountConvention
+	2,  // 10: greedy_eye.v1.AssetIdentifier.kind:type_name -> greedy_eye.v1.AssetIdentifierKind
+	62, // 11: greedy_eye.v1.AssetIdentifier.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 12: greedy_eye.v1.CorporateAction.type:type_name -> greedy_eye.v1.CorporateActionType
+	4,  // 13: greedy_eye.v1.CorporateAction.status:type_name -> greedy_eye.v1.CorporateActionStatus
+	62, // 14: greedy_eye.v1.CorporateAction.effective_at:type_name -> google.protobuf.Timestamp
+	62, // 15: greedy_eye.v1.CorporateAction.applied_at:type_name -> google.protobuf.Timestamp
+	62, // 16: greedy_eye.v1.CorporateAction.reverted_at:type_name -> google.protobuf.Timestamp
+	62, // 17: greedy_eye.v1.CorporateAction.created_at:type_name -> google.protobuf.Timestamp
+	62, // 18: greedy_eye.v1.CorporateAction.updated_at:type_name -> google.protobuf.Timestamp
+	62, // 19: greedy_eye.v1.Price.timestamp:type_name -> google.protobuf.Timestamp
+	5,  // 20: greedy_eye.v1.CreateAssetRequest.asset:type_name -> greedy_eye.v1.Asset
+	5,  // 21: greedy_eye.v1.UpdateAssetRequest.asset:type_name -> greedy_eye.v1.Asset
+	63, // 22: greedy_eye.v1.UpdateAssetRequest.update_mask:type_name -> google.protobuf.FieldMask
+	5,  // 23: greedy_eye.v1.ListAssetsResponse.assets:type_name -> greedy_eye.v1.Asset
+	0,  // 24: greedy_eye.v1.SearchAssetsRequest.type:type_name -> greedy_eye.v1.AssetType
+	5,  // 25: greedy_eye.v1.SearchAssetsResponse.assets:type_name -> greedy_eye.v1.Asset
+	19, // 26: greedy_eye.v1.SearchAssetsResponse.external_assets:type_name -> greedy_eye.v1.ExternalAsset
+	0,  // 27: greedy_eye.v1.ExternalAsset.type:type_name -> greedy_eye.v1.AssetType
+	5,  // 28: greedy_eye.v1.SimilarAsset.asset:type_name -> greedy_eye.v1.Asset
+	23, // 29: greedy_eye.v1.FindSimilarAssetsResponse.assets:type_name -> greedy_eye.v1.SimilarAsset
+	62, // 30: greedy_eye.v1.GetBondAnalyticsRequest.settlement_time:type_name -> google.protobuf.Timestamp
+	62, // 31: greedy_eye.v1.BondCashFlow.payment_date:type_name -> google.protobuf.Timestamp
+	62, // 32: greedy_eye.v1.GetBondAnalyticsResponse.settlement_time:type_name -> google.protobuf.Timestamp
+	62, // 33: greedy_eye.v1.GetBondAnalyticsResponse.previous_coupon_date:type_name -> google.protobuf.Timestamp
+	62, // 34: greedy_eye.v1.GetBondAnalyticsResponse.next_coupon_date:type_name -> google.protobuf.Timestamp
+	26, // 35: greedy_eye.v1.GetBondAnalyticsResponse.cash_flows:type_name -> greedy_eye.v1.BondCashFlow
+	8,  // 36: greedy_eye.v1.CreateAssetIdentifierRequest.identifier:type_name -> greedy_eye.v1.AssetIdentifier
+	8,  // 37: greedy_eye.v1.ListAssetIdentifiersResponse.identifiers:type_name -> greedy_eye.v1.AssetIdentifier
+	2,  // 38: greedy_eye.v1.ResolveAssetRequest.kind:type_name -> greedy_eye.v1.AssetIdentifierKind
+	5,  // 39: greedy_eye.v1.AssetMatch.asset:type_name -> greedy_eye.v1.Asset
+	8,  // 40: greedy_eye.v1.AssetMatch.identifier:type_name -> greedy_eye.v1.AssetIdentifier
+	5,  // 41: greedy_eye.v1.ResolveAssetResponse.asset:type_name -> greedy_eye.v1.Asset
+	33, // 42: greedy_eye.v1.ResolveAssetResponse.matches:type_name -> greedy_eye.v1.AssetMatch
+	9,  // 43: greedy_eye.v1.CreateCorporateActionRequest.action:type_name -> greedy_eye.v1.CorporateAction
+	4,  // 44: greedy_eye.v1.ListCorporateActionsRequest.status:type_name -> greedy_eye.v1.CorporateActionStatus
+	9,  // 45: greedy_eye.v1.ListCorporateActionsResponse.actions:type_name -> greedy_eye.v1.CorporateAction
+	9,  // 46: greedy_eye.v1.ApplyCorporateActionResponse.action:type_name -> greedy_eye.v1.CorporateAction
+	10, // 47: greedy_eye.v1.CreatePriceRequest.price:type_name -> greedy_eye.v1.Price
+	10, // 48: greedy_eye.v1.CreatePricesRequest.prices:type_name -> greedy_eye.v1.Price
+	62, // 49: greedy_eye.v1.ListPriceHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	62, // 50: greedy_eye.v1.ListPriceHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	10, // 51: greedy_eye.v1.ListPriceHistoryResponse.prices:type_name -> greedy_eye.v1.Price
+	62, // 52: greedy_eye.v1.ListPricesByIntervalRequest.from:type_name -> google.protobuf.Timestamp
+	62, // 53: greedy_eye.v1.ListPricesByIntervalRequest.to:type_name -> google.protobuf.Timestamp
+	62, // 54: greedy_eye.v1.DeletePricesRequest.from:type_name -> google.protobuf.Timestamp
+	62, // 55: greedy_eye.v1.DeletePricesRequest.to:type_name -> google.protobuf.Timestamp
+	52, // 56: greedy_eye.v1.WatchPricesRequest.pairs:type_name -> greedy_eye.v1.AssetPair
+	62, // 57: greedy_eye.v1.FetchExternalPricesRequest.since:type_name -> google.protobuf.Timestamp
+	62, // 58: greedy_eye.v1.GetPriceDataQualityRequest.from:type_name -> google.protobuf.Timestamp
+	62, // 59: greedy_eye.v1.GetPriceDataQualityRequest.to:type_name -> google.protobuf.Timestamp
+	62, // 60: greedy_eye.v1.PriceGap.from:type_name -> google.protobuf.Timestamp
+	62, // 61: greedy_eye.v1.PriceGap.to:type_name -> google.protobuf.Timestamp
+	62, // 62: greedy_eye.v1.PriceSourceQuality.last_price_time:type_name -> google.protobuf.Timestamp
+	57, // 63: greedy_eye.v1.PriceSeriesQuality.gaps:type_name -> greedy_eye.v1.PriceGap
+	58, // 64: greedy_eye.v1.PriceSeriesQuality.sources:type_name -> greedy_eye.v1.PriceSourceQuality
+	62, // 65: greedy_eye.v1.GetPriceDataQualityResponse.from:type_name -> google.protobuf.Timestamp
+	62, // 66: greedy_eye.v1.GetPriceDataQualityResponse.to:type_name -> google.protobuf.Timestamp
+	59, // 67: greedy_eye.v1.GetPriceDataQualityResponse.series:type_name -> greedy_eye.v1.PriceSeriesQuality
+	6,  // 68: greedy_eye.v1.Asset.MetadataEntry.value:type_name -> greedy_eye.v1.AssetMetadata
+	11, // 69: greedy_eye.v1.MarketDataService.CreateAsset:input_type -> greedy_eye.v1.CreateAssetRequest
+	12, // 70: greedy_eye.v1.MarketDataService.GetAsset:input_type -> greedy_eye.v1.GetAssetRequest
+	13, // 71: greedy_eye.v1.MarketDataService.UpdateAsset:input_type -> greedy_eye.v1.UpdateAssetRequest
+	14, // 72: greedy_eye.v1.MarketDataService.DeleteAsset:input_type -> greedy_eye.v1.DeleteAssetRequest
+	15, // 73: greedy_eye.v1.MarketDataService.ListAssets:input_type -> greedy_eye.v1.ListAssetsRequest
+	17, // 74: greedy_eye.v1.MarketDataService.SearchAssets:input_type -> greedy_eye.v1.SearchAssetsRequest
+	20, // 75: greedy_eye.v1.MarketDataService.ImportExternalAsset:input_type -> greedy_eye.v1.ImportExternalAssetRequest
+	21, // 76: greedy_eye.v1.MarketDataService.EnrichAssetData:input_type -> greedy_eye.v1.EnrichAssetDataRequest
+	22, // 77: greedy_eye.v1.MarketDataService.FindSimilarAssets:input_type -> greedy_eye.v1.FindSimilarAssetsRequest
+	25, // 78: greedy_eye.v1.MarketDataService.GetBondAnalytics:input_type -> greedy_eye.v1.GetBondAnalyticsRequest
+	28, // 79: greedy_eye.v1.MarketDataService.CreateAssetIdentifier:input_type -> greedy_eye.v1.CreateAssetIdentifierRequest
+	29, // 80: greedy_eye.v1.MarketDataService.DeleteAssetIdentifier:input_type -> greedy_eye.v1.DeleteAssetIdentifierRequest
+	30, // 81: greedy_eye.v1.MarketDataService.ListAssetIdentifiers:input_type -> greedy_eye.v1.ListAssetIdentifiersRequest
+	32, // 82: greedy_eye.v1.MarketDataService.ResolveAsset:input_type -> greedy_eye.v1.ResolveAssetRequest
+	35, // 83: greedy_eye.v1.MarketDataService.CreateCorporateAction:input_type -> greedy_eye.v1.CreateCorporateActionRequest
+	36, // 84: greedy_eye.v1.MarketDataService.GetCorporateAction:input_type -> greedy_eye.v1.GetCorporateActionRequest
+	37, // 85: greedy_eye.v1.MarketDataService.ListCorporateActions:input_type -> greedy_eye.v1.ListCorporateActionsRequest
+	39, // 86: greedy_eye.v1.MarketDataService.DeleteCorporateAction:input_type -> greedy_eye.v1.DeleteCorporateActionRequest
+	40, // 87: greedy_eye.v1.MarketDataService.ApplyCorporateAction:input_type -> greedy_eye.v1.ApplyCorporateActionRequest
+	42, // 88: greedy_eye.v1.MarketDataService.RevertCorporateAction:input_type -> greedy_eye.v1.RevertCorporateActionRequest
+	43, // 89: greedy_eye.v1.MarketDataService.CreatePrice:input_type -> greedy_eye.v1.CreatePriceRequest
+	44, // 90: greedy_eye.v1.MarketDataService.CreatePrices:input_type -> greedy_eye.v1.CreatePricesRequest
+	46, // 91: greedy_eye.v1.MarketDataService.GetLatestPrice:input_type -> greedy_eye.v1.GetLatestPriceRequest
+	47, // 92: greedy_eye.v1.MarketDataService.ListPriceHistory:input_type -> greedy_eye.v1.ListPriceHistoryRequest
+	49, // 93: greedy_eye.v1.MarketDataService.ListPricesByInterval:input_type -> greedy_eye.v1.ListPricesByIntervalRequest
+	50, // 94: greedy_eye.v1.MarketDataService.DeletePrice:input_type -> greedy_eye.v1.DeletePriceRequest
+	51, // 95: greedy_eye.v1.MarketDataService.DeletePrices:input_type -> greedy_eye.v1.DeletePricesRequest
+	53, // 96: greedy_eye.v1.MarketDataService.WatchPrices:input_type -> greedy_eye.v1.WatchPricesRequest
+	54, // 97: greedy_eye.v1.MarketDataService.FetchExternalPrices:input_type -> greedy_eye.v1.FetchExternalPricesRequest
+	56, // 98: greedy_eye.v1.MarketDataService.GetPriceDataQuality:input_type -> greedy_eye.v1.GetPriceDataQualityRequest
+	5,  // 99: greedy_eye.v1.MarketDataService.CreateAsset:output_type -> greedy_eye.v1.Asset
+	5,  // 100: greedy_eye.v1.MarketDataService.GetAsset:output_type -> greedy_eye.v1.Asset
+	5,  // 101: greedy_eye.v1.MarketDataService.UpdateAsset:output_type -> greedy_eye.v1.Asset
+	64, // 102: greedy_eye.v1.MarketDataService.DeleteAsset:output_type -> google.protobuf.Empty
+	16, // 103: greedy_eye.v1.MarketDataService.ListAssets:output_type -> greedy_eye.v1.ListAssetsResponse
+	18, // 104: greedy_eye.v1.MarketDataService.SearchAssets:output_type -> greedy_eye.v1.SearchAssetsResponse
+	5,  // 105: greedy_eye.v1.MarketDataService.ImportExternalAsset:output_type -> greedy_eye.v1.Asset
+	5,  // 106: greedy_eye.v1.MarketDataService.EnrichAssetData:output_type -> greedy_eye.v1.Asset
+	24, // 107: greedy_eye.v1.MarketDataService.FindSimilarAssets:output_type -> greedy_eye.v1.FindSimilarAssetsResponse
+	27, // 108: greedy_eye.v1.MarketDataService.GetBondAnalytics:output_type -> greedy_eye.v1.GetBondAnalyticsResponse
+	8,  // 109: greedy_eye.v1.MarketDataService.CreateAssetIdentifier:output_type -> greedy_eye.v1.AssetIdentifier
+	64, // 110: greedy_eye.v1.MarketDataService.DeleteAssetIdentifier:output_type -> google.protobuf.Empty
+	31, // 111: greedy_eye.v1.MarketDataService.ListAssetIdentifiers:output_type -> greedy_eye.v1.ListAssetIdentifiersResponse
+	34, // 112: greedy_eye.v1.MarketDataService.ResolveAsset:output_type -> greedy_eye.v1.ResolveAssetResponse
+	9,  // 113: greedy_eye.v1.MarketDataService.CreateCorporateAction:output_type -> greedy_eye.v1.CorporateAction
+	9,  // 114: greedy_eye.v1.MarketDataService.GetCorporateAction:output_type -> greedy_eye.v1.CorporateAction
+	38, // 115: greedy_eye.v1.MarketDataService.ListCorporateActions:output_type -> greedy_eye.v1.ListCorporateActionsResponse
+	64, // 116: greedy_eye.v1.MarketDataService.DeleteCorporateAction:output_type -> google.protobuf.Empty
+	41, // 117: greedy_eye.v1.MarketDataService.ApplyCorporateAction:output_type -> greedy_eye.v1.ApplyCorporateActionResponse
+	9,  // 118: greedy_eye.v1.MarketDataService.RevertCorporateAction:output_type -> greedy_eye.v1.CorporateAction
+	10, // 119: greedy_eye.v1.MarketDataService.CreatePrice:output_type -> greedy_eye.v1.Price
+	45, // 120: greedy_eye.v1.MarketDataService.CreatePrices:output_type -> greedy_eye.v1.CreatePricesResponse
+	10, // 121: greedy_eye.v1.MarketDataService.GetLatestPrice:output_type -> greedy_eye.v1.Price
+	48, // 122: greedy_eye.v1.MarketDataService.ListPriceHistory:output_type -> greedy_eye.v1.ListPriceHistoryResponse
+	48, // 123: greedy_eye.v1.MarketDataService.ListPricesByInterval:output_type -> greedy_eye.v1.ListPriceHistoryResponse
+	64, // 124: greedy_eye.v1.MarketDataService.DeletePrice:output_type -> google.protobuf.Empty
+	64, // 125: greedy_eye.v1.MarketDataService.DeletePrices:output_type -> google.protobuf.Empty
+	10, // 126: greedy_eye.v1.MarketDataService.WatchPrices:output_type -> greedy_eye.v1.Price
+	55, // 127: greedy_eye.v1.MarketDataService.FetchExternalPrices:output_type -> greedy_eye.v1.FetchExternalPricesResponse
+	60, // 128: greedy_eye.v1.MarketDataService.GetPriceDataQuality:output_type -> greedy_eye.v1.GetPriceDataQualityResponse
+	99, // [99:129] is the sub-list for method output_type
+	69, // [69:99] is the sub-list for method input_type
+	69, // [69:69] is the sub-list for extension type_name
+	69, // [69:69] is the sub-list for extension extendee
+	0,  // [0:69] is the sub-list for field type_name
 }
 
 func init() { file_v1_marketdata_proto_init() }
@@ -1654,19 +4803,33 @@ func file_v1_marketdata_proto_init() {
 		return
 	}
 	file_v1_marketdata_proto_msgTypes[0].OneofWrappers = []any{}
-	file_v1_marketdata_proto_msgTypes[1].OneofWrappers = []any{}
-	file_v1_marketdata_proto_msgTypes[6].OneofWrappers = []any{}
-	file_v1_marketdata_proto_msgTypes[13].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[4].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[5].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[10].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[12].OneofWrappers = []any{}
 	file_v1_marketdata_proto_msgTypes[14].OneofWrappers = []any{}
-	file_v1_marketdata_proto_msgTypes[16].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[17].OneofWrappers = []any{}
 	file_v1_marketdata_proto_msgTypes[18].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[19].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[20].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[22].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[27].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[32].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[41].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[42].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[44].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[46].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[48].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[49].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[51].OneofWrappers = []any{}
+	file_v1_marketdata_proto_msgTypes[53].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_marketdata_proto_rawDesc), len(file_v1_marketdata_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   21,
+			NumEnums:      5,
+			NumMessages:   57,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -90,6 +90,11 @@ const (
 	TransactionType_TRANSACTION_TYPE_TRANSFER    TransactionType = 3 // Transferring asset between accounts
 	TransactionType_TRANSACTION_TYPE_DEPOSIT     TransactionType = 4 // Depositing asset into an account
 	TransactionType_TRANSACTION_TYPE_WITHDRAWAL  TransactionType = 5 // Withdrawing asset from an account
+	// Income from a holding. Its asset is what was received, and its data
+	// fields are "income" (dividend, staking, interest or airdrop), "amount"
+	// received after withholding tax, "withholding_tax" in the same asset,
+	// and "source_asset_id", the holding that paid it.
+	TransactionType_TRANSACTION_TYPE_INCOME TransactionType = 6
 )
 
 // Enum value maps for TransactionType.
@@ -101,6 +106,7 @@ var (
 		3: "TRANSACTION_TYPE_TRANSFER",
 		4: "TRANSACTION_TYPE_DEPOSIT",
 		5: "TRANSACTION_TYPE_WITHDRAWAL",
+		6: "TRANSACTION_TYPE_INCOME",
 	}
 	TransactionType_value = map[string]int32{
 		"TRANSACTION_TYPE_UNSPECIFIED": 0,
@@ -109,6 +115,7 @@ var (
 		"TRANSACTION_TYPE_TRANSFER":    3,
 		"TRANSACTION_TYPE_DEPOSIT":     4,
 		"TRANSACTION_TYPE_WITHDRAWAL":  5,
+		"TRANSACTION_TYPE_INCOME":      6,
 	}
 )
 
@@ -197,6 +204,324 @@ func (TransactionStatus) EnumDescriptor() ([]byte, []int) {
 	return file_v1_portfolio_proto_rawDescGZIP(), []int{2}
 }
 
+// PortfolioRole is what a member may do with a shared portfolio. Each role
+// includes the ones before it.
+type PortfolioRole int32
+
+const (
+	PortfolioRole_PORTFOLIO_ROLE_UNSPECIFIED PortfolioRole = 0
+	PortfolioRole_PORTFOLIO_ROLE_VIEWER      PortfolioRole = 1 // Read the portfolio, its holdings and rules
+	PortfolioRole_PORTFOLIO_ROLE_EDITOR      PortfolioRole = 2 // Change them and execute rules
+	PortfolioRole_PORTFOLIO_ROLE_OWNER       PortfolioRole = 3 // Manage members and delete the portfolio
+)
+
+// Enum value maps for PortfolioRole.
+var (
+	PortfolioRole_name = map[int32]string{
+		0: "PORTFOLIO_ROLE_UNSPECIFIED",
+		1: "PORTFOLIO_ROLE_VIEWER",
+		2: "PORTFOLIO_ROLE_EDITOR",
+		3: "PORTFOLIO_ROLE_OWNER",
+	}
+	PortfolioRole_value = map[string]int32{
+		"PORTFOLIO_ROLE_UNSPECIFIED": 0,
+		"PORTFOLIO_ROLE_VIEWER":      1,
+		"PORTFOLIO_ROLE_EDITOR":      2,
+		"PORTFOLIO_ROLE_OWNER":       3,
+	}
+)
+
+func (x PortfolioRole) Enum() *PortfolioRole {
+	p := new(PortfolioRole)
+	*p = x
+	return p
+}
+
+func (x PortfolioRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PortfolioRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_portfolio_proto_enumTypes[3].Descriptor()
+}
+
+func (PortfolioRole) Type() protoreflect.EnumType {
+	return &file_v1_portfolio_proto_enumTypes[3]
+}
+
+func (x PortfolioRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PortfolioRole.Descriptor instead.
+func (PortfolioRole) EnumDescriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{3}
+}
+
+type PortfolioMemberStatus int32
+
+const (
+	PortfolioMemberStatus_PORTFOLIO_MEMBER_STATUS_UNSPECIFIED PortfolioMemberStatus = 0
+	PortfolioMemberStatus_PORTFOLIO_MEMBER_STATUS_PENDING     PortfolioMemberStatus = 1 // Invited, not yet accepted
+	PortfolioMemberStatus_PORTFOLIO_MEMBER_STATUS_ACTIVE      PortfolioMemberStatus = 2
+)
+
+// Enum value maps for PortfolioMemberStatus.
+var (
+	PortfolioMemberStatus_name = map[int32]string{
+		0: "PORTFOLIO_MEMBER_STATUS_UNSPECIFIED",
+		1: "PORTFOLIO_MEMBER_STATUS_PENDING",
+		2: "PORTFOLIO_MEMBER_STATUS_ACTIVE",
+	}
+	PortfolioMemberStatus_value = map[string]int32{
+		"PORTFOLIO_MEMBER_STATUS_UNSPECIFIED": 0,
+		"PORTFOLIO_MEMBER_STATUS_PENDING":     1,
+		"PORTFOLIO_MEMBER_STATUS_ACTIVE":      2,
+	}
+)
+
+func (x PortfolioMemberStatus) Enum() *PortfolioMemberStatus {
+	p := new(PortfolioMemberStatus)
+	*p = x
+	return p
+}
+
+func (x PortfolioMemberStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PortfolioMemberStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_portfolio_proto_enumTypes[4].Descriptor()
+}
+
+func (PortfolioMemberStatus) Type() protoreflect.EnumType {
+	return &file_v1_portfolio_proto_enumTypes[4]
+}
+
+func (x PortfolioMemberStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PortfolioMemberStatus.Descriptor instead.
+func (PortfolioMemberStatus) EnumDescriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{4}
+}
+
+// ImportFormat names the layout of a transaction export.
+type ImportFormat int32
+
+const (
+	ImportFormat_IMPORT_FORMAT_UNSPECIFIED    ImportFormat = 0
+	ImportFormat_IMPORT_FORMAT_GENERIC        ImportFormat = 1 // Columns named by ImportColumnMapping
+	ImportFormat_IMPORT_FORMAT_BINANCE_TRADES ImportFormat = 2 // Binance spot trade history
+	ImportFormat_IMPORT_FORMAT_COINBASE       ImportFormat = 3 // Coinbase transaction history report
+	ImportFormat_IMPORT_FORMAT_KRAKEN_LEDGER  ImportFormat = 4 // Kraken ledger export
+	ImportFormat_IMPORT_FORMAT_IBKR_ACTIVITY  ImportFormat = 5 // Interactive Brokers activity statement
+)
+
+// Enum value maps for ImportFormat.
+var (
+	ImportFormat_name = map[int32]string{
+		0: "IMPORT_FORMAT_UNSPECIFIED",
+		1: "IMPORT_FORMAT_GENERIC",
+		2: "IMPORT_FORMAT_BINANCE_TRADES",
+		3: "IMPORT_FORMAT_COINBASE",
+		4: "IMPORT_FORMAT_KRAKEN_LEDGER",
+		5: "IMPORT_FORMAT_IBKR_ACTIVITY",
+	}
+	ImportFormat_value = map[string]int32{
+		"IMPORT_FORMAT_UNSPECIFIED":    0,
+		"IMPORT_FORMAT_GENERIC":        1,
+		"IMPORT_FORMAT_BINANCE_TRADES": 2,
+		"IMPORT_FORMAT_COINBASE":       3,
+		"IMPORT_FORMAT_KRAKEN_LEDGER":  4,
+		"IMPORT_FORMAT_IBKR_ACTIVITY":  5,
+	}
+)
+
+func (x ImportFormat) Enum() *ImportFormat {
+	p := new(ImportFormat)
+	*p = x
+	return p
+}
+
+func (x ImportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_portfolio_proto_enumTypes[5].Descriptor()
+}
+
+func (ImportFormat) Type() protoreflect.EnumType {
+	return &file_v1_portfolio_proto_enumTypes[5]
+}
+
+func (x ImportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportFormat.Descriptor instead.
+func (ImportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{5}
+}
+
+type ImportRowStatus int32
+
+const (
+	ImportRowStatus_IMPORT_ROW_STATUS_UNSPECIFIED ImportRowStatus = 0
+	ImportRowStatus_IMPORT_ROW_STATUS_NEW         ImportRowStatus = 1 // Imported, or would be on commit
+	ImportRowStatus_IMPORT_ROW_STATUS_DUPLICATE   ImportRowStatus = 2 // External ID already recorded
+	ImportRowStatus_IMPORT_ROW_STATUS_INVALID     ImportRowStatus = 3 // Could not be parsed or mapped
+)
+
+// Enum value maps for ImportRowStatus.
+var (
+	ImportRowStatus_name = map[int32]string{
+		0: "IMPORT_ROW_STATUS_UNSPECIFIED",
+		1: "IMPORT_ROW_STATUS_NEW",
+		2: "IMPORT_ROW_STATUS_DUPLICATE",
+		3: "IMPORT_ROW_STATUS_INVALID",
+	}
+	ImportRowStatus_value = map[string]int32{
+		"IMPORT_ROW_STATUS_UNSPECIFIED": 0,
+		"IMPORT_ROW_STATUS_NEW":         1,
+		"IMPORT_ROW_STATUS_DUPLICATE":   2,
+		"IMPORT_ROW_STATUS_INVALID":     3,
+	}
+)
+
+func (x ImportRowStatus) Enum() *ImportRowStatus {
+	p := new(ImportRowStatus)
+	*p = x
+	return p
+}
+
+func (x ImportRowStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportRowStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_portfolio_proto_enumTypes[6].Descriptor()
+}
+
+func (ImportRowStatus) Type() protoreflect.EnumType {
+	return &file_v1_portfolio_proto_enumTypes[6]
+}
+
+func (x ImportRowStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportRowStatus.Descriptor instead.
+func (ImportRowStatus) EnumDescriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{6}
+}
+
+// ExportFormat names the file format of a portfolio export.
+type ExportFormat int32
+
+const (
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0
+	ExportFormat_EXPORT_FORMAT_CSV         ExportFormat = 1 // One row per holding, transaction leg and summary
+	ExportFormat_EXPORT_FORMAT_JSONL       ExportFormat = 2 // One JSON object per line
+	ExportFormat_EXPORT_FORMAT_OFX         ExportFormat = 3 // OFX 2.2 investment statement per account
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_UNSPECIFIED",
+		1: "EXPORT_FORMAT_CSV",
+		2: "EXPORT_FORMAT_JSONL",
+		3: "EXPORT_FORMAT_OFX",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_UNSPECIFIED": 0,
+		"EXPORT_FORMAT_CSV":         1,
+		"EXPORT_FORMAT_JSONL":       2,
+		"EXPORT_FORMAT_OFX":         3,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_portfolio_proto_enumTypes[7].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_v1_portfolio_proto_enumTypes[7]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{7}
+}
+
+// HoldingPeriod is how a jurisdiction taxes a disposal by how long the asset
+// was held.
+type HoldingPeriod int32
+
+const (
+	HoldingPeriod_HOLDING_PERIOD_UNSPECIFIED HoldingPeriod = 0 // No distinction, or the acquisition date is unknown
+	HoldingPeriod_HOLDING_PERIOD_SHORT_TERM  HoldingPeriod = 1
+	HoldingPeriod_HOLDING_PERIOD_LONG_TERM   HoldingPeriod = 2
+)
+
+// Enum value maps for HoldingPeriod.
+var (
+	HoldingPeriod_name = map[int32]string{
+		0: "HOLDING_PERIOD_UNSPECIFIED",
+		1: "HOLDING_PERIOD_SHORT_TERM",
+		2: "HOLDING_PERIOD_LONG_TERM",
+	}
+	HoldingPeriod_value = map[string]int32{
+		"HOLDING_PERIOD_UNSPECIFIED": 0,
+		"HOLDING_PERIOD_SHORT_TERM":  1,
+		"HOLDING_PERIOD_LONG_TERM":   2,
+	}
+)
+
+func (x HoldingPeriod) Enum() *HoldingPeriod {
+	p := new(HoldingPeriod)
+	*p = x
+	return p
+}
+
+func (x HoldingPeriod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HoldingPeriod) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_portfolio_proto_enumTypes[8].Descriptor()
+}
+
+func (HoldingPeriod) Type() protoreflect.EnumType {
+	return &file_v1_portfolio_proto_enumTypes[8]
+}
+
+func (x HoldingPeriod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HoldingPeriod.Descriptor instead.
+func (HoldingPeriod) EnumDescriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{8}
+}
+
 // Portfolio represents a collection of holdings managed by a user.
 type Portfolio struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -290,6 +615,117 @@ func (x *Portfolio) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+// PortfolioMember grants a user other than the portfolio's creator a role on
+// it. The creator is always an owner and has no member record.
+type PortfolioMember struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PortfolioId string                 `protobuf:"bytes,2,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	UserId      string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email       string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Role        PortfolioRole          `protobuf:"varint,5,opt,name=role,proto3,enum=greedy_eye.v1.PortfolioRole" json:"role,omitempty"`
+	Status      PortfolioMemberStatus  `protobuf:"varint,6,opt,name=status,proto3,enum=greedy_eye.v1.PortfolioMemberStatus" json:"status,omitempty"`
+	// User who sent the invitation.
+	InvitedBy     string                 `protobuf:"bytes,7,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PortfolioMember) Reset() {
+	*x = PortfolioMember{}
+	mi := &file_v1_portfolio_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortfolioMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortfolioMember) ProtoMessage() {}
+
+func (x *PortfolioMember) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortfolioMember.ProtoReflect.Descriptor instead.
+func (*PortfolioMember) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PortfolioMember) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PortfolioMember) GetPortfolioId() string {
+	if x != nil {
+		return x.PortfolioId
+	}
+	return ""
+}
+
+func (x *PortfolioMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PortfolioMember) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *PortfolioMember) GetRole() PortfolioRole {
+	if x != nil {
+		return x.Role
+	}
+	return PortfolioRole_PORTFOLIO_ROLE_UNSPECIFIED
+}
+
+func (x *PortfolioMember) GetStatus() PortfolioMemberStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PortfolioMemberStatus_PORTFOLIO_MEMBER_STATUS_UNSPECIFIED
+}
+
+func (x *PortfolioMember) GetInvitedBy() string {
+	if x != nil {
+		return x.InvitedBy
+	}
+	return ""
+}
+
+func (x *PortfolioMember) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PortfolioMember) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
 // Holding represents a specific quantity of an Asset held within an Account.
 type Holding struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -307,7 +743,7 @@ type Holding struct {
 
 func (x *Holding) Reset() {
 	*x = Holding{}
-	mi := &file_v1_portfolio_proto_msgTypes[1]
+	mi := &file_v1_portfolio_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -319,7 +755,7 @@ func (x *Holding) String() string {
 func (*Holding) ProtoMessage() {}
 
 func (x *Holding) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[1]
+	mi := &file_v1_portfolio_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -332,7 +768,7 @@ func (x *Holding) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Holding.ProtoReflect.Descriptor instead.
 func (*Holding) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{1}
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Holding) GetId() string {
@@ -408,7 +844,7 @@ type Account struct {
 
 func (x *Account) Reset() {
 	*x = Account{}
-	mi := &file_v1_portfolio_proto_msgTypes[2]
+	mi := &file_v1_portfolio_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -420,7 +856,7 @@ func (x *Account) String() string {
 func (*Account) ProtoMessage() {}
 
 func (x *Account) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[2]
+	mi := &file_v1_portfolio_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,7 +869,7 @@ func (x *Account) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Account.ProtoReflect.Descriptor instead.
 func (*Account) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{2}
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Account) GetId() string {
@@ -492,35 +928,39 @@ func (x *Account) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-// Transaction represents a financial event involving assets, accounts, and portfolios.
-type Transaction struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Type          TransactionType        `protobuf:"varint,4,opt,name=type,proto3,enum=greedy_eye.v1.TransactionType" json:"type,omitempty"`
-	Status        TransactionStatus      `protobuf:"varint,5,opt,name=status,proto3,enum=greedy_eye.v1.TransactionStatus" json:"status,omitempty"`
-	AccountId     string                 `protobuf:"bytes,6,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Data          map[string]string      `protobuf:"bytes,7,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+// Lot represents a tax lot: a part of a Holding acquired at one time and cost.
+type Lot struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	HoldingId string                 `protobuf:"bytes,2,opt,name=holding_id,json=holdingId,proto3" json:"holding_id,omitempty"`
+	Amount    int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Decimals  uint32                 `protobuf:"varint,4,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	// Total acquisition cost of the remaining amount, quoted in cost_asset_id.
+	CostBasis     int64                  `protobuf:"varint,5,opt,name=cost_basis,json=costBasis,proto3" json:"cost_basis,omitempty"`
+	CostDecimals  uint32                 `protobuf:"varint,6,opt,name=cost_decimals,json=costDecimals,proto3" json:"cost_decimals,omitempty"`
+	CostAssetId   string                 `protobuf:"bytes,7,opt,name=cost_asset_id,json=costAssetId,proto3" json:"cost_asset_id,omitempty"`
+	AcquiredAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=acquired_at,json=acquiredAt,proto3" json:"acquired_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Transaction) Reset() {
-	*x = Transaction{}
-	mi := &file_v1_portfolio_proto_msgTypes[3]
+func (x *Lot) Reset() {
+	*x = Lot{}
+	mi := &file_v1_portfolio_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Transaction) String() string {
+func (x *Lot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Transaction) ProtoMessage() {}
+func (*Lot) ProtoMessage() {}
 
-func (x *Transaction) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[3]
+func (x *Lot) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -531,9 +971,127 @@ func (x *Transaction) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
-func (*Transaction) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use Lot.ProtoReflect.Descriptor instead.
+func (*Lot) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Lot) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Lot) GetHoldingId() string {
+	if x != nil {
+		return x.HoldingId
+	}
+	return ""
+}
+
+func (x *Lot) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Lot) GetDecimals() uint32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *Lot) GetCostBasis() int64 {
+	if x != nil {
+		return x.CostBasis
+	}
+	return 0
+}
+
+func (x *Lot) GetCostDecimals() uint32 {
+	if x != nil {
+		return x.CostDecimals
+	}
+	return 0
+}
+
+func (x *Lot) GetCostAssetId() string {
+	if x != nil {
+		return x.CostAssetId
+	}
+	return ""
+}
+
+func (x *Lot) GetAcquiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AcquiredAt
+	}
+	return nil
+}
+
+func (x *Lot) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Lot) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Transaction represents a financial event involving assets, accounts, and portfolios.
+type Transaction struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Type      TransactionType        `protobuf:"varint,4,opt,name=type,proto3,enum=greedy_eye.v1.TransactionType" json:"type,omitempty"`
+	Status    TransactionStatus      `protobuf:"varint,5,opt,name=status,proto3,enum=greedy_eye.v1.TransactionStatus" json:"status,omitempty"`
+	AccountId string                 `protobuf:"bytes,6,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Data      map[string]string      `protobuf:"bytes,7,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	AssetId   *string                `protobuf:"bytes,8,opt,name=asset_id,json=assetId,proto3,oneof" json:"asset_id,omitempty"`
+	// Identifier of the transaction at its source, such as an exchange trade
+	// ID. Unique per account; imports skip rows whose ID is already recorded.
+	ExternalId    *string `protobuf:"bytes,9,opt,name=external_id,json=externalId,proto3,oneof" json:"external_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_v1_portfolio_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Transaction) GetId() string {
@@ -585,6 +1143,20 @@ func (x *Transaction) GetData() map[string]string {
 	return nil
 }
 
+func (x *Transaction) GetAssetId() string {
+	if x != nil && x.AssetId != nil {
+		return *x.AssetId
+	}
+	return ""
+}
+
+func (x *Transaction) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
 type CreatePortfolioRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Portfolio     *Portfolio             `protobuf:"bytes,1,opt,name=portfolio,proto3" json:"portfolio,omitempty"`
@@ -594,7 +1166,7 @@ type CreatePortfolioRequest struct {
 
 func (x *CreatePortfolioRequest) Reset() {
 	*x = CreatePortfolioRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[4]
+	mi := &file_v1_portfolio_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -606,7 +1178,7 @@ func (x *CreatePortfolioRequest) String() string {
 func (*CreatePortfolioRequest) ProtoMessage() {}
 
 func (x *CreatePortfolioRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[4]
+	mi := &file_v1_portfolio_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -619,7 +1191,7 @@ func (x *CreatePortfolioRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePortfolioRequest.ProtoReflect.Descriptor instead.
 func (*CreatePortfolioRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{4}
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CreatePortfolioRequest) GetPortfolio() *Portfolio {
@@ -638,7 +1210,7 @@ type GetPortfolioRequest struct {
 
 func (x *GetPortfolioRequest) Reset() {
 	*x = GetPortfolioRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[5]
+	mi := &file_v1_portfolio_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -650,7 +1222,7 @@ func (x *GetPortfolioRequest) String() string {
 func (*GetPortfolioRequest) ProtoMessage() {}
 
 func (x *GetPortfolioRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[5]
+	mi := &file_v1_portfolio_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -663,7 +1235,7 @@ func (x *GetPortfolioRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPortfolioRequest.ProtoReflect.Descriptor instead.
 func (*GetPortfolioRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{5}
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetPortfolioRequest) GetId() string {
@@ -683,7 +1255,7 @@ type UpdatePortfolioRequest struct {
 
 func (x *UpdatePortfolioRequest) Reset() {
 	*x = UpdatePortfolioRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[6]
+	mi := &file_v1_portfolio_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -695,7 +1267,7 @@ func (x *UpdatePortfolioRequest) String() string {
 func (*UpdatePortfolioRequest) ProtoMessage() {}
 
 func (x *UpdatePortfolioRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[6]
+	mi := &file_v1_portfolio_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -708,7 +1280,7 @@ func (x *UpdatePortfolioRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePortfolioRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePortfolioRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{6}
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UpdatePortfolioRequest) GetPortfolio() *Portfolio {
@@ -734,7 +1306,7 @@ type DeletePortfolioRequest struct {
 
 func (x *DeletePortfolioRequest) Reset() {
 	*x = DeletePortfolioRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[7]
+	mi := &file_v1_portfolio_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -746,7 +1318,7 @@ func (x *DeletePortfolioRequest) String() string {
 func (*DeletePortfolioRequest) ProtoMessage() {}
 
 func (x *DeletePortfolioRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[7]
+	mi := &file_v1_portfolio_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -759,7 +1331,7 @@ func (x *DeletePortfolioRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePortfolioRequest.ProtoReflect.Descriptor instead.
 func (*DeletePortfolioRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{7}
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DeletePortfolioRequest) GetId() string {
@@ -780,7 +1352,7 @@ type ListPortfoliosRequest struct {
 
 func (x *ListPortfoliosRequest) Reset() {
 	*x = ListPortfoliosRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[8]
+	mi := &file_v1_portfolio_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -792,7 +1364,7 @@ func (x *ListPortfoliosRequest) String() string {
 func (*ListPortfoliosRequest) ProtoMessage() {}
 
 func (x *ListPortfoliosRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[8]
+	mi := &file_v1_portfolio_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -805,7 +1377,7 @@ func (x *ListPortfoliosRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPortfoliosRequest.ProtoReflect.Descriptor instead.
 func (*ListPortfoliosRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{8}
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListPortfoliosRequest) GetUserId() string {
@@ -839,7 +1411,7 @@ type ListPortfoliosResponse struct {
 
 func (x *ListPortfoliosResponse) Reset() {
 	*x = ListPortfoliosResponse{}
-	mi := &file_v1_portfolio_proto_msgTypes[9]
+	mi := &file_v1_portfolio_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -851,7 +1423,7 @@ func (x *ListPortfoliosResponse) String() string {
 func (*ListPortfoliosResponse) ProtoMessage() {}
 
 func (x *ListPortfoliosResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[9]
+	mi := &file_v1_portfolio_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -864,7 +1436,7 @@ func (x *ListPortfoliosResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPortfoliosResponse.ProtoReflect.Descriptor instead.
 func (*ListPortfoliosResponse) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{9}
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListPortfoliosResponse) GetPortfolios() []*Portfolio {
@@ -881,10 +1453,14 @@ func (x *ListPortfoliosResponse) GetNextPageToken() string {
 	return ""
 }
 
+// CalculatePortfolioValueRequest values a portfolio's current holdings at
+// the prices and exchange rates of a time.
 type CalculatePortfolioValueRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PortfolioId   string                 `protobuf:"bytes,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
-	QuoteAssetId  string                 `protobuf:"bytes,2,opt,name=quote_asset_id,json=quoteAssetId,proto3" json:"quote_asset_id,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId string                 `protobuf:"bytes,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	// Defaults to the caller's default currency.
+	QuoteAssetId string `protobuf:"bytes,2,opt,name=quote_asset_id,json=quoteAssetId,proto3" json:"quote_asset_id,omitempty"`
+	// Defaults to now.
 	AtTime        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=at_time,json=atTime,proto3" json:"at_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -892,7 +1468,7 @@ type CalculatePortfolioValueRequest struct {
 
 func (x *CalculatePortfolioValueRequest) Reset() {
 	*x = CalculatePortfolioValueRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[10]
+	mi := &file_v1_portfolio_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -904,7 +1480,7 @@ func (x *CalculatePortfolioValueRequest) String() string {
 func (*CalculatePortfolioValueRequest) ProtoMessage() {}
 
 func (x *CalculatePortfolioValueRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[10]
+	mi := &file_v1_portfolio_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -917,7 +1493,7 @@ func (x *CalculatePortfolioValueRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CalculatePortfolioValueRequest.ProtoReflect.Descriptor instead.
 func (*CalculatePortfolioValueRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{10}
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CalculatePortfolioValueRequest) GetPortfolioId() string {
@@ -948,13 +1524,16 @@ type PortfolioValueResponse struct {
 	TotalValueAmount int64                  `protobuf:"varint,3,opt,name=total_value_amount,json=totalValueAmount,proto3" json:"total_value_amount,omitempty"`
 	Decimals         uint32                 `protobuf:"varint,4,opt,name=decimals,proto3" json:"decimals,omitempty"`
 	CalculationTime  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=calculation_time,json=calculationTime,proto3" json:"calculation_time,omitempty"`
+	// Held assets without a price in the quote asset at the time, left out
+	// of the total.
+	UnpricedAssetIds []string `protobuf:"bytes,6,rep,name=unpriced_asset_ids,json=unpricedAssetIds,proto3" json:"unpriced_asset_ids,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
 func (x *PortfolioValueResponse) Reset() {
 	*x = PortfolioValueResponse{}
-	mi := &file_v1_portfolio_proto_msgTypes[11]
+	mi := &file_v1_portfolio_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -966,7 +1545,7 @@ func (x *PortfolioValueResponse) String() string {
 func (*PortfolioValueResponse) ProtoMessage() {}
 
 func (x *PortfolioValueResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[11]
+	mi := &file_v1_portfolio_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -979,7 +1558,7 @@ func (x *PortfolioValueResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortfolioValueResponse.ProtoReflect.Descriptor instead.
 func (*PortfolioValueResponse) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{11}
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PortfolioValueResponse) GetPortfolioId() string {
@@ -1017,6 +1596,13 @@ func (x *PortfolioValueResponse) GetCalculationTime() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *PortfolioValueResponse) GetUnpricedAssetIds() []string {
+	if x != nil {
+		return x.UnpricedAssetIds
+	}
+	return nil
+}
+
 type GetPortfolioPerformanceRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	PortfolioId      string                 `protobuf:"bytes,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
@@ -1029,7 +1615,7 @@ type GetPortfolioPerformanceRequest struct {
 
 func (x *GetPortfolioPerformanceRequest) Reset() {
 	*x = GetPortfolioPerformanceRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[12]
+	mi := &file_v1_portfolio_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1041,7 +1627,7 @@ func (x *GetPortfolioPerformanceRequest) String() string {
 func (*GetPortfolioPerformanceRequest) ProtoMessage() {}
 
 func (x *GetPortfolioPerformanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[12]
+	mi := &file_v1_portfolio_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1054,7 +1640,7 @@ func (x *GetPortfolioPerformanceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPortfolioPerformanceRequest.ProtoReflect.Descriptor instead.
 func (*GetPortfolioPerformanceRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{12}
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetPortfolioPerformanceRequest) GetPortfolioId() string {
@@ -1097,7 +1683,7 @@ type PortfolioPerformanceResponse struct {
 
 func (x *PortfolioPerformanceResponse) Reset() {
 	*x = PortfolioPerformanceResponse{}
-	mi := &file_v1_portfolio_proto_msgTypes[13]
+	mi := &file_v1_portfolio_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1109,7 +1695,7 @@ func (x *PortfolioPerformanceResponse) String() string {
 func (*PortfolioPerformanceResponse) ProtoMessage() {}
 
 func (x *PortfolioPerformanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[13]
+	mi := &file_v1_portfolio_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1122,7 +1708,7 @@ func (x *PortfolioPerformanceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortfolioPerformanceResponse.ProtoReflect.Descriptor instead.
 func (*PortfolioPerformanceResponse) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{13}
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *PortfolioPerformanceResponse) GetPortfolioId() string {
@@ -1153,28 +1739,36 @@ func (x *PortfolioPerformanceResponse) GetSharpeRatio() float64 {
 	return 0
 }
 
-type CreateHoldingRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Holding       *Holding               `protobuf:"bytes,1,opt,name=holding,proto3" json:"holding,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GetIncomeSummaryRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId string                 `protobuf:"bytes,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	// Income received in [from, to). Defaults to the year up to now.
+	From *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Currency to value income in, at the rate of the day it was received.
+	// Defaults to the caller's default currency.
+	CurrencyAssetId *string `protobuf:"bytes,4,opt,name=currency_asset_id,json=currencyAssetId,proto3,oneof" json:"currency_asset_id,omitempty"`
+	// Months up to now that the projection is based on. Defaults to 12.
+	LookbackMonths *int32 `protobuf:"varint,5,opt,name=lookback_months,json=lookbackMonths,proto3,oneof" json:"lookback_months,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *CreateHoldingRequest) Reset() {
-	*x = CreateHoldingRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[14]
+func (x *GetIncomeSummaryRequest) Reset() {
+	*x = GetIncomeSummaryRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateHoldingRequest) String() string {
+func (x *GetIncomeSummaryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateHoldingRequest) ProtoMessage() {}
+func (*GetIncomeSummaryRequest) ProtoMessage() {}
 
-func (x *CreateHoldingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[14]
+func (x *GetIncomeSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1185,85 +1779,76 @@ func (x *CreateHoldingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateHoldingRequest.ProtoReflect.Descriptor instead.
-func (*CreateHoldingRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use GetIncomeSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetIncomeSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *CreateHoldingRequest) GetHolding() *Holding {
+func (x *GetIncomeSummaryRequest) GetPortfolioId() string {
 	if x != nil {
-		return x.Holding
+		return x.PortfolioId
 	}
-	return nil
-}
-
-type GetHoldingRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetHoldingRequest) Reset() {
-	*x = GetHoldingRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[15]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return ""
 }
 
-func (x *GetHoldingRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GetIncomeSummaryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
 }
 
-func (*GetHoldingRequest) ProtoMessage() {}
-
-func (x *GetHoldingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[15]
+func (x *GetIncomeSummaryRequest) GetTo() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.To
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GetHoldingRequest.ProtoReflect.Descriptor instead.
-func (*GetHoldingRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{15}
+func (x *GetIncomeSummaryRequest) GetCurrencyAssetId() string {
+	if x != nil && x.CurrencyAssetId != nil {
+		return *x.CurrencyAssetId
+	}
+	return ""
 }
 
-func (x *GetHoldingRequest) GetId() string {
-	if x != nil {
-		return x.Id
+func (x *GetIncomeSummaryRequest) GetLookbackMonths() int32 {
+	if x != nil && x.LookbackMonths != nil {
+		return *x.LookbackMonths
 	}
-	return ""
+	return 0
 }
 
-type UpdateHoldingRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Holding       *Holding               `protobuf:"bytes,1,opt,name=holding,proto3" json:"holding,omitempty"`
-	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// AssetIncome is the income paid by one asset: the holding a dividend or
+// reward was paid on, or else the asset received. Decimal values are
+// strings in the summary's currency.
+type AssetIncome struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AssetId string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Symbol  string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Received, after withholding tax.
+	Income         string `protobuf:"bytes,3,opt,name=income,proto3" json:"income,omitempty"`
+	WithholdingTax string `protobuf:"bytes,4,opt,name=withholding_tax,json=withholdingTax,proto3" json:"withholding_tax,omitempty"`
+	Count          int32  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *UpdateHoldingRequest) Reset() {
-	*x = UpdateHoldingRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[16]
+func (x *AssetIncome) Reset() {
+	*x = AssetIncome{}
+	mi := &file_v1_portfolio_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateHoldingRequest) String() string {
+func (x *AssetIncome) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateHoldingRequest) ProtoMessage() {}
+func (*AssetIncome) ProtoMessage() {}
 
-func (x *UpdateHoldingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[16]
+func (x *AssetIncome) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1274,51 +1859,2090 @@ func (x *UpdateHoldingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateHoldingRequest.ProtoReflect.Descriptor instead.
-func (*UpdateHoldingRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use AssetIncome.ProtoReflect.Descriptor instead.
+func (*AssetIncome) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *UpdateHoldingRequest) GetHolding() *Holding {
+func (x *AssetIncome) GetAssetId() string {
 	if x != nil {
-		return x.Holding
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *AssetIncome) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AssetIncome) GetIncome() string {
+	if x != nil {
+		return x.Income
+	}
+	return ""
+}
+
+func (x *AssetIncome) GetWithholdingTax() string {
+	if x != nil {
+		return x.WithholdingTax
+	}
+	return ""
+}
+
+func (x *AssetIncome) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type AccountIncome struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountId      string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Income         string                 `protobuf:"bytes,2,opt,name=income,proto3" json:"income,omitempty"`
+	WithholdingTax string                 `protobuf:"bytes,3,opt,name=withholding_tax,json=withholdingTax,proto3" json:"withholding_tax,omitempty"`
+	Count          int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AccountIncome) Reset() {
+	*x = AccountIncome{}
+	mi := &file_v1_portfolio_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountIncome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountIncome) ProtoMessage() {}
+
+func (x *AccountIncome) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountIncome.ProtoReflect.Descriptor instead.
+func (*AccountIncome) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AccountIncome) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AccountIncome) GetIncome() string {
+	if x != nil {
+		return x.Income
+	}
+	return ""
+}
+
+func (x *AccountIncome) GetWithholdingTax() string {
+	if x != nil {
+		return x.WithholdingTax
+	}
+	return ""
+}
+
+func (x *AccountIncome) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type MonthlyIncome struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// First day of the month, in UTC.
+	Month          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"`
+	Income         string                 `protobuf:"bytes,2,opt,name=income,proto3" json:"income,omitempty"`
+	WithholdingTax string                 `protobuf:"bytes,3,opt,name=withholding_tax,json=withholdingTax,proto3" json:"withholding_tax,omitempty"`
+	Count          int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MonthlyIncome) Reset() {
+	*x = MonthlyIncome{}
+	mi := &file_v1_portfolio_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonthlyIncome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonthlyIncome) ProtoMessage() {}
+
+func (x *MonthlyIncome) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonthlyIncome.ProtoReflect.Descriptor instead.
+func (*MonthlyIncome) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MonthlyIncome) GetMonth() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Month
+	}
+	return nil
+}
+
+func (x *MonthlyIncome) GetIncome() string {
+	if x != nil {
+		return x.Income
+	}
+	return ""
+}
+
+func (x *MonthlyIncome) GetWithholdingTax() string {
+	if x != nil {
+		return x.WithholdingTax
+	}
+	return ""
+}
+
+func (x *MonthlyIncome) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// AssetYield projects the income of an asset still held.
+type AssetYield struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AssetId string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Symbol  string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Income of the lookback, scaled to a year.
+	AnnualIncome string `protobuf:"bytes,3,opt,name=annual_income,json=annualIncome,proto3" json:"annual_income,omitempty"`
+	// Current value of the portfolio's holdings of the asset.
+	Value *string `protobuf:"bytes,4,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	// annual_income / value.
+	Yield         *string `protobuf:"bytes,5,opt,name=yield,proto3,oneof" json:"yield,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetYield) Reset() {
+	*x = AssetYield{}
+	mi := &file_v1_portfolio_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetYield) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetYield) ProtoMessage() {}
+
+func (x *AssetYield) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetYield.ProtoReflect.Descriptor instead.
+func (*AssetYield) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AssetYield) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *AssetYield) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AssetYield) GetAnnualIncome() string {
+	if x != nil {
+		return x.AnnualIncome
+	}
+	return ""
+}
+
+func (x *AssetYield) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+func (x *AssetYield) GetYield() string {
+	if x != nil && x.Yield != nil {
+		return *x.Yield
+	}
+	return ""
+}
+
+// IncomeProjection expects the next year to pay what the lookback did, for
+// the assets still held.
+type IncomeProjection struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	LookbackMonths int32                  `protobuf:"varint,1,opt,name=lookback_months,json=lookbackMonths,proto3" json:"lookback_months,omitempty"`
+	AnnualIncome   string                 `protobuf:"bytes,2,opt,name=annual_income,json=annualIncome,proto3" json:"annual_income,omitempty"`
+	// Current value of the portfolio's priced holdings.
+	PortfolioValue string `protobuf:"bytes,3,opt,name=portfolio_value,json=portfolioValue,proto3" json:"portfolio_value,omitempty"`
+	// annual_income / portfolio_value.
+	Yield *string `protobuf:"bytes,4,opt,name=yield,proto3,oneof" json:"yield,omitempty"`
+	// By annual income, largest first.
+	Assets        []*AssetYield `protobuf:"bytes,5,rep,name=assets,proto3" json:"assets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncomeProjection) Reset() {
+	*x = IncomeProjection{}
+	mi := &file_v1_portfolio_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncomeProjection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncomeProjection) ProtoMessage() {}
+
+func (x *IncomeProjection) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncomeProjection.ProtoReflect.Descriptor instead.
+func (*IncomeProjection) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *IncomeProjection) GetLookbackMonths() int32 {
+	if x != nil {
+		return x.LookbackMonths
+	}
+	return 0
+}
+
+func (x *IncomeProjection) GetAnnualIncome() string {
+	if x != nil {
+		return x.AnnualIncome
+	}
+	return ""
+}
+
+func (x *IncomeProjection) GetPortfolioValue() string {
+	if x != nil {
+		return x.PortfolioValue
+	}
+	return ""
+}
+
+func (x *IncomeProjection) GetYield() string {
+	if x != nil && x.Yield != nil {
+		return *x.Yield
+	}
+	return ""
+}
+
+func (x *IncomeProjection) GetAssets() []*AssetYield {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+// IncomeSummary totals income transactions: the income type and, as
+// recorded before it, extended transactions with an "income" data field.
+type IncomeSummary struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId     string                 `protobuf:"bytes,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	CurrencyAssetId string                 `protobuf:"bytes,2,opt,name=currency_asset_id,json=currencyAssetId,proto3" json:"currency_asset_id,omitempty"`
+	From            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To              *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	// Received in the period, after withholding tax.
+	Income         string `protobuf:"bytes,5,opt,name=income,proto3" json:"income,omitempty"`
+	WithholdingTax string `protobuf:"bytes,6,opt,name=withholding_tax,json=withholdingTax,proto3" json:"withholding_tax,omitempty"`
+	// By income, largest first.
+	Assets   []*AssetIncome   `protobuf:"bytes,7,rep,name=assets,proto3" json:"assets,omitempty"`
+	Accounts []*AccountIncome `protobuf:"bytes,8,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	// In order; months without income are left out.
+	Months []*MonthlyIncome `protobuf:"bytes,9,rep,name=months,proto3" json:"months,omitempty"`
+	// Income without a rate to the currency, left out of the sums.
+	UnvaluedCount int32             `protobuf:"varint,10,opt,name=unvalued_count,json=unvaluedCount,proto3" json:"unvalued_count,omitempty"`
+	Projection    *IncomeProjection `protobuf:"bytes,11,opt,name=projection,proto3" json:"projection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncomeSummary) Reset() {
+	*x = IncomeSummary{}
+	mi := &file_v1_portfolio_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncomeSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncomeSummary) ProtoMessage() {}
+
+func (x *IncomeSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncomeSummary.ProtoReflect.Descriptor instead.
+func (*IncomeSummary) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *IncomeSummary) GetPortfolioId() string {
+	if x != nil {
+		return x.PortfolioId
+	}
+	return ""
+}
+
+func (x *IncomeSummary) GetCurrencyAssetId() string {
+	if x != nil {
+		return x.CurrencyAssetId
+	}
+	return ""
+}
+
+func (x *IncomeSummary) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *IncomeSummary) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *IncomeSummary) GetIncome() string {
+	if x != nil {
+		return x.Income
+	}
+	return ""
+}
+
+func (x *IncomeSummary) GetWithholdingTax() string {
+	if x != nil {
+		return x.WithholdingTax
+	}
+	return ""
+}
+
+func (x *IncomeSummary) GetAssets() []*AssetIncome {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+func (x *IncomeSummary) GetAccounts() []*AccountIncome {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+func (x *IncomeSummary) GetMonths() []*MonthlyIncome {
+	if x != nil {
+		return x.Months
+	}
+	return nil
+}
+
+func (x *IncomeSummary) GetUnvaluedCount() int32 {
+	if x != nil {
+		return x.UnvaluedCount
+	}
+	return 0
+}
+
+func (x *IncomeSummary) GetProjection() *IncomeProjection {
+	if x != nil {
+		return x.Projection
+	}
+	return nil
+}
+
+type InvitePortfolioMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId   string                 `protobuf:"bytes,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Role          PortfolioRole          `protobuf:"varint,3,opt,name=role,proto3,enum=greedy_eye.v1.PortfolioRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvitePortfolioMemberRequest) Reset() {
+	*x = InvitePortfolioMemberRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvitePortfolioMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvitePortfolioMemberRequest) ProtoMessage() {}
+
+func (x *InvitePortfolioMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvitePortfolioMemberRequest.ProtoReflect.Descriptor instead.
+func (*InvitePortfolioMemberRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *InvitePortfolioMemberRequest) GetPortfolioId() string {
+	if x != nil {
+		return x.PortfolioId
+	}
+	return ""
+}
+
+func (x *InvitePortfolioMemberRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *InvitePortfolioMemberRequest) GetRole() PortfolioRole {
+	if x != nil {
+		return x.Role
+	}
+	return PortfolioRole_PORTFOLIO_ROLE_UNSPECIFIED
+}
+
+type AcceptPortfolioInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId   string                 `protobuf:"bytes,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptPortfolioInvitationRequest) Reset() {
+	*x = AcceptPortfolioInvitationRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptPortfolioInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptPortfolioInvitationRequest) ProtoMessage() {}
+
+func (x *AcceptPortfolioInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptPortfolioInvitationRequest.ProtoReflect.Descriptor instead.
+func (*AcceptPortfolioInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AcceptPortfolioInvitationRequest) GetPortfolioId() string {
+	if x != nil {
+		return x.PortfolioId
+	}
+	return ""
+}
+
+type RevokePortfolioMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId   string                 `protobuf:"bytes,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokePortfolioMemberRequest) Reset() {
+	*x = RevokePortfolioMemberRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokePortfolioMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokePortfolioMemberRequest) ProtoMessage() {}
+
+func (x *RevokePortfolioMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokePortfolioMemberRequest.ProtoReflect.Descriptor instead.
+func (*RevokePortfolioMemberRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RevokePortfolioMemberRequest) GetPortfolioId() string {
+	if x != nil {
+		return x.PortfolioId
+	}
+	return ""
+}
+
+func (x *RevokePortfolioMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListPortfolioMembersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of the filters is required.
+	PortfolioId   *string `protobuf:"bytes,1,opt,name=portfolio_id,json=portfolioId,proto3,oneof" json:"portfolio_id,omitempty"`
+	UserId        *string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPortfolioMembersRequest) Reset() {
+	*x = ListPortfolioMembersRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPortfolioMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPortfolioMembersRequest) ProtoMessage() {}
+
+func (x *ListPortfolioMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPortfolioMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListPortfolioMembersRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListPortfolioMembersRequest) GetPortfolioId() string {
+	if x != nil && x.PortfolioId != nil {
+		return *x.PortfolioId
+	}
+	return ""
+}
+
+func (x *ListPortfolioMembersRequest) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+type ListPortfolioMembersResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioMembers []*PortfolioMember     `protobuf:"bytes,1,rep,name=portfolio_members,json=portfolioMembers,proto3" json:"portfolio_members,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListPortfolioMembersResponse) Reset() {
+	*x = ListPortfolioMembersResponse{}
+	mi := &file_v1_portfolio_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPortfolioMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPortfolioMembersResponse) ProtoMessage() {}
+
+func (x *ListPortfolioMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPortfolioMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListPortfolioMembersResponse) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListPortfolioMembersResponse) GetPortfolioMembers() []*PortfolioMember {
+	if x != nil {
+		return x.PortfolioMembers
+	}
+	return nil
+}
+
+type CreateHoldingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Holding       *Holding               `protobuf:"bytes,1,opt,name=holding,proto3" json:"holding,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateHoldingRequest) Reset() {
+	*x = CreateHoldingRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateHoldingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateHoldingRequest) ProtoMessage() {}
+
+func (x *CreateHoldingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateHoldingRequest.ProtoReflect.Descriptor instead.
+func (*CreateHoldingRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateHoldingRequest) GetHolding() *Holding {
+	if x != nil {
+		return x.Holding
+	}
+	return nil
+}
+
+type GetHoldingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHoldingRequest) Reset() {
+	*x = GetHoldingRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHoldingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHoldingRequest) ProtoMessage() {}
+
+func (x *GetHoldingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHoldingRequest.ProtoReflect.Descriptor instead.
+func (*GetHoldingRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetHoldingRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateHoldingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Holding       *Holding               `protobuf:"bytes,1,opt,name=holding,proto3" json:"holding,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateHoldingRequest) Reset() {
+	*x = UpdateHoldingRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateHoldingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateHoldingRequest) ProtoMessage() {}
+
+func (x *UpdateHoldingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateHoldingRequest.ProtoReflect.Descriptor instead.
+func (*UpdateHoldingRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateHoldingRequest) GetHolding() *Holding {
+	if x != nil {
+		return x.Holding
+	}
+	return nil
+}
+
+func (x *UpdateHoldingRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type ListHoldingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId   *string                `protobuf:"bytes,1,opt,name=portfolio_id,json=portfolioId,proto3,oneof" json:"portfolio_id,omitempty"`
+	AccountId     *string                `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	AssetId       *string                `protobuf:"bytes,3,opt,name=asset_id,json=assetId,proto3,oneof" json:"asset_id,omitempty"`
+	PageSize      *int32                 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	PageToken     *string                `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoldingsRequest) Reset() {
+	*x = ListHoldingsRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoldingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoldingsRequest) ProtoMessage() {}
+
+func (x *ListHoldingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoldingsRequest.ProtoReflect.Descriptor instead.
+func (*ListHoldingsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListHoldingsRequest) GetPortfolioId() string {
+	if x != nil && x.PortfolioId != nil {
+		return *x.PortfolioId
+	}
+	return ""
+}
+
+func (x *ListHoldingsRequest) GetAccountId() string {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return ""
+}
+
+func (x *ListHoldingsRequest) GetAssetId() string {
+	if x != nil && x.AssetId != nil {
+		return *x.AssetId
+	}
+	return ""
+}
+
+func (x *ListHoldingsRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListHoldingsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+type ListHoldingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Holdings      []*Holding             `protobuf:"bytes,1,rep,name=holdings,proto3" json:"holdings,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoldingsResponse) Reset() {
+	*x = ListHoldingsResponse{}
+	mi := &file_v1_portfolio_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoldingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoldingsResponse) ProtoMessage() {}
+
+func (x *ListHoldingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoldingsResponse.ProtoReflect.Descriptor instead.
+func (*ListHoldingsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListHoldingsResponse) GetHoldings() []*Holding {
+	if x != nil {
+		return x.Holdings
+	}
+	return nil
+}
+
+func (x *ListHoldingsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CreateLotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lot           *Lot                   `protobuf:"bytes,1,opt,name=lot,proto3" json:"lot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLotRequest) Reset() {
+	*x = CreateLotRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLotRequest) ProtoMessage() {}
+
+func (x *CreateLotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLotRequest.ProtoReflect.Descriptor instead.
+func (*CreateLotRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateLotRequest) GetLot() *Lot {
+	if x != nil {
+		return x.Lot
+	}
+	return nil
+}
+
+type GetLotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLotRequest) Reset() {
+	*x = GetLotRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLotRequest) ProtoMessage() {}
+
+func (x *GetLotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLotRequest.ProtoReflect.Descriptor instead.
+func (*GetLotRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetLotRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateLotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lot           *Lot                   `protobuf:"bytes,1,opt,name=lot,proto3" json:"lot,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLotRequest) Reset() {
+	*x = UpdateLotRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLotRequest) ProtoMessage() {}
+
+func (x *UpdateLotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLotRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLotRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpdateLotRequest) GetLot() *Lot {
+	if x != nil {
+		return x.Lot
+	}
+	return nil
+}
+
+func (x *UpdateLotRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type ListLotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldingId     *string                `protobuf:"bytes,1,opt,name=holding_id,json=holdingId,proto3,oneof" json:"holding_id,omitempty"`
+	PortfolioId   *string                `protobuf:"bytes,2,opt,name=portfolio_id,json=portfolioId,proto3,oneof" json:"portfolio_id,omitempty"`
+	PageSize      *int32                 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	PageToken     *string                `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLotsRequest) Reset() {
+	*x = ListLotsRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLotsRequest) ProtoMessage() {}
+
+func (x *ListLotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLotsRequest.ProtoReflect.Descriptor instead.
+func (*ListLotsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListLotsRequest) GetHoldingId() string {
+	if x != nil && x.HoldingId != nil {
+		return *x.HoldingId
+	}
+	return ""
+}
+
+func (x *ListLotsRequest) GetPortfolioId() string {
+	if x != nil && x.PortfolioId != nil {
+		return *x.PortfolioId
+	}
+	return ""
+}
+
+func (x *ListLotsRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListLotsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+type ListLotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lots          []*Lot                 `protobuf:"bytes,1,rep,name=lots,proto3" json:"lots,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLotsResponse) Reset() {
+	*x = ListLotsResponse{}
+	mi := &file_v1_portfolio_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLotsResponse) ProtoMessage() {}
+
+func (x *ListLotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLotsResponse.ProtoReflect.Descriptor instead.
+func (*ListLotsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListLotsResponse) GetLots() []*Lot {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+func (x *ListLotsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CreateAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccountRequest) Reset() {
+	*x = CreateAccountRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccountRequest) ProtoMessage() {}
+
+func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateAccountRequest) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+type GetAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountRequest) Reset() {
+	*x = GetAccountRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountRequest) ProtoMessage() {}
+
+func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetAccountRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAccountRequest) Reset() {
+	*x = UpdateAccountRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAccountRequest) ProtoMessage() {}
+
+func (x *UpdateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAccountRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *UpdateAccountRequest) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *UpdateAccountRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DeleteAccountRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        *string                `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	Type          *AccountType           `protobuf:"varint,2,opt,name=type,proto3,enum=greedy_eye.v1.AccountType,oneof" json:"type,omitempty"`
+	PageSize      *int32                 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	PageToken     *string                `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsRequest) Reset() {
+	*x = ListAccountsRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsRequest) ProtoMessage() {}
+
+func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListAccountsRequest) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *ListAccountsRequest) GetType() AccountType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return AccountType_ACCOUNT_TYPE_UNSPECIFIED
+}
+
+func (x *ListAccountsRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAccountsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+type ListAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      []*Account             `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsResponse) Reset() {
+	*x = ListAccountsResponse{}
+	mi := &file_v1_portfolio_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsResponse) ProtoMessage() {}
+
+func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListAccountsResponse) GetAccounts() []*Account {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+func (x *ListAccountsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CreateTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTransactionRequest) Reset() {
+	*x = CreateTransactionRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransactionRequest) ProtoMessage() {}
+
+func (x *CreateTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransactionRequest.ProtoReflect.Descriptor instead.
+func (*CreateTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CreateTransactionRequest) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+type GetTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionRequest) Reset() {
+	*x = GetTransactionRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRequest) ProtoMessage() {}
+
+func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetTransactionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTransactionRequest) Reset() {
+	*x = UpdateTransactionRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTransactionRequest) ProtoMessage() {}
+
+func (x *UpdateTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTransactionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UpdateTransactionRequest) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *UpdateTransactionRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type ListTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          *TransactionType       `protobuf:"varint,1,opt,name=type,proto3,enum=greedy_eye.v1.TransactionType,oneof" json:"type,omitempty"`
+	Status        *TransactionStatus     `protobuf:"varint,2,opt,name=status,proto3,enum=greedy_eye.v1.TransactionStatus,oneof" json:"status,omitempty"`
+	AccountId     *string                `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3,oneof" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3,oneof" json:"to,omitempty"`
+	PageSize      *int32                 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	PageToken     *string                `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListTransactionsRequest) GetType() TransactionType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return TransactionType_TRANSACTION_TYPE_UNSPECIFIED
+}
+
+func (x *ListTransactionsRequest) GetStatus() TransactionStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED
+}
+
+func (x *ListTransactionsRequest) GetAccountId() string {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
 	}
 	return nil
 }
 
-func (x *UpdateHoldingRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+func (x *ListTransactionsRequest) GetTo() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UpdateMask
+		return x.To
 	}
 	return nil
 }
 
-type ListHoldingsRequest struct {
+func (x *ListTransactionsRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+type ListTransactionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PortfolioId   *string                `protobuf:"bytes,1,opt,name=portfolio_id,json=portfolioId,proto3,oneof" json:"portfolio_id,omitempty"`
-	AccountId     *string                `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
-	AssetId       *string                `protobuf:"bytes,3,opt,name=asset_id,json=assetId,proto3,oneof" json:"asset_id,omitempty"`
-	PageSize      *int32                 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
-	PageToken     *string                `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_v1_portfolio_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListTransactionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// ImportColumnMapping names the CSV columns of a generic import. Type values
+// are buy, sell, deposit, withdrawal or transfer, or dividend, staking,
+// interest or airdrop for income; a missing type column makes positive
+// amounts deposits and negative ones withdrawals.
+type ImportColumnMapping struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp   string                 `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Asset       string                 `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount      string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Type        *string                `protobuf:"bytes,4,opt,name=type,proto3,oneof" json:"type,omitempty"`
+	Price       *string                `protobuf:"bytes,5,opt,name=price,proto3,oneof" json:"price,omitempty"`
+	QuoteAsset  *string                `protobuf:"bytes,6,opt,name=quote_asset,json=quoteAsset,proto3,oneof" json:"quote_asset,omitempty"`
+	QuoteAmount *string                `protobuf:"bytes,7,opt,name=quote_amount,json=quoteAmount,proto3,oneof" json:"quote_amount,omitempty"`
+	Fee         *string                `protobuf:"bytes,8,opt,name=fee,proto3,oneof" json:"fee,omitempty"`
+	FeeAsset    *string                `protobuf:"bytes,9,opt,name=fee_asset,json=feeAsset,proto3,oneof" json:"fee_asset,omitempty"`
+	ExternalId  *string                `protobuf:"bytes,10,opt,name=external_id,json=externalId,proto3,oneof" json:"external_id,omitempty"`
+	// Go time layout of the timestamp column. Defaults to common ISO 8601
+	// forms; timestamps without an offset are read as UTC.
+	TimestampLayout *string `protobuf:"bytes,11,opt,name=timestamp_layout,json=timestampLayout,proto3,oneof" json:"timestamp_layout,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ImportColumnMapping) Reset() {
+	*x = ImportColumnMapping{}
+	mi := &file_v1_portfolio_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportColumnMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportColumnMapping) ProtoMessage() {}
+
+func (x *ImportColumnMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportColumnMapping.ProtoReflect.Descriptor instead.
+func (*ImportColumnMapping) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ImportColumnMapping) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetType() string {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetPrice() string {
+	if x != nil && x.Price != nil {
+		return *x.Price
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetQuoteAsset() string {
+	if x != nil && x.QuoteAsset != nil {
+		return *x.QuoteAsset
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetQuoteAmount() string {
+	if x != nil && x.QuoteAmount != nil {
+		return *x.QuoteAmount
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetFee() string {
+	if x != nil && x.Fee != nil {
+		return *x.Fee
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetFeeAsset() string {
+	if x != nil && x.FeeAsset != nil {
+		return *x.FeeAsset
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+func (x *ImportColumnMapping) GetTimestampLayout() string {
+	if x != nil && x.TimestampLayout != nil {
+		return *x.TimestampLayout
+	}
+	return ""
+}
+
+type ImportTransactionsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Format    ImportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=greedy_eye.v1.ImportFormat" json:"format,omitempty"`
+	// The export; larger ones than the server's limit (10 MiB by default)
+	// are rejected.
+	Content []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// Required for IMPORT_FORMAT_GENERIC.
+	Mapping *ImportColumnMapping `protobuf:"bytes,4,opt,name=mapping,proto3" json:"mapping,omitempty"`
+	DryRun  bool                 `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Import the valid rows even if others are invalid. Otherwise any invalid
+	// row fails the import.
+	SkipInvalid   bool `protobuf:"varint,6,opt,name=skip_invalid,json=skipInvalid,proto3" json:"skip_invalid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTransactionsRequest) Reset() {
+	*x = ImportTransactionsRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTransactionsRequest) ProtoMessage() {}
+
+func (x *ImportTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ImportTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ImportTransactionsRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ImportTransactionsRequest) GetFormat() ImportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ImportFormat_IMPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *ImportTransactionsRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ImportTransactionsRequest) GetMapping() *ImportColumnMapping {
+	if x != nil {
+		return x.Mapping
+	}
+	return nil
+}
+
+func (x *ImportTransactionsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportTransactionsRequest) GetSkipInvalid() bool {
+	if x != nil {
+		return x.SkipInvalid
+	}
+	return false
+}
+
+type ImportedRow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Line of the row in the upload, starting at 1.
+	Line       int32           `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Status     ImportRowStatus `protobuf:"varint,2,opt,name=status,proto3,enum=greedy_eye.v1.ImportRowStatus" json:"status,omitempty"`
+	ExternalId string          `protobuf:"bytes,3,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	// The transaction as recorded, or as it would be on a dry run.
+	Transaction   *Transaction `protobuf:"bytes,4,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Error         *string      `protobuf:"bytes,5,opt,name=error,proto3,oneof" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListHoldingsRequest) Reset() {
-	*x = ListHoldingsRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[17]
+func (x *ImportedRow) Reset() {
+	*x = ImportedRow{}
+	mi := &file_v1_portfolio_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListHoldingsRequest) String() string {
+func (x *ImportedRow) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListHoldingsRequest) ProtoMessage() {}
+func (*ImportedRow) ProtoMessage() {}
 
-func (x *ListHoldingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[17]
+func (x *ImportedRow) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1329,69 +3953,73 @@ func (x *ListHoldingsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListHoldingsRequest.ProtoReflect.Descriptor instead.
-func (*ListHoldingsRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ImportedRow.ProtoReflect.Descriptor instead.
+func (*ImportedRow) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *ListHoldingsRequest) GetPortfolioId() string {
-	if x != nil && x.PortfolioId != nil {
-		return *x.PortfolioId
+func (x *ImportedRow) GetLine() int32 {
+	if x != nil {
+		return x.Line
 	}
-	return ""
+	return 0
 }
 
-func (x *ListHoldingsRequest) GetAccountId() string {
-	if x != nil && x.AccountId != nil {
-		return *x.AccountId
+func (x *ImportedRow) GetStatus() ImportRowStatus {
+	if x != nil {
+		return x.Status
 	}
-	return ""
+	return ImportRowStatus_IMPORT_ROW_STATUS_UNSPECIFIED
 }
 
-func (x *ListHoldingsRequest) GetAssetId() string {
-	if x != nil && x.AssetId != nil {
-		return *x.AssetId
+func (x *ImportedRow) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
 	}
 	return ""
 }
 
-func (x *ListHoldingsRequest) GetPageSize() int32 {
-	if x != nil && x.PageSize != nil {
-		return *x.PageSize
+func (x *ImportedRow) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
 	}
-	return 0
+	return nil
 }
 
-func (x *ListHoldingsRequest) GetPageToken() string {
-	if x != nil && x.PageToken != nil {
-		return *x.PageToken
+func (x *ImportedRow) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
 	}
 	return ""
 }
 
-type ListHoldingsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Holdings      []*Holding             `protobuf:"bytes,1,rep,name=holdings,proto3" json:"holdings,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+type ImportTransactionsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NewCount       int32                  `protobuf:"varint,1,opt,name=new_count,json=newCount,proto3" json:"new_count,omitempty"`
+	DuplicateCount int32                  `protobuf:"varint,2,opt,name=duplicate_count,json=duplicateCount,proto3" json:"duplicate_count,omitempty"`
+	InvalidCount   int32                  `protobuf:"varint,3,opt,name=invalid_count,json=invalidCount,proto3" json:"invalid_count,omitempty"`
+	// False for dry runs.
+	Committed     bool           `protobuf:"varint,4,opt,name=committed,proto3" json:"committed,omitempty"`
+	Rows          []*ImportedRow `protobuf:"bytes,5,rep,name=rows,proto3" json:"rows,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListHoldingsResponse) Reset() {
-	*x = ListHoldingsResponse{}
-	mi := &file_v1_portfolio_proto_msgTypes[18]
+func (x *ImportTransactionsResponse) Reset() {
+	*x = ImportTransactionsResponse{}
+	mi := &file_v1_portfolio_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListHoldingsResponse) String() string {
+func (x *ImportTransactionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListHoldingsResponse) ProtoMessage() {}
+func (*ImportTransactionsResponse) ProtoMessage() {}
 
-func (x *ListHoldingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[18]
+func (x *ImportTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1402,47 +4030,76 @@ func (x *ListHoldingsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListHoldingsResponse.ProtoReflect.Descriptor instead.
-func (*ListHoldingsResponse) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ImportTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ImportTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *ListHoldingsResponse) GetHoldings() []*Holding {
+func (x *ImportTransactionsResponse) GetNewCount() int32 {
 	if x != nil {
-		return x.Holdings
+		return x.NewCount
 	}
-	return nil
+	return 0
 }
 
-func (x *ListHoldingsResponse) GetNextPageToken() string {
+func (x *ImportTransactionsResponse) GetDuplicateCount() int32 {
 	if x != nil {
-		return x.NextPageToken
+		return x.DuplicateCount
 	}
-	return ""
+	return 0
 }
 
-type CreateAccountRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+func (x *ImportTransactionsResponse) GetInvalidCount() int32 {
+	if x != nil {
+		return x.InvalidCount
+	}
+	return 0
+}
+
+func (x *ImportTransactionsResponse) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+func (x *ImportTransactionsResponse) GetRows() []*ImportedRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type ExportPortfolioRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId string                 `protobuf:"bytes,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	Format      ExportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=greedy_eye.v1.ExportFormat" json:"format,omitempty"`
+	// Transactions executed in [from, to). Both are optional.
+	From *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	// Asset to value holdings and P&L in, converted at the rates of the dates
+	// they arose. Defaults to the caller's default currency; without either,
+	// no values are exported.
+	QuoteAssetId  *string `protobuf:"bytes,5,opt,name=quote_asset_id,json=quoteAssetId,proto3,oneof" json:"quote_asset_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAccountRequest) Reset() {
-	*x = CreateAccountRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[19]
+func (x *ExportPortfolioRequest) Reset() {
+	*x = ExportPortfolioRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAccountRequest) String() string {
+func (x *ExportPortfolioRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAccountRequest) ProtoMessage() {}
+func (*ExportPortfolioRequest) ProtoMessage() {}
 
-func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[19]
+func (x *ExportPortfolioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1453,40 +4110,69 @@ func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
-func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ExportPortfolioRequest.ProtoReflect.Descriptor instead.
+func (*ExportPortfolioRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *CreateAccountRequest) GetAccount() *Account {
+func (x *ExportPortfolioRequest) GetPortfolioId() string {
 	if x != nil {
-		return x.Account
+		return x.PortfolioId
+	}
+	return ""
+}
+
+func (x *ExportPortfolioRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *ExportPortfolioRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
 	}
 	return nil
 }
 
-type GetAccountRequest struct {
+func (x *ExportPortfolioRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ExportPortfolioRequest) GetQuoteAssetId() string {
+	if x != nil && x.QuoteAssetId != nil {
+		return *x.QuoteAssetId
+	}
+	return ""
+}
+
+// ExportChunk is the next part of the exported file.
+type ExportChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAccountRequest) Reset() {
-	*x = GetAccountRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[20]
+func (x *ExportChunk) Reset() {
+	*x = ExportChunk{}
+	mi := &file_v1_portfolio_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAccountRequest) String() string {
+func (x *ExportChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAccountRequest) ProtoMessage() {}
+func (*ExportChunk) ProtoMessage() {}
 
-func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[20]
+func (x *ExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1497,41 +4183,51 @@ func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
-func (*GetAccountRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use ExportChunk.ProtoReflect.Descriptor instead.
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *GetAccountRequest) GetId() string {
+func (x *ExportChunk) GetData() []byte {
 	if x != nil {
-		return x.Id
+		return x.Data
 	}
-	return ""
-}
-
-type UpdateAccountRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
-	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return nil
 }
 
-func (x *UpdateAccountRequest) Reset() {
-	*x = UpdateAccountRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[21]
+type GenerateTaxReportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to the caller. Other users' reports require the admin scope.
+	UserId *string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	// Tax year, named by the calendar year it starts in.
+	TaxYear int32 `protobuf:"varint,2,opt,name=tax_year,json=taxYear,proto3" json:"tax_year,omitempty"`
+	// A configured jurisdiction. Defaults to the configured default.
+	Jurisdiction *string `protobuf:"bytes,3,opt,name=jurisdiction,proto3,oneof" json:"jurisdiction,omitempty"`
+	// Also render the report as CSV.
+	IncludeCsv bool `protobuf:"varint,4,opt,name=include_csv,json=includeCsv,proto3" json:"include_csv,omitempty"`
+	// Currency to report values in, each converted at the rate of the date it
+	// arose. Defaults to the user's default currency; without either, values
+	// stay in the currency they were recorded in.
+	CurrencyAssetId *string `protobuf:"bytes,5,opt,name=currency_asset_id,json=currencyAssetId,proto3,oneof" json:"currency_asset_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GenerateTaxReportRequest) Reset() {
+	*x = GenerateTaxReportRequest{}
+	mi := &file_v1_portfolio_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAccountRequest) String() string {
+func (x *GenerateTaxReportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAccountRequest) ProtoMessage() {}
+func (*GenerateTaxReportRequest) ProtoMessage() {}
 
-func (x *UpdateAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[21]
+func (x *GenerateTaxReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1542,47 +4238,86 @@ func (x *UpdateAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAccountRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAccountRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use GenerateTaxReportRequest.ProtoReflect.Descriptor instead.
+func (*GenerateTaxReportRequest) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *UpdateAccountRequest) GetAccount() *Account {
+func (x *GenerateTaxReportRequest) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *GenerateTaxReportRequest) GetTaxYear() int32 {
 	if x != nil {
-		return x.Account
+		return x.TaxYear
 	}
-	return nil
+	return 0
 }
 
-func (x *UpdateAccountRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+func (x *GenerateTaxReportRequest) GetJurisdiction() string {
+	if x != nil && x.Jurisdiction != nil {
+		return *x.Jurisdiction
+	}
+	return ""
+}
+
+func (x *GenerateTaxReportRequest) GetIncludeCsv() bool {
 	if x != nil {
-		return x.UpdateMask
+		return x.IncludeCsv
 	}
-	return nil
+	return false
 }
 
-type DeleteAccountRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GenerateTaxReportRequest) GetCurrencyAssetId() string {
+	if x != nil && x.CurrencyAssetId != nil {
+		return *x.CurrencyAssetId
+	}
+	return ""
 }
 
-func (x *DeleteAccountRequest) Reset() {
-	*x = DeleteAccountRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[22]
+// TaxDisposal is a sale of an asset from one lot. Sales that consumed a
+// stored lot record it; others, such as imported sales, are split over the
+// lots the account acquired before, by the jurisdiction's lot method, with
+// units no lot covered leaving the cost basis and gain unset. Decimal values
+// are strings.
+type TaxDisposal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AssetId       string                 `protobuf:"bytes,3,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Amount        string                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	LotId         *string                `protobuf:"bytes,6,opt,name=lot_id,json=lotId,proto3,oneof" json:"lot_id,omitempty"`
+	AcquiredAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=acquired_at,json=acquiredAt,proto3" json:"acquired_at,omitempty"`
+	DisposedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=disposed_at,json=disposedAt,proto3" json:"disposed_at,omitempty"`
+	// Asset the proceeds, cost basis and gain are quoted in.
+	CurrencyAssetId string        `protobuf:"bytes,9,opt,name=currency_asset_id,json=currencyAssetId,proto3" json:"currency_asset_id,omitempty"`
+	Proceeds        string        `protobuf:"bytes,10,opt,name=proceeds,proto3" json:"proceeds,omitempty"`
+	CostBasis       *string       `protobuf:"bytes,11,opt,name=cost_basis,json=costBasis,proto3,oneof" json:"cost_basis,omitempty"`
+	Gain            *string       `protobuf:"bytes,12,opt,name=gain,proto3,oneof" json:"gain,omitempty"`
+	HoldingPeriod   HoldingPeriod `protobuf:"varint,13,opt,name=holding_period,json=holdingPeriod,proto3,enum=greedy_eye.v1.HoldingPeriod" json:"holding_period,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TaxDisposal) Reset() {
+	*x = TaxDisposal{}
+	mi := &file_v1_portfolio_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAccountRequest) String() string {
+func (x *TaxDisposal) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAccountRequest) ProtoMessage() {}
+func (*TaxDisposal) ProtoMessage() {}
 
-func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[22]
+func (x *TaxDisposal) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1593,109 +4328,136 @@ func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
-func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use TaxDisposal.ProtoReflect.Descriptor instead.
+func (*TaxDisposal) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *DeleteAccountRequest) GetId() string {
+func (x *TaxDisposal) GetTransactionId() string {
 	if x != nil {
-		return x.Id
+		return x.TransactionId
 	}
 	return ""
 }
 
-type ListAccountsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        *string                `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
-	Type          *AccountType           `protobuf:"varint,2,opt,name=type,proto3,enum=greedy_eye.v1.AccountType,oneof" json:"type,omitempty"`
-	PageSize      *int32                 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
-	PageToken     *string                `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *TaxDisposal) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
 }
 
-func (x *ListAccountsRequest) Reset() {
-	*x = ListAccountsRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[23]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *TaxDisposal) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
 }
 
-func (x *ListAccountsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *TaxDisposal) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
 }
 
-func (*ListAccountsRequest) ProtoMessage() {}
+func (x *TaxDisposal) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
 
-func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[23]
+func (x *TaxDisposal) GetLotId() string {
+	if x != nil && x.LotId != nil {
+		return *x.LotId
+	}
+	return ""
+}
+
+func (x *TaxDisposal) GetAcquiredAt() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.AcquiredAt
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ListAccountsRequest.ProtoReflect.Descriptor instead.
-func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{23}
+func (x *TaxDisposal) GetDisposedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DisposedAt
+	}
+	return nil
 }
 
-func (x *ListAccountsRequest) GetUserId() string {
-	if x != nil && x.UserId != nil {
-		return *x.UserId
+func (x *TaxDisposal) GetCurrencyAssetId() string {
+	if x != nil {
+		return x.CurrencyAssetId
 	}
 	return ""
 }
 
-func (x *ListAccountsRequest) GetType() AccountType {
-	if x != nil && x.Type != nil {
-		return *x.Type
+func (x *TaxDisposal) GetProceeds() string {
+	if x != nil {
+		return x.Proceeds
 	}
-	return AccountType_ACCOUNT_TYPE_UNSPECIFIED
+	return ""
 }
 
-func (x *ListAccountsRequest) GetPageSize() int32 {
-	if x != nil && x.PageSize != nil {
-		return *x.PageSize
+func (x *TaxDisposal) GetCostBasis() string {
+	if x != nil && x.CostBasis != nil {
+		return *x.CostBasis
 	}
-	return 0
+	return ""
 }
 
-func (x *ListAccountsRequest) GetPageToken() string {
-	if x != nil && x.PageToken != nil {
-		return *x.PageToken
+func (x *TaxDisposal) GetGain() string {
+	if x != nil && x.Gain != nil {
+		return *x.Gain
+	}
+	return ""
+}
+
+func (x *TaxDisposal) GetHoldingPeriod() HoldingPeriod {
+	if x != nil {
+		return x.HoldingPeriod
 	}
-	return ""
+	return HoldingPeriod_HOLDING_PERIOD_UNSPECIFIED
 }
 
-type ListAccountsResponse struct {
+// TaxIncome is an asset received as income, such as a staking reward or an
+// airdrop, recorded as an income transaction.
+type TaxIncome struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Accounts      []*Account             `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListAccountsResponse) Reset() {
-	*x = ListAccountsResponse{}
-	mi := &file_v1_portfolio_proto_msgTypes[24]
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AssetId       string                 `protobuf:"bytes,3,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Amount        string                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	// The "income" data field, e.g. "staking" or "airdrop".
+	Kind       string                 `protobuf:"bytes,6,opt,name=kind,proto3" json:"kind,omitempty"`
+	ReceivedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	// Value when received, from the "value" and "value_asset_id" data fields.
+	Value           *string `protobuf:"bytes,8,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	CurrencyAssetId *string `protobuf:"bytes,9,opt,name=currency_asset_id,json=currencyAssetId,proto3,oneof" json:"currency_asset_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TaxIncome) Reset() {
+	*x = TaxIncome{}
+	mi := &file_v1_portfolio_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAccountsResponse) String() string {
+func (x *TaxIncome) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAccountsResponse) ProtoMessage() {}
+func (*TaxIncome) ProtoMessage() {}
 
-func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[24]
+func (x *TaxIncome) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1706,91 +4468,105 @@ func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
-func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use TaxIncome.ProtoReflect.Descriptor instead.
+func (*TaxIncome) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *ListAccountsResponse) GetAccounts() []*Account {
+func (x *TaxIncome) GetTransactionId() string {
 	if x != nil {
-		return x.Accounts
+		return x.TransactionId
 	}
-	return nil
+	return ""
 }
 
-func (x *ListAccountsResponse) GetNextPageToken() string {
+func (x *TaxIncome) GetAccountId() string {
 	if x != nil {
-		return x.NextPageToken
+		return x.AccountId
 	}
 	return ""
 }
 
-type CreateTransactionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *TaxIncome) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
 }
 
-func (x *CreateTransactionRequest) Reset() {
-	*x = CreateTransactionRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[25]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *TaxIncome) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
 }
 
-func (x *CreateTransactionRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *TaxIncome) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
 }
 
-func (*CreateTransactionRequest) ProtoMessage() {}
+func (x *TaxIncome) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
 
-func (x *CreateTransactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[25]
+func (x *TaxIncome) GetReceivedAt() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ReceivedAt
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use CreateTransactionRequest.ProtoReflect.Descriptor instead.
-func (*CreateTransactionRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{25}
+func (x *TaxIncome) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
 }
 
-func (x *CreateTransactionRequest) GetTransaction() *Transaction {
-	if x != nil {
-		return x.Transaction
+func (x *TaxIncome) GetCurrencyAssetId() string {
+	if x != nil && x.CurrencyAssetId != nil {
+		return *x.CurrencyAssetId
 	}
-	return nil
+	return ""
 }
 
-type GetTransactionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+// TaxTotal sums the report in one currency. Gains cover the disposals with
+// a known cost basis.
+type TaxTotal struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CurrencyAssetId string                 `protobuf:"bytes,1,opt,name=currency_asset_id,json=currencyAssetId,proto3" json:"currency_asset_id,omitempty"`
+	Proceeds        string                 `protobuf:"bytes,2,opt,name=proceeds,proto3" json:"proceeds,omitempty"`
+	CostBasis       string                 `protobuf:"bytes,3,opt,name=cost_basis,json=costBasis,proto3" json:"cost_basis,omitempty"`
+	ShortTermGain   string                 `protobuf:"bytes,4,opt,name=short_term_gain,json=shortTermGain,proto3" json:"short_term_gain,omitempty"`
+	LongTermGain    string                 `protobuf:"bytes,5,opt,name=long_term_gain,json=longTermGain,proto3" json:"long_term_gain,omitempty"`
+	// Gains of disposals without a holding period.
+	OtherGain     string `protobuf:"bytes,6,opt,name=other_gain,json=otherGain,proto3" json:"other_gain,omitempty"`
+	Income        string `protobuf:"bytes,7,opt,name=income,proto3" json:"income,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTransactionRequest) Reset() {
-	*x = GetTransactionRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[26]
+func (x *TaxTotal) Reset() {
+	*x = TaxTotal{}
+	mi := &file_v1_portfolio_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTransactionRequest) String() string {
+func (x *TaxTotal) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTransactionRequest) ProtoMessage() {}
+func (*TaxTotal) ProtoMessage() {}
 
-func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[26]
+func (x *TaxTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1801,98 +4577,97 @@ func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
-func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use TaxTotal.ProtoReflect.Descriptor instead.
+func (*TaxTotal) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *GetTransactionRequest) GetId() string {
+func (x *TaxTotal) GetCurrencyAssetId() string {
 	if x != nil {
-		return x.Id
+		return x.CurrencyAssetId
 	}
 	return ""
 }
 
-type UpdateTransactionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
-	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UpdateTransactionRequest) Reset() {
-	*x = UpdateTransactionRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[27]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *TaxTotal) GetProceeds() string {
+	if x != nil {
+		return x.Proceeds
+	}
+	return ""
 }
 
-func (x *UpdateTransactionRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *TaxTotal) GetCostBasis() string {
+	if x != nil {
+		return x.CostBasis
+	}
+	return ""
 }
 
-func (*UpdateTransactionRequest) ProtoMessage() {}
-
-func (x *UpdateTransactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[27]
+func (x *TaxTotal) GetShortTermGain() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ShortTermGain
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use UpdateTransactionRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTransactionRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{27}
+func (x *TaxTotal) GetLongTermGain() string {
+	if x != nil {
+		return x.LongTermGain
+	}
+	return ""
 }
 
-func (x *UpdateTransactionRequest) GetTransaction() *Transaction {
+func (x *TaxTotal) GetOtherGain() string {
 	if x != nil {
-		return x.Transaction
+		return x.OtherGain
 	}
-	return nil
+	return ""
 }
 
-func (x *UpdateTransactionRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+func (x *TaxTotal) GetIncome() string {
 	if x != nil {
-		return x.UpdateMask
+		return x.Income
 	}
-	return nil
+	return ""
 }
 
-type ListTransactionsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          *TransactionType       `protobuf:"varint,1,opt,name=type,proto3,enum=greedy_eye.v1.TransactionType,oneof" json:"type,omitempty"`
-	Status        *TransactionStatus     `protobuf:"varint,2,opt,name=status,proto3,enum=greedy_eye.v1.TransactionStatus,oneof" json:"status,omitempty"`
-	AccountId     *string                `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
-	From          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3,oneof" json:"from,omitempty"`
-	To            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3,oneof" json:"to,omitempty"`
-	PageSize      *int32                 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
-	PageToken     *string                `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+type TaxReport struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	UserId       string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TaxYear      int32                  `protobuf:"varint,2,opt,name=tax_year,json=taxYear,proto3" json:"tax_year,omitempty"`
+	Jurisdiction string                 `protobuf:"bytes,3,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`
+	// The tax year is [period_start, period_end).
+	PeriodStart *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	PeriodEnd   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+	// In order of disposal.
+	Disposals []*TaxDisposal `protobuf:"bytes,6,rep,name=disposals,proto3" json:"disposals,omitempty"`
+	// In order of receipt.
+	Income []*TaxIncome `protobuf:"bytes,7,rep,name=income,proto3" json:"income,omitempty"`
+	// By currency asset ID.
+	Totals []*TaxTotal `protobuf:"bytes,8,rep,name=totals,proto3" json:"totals,omitempty"`
+	// Disposals whose cost basis is unknown, so their gain is left out.
+	IncompleteCount int32 `protobuf:"varint,9,opt,name=incomplete_count,json=incompleteCount,proto3" json:"incomplete_count,omitempty"`
+	// The report as CSV, if requested.
+	Csv           []byte `protobuf:"bytes,10,opt,name=csv,proto3" json:"csv,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTransactionsRequest) Reset() {
-	*x = ListTransactionsRequest{}
-	mi := &file_v1_portfolio_proto_msgTypes[28]
+func (x *TaxReport) Reset() {
+	*x = TaxReport{}
+	mi := &file_v1_portfolio_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTransactionsRequest) String() string {
+func (x *TaxReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTransactionsRequest) ProtoMessage() {}
+func (*TaxReport) ProtoMessage() {}
 
-func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[28]
+func (x *TaxReport) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_portfolio_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1903,110 +4678,79 @@ func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
-func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use TaxReport.ProtoReflect.Descriptor instead.
+func (*TaxReport) Descriptor() ([]byte, []int) {
+	return file_v1_portfolio_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *ListTransactionsRequest) GetType() TransactionType {
-	if x != nil && x.Type != nil {
-		return *x.Type
+func (x *TaxReport) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
-	return TransactionType_TRANSACTION_TYPE_UNSPECIFIED
+	return ""
 }
 
-func (x *ListTransactionsRequest) GetStatus() TransactionStatus {
-	if x != nil && x.Status != nil {
-		return *x.Status
+func (x *TaxReport) GetTaxYear() int32 {
+	if x != nil {
+		return x.TaxYear
 	}
-	return TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED
+	return 0
 }
 
-func (x *ListTransactionsRequest) GetAccountId() string {
-	if x != nil && x.AccountId != nil {
-		return *x.AccountId
+func (x *TaxReport) GetJurisdiction() string {
+	if x != nil {
+		return x.Jurisdiction
 	}
 	return ""
 }
 
-func (x *ListTransactionsRequest) GetFrom() *timestamppb.Timestamp {
+func (x *TaxReport) GetPeriodStart() *timestamppb.Timestamp {
 	if x != nil {
-		return x.From
+		return x.PeriodStart
 	}
 	return nil
 }
 
-func (x *ListTransactionsRequest) GetTo() *timestamppb.Timestamp {
+func (x *TaxReport) GetPeriodEnd() *timestamppb.Timestamp {
 	if x != nil {
-		return x.To
+		return x.PeriodEnd
 	}
 	return nil
 }
 
-func (x *ListTransactionsRequest) GetPageSize() int32 {
-	if x != nil && x.PageSize != nil {
-		return *x.PageSize
+func (x *TaxReport) GetDisposals() []*TaxDisposal {
+	if x != nil {
+		return x.Disposals
 	}
-	return 0
+	return nil
 }
 
-func (x *ListTransactionsRequest) GetPageToken() string {
-	if x != nil && x.PageToken != nil {
-		return *x.PageToken
+func (x *TaxReport) GetIncome() []*TaxIncome {
+	if x != nil {
+		return x.Income
 	}
-	return ""
-}
-
-type ListTransactionsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListTransactionsResponse) Reset() {
-	*x = ListTransactionsResponse{}
-	mi := &file_v1_portfolio_proto_msgTypes[29]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ListTransactionsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return nil
 }
 
-func (*ListTransactionsResponse) ProtoMessage() {}
-
-func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_portfolio_proto_msgTypes[29]
+func (x *TaxReport) GetTotals() []*TaxTotal {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Totals
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
-func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
-	return file_v1_portfolio_proto_rawDescGZIP(), []int{29}
+	return nil
 }
 
-func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+func (x *TaxReport) GetIncompleteCount() int32 {
 	if x != nil {
-		return x.Transactions
+		return x.IncompleteCount
 	}
-	return nil
+	return 0
 }
 
-func (x *ListTransactionsResponse) GetNextPageToken() string {
+func (x *TaxReport) GetCsv() []byte {
 	if x != nil {
-		return x.NextPageToken
+		return x.Csv
 	}
-	return ""
+	return nil
 }
 
 var File_v1_portfolio_proto protoreflect.FileDescriptor
@@ -2027,7 +4771,20 @@ const file_v1_portfolio_proto_rawDesc = "" +
 	"\tDataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
 	"\x05value\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\x05value:\x028\x01B\x0e\n" +
-	"\f_description\"\xb6\x02\n" +
+	"\f_description\"\xf8\x02\n" +
+	"\x0fPortfolioMember\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fportfolio_id\x18\x02 \x01(\tR\vportfolioId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x120\n" +
+	"\x04role\x18\x05 \x01(\x0e2\x1c.greedy_eye.v1.PortfolioRoleR\x04role\x12<\n" +
+	"\x06status\x18\x06 \x01(\x0e2$.greedy_eye.v1.PortfolioMemberStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"invited_by\x18\a \x01(\tR\tinvitedBy\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb6\x02\n" +
 	"\aHolding\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x1a\n" +
@@ -2055,7 +4812,24 @@ const file_v1_portfolio_proto_rawDesc = "" +
 	"\tDataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
-	"\f_description\"\x93\x03\n" +
+	"\f_description\"\x83\x03\n" +
+	"\x03Lot\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"holding_id\x18\x02 \x01(\tR\tholdingId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bdecimals\x18\x04 \x01(\rR\bdecimals\x12\x1d\n" +
+	"\n" +
+	"cost_basis\x18\x05 \x01(\x03R\tcostBasis\x12#\n" +
+	"\rcost_decimals\x18\x06 \x01(\rR\fcostDecimals\x12\"\n" +
+	"\rcost_asset_id\x18\a \x01(\tR\vcostAssetId\x12;\n" +
+	"\vacquired_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"acquiredAt\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf6\x03\n" +
 	"\vTransaction\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
 	"\n" +
@@ -2066,10 +4840,15 @@ const file_v1_portfolio_proto_rawDesc = "" +
 	"\x06status\x18\x05 \x01(\x0e2 .greedy_eye.v1.TransactionStatusR\x06status\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x06 \x01(\tR\taccountId\x128\n" +
-	"\x04data\x18\a \x03(\v2$.greedy_eye.v1.Transaction.DataEntryR\x04data\x1a7\n" +
+	"\x04data\x18\a \x03(\v2$.greedy_eye.v1.Transaction.DataEntryR\x04data\x12\x1e\n" +
+	"\basset_id\x18\b \x01(\tH\x00R\aassetId\x88\x01\x01\x12$\n" +
+	"\vexternal_id\x18\t \x01(\tH\x01R\n" +
+	"externalId\x88\x01\x01\x1a7\n" +
 	"\tDataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"P\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\v\n" +
+	"\t_asset_idB\x0e\n" +
+	"\f_external_id\"P\n" +
 	"\x16CreatePortfolioRequest\x126\n" +
 	"\tportfolio\x18\x01 \x01(\v2\x18.greedy_eye.v1.PortfolioR\tportfolio\"%\n" +
 	"\x13GetPortfolioRequest\x12\x0e\n" +
@@ -2098,13 +4877,14 @@ const file_v1_portfolio_proto_rawDesc = "" +
 	"\x1eCalculatePortfolioValueRequest\x12!\n" +
 	"\fportfolio_id\x18\x01 \x01(\tR\vportfolioId\x12$\n" +
 	"\x0equote_asset_id\x18\x02 \x01(\tR\fquoteAssetId\x123\n" +
-	"\aat_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06atTime\"\xf2\x01\n" +
+	"\aat_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06atTime\"\xa0\x02\n" +
 	"\x16PortfolioValueResponse\x12!\n" +
 	"\fportfolio_id\x18\x01 \x01(\tR\vportfolioId\x12$\n" +
 	"\x0equote_asset_id\x18\x02 \x01(\tR\fquoteAssetId\x12,\n" +
 	"\x12total_value_amount\x18\x03 \x01(\x03R\x10totalValueAmount\x12\x1a\n" +
 	"\bdecimals\x18\x04 \x01(\rR\bdecimals\x12E\n" +
-	"\x10calculation_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0fcalculationTime\"\xcd\x01\n" +
+	"\x10calculation_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0fcalculationTime\x12,\n" +
+	"\x12unpriced_asset_ids\x18\x06 \x03(\tR\x10unpricedAssetIds\"\xcd\x01\n" +
 	"\x1eGetPortfolioPerformanceRequest\x12!\n" +
 	"\fportfolio_id\x18\x01 \x01(\tR\vportfolioId\x12.\n" +
 	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
@@ -2116,7 +4896,80 @@ const file_v1_portfolio_proto_rawDesc = "" +
 	"\n" +
 	"volatility\x18\x03 \x01(\x01R\n" +
 	"volatility\x12!\n" +
-	"\fsharpe_ratio\x18\x04 \x01(\x01R\vsharpeRatio\"H\n" +
+	"\fsharpe_ratio\x18\x04 \x01(\x01R\vsharpeRatio\"\xa1\x02\n" +
+	"\x17GetIncomeSummaryRequest\x12!\n" +
+	"\fportfolio_id\x18\x01 \x01(\tR\vportfolioId\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12/\n" +
+	"\x11currency_asset_id\x18\x04 \x01(\tH\x00R\x0fcurrencyAssetId\x88\x01\x01\x12,\n" +
+	"\x0flookback_months\x18\x05 \x01(\x05H\x01R\x0elookbackMonths\x88\x01\x01B\x14\n" +
+	"\x12_currency_asset_idB\x12\n" +
+	"\x10_lookback_months\"\x97\x01\n" +
+	"\vAssetIncome\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x16\n" +
+	"\x06income\x18\x03 \x01(\tR\x06income\x12'\n" +
+	"\x0fwithholding_tax\x18\x04 \x01(\tR\x0ewithholdingTax\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x05R\x05count\"\x85\x01\n" +
+	"\rAccountIncome\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06income\x18\x02 \x01(\tR\x06income\x12'\n" +
+	"\x0fwithholding_tax\x18\x03 \x01(\tR\x0ewithholdingTax\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\"\x98\x01\n" +
+	"\rMonthlyIncome\x120\n" +
+	"\x05month\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05month\x12\x16\n" +
+	"\x06income\x18\x02 \x01(\tR\x06income\x12'\n" +
+	"\x0fwithholding_tax\x18\x03 \x01(\tR\x0ewithholdingTax\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\"\xae\x01\n" +
+	"\n" +
+	"AssetYield\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12#\n" +
+	"\rannual_income\x18\x03 \x01(\tR\fannualIncome\x12\x19\n" +
+	"\x05value\x18\x04 \x01(\tH\x00R\x05value\x88\x01\x01\x12\x19\n" +
+	"\x05yield\x18\x05 \x01(\tH\x01R\x05yield\x88\x01\x01B\b\n" +
+	"\x06_valueB\b\n" +
+	"\x06_yield\"\xe1\x01\n" +
+	"\x10IncomeProjection\x12'\n" +
+	"\x0flookback_months\x18\x01 \x01(\x05R\x0elookbackMonths\x12#\n" +
+	"\rannual_income\x18\x02 \x01(\tR\fannualIncome\x12'\n" +
+	"\x0fportfolio_value\x18\x03 \x01(\tR\x0eportfolioValue\x12\x19\n" +
+	"\x05yield\x18\x04 \x01(\tH\x00R\x05yield\x88\x01\x01\x121\n" +
+	"\x06assets\x18\x05 \x03(\v2\x19.greedy_eye.v1.AssetYieldR\x06assetsB\b\n" +
+	"\x06_yield\"\x87\x04\n" +
+	"\rIncomeSummary\x12!\n" +
+	"\fportfolio_id\x18\x01 \x01(\tR\vportfolioId\x12*\n" +
+	"\x11currency_asset_id\x18\x02 \x01(\tR\x0fcurrencyAssetId\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x16\n" +
+	"\x06income\x18\x05 \x01(\tR\x06income\x12'\n" +
+	"\x0fwithholding_tax\x18\x06 \x01(\tR\x0ewithholdingTax\x122\n" +
+	"\x06assets\x18\a \x03(\v2\x1a.greedy_eye.v1.AssetIncomeR\x06assets\x128\n" +
+	"\baccounts\x18\b \x03(\v2\x1c.greedy_eye.v1.AccountIncomeR\baccounts\x124\n" +
+	"\x06months\x18\t \x03(\v2\x1c.greedy_eye.v1.MonthlyIncomeR\x06months\x12%\n" +
+	"\x0eunvalued_count\x18\n" +
+	" \x01(\x05R\runvaluedCount\x12?\n" +
+	"\n" +
+	"projection\x18\v \x01(\v2\x1f.greedy_eye.v1.IncomeProjectionR\n" +
+	"projection\"\x89\x01\n" +
+	"\x1cInvitePortfolioMemberRequest\x12!\n" +
+	"\fportfolio_id\x18\x01 \x01(\tR\vportfolioId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x120\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x1c.greedy_eye.v1.PortfolioRoleR\x04role\"E\n" +
+	" AcceptPortfolioInvitationRequest\x12!\n" +
+	"\fportfolio_id\x18\x01 \x01(\tR\vportfolioId\"Z\n" +
+	"\x1cRevokePortfolioMemberRequest\x12!\n" +
+	"\fportfolio_id\x18\x01 \x01(\tR\vportfolioId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x80\x01\n" +
+	"\x1bListPortfolioMembersRequest\x12&\n" +
+	"\fportfolio_id\x18\x01 \x01(\tH\x00R\vportfolioId\x88\x01\x01\x12\x1c\n" +
+	"\auser_id\x18\x02 \x01(\tH\x01R\x06userId\x88\x01\x01B\x0f\n" +
+	"\r_portfolio_idB\n" +
+	"\n" +
+	"\b_user_id\"k\n" +
+	"\x1cListPortfolioMembersResponse\x12K\n" +
+	"\x11portfolio_members\x18\x01 \x03(\v2\x1e.greedy_eye.v1.PortfolioMemberR\x10portfolioMembers\"H\n" +
 	"\x14CreateHoldingRequest\x120\n" +
 	"\aholding\x18\x01 \x01(\v2\x16.greedy_eye.v1.HoldingR\aholding\"#\n" +
 	"\x11GetHoldingRequest\x12\x0e\n" +
@@ -2141,6 +4994,29 @@ const file_v1_portfolio_proto_rawDesc = "" +
 	"\v_page_token\"r\n" +
 	"\x14ListHoldingsResponse\x122\n" +
 	"\bholdings\x18\x01 \x03(\v2\x16.greedy_eye.v1.HoldingR\bholdings\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"8\n" +
+	"\x10CreateLotRequest\x12$\n" +
+	"\x03lot\x18\x01 \x01(\v2\x12.greedy_eye.v1.LotR\x03lot\"\x1f\n" +
+	"\rGetLotRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"u\n" +
+	"\x10UpdateLotRequest\x12$\n" +
+	"\x03lot\x18\x01 \x01(\v2\x12.greedy_eye.v1.LotR\x03lot\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"\xe0\x01\n" +
+	"\x0fListLotsRequest\x12\"\n" +
+	"\n" +
+	"holding_id\x18\x01 \x01(\tH\x00R\tholdingId\x88\x01\x01\x12&\n" +
+	"\fportfolio_id\x18\x02 \x01(\tH\x01R\vportfolioId\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x03 \x01(\x05H\x02R\bpageSize\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tH\x03R\tpageToken\x88\x01\x01B\r\n" +
+	"\v_holding_idB\x0f\n" +
+	"\r_portfolio_idB\f\n" +
+	"\n" +
+	"_page_sizeB\r\n" +
+	"\v_page_token\"b\n" +
+	"\x10ListLotsResponse\x12&\n" +
+	"\x04lots\x18\x01 \x03(\v2\x12.greedy_eye.v1.LotR\x04lots\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"H\n" +
 	"\x14CreateAccountRequest\x120\n" +
 	"\aaccount\x18\x01 \x01(\v2\x16.greedy_eye.v1.AccountR\aaccount\"#\n" +
@@ -2195,40 +5071,205 @@ const file_v1_portfolio_proto_rawDesc = "" +
 	"\v_page_token\"\x82\x01\n" +
 	"\x18ListTransactionsResponse\x12>\n" +
 	"\ftransactions\x18\x01 \x03(\v2\x1a.greedy_eye.v1.TransactionR\ftransactions\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*\x8f\x01\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xe1\x03\n" +
+	"\x13ImportColumnMapping\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\tR\ttimestamp\x12\x14\n" +
+	"\x05asset\x18\x02 \x01(\tR\x05asset\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x12\x17\n" +
+	"\x04type\x18\x04 \x01(\tH\x00R\x04type\x88\x01\x01\x12\x19\n" +
+	"\x05price\x18\x05 \x01(\tH\x01R\x05price\x88\x01\x01\x12$\n" +
+	"\vquote_asset\x18\x06 \x01(\tH\x02R\n" +
+	"quoteAsset\x88\x01\x01\x12&\n" +
+	"\fquote_amount\x18\a \x01(\tH\x03R\vquoteAmount\x88\x01\x01\x12\x15\n" +
+	"\x03fee\x18\b \x01(\tH\x04R\x03fee\x88\x01\x01\x12 \n" +
+	"\tfee_asset\x18\t \x01(\tH\x05R\bfeeAsset\x88\x01\x01\x12$\n" +
+	"\vexternal_id\x18\n" +
+	" \x01(\tH\x06R\n" +
+	"externalId\x88\x01\x01\x12.\n" +
+	"\x10timestamp_layout\x18\v \x01(\tH\aR\x0ftimestampLayout\x88\x01\x01B\a\n" +
+	"\x05_typeB\b\n" +
+	"\x06_priceB\x0e\n" +
+	"\f_quote_assetB\x0f\n" +
+	"\r_quote_amountB\x06\n" +
+	"\x04_feeB\f\n" +
+	"\n" +
+	"_fee_assetB\x0e\n" +
+	"\f_external_idB\x13\n" +
+	"\x11_timestamp_layout\"\x83\x02\n" +
+	"\x19ImportTransactionsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x123\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x1b.greedy_eye.v1.ImportFormatR\x06format\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\x12<\n" +
+	"\amapping\x18\x04 \x01(\v2\".greedy_eye.v1.ImportColumnMappingR\amapping\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\x12!\n" +
+	"\fskip_invalid\x18\x06 \x01(\bR\vskipInvalid\"\xdd\x01\n" +
+	"\vImportedRow\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x126\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1e.greedy_eye.v1.ImportRowStatusR\x06status\x12\x1f\n" +
+	"\vexternal_id\x18\x03 \x01(\tR\n" +
+	"externalId\x12<\n" +
+	"\vtransaction\x18\x04 \x01(\v2\x1a.greedy_eye.v1.TransactionR\vtransaction\x12\x19\n" +
+	"\x05error\x18\x05 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"\xd5\x01\n" +
+	"\x1aImportTransactionsResponse\x12\x1b\n" +
+	"\tnew_count\x18\x01 \x01(\x05R\bnewCount\x12'\n" +
+	"\x0fduplicate_count\x18\x02 \x01(\x05R\x0eduplicateCount\x12#\n" +
+	"\rinvalid_count\x18\x03 \x01(\x05R\finvalidCount\x12\x1c\n" +
+	"\tcommitted\x18\x04 \x01(\bR\tcommitted\x12.\n" +
+	"\x04rows\x18\x05 \x03(\v2\x1a.greedy_eye.v1.ImportedRowR\x04rows\"\x8a\x02\n" +
+	"\x16ExportPortfolioRequest\x12!\n" +
+	"\fportfolio_id\x18\x01 \x01(\tR\vportfolioId\x123\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x1b.greedy_eye.v1.ExportFormatR\x06format\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12)\n" +
+	"\x0equote_asset_id\x18\x05 \x01(\tH\x00R\fquoteAssetId\x88\x01\x01B\x11\n" +
+	"\x0f_quote_asset_id\"!\n" +
+	"\vExportChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x81\x02\n" +
+	"\x18GenerateTaxReportRequest\x12\x1c\n" +
+	"\auser_id\x18\x01 \x01(\tH\x00R\x06userId\x88\x01\x01\x12\x19\n" +
+	"\btax_year\x18\x02 \x01(\x05R\ataxYear\x12'\n" +
+	"\fjurisdiction\x18\x03 \x01(\tH\x01R\fjurisdiction\x88\x01\x01\x12\x1f\n" +
+	"\vinclude_csv\x18\x04 \x01(\bR\n" +
+	"includeCsv\x12/\n" +
+	"\x11currency_asset_id\x18\x05 \x01(\tH\x02R\x0fcurrencyAssetId\x88\x01\x01B\n" +
+	"\n" +
+	"\b_user_idB\x0f\n" +
+	"\r_jurisdictionB\x14\n" +
+	"\x12_currency_asset_id\"\xa1\x04\n" +
+	"\vTaxDisposal\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x19\n" +
+	"\basset_id\x18\x03 \x01(\tR\aassetId\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\tR\x06amount\x12\x1a\n" +
+	"\x06lot_id\x18\x06 \x01(\tH\x00R\x05lotId\x88\x01\x01\x12;\n" +
+	"\vacquired_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"acquiredAt\x12;\n" +
+	"\vdisposed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"disposedAt\x12*\n" +
+	"\x11currency_asset_id\x18\t \x01(\tR\x0fcurrencyAssetId\x12\x1a\n" +
+	"\bproceeds\x18\n" +
+	" \x01(\tR\bproceeds\x12\"\n" +
+	"\n" +
+	"cost_basis\x18\v \x01(\tH\x01R\tcostBasis\x88\x01\x01\x12\x17\n" +
+	"\x04gain\x18\f \x01(\tH\x02R\x04gain\x88\x01\x01\x12C\n" +
+	"\x0eholding_period\x18\r \x01(\x0e2\x1c.greedy_eye.v1.HoldingPeriodR\rholdingPeriodB\t\n" +
+	"\a_lot_idB\r\n" +
+	"\v_cost_basisB\a\n" +
+	"\x05_gain\"\xd9\x02\n" +
+	"\tTaxIncome\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x19\n" +
+	"\basset_id\x18\x03 \x01(\tR\aassetId\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\tR\x06amount\x12\x12\n" +
+	"\x04kind\x18\x06 \x01(\tR\x04kind\x12;\n" +
+	"\vreceived_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"receivedAt\x12\x19\n" +
+	"\x05value\x18\b \x01(\tH\x00R\x05value\x88\x01\x01\x12/\n" +
+	"\x11currency_asset_id\x18\t \x01(\tH\x01R\x0fcurrencyAssetId\x88\x01\x01B\b\n" +
+	"\x06_valueB\x14\n" +
+	"\x12_currency_asset_id\"\xf6\x01\n" +
+	"\bTaxTotal\x12*\n" +
+	"\x11currency_asset_id\x18\x01 \x01(\tR\x0fcurrencyAssetId\x12\x1a\n" +
+	"\bproceeds\x18\x02 \x01(\tR\bproceeds\x12\x1d\n" +
+	"\n" +
+	"cost_basis\x18\x03 \x01(\tR\tcostBasis\x12&\n" +
+	"\x0fshort_term_gain\x18\x04 \x01(\tR\rshortTermGain\x12$\n" +
+	"\x0elong_term_gain\x18\x05 \x01(\tR\flongTermGain\x12\x1d\n" +
+	"\n" +
+	"other_gain\x18\x06 \x01(\tR\totherGain\x12\x16\n" +
+	"\x06income\x18\a \x01(\tR\x06income\"\xb7\x03\n" +
+	"\tTaxReport\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\btax_year\x18\x02 \x01(\x05R\ataxYear\x12\"\n" +
+	"\fjurisdiction\x18\x03 \x01(\tR\fjurisdiction\x12=\n" +
+	"\fperiod_start\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x129\n" +
+	"\n" +
+	"period_end\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tperiodEnd\x128\n" +
+	"\tdisposals\x18\x06 \x03(\v2\x1a.greedy_eye.v1.TaxDisposalR\tdisposals\x120\n" +
+	"\x06income\x18\a \x03(\v2\x18.greedy_eye.v1.TaxIncomeR\x06income\x12/\n" +
+	"\x06totals\x18\b \x03(\v2\x17.greedy_eye.v1.TaxTotalR\x06totals\x12)\n" +
+	"\x10incomplete_count\x18\t \x01(\x05R\x0fincompleteCount\x12\x10\n" +
+	"\x03csv\x18\n" +
+	" \x01(\fR\x03csv*\x8f\x01\n" +
 	"\vAccountType\x12\x1c\n" +
 	"\x18ACCOUNT_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13ACCOUNT_TYPE_WALLET\x10\x01\x12\x19\n" +
 	"\x15ACCOUNT_TYPE_EXCHANGE\x10\x02\x12\x15\n" +
 	"\x11ACCOUNT_TYPE_BANK\x10\x03\x12\x17\n" +
-	"\x13ACCOUNT_TYPE_BROKER\x10\x04*\xcc\x01\n" +
+	"\x13ACCOUNT_TYPE_BROKER\x10\x04*\xe9\x01\n" +
 	"\x0fTransactionType\x12 \n" +
 	"\x1cTRANSACTION_TYPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
 	"\x19TRANSACTION_TYPE_EXTENDED\x10\x01\x12\x1a\n" +
 	"\x16TRANSACTION_TYPE_TRADE\x10\x02\x12\x1d\n" +
 	"\x19TRANSACTION_TYPE_TRANSFER\x10\x03\x12\x1c\n" +
 	"\x18TRANSACTION_TYPE_DEPOSIT\x10\x04\x12\x1f\n" +
-	"\x1bTRANSACTION_TYPE_WITHDRAWAL\x10\x05*\xdd\x01\n" +
+	"\x1bTRANSACTION_TYPE_WITHDRAWAL\x10\x05\x12\x1b\n" +
+	"\x17TRANSACTION_TYPE_INCOME\x10\x06*\xdd\x01\n" +
 	"\x11TransactionStatus\x12\"\n" +
 	"\x1eTRANSACTION_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aTRANSACTION_STATUS_PENDING\x10\x01\x12!\n" +
 	"\x1dTRANSACTION_STATUS_PROCESSING\x10\x02\x12 \n" +
 	"\x1cTRANSACTION_STATUS_COMPLETED\x10\x03\x12\x1d\n" +
 	"\x19TRANSACTION_STATUS_FAILED\x10\x04\x12 \n" +
-	"\x1cTRANSACTION_STATUS_CANCELLED\x10\x052\xee\x13\n" +
+	"\x1cTRANSACTION_STATUS_CANCELLED\x10\x05*\x7f\n" +
+	"\rPortfolioRole\x12\x1e\n" +
+	"\x1aPORTFOLIO_ROLE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15PORTFOLIO_ROLE_VIEWER\x10\x01\x12\x19\n" +
+	"\x15PORTFOLIO_ROLE_EDITOR\x10\x02\x12\x18\n" +
+	"\x14PORTFOLIO_ROLE_OWNER\x10\x03*\x89\x01\n" +
+	"\x15PortfolioMemberStatus\x12'\n" +
+	"#PORTFOLIO_MEMBER_STATUS_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fPORTFOLIO_MEMBER_STATUS_PENDING\x10\x01\x12\"\n" +
+	"\x1ePORTFOLIO_MEMBER_STATUS_ACTIVE\x10\x02*\xc8\x01\n" +
+	"\fImportFormat\x12\x1d\n" +
+	"\x19IMPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15IMPORT_FORMAT_GENERIC\x10\x01\x12 \n" +
+	"\x1cIMPORT_FORMAT_BINANCE_TRADES\x10\x02\x12\x1a\n" +
+	"\x16IMPORT_FORMAT_COINBASE\x10\x03\x12\x1f\n" +
+	"\x1bIMPORT_FORMAT_KRAKEN_LEDGER\x10\x04\x12\x1f\n" +
+	"\x1bIMPORT_FORMAT_IBKR_ACTIVITY\x10\x05*\x8f\x01\n" +
+	"\x0fImportRowStatus\x12!\n" +
+	"\x1dIMPORT_ROW_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15IMPORT_ROW_STATUS_NEW\x10\x01\x12\x1f\n" +
+	"\x1bIMPORT_ROW_STATUS_DUPLICATE\x10\x02\x12\x1d\n" +
+	"\x19IMPORT_ROW_STATUS_INVALID\x10\x03*t\n" +
+	"\fExportFormat\x12\x1d\n" +
+	"\x19EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11EXPORT_FORMAT_CSV\x10\x01\x12\x17\n" +
+	"\x13EXPORT_FORMAT_JSONL\x10\x02\x12\x15\n" +
+	"\x11EXPORT_FORMAT_OFX\x10\x03*l\n" +
+	"\rHoldingPeriod\x12\x1e\n" +
+	"\x1aHOLDING_PERIOD_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19HOLDING_PERIOD_SHORT_TERM\x10\x01\x12\x1c\n" +
+	"\x18HOLDING_PERIOD_LONG_TERM\x10\x022\xa2 \n" +
 	"\x10PortfolioService\x12y\n" +
 	"\x0fCreatePortfolio\x12%.greedy_eye.v1.CreatePortfolioRequest\x1a\x18.greedy_eye.v1.Portfolio\"%\x82\xd3\xe4\x93\x02\x1f:\tportfolio\"\x12/api/v1/portfolios\x12m\n" +
 	"\fGetPortfolio\x12\".greedy_eye.v1.GetPortfolioRequest\x1a\x18.greedy_eye.v1.Portfolio\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/portfolios/{id}\x12\x88\x01\n" +
 	"\x0fUpdatePortfolio\x12%.greedy_eye.v1.UpdatePortfolioRequest\x1a\x18.greedy_eye.v1.Portfolio\"4\x82\xd3\xe4\x93\x02.:\tportfolio\x1a!/api/v1/portfolios/{portfolio.id}\x12q\n" +
 	"\x0fDeletePortfolio\x12%.greedy_eye.v1.DeletePortfolioRequest\x1a\x16.google.protobuf.Empty\"\x1f\x82\xd3\xe4\x93\x02\x19*\x17/api/v1/portfolios/{id}\x12y\n" +
-	"\x0eListPortfolios\x12$.greedy_eye.v1.ListPortfoliosRequest\x1a%.greedy_eye.v1.ListPortfoliosResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/portfolios\x12\xad\x01\n" +
+	"\x0eListPortfolios\x12$.greedy_eye.v1.ListPortfoliosRequest\x1a%.greedy_eye.v1.ListPortfoliosResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/portfolios\x12\x9a\x01\n" +
+	"\x15InvitePortfolioMember\x12+.greedy_eye.v1.InvitePortfolioMemberRequest\x1a\x1e.greedy_eye.v1.PortfolioMember\"4\x82\xd3\xe4\x93\x02.:\x01*\")/api/v1/portfolios/{portfolio_id}/members\x12\xac\x01\n" +
+	"\x19AcceptPortfolioInvitation\x12/.greedy_eye.v1.AcceptPortfolioInvitationRequest\x1a\x1e.greedy_eye.v1.PortfolioMember\">\x82\xd3\xe4\x93\x028:\x01*\"3/api/v1/portfolios/{portfolio_id}/accept-invitation\x12\x99\x01\n" +
+	"\x15RevokePortfolioMember\x12+.greedy_eye.v1.RevokePortfolioMemberRequest\x1a\x16.google.protobuf.Empty\";\x82\xd3\xe4\x93\x025*3/api/v1/portfolios/{portfolio_id}/members/{user_id}\x12\x92\x01\n" +
+	"\x14ListPortfolioMembers\x12*.greedy_eye.v1.ListPortfolioMembersRequest\x1a+.greedy_eye.v1.ListPortfolioMembersResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/portfolio-members\x12\xad\x01\n" +
 	"\x17CalculatePortfolioValue\x12-.greedy_eye.v1.CalculatePortfolioValueRequest\x1a%.greedy_eye.v1.PortfolioValueResponse\"<\x82\xd3\xe4\x93\x026:\x01*\"1/api/v1/portfolios/{portfolio_id}/calculate-value\x12\xaf\x01\n" +
-	"\x17GetPortfolioPerformance\x12-.greedy_eye.v1.GetPortfolioPerformanceRequest\x1a+.greedy_eye.v1.PortfolioPerformanceResponse\"8\x82\xd3\xe4\x93\x022:\x01*\"-/api/v1/portfolios/{portfolio_id}/performance\x12o\n" +
+	"\x17GetPortfolioPerformance\x12-.greedy_eye.v1.GetPortfolioPerformanceRequest\x1a+.greedy_eye.v1.PortfolioPerformanceResponse\"8\x82\xd3\xe4\x93\x022:\x01*\"-/api/v1/portfolios/{portfolio_id}/performance\x12\x8a\x01\n" +
+	"\x10GetIncomeSummary\x12&.greedy_eye.v1.GetIncomeSummaryRequest\x1a\x1c.greedy_eye.v1.IncomeSummary\"0\x82\xd3\xe4\x93\x02*\x12(/api/v1/portfolios/{portfolio_id}/income\x12o\n" +
 	"\rCreateHolding\x12#.greedy_eye.v1.CreateHoldingRequest\x1a\x16.greedy_eye.v1.Holding\"!\x82\xd3\xe4\x93\x02\x1b:\aholding\"\x10/api/v1/holdings\x12e\n" +
 	"\n" +
 	"GetHolding\x12 .greedy_eye.v1.GetHoldingRequest\x1a\x16.greedy_eye.v1.Holding\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/holdings/{id}\x12|\n" +
 	"\rUpdateHolding\x12#.greedy_eye.v1.UpdateHoldingRequest\x1a\x16.greedy_eye.v1.Holding\".\x82\xd3\xe4\x93\x02(:\aholding\x1a\x1d/api/v1/holdings/{holding.id}\x12q\n" +
-	"\fListHoldings\x12\".greedy_eye.v1.ListHoldingsRequest\x1a#.greedy_eye.v1.ListHoldingsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/holdings\x12o\n" +
+	"\fListHoldings\x12\".greedy_eye.v1.ListHoldingsRequest\x1a#.greedy_eye.v1.ListHoldingsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/holdings\x12[\n" +
+	"\tCreateLot\x12\x1f.greedy_eye.v1.CreateLotRequest\x1a\x12.greedy_eye.v1.Lot\"\x19\x82\xd3\xe4\x93\x02\x13:\x03lot\"\f/api/v1/lots\x12U\n" +
+	"\x06GetLot\x12\x1c.greedy_eye.v1.GetLotRequest\x1a\x12.greedy_eye.v1.Lot\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/lots/{id}\x12d\n" +
+	"\tUpdateLot\x12\x1f.greedy_eye.v1.UpdateLotRequest\x1a\x12.greedy_eye.v1.Lot\"\"\x82\xd3\xe4\x93\x02\x1c:\x03lot\x1a\x15/api/v1/lots/{lot.id}\x12a\n" +
+	"\bListLots\x12\x1e.greedy_eye.v1.ListLotsRequest\x1a\x1f.greedy_eye.v1.ListLotsResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/api/v1/lots\x12o\n" +
 	"\rCreateAccount\x12#.greedy_eye.v1.CreateAccountRequest\x1a\x16.greedy_eye.v1.Account\"!\x82\xd3\xe4\x93\x02\x1b:\aaccount\"\x10/api/v1/accounts\x12e\n" +
 	"\n" +
 	"GetAccount\x12 .greedy_eye.v1.GetAccountRequest\x1a\x16.greedy_eye.v1.Account\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/accounts/{id}\x12|\n" +
@@ -2238,7 +5279,10 @@ const file_v1_portfolio_proto_rawDesc = "" +
 	"\x11CreateTransaction\x12'.greedy_eye.v1.CreateTransactionRequest\x1a\x1a.greedy_eye.v1.Transaction\")\x82\xd3\xe4\x93\x02#:\vtransaction\"\x14/api/v1/transactions\x12u\n" +
 	"\x0eGetTransaction\x12$.greedy_eye.v1.GetTransactionRequest\x1a\x1a.greedy_eye.v1.Transaction\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/transactions/{id}\x12\x94\x01\n" +
 	"\x11UpdateTransaction\x12'.greedy_eye.v1.UpdateTransactionRequest\x1a\x1a.greedy_eye.v1.Transaction\":\x82\xd3\xe4\x93\x024:\vtransaction\x1a%/api/v1/transactions/{transaction.id}\x12\x81\x01\n" +
-	"\x10ListTransactions\x12&.greedy_eye.v1.ListTransactionsRequest\x1a'.greedy_eye.v1.ListTransactionsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/transactionsB\xa9\x01\n" +
+	"\x10ListTransactions\x12&.greedy_eye.v1.ListTransactionsRequest\x1a'.greedy_eye.v1.ListTransactionsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/transactions\x12\xa7\x01\n" +
+	"\x12ImportTransactions\x12(.greedy_eye.v1.ImportTransactionsRequest\x1a).greedy_eye.v1.ImportTransactionsResponse\"<\x82\xd3\xe4\x93\x026:\x01*\"1/api/v1/accounts/{account_id}/import-transactions\x12\x88\x01\n" +
+	"\x0fExportPortfolio\x12%.greedy_eye.v1.ExportPortfolioRequest\x1a\x1a.greedy_eye.v1.ExportChunk\"0\x82\xd3\xe4\x93\x02*\x12(/api/v1/portfolios/{portfolio_id}/export0\x01\x12v\n" +
+	"\x11GenerateTaxReport\x12'.greedy_eye.v1.GenerateTaxReportRequest\x1a\x18.greedy_eye.v1.TaxReport\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/api/v1/tax-reportsB\xa9\x01\n" +
 	"\x11com.greedy_eye.v1B\x0ePortfolioProtoP\x01Z3github.com/foxcool/greedy-eye/internal/api/v1;apiv1\xa2\x02\x03GXX\xaa\x02\fGreedyEye.V1\xca\x02\fGreedyEye\\V1\xe2\x02\x18GreedyEye\\V1\\GPBMetadata\xea\x02\rGreedyEye::V1b\x06proto3"
 
 var (
@@ -2253,136 +5297,236 @@ func file_v1_portfolio_proto_rawDescGZIP() []byte {
 	return file_v1_portfolio_proto_rawDescData
 }
 
-var file_v1_portfolio_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_v1_portfolio_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_v1_portfolio_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_v1_portfolio_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
 var file_v1_portfolio_proto_goTypes = []any{
-	(AccountType)(0),                       // 0: greedy_eye.v1.AccountType
-	(TransactionType)(0),                   // 1: greedy_eye.v1.TransactionType
-	(TransactionStatus)(0),                 // 2: greedy_eye.v1.TransactionStatus
-	(*Portfolio)(nil),                      // 3: greedy_eye.v1.Portfolio
-	(*Holding)(nil),                        // 4: greedy_eye.v1.Holding
-	(*Account)(nil),                        // 5: greedy_eye.v1.Account
-	(*Transaction)(nil),                    // 6: greedy_eye.v1.Transaction
-	(*CreatePortfolioRequest)(nil),         // 7: greedy_eye.v1.CreatePortfolioRequest
-	(*GetPortfolioRequest)(nil),            // 8: greedy_eye.v1.GetPortfolioRequest
-	(*UpdatePortfolioRequest)(nil),         // 9: greedy_eye.v1.UpdatePortfolioRequest
-	(*DeletePortfolioRequest)(nil),         // 10: greedy_eye.v1.DeletePortfolioRequest
-	(*ListPortfoliosRequest)(nil),          // 11: greedy_eye.v1.ListPortfoliosRequest
-	(*ListPortfoliosResponse)(nil),         // 12: greedy_eye.v1.ListPortfoliosResponse
-	(*CalculatePortfolioValueRequest)(nil), // 13: greedy_eye.v1.CalculatePortfolioValueRequest
-	(*PortfolioValueResponse)(nil),         // 14: greedy_eye.v1.PortfolioValueResponse
-	(*GetPortfolioPerformanceRequest)(nil), // 15: greedy_eye.v1.GetPortfolioPerformanceRequest
-	(*PortfolioPerformanceResponse)(nil),   // 16: greedy_eye.v1.PortfolioPerformanceResponse
-	(*CreateHoldingRequest)(nil),           // 17: greedy_eye.v1.CreateHoldingRequest
-	(*GetHoldingRequest)(nil),              // 18: greedy_eye.v1.GetHoldingRequest
-	(*UpdateHoldingRequest)(nil),           // 19: greedy_eye.v1.UpdateHoldingRequest
-	(*ListHoldingsRequest)(nil),            // 20: greedy_eye.v1.ListHoldingsRequest
-	(*ListHoldingsResponse)(nil),           // 21: greedy_eye.v1.ListHoldingsResponse
-	(*CreateAccountRequest)(nil),           // 22: greedy_eye.v1.CreateAccountRequest
-	(*GetAccountRequest)(nil),              // 23: greedy_eye.v1.GetAccountRequest
-	(*UpdateAccountRequest)(nil),           // 24: greedy_eye.v1.UpdateAccountRequest
-	(*DeleteAccountRequest)(nil),           // 25: greedy_eye.v1.DeleteAccountRequest
-	(*ListAccountsRequest)(nil),            // 26: greedy_eye.v1.ListAccountsRequest
-	(*ListAccountsResponse)(nil),           // 27: greedy_eye.v1.ListAccountsResponse
-	(*CreateTransactionRequest)(nil),       // 28: greedy_eye.v1.CreateTransactionRequest
-	(*GetTransactionRequest)(nil),          // 29: greedy_eye.v1.GetTransactionRequest
-	(*UpdateTransactionRequest)(nil),       // 30: greedy_eye.v1.UpdateTransactionRequest
-	(*ListTransactionsRequest)(nil),        // 31: greedy_eye.v1.ListTransactionsRequest
-	(*ListTransactionsResponse)(nil),       // 32: greedy_eye.v1.ListTransactionsResponse
-	nil,                                    // 33: greedy_eye.v1.Portfolio.DataEntry
-	nil,                                    // 34: greedy_eye.v1.Account.DataEntry
-	nil,                                    // 35: greedy_eye.v1.Transaction.DataEntry
-	(*timestamppb.Timestamp)(nil),          // 36: google.protobuf.Timestamp
-	(*fieldmaskpb.FieldMask)(nil),          // 37: google.protobuf.FieldMask
-	(*anypb.Any)(nil),                      // 38: google.protobuf.Any
-	(*emptypb.Empty)(nil),                  // 39: google.protobuf.Empty
+	(AccountType)(0),                         // 0: greedy_eye.v1.AccountType
+	(TransactionType)(0),                     // 1: greedy_eye.v1.TransactionType
+	(TransactionStatus)(0),                   // 2: greedy_eye.v1.TransactionStatus
+	(PortfolioRole)(0),                       // 3: greedy_eye.v1.PortfolioRole
+	(PortfolioMemberStatus)(0),               // 4: greedy_eye.v1.PortfolioMemberStatus
+	(ImportFormat)(0),                        // 5: greedy_eye.v1.ImportFormat
+	(ImportRowStatus)(0),                     // 6: greedy_eye.v1.ImportRowStatus
+	(ExportFormat)(0),                        // 7: greedy_eye.v1.ExportFormat
+	(HoldingPeriod)(0),                       // 8: greedy_eye.v1.HoldingPeriod
+	(*Portfolio)(nil),                        // 9: greedy_eye.v1.Portfolio
+	(*PortfolioMember)(nil),                  // 10: greedy_eye.v1.PortfolioMember
+	(*Holding)(nil),                          // 11: greedy_eye.v1.Holding
+	(*Account)(nil),                          // 12: greedy_eye.v1.Account
+	(*Lot)(nil),                              // 13: greedy_eye.v1.Lot
+	(*Transaction)(nil),                      // 14: greedy_eye.v1.Transaction
+	(*CreatePortfolioRequest)(nil),           // 15: greedy_eye.v1.CreatePortfolioRequest
+	(*GetPortfolioRequest)(nil),              // 16: greedy_eye.v1.GetPortfolioRequest
+	(*UpdatePortfolioRequest)(nil),           // 17: greedy_eye.v1.UpdatePortfolioRequest
+	(*DeletePortfolioRequest)(nil),           // 18: greedy_eye.v1.DeletePortfolioRequest
+	(*ListPortfoliosRequest)(nil),            // 19: greedy_eye.v1.ListPortfoliosRequest
+	(*ListPortfoliosResponse)(nil),           // 20: greedy_eye.v1.ListPortfoliosResponse
+	(*CalculatePortfolioValueRequest)(nil),   // 21: greedy_eye.v1.CalculatePortfolioValueRequest
+	(*PortfolioValueResponse)(nil),           // 22: greedy_eye.v1.PortfolioValueResponse
+	(*GetPortfolioPerformanceRequest)(nil),   // 23: greedy_eye.v1.GetPortfolioPerformanceRequest
+	(*PortfolioPerformanceResponse)(nil),     // 24: greedy_eye.v1.PortfolioPerformanceResponse
+	(*GetIncomeSummaryRequest)(nil),          // 25: greedy_eye.v1.GetIncomeSummaryRequest
+	(*AssetIncome)(nil),                      // 26: greedy_eye.v1.AssetIncome
+	(*AccountIncome)(nil),                    // 27: greedy_eye.v1.AccountIncome
+	(*MonthlyIncome)(nil),                    // 28: greedy_eye.v1.MonthlyIncome
+	(*AssetYield)(nil),                       // 29: greedy_eye.v1.AssetYield
+	(*IncomeProjection)(nil),                 // 30: greedy_eye.v1.IncomeProjection
+	(*IncomeSummary)(nil),                    // 31: greedy_eye.v1.IncomeSummary
+	(*InvitePortfolioMemberRequest)(nil),     // 32: greedy_eye.v1.InvitePortfolioMemberRequest
+	(*AcceptPortfolioInvitationRequest)(nil), // 33: greedy_eye.v1.AcceptPortfolioInvitationRequest
+	(*RevokePortfolioMemberRequest)(nil),     // 34: greedy_eye.v1.RevokePortfolioMemberRequest
+	(*ListPortfolioMembersRequest)(nil),      // 35: greedy_eye.v1.ListPortfolioMembersRequest
+	(*ListPortfolioMembersResponse)(nil),     // 36: greedy_eye.v1.ListPortfolioMembersResponse
+	(*CreateHoldingRequest)(nil),             // 37: greedy_eye.v1.CreateHoldingRequest
+	(*GetHoldingRequest)(nil),                // 38: greedy_eye.v1.GetHoldingRequest
+	(*UpdateHoldingRequest)(nil),             // 39: greedy_eye.v1.UpdateHoldingRequest
+	(*ListHoldingsRequest)(nil),              // 40: greedy_eye.v1.ListHoldingsRequest
+	(*ListHoldingsResponse)(nil),             // 41: greedy_eye.v1.ListHoldingsResponse
+	(*CreateLotRequest)(nil),                 // 42: greedy_eye.v1.CreateLotRequest
+	(*GetLotRequest)(nil),                    // 43: greedy_eye.v1.GetLotRequest
+	(*UpdateLotRequest)(nil),                 // 44: greedy_eye.v1.UpdateLotRequest
+	(*ListLotsRequest)(nil),                  // 45: greedy_eye.v1.ListLotsRequest
+	(*ListLotsResponse)(nil),                 // 46: greedy_eye.v1.ListLotsResponse
+	(*CreateAccountRequest)(nil),             // 47: greedy_eye.v1.CreateAccountRequest
+	(*GetAccountRequest)(nil),                // 48: greedy_eye.v1.GetAccountRequest
+	(*UpdateAccountRequest)(nil),             // 49: greedy_eye.v1.UpdateAccountRequest
+	(*DeleteAccountRequest)(nil),             // 50: greedy_eye.v1.DeleteAccountRequest
+	(*ListAccountsRequest)(nil),              // 51: greedy_eye.v1.ListAccountsRequest
+	(*ListAccountsResponse)(nil),             // 52: greedy_eye.v1.ListAccountsResponse
+	(*CreateTransactionRequest)(nil),         // 53: greedy_eye.v1.CreateTransactionRequest
+	(*GetTransactionRequest)(nil),            // 54: greedy_eye.v1.GetTransactionRequest
+	(*UpdateTransactionRequest)(nil),         // 55: greedy_eye.v1.UpdateTransactionRequest
+	(*ListTransactionsRequest)(nil),          // 56: greedy_eye.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),         // 57: greedy_eye.v1.ListTransactionsResponse
+	(*ImportColumnMapping)(nil),              // 58: greedy_eye.v1.ImportColumnMapping
+	(*ImportTransactionsRequest)(nil),        // 59: greedy_eye.v1.ImportTransactionsRequest
+	(*ImportedRow)(nil),                      // 60: greedy_eye.v1.ImportedRow
+	(*ImportTransactionsResponse)(nil),       // 61: greedy_eye.v1.ImportTransactionsResponse
+	(*ExportPortfolioRequest)(nil),           // 62: greedy_eye.v1.ExportPortfolioRequest
+	(*ExportChunk)(nil),                      // 63: greedy_eye.v1.ExportChunk
+	(*GenerateTaxReportRequest)(nil),         // 64: greedy_eye.v1.GenerateTaxReportRequest
+	(*TaxDisposal)(nil),                      // 65: greedy_eye.v1.TaxDisposal
+	(*TaxIncome)(nil),                        // 66: greedy_eye.v1.TaxIncome
+	(*TaxTotal)(nil),                         // 67: greedy_eye.v1.TaxTotal
+	(*TaxReport)(nil),                        // 68: greedy_eye.v1.TaxReport
+	nil,                                      // 69: greedy_eye.v1.Portfolio.DataEntry
+	nil,                                      // 70: greedy_eye.v1.Account.DataEntry
+	nil,                                      // 71: greedy_eye.v1.Transaction.DataEntry
+	(*timestamppb.Timestamp)(nil),            // 72: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),            // 73: google.protobuf.FieldMask
+	(*anypb.Any)(nil),                        // 74: google.protobuf.Any
+	(*emptypb.Empty)(nil),                    // 75: google.protobuf.Empty
 }
 var file_v1_portfolio_proto_depIdxs = []int32{
-	33, // 0: greedy_eye.v1.Portfolio.data:type_name -> greedy_eye.v1.Portfolio.DataEntry
-	36, // 1: greedy_eye.v1.Portfolio.created_at:type_name -> google.protobuf.Timestamp
-	36, // 2: greedy_eye.v1.Portfolio.updated_at:type_name -> google.protobuf.Timestamp
-	36, // 3: greedy_eye.v1.Holding.created_at:type_name -> google.protobuf.Timestamp
-	36, // 4: greedy_eye.v1.Holding.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 5: greedy_eye.v1.Account.type:type_name -> greedy_eye.v1.AccountType
-	34, // 6: greedy_eye.v1.Account.data:type_name -> greedy_eye.v1.Account.DataEntry
-	36, // 7: greedy_eye.v1.Account.created_at:type_name -> google.protobuf.Timestamp
-	36, // 8: greedy_eye.v1.Account.updated_at:type_name -> google.protobuf.Timestamp
-	36, // 9: greedy_eye.v1.Transaction.created_at:type_name -> google.protobuf.Timestamp
-	36, // 10: greedy_eye.v1.Transaction.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 11: greedy_eye.v1.Transaction.type:type_name -> greedy_eye.v1.TransactionType
-	2,  // 12: greedy_eye.v1.Transaction.status:type_name -> greedy_eye.v1.TransactionStatus
-	35, // 13: greedy_eye.v1.Transaction.data:type_name -> greedy_eye.v1.Transaction.DataEntry
-	3,  // 14: greedy_eye.v1.CreatePortfolioRequest.portfolio:type_name -> greedy_eye.v1.Portfolio
-	3,  // 15: greedy_eye.v1.UpdatePortfolioRequest.portfolio:type_name -> greedy_eye.v1.Portfolio
-	37, // 16: greedy_eye.v1.UpdatePortfolioRequest.update_mask:type_name -> google.protobuf.FieldMask
-	3,  // 17: greedy_eye.v1.ListPortfoliosResponse.portfolios:type_name -> greedy_eye.v1.Portfolio
-	36, // 18: greedy_eye.v1.CalculatePortfolioValueRequest.at_time:type_name -> google.protobuf.Timestamp
-	36, // 19: greedy_eye.v1.PortfolioValueResponse.calculation_time:type_name -> google.protobuf.Timestamp
-	36, // 20: greedy_eye.v1.GetPortfolioPerformanceRequest.from:type_name -> google.protobuf.Timestamp
-	36, // 21: greedy_eye.v1.GetPortfolioPerformanceRequest.to:type_name -> google.protobuf.Timestamp
-	4,  // 22: greedy_eye.v1.CreateHoldingRequest.holding:type_name -> greedy_eye.v1.Holding
-	4,  // 23: greedy_eye.v1.UpdateHoldingRequest.holding:type_name -> greedy_eye.v1.Holding
-	37, // 24: greedy_eye.v1.UpdateHoldingRequest.update_mask:type_name -> google.protobuf.FieldMask
-	4,  // 25: greedy_eye.v1.ListHoldingsResponse.holdings:type_name -> greedy_eye.v1.Holding
-	5,  // 26: greedy_eye.v1.CreateAccountRequest.account:type_name -> greedy_eye.v1.Account
-	5,  // 27: greedy_eye.v1.UpdateAccountRequest.account:type_name -> greedy_eye.v1.Account
-	37, // 28: greedy_eye.v1.UpdateAccountRequest.update_mask:type_name -> google.protobuf.FieldMask
-	0,  // 29: greedy_eye.v1.ListAccountsRequest.type:type_name -> greedy_eye.v1.AccountType
-	5,  // 30: greedy_eye.v1.ListAccountsResponse.accounts:type_name -> greedy_eye.v1.Account
-	6,  // 31: greedy_eye.v1.CreateTransactionRequest.transaction:type_name -> greedy_eye.v1.Transaction
-	6,  // 32: greedy_eye.v1.UpdateTransactionRequest.transaction:type_name -> greedy_eye.v1.Transaction
-	37, // 33: greedy_eye.v1.UpdateTransactionRequest.update_mask:type_name -> google.protobuf.FieldMask
-	1,  // 34: greedy_eye.v1.ListTransactionsRequest.type:type_name -> greedy_eye.v1.TransactionType
-	2,  // 35: greedy_eye.v1.ListTransactionsRequest.status:type_name -> greedy_eye.v1.TransactionStatus
-	36, // 36: greedy_eye.v1.ListTransactionsRequest.from:type_name -> google.protobuf.Timestamp
-	36, // 37: greedy_eye.v1.ListTransactionsRequest.to:type_name -> google.protobuf.Timestamp
-	6,  // 38: greedy_eye.v1.ListTransactionsResponse.transactions:type_name -> greedy_eye.v1.Transaction
-	38, // 39: greedy_eye.v1.Portfolio.DataEntry.value:type_name -> google.protobuf.Any
-	7,  // 40: greedy_eye.v1.PortfolioService.CreatePortfolio:input_type -> greedy_eye.v1.CreatePortfolioRequest
-	8,  // 41: greedy_eye.v1.PortfolioService.GetPortfolio:input_type -> greedy_eye.v1.GetPortfolioRequest
-	9,  // 42: greedy_eye.v1.PortfolioService.UpdatePortfolio:input_type -> greedy_eye.v1.UpdatePortfolioRequest
-	10, // 43: greedy_eye.v1.PortfolioService.DeletePortfolio:input_type -> greedy_eye.v1.DeletePortfolioRequest
-	11, // 44: greedy_eye.v1.PortfolioService.ListPortfolios:input_type -> greedy_eye.v1.ListPortfoliosRequest
-	13, // 45: greedy_eye.v1.PortfolioService.CalculatePortfolioValue:input_type -> greedy_eye.v1.CalculatePortfolioValueRequest
-	15, // 46: greedy_eye.v1.PortfolioService.GetPortfolioPerformance:input_type -> greedy_eye.v1.GetPortfolioPerformanceRequest
-	17, // 47: greedy_eye.v1.PortfolioService.CreateHolding:input_type -> greedy_eye.v1.CreateHoldingRequest
-	18, // 48: greedy_eye.v1.PortfolioService.GetHolding:input_type -> greedy_eye.v1.GetHoldingRequest
-	19, // 49: greedy_eye.v1.PortfolioService.UpdateHolding:input_type -> greedy_eye.v1.UpdateHoldingRequest
-	20, // 50: greedy_eye.v1.PortfolioService.ListHoldings:input_type -> greedy_eye.v1.ListHoldingsRequest
-	22, // 51: greedy_eye.v1.PortfolioService.CreateAccount:input_type -> greedy_eye.v1.CreateAccountRequest
-	23, // 52: greedy_eye.v1.PortfolioService.GetAccount:input_type -> greedy_eye.v1.GetAccountRequest
-	24, // 53: greedy_eye.v1.PortfolioService.UpdateAccount:input_type -> greedy_eye.v1.UpdateAccountRequest
-	25, // 54: greedy_eye.v1.PortfolioService.DeleteAccount:input_type -> greedy_eye.v1.DeleteAccountRequest
-	26, // 55: greedy_eye.v1.PortfolioService.ListAccounts:input_type -> greedy_eye.v1.ListAccountsRequest
-	28, // 56: greedy_eye.v1.PortfolioService.CreateTransaction:input_type -> greedy_eye.v1.CreateTransactionRequest
-	29, // 57: greedy_eye.v1.PortfolioService.GetTransaction:input_type -> greedy_eye.v1.GetTransactionRequest
-	30, // 58: greedy_eye.v1.PortfolioService.UpdateTransaction:input_type -> greedy_eye.v1.UpdateTransactionRequest
-	31, // 59: greedy_eye.v1.PortfolioService.ListTransactions:input_type -> greedy_eye.v1.ListTransactionsRequest
-	3,  // 60: greedy_eye.v1.PortfolioService.CreatePortfolio:output_type -> greedy_eye.v1.Portfolio
-	3,  // 61: greedy_eye.v1.PortfolioService.GetPortfolio:output_type -> greedy_eye.v1.Portfolio
-	3,  // 62: greedy_eye.v1.PortfolioService.UpdatePortfolio:output_type -> greedy_eye.v1.Portfolio
-	39, // 63: greedy_eye.v1.PortfolioService.DeletePortfolio:output_type -> google.protobuf.Empty
-	12, // 64: greedy_eye.v1.PortfolioService.ListPortfolios:output_type -> greedy_eye.v1.ListPortfoliosResponse
-	14, // 65: greedy_eye.v1.PortfolioService.CalculatePortfolioValue:output_type -> greedy_eye.v1.PortfolioValueResponse
-	16, // 66: greedy_eye.v1.PortfolioService.GetPortfolioPerformance:output_type -> greedy_eye.v1.PortfolioPerformanceResponse
-	4,  // 67: greedy_eye.v1.PortfolioService.CreateHolding:output_type -> greedy_eye.v1.Holding
-	4,  // 68: greedy_eye.v1.PortfolioService.GetHolding:output_type -> greedy_eye.v1.Holding
-	4,  // 69: greedy_eye.v1.PortfolioService.UpdateHolding:output_type -> greedy_eye.v1.Holding
-	21, // 70: greedy_eye.v1.PortfolioService.ListHoldings:output_type -> greedy_eye.v1.ListHoldingsResponse
-	5,  // 71: greedy_eye.v1.PortfolioService.CreateAccount:output_type -> greedy_eye.v1.Account
-	5,  // 72: greedy_eye.v1.PortfolioService.GetAccount:output_type -> greedy_eye.v1.Account
-	5,  // 73: greedy_eye.v1.PortfolioService.UpdateAccount:output_type -> greedy_eye.v1.Account
-	39, // 74: greedy_eye.v1.PortfolioService.DeleteAccount:output_type -> google.protobuf.Empty
-	27, // 75: greedy_eye.v1.PortfolioService.ListAccounts:output_type -> greedy_eye.v1.ListAccountsResponse
-	6,  // 76: greedy_eye.v1.PortfolioService.CreateTransaction:output_type -> greedy_eye.v1.Transaction
-	6,  // 77: greedy_eye.v1.PortfolioService.GetTransaction:output_type -> greedy_eye.v1.Transaction
-	6,  // 78: greedy_eye.v1.PortfolioService.UpdateTransaction:output_type -> greedy_eye.v1.Transaction
-	32, // 79: greedy_eye.v1.PortfolioService.ListTransactions:output_type -> greedy_eye.v1.ListTransactionsResponse
-	60, // [60:80] is the sub-list for method output_type
-	40, // [40:60] is the sub-list for method input_type
-	40, // [40:40] is the sub-list for extension type_name
-	40, // [40:40] is the sub-list for extension extendee
-	0,  // [0:40] is the sub-list for field type_name
+	69,  // 0: greedy_eye.v1.Portfolio.data:type_name -> greedy_eye.v1.Portfolio.DataEntry
+	72,  // 1: greedy_eye.v1.Portfolio.created_at:type_name -> google.protobuf.Timestamp
+	72,  // 2: greedy_eye.v1.Portfolio.updated_at:type_name -> google.protobuf.Timestamp
+	3,   // 3: greedy_eye.v1.PortfolioMember.role:type_name -> greedy_eye.v1.PortfolioRole
+	4,   // 4: greedy_eye.v1.PortfolioMember.status:type_name -> greedy_eye.v1.PortfolioMemberStatus
+	72,  // 5: greedy_eye.v1.PortfolioMember.created_at:type_name -> google.protobuf.Timestamp
+	72,  // 6: greedy_eye.v1.PortfolioMember.updated_at:type_name -> google.protobuf.Timestamp
+	72,  // 7: greedy_eye.v1.Holding.created_at:type_name -> google.protobuf.Timestamp
+	72,  // 8: greedy_eye.v1.Holding.updated_at:type_name -> google.protobuf.Timestamp
+	0,   // 9: greedy_eye.v1.Account.type:type_name -> greedy_eye.v1.AccountType
+	70,  // 10: greedy_eye.v1.Account.data:type_name -> greedy_eye.v1.Account.DataEntry
+	72,  // 11: greedy_eye.v1.Account.created_at:type_name -> google.protobuf.Timestamp
+	72,  // 12: greedy_eye.v1.Account.updated_at:type_name -> google.protobuf.Timestamp
+	72,  // 13: greedy_eye.v1.Lot.acquired_at:type_name -> google.protobuf.Timestamp
+	72,  // 14: greedy_eye.v1.Lot.created_at:type_name -> google.protobuf.Timestamp
+	72,  // 15: greedy_eye.v1.Lot.updated_at:type_name -> google.protobuf.Timestamp
+	72,  // 16: greedy_eye.v1.Transaction.created_at:type_name -> google.protobuf.Timestamp
+	72,  // 17: greedy_eye.v1.Transaction.updated_at:type_name -> google.protobuf.Timestamp
+	1,   // 18: greedy_eye.v1.Transaction.type:type_name -> greedy_eye.v1.TransactionType
+	2,   // 19: greedy_eye.v1.Transaction.status:type_name -> greedy_eye.v1.TransactionStatus
+	71,  // 20: greedy_eye.v1.Transaction.data:type_name -> greedy_eye.v1.Transaction.DataEntry
+	9,   // 21: greedy_eye.v1.CreatePortfolioRequest.portfolio:type_name -> greedy_eye.v1.Portfolio
+	9,   // 22: greedy_eye.v1.UpdatePortfolioRequest.portfolio:type_name -> greedy_eye.v1.Portfolio
+	73,  // 23: greedy_eye.v1.UpdatePortfolioRequest.update_mask:type_name -> google.protobuf.FieldMask
+	9,   // 24: greedy_eye.v1.ListPortfoliosResponse.portfolios:type_name -> greedy_eye.v1.Portfolio
+	72,  // 25: greedy_eye.v1.CalculatePortfolioValueRequest.at_time:type_name -> google.protobuf.Timestamp
+	72,  // 26: greedy_eye.v1.PortfolioValueResponse.calculation_time:type_name -> google.protobuf.Timestamp
+	72,  // 27: greedy_eye.v1.GetPortfolioPerformanceRequest.from:type_name -> google.protobuf.Timestamp
+	72,  // 28: greedy_eye.v1.GetPortfolioPerformanceRequest.to:type_name -> google.protobuf.Timestamp
+	72,  // 29: greedy_eye.v1.GetIncomeSummaryRequest.from:type_name -> google.protobuf.Timestamp
+	72,  // 30: greedy_eye.v1.GetIncomeSummaryRequest.to:type_name -> google.protobuf.Timestamp
+	72,  // 31: greedy_eye.v1.MonthlyIncome.month:type_name -> google.protobuf.Timestamp
+	29,  // 32: greedy_eye.v1.IncomeProjection.assets:type_name -> greedy_eye.v1.AssetYield
+	72,  // 33: greedy_eye.v1.IncomeSummary.from:type_name -> google.protobuf.Timestamp
+	72,  // 34: greedy_eye.v1.IncomeSummary.to:type_name -> google.protobuf.Timestamp
+	26,  // 35: greedy_eye.v1.IncomeSummary.assets:type_name -> greedy_eye.v1.AssetIncome
+	27,  // 36: greedy_eye.v1.IncomeSummary.accounts:type_name -> greedy_eye.v1.AccountIncome
+	28,  // 37: greedy_eye.v1.IncomeSummary.months:type_name -> greedy_eye.v1.MonthlyIncome
+	30,  // 38: greedy_eye.v1.IncomeSummary.projection:type_name -> greedy_eye.v1.IncomeProjection
+	3,   // 39: greedy_eye.v1.InvitePortfolioMemberRequest.role:type_name -> greedy_eye.v1.PortfolioRole
+	10,  // 40: greedy_eye.v1.ListPortfolioMembersResponse.portfolio_members:type_name -> greedy_eye.v1.PortfolioMember
+	11,  // 41: greedy_eye.v1.CreateHoldingRequest.holding:type_name -> greedy_eye.v1.Holding
+	11,  // 42: greedy_eye.v1.UpdateHoldingRequest.holding:type_name -> greedy_eye.v1.Holding
+	73,  // 43: greedy_eye.v1.UpdateHoldingRequest.update_mask:type_name -> google.protobuf.FieldMask
+	11,  // 44: greedy_eye.v1.ListHoldingsResponse.holdings:type_name -> greedy_eye.v1.Holding
+	13,  // 45: greedy_eye.v1.CreateLotRequest.lot:type_name -> greedy_eye.v1.Lot
+	13,  // 46: greedy_eye.v1.UpdateLotRequest.lot:type_name -> greedy_eye.v1.Lot
+	73,  // 47: greedy_eye.v1.UpdateLotRequest.update_mask:type_name -> google.protobuf.FieldMask
+	13,  // 48: greedy_eye.v1.ListLotsResponse.lots:type_name -> greedy_eye.v1.Lot
+	12,  // 49: greedy_eye.v1.CreateAccountRequest.account:type_name -> greedy_eye.v1.Account
+	12,  // 50: greedy_eye.v1.UpdateAccountRequest.account:type_name -> greedy_eye.v1.Account
+	73,  // 51: greedy_eye.v1.UpdateAccountRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,   // 52: greedy_eye.v1.ListAccountsRequest.type:type_name -> greedy_eye.v1.AccountType
+	12,  // 53: greedy_eye.v1.ListAccountsResponse.accounts:type_name -> greedy_eye.v1.Account
+	14,  // 54: greedy_eye.v1.CreateTransactionRequest.transaction:type_name -> greedy_eye.v1.Transaction
+	14,  // 55: greedy_eye.v1.UpdateTransactionRequest.transaction:type_name -> greedy_eye.v1.Transaction
+	73,  // 56: greedy_eye.v1.UpdateTransactionRequest.update_mask:type_name -> google.protobuf.FieldMask
+	1,   // 57: greedy_eye.v1.ListTransactionsRequest.type:type_name -> greedy_eye.v1.TransactionType
+	2,   // 58: greedy_eye.v1.ListTransactionsRequest.status:type_name -> greedy_eye.v1.TransactionStatus
+	72,  // 59: greedy_eye.v1.ListTransactionsRequest.from:type_name -> google.protobuf.Timestamp
+	72,  // 60: greedy_eye.v1.ListTransactionsRequest.to:type_name -> google.protobuf.Timestamp
+	14,  // 61: greedy_eye.v1.ListTransactionsResponse.transactions:type_name -> greedy_eye.v1.Transaction
+	5,   // 62: greedy_eye.v1.ImportTransactionsRequest.format:type_name -> greedy_eye.v1.ImportFormat
+	58,  // 63: greedy_eye.v1.ImportTransactionsRequest.mapping:type_name -> greedy_eye.v1.ImportColumnMapping
+	6,   // 64: greedy_eye.v1.ImportedRow.status:type_name -> greedy_eye.v1.ImportRowStatus
+	14,  // 65: greedy_eye.v1.ImportedRow.transaction:type_name -> greedy_eye.v1.Transaction
+	60,  // 66: greedy_eye.v1.ImportTransactionsResponse.rows:type_name -> greedy_eye.v1.ImportedRow
+	7,   // 67: greedy_eye.v1.ExportPortfolioRequest.format:type_name -> greedy_eye.v1.ExportFormat
+	72,  // 68: greedy_eye.v1.ExportPortfolioRequest.from:type_name -> google.protobuf.Timestamp
+	72,  // 69: greedy_eye.v1.ExportPortfolioRequest.to:type_name -> google.protobuf.Timestamp
+	72,  // 70: greedy_eye.v1.TaxDisposal.acquired_at:type_name -> google.protobuf.Timestamp
+	72,  // 71: greedy_eye.v1.TaxDisposal.disposed_at:type_name -> google.protobuf.Timestamp
+	8,   // 72: greedy_eye.v1.TaxDisposal.holding_period:type_name -> greedy_eye.v1.HoldingPeriod
+	72,  // 73: greedy_eye.v1.TaxIncome.received_at:type_name -> google.protobuf.Timestamp
+	72,  // 74: greedy_eye.v1.TaxReport.period_start:type_name -> google.protobuf.Timestamp
+	72,  // 75: greedy_eye.v1.TaxReport.period_end:type_name -> google.protobuf.Timestamp
+	65,  // 76: greedy_eye.v1.TaxReport.disposals:type_name -> greedy_eye.v1.TaxDisposal
+	66,  // 77: greedy_eye.v1.TaxReport.income:type_name -> greedy_eye.v1.TaxIncome
+	67,  // 78: greedy_eye.v1.TaxReport.totals:type_name -> greedy_eye.v1.TaxTotal
+	74,  // 79: greedy_eye.v1.Portfolio.DataEntry.value:type_name -> google.protobuf.Any
+	15,  // 80: greedy_eye.v1.PortfolioService.CreatePortfolio:input_type -> greedy_eye.v1.CreatePortfolioRequest
+	16,  // 81: greedy_eye.v1.PortfolioService.GetPortfolio:input_type -> greedy_eye.v1.GetPortfolioRequest
+	17,  // 82: greedy_eye.v1.PortfolioService.UpdatePortfolio:input_type -> greedy_eye.v1.UpdatePortfolioRequest
+	18,  // 83: greedy_eye.v1.PortfolioService.DeletePortfolio:input_type -> greedy_eye.v1.DeletePortfolioRequest
+	19,  // 84: greedy_eye.v1.PortfolioService.ListPortfolios:input_type -> greedy_eye.v1.ListPortfoliosRequest
+	32,  // 85: greedy_eye.v1.PortfolioService.InvitePortfolioMember:input_type -> greedy_eye.v1.InvitePortfolioMemberRequest
+	33,  // 86: greedy_eye.v1.PortfolioService.AcceptPortfolioInvitation:input_type -> greedy_eye.v1.AcceptPortfolioInvitationRequest
+	34,  // 87: greedy_eye.v1.PortfolioService.RevokePortfolioMember:input_type -> greedy_eye.v1.RevokePortfolioMemberRequest
+	35,  // 88: greedy_eye.v1.PortfolioService.ListPortfolioMembers:input_type -> greedy_eye.v1.ListPortfolioMembersRequest
+	21,  // 89: greedy_eye.v1.PortfolioService.CalculatePortfolioValue:input_type -> greedy_eye.v1.CalculatePortfolioValueRequest
+	23,  // 90: greedy_eye.v1.PortfolioService.GetPortfolioPerformance:input_type -> greedy_eye.v1.GetPortfolioPerformanceRequest
+	25,  // 91: greedy_eye.v1.PortfolioService.GetIncomeSummary:input_type -> greedy_eye.v1.GetIncomeSummaryRequest
+	37,  // 92: greedy_eye.v1.PortfolioService.CreateHolding:input_type -> greedy_eye.v1.CreateHoldingRequest
+	38,  // 93: greedy_eye.v1.PortfolioService.GetHolding:input_type -> greedy_eye.v1.GetHoldingRequest
+	39,  // 94: greedy_eye.v1.PortfolioService.UpdateHolding:input_type -> greedy_eye.v1.UpdateHoldingRequest
+	40,  // 95: greedy_eye.v1.PortfolioService.ListHoldings:input_type -> greedy_eye.v1.ListHoldingsRequest
+	42,  // 96: greedy_eye.v1.PortfolioService.CreateLot:input_type -> greedy_eye.v1.CreateLotRequest
+	43,  // 97: greedy_eye.v1.PortfolioService.GetLot:input_type -> greedy_eye.v1.GetLotRequest
+	44,  // 98: greedy_eye.v1.PortfolioService.UpdateLot:input_type -> greedy_eye.v1.UpdateLotRequest
+	45,  // 99: greedy_eye.v1.PortfolioService.ListLots:input_type -> greedy_eye.v1.ListLotsRequest
+	47,  // 100: greedy_eye.v1.PortfolioService.CreateAccount:input_type -> greedy_eye.v1.CreateAccountRequest
+	48,  // 101: greedy_eye.v1.PortfolioService.GetAccount:input_type -> greedy_eye.v1.GetAccountRequest
+	49,  // 102: greedy_eye.v1.PortfolioService.UpdateAccount:input_type -> greedy_eye.v1.UpdateAccountRequest
+	50,  // 103: greedy_eye.v1.PortfolioService.DeleteAccount:input_type -> greedy_eye.v1.DeleteAccountRequest
+	51,  // 104: greedy_eye.v1.PortfolioService.ListAccounts:input_type -> greedy_eye.v1.ListAccountsRequest
+	53,  // 105: greedy_eye.v1.PortfolioService.CreateTransaction:input_type -> greedy_eye.v1.CreateTransactionRequest
+	54,  // 106: greedy_eye.v1.PortfolioService.GetTransaction:input_type -> greedy_eye.v1.GetTransactionRequest
+	55,  // 107: greedy_eye.v1.PortfolioService.UpdateTransaction:input_type -> greedy_eye.v1.UpdateTransactionRequest
+	56,  // 108: greedy_eye.v1.PortfolioService.ListTransactions:input_type -> greedy_eye.v1.ListTransactionsRequest
+	59,  // 109: greedy_eye.v1.PortfolioService.ImportTransactions:input_type -> greedy_eye.v1.ImportTransactionsRequest
+	62,  // 110: greedy_eye.v1.PortfolioService.ExportPortfolio:input_type -> greedy_eye.v1.ExportPortfolioRequest
+	64,  // 111: greedy_eye.v1.PortfolioService.GenerateTaxReport:input_type -> greedy_eye.v1.GenerateTaxReportRequest
+	9,   // 112: greedy_eye.v1.PortfolioService.CreatePortfolio:output_type -> greedy_eye.v1.Portfolio
+	9,   // 113: greedy_eye.v1.PortfolioService.GetPortfolio:output_type -> greedy_eye.v1.Portfolio
+	9,   // 114: greedy_eye.v1.PortfolioService.UpdatePortfolio:output_type -> greedy_eye.v1.Portfolio
+	75,  // 115: greedy_eye.v1.PortfolioService.DeletePortfolio:output_type -> google.protobuf.Empty
+	20,  // 116: greedy_eye.v1.PortfolioService.ListPortfolios:output_type -> greedy_eye.v1.ListPortfoliosResponse
+	10,  // 117: greedy_eye.v1.PortfolioService.InvitePortfolioMember:output_type -> greedy_eye.v1.PortfolioMember
+	10,  // 118: greedy_eye.v1.PortfolioService.AcceptPortfolioInvitation:output_type -> greedy_eye.v1.PortfolioMember
+	75,  // 119: greedy_eye.v1.PortfolioService.RevokePortfolioMember:output_type -> google.protobuf.Empty
+	36,  // 120: greedy_eye.v1.PortfolioService.ListPortfolioMembers:output_type -> greedy_eye.v1.ListPortfolioMembersResponse
+	22,  // 121: greedy_eye.v1.PortfolioService.CalculatePortfolioValue:output_type -> greedy_eye.v1.PortfolioValueResponse
+	24,  // 122: greedy_eye.v1.PortfolioService.GetPortfolioPerformance:output_type -> greedy_eye.v1.PortfolioPerformanceResponse
+	31,  // 123: greedy_eye.v1.PortfolioService.GetIncomeSummary:output_type -> greedy_eye.v1.IncomeSummary
+	11,  // 124: greedy_eye.v1.PortfolioService.CreateHolding:output_type -> greedy_eye.v1.Holding
+	11,  // 125: greedy_eye.v1.PortfolioService.GetHolding:output_type -> greedy_eye.v1.Holding
+	11,  // 126: greedy_eye.v1.PortfolioService.UpdateHolding:output_type -> greedy_eye.v1.Holding
+	41,  // 127: greedy_eye.v1.PortfolioService.ListHoldings:output_type -> greedy_eye.v1.ListHoldingsResponse
+	13,  // 128: greedy_eye.v1.PortfolioService.CreateLot:output_type -> greedy_eye.v1.Lot
+	13,  // 129: greedy_eye.v1.PortfolioService.GetLot:output_type -> greedy_eye.v1.Lot
+	13,  // 130: greedy_eye.v1.PortfolioService.UpdateLot:output_type -> greedy_eye.v1.Lot
+	46,  // 131: greedy_eye.v1.PortfolioService.ListLots:output_type -> greedy_eye.v1.ListLotsResponse
+	12,  // 132: greedy_eye.v1.PortfolioService.CreateAccount:output_type -> greedy_eye.v1.Account
+	12,  // 133: greedy_eye.v1.PortfolioService.GetAccount:output_type -> greedy_eye.v1.Account
+	12,  // 134: greedy_eye.v1.PortfolioService.UpdateAccount:output_type -> greedy_eye.v1.Account
+	75,  // 135: greedy_eye.v1.PortfolioService.DeleteAccount:output_type -> google.protobuf.Empty
+	52,  // 136: greedy_eye.v1.PortfolioService.ListAccounts:output_type -> greedy_eye.v1.ListAccountsResponse
+	14,  // 137: greedy_eye.v1.PortfolioService.CreateTransaction:output_type -> greedy_eye.v1.Transaction
+	14,  // 138: greedy_eye.v1.PortfolioService.GetTransaction:output_type -> greedy_eye.v1.Transaction
+	14,  // 139: greedy_eye.v1.PortfolioService.UpdateTransaction:output_type -> greedy_eye.v1.Transaction
+	57,  // 140: greedy_eye.v1.PortfolioService.ListTransactions:output_type -> greedy_eye.v1.ListTransactionsResponse
+	61,  // 141: greedy_eye.v1.PortfolioService.ImportTransactions:output_type -> greedy_eye.v1.ImportTransactionsResponse
+	63,  // 142: greedy_eye.v1.PortfolioService.ExportPortfolio:output_type -> greedy_eye.v1.ExportChunk
+	68,  // 143: greedy_eye.v1.PortfolioService.GenerateTaxReport:output_type -> greedy_eye.v1.TaxReport
+	112, // [112:144] is the sub-list for method output_type
+	80,  // [80:112] is the sub-list for method input_type
+	80,  // [80:80] is the sub-list for extension type_name
+	80,  // [80:80] is the sub-list for extension extendee
+	0,   // [0:80] is the sub-list for field type_name
 }
 
 func init() { file_v1_portfolio_proto_init() }
@@ -2391,19 +5535,31 @@ func file_v1_portfolio_proto_init() {
 		return
 	}
 	file_v1_portfolio_proto_msgTypes[0].OneofWrappers = []any{}
-	file_v1_portfolio_proto_msgTypes[1].OneofWrappers = []any{}
 	file_v1_portfolio_proto_msgTypes[2].OneofWrappers = []any{}
-	file_v1_portfolio_proto_msgTypes[8].OneofWrappers = []any{}
-	file_v1_portfolio_proto_msgTypes[17].OneofWrappers = []any{}
-	file_v1_portfolio_proto_msgTypes[23].OneofWrappers = []any{}
-	file_v1_portfolio_proto_msgTypes[28].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[3].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[5].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[10].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[16].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[20].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[21].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[26].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[31].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[36].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[42].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[47].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[49].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[51].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[53].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[55].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[56].OneofWrappers = []any{}
+	file_v1_portfolio_proto_msgTypes[57].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_portfolio_proto_rawDesc), len(file_v1_portfolio_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   33,
+			NumEnums:      9,
+			NumMessages:   63,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
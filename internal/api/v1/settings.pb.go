@@ -0,0 +1,841 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: v1/settings.proto
+
+package apiv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RiskTolerance int32
+
+const (
+	RiskTolerance_RISK_TOLERANCE_UNSPECIFIED  RiskTolerance = 0
+	RiskTolerance_RISK_TOLERANCE_CONSERVATIVE RiskTolerance = 1
+	RiskTolerance_RISK_TOLERANCE_MODERATE     RiskTolerance = 2
+	RiskTolerance_RISK_TOLERANCE_AGGRESSIVE   RiskTolerance = 3
+)
+
+// Enum value maps for RiskTolerance.
+var (
+	RiskTolerance_name = map[int32]string{
+		0: "RISK_TOLERANCE_UNSPECIFIED",
+		1: "RISK_TOLERANCE_CONSERVATIVE",
+		2: "RISK_TOLERANCE_MODERATE",
+		3: "RISK_TOLERANCE_AGGRESSIVE",
+	}
+	RiskTolerance_value = map[string]int32{
+		"RISK_TOLERANCE_UNSPECIFIED":  0,
+		"RISK_TOLERANCE_CONSERVATIVE": 1,
+		"RISK_TOLERANCE_MODERATE":     2,
+		"RISK_TOLERANCE_AGGRESSIVE":   3,
+	}
+)
+
+func (x RiskTolerance) Enum() *RiskTolerance {
+	p := new(RiskTolerance)
+	*p = x
+	return p
+}
+
+func (x RiskTolerance) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RiskTolerance) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_settings_proto_enumTypes[0].Descriptor()
+}
+
+func (RiskTolerance) Type() protoreflect.EnumType {
+	return &file_v1_settings_proto_enumTypes[0]
+}
+
+func (x RiskTolerance) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RiskTolerance.Descriptor instead.
+func (RiskTolerance) EnumDescriptor() ([]byte, []int) {
+	return file_v1_settings_proto_rawDescGZIP(), []int{0}
+}
+
+type NotificationChannel int32
+
+const (
+	NotificationChannel_NOTIFICATION_CHANNEL_UNSPECIFIED NotificationChannel = 0
+	NotificationChannel_NOTIFICATION_CHANNEL_TELEGRAM    NotificationChannel = 1
+	NotificationChannel_NOTIFICATION_CHANNEL_EMAIL       NotificationChannel = 2
+)
+
+// Enum value maps for NotificationChannel.
+var (
+	NotificationChannel_name = map[int32]string{
+		0: "NOTIFICATION_CHANNEL_UNSPECIFIED",
+		1: "NOTIFICATION_CHANNEL_TELEGRAM",
+		2: "NOTIFICATION_CHANNEL_EMAIL",
+	}
+	NotificationChannel_value = map[string]int32{
+		"NOTIFICATION_CHANNEL_UNSPECIFIED": 0,
+		"NOTIFICATION_CHANNEL_TELEGRAM":    1,
+		"NOTIFICATION_CHANNEL_EMAIL":       2,
+	}
+)
+
+func (x NotificationChannel) Enum() *NotificationChannel {
+	p := new(NotificationChannel)
+	*p = x
+	return p
+}
+
+func (x NotificationChannel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotificationChannel) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_settings_proto_enumTypes[1].Descriptor()
+}
+
+func (NotificationChannel) Type() protoreflect.EnumType {
+	return &file_v1_settings_proto_enumTypes[1]
+}
+
+func (x NotificationChannel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotificationChannel.Descriptor instead.
+func (NotificationChannel) EnumDescriptor() ([]byte, []int) {
+	return file_v1_settings_proto_rawDescGZIP(), []int{1}
+}
+
+// User is a person who owns portfolios, accounts and rules.
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Preferences   *UserPreferences       `protobuf:"bytes,4,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_v1_settings_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_settings_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_v1_settings_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetPreferences() *UserPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// UserPreferences holds per-user settings. Unset fields use system defaults.
+type UserPreferences struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Currency portfolios are reported in: the symbol of a stored forex
+	// asset, such as "USD", or of a cryptocurrency with stored prices, such
+	// as "BTC".
+	DefaultCurrency *string       `protobuf:"bytes,1,opt,name=default_currency,json=defaultCurrency,proto3,oneof" json:"default_currency,omitempty"`
+	RiskTolerance   RiskTolerance `protobuf:"varint,2,opt,name=risk_tolerance,json=riskTolerance,proto3,enum=greedy_eye.v1.RiskTolerance" json:"risk_tolerance,omitempty"`
+	// IANA time zone name, e.g. "Europe/Berlin".
+	Timezone             *string               `protobuf:"bytes,3,opt,name=timezone,proto3,oneof" json:"timezone,omitempty"`
+	NotificationChannels []NotificationChannel `protobuf:"varint,4,rep,packed,name=notification_channels,json=notificationChannels,proto3,enum=greedy_eye.v1.NotificationChannel" json:"notification_channels,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *UserPreferences) Reset() {
+	*x = UserPreferences{}
+	mi := &file_v1_settings_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserPreferences) ProtoMessage() {}
+
+func (x *UserPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_settings_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserPreferences.ProtoReflect.Descriptor instead.
+func (*UserPreferences) Descriptor() ([]byte, []int) {
+	return file_v1_settings_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserPreferences) GetDefaultCurrency() string {
+	if x != nil && x.DefaultCurrency != nil {
+		return *x.DefaultCurrency
+	}
+	return ""
+}
+
+func (x *UserPreferences) GetRiskTolerance() RiskTolerance {
+	if x != nil {
+		return x.RiskTolerance
+	}
+	return RiskTolerance_RISK_TOLERANCE_UNSPECIFIED
+}
+
+func (x *UserPreferences) GetTimezone() string {
+	if x != nil && x.Timezone != nil {
+		return *x.Timezone
+	}
+	return ""
+}
+
+func (x *UserPreferences) GetNotificationChannels() []NotificationChannel {
+	if x != nil {
+		return x.NotificationChannels
+	}
+	return nil
+}
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_v1_settings_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_settings_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_v1_settings_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_v1_settings_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_settings_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_v1_settings_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetUserByEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByEmailRequest) Reset() {
+	*x = GetUserByEmailRequest{}
+	mi := &file_v1_settings_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByEmailRequest) ProtoMessage() {}
+
+func (x *GetUserByEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_settings_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByEmailRequest.ProtoReflect.Descriptor instead.
+func (*GetUserByEmailRequest) Descriptor() ([]byte, []int) {
+	return file_v1_settings_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetUserByEmailRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type UpdateUserRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	User  *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	// Supported paths: email, name, preferences.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_v1_settings_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_settings_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_v1_settings_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_v1_settings_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_settings_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_v1_settings_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      *int32                 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	PageToken     *string                `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_v1_settings_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_settings_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_v1_settings_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListUsersRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_v1_settings_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_settings_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_v1_settings_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateUserPreferencesRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	UserId      string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Preferences *UserPreferences       `protobuf:"bytes,2,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	// Supported paths: default_currency, risk_tolerance, timezone,
+	// notification_channels. Masked fields left unset are cleared.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserPreferencesRequest) Reset() {
+	*x = UpdateUserPreferencesRequest{}
+	mi := &file_v1_settings_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserPreferencesRequest) ProtoMessage() {}
+
+func (x *UpdateUserPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_settings_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_settings_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateUserPreferencesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateUserPreferencesRequest) GetPreferences() *UserPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+func (x *UpdateUserPreferencesRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+var File_v1_settings_proto protoreflect.FileDescriptor
+
+const file_v1_settings_proto_rawDesc = "" +
+	"\n" +
+	"\x11v1/settings.proto\x12\rgreedy_eye.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/api/annotations.proto\"\xf8\x01\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12@\n" +
+	"\vpreferences\x18\x04 \x01(\v2\x1e.greedy_eye.v1.UserPreferencesR\vpreferences\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa2\x02\n" +
+	"\x0fUserPreferences\x12.\n" +
+	"\x10default_currency\x18\x01 \x01(\tH\x00R\x0fdefaultCurrency\x88\x01\x01\x12C\n" +
+	"\x0erisk_tolerance\x18\x02 \x01(\x0e2\x1c.greedy_eye.v1.RiskToleranceR\rriskTolerance\x12\x1f\n" +
+	"\btimezone\x18\x03 \x01(\tH\x01R\btimezone\x88\x01\x01\x12W\n" +
+	"\x15notification_channels\x18\x04 \x03(\x0e2\".greedy_eye.v1.NotificationChannelR\x14notificationChannelsB\x13\n" +
+	"\x11_default_currencyB\v\n" +
+	"\t_timezone\"<\n" +
+	"\x11CreateUserRequest\x12'\n" +
+	"\x04user\x18\x01 \x01(\v2\x13.greedy_eye.v1.UserR\x04user\" \n" +
+	"\x0eGetUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"-\n" +
+	"\x15GetUserByEmailRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"y\n" +
+	"\x11UpdateUserRequest\x12'\n" +
+	"\x04user\x18\x01 \x01(\v2\x13.greedy_eye.v1.UserR\x04user\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"#\n" +
+	"\x11DeleteUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"u\n" +
+	"\x10ListUsersRequest\x12 \n" +
+	"\tpage_size\x18\x01 \x01(\x05H\x00R\bpageSize\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tH\x01R\tpageToken\x88\x01\x01B\f\n" +
+	"\n" +
+	"_page_sizeB\r\n" +
+	"\v_page_token\"f\n" +
+	"\x11ListUsersResponse\x12)\n" +
+	"\x05users\x18\x01 \x03(\v2\x13.greedy_eye.v1.UserR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xb6\x01\n" +
+	"\x1cUpdateUserPreferencesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12@\n" +
+	"\vpreferences\x18\x02 \x01(\v2\x1e.greedy_eye.v1.UserPreferencesR\vpreferences\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask*\x8c\x01\n" +
+	"\rRiskTolerance\x12\x1e\n" +
+	"\x1aRISK_TOLERANCE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bRISK_TOLERANCE_CONSERVATIVE\x10\x01\x12\x1b\n" +
+	"\x17RISK_TOLERANCE_MODERATE\x10\x02\x12\x1d\n" +
+	"\x19RISK_TOLERANCE_AGGRESSIVE\x10\x03*~\n" +
+	"\x13NotificationChannel\x12$\n" +
+	" NOTIFICATION_CHANNEL_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dNOTIFICATION_CHANNEL_TELEGRAM\x10\x01\x12\x1e\n" +
+	"\x1aNOTIFICATION_CHANNEL_EMAIL\x10\x022\x90\x06\n" +
+	"\x0fSettingsService\x12`\n" +
+	"\n" +
+	"CreateUser\x12 .greedy_eye.v1.CreateUserRequest\x1a\x13.greedy_eye.v1.User\"\x1b\x82\xd3\xe4\x93\x02\x15:\x04user\"\r/api/v1/users\x12Y\n" +
+	"\aGetUser\x12\x1d.greedy_eye.v1.GetUserRequest\x1a\x13.greedy_eye.v1.User\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/users/{id}\x12s\n" +
+	"\x0eGetUserByEmail\x12$.greedy_eye.v1.GetUserByEmailRequest\x1a\x13.greedy_eye.v1.User\"&\x82\xd3\xe4\x93\x02 \x12\x1e/api/v1/users/by-email/{email}\x12j\n" +
+	"\n" +
+	"UpdateUser\x12 .greedy_eye.v1.UpdateUserRequest\x1a\x13.greedy_eye.v1.User\"%\x82\xd3\xe4\x93\x02\x1f:\x04user\x1a\x17/api/v1/users/{user.id}\x12b\n" +
+	"\n" +
+	"DeleteUser\x12 .greedy_eye.v1.DeleteUserRequest\x1a\x16.google.protobuf.Empty\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/api/v1/users/{id}\x12e\n" +
+	"\tListUsers\x12\x1f.greedy_eye.v1.ListUsersRequest\x1a .greedy_eye.v1.ListUsersResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/users\x12\x93\x01\n" +
+	"\x15UpdateUserPreferences\x12+.greedy_eye.v1.UpdateUserPreferencesRequest\x1a\x13.greedy_eye.v1.User\"8\x82\xd3\xe4\x93\x022:\vpreferences2#/api/v1/users/{user_id}/preferencesB\xa8\x01\n" +
+	"\x11com.greedy_eye.v1B\rSettingsProtoP\x01Z3github.com/foxcool/greedy-eye/internal/api/v1;apiv1\xa2\x02\x03GXX\xaa\x02\fGreedyEye.V1\xca\x02\fGreedyEye\\V1\xe2\x02\x18GreedyEye\\V1\\GPBMetadata\xea\x02\rGreedyEye::V1b\x06proto3"
+
+var (
+	file_v1_settings_proto_rawDescOnce sync.Once
+	file_v1_settings_proto_rawDescData []byte
+)
+
+func file_v1_settings_proto_rawDescGZIP() []byte {
+	file_v1_settings_proto_rawDescOnce.Do(func() {
+		file_v1_settings_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_v1_settings_proto_rawDesc), len(file_v1_settings_proto_rawDesc)))
+	})
+	return file_v1_settings_proto_rawDescData
+}
+
+var file_v1_settings_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_v1_settings_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_v1_settings_proto_goTypes = []any{
+	(RiskTolerance)(0),                   // 0: greedy_eye.v1.RiskTolerance
+	(NotificationChannel)(0),             // 1: greedy_eye.v1.NotificationChannel
+	(*User)(nil),                         // 2: greedy_eye.v1.User
+	(*UserPreferences)(nil),              // 3: greedy_eye.v1.UserPreferences
+	(*CreateUserRequest)(nil),            // 4: greedy_eye.v1.CreateUserRequest
+	(*GetUserRequest)(nil),               // 5: greedy_eye.v1.GetUserRequest
+	(*GetUserByEmailRequest)(nil),        // 6: greedy_eye.v1.GetUserByEmailRequest
+	(*UpdateUserRequest)(nil),            // 7: greedy_eye.v1.UpdateUserRequest
+	(*DeleteUserRequest)(nil),            // 8: greedy_eye.v1.DeleteUserRequest
+	(*ListUsersRequest)(nil),             // 9: greedy_eye.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),            // 10: greedy_eye.v1.ListUsersResponse
+	(*UpdateUserPreferencesRequest)(nil), // 11: greedy_eye.v1.UpdateUserPreferencesRequest
+	(*timestamppb.Timestamp)(nil),        // 12: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),        // 13: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),                // 14: google.protobuf.Empty
+}
+var file_v1_settings_proto_depIdxs = []int32{
+	3,  // 0: greedy_eye.v1.User.preferences:type_name -> greedy_eye.v1.UserPreferences
+	12, // 1: greedy_eye.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	12, // 2: greedy_eye.v1.User.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: greedy_eye.v1.UserPreferences.risk_tolerance:type_name -> greedy_eye.v1.RiskTolerance
+	1,  // 4: greedy_eye.v1.UserPreferences.notification_channels:type_name -> greedy_eye.v1.NotificationChannel
+	2,  // 5: greedy_eye.v1.CreateUserRequest.user:type_name -> greedy_eye.v1.User
+	2,  // 6: greedy_eye.v1.UpdateUserRequest.user:type_name -> greedy_eye.v1.User
+	13, // 7: greedy_eye.v1.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 8: greedy_eye.v1.ListUsersResponse.users:type_name -> greedy_eye.v1.User
+	3,  // 9: greedy_eye.v1.UpdateUserPreferencesRequest.preferences:type_name -> greedy_eye.v1.UserPreferences
+	13, // 10: greedy_eye.v1.UpdateUserPreferencesRequest.update_mask:type_name -> google.protobuf.FieldMask
+	4,  // 11: greedy_eye.v1.SettingsService.CreateUser:input_type -> greedy_eye.v1.CreateUserRequest
+	5,  // 12: greedy_eye.v1.SettingsService.GetUser:input_type -> greedy_eye.v1.GetUserRequest
+	6,  // 13: greedy_eye.v1.SettingsService.GetUserByEmail:input_type -> greedy_eye.v1.GetUserByEmailRequest
+	7,  // 14: greedy_eye.v1.SettingsService.UpdateUser:input_type -> greedy_eye.v1.UpdateUserRequest
+	8,  // 15: greedy_eye.v1.SettingsService.DeleteUser:input_type -> greedy_eye.v1.DeleteUserRequest
+	9,  // 16: greedy_eye.v1.SettingsService.ListUsers:input_type -> greedy_eye.v1.ListUsersRequest
+	11, // 17: greedy_eye.v1.SettingsService.UpdateUserPreferences:input_type -> greedy_eye.v1.UpdateUserPreferencesRequest
+	2,  // 18: greedy_eye.v1.SettingsService.CreateUser:output_type -> greedy_eye.v1.User
+	2,  // 19: greedy_eye.v1.SettingsService.GetUser:output_type -> greedy_eye.v1.User
+	2,  // 20: greedy_eye.v1.SettingsService.GetUserByEmail:output_type -> greedy_eye.v1.User
+	2,  // 21: greedy_eye.v1.SettingsService.UpdateUser:output_type -> greedy_eye.v1.User
+	14, // 22: greedy_eye.v1.SettingsService.DeleteUser:output_type -> google.protobuf.Empty
+	10, // 23: greedy_eye.v1.SettingsService.ListUsers:output_type -> greedy_eye.v1.ListUsersResponse
+	2,  // 24: greedy_eye.v1.SettingsService.UpdateUserPreferences:output_type -> greedy_eye.v1.User
+	18, // [18:25] is the sub-list for method output_type
+	11, // [11:18] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_v1_settings_proto_init() }
+func file_v1_settings_proto_init() {
+	if File_v1_settings_proto != nil {
+		return
+	}
+	file_v1_settings_proto_msgTypes[1].OneofWrappers = []any{}
+	file_v1_settings_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_settings_proto_rawDesc), len(file_v1_settings_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_v1_settings_proto_goTypes,
+		DependencyIndexes: file_v1_settings_proto_depIdxs,
+		EnumInfos:         file_v1_settings_proto_enumTypes,
+		MessageInfos:      file_v1_settings_proto_msgTypes,
+	}.Build()
+	File_v1_settings_proto = out.File
+	file_v1_settings_proto_goTypes = nil
+	file_v1_settings_proto_depIdxs = nil
+}
@@ -0,0 +1,199 @@
+// Package audit records who changed what. A Connect interceptor opens an
+// audit scope for every mutating call, and stores report each change they
+// make to the scope in the context. Background processes open scopes for
+// system actors.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/secrets"
+)
+
+// System actors.
+const (
+	ActorAutomation = "automation"
+	ActorDividends  = "dividends"
+)
+
+// Store is the append-only audit log.
+type Store interface {
+	CreateAuditEvent(ctx context.Context, e *entity.AuditEvent) error
+	ListAuditEvents(ctx context.Context, opts ListAuditEventsOpts) ([]*entity.AuditEvent, string, error)
+}
+
+// ListAuditEventsOpts contains options for listing audit events.
+type ListAuditEventsOpts struct {
+	ActorID      string
+	ResourceType string
+	ResourceID   string
+	Procedure    string
+	From         *time.Time
+	To           *time.Time
+	PageSize     int
+	PageToken    string
+}
+
+// scope is the audit state of one call or background task.
+type scope struct {
+	store Store
+	log   *slog.Logger
+	base  entity.AuditEvent
+	// recorded counts changes written, so the interceptor knows whether the
+	// call still needs a row of its own.
+	recorded int
+}
+
+type scopeKey struct{}
+
+func withScope(ctx context.Context, s *scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, s)
+}
+
+func scopeFrom(ctx context.Context) *scope {
+	s, _ := ctx.Value(scopeKey{}).(*scope)
+	return s
+}
+
+// WithSystemActor returns a context whose changes are recorded as made by
+// the named background process.
+func WithSystemActor(ctx context.Context, store Store, log *slog.Logger, actor string) context.Context {
+	return withScope(ctx, &scope{
+		store: store,
+		log:   log,
+		base:  entity.AuditEvent{ActorType: entity.ActorTypeSystem, ActorID: actor},
+	})
+}
+
+// WithTask narrows the scope in ctx to one task of a background process,
+// such as a rule execution, done on behalf of ownerID.
+func WithTask(ctx context.Context, taskID, ownerID string) context.Context {
+	s := scopeFrom(ctx)
+	if s == nil {
+		return ctx
+	}
+	task := &scope{store: s.store, log: s.log, base: s.base}
+	task.base.RequestID = taskID
+	task.base.OwnerID = ownerID
+	return withScope(ctx, task)
+}
+
+// Enabled reports whether changes made with ctx are recorded, so callers can
+// skip loading state only needed for the record.
+func Enabled(ctx context.Context) bool {
+	return scopeFrom(ctx) != nil
+}
+
+// RecordChange writes an event for a change to a resource. before is nil
+// for creations and after is nil for deletions. Only differing fields are
+// kept, and secrets are redacted. Without an audit scope it does nothing.
+func RecordChange(ctx context.Context, resourceType, resourceID string, before, after any) {
+	s := scopeFrom(ctx)
+	if s == nil {
+		return
+	}
+	b, a := toFields(before), toFields(after)
+	e := s.base
+	e.ResourceType = resourceType
+	e.ResourceID = resourceID
+	e.Changes = diffFields(b, a)
+	// Record whose data changed, which the call itself may not know, e.g.
+	// when an admin acts on another user's records.
+	if resourceType == "user" {
+		e.OwnerID = resourceID
+	} else if owner := ownerOf(a, b); owner != "" {
+		e.OwnerID = owner
+	}
+	s.write(ctx, &e)
+}
+
+// ownerOf returns the first non-empty UserID of the given entity fields.
+func ownerOf(fields ...map[string]any) string {
+	for _, f := range fields {
+		if id, _ := f["UserID"].(string); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+func (s *scope) write(ctx context.Context, e *entity.AuditEvent) {
+	s.recorded++
+	// The change has happened; record it even if the caller has gone away.
+	if err := s.store.CreateAuditEvent(context.WithoutCancel(ctx), e); err != nil {
+		s.log.Error("Failed to write audit event",
+			slog.String("procedure", e.Procedure),
+			slog.String("resource_type", e.ResourceType),
+			slog.String("resource_id", e.ResourceID),
+			slog.Any("error", err))
+	}
+}
+
+// Fields that are never written to the audit log in clear. Keys of map
+// fields are checked with secrets.IsSecretField as well.
+var redactedFields = []string{"Secrets", "Hash", "PrivateKey"}
+
+// Diff returns the fields of two entities that differ. Both are compared in
+// their JSON form; nil stands for a missing entity.
+func Diff(before, after any) map[string]entity.AuditChange {
+	return diffFields(toFields(before), toFields(after))
+}
+
+func diffFields(b, a map[string]any) map[string]entity.AuditChange {
+	changes := map[string]entity.AuditChange{}
+	for name, value := range a {
+		if old, ok := b[name]; !ok || !reflect.DeepEqual(old, value) {
+			changes[name] = entity.AuditChange{Before: b[name], After: value}
+		}
+	}
+	for name, old := range b {
+		if _, ok := a[name]; !ok {
+			changes[name] = entity.AuditChange{Before: old}
+		}
+	}
+	delete(changes, "UpdatedAt")
+
+	// Redact after comparing, so changed secrets still show up as changed.
+	for name, c := range changes {
+		all := slices.Contains(redactedFields, name) || secrets.IsSecretField(name)
+		changes[name] = entity.AuditChange{Before: redact(c.Before, all), After: redact(c.After, all)}
+	}
+	return changes
+}
+
+func toFields(v any) map[string]any {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if json.Unmarshal(raw, &fields) != nil {
+		return nil
+	}
+	return fields
+}
+
+// redact replaces secret values. Maps are redacted key by key, so the log
+// still shows which secrets a change touched.
+func redact(v any, all bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, inner := range v {
+			v[k] = redact(inner, all || secrets.IsSecretField(k))
+		}
+		return v
+	case nil:
+		return nil
+	default:
+		if all {
+			return secrets.Redacted
+		}
+		return v
+	}
+}
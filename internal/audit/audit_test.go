@@ -0,0 +1,185 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/api/v1/apiv1connect"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// memStore is an in-memory Store for tests.
+type memStore struct {
+	mu     sync.Mutex
+	events []*entity.AuditEvent
+}
+
+func (m *memStore) CreateAuditEvent(_ context.Context, e *entity.AuditEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, e)
+	return nil
+}
+
+func (m *memStore) ListAuditEvents(context.Context, ListAuditEventsOpts) ([]*entity.AuditEvent, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.events, "", nil
+}
+
+func TestDiff(t *testing.T) {
+	before := &entity.Account{
+		ID:      "a1",
+		Name:    "Binance",
+		Data:    map[string]string{"exchange": "binance"},
+		Secrets: map[string]string{"api_key": "AK", "api_secret": "old"},
+	}
+	after := &entity.Account{
+		ID:      "a1",
+		Name:    "Binance main",
+		Data:    map[string]string{"exchange": "binance", "password": "hunter2"},
+		Secrets: map[string]string{"api_key": "AK", "api_secret": "new"},
+	}
+
+	changes := Diff(before, after)
+	assert.Equal(t, entity.AuditChange{Before: "Binance", After: "Binance main"}, changes["Name"])
+	assert.NotContains(t, changes, "ID")
+
+	// Changed secrets show up as changed, but never in clear.
+	require.Contains(t, changes, "Secrets")
+	assert.Equal(t, map[string]any{"api_key": secrets.Redacted, "api_secret": secrets.Redacted}, changes["Secrets"].Before)
+	assert.Equal(t, map[string]any{"api_key": secrets.Redacted, "api_secret": secrets.Redacted}, changes["Secrets"].After)
+	assert.Equal(t, map[string]any{"exchange": "binance", "password": secrets.Redacted}, changes["Data"].After)
+
+	created := Diff(nil, &entity.APIKey{ID: "k1", Name: "ci", Hash: []byte("hash")})
+	assert.Nil(t, created["Name"].Before)
+	assert.Equal(t, "ci", created["Name"].After)
+	assert.Equal(t, secrets.Redacted, created["Hash"].After)
+
+	deleted := Diff(&entity.Portfolio{ID: "p1", Name: "Main"}, nil)
+	assert.Equal(t, entity.AuditChange{Before: "Main"}, deleted["Name"])
+
+	assert.Empty(t, Diff(before, before))
+}
+
+func TestRecordChange(t *testing.T) {
+	st := &memStore{}
+
+	// Without a scope nothing is recorded.
+	RecordChange(context.Background(), "account", "a1", nil, &entity.Account{ID: "a1"})
+	assert.Empty(t, st.events)
+
+	ctx := WithSystemActor(context.Background(), st, slog.New(slog.DiscardHandler), ActorAutomation)
+	ctx = WithTask(ctx, "exec-1", "u1")
+	assert.True(t, Enabled(ctx))
+	RecordChange(ctx, "holding", "h1", nil, &entity.Holding{ID: "h1"})
+	RecordChange(ctx, "account", "a1", nil, &entity.Account{ID: "a1", UserID: "u2"})
+
+	require.Len(t, st.events, 2)
+	e := st.events[0]
+	assert.Equal(t, entity.ActorTypeSystem, e.ActorType)
+	assert.Equal(t, ActorAutomation, e.ActorID)
+	assert.Equal(t, "exec-1", e.RequestID)
+	assert.Equal(t, "u1", e.OwnerID)
+	assert.Equal(t, "holding", e.ResourceType)
+	assert.Equal(t, "u2", st.events[1].OwnerID, "the owner of the entity wins")
+}
+
+// portfolioService records a change for UpdateAccount only.
+type portfolioService struct {
+	apiv1connect.UnimplementedPortfolioServiceHandler
+}
+
+func (portfolioService) GetPortfolio(context.Context, *connect.Request[apiv1.GetPortfolioRequest]) (*connect.Response[apiv1.Portfolio], error) {
+	return connect.NewResponse(&apiv1.Portfolio{}), nil
+}
+
+func (portfolioService) UpdateAccount(ctx context.Context, req *connect.Request[apiv1.UpdateAccountRequest]) (*connect.Response[apiv1.Account], error) {
+	RecordChange(ctx, "account", req.Msg.Account.Id, &entity.Account{Name: "old"}, &entity.Account{Name: req.Msg.Account.Name})
+	return connect.NewResponse(req.Msg.Account), nil
+}
+
+func (portfolioService) DeletePortfolio(context.Context, *connect.Request[apiv1.DeletePortfolioRequest]) (*connect.Response[emptypb.Empty], error) {
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func TestInterceptor(t *testing.T) {
+	ctx := context.Background()
+	st := &memStore{}
+	asAlice := connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			return next(auth.WithPrincipal(ctx, &auth.Principal{UserID: "alice", CredentialID: "key-1"}), req)
+		}
+	})
+
+	proxies, err := auth.ParseProxies("127.0.0.1, 10.0.0.0/8")
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewPortfolioServiceHandler(portfolioService{},
+		connect.WithInterceptors(asAlice, NewInterceptor(st, proxies, slog.New(slog.DiscardHandler)))))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := apiv1connect.NewPortfolioServiceClient(srv.Client(), srv.URL)
+
+	// Reads are not audited.
+	_, err = client.GetPortfolio(ctx, connect.NewRequest(&apiv1.GetPortfolioRequest{Id: "p1"}))
+	require.NoError(t, err)
+	assert.Empty(t, st.events)
+
+	req := connect.NewRequest(&apiv1.UpdateAccountRequest{
+		Account:    &apiv1.Account{Id: "a1", Name: "new"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+	})
+	req.Header().Set(RequestIDHeader, "req-1")
+	req.Header().Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	resp, err := client.UpdateAccount(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "req-1", resp.Header().Get(RequestIDHeader))
+
+	require.Len(t, st.events, 1)
+	e := st.events[0]
+	assert.Equal(t, entity.ActorTypeUser, e.ActorType)
+	assert.Equal(t, "alice", e.ActorID)
+	assert.Equal(t, "key-1", e.CredentialID)
+	assert.Equal(t, apiv1connect.PortfolioServiceUpdateAccountProcedure, e.Procedure)
+	assert.Equal(t, "account", e.ResourceType)
+	assert.Equal(t, "a1", e.ResourceID)
+	assert.Equal(t, []string{"name"}, e.FieldPaths)
+	assert.Equal(t, entity.AuditChange{Before: "old", After: "new"}, e.Changes["Name"])
+	assert.Equal(t, "req-1", e.RequestID)
+	assert.Equal(t, "203.0.113.7", e.ClientIP)
+
+	// A call that records nothing still gets an event of its own.
+	resp2, err := client.DeletePortfolio(ctx, connect.NewRequest(&apiv1.DeletePortfolioRequest{Id: "p1"}))
+	require.NoError(t, err)
+	require.Len(t, st.events, 2)
+	e = st.events[1]
+	assert.Equal(t, "portfolio", e.ResourceType)
+	assert.Equal(t, "p1", e.ResourceID)
+	assert.Equal(t, "127.0.0.1", e.ClientIP)
+	assert.NotEmpty(t, e.RequestID)
+	assert.Equal(t, e.RequestID, resp2.Header().Get(RequestIDHeader))
+}
+
+func TestResourceType(t *testing.T) {
+	for procedure, want := range map[string]string{
+		"/greedy_eye.v1.PortfolioService/CreatePortfolio":      "portfolio",
+		"/greedy_eye.v1.AuthService/RevokeAPIKey":              "api_key",
+		"/greedy_eye.v1.AuthService/CreateSession":             "session",
+		"/greedy_eye.v1.AutomationService/CancelRuleExecution": "rule_execution",
+	} {
+		assert.Equal(t, want, resourceType(procedure), procedure)
+	}
+}
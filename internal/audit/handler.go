@@ -0,0 +1,110 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/api/v1/apiv1connect"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Handler implements apiv1connect.AuditServiceHandler.
+type Handler struct {
+	apiv1connect.UnimplementedAuditServiceHandler
+	store Store
+	log   *slog.Logger
+}
+
+func NewHandler(store Store, log *slog.Logger) *Handler {
+	return &Handler{store: store, log: log}
+}
+
+// ListAuditEvents lists audit events, newest first.
+func (h *Handler) ListAuditEvents(ctx context.Context, req *connect.Request[apiv1.ListAuditEventsRequest]) (*connect.Response[apiv1.ListAuditEventsResponse], error) {
+	opts := ListAuditEventsOpts{
+		ActorID:      req.Msg.GetActorId(),
+		ResourceType: req.Msg.GetResourceType(),
+		ResourceID:   req.Msg.GetResourceId(),
+		Procedure:    req.Msg.GetProcedure(),
+		PageSize:     int(req.Msg.GetPageSize()),
+		PageToken:    req.Msg.GetPageToken(),
+	}
+	if req.Msg.From != nil {
+		from := req.Msg.From.AsTime()
+		opts.From = &from
+	}
+	if req.Msg.To != nil {
+		to := req.Msg.To.AsTime()
+		opts.To = &to
+	}
+
+	events, nextPageToken, err := h.store.ListAuditEvents(ctx, opts)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	protoEvents := make([]*apiv1.AuditEvent, 0, len(events))
+	for _, e := range events {
+		pe, err := eventToProto(e)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		protoEvents = append(protoEvents, pe)
+	}
+
+	return connect.NewResponse(&apiv1.ListAuditEventsResponse{
+		AuditEvents:   protoEvents,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+func toConnectError(err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	if errors.Is(err, store.ErrInvalidArgument) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+func eventToProto(e *entity.AuditEvent) (*apiv1.AuditEvent, error) {
+	changes := make(map[string]any, len(e.Changes))
+	for name, c := range e.Changes {
+		change := map[string]any{}
+		if c.Before != nil {
+			change["before"] = c.Before
+		}
+		if c.After != nil {
+			change["after"] = c.After
+		}
+		changes[name] = change
+	}
+	changesStruct, err := structpb.NewStruct(changes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert changes of audit event %s: %w", e.ID, err)
+	}
+
+	return &apiv1.AuditEvent{
+		Id:           e.ID,
+		CreatedAt:    timestamppb.New(e.CreatedAt),
+		ActorType:    e.ActorType,
+		ActorId:      e.ActorID,
+		CredentialId: e.CredentialID,
+		OwnerId:      e.OwnerID,
+		Procedure:    e.Procedure,
+		ResourceType: e.ResourceType,
+		ResourceId:   e.ResourceID,
+		FieldPaths:   e.FieldPaths,
+		Changes:      changesStruct,
+		RequestId:    e.RequestID,
+		ClientIp:     e.ClientIP,
+	}, nil
+}
@@ -0,0 +1,162 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"unicode"
+
+	"connectrpc.com/connect"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// RequestIDHeader carries the request ID. A caller-supplied value is kept,
+// otherwise one is generated; either way it is echoed in the response.
+const RequestIDHeader = "X-Request-Id"
+
+// Interceptor opens an audit scope for every mutating unary call. Stores
+// record the changes they make through RecordChange; a successful call that
+// recorded nothing still gets one event of its own. It must run after the
+// auth interceptor, which attaches the principal.
+type Interceptor struct {
+	store   Store
+	proxies auth.Proxies
+	log     *slog.Logger
+}
+
+// Compile-time interface implementation check.
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+// NewInterceptor creates an audit interceptor. Client addresses are taken
+// from X-Forwarded-For only behind proxies.
+func NewInterceptor(store Store, proxies auth.Proxies, log *slog.Logger) *Interceptor {
+	return &Interceptor{store: store, proxies: proxies, log: log}
+}
+
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		procedure := req.Spec().Procedure
+		if req.Spec().IsClient || auth.IsReadOnly(procedure) {
+			return next(ctx, req)
+		}
+
+		requestID := req.Header().Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		s := &scope{store: i.store, log: i.log, base: entity.AuditEvent{
+			ActorType:  entity.ActorTypeUser,
+			Procedure:  procedure,
+			FieldPaths: updateMask(req.Any()),
+			RequestID:  requestID,
+			ClientIP:   i.proxies.ClientIP(req.Header(), req.Peer().Addr),
+		}}
+		if p, ok := auth.FromContext(ctx); ok {
+			s.base.ActorID = p.UserID
+			s.base.CredentialID = p.CredentialID
+			s.base.OwnerID = p.UserID
+		}
+
+		ctx = withScope(ctx, s)
+		resp, err := next(ctx, req)
+		if err != nil {
+			var connectErr *connect.Error
+			if errors.As(err, &connectErr) {
+				connectErr.Meta().Set(RequestIDHeader, requestID)
+			}
+			return nil, err
+		}
+		resp.Header().Set(RequestIDHeader, requestID)
+
+		if s.recorded == 0 {
+			e := s.base
+			e.ResourceType = resourceType(procedure)
+			e.ResourceID = messageID(req.Any())
+			if e.ResourceID == "" {
+				e.ResourceID = messageID(resp.Any())
+			}
+			s.write(ctx, &e)
+		}
+		return resp, nil
+	}
+}
+
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler passes streams through: every streaming procedure
+// only reads.
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// updateMask returns the paths of the message's update_mask field, if any.
+func updateMask(msg any) []string {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return nil
+	}
+	r := m.ProtoReflect()
+	fd := r.Descriptor().Fields().ByName("update_mask")
+	if fd == nil || fd.Kind() != protoreflect.MessageKind || !r.Has(fd) {
+		return nil
+	}
+	mask, ok := r.Get(fd).Message().Interface().(*fieldmaskpb.FieldMask)
+	if !ok {
+		return nil
+	}
+	return mask.GetPaths()
+}
+
+// messageID returns the message's string id field, if any.
+func messageID(msg any) string {
+	m, ok := msg.(proto.Message)
+	if !ok || m == nil {
+		return ""
+	}
+	r := m.ProtoReflect()
+	fd := r.Descriptor().Fields().ByName("id")
+	if fd == nil || fd.Kind() != protoreflect.StringKind {
+		return ""
+	}
+	return r.Get(fd).String()
+}
+
+// resourceType derives the resource from the method name by dropping the
+// leading verb, e.g. "RevokeAPIKey" becomes "api_key".
+func resourceType(procedure string) string {
+	method := []rune(procedure[strings.LastIndex(procedure, "/")+1:])
+	start := len(method)
+	for i := 1; i < len(method); i++ {
+		if unicode.IsUpper(method[i]) {
+			start = i
+			break
+		}
+	}
+	return snakeCase(method[start:])
+}
+
+func snakeCase(name []rune) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			// Break before a capital that starts a word: after a lower-case
+			// letter, or as the last capital of an acronym.
+			if i > 0 && (unicode.IsLower(name[i-1]) ||
+				(i+1 < len(name) && unicode.IsLower(name[i+1]) && unicode.IsUpper(name[i-1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+)
+
+// APIKeyPrefix starts every API key so callers and the interceptor can tell
+// keys from session tokens.
+const APIKeyPrefix = "eye_"
+
+// displayPrefixLen is how much of a key is stored in clear to identify it.
+const displayPrefixLen = len(APIKeyPrefix) + 8
+
+// GenerateAPIKey returns a new random key together with its display prefix
+// and the hash to store.
+func GenerateAPIKey() (key, prefix string, hash []byte) {
+	secret := make([]byte, 32)
+	// crypto/rand.Read never returns an error.
+	_, _ = rand.Read(secret)
+	key = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	return key, key[:displayPrefixLen], HashAPIKey(key)
+}
+
+// HashAPIKey hashes a key for lookup. Keys carry 256 bits of entropy, so a
+// plain SHA-256 digest is enough; a slow password hash would only add latency.
+func HashAPIKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
+
+// IsAPIKey reports whether a bearer credential looks like an API key.
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, APIKeyPrefix)
+}
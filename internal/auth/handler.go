@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/api/v1/apiv1connect"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Handler implements apiv1connect.AuthServiceHandler.
+type Handler struct {
+	apiv1connect.UnimplementedAuthServiceHandler
+	store Store
+	keys  *KeyRing
+	log   *slog.Logger
+}
+
+func NewHandler(store Store, keys *KeyRing, log *slog.Logger) *Handler {
+	return &Handler{store: store, keys: keys, log: log}
+}
+
+func (h *Handler) CreateSession(ctx context.Context, req *connect.Request[apiv1.CreateSessionRequest]) (*connect.Response[apiv1.Session], error) {
+	p, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p.Method != MethodAPIKey {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("sessions can only be created with an API key"))
+	}
+
+	scopes := p.Scopes
+	if len(req.Msg.Scopes) > 0 {
+		if err := checkScopes(req.Msg.Scopes, p); err != nil {
+			return nil, err
+		}
+		scopes = req.Msg.Scopes
+	}
+	var ttl time.Duration
+	if req.Msg.Ttl != nil {
+		ttl = req.Msg.Ttl.AsDuration()
+	}
+
+	session := &Principal{UserID: p.UserID, Scopes: scopes, Method: MethodSession, CredentialID: p.CredentialID}
+	token, expires, err := h.keys.Issue(ctx, session, ttl)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	return connect.NewResponse(&apiv1.Session{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresAt:   timestamppb.New(expires),
+		Scopes:      scopes,
+	}), nil
+}
+
+func (h *Handler) CreateAPIKey(ctx context.Context, req *connect.Request[apiv1.CreateAPIKeyRequest]) (*connect.Response[apiv1.CreateAPIKeyResponse], error) {
+	p, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.Name == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("name is required"))
+	}
+	if len(req.Msg.Scopes) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one scope is required"))
+	}
+	if err := checkScopes(req.Msg.Scopes, p); err != nil {
+		return nil, err
+	}
+
+	key, prefix, hash := GenerateAPIKey()
+	k := &entity.APIKey{
+		UserID: p.UserID,
+		Name:   req.Msg.Name,
+		Prefix: prefix,
+		Hash:   hash,
+		Scopes: req.Msg.Scopes,
+	}
+	if req.Msg.ExpiresAt != nil {
+		expires := req.Msg.ExpiresAt.AsTime()
+		if !expires.After(time.Now()) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("expires_at must be in the future"))
+		}
+		k.ExpiresAt = &expires
+	}
+
+	created, err := h.store.CreateAPIKey(ctx, k)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	h.log.Info("API key created", slog.String("user_id", p.UserID), slog.String("key_id", created.ID))
+
+	return connect.NewResponse(&apiv1.CreateAPIKeyResponse{
+		ApiKey: apiKeyToProto(created),
+		Key:    key,
+	}), nil
+}
+
+func (h *Handler) ListAPIKeys(ctx context.Context, req *connect.Request[apiv1.ListAPIKeysRequest]) (*connect.Response[apiv1.ListAPIKeysResponse], error) {
+	p, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := h.store.ListAPIKeys(ctx, p.UserID)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	protoKeys := make([]*apiv1.APIKey, 0, len(keys))
+	for _, k := range keys {
+		protoKeys = append(protoKeys, apiKeyToProto(k))
+	}
+
+	return connect.NewResponse(&apiv1.ListAPIKeysResponse{ApiKeys: protoKeys}), nil
+}
+
+func (h *Handler) RevokeAPIKey(ctx context.Context, req *connect.Request[apiv1.RevokeAPIKeyRequest]) (*connect.Response[emptypb.Empty], error) {
+	p, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("API key ID is required"))
+	}
+
+	if err := h.store.DeleteAPIKey(ctx, p.UserID, req.Msg.Id); err != nil {
+		return nil, toConnectError(err)
+	}
+	h.log.Info("API key revoked", slog.String("user_id", p.UserID), slog.String("key_id", req.Msg.Id))
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func principal(ctx context.Context) (*Principal, error) {
+	p, ok := FromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("not authenticated"))
+	}
+	return p, nil
+}
+
+// checkScopes rejects unknown scopes and scopes the caller does not hold,
+// so credentials can only be narrowed, never widened.
+func checkScopes(scopes []string, p *Principal) error {
+	for _, s := range scopes {
+		if !ValidScope(s) {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown scope %q", s))
+		}
+		if !slices.Contains(p.Scopes, s) {
+			return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("scope %q exceeds the caller's scopes", s))
+		}
+	}
+	return nil
+}
+
+// --- Converters ---
+
+func toConnectError(err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	if errors.Is(err, store.ErrInvalidArgument) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if errors.Is(err, store.ErrConstraint) {
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+func apiKeyToProto(k *entity.APIKey) *apiv1.APIKey {
+	result := &apiv1.APIKey{
+		Id:        k.ID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes,
+		CreatedAt: timestamppb.New(k.CreatedAt),
+	}
+	if k.ExpiresAt != nil {
+		result.ExpiresAt = timestamppb.New(*k.ExpiresAt)
+	}
+	if k.LastUsedAt != nil {
+		result.LastUsedAt = timestamppb.New(*k.LastUsedAt)
+	}
+	return result
+}
@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/foxcool/greedy-eye/internal/store"
+)
+
+// lastUsedResolution is how stale an API key's last-used time may get
+// before a request updates it, to avoid a write per call.
+const lastUsedResolution = time.Minute
+
+// Authenticator resolves bearer credentials to principals.
+type Authenticator struct {
+	store Store
+	keys  *KeyRing
+	log   *slog.Logger
+	now   func() time.Time
+}
+
+func NewAuthenticator(store Store, keys *KeyRing, log *slog.Logger) *Authenticator {
+	return &Authenticator{store: store, keys: keys, log: log, now: time.Now}
+}
+
+// Authenticate checks the Authorization header. It accepts "Bearer <key>"
+// for API keys and "Bearer <token>" for session tokens.
+func (a *Authenticator) Authenticate(ctx context.Context, header http.Header) (*Principal, error) {
+	scheme, credential, _ := strings.Cut(header.Get("Authorization"), " ")
+	if !strings.EqualFold(scheme, "Bearer") || credential == "" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing bearer credentials"))
+	}
+
+	if IsAPIKey(credential) {
+		return a.authenticateAPIKey(ctx, credential)
+	}
+
+	p, err := a.keys.Verify(ctx, credential)
+	if err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			return nil, connect.NewError(connect.CodeUnauthenticated, err)
+		}
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("verify session token: %w", err))
+	}
+	return p, nil
+}
+
+func (a *Authenticator) authenticateAPIKey(ctx context.Context, key string) (*Principal, error) {
+	k, err := a.store.GetAPIKeyByHash(ctx, HashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid API key"))
+		}
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("look up API key: %w", err))
+	}
+
+	now := a.now()
+	if k.ExpiresAt != nil && !now.Before(*k.ExpiresAt) {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("API key expired"))
+	}
+	if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= lastUsedResolution {
+		if err := a.store.TouchAPIKey(ctx, k.ID, now); err != nil {
+			a.log.Warn("Failed to record API key use", slog.String("key_id", k.ID), slog.Any("error", err))
+		}
+	}
+
+	return &Principal{
+		UserID:       k.UserID,
+		Scopes:       k.Scopes,
+		Method:       MethodAPIKey,
+		CredentialID: k.ID,
+	}, nil
+}
+
+// Interceptor authenticates every Connect call, rejects callers whose scopes
+// do not cover the procedure and attaches the principal to the context.
+// Plain HTTP routes such as /health are not Connect procedures and stay open.
+type Interceptor struct {
+	auth *Authenticator
+}
+
+// Compile-time interface implementation check.
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+func NewInterceptor(auth *Authenticator) *Interceptor {
+	return &Interceptor{auth: auth}
+}
+
+func (i *Interceptor) authorize(ctx context.Context, procedure string, header http.Header) (context.Context, error) {
+	p, err := i.auth.Authenticate(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+	if !p.CanCall(procedure) {
+		return nil, connect.NewError(connect.CodePermissionDenied,
+			fmt.Errorf("scopes %q do not permit %s", p.Scopes, procedure))
+	}
+	return WithPrincipal(ctx, p), nil
+}
+
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		ctx, err := i.authorize(ctx, req.Spec().Procedure, req.Header())
+		if err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := i.authorize(ctx, conn.Spec().Procedure, conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// Wrap applies the interceptor to a plain HTTP route serving the same
+// content as procedure, such as a file download. Errors are written in the
+// Connect JSON format.
+func (i *Interceptor) Wrap(procedure string, next http.Handler) http.Handler {
+	errWriter := connect.NewErrorWriter()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := i.authorize(r.Context(), procedure, r.Header)
+		if err != nil {
+			_ = errWriter.Write(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
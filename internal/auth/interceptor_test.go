@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/api/v1/apiv1connect"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrincipal_CanCall(t *testing.T) {
+	reader := &Principal{Scopes: []string{ScopeRead}}
+	assert.True(t, reader.CanCall("/greedy_eye.v1.PortfolioService/ListPortfolios"))
+	assert.True(t, reader.CanCall("/greedy_eye.v1.MarketDataService/WatchPrices"))
+	assert.True(t, reader.CanCall("/greedy_eye.v1.PortfolioService/ExportPortfolio"))
+	assert.True(t, reader.CanCall("/greedy_eye.v1.PortfolioService/GenerateTaxReport"))
+	assert.True(t, reader.CanCall("/greedy_eye.v1.MarketDataService/FindSimilarAssets"))
+	assert.True(t, reader.CanCall("/greedy_eye.v1.MarketDataService/ResolveAsset"))
+	assert.False(t, reader.CanCall("/greedy_eye.v1.MarketDataService/CreateAssetIdentifier"))
+	assert.False(t, reader.CanCall("/greedy_eye.v1.PortfolioService/CreatePortfolio"))
+
+	writer := &Principal{Scopes: []string{ScopeWrite}}
+	assert.True(t, writer.CanCall("/greedy_eye.v1.PortfolioService/CreatePortfolio"))
+	assert.False(t, (&Principal{}).CanCall("/greedy_eye.v1.PortfolioService/GetPortfolio"))
+}
+
+func bearer[T any](msg *T, credential string) *connect.Request[T] {
+	req := connect.NewRequest(msg)
+	if credential != "" {
+		req.Header().Set("Authorization", "Bearer "+credential)
+	}
+	return req
+}
+
+func TestInterceptor(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.DiscardHandler)
+	st := &memStore{}
+	keys := NewKeyRing(st, KeyRingConfig{}, log)
+	require.NoError(t, keys.Refresh(ctx))
+
+	addKey := func(userID string, scopes []string, expires *time.Time) string {
+		key, prefix, hash := GenerateAPIKey()
+		_, err := st.CreateAPIKey(ctx, &entity.APIKey{UserID: userID, Name: "test", Prefix: prefix, Hash: hash, Scopes: scopes, ExpiresAt: expires})
+		require.NoError(t, err)
+		return key
+	}
+	writeKey := addKey("u1", []string{ScopeRead, ScopeWrite}, nil)
+	readKey := addKey("u1", []string{ScopeRead}, nil)
+	expired := time.Now().Add(-time.Minute)
+	expiredKey := addKey("u1", []string{ScopeRead, ScopeWrite}, &expired)
+
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewAuthServiceHandler(
+		NewHandler(st, keys, log),
+		connect.WithInterceptors(NewInterceptor(NewAuthenticator(st, keys, log))),
+	))
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := apiv1connect.NewAuthServiceClient(srv.Client(), srv.URL)
+
+	for name, tc := range map[string]struct {
+		credential string
+		code       connect.Code
+	}{
+		"missing":   {"", connect.CodeUnauthenticated},
+		"unknown":   {APIKeyPrefix + "nope", connect.CodeUnauthenticated},
+		"expired":   {expiredKey, connect.CodeUnauthenticated},
+		"bad token": {"a.b.c", connect.CodeUnauthenticated},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.ListAPIKeys(ctx, bearer(&apiv1.ListAPIKeysRequest{}, tc.credential))
+			assert.Equal(t, tc.code, connect.CodeOf(err))
+		})
+	}
+
+	resp, err := http.Get(srv.URL + "/health")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// A read-only key can list but not create keys.
+	_, err = client.ListAPIKeys(ctx, bearer(&apiv1.ListAPIKeysRequest{}, readKey))
+	require.NoError(t, err)
+	_, err = client.CreateAPIKey(ctx, bearer(&apiv1.CreateAPIKeyRequest{Name: "x", Scopes: []string{ScopeRead}}, readKey))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// Exchange a key for a narrower session and use it.
+	session, err := client.CreateSession(ctx, bearer(&apiv1.CreateSessionRequest{Scopes: []string{ScopeRead}}, writeKey))
+	require.NoError(t, err)
+	assert.Equal(t, []string{ScopeRead}, session.Msg.Scopes)
+	list, err := client.ListAPIKeys(ctx, bearer(&apiv1.ListAPIKeysRequest{}, session.Msg.AccessToken))
+	require.NoError(t, err)
+	assert.Len(t, list.Msg.ApiKeys, 3)
+	assert.NotNil(t, list.Msg.ApiKeys[0].LastUsedAt, "use of the write key is recorded")
+
+	_, err = client.RevokeAPIKey(ctx, bearer(&apiv1.RevokeAPIKeyRequest{Id: list.Msg.ApiKeys[1].Id}, session.Msg.AccessToken))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// Keys cannot grant more than the caller holds, and sessions cannot mint sessions.
+	_, err = client.CreateSession(ctx, bearer(&apiv1.CreateSessionRequest{}, session.Msg.AccessToken))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	_, err = client.CreateSession(ctx, bearer(&apiv1.CreateSessionRequest{Scopes: []string{ScopeWrite}}, readKey))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// Another user's key is not found.
+	other := addKey("u2", []string{ScopeRead, ScopeWrite}, nil)
+	_, err = client.RevokeAPIKey(ctx, bearer(&apiv1.RevokeAPIKeyRequest{Id: list.Msg.ApiKeys[1].Id}, other))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestProxies_ClientIP(t *testing.T) {
+	proxies, err := ParseProxies("10.0.0.0/8, 192.0.2.1, ::1")
+	require.NoError(t, err)
+	_, err = ParseProxies("10.0.0.0/33")
+	assert.Error(t, err)
+
+	forwarded := func(values ...string) http.Header {
+		h := http.Header{}
+		for _, v := range values {
+			h.Add("X-Forwarded-For", v)
+		}
+		return h
+	}
+	for _, tc := range []struct {
+		name    string
+		proxies Proxies
+		header  http.Header
+		peer    string
+		want    string
+	}{
+		{"no proxies ignore the header", nil, forwarded("203.0.113.7"), "198.51.100.1:4000", "198.51.100.1"},
+		{"untrusted peer ignores the header", proxies, forwarded("203.0.113.7"), "198.51.100.1:4000", "198.51.100.1"},
+		{"trusted peer", proxies, forwarded("203.0.113.7"), "192.0.2.1:4000", "203.0.113.7"},
+		{"spoofed hops left of the client", proxies, forwarded("1.2.3.4, 203.0.113.7, 10.0.0.2"), "10.0.0.1:4000", "203.0.113.7"},
+		{"several headers", proxies, forwarded("1.2.3.4", "203.0.113.7", "10.0.0.2"), "[::1]:4000", "203.0.113.7"},
+		{"only proxies", proxies, forwarded("10.0.0.3, 10.0.0.2"), "10.0.0.1:4000", "10.0.0.3"},
+		{"no header", proxies, http.Header{}, "10.0.0.1:4000", "10.0.0.1"},
+		{"peer without port", nil, http.Header{}, "198.51.100.1", "198.51.100.1"},
+	} {
+		assert.Equal(t, tc.want, tc.proxies.ClientIP(tc.header, tc.peer), tc.name)
+	}
+}
@@ -0,0 +1,78 @@
+// Package auth authenticates API callers with API keys and session tokens
+// and carries the resulting principal through the request context.
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// Scopes granted to credentials.
+const (
+	// ScopeRead permits procedures that only read, as classified in procedures.
+	ScopeRead = "read"
+	// ScopeWrite permits every method.
+	ScopeWrite = "write"
+	// ScopeAdmin permits every method on every user's data.
+	ScopeAdmin = "admin"
+)
+
+// Authentication methods recorded on a principal.
+const (
+	MethodAPIKey  = "api_key"
+	MethodSession = "session"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	UserID string
+	Scopes []string
+	Method string
+	// CredentialID is the API key the caller authenticated with, directly or
+	// through the session it was exchanged for.
+	CredentialID string
+}
+
+// HasScope reports whether the principal was granted scope.
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// CanCall reports whether the principal's scopes permit the Connect procedure,
+// e.g. "/greedy_eye.v1.PortfolioService/ListPortfolios".
+func (p *Principal) CanCall(procedure string) bool {
+	if p.HasScope(ScopeWrite) || p.HasScope(ScopeAdmin) {
+		return true
+	}
+	return IsReadOnly(procedure) && p.HasScope(ScopeRead)
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying p.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal attached by the interceptor, if any.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// ValidScope reports whether scope is one this package knows.
+func ValidScope(scope string) bool {
+	return scope == ScopeRead || scope == ScopeWrite || scope == ScopeAdmin
+}
+
+// OwnerID returns userID, defaulting to the caller's user when it is empty,
+// so clients need not repeat who they are when creating their own records.
+func OwnerID(ctx context.Context, userID string) string {
+	if userID != "" {
+		return userID
+	}
+	if p, ok := FromContext(ctx); ok {
+		return p.UserID
+	}
+	return ""
+}
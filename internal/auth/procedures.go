@@ -0,0 +1,139 @@
+package auth
+
+import "github.com/foxcool/greedy-eye/internal/api/v1/apiv1connect"
+
+// Procedure describes what a Connect procedure does, which decides the scope
+// it needs, how it is rate limited and whether it is audited.
+type Procedure struct {
+	// ReadOnly procedures change nothing: the read scope permits them and
+	// they are not audited.
+	ReadOnly bool
+	// Expensive procedures fetch from external providers or compute over
+	// whole portfolios and histories, and share a stricter rate limit.
+	Expensive bool
+}
+
+var (
+	reads          = Procedure{ReadOnly: true}
+	writes         = Procedure{}
+	expensiveRead  = Procedure{ReadOnly: true, Expensive: true}
+	expensiveWrite = Procedure{Expensive: true}
+)
+
+// procedures classifies every procedure the API serves. A procedure missing
+// from it is treated as a cheap write, so it needs the write scope.
+var procedures = map[string]Procedure{
+	apiv1connect.AuditServiceListAuditEventsProcedure: reads,
+
+	apiv1connect.AuthServiceCreateSessionProcedure: writes,
+	apiv1connect.AuthServiceCreateAPIKeyProcedure:  writes,
+	apiv1connect.AuthServiceListAPIKeysProcedure:   reads,
+	apiv1connect.AuthServiceRevokeAPIKeyProcedure:  writes,
+
+	apiv1connect.AutomationServiceCreateRuleProcedure:          writes,
+	apiv1connect.AutomationServiceGetRuleProcedure:             reads,
+	apiv1connect.AutomationServiceUpdateRuleProcedure:          writes,
+	apiv1connect.AutomationServiceDeleteRuleProcedure:          writes,
+	apiv1connect.AutomationServiceListRulesProcedure:           reads,
+	apiv1connect.AutomationServiceExecuteRuleProcedure:         expensiveWrite,
+	apiv1connect.AutomationServiceExecuteRuleAsyncProcedure:    writes,
+	apiv1connect.AutomationServiceCancelRuleExecutionProcedure: writes,
+	apiv1connect.AutomationServiceValidateRuleProcedure:        reads,
+	apiv1connect.AutomationServiceListRuleTypesProcedure:       reads,
+	apiv1connect.AutomationServiceBacktestRuleProcedure:        expensiveRead,
+	apiv1connect.AutomationServiceSimulateRuleProcedure:        expensiveRead,
+	apiv1connect.AutomationServiceEnableRuleProcedure:          writes,
+	apiv1connect.AutomationServiceDisableRuleProcedure:         writes,
+	apiv1connect.AutomationServicePauseRuleProcedure:           writes,
+	apiv1connect.AutomationServiceResumeRuleProcedure:          writes,
+	apiv1connect.AutomationServiceCreateRuleExecutionProcedure: writes,
+	apiv1connect.AutomationServiceGetRuleExecutionProcedure:    reads,
+	apiv1connect.AutomationServiceUpdateRuleExecutionProcedure: writes,
+	apiv1connect.AutomationServiceListRuleExecutionsProcedure:  reads,
+	apiv1connect.AutomationServiceWatchRuleExecutionProcedure:  reads,
+
+	apiv1connect.MarketDataServiceCreateAssetProcedure:           writes,
+	apiv1connect.MarketDataServiceGetAssetProcedure:              reads,
+	apiv1connect.MarketDataServiceUpdateAssetProcedure:           writes,
+	apiv1connect.MarketDataServiceDeleteAssetProcedure:           writes,
+	apiv1connect.MarketDataServiceListAssetsProcedure:            reads,
+	apiv1connect.MarketDataServiceSearchAssetsProcedure:          reads,
+	apiv1connect.MarketDataServiceImportExternalAssetProcedure:   expensiveWrite,
+	apiv1connect.MarketDataServiceEnrichAssetDataProcedure:       expensiveWrite,
+	apiv1connect.MarketDataServiceFindSimilarAssetsProcedure:     expensiveRead,
+	apiv1connect.MarketDataServiceGetBondAnalyticsProcedure:      reads,
+	apiv1connect.MarketDataServiceCreateAssetIdentifierProcedure: writes,
+	apiv1connect.MarketDataServiceDeleteAssetIdentifierProcedure: writes,
+	apiv1connect.MarketDataServiceListAssetIdentifiersProcedure:  reads,
+	apiv1connect.MarketDataServiceResolveAssetProcedure:          reads,
+	apiv1connect.MarketDataServiceCreateCorporateActionProcedure: writes,
+	apiv1connect.MarketDataServiceGetCorporateActionProcedure:    reads,
+	apiv1connect.MarketDataServiceListCorporateActionsProcedure:  reads,
+	apiv1connect.MarketDataServiceDeleteCorporateActionProcedure: writes,
+	apiv1connect.MarketDataServiceApplyCorporateActionProcedure:  expensiveWrite,
+	apiv1connect.MarketDataServiceRevertCorporateActionProcedure: expensiveWrite,
+	apiv1connect.MarketDataServiceCreatePriceProcedure:           writes,
+	apiv1connect.MarketDataServiceCreatePricesProcedure:          writes,
+	apiv1connect.MarketDataServiceGetLatestPriceProcedure:        reads,
+	apiv1connect.MarketDataServiceListPriceHistoryProcedure:      reads,
+	apiv1connect.MarketDataServiceListPricesByIntervalProcedure:  reads,
+	apiv1connect.MarketDataServiceDeletePriceProcedure:           writes,
+	apiv1connect.MarketDataServiceDeletePricesProcedure:          writes,
+	apiv1connect.MarketDataServiceWatchPricesProcedure:           reads,
+	apiv1connect.MarketDataServiceFetchExternalPricesProcedure:   expensiveWrite,
+	apiv1connect.MarketDataServiceGetPriceDataQualityProcedure:   expensiveRead,
+
+	apiv1connect.PortfolioServiceCreatePortfolioProcedure:           writes,
+	apiv1connect.PortfolioServiceGetPortfolioProcedure:              reads,
+	apiv1connect.PortfolioServiceUpdatePortfolioProcedure:           writes,
+	apiv1connect.PortfolioServiceDeletePortfolioProcedure:           writes,
+	apiv1connect.PortfolioServiceListPortfoliosProcedure:            reads,
+	apiv1connect.PortfolioServiceInvitePortfolioMemberProcedure:     writes,
+	apiv1connect.PortfolioServiceAcceptPortfolioInvitationProcedure: writes,
+	apiv1connect.PortfolioServiceRevokePortfolioMemberProcedure:     writes,
+	apiv1connect.PortfolioServiceListPortfolioMembersProcedure:      reads,
+	apiv1connect.PortfolioServiceCalculatePortfolioValueProcedure:   expensiveRead,
+	apiv1connect.PortfolioServiceGetPortfolioPerformanceProcedure:   expensiveRead,
+	apiv1connect.PortfolioServiceGetIncomeSummaryProcedure:          expensiveRead,
+	apiv1connect.PortfolioServiceCreateHoldingProcedure:             writes,
+	apiv1connect.PortfolioServiceGetHoldingProcedure:                reads,
+	apiv1connect.PortfolioServiceUpdateHoldingProcedure:             writes,
+	apiv1connect.PortfolioServiceListHoldingsProcedure:              reads,
+	apiv1connect.PortfolioServiceCreateLotProcedure:                 writes,
+	apiv1connect.PortfolioServiceGetLotProcedure:                    reads,
+	apiv1connect.PortfolioServiceUpdateLotProcedure:                 writes,
+	apiv1connect.PortfolioServiceListLotsProcedure:                  reads,
+	apiv1connect.PortfolioServiceCreateAccountProcedure:             writes,
+	apiv1connect.PortfolioServiceGetAccountProcedure:                reads,
+	apiv1connect.PortfolioServiceUpdateAccountProcedure:             writes,
+	apiv1connect.PortfolioServiceDeleteAccountProcedure:             writes,
+	apiv1connect.PortfolioServiceListAccountsProcedure:              reads,
+	apiv1connect.PortfolioServiceCreateTransactionProcedure:         writes,
+	apiv1connect.PortfolioServiceGetTransactionProcedure:            reads,
+	apiv1connect.PortfolioServiceUpdateTransactionProcedure:         writes,
+	apiv1connect.PortfolioServiceListTransactionsProcedure:          reads,
+	apiv1connect.PortfolioServiceImportTransactionsProcedure:        writes,
+	apiv1connect.PortfolioServiceExportPortfolioProcedure:           expensiveRead,
+	apiv1connect.PortfolioServiceGenerateTaxReportProcedure:         expensiveRead,
+
+	apiv1connect.SettingsServiceCreateUserProcedure:            writes,
+	apiv1connect.SettingsServiceGetUserProcedure:               reads,
+	apiv1connect.SettingsServiceGetUserByEmailProcedure:        reads,
+	apiv1connect.SettingsServiceUpdateUserProcedure:            writes,
+	apiv1connect.SettingsServiceDeleteUserProcedure:            writes,
+	apiv1connect.SettingsServiceListUsersProcedure:             reads,
+	apiv1connect.SettingsServiceUpdateUserPreferencesProcedure: writes,
+}
+
+// Describe returns what the Connect procedure does, e.g.
+// "/greedy_eye.v1.PortfolioService/ListPortfolios", and whether it is one
+// the API serves.
+func Describe(procedure string) (Procedure, bool) {
+	p, ok := procedures[procedure]
+	return p, ok
+}
+
+// IsReadOnly reports whether the Connect procedure only reads.
+func IsReadOnly(procedure string) bool {
+	return procedures[procedure].ReadOnly
+}
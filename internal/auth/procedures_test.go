@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"testing"
+
+	_ "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestProcedures_Complete(t *testing.T) {
+	served := map[string]bool{}
+	protoregistry.GlobalFiles.RangeFilesByPackage("greedy_eye.v1", func(file protoreflect.FileDescriptor) bool {
+		for i := range file.Services().Len() {
+			service := file.Services().Get(i)
+			for j := range service.Methods().Len() {
+				served["/"+string(service.FullName())+"/"+string(service.Methods().Get(j).Name())] = true
+			}
+		}
+		return true
+	})
+	assert.NotEmpty(t, served)
+
+	for procedure := range served {
+		_, ok := Describe(procedure)
+		assert.True(t, ok, "%s is not classified", procedure)
+	}
+	for procedure := range procedures {
+		assert.True(t, served[procedure], "%s is classified but not served", procedure)
+	}
+}
+
+func TestIsReadOnly(t *testing.T) {
+	assert.True(t, IsReadOnly("/greedy_eye.v1.PortfolioService/CalculatePortfolioValue"))
+	assert.True(t, IsReadOnly("/greedy_eye.v1.AutomationService/SimulateRule"))
+	assert.False(t, IsReadOnly("/greedy_eye.v1.PortfolioService/ImportTransactions"))
+	assert.False(t, IsReadOnly("/greedy_eye.v1.PortfolioService/GetUnknown"), "unclassified procedures need the write scope")
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// Proxies are the reverse proxies trusted to report the client address in
+// X-Forwarded-For. Without any, the header is ignored.
+type Proxies []netip.Prefix
+
+// ParseProxies parses comma-separated addresses and CIDR ranges.
+func ParseProxies(s string) (Proxies, error) {
+	var proxies Proxies
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !strings.Contains(field, "/") {
+			addr, err := netip.ParseAddr(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", field, err)
+			}
+			proxies = append(proxies, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", field, err)
+		}
+		proxies = append(proxies, prefix.Masked())
+	}
+	return proxies, nil
+}
+
+func (p Proxies) trusts(addr string) bool {
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return false
+	}
+	ip = ip.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the address a request came from: the connection's peer,
+// or, when the peer is a trusted proxy, the right-most X-Forwarded-For hop
+// that is not one. Hops further left are set by the client and cannot be
+// trusted.
+func (p Proxies) ClientIP(header http.Header, peerAddr string) string {
+	ip := peerAddr
+	if host, _, err := net.SplitHostPort(peerAddr); err == nil {
+		ip = host
+	}
+	if !p.trusts(ip) {
+		return ip
+	}
+
+	var hops []string
+	for _, value := range header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		ip = hop
+		if !p.trusts(hop) {
+			break
+		}
+	}
+	return ip
+}
@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+)
+
+// Store defines the data access contract for API keys and signing keys.
+type Store interface {
+	CreateAPIKey(ctx context.Context, k *entity.APIKey) (*entity.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, hash []byte) (*entity.APIKey, error)
+	ListAPIKeys(ctx context.Context, userID string) ([]*entity.APIKey, error)
+	// DeleteAPIKey removes a key owned by userID.
+	DeleteAPIKey(ctx context.Context, userID, id string) error
+	TouchAPIKey(ctx context.Context, id string, at time.Time) error
+
+	CreateSigningKey(ctx context.Context, k *entity.SigningKey) error
+	ListSigningKeys(ctx context.Context) ([]*entity.SigningKey, error)
+	DeleteSigningKeysExpiredBefore(ctx context.Context, t time.Time) error
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+)
+
+// memStore is an in-memory Store for tests.
+type memStore struct {
+	mu          sync.Mutex
+	apiKeys     []*entity.APIKey
+	signingKeys []*entity.SigningKey
+	nextID      int
+}
+
+func (m *memStore) CreateAPIKey(_ context.Context, k *entity.APIKey) (*entity.APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	k.ID = fmt.Sprintf("key-%d", m.nextID)
+	k.CreatedAt = time.Now()
+	m.apiKeys = append(m.apiKeys, k)
+	return k, nil
+}
+
+func (m *memStore) GetAPIKeyByHash(_ context.Context, hash []byte) (*entity.APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, k := range m.apiKeys {
+		if bytes.Equal(k.Hash, hash) {
+			c := *k
+			return &c, nil
+		}
+	}
+	return nil, store.ErrNotFound
+}
+
+func (m *memStore) ListAPIKeys(_ context.Context, userID string) ([]*entity.APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []*entity.APIKey
+	for _, k := range m.apiKeys {
+		if k.UserID == userID {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+func (m *memStore) DeleteAPIKey(_ context.Context, userID, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, k := range m.apiKeys {
+		if k.ID == id && k.UserID == userID {
+			m.apiKeys = append(m.apiKeys[:i], m.apiKeys[i+1:]...)
+			return nil
+		}
+	}
+	return store.ErrNotFound
+}
+
+func (m *memStore) TouchAPIKey(_ context.Context, id string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, k := range m.apiKeys {
+		if k.ID == id {
+			k.LastUsedAt = &at
+		}
+	}
+	return nil
+}
+
+func (m *memStore) CreateSigningKey(_ context.Context, k *entity.SigningKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.signingKeys = append(m.signingKeys, k)
+	return nil
+}
+
+func (m *memStore) ListSigningKeys(context.Context) ([]*entity.SigningKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*entity.SigningKey(nil), m.signingKeys...), nil
+}
+
+func (m *memStore) DeleteSigningKeysExpiredBefore(_ context.Context, t time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kept := m.signingKeys[:0]
+	for _, k := range m.signingKeys {
+		if !k.ExpiresAt.Before(t) {
+			kept = append(kept, k)
+		}
+	}
+	m.signingKeys = kept
+	return nil
+}
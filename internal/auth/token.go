@@ -0,0 +1,296 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+)
+
+// ErrInvalidToken is returned for session tokens that are malformed, signed
+// by an unknown key, expired or otherwise unacceptable.
+var ErrInvalidToken = errors.New("invalid session token")
+
+const tokenIssuer = "greedy-eye"
+
+// missRefreshInterval limits store reloads triggered by tokens signed with
+// a key this replica has not seen yet.
+const missRefreshInterval = 10 * time.Second
+
+// KeyRingConfig configures session token signing.
+type KeyRingConfig struct {
+	// RotateEvery is how long a key signs new tokens before it is replaced.
+	RotateEvery time.Duration
+	// TokenTTL is the longest lifetime a session token may have.
+	TokenTTL time.Duration
+}
+
+func (c KeyRingConfig) withDefaults() KeyRingConfig {
+	if c.RotateEvery <= 0 {
+		c.RotateEvery = 24 * time.Hour
+	}
+	if c.TokenTTL <= 0 {
+		c.TokenTTL = time.Hour
+	}
+	return c
+}
+
+// KeyRing issues and verifies session tokens: JWTs signed with Ed25519
+// (alg EdDSA). Keys live in the store so every replica shares them. The
+// newest key signs; older keys keep verifying until the tokens they signed
+// have expired.
+type KeyRing struct {
+	store Store
+	cfg   KeyRingConfig
+	log   *slog.Logger
+	now   func() time.Time
+
+	mu       sync.RWMutex
+	signing  *entity.SigningKey
+	verify   map[string]ed25519.PublicKey
+	lastMiss time.Time
+}
+
+func NewKeyRing(store Store, cfg KeyRingConfig, log *slog.Logger) *KeyRing {
+	return &KeyRing{
+		store:  store,
+		cfg:    cfg.withDefaults(),
+		log:    log,
+		now:    time.Now,
+		verify: map[string]ed25519.PublicKey{},
+	}
+}
+
+// TokenTTL returns the longest lifetime Issue grants.
+func (r *KeyRing) TokenTTL() time.Duration {
+	return r.cfg.TokenTTL
+}
+
+// Refresh reloads keys from the store, creating a key when none is young
+// enough to sign, and deletes keys nothing valid can be signed with anymore.
+func (r *KeyRing) Refresh(ctx context.Context) error {
+	now := r.now()
+	if err := r.store.DeleteSigningKeysExpiredBefore(ctx, now); err != nil {
+		return fmt.Errorf("delete expired signing keys: %w", err)
+	}
+	keys, err := r.store.ListSigningKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("list signing keys: %w", err)
+	}
+
+	var newest *entity.SigningKey
+	for _, k := range keys {
+		if newest == nil || k.CreatedAt.After(newest.CreatedAt) {
+			newest = k
+		}
+	}
+	if newest == nil || now.Sub(newest.CreatedAt) >= r.cfg.RotateEvery {
+		if newest, err = r.createKey(ctx, now); err != nil {
+			return err
+		}
+		keys = append(keys, newest)
+		r.log.Info("Rotated session signing key", slog.String("kid", newest.ID))
+	}
+
+	verify := make(map[string]ed25519.PublicKey, len(keys))
+	for _, k := range keys {
+		if len(k.PrivateKey) != ed25519.SeedSize {
+			r.log.Warn("Skipping malformed signing key", slog.String("kid", k.ID))
+			continue
+		}
+		verify[k.ID] = ed25519.NewKeyFromSeed(k.PrivateKey).Public().(ed25519.PublicKey)
+	}
+
+	r.mu.Lock()
+	r.signing = newest
+	r.verify = verify
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *KeyRing) createKey(ctx context.Context, now time.Time) (*entity.SigningKey, error) {
+	id := make([]byte, 9)
+	seed := make([]byte, ed25519.SeedSize)
+	_, _ = rand.Read(id)
+	_, _ = rand.Read(seed)
+	k := &entity.SigningKey{
+		ID:         base64.RawURLEncoding.EncodeToString(id),
+		PrivateKey: seed,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(r.cfg.RotateEvery + r.cfg.TokenTTL),
+	}
+	if err := r.store.CreateSigningKey(ctx, k); err != nil {
+		return nil, fmt.Errorf("create signing key: %w", err)
+	}
+	return k, nil
+}
+
+// Run refreshes keys every interval until ctx is done, so rotation happens
+// without a restart and keys made by other replicas are picked up.
+func (r *KeyRing) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil && ctx.Err() == nil {
+				r.log.Error("Failed to refresh signing keys", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+type tokenClaims struct {
+	Issuer     string `json:"iss"`
+	Subject    string `json:"sub"`
+	Scope      string `json:"scope"`
+	Credential string `json:"cred,omitempty"`
+	IssuedAt   int64  `json:"iat"`
+	ExpiresAt  int64  `json:"exp"`
+}
+
+// Issue signs a session token for p. A ttl outside (0, TokenTTL] is
+// replaced by TokenTTL.
+func (r *KeyRing) Issue(ctx context.Context, p *Principal, ttl time.Duration) (string, time.Time, error) {
+	if ttl <= 0 || ttl > r.cfg.TokenTTL {
+		ttl = r.cfg.TokenTTL
+	}
+	now := r.now()
+
+	r.mu.RLock()
+	key := r.signing
+	r.mu.RUnlock()
+	if key == nil || now.Sub(key.CreatedAt) >= r.cfg.RotateEvery {
+		if err := r.Refresh(ctx); err != nil {
+			return "", time.Time{}, err
+		}
+		r.mu.RLock()
+		key = r.signing
+		r.mu.RUnlock()
+	}
+
+	// A token must not outlive the key that verifies it.
+	expires := now.Add(ttl)
+	if key.ExpiresAt.Before(expires) {
+		expires = key.ExpiresAt
+	}
+	expires = expires.Truncate(time.Second)
+
+	header, err := json.Marshal(tokenHeader{Alg: "EdDSA", Typ: "JWT", Kid: key.ID})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	claims, err := json.Marshal(tokenClaims{
+		Issuer:     tokenIssuer,
+		Subject:    p.UserID,
+		Scope:      strings.Join(p.Scopes, " "),
+		Credential: p.CredentialID,
+		IssuedAt:   now.Unix(),
+		ExpiresAt:  expires.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	sig := ed25519.Sign(ed25519.NewKeyFromSeed(key.PrivateKey), []byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), expires, nil
+}
+
+// Verify checks a session token and returns its principal.
+func (r *KeyRing) Verify(ctx context.Context, token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed", ErrInvalidToken)
+	}
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Alg != "EdDSA" {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, header.Alg)
+	}
+	pub, err := r.publicKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !ed25519.Verify(pub, []byte(parts[0]+"."+parts[1]), sig) {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+
+	var claims tokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if claims.Issuer != tokenIssuer || claims.Subject == "" {
+		return nil, fmt.Errorf("%w: bad claims", ErrInvalidToken)
+	}
+	if !r.now().Before(time.Unix(claims.ExpiresAt, 0)) {
+		return nil, fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+
+	return &Principal{
+		UserID:       claims.Subject,
+		Scopes:       strings.Fields(claims.Scope),
+		Method:       MethodSession,
+		CredentialID: claims.Credential,
+	}, nil
+}
+
+// publicKey looks up a verification key, reloading the store at most once
+// per missRefreshInterval for keys another replica may have just created.
+func (r *KeyRing) publicKey(ctx context.Context, kid string) (ed25519.PublicKey, error) {
+	r.mu.Lock()
+	pub, ok := r.verify[kid]
+	reload := !ok && r.now().Sub(r.lastMiss) >= missRefreshInterval
+	if reload {
+		r.lastMiss = r.now()
+	}
+	r.mu.Unlock()
+	if ok {
+		return pub, nil
+	}
+
+	if reload {
+		if err := r.Refresh(ctx); err != nil {
+			return nil, err
+		}
+		r.mu.RLock()
+		pub, ok = r.verify[kid]
+		r.mu.RUnlock()
+		if ok {
+			return pub, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unknown key", ErrInvalidToken)
+}
+
+func decodeSegment(segment string, v any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: malformed", ErrInvalidToken)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("%w: malformed", ErrInvalidToken)
+	}
+	return nil
+}
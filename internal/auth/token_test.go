@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestKeyRing(st Store, c *clock) *KeyRing {
+	r := NewKeyRing(st, KeyRingConfig{RotateEvery: 24 * time.Hour, TokenTTL: time.Hour}, slog.New(slog.DiscardHandler))
+	r.now = c.now
+	return r
+}
+
+func TestKeyRing_IssueVerify(t *testing.T) {
+	ctx := context.Background()
+	c := &clock{t: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	r := newTestKeyRing(&memStore{}, c)
+
+	token, expires, err := r.Issue(ctx, &Principal{UserID: "u1", Scopes: []string{ScopeRead}, CredentialID: "key-1"}, 2*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, c.t.Add(time.Hour), expires, "ttl is capped at TokenTTL")
+
+	p, err := r.Verify(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, &Principal{UserID: "u1", Scopes: []string{ScopeRead}, Method: MethodSession, CredentialID: "key-1"}, p)
+
+	parts := strings.Split(token, ".")
+	_, err = r.Verify(ctx, parts[0]+"."+parts[1]+"."+strings.Repeat("A", len(parts[2])))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	c.advance(time.Hour)
+	_, err = r.Verify(ctx, token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	ctx := context.Background()
+	st := &memStore{}
+	c := &clock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	r := newTestKeyRing(st, c)
+	p := &Principal{UserID: "u1", Scopes: []string{ScopeWrite}}
+	require.NoError(t, r.Refresh(ctx))
+
+	c.advance(23*time.Hour + 30*time.Minute)
+	old, _, err := r.Issue(ctx, p, 0)
+	require.NoError(t, err)
+	require.Len(t, st.signingKeys, 1)
+
+	// The key is rotated once it is a day old; tokens it signed still verify.
+	c.advance(40 * time.Minute)
+	fresh, _, err := r.Issue(ctx, p, 0)
+	require.NoError(t, err)
+	require.Len(t, st.signingKeys, 2)
+	assert.NotEqual(t, strings.Split(old, ".")[0], strings.Split(fresh, ".")[0])
+	_, err = r.Verify(ctx, old)
+	require.NoError(t, err)
+
+	// Another replica sharing the store learns the new key on first sight.
+	other := newTestKeyRing(st, c)
+	_, err = other.Verify(ctx, fresh)
+	require.NoError(t, err)
+
+	// The old key is deleted after the tokens it could sign have expired.
+	c.advance(time.Hour)
+	require.NoError(t, r.Refresh(ctx))
+	assert.Len(t, st.signingKeys, 1)
+}
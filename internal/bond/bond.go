@@ -0,0 +1,281 @@
+// Package bond computes the coupon schedule, accrued interest and yield of
+// fixed-rate bonds. Dates are calendar days in UTC. Coupons fall on the day
+// of the month the bond matures on, counted back from maturity, or on the
+// last day of shorter months; bonds maturing at the end of a month pay at
+// the end of every month. A first period cut short by the issue date pays
+// the interest accrued over it.
+package bond
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/shopspring/decimal"
+)
+
+// places is the precision of computed amounts.
+const places = 8
+
+// yieldPlaces is the precision of yields.
+const yieldPlaces = 6
+
+// CashFlow is a payment per unit of a bond.
+type CashFlow struct {
+	Date      time.Time
+	Coupon    decimal.Decimal
+	Principal decimal.Decimal // Face value on the maturity date, zero before
+}
+
+// Validate reports what is wrong with terms, if anything.
+func Validate(t *entity.BondTerms) error {
+	switch {
+	case !t.FaceValue.IsPositive():
+		return errors.New("face value must be positive")
+	case t.CurrencyAssetID == "":
+		return errors.New("currency asset is required")
+	case t.CouponRate.IsNegative():
+		return errors.New("coupon rate cannot be negative")
+	case t.MaturityDate.IsZero():
+		return errors.New("maturity date is required")
+	case !t.IssueDate.IsZero() && !day(t.IssueDate).Before(day(t.MaturityDate)):
+		return errors.New("issue date must be before the maturity date")
+	}
+	switch t.CouponFrequency {
+	case 0:
+		if t.CouponRate.IsPositive() {
+			return errors.New("a bond paying coupons needs a coupon frequency")
+		}
+	case 1, 2, 4, 12:
+		if t.CouponRate.IsPositive() && t.DayCount == entity.DayCountUnspecified {
+			return errors.New("a bond paying coupons needs a day count convention")
+		}
+	default:
+		return errors.New("coupon frequency must be 0, 1, 2, 4 or 12")
+	}
+	return nil
+}
+
+// Matured reports whether the bond has matured by at.
+func Matured(t *entity.BondTerms, at time.Time) bool {
+	return !day(at).Before(day(t.MaturityDate))
+}
+
+// Coupons returns the coupon dates around at: the last one paid on or
+// before it, zero if none was, and the next one after it, zero once the
+// bond has matured.
+func Coupons(t *entity.BondTerms, at time.Time) (previous, next time.Time) {
+	if Matured(t, at) {
+		return time.Time{}, time.Time{}
+	}
+	if !pays(t) {
+		return time.Time{}, day(t.MaturityDate)
+	}
+	prev, next := period(t, issueOr(t, at))
+	if prev.Before(accrualStart(t, prev)) {
+		prev = time.Time{}
+	}
+	return prev, next
+}
+
+// AccruedInterest returns the interest a unit has accrued since its last
+// coupon by at, which a buyer pays the seller on top of the clean price.
+func AccruedInterest(t *entity.BondTerms, at time.Time) decimal.Decimal {
+	s := day(at)
+	if !pays(t) || Matured(t, s) || (!t.IssueDate.IsZero() && s.Before(day(t.IssueDate))) {
+		return decimal.Zero
+	}
+	prev, next := period(t, s)
+	return t.FaceValue.Mul(t.CouponRate).Mul(yearFraction(t, accrualStart(t, prev), s, prev, next)).Round(places)
+}
+
+// CashFlows returns the payments per unit after at, through maturity.
+func CashFlows(t *entity.BondTerms, at time.Time) []CashFlow {
+	if Matured(t, at) {
+		return nil
+	}
+	maturity := day(t.MaturityDate)
+	if !pays(t) {
+		return []CashFlow{{Date: maturity, Principal: t.FaceValue}}
+	}
+	dates := couponDates(t, issueOr(t, at))
+	flows := make([]CashFlow, 0, len(dates)-1)
+	for i := 1; i < len(dates); i++ {
+		flow := CashFlow{Date: dates[i], Coupon: coupon(t, dates[i-1], dates[i])}
+		if i == len(dates)-1 {
+			flow.Principal = t.FaceValue
+		}
+		flows = append(flows, flow)
+	}
+	return flows
+}
+
+// YieldToMaturity returns the annual yield, compounded at the coupon
+// frequency, at which the cash flows after at are worth the dirty price:
+// the clean price plus accrued interest. Time is counted in coupon periods,
+// the current one pro rata by actual days; zero-coupon bonds compound
+// yearly over actual days of 365. It returns false for a matured bond or a
+// price no yield matches.
+func YieldToMaturity(t *entity.BondTerms, at time.Time, dirtyPrice decimal.Decimal) (decimal.Decimal, bool) {
+	flows := CashFlows(t, at)
+	if len(flows) == 0 || !dirtyPrice.IsPositive() {
+		return decimal.Zero, false
+	}
+	s := day(at)
+	frequency := 1.0
+	periods := make([]float64, len(flows))
+	if pays(t) {
+		frequency = float64(t.CouponFrequency)
+		dates := couponDates(t, issueOr(t, s))
+		w := days(s, dates[1]) / days(dates[0], dates[1])
+		for i := range flows {
+			periods[i] = w + float64(i)
+		}
+	} else {
+		periods[0] = days(s, flows[0].Date) / 365
+	}
+
+	amounts := make([]float64, len(flows))
+	for i, f := range flows {
+		amounts[i] = f.Coupon.Add(f.Principal).InexactFloat64()
+	}
+	price := dirtyPrice.InexactFloat64()
+	value := func(y float64) float64 {
+		var v float64
+		for i, a := range amounts {
+			v += a / math.Pow(1+y/frequency, periods[i])
+		}
+		return v
+	}
+
+	// Value falls as the yield rises; bracket the price and bisect.
+	lo, hi := -frequency*0.999, 1.0
+	for value(hi) > price {
+		if hi *= 2; hi > 1e6 {
+			return decimal.Zero, false
+		}
+	}
+	if value(lo) < price {
+		return decimal.Zero, false
+	}
+	for range 200 {
+		mid := (lo + hi) / 2
+		if value(mid) > price {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return decimal.NewFromFloat((lo + hi) / 2).Round(yieldPlaces), true
+}
+
+func pays(t *entity.BondTerms) bool {
+	return t.CouponFrequency > 0 && t.CouponRate.IsPositive()
+}
+
+// day returns midnight UTC of the day of t.
+func day(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// issueOr returns the issue date if at is before it, else the day of at.
+func issueOr(t *entity.BondTerms, at time.Time) time.Time {
+	if !t.IssueDate.IsZero() && at.Before(day(t.IssueDate)) {
+		return day(t.IssueDate)
+	}
+	return day(at)
+}
+
+// accrualStart returns when interest of the period from prev accrues from:
+// the issue date in a first period it cuts short.
+func accrualStart(t *entity.BondTerms, prev time.Time) time.Time {
+	if issue := day(t.IssueDate); !t.IssueDate.IsZero() && issue.After(prev) {
+		return issue
+	}
+	return prev
+}
+
+// couponDates returns the coupon dates from the last one on or before from
+// through maturity. The first may be a notional date before the issue.
+func couponDates(t *entity.BondTerms, from time.Time) []time.Time {
+	maturity := day(t.MaturityDate)
+	step := 12 / t.CouponFrequency
+	endOfMonth := maturity.AddDate(0, 0, 1).Day() == 1
+	var dates []time.Time
+	for k := 0; ; k++ {
+		d := addMonths(maturity, -k*step, endOfMonth)
+		dates = append(dates, d)
+		if !d.After(from) {
+			break
+		}
+	}
+	slices.Reverse(dates)
+	return dates
+}
+
+// period returns the coupon dates at is between, before maturity.
+func period(t *entity.BondTerms, at time.Time) (prev, next time.Time) {
+	dates := couponDates(t, day(at))
+	return dates[0], dates[1]
+}
+
+// coupon returns what a unit pays for the period from prev to next: a
+// regular coupon, or the interest accrued from the issue date.
+func coupon(t *entity.BondTerms, prev, next time.Time) decimal.Decimal {
+	rate := t.FaceValue.Mul(t.CouponRate)
+	if start := accrualStart(t, prev); start.After(prev) {
+		return rate.Mul(yearFraction(t, start, next, prev, next)).Round(places)
+	}
+	return rate.Div(decimal.NewFromInt(int64(t.CouponFrequency))).Round(places)
+}
+
+// yearFraction returns the time from start to end in years by the bond's
+// day count, in the coupon period from prev to next.
+func yearFraction(t *entity.BondTerms, start, end, prev, next time.Time) decimal.Decimal {
+	actual := decimal.NewFromFloat(days(start, end))
+	switch t.DayCount {
+	case entity.DayCount30360:
+		return decimal.NewFromInt(days30360(start, end)).Div(decimal.NewFromInt(360))
+	case entity.DayCountActual360:
+		return actual.Div(decimal.NewFromInt(360))
+	case entity.DayCountActual365Fixed:
+		return actual.Div(decimal.NewFromInt(365))
+	default:
+		periodDays := decimal.NewFromFloat(days(prev, next))
+		return actual.Div(periodDays.Mul(decimal.NewFromInt(int64(t.CouponFrequency))))
+	}
+}
+
+// days returns the actual days from start to end.
+func days(start, end time.Time) float64 {
+	return math.Round(end.Sub(start).Hours() / 24)
+}
+
+// days30360 returns the days from start to end counting months of 30 days,
+// by the US bond basis rule.
+func days30360(start, end time.Time) int64 {
+	y1, m1, d1 := start.Date()
+	y2, m2, d2 := end.Date()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 >= 30 {
+		d2 = 30
+	}
+	return int64(360*(y2-y1) + 30*(int(m2)-int(m1)) + d2 - d1)
+}
+
+// addMonths returns the date n months from t, on the last day of the month
+// if endOfMonth is set or the month is too short for the day of t.
+func addMonths(t time.Time, n int, endOfMonth bool) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	d := t.Day()
+	if endOfMonth || d > last {
+		d = last
+	}
+	return time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, time.UTC)
+}
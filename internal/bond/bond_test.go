@@ -0,0 +1,141 @@
+package bond
+
+import (
+	"testing"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+// semiannual is a 5% bond of 100 paying on June 15 and December 15.
+func semiannual(dayCount entity.DayCount) *entity.BondTerms {
+	return &entity.BondTerms{
+		FaceValue:       dec("100"),
+		CurrencyAssetID: "usd",
+		CouponRate:      dec("0.05"),
+		CouponFrequency: 2,
+		MaturityDate:    date(2030, time.June, 15),
+		DayCount:        dayCount,
+	}
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Validate(semiannual(entity.DayCount30360)))
+	require.NoError(t, Validate(&entity.BondTerms{FaceValue: dec("1000"), CurrencyAssetID: "usd", MaturityDate: date(2030, time.June, 15)}))
+
+	for name, change := range map[string]func(*entity.BondTerms){
+		"no face value":      func(b *entity.BondTerms) { b.FaceValue = decimal.Zero },
+		"no currency":        func(b *entity.BondTerms) { b.CurrencyAssetID = "" },
+		"negative coupon":    func(b *entity.BondTerms) { b.CouponRate = dec("-0.01") },
+		"no maturity":        func(b *entity.BondTerms) { b.MaturityDate = time.Time{} },
+		"issued at maturity": func(b *entity.BondTerms) { b.IssueDate = b.MaturityDate },
+		"no frequency":       func(b *entity.BondTerms) { b.CouponFrequency = 0 },
+		"odd frequency":      func(b *entity.BondTerms) { b.CouponFrequency = 3 },
+		"no day count":       func(b *entity.BondTerms) { b.DayCount = entity.DayCountUnspecified },
+	} {
+		b := semiannual(entity.DayCount30360)
+		change(b)
+		assert.Error(t, Validate(b), name)
+	}
+}
+
+func TestAccruedInterest(t *testing.T) {
+	settlement := date(2025, time.August, 15)
+	for _, tc := range []struct {
+		dayCount entity.DayCount
+		want     string
+	}{
+		// 60 days of 30 in 360.
+		{entity.DayCount30360, "0.83333333"},
+		// 61 of the 183 days of the period.
+		{entity.DayCountActualActual, "0.83333333"},
+		{entity.DayCountActual360, "0.84722222"},
+		{entity.DayCountActual365Fixed, "0.83561644"},
+	} {
+		assert.Equal(t, tc.want, AccruedInterest(semiannual(tc.dayCount), settlement).String(), tc.dayCount)
+	}
+
+	b := semiannual(entity.DayCount30360)
+	assert.True(t, AccruedInterest(b, date(2025, time.June, 15)).IsZero(), "on a coupon date")
+	assert.True(t, AccruedInterest(b, date(2030, time.June, 15)).IsZero(), "at maturity")
+
+	// Interest accrues from the issue date in the first period.
+	b.IssueDate = date(2025, time.July, 15)
+	assert.Equal(t, "0.41666667", AccruedInterest(b, settlement).String())
+	assert.True(t, AccruedInterest(b, date(2025, time.July, 1)).IsZero(), "before the issue")
+
+	zero := &entity.BondTerms{FaceValue: dec("100"), CurrencyAssetID: "usd", MaturityDate: date(2030, time.June, 15)}
+	assert.True(t, AccruedInterest(zero, settlement).IsZero())
+}
+
+func TestCashFlows(t *testing.T) {
+	b := semiannual(entity.DayCountActualActual)
+	b.MaturityDate = date(2026, time.June, 15)
+	b.IssueDate = date(2025, time.September, 15)
+
+	flows := CashFlows(b, date(2025, time.August, 1))
+	require.Len(t, flows, 2)
+	// The first period runs 91 of the 183 days from the notional June 15.
+	assert.Equal(t, date(2025, time.December, 15), flows[0].Date)
+	assert.Equal(t, "1.2431694", flows[0].Coupon.String())
+	assert.True(t, flows[0].Principal.IsZero())
+	assert.Equal(t, date(2026, time.June, 15), flows[1].Date)
+	assert.Equal(t, "2.5", flows[1].Coupon.String())
+	assert.Equal(t, "100", flows[1].Principal.String())
+
+	prev, next := Coupons(b, date(2025, time.October, 1))
+	assert.True(t, prev.IsZero())
+	assert.Equal(t, date(2025, time.December, 15), next)
+	prev, next = Coupons(b, date(2026, time.January, 1))
+	assert.Equal(t, date(2025, time.December, 15), prev)
+	assert.Equal(t, date(2026, time.June, 15), next)
+
+	assert.Empty(t, CashFlows(b, date(2026, time.June, 15)))
+	assert.True(t, Matured(b, date(2026, time.June, 15).Add(time.Hour)))
+}
+
+func TestCouponDatesAtMonthEnd(t *testing.T) {
+	b := &entity.BondTerms{CouponFrequency: 4, MaturityDate: date(2026, time.February, 28)}
+	assert.Equal(t, []time.Time{
+		date(2025, time.February, 28), date(2025, time.May, 31), date(2025, time.August, 31),
+		date(2025, time.November, 30), date(2026, time.February, 28),
+	}, couponDates(b, date(2025, time.March, 1)))
+
+	b = &entity.BondTerms{CouponFrequency: 12, MaturityDate: date(2026, time.March, 30)}
+	assert.Equal(t, []time.Time{
+		date(2026, time.January, 30), date(2026, time.February, 28), date(2026, time.March, 30),
+	}, couponDates(b, date(2026, time.February, 1)))
+}
+
+func TestYieldToMaturity(t *testing.T) {
+	b := semiannual(entity.DayCount30360)
+	// At par on a coupon date, the yield is the coupon rate.
+	y, ok := YieldToMaturity(b, date(2025, time.June, 15), dec("100"))
+	require.True(t, ok)
+	assert.Equal(t, "0.05", y.String())
+
+	// Below par it is higher.
+	y, ok = YieldToMaturity(b, date(2025, time.June, 15), dec("95"))
+	require.True(t, ok)
+	assert.Equal(t, "0.061776", y.String())
+
+	// Two years of 365 days at 5% a year.
+	zero := &entity.BondTerms{FaceValue: dec("100"), CurrencyAssetID: "usd", MaturityDate: date(2027, time.January, 1)}
+	y, ok = YieldToMaturity(zero, date(2025, time.January, 1), dec("90.70294785"))
+	require.True(t, ok)
+	assert.Equal(t, "0.05", y.String())
+
+	_, ok = YieldToMaturity(b, date(2030, time.June, 15), dec("100"))
+	assert.False(t, ok, "matured")
+}
@@ -0,0 +1,265 @@
+// Package calendar knows when exchanges trade: their regular session in
+// local time, and the holidays and early closes of each year. Special
+// closures announced at short notice, such as days of mourning, are not
+// known.
+package calendar
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // The alpine image has no zoneinfo
+)
+
+// Exchange is the trading calendar of an exchange.
+type Exchange struct {
+	MIC      string // ISO 10383 market identifier, e.g. "XNYS"
+	Name     string
+	Location *time.Location
+	// Regular session and early close, in minutes after local midnight.
+	open, close, earlyClose int
+	// closures returns the year's holidays and early closes.
+	closures func(year int) (holidays, earlyCloses []date)
+}
+
+// date is a calendar day, independent of time zone.
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) date {
+	y, m, d := t.Date()
+	return date{y, m, d}
+}
+
+func (d date) weekday() time.Weekday {
+	return time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC).Weekday()
+}
+
+// add returns the day n days after d.
+func (d date) add(n int) date {
+	return dateOf(time.Date(d.year, d.month, d.day+n, 0, 0, 0, 0, time.UTC))
+}
+
+var exchanges = map[string]*Exchange{}
+
+func register(e *Exchange, aliases ...string) {
+	for _, mic := range append([]string{e.MIC}, aliases...) {
+		exchanges[mic] = e
+	}
+}
+
+func init() {
+	newYork := mustLoad("America/New_York")
+	register(&Exchange{
+		MIC: "XNYS", Name: "New York Stock Exchange", Location: newYork,
+		open: 9*60 + 30, close: 16 * 60, earlyClose: 13 * 60,
+		closures: nyseClosures,
+	}, "ARCX", "XASE")
+	register(&Exchange{
+		MIC: "XNAS", Name: "Nasdaq", Location: newYork,
+		open: 9*60 + 30, close: 16 * 60, earlyClose: 13 * 60,
+		closures: nyseClosures,
+	})
+	register(&Exchange{
+		MIC: "XLON", Name: "London Stock Exchange", Location: mustLoad("Europe/London"),
+		open: 8 * 60, close: 16*60 + 30, earlyClose: 12*60 + 30,
+		closures: lseClosures,
+	})
+	register(&Exchange{
+		MIC: "XETR", Name: "Xetra", Location: mustLoad("Europe/Berlin"),
+		open: 9 * 60, close: 17*60 + 30,
+		closures: xetraClosures,
+	})
+}
+
+func mustLoad(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(fmt.Sprintf("calendar: %v", err))
+	}
+	return loc
+}
+
+// ByMIC returns the calendar of the exchange with a market identifier.
+func ByMIC(mic string) (*Exchange, bool) {
+	e, ok := exchanges[mic]
+	return e, ok
+}
+
+// Session returns the regular session on the local day of t, or false if
+// the exchange does not trade that day.
+func (e *Exchange) Session(t time.Time) (open, close time.Time, ok bool) {
+	d := dateOf(t.In(e.Location))
+	if wd := d.weekday(); wd == time.Saturday || wd == time.Sunday {
+		return time.Time{}, time.Time{}, false
+	}
+	holidays, earlyCloses := e.closures(d.year)
+	if contains(holidays, d) {
+		return time.Time{}, time.Time{}, false
+	}
+	closeAt := e.close
+	if contains(earlyCloses, d) {
+		closeAt = e.earlyClose
+	}
+	return e.at(d, e.open), e.at(d, closeAt), true
+}
+
+// IsOpen reports whether the exchange is in its regular session at t.
+func (e *Exchange) IsOpen(t time.Time) bool {
+	open, close, ok := e.Session(t)
+	return ok && !t.Before(open) && t.Before(close)
+}
+
+// LastClose returns the end of the latest session that closed at or before
+// t.
+func (e *Exchange) LastClose(t time.Time) time.Time {
+	d := dateOf(t.In(e.Location))
+	// No exchange is closed for more than a few days in a row.
+	for range 14 {
+		if _, close, ok := e.Session(e.at(d, 12*60)); ok && !close.After(t) {
+			return close
+		}
+		d = d.add(-1)
+	}
+	panic("calendar: no session in two weeks on " + e.MIC)
+}
+
+// at returns the time minutes after midnight on local day d.
+func (e *Exchange) at(d date, minutes int) time.Time {
+	return time.Date(d.year, d.month, d.day, minutes/60, minutes%60, 0, 0, e.Location)
+}
+
+func contains(days []date, d date) bool {
+	for _, day := range days {
+		if day == d {
+			return true
+		}
+	}
+	return false
+}
+
+// easter returns Easter Sunday of a Gregorian year.
+func easter(year int) date {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date{year, time.Month(month), day}
+}
+
+// nthWeekday returns the nth weekday of a month, or the last if n is -1.
+func nthWeekday(year int, month time.Month, wd time.Weekday, n int) date {
+	if n < 0 {
+		last := date{year, month + 1, 1}.add(-1)
+		return last.add(-((int(last.weekday()) - int(wd) + 7) % 7))
+	}
+	first := date{year, month, 1}
+	return first.add((int(wd)-int(first.weekday())+7)%7 + 7*(n-1))
+}
+
+// nearestWeekday moves a Saturday holiday to Friday and a Sunday one to
+// Monday.
+func nearestWeekday(d date) date {
+	switch d.weekday() {
+	case time.Saturday:
+		return d.add(-1)
+	case time.Sunday:
+		return d.add(1)
+	}
+	return d
+}
+
+// nyseClosures follows NYSE Rule 7.2: holidays on a Sunday are observed
+// the Monday after and those on a Saturday the Friday before, except New
+// Year's Day, which would close the exchange on the last day of a year.
+func nyseClosures(year int) (holidays, earlyCloses []date) {
+	newYear := date{year, time.January, 1}
+	if newYear.weekday() == time.Sunday {
+		holidays = append(holidays, newYear.add(1))
+	} else if newYear.weekday() != time.Saturday {
+		holidays = append(holidays, newYear)
+	}
+	holidays = append(holidays,
+		nthWeekday(year, time.January, time.Monday, 3),  // Martin Luther King Jr. Day
+		nthWeekday(year, time.February, time.Monday, 3), // Washington's Birthday
+		easter(year).add(-2),                            // Good Friday
+		nthWeekday(year, time.May, time.Monday, -1),     // Memorial Day
+		nearestWeekday(date{year, time.July, 4}),
+		nthWeekday(year, time.September, time.Monday, 1),  // Labor Day
+		nthWeekday(year, time.November, time.Thursday, 4), // Thanksgiving
+		nearestWeekday(date{year, time.December, 25}),
+	)
+	if year >= 2022 {
+		holidays = append(holidays, nearestWeekday(date{year, time.June, 19})) // Juneteenth
+	}
+
+	earlyCloses = append(earlyCloses, nthWeekday(year, time.November, time.Thursday, 4).add(1))
+	// The eves of Independence Day and Christmas close early, unless they
+	// are the observed holiday or a weekend.
+	for _, eve := range []date{{year, time.July, 3}, {year, time.December, 24}} {
+		if wd := eve.weekday(); wd >= time.Monday && wd <= time.Thursday {
+			earlyCloses = append(earlyCloses, eve)
+		}
+	}
+	return holidays, earlyCloses
+}
+
+// lseClosures follows the English bank holidays: those on a weekend move to
+// the next weekday that is not already a holiday.
+func lseClosures(year int) (holidays, earlyCloses []date) {
+	e := easter(year)
+	holidays = []date{
+		e.add(-2), // Good Friday
+		e.add(1),  // Easter Monday
+		nthWeekday(year, time.May, time.Monday, 1),     // Early May bank holiday
+		nthWeekday(year, time.May, time.Monday, -1),    // Spring bank holiday
+		nthWeekday(year, time.August, time.Monday, -1), // Summer bank holiday
+	}
+	fixed := []date{{year, time.January, 1}, {year, time.December, 25}, {year, time.December, 26}}
+	var moved []date
+	for _, d := range fixed {
+		if wd := d.weekday(); wd == time.Saturday || wd == time.Sunday {
+			moved = append(moved, d)
+		} else {
+			holidays = append(holidays, d)
+		}
+	}
+	for _, d := range moved {
+		for d.weekday() == time.Saturday || d.weekday() == time.Sunday || contains(holidays, d) {
+			d = d.add(1)
+		}
+		holidays = append(holidays, d)
+	}
+
+	for _, eve := range []date{{year, time.December, 24}, {year, time.December, 31}} {
+		if !contains(holidays, eve) {
+			earlyCloses = append(earlyCloses, eve)
+		}
+	}
+	return holidays, earlyCloses
+}
+
+// xetraClosures lists the days Xetra does not trade; holidays on a weekend
+// are not made up.
+func xetraClosures(year int) (holidays, earlyCloses []date) {
+	e := easter(year)
+	return []date{
+		{year, time.January, 1},
+		e.add(-2), // Good Friday
+		e.add(1),  // Easter Monday
+		{year, time.May, 1},
+		{year, time.December, 24},
+		{year, time.December, 25},
+		{year, time.December, 26},
+		{year, time.December, 31},
+	}, nil
+}
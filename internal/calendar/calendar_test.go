@@ -0,0 +1,91 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exchange(t *testing.T, mic string) *Exchange {
+	t.Helper()
+	e, ok := ByMIC(mic)
+	require.True(t, ok, mic)
+	return e
+}
+
+func TestEaster(t *testing.T) {
+	for year, want := range map[int]date{
+		2024: {2024, time.March, 31},
+		2025: {2025, time.April, 20},
+		2026: {2026, time.April, 5},
+		2038: {2038, time.April, 25},
+	} {
+		assert.Equal(t, want, easter(year), year)
+	}
+}
+
+func TestNYSEHolidays(t *testing.T) {
+	nyse := exchange(t, "XNYS")
+	closed := []string{
+		"2025-01-01", "2025-01-20", "2025-02-17", "2025-04-18", "2025-05-26",
+		"2025-06-19", "2025-07-04", "2025-09-01", "2025-11-27", "2025-12-25",
+		"2026-07-03", // Independence Day on a Saturday
+		"2022-12-26", // Christmas on a Sunday
+	}
+	for _, day := range closed {
+		d, err := time.ParseInLocation(time.DateOnly, day, nyse.Location)
+		require.NoError(t, err)
+		_, _, ok := nyse.Session(d.Add(12 * time.Hour))
+		assert.False(t, ok, day)
+	}
+
+	// New Year's Day on a Saturday is not observed the Friday before.
+	_, close, ok := nyse.Session(time.Date(2021, time.December, 31, 12, 0, 0, 0, nyse.Location))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2021, time.December, 31, 16, 0, 0, 0, nyse.Location), close)
+
+	// The day after Thanksgiving closes early.
+	_, close, ok = nyse.Session(time.Date(2025, time.November, 28, 12, 0, 0, 0, nyse.Location))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, time.November, 28, 13, 0, 0, 0, nyse.Location), close)
+
+	assert.Equal(t, nyse, exchange(t, "ARCX"))
+}
+
+func TestLSEHolidays(t *testing.T) {
+	lse := exchange(t, "XLON")
+	// Christmas on a Saturday and Boxing Day on a Sunday move to Monday
+	// and Tuesday.
+	for _, day := range []int{27, 28} {
+		_, _, ok := lse.Session(time.Date(2021, time.December, day, 12, 0, 0, 0, lse.Location))
+		assert.False(t, ok, day)
+	}
+	_, close, ok := lse.Session(time.Date(2021, time.December, 24, 10, 0, 0, 0, lse.Location))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2021, time.December, 24, 12, 30, 0, 0, lse.Location), close)
+
+	_, _, ok = lse.Session(time.Date(2025, time.April, 21, 12, 0, 0, 0, lse.Location))
+	assert.False(t, ok, "Easter Monday")
+}
+
+func TestLastClose(t *testing.T) {
+	nyse := exchange(t, "XNYS")
+	thursdayClose := time.Date(2025, time.April, 17, 16, 0, 0, 0, nyse.Location)
+
+	for name, at := range map[string]time.Time{
+		"at close":            thursdayClose,
+		"Good Friday":         time.Date(2025, time.April, 18, 12, 0, 0, 0, nyse.Location),
+		"weekend":             time.Date(2025, time.April, 20, 12, 0, 0, 0, time.UTC),
+		"before Monday close": time.Date(2025, time.April, 21, 15, 59, 0, 0, nyse.Location),
+	} {
+		assert.True(t, thursdayClose.Equal(nyse.LastClose(at)), name)
+	}
+
+	xetra := exchange(t, "XETR")
+	assert.True(t, xetra.IsOpen(time.Date(2025, time.April, 17, 15, 0, 0, 0, time.UTC)))
+	assert.False(t, xetra.IsOpen(time.Date(2025, time.April, 17, 15, 30, 0, 0, time.UTC)), "after 17:30 in Frankfurt")
+	assert.True(t, time.Date(2025, time.April, 17, 15, 30, 0, 0, time.UTC).Equal(
+		xetra.LastClose(time.Date(2025, time.April, 22, 6, 0, 0, 0, time.UTC))))
+}
@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 // AssetSymbol is a simple string representation of an asset symbol.
 // Kept for backward compatibility with existing code.
@@ -26,6 +30,88 @@ type Asset struct {
 	Symbol    string
 	Type      AssetType
 	Tags      []string
+	Metadata  map[string]AssetMetadata // By metadata key
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// LastEnrichedAt is when metadata providers were last consulted.
+	LastEnrichedAt *time.Time
+	Bond           *BondTerms // Set on bonds only
+}
+
+// DayCount is how a bond counts the time interest accrues over.
+type DayCount int32
+
+const (
+	DayCountUnspecified  DayCount = iota
+	DayCount30360                 // 30/360 US bond basis: months of 30 days
+	DayCountActualActual          // Actual/Actual ICMA: actual days over those of the coupon period
+	DayCountActual360
+	DayCountActual365Fixed
+)
+
+// BondTerms are the terms of a fixed-rate bond. A unit of the asset is one
+// bond of FaceValue, and its prices are clean: without accrued interest.
+type BondTerms struct {
+	FaceValue       decimal.Decimal // Repaid per unit at maturity
+	CurrencyAssetID string          // Asset the bond pays in
+	CouponRate      decimal.Decimal // Annual, as a fraction of face value
+	CouponFrequency int             // Coupons a year: 1, 2, 4 or 12; 0 for zero-coupon bonds
+	IssueDate       time.Time       // Interest accrues from; optional
+	MaturityDate    time.Time
+	DayCount        DayCount
+}
+
+// AssetMetadata is a descriptive field of an asset and where it came from.
+type AssetMetadata struct {
+	Value     string    `json:"value"`
+	Source    string    `json:"source"` // Provider name, or MetadataSourceManual
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Metadata sources other than providers.
+const (
+	MetadataSourceManual          = "manual"           // Set through the API
+	MetadataSourceCorporateAction = "corporate_action" // Set by applying a corporate action
+)
+
+// Asset metadata keys.
+const (
+	MetadataName          = "name" // Full name
+	MetadataDescription   = "description"
+	MetadataLogoURL       = "logo_url"
+	MetadataWebsite       = "website"
+	MetadataCategory      = "category" // Sector or category, comma-separated if several
+	MetadataChain         = "chain"    // Chain a token is issued on
+	MetadataMarketCapRank = "market_cap_rank"
+	MetadataDelistedAt    = "delisted_at" // Date the asset stopped trading, as 2006-01-02
+	MetadataExchange      = "exchange"    // Market identifier code of the listing, e.g. "XNYS"
+	MetadataCurrency      = "currency"    // ISO 4217 code of the currency the asset is quoted in
+	// MetadataContractPrefix prefixes contract addresses by chain, as in
+	// "contract:ethereum".
+	MetadataContractPrefix = "contract:"
+)
+
+// IdentifierKind is the scheme of an asset identifier.
+type IdentifierKind int32
+
+const (
+	IdentifierKindUnspecified IdentifierKind = iota
+	IdentifierKindCoinGecko                  // CoinGecko coin ID, e.g. "bitcoin"
+	IdentifierKindBinance                    // Binance asset code, e.g. "BTC"
+	IdentifierKindISIN
+	IdentifierKindCUSIP
+	IdentifierKindFIGI
+	IdentifierKindContract // Token contract address on Chain
+	IdentifierKindTicker   // Ticker with its exchange's suffix, e.g. "VOD.L"
+)
+
+// AssetIdentifier maps an asset to an ID a provider or registry knows it
+// by. Each identifier belongs to one asset.
+type AssetIdentifier struct {
+	ID        string
+	AssetID   string
+	Kind      IdentifierKind
+	Value     string
+	Chain     string // Chain of a contract, e.g. "ethereum"; empty otherwise
+	CreatedAt time.Time
 }
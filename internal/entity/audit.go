@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+// Audit actor types.
+const (
+	ActorTypeUser   = "user"
+	ActorTypeSystem = "system"
+)
+
+// AuditEvent records one change to one resource, or a mutating call that
+// changed no stored resource.
+type AuditEvent struct {
+	ID        string
+	CreatedAt time.Time
+	// ActorType is ActorTypeUser or ActorTypeSystem. ActorID is the user ID
+	// or the name of the background process.
+	ActorType    string
+	ActorID      string
+	CredentialID string
+	// OwnerID is the user whose data changed, when known.
+	OwnerID      string
+	Procedure    string
+	ResourceType string
+	ResourceID   string
+	FieldPaths   []string
+	Changes      map[string]AuditChange
+	RequestID    string
+	ClientIP     string
+}
+
+// AuditChange holds a field's value before and after a change, in JSON form.
+type AuditChange struct {
+	Before any `json:"before,omitempty"`
+	After  any `json:"after,omitempty"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// APIKey is a long-lived credential for programmatic access. Only a hash of
+// the key is stored; the key itself is shown once on creation.
+type APIKey struct {
+	ID         string
+	UserID     string
+	Name       string
+	Prefix     string // First characters of the key, to tell keys apart
+	Hash       []byte
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// SigningKey is a key used to sign session tokens. A key signs new tokens
+// until it is rotated and verifies them until ExpiresAt.
+type SigningKey struct {
+	ID         string // Key ID, carried in the token header
+	PrivateKey []byte
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+}
@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+// CorporateActionType is what happens to an asset in a corporate action.
+type CorporateActionType int32
+
+const (
+	CorporateActionTypeUnspecified CorporateActionType = iota
+	CorporateActionTypeSplit                           // Units multiply, as in a stock split or redenomination
+	CorporateActionTypeMigration                       // Holdings move to another asset, as in a token swap or merger
+	CorporateActionTypeDelisting                       // The asset stops trading
+)
+
+// CorporateActionStatus tracks whether an action's adjustments are in effect.
+type CorporateActionStatus int32
+
+const (
+	CorporateActionStatusUnspecified CorporateActionStatus = iota
+	CorporateActionStatusPending
+	CorporateActionStatusApplied
+	CorporateActionStatusReverted
+)
+
+// CorporateAction is a change to an asset that holdings, lots and prices
+// follow once it is applied. Holders get NewUnits of the resulting asset
+// for every OldUnits they held; delistings have no ratio.
+type CorporateAction struct {
+	ID          string
+	AssetID     string
+	Type        CorporateActionType
+	Status      CorporateActionStatus
+	EffectiveAt time.Time
+	NewUnits    int64
+	OldUnits    int64
+	NewAssetID  string // Migration target
+	Note        string
+	AppliedAt   *time.Time
+	RevertedAt  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// CorporateActionAdjustments counts the rows applying an action changed.
+type CorporateActionAdjustments struct {
+	Holdings int
+	Lots     int
+	Prices   int
+}
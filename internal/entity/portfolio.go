@@ -16,6 +16,40 @@ type Portfolio struct {
 	UpdatedAt   time.Time
 }
 
+// PortfolioRole is what a member may do with a shared portfolio. Roles are
+// ordered: each includes the ones before it.
+type PortfolioRole int32
+
+const (
+	PortfolioRoleUnspecified PortfolioRole = iota
+	PortfolioRoleViewer
+	PortfolioRoleEditor
+	PortfolioRoleOwner
+)
+
+// PortfolioMemberStatus tracks an invitation to a portfolio.
+type PortfolioMemberStatus int32
+
+const (
+	PortfolioMemberStatusUnspecified PortfolioMemberStatus = iota
+	PortfolioMemberStatusPending
+	PortfolioMemberStatusActive
+)
+
+// PortfolioMember grants a user other than the portfolio's creator a role on
+// it. The creator is implicitly an owner.
+type PortfolioMember struct {
+	ID          string
+	PortfolioID string
+	UserID      string
+	Email       string
+	Role        PortfolioRole
+	Status      PortfolioMemberStatus
+	InvitedBy   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
 // AccountType represents the type of financial account.
 type AccountType int32
 
@@ -34,7 +68,8 @@ type Account struct {
 	Name        string
 	Description string
 	Type        AccountType
-	Data        map[string]string // API keys, identifiers, etc.
+	Data        map[string]string // Identifiers and other non-secret settings
+	Secrets     map[string]string // API keys etc.; encrypted at rest, never returned by the API
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -51,6 +86,21 @@ type Holding struct {
 	UpdatedAt   time.Time
 }
 
+// Lot represents a tax lot: a part of a Holding acquired at one time and cost.
+// CostBasis is the total acquisition cost of the remaining Amount in CostAssetID.
+type Lot struct {
+	ID           string
+	HoldingID    string
+	Amount       int64
+	Decimals     uint32
+	CostBasis    int64
+	CostDecimals uint32
+	CostAssetID  string
+	AcquiredAt   time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
 // TransactionType represents the type of financial transaction.
 type TransactionType int32
 
@@ -61,6 +111,7 @@ const (
 	TransactionTypeTransfer                    // Transferring asset between accounts
 	TransactionTypeDeposit                     // Depositing asset into an account
 	TransactionTypeWithdrawal                  // Withdrawing asset from an account
+	TransactionTypeIncome                      // Income from a holding: dividend, staking reward, interest, airdrop
 )
 
 // TransactionStatus represents the current status of a transaction.
@@ -77,12 +128,13 @@ const (
 
 // Transaction represents a financial event involving assets, accounts, and portfolios.
 type Transaction struct {
-	ID        string
-	Type      TransactionType
-	Status    TransactionStatus
-	AccountID string
-	AssetID   string // Optional, for linking to specific asset
-	Data      map[string]string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID         string
+	Type       TransactionType
+	Status     TransactionStatus
+	AccountID  string
+	AssetID    string // Optional, for linking to specific asset
+	ExternalID string // Optional ID at the source, unique per account
+	Data       map[string]string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
@@ -38,4 +38,45 @@ type StoredPrice struct {
 	Close       *int64
 	Volume      *int64
 	Timestamp   time.Time
+	// Quarantine is why the price failed its ingestion checks, empty if it
+	// passed. Quarantined prices are stored but not used.
+	Quarantine string
+}
+
+// Reasons a price is quarantined.
+const (
+	PriceQuarantineJump      = "jump"      // Moved too far from the last price of the pair
+	PriceQuarantineDeviation = "deviation" // Too far from the prices of other sources
+)
+
+// PriceSourceStats summarizes the prices one source stored for an asset
+// pair and interval.
+type PriceSourceStats struct {
+	AssetID     string
+	BaseAssetID string
+	Interval    string
+	SourceID    string
+	Count       int       // Prices in the range
+	Quarantined int       // Quarantined prices in the range
+	Last        time.Time // Last unquarantined price before the end of the range; zero if none
+}
+
+// PriceGap is a span between two consecutive unquarantined prices of an
+// asset pair and interval.
+type PriceGap struct {
+	AssetID     string
+	BaseAssetID string
+	Interval    string
+	From        time.Time
+	To          time.Time
+}
+
+// Dividend is a cash payment per unit of an asset to those holding it on
+// its ex-date.
+type Dividend struct {
+	Source          string
+	AssetID         string
+	CurrencyAssetID string    // Asset the dividend is paid in
+	ExDate          time.Time // Midnight UTC of the ex-date
+	PerShare        decimal.Decimal
 }
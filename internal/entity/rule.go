@@ -0,0 +1,68 @@
+package entity
+
+import "time"
+
+// RuleStatus represents the lifecycle state of a rule.
+type RuleStatus int32
+
+const (
+	RuleStatusUnknown RuleStatus = iota
+	RuleStatusActive
+	RuleStatusPaused
+	RuleStatusDisabled
+	RuleStatusError
+)
+
+// ExecutionStatus represents the state of a single rule execution.
+type ExecutionStatus int32
+
+const (
+	ExecutionStatusUnknown ExecutionStatus = iota
+	ExecutionStatusPending
+	ExecutionStatusInProgress
+	ExecutionStatusCompleted
+	ExecutionStatusFailed
+	ExecutionStatusCancelled
+)
+
+// RuleSchedule defines when a rule should be executed.
+type RuleSchedule struct {
+	CronExpression string     `json:"cron_expression,omitempty"`
+	Timezone       string     `json:"timezone,omitempty"`
+	OneTime        bool       `json:"one_time,omitempty"`
+	ExecuteAfter   *time.Time `json:"execute_after,omitempty"`
+}
+
+// Rule defines a business rule that can be applied to a portfolio.
+type Rule struct {
+	ID            string
+	Name          string
+	Description   string
+	RuleType      string // e.g. "monthly_withdrawal", "target_allocation", "dca"
+	PortfolioID   string
+	UserID        string
+	Status        RuleStatus
+	Configuration map[string]any // Rule type specific settings
+	Schedule      *RuleSchedule
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// RuleExecution represents a single execution of a rule.
+type RuleExecution struct {
+	ID                    string
+	RuleID                string
+	PortfolioID           string
+	UserID                string
+	Status                ExecutionStatus
+	StartedAt             time.Time
+	CompletedAt           *time.Time
+	ErrorMessage          string
+	CreatedTransactionIDs []string
+	AffectedHoldingIDs    []string
+	Summary               map[string]any // Plan, progress and results
+	DryRun                bool
+	ExecutionContext      string
+	CancelRequested       bool
+	CreatedBy             string // User who requested the execution, if any
+}
@@ -0,0 +1,207 @@
+// Package pubsub fans out change events to in-process subscribers, such as
+// streaming RPCs, optionally relaying them between replicas through a Broker.
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// subscriptionBuffer is how many undelivered events a subscription holds
+// before newer events are dropped.
+const subscriptionBuffer = 64
+
+// Event is a message published on a topic.
+type Event struct {
+	Topic   string          `json:"topic"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// Broker carries events between replicas.
+type Broker interface {
+	Publish(ctx context.Context, msg []byte) error
+	// Listen delivers messages published by any replica until ctx is
+	// cancelled or the connection fails.
+	Listen(ctx context.Context, handle func(msg []byte)) error
+}
+
+// envelope tags relayed events with the hub that published them, so a hub
+// does not deliver its own events twice.
+type envelope struct {
+	Origin string `json:"origin"`
+	Event
+}
+
+// Hub delivers published events to subscribers of their topic. A nil Hub
+// discards everything published to it.
+type Hub struct {
+	id  string
+	log *slog.Logger
+
+	mu     sync.RWMutex
+	subs   map[string]map[*Subscription]struct{}
+	broker Broker
+}
+
+func NewHub(log *slog.Logger) *Hub {
+	return &Hub{
+		id:   uuid.NewString(),
+		log:  log,
+		subs: map[string]map[*Subscription]struct{}{},
+	}
+}
+
+// Publish delivers payload, marshaled as JSON, to subscribers of topic in this
+// process and, when a broker is connected, in other replicas.
+func (h *Hub) Publish(ctx context.Context, topic string, payload any) {
+	if h == nil {
+		return
+	}
+
+	event := Event{Topic: topic}
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			h.log.Error("failed to marshal event", "topic", topic, "error", err)
+			return
+		}
+		event.Payload = raw
+	}
+
+	h.deliver(event)
+
+	h.mu.RLock()
+	broker := h.broker
+	h.mu.RUnlock()
+	if broker == nil {
+		return
+	}
+
+	msg, err := json.Marshal(envelope{Origin: h.id, Event: event})
+	if err == nil {
+		err = broker.Publish(context.WithoutCancel(ctx), msg)
+	}
+	if err != nil {
+		h.log.Warn("failed to relay event", "topic", topic, "error", err)
+	}
+}
+
+// Subscribe returns a subscription to events on any of topics. Close it when done.
+func (h *Hub) Subscribe(topics ...string) *Subscription {
+	ch := make(chan Event, subscriptionBuffer)
+	s := &Subscription{C: ch, ch: ch, hub: h, topics: topics}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, t := range topics {
+		if h.subs[t] == nil {
+			h.subs[t] = map[*Subscription]struct{}{}
+		}
+		h.subs[t][s] = struct{}{}
+	}
+	return s
+}
+
+// Close ends every subscription, letting streams finish before shutdown.
+func (h *Hub) Close() {
+	h.mu.RLock()
+	subs := map[*Subscription]struct{}{}
+	for _, topicSubs := range h.subs {
+		for s := range topicSubs {
+			subs[s] = struct{}{}
+		}
+	}
+	h.mu.RUnlock()
+
+	for s := range subs {
+		s.Close()
+	}
+}
+
+// Run relays events through broker until ctx is cancelled, reconnecting after
+// retryDelay when the broker connection fails.
+func (h *Hub) Run(ctx context.Context, broker Broker, retryDelay time.Duration) error {
+	h.mu.Lock()
+	h.broker = broker
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		h.broker = nil
+		h.mu.Unlock()
+	}()
+
+	for {
+		err := broker.Listen(ctx, h.receive)
+		if ctx.Err() != nil {
+			return nil
+		}
+		h.log.Warn("event relay disconnected, reconnecting", "error", err, "retry_in", retryDelay)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+func (h *Hub) receive(msg []byte) {
+	var env envelope
+	if err := json.Unmarshal(msg, &env); err != nil {
+		h.log.Warn("dropping malformed relayed event", "error", err)
+		return
+	}
+	if env.Origin == h.id {
+		return
+	}
+	h.deliver(env.Event)
+}
+
+func (h *Hub) deliver(event Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for s := range h.subs[event.Topic] {
+		select {
+		case s.ch <- event:
+		default:
+			h.log.Debug("subscriber is falling behind, dropping event", "topic", event.Topic)
+		}
+	}
+}
+
+// Subscription receives events on C until it or its hub is closed. Events are dropped
+// when the subscriber falls behind by more than a small buffer.
+type Subscription struct {
+	C <-chan Event
+
+	ch     chan Event
+	hub    *Hub
+	topics []string
+	once   sync.Once
+}
+
+// Close unsubscribes and closes C.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.hub.mu.Lock()
+		defer s.hub.mu.Unlock()
+		for _, t := range s.topics {
+			delete(s.hub.subs[t], s)
+			if len(s.hub.subs[t]) == 0 {
+				delete(s.hub.subs, t)
+			}
+		}
+		close(s.ch)
+	})
+}
+
+// Topic joins parts into a topic name.
+func Topic(parts ...string) string {
+	return strings.Join(parts, ".")
+}
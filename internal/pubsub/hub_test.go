@@ -0,0 +1,133 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHub() *Hub {
+	return NewHub(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func receive(t *testing.T, sub *Subscription) Event {
+	t.Helper()
+	select {
+	case e := <-sub.C:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return Event{}
+	}
+}
+
+func TestHub_DeliversByTopic(t *testing.T) {
+	hub := newTestHub()
+	btc := hub.Subscribe(Topic("prices", "btc", "usd"))
+	defer btc.Close()
+	eth := hub.Subscribe(Topic("prices", "eth", "usd"))
+	defer eth.Close()
+
+	hub.Publish(context.Background(), "prices.btc.usd", map[string]int{"last": 100})
+
+	e := receive(t, btc)
+	assert.Equal(t, "prices.btc.usd", e.Topic)
+	assert.JSONEq(t, `{"last":100}`, string(e.Payload))
+	assert.Empty(t, eth.C)
+}
+
+func TestHub_DropsWhenSubscriberFallsBehind(t *testing.T) {
+	hub := newTestHub()
+	sub := hub.Subscribe("t")
+	defer sub.Close()
+
+	for i := 0; i < subscriptionBuffer+10; i++ {
+		hub.Publish(context.Background(), "t", i)
+	}
+
+	assert.Len(t, sub.C, subscriptionBuffer)
+}
+
+func TestHub_CloseEndsSubscriptions(t *testing.T) {
+	hub := newTestHub()
+	sub := hub.Subscribe("a", "b")
+
+	hub.Close()
+	_, ok := <-sub.C
+	assert.False(t, ok)
+	assert.Empty(t, hub.subs)
+
+	sub.Close() // closing twice is safe
+}
+
+func TestNilHub_DiscardsEvents(t *testing.T) {
+	var hub *Hub
+	hub.Publish(context.Background(), "t", 1)
+}
+
+// memoryBroker connects hubs as if they ran in separate replicas.
+type memoryBroker struct {
+	messages chan []byte
+}
+
+func (b *memoryBroker) Publish(_ context.Context, msg []byte) error {
+	b.messages <- msg
+	return nil
+}
+
+func (b *memoryBroker) Listen(ctx context.Context, handle func([]byte)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg := <-b.messages:
+			handle(msg)
+		}
+	}
+}
+
+func TestHub_RelaysThroughBroker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := &memoryBroker{messages: make(chan []byte, 10)}
+	local, remote := newTestHub(), newTestHub()
+	go func() { _ = remote.Run(ctx, broker, time.Millisecond) }()
+
+	// Only the publishing hub needs a broker to send; wait until Run sets it on remote.
+	local.broker = broker
+	require.Eventually(t, func() bool {
+		remote.mu.RLock()
+		defer remote.mu.RUnlock()
+		return remote.broker != nil
+	}, time.Second, time.Millisecond)
+
+	localSub := local.Subscribe("t")
+	defer localSub.Close()
+	remoteSub := remote.Subscribe("t")
+	defer remoteSub.Close()
+
+	local.Publish(ctx, "t", "hello")
+
+	assert.JSONEq(t, `"hello"`, string(receive(t, localSub).Payload))
+	assert.JSONEq(t, `"hello"`, string(receive(t, remoteSub).Payload))
+
+	// Events relayed back to their origin are not delivered twice.
+	remote.receive(mustEnvelope(t, local.id, "t"))
+	assert.Len(t, remoteSub.C, 1)
+	local.receive(mustEnvelope(t, local.id, "t"))
+	assert.Empty(t, localSub.C)
+}
+
+func mustEnvelope(t *testing.T, origin, topic string) []byte {
+	t.Helper()
+	msg, err := json.Marshal(envelope{Origin: origin, Event: Event{Topic: topic}})
+	require.NoError(t, err)
+	return msg
+}
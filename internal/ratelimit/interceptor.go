@@ -0,0 +1,171 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/foxcool/greedy-eye/internal/auth"
+)
+
+// Config sets the limit of each procedure class, and of each client address
+// before authentication.
+type Config struct {
+	Read      Limit `koanf:"read"`
+	Write     Limit `koanf:"write"`
+	Expensive Limit `koanf:"expensive"`
+	IP        Limit `koanf:"ip"`
+}
+
+func (c Config) limit(class Class) Limit {
+	switch class {
+	case ClassRead:
+		return c.Read
+	case ClassExpensive:
+		return c.Expensive
+	default:
+		return c.Write
+	}
+}
+
+// Interceptor rejects calls over their caller's limit with
+// CodeResourceExhausted and a Retry-After header in seconds. It must run
+// after the auth interceptor, which attaches the principal, except when it
+// limits client addresses. If the store fails, calls are let through.
+type Interceptor struct {
+	store   Store
+	config  Config
+	proxies auth.Proxies
+	perIP   bool
+	log     *slog.Logger
+}
+
+// Compile-time interface implementation check.
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+// NewInterceptor creates a rate limiting interceptor. Client addresses are
+// taken from X-Forwarded-For only behind proxies.
+func NewInterceptor(store Store, config Config, proxies auth.Proxies, log *slog.Logger) *Interceptor {
+	return &Interceptor{store: store, config: config, proxies: proxies, log: log}
+}
+
+// NewIPInterceptor creates an interceptor limiting all calls from a client
+// address to config.IP. It runs before the auth interceptor, so calls with
+// bad credentials, which never reach a per-caller limit, are throttled too.
+func NewIPInterceptor(store Store, config Config, proxies auth.Proxies, log *slog.Logger) *Interceptor {
+	return &Interceptor{store: store, config: config, proxies: proxies, perIP: true, log: log}
+}
+
+func (i *Interceptor) take(ctx context.Context, procedure string, header http.Header, peerAddr string) error {
+	name, limit, key := i.bucket(ctx, procedure, header, peerAddr)
+	if limit.Rate <= 0 {
+		return nil
+	}
+
+	ok, retryAfter, err := i.store.Take(ctx, key, limit)
+	if err != nil {
+		i.log.Error("Failed to check rate limit", slog.String("key", key), slog.Any("error", err))
+		return nil
+	}
+	if ok {
+		return nil
+	}
+
+	connectErr := connect.NewError(connect.CodeResourceExhausted,
+		fmt.Errorf("%s rate limit exceeded, retry in %s", name, retryAfter.Round(time.Millisecond)))
+	connectErr.Meta().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	return connectErr
+}
+
+// bucket returns the name and limit of the bucket a call takes a token
+// from, and its key.
+func (i *Interceptor) bucket(ctx context.Context, procedure string, header http.Header, peerAddr string) (string, Limit, string) {
+	if i.perIP {
+		return "client address", i.config.IP, "ip:" + i.proxies.ClientIP(header, peerAddr)
+	}
+	class := Classify(procedure)
+	return string(class), i.config.limit(class), string(class) + ":" + i.caller(ctx, header, peerAddr)
+}
+
+// caller identifies who a call counts against.
+func (i *Interceptor) caller(ctx context.Context, header http.Header, peerAddr string) string {
+	if p, ok := auth.FromContext(ctx); ok {
+		if p.CredentialID != "" {
+			return "key:" + p.CredentialID
+		}
+		if p.UserID != "" {
+			return "user:" + p.UserID
+		}
+	}
+	return "ip:" + i.proxies.ClientIP(header, peerAddr)
+}
+
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		if err := i.take(ctx, req.Spec().Procedure, req.Header(), req.Peer().Addr); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler counts opening a stream as one call.
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := i.take(ctx, conn.Spec().Procedure, conn.RequestHeader(), conn.Peer().Addr); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// Wrap applies the interceptor to a plain HTTP route serving the same
+// content as procedure. Unless it limits client addresses, it must be
+// wrapped by the auth interceptor's Wrap.
+func (i *Interceptor) Wrap(procedure string, next http.Handler) http.Handler {
+	errWriter := connect.NewErrorWriter()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := i.take(r.Context(), procedure, r.Header, r.RemoteAddr); err != nil {
+			_ = errWriter.Write(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Run prunes idle buckets every interval until ctx is done. Buckets of
+// every limit in the config are kept until they would be full, so one Run
+// serves interceptors sharing a store.
+func (i *Interceptor) Run(ctx context.Context, interval time.Duration) {
+	var idle time.Duration
+	for _, l := range []Limit{i.config.Read, i.config.Write, i.config.Expensive, i.config.IP} {
+		if l.Rate > 0 {
+			idle = max(idle, l.refillTime())
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := i.store.Prune(ctx, idle); err != nil {
+			i.log.Error("Failed to prune rate limit buckets", slog.Any("error", err))
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Package ratelimit throttles Connect calls with token buckets, one per
+// caller and procedure class. Callers are identified by API key, user or
+// client IP, in that order of preference.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/auth"
+)
+
+// Class groups procedures that share a limit.
+type Class string
+
+const (
+	ClassRead      Class = "read"
+	ClassWrite     Class = "write"
+	ClassExpensive Class = "expensive"
+)
+
+// Classify returns the class of the Connect procedure, as described by
+// auth.Describe.
+func Classify(procedure string) Class {
+	p, _ := auth.Describe(procedure)
+	switch {
+	case p.Expensive:
+		return ClassExpensive
+	case p.ReadOnly:
+		return ClassRead
+	default:
+		return ClassWrite
+	}
+}
+
+// Limit is a token bucket: it refills at Rate tokens per second up to Burst
+// tokens, and every call takes one. A zero Rate means no limit.
+type Limit struct {
+	Rate  float64 `koanf:"rate"`
+	Burst int     `koanf:"burst"`
+}
+
+func (l Limit) burst() float64 {
+	return float64(max(l.Burst, 1))
+}
+
+// refillTime is how long an empty bucket takes to fill up. A bucket idle for
+// that long is indistinguishable from a new one.
+func (l Limit) refillTime() time.Duration {
+	return time.Duration(l.burst() / l.Rate * float64(time.Second))
+}
+
+// Store keeps token buckets.
+type Store interface {
+	// Take takes a token from the bucket at key. When the bucket is empty it
+	// returns false and how long until a token is available.
+	Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error)
+	// Prune forgets buckets untouched for longer than idle.
+	Prune(ctx context.Context, idle time.Duration) error
+}
+
+// Memory keeps buckets in process memory, so each replica limits on its own.
+type Memory struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Compile-time interface implementation check.
+var _ Store = (*Memory)(nil)
+
+func NewMemory() *Memory {
+	return &Memory{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+func (m *Memory) Take(_ context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: limit.burst(), updated: now}
+		m.buckets[key] = b
+	}
+	b.tokens = math.Min(limit.burst(), b.tokens+now.Sub(b.updated).Seconds()*limit.Rate)
+	b.updated = now
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second)), nil
+	}
+	b.tokens--
+	return true, 0, nil
+}
+
+func (m *Memory) Prune(_ context.Context, idle time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cutoff := m.now().Add(-idle)
+	for key, b := range m.buckets {
+		if b.updated.Before(cutoff) {
+			delete(m.buckets, key)
+		}
+	}
+	return nil
+}
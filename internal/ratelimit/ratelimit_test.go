@@ -0,0 +1,178 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/api/v1/apiv1connect"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	assert.Equal(t, ClassRead, Classify(apiv1connect.PortfolioServiceListPortfoliosProcedure))
+	assert.Equal(t, ClassRead, Classify(apiv1connect.MarketDataServiceWatchPricesProcedure))
+	assert.Equal(t, ClassWrite, Classify(apiv1connect.PortfolioServiceCreatePortfolioProcedure))
+	assert.Equal(t, ClassExpensive, Classify(apiv1connect.MarketDataServiceFetchExternalPricesProcedure))
+	assert.Equal(t, ClassExpensive, Classify(apiv1connect.PortfolioServiceCalculatePortfolioValueProcedure))
+	assert.Equal(t, ClassExpensive, Classify(apiv1connect.AutomationServiceSimulateRuleProcedure))
+	assert.Equal(t, ClassExpensive, Classify(apiv1connect.PortfolioServiceExportPortfolioProcedure))
+	assert.Equal(t, ClassExpensive, Classify(apiv1connect.PortfolioServiceGenerateTaxReportProcedure))
+	assert.Equal(t, ClassExpensive, Classify(apiv1connect.MarketDataServiceImportExternalAssetProcedure))
+	assert.Equal(t, ClassRead, Classify(apiv1connect.MarketDataServiceSearchAssetsProcedure))
+	assert.Equal(t, ClassExpensive, Classify(apiv1connect.MarketDataServiceApplyCorporateActionProcedure))
+}
+
+func TestMemory_Take(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(0, 0)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+	limit := Limit{Rate: 2, Burst: 3}
+
+	for range 3 {
+		ok, _, err := m.Take(ctx, "k", limit)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+	ok, retryAfter, err := m.Take(ctx, "k", limit)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	// Other keys have buckets of their own.
+	ok, _, _ = m.Take(ctx, "other", limit)
+	assert.True(t, ok)
+
+	// Tokens refill at the rate, but never beyond the burst.
+	now = now.Add(250 * time.Millisecond)
+	ok, retryAfter, _ = m.Take(ctx, "k", limit)
+	assert.False(t, ok)
+	assert.Equal(t, 250*time.Millisecond, retryAfter)
+	now = now.Add(250 * time.Millisecond)
+	ok, _, _ = m.Take(ctx, "k", limit)
+	assert.True(t, ok)
+
+	now = now.Add(time.Hour)
+	for range 3 {
+		ok, _, _ = m.Take(ctx, "k", limit)
+		assert.True(t, ok)
+	}
+	ok, _, _ = m.Take(ctx, "k", limit)
+	assert.False(t, ok)
+
+	require.NoError(t, m.Prune(ctx, time.Minute))
+	assert.Len(t, m.buckets, 1, "the idle bucket is pruned")
+}
+
+// portfolioService answers every read.
+type portfolioService struct {
+	apiv1connect.UnimplementedPortfolioServiceHandler
+}
+
+func (portfolioService) GetPortfolio(context.Context, *connect.Request[apiv1.GetPortfolioRequest]) (*connect.Response[apiv1.Portfolio], error) {
+	return connect.NewResponse(&apiv1.Portfolio{}), nil
+}
+
+func (portfolioService) CreatePortfolio(_ context.Context, req *connect.Request[apiv1.CreatePortfolioRequest]) (*connect.Response[apiv1.Portfolio], error) {
+	return connect.NewResponse(req.Msg.Portfolio), nil
+}
+
+func TestInterceptor(t *testing.T) {
+	ctx := context.Background()
+	// Callers name their credential in a header, standing in for the auth
+	// interceptor.
+	fakeAuth := connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if key := req.Header().Get("X-Key"); key != "" {
+				ctx = auth.WithPrincipal(ctx, &auth.Principal{UserID: "u1", CredentialID: key})
+			}
+			return next(ctx, req)
+		}
+	})
+	limiter := NewInterceptor(NewMemory(), Config{
+		Read:  Limit{Rate: 0.01, Burst: 2},
+		Write: Limit{},
+	}, nil, slog.New(slog.DiscardHandler))
+
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewPortfolioServiceHandler(portfolioService{}, connect.WithInterceptors(fakeAuth, limiter)))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := apiv1connect.NewPortfolioServiceClient(srv.Client(), srv.URL)
+
+	get := func(key string) error {
+		req := connect.NewRequest(&apiv1.GetPortfolioRequest{Id: "p1"})
+		if key != "" {
+			req.Header().Set("X-Key", key)
+		}
+		_, err := client.GetPortfolio(ctx, req)
+		return err
+	}
+
+	require.NoError(t, get("key-1"))
+	require.NoError(t, get("key-1"))
+	err := get("key-1")
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, "100", connectErr.Meta().Get("Retry-After"))
+
+	// Each key, and each anonymous client address, has its own bucket.
+	require.NoError(t, get("key-2"))
+	require.NoError(t, get(""))
+	require.NoError(t, get(""))
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(get("")))
+
+	// A zero rate leaves the class unlimited.
+	for range 5 {
+		req := connect.NewRequest(&apiv1.CreatePortfolioRequest{Portfolio: &apiv1.Portfolio{Name: "p"}})
+		req.Header().Set("X-Key", "key-1")
+		_, err := client.CreatePortfolio(ctx, req)
+		require.NoError(t, err)
+	}
+}
+
+func TestIPInterceptor(t *testing.T) {
+	ctx := context.Background()
+	// Every call is rejected after the address limit, standing in for bad
+	// credentials.
+	rejectAll := connect.UnaryInterceptorFunc(func(connect.UnaryFunc) connect.UnaryFunc {
+		return func(context.Context, connect.AnyRequest) (connect.AnyResponse, error) {
+			return nil, connect.NewError(connect.CodeUnauthenticated, nil)
+		}
+	})
+	proxies, err := auth.ParseProxies("127.0.0.1")
+	require.NoError(t, err)
+	limiter := NewIPInterceptor(NewMemory(), Config{
+		Read: Limit{Rate: 100, Burst: 100},
+		IP:   Limit{Rate: 0.01, Burst: 2},
+	}, proxies, slog.New(slog.DiscardHandler))
+
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewPortfolioServiceHandler(portfolioService{}, connect.WithInterceptors(limiter, rejectAll)))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := apiv1connect.NewPortfolioServiceClient(srv.Client(), srv.URL)
+
+	get := func(ip, credential string) connect.Code {
+		req := connect.NewRequest(&apiv1.GetPortfolioRequest{Id: "p1"})
+		req.Header().Set("X-Forwarded-For", ip)
+		req.Header().Set("Authorization", "Bearer "+credential)
+		_, err := client.GetPortfolio(ctx, req)
+		return connect.CodeOf(err)
+	}
+
+	// Changing credentials does not escape the limit of an address.
+	assert.Equal(t, connect.CodeUnauthenticated, get("203.0.113.7", "a"))
+	assert.Equal(t, connect.CodeUnauthenticated, get("203.0.113.7", "b"))
+	assert.Equal(t, connect.CodeResourceExhausted, get("203.0.113.7", "c"))
+	assert.Equal(t, connect.CodeUnauthenticated, get("203.0.113.8", "a"))
+}
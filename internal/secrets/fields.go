@@ -0,0 +1,53 @@
+package secrets
+
+import "strings"
+
+// Redacted replaces secret values in API responses. Sending it back in an
+// update keeps the stored value.
+const Redacted = "********"
+
+// secretFields are the credential field names that must be encrypted,
+// lowercased without separators. Names are matched exactly, so identifiers
+// like "key_id" or "public_key" stay plain.
+var secretFields = map[string]bool{
+	"apikey":         true,
+	"apisecret":      true,
+	"apipassphrase":  true,
+	"secret":         true,
+	"secretkey":      true,
+	"privatekey":     true,
+	"token":          true,
+	"accesstoken":    true,
+	"refreshtoken":   true,
+	"authtoken":      true,
+	"password":       true,
+	"passphrase":     true,
+	"seed":           true,
+	"seedphrase":     true,
+	"mnemonic":       true,
+	"walletmnemonic": true,
+}
+
+// IsSecretField reports whether a credential field must be encrypted, going by
+// its name: "api_key", "apiSecret", "access_token", "passphrase" and so on.
+func IsSecretField(name string) bool {
+	return secretFields[strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))]
+}
+
+// Split separates secret fields from plain ones.
+func Split(data map[string]string) (plain, secret map[string]string) {
+	for k, v := range data {
+		if IsSecretField(k) {
+			if secret == nil {
+				secret = map[string]string{}
+			}
+			secret[k] = v
+			continue
+		}
+		if plain == nil {
+			plain = map[string]string{}
+		}
+		plain[k] = v
+	}
+	return plain, secret
+}
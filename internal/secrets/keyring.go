@@ -0,0 +1,216 @@
+// Package secrets encrypts credentials at rest with envelope encryption:
+// each value is sealed with its own AES-256-GCM data key, and the data key is
+// wrapped with a versioned master key. Rotating the master key only rewraps
+// data keys; the sealed values are left untouched.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const keySize = 32
+
+// ErrNoKey is returned when sealed data references a master key version the
+// keyring does not hold, or when the keyring is empty.
+var ErrNoKey = errors.New("master key not available")
+
+// Sealed is an encrypted value together with its wrapped data key.
+type Sealed struct {
+	// KeyVersion is the master key version that wrapped DataKey.
+	KeyVersion uint32
+	DataKey    []byte
+	Ciphertext []byte
+}
+
+// Keyring holds the master keys. New data is wrapped with the highest version.
+type Keyring struct {
+	keys    map[uint32]cipher.AEAD
+	current uint32
+}
+
+// NewKeyring returns a keyring over 32-byte master keys indexed by version.
+func NewKeyring(keys map[uint32][]byte) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKey
+	}
+	k := &Keyring{keys: make(map[uint32]cipher.AEAD, len(keys))}
+	for version, key := range keys {
+		if version == 0 {
+			return nil, errors.New("master key versions start at 1")
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("master key %d: %w", version, err)
+		}
+		k.keys[version] = aead
+		k.current = max(k.current, version)
+	}
+	return k, nil
+}
+
+// ParseKeys parses master keys written as comma or newline separated
+// "version:base64key" pairs, e.g. "1:...,2:...".
+func ParseKeys(spec string) (map[uint32][]byte, error) {
+	keys := map[uint32][]byte{}
+	for _, entry := range strings.FieldsFunc(spec, func(r rune) bool { return r == ',' || r == '\n' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		v, encoded, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("master key %q: want version:base64key", entry)
+		}
+		version, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("master key version %q: %w", v, err)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("master key %d: %w", version, err)
+		}
+		if _, dup := keys[uint32(version)]; dup {
+			return nil, fmt.Errorf("master key %d given twice", version)
+		}
+		keys[uint32(version)] = key
+	}
+	return keys, nil
+}
+
+// LoadKeyring builds a keyring from inline keys and a key file, both in the
+// ParseKeys format. It returns nil when neither is set.
+func LoadKeyring(inline, file string) (*Keyring, error) {
+	spec := inline
+	if file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read master key file: %w", err)
+		}
+		spec += "\n" + string(content)
+	}
+	keys, err := ParseKeys(spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	return NewKeyring(keys)
+}
+
+// CurrentVersion is the master key version new data keys are wrapped with.
+func (k *Keyring) CurrentVersion() uint32 {
+	return k.current
+}
+
+// Seal encrypts plaintext under a fresh data key. aad binds the ciphertext to
+// its context, such as the owning row's ID, and must be passed to Open again.
+func (k *Keyring) Seal(plaintext, aad []byte) (*Sealed, error) {
+	dataKey := make([]byte, keySize)
+	// crypto/rand.Read never returns an error.
+	_, _ = rand.Read(dataKey)
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := k.wrap(k.current, dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sealed{
+		KeyVersion: k.current,
+		DataKey:    wrapped,
+		Ciphertext: seal(aead, plaintext, aad),
+	}, nil
+}
+
+// Open decrypts sealed data.
+func (k *Keyring) Open(s *Sealed, aad []byte) ([]byte, error) {
+	dataKey, err := k.unwrap(s.KeyVersion, s.DataKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(aead, s.Ciphertext, aad)
+}
+
+// Rewrap rewraps the data key of s with the current master key. It reports
+// false when s already uses the current version.
+func (k *Keyring) Rewrap(s *Sealed) (*Sealed, bool, error) {
+	if s.KeyVersion == k.current {
+		return s, false, nil
+	}
+	dataKey, err := k.unwrap(s.KeyVersion, s.DataKey)
+	if err != nil {
+		return nil, false, err
+	}
+	wrapped, err := k.wrap(k.current, dataKey)
+	if err != nil {
+		return nil, false, err
+	}
+	return &Sealed{KeyVersion: k.current, DataKey: wrapped, Ciphertext: s.Ciphertext}, true, nil
+}
+
+func (k *Keyring) wrap(version uint32, dataKey []byte) ([]byte, error) {
+	aead, ok := k.keys[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: version %d", ErrNoKey, version)
+	}
+	return seal(aead, dataKey, versionAAD(version)), nil
+}
+
+func (k *Keyring) unwrap(version uint32, wrapped []byte) ([]byte, error) {
+	aead, ok := k.keys[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: version %d", ErrNoKey, version)
+	}
+	dataKey, err := open(aead, wrapped, versionAAD(version))
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key: %w", err)
+	}
+	return dataKey, nil
+}
+
+func versionAAD(version uint32) []byte {
+	return binary.BigEndian.AppendUint32([]byte("greedy-eye/data-key/"), version)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", keySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal returns nonce || ciphertext.
+func seal(aead cipher.AEAD, plaintext, aad []byte) []byte {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	_, _ = rand.Read(nonce)
+	return aead.Seal(nonce, nonce, plaintext, aad)
+}
+
+func open(aead cipher.AEAD, sealed, aad []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, aad)
+}
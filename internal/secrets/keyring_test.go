@@ -0,0 +1,123 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, keySize)
+}
+
+func TestKeyring_SealOpen(t *testing.T) {
+	k, err := NewKeyring(map[uint32][]byte{1: testKey(1)})
+	require.NoError(t, err)
+
+	sealed, err := k.Seal([]byte(`{"api_secret":"s3cr3t"}`), []byte("account-1"))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), sealed.KeyVersion)
+	assert.NotContains(t, string(sealed.Ciphertext), "s3cr3t")
+
+	plaintext, err := k.Open(sealed, []byte("account-1"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"api_secret":"s3cr3t"}`, string(plaintext))
+
+	// Sealed data is bound to its context.
+	_, err = k.Open(sealed, []byte("account-2"))
+	assert.Error(t, err)
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	old, err := NewKeyring(map[uint32][]byte{1: testKey(1)})
+	require.NoError(t, err)
+	sealed, err := old.Seal([]byte("secret"), nil)
+	require.NoError(t, err)
+
+	k, err := NewKeyring(map[uint32][]byte{1: testKey(1), 2: testKey(2)})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), k.CurrentVersion())
+
+	// Data wrapped with an older key stays readable.
+	plaintext, err := k.Open(sealed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+
+	rewrapped, changed, err := k.Rewrap(sealed)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, uint32(2), rewrapped.KeyVersion)
+	assert.Equal(t, sealed.Ciphertext, rewrapped.Ciphertext)
+
+	_, changed, err = k.Rewrap(rewrapped)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// Once rewrapped, the old key can be dropped.
+	current, err := NewKeyring(map[uint32][]byte{2: testKey(2)})
+	require.NoError(t, err)
+	plaintext, err = current.Open(rewrapped, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+
+	_, err = current.Open(sealed, nil)
+	assert.True(t, errors.Is(err, ErrNoKey))
+}
+
+func TestNewKeyring_Invalid(t *testing.T) {
+	_, err := NewKeyring(nil)
+	assert.ErrorIs(t, err, ErrNoKey)
+
+	_, err = NewKeyring(map[uint32][]byte{1: []byte("short")})
+	assert.Error(t, err)
+
+	_, err = NewKeyring(map[uint32][]byte{0: testKey(1)})
+	assert.Error(t, err)
+}
+
+func TestLoadKeyring(t *testing.T) {
+	k1 := base64.StdEncoding.EncodeToString(testKey(1))
+	k2 := base64.StdEncoding.EncodeToString(testKey(2))
+
+	k, err := LoadKeyring("", "")
+	require.NoError(t, err)
+	assert.Nil(t, k)
+
+	file := filepath.Join(t.TempDir(), "master.keys")
+	require.NoError(t, os.WriteFile(file, []byte("# rotated 2026-10\n2:"+k2+"\n"), 0o600))
+
+	k, err = LoadKeyring("1:"+k1, file)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), k.CurrentVersion())
+
+	_, err = LoadKeyring("1:"+k1+",1:"+k2, "")
+	assert.Error(t, err)
+
+	_, err = LoadKeyring(k1, "")
+	assert.Error(t, err)
+}
+
+func TestIsSecretField(t *testing.T) {
+	for _, name := range []string{"api_key", "apiKey", "api_secret", "secret", "access_token", "password", "passphrase", "seed", "wallet-mnemonic"} {
+		assert.True(t, IsSecretField(name), name)
+	}
+	for _, name := range []string{"address", "key_id", "public_key", "publicKey", "exchange", "user_id", "network", "monkey"} {
+		assert.False(t, IsSecretField(name), name)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	plain, secret := Split(map[string]string{"address": "0xabc", "api_key": "k", "api_secret": "s"})
+	assert.Equal(t, map[string]string{"address": "0xabc"}, plain)
+	assert.Equal(t, map[string]string{"api_key": "k", "api_secret": "s"}, secret)
+
+	plain, secret = Split(nil)
+	assert.Nil(t, plain)
+	assert.Nil(t, secret)
+}
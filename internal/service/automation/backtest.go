@@ -0,0 +1,494 @@
+package automation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/foxcool/greedy-eye/internal/service/portfolio"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Cadences at which scheduled strategies act during a backtest.
+const (
+	IntervalDaily   = "daily"
+	IntervalWeekly  = "weekly"
+	IntervalMonthly = "monthly"
+)
+
+// periodKey identifies the calendar period containing t, so a strategy acts
+// once per period on the first price bar inside it.
+func periodKey(interval string, t time.Time) string {
+	switch interval {
+	case IntervalDaily:
+		return t.Format("2006-01-02")
+	case IntervalWeekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	default:
+		return t.Format("2006-01")
+	}
+}
+
+// backtestStrategy decides the trades of a rule at every price bar.
+type backtestStrategy interface {
+	// assets lists the assets the strategy needs prices for.
+	assets() []string
+	// benchmarkWeights splits initial cash across assets for the buy-and-hold
+	// comparison; nil keeps it in cash.
+	benchmarkWeights() map[string]decimal.Decimal
+	step(bt *backtest, at time.Time)
+}
+
+// backtestCosts models the cost of every simulated trade.
+type backtestCosts struct {
+	feeRate  decimal.Decimal // fraction of the trade value
+	fixedFee decimal.Decimal // per trade, in the currency asset
+	slippage decimal.Decimal // fraction the fill price moves against the trade
+}
+
+type pricePoint struct {
+	at    time.Time
+	price decimal.Decimal
+}
+
+type backtestTrade struct {
+	at      time.Time
+	assetID string
+	side    string
+	amount  decimal.Decimal
+	price   decimal.Decimal
+	value   decimal.Decimal
+	fee     decimal.Decimal
+	reason  string
+}
+
+type equityPoint struct {
+	at        time.Time
+	value     decimal.Decimal
+	benchmark decimal.Decimal
+}
+
+type backtestInput struct {
+	strategy backtestStrategy
+	currency string
+	holdings map[string]decimal.Decimal
+	// prices holds each asset's series in the currency asset, ordered by time.
+	prices map[string][]pricePoint
+	costs  backtestCosts
+}
+
+type backtestResult struct {
+	equity   []equityPoint
+	trades   []*backtestTrade
+	holdings map[string]decimal.Decimal
+	prices   map[string]decimal.Decimal
+	fees     decimal.Decimal
+	warnings []string
+}
+
+// backtest is the simulated portfolio a strategy trades against.
+type backtest struct {
+	currency string
+	costs    backtestCosts
+	holdings map[string]decimal.Decimal
+	prices   map[string]decimal.Decimal
+	trades   []*backtestTrade
+	fees     decimal.Decimal
+	warnings []string
+	warned   map[string]bool
+}
+
+// runBacktest replays the price series bar by bar. It reads nothing but its
+// input, so the same input always produces the same result.
+func runBacktest(in backtestInput) *backtestResult {
+	bt := &backtest{
+		currency: in.currency,
+		costs:    in.costs,
+		holdings: copyAmounts(in.holdings),
+		prices:   map[string]decimal.Decimal{},
+		warned:   map[string]bool{},
+	}
+	bench := &benchmark{holdings: copyAmounts(in.holdings), weights: in.strategy.benchmarkWeights()}
+
+	cursor := map[string]int{}
+	var equity []equityPoint
+	for _, at := range timeline(in.prices) {
+		for asset, series := range in.prices {
+			i := cursor[asset]
+			for i < len(series) && !series[i].at.After(at) {
+				bt.prices[asset] = series[i].price
+				i++
+			}
+			cursor[asset] = i
+		}
+
+		if len(equity) == 0 {
+			for _, asset := range sortedKeys(bt.holdings) {
+				if _, ok := bt.price(asset); !ok && bt.holdings[asset].IsPositive() {
+					bt.warn("unpriced:"+asset, "asset %s has no price at the start of the range and is valued at zero until its first price", asset)
+				}
+			}
+		}
+
+		bench.invest(bt)
+		in.strategy.step(bt, at)
+		equity = append(equity, equityPoint{at: at, value: bt.value(bt.holdings), benchmark: bt.value(bench.holdings)})
+	}
+
+	if len(equity) == 0 {
+		bt.warn("empty", "no prices in the requested range")
+	}
+
+	return &backtestResult{
+		equity:   equity,
+		trades:   bt.trades,
+		holdings: bt.holdings,
+		prices:   bt.prices,
+		fees:     bt.fees,
+		warnings: bt.warnings,
+	}
+}
+
+// timeline returns every distinct bar time across the series in order.
+func timeline(prices map[string][]pricePoint) []time.Time {
+	seen := map[time.Time]bool{}
+	var times []time.Time
+	for _, series := range prices {
+		for _, p := range series {
+			if !seen[p.at] {
+				seen[p.at] = true
+				times = append(times, p.at)
+			}
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times
+}
+
+// benchmark holds the initial portfolio without trading. Initial cash is
+// invested by the strategy's weights, without costs, once all are priced.
+type benchmark struct {
+	holdings map[string]decimal.Decimal
+	weights  map[string]decimal.Decimal
+	invested bool
+}
+
+func (b *benchmark) invest(bt *backtest) {
+	if b.invested || len(b.weights) == 0 {
+		return
+	}
+	for asset := range b.weights {
+		if _, ok := bt.price(asset); !ok {
+			return
+		}
+	}
+	b.invested = true
+
+	cash := b.holdings[bt.currency]
+	for _, asset := range sortedKeys(b.weights) {
+		if asset == bt.currency {
+			continue
+		}
+		price, _ := bt.price(asset)
+		spend := cash.Mul(b.weights[asset])
+		b.holdings[asset] = b.holdings[asset].Add(spend.Div(price))
+		b.holdings[bt.currency] = b.holdings[bt.currency].Sub(spend)
+	}
+}
+
+func (bt *backtest) cash() decimal.Decimal {
+	return bt.holdings[bt.currency]
+}
+
+func (bt *backtest) price(asset string) (decimal.Decimal, bool) {
+	if asset == bt.currency {
+		return decimal.NewFromInt(1), true
+	}
+	p, ok := bt.prices[asset]
+	return p, ok && p.IsPositive()
+}
+
+// value totals holdings at the last known prices; unpriced assets count as zero.
+func (bt *backtest) value(holdings map[string]decimal.Decimal) decimal.Decimal {
+	total := decimal.Zero
+	for asset, amount := range holdings {
+		if price, ok := bt.price(asset); ok {
+			total = total.Add(amount.Mul(price))
+		}
+	}
+	return total
+}
+
+func (bt *backtest) fee(value decimal.Decimal) decimal.Decimal {
+	return bt.costs.fixedFee.Add(value.Mul(bt.costs.feeRate))
+}
+
+// buy spends up to spend of cash, fees included, on asset.
+func (bt *backtest) buy(at time.Time, asset string, spend decimal.Decimal, reason string) {
+	spend = decimal.Min(spend, bt.cash())
+	price, ok := bt.price(asset)
+	if !ok || !spend.IsPositive() {
+		return
+	}
+
+	// spend = value + fixedFee + value*feeRate
+	value := spend.Sub(bt.costs.fixedFee).Div(decimal.NewFromInt(1).Add(bt.costs.feeRate))
+	if !value.IsPositive() {
+		bt.warn("fee:"+reason, "%s trades smaller than their fees were skipped", reason)
+		return
+	}
+	fill := price.Mul(decimal.NewFromInt(1).Add(bt.costs.slippage))
+	amount := value.Div(fill)
+
+	bt.holdings[bt.currency] = bt.cash().Sub(spend)
+	bt.holdings[asset] = bt.holdings[asset].Add(amount)
+	bt.record(&backtestTrade{at: at, assetID: asset, side: "buy", amount: amount, price: fill, value: value, fee: spend.Sub(value), reason: reason})
+}
+
+// sell sells up to amount of asset for cash, net of fees.
+func (bt *backtest) sell(at time.Time, asset string, amount decimal.Decimal, reason string) {
+	amount = decimal.Min(amount, bt.holdings[asset])
+	price, ok := bt.price(asset)
+	if !ok || !amount.IsPositive() {
+		return
+	}
+
+	fill := price.Mul(decimal.NewFromInt(1).Sub(bt.costs.slippage))
+	value := amount.Mul(fill)
+	fee := decimal.Min(bt.fee(value), value)
+
+	bt.holdings[asset] = bt.holdings[asset].Sub(amount)
+	bt.holdings[bt.currency] = bt.cash().Add(value).Sub(fee)
+	bt.record(&backtestTrade{at: at, assetID: asset, side: "sell", amount: amount, price: fill, value: value, fee: fee, reason: reason})
+}
+
+func (bt *backtest) record(t *backtestTrade) {
+	bt.trades = append(bt.trades, t)
+	bt.fees = bt.fees.Add(t.fee)
+}
+
+// warn records a warning once per key.
+func (bt *backtest) warn(key, format string, args ...any) {
+	if bt.warned[key] {
+		return
+	}
+	bt.warned[key] = true
+	bt.warnings = append(bt.warnings, fmt.Sprintf(format, args...))
+}
+
+// backtestStats summarizes an equity curve.
+type backtestStats struct {
+	initial, final         decimal.Decimal
+	returnPct, drawdownPct decimal.Decimal
+	benchFinal             decimal.Decimal
+	benchReturnPct         decimal.Decimal
+	benchDrawdownPct       decimal.Decimal
+}
+
+func summarizeBacktest(equity []equityPoint) backtestStats {
+	var s backtestStats
+	if len(equity) == 0 {
+		return s
+	}
+	first, last := equity[0], equity[len(equity)-1]
+	s.initial, s.final = first.value, last.value
+	s.benchFinal = last.benchmark
+	s.returnPct = percentChange(first.value, last.value)
+	s.benchReturnPct = percentChange(first.benchmark, last.benchmark)
+
+	var peak, benchPeak decimal.Decimal
+	for _, p := range equity {
+		peak = decimal.Max(peak, p.value)
+		benchPeak = decimal.Max(benchPeak, p.benchmark)
+		s.drawdownPct = decimal.Max(s.drawdownPct, percentBelow(peak, p.value))
+		s.benchDrawdownPct = decimal.Max(s.benchDrawdownPct, percentBelow(benchPeak, p.benchmark))
+	}
+	return s
+}
+
+func percentChange(from, to decimal.Decimal) decimal.Decimal {
+	if !from.IsPositive() {
+		return decimal.Zero
+	}
+	return to.Sub(from).Div(from).Mul(decimal.NewFromInt(100))
+}
+
+func percentBelow(peak, v decimal.Decimal) decimal.Decimal {
+	if !peak.IsPositive() {
+		return decimal.Zero
+	}
+	return peak.Sub(v).Div(peak).Mul(decimal.NewFromInt(100))
+}
+
+func copyAmounts(m map[string]decimal.Decimal) map[string]decimal.Decimal {
+	out := make(map[string]decimal.Decimal, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// --- Store-backed backtest ---
+
+// maxBacktestPrices bounds the price points loaded for one backtest.
+const maxBacktestPrices = 200_000
+
+// backtestPageSize is the page size used to read price history.
+const backtestPageSize = 1000
+
+// newBacktestStrategy builds the strategy for a rule and returns the asset
+// its trades settle in.
+func newBacktestStrategy(rule *entity.Rule) (backtestStrategy, string, error) {
+	loc := time.UTC
+	if rule.Schedule != nil && rule.Schedule.Timezone != "" {
+		if l, err := time.LoadLocation(rule.Schedule.Timezone); err == nil {
+			loc = l
+		}
+	}
+
+	switch rule.RuleType {
+	case RuleTypeDCA:
+		cfg, err := parseDCAConfig(rule.Configuration)
+		if err != nil {
+			return nil, "", err
+		}
+		return &dcaStrategy{config: cfg, loc: loc}, cfg.currencyAssetID, nil
+	case RuleTypeRebalancing:
+		cfg, err := parseRebalancingConfig(rule.Configuration)
+		if err != nil {
+			return nil, "", err
+		}
+		return &rebalancingStrategy{config: cfg, loc: loc}, cfg.currencyAssetID, nil
+	case RuleTypeStopLoss:
+		cfg, err := parseStopLossConfig(rule.Configuration)
+		if err != nil {
+			return nil, "", err
+		}
+		return &stopLossStrategy{config: cfg}, cfg.currencyAssetID, nil
+	}
+	return nil, "", errBacktestUnsupported
+}
+
+var errBacktestUnsupported = errors.New("rule type cannot be backtested")
+
+// loadPortfolioAmounts sums the portfolio's current holdings by asset.
+func (h *Handler) loadPortfolioAmounts(ctx context.Context, portfolioID string) (map[string]decimal.Decimal, error) {
+	amounts := map[string]decimal.Decimal{}
+	for token := ""; ; {
+		page, next, err := h.portfolios.ListHoldings(ctx, portfolio.ListHoldingsOpts{PortfolioID: portfolioID, PageToken: token})
+		if err != nil {
+			return nil, err
+		}
+		for _, holding := range page {
+			amounts[holding.AssetID] = amounts[holding.AssetID].Add(amountToDecimal(holding.Amount, holding.Decimals))
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	return amounts, nil
+}
+
+// loadPriceSeries reads every stored price of asset in currency in the range.
+// When several sources share a timestamp, the first source by ID wins.
+func (h *Handler) loadPriceSeries(ctx context.Context, opts marketdata.ListPriceHistoryOpts, budget *int) ([]pricePoint, error) {
+	opts.PageSize = backtestPageSize
+	var series []pricePoint
+	for {
+		page, next, err := h.marketData.ListPriceHistory(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range page {
+			if len(series) > 0 && series[len(series)-1].at.Equal(p.Timestamp) {
+				continue
+			}
+			series = append(series, pricePoint{at: p.Timestamp, price: storedPriceValue(p)})
+		}
+		if *budget -= len(page); *budget < 0 {
+			return nil, fmt.Errorf("%w: more than %d prices in range, narrow the range or use a longer interval", store.ErrInvalidArgument, maxBacktestPrices)
+		}
+		if next == "" {
+			return series, nil
+		}
+		opts.PageToken = next
+	}
+}
+
+// storedPriceValue prefers the candle close over the last trade price.
+func storedPriceValue(p *entity.StoredPrice) decimal.Decimal {
+	if p.Close != nil {
+		return amountToDecimal(*p.Close, p.Decimals)
+	}
+	return amountToDecimal(p.Last, p.Decimals)
+}
+
+func backtestToProto(result *backtestResult) *apiv1.BacktestRuleResponse {
+	resp := &apiv1.BacktestRuleResponse{Warnings: result.warnings}
+
+	for _, p := range result.equity {
+		resp.EquityCurve = append(resp.EquityCurve, &apiv1.EquityPoint{
+			Timestamp:       timestamppb.New(p.at),
+			Value:           p.value.InexactFloat64(),
+			BuyAndHoldValue: p.benchmark.InexactFloat64(),
+		})
+	}
+
+	for _, t := range result.trades {
+		resp.Trades = append(resp.Trades, &apiv1.BacktestTrade{
+			Timestamp: timestamppb.New(t.at),
+			AssetId:   t.assetID,
+			Action:    t.side,
+			Amount:    t.amount.InexactFloat64(),
+			Price:     t.price.InexactFloat64(),
+			Value:     t.value.InexactFloat64(),
+			Fee:       t.fee.InexactFloat64(),
+			Reason:    t.reason,
+		})
+	}
+
+	for _, asset := range sortedKeys(result.holdings) {
+		amount := result.holdings[asset]
+		if amount.IsZero() {
+			continue
+		}
+		holding := &apiv1.BacktestHolding{AssetId: asset, Amount: amount.InexactFloat64()}
+		if price, ok := result.prices[asset]; ok {
+			value := amount.Mul(price).InexactFloat64()
+			holding.Value = &value
+		}
+		resp.FinalHoldings = append(resp.FinalHoldings, holding)
+	}
+
+	stats := summarizeBacktest(result.equity)
+	resp.Stats = &apiv1.BacktestStats{
+		InitialValue:                 stats.initial.InexactFloat64(),
+		FinalValue:                   stats.final.InexactFloat64(),
+		TotalReturnPercent:           stats.returnPct.InexactFloat64(),
+		MaxDrawdownPercent:           stats.drawdownPct.InexactFloat64(),
+		BuyAndHoldFinalValue:         stats.benchFinal.InexactFloat64(),
+		BuyAndHoldReturnPercent:      stats.benchReturnPct.InexactFloat64(),
+		BuyAndHoldMaxDrawdownPercent: stats.benchDrawdownPct.InexactFloat64(),
+		TotalFees:                    result.fees.InexactFloat64(),
+		TradeCount:                   int32(len(result.trades)),
+	}
+	return resp
+}
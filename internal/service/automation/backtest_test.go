@@ -0,0 +1,146 @@
+package automation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// series returns daily prices starting on 2024-01-01.
+func series(prices ...float64) []pricePoint {
+	points := make([]pricePoint, len(prices))
+	for i, p := range prices {
+		points[i] = pricePoint{at: day(i), price: decimal.NewFromFloat(p)}
+	}
+	return points
+}
+
+func day(i int) time.Time {
+	return time.Date(2024, 1, 1+i, 0, 0, 0, 0, time.UTC)
+}
+
+func amounts(kv ...any) map[string]decimal.Decimal {
+	m := map[string]decimal.Decimal{}
+	for i := 0; i < len(kv); i += 2 {
+		m[kv[i].(string)] = decimal.NewFromFloat(kv[i+1].(float64))
+	}
+	return m
+}
+
+func TestBacktest_DCAWithCosts(t *testing.T) {
+	strategy := &dcaStrategy{
+		config: &dcaConfig{amount: decimal.NewFromInt(100), currencyAssetID: usd, targetAssetID: btc, interval: IntervalDaily},
+		loc:    time.UTC,
+	}
+	result := runBacktest(backtestInput{
+		strategy: strategy,
+		currency: usd,
+		holdings: amounts(usd, 250.0),
+		prices:   map[string][]pricePoint{btc: series(10, 20, 40, 40)},
+		costs: backtestCosts{
+			feeRate:  decimal.RequireFromString("0.01"),
+			fixedFee: decimal.NewFromInt(1),
+			slippage: decimal.RequireFromString("0.001"),
+		},
+	})
+
+	// 100, 100, then the remaining 50; the fourth day has no cash left.
+	require.Len(t, result.trades, 3)
+	first := result.trades[0]
+	assert.Equal(t, "buy", first.side)
+	assert.Equal(t, "10.01", first.price.String())
+	assert.Equal(t, "98.019801980198", first.value.StringFixed(12))
+	assert.Equal(t, "1.980198019802", first.fee.StringFixed(12))
+	assert.True(t, result.holdings[usd].IsZero())
+	assert.Equal(t, []string{"cash ran out on 2024-01-03; later purchases were reduced or skipped"}, result.warnings)
+
+	// Buy and hold puts all cash into BTC on the first day.
+	require.Len(t, result.equity, 4)
+	assert.Equal(t, "1000", result.equity[3].benchmark.Round(6).String())
+	assert.Equal(t, "250", result.equity[0].benchmark.String())
+}
+
+func TestBacktest_Rebalancing(t *testing.T) {
+	strategy := &rebalancingStrategy{
+		config: &rebalancingConfig{
+			currencyAssetID:   usd,
+			targetAllocations: map[string]decimal.Decimal{btc: decimal.NewFromInt(50), eth: decimal.NewFromInt(50)},
+			threshold:         decimal.NewFromInt(5),
+			interval:          IntervalDaily,
+		},
+		loc: time.UTC,
+	}
+	result := runBacktest(backtestInput{
+		strategy: strategy,
+		currency: usd,
+		holdings: amounts(btc, 5.0, eth, 5.0),
+		prices: map[string][]pricePoint{
+			btc: series(100, 104, 300),
+			eth: series(100, 100, 100),
+		},
+	})
+
+	// Day 2 drifts 1 point, within the threshold; day 3 triggers a rebalance.
+	require.Len(t, result.trades, 2)
+	assert.Equal(t, day(2), result.trades[0].at)
+	assert.Equal(t, btc, result.trades[0].assetID)
+	assert.Equal(t, "sell", result.trades[0].side)
+	assert.Equal(t, eth, result.trades[1].assetID)
+	assert.Equal(t, "buy", result.trades[1].side)
+
+	btcValue := result.holdings[btc].Mul(decimal.NewFromInt(300))
+	ethValue := result.holdings[eth].Mul(decimal.NewFromInt(100))
+	assert.Equal(t, "1000", btcValue.Round(6).String())
+	assert.Equal(t, "1000", ethValue.Round(6).String())
+}
+
+func TestBacktest_TrailingStopLoss(t *testing.T) {
+	strategy := &stopLossStrategy{config: &stopLossConfig{currencyAssetID: usd, threshold: decimal.NewFromInt(10), trailing: true}}
+	result := runBacktest(backtestInput{
+		strategy: strategy,
+		currency: usd,
+		holdings: amounts(btc, 1.0),
+		prices:   map[string][]pricePoint{btc: series(100, 120, 110, 107, 90)},
+	})
+
+	// Peak 120, sold at 107 (10.8% below), not held for the drop to 90.
+	require.Len(t, result.trades, 1)
+	assert.Equal(t, day(3), result.trades[0].at)
+	assert.Equal(t, "107", result.holdings[usd].String())
+
+	stats := summarizeBacktest(result.equity)
+	assert.Equal(t, "7", stats.returnPct.String())
+	assert.Equal(t, "-10", stats.benchReturnPct.String())
+	assert.Equal(t, "25", stats.benchDrawdownPct.String())
+}
+
+func TestBacktest_Deterministic(t *testing.T) {
+	run := func() *backtestResult {
+		return runBacktest(backtestInput{
+			strategy: &rebalancingStrategy{
+				config: &rebalancingConfig{
+					currencyAssetID:   usd,
+					targetAllocations: map[string]decimal.Decimal{btc: decimal.NewFromInt(40), eth: decimal.NewFromInt(30), sol: decimal.NewFromInt(30)},
+					interval:          IntervalDaily,
+				},
+				loc: time.UTC,
+			},
+			currency: usd,
+			holdings: amounts(usd, 1000.0),
+			prices: map[string][]pricePoint{
+				btc: series(10, 12, 9, 14),
+				eth: series(5, 4, 6, 5),
+				sol: series(1, 1.5, 0.8, 1.1),
+			},
+			costs: backtestCosts{feeRate: decimal.RequireFromString("0.002")},
+		})
+	}
+
+	first := run()
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, first, run())
+	}
+}
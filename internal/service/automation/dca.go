@@ -0,0 +1,140 @@
+package automation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// RuleTypeDCA buys a fixed cash amount of one asset every period.
+//
+// Configuration:
+//
+//	amount             number or decimal string, required
+//	currency_asset_id  asset the amount is paid in, required
+//	target_asset_id    asset to buy, required
+//	interval           daily, weekly or monthly (default)
+const RuleTypeDCA = "dca"
+
+var intervalSchema = &Schema{
+	Type:        Types{"string"},
+	Description: "How often the rule acts; it acts on the first price of each period.",
+	Enum:        []any{IntervalDaily, IntervalWeekly, IntervalMonthly},
+	Default:     IntervalMonthly,
+}
+
+var dcaType = &ruleTypeDefinition{
+	name:        RuleTypeDCA,
+	description: "Dollar-cost averaging: buys a fixed cash amount of one asset every period.",
+	schema: &Schema{
+		Type:     Types{"object"},
+		Required: []string{"amount", "currency_asset_id", "target_asset_id"},
+		Properties: map[string]*Schema{
+			"amount": {
+				Type:             Types{"number", "string"},
+				Format:           "decimal",
+				Description:      "Cash spent per purchase, fees included, in the currency asset.",
+				ExclusiveMinimum: ptr(0.0),
+			},
+			"currency_asset_id": {
+				Type:        Types{"string"},
+				Format:      "uuid",
+				Description: "Asset purchases are paid in.",
+				Reference:   "asset",
+			},
+			"target_asset_id": {
+				Type:        Types{"string"},
+				Format:      "uuid",
+				Description: "Asset to buy.",
+				Reference:   "asset",
+			},
+			"interval": intervalSchema,
+		},
+	},
+	check: func(cfg map[string]any) (errs, warnings []string) {
+		c, err := parseDCAConfig(cfg)
+		if err != nil {
+			return []string{"configuration: " + err.Error()}, nil
+		}
+		if c.targetAssetID == c.currencyAssetID {
+			errs = append(errs, "configuration.target_asset_id: must differ from currency_asset_id")
+		}
+		return errs, nil
+	},
+}
+
+type dcaConfig struct {
+	amount          decimal.Decimal
+	currencyAssetID string
+	targetAssetID   string
+	interval        string
+}
+
+func parseDCAConfig(cfg map[string]any) (*dcaConfig, error) {
+	amount, err := decimalValue(cfg["amount"])
+	if err != nil {
+		return nil, fmt.Errorf("amount: %w", err)
+	}
+	if !amount.IsPositive() {
+		return nil, errors.New("amount must be positive")
+	}
+
+	c := &dcaConfig{amount: amount}
+	c.currencyAssetID, _ = cfg["currency_asset_id"].(string)
+	if c.currencyAssetID == "" {
+		return nil, errors.New("currency_asset_id is required")
+	}
+	c.targetAssetID, _ = cfg["target_asset_id"].(string)
+	if c.targetAssetID == "" {
+		return nil, errors.New("target_asset_id is required")
+	}
+	if c.interval, err = parseInterval(cfg); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parseInterval reads the optional interval key shared by scheduled rule types.
+func parseInterval(cfg map[string]any) (string, error) {
+	v, ok := cfg["interval"]
+	if !ok {
+		return IntervalMonthly, nil
+	}
+	switch interval, _ := v.(string); interval {
+	case IntervalDaily, IntervalWeekly, IntervalMonthly:
+		return interval, nil
+	}
+	return "", fmt.Errorf("unsupported interval %v", v)
+}
+
+type dcaStrategy struct {
+	config *dcaConfig
+	loc    *time.Location
+	period string
+}
+
+func (s *dcaStrategy) assets() []string {
+	return []string{s.config.targetAssetID}
+}
+
+func (s *dcaStrategy) benchmarkWeights() map[string]decimal.Decimal {
+	return map[string]decimal.Decimal{s.config.targetAssetID: decimal.NewFromInt(1)}
+}
+
+func (s *dcaStrategy) step(bt *backtest, at time.Time) {
+	period := periodKey(s.config.interval, at.In(s.loc))
+	if period == s.period {
+		return
+	}
+	if _, ok := bt.price(s.config.targetAssetID); !ok {
+		return
+	}
+	s.period = period
+
+	if bt.cash().LessThan(s.config.amount) {
+		bt.warn("dca:cash", "cash ran out on %s; later purchases were reduced or skipped", at.UTC().Format(time.DateOnly))
+	}
+	bt.buy(at, s.config.targetAssetID, s.config.amount, "dca")
+}
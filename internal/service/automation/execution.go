@@ -0,0 +1,332 @@
+package automation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/audit"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/google/uuid"
+)
+
+// errExecutionCancelled stops a run at the next checkpoint after cancellation.
+var errExecutionCancelled = errors.New("execution cancelled")
+
+// executionFields are persisted after every checkpoint and when a run finishes.
+var executionFields = []string{
+	"status", "completed_at", "error_message", "created_transaction_ids", "affected_holding_ids", "execution_summary",
+}
+
+// executions tracks runs in this process so they can be cancelled locally.
+type executions struct {
+	mu      sync.Mutex
+	running map[string]context.CancelFunc
+	// wake nudges idle workers when an execution is queued.
+	wake chan struct{}
+}
+
+func newExecutions() *executions {
+	return &executions{
+		running: map[string]context.CancelFunc{},
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+func (e *executions) register(id string, cancel context.CancelFunc) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.running[id] = cancel
+}
+
+func (e *executions) unregister(id string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.running, id)
+}
+
+// cancel stops a run of this process, reporting whether one was found.
+func (e *executions) cancel(id string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	cancel, ok := e.running[id]
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+func (e *executions) notify() {
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+// runExecution runs the rule with the executor of its type.
+func (h *Handler) runExecution(ctx context.Context, rule *entity.Rule, exec *entity.RuleExecution) error {
+	switch rule.RuleType {
+	case RuleTypeMonthlyWithdrawal:
+		return h.runWithdrawal(ctx, rule, exec)
+	default:
+		return checkRuleType(rule)
+	}
+}
+
+// runWithdrawal plans the withdrawal and, unless it is a dry run, applies
+// the plan. Progress and partial results are written to the execution
+// summary at every checkpoint. Once a step is being applied it completes
+// even if ctx is cancelled; cancellation takes effect before the next step.
+func (h *Handler) runWithdrawal(ctx context.Context, rule *entity.Rule, exec *entity.RuleExecution) error {
+	cfg, err := parseWithdrawalConfig(rule.Configuration)
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	plan, err := h.buildWithdrawalPlan(ctx, rule.PortfolioID, cfg)
+	if err != nil {
+		return err
+	}
+
+	progress := map[string]any{"total_steps": len(plan.steps), "completed_steps": 0}
+	exec.Summary = withdrawalSummary(plan)
+	exec.Summary["dry_run"] = exec.DryRun
+	if exec.ExecutionContext != "" {
+		exec.Summary["execution_context"] = exec.ExecutionContext
+	}
+	exec.Summary["progress"] = progress
+
+	if !plan.sufficientFunds() {
+		return fmt.Errorf("insufficient funds: requested %s, can cover %s", cfg.amount.String(), plan.covered.String())
+	}
+	if exec.DryRun {
+		return nil
+	}
+
+	// The first checkpoint saves the plan and its progress before any step
+	// is applied. From then on, an interrupted run is failed by recovery
+	// rather than queued again, since a step may have been applied without
+	// being saved.
+	writes := context.WithoutCancel(ctx)
+	checkpoint := func(done int) error {
+		progress["completed_steps"] = done
+		if _, err := h.store.UpdateRuleExecution(writes, exec, executionFields); err != nil {
+			return fmt.Errorf("save progress: %w", err)
+		}
+		h.publishExecution(writes, exec)
+		if ctx.Err() != nil {
+			return errExecutionCancelled
+		}
+		return nil
+	}
+
+	if err := h.executeWithdrawal(writes, rule, plan, exec, checkpoint); err != nil {
+		return err
+	}
+	progress["completed_steps"] = len(plan.steps)
+	return nil
+}
+
+// finishExecution records the outcome of a run. A cancelled run keeps the
+// reason given when cancellation was requested.
+func (h *Handler) finishExecution(ctx context.Context, exec *entity.RuleExecution, runErr error) (*entity.RuleExecution, error) {
+	completedAt := time.Now()
+	exec.CompletedAt = &completedAt
+
+	switch {
+	case runErr == nil:
+		exec.Status = entity.ExecutionStatusCompleted
+	case errors.Is(runErr, errExecutionCancelled):
+		exec.Status = entity.ExecutionStatusCancelled
+		if runErr != errExecutionCancelled {
+			h.log.Warn("rule execution cancelled with errors", "execution_id", exec.ID, "error", runErr)
+		}
+	default:
+		h.log.Warn("rule execution failed", "rule_id", exec.RuleID, "execution_id", exec.ID, "error", runErr)
+		exec.Status = entity.ExecutionStatusFailed
+		exec.ErrorMessage = runErr.Error()
+	}
+
+	fields := executionFields
+	if exec.Status == entity.ExecutionStatusCancelled {
+		fields = []string{"status", "completed_at", "created_transaction_ids", "affected_holding_ids", "execution_summary"}
+	}
+	updated, err := h.store.UpdateRuleExecution(context.WithoutCancel(ctx), exec, fields)
+	if err != nil {
+		return nil, err
+	}
+	h.publishExecution(ctx, updated)
+	return updated, nil
+}
+
+// WorkerConfig configures the background rule execution workers.
+type WorkerConfig struct {
+	// Workers is the number of executions run concurrently.
+	Workers int
+	// PollInterval is how often idle workers check for queued executions.
+	PollInterval time.Duration
+	// HeartbeatInterval is how often a running execution reports progress
+	// and checks for cancellation.
+	HeartbeatInterval time.Duration
+	// StaleAfter is how long an execution may go without a heartbeat before
+	// it is considered orphaned and recovered.
+	StaleAfter time.Duration
+}
+
+func (c WorkerConfig) withDefaults() WorkerConfig {
+	if c.Workers <= 0 {
+		c.Workers = 2
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	if c.HeartbeatInterval <= 0 {
+		c.HeartbeatInterval = 10 * time.Second
+	}
+	if c.StaleAfter <= 0 {
+		c.StaleAfter = 2 * time.Minute
+	}
+	return c
+}
+
+// RunWorkers processes queued rule executions until ctx is cancelled, then
+// waits for running executions to finish. Orphaned executions are recovered
+// on start and periodically while running.
+func (h *Handler) RunWorkers(ctx context.Context, cfg WorkerConfig) error {
+	cfg = cfg.withDefaults()
+
+	host, _ := os.Hostname()
+	prefix := fmt.Sprintf("%s-%d", host, os.Getpid())
+
+	h.recoverExecutions(ctx, cfg.StaleAfter)
+
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.Workers; i++ {
+		workerID := fmt.Sprintf("%s-%s", prefix, uuid.NewString()[:8])
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.workLoop(ctx, workerID, cfg)
+		}()
+	}
+
+	ticker := time.NewTicker(cfg.StaleAfter)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return nil
+		case <-ticker.C:
+			h.recoverExecutions(ctx, cfg.StaleAfter)
+		}
+	}
+}
+
+func (h *Handler) recoverExecutions(ctx context.Context, staleAfter time.Duration) {
+	requeued, failed, err := h.store.RecoverRuleExecutions(ctx, time.Now().Add(-staleAfter))
+	if err != nil {
+		h.log.Error("failed to recover rule executions", "error", err)
+		return
+	}
+	if requeued > 0 || failed > 0 {
+		h.log.Warn("recovered orphaned rule executions", "requeued", requeued, "failed", failed)
+	}
+}
+
+func (h *Handler) workLoop(ctx context.Context, workerID string, cfg WorkerConfig) {
+	for ctx.Err() == nil {
+		exec, err := h.store.ClaimRuleExecution(ctx, workerID)
+		if err == nil {
+			h.work(ctx, workerID, exec, cfg.HeartbeatInterval)
+			continue
+		}
+		if !errors.Is(err, store.ErrNotFound) && ctx.Err() == nil {
+			h.log.Error("failed to claim rule execution", "worker_id", workerID, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-h.executions.wake:
+		case <-time.After(cfg.PollInterval):
+		}
+	}
+}
+
+// work runs a claimed execution. Shutting down does not cancel it; only
+// CancelRuleExecution or losing ownership of the execution does.
+func (h *Handler) work(ctx context.Context, workerID string, exec *entity.RuleExecution, heartbeat time.Duration) {
+	// Changes are audited as the worker's, tagged with the execution.
+	ctx = audit.WithTask(ctx, exec.ID, exec.UserID)
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
+	h.executions.register(exec.ID, cancel)
+	defer h.executions.unregister(exec.ID)
+
+	done := make(chan struct{})
+	defer close(done)
+	go h.heartbeat(runCtx, workerID, exec.ID, heartbeat, cancel, done)
+
+	log := h.log.With("execution_id", exec.ID, "rule_id", exec.RuleID, "worker_id", workerID)
+	log.Info("rule execution started")
+	h.publishExecution(ctx, exec)
+
+	runErr := func() error {
+		rule, err := h.store.GetRule(runCtx, exec.RuleID)
+		if err != nil {
+			return fmt.Errorf("load rule: %w", err)
+		}
+		if err := checkRuleType(rule); err != nil {
+			return err
+		}
+		if !exec.DryRun && rule.Status != entity.RuleStatusActive {
+			return errors.New("rule is not active")
+		}
+		return h.runExecution(runCtx, rule, exec)
+	}()
+	if errors.Is(runErr, context.Canceled) && runCtx.Err() != nil {
+		// Cancelled while loading or planning, before any step was applied.
+		runErr = errExecutionCancelled
+	}
+
+	exec, err := h.finishExecution(ctx, exec, runErr)
+	if err != nil {
+		log.Error("failed to record rule execution result", "error", err)
+		return
+	}
+	log.Info("rule execution finished", "status", exec.Status)
+}
+
+// heartbeat keeps the claim on an execution alive and cancels the run when
+// cancellation is requested or the claim is lost.
+func (h *Handler) heartbeat(ctx context.Context, workerID, id string, interval time.Duration, cancel context.CancelFunc, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cancelRequested, err := h.store.HeartbeatRuleExecution(ctx, id, workerID)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			h.log.Warn("lost rule execution claim", "execution_id", id, "worker_id", workerID)
+			cancel()
+			return
+		case err != nil:
+			h.log.Error("failed to heartbeat rule execution", "execution_id", id, "error", err)
+		case cancelRequested:
+			cancel()
+			return
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package automation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/portfolio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPortfolios records writes made while applying a withdrawal.
+type recordingPortfolios struct {
+	PortfolioStore
+	steps        []*portfolio.WithdrawalStep
+	lots         []*entity.Lot
+	transactions []*entity.Transaction
+	// failAt fails the step with this number, counted from one.
+	failAt int
+}
+
+func (r *recordingPortfolios) ApplyWithdrawalStep(ctx context.Context, w *portfolio.WithdrawalStep) error {
+	if len(r.steps)+1 == r.failAt {
+		return errors.New("store unavailable")
+	}
+	r.steps = append(r.steps, w)
+	if w.Lot != nil {
+		copied := *w.Lot
+		r.lots = append(r.lots, &copied)
+	}
+	if w.Transaction != nil {
+		_, _ = r.CreateTransaction(ctx, w.Transaction)
+	}
+	return nil
+}
+
+func (r *recordingPortfolios) CreateTransaction(_ context.Context, t *entity.Transaction) (*entity.Transaction, error) {
+	t.ID = fmt.Sprintf("tx-%d", len(r.transactions)+1)
+	r.transactions = append(r.transactions, t)
+	return t, nil
+}
+
+// recordingStore keeps the fields of the last execution update.
+type recordingStore struct {
+	Store
+	fields []string
+}
+
+func (s *recordingStore) UpdateRuleExecution(_ context.Context, e *entity.RuleExecution, fields []string) (*entity.RuleExecution, error) {
+	s.fields = fields
+	return e, nil
+}
+
+func TestExecuteWithdrawal_StopsAtCheckpoint(t *testing.T) {
+	portfolios := &recordingPortfolios{}
+	h := &Handler{portfolios: portfolios}
+
+	plan := planWithdrawal(config(1700, SellOrderOverweightFirst), []*withdrawalPosition{
+		cashPosition("h-cash", 500_00),
+		assetPosition("h-btc", btc, 1_00000000, 1000),
+		assetPosition("h-eth", eth, 1_00000000, 1000),
+	})
+	require.Len(t, plan.steps, 3)
+
+	exec := &entity.RuleExecution{ID: "exec"}
+	err := h.executeWithdrawal(context.Background(), &entity.Rule{ID: "rule"}, plan, exec, func(done int) error {
+		if done == 2 {
+			return errExecutionCancelled
+		}
+		return nil
+	})
+
+	require.ErrorIs(t, err, errExecutionCancelled)
+	assert.Len(t, portfolios.steps, 2)
+	assert.Len(t, exec.AffectedHoldingIDs, 2)
+
+	// One sale for the step that sold, and the withdrawal of what was raised so far.
+	require.Len(t, portfolios.transactions, 2)
+	assert.Equal(t, entity.TransactionTypeTrade, portfolios.transactions[0].Type)
+	withdrawal := portfolios.transactions[1]
+	assert.Equal(t, entity.TransactionTypeWithdrawal, withdrawal.Type)
+	assert.Equal(t, "1500", withdrawal.Data["amount"])
+	assert.Equal(t, []string{"tx-1", "tx-2"}, exec.CreatedTransactionIDs)
+}
+
+func TestExecuteWithdrawal_FailedStep(t *testing.T) {
+	portfolios := &recordingPortfolios{failAt: 2}
+	h := &Handler{portfolios: portfolios}
+
+	plan := planWithdrawal(config(1700, SellOrderOverweightFirst), []*withdrawalPosition{
+		cashPosition("h-cash", 500_00),
+		assetPosition("h-btc", btc, 1_00000000, 1000),
+		assetPosition("h-eth", eth, 1_00000000, 1000),
+	})
+
+	exec := &entity.RuleExecution{ID: "exec"}
+	err := h.executeWithdrawal(context.Background(), &entity.Rule{ID: "rule"}, plan, exec, func(int) error { return nil })
+
+	// The failed step took nothing, so only the first counts.
+	require.ErrorContains(t, err, "store unavailable")
+	assert.Equal(t, []string{plan.steps[0].holding.ID}, exec.AffectedHoldingIDs)
+	require.Len(t, portfolios.transactions, 1)
+	assert.Equal(t, entity.TransactionTypeWithdrawal, portfolios.transactions[0].Type)
+	assert.Equal(t, plan.steps[0].value.String(), portfolios.transactions[0].Data["amount"])
+}
+
+func TestExecuteWithdrawal_LotCostBasis(t *testing.T) {
+	portfolios := &recordingPortfolios{}
+	h := &Handler{portfolios: portfolios}
+
+	inUSD := lot("l-usd", 1_00000000, 800, 1)
+	inEUR := lot("l-eur", 1_00000000, 900, 2)
+	inEUR.CostAssetID = "eur"
+	plan := planWithdrawal(config(1600, SellOrderLowestGainFirst), []*withdrawalPosition{
+		assetPosition("h-btc", btc, 2_00000000, 1000, inUSD, inEUR),
+	})
+
+	exec := &entity.RuleExecution{ID: "exec"}
+	require.NoError(t, h.executeWithdrawal(context.Background(), &entity.Rule{ID: "rule"}, plan, exec, func(int) error { return nil }))
+
+	// The whole first lot goes; 0.6 of the second takes 60% of its cost
+	// basis, though the gain on it is unknown.
+	require.Len(t, portfolios.lots, 2)
+	assert.Equal(t, entity.Lot{ID: "l-usd", HoldingID: "h-btc", Decimals: 8, CostDecimals: 2, CostAssetID: usd,
+		AcquiredAt: inUSD.AcquiredAt}, *portfolios.lots[0])
+	assert.Equal(t, int64(40000000), portfolios.lots[1].Amount)
+	assert.Equal(t, int64(360_00), portfolios.lots[1].CostBasis)
+}
+
+// ruleStore returns one stored rule.
+type ruleStore struct {
+	Store
+	rule *entity.Rule
+}
+
+func (s *ruleStore) GetRule(context.Context, string) (*entity.Rule, error) {
+	return s.rule, nil
+}
+
+func TestExecutableRule(t *testing.T) {
+	tests := []struct {
+		name string
+		rule *entity.Rule
+		code connect.Code
+	}{
+		{
+			name: "withdrawal",
+			rule: withdrawalRule(map[string]any{"amount": "1000", "currency_asset_id": testUSD, "sell_order": "lowest_gain_first"}),
+		},
+		{
+			name: "invalid withdrawal",
+			rule: withdrawalRule(map[string]any{"amount": "-1", "currency_asset_id": testUSD}),
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name: "backtest only",
+			rule: &entity.Rule{RuleType: RuleTypeDCA,
+				Configuration: map[string]any{"amount": "100", "currency_asset_id": testUSD, "target_asset_id": testBTC}},
+			code: connect.CodeUnimplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rule.Status = entity.RuleStatusActive
+			h := &Handler{store: &ruleStore{rule: tt.rule}}
+			_, err := h.executableRule(context.Background(), "rule", false)
+			if tt.code == 0 {
+				require.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.code, connect.CodeOf(err))
+		})
+	}
+}
+
+func TestFinishExecution(t *testing.T) {
+	tests := []struct {
+		name    string
+		runErr  error
+		status  entity.ExecutionStatus
+		message string
+	}{
+		{name: "completed", status: entity.ExecutionStatusCompleted},
+		{name: "cancelled keeps reason", runErr: errExecutionCancelled, status: entity.ExecutionStatusCancelled, message: "user request"},
+		{name: "failed", runErr: errors.New("boom"), status: entity.ExecutionStatusFailed, message: "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &recordingStore{}
+			h := &Handler{store: s, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+			exec, err := h.finishExecution(context.Background(), &entity.RuleExecution{ErrorMessage: "user request"}, tt.runErr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, exec.Status)
+			assert.NotNil(t, exec.CompletedAt)
+			if tt.status == entity.ExecutionStatusCancelled {
+				assert.NotContains(t, s.fields, "error_message")
+			} else if tt.runErr != nil {
+				assert.Equal(t, tt.message, exec.ErrorMessage)
+			}
+		})
+	}
+}
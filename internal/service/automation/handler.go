@@ -0,0 +1,865 @@
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/api/v1/apiv1connect"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/pubsub"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/foxcool/greedy-eye/internal/service/portfolio"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Handler implements apiv1connect.AutomationServiceHandler.
+type Handler struct {
+	apiv1connect.UnimplementedAutomationServiceHandler
+	store      Store
+	portfolios PortfolioStore
+	marketData MarketDataStore
+	executions *executions
+	events     *pubsub.Hub
+	log        *slog.Logger
+}
+
+func NewHandler(store Store, portfolios PortfolioStore, marketData MarketDataStore, events *pubsub.Hub, log *slog.Logger) *Handler {
+	return &Handler{
+		store:      store,
+		portfolios: portfolios,
+		marketData: marketData,
+		executions: newExecutions(),
+		events:     events,
+		log:        log,
+	}
+}
+
+// --- Rule CRUD ---
+
+// CreateRule creates a new rule.
+func (h *Handler) CreateRule(ctx context.Context, req *connect.Request[apiv1.CreateRuleRequest]) (*connect.Response[apiv1.Rule], error) {
+	if req.Msg.Rule == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("rule is required"))
+	}
+
+	rule := ruleFromProto(req.Msg.Rule)
+	rule.UserID = auth.OwnerID(ctx, rule.UserID)
+	if err := h.checkRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	created, err := h.store.CreateRule(ctx, rule)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(ruleToProto(created)), nil
+}
+
+// GetRule retrieves a rule by ID.
+func (h *Handler) GetRule(ctx context.Context, req *connect.Request[apiv1.GetRuleRequest]) (*connect.Response[apiv1.Rule], error) {
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("rule ID is required"))
+	}
+
+	rule, err := h.store.GetRule(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(ruleToProto(rule)), nil
+}
+
+// UpdateRule updates a rule.
+func (h *Handler) UpdateRule(ctx context.Context, req *connect.Request[apiv1.UpdateRuleRequest]) (*connect.Response[apiv1.Rule], error) {
+	if req.Msg.Rule == nil || req.Msg.Rule.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("rule with ID is required"))
+	}
+
+	var fields []string
+	if req.Msg.UpdateMask != nil {
+		fields = req.Msg.UpdateMask.Paths
+	}
+
+	existing, err := h.store.GetRule(ctx, req.Msg.Rule.Id)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	rule := ruleFromProto(req.Msg.Rule)
+	if err := h.checkRule(ctx, mergeRule(existing, rule, fields)); err != nil {
+		return nil, err
+	}
+
+	updated, err := h.store.UpdateRule(ctx, rule, fields)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(ruleToProto(updated)), nil
+}
+
+// DeleteRule deletes a rule by ID.
+func (h *Handler) DeleteRule(ctx context.Context, req *connect.Request[apiv1.DeleteRuleRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("rule ID is required"))
+	}
+
+	if err := h.store.DeleteRule(ctx, req.Msg.Id); err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// ListRules lists rules with filtering and pagination.
+func (h *Handler) ListRules(ctx context.Context, req *connect.Request[apiv1.ListRulesRequest]) (*connect.Response[apiv1.ListRulesResponse], error) {
+	opts := ListRulesOpts{}
+	if req.Msg.UserId != nil {
+		opts.UserID = *req.Msg.UserId
+	}
+	if req.Msg.PortfolioId != nil {
+		opts.PortfolioID = *req.Msg.PortfolioId
+	}
+	if req.Msg.RuleType != nil {
+		opts.RuleType = *req.Msg.RuleType
+	}
+	if req.Msg.Status != nil {
+		opts.Status = entity.RuleStatus(*req.Msg.Status)
+	}
+	if req.Msg.PageSize != nil {
+		opts.PageSize = int(*req.Msg.PageSize)
+	}
+	if req.Msg.PageToken != nil {
+		opts.PageToken = *req.Msg.PageToken
+	}
+
+	rules, nextPageToken, err := h.store.ListRules(ctx, opts)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	protoRules := make([]*apiv1.Rule, 0, len(rules))
+	for _, r := range rules {
+		protoRules = append(protoRules, ruleToProto(r))
+	}
+
+	return connect.NewResponse(&apiv1.ListRulesResponse{
+		Rules:         protoRules,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+// --- Rule execution ---
+
+// ExecuteRule plans and applies a rule synchronously. Every call is recorded as
+// a rule execution; a dry run records the plan without changing holdings.
+func (h *Handler) ExecuteRule(ctx context.Context, req *connect.Request[apiv1.ExecuteRuleRequest]) (*connect.Response[apiv1.ExecuteRuleResponse], error) {
+	rule, err := h.executableRule(ctx, req.Msg.RuleId, req.Msg.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	exec, err := h.store.CreateRuleExecution(ctx, &entity.RuleExecution{
+		RuleID:           rule.ID,
+		Status:           entity.ExecutionStatusInProgress,
+		StartedAt:        time.Now(),
+		DryRun:           req.Msg.DryRun,
+		ExecutionContext: req.Msg.GetExecutionContext(),
+		CreatedBy:        auth.OwnerID(ctx, ""),
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	h.executions.register(exec.ID, cancel)
+	defer h.executions.unregister(exec.ID)
+
+	runErr := h.runExecution(runCtx, rule, exec)
+	if errors.Is(runErr, context.Canceled) && runCtx.Err() != nil {
+		runErr = errExecutionCancelled
+	}
+
+	exec, err = h.finishExecution(ctx, exec, runErr)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&apiv1.ExecuteRuleResponse{Execution: ruleExecutionToProto(exec)}), nil
+}
+
+// ExecuteRuleAsync queues a rule execution for the background workers. Poll
+// GetRuleExecution for progress, which is reported in the execution summary.
+func (h *Handler) ExecuteRuleAsync(ctx context.Context, req *connect.Request[apiv1.ExecuteRuleAsyncRequest]) (*connect.Response[apiv1.ExecuteRuleAsyncResponse], error) {
+	rule, err := h.executableRule(ctx, req.Msg.RuleId, req.Msg.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	exec, err := h.store.CreateRuleExecution(ctx, &entity.RuleExecution{
+		RuleID:           rule.ID,
+		Status:           entity.ExecutionStatusPending,
+		StartedAt:        time.Now(),
+		DryRun:           req.Msg.DryRun,
+		ExecutionContext: req.Msg.GetExecutionContext(),
+		CreatedBy:        auth.OwnerID(ctx, ""),
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	h.publishExecution(ctx, exec)
+	h.executions.notify()
+
+	return connect.NewResponse(&apiv1.ExecuteRuleAsyncResponse{
+		ExecutionId: exec.ID,
+		Status:      apiv1.ExecutionStatus(exec.Status).String(),
+	}), nil
+}
+
+// CancelRuleExecution cancels a queued execution immediately. A running
+// execution stops before its next step; steps already applied are kept and
+// listed in the execution.
+func (h *Handler) CancelRuleExecution(ctx context.Context, req *connect.Request[apiv1.CancelRuleExecutionRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.ExecutionId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("execution ID is required"))
+	}
+
+	exec, err := h.store.CancelRuleExecution(ctx, req.Msg.ExecutionId, req.Msg.Reason)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	h.publishExecution(ctx, exec)
+	// Executions running on other instances see the request on their next heartbeat.
+	h.executions.cancel(req.Msg.ExecutionId)
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// executableRule loads a rule and checks that it can be executed.
+func (h *Handler) executableRule(ctx context.Context, ruleID string, dryRun bool) (*entity.Rule, error) {
+	if ruleID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("rule ID is required"))
+	}
+
+	rule, err := h.store.GetRule(ctx, ruleID)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	if err := checkRuleType(rule); err != nil {
+		return nil, err
+	}
+	if !dryRun && rule.Status != entity.RuleStatusActive {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("rule is not active"))
+	}
+	errs, _, err := checkConfiguration(ctx, ruleTypes[rule.RuleType], rule.Configuration, nil)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	if len(errs) > 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("invalid configuration: %s", strings.Join(errs, "; ")))
+	}
+
+	return rule, nil
+}
+
+// --- Rule validation and simulation ---
+
+// ValidateRule checks a rule definition against its rule type schema and
+// referenced entities without saving it.
+func (h *Handler) ValidateRule(ctx context.Context, req *connect.Request[apiv1.ValidateRuleRequest]) (*connect.Response[apiv1.ValidateRuleResponse], error) {
+	if req.Msg.Rule == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("rule is required"))
+	}
+
+	result, err := validateRule(ctx, ruleFromProto(req.Msg.Rule), h.references())
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&apiv1.ValidateRuleResponse{
+		Valid:            len(result.errors) == 0,
+		ValidationErrors: result.errors,
+		Warnings:         result.warnings,
+	}), nil
+}
+
+// ListRuleTypes lists supported rule types with their configuration schemas.
+func (h *Handler) ListRuleTypes(ctx context.Context, req *connect.Request[apiv1.ListRuleTypesRequest]) (*connect.Response[apiv1.ListRuleTypesResponse], error) {
+	defs := sortedRuleTypes()
+	types := make([]*apiv1.RuleType, 0, len(defs))
+	for _, d := range defs {
+		schema, err := schemaToStruct(d.schema)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		types = append(types, &apiv1.RuleType{
+			RuleType:            d.name,
+			Description:         d.description,
+			ConfigurationSchema: schema,
+			Executable:          d.executable,
+		})
+	}
+
+	return connect.NewResponse(&apiv1.ListRuleTypesResponse{RuleTypes: types}), nil
+}
+
+// SimulateRule builds the plan a rule would execute now without applying it.
+func (h *Handler) SimulateRule(ctx context.Context, req *connect.Request[apiv1.SimulateRuleRequest]) (*connect.Response[apiv1.SimulateRuleResponse], error) {
+	if req.Msg.RuleId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("rule ID is required"))
+	}
+
+	rule, err := h.store.GetRule(ctx, req.Msg.RuleId)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	switch rule.RuleType {
+	case RuleTypeMonthlyWithdrawal:
+		return h.simulateWithdrawal(ctx, rule)
+	default:
+		return nil, checkRuleType(rule)
+	}
+}
+
+// simulateWithdrawal builds the plan of a withdrawal rule.
+func (h *Handler) simulateWithdrawal(ctx context.Context, rule *entity.Rule) (*connect.Response[apiv1.SimulateRuleResponse], error) {
+	cfg, err := parseWithdrawalConfig(rule.Configuration)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid configuration: %w", err))
+	}
+
+	plan, err := h.buildWithdrawalPlan(ctx, rule.PortfolioID, cfg)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&apiv1.SimulateRuleResponse{
+		Success: true,
+		Result: &apiv1.SimulationResult{
+			EstimatedSteps: int32(len(plan.steps)),
+			RuleResult:     &apiv1.SimulationResult_Withdrawal{Withdrawal: withdrawalToProto(plan)},
+		},
+		Warnings: plan.warnings,
+	}), nil
+}
+
+// BacktestRule replays a DCA, rebalancing or stop-loss rule against stored
+// prices between from and to. It reads only the price database and never
+// changes the portfolio.
+func (h *Handler) BacktestRule(ctx context.Context, req *connect.Request[apiv1.BacktestRuleRequest]) (*connect.Response[apiv1.BacktestRuleResponse], error) {
+	msg := req.Msg
+	if msg.RuleId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("rule ID is required"))
+	}
+	if msg.From == nil || msg.To == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("from and to are required"))
+	}
+	from, to := msg.From.AsTime(), msg.To.AsTime()
+	if !from.Before(to) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("from must be before to"))
+	}
+
+	costs, err := backtestCostsFromProto(msg.Costs)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	rule, err := h.store.GetRule(ctx, msg.RuleId)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	strategy, currency, err := newBacktestStrategy(rule)
+	if errors.Is(err, errBacktestUnsupported) {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("rule type %q cannot be backtested", rule.RuleType))
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid configuration: %w", err))
+	}
+
+	holdings := map[string]decimal.Decimal{}
+	for _, hld := range msg.InitialHoldings {
+		if hld.AssetId == "" || hld.Amount < 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("initial holdings need an asset_id and a non-negative amount"))
+		}
+		holdings[hld.AssetId] = holdings[hld.AssetId].Add(decimal.NewFromFloat(hld.Amount))
+	}
+	if msg.InitialCash != nil {
+		if *msg.InitialCash < 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("initial_cash must not be negative"))
+		}
+		holdings[currency] = holdings[currency].Add(decimal.NewFromFloat(*msg.InitialCash))
+	}
+	if len(msg.InitialHoldings) == 0 && msg.InitialCash == nil {
+		if holdings, err = h.loadPortfolioAmounts(ctx, rule.PortfolioID); err != nil {
+			return nil, toConnectError(err)
+		}
+	}
+
+	assets := map[string]bool{}
+	for _, asset := range strategy.assets() {
+		assets[asset] = true
+	}
+	for asset := range holdings {
+		assets[asset] = true
+	}
+	delete(assets, currency)
+
+	interval := "1d"
+	if msg.Interval != nil && *msg.Interval != "" {
+		interval = *msg.Interval
+	}
+	budget := maxBacktestPrices
+	prices := map[string][]pricePoint{}
+	for _, asset := range sortedKeys(assets) {
+		series, err := h.loadPriceSeries(ctx, marketdata.ListPriceHistoryOpts{
+			AssetID:     asset,
+			BaseAssetID: currency,
+			SourceID:    msg.GetSourceId(),
+			Interval:    interval,
+			From:        &from,
+			To:          &to,
+		}, &budget)
+		if errors.Is(err, store.ErrNotFound) {
+			continue // unknown asset: reported as unpriced by the engine
+		}
+		if err != nil {
+			return nil, toConnectError(err)
+		}
+		prices[asset] = series
+	}
+
+	result := runBacktest(backtestInput{
+		strategy: strategy,
+		currency: currency,
+		holdings: holdings,
+		prices:   prices,
+		costs:    costs,
+	})
+	return connect.NewResponse(backtestToProto(result)), nil
+}
+
+func backtestCostsFromProto(c *apiv1.BacktestCosts) (backtestCosts, error) {
+	if c == nil {
+		return backtestCosts{}, nil
+	}
+	if c.FeePercent < 0 || c.FeePercent >= 100 {
+		return backtestCosts{}, errors.New("costs.fee_percent must be at least 0 and below 100")
+	}
+	if c.FixedFee < 0 {
+		return backtestCosts{}, errors.New("costs.fixed_fee must not be negative")
+	}
+	if c.SlippageBps < 0 || c.SlippageBps >= 10000 {
+		return backtestCosts{}, errors.New("costs.slippage_bps must be at least 0 and below 10000")
+	}
+	return backtestCosts{
+		feeRate:  decimal.NewFromFloat(c.FeePercent).Div(decimal.NewFromInt(100)),
+		fixedFee: decimal.NewFromFloat(c.FixedFee),
+		slippage: decimal.NewFromFloat(c.SlippageBps).Div(decimal.NewFromInt(10000)),
+	}, nil
+}
+
+// --- Rule status management ---
+
+// EnableRule activates a rule.
+func (h *Handler) EnableRule(ctx context.Context, req *connect.Request[apiv1.EnableRuleRequest]) (*connect.Response[apiv1.Rule], error) {
+	return h.setRuleStatus(ctx, req.Msg.RuleId, entity.RuleStatusActive)
+}
+
+// DisableRule disables a rule.
+func (h *Handler) DisableRule(ctx context.Context, req *connect.Request[apiv1.DisableRuleRequest]) (*connect.Response[apiv1.Rule], error) {
+	return h.setRuleStatus(ctx, req.Msg.RuleId, entity.RuleStatusDisabled)
+}
+
+// PauseRule pauses a rule.
+func (h *Handler) PauseRule(ctx context.Context, req *connect.Request[apiv1.PauseRuleRequest]) (*connect.Response[apiv1.Rule], error) {
+	return h.setRuleStatus(ctx, req.Msg.RuleId, entity.RuleStatusPaused)
+}
+
+// ResumeRule resumes a paused rule.
+func (h *Handler) ResumeRule(ctx context.Context, req *connect.Request[apiv1.ResumeRuleRequest]) (*connect.Response[apiv1.Rule], error) {
+	return h.setRuleStatus(ctx, req.Msg.RuleId, entity.RuleStatusActive)
+}
+
+func (h *Handler) setRuleStatus(ctx context.Context, ruleID string, status entity.RuleStatus) (*connect.Response[apiv1.Rule], error) {
+	if ruleID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("rule ID is required"))
+	}
+	if status == entity.RuleStatusActive {
+		rule, err := h.store.GetRule(ctx, ruleID)
+		if err != nil {
+			return nil, toConnectError(err)
+		}
+		if def, ok := ruleTypes[rule.RuleType]; ok && !def.executable {
+			return nil, connect.NewError(connect.CodeFailedPrecondition,
+				fmt.Errorf("%s rules can only be backtested and cannot be activated", rule.RuleType))
+		}
+	}
+
+	updated, err := h.store.UpdateRule(ctx, &entity.Rule{ID: ruleID, Status: status}, []string{"status"})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(ruleToProto(updated)), nil
+}
+
+// --- RuleExecution CRUD ---
+
+// CreateRuleExecution records a rule execution.
+func (h *Handler) CreateRuleExecution(ctx context.Context, req *connect.Request[apiv1.CreateRuleExecutionRequest]) (*connect.Response[apiv1.RuleExecution], error) {
+	if req.Msg.RuleExecution == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("rule execution is required"))
+	}
+
+	created, err := h.store.CreateRuleExecution(ctx, ruleExecutionFromProto(req.Msg.RuleExecution))
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(ruleExecutionToProto(created)), nil
+}
+
+// GetRuleExecution retrieves a rule execution by ID.
+func (h *Handler) GetRuleExecution(ctx context.Context, req *connect.Request[apiv1.GetRuleExecutionRequest]) (*connect.Response[apiv1.RuleExecution], error) {
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("rule execution ID is required"))
+	}
+
+	exec, err := h.store.GetRuleExecution(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(ruleExecutionToProto(exec)), nil
+}
+
+// UpdateRuleExecution updates a rule execution.
+func (h *Handler) UpdateRuleExecution(ctx context.Context, req *connect.Request[apiv1.UpdateRuleExecutionRequest]) (*connect.Response[apiv1.RuleExecution], error) {
+	if req.Msg.RuleExecution == nil || req.Msg.RuleExecution.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("rule execution with ID is required"))
+	}
+
+	var fields []string
+	if req.Msg.UpdateMask != nil {
+		fields = req.Msg.UpdateMask.Paths
+	}
+
+	updated, err := h.store.UpdateRuleExecution(ctx, ruleExecutionFromProto(req.Msg.RuleExecution), fields)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	h.publishExecution(ctx, updated)
+
+	return connect.NewResponse(ruleExecutionToProto(updated)), nil
+}
+
+// ListRuleExecutions lists rule executions with filtering and pagination.
+func (h *Handler) ListRuleExecutions(ctx context.Context, req *connect.Request[apiv1.ListRuleExecutionsRequest]) (*connect.Response[apiv1.ListRuleExecutionsResponse], error) {
+	opts := ListRuleExecutionsOpts{}
+	if req.Msg.RuleId != nil {
+		opts.RuleID = *req.Msg.RuleId
+	}
+	if req.Msg.PortfolioId != nil {
+		opts.PortfolioID = *req.Msg.PortfolioId
+	}
+	if req.Msg.UserId != nil {
+		opts.UserID = *req.Msg.UserId
+	}
+	if req.Msg.Status != nil {
+		opts.Status = entity.ExecutionStatus(*req.Msg.Status)
+	}
+	if req.Msg.From != nil {
+		from := req.Msg.From.AsTime()
+		opts.From = &from
+	}
+	if req.Msg.To != nil {
+		to := req.Msg.To.AsTime()
+		opts.To = &to
+	}
+	if req.Msg.PageSize != nil {
+		opts.PageSize = int(*req.Msg.PageSize)
+	}
+	if req.Msg.PageToken != nil {
+		opts.PageToken = *req.Msg.PageToken
+	}
+
+	executions, nextPageToken, err := h.store.ListRuleExecutions(ctx, opts)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	protoExecutions := make([]*apiv1.RuleExecution, 0, len(executions))
+	for _, e := range executions {
+		protoExecutions = append(protoExecutions, ruleExecutionToProto(e))
+	}
+
+	return connect.NewResponse(&apiv1.ListRuleExecutionsResponse{
+		RuleExecutions: protoExecutions,
+		NextPageToken:  nextPageToken,
+	}), nil
+}
+
+// checkRule rejects rule definitions that fail validation.
+func (h *Handler) checkRule(ctx context.Context, rule *entity.Rule) error {
+	result, err := validateRule(ctx, rule, h.references())
+	if err != nil {
+		return toConnectError(err)
+	}
+	if len(result.errors) > 0 {
+		return connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("invalid rule: %s", strings.Join(result.errors, "; ")))
+	}
+	return nil
+}
+
+// mergeRule applies the masked fields of update to a copy of existing.
+func mergeRule(existing, update *entity.Rule, fields []string) *entity.Rule {
+	merged := *existing
+	for _, field := range fields {
+		switch field {
+		case "name":
+			merged.Name = update.Name
+		case "description":
+			merged.Description = update.Description
+		case "status":
+			merged.Status = update.Status
+		case "configuration":
+			merged.Configuration = update.Configuration
+		case "schedule":
+			merged.Schedule = update.Schedule
+		}
+	}
+	return &merged
+}
+
+func (h *Handler) references() RuleReferences {
+	return storeReferences{portfolios: h.portfolios, marketData: h.marketData}
+}
+
+// storeReferences resolves rule references through the portfolio and market data stores.
+type storeReferences struct {
+	portfolios PortfolioStore
+	marketData MarketDataStore
+}
+
+func (r storeReferences) Exists(ctx context.Context, kind, id string) (bool, error) {
+	var err error
+	switch kind {
+	case "asset":
+		_, err = r.marketData.GetAsset(ctx, id)
+	case "portfolio":
+		_, err = r.portfolios.GetPortfolio(ctx, id)
+	default:
+		return false, fmt.Errorf("unknown reference kind %q", kind)
+	}
+	if errors.Is(err, store.ErrNotFound) || errors.Is(err, store.ErrInvalidArgument) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r storeReferences) PortfolioRole(ctx context.Context, portfolioID, userID string) (entity.PortfolioRole, error) {
+	p, err := r.portfolios.GetPortfolio(ctx, portfolioID)
+	if errors.Is(err, store.ErrNotFound) || errors.Is(err, store.ErrInvalidArgument) {
+		return entity.PortfolioRoleUnspecified, nil
+	}
+	if err != nil {
+		return entity.PortfolioRoleUnspecified, err
+	}
+	if p.UserID == userID {
+		return entity.PortfolioRoleOwner, nil
+	}
+
+	members, err := r.portfolios.ListPortfolioMembers(ctx, portfolio.ListPortfolioMembersOpts{PortfolioID: portfolioID, UserID: userID})
+	if errors.Is(err, store.ErrInvalidArgument) {
+		return entity.PortfolioRoleUnspecified, nil
+	}
+	if err != nil {
+		return entity.PortfolioRoleUnspecified, err
+	}
+	for _, m := range members {
+		if m.Status == entity.PortfolioMemberStatusActive {
+			return m.Role, nil
+		}
+	}
+	return entity.PortfolioRoleUnspecified, nil
+}
+
+// checkRuleType rejects rules of types that can only be backtested.
+func checkRuleType(rule *entity.Rule) error {
+	if def, ok := ruleTypes[rule.RuleType]; !ok || !def.executable {
+		return connect.NewError(connect.CodeUnimplemented, fmt.Errorf("rule type %q cannot be executed or simulated", rule.RuleType))
+	}
+	return nil
+}
+
+// --- Converters ---
+
+func toConnectError(err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	if errors.Is(err, store.ErrInvalidArgument) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if errors.Is(err, store.ErrConstraint) {
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	if errors.Is(err, store.ErrPermissionDenied) {
+		return connect.NewError(connect.CodePermissionDenied, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+func ruleFromProto(r *apiv1.Rule) *entity.Rule {
+	result := &entity.Rule{
+		ID:          r.Id,
+		Name:        r.Name,
+		Description: r.Description,
+		RuleType:    r.RuleType,
+		PortfolioID: r.PortfolioId,
+		UserID:      r.UserId,
+		Status:      entity.RuleStatus(r.Status),
+	}
+	if r.Configuration != nil {
+		result.Configuration = r.Configuration.AsMap()
+	}
+	if r.Schedule != nil {
+		result.Schedule = &entity.RuleSchedule{
+			CronExpression: r.Schedule.CronExpression,
+			Timezone:       r.Schedule.Timezone,
+			OneTime:        r.Schedule.OneTime,
+		}
+		if r.Schedule.ExecuteAfter != nil {
+			t := r.Schedule.ExecuteAfter.AsTime()
+			result.Schedule.ExecuteAfter = &t
+		}
+	}
+	return result
+}
+
+func ruleToProto(r *entity.Rule) *apiv1.Rule {
+	result := &apiv1.Rule{
+		Id:            r.ID,
+		Name:          r.Name,
+		Description:   r.Description,
+		RuleType:      r.RuleType,
+		PortfolioId:   r.PortfolioID,
+		UserId:        r.UserID,
+		Status:        apiv1.RuleStatus(r.Status),
+		Configuration: toStruct(r.Configuration),
+		CreatedAt:     timestamppb.New(r.CreatedAt),
+		UpdatedAt:     timestamppb.New(r.UpdatedAt),
+	}
+	if r.Schedule != nil {
+		result.Schedule = &apiv1.RuleSchedule{
+			CronExpression: r.Schedule.CronExpression,
+			Timezone:       r.Schedule.Timezone,
+			OneTime:        r.Schedule.OneTime,
+		}
+		if r.Schedule.ExecuteAfter != nil {
+			result.Schedule.ExecuteAfter = timestamppb.New(*r.Schedule.ExecuteAfter)
+		}
+	}
+	return result
+}
+
+func ruleExecutionFromProto(e *apiv1.RuleExecution) *entity.RuleExecution {
+	result := &entity.RuleExecution{
+		ID:                    e.Id,
+		RuleID:                e.RuleId,
+		Status:                entity.ExecutionStatus(e.Status),
+		CreatedTransactionIDs: e.CreatedTransactionIds,
+		AffectedHoldingIDs:    e.AffectedHoldingIds,
+		DryRun:                e.DryRun,
+		ExecutionContext:      e.GetExecutionContext(),
+		CancelRequested:       e.CancelRequested,
+	}
+	if e.StartedAt != nil {
+		result.StartedAt = e.StartedAt.AsTime()
+	}
+	if e.CompletedAt != nil {
+		t := e.CompletedAt.AsTime()
+		result.CompletedAt = &t
+	}
+	if e.ErrorMessage != nil {
+		result.ErrorMessage = *e.ErrorMessage
+	}
+	if e.ExecutionSummary != nil {
+		result.Summary = e.ExecutionSummary.AsMap()
+	}
+	return result
+}
+
+func ruleExecutionToProto(e *entity.RuleExecution) *apiv1.RuleExecution {
+	result := &apiv1.RuleExecution{
+		Id:                    e.ID,
+		RuleId:                e.RuleID,
+		StartedAt:             timestamppb.New(e.StartedAt),
+		Status:                apiv1.ExecutionStatus(e.Status),
+		CreatedTransactionIds: e.CreatedTransactionIDs,
+		AffectedHoldingIds:    e.AffectedHoldingIDs,
+		TransactionsCreated:   int32(len(e.CreatedTransactionIDs)),
+		ExecutionSummary:      toStruct(e.Summary),
+		DryRun:                e.DryRun,
+		CancelRequested:       e.CancelRequested,
+	}
+	if e.ExecutionContext != "" {
+		result.ExecutionContext = &e.ExecutionContext
+	}
+	if e.PortfolioID != "" {
+		result.PortfolioId = &e.PortfolioID
+	}
+	if e.UserID != "" {
+		result.UserId = &e.UserID
+	}
+	if e.CreatedBy != "" {
+		result.CreatedBy = &e.CreatedBy
+	}
+	if e.CompletedAt != nil {
+		result.CompletedAt = timestamppb.New(*e.CompletedAt)
+	}
+	if e.ErrorMessage != "" {
+		result.ErrorMessage = &e.ErrorMessage
+	}
+	return result
+}
+
+func schemaToStruct(s *Schema) (*structpb.Struct, error) {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("marshal schema: %w", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, fmt.Errorf("unmarshal schema: %w", err)
+	}
+	return structpb.NewStruct(m)
+}
+
+// toStruct converts a JSON-compatible map, returning nil if it cannot be represented.
+func toStruct(m map[string]any) *structpb.Struct {
+	if m == nil {
+		return nil
+	}
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		return nil
+	}
+	return s
+}
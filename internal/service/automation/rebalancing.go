@@ -0,0 +1,218 @@
+package automation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// RuleTypeRebalancing trades the portfolio back to target weights every
+// period when any asset has drifted beyond a threshold.
+//
+// Configuration:
+//
+//	currency_asset_id   asset trades are settled in, required
+//	target_allocations  {asset_id: percent} summing to 100, required
+//	threshold_percent   drift in percentage points that triggers a rebalance (default 0)
+//	interval            daily, weekly or monthly (default)
+const RuleTypeRebalancing = "rebalancing"
+
+var rebalancingType = &ruleTypeDefinition{
+	name:        RuleTypeRebalancing,
+	description: "Trades the portfolio back to target weights when they drift beyond a threshold.",
+	schema: &Schema{
+		Type:     Types{"object"},
+		Required: []string{"currency_asset_id", "target_allocations"},
+		Properties: map[string]*Schema{
+			"currency_asset_id": {
+				Type:        Types{"string"},
+				Format:      "uuid",
+				Description: "Asset trades are settled in; include it in the targets to keep a cash weight.",
+				Reference:   "asset",
+			},
+			"target_allocations": {
+				Type:          Types{"object"},
+				Description:   "Target weight in percent by asset ID; must sum to 100.",
+				PropertyNames: &Schema{Type: Types{"string"}, Format: "uuid", Reference: "asset"},
+				AdditionalProperties: &Schema{
+					Type:    Types{"number", "string"},
+					Format:  "decimal",
+					Minimum: ptr(0.0),
+					Maximum: ptr(100.0),
+				},
+			},
+			"threshold_percent": {
+				Type:        Types{"number", "string"},
+				Format:      "decimal",
+				Description: "Rebalance only when an asset is this many percentage points off target.",
+				Minimum:     ptr(0.0),
+				Maximum:     ptr(100.0),
+				Default:     0,
+			},
+			"interval": intervalSchema,
+		},
+	},
+	check: func(cfg map[string]any) (errs, warnings []string) {
+		c, err := parseRebalancingConfig(cfg)
+		if err != nil {
+			return []string{"configuration: " + err.Error()}, nil
+		}
+		sum := decimal.Zero
+		for _, pct := range c.targetAllocations {
+			sum = sum.Add(pct)
+		}
+		if !sum.Equal(decimal.NewFromInt(100)) {
+			errs = append(errs, fmt.Sprintf("configuration.target_allocations: percentages sum to %s, must be 100", sum.String()))
+		}
+		return errs, nil
+	},
+}
+
+type rebalancingConfig struct {
+	currencyAssetID   string
+	targetAllocations map[string]decimal.Decimal
+	threshold         decimal.Decimal
+	interval          string
+}
+
+func parseRebalancingConfig(cfg map[string]any) (*rebalancingConfig, error) {
+	c := &rebalancingConfig{targetAllocations: map[string]decimal.Decimal{}}
+	c.currencyAssetID, _ = cfg["currency_asset_id"].(string)
+	if c.currencyAssetID == "" {
+		return nil, errors.New("currency_asset_id is required")
+	}
+
+	targets, ok := cfg["target_allocations"].(map[string]any)
+	if !ok || len(targets) == 0 {
+		return nil, errors.New("target_allocations must be a non-empty object of asset_id to percent")
+	}
+	for assetID, raw := range targets {
+		pct, err := decimalValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("target_allocations.%s: %w", assetID, err)
+		}
+		if pct.IsNegative() || pct.GreaterThan(decimal.NewFromInt(100)) {
+			return nil, fmt.Errorf("target_allocations.%s must be between 0 and 100", assetID)
+		}
+		c.targetAllocations[assetID] = pct
+	}
+
+	if v, ok := cfg["threshold_percent"]; ok {
+		threshold, err := decimalValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("threshold_percent: %w", err)
+		}
+		if threshold.IsNegative() {
+			return nil, errors.New("threshold_percent must not be negative")
+		}
+		c.threshold = threshold
+	}
+
+	var err error
+	if c.interval, err = parseInterval(cfg); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type rebalancingStrategy struct {
+	config *rebalancingConfig
+	loc    *time.Location
+	period string
+}
+
+func (s *rebalancingStrategy) assets() []string {
+	return sortedKeys(s.config.targetAllocations)
+}
+
+func (s *rebalancingStrategy) benchmarkWeights() map[string]decimal.Decimal {
+	weights := map[string]decimal.Decimal{}
+	for asset, pct := range s.config.targetAllocations {
+		weights[asset] = pct.Div(decimal.NewFromInt(100))
+	}
+	return weights
+}
+
+func (s *rebalancingStrategy) step(bt *backtest, at time.Time) {
+	period := periodKey(s.config.interval, at.In(s.loc))
+	if period == s.period {
+		return
+	}
+	for asset := range s.config.targetAllocations {
+		if _, ok := bt.price(asset); !ok {
+			return
+		}
+	}
+	s.period = period
+
+	total := bt.value(bt.holdings)
+	if !total.IsPositive() {
+		return
+	}
+	hundred := decimal.NewFromInt(100)
+
+	// Assets held without a target are sold down to zero.
+	assets := map[string]bool{}
+	for asset := range s.config.targetAllocations {
+		assets[asset] = true
+	}
+	for asset, amount := range bt.holdings {
+		if amount.IsPositive() {
+			assets[asset] = true
+		}
+	}
+
+	type drift struct {
+		asset string
+		diff  decimal.Decimal // value above target, negative when below
+	}
+	var drifts []drift
+	rebalance := false
+	for _, asset := range sortedKeys(assets) {
+		if asset == bt.currency {
+			continue
+		}
+		price, ok := bt.price(asset)
+		if !ok {
+			bt.warn("unpriced:"+asset, "asset %s has no price and was left out of rebalancing", asset)
+			continue
+		}
+		value := bt.holdings[asset].Mul(price)
+		target := total.Mul(s.config.targetAllocations[asset]).Div(hundred)
+		if value.Sub(target).Abs().Div(total).Mul(hundred).GreaterThan(s.config.threshold) {
+			rebalance = true
+		}
+		drifts = append(drifts, drift{asset: asset, diff: value.Sub(target)})
+	}
+	if !rebalance {
+		return
+	}
+
+	for _, d := range drifts {
+		if d.diff.IsPositive() {
+			price, _ := bt.price(d.asset)
+			bt.sell(at, d.asset, d.diff.Div(price), "rebalance")
+		}
+	}
+
+	// Buy underweight assets with cash above its own target, pro rata when short.
+	cashTarget := total.Mul(s.config.targetAllocations[bt.currency]).Div(hundred)
+	available := bt.cash().Sub(cashTarget)
+	needed := decimal.Zero
+	for _, d := range drifts {
+		if d.diff.IsNegative() {
+			needed = needed.Add(d.diff.Neg())
+		}
+	}
+	if !available.IsPositive() || !needed.IsPositive() {
+		return
+	}
+	scale := decimal.Min(decimal.NewFromInt(1), available.Div(needed))
+	for _, d := range drifts {
+		if d.diff.IsNegative() {
+			bt.buy(at, d.asset, d.diff.Neg().Mul(scale), "rebalance")
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+)
+
+// ruleTypeDefinition registers a rule type with the schema of its configuration.
+type ruleTypeDefinition struct {
+	name        string
+	description string
+	schema      *Schema
+	// check runs semantic checks the schema cannot express on a schema-valid configuration.
+	check func(cfg map[string]any) (errs, warnings []string)
+	// executable marks types that ExecuteRule and SimulateRule can run.
+	// Rules of other types can only be backtested, so they cannot be active.
+	executable bool
+}
+
+var ruleTypes = map[string]*ruleTypeDefinition{
+	RuleTypeMonthlyWithdrawal: monthlyWithdrawalType,
+	RuleTypeDCA:               dcaType,
+	RuleTypeRebalancing:       rebalancingType,
+	RuleTypeStopLoss:          stopLossType,
+}
+
+// sortedRuleTypes returns registered rule types ordered by name.
+func sortedRuleTypes() []*ruleTypeDefinition {
+	defs := make([]*ruleTypeDefinition, 0, len(ruleTypes))
+	for _, d := range ruleTypes {
+		defs = append(defs, d)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].name < defs[j].name })
+	return defs
+}
+
+// RuleReferences resolves everything rule validation looks up.
+type RuleReferences interface {
+	References
+	// PortfolioRole returns the role userID holds on the portfolio, owner for
+	// its creator, or PortfolioRoleUnspecified without access.
+	PortfolioRole(ctx context.Context, portfolioID, userID string) (entity.PortfolioRole, error)
+}
+
+type ruleValidation struct {
+	errors   []string
+	warnings []string
+}
+
+// validateRule checks a complete rule definition. Problems with the rule are
+// reported in the result; the error is reserved for failed lookups.
+func validateRule(ctx context.Context, rule *entity.Rule, refs RuleReferences) (*ruleValidation, error) {
+	result := &ruleValidation{}
+	fail := func(format string, args ...any) {
+		result.errors = append(result.errors, fmt.Sprintf(format, args...))
+	}
+
+	if rule.Name == "" {
+		fail("name: is required")
+	}
+	if rule.UserID == "" {
+		fail("user_id: is required")
+	}
+
+	if rule.PortfolioID == "" {
+		fail("portfolio_id: is required")
+	} else if refs != nil {
+		exists, err := refs.Exists(ctx, "portfolio", rule.PortfolioID)
+		if err != nil {
+			return nil, err
+		}
+		// Rules act on the portfolio, so whoever saves one must be able to
+		// change it: the caller, or the rule's user for unscoped requests.
+		user := rule.UserID
+		if p, ok := auth.FromContext(ctx); ok && !p.HasScope(auth.ScopeAdmin) {
+			user = p.UserID
+		}
+		if !exists {
+			fail("portfolio_id: portfolio %s does not exist", rule.PortfolioID)
+		} else if user != "" {
+			role, err := refs.PortfolioRole(ctx, rule.PortfolioID, user)
+			if err != nil {
+				return nil, err
+			}
+			if role < entity.PortfolioRoleEditor {
+				fail("portfolio_id: user %s needs at least the editor role on portfolio %s", user, rule.PortfolioID)
+			}
+		}
+	}
+
+	if rule.Schedule != nil && rule.Schedule.Timezone != "" {
+		if _, err := time.LoadLocation(rule.Schedule.Timezone); err != nil {
+			fail("schedule.timezone: unknown time zone %q", rule.Schedule.Timezone)
+		}
+	}
+
+	def, ok := ruleTypes[rule.RuleType]
+	if !ok {
+		if rule.RuleType == "" {
+			fail("rule_type: is required")
+		} else {
+			fail("rule_type: unknown rule type %q", rule.RuleType)
+		}
+		return result, nil
+	}
+	if !def.executable && (rule.Status == entity.RuleStatusActive || rule.Status == entity.RuleStatusUnknown) {
+		fail("status: %s rules can only be backtested, so they must be paused or disabled", rule.RuleType)
+	}
+
+	errs, warnings, err := checkConfiguration(ctx, def, rule.Configuration, refs)
+	if err != nil {
+		return nil, err
+	}
+	result.errors = append(result.errors, errs...)
+	result.warnings = append(result.warnings, warnings...)
+
+	return result, nil
+}
+
+// checkConfiguration checks a configuration against the schema of its rule
+// type, then runs the type's own checks on a schema-valid one. Referenced
+// entities are looked up only when refs is set.
+func checkConfiguration(ctx context.Context, def *ruleTypeDefinition, cfg map[string]any, refs References) (errs, warnings []string, err error) {
+	if cfg == nil {
+		cfg = map[string]any{}
+	}
+
+	v := &schemaValidator{ctx: ctx, refs: refs}
+	if err := v.validate("configuration", def.schema, cfg); err != nil {
+		return nil, nil, err
+	}
+	if len(v.errors) > 0 || def.check == nil {
+		return v.errors, nil, nil
+	}
+	errs, warnings = def.check(cfg)
+	return errs, warnings, nil
+}
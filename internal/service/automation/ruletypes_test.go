@@ -0,0 +1,273 @@
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUserID      = "6f1c1a4e-2b7e-4c55-9d4f-0a9b8c7d6e5f"
+	testPortfolioID = "0b6f7a3c-5d2e-4f1a-8b9c-1d2e3f4a5b6c"
+	testUSD         = "11111111-1111-4111-8111-111111111111"
+	testBTC         = "22222222-2222-4222-8222-222222222222"
+	testMissing     = "33333333-3333-4333-8333-333333333333"
+)
+
+type fakeReferences struct {
+	assets  map[string]bool
+	owners  map[string]string
+	members map[string]entity.PortfolioRole // By user, on every portfolio
+}
+
+func (f fakeReferences) Exists(_ context.Context, kind, id string) (bool, error) {
+	if kind == "asset" {
+		return f.assets[id], nil
+	}
+	_, ok := f.owners[id]
+	return ok, nil
+}
+
+func (f fakeReferences) PortfolioRole(_ context.Context, id, userID string) (entity.PortfolioRole, error) {
+	if owner, ok := f.owners[id]; ok && owner == userID {
+		return entity.PortfolioRoleOwner, nil
+	}
+	return f.members[userID], nil
+}
+
+var testRefs = fakeReferences{
+	assets: map[string]bool{testUSD: true, testBTC: true},
+	owners: map[string]string{testPortfolioID: testUserID},
+}
+
+func withdrawalRule(cfg map[string]any) *entity.Rule {
+	return &entity.Rule{
+		Name:          "Monthly pension",
+		RuleType:      RuleTypeMonthlyWithdrawal,
+		PortfolioID:   testPortfolioID,
+		UserID:        testUserID,
+		Configuration: cfg,
+	}
+}
+
+func TestValidateRule_Valid(t *testing.T) {
+	result, err := validateRule(context.Background(), withdrawalRule(map[string]any{
+		"amount":             "2500",
+		"currency_asset_id":  testUSD,
+		"target_allocations": map[string]any{testBTC: float64(60), testUSD: float64(40)},
+	}), testRefs)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.errors)
+	assert.Empty(t, result.warnings)
+}
+
+func TestValidateRule_ConfigurationErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    map[string]any
+		errors []string
+	}{
+		{
+			name:   "missing required keys",
+			cfg:    map[string]any{},
+			errors: []string{"configuration.amount: is required", "configuration.currency_asset_id: is required"},
+		},
+		{
+			name: "typo in key",
+			cfg:  map[string]any{"amount": float64(1), "currency_asset_id": testUSD, "sell_ordr": "lowest_gain_first"},
+			errors: []string{
+				`configuration.sell_ordr: unknown field, did you mean "sell_order"?`,
+			},
+		},
+		{
+			name: "wrong types and ranges",
+			cfg:  map[string]any{"amount": float64(0), "currency_asset_id": float64(5)},
+			errors: []string{
+				"configuration.amount: must be greater than 0",
+				"configuration.currency_asset_id: must be string, got number",
+			},
+		},
+		{
+			name: "unknown enum value",
+			cfg:  map[string]any{"amount": "10", "currency_asset_id": testUSD, "sell_order": "fifo"},
+			errors: []string{
+				`configuration.sell_order: must be one of "overweight_first", "lowest_gain_first", "largest_loss_first"`,
+			},
+		},
+		{
+			name: "missing referenced assets",
+			cfg: map[string]any{
+				"amount":             "10",
+				"currency_asset_id":  testMissing,
+				"target_allocations": map[string]any{"btc": float64(50), testBTC: float64(150)},
+			},
+			errors: []string{
+				"configuration.currency_asset_id: asset " + testMissing + " does not exist",
+				"configuration.target_allocations." + testBTC + ": must be at most 100",
+				"configuration.target_allocations.btc: must be a UUID",
+			},
+		},
+		{
+			name: "targets over 100 percent",
+			cfg: map[string]any{
+				"amount":             "10",
+				"currency_asset_id":  testUSD,
+				"target_allocations": map[string]any{testBTC: float64(80), testUSD: float64(30)},
+			},
+			errors: []string{"configuration.target_allocations: percentages sum to 110, must not exceed 100"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validateRule(context.Background(), withdrawalRule(tt.cfg), testRefs)
+			require.NoError(t, err)
+			assert.Equal(t, tt.errors, result.errors)
+		})
+	}
+}
+
+func TestValidateRule_RuleErrors(t *testing.T) {
+	cfg := map[string]any{"amount": "10", "currency_asset_id": testUSD}
+
+	t.Run("unknown rule type", func(t *testing.T) {
+		rule := withdrawalRule(cfg)
+		rule.RuleType = "monthly_withdrawl"
+		result, err := validateRule(context.Background(), rule, testRefs)
+		require.NoError(t, err)
+		assert.Equal(t, []string{`rule_type: unknown rule type "monthly_withdrawl"`}, result.errors)
+	})
+
+	t.Run("portfolio of another user", func(t *testing.T) {
+		rule := withdrawalRule(cfg)
+		rule.UserID = testMissing
+		result, err := validateRule(context.Background(), rule, testRefs)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"portfolio_id: user " + testMissing + " needs at least the editor role on portfolio " + testPortfolioID,
+		}, result.errors)
+	})
+
+	t.Run("shared portfolio", func(t *testing.T) {
+		refs := testRefs
+		for role, want := range map[entity.PortfolioRole]int{entity.PortfolioRoleViewer: 1, entity.PortfolioRoleEditor: 0} {
+			refs.members = map[string]entity.PortfolioRole{testMissing: role}
+			rule := withdrawalRule(cfg)
+			rule.UserID = testMissing
+			result, err := validateRule(context.Background(), rule, refs)
+			require.NoError(t, err)
+			assert.Len(t, result.errors, want, "role %d", role)
+		}
+	})
+
+	t.Run("caller rather than rule user", func(t *testing.T) {
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: testMissing, Scopes: []string{auth.ScopeWrite}})
+		result, err := validateRule(ctx, withdrawalRule(cfg), testRefs)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"portfolio_id: user " + testMissing + " needs at least the editor role on portfolio " + testPortfolioID,
+		}, result.errors)
+	})
+
+	t.Run("unknown time zone", func(t *testing.T) {
+		rule := withdrawalRule(cfg)
+		rule.Schedule = &entity.RuleSchedule{CronExpression: "0 9 1 * *", Timezone: "Mars/Olympus"}
+		result, err := validateRule(context.Background(), rule, testRefs)
+		require.NoError(t, err)
+		assert.Equal(t, []string{`schedule.timezone: unknown time zone "Mars/Olympus"`}, result.errors)
+	})
+}
+
+func TestValidateRule_Warnings(t *testing.T) {
+	result, err := validateRule(context.Background(), withdrawalRule(map[string]any{
+		"amount":             float64(100),
+		"currency_asset_id":  testUSD,
+		"sell_order":         SellOrderLowestGainFirst,
+		"target_allocations": map[string]any{testBTC: float64(50)},
+	}), testRefs)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.errors)
+	assert.Equal(t, []string{"configuration.target_allocations: ignored by sell_order lowest_gain_first"}, result.warnings)
+}
+
+func TestRuleTypeSchemaJSON(t *testing.T) {
+	raw, err := json.Marshal(monthlyWithdrawalType.schema)
+	require.NoError(t, err)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(raw, &schema))
+
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, false, schema["additionalProperties"])
+
+	props := schema["properties"].(map[string]any)
+	amount := props["amount"].(map[string]any)
+	assert.Equal(t, []any{"number", "string"}, amount["type"])
+	assert.Equal(t, float64(0), amount["exclusiveMinimum"])
+
+	targets := props["target_allocations"].(map[string]any)
+	assert.Equal(t, "asset", targets["propertyNames"].(map[string]any)["x-reference"])
+	assert.Equal(t, float64(100), targets["additionalProperties"].(map[string]any)["maximum"])
+}
+
+func TestValidateRule_StrategyTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		ruleType string
+		cfg      map[string]any
+		errors   []string
+	}{
+		{
+			name:     "dca",
+			ruleType: RuleTypeDCA,
+			cfg:      map[string]any{"amount": "100", "currency_asset_id": testUSD, "target_asset_id": testBTC, "interval": "weekly"},
+		},
+		{
+			name:     "dca buying its own currency",
+			ruleType: RuleTypeDCA,
+			cfg:      map[string]any{"amount": "100", "currency_asset_id": testUSD, "target_asset_id": testUSD},
+			errors:   []string{"configuration.target_asset_id: must differ from currency_asset_id"},
+		},
+		{
+			name:     "rebalancing targets below 100 percent",
+			ruleType: RuleTypeRebalancing,
+			cfg: map[string]any{
+				"currency_asset_id":  testUSD,
+				"target_allocations": map[string]any{testBTC: float64(60), testUSD: float64(30)},
+			},
+			errors: []string{"configuration.target_allocations: percentages sum to 90, must be 100"},
+		},
+		{
+			name:     "stop loss",
+			ruleType: RuleTypeStopLoss,
+			cfg:      map[string]any{"currency_asset_id": testUSD, "threshold_percent": float64(15), "trailing": "yes"},
+			errors:   []string{"configuration.trailing: must be boolean, got string"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := withdrawalRule(tt.cfg)
+			rule.RuleType = tt.ruleType
+			rule.Status = entity.RuleStatusPaused
+			result, err := validateRule(context.Background(), rule, testRefs)
+			require.NoError(t, err)
+			assert.Equal(t, tt.errors, result.errors)
+		})
+	}
+
+	t.Run("active strategy rule", func(t *testing.T) {
+		rule := withdrawalRule(map[string]any{"amount": "100", "currency_asset_id": testUSD, "target_asset_id": testBTC})
+		rule.RuleType = RuleTypeDCA
+		result, err := validateRule(context.Background(), rule, testRefs)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"status: dca rules can only be backtested, so they must be paused or disabled"}, result.errors)
+	})
+}
@@ -0,0 +1,301 @@
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Schema is the subset of JSON Schema used to describe rule configurations.
+// Objects reject unknown keys unless AdditionalProperties allows them.
+type Schema struct {
+	Type                 Types              `json:"type,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Format               string             `json:"format,omitempty"` // "uuid" or "decimal"
+	Enum                 []any              `json:"enum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	PropertyNames        *Schema            `json:"propertyNames,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	// Reference names the entity a string must identify, e.g. "asset".
+	Reference string `json:"x-reference,omitempty"`
+}
+
+// MarshalJSON emits additionalProperties: false for closed objects.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type plain Schema
+	if s.Type.has("object") && s.AdditionalProperties == nil {
+		return json.Marshal(struct {
+			*plain
+			AdditionalProperties bool `json:"additionalProperties"`
+		}{plain: (*plain)(s)})
+	}
+	return json.Marshal((*plain)(s))
+}
+
+// Types lists the JSON types a value may have; a single type marshals as a string.
+type Types []string
+
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+func (t Types) has(name string) bool {
+	for _, v := range t {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+// References checks that referenced entities exist.
+type References interface {
+	Exists(ctx context.Context, kind, id string) (bool, error)
+}
+
+// schemaValidator collects every violation instead of stopping at the first one.
+type schemaValidator struct {
+	ctx    context.Context
+	refs   References
+	errors []string
+}
+
+func (v *schemaValidator) fail(path, format string, args ...any) {
+	v.errors = append(v.errors, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (v *schemaValidator) validate(path string, s *Schema, value any) error {
+	if !v.checkType(path, s, value) {
+		return nil
+	}
+
+	if len(s.Enum) > 0 && !containsValue(s.Enum, value) {
+		v.fail(path, "must be one of %s", joinValues(s.Enum))
+		return nil
+	}
+
+	switch val := value.(type) {
+	case map[string]any:
+		return v.validateObject(path, s, val)
+	case string:
+		if s.Format == "decimal" {
+			d, err := decimal.NewFromString(val)
+			if err != nil {
+				v.fail(path, "must be a decimal number")
+				return nil
+			}
+			v.checkRange(path, s, d.InexactFloat64())
+			return nil
+		}
+		return v.validateString(path, s, val)
+	case float64:
+		v.checkRange(path, s, val)
+	}
+	return nil
+}
+
+func (v *schemaValidator) checkType(path string, s *Schema, value any) bool {
+	if len(s.Type) == 0 {
+		return true
+	}
+	for _, t := range s.Type {
+		if matchesType(t, value) {
+			return true
+		}
+	}
+	v.fail(path, "must be %s, got %s", strings.Join(s.Type, " or "), typeName(value))
+	return false
+}
+
+func (v *schemaValidator) checkRange(path string, s *Schema, n float64) {
+	if s.ExclusiveMinimum != nil && n <= *s.ExclusiveMinimum {
+		v.fail(path, "must be greater than %v", *s.ExclusiveMinimum)
+	}
+	if s.Minimum != nil && n < *s.Minimum {
+		v.fail(path, "must be at least %v", *s.Minimum)
+	}
+	if s.Maximum != nil && n > *s.Maximum {
+		v.fail(path, "must be at most %v", *s.Maximum)
+	}
+}
+
+func (v *schemaValidator) validateString(path string, s *Schema, val string) error {
+	if s.Format == "uuid" {
+		if _, err := uuid.Parse(val); err != nil {
+			v.fail(path, "must be a UUID")
+			return nil
+		}
+	}
+	if s.Reference != "" && v.refs != nil {
+		ok, err := v.refs.Exists(v.ctx, s.Reference, val)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			v.fail(path, "%s %s does not exist", s.Reference, val)
+		}
+	}
+	return nil
+}
+
+func (v *schemaValidator) validateObject(path string, s *Schema, obj map[string]any) error {
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			v.fail(joinPath(path, name), "is required")
+		}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		childPath := joinPath(path, k)
+		if s.PropertyNames != nil {
+			before := len(v.errors)
+			if err := v.validate(childPath, s.PropertyNames, k); err != nil {
+				return err
+			}
+			if len(v.errors) > before {
+				continue
+			}
+		}
+
+		child, ok := s.Properties[k]
+		if !ok {
+			child = s.AdditionalProperties
+		}
+		if child == nil {
+			if suggestion := closestKey(k, s.Properties); suggestion != "" {
+				v.fail(childPath, "unknown field, did you mean %q?", suggestion)
+			} else {
+				v.fail(childPath, "unknown field")
+			}
+			continue
+		}
+		if err := v.validate(childPath, child, obj[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func matchesType(t string, value any) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "null":
+		return value == nil
+	}
+	return false
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinValues(values []any) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, fmt.Sprintf("%q", fmt.Sprint(v)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// closestKey suggests a known property within two edits of key.
+func closestKey(key string, properties map[string]*Schema) string {
+	best, bestDist := "", 3
+	for name := range properties {
+		if d := editDistance(key, name); d < bestDist || (d == bestDist && name < best) {
+			best, bestDist = name, d
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,125 @@
+package automation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// RuleTypeStopLoss sells a position once its price falls a given percentage
+// below its entry price, or below its highest price when trailing.
+//
+// Configuration:
+//
+//	currency_asset_id  asset positions are sold for, required
+//	threshold_percent  loss that triggers the sale, required
+//	trailing           measure the loss from the highest price seen (default false)
+const RuleTypeStopLoss = "stop_loss"
+
+var stopLossType = &ruleTypeDefinition{
+	name:        RuleTypeStopLoss,
+	description: "Sells a position once its price falls a percentage below its entry or, when trailing, its highest price.",
+	schema: &Schema{
+		Type:     Types{"object"},
+		Required: []string{"currency_asset_id", "threshold_percent"},
+		Properties: map[string]*Schema{
+			"currency_asset_id": {
+				Type:        Types{"string"},
+				Format:      "uuid",
+				Description: "Asset positions are sold for.",
+				Reference:   "asset",
+			},
+			"threshold_percent": {
+				Type:             Types{"number", "string"},
+				Format:           "decimal",
+				Description:      "Price drop in percent that triggers the sale.",
+				ExclusiveMinimum: ptr(0.0),
+				Maximum:          ptr(100.0),
+			},
+			"trailing": {
+				Type:        Types{"boolean"},
+				Description: "Measure the drop from the highest price since entry instead of the entry price.",
+				Default:     false,
+			},
+		},
+	},
+	check: func(cfg map[string]any) (errs, warnings []string) {
+		if _, err := parseStopLossConfig(cfg); err != nil {
+			return []string{"configuration: " + err.Error()}, nil
+		}
+		return nil, nil
+	},
+}
+
+type stopLossConfig struct {
+	currencyAssetID string
+	threshold       decimal.Decimal
+	trailing        bool
+}
+
+func parseStopLossConfig(cfg map[string]any) (*stopLossConfig, error) {
+	c := &stopLossConfig{}
+	c.currencyAssetID, _ = cfg["currency_asset_id"].(string)
+	if c.currencyAssetID == "" {
+		return nil, errors.New("currency_asset_id is required")
+	}
+
+	threshold, err := decimalValue(cfg["threshold_percent"])
+	if err != nil {
+		return nil, fmt.Errorf("threshold_percent: %w", err)
+	}
+	if !threshold.IsPositive() || threshold.GreaterThan(decimal.NewFromInt(100)) {
+		return nil, errors.New("threshold_percent must be greater than 0 and at most 100")
+	}
+	c.threshold = threshold
+
+	if v, ok := cfg["trailing"]; ok {
+		trailing, ok := v.(bool)
+		if !ok {
+			return nil, errors.New("trailing must be a boolean")
+		}
+		c.trailing = trailing
+	}
+	return c, nil
+}
+
+// stopLossStrategy checks every position at every bar. A position's entry
+// price is its first price in the backtest.
+type stopLossStrategy struct {
+	config    *stopLossConfig
+	reference map[string]decimal.Decimal
+}
+
+func (s *stopLossStrategy) assets() []string {
+	return nil
+}
+
+func (s *stopLossStrategy) benchmarkWeights() map[string]decimal.Decimal {
+	return nil
+}
+
+func (s *stopLossStrategy) step(bt *backtest, at time.Time) {
+	if s.reference == nil {
+		s.reference = map[string]decimal.Decimal{}
+	}
+	trigger := decimal.NewFromInt(1).Sub(s.config.threshold.Div(decimal.NewFromInt(100)))
+
+	for _, asset := range sortedKeys(bt.holdings) {
+		amount := bt.holdings[asset]
+		price, ok := bt.price(asset)
+		if asset == bt.currency || !amount.IsPositive() || !ok {
+			continue
+		}
+
+		ref, seen := s.reference[asset]
+		if !seen || (s.config.trailing && price.GreaterThan(ref)) {
+			s.reference[asset] = price
+			continue
+		}
+		if price.LessThanOrEqual(ref.Mul(trigger)) {
+			bt.sell(at, asset, amount, "stop_loss")
+		}
+	}
+}
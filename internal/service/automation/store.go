@@ -0,0 +1,71 @@
+package automation
+
+import (
+	"context"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/foxcool/greedy-eye/internal/service/portfolio"
+)
+
+// Store defines the data access contract for AutomationService.
+type Store interface {
+	// Rules
+	CreateRule(ctx context.Context, r *entity.Rule) (*entity.Rule, error)
+	GetRule(ctx context.Context, id string) (*entity.Rule, error)
+	UpdateRule(ctx context.Context, r *entity.Rule, fields []string) (*entity.Rule, error)
+	DeleteRule(ctx context.Context, id string) error
+	ListRules(ctx context.Context, opts ListRulesOpts) ([]*entity.Rule, string, error)
+
+	// Rule executions
+	CreateRuleExecution(ctx context.Context, e *entity.RuleExecution) (*entity.RuleExecution, error)
+	GetRuleExecution(ctx context.Context, id string) (*entity.RuleExecution, error)
+	UpdateRuleExecution(ctx context.Context, e *entity.RuleExecution, fields []string) (*entity.RuleExecution, error)
+	ListRuleExecutions(ctx context.Context, opts ListRuleExecutionsOpts) ([]*entity.RuleExecution, string, error)
+
+	// Execution queue
+	ClaimRuleExecution(ctx context.Context, workerID string) (*entity.RuleExecution, error)
+	HeartbeatRuleExecution(ctx context.Context, id, workerID string) (cancelRequested bool, err error)
+	CancelRuleExecution(ctx context.Context, id, reason string) (*entity.RuleExecution, error)
+	RecoverRuleExecutions(ctx context.Context, staleBefore time.Time) (requeued, failed int, err error)
+}
+
+// PortfolioStore is the subset of portfolio.Store that rule execution needs.
+type PortfolioStore interface {
+	GetPortfolio(ctx context.Context, id string) (*entity.Portfolio, error)
+	ListPortfolioMembers(ctx context.Context, opts portfolio.ListPortfolioMembersOpts) ([]*entity.PortfolioMember, error)
+	ListHoldings(ctx context.Context, opts portfolio.ListHoldingsOpts) ([]*entity.Holding, string, error)
+	ListLots(ctx context.Context, opts portfolio.ListLotsOpts) ([]*entity.Lot, string, error)
+	ApplyWithdrawalStep(ctx context.Context, w *portfolio.WithdrawalStep) error
+	CreateTransaction(ctx context.Context, t *entity.Transaction) (*entity.Transaction, error)
+}
+
+// MarketDataStore is the subset of marketdata.Store that rule execution needs.
+type MarketDataStore interface {
+	GetAsset(ctx context.Context, id string) (*entity.Asset, error)
+	GetLatestPrice(ctx context.Context, assetID, baseAssetID, sourceID string) (*entity.StoredPrice, error)
+	ListPriceHistory(ctx context.Context, opts marketdata.ListPriceHistoryOpts) ([]*entity.StoredPrice, string, error)
+}
+
+// ListRulesOpts contains options for listing rules.
+type ListRulesOpts struct {
+	UserID      string
+	PortfolioID string
+	RuleType    string
+	Status      entity.RuleStatus
+	PageSize    int
+	PageToken   string
+}
+
+// ListRuleExecutionsOpts contains options for listing rule executions.
+type ListRuleExecutionsOpts struct {
+	RuleID      string
+	PortfolioID string
+	UserID      string
+	Status      entity.ExecutionStatus
+	From        *time.Time
+	To          *time.Time
+	PageSize    int
+	PageToken   string
+}
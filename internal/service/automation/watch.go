@@ -0,0 +1,82 @@
+package automation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/pubsub"
+	"google.golang.org/protobuf/proto"
+)
+
+// watchPollInterval bounds how long a watcher can miss a change that was not
+// published, e.g. one made by crash recovery.
+const watchPollInterval = 5 * time.Second
+
+// WatchRuleExecution streams the execution when it changes until it completes,
+// fails or is cancelled.
+func (h *Handler) WatchRuleExecution(ctx context.Context, req *connect.Request[apiv1.WatchRuleExecutionRequest], stream *connect.ServerStream[apiv1.RuleExecution]) error {
+	if req.Msg.Id == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("ID is required"))
+	}
+
+	// Subscribe before the first read so no change is missed in between.
+	sub := h.events.Subscribe(executionTopic(req.Msg.Id))
+	defer sub.Close()
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	var last *apiv1.RuleExecution
+	for {
+		exec, err := h.store.GetRuleExecution(ctx, req.Msg.Id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return toConnectError(err)
+		}
+
+		msg := ruleExecutionToProto(exec)
+		if !proto.Equal(msg, last) {
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+			last = msg
+		}
+		if isTerminal(exec.Status) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-sub.C:
+			if !ok {
+				return nil
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishExecution notifies watchers that the execution changed. Watchers
+// read the execution back, so the event carries no payload.
+func (h *Handler) publishExecution(ctx context.Context, exec *entity.RuleExecution) {
+	h.events.Publish(ctx, executionTopic(exec.ID), nil)
+}
+
+func executionTopic(id string) string {
+	return pubsub.Topic("rule_executions", id)
+}
+
+func isTerminal(status entity.ExecutionStatus) bool {
+	switch status {
+	case entity.ExecutionStatusCompleted, entity.ExecutionStatusFailed, entity.ExecutionStatusCancelled:
+		return true
+	}
+	return false
+}
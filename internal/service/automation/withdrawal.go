@@ -0,0 +1,761 @@
+package automation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/portfolio"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+)
+
+// RuleTypeMonthlyWithdrawal raises a target cash amount from a portfolio.
+//
+// Configuration:
+//
+//	amount             number or decimal string, required
+//	currency_asset_id  asset the amount is denominated in, required
+//	sell_order         overweight_first (default), lowest_gain_first, largest_loss_first
+//	target_allocations {asset_id: percent}, used by overweight_first
+const RuleTypeMonthlyWithdrawal = "monthly_withdrawal"
+
+// Sell orders supported by monthly_withdrawal rules.
+const (
+	SellOrderOverweightFirst  = "overweight_first"
+	SellOrderLowestGainFirst  = "lowest_gain_first"
+	SellOrderLargestLossFirst = "largest_loss_first"
+)
+
+var monthlyWithdrawalType = &ruleTypeDefinition{
+	name:        RuleTypeMonthlyWithdrawal,
+	description: "Raises a cash amount from the portfolio: cash balances first, then sales in the configured order.",
+	executable:  true,
+	schema: &Schema{
+		Type:     Types{"object"},
+		Required: []string{"amount", "currency_asset_id"},
+		Properties: map[string]*Schema{
+			"amount": {
+				Type:             Types{"number", "string"},
+				Format:           "decimal",
+				Description:      "Cash amount to withdraw, in the currency asset.",
+				ExclusiveMinimum: ptr(0.0),
+			},
+			"currency_asset_id": {
+				Type:        Types{"string"},
+				Format:      "uuid",
+				Description: "Asset the amount is denominated in; holdings of it are drawn as cash.",
+				Reference:   "asset",
+			},
+			"sell_order": {
+				Type:        Types{"string"},
+				Description: "Order in which positions are sold once cash is exhausted.",
+				Enum:        []any{SellOrderOverweightFirst, SellOrderLowestGainFirst, SellOrderLargestLossFirst},
+				Default:     SellOrderOverweightFirst,
+			},
+			"target_allocations": {
+				Type:          Types{"object"},
+				Description:   "Target weight in percent by asset ID, used by overweight_first.",
+				PropertyNames: &Schema{Type: Types{"string"}, Format: "uuid", Reference: "asset"},
+				AdditionalProperties: &Schema{
+					Type:    Types{"number", "string"},
+					Format:  "decimal",
+					Minimum: ptr(0.0),
+					Maximum: ptr(100.0),
+				},
+			},
+		},
+	},
+	check: checkWithdrawalConfig,
+}
+
+func checkWithdrawalConfig(cfg map[string]any) (errs, warnings []string) {
+	c, err := parseWithdrawalConfig(cfg)
+	if err != nil {
+		return []string{"configuration: " + err.Error()}, nil
+	}
+
+	sum := decimal.Zero
+	for _, pct := range c.targetAllocations {
+		sum = sum.Add(pct)
+	}
+	hundred := decimal.NewFromInt(100)
+
+	switch {
+	case sum.GreaterThan(hundred):
+		errs = append(errs, fmt.Sprintf(
+			"configuration.target_allocations: percentages sum to %s, must not exceed 100", sum.String()))
+	case c.sellOrder != SellOrderOverweightFirst && len(c.targetAllocations) > 0:
+		warnings = append(warnings, fmt.Sprintf(
+			"configuration.target_allocations: ignored by sell_order %s", c.sellOrder))
+	case c.sellOrder == SellOrderOverweightFirst && len(c.targetAllocations) == 0:
+		warnings = append(warnings,
+			"configuration.target_allocations: not set, overweight_first sells the largest positions first")
+	case c.sellOrder == SellOrderOverweightFirst && sum.LessThan(hundred):
+		warnings = append(warnings, fmt.Sprintf(
+			"configuration.target_allocations: percentages sum to %s, assets without a target are sold first", sum.String()))
+	}
+
+	return errs, warnings
+}
+
+type withdrawalConfig struct {
+	amount            decimal.Decimal
+	currencyAssetID   string
+	sellOrder         string
+	targetAllocations map[string]decimal.Decimal
+}
+
+func parseWithdrawalConfig(cfg map[string]any) (*withdrawalConfig, error) {
+	amount, err := decimalValue(cfg["amount"])
+	if err != nil {
+		return nil, fmt.Errorf("amount: %w", err)
+	}
+	if !amount.IsPositive() {
+		return nil, errors.New("amount must be positive")
+	}
+
+	currency, _ := cfg["currency_asset_id"].(string)
+	if currency == "" {
+		return nil, errors.New("currency_asset_id is required")
+	}
+
+	c := &withdrawalConfig{
+		amount:            amount,
+		currencyAssetID:   currency,
+		sellOrder:         SellOrderOverweightFirst,
+		targetAllocations: map[string]decimal.Decimal{},
+	}
+
+	if v, ok := cfg["sell_order"]; ok {
+		order, _ := v.(string)
+		switch order {
+		case SellOrderOverweightFirst, SellOrderLowestGainFirst, SellOrderLargestLossFirst:
+			c.sellOrder = order
+		default:
+			return nil, fmt.Errorf("unsupported sell_order %v", v)
+		}
+	}
+
+	if v, ok := cfg["target_allocations"]; ok {
+		targets, ok := v.(map[string]any)
+		if !ok {
+			return nil, errors.New("target_allocations must be an object of asset_id to percent")
+		}
+		for assetID, raw := range targets {
+			pct, err := decimalValue(raw)
+			if err != nil {
+				return nil, fmt.Errorf("target_allocations.%s: %w", assetID, err)
+			}
+			if pct.IsNegative() || pct.GreaterThan(decimal.NewFromInt(100)) {
+				return nil, fmt.Errorf("target_allocations.%s must be between 0 and 100", assetID)
+			}
+			c.targetAllocations[assetID] = pct
+		}
+	}
+
+	return c, nil
+}
+
+// decimalValue accepts JSON numbers and decimal strings.
+func decimalValue(v any) (decimal.Decimal, error) {
+	switch t := v.(type) {
+	case float64:
+		return decimal.NewFromFloat(t), nil
+	case int:
+		return decimal.NewFromInt(int64(t)), nil
+	case int64:
+		return decimal.NewFromInt(t), nil
+	case string:
+		d, err := decimal.NewFromString(t)
+		if err != nil {
+			return decimal.Zero, fmt.Errorf("invalid decimal %q", t)
+		}
+		return d, nil
+	case nil:
+		return decimal.Zero, errors.New("value is required")
+	default:
+		return decimal.Zero, fmt.Errorf("unsupported value type %T", v)
+	}
+}
+
+// withdrawalPosition is a holding with its lots and latest price in the withdrawal currency.
+type withdrawalPosition struct {
+	holding *entity.Holding
+	lots    []*entity.Lot
+	price   decimal.Decimal
+	priced  bool
+}
+
+// withdrawalStep draws cash from, or sells part of, a single holding or lot.
+type withdrawalStep struct {
+	holding      *entity.Holding
+	lot          *entity.Lot // nil for cash and for amounts not covered by lots
+	amount       decimal.Decimal
+	price        decimal.Decimal
+	value        decimal.Decimal
+	costBasis    decimal.Decimal
+	gainKnown    bool
+	needsSelling bool
+}
+
+func (s *withdrawalStep) gain() decimal.Decimal {
+	return s.value.Sub(s.costBasis)
+}
+
+type withdrawalPlan struct {
+	config    *withdrawalConfig
+	available decimal.Decimal
+	covered   decimal.Decimal
+	steps     []*withdrawalStep
+	warnings  []string
+}
+
+func (p *withdrawalPlan) sufficientFunds() bool {
+	return p.covered.GreaterThanOrEqual(p.config.amount)
+}
+
+func (p *withdrawalPlan) realizedGain() decimal.Decimal {
+	total := decimal.Zero
+	for _, s := range p.steps {
+		if s.gainKnown {
+			total = total.Add(s.gain())
+		}
+	}
+	return total
+}
+
+// sellCandidate is the unsold part of a lot, or of a holding without lot coverage.
+type sellCandidate struct {
+	holding    *entity.Holding
+	lot        *entity.Lot
+	quantity   decimal.Decimal
+	costBasis  decimal.Decimal
+	gainKnown  bool
+	acquiredAt time.Time
+	price      decimal.Decimal
+	step       *withdrawalStep
+}
+
+func (c *sellCandidate) value() decimal.Decimal {
+	return c.quantity.Mul(c.price)
+}
+
+// planWithdrawal draws cash holdings first and then sells positions in the
+// configured order until the requested amount is covered. Positions without
+// a price are skipped. The plan covers less than requested when funds run out.
+func planWithdrawal(cfg *withdrawalConfig, positions []*withdrawalPosition) *withdrawalPlan {
+	plan := &withdrawalPlan{config: cfg}
+	remaining := cfg.amount
+
+	var cash []*withdrawalPosition
+	var candidates []*sellCandidate
+	assetValues := map[string]decimal.Decimal{}
+	total := decimal.Zero
+
+	for _, p := range positions {
+		qty := amountToDecimal(p.holding.Amount, p.holding.Decimals)
+		if !qty.IsPositive() {
+			continue
+		}
+		if p.holding.AssetID == cfg.currencyAssetID {
+			cash = append(cash, p)
+			total = total.Add(qty)
+			continue
+		}
+		if !p.priced {
+			plan.warnings = append(plan.warnings, fmt.Sprintf(
+				"holding %s skipped: no price for asset %s in %s", p.holding.ID, p.holding.AssetID, cfg.currencyAssetID))
+			continue
+		}
+		cs := candidatesForPosition(p, cfg.currencyAssetID, &plan.warnings)
+		candidates = append(candidates, cs...)
+		value := qty.Mul(p.price)
+		assetValues[p.holding.AssetID] = assetValues[p.holding.AssetID].Add(value)
+		total = total.Add(value)
+	}
+	plan.available = total
+
+	// Cash first, largest balance first.
+	sort.SliceStable(cash, func(i, j int) bool {
+		qi := amountToDecimal(cash[i].holding.Amount, cash[i].holding.Decimals)
+		qj := amountToDecimal(cash[j].holding.Amount, cash[j].holding.Decimals)
+		if !qi.Equal(qj) {
+			return qi.GreaterThan(qj)
+		}
+		return cash[i].holding.ID < cash[j].holding.ID
+	})
+	for _, p := range cash {
+		if !remaining.IsPositive() {
+			break
+		}
+		qty := amountToDecimal(p.holding.Amount, p.holding.Decimals)
+		take := decimal.Min(qty, remaining.RoundCeil(int32(p.holding.Decimals)))
+		plan.steps = append(plan.steps, &withdrawalStep{
+			holding:   p.holding,
+			amount:    take,
+			price:     decimal.NewFromInt(1),
+			value:     take,
+			costBasis: take,
+			gainKnown: true,
+		})
+		plan.covered = plan.covered.Add(take)
+		remaining = remaining.Sub(take)
+	}
+
+	switch cfg.sellOrder {
+	case SellOrderLowestGainFirst:
+		sortCandidates(candidates, func(c *sellCandidate) decimal.Decimal {
+			value := c.value()
+			if value.IsZero() {
+				return decimal.Zero
+			}
+			return value.Sub(c.costBasis).Div(value)
+		})
+		for _, c := range candidates {
+			remaining = plan.sell(c, remaining)
+		}
+	case SellOrderLargestLossFirst:
+		sortCandidates(candidates, func(c *sellCandidate) decimal.Decimal {
+			return c.value().Sub(c.costBasis)
+		})
+		for _, c := range candidates {
+			remaining = plan.sell(c, remaining)
+		}
+	default:
+		ordered, excess := overweightOrder(candidates, assetValues, total, cfg.targetAllocations)
+		// First trim each asset down to its target, then sell further in the same order.
+		for _, c := range ordered {
+			budget := decimal.Min(remaining, excess[c.holding.AssetID])
+			if !budget.IsPositive() {
+				continue
+			}
+			sold := budget.Sub(plan.sell(c, budget))
+			excess[c.holding.AssetID] = excess[c.holding.AssetID].Sub(sold)
+			remaining = remaining.Sub(sold)
+		}
+		for _, c := range ordered {
+			remaining = plan.sell(c, remaining)
+		}
+	}
+
+	if !plan.sufficientFunds() {
+		plan.warnings = append(plan.warnings, fmt.Sprintf(
+			"insufficient funds: requested %s, can cover %s", cfg.amount.String(), plan.covered.String()))
+	}
+
+	return plan
+}
+
+// sell takes up to want (in withdrawal currency) from c and returns what is still needed.
+func (p *withdrawalPlan) sell(c *sellCandidate, want decimal.Decimal) decimal.Decimal {
+	if !want.IsPositive() || !c.quantity.IsPositive() {
+		return want
+	}
+
+	qty := decimal.Min(c.quantity, want.Div(c.price).RoundCeil(int32(c.holding.Decimals)))
+	cost := decimal.Zero
+	if c.gainKnown {
+		cost = c.costBasis.Mul(qty).Div(c.quantity)
+	}
+	value := qty.Mul(c.price)
+
+	if c.step == nil {
+		c.step = &withdrawalStep{
+			holding:      c.holding,
+			lot:          c.lot,
+			price:        c.price,
+			gainKnown:    c.gainKnown,
+			needsSelling: true,
+		}
+		p.steps = append(p.steps, c.step)
+	}
+	c.step.amount = c.step.amount.Add(qty)
+	c.step.value = c.step.value.Add(value)
+	c.step.costBasis = c.step.costBasis.Add(cost)
+
+	c.quantity = c.quantity.Sub(qty)
+	c.costBasis = c.costBasis.Sub(cost)
+	p.covered = p.covered.Add(value)
+
+	return want.Sub(value)
+}
+
+// candidatesForPosition splits a holding into lots, oldest first, plus any
+// amount the lots do not cover. Lots priced in another asset have unknown gain.
+func candidatesForPosition(p *withdrawalPosition, currencyAssetID string, warnings *[]string) []*sellCandidate {
+	lots := make([]*entity.Lot, len(p.lots))
+	copy(lots, p.lots)
+	sort.SliceStable(lots, func(i, j int) bool {
+		return lots[i].AcquiredAt.Before(lots[j].AcquiredAt)
+	})
+
+	remaining := amountToDecimal(p.holding.Amount, p.holding.Decimals)
+	var result []*sellCandidate
+	for _, l := range lots {
+		lotQty := amountToDecimal(l.Amount, l.Decimals)
+		qty := decimal.Min(lotQty, remaining)
+		if !qty.IsPositive() {
+			continue
+		}
+		remaining = remaining.Sub(qty)
+
+		c := &sellCandidate{
+			holding:    p.holding,
+			lot:        l,
+			quantity:   qty,
+			acquiredAt: l.AcquiredAt,
+			price:      p.price,
+		}
+		if l.CostAssetID == currencyAssetID {
+			c.gainKnown = true
+			c.costBasis = amountToDecimal(l.CostBasis, l.CostDecimals).Mul(qty).Div(lotQty)
+		} else {
+			*warnings = append(*warnings, fmt.Sprintf(
+				"lot %s cost basis is in asset %s, gain unknown", l.ID, l.CostAssetID))
+		}
+		result = append(result, c)
+	}
+
+	if remaining.IsPositive() {
+		result = append(result, &sellCandidate{
+			holding:  p.holding,
+			quantity: remaining,
+			price:    p.price,
+		})
+	}
+
+	return result
+}
+
+// sortCandidates orders by key ascending; candidates with unknown gain go last.
+func sortCandidates(cs []*sellCandidate, key func(*sellCandidate) decimal.Decimal) {
+	sort.SliceStable(cs, func(i, j int) bool {
+		a, b := cs[i], cs[j]
+		if a.gainKnown != b.gainKnown {
+			return a.gainKnown
+		}
+		if a.gainKnown {
+			ka, kb := key(a), key(b)
+			if !ka.Equal(kb) {
+				return ka.LessThan(kb)
+			}
+		}
+		if !a.acquiredAt.Equal(b.acquiredAt) {
+			return a.acquiredAt.Before(b.acquiredAt)
+		}
+		return a.holding.ID < b.holding.ID
+	})
+}
+
+// overweightOrder groups candidates by asset, most overweight asset first, and
+// returns the value by which each asset exceeds its target allocation. Assets
+// without a target are treated as having a target of zero.
+func overweightOrder(cs []*sellCandidate, assetValues map[string]decimal.Decimal, total decimal.Decimal, targets map[string]decimal.Decimal) ([]*sellCandidate, map[string]decimal.Decimal) {
+	excess := make(map[string]decimal.Decimal, len(assetValues))
+	for assetID, value := range assetValues {
+		target := decimal.Zero
+		if total.IsPositive() {
+			target = total.Mul(targets[assetID]).Div(decimal.NewFromInt(100))
+		}
+		excess[assetID] = value.Sub(target)
+	}
+
+	ordered := make([]*sellCandidate, len(cs))
+	copy(ordered, cs)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if a.holding.AssetID != b.holding.AssetID {
+			ea, eb := excess[a.holding.AssetID], excess[b.holding.AssetID]
+			if !ea.Equal(eb) {
+				return ea.GreaterThan(eb)
+			}
+			return a.holding.AssetID < b.holding.AssetID
+		}
+		if a.holding.ID != b.holding.ID {
+			return a.holding.ID < b.holding.ID
+		}
+		// Lot-less remainder after lots, lots oldest first.
+		if (a.lot == nil) != (b.lot == nil) {
+			return a.lot != nil
+		}
+		return a.acquiredAt.Before(b.acquiredAt)
+	})
+
+	return ordered, excess
+}
+
+func amountToDecimal(amount int64, decimals uint32) decimal.Decimal {
+	return decimal.New(amount, -int32(decimals))
+}
+
+func decimalToAmount(d decimal.Decimal, decimals uint32) int64 {
+	return d.Shift(int32(decimals)).Round(0).IntPart()
+}
+
+// --- Store-backed simulation and execution ---
+
+// loadWithdrawalPositions reads the portfolio's holdings, their lots and the
+// latest price of each non-cash asset in the withdrawal currency.
+func (h *Handler) loadWithdrawalPositions(ctx context.Context, portfolioID, currencyAssetID string) ([]*withdrawalPosition, error) {
+	var holdings []*entity.Holding
+	for token := ""; ; {
+		page, next, err := h.portfolios.ListHoldings(ctx, portfolio.ListHoldingsOpts{PortfolioID: portfolioID, PageToken: token})
+		if err != nil {
+			return nil, err
+		}
+		holdings = append(holdings, page...)
+		if next == "" {
+			break
+		}
+		token = next
+	}
+
+	lotsByHolding := map[string][]*entity.Lot{}
+	for token := ""; ; {
+		page, next, err := h.portfolios.ListLots(ctx, portfolio.ListLotsOpts{PortfolioID: portfolioID, PageToken: token})
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range page {
+			lotsByHolding[l.HoldingID] = append(lotsByHolding[l.HoldingID], l)
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+
+	prices := map[string]*entity.StoredPrice{}
+	positions := make([]*withdrawalPosition, 0, len(holdings))
+	for _, holding := range holdings {
+		p := &withdrawalPosition{holding: holding, lots: lotsByHolding[holding.ID]}
+		if holding.AssetID != currencyAssetID {
+			price, ok := prices[holding.AssetID]
+			if !ok {
+				var err error
+				price, err = h.marketData.GetLatestPrice(ctx, holding.AssetID, currencyAssetID, "")
+				if err != nil && !errors.Is(err, store.ErrNotFound) {
+					return nil, err
+				}
+				prices[holding.AssetID] = price
+			}
+			if price != nil {
+				p.price = amountToDecimal(price.Last, price.Decimals)
+				p.priced = p.price.IsPositive()
+			}
+		}
+		positions = append(positions, p)
+	}
+
+	return positions, nil
+}
+
+func (h *Handler) buildWithdrawalPlan(ctx context.Context, portfolioID string, cfg *withdrawalConfig) (*withdrawalPlan, error) {
+	positions, err := h.loadWithdrawalPositions(ctx, portfolioID, cfg.currencyAssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return planWithdrawal(cfg, positions), nil
+}
+
+// executeWithdrawal applies the plan step by step: it reduces holdings and
+// lots and records a trade for every sale and a withdrawal per account.
+// checkpoint runs before each step and stops the run when it fails. Withdrawals
+// are recorded for the steps applied so far even when the run stops early.
+func (h *Handler) executeWithdrawal(ctx context.Context, rule *entity.Rule, plan *withdrawalPlan, exec *entity.RuleExecution, checkpoint func(done int) error) error {
+	run := &withdrawalRun{
+		h:         h,
+		rule:      rule,
+		exec:      exec,
+		currency:  plan.config.currencyAssetID,
+		affected:  map[string]bool{},
+		withdrawn: map[string]decimal.Decimal{},
+	}
+
+	var err error
+	for i, step := range plan.steps {
+		if err = checkpoint(i); err != nil {
+			break
+		}
+		if err = run.apply(ctx, step); err != nil {
+			break
+		}
+	}
+
+	return errors.Join(err, run.recordWithdrawals(ctx))
+}
+
+// withdrawalRun tracks the state of a withdrawal being applied.
+type withdrawalRun struct {
+	h         *Handler
+	rule      *entity.Rule
+	exec      *entity.RuleExecution
+	currency  string
+	affected  map[string]bool
+	withdrawn map[string]decimal.Decimal
+	accounts  []string
+}
+
+// apply takes a step's units out of its holding and lot and records its sale,
+// all in one store call, so a step is applied whole or not at all.
+func (r *withdrawalRun) apply(ctx context.Context, step *withdrawalStep) error {
+	holding := step.holding
+	w := &portfolio.WithdrawalStep{
+		HoldingID: holding.ID,
+		Amount:    decimalToAmount(step.amount, holding.Decimals),
+	}
+
+	if step.lot != nil {
+		lot := *step.lot
+		removed := decimalToAmount(step.amount, lot.Decimals)
+		if removed >= lot.Amount {
+			lot.Amount, lot.CostBasis = 0, 0
+		} else {
+			// The cost basis goes with the units removed, whatever asset it
+			// is in, so what is left keeps its cost per unit.
+			cost := amountToDecimal(lot.CostBasis, lot.CostDecimals).Mul(step.amount).Div(amountToDecimal(lot.Amount, lot.Decimals))
+			lot.Amount -= removed
+			lot.CostBasis -= decimalToAmount(cost, lot.CostDecimals)
+		}
+		w.Lot = &lot
+	}
+
+	if step.needsSelling {
+		data := map[string]string{
+			"side":           "sell",
+			"amount":         step.amount.String(),
+			"price":          step.price.String(),
+			"quote_asset_id": r.currency,
+			"proceeds":       step.value.String(),
+			"rule_id":        r.rule.ID,
+			"execution_id":   r.exec.ID,
+		}
+		if step.lot != nil {
+			data["lot_id"] = step.lot.ID
+			data["acquired_at"] = step.lot.AcquiredAt.UTC().Format(time.RFC3339)
+		}
+		if step.gainKnown {
+			data["cost_basis"] = step.costBasis.String()
+			data["realized_gain"] = step.gain().String()
+		}
+		w.Transaction = &entity.Transaction{
+			Type:      entity.TransactionTypeTrade,
+			Status:    entity.TransactionStatusCompleted,
+			AccountID: holding.AccountID,
+			AssetID:   holding.AssetID,
+			Data:      data,
+		}
+	}
+
+	if err := r.h.portfolios.ApplyWithdrawalStep(ctx, w); err != nil {
+		return fmt.Errorf("withdraw from holding %s: %w", holding.ID, err)
+	}
+
+	if !r.affected[holding.ID] {
+		r.affected[holding.ID] = true
+		r.exec.AffectedHoldingIDs = append(r.exec.AffectedHoldingIDs, holding.ID)
+	}
+	if _, ok := r.withdrawn[holding.AccountID]; !ok {
+		r.accounts = append(r.accounts, holding.AccountID)
+	}
+	r.withdrawn[holding.AccountID] = r.withdrawn[holding.AccountID].Add(step.value)
+	if w.Transaction != nil {
+		r.exec.CreatedTransactionIDs = append(r.exec.CreatedTransactionIDs, w.Transaction.ID)
+	}
+	return nil
+}
+
+// recordWithdrawals records one withdrawal per account for the cash raised.
+func (r *withdrawalRun) recordWithdrawals(ctx context.Context) error {
+	for _, accountID := range r.accounts {
+		tx, err := r.h.portfolios.CreateTransaction(ctx, &entity.Transaction{
+			Type:      entity.TransactionTypeWithdrawal,
+			Status:    entity.TransactionStatusCompleted,
+			AccountID: accountID,
+			AssetID:   r.currency,
+			Data: map[string]string{
+				"amount":       r.withdrawn[accountID].String(),
+				"rule_id":      r.rule.ID,
+				"execution_id": r.exec.ID,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("record withdrawal from account %s: %w", accountID, err)
+		}
+		r.exec.CreatedTransactionIDs = append(r.exec.CreatedTransactionIDs, tx.ID)
+	}
+	return nil
+}
+
+func withdrawalToProto(plan *withdrawalPlan) *apiv1.WithdrawalSimulation {
+	steps := make([]*apiv1.AssetWithdrawalPlan, 0, len(plan.steps))
+	for _, s := range plan.steps {
+		step := &apiv1.AssetWithdrawalPlan{
+			AssetId:          s.holding.AssetID,
+			AmountToWithdraw: s.amount.InexactFloat64(),
+			EstimatedValue:   s.value.InexactFloat64(),
+			NeedsSelling:     s.needsSelling,
+			HoldingId:        s.holding.ID,
+		}
+		if s.lot != nil {
+			step.LotId = &s.lot.ID
+		}
+		if s.needsSelling && s.gainKnown {
+			gain := s.gain().InexactFloat64()
+			step.EstimatedGain = &gain
+		}
+		steps = append(steps, step)
+	}
+
+	return &apiv1.WithdrawalSimulation{
+		AvailableBalance: plan.available.InexactFloat64(),
+		RequestedAmount:  plan.config.amount.InexactFloat64(),
+		SufficientFunds:  plan.sufficientFunds(),
+		WithdrawalPlan:   steps,
+	}
+}
+
+// withdrawalSummary renders the plan as a JSON-compatible execution summary.
+func withdrawalSummary(plan *withdrawalPlan) map[string]any {
+	steps := make([]any, 0, len(plan.steps))
+	for _, s := range plan.steps {
+		step := map[string]any{
+			"asset_id":      s.holding.AssetID,
+			"holding_id":    s.holding.ID,
+			"amount":        s.amount.String(),
+			"value":         s.value.String(),
+			"needs_selling": s.needsSelling,
+		}
+		if s.lot != nil {
+			step["lot_id"] = s.lot.ID
+		}
+		if s.needsSelling && s.gainKnown {
+			step["realized_gain"] = s.gain().String()
+		}
+		steps = append(steps, step)
+	}
+
+	warnings := make([]any, 0, len(plan.warnings))
+	for _, w := range plan.warnings {
+		warnings = append(warnings, w)
+	}
+
+	return map[string]any{
+		"rule_type":         RuleTypeMonthlyWithdrawal,
+		"currency_asset_id": plan.config.currencyAssetID,
+		"sell_order":        plan.config.sellOrder,
+		"requested_amount":  plan.config.amount.String(),
+		"available_balance": plan.available.String(),
+		"covered_amount":    plan.covered.String(),
+		"sufficient_funds":  plan.sufficientFunds(),
+		"realized_gain":     plan.realizedGain().String(),
+		"steps":             steps,
+		"warnings":          warnings,
+	}
+}
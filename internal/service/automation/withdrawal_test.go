@@ -0,0 +1,187 @@
+package automation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	usd = "usd"
+	btc = "btc"
+	eth = "eth"
+	sol = "sol"
+)
+
+func cashPosition(id string, amount int64) *withdrawalPosition {
+	return &withdrawalPosition{
+		holding: &entity.Holding{ID: id, AssetID: usd, AccountID: "acc", Amount: amount, Decimals: 2},
+	}
+}
+
+func assetPosition(id, assetID string, amount int64, price float64, lots ...*entity.Lot) *withdrawalPosition {
+	for _, l := range lots {
+		l.HoldingID = id
+	}
+	return &withdrawalPosition{
+		holding: &entity.Holding{ID: id, AssetID: assetID, AccountID: "acc", Amount: amount, Decimals: 8},
+		lots:    lots,
+		price:   decimal.NewFromFloat(price),
+		priced:  true,
+	}
+}
+
+// lot returns a lot of amount (8 decimals) bought for cost USD at the given day.
+func lot(id string, amount int64, cost float64, day int) *entity.Lot {
+	return &entity.Lot{
+		ID:           id,
+		Amount:       amount,
+		Decimals:     8,
+		CostBasis:    decimalToAmount(decimal.NewFromFloat(cost), 2),
+		CostDecimals: 2,
+		CostAssetID:  usd,
+		AcquiredAt:   time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func config(amount float64, order string) *withdrawalConfig {
+	return &withdrawalConfig{
+		amount:            decimal.NewFromFloat(amount),
+		currencyAssetID:   usd,
+		sellOrder:         order,
+		targetAllocations: map[string]decimal.Decimal{},
+	}
+}
+
+func TestPlanWithdrawal_CashFirst(t *testing.T) {
+	plan := planWithdrawal(config(200, SellOrderOverweightFirst), []*withdrawalPosition{
+		assetPosition("h-btc", btc, 1_00000000, 1000),
+		cashPosition("h-cash", 300_00),
+	})
+
+	require.Len(t, plan.steps, 1)
+	assert.Equal(t, "h-cash", plan.steps[0].holding.ID)
+	assert.False(t, plan.steps[0].needsSelling)
+	assert.Equal(t, "200", plan.steps[0].amount.String())
+	assert.True(t, plan.sufficientFunds())
+	assert.Equal(t, "1300", plan.available.String())
+}
+
+func TestPlanWithdrawal_LowestGainFirst(t *testing.T) {
+	plan := planWithdrawal(config(600, SellOrderLowestGainFirst), []*withdrawalPosition{
+		cashPosition("h-cash", 100_00),
+		// Old lot doubled in value, new lot barely moved.
+		assetPosition("h-btc", btc, 2_00000000, 1000,
+			lot("old", 1_00000000, 500, 1),
+			lot("new", 1_00000000, 950, 20),
+		),
+	})
+
+	require.Len(t, plan.steps, 2)
+	assert.Equal(t, "h-cash", plan.steps[0].holding.ID)
+
+	sale := plan.steps[1]
+	require.NotNil(t, sale.lot)
+	assert.Equal(t, "new", sale.lot.ID)
+	assert.True(t, sale.needsSelling)
+	assert.Equal(t, "0.5", sale.amount.String())
+	assert.Equal(t, "500", sale.value.String())
+	assert.Equal(t, "25", sale.gain().String())
+	assert.True(t, plan.sufficientFunds())
+}
+
+func TestPlanWithdrawal_LargestLossFirst(t *testing.T) {
+	plan := planWithdrawal(config(1500, SellOrderLargestLossFirst), []*withdrawalPosition{
+		assetPosition("h-btc", btc, 1_00000000, 1000, lot("btc-gain", 1_00000000, 400, 1)),
+		assetPosition("h-eth", eth, 10_00000000, 100, lot("eth-loss", 10_00000000, 3000, 1)),
+	})
+
+	require.Len(t, plan.steps, 2)
+	assert.Equal(t, "eth-loss", plan.steps[0].lot.ID)
+	assert.Equal(t, "-2000", plan.steps[0].gain().String())
+	assert.Equal(t, "btc-gain", plan.steps[1].lot.ID)
+	assert.Equal(t, "500", plan.steps[1].value.String())
+	assert.Equal(t, "-1700", plan.realizedGain().String())
+}
+
+func TestPlanWithdrawal_OverweightFirst(t *testing.T) {
+	cfg := config(350, SellOrderOverweightFirst)
+	cfg.targetAllocations = map[string]decimal.Decimal{
+		btc: decimal.NewFromInt(30),
+		eth: decimal.NewFromInt(20),
+		sol: decimal.NewFromInt(50),
+	}
+
+	// BTC is 200 over target and ETH 100 over, SOL is underweight. Both are
+	// trimmed to target first, then BTC, the most overweight, covers the rest.
+	plan := planWithdrawal(cfg, []*withdrawalPosition{
+		assetPosition("h-sol", sol, 2_00000000, 100),
+		assetPosition("h-eth", eth, 3_00000000, 100),
+		assetPosition("h-btc", btc, 5_00000000, 100),
+	})
+
+	require.Len(t, plan.steps, 2)
+	assert.Equal(t, btc, plan.steps[0].holding.AssetID)
+	assert.Equal(t, "250", plan.steps[0].value.String())
+	assert.False(t, plan.steps[0].gainKnown)
+	assert.Equal(t, eth, plan.steps[1].holding.AssetID)
+	assert.Equal(t, "100", plan.steps[1].value.String())
+	assert.True(t, plan.sufficientFunds())
+}
+
+func TestPlanWithdrawal_InsufficientFunds(t *testing.T) {
+	unpriced := assetPosition("h-eth", eth, 1_00000000, 0)
+	unpriced.priced = false
+
+	plan := planWithdrawal(config(1000, SellOrderLowestGainFirst), []*withdrawalPosition{
+		cashPosition("h-cash", 100_00),
+		assetPosition("h-btc", btc, 1_00000000, 500),
+		unpriced,
+	})
+
+	assert.False(t, plan.sufficientFunds())
+	assert.Equal(t, "600", plan.covered.String())
+	assert.Equal(t, "600", plan.available.String())
+	require.Len(t, plan.warnings, 2)
+	assert.Contains(t, plan.warnings[0], "h-eth")
+	assert.Contains(t, plan.warnings[1], "insufficient funds")
+}
+
+func TestParseWithdrawalConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := parseWithdrawalConfig(map[string]any{"amount": "1500.50", "currency_asset_id": usd})
+		require.NoError(t, err)
+		assert.Equal(t, "1500.5", cfg.amount.String())
+		assert.Equal(t, SellOrderOverweightFirst, cfg.sellOrder)
+	})
+
+	t.Run("target allocations", func(t *testing.T) {
+		cfg, err := parseWithdrawalConfig(map[string]any{
+			"amount":             float64(100),
+			"currency_asset_id":  usd,
+			"sell_order":         SellOrderLargestLossFirst,
+			"target_allocations": map[string]any{btc: float64(60), eth: "40"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, SellOrderLargestLossFirst, cfg.sellOrder)
+		assert.Equal(t, "40", cfg.targetAllocations[eth].String())
+	})
+
+	for name, raw := range map[string]map[string]any{
+		"missing amount":    {"currency_asset_id": usd},
+		"negative amount":   {"amount": float64(-1), "currency_asset_id": usd},
+		"missing currency":  {"amount": float64(1)},
+		"unknown order":     {"amount": float64(1), "currency_asset_id": usd, "sell_order": "random"},
+		"target over 100%":  {"amount": float64(1), "currency_asset_id": usd, "target_allocations": map[string]any{btc: float64(120)}},
+		"malformed targets": {"amount": float64(1), "currency_asset_id": usd, "target_allocations": "btc"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseWithdrawalConfig(raw)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package marketdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/bond"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetBondAnalytics computes a bond's accrued interest, remaining payments
+// and, at the requested or last stored price, its yield to maturity.
+func (h *Handler) GetBondAnalytics(ctx context.Context, req *connect.Request[apiv1.GetBondAnalyticsRequest]) (*connect.Response[apiv1.GetBondAnalyticsResponse], error) {
+	if req.Msg.AssetId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("asset ID is required"))
+	}
+	asset, err := h.store.GetAsset(ctx, req.Msg.AssetId)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	terms := asset.Bond
+	if terms == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("asset %s has no bond terms", asset.ID))
+	}
+	settlement := time.Now()
+	if req.Msg.SettlementTime != nil {
+		settlement = req.Msg.SettlementTime.AsTime()
+	}
+
+	accrued := bond.AccruedInterest(terms, settlement)
+	resp := &apiv1.GetBondAnalyticsResponse{
+		AssetId:         asset.ID,
+		CurrencyAssetId: terms.CurrencyAssetID,
+		SettlementTime:  timestamppb.New(settlement),
+		Matured:         bond.Matured(terms, settlement),
+		AccruedInterest: accrued.String(),
+	}
+	previous, next := bond.Coupons(terms, settlement)
+	if !previous.IsZero() {
+		resp.PreviousCouponDate = timestamppb.New(previous)
+	}
+	if !next.IsZero() {
+		resp.NextCouponDate = timestamppb.New(next)
+	}
+	for _, flow := range bond.CashFlows(terms, settlement) {
+		resp.CashFlows = append(resp.CashFlows, &apiv1.BondCashFlow{
+			PaymentDate: timestamppb.New(flow.Date),
+			Coupon:      flow.Coupon.String(),
+			Principal:   flow.Principal.String(),
+		})
+	}
+	if resp.Matured {
+		return connect.NewResponse(resp), nil
+	}
+
+	clean, ok, err := h.bondPrice(ctx, asset, req.Msg.CleanPrice, settlement)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		dirty := clean.Add(accrued)
+		resp.CleanPrice = proto.String(clean.String())
+		resp.DirtyPrice = proto.String(dirty.String())
+		if y, ok := bond.YieldToMaturity(terms, settlement, dirty); ok {
+			resp.YieldToMaturity = proto.String(y.String())
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// bondPrice returns the requested clean price of a bond, or else the last
+// one stored in its currency at settlement, and false if there is none.
+func (h *Handler) bondPrice(ctx context.Context, asset *entity.Asset, requested *string, settlement time.Time) (decimal.Decimal, bool, error) {
+	if requested != nil {
+		price, err := decimal.NewFromString(*requested)
+		if err != nil || !price.IsPositive() {
+			return decimal.Zero, false, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid clean price %q", *requested))
+		}
+		return price, true, nil
+	}
+	stored, err := h.store.GetPriceAt(ctx, asset.ID, asset.Bond.CurrencyAssetID, settlement)
+	if errors.Is(err, store.ErrNotFound) {
+		return decimal.Zero, false, nil
+	}
+	if err != nil {
+		return decimal.Zero, false, toConnectError(err)
+	}
+	return decimal.New(stored.Last, -int32(stored.Decimals)), true, nil
+}
+
+// checkBondTerms sets the bond terms of asset from p, which only an asset
+// of type bond may have, and checks them and their currency.
+func (h *Handler) checkBondTerms(ctx context.Context, asset *entity.Asset, p *apiv1.BondTerms, assetType entity.AssetType) error {
+	terms, err := bondFromProto(p)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	asset.Bond = terms
+	if terms == nil {
+		return nil
+	}
+	if assetType != entity.AssetTypeBond {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("only bond assets have bond terms"))
+	}
+	if err := bond.Validate(terms); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if _, err := h.store.GetAsset(ctx, terms.CurrencyAssetID); err != nil {
+		return toConnectError(fmt.Errorf("bond currency: %w", err))
+	}
+	return nil
+}
+
+func bondFromProto(p *apiv1.BondTerms) (*entity.BondTerms, error) {
+	if p == nil {
+		return nil, nil
+	}
+	face, err := decimal.NewFromString(p.FaceValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid face value %q", p.FaceValue)
+	}
+	rate := decimal.Zero
+	if p.CouponRate != "" {
+		if rate, err = decimal.NewFromString(p.CouponRate); err != nil {
+			return nil, fmt.Errorf("invalid coupon rate %q", p.CouponRate)
+		}
+	}
+	terms := &entity.BondTerms{
+		FaceValue:       face,
+		CurrencyAssetID: p.CurrencyAssetId,
+		CouponRate:      rate,
+		CouponFrequency: int(p.CouponFrequency),
+		DayCount:        entity.DayCount(p.DayCount),
+	}
+	if p.MaturityDate != nil {
+		terms.MaturityDate = p.MaturityDate.AsTime()
+	}
+	if p.IssueDate != nil {
+		terms.IssueDate = p.IssueDate.AsTime()
+	}
+	return terms, nil
+}
+
+func bondToProto(e *entity.BondTerms) *apiv1.BondTerms {
+	p := &apiv1.BondTerms{
+		FaceValue:       e.FaceValue.String(),
+		CurrencyAssetId: e.CurrencyAssetID,
+		CouponRate:      e.CouponRate.String(),
+		CouponFrequency: uint32(e.CouponFrequency),
+		MaturityDate:    timestamppb.New(e.MaturityDate),
+		DayCount:        apiv1.DayCountConvention(e.DayCount),
+	}
+	if !e.IssueDate.IsZero() {
+		p.IssueDate = timestamppb.New(e.IssueDate)
+	}
+	return p
+}
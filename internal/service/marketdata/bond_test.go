@@ -0,0 +1,140 @@
+package marketdata
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// bondStore holds assets and one bond price.
+type bondStore struct {
+	*assetStore
+	price *entity.StoredPrice
+}
+
+func (s *bondStore) GetPriceAt(_ context.Context, assetID, baseAssetID string, at time.Time) (*entity.StoredPrice, error) {
+	if p := s.price; p != nil && p.AssetID == assetID && p.BaseAssetID == baseAssetID && !p.Timestamp.After(at) {
+		return p, nil
+	}
+	return nil, store.ErrNotFound
+}
+
+func newBondHandler() (*Handler, *bondStore) {
+	s := &bondStore{
+		assetStore: &assetStore{assets: map[string]*entity.Asset{
+			"usd": {ID: "usd", Symbol: "USD", Type: entity.AssetTypeForex},
+			"ust": {ID: "ust", Symbol: "T 5 06/30", Type: entity.AssetTypeBond, Bond: &entity.BondTerms{
+				FaceValue:       decimal.RequireFromString("100"),
+				CurrencyAssetID: "usd",
+				CouponRate:      decimal.RequireFromString("0.05"),
+				CouponFrequency: 2,
+				MaturityDate:    time.Date(2030, time.June, 15, 0, 0, 0, 0, time.UTC),
+				DayCount:        entity.DayCount30360,
+			}},
+			"aapl": {ID: "aapl", Symbol: "AAPL", Type: entity.AssetTypeStock},
+		}},
+		price: &entity.StoredPrice{AssetID: "ust", BaseAssetID: "usd", Last: 9500, Decimals: 2,
+			Timestamp: time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	return NewHandler(s, nil, nil, nil, nil, QualityConfig{}, slog.New(slog.DiscardHandler)), s
+}
+
+func TestCreateBondAsset(t *testing.T) {
+	h, s := newBondHandler()
+	terms := &apiv1.BondTerms{
+		FaceValue:       "1000",
+		CurrencyAssetId: "usd",
+		CouponRate:      "0.04",
+		CouponFrequency: 4,
+		MaturityDate:    timestamppb.New(time.Date(2029, time.March, 31, 0, 0, 0, 0, time.UTC)),
+		DayCount:        apiv1.DayCountConvention_DAY_COUNT_CONVENTION_ACTUAL_ACTUAL,
+	}
+	resp, err := h.CreateAsset(context.Background(), connect.NewRequest(&apiv1.CreateAssetRequest{
+		Asset: &apiv1.Asset{Name: "Corp 4% 2029", Type: apiv1.AssetType_ASSET_TYPE_BOND, Bond: terms},
+	}))
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(terms, resp.Msg.Bond), resp.Msg.Bond)
+	assert.Equal(t, 4, s.assets[resp.Msg.Id].Bond.CouponFrequency)
+
+	for name, asset := range map[string]*apiv1.Asset{
+		"not a bond":       {Name: "Apple", Type: apiv1.AssetType_ASSET_TYPE_STOCK, Bond: terms},
+		"bad face value":   {Name: "Bond", Type: apiv1.AssetType_ASSET_TYPE_BOND, Bond: &apiv1.BondTerms{FaceValue: "par", CurrencyAssetId: "usd"}},
+		"no maturity":      {Name: "Bond", Type: apiv1.AssetType_ASSET_TYPE_BOND, Bond: &apiv1.BondTerms{FaceValue: "100", CurrencyAssetId: "usd"}},
+		"unknown currency": {Name: "Bond", Type: apiv1.AssetType_ASSET_TYPE_BOND, Bond: &apiv1.BondTerms{FaceValue: "100", CurrencyAssetId: "xyz", MaturityDate: terms.MaturityDate}},
+	} {
+		_, err := h.CreateAsset(context.Background(), connect.NewRequest(&apiv1.CreateAssetRequest{Asset: asset}))
+		assert.Error(t, err, name)
+	}
+
+	// Terms set on update are checked against the stored type.
+	_, err = h.UpdateAsset(context.Background(), connect.NewRequest(&apiv1.UpdateAssetRequest{
+		Asset:      &apiv1.Asset{Id: "aapl", Bond: terms},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"bond"}},
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestGetBondAnalytics(t *testing.T) {
+	h, _ := newBondHandler()
+	settlement := timestamppb.New(time.Date(2025, time.August, 15, 0, 0, 0, 0, time.UTC))
+
+	resp, err := h.GetBondAnalytics(context.Background(), connect.NewRequest(&apiv1.GetBondAnalyticsRequest{
+		AssetId:        "ust",
+		SettlementTime: settlement,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "usd", resp.Msg.CurrencyAssetId)
+	assert.False(t, resp.Msg.Matured)
+	assert.Equal(t, "0.83333333", resp.Msg.AccruedInterest)
+	assert.Equal(t, time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC), resp.Msg.PreviousCouponDate.AsTime())
+	assert.Equal(t, time.Date(2025, time.December, 15, 0, 0, 0, 0, time.UTC), resp.Msg.NextCouponDate.AsTime())
+	require.Len(t, resp.Msg.CashFlows, 10)
+	assert.Equal(t, "2.5", resp.Msg.CashFlows[0].Coupon)
+	assert.Equal(t, "100", resp.Msg.CashFlows[9].Principal)
+	// The stored price.
+	assert.Equal(t, "95", resp.Msg.GetCleanPrice())
+	assert.Equal(t, "95.83333333", resp.Msg.GetDirtyPrice())
+	require.NotNil(t, resp.Msg.YieldToMaturity)
+
+	resp, err = h.GetBondAnalytics(context.Background(), connect.NewRequest(&apiv1.GetBondAnalyticsRequest{
+		AssetId:        "ust",
+		SettlementTime: timestamppb.New(time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC)),
+		CleanPrice:     proto.String("100"),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "0.05", resp.Msg.GetYieldToMaturity())
+
+	// Before any price there is no yield.
+	resp, err = h.GetBondAnalytics(context.Background(), connect.NewRequest(&apiv1.GetBondAnalyticsRequest{
+		AssetId:        "ust",
+		SettlementTime: timestamppb.New(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)),
+	}))
+	require.NoError(t, err)
+	assert.Nil(t, resp.Msg.CleanPrice)
+	assert.Nil(t, resp.Msg.YieldToMaturity)
+
+	for name, tc := range map[string]struct {
+		req  *apiv1.GetBondAnalyticsRequest
+		code connect.Code
+	}{
+		"no asset":      {&apiv1.GetBondAnalyticsRequest{}, connect.CodeInvalidArgument},
+		"unknown asset": {&apiv1.GetBondAnalyticsRequest{AssetId: "xyz"}, connect.CodeNotFound},
+		"not a bond":    {&apiv1.GetBondAnalyticsRequest{AssetId: "aapl"}, connect.CodeFailedPrecondition},
+		"bad price":     {&apiv1.GetBondAnalyticsRequest{AssetId: "ust", SettlementTime: settlement, CleanPrice: proto.String("-1")}, connect.CodeInvalidArgument},
+	} {
+		_, err := h.GetBondAnalytics(context.Background(), connect.NewRequest(tc.req))
+		assert.Equal(t, tc.code, connect.CodeOf(err), name)
+	}
+}
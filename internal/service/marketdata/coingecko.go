@@ -0,0 +1,121 @@
+package marketdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/foxcool/greedy-eye/internal/adapter/coingecko"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+)
+
+// SourceCoinGecko names CoinGecko as a data source.
+const SourceCoinGecko = "coingecko"
+
+// coinGeckoClient is the part of coingecko.Client the provider uses.
+type coinGeckoClient interface {
+	GetAssetDetails(ctx context.Context, assetID string) (*coingecko.AssetDetails, error)
+	SearchAssets(ctx context.Context, query string) ([]coingecko.SearchResult, error)
+}
+
+// CoinGeckoProvider provides asset metadata from CoinGecko and finds coins
+// to add.
+type CoinGeckoProvider struct {
+	client coinGeckoClient
+	store  Store
+}
+
+func NewCoinGeckoProvider(client *coingecko.Client, store Store) *CoinGeckoProvider {
+	return &CoinGeckoProvider{client: client, store: store}
+}
+
+func (p *CoinGeckoProvider) Source() string {
+	return SourceCoinGecko
+}
+
+// AssetMetadata looks the asset up by its CoinGecko identifier. An asset
+// without one is not known to CoinGecko.
+func (p *CoinGeckoProvider) AssetMetadata(ctx context.Context, asset *entity.Asset) (map[string]string, error) {
+	identifiers, err := p.store.ListAssetIdentifiers(ctx, asset.ID)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(identifiers, func(ident *entity.AssetIdentifier) bool {
+		return ident.Kind == entity.IdentifierKindCoinGecko
+	})
+	if i < 0 {
+		return nil, fmt.Errorf("%w: no CoinGecko ID for asset %s", store.ErrNotFound, asset.ID)
+	}
+	id := identifiers[i].Value
+
+	details, err := p.client.GetAssetDetails(ctx, id)
+	if errors.Is(err, coingecko.ErrNotFound) {
+		return nil, fmt.Errorf("%w: CoinGecko coin %q", store.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{
+		entity.MetadataName:        details.Name,
+		entity.MetadataDescription: details.Description,
+		entity.MetadataLogoURL:     details.ImageURL,
+		entity.MetadataWebsite:     details.Homepage,
+		entity.MetadataCategory:    strings.Join(details.Categories, ", "),
+		entity.MetadataChain:       details.AssetPlatformID,
+	}
+	if details.MarketCapRank > 0 {
+		fields[entity.MetadataMarketCapRank] = strconv.Itoa(details.MarketCapRank)
+	}
+	for chain, address := range details.Platforms {
+		fields[entity.MetadataContractPrefix+chain] = address
+	}
+	return fields, nil
+}
+
+func (p *CoinGeckoProvider) IdentifierKind() entity.IdentifierKind {
+	return entity.IdentifierKindCoinGecko
+}
+
+func (p *CoinGeckoProvider) SearchAssets(ctx context.Context, query string) ([]*ExternalAsset, error) {
+	results, err := p.client.SearchAssets(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	assets := make([]*ExternalAsset, len(results))
+	for i, r := range results {
+		assets[i] = &ExternalAsset{
+			Source:        SourceCoinGecko,
+			ID:            r.ID,
+			Symbol:        r.Symbol,
+			Name:          r.Name,
+			Type:          entity.AssetTypeCryptocurrency,
+			MarketCapRank: r.MarketCapRank,
+			ImageURL:      r.ImageURL,
+		}
+	}
+	return assets, nil
+}
+
+func (p *CoinGeckoProvider) ExternalAsset(ctx context.Context, id string) (*ExternalAsset, error) {
+	details, err := p.client.GetAssetDetails(ctx, id)
+	if errors.Is(err, coingecko.ErrNotFound) {
+		return nil, fmt.Errorf("%w: CoinGecko coin %q", store.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ExternalAsset{
+		Source:        SourceCoinGecko,
+		ID:            details.ID,
+		Symbol:        details.Symbol,
+		Name:          details.Name,
+		Type:          entity.AssetTypeCryptocurrency,
+		MarketCapRank: details.MarketCapRank,
+		ImageURL:      details.ImageURL,
+	}, nil
+}
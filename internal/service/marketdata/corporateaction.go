@@ -0,0 +1,214 @@
+package marketdata
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxCorporateActionNote bounds the note of a corporate action, in bytes.
+const maxCorporateActionNote = 1000
+
+// validateCorporateAction checks the fields an action of its type needs and
+// defaults the ratio of migrations to 1:1.
+func validateCorporateAction(a *entity.CorporateAction) error {
+	if a.AssetID == "" {
+		return errors.New("asset ID is required")
+	}
+	if a.EffectiveAt.IsZero() {
+		return errors.New("effective time is required")
+	}
+	if len(a.Note) > maxCorporateActionNote {
+		return errors.New("note is too long")
+	}
+	switch a.Type {
+	case entity.CorporateActionTypeSplit:
+		if a.NewAssetID != "" {
+			return errors.New("splits keep the asset; use a migration to move holdings to another")
+		}
+		if a.NewUnits <= 0 || a.OldUnits <= 0 {
+			return errors.New("splits need positive new and old units")
+		}
+		if a.NewUnits == a.OldUnits {
+			return errors.New("a 1:1 split changes nothing")
+		}
+	case entity.CorporateActionTypeMigration:
+		if a.NewAssetID == "" || a.NewAssetID == a.AssetID {
+			return errors.New("migrations need a new asset other than the asset")
+		}
+		if a.NewUnits == 0 && a.OldUnits == 0 {
+			a.NewUnits, a.OldUnits = 1, 1
+		}
+		if a.NewUnits <= 0 || a.OldUnits <= 0 {
+			return errors.New("migrations need positive new and old units")
+		}
+	case entity.CorporateActionTypeDelisting:
+		if a.NewAssetID != "" || a.NewUnits != 0 || a.OldUnits != 0 {
+			return errors.New("delistings have no new asset or ratio")
+		}
+	default:
+		return errors.New("corporate action type is required")
+	}
+	return nil
+}
+
+// requireAdmin rejects callers without the admin scope, as applying and
+// reverting actions changes every user's holdings.
+func requireAdmin(ctx context.Context, what string) error {
+	if p, ok := auth.FromContext(ctx); ok && !p.HasScope(auth.ScopeAdmin) {
+		return connect.NewError(connect.CodePermissionDenied, errors.New(what+" requires the admin scope"))
+	}
+	return nil
+}
+
+// CreateCorporateAction records a pending corporate action.
+func (h *Handler) CreateCorporateAction(ctx context.Context, req *connect.Request[apiv1.CreateCorporateActionRequest]) (*connect.Response[apiv1.CorporateAction], error) {
+	if req.Msg.Action == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("action is required"))
+	}
+
+	action := corporateActionFromProto(req.Msg.Action)
+	if err := validateCorporateAction(action); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	created, err := h.store.CreateCorporateAction(ctx, action)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(corporateActionToProto(created)), nil
+}
+
+// GetCorporateAction retrieves a corporate action by ID.
+func (h *Handler) GetCorporateAction(ctx context.Context, req *connect.Request[apiv1.GetCorporateActionRequest]) (*connect.Response[apiv1.CorporateAction], error) {
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("corporate action ID is required"))
+	}
+
+	action, err := h.store.GetCorporateAction(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(corporateActionToProto(action)), nil
+}
+
+// ListCorporateActions lists corporate actions, newest first.
+func (h *Handler) ListCorporateActions(ctx context.Context, req *connect.Request[apiv1.ListCorporateActionsRequest]) (*connect.Response[apiv1.ListCorporateActionsResponse], error) {
+	opts := ListCorporateActionsOpts{
+		AssetID:   req.Msg.GetAssetId(),
+		Status:    entity.CorporateActionStatus(req.Msg.GetStatus()),
+		PageSize:  int(req.Msg.GetPageSize()),
+		PageToken: req.Msg.GetPageToken(),
+	}
+
+	actions, nextPageToken, err := h.store.ListCorporateActions(ctx, opts)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	resp := &apiv1.ListCorporateActionsResponse{
+		Actions:       make([]*apiv1.CorporateAction, len(actions)),
+		NextPageToken: nextPageToken,
+	}
+	for i, a := range actions {
+		resp.Actions[i] = corporateActionToProto(a)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// DeleteCorporateAction deletes a corporate action that is not applied.
+func (h *Handler) DeleteCorporateAction(ctx context.Context, req *connect.Request[apiv1.DeleteCorporateActionRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("corporate action ID is required"))
+	}
+
+	if err := h.store.DeleteCorporateAction(ctx, req.Msg.Id); err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// ApplyCorporateAction adjusts holdings, lots and prices for an action.
+func (h *Handler) ApplyCorporateAction(ctx context.Context, req *connect.Request[apiv1.ApplyCorporateActionRequest]) (*connect.Response[apiv1.ApplyCorporateActionResponse], error) {
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("corporate action ID is required"))
+	}
+	if err := requireAdmin(ctx, "applying corporate actions"); err != nil {
+		return nil, err
+	}
+
+	action, adjusted, err := h.store.ApplyCorporateAction(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	h.log.Info("Applied corporate action",
+		"action_id", action.ID, "asset_id", action.AssetID,
+		"holdings", adjusted.Holdings, "lots", adjusted.Lots, "prices", adjusted.Prices)
+	return connect.NewResponse(&apiv1.ApplyCorporateActionResponse{
+		Action:           corporateActionToProto(action),
+		HoldingsAdjusted: int32(adjusted.Holdings),
+		LotsAdjusted:     int32(adjusted.Lots),
+		PricesAdjusted:   int32(adjusted.Prices),
+	}), nil
+}
+
+// RevertCorporateAction restores what applying an action changed.
+func (h *Handler) RevertCorporateAction(ctx context.Context, req *connect.Request[apiv1.RevertCorporateActionRequest]) (*connect.Response[apiv1.CorporateAction], error) {
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("corporate action ID is required"))
+	}
+	if err := requireAdmin(ctx, "reverting corporate actions"); err != nil {
+		return nil, err
+	}
+
+	action, err := h.store.RevertCorporateAction(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	h.log.Info("Reverted corporate action", "action_id", action.ID, "asset_id", action.AssetID)
+	return connect.NewResponse(corporateActionToProto(action)), nil
+}
+
+func corporateActionFromProto(p *apiv1.CorporateAction) *entity.CorporateAction {
+	a := &entity.CorporateAction{
+		AssetID:    p.AssetId,
+		Type:       entity.CorporateActionType(p.Type),
+		NewUnits:   p.NewUnits,
+		OldUnits:   p.OldUnits,
+		NewAssetID: p.GetNewAssetId(),
+		Note:       p.Note,
+	}
+	if p.EffectiveAt != nil {
+		a.EffectiveAt = p.EffectiveAt.AsTime()
+	}
+	return a
+}
+
+func corporateActionToProto(a *entity.CorporateAction) *apiv1.CorporateAction {
+	p := &apiv1.CorporateAction{
+		Id:          a.ID,
+		AssetId:     a.AssetID,
+		Type:        apiv1.CorporateActionType(a.Type),
+		Status:      apiv1.CorporateActionStatus(a.Status),
+		EffectiveAt: timestamppb.New(a.EffectiveAt),
+		NewUnits:    a.NewUnits,
+		OldUnits:    a.OldUnits,
+		Note:        a.Note,
+		CreatedAt:   timestamppb.New(a.CreatedAt),
+		UpdatedAt:   timestamppb.New(a.UpdatedAt),
+	}
+	if a.NewAssetID != "" {
+		p.NewAssetId = &a.NewAssetID
+	}
+	if a.AppliedAt != nil {
+		p.AppliedAt = timestamppb.New(*a.AppliedAt)
+	}
+	if a.RevertedAt != nil {
+		p.RevertedAt = timestamppb.New(*a.RevertedAt)
+	}
+	return p
+}
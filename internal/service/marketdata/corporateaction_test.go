@@ -0,0 +1,96 @@
+package marketdata
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// actionStore records corporate actions and which were applied.
+type actionStore struct {
+	Store
+	created *entity.CorporateAction
+	applied []string
+}
+
+func (s *actionStore) CreateCorporateAction(_ context.Context, a *entity.CorporateAction) (*entity.CorporateAction, error) {
+	a.ID = "ca1"
+	a.Status = entity.CorporateActionStatusPending
+	s.created = a
+	return a, nil
+}
+
+func (s *actionStore) ApplyCorporateAction(_ context.Context, id string) (*entity.CorporateAction, *entity.CorporateActionAdjustments, error) {
+	s.applied = append(s.applied, id)
+	return &entity.CorporateAction{ID: id, Status: entity.CorporateActionStatusApplied},
+		&entity.CorporateActionAdjustments{Holdings: 3, Lots: 5, Prices: 100}, nil
+}
+
+func TestCreateCorporateAction(t *testing.T) {
+	s := &actionStore{}
+	h := NewHandler(s, nil, nil, nil, nil, QualityConfig{}, slog.New(slog.DiscardHandler))
+	effective := timestamppb.New(time.Date(2024, 9, 4, 0, 0, 0, 0, time.UTC))
+
+	resp, err := h.CreateCorporateAction(context.Background(), connect.NewRequest(&apiv1.CreateCorporateActionRequest{
+		Action: &apiv1.CorporateAction{
+			AssetId:     "matic",
+			Type:        apiv1.CorporateActionType_CORPORATE_ACTION_TYPE_MIGRATION,
+			NewAssetId:  proto.String("pol"),
+			EffectiveAt: effective,
+		},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, apiv1.CorporateActionStatus_CORPORATE_ACTION_STATUS_PENDING, resp.Msg.Status)
+	// Migrations default to one new unit per old one.
+	assert.Equal(t, int64(1), s.created.NewUnits)
+	assert.Equal(t, int64(1), s.created.OldUnits)
+	assert.Equal(t, "pol", s.created.NewAssetID)
+
+	for name, action := range map[string]*apiv1.CorporateAction{
+		"no asset":          {Type: apiv1.CorporateActionType_CORPORATE_ACTION_TYPE_DELISTING, EffectiveAt: effective},
+		"no type":           {AssetId: "aapl", EffectiveAt: effective},
+		"no effective":      {AssetId: "aapl", Type: apiv1.CorporateActionType_CORPORATE_ACTION_TYPE_DELISTING},
+		"split no ratio":    {AssetId: "aapl", Type: apiv1.CorporateActionType_CORPORATE_ACTION_TYPE_SPLIT, EffectiveAt: effective},
+		"split 1:1":         {AssetId: "aapl", Type: apiv1.CorporateActionType_CORPORATE_ACTION_TYPE_SPLIT, NewUnits: 4, OldUnits: 4, EffectiveAt: effective},
+		"split to asset":    {AssetId: "aapl", Type: apiv1.CorporateActionType_CORPORATE_ACTION_TYPE_SPLIT, NewUnits: 4, OldUnits: 1, NewAssetId: proto.String("msft"), EffectiveAt: effective},
+		"migrate to self":   {AssetId: "matic", Type: apiv1.CorporateActionType_CORPORATE_ACTION_TYPE_MIGRATION, NewAssetId: proto.String("matic"), EffectiveAt: effective},
+		"negative ratio":    {AssetId: "matic", Type: apiv1.CorporateActionType_CORPORATE_ACTION_TYPE_MIGRATION, NewAssetId: proto.String("pol"), NewUnits: -1, OldUnits: 1, EffectiveAt: effective},
+		"delist with ratio": {AssetId: "luna", Type: apiv1.CorporateActionType_CORPORATE_ACTION_TYPE_DELISTING, NewUnits: 1, OldUnits: 1, EffectiveAt: effective},
+	} {
+		_, err := h.CreateCorporateAction(context.Background(), connect.NewRequest(&apiv1.CreateCorporateActionRequest{Action: action}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), name)
+	}
+}
+
+func TestApplyCorporateAction(t *testing.T) {
+	s := &actionStore{}
+	h := NewHandler(s, nil, nil, nil, nil, QualityConfig{}, slog.New(slog.DiscardHandler))
+	req := connect.NewRequest(&apiv1.ApplyCorporateActionRequest{Id: "ca1"})
+
+	// Applying changes every user's holdings, so writers may not.
+	writer := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "u1", Scopes: []string{auth.ScopeWrite}})
+	_, err := h.ApplyCorporateAction(writer, req)
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	_, err = h.RevertCorporateAction(writer, connect.NewRequest(&apiv1.RevertCorporateActionRequest{Id: "ca1"}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	assert.Empty(t, s.applied)
+
+	admin := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "u1", Scopes: []string{auth.ScopeAdmin}})
+	resp, err := h.ApplyCorporateAction(admin, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ca1"}, s.applied)
+	assert.Equal(t, apiv1.CorporateActionStatus_CORPORATE_ACTION_STATUS_APPLIED, resp.Msg.Action.Status)
+	assert.EqualValues(t, 3, resp.Msg.HoldingsAdjusted)
+	assert.EqualValues(t, 5, resp.Msg.LotsAdjusted)
+	assert.EqualValues(t, 100, resp.Msg.PricesAdjusted)
+}
@@ -0,0 +1,134 @@
+package marketdata
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/adapter/ecb"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+)
+
+// SourceECB names the European Central Bank reference rates as a data
+// source.
+const SourceECB = "ecb"
+
+// ecbDecimals is the precision of stored ECB rates. A currency is stored
+// priced in euros, which for the weakest quoted currencies takes many
+// places.
+const ecbDecimals = 10
+
+// ecbRatesTTL is how long a rate file is reused, so a fetch of every
+// currency reads it once.
+const ecbRatesTTL = 10 * time.Minute
+
+// ecbPublicationHour is when rates are published on their day, in
+// Frankfurt.
+const ecbPublicationHour = 16
+
+// frankfurt is the time zone of the ECB.
+var frankfurt = func() *time.Location {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}()
+
+// ecbClient is the part of ecb.Client the provider uses.
+type ecbClient interface {
+	Rates(ctx context.Context, since time.Time) ([]ecb.DailyRates, error)
+}
+
+// ECBProvider prices fiat currencies from the ECB euro reference rates. The
+// ECB publishes how much of a currency a euro buys; each forex asset is
+// stored priced in euros instead, the inverse, as an asset has one price
+// per time. Cross rates go through the euro.
+type ECBProvider struct {
+	client ecbClient
+	store  Store
+	now    func() time.Time
+
+	mu          sync.Mutex
+	cached      []ecb.DailyRates
+	cachedSince time.Time
+	cachedAt    time.Time
+}
+
+func NewECBProvider(client *ecb.Client, store Store) *ECBProvider {
+	return &ECBProvider{client: client, store: store, now: time.Now}
+}
+
+func (p *ECBProvider) Source() string {
+	return SourceECB
+}
+
+// FetchPrices stores a daily price in euros of a forex asset for each day
+// the ECB published a rate for its currency, stamped at the time of
+// publication.
+func (p *ECBProvider) FetchPrices(ctx context.Context, asset *entity.Asset, since time.Time) (*FetchedPrices, error) {
+	code := strings.ToUpper(asset.Symbol)
+	if asset.Type != entity.AssetTypeForex || code == "EUR" {
+		return nil, fmt.Errorf("%w: ECB prices currencies other than the euro", store.ErrNotFound)
+	}
+	days, err := p.rates(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	euro, err := forexAsset(ctx, p.store, "EUR")
+	if err != nil {
+		return nil, err
+	}
+
+	now := p.now()
+	fetched := &FetchedPrices{}
+	quoted := false
+	for _, day := range days {
+		rate, ok := day.Rates[code]
+		if !ok {
+			continue
+		}
+		quoted = true
+		y, m, d := day.Date.Date()
+		at := time.Date(y, m, d, ecbPublicationHour, 0, 0, 0, frankfurt)
+		if at.Before(since) || at.After(now) {
+			continue
+		}
+		last := decimal.NewFromInt(1).DivRound(rate, ecbDecimals).Shift(ecbDecimals).IntPart()
+		fetched.Prices = append(fetched.Prices, &entity.StoredPrice{
+			SourceID:    SourceECB,
+			AssetID:     asset.ID,
+			BaseAssetID: euro.ID,
+			Interval:    IntervalDaily,
+			Decimals:    ecbDecimals,
+			Last:        last,
+			Close:       &last,
+			Timestamp:   at,
+		})
+	}
+	if !quoted && len(days) > 0 {
+		return nil, fmt.Errorf("%w: ECB publishes no rate for %s", store.ErrNotFound, code)
+	}
+	return fetched, nil
+}
+
+// rates returns the published rates from since on, reusing a recent read
+// that reaches back as far.
+func (p *ECBProvider) rates(ctx context.Context, since time.Time) ([]ecb.DailyRates, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	if p.cached != nil && !p.cachedSince.After(since) && now.Sub(p.cachedAt) < ecbRatesTTL {
+		return p.cached, nil
+	}
+	days, err := p.client.Rates(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	p.cached, p.cachedSince, p.cachedAt = days, since, now
+	return days, nil
+}
@@ -0,0 +1,82 @@
+package marketdata
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/adapter/ecb"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ PriceProvider = (*ECBProvider)(nil)
+
+// fakeECB publishes USD and GBP rates on April 14 to 16 2025, and counts
+// the reads.
+type fakeECB struct {
+	reads int
+}
+
+func (c *fakeECB) Rates(_ context.Context, since time.Time) ([]ecb.DailyRates, error) {
+	c.reads++
+	var days []ecb.DailyRates
+	for i, usd := range []string{"1.1362", "1.1285", "1.1305"} {
+		date := time.Date(2025, time.April, 14+i, 0, 0, 0, 0, time.UTC)
+		if date.Before(since.Truncate(24 * time.Hour)) {
+			continue
+		}
+		days = append(days, ecb.DailyRates{Date: date, Rates: map[string]decimal.Decimal{
+			"USD": decimal.RequireFromString(usd),
+			"GBP": decimal.RequireFromString("0.85"),
+		}})
+	}
+	return days, nil
+}
+
+func TestECBFetchPrices(t *testing.T) {
+	now := time.Date(2025, time.April, 16, 13, 0, 0, 0, time.UTC)
+	client := &fakeECB{}
+	s := &assetStore{assets: map[string]*entity.Asset{
+		"eur": {ID: "eur", Symbol: "EUR", Type: entity.AssetTypeForex},
+		"usd": {ID: "usd", Symbol: "USD", Type: entity.AssetTypeForex},
+		"rub": {ID: "rub", Symbol: "RUB", Type: entity.AssetTypeForex},
+		"btc": {ID: "btc", Symbol: "BTC", Type: entity.AssetTypeCryptocurrency},
+	}}
+	p := &ECBProvider{client: client, store: s, now: func() time.Time { return now }}
+	since := time.Date(2025, time.April, 14, 0, 0, 0, 0, time.UTC)
+
+	fetched, err := p.FetchPrices(context.Background(), s.assets["usd"], since)
+	require.NoError(t, err)
+	// Dollars are priced in euros at each publication, 16:00 in Frankfurt;
+	// today's rate is not out yet.
+	require.Len(t, fetched.Prices, 2)
+	for i, want := range []struct {
+		last int64
+		at   time.Time
+	}{
+		{8801267383, time.Date(2025, time.April, 14, 14, 0, 0, 0, time.UTC)},
+		{8861320337, time.Date(2025, time.April, 15, 14, 0, 0, 0, time.UTC)},
+	} {
+		price := fetched.Prices[i]
+		assert.Equal(t, want.last, price.Last, i)
+		assert.True(t, want.at.Equal(price.Timestamp), i)
+		assert.Equal(t, "eur", price.BaseAssetID)
+		assert.Equal(t, IntervalDaily, price.Interval)
+		assert.Equal(t, uint32(ecbDecimals), price.Decimals)
+	}
+
+	for _, id := range []string{"eur", "rub", "btc"} {
+		_, err := p.FetchPrices(context.Background(), s.assets[id], since)
+		assert.ErrorIs(t, err, store.ErrNotFound, id)
+	}
+	// One read served every currency.
+	assert.Equal(t, 1, client.reads)
+
+	_, err = p.FetchPrices(context.Background(), s.assets["usd"], since.AddDate(0, 0, -1))
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.reads)
+}
@@ -0,0 +1,138 @@
+package marketdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+)
+
+// MetadataProvider looks up descriptive data about assets.
+type MetadataProvider interface {
+	// Source names the provider in metadata provenance, e.g. "coingecko".
+	Source() string
+	// AssetMetadata returns what the provider knows about asset by metadata
+	// key, or store.ErrNotFound if it does not know the asset.
+	AssetMetadata(ctx context.Context, asset *entity.Asset) (map[string]string, error)
+}
+
+// EnrichConfig sets which source wins when several provide a metadata field.
+// Sources missing from a list rank after those in it, in no particular
+// order. Manual edits rank first unless "manual" is listed.
+type EnrichConfig struct {
+	// Precedence lists sources from most to least trusted.
+	Precedence []string `koanf:"precedence"`
+	// Fields overrides Precedence for single metadata keys.
+	Fields map[string][]string `koanf:"fields"`
+}
+
+// Enricher merges metadata from providers into assets.
+type Enricher struct {
+	cfg       EnrichConfig
+	providers []MetadataProvider
+	log       *slog.Logger
+	now       func() time.Time
+}
+
+func NewEnricher(cfg EnrichConfig, providers []MetadataProvider, log *slog.Logger) *Enricher {
+	return &Enricher{cfg: cfg, providers: providers, log: log, now: time.Now}
+}
+
+// selectProviders returns the providers named by sources, or all of them if
+// none are named.
+func (e *Enricher) selectProviders(sources []string) ([]MetadataProvider, error) {
+	if len(sources) == 0 {
+		return e.providers, nil
+	}
+	var selected []MetadataProvider
+	for _, source := range sources {
+		i := slices.IndexFunc(e.providers, func(p MetadataProvider) bool { return p.Source() == source })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown metadata source %q", source)
+		}
+		if !slices.Contains(selected, e.providers[i]) {
+			selected = append(selected, e.providers[i])
+		}
+	}
+	return selected, nil
+}
+
+// rank orders the sources of a metadata field; the lowest rank wins.
+func (e *Enricher) rank(key, source string) int {
+	precedence, ok := e.cfg.Fields[key]
+	if !ok {
+		precedence = e.cfg.Precedence
+	}
+	if i := slices.Index(precedence, source); i >= 0 {
+		return i
+	}
+	if source == entity.MetadataSourceManual {
+		return -1
+	}
+	return len(precedence)
+}
+
+// enrich asks providers about asset and merges their answers into its
+// metadata. A field is replaced only by a source that ranks as high or
+// higher, so a source refreshes its own values. It reports whether any
+// provider knew the asset, and fails only if none could be asked.
+func (e *Enricher) enrich(ctx context.Context, asset *entity.Asset, providers []MetadataProvider) (bool, error) {
+	var found bool
+	var errs []error
+	for _, p := range providers {
+		fields, err := p.AssetMetadata(ctx, asset)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			e.log.Warn("Metadata provider failed", "source", p.Source(), "asset_id", asset.ID, slog.Any("error", err))
+			errs = append(errs, fmt.Errorf("%s: %w", p.Source(), err))
+			continue
+		}
+		found = true
+		e.merge(asset, p.Source(), fields)
+	}
+	if !found && len(errs) > 0 {
+		return false, errors.Join(errs...)
+	}
+	if found {
+		now := e.now()
+		asset.LastEnrichedAt = &now
+	}
+	return found, nil
+}
+
+func (e *Enricher) merge(asset *entity.Asset, source string, fields map[string]string) {
+	if asset.Metadata == nil {
+		asset.Metadata = map[string]entity.AssetMetadata{}
+	}
+	now := e.now()
+	for key, value := range fields {
+		if value == "" {
+			continue
+		}
+		if current, ok := asset.Metadata[key]; ok && e.rank(key, source) > e.rank(key, current.Source) {
+			continue
+		}
+		asset.Metadata[key] = entity.AssetMetadata{Value: value, Source: source, UpdatedAt: now}
+	}
+}
+
+// stampManualMetadata records metadata set through the API without a source
+// as a manual edit made now.
+func stampManualMetadata(asset *entity.Asset, now time.Time) {
+	for key, m := range asset.Metadata {
+		if m.Source == "" {
+			m.Source = entity.MetadataSourceManual
+		}
+		if m.UpdatedAt.IsZero() {
+			m.UpdatedAt = now
+		}
+		asset.Metadata[key] = m
+	}
+}
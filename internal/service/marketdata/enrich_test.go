@@ -0,0 +1,279 @@
+package marketdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/foxcool/greedy-eye/internal/adapter/coingecko"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assetStore holds assets and their identifiers in memory.
+type assetStore struct {
+	Store
+	assets      map[string]*entity.Asset
+	identifiers []*entity.AssetIdentifier
+	updated     []string
+	found       []*entity.Asset // Search results
+	searchOpts  SearchAssetsOpts
+}
+
+func (s *assetStore) CreateAsset(_ context.Context, asset *entity.Asset) (*entity.Asset, error) {
+	asset.ID = fmt.Sprintf("asset-%d", len(s.assets)+1)
+	s.assets[asset.ID] = asset
+	return asset, nil
+}
+
+func (s *assetStore) GetAsset(_ context.Context, id string) (*entity.Asset, error) {
+	a, ok := s.assets[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	copied := *a
+	return &copied, nil
+}
+
+func (s *assetStore) UpdateAsset(_ context.Context, asset *entity.Asset, fields []string) (*entity.Asset, error) {
+	s.updated = fields
+	s.assets[asset.ID] = asset
+	return asset, nil
+}
+
+func (s *assetStore) ListAssets(_ context.Context, opts ListAssetsOpts) ([]*entity.Asset, string, error) {
+	var assets []*entity.Asset
+	for _, a := range s.assets {
+		if opts.Type != entity.AssetTypeUnspecified && a.Type != opts.Type {
+			continue
+		}
+		if len(opts.AnyTags) > 0 && !slices.ContainsFunc(a.Tags, func(tag string) bool { return slices.Contains(opts.AnyTags, tag) }) {
+			continue
+		}
+		if opts.Symbol != "" && !strings.EqualFold(a.Symbol, opts.Symbol) {
+			continue
+		}
+		if want := opts.Identifier; want != nil && !slices.ContainsFunc(s.identifiers, func(i *entity.AssetIdentifier) bool {
+			return i.AssetID == a.ID && i.Value == want.Value &&
+				(want.Kind == entity.IdentifierKindUnspecified || i.Kind == want.Kind) &&
+				(want.Chain == "" || i.Chain == want.Chain)
+		}) {
+			continue
+		}
+		assets = append(assets, a)
+	}
+	slices.SortFunc(assets, func(a, b *entity.Asset) int { return strings.Compare(a.ID, b.ID) })
+	return assets, "", nil
+}
+
+func (s *assetStore) SearchAssets(_ context.Context, opts SearchAssetsOpts) ([]*entity.Asset, string, error) {
+	s.searchOpts = opts
+	return s.found, "", nil
+}
+
+func (s *assetStore) CreateAssetIdentifier(_ context.Context, ident *entity.AssetIdentifier) (*entity.AssetIdentifier, error) {
+	if _, ok := s.assets[ident.AssetID]; !ok {
+		return nil, store.ErrNotFound
+	}
+	for _, i := range s.identifiers {
+		if i.Kind == ident.Kind && i.Chain == ident.Chain && i.Value == ident.Value {
+			return nil, store.ErrConstraint
+		}
+	}
+	ident.ID = fmt.Sprintf("ident-%d", len(s.identifiers)+1)
+	s.identifiers = append(s.identifiers, ident)
+	return ident, nil
+}
+
+func (s *assetStore) ListAssetIdentifiers(_ context.Context, assetID string) ([]*entity.AssetIdentifier, error) {
+	var identifiers []*entity.AssetIdentifier
+	for _, i := range s.identifiers {
+		if i.AssetID == assetID {
+			identifiers = append(identifiers, i)
+		}
+	}
+	return identifiers, nil
+}
+
+type fakeProvider struct {
+	source string
+	fields map[string]string
+	err    error
+}
+
+func (p *fakeProvider) Source() string { return p.source }
+
+func (p *fakeProvider) AssetMetadata(context.Context, *entity.Asset) (map[string]string, error) {
+	return p.fields, p.err
+}
+
+var enrichedAt = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func newEnrichHandler(cfg EnrichConfig, providers ...MetadataProvider) (*Handler, *assetStore) {
+	s := &assetStore{assets: map[string]*entity.Asset{
+		"btc": {ID: "btc", Name: "Bitcoin", Symbol: "BTC", Metadata: map[string]entity.AssetMetadata{
+			entity.MetadataDescription: {Value: "Our own words", Source: entity.MetadataSourceManual},
+			entity.MetadataWebsite:     {Value: "https://old.example", Source: "cmc"},
+		}},
+	}}
+	log := slog.New(slog.DiscardHandler)
+	enricher := NewEnricher(cfg, providers, log)
+	enricher.now = func() time.Time { return enrichedAt }
+	return NewHandler(s, nil, enricher, nil, nil, QualityConfig{}, log), s
+}
+
+func TestEnrichAssetData(t *testing.T) {
+	gecko := &fakeProvider{source: "coingecko", fields: map[string]string{
+		entity.MetadataDescription: "The first cryptocurrency",
+		entity.MetadataWebsite:     "https://bitcoin.org",
+		entity.MetadataLogoURL:     "https://img/btc.png",
+		entity.MetadataCategory:    "",
+	}}
+	cmc := &fakeProvider{source: "cmc", fields: map[string]string{
+		entity.MetadataLogoURL:       "https://cmc/btc.png",
+		entity.MetadataMarketCapRank: "1",
+	}}
+	h, s := newEnrichHandler(EnrichConfig{
+		Precedence: []string{"coingecko", "cmc"},
+		Fields:     map[string][]string{entity.MetadataLogoURL: {"cmc"}},
+	}, gecko, cmc)
+
+	resp, err := h.EnrichAssetData(context.Background(), connect.NewRequest(&apiv1.EnrichAssetDataRequest{AssetId: "btc"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"metadata", "last_enriched_at"}, s.updated)
+	metadata := resp.Msg.Metadata
+
+	// Manual edits win, the higher source replaces the lower one, and the
+	// field override puts cmc first for logos.
+	assert.Equal(t, "Our own words", metadata[entity.MetadataDescription].Value)
+	assert.Equal(t, entity.MetadataSourceManual, metadata[entity.MetadataDescription].Source)
+	assert.Equal(t, "https://bitcoin.org", metadata[entity.MetadataWebsite].Value)
+	assert.Equal(t, "coingecko", metadata[entity.MetadataWebsite].Source)
+	assert.Equal(t, enrichedAt, metadata[entity.MetadataWebsite].UpdatedAt.AsTime())
+	assert.Equal(t, "https://cmc/btc.png", metadata[entity.MetadataLogoURL].Value)
+	assert.Equal(t, "1", metadata[entity.MetadataMarketCapRank].Value)
+	assert.NotContains(t, metadata, entity.MetadataCategory)
+	assert.Equal(t, enrichedAt, resp.Msg.LastEnrichedAt.AsTime())
+
+	// Only the named source is asked; it may refresh its own values.
+	cmc.fields = map[string]string{entity.MetadataWebsite: "https://cmc.example", entity.MetadataMarketCapRank: "2"}
+	resp, err = h.EnrichAssetData(context.Background(), connect.NewRequest(&apiv1.EnrichAssetDataRequest{
+		AssetId: "btc", Sources: []string{"cmc"},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "https://bitcoin.org", resp.Msg.Metadata[entity.MetadataWebsite].Value)
+	assert.Equal(t, "2", resp.Msg.Metadata[entity.MetadataMarketCapRank].Value)
+}
+
+func TestEnrichAssetData_Errors(t *testing.T) {
+	failing := &fakeProvider{source: "down", err: errors.New("timeout")}
+	unknown := &fakeProvider{source: "empty", err: store.ErrNotFound}
+	ok := &fakeProvider{source: "ok", fields: map[string]string{entity.MetadataWebsite: "https://bitcoin.org"}}
+
+	h, _ := newEnrichHandler(EnrichConfig{})
+	_, err := h.EnrichAssetData(context.Background(), connect.NewRequest(&apiv1.EnrichAssetDataRequest{AssetId: "btc"}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	h, s := newEnrichHandler(EnrichConfig{}, failing, unknown, ok)
+	for name, tc := range map[string]struct {
+		req  *apiv1.EnrichAssetDataRequest
+		code connect.Code
+	}{
+		"no asset ID":     {&apiv1.EnrichAssetDataRequest{}, connect.CodeInvalidArgument},
+		"unknown source":  {&apiv1.EnrichAssetDataRequest{AssetId: "btc", Sources: []string{"nope"}}, connect.CodeInvalidArgument},
+		"unknown asset":   {&apiv1.EnrichAssetDataRequest{AssetId: "eth"}, connect.CodeNotFound},
+		"source failed":   {&apiv1.EnrichAssetDataRequest{AssetId: "btc", Sources: []string{"down"}}, connect.CodeUnavailable},
+		"no source knows": {&apiv1.EnrichAssetDataRequest{AssetId: "btc", Sources: []string{"empty"}}, connect.CodeNotFound},
+	} {
+		_, err := h.EnrichAssetData(context.Background(), connect.NewRequest(tc.req))
+		assert.Equal(t, tc.code, connect.CodeOf(err), name)
+	}
+	assert.Nil(t, s.updated)
+
+	// A failing source does not stop the others.
+	resp, err := h.EnrichAssetData(context.Background(), connect.NewRequest(&apiv1.EnrichAssetDataRequest{AssetId: "btc"}))
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp.Msg.Metadata[entity.MetadataWebsite].Source)
+}
+
+func TestStampManualMetadata(t *testing.T) {
+	asset := assetFromProto(&apiv1.Asset{Metadata: map[string]*apiv1.AssetMetadata{
+		entity.MetadataWebsite:     {Value: "https://bitcoin.org"},
+		entity.MetadataDescription: {Value: "From a provider", Source: "coingecko"},
+	}})
+	stampManualMetadata(asset, enrichedAt)
+	assert.Equal(t, entity.AssetMetadata{Value: "https://bitcoin.org", Source: entity.MetadataSourceManual, UpdatedAt: enrichedAt},
+		asset.Metadata[entity.MetadataWebsite])
+	assert.Equal(t, "coingecko", asset.Metadata[entity.MetadataDescription].Source)
+}
+
+type fakeCoinGecko map[string]*coingecko.AssetDetails
+
+func (f fakeCoinGecko) GetAssetDetails(_ context.Context, id string) (*coingecko.AssetDetails, error) {
+	if d, ok := f[id]; ok {
+		return d, nil
+	}
+	return nil, coingecko.ErrNotFound
+}
+
+// SearchAssets finds coins by name, ordered by ID.
+func (f fakeCoinGecko) SearchAssets(_ context.Context, query string) ([]coingecko.SearchResult, error) {
+	if query == "down" {
+		return nil, errors.New("coingecko: 503 Service Unavailable")
+	}
+	var results []coingecko.SearchResult
+	for _, d := range f {
+		if strings.Contains(strings.ToLower(d.Name), strings.ToLower(query)) {
+			results = append(results, coingecko.SearchResult{ID: d.ID, Symbol: d.Symbol, Name: d.Name, MarketCapRank: d.MarketCapRank, ImageURL: d.ImageURL})
+		}
+	}
+	slices.SortFunc(results, func(a, b coingecko.SearchResult) int { return strings.Compare(a.ID, b.ID) })
+	return results, nil
+}
+
+func TestCoinGeckoProvider(t *testing.T) {
+	s := &assetStore{identifiers: []*entity.AssetIdentifier{
+		{AssetID: "wbtc", Kind: entity.IdentifierKindBinance, Value: "WBTC"},
+		{AssetID: "wbtc", Kind: entity.IdentifierKindCoinGecko, Value: "wrapped-bitcoin"},
+		{AssetID: "xbt", Kind: entity.IdentifierKindCoinGecko, Value: "bitcoin"},
+		{AssetID: "gone", Kind: entity.IdentifierKindCoinGecko, Value: "delisted-coin"},
+	}}
+	p := &CoinGeckoProvider{store: s, client: fakeCoinGecko{
+		"wrapped-bitcoin": {ID: "wrapped-bitcoin", Symbol: "WBTC", Name: "Wrapped Bitcoin", Categories: []string{"Wrapped-Tokens", "Bitcoin Ecosystem"},
+			MarketCapRank: 15, AssetPlatformID: "ethereum", Platforms: map[string]string{"ethereum": "0x2260"}},
+		"bitcoin": {ID: "bitcoin", Symbol: "BTC", Name: "Bitcoin"},
+	}}
+
+	fields, err := p.AssetMetadata(context.Background(), &entity.Asset{ID: "wbtc", Name: "Wrapped  Bitcoin", Symbol: "wbtc"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		entity.MetadataName:                        "Wrapped Bitcoin",
+		entity.MetadataDescription:                 "",
+		entity.MetadataLogoURL:                     "",
+		entity.MetadataWebsite:                     "",
+		entity.MetadataCategory:                    "Wrapped-Tokens, Bitcoin Ecosystem",
+		entity.MetadataChain:                       "ethereum",
+		entity.MetadataMarketCapRank:               "15",
+		entity.MetadataContractPrefix + "ethereum": "0x2260",
+	}, fields)
+
+	// The mapped ID is used whatever the symbol.
+	fields, err = p.AssetMetadata(context.Background(), &entity.Asset{ID: "xbt", Name: "XBT", Symbol: "XBT"})
+	require.NoError(t, err)
+	assert.Equal(t, "Bitcoin", fields[entity.MetadataName])
+
+	// Without a mapping the name is not guessed.
+	_, err = p.AssetMetadata(context.Background(), &entity.Asset{ID: "btc", Name: "Bitcoin", Symbol: "BTC"})
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	_, err = p.AssetMetadata(context.Background(), &entity.Asset{ID: "gone", Name: "Delisted Coin"})
+	assert.ErrorIs(t, err, store.ErrNotFound)
+}
@@ -0,0 +1,158 @@
+package marketdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+)
+
+// defaultFetchPeriod is how far back FetchExternalPrices looks unless told
+// otherwise; a week spans any run of holidays.
+const defaultFetchPeriod = 7 * 24 * time.Hour
+
+// Price intervals stored by providers.
+const (
+	IntervalDaily  = "1d"
+	IntervalLatest = "latest"
+)
+
+// PriceProvider fetches prices and dividends of assets from an external
+// source.
+type PriceProvider interface {
+	Source() string
+	// FetchPrices returns the prices of asset from since on, oldest first,
+	// and the dividends that went ex in that time. It returns
+	// store.ErrNotFound for assets the provider does not price.
+	FetchPrices(ctx context.Context, asset *entity.Asset, since time.Time) (*FetchedPrices, error)
+}
+
+// FetchedPrices is what a PriceProvider found for an asset.
+type FetchedPrices struct {
+	Prices    []*entity.StoredPrice
+	Dividends []*entity.Dividend
+}
+
+// FetchExternalPrices fetches and stores prices and dividends of assets from
+// price providers. Income from the dividends is recorded by the portfolio
+// service's background job, not here, as it is written to every user's
+// accounts. Prices
+// already stored are skipped, so overlapping fetches are harmless. Prices
+// failing the ingestion checks are stored quarantined.
+func (h *Handler) FetchExternalPrices(ctx context.Context, req *connect.Request[apiv1.FetchExternalPricesRequest]) (*connect.Response[apiv1.FetchExternalPricesResponse], error) {
+	providers := h.priceProviders
+	if len(req.Msg.SourceIds) > 0 {
+		providers = nil
+		for _, source := range req.Msg.SourceIds {
+			i := slices.IndexFunc(h.priceProviders, func(p PriceProvider) bool { return p.Source() == source })
+			if i < 0 {
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown price source %q", source))
+			}
+			providers = append(providers, h.priceProviders[i])
+		}
+	}
+	now := time.Now()
+	since := now.Add(-defaultFetchPeriod)
+	if req.Msg.Since != nil {
+		since = req.Msg.Since.AsTime()
+		if !since.Before(now) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("since must be in the past"))
+		}
+	}
+
+	assets, err := h.assetsToFetch(ctx, req.Msg.AssetIds)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	resp := &apiv1.FetchExternalPricesResponse{}
+	for _, asset := range assets {
+		var priced bool
+		for _, provider := range providers {
+			fetched, err := provider.FetchPrices(ctx, asset, since)
+			if errors.Is(err, store.ErrNotFound) {
+				continue
+			}
+			priced = true
+			if err != nil {
+				h.log.Warn("Price fetch failed", "source", provider.Source(), "asset_id", asset.ID, slog.Any("error", err))
+				resp.Errors = append(resp.Errors, fmt.Sprintf("%s: asset %s: %v", provider.Source(), asset.ID, err))
+				continue
+			}
+
+			quarantined, err := h.checkPrices(ctx, fetched.Prices)
+			if err != nil {
+				return nil, toConnectError(err)
+			}
+			stored, err := h.store.CreatePrices(ctx, fetched.Prices)
+			if err != nil {
+				return nil, toConnectError(err)
+			}
+			resp.PricesFetched += int32(len(fetched.Prices))
+			resp.PricesStored += int32(stored)
+			resp.PricesQuarantined += int32(quarantined)
+			for _, latest := range slices.Backward(fetched.Prices) {
+				if stored > 0 && latest.Quarantine == "" {
+					h.events.Publish(ctx, priceTopic(latest.AssetID, latest.BaseAssetID), latest)
+					break
+				}
+			}
+
+			dividends, err := h.store.CreateDividends(ctx, fetched.Dividends)
+			if err != nil {
+				resp.Errors = append(resp.Errors, fmt.Sprintf("%s: dividends of asset %s: %v", provider.Source(), asset.ID, err))
+			}
+			resp.DividendsStored += int32(dividends)
+		}
+		if !priced && len(req.Msg.AssetIds) > 0 {
+			resp.Errors = append(resp.Errors, fmt.Sprintf("no source prices asset %s", asset.ID))
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// assetsToFetch returns the assets with the given IDs, or every asset if
+// none are given.
+func (h *Handler) assetsToFetch(ctx context.Context, ids []string) ([]*entity.Asset, error) {
+	var assets []*entity.Asset
+	if len(ids) > 0 {
+		for _, id := range ids {
+			asset, err := h.store.GetAsset(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			assets = append(assets, asset)
+		}
+		return assets, nil
+	}
+	for token := ""; ; {
+		page, next, err := h.store.ListAssets(ctx, ListAssetsOpts{PageSize: 100, PageToken: token})
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, page...)
+		if next == "" {
+			return assets, nil
+		}
+		token = next
+	}
+}
+
+// forexAsset returns the forex asset of an ISO 4217 code.
+func forexAsset(ctx context.Context, s Store, code string) (*entity.Asset, error) {
+	assets, _, err := s.ListAssets(ctx, ListAssetsOpts{Symbol: code, Type: entity.AssetTypeForex, PageSize: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(assets) == 0 {
+		return nil, fmt.Errorf("no forex asset for currency %s", code)
+	}
+	return assets[0], nil
+}
@@ -0,0 +1,111 @@
+package marketdata
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fetchStore stores prices once per timestamp and keeps dividends.
+type fetchStore struct {
+	*assetStore
+	prices    map[time.Time]*entity.StoredPrice
+	dividends []*entity.Dividend
+}
+
+func (s *fetchStore) CreatePrices(_ context.Context, prices []*entity.StoredPrice) (int, error) {
+	var created int
+	for _, p := range prices {
+		if _, ok := s.prices[p.Timestamp]; !ok {
+			s.prices[p.Timestamp] = p
+			created++
+		}
+	}
+	return created, nil
+}
+
+func (s *fetchStore) CreateDividends(_ context.Context, dividends []*entity.Dividend) (int, error) {
+	s.dividends = append(s.dividends, dividends...)
+	return len(dividends), nil
+}
+
+// fakePriceProvider prices "aapl", fails for "broken" and knows no others.
+type fakePriceProvider struct {
+	since time.Time
+}
+
+func (p *fakePriceProvider) Source() string { return "fake" }
+
+func (p *fakePriceProvider) FetchPrices(_ context.Context, asset *entity.Asset, since time.Time) (*FetchedPrices, error) {
+	p.since = since
+	switch asset.ID {
+	case "aapl":
+		day := time.Date(2025, time.April, 14, 20, 0, 0, 0, time.UTC)
+		return &FetchedPrices{
+			Prices: []*entity.StoredPrice{
+				{AssetID: "aapl", BaseAssetID: "usd", Interval: IntervalDaily, Last: 100, Timestamp: day},
+				{AssetID: "aapl", BaseAssetID: "usd", Interval: IntervalDaily, Last: 101, Timestamp: day.AddDate(0, 0, 1)},
+			},
+			Dividends: []*entity.Dividend{{AssetID: "aapl", CurrencyAssetID: "usd", ExDate: day, PerShare: decimal.RequireFromString("0.25")}},
+		}, nil
+	case "broken":
+		return nil, errors.New("upstream is down")
+	}
+	return nil, store.ErrNotFound
+}
+
+func TestFetchExternalPrices(t *testing.T) {
+	s := &fetchStore{
+		assetStore: &assetStore{assets: map[string]*entity.Asset{
+			"aapl":   {ID: "aapl", Symbol: "AAPL"},
+			"broken": {ID: "broken", Symbol: "BRKN"},
+			"btc":    {ID: "btc", Symbol: "BTC"},
+		}},
+		prices: map[time.Time]*entity.StoredPrice{},
+	}
+	provider := &fakePriceProvider{}
+	h := NewHandler(s, nil, nil, nil, []PriceProvider{provider}, QualityConfig{}, slog.New(slog.DiscardHandler))
+
+	resp, err := h.FetchExternalPrices(context.Background(), connect.NewRequest(&apiv1.FetchExternalPricesRequest{}))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, resp.Msg.PricesFetched)
+	assert.EqualValues(t, 2, resp.Msg.PricesStored)
+	assert.EqualValues(t, 1, resp.Msg.DividendsStored)
+	// Assets the provider does not price are no error unless asked for.
+	assert.Equal(t, []string{"fake: asset broken: upstream is down"}, resp.Msg.Errors)
+	assert.WithinDuration(t, time.Now().Add(-defaultFetchPeriod), provider.since, time.Minute)
+
+	// Fetching again stores nothing new.
+	since := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	resp, err = h.FetchExternalPrices(context.Background(), connect.NewRequest(&apiv1.FetchExternalPricesRequest{
+		SourceIds: []string{"fake"},
+		AssetIds:  []string{"aapl", "btc"},
+		Since:     timestamppb.New(since),
+	}))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, resp.Msg.PricesFetched)
+	assert.EqualValues(t, 0, resp.Msg.PricesStored)
+	assert.Equal(t, []string{"no source prices asset btc"}, resp.Msg.Errors)
+	assert.Equal(t, since, provider.since)
+
+	for name, req := range map[string]*apiv1.FetchExternalPricesRequest{
+		"unknown source": {SourceIds: []string{"bloomberg"}},
+		"future since":   {Since: timestamppb.New(time.Now().Add(time.Hour))},
+	} {
+		_, err := h.FetchExternalPrices(context.Background(), connect.NewRequest(req))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), name)
+	}
+	_, err = h.FetchExternalPrices(context.Background(), connect.NewRequest(&apiv1.FetchExternalPricesRequest{AssetIds: []string{"gone"}}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
@@ -2,13 +2,18 @@ package marketdata
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
 	"connectrpc.com/connect"
 	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
 	"github.com/foxcool/greedy-eye/internal/api/v1/apiv1connect"
 	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/pubsub"
 	"github.com/foxcool/greedy-eye/internal/store"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -17,12 +22,18 @@ import (
 // Handler implements apiv1connect.MarketDataServiceHandler.
 type Handler struct {
 	apiv1connect.UnimplementedMarketDataServiceHandler
-	store Store
-	log   *slog.Logger
+	store     Store
+	events    *pubsub.Hub
+	enricher  *Enricher
+	searchers []AssetSearcher
+	// priceProviders are consulted by FetchExternalPrices.
+	priceProviders []PriceProvider
+	quality        QualityConfig
+	log            *slog.Logger
 }
 
-func NewHandler(store Store, log *slog.Logger) *Handler {
-	return &Handler{store: store, log: log}
+func NewHandler(store Store, events *pubsub.Hub, enricher *Enricher, searchers []AssetSearcher, priceProviders []PriceProvider, quality QualityConfig, log *slog.Logger) *Handler {
+	return &Handler{store: store, events: events, enricher: enricher, searchers: searchers, priceProviders: priceProviders, quality: quality, log: log}
 }
 
 // CreateAsset creates a new asset.
@@ -32,6 +43,10 @@ func (h *Handler) CreateAsset(ctx context.Context, req *connect.Request[apiv1.Cr
 	}
 
 	asset := assetFromProto(req.Msg.Asset)
+	if err := h.checkBondTerms(ctx, asset, req.Msg.Asset.Bond, asset.Type); err != nil {
+		return nil, err
+	}
+	stampManualMetadata(asset, time.Now())
 	created, err := h.store.CreateAsset(ctx, asset)
 	if err != nil {
 		return nil, toConnectError(err)
@@ -66,6 +81,20 @@ func (h *Handler) UpdateAsset(ctx context.Context, req *connect.Request[apiv1.Up
 	}
 
 	asset := assetFromProto(req.Msg.Asset)
+	if slices.Contains(fields, "bond") {
+		assetType := asset.Type
+		if !slices.Contains(fields, "type") {
+			stored, err := h.store.GetAsset(ctx, asset.ID)
+			if err != nil {
+				return nil, toConnectError(err)
+			}
+			assetType = stored.Type
+		}
+		if err := h.checkBondTerms(ctx, asset, req.Msg.Asset.Bond, assetType); err != nil {
+			return nil, err
+		}
+	}
+	stampManualMetadata(asset, time.Now())
 	updated, err := h.store.UpdateAsset(ctx, asset, fields)
 	if err != nil {
 		return nil, toConnectError(err)
@@ -115,35 +144,53 @@ func (h *Handler) ListAssets(ctx context.Context, req *connect.Request[apiv1.Lis
 	}), nil
 }
 
-// CreatePrice creates a new price record.
+// CreatePrice creates a new price record, quarantined if it fails the
+// ingestion checks.
 func (h *Handler) CreatePrice(ctx context.Context, req *connect.Request[apiv1.CreatePriceRequest]) (*connect.Response[apiv1.Price], error) {
 	if req.Msg.Price == nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("price is required"))
 	}
 
 	price := priceFromProto(req.Msg.Price)
+	if _, err := h.checkPrices(ctx, []*entity.StoredPrice{price}); err != nil {
+		return nil, toConnectError(err)
+	}
 	created, err := h.store.CreatePrice(ctx, price)
 	if err != nil {
 		return nil, toConnectError(err)
 	}
+	if created.Quarantine == "" {
+		h.events.Publish(ctx, priceTopic(created.AssetID, created.BaseAssetID), created)
+	}
 
 	return connect.NewResponse(priceToProto(created)), nil
 }
 
-// CreatePrices creates multiple price records in bulk.
+// CreatePrices creates multiple price records in bulk, oldest first,
+// quarantining those that fail the ingestion checks.
 func (h *Handler) CreatePrices(ctx context.Context, req *connect.Request[apiv1.CreatePricesRequest]) (*connect.Response[apiv1.CreatePricesResponse], error) {
 	prices := make([]*entity.StoredPrice, 0, len(req.Msg.Prices))
 	for _, p := range req.Msg.Prices {
 		prices = append(prices, priceFromProto(p))
 	}
 
+	quarantined, err := h.checkPrices(ctx, prices)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
 	count, err := h.store.CreatePrices(ctx, prices)
 	if err != nil {
 		return nil, toConnectError(err)
 	}
+	for _, p := range prices {
+		if p.Quarantine == "" {
+			h.events.Publish(ctx, priceTopic(p.AssetID, p.BaseAssetID), p)
+		}
+	}
 
 	return connect.NewResponse(&apiv1.CreatePricesResponse{
-		CreatedCount: int32(count),
+		CreatedCount:     int32(count),
+		QuarantinedCount: int32(quarantined),
 	}), nil
 }
 
@@ -166,6 +213,67 @@ func (h *Handler) GetLatestPrice(ctx context.Context, req *connect.Request[apiv1
 	return connect.NewResponse(priceToProto(price)), nil
 }
 
+// WatchPrices streams prices for the requested pairs as they are stored,
+// optionally starting with the latest stored price of each pair.
+func (h *Handler) WatchPrices(ctx context.Context, req *connect.Request[apiv1.WatchPricesRequest], stream *connect.ServerStream[apiv1.Price]) error {
+	if len(req.Msg.Pairs) == 0 {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("at least one pair is required"))
+	}
+	if len(req.Msg.Pairs) > maxWatchedPairs {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("at most %d pairs can be watched", maxWatchedPairs))
+	}
+
+	topics := make([]string, 0, len(req.Msg.Pairs))
+	for _, p := range req.Msg.Pairs {
+		if p.AssetId == "" || p.BaseAssetId == "" {
+			return connect.NewError(connect.CodeInvalidArgument, errors.New("asset_id and base_asset_id are required"))
+		}
+		topics = append(topics, priceTopic(p.AssetId, p.BaseAssetId))
+	}
+	sourceID := req.Msg.GetSourceId()
+
+	// Subscribe before reading the latest prices so nothing stored in between is missed.
+	sub := h.events.Subscribe(topics...)
+	defer sub.Close()
+
+	if req.Msg.IncludeLatest {
+		for _, p := range req.Msg.Pairs {
+			price, err := h.store.GetLatestPrice(ctx, p.AssetId, p.BaseAssetId, sourceID)
+			if errors.Is(err, store.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return toConnectError(err)
+			}
+			if err := stream.Send(priceToProto(price)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.C:
+			if !ok {
+				return nil
+			}
+			var price entity.StoredPrice
+			if err := json.Unmarshal(event.Payload, &price); err != nil {
+				h.log.Warn("dropping malformed price event", "topic", event.Topic, "error", err)
+				continue
+			}
+			if sourceID != "" && price.SourceID != sourceID {
+				continue
+			}
+			if err := stream.Send(priceToProto(&price)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 // ListPriceHistory returns price history for an asset pair.
 func (h *Handler) ListPriceHistory(ctx context.Context, req *connect.Request[apiv1.ListPriceHistoryRequest]) (*connect.Response[apiv1.ListPriceHistoryResponse], error) {
 	if req.Msg.AssetId == "" || req.Msg.BaseAssetId == "" {
@@ -173,8 +281,9 @@ func (h *Handler) ListPriceHistory(ctx context.Context, req *connect.Request[api
 	}
 
 	opts := ListPriceHistoryOpts{
-		AssetID:     req.Msg.AssetId,
-		BaseAssetID: req.Msg.BaseAssetId,
+		AssetID:            req.Msg.AssetId,
+		BaseAssetID:        req.Msg.BaseAssetId,
+		IncludeQuarantined: req.Msg.IncludeQuarantined,
 	}
 	if req.Msg.SourceId != nil {
 		opts.SourceID = *req.Msg.SourceId
@@ -265,22 +374,44 @@ func (h *Handler) DeletePrices(ctx context.Context, req *connect.Request[apiv1.D
 	return connect.NewResponse(&emptypb.Empty{}), nil
 }
 
-// EnrichAssetData enriches asset with external data (stub).
+// EnrichAssetData merges metadata from providers into an asset.
 func (h *Handler) EnrichAssetData(ctx context.Context, req *connect.Request[apiv1.EnrichAssetDataRequest]) (*connect.Response[apiv1.Asset], error) {
-	// TODO: Implement with external data sources
-	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("EnrichAssetData not implemented"))
-}
+	if req.Msg.AssetId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("asset ID is required"))
+	}
+	providers, err := h.enricher.selectProviders(req.Msg.Sources)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if len(providers) == 0 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("no metadata providers are configured"))
+	}
 
-// FindSimilarAssets finds similar assets (stub).
-func (h *Handler) FindSimilarAssets(ctx context.Context, req *connect.Request[apiv1.FindSimilarAssetsRequest]) (*connect.Response[apiv1.ListAssetsResponse], error) {
-	// TODO: Implement similarity search
-	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("FindSimilarAssets not implemented"))
+	asset, err := h.store.GetAsset(ctx, req.Msg.AssetId)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	found, err := h.enricher.enrich(ctx, asset, providers)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+	if !found {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no metadata source knows asset %s", asset.ID))
+	}
+
+	updated, err := h.store.UpdateAsset(ctx, asset, []string{"metadata", "last_enriched_at"})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(assetToProto(updated)), nil
 }
 
-// FetchExternalPrices fetches prices from external sources (stub).
-func (h *Handler) FetchExternalPrices(ctx context.Context, req *connect.Request[apiv1.FetchExternalPricesRequest]) (*connect.Response[apiv1.FetchExternalPricesResponse], error) {
-	// TODO: Implement with external price providers
-	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("FetchExternalPrices not implemented"))
+// maxWatchedPairs limits the pairs a single WatchPrices stream can follow.
+const maxWatchedPairs = 100
+
+func priceTopic(assetID, baseAssetID string) string {
+	return pubsub.Topic("prices", assetID, baseAssetID)
 }
 
 // toConnectError converts store errors to Connect errors.
@@ -304,13 +435,24 @@ func assetFromProto(p *apiv1.Asset) *entity.Asset {
 	if p.Symbol != nil {
 		symbol = *p.Symbol
 	}
-	return &entity.Asset{
+	asset := &entity.Asset{
 		ID:     p.Id,
 		Name:   p.Name,
 		Symbol: symbol,
 		Type:   entity.AssetType(p.Type),
 		Tags:   p.Tags,
 	}
+	if len(p.Metadata) > 0 {
+		asset.Metadata = make(map[string]entity.AssetMetadata, len(p.Metadata))
+		for key, m := range p.Metadata {
+			metadata := entity.AssetMetadata{Value: m.GetValue(), Source: m.GetSource()}
+			if m.GetUpdatedAt() != nil {
+				metadata.UpdatedAt = m.UpdatedAt.AsTime()
+			}
+			asset.Metadata[key] = metadata
+		}
+	}
+	return asset
 }
 
 func assetToProto(e *entity.Asset) *apiv1.Asset {
@@ -318,7 +460,7 @@ func assetToProto(e *entity.Asset) *apiv1.Asset {
 	if e.Symbol != "" {
 		symbol = &e.Symbol
 	}
-	return &apiv1.Asset{
+	result := &apiv1.Asset{
 		Id:        e.ID,
 		Name:      e.Name,
 		Symbol:    symbol,
@@ -327,6 +469,23 @@ func assetToProto(e *entity.Asset) *apiv1.Asset {
 		CreatedAt: timestamppb.New(e.CreatedAt),
 		UpdatedAt: timestamppb.New(e.UpdatedAt),
 	}
+	if len(e.Metadata) > 0 {
+		result.Metadata = make(map[string]*apiv1.AssetMetadata, len(e.Metadata))
+		for key, m := range e.Metadata {
+			result.Metadata[key] = &apiv1.AssetMetadata{
+				Value:     m.Value,
+				Source:    m.Source,
+				UpdatedAt: timestamppb.New(m.UpdatedAt),
+			}
+		}
+	}
+	if e.LastEnrichedAt != nil {
+		result.LastEnrichedAt = timestamppb.New(*e.LastEnrichedAt)
+	}
+	if e.Bond != nil {
+		result.Bond = bondToProto(e.Bond)
+	}
+	return result
 }
 
 func priceFromProto(p *apiv1.Price) *entity.StoredPrice {
@@ -365,5 +524,6 @@ func priceToProto(e *entity.StoredPrice) *apiv1.Price {
 		Close:       e.Close,
 		Volume:      e.Volume,
 		Timestamp:   timestamppb.New(e.Timestamp),
+		Quarantine:  e.Quarantine,
 	}
 }
@@ -0,0 +1,307 @@
+package marketdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxResolveMatches bounds the assets ResolveAsset lists.
+const maxResolveMatches = 10
+
+var (
+	coinGeckoIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+	binancePattern     = regexp.MustCompile(`^[A-Z0-9]+$`)
+	isinPattern        = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{9}[0-9]$`)
+	cusipPattern       = regexp.MustCompile(`^[A-Z0-9*@#]{8}[0-9]$`)
+	figiPattern        = regexp.MustCompile(`^[B-DF-HJ-NP-TV-Z]{2}G[B-DF-HJ-NP-TV-Z0-9]{8}[0-9]$`)
+	evmAddressPattern  = regexp.MustCompile(`^0x[0-9a-f]{40}$`)
+	tickerPattern      = regexp.MustCompile(`^[A-Z0-9^][A-Z0-9.=-]{0,19}$`)
+)
+
+// normalizeIdentifier returns value in the form identifiers of kind are
+// stored in, or an error if it is not a valid identifier of that kind.
+func normalizeIdentifier(kind entity.IdentifierKind, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch kind {
+	case entity.IdentifierKindCoinGecko:
+		value = strings.ToLower(value)
+		if !coinGeckoIDPattern.MatchString(value) {
+			return "", fmt.Errorf("invalid CoinGecko ID %q", value)
+		}
+	case entity.IdentifierKindBinance:
+		value = strings.ToUpper(value)
+		if !binancePattern.MatchString(value) {
+			return "", fmt.Errorf("invalid Binance asset code %q", value)
+		}
+	case entity.IdentifierKindISIN:
+		value = strings.ToUpper(value)
+		if !isinPattern.MatchString(value) || !validISIN(value) {
+			return "", fmt.Errorf("invalid ISIN %q", value)
+		}
+	case entity.IdentifierKindCUSIP:
+		value = strings.ToUpper(value)
+		if !cusipPattern.MatchString(value) || checkDigit(value[:8]) != value[8] {
+			return "", fmt.Errorf("invalid CUSIP %q", value)
+		}
+	case entity.IdentifierKindFIGI:
+		value = strings.ToUpper(value)
+		if !figiPattern.MatchString(value) || checkDigit(value[:11]) != value[11] {
+			return "", fmt.Errorf("invalid FIGI %q", value)
+		}
+	case entity.IdentifierKindContract:
+		// EVM addresses ignore case; others, such as Solana's, do not.
+		if strings.HasPrefix(strings.ToLower(value), "0x") {
+			value = strings.ToLower(value)
+			if !evmAddressPattern.MatchString(value) {
+				return "", fmt.Errorf("invalid contract address %q", value)
+			}
+		} else if value == "" || strings.ContainsFunc(value, func(r rune) bool { return r <= ' ' }) {
+			return "", fmt.Errorf("invalid contract address %q", value)
+		}
+	case entity.IdentifierKindTicker:
+		value = strings.ToUpper(value)
+		if !tickerPattern.MatchString(value) {
+			return "", fmt.Errorf("invalid ticker %q", value)
+		}
+	default:
+		return "", errors.New("identifier kind is required")
+	}
+	return value, nil
+}
+
+// checkDigit computes the check digit of a CUSIP or FIGI body: characters
+// count as their value, letters from 10, and every second one is doubled
+// before its digits are summed.
+func checkDigit(body string) byte {
+	sum := 0
+	for i := range len(body) {
+		c := body[i]
+		var v int
+		switch {
+		case c >= '0' && c <= '9':
+			v = int(c - '0')
+		case c >= 'A' && c <= 'Z':
+			v = int(c-'A') + 10
+		default:
+			v = strings.IndexByte("*@#", c) + 36
+		}
+		if i%2 == 1 {
+			v *= 2
+		}
+		sum += v/10 + v%10
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// validISIN runs the Luhn check over an ISIN with its letters expanded to
+// numbers from 10.
+func validISIN(isin string) bool {
+	var digits []int
+	for i := range len(isin) {
+		c := isin[i]
+		if c >= 'A' && c <= 'Z' {
+			v := int(c-'A') + 10
+			digits = append(digits, v/10, v%10)
+		} else {
+			digits = append(digits, int(c-'0'))
+		}
+	}
+	sum := 0
+	for i := range digits {
+		d := digits[len(digits)-1-i]
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// normalizeChain checks that only contracts name a chain.
+func normalizeChain(kind entity.IdentifierKind, chain string) (string, error) {
+	chain = strings.ToLower(strings.TrimSpace(chain))
+	if kind == entity.IdentifierKindContract && chain == "" {
+		return "", errors.New("contract identifiers need a chain")
+	}
+	if kind != entity.IdentifierKindContract && chain != "" {
+		return "", errors.New("only contract identifiers have a chain")
+	}
+	return chain, nil
+}
+
+// CreateAssetIdentifier maps an asset to an identifier.
+func (h *Handler) CreateAssetIdentifier(ctx context.Context, req *connect.Request[apiv1.CreateAssetIdentifierRequest]) (*connect.Response[apiv1.AssetIdentifier], error) {
+	if req.Msg.Identifier == nil || req.Msg.Identifier.AssetId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("identifier with asset ID is required"))
+	}
+
+	ident := identifierFromProto(req.Msg.Identifier)
+	var err error
+	if ident.Value, err = normalizeIdentifier(ident.Kind, ident.Value); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if ident.Chain, err = normalizeChain(ident.Kind, ident.Chain); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	created, err := h.store.CreateAssetIdentifier(ctx, ident)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(identifierToProto(created)), nil
+}
+
+// DeleteAssetIdentifier deletes an asset identifier by ID.
+func (h *Handler) DeleteAssetIdentifier(ctx context.Context, req *connect.Request[apiv1.DeleteAssetIdentifierRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("identifier ID is required"))
+	}
+
+	if err := h.store.DeleteAssetIdentifier(ctx, req.Msg.Id); err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// ListAssetIdentifiers lists the identifiers of an asset.
+func (h *Handler) ListAssetIdentifiers(ctx context.Context, req *connect.Request[apiv1.ListAssetIdentifiersRequest]) (*connect.Response[apiv1.ListAssetIdentifiersResponse], error) {
+	if req.Msg.AssetId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("asset ID is required"))
+	}
+
+	identifiers, err := h.store.ListAssetIdentifiers(ctx, req.Msg.AssetId)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	resp := &apiv1.ListAssetIdentifiersResponse{Identifiers: make([]*apiv1.AssetIdentifier, len(identifiers))}
+	for i, ident := range identifiers {
+		resp.Identifiers[i] = identifierToProto(ident)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// resolveKinds are tried in order when ResolveAsset is given no kind.
+var resolveKinds = []entity.IdentifierKind{
+	entity.IdentifierKindISIN,
+	entity.IdentifierKindCUSIP,
+	entity.IdentifierKindFIGI,
+	entity.IdentifierKindContract,
+	entity.IdentifierKindCoinGecko,
+	entity.IdentifierKindBinance,
+	entity.IdentifierKindTicker,
+}
+
+// ResolveAsset finds the assets an identifier maps to, falling back to the
+// symbol when no kind is given and no identifier matches.
+func (h *Handler) ResolveAsset(ctx context.Context, req *connect.Request[apiv1.ResolveAssetRequest]) (*connect.Response[apiv1.ResolveAssetResponse], error) {
+	if strings.TrimSpace(req.Msg.Value) == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("value is required"))
+	}
+	kind := entity.IdentifierKind(req.Msg.Kind)
+	kinds := resolveKinds
+	if kind != entity.IdentifierKindUnspecified {
+		kinds = []entity.IdentifierKind{kind}
+	}
+	chain := strings.ToLower(strings.TrimSpace(req.Msg.GetChain()))
+
+	var matches []*apiv1.AssetMatch
+	seen := make(map[string]bool)
+	for _, k := range kinds {
+		value, err := normalizeIdentifier(k, req.Msg.Value)
+		if err != nil {
+			if kind != entity.IdentifierKindUnspecified {
+				return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			}
+			continue
+		}
+		var c string
+		if k == entity.IdentifierKindContract {
+			c = chain
+		}
+		assets, _, err := h.store.ListAssets(ctx, ListAssetsOpts{
+			Identifier: &entity.AssetIdentifier{Kind: k, Value: value, Chain: c},
+			PageSize:   maxResolveMatches,
+		})
+		if err != nil {
+			return nil, toConnectError(err)
+		}
+		for _, a := range assets {
+			if seen[a.ID] || len(matches) == maxResolveMatches {
+				continue
+			}
+			seen[a.ID] = true
+			ident, err := h.matchedIdentifier(ctx, a.ID, k, value, c)
+			if err != nil {
+				return nil, toConnectError(err)
+			}
+			matches = append(matches, &apiv1.AssetMatch{Asset: assetToProto(a), Identifier: ident})
+		}
+	}
+
+	if len(matches) == 0 && kind == entity.IdentifierKindUnspecified {
+		assets, _, err := h.store.ListAssets(ctx, ListAssetsOpts{Symbol: strings.TrimSpace(req.Msg.Value), PageSize: maxResolveMatches})
+		if err != nil {
+			return nil, toConnectError(err)
+		}
+		for _, a := range assets {
+			matches = append(matches, &apiv1.AssetMatch{Asset: assetToProto(a)})
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no asset matches %q", req.Msg.Value))
+	case 1:
+		return connect.NewResponse(&apiv1.ResolveAssetResponse{Asset: matches[0].Asset, Matches: matches}), nil
+	default:
+		return connect.NewResponse(&apiv1.ResolveAssetResponse{Ambiguous: true, Matches: matches}), nil
+	}
+}
+
+// matchedIdentifier finds the identifier of an asset that a resolved value
+// matched, as the chain of a contract may not have been given.
+func (h *Handler) matchedIdentifier(ctx context.Context, assetID string, kind entity.IdentifierKind, value, chain string) (*apiv1.AssetIdentifier, error) {
+	identifiers, err := h.store.ListAssetIdentifiers(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ident := range identifiers {
+		if ident.Kind == kind && ident.Value == value && (chain == "" || ident.Chain == chain) {
+			return identifierToProto(ident), nil
+		}
+	}
+	return nil, nil
+}
+
+func identifierFromProto(p *apiv1.AssetIdentifier) *entity.AssetIdentifier {
+	return &entity.AssetIdentifier{
+		ID:      p.Id,
+		AssetID: p.AssetId,
+		Kind:    entity.IdentifierKind(p.Kind),
+		Value:   p.Value,
+		Chain:   p.Chain,
+	}
+}
+
+func identifierToProto(e *entity.AssetIdentifier) *apiv1.AssetIdentifier {
+	return &apiv1.AssetIdentifier{
+		Id:        e.ID,
+		AssetId:   e.AssetID,
+		Kind:      apiv1.AssetIdentifierKind(e.Kind),
+		Value:     e.Value,
+		Chain:     e.Chain,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+}
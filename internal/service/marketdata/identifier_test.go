@@ -0,0 +1,145 @@
+package marketdata
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNormalizeIdentifier(t *testing.T) {
+	for _, tc := range []struct {
+		kind  entity.IdentifierKind
+		value string
+		want  string // Empty if invalid
+	}{
+		{entity.IdentifierKindCoinGecko, " Wrapped-Bitcoin ", "wrapped-bitcoin"},
+		{entity.IdentifierKindCoinGecko, "wrapped bitcoin", ""},
+		{entity.IdentifierKindBinance, "wbtc", "WBTC"},
+		{entity.IdentifierKindBinance, "BTC/USDT", ""},
+		{entity.IdentifierKindISIN, "us0378331005", "US0378331005"},
+		{entity.IdentifierKindISIN, "US0378331006", ""},
+		{entity.IdentifierKindCUSIP, "037833100", "037833100"},
+		{entity.IdentifierKindCUSIP, "037833101", ""},
+		{entity.IdentifierKindFIGI, "bbg000b9xry4", "BBG000B9XRY4"},
+		{entity.IdentifierKindFIGI, "BBG000B9XRY5", ""},
+		{entity.IdentifierKindContract, "0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599", "0x2260fac5e5542a773aa44fbcfedf7c193bc2c599"},
+		{entity.IdentifierKindContract, "0x2260", ""},
+		{entity.IdentifierKindContract, "3NZ9JMVBmGAqocybic2c7LQCJScmgsAZ6vQqTDzcqmJh", "3NZ9JMVBmGAqocybic2c7LQCJScmgsAZ6vQqTDzcqmJh"},
+		{entity.IdentifierKindTicker, "vod.l", "VOD.L"},
+		{entity.IdentifierKindTicker, "BRK-B", "BRK-B"},
+		{entity.IdentifierKindTicker, "AAPL US", ""},
+		{entity.IdentifierKindUnspecified, "BTC", ""},
+	} {
+		got, err := normalizeIdentifier(tc.kind, tc.value)
+		if tc.want == "" {
+			assert.Error(t, err, tc.value)
+			continue
+		}
+		require.NoError(t, err, tc.value)
+		assert.Equal(t, tc.want, got)
+	}
+}
+
+const wbtcAddress = "0x2260fac5e5542a773aa44fbcfedf7c193bc2c599"
+
+func newIdentifierHandler() (*Handler, *assetStore) {
+	s := &assetStore{
+		assets: map[string]*entity.Asset{
+			"btc":       {ID: "btc", Symbol: "BTC", Name: "Bitcoin"},
+			"wbtc-eth":  {ID: "wbtc-eth", Symbol: "WBTC", Name: "Wrapped Bitcoin"},
+			"wbtc-poly": {ID: "wbtc-poly", Symbol: "WBTC", Name: "Wrapped Bitcoin (Polygon)"},
+			"aapl":      {ID: "aapl", Symbol: "AAPL", Name: "Apple"},
+		},
+		identifiers: []*entity.AssetIdentifier{
+			{ID: "i1", AssetID: "btc", Kind: entity.IdentifierKindCoinGecko, Value: "bitcoin"},
+			{ID: "i2", AssetID: "btc", Kind: entity.IdentifierKindBinance, Value: "BTC"},
+			{ID: "i3", AssetID: "wbtc-eth", Kind: entity.IdentifierKindContract, Chain: "ethereum", Value: wbtcAddress},
+			{ID: "i4", AssetID: "wbtc-poly", Kind: entity.IdentifierKindContract, Chain: "polygon-pos", Value: wbtcAddress},
+			{ID: "i5", AssetID: "aapl", Kind: entity.IdentifierKindISIN, Value: "US0378331005"},
+		},
+	}
+	return NewHandler(s, nil, nil, nil, nil, QualityConfig{}, slog.New(slog.DiscardHandler)), s
+}
+
+func TestCreateAssetIdentifier(t *testing.T) {
+	h, s := newIdentifierHandler()
+
+	resp, err := h.CreateAssetIdentifier(context.Background(), connect.NewRequest(&apiv1.CreateAssetIdentifierRequest{
+		Identifier: &apiv1.AssetIdentifier{AssetId: "aapl", Kind: apiv1.AssetIdentifierKind_ASSET_IDENTIFIER_KIND_FIGI, Value: "bbg000b9xry4"},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "BBG000B9XRY4", resp.Msg.Value)
+	assert.Len(t, s.identifiers, 6)
+
+	for name, tc := range map[string]struct {
+		ident *apiv1.AssetIdentifier
+		code  connect.Code
+	}{
+		"no asset":          {&apiv1.AssetIdentifier{Kind: apiv1.AssetIdentifierKind_ASSET_IDENTIFIER_KIND_BINANCE, Value: "ETH"}, connect.CodeInvalidArgument},
+		"no kind":           {&apiv1.AssetIdentifier{AssetId: "btc", Value: "BTC"}, connect.CodeInvalidArgument},
+		"invalid ISIN":      {&apiv1.AssetIdentifier{AssetId: "aapl", Kind: apiv1.AssetIdentifierKind_ASSET_IDENTIFIER_KIND_ISIN, Value: "US0378331006"}, connect.CodeInvalidArgument},
+		"contract no chain": {&apiv1.AssetIdentifier{AssetId: "wbtc-eth", Kind: apiv1.AssetIdentifierKind_ASSET_IDENTIFIER_KIND_CONTRACT, Value: wbtcAddress}, connect.CodeInvalidArgument},
+		"chain on ticker":   {&apiv1.AssetIdentifier{AssetId: "btc", Kind: apiv1.AssetIdentifierKind_ASSET_IDENTIFIER_KIND_BINANCE, Value: "XBT", Chain: "bitcoin"}, connect.CodeInvalidArgument},
+		"taken":             {&apiv1.AssetIdentifier{AssetId: "wbtc-eth", Kind: apiv1.AssetIdentifierKind_ASSET_IDENTIFIER_KIND_BINANCE, Value: "btc"}, connect.CodeFailedPrecondition},
+		"unknown asset":     {&apiv1.AssetIdentifier{AssetId: "eth", Kind: apiv1.AssetIdentifierKind_ASSET_IDENTIFIER_KIND_BINANCE, Value: "ETH"}, connect.CodeNotFound},
+	} {
+		_, err := h.CreateAssetIdentifier(context.Background(), connect.NewRequest(&apiv1.CreateAssetIdentifierRequest{Identifier: tc.ident}))
+		assert.Equal(t, tc.code, connect.CodeOf(err), name)
+	}
+}
+
+func TestResolveAsset(t *testing.T) {
+	h, _ := newIdentifierHandler()
+	resolve := func(req *apiv1.ResolveAssetRequest) *apiv1.ResolveAssetResponse {
+		t.Helper()
+		resp, err := h.ResolveAsset(context.Background(), connect.NewRequest(req))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+
+	// Without a kind, every kind the value fits is tried.
+	resp := resolve(&apiv1.ResolveAssetRequest{Value: "us0378331005"})
+	assert.False(t, resp.Ambiguous)
+	assert.Equal(t, "aapl", resp.Asset.Id)
+	assert.Equal(t, apiv1.AssetIdentifierKind_ASSET_IDENTIFIER_KIND_ISIN, resp.Matches[0].Identifier.Kind)
+
+	resp = resolve(&apiv1.ResolveAssetRequest{Kind: apiv1.AssetIdentifierKind_ASSET_IDENTIFIER_KIND_BINANCE, Value: "btc"})
+	assert.Equal(t, "btc", resp.Asset.Id)
+
+	// A bridged token has the same address on several chains.
+	resp = resolve(&apiv1.ResolveAssetRequest{Value: "0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599"})
+	assert.True(t, resp.Ambiguous)
+	assert.Nil(t, resp.Asset)
+	require.Len(t, resp.Matches, 2)
+	assert.Equal(t, "ethereum", resp.Matches[0].Identifier.Chain)
+	assert.Equal(t, "polygon-pos", resp.Matches[1].Identifier.Chain)
+
+	resp = resolve(&apiv1.ResolveAssetRequest{Value: wbtcAddress, Chain: proto.String("Polygon-POS")})
+	assert.Equal(t, "wbtc-poly", resp.Asset.Id)
+
+	// With no identifier matching, the symbol is tried.
+	resp = resolve(&apiv1.ResolveAssetRequest{Value: "wbtc"})
+	assert.True(t, resp.Ambiguous)
+	require.Len(t, resp.Matches, 2)
+	assert.Nil(t, resp.Matches[0].Identifier)
+
+	for name, tc := range map[string]struct {
+		req  *apiv1.ResolveAssetRequest
+		code connect.Code
+	}{
+		"no value":          {&apiv1.ResolveAssetRequest{}, connect.CodeInvalidArgument},
+		"invalid for kind":  {&apiv1.ResolveAssetRequest{Kind: apiv1.AssetIdentifierKind_ASSET_IDENTIFIER_KIND_ISIN, Value: "BTC"}, connect.CodeInvalidArgument},
+		"unknown":           {&apiv1.ResolveAssetRequest{Value: "DOGE"}, connect.CodeNotFound},
+		"no symbol by kind": {&apiv1.ResolveAssetRequest{Kind: apiv1.AssetIdentifierKind_ASSET_IDENTIFIER_KIND_BINANCE, Value: "AAPL"}, connect.CodeNotFound},
+	} {
+		_, err := h.ResolveAsset(context.Background(), connect.NewRequest(tc.req))
+		assert.Equal(t, tc.code, connect.CodeOf(err), name)
+	}
+}
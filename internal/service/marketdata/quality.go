@@ -0,0 +1,348 @@
+package marketdata
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultQualityPeriod is how far back GetPriceDataQuality looks unless
+// told otherwise.
+const defaultQualityPeriod = 30 * 24 * time.Hour
+
+// QualityConfig sets the checks prices go through as they are stored, and
+// when GetPriceDataQuality calls a source stale. A zero limit disables its
+// check.
+type QualityConfig struct {
+	// MaxJumpPercent is how far a price may move from the last
+	// unquarantined price of its pair. A price moving further passes only if
+	// the previous price of its source was quarantined at about the same
+	// level, which confirms the move.
+	MaxJumpPercent float64 `koanf:"maxJumpPercent"`
+	// MaxDeviationPercent is how far a price may be from the median of the
+	// last prices other sources stored for its pair in DeviationWindow
+	// before it.
+	MaxDeviationPercent float64       `koanf:"maxDeviationPercent"`
+	DeviationWindow     time.Duration `koanf:"deviationWindow"`
+	// StaleAfter is how long a source may go without a price of a pair.
+	StaleAfter time.Duration `koanf:"staleAfter"`
+}
+
+// Validate checks that no limit is negative and that deviations have a
+// window to be looked for in.
+func (c QualityConfig) Validate() error {
+	if c.MaxJumpPercent < 0 || c.MaxDeviationPercent < 0 || c.DeviationWindow < 0 || c.StaleAfter < 0 {
+		return errors.New("price quality limits must not be negative")
+	}
+	if c.MaxDeviationPercent > 0 && c.DeviationWindow == 0 {
+		return errors.New("price deviation checks need a window")
+	}
+	return nil
+}
+
+// priceRefs are what the next price of a pair from a source is checked
+// against.
+type priceRefs struct {
+	clean   *entity.StoredPrice // Last unquarantined price of the pair
+	suspect *entity.StoredPrice // Price of the source quarantined since clean
+}
+
+// checkPrices runs the ingestion checks on prices about to be stored and
+// sets Quarantine on those failing them. Each price is checked against the
+// prices stored before it and those of its source earlier in the batch, so
+// batches go oldest first. It returns how many prices were quarantined.
+func (h *Handler) checkPrices(ctx context.Context, prices []*entity.StoredPrice) (int, error) {
+	type key struct{ asset, base, source string }
+	refs := make(map[key]*priceRefs)
+	now := time.Now()
+	var quarantined int
+	for _, p := range prices {
+		p.Quarantine = ""
+		if p.AssetID == "" || p.BaseAssetID == "" {
+			continue // The store rejects it
+		}
+		if p.Timestamp.IsZero() {
+			p.Timestamp = now
+		}
+		k := key{p.AssetID, p.BaseAssetID, p.SourceID}
+		r, ok := refs[k]
+		if !ok {
+			var err error
+			if r, err = h.priceRefs(ctx, p); err != nil {
+				return 0, err
+			}
+			refs[k] = r
+		}
+
+		if h.jumped(p, r) {
+			p.Quarantine = entity.PriceQuarantineJump
+		} else {
+			deviates, err := h.deviates(ctx, p)
+			if err != nil {
+				return 0, err
+			}
+			if deviates {
+				p.Quarantine = entity.PriceQuarantineDeviation
+			}
+		}
+
+		if p.Quarantine != "" {
+			h.log.Warn("Quarantined price", "asset_id", p.AssetID, "base_asset_id", p.BaseAssetID,
+				"source", p.SourceID, "timestamp", p.Timestamp, "reason", p.Quarantine)
+			r.suspect = p
+			quarantined++
+			continue
+		}
+		if r.clean == nil || !p.Timestamp.Before(r.clean.Timestamp) {
+			r.clean, r.suspect = p, nil
+		}
+	}
+	return quarantined, nil
+}
+
+// priceRefs loads the stored prices a new price is checked against for
+// jumps.
+func (h *Handler) priceRefs(ctx context.Context, p *entity.StoredPrice) (*priceRefs, error) {
+	r := &priceRefs{}
+	if h.quality.MaxJumpPercent <= 0 {
+		return r, nil
+	}
+	clean, err := h.store.GetPriceAt(ctx, p.AssetID, p.BaseAssetID, p.Timestamp)
+	if errors.Is(err, store.ErrNotFound) || errors.Is(err, store.ErrInvalidArgument) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.clean = clean
+
+	suspects, err := h.store.ListLatestPrices(ctx, LatestPricesOpts{
+		AssetID:     p.AssetID,
+		BaseAssetID: p.BaseAssetID,
+		From:        clean.Timestamp,
+		To:          p.Timestamp,
+		Quarantined: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if i := slices.IndexFunc(suspects, func(s *entity.StoredPrice) bool { return s.SourceID == p.SourceID }); i >= 0 {
+		r.suspect = suspects[i]
+	}
+	return r, nil
+}
+
+// jumped reports whether p moved too far from the last clean price of its
+// pair, without the last price of its source confirming the move.
+func (h *Handler) jumped(p *entity.StoredPrice, r *priceRefs) bool {
+	limit := h.quality.MaxJumpPercent
+	if limit <= 0 || r.clean == nil || withinPercent(p, storedPriceValue(r.clean), limit) {
+		return false
+	}
+	return r.suspect == nil || !withinPercent(p, storedPriceValue(r.suspect), limit)
+}
+
+// deviates reports whether p is too far from the median of the last clean
+// prices other sources stored for its pair.
+func (h *Handler) deviates(ctx context.Context, p *entity.StoredPrice) (bool, error) {
+	limit := h.quality.MaxDeviationPercent
+	if limit <= 0 {
+		return false, nil
+	}
+	latest, err := h.store.ListLatestPrices(ctx, LatestPricesOpts{
+		AssetID:     p.AssetID,
+		BaseAssetID: p.BaseAssetID,
+		From:        p.Timestamp.Add(-h.quality.DeviationWindow),
+		To:          p.Timestamp,
+	})
+	if errors.Is(err, store.ErrNotFound) || errors.Is(err, store.ErrInvalidArgument) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	var others []decimal.Decimal
+	for _, other := range latest {
+		if other.SourceID != p.SourceID {
+			others = append(others, storedPriceValue(other))
+		}
+	}
+	if len(others) == 0 {
+		return false, nil
+	}
+	return !withinPercent(p, median(others), limit), nil
+}
+
+// withinPercent reports whether p is within limit percent of ref. Any
+// price is within range of a zero one.
+func withinPercent(p *entity.StoredPrice, ref decimal.Decimal, limit float64) bool {
+	if ref.IsZero() {
+		return true
+	}
+	change := storedPriceValue(p).Sub(ref).Div(ref).Abs().Mul(decimal.NewFromInt(100))
+	return change.LessThanOrEqual(decimal.NewFromFloat(limit))
+}
+
+func storedPriceValue(p *entity.StoredPrice) decimal.Decimal {
+	return decimal.New(p.Last, -int32(p.Decimals))
+}
+
+func median(values []decimal.Decimal) decimal.Decimal {
+	sorted := slices.SortedFunc(slices.Values(values), decimal.Decimal.Cmp)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return sorted[mid-1].Add(sorted[mid]).Div(decimal.NewFromInt(2))
+}
+
+// GetPriceDataQuality reports the gaps, quarantined prices and stale
+// sources of each asset pair and interval with prices in the range.
+func (h *Handler) GetPriceDataQuality(ctx context.Context, req *connect.Request[apiv1.GetPriceDataQualityRequest]) (*connect.Response[apiv1.GetPriceDataQualityResponse], error) {
+	to := time.Now()
+	if req.Msg.To != nil {
+		to = req.Msg.To.AsTime()
+	}
+	from := to.Add(-defaultQualityPeriod)
+	if req.Msg.From != nil {
+		from = req.Msg.From.AsTime()
+	}
+	if !from.Before(to) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("from must be before to"))
+	}
+	opts := PriceQualityOpts{
+		AssetID:     req.Msg.GetAssetId(),
+		BaseAssetID: req.Msg.GetBaseAssetId(),
+		Interval:    req.Msg.GetInterval(),
+		From:        from,
+		To:          to,
+	}
+
+	stats, err := h.store.ListPriceSourceStats(ctx, opts)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	resp := &apiv1.GetPriceDataQualityResponse{From: timestamppb.New(from), To: timestamppb.New(to)}
+	type seriesKey struct{ asset, base, interval string }
+	series := make(map[seriesKey]*apiv1.PriceSeriesQuality)
+	var intervals []string
+	for _, st := range stats {
+		k := seriesKey{st.AssetID, st.BaseAssetID, st.Interval}
+		s, ok := series[k]
+		if !ok {
+			s = &apiv1.PriceSeriesQuality{AssetId: st.AssetID, BaseAssetId: st.BaseAssetID, Interval: st.Interval}
+			series[k] = s
+			resp.Series = append(resp.Series, s)
+			if !slices.Contains(intervals, st.Interval) {
+				intervals = append(intervals, st.Interval)
+			}
+		}
+		source := &apiv1.PriceSourceQuality{
+			SourceId:         st.SourceID,
+			PriceCount:       int32(st.Count),
+			QuarantinedCount: int32(st.Quarantined),
+			Stale:            h.quality.StaleAfter > 0 && to.Sub(st.Last) > h.quality.StaleAfter,
+		}
+		if !st.Last.IsZero() {
+			source.LastPriceTime = timestamppb.New(st.Last)
+		}
+		s.Sources = append(s.Sources, source)
+		s.PriceCount += source.PriceCount
+		s.QuarantinedCount += source.QuarantinedCount
+		resp.QuarantinedCount += source.QuarantinedCount
+		if source.Stale {
+			resp.StaleSourceCount++
+		}
+	}
+
+	types := make(map[string]entity.AssetType)
+	for _, interval := range intervals {
+		step, ok := intervalDuration(interval)
+		if !ok {
+			continue
+		}
+		opts.Interval = interval
+		opts.MinSpacing = step + step/2
+		gaps, err := h.store.ListPriceGaps(ctx, opts)
+		if err != nil {
+			return nil, toConnectError(err)
+		}
+		for _, gap := range gaps {
+			s, ok := series[seriesKey{gap.AssetID, gap.BaseAssetID, gap.Interval}]
+			if !ok {
+				continue
+			}
+			assetType, ok := types[gap.AssetID]
+			if !ok {
+				asset, err := h.store.GetAsset(ctx, gap.AssetID)
+				if err != nil && !errors.Is(err, store.ErrNotFound) {
+					return nil, toConnectError(err)
+				}
+				if asset != nil {
+					assetType = asset.Type
+				}
+				types[gap.AssetID] = assetType
+			}
+			missing := missingPrices(gap.From, gap.To, step, assetType == entity.AssetTypeCryptocurrency)
+			if missing == 0 {
+				continue
+			}
+			s.Gaps = append(s.Gaps, &apiv1.PriceGap{
+				From:    timestamppb.New(gap.From),
+				To:      timestamppb.New(gap.To),
+				Missing: int32(missing),
+			})
+			resp.GapCount++
+		}
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// intervalDuration parses price intervals such as "15m", "4h", "1d" and
+// "1w". Intervals without a fixed length, such as "latest", have none.
+func intervalDuration(interval string) (time.Duration, bool) {
+	if len(interval) < 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(interval[:len(interval)-1])
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	unit := map[byte]time.Duration{'m': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}[interval[len(interval)-1]]
+	if unit == 0 {
+		return 0, false
+	}
+	return time.Duration(n) * unit, true
+}
+
+// missingPrices returns how many prices step apart are expected strictly
+// between from and to. Assets that do not trade around the clock are only
+// expected to have daily prices on weekdays, and nothing is known of their
+// trading hours, so shorter steps expect none.
+func missingPrices(from, to time.Time, step time.Duration, aroundTheClock bool) int {
+	if aroundTheClock || step > 24*time.Hour {
+		return max(int(math.Round(float64(to.Sub(from))/float64(step)))-1, 0)
+	}
+	if step < 24*time.Hour {
+		return 0
+	}
+	var missing int
+	last := to.UTC().Truncate(24 * time.Hour)
+	for day := from.UTC().Truncate(24*time.Hour).AddDate(0, 0, 1); day.Before(last); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			missing++
+		}
+	}
+	return missing
+}
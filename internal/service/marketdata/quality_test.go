@@ -0,0 +1,234 @@
+package marketdata
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// qualityStore keeps prices in memory and returns canned quality stats.
+type qualityStore struct {
+	*assetStore
+	prices        []*entity.StoredPrice
+	stats         []*entity.PriceSourceStats
+	gaps          []*entity.PriceGap
+	gapIntervals  []string
+	gapMinSpacing []time.Duration
+}
+
+func (s *qualityStore) CreatePrice(_ context.Context, p *entity.StoredPrice) (*entity.StoredPrice, error) {
+	s.prices = append(s.prices, p)
+	return p, nil
+}
+
+func (s *qualityStore) CreatePrices(_ context.Context, prices []*entity.StoredPrice) (int, error) {
+	s.prices = append(s.prices, prices...)
+	return len(prices), nil
+}
+
+func (s *qualityStore) GetPriceAt(_ context.Context, assetID, baseAssetID string, at time.Time) (*entity.StoredPrice, error) {
+	var found *entity.StoredPrice
+	for _, p := range s.prices {
+		if p.AssetID == assetID && p.BaseAssetID == baseAssetID && p.Quarantine == "" && !p.Timestamp.After(at) &&
+			(found == nil || p.Timestamp.After(found.Timestamp)) {
+			found = p
+		}
+	}
+	if found == nil {
+		return nil, store.ErrNotFound
+	}
+	return found, nil
+}
+
+func (s *qualityStore) ListLatestPrices(_ context.Context, opts LatestPricesOpts) ([]*entity.StoredPrice, error) {
+	latest := make(map[string]*entity.StoredPrice)
+	for _, p := range s.prices {
+		if p.AssetID != opts.AssetID || p.BaseAssetID != opts.BaseAssetID || (p.Quarantine != "") != opts.Quarantined ||
+			p.Timestamp.Before(opts.From) || p.Timestamp.After(opts.To) {
+			continue
+		}
+		if l, ok := latest[p.SourceID]; !ok || p.Timestamp.After(l.Timestamp) {
+			latest[p.SourceID] = p
+		}
+	}
+	return slices.SortedFunc(func(yield func(*entity.StoredPrice) bool) {
+		for _, p := range latest {
+			if !yield(p) {
+				return
+			}
+		}
+	}, func(a, b *entity.StoredPrice) int { return cmp.Compare(a.SourceID, b.SourceID) }), nil
+}
+
+func (s *qualityStore) ListPriceSourceStats(context.Context, PriceQualityOpts) ([]*entity.PriceSourceStats, error) {
+	return s.stats, nil
+}
+
+func (s *qualityStore) ListPriceGaps(_ context.Context, opts PriceQualityOpts) ([]*entity.PriceGap, error) {
+	s.gapIntervals = append(s.gapIntervals, opts.Interval)
+	s.gapMinSpacing = append(s.gapMinSpacing, opts.MinSpacing)
+	var gaps []*entity.PriceGap
+	for _, g := range s.gaps {
+		if g.Interval == opts.Interval {
+			gaps = append(gaps, g)
+		}
+	}
+	return gaps, nil
+}
+
+func TestCheckPrices(t *testing.T) {
+	t0 := time.Date(2025, time.April, 14, 12, 0, 0, 0, time.UTC)
+	price := func(asset, source string, last int64, hours int) *entity.StoredPrice {
+		return &entity.StoredPrice{AssetID: asset, BaseAssetID: "usd", SourceID: source, Interval: IntervalLatest,
+			Decimals: 2, Last: last * 100, Timestamp: t0.Add(time.Duration(hours) * time.Hour)}
+	}
+	s := &qualityStore{assetStore: &assetStore{}, prices: []*entity.StoredPrice{
+		price("btc", "gecko", 100, 0),
+		price("btc", "kraken", 101, 1),
+		price("eth", "gecko", 100, 0),
+	}}
+	h := NewHandler(s, nil, nil, nil, nil, QualityConfig{
+		MaxJumpPercent:      50,
+		MaxDeviationPercent: 10,
+		DeviationWindow:     24 * time.Hour,
+	}, slog.New(slog.DiscardHandler))
+	toProto := func(prices ...*entity.StoredPrice) []*apiv1.Price {
+		var protos []*apiv1.Price
+		for _, p := range prices {
+			protos = append(protos, priceToProto(p))
+		}
+		return protos
+	}
+
+	// An extra zero is quarantined and the next price is checked against
+	// the one before it.
+	resp, err := h.CreatePrices(context.Background(), connect.NewRequest(&apiv1.CreatePricesRequest{Prices: toProto(
+		price("btc", "gecko", 102, 2),
+		price("btc", "gecko", 1020, 3),
+		price("btc", "gecko", 103, 4),
+	)}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), resp.Msg.CreatedCount)
+	assert.Equal(t, int32(1), resp.Msg.QuarantinedCount)
+	assert.Equal(t, []string{"", entity.PriceQuarantineJump, ""}, []string{s.prices[3].Quarantine, s.prices[4].Quarantine, s.prices[5].Quarantine})
+
+	// Within the jump limit but far from Kraken.
+	created, err := h.CreatePrice(context.Background(), connect.NewRequest(&apiv1.CreatePriceRequest{Price: priceToProto(price("btc", "gecko", 80, 5))}))
+	require.NoError(t, err)
+	assert.Equal(t, entity.PriceQuarantineDeviation, created.Msg.Quarantine)
+	created, err = h.CreatePrice(context.Background(), connect.NewRequest(&apiv1.CreatePriceRequest{Price: priceToProto(price("btc", "gecko", 95, 6))}))
+	require.NoError(t, err)
+	assert.Empty(t, created.Msg.Quarantine)
+
+	// Ether doubles: the first price is quarantined, the next confirms the
+	// move.
+	for _, tc := range []struct {
+		last int64
+		want string
+	}{
+		{200, entity.PriceQuarantineJump},
+		{205, ""},
+		{210, ""},
+		{100, entity.PriceQuarantineJump},
+	} {
+		created, err := h.CreatePrice(context.Background(), connect.NewRequest(&apiv1.CreatePriceRequest{Price: priceToProto(price("eth", "gecko", tc.last, len(s.prices)))}))
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, created.Msg.Quarantine, "eth at %d", tc.last)
+	}
+
+	// Nothing is quarantined with the checks off.
+	h.quality = QualityConfig{}
+	created, err = h.CreatePrice(context.Background(), connect.NewRequest(&apiv1.CreatePriceRequest{Price: priceToProto(price("btc", "gecko", 5000, 30))}))
+	require.NoError(t, err)
+	assert.Empty(t, created.Msg.Quarantine)
+}
+
+func TestGetPriceDataQuality(t *testing.T) {
+	to := time.Date(2025, time.April, 30, 12, 0, 0, 0, time.UTC) // A Wednesday
+	day := func(d int) time.Time { return time.Date(2025, time.April, d, 20, 0, 0, 0, time.UTC) }
+	s := &qualityStore{
+		assetStore: &assetStore{assets: map[string]*entity.Asset{
+			"btc":  {ID: "btc", Type: entity.AssetTypeCryptocurrency},
+			"aapl": {ID: "aapl", Type: entity.AssetTypeStock},
+		}},
+		stats: []*entity.PriceSourceStats{
+			{AssetID: "aapl", BaseAssetID: "usd", Interval: IntervalDaily, SourceID: "yahoo", Count: 18, Last: day(29)},
+			{AssetID: "btc", BaseAssetID: "usd", Interval: IntervalDaily, SourceID: "gecko", Count: 28, Quarantined: 1, Last: day(29)},
+			{AssetID: "btc", BaseAssetID: "usd", Interval: IntervalDaily, SourceID: "kraken", Count: 5, Last: day(20)},
+			{AssetID: "btc", BaseAssetID: "usd", Interval: IntervalLatest, SourceID: "gecko", Count: 100, Quarantined: 2, Last: to.Add(-time.Minute)},
+		},
+		gaps: []*entity.PriceGap{
+			// A weekend, then a Monday and a Tuesday missing.
+			{AssetID: "aapl", BaseAssetID: "usd", Interval: IntervalDaily, From: day(4), To: day(7)},
+			{AssetID: "aapl", BaseAssetID: "usd", Interval: IntervalDaily, From: day(11), To: day(16)},
+			{AssetID: "btc", BaseAssetID: "usd", Interval: IntervalDaily, From: day(5), To: day(8)},
+		},
+	}
+	h := NewHandler(s, nil, nil, nil, nil, QualityConfig{StaleAfter: 96 * time.Hour}, slog.New(slog.DiscardHandler))
+
+	resp, err := h.GetPriceDataQuality(context.Background(), connect.NewRequest(&apiv1.GetPriceDataQualityRequest{To: timestamppb.New(to)}))
+	require.NoError(t, err)
+	report := resp.Msg
+	assert.Equal(t, to.AddDate(0, 0, -30), report.From.AsTime())
+	assert.Equal(t, []string{IntervalDaily}, s.gapIntervals)
+	assert.Equal(t, []time.Duration{36 * time.Hour}, s.gapMinSpacing)
+	assert.Equal(t, int32(2), report.GapCount)
+	assert.Equal(t, int32(3), report.QuarantinedCount)
+	assert.Equal(t, int32(1), report.StaleSourceCount)
+
+	require.Len(t, report.Series, 3)
+	aapl := report.Series[0]
+	assert.Equal(t, "aapl", aapl.AssetId)
+	require.Len(t, aapl.Gaps, 1)
+	assert.Equal(t, day(11), aapl.Gaps[0].From.AsTime())
+	assert.Equal(t, int32(2), aapl.Gaps[0].Missing)
+
+	btc := report.Series[1]
+	assert.Equal(t, IntervalDaily, btc.Interval)
+	assert.Equal(t, int32(33), btc.PriceCount)
+	assert.Equal(t, int32(1), btc.QuarantinedCount)
+	require.Len(t, btc.Gaps, 1)
+	assert.Equal(t, int32(2), btc.Gaps[0].Missing)
+	require.Len(t, btc.Sources, 2)
+	assert.False(t, btc.Sources[0].Stale)
+	assert.True(t, btc.Sources[1].Stale)
+	assert.Equal(t, day(20), btc.Sources[1].LastPriceTime.AsTime())
+
+	latest := report.Series[2]
+	assert.Equal(t, IntervalLatest, latest.Interval)
+	assert.Empty(t, latest.Gaps)
+
+	_, err = h.GetPriceDataQuality(context.Background(), connect.NewRequest(&apiv1.GetPriceDataQualityRequest{
+		From: timestamppb.New(to),
+		To:   timestamppb.New(to.Add(-time.Hour)),
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestIntervalDuration(t *testing.T) {
+	for interval, want := range map[string]time.Duration{
+		"1m":     time.Minute,
+		"15m":    15 * time.Minute,
+		"4h":     4 * time.Hour,
+		"1d":     24 * time.Hour,
+		"1w":     7 * 24 * time.Hour,
+		"latest": 0,
+		"tick":   0,
+		"0d":     0,
+	} {
+		got, ok := intervalDuration(interval)
+		assert.Equal(t, want, got, interval)
+		assert.Equal(t, want != 0, ok, interval)
+	}
+}
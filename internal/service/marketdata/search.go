@@ -0,0 +1,216 @@
+package marketdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+)
+
+const (
+	maxSearchPageSize     = 100
+	maxSearchQuery        = 100 // Bytes
+	maxExternalResults    = 10  // Per searcher
+	defaultSearchPageSize = 20
+)
+
+// ExternalAsset is an asset a provider knows.
+type ExternalAsset struct {
+	Source        string
+	ID            string // The provider's ID
+	Symbol        string
+	Name          string
+	Type          entity.AssetType
+	MarketCapRank int // Zero if unranked
+	ImageURL      string
+}
+
+// AssetSearcher finds assets a provider knows, to add those not stored yet.
+type AssetSearcher interface {
+	Source() string
+	// IdentifierKind is the kind of identifier the provider's IDs are.
+	IdentifierKind() entity.IdentifierKind
+	// SearchAssets returns the provider's matches for query, best first.
+	SearchAssets(ctx context.Context, query string) ([]*ExternalAsset, error)
+	// ExternalAsset looks an asset up by the provider's ID, or returns
+	// store.ErrNotFound.
+	ExternalAsset(ctx context.Context, id string) (*ExternalAsset, error)
+}
+
+// SearchAssets finds stored assets for a query, and asks external providers
+// if none match and the caller wants them.
+func (h *Handler) SearchAssets(ctx context.Context, req *connect.Request[apiv1.SearchAssetsRequest]) (*connect.Response[apiv1.SearchAssetsResponse], error) {
+	query := strings.TrimSpace(req.Msg.Query)
+	if query == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("query is required"))
+	}
+	if len(query) > maxSearchQuery {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("query is longer than %d bytes", maxSearchQuery))
+	}
+
+	opts := SearchAssetsOpts{
+		Query: query,
+		Type:  entity.AssetType(req.Msg.GetType()),
+		Tags:  req.Msg.Tags,
+		// Providers are asked for what is not stored; otherwise the query
+		// may be misspelled.
+		Similar:   !req.Msg.IncludeExternal,
+		PageSize:  defaultSearchPageSize,
+		PageToken: req.Msg.GetPageToken(),
+	}
+	if req.Msg.PageSize != nil && *req.Msg.PageSize > 0 {
+		opts.PageSize = min(int(*req.Msg.PageSize), maxSearchPageSize)
+	}
+	assets, nextPageToken, err := h.store.SearchAssets(ctx, opts)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	resp := &apiv1.SearchAssetsResponse{
+		Assets:        make([]*apiv1.Asset, len(assets)),
+		NextPageToken: nextPageToken,
+	}
+	for i, a := range assets {
+		resp.Assets[i] = assetToProto(a)
+	}
+	// External assets have no tags to filter by.
+	if len(assets) == 0 && req.Msg.IncludeExternal && opts.PageToken == "" && len(opts.Tags) == 0 {
+		if resp.ExternalAssets, err = h.searchExternal(ctx, query, opts.Type); err != nil {
+			return nil, toConnectError(err)
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// searchExternal asks every searcher for query. A failing searcher is
+// skipped, so that type-ahead keeps working while a provider is down.
+func (h *Handler) searchExternal(ctx context.Context, query string, assetType entity.AssetType) ([]*apiv1.ExternalAsset, error) {
+	var results []*apiv1.ExternalAsset
+	for _, searcher := range h.searchers {
+		found, err := searcher.SearchAssets(ctx, query)
+		if err != nil {
+			h.log.Warn("Asset search failed", "source", searcher.Source(), slog.Any("error", err))
+			continue
+		}
+		var n int
+		for _, ext := range found {
+			if n == maxExternalResults {
+				break
+			}
+			if assetType != entity.AssetTypeUnspecified && ext.Type != assetType {
+				continue
+			}
+			value, err := normalizeIdentifier(searcher.IdentifierKind(), ext.ID)
+			if err != nil {
+				continue
+			}
+			n++
+			result := externalAssetToProto(ext)
+			existing, err := h.assetByIdentifier(ctx, searcher.IdentifierKind(), value)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				result.AssetId = &existing.ID
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// assetByIdentifier returns the stored asset an identifier maps to, or nil.
+func (h *Handler) assetByIdentifier(ctx context.Context, kind entity.IdentifierKind, value string) (*entity.Asset, error) {
+	assets, _, err := h.store.ListAssets(ctx, ListAssetsOpts{
+		Identifier: &entity.AssetIdentifier{Kind: kind, Value: value},
+		PageSize:   1,
+	})
+	if err != nil || len(assets) == 0 {
+		return nil, err
+	}
+	return assets[0], nil
+}
+
+// ImportExternalAsset creates an asset from what a provider knows about it
+// and maps it to the provider's ID.
+func (h *Handler) ImportExternalAsset(ctx context.Context, req *connect.Request[apiv1.ImportExternalAssetRequest]) (*connect.Response[apiv1.Asset], error) {
+	if req.Msg.Source == "" || req.Msg.ExternalId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("source and external ID are required"))
+	}
+	var searcher AssetSearcher
+	for _, s := range h.searchers {
+		if s.Source() == req.Msg.Source {
+			searcher = s
+		}
+	}
+	if searcher == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown asset source %q", req.Msg.Source))
+	}
+
+	kind := searcher.IdentifierKind()
+	value, err := normalizeIdentifier(kind, req.Msg.ExternalId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	existing, err := h.assetByIdentifier(ctx, kind, value)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	if existing != nil {
+		return connect.NewResponse(assetToProto(existing)), nil
+	}
+
+	ext, err := searcher.ExternalAsset(ctx, value)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	now := time.Now()
+	asset := &entity.Asset{Name: ext.Name, Symbol: ext.Symbol, Type: ext.Type, Metadata: map[string]entity.AssetMetadata{}}
+	if ext.ImageURL != "" {
+		asset.Metadata[entity.MetadataLogoURL] = entity.AssetMetadata{Value: ext.ImageURL, Source: ext.Source, UpdatedAt: now}
+	}
+	if ext.MarketCapRank > 0 {
+		asset.Metadata[entity.MetadataMarketCapRank] = entity.AssetMetadata{Value: strconv.Itoa(ext.MarketCapRank), Source: ext.Source, UpdatedAt: now}
+	}
+	created, err := h.store.CreateAsset(ctx, asset)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	ident := &entity.AssetIdentifier{AssetID: created.ID, Kind: kind, Value: value}
+	if _, err := h.store.CreateAssetIdentifier(ctx, ident); err != nil {
+		// A concurrent import mapped the ID first; drop the duplicate.
+		if err := h.store.DeleteAsset(ctx, created.ID); err != nil {
+			h.log.Error("Failed to delete unmapped imported asset", "asset_id", created.ID, slog.Any("error", err))
+		}
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(assetToProto(created)), nil
+}
+
+func externalAssetToProto(e *ExternalAsset) *apiv1.ExternalAsset {
+	p := &apiv1.ExternalAsset{
+		Source:     e.Source,
+		ExternalId: e.ID,
+		Symbol:     e.Symbol,
+		Name:       e.Name,
+		Type:       apiv1.AssetType(e.Type),
+		ImageUrl:   e.ImageURL,
+	}
+	if e.MarketCapRank > 0 {
+		rank := int32(e.MarketCapRank)
+		p.MarketCapRank = &rank
+	}
+	return p
+}
@@ -0,0 +1,127 @@
+package marketdata
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newSearchHandler() (*Handler, *assetStore) {
+	s := &assetStore{
+		assets: map[string]*entity.Asset{
+			"wbtc": {ID: "wbtc", Symbol: "WBTC", Name: "Wrapped Bitcoin", Type: entity.AssetTypeCryptocurrency},
+		},
+		identifiers: []*entity.AssetIdentifier{
+			{AssetID: "wbtc", Kind: entity.IdentifierKindCoinGecko, Value: "wrapped-bitcoin"},
+		},
+	}
+	gecko := &CoinGeckoProvider{store: s, client: fakeCoinGecko{
+		"wrapped-bitcoin": {ID: "wrapped-bitcoin", Symbol: "WBTC", Name: "Wrapped Bitcoin", MarketCapRank: 15},
+		"wrapped-bitcoin-sollet": {ID: "wrapped-bitcoin-sollet", Symbol: "SOBTC", Name: "Wrapped Bitcoin (Sollet)",
+			ImageURL: "https://img/sobtc.png", MarketCapRank: 900},
+	}}
+	return NewHandler(s, nil, nil, []AssetSearcher{gecko}, nil, QualityConfig{}, slog.New(slog.DiscardHandler)), s
+}
+
+func TestSearchAssets(t *testing.T) {
+	h, s := newSearchHandler()
+	s.found = []*entity.Asset{s.assets["wbtc"]}
+
+	resp, err := h.SearchAssets(context.Background(), connect.NewRequest(&apiv1.SearchAssetsRequest{
+		Query:           " wbtc ",
+		Type:            apiv1.AssetType_ASSET_TYPE_CRYPTOCURRENCY.Enum(),
+		Tags:            []string{"defi"},
+		PageSize:        proto.Int32(500),
+		IncludeExternal: true,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, SearchAssetsOpts{Query: "wbtc", Type: entity.AssetTypeCryptocurrency, Tags: []string{"defi"}, PageSize: maxSearchPageSize}, s.searchOpts)
+	require.Len(t, resp.Msg.Assets, 1)
+	assert.Empty(t, resp.Msg.ExternalAssets)
+
+	// Nothing stored matches: providers are asked, and coins already
+	// imported point at their asset.
+	s.found = nil
+	resp, err = h.SearchAssets(context.Background(), connect.NewRequest(&apiv1.SearchAssetsRequest{Query: "wrapped", IncludeExternal: true}))
+	require.NoError(t, err)
+	assert.Equal(t, defaultSearchPageSize, s.searchOpts.PageSize)
+	assert.False(t, s.searchOpts.Similar)
+	require.Len(t, resp.Msg.ExternalAssets, 2)
+	assert.Equal(t, "wrapped-bitcoin", resp.Msg.ExternalAssets[0].ExternalId)
+	assert.Equal(t, "wbtc", resp.Msg.ExternalAssets[0].GetAssetId())
+	sollet := resp.Msg.ExternalAssets[1]
+	assert.Nil(t, sollet.AssetId)
+	assert.Equal(t, SourceCoinGecko, sollet.Source)
+	assert.Equal(t, apiv1.AssetType_ASSET_TYPE_CRYPTOCURRENCY, sollet.Type)
+	assert.EqualValues(t, 900, sollet.GetMarketCapRank())
+
+	for name, req := range map[string]*apiv1.SearchAssetsRequest{
+		"not asked":     {Query: "wrapped"},
+		"other type":    {Query: "wrapped", Type: apiv1.AssetType_ASSET_TYPE_STOCK.Enum(), IncludeExternal: true},
+		"tagged":        {Query: "wrapped", Tags: []string{"defi"}, IncludeExternal: true},
+		"provider down": {Query: "down", IncludeExternal: true},
+	} {
+		resp, err := h.SearchAssets(context.Background(), connect.NewRequest(req))
+		require.NoError(t, err, name)
+		assert.Empty(t, resp.Msg.ExternalAssets, name)
+	}
+
+	for name, query := range map[string]string{"empty": " ", "too long": strings.Repeat("a", maxSearchQuery+1)} {
+		_, err := h.SearchAssets(context.Background(), connect.NewRequest(&apiv1.SearchAssetsRequest{Query: query}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), name)
+	}
+}
+
+func TestImportExternalAsset(t *testing.T) {
+	h, s := newSearchHandler()
+	importAsset := func(source, id string) (*apiv1.Asset, error) {
+		resp, err := h.ImportExternalAsset(context.Background(), connect.NewRequest(&apiv1.ImportExternalAssetRequest{Source: source, ExternalId: id}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	asset, err := importAsset(SourceCoinGecko, "Wrapped-Bitcoin-Sollet")
+	require.NoError(t, err)
+	assert.Equal(t, "SOBTC", asset.GetSymbol())
+	assert.Equal(t, "Wrapped Bitcoin (Sollet)", asset.Name)
+	assert.Equal(t, apiv1.AssetType_ASSET_TYPE_CRYPTOCURRENCY, asset.Type)
+	assert.Equal(t, "https://img/sobtc.png", asset.Metadata[entity.MetadataLogoURL].Value)
+	assert.Equal(t, SourceCoinGecko, asset.Metadata[entity.MetadataLogoURL].Source)
+	assert.Equal(t, "900", asset.Metadata[entity.MetadataMarketCapRank].Value)
+	identifiers, err := s.ListAssetIdentifiers(context.Background(), asset.Id)
+	require.NoError(t, err)
+	require.Len(t, identifiers, 1)
+	assert.Equal(t, entity.AssetIdentifier{ID: identifiers[0].ID, AssetID: asset.Id, Kind: entity.IdentifierKindCoinGecko, Value: "wrapped-bitcoin-sollet"}, *identifiers[0])
+
+	// Importing again returns the mapped asset.
+	again, err := importAsset(SourceCoinGecko, "wrapped-bitcoin-sollet")
+	require.NoError(t, err)
+	assert.Equal(t, asset.Id, again.Id)
+	assert.Len(t, s.assets, 2)
+
+	for name, tc := range map[string]struct {
+		source, id string
+		code       connect.Code
+	}{
+		"no ID":          {SourceCoinGecko, "", connect.CodeInvalidArgument},
+		"unknown source": {"cmc", "bitcoin", connect.CodeInvalidArgument},
+		"invalid ID":     {SourceCoinGecko, "wrapped bitcoin", connect.CodeInvalidArgument},
+		"unknown coin":   {SourceCoinGecko, "dogecoin", connect.CodeNotFound},
+	} {
+		_, err := importAsset(tc.source, tc.id)
+		assert.Equal(t, tc.code, connect.CodeOf(err), name)
+	}
+}
+
+// Compile-time check that the provider searches.
+var _ AssetSearcher = (*CoinGeckoProvider)(nil)
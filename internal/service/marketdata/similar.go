@@ -0,0 +1,266 @@
+package marketdata
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+)
+
+const (
+	defaultSimilarLimit  = 10
+	maxSimilarLimit      = 100
+	defaultLookbackDays  = 90
+	maxLookbackDays      = 3650
+	similarCandidatePool = 200 // Candidates read per query
+	minCorrelatedReturns = 20  // Fewer overlapping returns give no correlation
+	tagSimilarityWeight  = 0.4
+	sameTypeWeight       = 0.2
+	correlationWeight    = 0.4
+)
+
+// similarity is how alike a candidate is to the asset.
+type similarity struct {
+	asset           *entity.Asset
+	sharedTags      []string
+	tagSimilarity   float64
+	sameType        bool
+	correlation     float64
+	correlationDays int
+	correlated      bool
+}
+
+func (s *similarity) score() float64 {
+	score := tagSimilarityWeight * s.tagSimilarity
+	if s.sameType {
+		score += sameTypeWeight
+	}
+	if s.correlated && s.correlation > 0 {
+		score += correlationWeight * s.correlation
+	}
+	return score
+}
+
+func (s *similarity) reasons() []string {
+	var reasons []string
+	if len(s.sharedTags) > 0 {
+		reasons = append(reasons, "shares tags: "+strings.Join(s.sharedTags, ", "))
+	}
+	if s.sameType {
+		reasons = append(reasons, "same type: "+strings.ToLower(strings.TrimPrefix(apiv1.AssetType(s.asset.Type).String(), "ASSET_TYPE_")))
+	}
+	if s.correlated {
+		reasons = append(reasons, fmt.Sprintf("daily returns correlate at %.2f over %d days", s.correlation, s.correlationDays))
+	}
+	return reasons
+}
+
+// jaccard returns the Jaccard index of two tag sets and the tags they share,
+// sorted.
+func jaccard(a, b []string) (float64, []string) {
+	set := make(map[string]bool, len(a))
+	for _, tag := range a {
+		set[tag] = true
+	}
+	union := len(set)
+	var shared []string
+	seen := make(map[string]bool, len(b))
+	for _, tag := range b {
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		if set[tag] {
+			shared = append(shared, tag)
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 0, nil
+	}
+	slices.Sort(shared)
+	return float64(len(shared)) / float64(union), shared
+}
+
+// dailyReturns turns the closes of an asset, oldest first, into returns by
+// the UTC day they end on. Days without a close are skipped, so a return may
+// span several days.
+func dailyReturns(closes []*entity.StoredPrice) map[time.Time]float64 {
+	returns := make(map[time.Time]float64, len(closes))
+	var prev float64
+	for _, p := range closes {
+		price := closePrice(p)
+		if prev > 0 {
+			returns[p.Timestamp.UTC().Truncate(24*time.Hour)] = price/prev - 1
+		}
+		prev = price
+	}
+	return returns
+}
+
+// closePrice prefers the candle close over the last trade price.
+func closePrice(p *entity.StoredPrice) float64 {
+	price := p.Last
+	if p.Close != nil {
+		price = *p.Close
+	}
+	return float64(price) / math.Pow10(int(p.Decimals))
+}
+
+// pearson returns the correlation of the returns two assets have on the
+// same days, and how many days that is. It reports false if too few days
+// overlap or either series is flat.
+func pearson(a, b map[time.Time]float64) (float64, int, bool) {
+	var xs, ys []float64
+	for day, x := range a {
+		if y, ok := b[day]; ok {
+			xs = append(xs, x)
+			ys = append(ys, y)
+		}
+	}
+	n := len(xs)
+	if n < minCorrelatedReturns {
+		return 0, n, false
+	}
+	var meanX, meanY float64
+	for i := range n {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= float64(n)
+	meanY /= float64(n)
+	var cov, varX, varY float64
+	for i := range n {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0, n, false
+	}
+	return cov / math.Sqrt(varX*varY), n, true
+}
+
+// similarCandidates reads the assets that share a tag with asset, and those
+// of its type, up to similarCandidatePool of each.
+func (h *Handler) similarCandidates(ctx context.Context, asset *entity.Asset) ([]*entity.Asset, error) {
+	var queries []ListAssetsOpts
+	if len(asset.Tags) > 0 {
+		queries = append(queries, ListAssetsOpts{AnyTags: asset.Tags, PageSize: similarCandidatePool})
+	}
+	if asset.Type != entity.AssetTypeUnspecified {
+		queries = append(queries, ListAssetsOpts{Type: asset.Type, PageSize: similarCandidatePool})
+	}
+
+	seen := map[string]bool{asset.ID: true}
+	var candidates []*entity.Asset
+	for _, opts := range queries {
+		page, _, err := h.store.ListAssets(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range page {
+			if !seen[a.ID] {
+				seen[a.ID] = true
+				candidates = append(candidates, a)
+			}
+		}
+	}
+	return candidates, nil
+}
+
+// FindSimilarAssets ranks the assets that share a tag or the type of an
+// asset by tag similarity, type and correlation of daily returns.
+func (h *Handler) FindSimilarAssets(ctx context.Context, req *connect.Request[apiv1.FindSimilarAssetsRequest]) (*connect.Response[apiv1.FindSimilarAssetsResponse], error) {
+	if req.Msg.AssetId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("asset ID is required"))
+	}
+	limit := int(req.Msg.Limit)
+	if limit <= 0 {
+		limit = defaultSimilarLimit
+	}
+	limit = min(limit, maxSimilarLimit)
+	lookback := defaultLookbackDays
+	if req.Msg.LookbackDays != nil {
+		lookback = int(*req.Msg.LookbackDays)
+	}
+	if lookback < 1 || lookback > maxLookbackDays {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("lookback must be 1 to %d days", maxLookbackDays))
+	}
+
+	asset, err := h.store.GetAsset(ctx, req.Msg.AssetId)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	candidates, err := h.similarCandidates(ctx, asset)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	// One query reads the closes of the asset and every candidate; the
+	// asset goes first so that it picks the default base.
+	ids := make([]string, 0, len(candidates)+1)
+	ids = append(ids, asset.ID)
+	for _, c := range candidates {
+		ids = append(ids, c.ID)
+	}
+	to := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	closes, err := h.store.ListDailyCloses(ctx, DailyClosesOpts{
+		AssetIDs:    ids,
+		BaseAssetID: req.Msg.GetBaseAssetId(),
+		From:        to.AddDate(0, 0, -lookback-1),
+		To:          to,
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	closesByAsset := map[string][]*entity.StoredPrice{}
+	for _, p := range closes {
+		closesByAsset[p.AssetID] = append(closesByAsset[p.AssetID], p)
+	}
+	assetReturns := dailyReturns(closesByAsset[asset.ID])
+
+	results := make([]*similarity, 0, len(candidates))
+	for _, c := range candidates {
+		s := &similarity{asset: c, sameType: c.Type == asset.Type && c.Type != entity.AssetTypeUnspecified}
+		s.tagSimilarity, s.sharedTags = jaccard(asset.Tags, c.Tags)
+		s.correlation, s.correlationDays, s.correlated = pearson(assetReturns, dailyReturns(closesByAsset[c.ID]))
+		results = append(results, s)
+	}
+	slices.SortStableFunc(results, func(a, b *similarity) int {
+		return cmp.Or(cmp.Compare(b.score(), a.score()), cmp.Compare(a.asset.ID, b.asset.ID))
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	resp := &apiv1.FindSimilarAssetsResponse{}
+	if len(closes) > 0 {
+		resp.BaseAssetId = &closes[0].BaseAssetID
+	}
+	for _, s := range results {
+		similar := &apiv1.SimilarAsset{
+			Asset:           assetToProto(s.asset),
+			Score:           s.score(),
+			TagSimilarity:   s.tagSimilarity,
+			SameType:        s.sameType,
+			CorrelationDays: int32(s.correlationDays),
+			Reasons:         s.reasons(),
+		}
+		if s.correlated {
+			similar.Correlation = &s.correlation
+		}
+		resp.Assets = append(resp.Assets, similar)
+	}
+	return connect.NewResponse(resp), nil
+}
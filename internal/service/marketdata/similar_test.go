@@ -0,0 +1,141 @@
+package marketdata
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// similarStore serves assets and daily closes from memory.
+type similarStore struct {
+	assetStore
+	closes    []*entity.StoredPrice
+	closeOpts DailyClosesOpts
+}
+
+func (s *similarStore) ListDailyCloses(_ context.Context, opts DailyClosesOpts) ([]*entity.StoredPrice, error) {
+	s.closeOpts = opts
+	var closes []*entity.StoredPrice
+	for _, p := range s.closes {
+		if slices.Contains(opts.AssetIDs, p.AssetID) && !p.Timestamp.Before(opts.From) && p.Timestamp.Before(opts.To) {
+			closes = append(closes, p)
+		}
+	}
+	return closes, nil
+}
+
+// addCloses records a close a day for assetID, ending yesterday.
+func (s *similarStore) addCloses(assetID string, days int, price func(day int) float64) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for day := range days {
+		close := int64(math.Round(price(day) * 100))
+		s.closes = append(s.closes, &entity.StoredPrice{
+			AssetID:     assetID,
+			BaseAssetID: "usd",
+			Decimals:    2,
+			Close:       &close,
+			Timestamp:   today.AddDate(0, 0, day-days).Add(23 * time.Hour),
+		})
+	}
+}
+
+func newSimilarHandler() (*Handler, *similarStore) {
+	s := &similarStore{assetStore: assetStore{assets: map[string]*entity.Asset{
+		"btc":  {ID: "btc", Symbol: "BTC", Type: entity.AssetTypeCryptocurrency, Tags: []string{"bitcoin", "store-of-value"}},
+		"wbtc": {ID: "wbtc", Symbol: "WBTC", Type: entity.AssetTypeCryptocurrency, Tags: []string{"bitcoin", "store-of-value", "wrapped"}},
+		"eth":  {ID: "eth", Symbol: "ETH", Type: entity.AssetTypeCryptocurrency, Tags: []string{"smart-contracts"}},
+		"gold": {ID: "gold", Symbol: "XAU", Type: entity.AssetTypeCommodity, Tags: []string{"store-of-value"}},
+		"aapl": {ID: "aapl", Symbol: "AAPL", Type: entity.AssetTypeStock, Tags: []string{"tech"}},
+	}}}
+	wave := func(day int) float64 { return math.Sin(float64(day)) + math.Sin(float64(day)/3) }
+	s.addCloses("btc", 40, func(day int) float64 { return 60000 * (1 + 0.03*wave(day)) })
+	s.addCloses("wbtc", 40, func(day int) float64 { return 59900 * (1 + 0.03*wave(day)) })
+	s.addCloses("eth", 40, func(day int) float64 { return 3000 * (1 - 0.03*wave(day)) })
+	s.addCloses("gold", 10, func(day int) float64 { return 2000 * (1 + 0.01*wave(day)) })
+	return NewHandler(s, nil, nil, nil, nil, QualityConfig{}, slog.New(slog.DiscardHandler)), s
+}
+
+func TestFindSimilarAssets(t *testing.T) {
+	h, s := newSimilarHandler()
+
+	resp, err := h.FindSimilarAssets(context.Background(), connect.NewRequest(&apiv1.FindSimilarAssetsRequest{AssetId: "btc"}))
+	require.NoError(t, err)
+	assert.Equal(t, "usd", resp.Msg.GetBaseAssetId())
+	assert.Equal(t, "btc", s.closeOpts.AssetIDs[0])
+	assert.Equal(t, defaultLookbackDays+1, int(s.closeOpts.To.Sub(s.closeOpts.From).Hours()/24))
+
+	// The wrapped token shares tags, type and price moves; ether only the
+	// type, as falling when bitcoin rises is no likeness; gold one of three
+	// tags, with too little history to correlate. Stocks share nothing.
+	var ids []string
+	for _, a := range resp.Msg.Assets {
+		ids = append(ids, a.Asset.Id)
+	}
+	assert.Equal(t, []string{"wbtc", "eth", "gold"}, ids)
+
+	wbtc := resp.Msg.Assets[0]
+	assert.InDelta(t, 2.0/3, wbtc.TagSimilarity, 1e-9)
+	assert.True(t, wbtc.SameType)
+	assert.InDelta(t, 1, wbtc.GetCorrelation(), 1e-3)
+	assert.EqualValues(t, 39, wbtc.CorrelationDays)
+	assert.InDelta(t, 0.4*2/3+0.2+0.4*wbtc.GetCorrelation(), wbtc.Score, 1e-9)
+	assert.Equal(t, []string{
+		"shares tags: bitcoin, store-of-value",
+		"same type: cryptocurrency",
+		"daily returns correlate at 1.00 over 39 days",
+	}, wbtc.Reasons)
+
+	eth := resp.Msg.Assets[1]
+	assert.Less(t, eth.GetCorrelation(), -0.99)
+	assert.InDelta(t, sameTypeWeight, eth.Score, 1e-9)
+
+	gold := resp.Msg.Assets[2]
+	assert.Nil(t, gold.Correlation)
+	assert.EqualValues(t, 9, gold.CorrelationDays)
+	assert.Equal(t, []string{"shares tags: store-of-value"}, gold.Reasons)
+
+	resp, err = h.FindSimilarAssets(context.Background(), connect.NewRequest(&apiv1.FindSimilarAssetsRequest{
+		AssetId: "btc", Limit: 1, LookbackDays: proto.Int32(7), BaseAssetId: proto.String("eur"),
+	}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Assets, 1)
+	assert.Equal(t, "eur", s.closeOpts.BaseAssetID)
+	// A week of returns is too short to correlate.
+	assert.Nil(t, resp.Msg.Assets[0].Correlation)
+}
+
+func TestFindSimilarAssets_Errors(t *testing.T) {
+	h, _ := newSimilarHandler()
+	for name, tc := range map[string]struct {
+		req  *apiv1.FindSimilarAssetsRequest
+		code connect.Code
+	}{
+		"no asset ID":       {&apiv1.FindSimilarAssetsRequest{}, connect.CodeInvalidArgument},
+		"no lookback":       {&apiv1.FindSimilarAssetsRequest{AssetId: "btc", LookbackDays: proto.Int32(0)}, connect.CodeInvalidArgument},
+		"too long lookback": {&apiv1.FindSimilarAssetsRequest{AssetId: "btc", LookbackDays: proto.Int32(maxLookbackDays + 1)}, connect.CodeInvalidArgument},
+		"unknown asset":     {&apiv1.FindSimilarAssetsRequest{AssetId: "doge"}, connect.CodeNotFound},
+	} {
+		_, err := h.FindSimilarAssets(context.Background(), connect.NewRequest(tc.req))
+		assert.Equal(t, tc.code, connect.CodeOf(err), name)
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	score, shared := jaccard([]string{"b", "a", "c"}, []string{"c", "d", "b", "b"})
+	assert.InDelta(t, 0.5, score, 1e-9)
+	assert.Equal(t, []string{"b", "c"}, shared)
+
+	score, shared = jaccard(nil, nil)
+	assert.Zero(t, score)
+	assert.Nil(t, shared)
+}
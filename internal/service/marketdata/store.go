@@ -16,14 +16,55 @@ type Store interface {
 	UpdateAsset(ctx context.Context, asset *entity.Asset, fields []string) (*entity.Asset, error)
 	DeleteAsset(ctx context.Context, id string) error
 	ListAssets(ctx context.Context, opts ListAssetsOpts) ([]*entity.Asset, string, error)
+	SearchAssets(ctx context.Context, opts SearchAssetsOpts) ([]*entity.Asset, string, error)
 
-	// Prices
+	// Asset identifiers
+	CreateAssetIdentifier(ctx context.Context, identifier *entity.AssetIdentifier) (*entity.AssetIdentifier, error)
+	DeleteAssetIdentifier(ctx context.Context, id string) error
+	ListAssetIdentifiers(ctx context.Context, assetID string) ([]*entity.AssetIdentifier, error)
+
+	// Corporate actions
+	CreateCorporateAction(ctx context.Context, action *entity.CorporateAction) (*entity.CorporateAction, error)
+	GetCorporateAction(ctx context.Context, id string) (*entity.CorporateAction, error)
+	ListCorporateActions(ctx context.Context, opts ListCorporateActionsOpts) ([]*entity.CorporateAction, string, error)
+	DeleteCorporateAction(ctx context.Context, id string) error
+	// ApplyCorporateAction adjusts holdings, lots and prices for a pending
+	// or reverted action, recording every change, and marks it applied.
+	ApplyCorporateAction(ctx context.Context, id string) (*entity.CorporateAction, *entity.CorporateActionAdjustments, error)
+	// RevertCorporateAction restores the recorded changes of an applied
+	// action, unless any of them changed since.
+	RevertCorporateAction(ctx context.Context, id string) (*entity.CorporateAction, error)
+
+	// Prices. Only ListPriceHistory and ListLatestPrices return quarantined
+	// prices, and only when asked to.
 	CreatePrice(ctx context.Context, price *entity.StoredPrice) (*entity.StoredPrice, error)
 	CreatePrices(ctx context.Context, prices []*entity.StoredPrice) (int, error)
 	GetLatestPrice(ctx context.Context, assetID, baseAssetID, sourceID string) (*entity.StoredPrice, error)
+	// GetPriceAt returns the last price of an asset at or before at, in
+	// baseAssetID or, if it is empty, in any asset.
+	GetPriceAt(ctx context.Context, assetID, baseAssetID string, at time.Time) (*entity.StoredPrice, error)
 	ListPriceHistory(ctx context.Context, opts ListPriceHistoryOpts) ([]*entity.StoredPrice, string, error)
+	ListDailyCloses(ctx context.Context, opts DailyClosesOpts) ([]*entity.StoredPrice, error)
+	// ListLatestPrices returns the last price of each source of a pair in
+	// the range.
+	ListLatestPrices(ctx context.Context, opts LatestPricesOpts) ([]*entity.StoredPrice, error)
 	DeletePrice(ctx context.Context, id string) error
 	DeletePrices(ctx context.Context, opts DeletePricesOpts) error
+
+	// Price quality
+	// ListPriceSourceStats summarizes the prices of each pair, interval and
+	// source matching opts, ordered by asset, base asset, interval and
+	// source.
+	ListPriceSourceStats(ctx context.Context, opts PriceQualityOpts) ([]*entity.PriceSourceStats, error)
+	// ListPriceGaps returns the spans longer than opts.MinSpacing between
+	// consecutive unquarantined prices of each pair in opts.Interval,
+	// ordered by asset, base asset and time.
+	ListPriceGaps(ctx context.Context, opts PriceQualityOpts) ([]*entity.PriceGap, error)
+
+	// Dividends
+	// CreateDividends stores dividends not stored before, one per asset and
+	// ex-date, and returns how many it stored.
+	CreateDividends(ctx context.Context, dividends []*entity.Dividend) (int, error)
 }
 
 // ListAssetsOpts contains options for listing assets.
@@ -31,6 +72,32 @@ type ListAssetsOpts struct {
 	PageSize  int
 	PageToken string
 	Tags      []string
+	AnyTags   []string // Assets with at least one of these tags
+	Type      entity.AssetType
+	Symbol    string // Case-insensitive exact match
+	// Identifier selects the assets with an identifier of the same value,
+	// and of the same kind and chain where those are set.
+	Identifier *entity.AssetIdentifier
+}
+
+// SearchAssetsOpts contains options for searching assets. Results are
+// ranked: exact symbol, exact identifier, symbol prefix, then names by
+// relevance.
+type SearchAssetsOpts struct {
+	Query     string
+	Type      entity.AssetType
+	Tags      []string // Assets with all of these tags
+	Similar   bool     // When none match, the assets most similar to Query
+	PageSize  int
+	PageToken string
+}
+
+// ListCorporateActionsOpts contains options for listing corporate actions.
+type ListCorporateActionsOpts struct {
+	AssetID   string // Actions on the asset or migrating to it
+	Status    entity.CorporateActionStatus
+	PageSize  int
+	PageToken string
 }
 
 // ListPriceHistoryOpts contains options for listing price history.
@@ -43,6 +110,42 @@ type ListPriceHistoryOpts struct {
 	To          *time.Time
 	PageSize    int
 	PageToken   string
+	// IncludeQuarantined lists quarantined prices too.
+	IncludeQuarantined bool
+}
+
+// DailyClosesOpts selects the last price of each UTC day for several
+// assets.
+type DailyClosesOpts struct {
+	AssetIDs []string
+	// BaseAssetID defaults to the base asset most prices of AssetIDs[0] in
+	// the range are quoted in.
+	BaseAssetID string
+	From        time.Time
+	To          time.Time // Exclusive
+}
+
+// LatestPricesOpts selects the last price of each source of a pair.
+type LatestPricesOpts struct {
+	AssetID     string
+	BaseAssetID string
+	From        time.Time
+	To          time.Time // Inclusive
+	// Quarantined selects quarantined prices instead of those that passed
+	// their checks.
+	Quarantined bool
+}
+
+// PriceQualityOpts selects the prices a quality report covers. Empty
+// filters match every asset, base asset and interval.
+type PriceQualityOpts struct {
+	AssetID     string
+	BaseAssetID string
+	Interval    string
+	From        time.Time
+	To          time.Time // Exclusive
+	// MinSpacing is the shortest span ListPriceGaps reports.
+	MinSpacing time.Duration
 }
 
 // DeletePricesOpts contains options for batch deleting prices.
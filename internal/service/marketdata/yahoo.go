@@ -0,0 +1,275 @@
+package marketdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/adapter/yahoo"
+	"github.com/foxcool/greedy-eye/internal/calendar"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+)
+
+// SourceYahoo names Yahoo Finance as a data source.
+const SourceYahoo = "yahoo"
+
+// yahooDecimals is the precision of stored Yahoo prices.
+const yahooDecimals = 4
+
+// yahooExchanges maps Yahoo exchange codes to market identifier codes.
+var yahooExchanges = map[string]string{
+	"NMS": "XNAS", "NGM": "XNAS", "NCM": "XNAS", "NAS": "XNAS",
+	"NYQ": "XNYS", "NYS": "XNYS",
+	"PCX": "ARCX",
+	"ASE": "XASE",
+	"LSE": "XLON",
+	"GER": "XETR",
+}
+
+// yahooTypes maps Yahoo instrument types to asset types. Others, such as
+// indices and currencies, are not imported.
+var yahooTypes = map[string]entity.AssetType{
+	"EQUITY":     entity.AssetTypeStock,
+	"ETF":        entity.AssetTypeFund,
+	"MUTUALFUND": entity.AssetTypeFund,
+}
+
+// yahooClient is the part of yahoo.Client the provider uses.
+type yahooClient interface {
+	GetChart(ctx context.Context, symbol string, from, to time.Time) (*yahoo.Chart, error)
+	Search(ctx context.Context, query string) ([]yahoo.Quote, error)
+}
+
+// YahooProvider prices stocks and funds from Yahoo Finance, provides their
+// metadata and finds them to add. Assets are known to it by ticker; an
+// asset with only an ISIN gets the ticker the ISIN is listed under.
+type YahooProvider struct {
+	client yahooClient
+	store  Store
+	now    func() time.Time
+}
+
+func NewYahooProvider(client *yahoo.Client, store Store) *YahooProvider {
+	return &YahooProvider{client: client, store: store, now: time.Now}
+}
+
+func (p *YahooProvider) Source() string {
+	return SourceYahoo
+}
+
+func (p *YahooProvider) IdentifierKind() entity.IdentifierKind {
+	return entity.IdentifierKindTicker
+}
+
+// FetchPrices stores a daily price per session once the session closed,
+// stamped with the close, and the regular market price while one is open.
+// Prices are in the currency the asset is quoted in, which must be stored
+// as a forex asset.
+func (p *YahooProvider) FetchPrices(ctx context.Context, asset *entity.Asset, since time.Time) (*FetchedPrices, error) {
+	now := p.now()
+	chart, exchange, err := p.chart(ctx, asset, since, now)
+	if err != nil {
+		return nil, err
+	}
+	currency, err := forexAsset(ctx, p.store, chart.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	fetched := &FetchedPrices{}
+	for _, bar := range chart.Bars {
+		// Holidays have no session; today's closes later.
+		_, close, ok := exchange.Session(bar.Time)
+		if !ok || close.After(now) || close.Before(since) {
+			continue
+		}
+		last := yahooUnits(bar.Close)
+		price := &entity.StoredPrice{
+			SourceID:    SourceYahoo,
+			AssetID:     asset.ID,
+			BaseAssetID: currency.ID,
+			Interval:    IntervalDaily,
+			Decimals:    yahooDecimals,
+			Last:        last,
+			Open:        yahooNullUnits(bar.Open),
+			High:        yahooNullUnits(bar.High),
+			Low:         yahooNullUnits(bar.Low),
+			Close:       &last,
+			Volume:      bar.Volume,
+			Timestamp:   close,
+		}
+		fetched.Prices = append(fetched.Prices, price)
+	}
+	if open, _, ok := exchange.Session(now); ok && exchange.IsOpen(now) && !chart.PriceTime.Before(open) {
+		fetched.Prices = append(fetched.Prices, &entity.StoredPrice{
+			SourceID:    SourceYahoo,
+			AssetID:     asset.ID,
+			BaseAssetID: currency.ID,
+			Interval:    IntervalLatest,
+			Decimals:    yahooDecimals,
+			Last:        yahooUnits(chart.Price),
+			Timestamp:   chart.PriceTime,
+		})
+	}
+
+	for _, d := range chart.Dividends {
+		y, m, day := d.ExDate.In(exchange.Location).Date()
+		exDate := time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+		if exDate.After(now) || !d.Amount.IsPositive() {
+			continue
+		}
+		fetched.Dividends = append(fetched.Dividends, &entity.Dividend{
+			Source:          SourceYahoo,
+			AssetID:         asset.ID,
+			CurrencyAssetID: currency.ID,
+			ExDate:          exDate,
+			PerShare:        d.Amount,
+		})
+	}
+	return fetched, nil
+}
+
+// AssetMetadata reports the name, exchange and currency of the asset's
+// listing.
+func (p *YahooProvider) AssetMetadata(ctx context.Context, asset *entity.Asset) (map[string]string, error) {
+	now := p.now()
+	chart, exchange, err := p.chart(ctx, asset, now, now)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		entity.MetadataName:     chart.Name,
+		entity.MetadataExchange: exchange.MIC,
+		entity.MetadataCurrency: chart.Currency,
+	}, nil
+}
+
+func (p *YahooProvider) SearchAssets(ctx context.Context, query string) ([]*ExternalAsset, error) {
+	quotes, err := p.client.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var assets []*ExternalAsset
+	for _, q := range quotes {
+		assetType, ok := yahooTypes[q.Type]
+		if !ok {
+			continue
+		}
+		assets = append(assets, &ExternalAsset{
+			Source: SourceYahoo,
+			ID:     q.Symbol,
+			Symbol: tickerSymbol(q.Symbol),
+			Name:   q.Name,
+			Type:   assetType,
+		})
+	}
+	return assets, nil
+}
+
+func (p *YahooProvider) ExternalAsset(ctx context.Context, id string) (*ExternalAsset, error) {
+	now := p.now()
+	chart, err := p.client.GetChart(ctx, id, now, now)
+	if errors.Is(err, yahoo.ErrNotFound) {
+		return nil, fmt.Errorf("%w: Yahoo symbol %q", store.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	assetType, ok := yahooTypes[chart.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: Yahoo symbol %q is a %s, not a stock or fund", store.ErrNotFound, id, chart.Type)
+	}
+	return &ExternalAsset{
+		Source: SourceYahoo,
+		ID:     chart.Symbol,
+		Symbol: tickerSymbol(chart.Symbol),
+		Name:   chart.Name,
+		Type:   assetType,
+	}, nil
+}
+
+// chart fetches the asset's chart and the calendar of its exchange.
+func (p *YahooProvider) chart(ctx context.Context, asset *entity.Asset, from, to time.Time) (*yahoo.Chart, *calendar.Exchange, error) {
+	ticker, err := p.ticker(ctx, asset)
+	if err != nil {
+		return nil, nil, err
+	}
+	chart, err := p.client.GetChart(ctx, ticker, from, to)
+	if errors.Is(err, yahoo.ErrNotFound) {
+		return nil, nil, fmt.Errorf("%w: Yahoo symbol %q", store.ErrNotFound, ticker)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	exchange, ok := calendar.ByMIC(yahooExchanges[chart.Exchange])
+	if !ok {
+		return nil, nil, fmt.Errorf("no trading calendar for Yahoo exchange %q", chart.Exchange)
+	}
+	return chart, exchange, nil
+}
+
+// ticker returns the asset's ticker. An asset without one but with an ISIN
+// is mapped to the first stock or fund the ISIN finds.
+func (p *YahooProvider) ticker(ctx context.Context, asset *entity.Asset) (string, error) {
+	identifiers, err := p.store.ListAssetIdentifiers(ctx, asset.ID)
+	if err != nil {
+		return "", err
+	}
+	for _, ident := range identifiers {
+		if ident.Kind == entity.IdentifierKindTicker {
+			return ident.Value, nil
+		}
+	}
+	i := slices.IndexFunc(identifiers, func(ident *entity.AssetIdentifier) bool {
+		return ident.Kind == entity.IdentifierKindISIN
+	})
+	if i < 0 {
+		return "", fmt.Errorf("%w: no ticker or ISIN for asset %s", store.ErrNotFound, asset.ID)
+	}
+	isin := identifiers[i].Value
+
+	quotes, err := p.client.Search(ctx, isin)
+	if err != nil {
+		return "", err
+	}
+	for _, q := range quotes {
+		if _, ok := yahooTypes[q.Type]; !ok {
+			continue
+		}
+		ticker, err := normalizeIdentifier(entity.IdentifierKindTicker, q.Symbol)
+		if err != nil {
+			continue
+		}
+		_, err = p.store.CreateAssetIdentifier(ctx, &entity.AssetIdentifier{
+			AssetID: asset.ID, Kind: entity.IdentifierKindTicker, Value: ticker,
+		})
+		if err != nil {
+			return "", fmt.Errorf("map ISIN %s to ticker %s: %w", isin, ticker, err)
+		}
+		return ticker, nil
+	}
+	return "", fmt.Errorf("%w: no listing for ISIN %s", store.ErrNotFound, isin)
+}
+
+// tickerSymbol strips the exchange suffix from a ticker.
+func tickerSymbol(ticker string) string {
+	symbol, _, _ := strings.Cut(ticker, ".")
+	return symbol
+}
+
+func yahooUnits(d decimal.Decimal) int64 {
+	return d.Shift(yahooDecimals).Round(0).IntPart()
+}
+
+func yahooNullUnits(d decimal.NullDecimal) *int64 {
+	if !d.Valid {
+		return nil
+	}
+	units := yahooUnits(d.Decimal)
+	return &units
+}
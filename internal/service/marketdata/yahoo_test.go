@@ -0,0 +1,174 @@
+package marketdata
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/adapter/yahoo"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ interface {
+	PriceProvider
+	MetadataProvider
+	AssetSearcher
+} = (*YahooProvider)(nil)
+
+// fakeYahoo serves charts and search results by symbol and query.
+type fakeYahoo struct {
+	charts   map[string]*yahoo.Chart
+	quotes   map[string][]yahoo.Quote
+	searched []string
+}
+
+func (c *fakeYahoo) GetChart(_ context.Context, symbol string, _, _ time.Time) (*yahoo.Chart, error) {
+	chart, ok := c.charts[symbol]
+	if !ok {
+		return nil, yahoo.ErrNotFound
+	}
+	return chart, nil
+}
+
+func (c *fakeYahoo) Search(_ context.Context, query string) ([]yahoo.Quote, error) {
+	c.searched = append(c.searched, query)
+	return c.quotes[query], nil
+}
+
+func newYahooProvider(now time.Time) (*YahooProvider, *assetStore, *fakeYahoo) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	day := func(d int) time.Time { return time.Date(2025, time.April, d, 9, 30, 0, 0, newYork) }
+	bar := func(d int, close string) yahoo.Bar {
+		return yahoo.Bar{Time: day(d), Close: decimal.RequireFromString(close)}
+	}
+	client := &fakeYahoo{
+		charts: map[string]*yahoo.Chart{
+			"AAPL": {
+				Symbol: "AAPL", Name: "Apple Inc.", Type: "EQUITY", Exchange: "NMS", Currency: "USD",
+				Price: decimal.RequireFromString("195.5"), PriceTime: time.Date(2025, time.April, 16, 14, 59, 0, 0, newYork),
+				Bars: []yahoo.Bar{
+					bar(11, "198.15"),
+					bar(12, "198.15"), // A Saturday
+					bar(14, "202.52"),
+					bar(15, "202.14"),
+					bar(16, "195.5"), // Still trading
+				},
+				Dividends: []yahoo.Dividend{{ExDate: day(14), Amount: decimal.RequireFromString("0.25")}},
+			},
+			"VOD.L": {Symbol: "VOD.L", Type: "EQUITY", Exchange: "LSE", Currency: "GBP"},
+			"^GSPC": {Symbol: "^GSPC", Type: "INDEX", Exchange: "SNP", Currency: "USD"},
+		},
+		quotes: map[string][]yahoo.Quote{
+			"US0378331005": {
+				{Symbol: "^AAPL", Type: "INDEX"},
+				{Symbol: "AAPL", Name: "Apple Inc.", Type: "EQUITY", Exchange: "NMS"},
+			},
+		},
+	}
+	s := &assetStore{
+		assets: map[string]*entity.Asset{
+			"aapl": {ID: "aapl", Symbol: "AAPL", Type: entity.AssetTypeStock},
+			"vod":  {ID: "vod", Symbol: "VOD", Type: entity.AssetTypeStock},
+			"btc":  {ID: "btc", Symbol: "BTC", Type: entity.AssetTypeCryptocurrency},
+			"usd":  {ID: "usd", Symbol: "USD", Type: entity.AssetTypeForex},
+		},
+		identifiers: []*entity.AssetIdentifier{
+			{ID: "i1", AssetID: "aapl", Kind: entity.IdentifierKindISIN, Value: "US0378331005"},
+			{ID: "i2", AssetID: "vod", Kind: entity.IdentifierKindTicker, Value: "VOD.L"},
+		},
+	}
+	p := &YahooProvider{client: client, store: s, now: func() time.Time { return now }}
+	return p, s, client
+}
+
+func TestYahooFetchPrices(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	closeOf := func(d int) time.Time { return time.Date(2025, time.April, d, 16, 0, 0, 0, newYork) }
+
+	t.Run("During a session", func(t *testing.T) {
+		p, s, client := newYahooProvider(time.Date(2025, time.April, 16, 15, 0, 0, 0, newYork))
+
+		fetched, err := p.FetchPrices(context.Background(), s.assets["aapl"], time.Date(2025, time.April, 9, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		// Closed sessions are stamped with their close; the open one is
+		// the latest price.
+		require.Len(t, fetched.Prices, 4)
+		for i, want := range []struct {
+			interval string
+			last     int64
+			at       time.Time
+		}{
+			{IntervalDaily, 1981500, closeOf(11)},
+			{IntervalDaily, 2025200, closeOf(14)},
+			{IntervalDaily, 2021400, closeOf(15)},
+			{IntervalLatest, 1955000, time.Date(2025, time.April, 16, 14, 59, 0, 0, newYork)},
+		} {
+			price := fetched.Prices[i]
+			assert.Equal(t, want.interval, price.Interval, i)
+			assert.Equal(t, want.last, price.Last, i)
+			assert.True(t, want.at.Equal(price.Timestamp), i)
+			assert.Equal(t, "usd", price.BaseAssetID)
+			assert.Equal(t, uint32(yahooDecimals), price.Decimals)
+		}
+		require.Len(t, fetched.Dividends, 1)
+		assert.Equal(t, time.Date(2025, time.April, 14, 0, 0, 0, 0, time.UTC), fetched.Dividends[0].ExDate)
+		assert.Equal(t, "0.25", fetched.Dividends[0].PerShare.String())
+		assert.Equal(t, "usd", fetched.Dividends[0].CurrencyAssetID)
+
+		// The ISIN was mapped to the ticker it is listed under, once.
+		identifiers, _ := s.ListAssetIdentifiers(context.Background(), "aapl")
+		assert.Contains(t, identifiers, &entity.AssetIdentifier{ID: "ident-3", AssetID: "aapl", Kind: entity.IdentifierKindTicker, Value: "AAPL"})
+		_, err = p.FetchPrices(context.Background(), s.assets["aapl"], time.Date(2025, time.April, 9, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"US0378331005"}, client.searched)
+	})
+
+	t.Run("After the close", func(t *testing.T) {
+		p, s, _ := newYahooProvider(time.Date(2025, time.April, 19, 10, 0, 0, 0, newYork))
+
+		fetched, err := p.FetchPrices(context.Background(), s.assets["aapl"], time.Date(2025, time.April, 9, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, fetched.Prices, 4)
+		last := fetched.Prices[3]
+		assert.Equal(t, IntervalDaily, last.Interval)
+		assert.True(t, closeOf(16).Equal(last.Timestamp))
+	})
+
+	t.Run("Not priced", func(t *testing.T) {
+		p, s, _ := newYahooProvider(time.Now())
+
+		_, err := p.FetchPrices(context.Background(), s.assets["btc"], time.Now().AddDate(0, 0, -7))
+		assert.ErrorIs(t, err, store.ErrNotFound)
+
+		// Pounds are not stored as an asset.
+		_, err = p.FetchPrices(context.Background(), s.assets["vod"], time.Now().AddDate(0, 0, -7))
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, store.ErrNotFound)
+	})
+}
+
+func TestYahooAssets(t *testing.T) {
+	p, s, _ := newYahooProvider(time.Now())
+
+	found, err := p.SearchAssets(context.Background(), "US0378331005")
+	require.NoError(t, err)
+	assert.Equal(t, []*ExternalAsset{
+		{Source: SourceYahoo, ID: "AAPL", Symbol: "AAPL", Name: "Apple Inc.", Type: entity.AssetTypeStock},
+	}, found)
+
+	ext, err := p.ExternalAsset(context.Background(), "VOD.L")
+	require.NoError(t, err)
+	assert.Equal(t, "VOD", ext.Symbol)
+	_, err = p.ExternalAsset(context.Background(), "^GSPC")
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	fields, err := p.AssetMetadata(context.Background(), s.assets["vod"])
+	require.NoError(t, err)
+	assert.Equal(t, "XLON", fields[entity.MetadataExchange])
+	assert.Equal(t, "GBP", fields[entity.MetadataCurrency])
+}
@@ -0,0 +1,1078 @@
+package portfolio
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/shopspring/decimal"
+)
+
+// ImportConfig bounds transaction imports.
+type ImportConfig struct {
+	// MaxBytes is the largest export accepted; defaultMaxImportBytes when
+	// zero.
+	MaxBytes int `koanf:"maxBytes"`
+}
+
+// defaultMaxImportBytes is the largest export accepted when none is
+// configured.
+const defaultMaxImportBytes = 10 << 20
+
+// maxBytes returns the largest export accepted.
+func (c ImportConfig) maxBytes() int {
+	if c.MaxBytes > 0 {
+		return c.MaxBytes
+	}
+	return defaultMaxImportBytes
+}
+
+// Trade sides, stored in the "side" data field of trades.
+const (
+	SideBuy  = "buy"
+	SideSell = "sell"
+)
+
+// importRow is one transaction read from an export, before its symbols are
+// resolved to assets. Amounts are never negative: side and type carry the
+// direction.
+type importRow struct {
+	line        int
+	externalID  string
+	txType      entity.TransactionType
+	side        string // For trades
+	income      string // Kind of income, for income rows
+	at          time.Time
+	asset       string
+	amount      decimal.Decimal
+	price       decimal.Decimal // Zero when unknown
+	quoteAsset  string
+	quoteAmount decimal.Decimal
+	fee         decimal.Decimal
+	feeAsset    string
+	err         error
+}
+
+// formatSource names each format in the "source" data field of imported
+// transactions.
+var formatSource = map[apiv1.ImportFormat]string{
+	apiv1.ImportFormat_IMPORT_FORMAT_GENERIC:        "csv",
+	apiv1.ImportFormat_IMPORT_FORMAT_BINANCE_TRADES: "binance",
+	apiv1.ImportFormat_IMPORT_FORMAT_COINBASE:       "coinbase",
+	apiv1.ImportFormat_IMPORT_FORMAT_KRAKEN_LEDGER:  "kraken",
+	apiv1.ImportFormat_IMPORT_FORMAT_IBKR_ACTIVITY:  "ibkr",
+}
+
+// sourceIdentifierKinds gives the asset identifier kind of the codes in
+// exports that have one; the asset they map to wins over symbol matches.
+var sourceIdentifierKinds = map[string]entity.IdentifierKind{
+	"binance": entity.IdentifierKindBinance,
+}
+
+// parseImport reads the rows of an export. Problems with single rows are
+// reported on the row; an error means the whole file is unusable.
+func parseImport(format apiv1.ImportFormat, content []byte, mapping *apiv1.ImportColumnMapping) ([]importRow, error) {
+	records, err := readRecords(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	switch format {
+	case apiv1.ImportFormat_IMPORT_FORMAT_GENERIC:
+		if mapping == nil {
+			return nil, errors.New("column mapping is required for generic imports")
+		}
+		rows, err = parseGeneric(records, mapping)
+	case apiv1.ImportFormat_IMPORT_FORMAT_BINANCE_TRADES:
+		rows, err = parseBinanceTrades(records)
+	case apiv1.ImportFormat_IMPORT_FORMAT_COINBASE:
+		rows, err = parseCoinbase(records)
+	case apiv1.ImportFormat_IMPORT_FORMAT_KRAKEN_LEDGER:
+		rows, err = parseKrakenLedger(records)
+	case apiv1.ImportFormat_IMPORT_FORMAT_IBKR_ACTIVITY:
+		rows, err = parseIBKRActivity(records)
+	default:
+		return nil, fmt.Errorf("unsupported import format %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	assignExternalIDs(rows, records)
+	return rows, nil
+}
+
+// importedRow is a parsed row with its outcome.
+type importedRow struct {
+	importRow
+	status apiv1.ImportRowStatus
+	tx     *entity.Transaction // Nil for invalid rows
+}
+
+// externalIDBatch bounds the IDs looked up per duplicate query.
+const externalIDBatch = 500
+
+// prepareImport resolves the symbols of rows to assets, builds their
+// transactions and marks the duplicates.
+func (h *Handler) prepareImport(ctx context.Context, accountID, source string, rows []importRow) ([]*importedRow, error) {
+	assets := make(map[string]string)
+	resolve := func(symbol string) (string, error) {
+		if symbol == "" {
+			return "", nil
+		}
+		if id, ok := assets[symbol]; ok {
+			return id, nil
+		}
+		if kind, ok := sourceIdentifierKinds[source]; ok {
+			found, _, err := h.marketData.ListAssets(ctx, marketdata.ListAssetsOpts{
+				Identifier: &entity.AssetIdentifier{Kind: kind, Value: symbol},
+				PageSize:   1,
+			})
+			if err != nil {
+				return "", err
+			}
+			if len(found) == 1 {
+				assets[symbol] = found[0].ID
+				return assets[symbol], nil
+			}
+		}
+		found, _, err := h.marketData.ListAssets(ctx, marketdata.ListAssetsOpts{Symbol: symbol, PageSize: 2})
+		if err != nil {
+			return "", err
+		}
+		switch len(found) {
+		case 0:
+			assets[symbol] = ""
+		case 1:
+			assets[symbol] = found[0].ID
+		default:
+			assets[symbol] = "?"
+		}
+		return assets[symbol], nil
+	}
+
+	imported := make([]*importedRow, 0, len(rows))
+	seen := make(map[string]bool)
+	var externalIDs []string
+	for _, row := range rows {
+		r := &importedRow{importRow: row, status: apiv1.ImportRowStatus_IMPORT_ROW_STATUS_INVALID}
+		imported = append(imported, r)
+		if r.err != nil {
+			continue
+		}
+
+		ids := make(map[string]string)
+		for _, symbol := range []string{r.asset, r.quoteAsset, r.feeAsset} {
+			id, err := resolve(symbol)
+			if err != nil {
+				return nil, err
+			}
+			switch id {
+			case "":
+				if symbol != "" {
+					r.err = fmt.Errorf("unknown asset %q", symbol)
+				}
+			case "?":
+				r.err = fmt.Errorf("asset symbol %q is ambiguous", symbol)
+			}
+			ids[symbol] = id
+		}
+		if r.err != nil {
+			continue
+		}
+
+		r.tx = importTransaction(accountID, source, &r.importRow, ids)
+		if seen[r.externalID] {
+			r.status = apiv1.ImportRowStatus_IMPORT_ROW_STATUS_DUPLICATE
+			continue
+		}
+		seen[r.externalID] = true
+		r.status = apiv1.ImportRowStatus_IMPORT_ROW_STATUS_NEW
+		externalIDs = append(externalIDs, r.externalID)
+	}
+
+	existing := make(map[string]bool)
+	for batch := range slices.Chunk(externalIDs, externalIDBatch) {
+		txs, _, err := h.store.ListTransactions(ctx, ListTransactionsOpts{
+			AccountID:   accountID,
+			ExternalIDs: batch,
+			PageSize:    len(batch),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range txs {
+			existing[t.ExternalID] = true
+		}
+	}
+	for _, r := range imported {
+		if r.status == apiv1.ImportRowStatus_IMPORT_ROW_STATUS_NEW && existing[r.externalID] {
+			r.status = apiv1.ImportRowStatus_IMPORT_ROW_STATUS_DUPLICATE
+		}
+	}
+	return imported, nil
+}
+
+// importTransaction builds the transaction of a row, given the asset IDs of
+// its symbols.
+func importTransaction(accountID, source string, r *importRow, assetIDs map[string]string) *entity.Transaction {
+	data := map[string]string{
+		"amount":      r.amount.String(),
+		"executed_at": r.at.Format(time.RFC3339),
+		"source":      source,
+	}
+	if r.txType == entity.TransactionTypeTrade {
+		data["side"] = r.side
+		if r.price.IsPositive() {
+			data["price"] = r.price.String()
+		}
+		if r.quoteAsset != "" {
+			data["quote_asset_id"] = assetIDs[r.quoteAsset]
+			if r.side == SideBuy {
+				data["cost"] = r.quoteAmount.String()
+			} else {
+				data["proceeds"] = r.quoteAmount.String()
+			}
+		}
+	}
+	if r.fee.IsPositive() {
+		data["fee"] = r.fee.String()
+		data["fee_asset_id"] = assetIDs[r.feeAsset]
+	}
+	if r.income != "" {
+		data["income"] = r.income
+	}
+	return &entity.Transaction{
+		Type:       r.txType,
+		Status:     entity.TransactionStatusCompleted,
+		AccountID:  accountID,
+		AssetID:    assetIDs[r.asset],
+		ExternalID: r.externalID,
+		Data:       data,
+	}
+}
+
+// countStatuses sets the counts of resp from the statuses of rows.
+func countStatuses(resp *apiv1.ImportTransactionsResponse, rows []*importedRow) {
+	resp.NewCount, resp.DuplicateCount, resp.InvalidCount = 0, 0, 0
+	for _, r := range rows {
+		switch r.status {
+		case apiv1.ImportRowStatus_IMPORT_ROW_STATUS_NEW:
+			resp.NewCount++
+		case apiv1.ImportRowStatus_IMPORT_ROW_STATUS_DUPLICATE:
+			resp.DuplicateCount++
+		default:
+			resp.InvalidCount++
+		}
+	}
+}
+
+func firstInvalid(rows []*importedRow) *importedRow {
+	for _, r := range rows {
+		if r.status == apiv1.ImportRowStatus_IMPORT_ROW_STATUS_INVALID {
+			return r
+		}
+	}
+	return nil
+}
+
+// record is a CSV record and the line it starts on.
+type record struct {
+	line   int
+	fields []string
+}
+
+func (r record) empty() bool {
+	for _, f := range r.fields {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func readRecords(content []byte) ([]record, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	r := csv.NewReader(bytes.NewReader(content))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.TrimLeadingSpace = true
+
+	var records []record
+	for {
+		fields, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read CSV: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+		records = append(records, record{line: line, fields: fields})
+	}
+	if len(records) == 0 {
+		return nil, errors.New("the file is empty")
+	}
+	return records, nil
+}
+
+// header maps column names, compared case-insensitively, to field indexes.
+type header map[string]int
+
+func newHeader(fields []string) header {
+	h := make(header, len(fields))
+	for i, f := range fields {
+		h[strings.ToLower(strings.TrimSpace(f))] = i
+	}
+	return h
+}
+
+// require returns the index of the first of names present, or an error
+// naming the first.
+func (h header) require(names ...string) (int, error) {
+	if i, ok := h.lookup(names...); ok {
+		return i, nil
+	}
+	return 0, fmt.Errorf("missing column %q", names[0])
+}
+
+func (h header) lookup(names ...string) (int, bool) {
+	for _, name := range names {
+		if i, ok := h[strings.ToLower(name)]; ok {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// field returns the trimmed field at i, or "" if the record is short.
+func field(fields []string, i int) string {
+	if i < 0 || i >= len(fields) {
+		return ""
+	}
+	return strings.TrimSpace(fields[i])
+}
+
+// parseDecimal reads an amount, tolerating currency signs and commas that
+// separate thousands. Any other comma, as in the decimal comma of "0,5", is
+// rejected rather than guessed at. An empty field is zero.
+func parseDecimal(s string) (decimal.Decimal, error) {
+	s = strings.TrimSpace(s)
+	s = strings.NewReplacer(" ", "", "$", "", "€", "", "£", "").Replace(s)
+	if s == "" || s == "-" || s == "--" {
+		return decimal.Zero, nil
+	}
+	if strings.Contains(s, ",") {
+		if !thousandsGrouped(s) {
+			return decimal.Zero, fmt.Errorf("invalid amount %q: commas may only separate thousands", s)
+		}
+		s = strings.ReplaceAll(s, ",", "")
+	}
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid amount %q", s)
+	}
+	return d, nil
+}
+
+// thousandsGrouped reports whether the commas in s split the digits before
+// its decimal point into groups of three.
+func thousandsGrouped(s string) bool {
+	whole, _, _ := strings.Cut(s, ".")
+	groups := strings.Split(strings.TrimLeft(whole, "+-"), ",")
+	if len(groups[0]) == 0 || len(groups[0]) > 3 {
+		return false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return false
+		}
+	}
+	return true
+}
+
+// splitAmount splits an amount suffixed with its asset, e.g. "0.5BTC".
+func splitAmount(s string) (decimal.Decimal, string, error) {
+	s = strings.TrimSpace(s)
+	end := strings.LastIndexAny(s, "0123456789.") + 1
+	if end == 0 {
+		return decimal.Zero, "", fmt.Errorf("invalid amount %q", s)
+	}
+	amount, err := parseDecimal(s[:end])
+	if err != nil {
+		return decimal.Zero, "", err
+	}
+	return amount, strings.ToUpper(strings.TrimSpace(s[end:])), nil
+}
+
+// timeLayouts are tried in order when no layout is given. Times without an
+// offset are UTC.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02, 15:04:05",
+	"2006-01-02;15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func parseTime(s, layout string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if layout != "" {
+		t, err := time.ParseInLocation(layout, s, time.UTC)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+		}
+		return t.UTC(), nil
+	}
+	for _, l := range timeLayouts {
+		if t, err := time.ParseInLocation(l, s, time.UTC); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+}
+
+// assignExternalIDs gives rows without an ID at the source one derived from
+// their record, so importing the same file twice finds the duplicates.
+// Identical records are told apart by their order in the file.
+func assignExternalIDs(rows []importRow, records []record) {
+	byLine := make(map[int][]string, len(records))
+	for _, r := range records {
+		byLine[r.line] = r.fields
+	}
+	seen := make(map[string]int)
+	for i := range rows {
+		if rows[i].externalID != "" {
+			continue
+		}
+		sum := sha256.Sum256([]byte(strings.Join(byLine[rows[i].line], "\x1f")))
+		id := "row:" + hex.EncodeToString(sum[:12])
+		seen[id]++
+		if n := seen[id]; n > 1 {
+			id = fmt.Sprintf("%s#%d", id, n)
+		}
+		rows[i].externalID = id
+	}
+}
+
+// --- Generic ---
+
+func parseGeneric(records []record, m *apiv1.ImportColumnMapping) ([]importRow, error) {
+	h := newHeader(records[0].fields)
+	column := func(name string, required bool) (int, error) {
+		if name == "" {
+			if required {
+				return 0, errors.New("column mapping is incomplete: timestamp, asset and amount are required")
+			}
+			return -1, nil
+		}
+		i, ok := h.lookup(name)
+		if !ok {
+			return 0, fmt.Errorf("missing column %q", name)
+		}
+		return i, nil
+	}
+
+	var cols struct {
+		timestamp, asset, amount, txType, price, quoteAsset, quoteAmount, fee, feeAsset, externalID int
+	}
+	var err error
+	for _, c := range []struct {
+		dst      *int
+		name     string
+		required bool
+	}{
+		{&cols.timestamp, m.Timestamp, true},
+		{&cols.asset, m.Asset, true},
+		{&cols.amount, m.Amount, true},
+		{&cols.txType, m.GetType(), false},
+		{&cols.price, m.GetPrice(), false},
+		{&cols.quoteAsset, m.GetQuoteAsset(), false},
+		{&cols.quoteAmount, m.GetQuoteAmount(), false},
+		{&cols.fee, m.GetFee(), false},
+		{&cols.feeAsset, m.GetFeeAsset(), false},
+		{&cols.externalID, m.GetExternalId(), false},
+	} {
+		if *c.dst, err = column(c.name, c.required); err != nil {
+			return nil, err
+		}
+	}
+
+	var rows []importRow
+	for _, rec := range records[1:] {
+		if rec.empty() {
+			continue
+		}
+		f := rec.fields
+		row := importRow{
+			line:       rec.line,
+			externalID: field(f, cols.externalID),
+			asset:      strings.ToUpper(field(f, cols.asset)),
+			quoteAsset: strings.ToUpper(field(f, cols.quoteAsset)),
+			feeAsset:   strings.ToUpper(field(f, cols.feeAsset)),
+		}
+		row.err = func() error {
+			var err error
+			if row.at, err = parseTime(field(f, cols.timestamp), m.GetTimestampLayout()); err != nil {
+				return err
+			}
+			if row.amount, err = parseDecimal(field(f, cols.amount)); err != nil {
+				return err
+			}
+			if row.price, err = parseDecimal(field(f, cols.price)); err != nil {
+				return err
+			}
+			if row.quoteAmount, err = parseDecimal(field(f, cols.quoteAmount)); err != nil {
+				return err
+			}
+			if row.fee, err = parseDecimal(field(f, cols.fee)); err != nil {
+				return err
+			}
+
+			switch t := strings.ToLower(field(f, cols.txType)); t {
+			case SideBuy, SideSell:
+				row.txType, row.side = entity.TransactionTypeTrade, t
+			case "deposit":
+				row.txType = entity.TransactionTypeDeposit
+			case "withdrawal":
+				row.txType = entity.TransactionTypeWithdrawal
+			case "transfer":
+				row.txType = entity.TransactionTypeTransfer
+			case IncomeDividend, IncomeStaking, IncomeInterest, IncomeAirdrop:
+				row.txType, row.income = entity.TransactionTypeIncome, t
+			case "":
+				if cols.txType >= 0 {
+					return errors.New("transaction type is empty")
+				}
+				row.txType = entity.TransactionTypeDeposit
+				if row.amount.IsNegative() {
+					row.txType = entity.TransactionTypeWithdrawal
+				}
+			default:
+				return fmt.Errorf("unknown transaction type %q", t)
+			}
+			row.amount = row.amount.Abs()
+			row.quoteAmount = row.quoteAmount.Abs()
+			row.fee = row.fee.Abs()
+			if row.feeAsset == "" && row.fee.IsPositive() {
+				row.feeAsset = row.quoteAsset
+				if row.feeAsset == "" {
+					row.feeAsset = row.asset
+				}
+			}
+			return nil
+		}()
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// --- Binance ---
+
+// parseBinanceTrades reads the spot trade history export, whose amounts
+// carry their asset, e.g. "0.00100000BTC". It has no trade IDs.
+func parseBinanceTrades(records []record) ([]importRow, error) {
+	h := newHeader(records[0].fields)
+	var cols [7]int
+	for i, name := range []string{"Date(UTC)", "Side", "Price", "Executed", "Amount", "Fee", "Pair"} {
+		var err error
+		if cols[i], err = h.require(name); err != nil {
+			return nil, err
+		}
+	}
+	date, side, price, executed, amount, fee := cols[0], cols[1], cols[2], cols[3], cols[4], cols[5]
+
+	var rows []importRow
+	for _, rec := range records[1:] {
+		if rec.empty() {
+			continue
+		}
+		f := rec.fields
+		row := importRow{line: rec.line, txType: entity.TransactionTypeTrade}
+		row.err = func() error {
+			var err error
+			if row.at, err = parseTime(field(f, date), ""); err != nil {
+				return err
+			}
+			row.side = strings.ToLower(field(f, side))
+			if row.side != SideBuy && row.side != SideSell {
+				return fmt.Errorf("unknown side %q", field(f, side))
+			}
+			if row.price, err = parseDecimal(field(f, price)); err != nil {
+				return err
+			}
+			if row.amount, row.asset, err = splitAmount(field(f, executed)); err != nil {
+				return err
+			}
+			if row.quoteAmount, row.quoteAsset, err = splitAmount(field(f, amount)); err != nil {
+				return err
+			}
+			if field(f, fee) != "" {
+				if row.fee, row.feeAsset, err = splitAmount(field(f, fee)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}()
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// --- Coinbase ---
+
+var coinbaseConvertNote = regexp.MustCompile(`(?i)converted\s+([\d.,]+)\s+(\S+)\s+to\s+([\d.,]+)\s+(\S+)`)
+
+// parseCoinbase reads the transaction history report. The report opens with
+// a few lines of preamble before its header.
+func parseCoinbase(records []record) ([]importRow, error) {
+	start := -1
+	for i, rec := range records {
+		h := newHeader(rec.fields)
+		if _, ok := h["timestamp"]; ok {
+			if _, ok := h["transaction type"]; ok {
+				start = i
+				break
+			}
+		}
+	}
+	if start < 0 {
+		return nil, errors.New(`missing header with "Timestamp" and "Transaction Type" columns`)
+	}
+
+	h := newHeader(records[start].fields)
+	timestamp, _ := h.require("Timestamp")
+	txType, _ := h.require("Transaction Type")
+	asset, err := h.require("Asset")
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := h.require("Quantity Transacted")
+	if err != nil {
+		return nil, err
+	}
+	currency, err := h.require("Price Currency", "Spot Price Currency")
+	if err != nil {
+		return nil, err
+	}
+	price, err := h.require("Price at Transaction", "Spot Price at Transaction")
+	if err != nil {
+		return nil, err
+	}
+	total, err := h.require("Total (inclusive of fees and/or spread)", "Total")
+	if err != nil {
+		return nil, err
+	}
+	fees, err := h.require("Fees and/or Spread", "Fees")
+	if err != nil {
+		return nil, err
+	}
+	id, hasID := h.lookup("ID")
+	if !hasID {
+		id = -1
+	}
+	notes, hasNotes := h.lookup("Notes")
+	if !hasNotes {
+		notes = -1
+	}
+
+	var rows []importRow
+	for _, rec := range records[start+1:] {
+		if rec.empty() {
+			continue
+		}
+		f := rec.fields
+		row := importRow{
+			line:       rec.line,
+			externalID: field(f, id),
+			asset:      strings.ToUpper(field(f, asset)),
+		}
+		row.err = func() error {
+			var err error
+			if row.at, err = parseTime(field(f, timestamp), ""); err != nil {
+				return err
+			}
+			if row.amount, err = parseDecimal(field(f, quantity)); err != nil {
+				return err
+			}
+			if row.price, err = parseDecimal(field(f, price)); err != nil {
+				return err
+			}
+			if row.fee, err = parseDecimal(field(f, fees)); err != nil {
+				return err
+			}
+			var totalAmount decimal.Decimal
+			if totalAmount, err = parseDecimal(field(f, total)); err != nil {
+				return err
+			}
+			row.amount = row.amount.Abs()
+			row.fee = row.fee.Abs()
+			quote := strings.ToUpper(field(f, currency))
+			if row.fee.IsPositive() {
+				row.feeAsset = quote
+			}
+
+			switch t := strings.ToLower(field(f, txType)); t {
+			case "buy", "advanced trade buy", "advance trade buy":
+				row.txType, row.side = entity.TransactionTypeTrade, SideBuy
+				row.quoteAsset, row.quoteAmount = quote, totalAmount.Abs()
+			case "sell", "advanced trade sell", "advance trade sell":
+				row.txType, row.side = entity.TransactionTypeTrade, SideSell
+				row.quoteAsset, row.quoteAmount = quote, totalAmount.Abs()
+			case "convert":
+				m := coinbaseConvertNote.FindStringSubmatch(field(f, notes))
+				if m == nil {
+					return errors.New(`convert without a "Converted X A to Y B" note`)
+				}
+				row.txType, row.side = entity.TransactionTypeTrade, SideSell
+				if row.amount, err = parseDecimal(m[1]); err != nil {
+					return err
+				}
+				row.asset = strings.ToUpper(m[2])
+				if row.quoteAmount, err = parseDecimal(m[3]); err != nil {
+					return err
+				}
+				row.quoteAsset = strings.ToUpper(m[4])
+				row.price = decimal.Zero
+				if row.amount.IsPositive() {
+					row.price = row.quoteAmount.Div(row.amount)
+				}
+			case "send", "withdrawal":
+				row.txType = entity.TransactionTypeWithdrawal
+			case "receive", "deposit":
+				row.txType = entity.TransactionTypeDeposit
+			case "staking income", "inflation reward":
+				row.txType, row.income = entity.TransactionTypeIncome, IncomeStaking
+			case "rewards income":
+				row.txType, row.income = entity.TransactionTypeIncome, IncomeInterest
+			case "learning reward", "coinbase earn":
+				row.txType, row.income = entity.TransactionTypeIncome, IncomeAirdrop
+			default:
+				return fmt.Errorf("unsupported transaction type %q", field(f, txType))
+			}
+			return nil
+		}()
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// --- Kraken ---
+
+// krakenAssets maps Kraken's legacy asset codes to common symbols.
+var krakenAssets = map[string]string{
+	"XXBT": "BTC", "XBT": "BTC", "XBT.M": "BTC",
+	"XXDG": "DOGE", "XDG": "DOGE",
+	"XETH": "ETH", "XETC": "ETC", "XLTC": "LTC", "XXLM": "XLM", "XXMR": "XMR",
+	"XXRP": "XRP", "XZEC": "ZEC", "XREP": "REP", "XMLN": "MLN",
+	"ZUSD": "USD", "ZEUR": "EUR", "ZGBP": "GBP", "ZCAD": "CAD", "ZJPY": "JPY",
+	"ZCHF": "CHF", "ZAUD": "AUD",
+}
+
+// krakenAsset normalizes a Kraken asset code, dropping the suffixes of
+// staked and earning balances.
+func krakenAsset(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if s, ok := krakenAssets[code]; ok {
+		return s
+	}
+	if i := strings.IndexByte(code, '.'); i > 0 {
+		code = code[:i]
+		if s, ok := krakenAssets[code]; ok {
+			return s
+		}
+	}
+	return code
+}
+
+// quoteCurrencies are what trades are usually priced in. A Kraken trade
+// that receives one of them is a sale of the other asset.
+var quoteCurrencies = map[string]bool{
+	"USD": true, "EUR": true, "GBP": true, "CAD": true, "JPY": true, "CHF": true, "AUD": true,
+	"USDT": true, "USDC": true, "DAI": true,
+}
+
+type krakenLeg struct {
+	line   int
+	asset  string
+	amount decimal.Decimal
+	fee    decimal.Decimal
+	at     time.Time
+}
+
+// parseKrakenLedger reads the ledger export, where a trade is two entries
+// sharing a reference ID: the asset spent and the asset received. Entries
+// without a txid are unconfirmed copies of later entries and are ignored.
+func parseKrakenLedger(records []record) ([]importRow, error) {
+	h := newHeader(records[0].fields)
+	var cols [7]int
+	for i, name := range []string{"txid", "refid", "time", "type", "asset", "amount", "fee"} {
+		var err error
+		if cols[i], err = h.require(name); err != nil {
+			return nil, err
+		}
+	}
+	txid, refid, ts, txType, asset, amount, fee := cols[0], cols[1], cols[2], cols[3], cols[4], cols[5], cols[6]
+
+	var rows []importRow
+	trades := make(map[string][]krakenLeg)
+	var tradeOrder []string
+	for _, rec := range records[1:] {
+		f := rec.fields
+		if rec.empty() || field(f, txid) == "" {
+			continue
+		}
+		leg := krakenLeg{line: rec.line, asset: krakenAsset(field(f, asset))}
+		err := func() error {
+			var err error
+			if leg.at, err = parseTime(field(f, ts), ""); err != nil {
+				return err
+			}
+			if leg.amount, err = parseDecimal(field(f, amount)); err != nil {
+				return err
+			}
+			if leg.fee, err = parseDecimal(field(f, fee)); err != nil {
+				return err
+			}
+			return nil
+		}()
+
+		t := strings.ToLower(field(f, txType))
+		if err == nil && (t == "trade" || t == "spend" || t == "receive") {
+			ref := field(f, refid)
+			if _, ok := trades[ref]; !ok {
+				tradeOrder = append(tradeOrder, ref)
+			}
+			trades[ref] = append(trades[ref], leg)
+			continue
+		}
+
+		row := importRow{
+			line:       rec.line,
+			externalID: field(f, txid),
+			at:         leg.at,
+			asset:      leg.asset,
+			amount:     leg.amount.Abs(),
+			fee:        leg.fee.Abs(),
+			err:        err,
+		}
+		if row.fee.IsPositive() {
+			row.feeAsset = leg.asset
+		}
+		if err == nil {
+			switch t {
+			case "deposit":
+				row.txType = entity.TransactionTypeDeposit
+			case "withdrawal":
+				row.txType = entity.TransactionTypeWithdrawal
+			case "transfer":
+				row.txType = entity.TransactionTypeTransfer
+			case "staking", "earn":
+				row.txType, row.income = entity.TransactionTypeIncome, IncomeStaking
+			case "dividend":
+				row.txType, row.income = entity.TransactionTypeIncome, IncomeDividend
+			default:
+				row.err = fmt.Errorf("unsupported ledger type %q", field(f, txType))
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	for _, ref := range tradeOrder {
+		rows = append(rows, krakenTrade(ref, trades[ref]))
+	}
+	return rows, nil
+}
+
+// krakenTrade joins the legs of a trade.
+func krakenTrade(ref string, legs []krakenLeg) importRow {
+	row := importRow{line: legs[0].line, externalID: ref, txType: entity.TransactionTypeTrade, at: legs[0].at}
+	var spent, received *krakenLeg
+	for i := range legs {
+		switch {
+		case legs[i].amount.IsNegative() && spent == nil:
+			spent = &legs[i]
+		case legs[i].amount.IsPositive() && received == nil:
+			received = &legs[i]
+		}
+	}
+	if len(legs) != 2 || spent == nil || received == nil {
+		row.err = fmt.Errorf("trade %s needs one spent and one received entry, found %d entries", ref, len(legs))
+		return row
+	}
+
+	// Price trades in the quote currency when one side is one.
+	base, quote := received, spent
+	row.side = SideBuy
+	if quoteCurrencies[received.asset] && !quoteCurrencies[spent.asset] {
+		base, quote = spent, received
+		row.side = SideSell
+	}
+	row.asset, row.amount = base.asset, base.amount.Abs()
+	row.quoteAsset, row.quoteAmount = quote.asset, quote.amount.Abs()
+	if row.amount.IsPositive() {
+		row.price = row.quoteAmount.Div(row.amount)
+	}
+	for _, leg := range []*krakenLeg{received, spent} {
+		if leg.fee.IsPositive() {
+			row.fee, row.feeAsset = leg.fee, leg.asset
+			break
+		}
+	}
+	return row
+}
+
+// --- Interactive Brokers ---
+
+// parseIBKRActivity reads the Trades, Deposits & Withdrawals and Dividends
+// sections of an activity statement. Each section has its own header row;
+// the statement has no transaction IDs. Times are read as UTC.
+func parseIBKRActivity(records []record) ([]importRow, error) {
+	headers := make(map[string]header)
+	var rows []importRow
+	for _, rec := range records {
+		f := rec.fields
+		if len(f) < 2 {
+			continue
+		}
+		section, kind := field(f, 0), field(f, 1)
+		if kind == "Header" {
+			headers[section] = newHeader(f[2:])
+			continue
+		}
+		if kind != "Data" {
+			continue
+		}
+		h, ok := headers[section]
+		if !ok {
+			continue
+		}
+		data := f[2:]
+		switch section {
+		case "Trades":
+			if row, ok := ibkrTrade(rec.line, h, data); ok {
+				rows = append(rows, row)
+			}
+		case "Deposits & Withdrawals", "Dividends":
+			if row, ok := ibkrCash(rec.line, section, h, data); ok {
+				rows = append(rows, row)
+			}
+		}
+	}
+	if len(headers) == 0 {
+		return nil, errors.New("no statement sections found")
+	}
+	return rows, nil
+}
+
+func ibkrTrade(line int, h header, f []string) (importRow, bool) {
+	if i, ok := h.lookup("DataDiscriminator"); ok && !strings.EqualFold(field(f, i), "Order") {
+		return importRow{}, false
+	}
+	row := importRow{line: line, txType: entity.TransactionTypeTrade}
+	row.err = func() error {
+		cols := make(map[string]int)
+		for _, name := range []string{"Asset Category", "Currency", "Symbol", "Date/Time", "Quantity", "T. Price", "Proceeds", "Comm/Fee"} {
+			i, err := h.require(name)
+			if err != nil {
+				return err
+			}
+			cols[name] = i
+		}
+
+		var err error
+		if row.at, err = parseTime(field(f, cols["Date/Time"]), ""); err != nil {
+			return err
+		}
+		var quantity, proceeds decimal.Decimal
+		if quantity, err = parseDecimal(field(f, cols["Quantity"])); err != nil {
+			return err
+		}
+		if row.price, err = parseDecimal(field(f, cols["T. Price"])); err != nil {
+			return err
+		}
+		if proceeds, err = parseDecimal(field(f, cols["Proceeds"])); err != nil {
+			return err
+		}
+		if row.fee, err = parseDecimal(field(f, cols["Comm/Fee"])); err != nil {
+			return err
+		}
+
+		row.side = SideBuy
+		if quantity.IsNegative() {
+			row.side = SideSell
+		}
+		row.amount, row.quoteAmount, row.fee = quantity.Abs(), proceeds.Abs(), row.fee.Abs()
+		row.asset = strings.ToUpper(field(f, cols["Symbol"]))
+		row.quoteAsset = strings.ToUpper(field(f, cols["Currency"]))
+		// Currency pairs trade as e.g. "EUR.USD".
+		if strings.EqualFold(field(f, cols["Asset Category"]), "Forex") {
+			if base, quote, ok := strings.Cut(row.asset, "."); ok {
+				row.asset, row.quoteAsset = base, quote
+			}
+		}
+		if row.fee.IsPositive() {
+			row.feeAsset = strings.ToUpper(field(f, cols["Currency"]))
+		}
+		return nil
+	}()
+	return row, true
+}
+
+func ibkrCash(line int, section string, h header, f []string) (importRow, bool) {
+	currency, err := h.require("Currency")
+	if err != nil {
+		return importRow{line: line, err: err}, true
+	}
+	// Totals share the section, with the total's name in the first column.
+	if strings.HasPrefix(field(f, currency), "Total") {
+		return importRow{}, false
+	}
+
+	row := importRow{line: line, asset: strings.ToUpper(field(f, currency))}
+	row.err = func() error {
+		date, err := h.require("Settle Date", "Date")
+		if err != nil {
+			return err
+		}
+		amount, err := h.require("Amount")
+		if err != nil {
+			return err
+		}
+		if row.at, err = parseTime(field(f, date), ""); err != nil {
+			return err
+		}
+		if row.amount, err = parseDecimal(field(f, amount)); err != nil {
+			return err
+		}
+		switch {
+		case row.amount.IsNegative():
+			// Includes reversed dividends.
+			row.txType = entity.TransactionTypeWithdrawal
+		case section == "Dividends":
+			row.txType, row.income = entity.TransactionTypeIncome, IncomeDividend
+		default:
+			row.txType = entity.TransactionTypeDeposit
+		}
+		row.amount = row.amount.Abs()
+		return nil
+	}()
+	return row, true
+}
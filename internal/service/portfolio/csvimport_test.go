@@ -0,0 +1,431 @@
+package portfolio
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func parse(t *testing.T, format apiv1.ImportFormat, content string) []importRow {
+	t.Helper()
+	rows, err := parseImport(format, []byte(content), nil)
+	require.NoError(t, err)
+	return rows
+}
+
+func TestParseBinanceTrades(t *testing.T) {
+	rows := parse(t, apiv1.ImportFormat_IMPORT_FORMAT_BINANCE_TRADES, "\xef\xbb\xbf"+
+		"Date(UTC),Pair,Side,Price,Executed,Amount,Fee\n"+
+		"2024-03-01 10:15:00,BTCUSDT,BUY,\"61,000.50\",0.00100000BTC,61.0005USDT,0.00000100BTC\n"+
+		"2024-03-02 11:00:00,BTCUSDT,SELL,62000,0.0005BTC,31USDT,0.031USDT\n"+
+		"2024-03-02 11:00:00,BTCUSDT,HOLD,62000,0.0005BTC,31USDT,0.031USDT\n")
+	require.Len(t, rows, 3)
+
+	buy := rows[0]
+	require.NoError(t, buy.err)
+	assert.Equal(t, 2, buy.line)
+	assert.Equal(t, entity.TransactionTypeTrade, buy.txType)
+	assert.Equal(t, SideBuy, buy.side)
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 15, 0, 0, time.UTC), buy.at)
+	assert.Equal(t, "BTC", buy.asset)
+	assert.True(t, dec("0.001").Equal(buy.amount))
+	assert.True(t, dec("61000.5").Equal(buy.price))
+	assert.Equal(t, "USDT", buy.quoteAsset)
+	assert.True(t, dec("61.0005").Equal(buy.quoteAmount))
+	assert.True(t, dec("0.000001").Equal(buy.fee))
+	assert.Equal(t, "BTC", buy.feeAsset)
+	assert.Regexp(t, `^row:[0-9a-f]{24}$`, buy.externalID)
+
+	assert.Equal(t, SideSell, rows[1].side)
+	assert.Equal(t, "USDT", rows[1].feeAsset)
+	assert.ErrorContains(t, rows[2].err, `unknown side "HOLD"`)
+
+	// The same file yields the same IDs.
+	again := parse(t, apiv1.ImportFormat_IMPORT_FORMAT_BINANCE_TRADES,
+		"Date(UTC),Pair,Side,Price,Executed,Amount,Fee\n"+
+			"2024-03-01 10:15:00,BTCUSDT,BUY,\"61,000.50\",0.00100000BTC,61.0005USDT,0.00000100BTC\n")
+	assert.Equal(t, buy.externalID, again[0].externalID)
+
+	_, err := parseImport(apiv1.ImportFormat_IMPORT_FORMAT_BINANCE_TRADES, []byte("Date,Pair\n"), nil)
+	assert.ErrorContains(t, err, `missing column "Date(UTC)"`)
+}
+
+func TestParseCoinbase(t *testing.T) {
+	rows := parse(t, apiv1.ImportFormat_IMPORT_FORMAT_COINBASE,
+		"Transactions\n"+
+			"User,jane@example.com,abc\n"+
+			"\n"+
+			"ID,Timestamp,Transaction Type,Asset,Quantity Transacted,Price Currency,Price at Transaction,Subtotal,Total (inclusive of fees and/or spread),Fees and/or Spread,Notes\n"+
+			"cb1,2024-01-05 09:00:00 UTC,Buy,ETH,0.5,USD,$2200.00,$1100.00,$1110.00,$10.00,Bought 0.5 ETH\n"+
+			"cb2,2024-01-06 09:00:00 UTC,Convert,ETH,0.1,USD,$2300,$230,$230,$1.50,Converted 0.1 ETH to 0.005 BTC\n"+
+			"cb3,2024-01-07 09:00:00 UTC,Staking Income,SOL,0.02,USD,$100,$2,$2,$0,\n"+
+			"cb4,2024-01-08 09:00:00 UTC,Send,ETH,-0.2,USD,$2300,$460,$460,$0,\n"+
+			"cb5,2024-01-09 09:00:00 UTC,Airdrop,ETH,1,USD,$1,$1,$1,$0,\n")
+	require.Len(t, rows, 5)
+
+	buy := rows[0]
+	require.NoError(t, buy.err)
+	assert.Equal(t, "cb1", buy.externalID)
+	assert.Equal(t, SideBuy, buy.side)
+	assert.Equal(t, "ETH", buy.asset)
+	assert.Equal(t, "USD", buy.quoteAsset)
+	assert.True(t, dec("1110").Equal(buy.quoteAmount))
+	assert.True(t, dec("10").Equal(buy.fee))
+
+	convert := rows[1]
+	require.NoError(t, convert.err)
+	assert.Equal(t, SideSell, convert.side)
+	assert.Equal(t, "ETH", convert.asset)
+	assert.Equal(t, "BTC", convert.quoteAsset)
+	assert.True(t, dec("0.005").Equal(convert.quoteAmount))
+	assert.True(t, dec("0.05").Equal(convert.price))
+
+	assert.Equal(t, entity.TransactionTypeIncome, rows[2].txType)
+	assert.Equal(t, IncomeStaking, rows[2].income)
+	assert.Equal(t, entity.TransactionTypeWithdrawal, rows[3].txType)
+	assert.True(t, dec("0.2").Equal(rows[3].amount))
+	assert.ErrorContains(t, rows[4].err, "unsupported transaction type")
+}
+
+func TestParseKrakenLedger(t *testing.T) {
+	rows := parse(t, apiv1.ImportFormat_IMPORT_FORMAT_KRAKEN_LEDGER,
+		`"txid","refid","time","type","subtype","aclass","asset","amount","fee","balance"`+"\n"+
+			`"L1","R1","2024-02-01 12:00:00","deposit","","currency","ZEUR","1000.0000","0.0000","1000.0000"`+"\n"+
+			`"","R1","2024-02-01 11:59:00","deposit","","currency","ZEUR","1000.0000","0.0000",""`+"\n"+
+			`"L2","T1","2024-02-02 12:00:00","trade","","currency","ZEUR","-500.0000","1.3000","498.7000"`+"\n"+
+			`"L3","T1","2024-02-02 12:00:00","trade","","currency","XXBT","0.0100000000","0.0000000000","0.0100000000"`+"\n"+
+			`"L4","S1","2024-02-03 12:00:00","staking","","currency","DOT.S","0.5","0","0.5"`+"\n"+
+			`"L5","T2","2024-02-04 12:00:00","trade","","currency","XXBT","-0.005","0","0.005"`+"\n"+
+			`"L6","T2","2024-02-04 12:00:00","trade","","currency","ZUSD","300","0.5","299.5"`+"\n")
+	require.Len(t, rows, 4)
+
+	deposit := rows[0]
+	require.NoError(t, deposit.err)
+	assert.Equal(t, "L1", deposit.externalID)
+	assert.Equal(t, entity.TransactionTypeDeposit, deposit.txType)
+	assert.Equal(t, "EUR", deposit.asset)
+
+	assert.Equal(t, "DOT", rows[1].asset)
+	assert.Equal(t, entity.TransactionTypeIncome, rows[1].txType)
+	assert.Equal(t, IncomeStaking, rows[1].income)
+
+	buy := rows[2]
+	require.NoError(t, buy.err)
+	assert.Equal(t, "T1", buy.externalID)
+	assert.Equal(t, SideBuy, buy.side)
+	assert.Equal(t, "BTC", buy.asset)
+	assert.True(t, dec("0.01").Equal(buy.amount))
+	assert.Equal(t, "EUR", buy.quoteAsset)
+	assert.True(t, dec("500").Equal(buy.quoteAmount))
+	assert.True(t, dec("50000").Equal(buy.price))
+	assert.True(t, dec("1.3").Equal(buy.fee))
+	assert.Equal(t, "EUR", buy.feeAsset)
+
+	sell := rows[3]
+	require.NoError(t, sell.err)
+	assert.Equal(t, SideSell, sell.side)
+	assert.Equal(t, "BTC", sell.asset)
+	assert.Equal(t, "USD", sell.quoteAsset)
+	assert.True(t, dec("60000").Equal(sell.price))
+	assert.Equal(t, "USD", sell.feeAsset)
+}
+
+func TestParseIBKRActivity(t *testing.T) {
+	rows := parse(t, apiv1.ImportFormat_IMPORT_FORMAT_IBKR_ACTIVITY,
+		"Statement,Header,Field Name,Field Value\n"+
+			"Statement,Data,Period,\"January 1, 2024 - January 31, 2024\"\n"+
+			"Trades,Header,DataDiscriminator,Asset Category,Currency,Symbol,Date/Time,Quantity,T. Price,C. Price,Proceeds,Comm/Fee,Basis,Realized P/L,MTM P/L,Code\n"+
+			"Trades,Data,Order,Stocks,USD,AAPL,\"2024-01-10, 10:30:00\",10,185.5,186,-1855,-1,1856,0,5,O\n"+
+			"Trades,Data,Order,Stocks,USD,AAPL,\"2024-01-20, 10:30:00\",-4,190,190,760,-1,-742.4,16.6,0,C\n"+
+			"Trades,SubTotal,,Stocks,USD,AAPL,,6,,,-1095,-2,,,,\n"+
+			"Trades,Data,Order,Forex,USD,EUR.USD,\"2024-01-11, 09:00:00\",1000,1.09,1.09,-1090,-2,,,,\n"+
+			"Deposits & Withdrawals,Header,Currency,Settle Date,Description,Amount\n"+
+			"Deposits & Withdrawals,Data,USD,2024-01-02,Electronic Fund Transfer,5000\n"+
+			"Deposits & Withdrawals,Data,Total,,,5000\n"+
+			"Dividends,Header,Currency,Date,Description,Amount\n"+
+			"Dividends,Data,USD,2024-01-25,AAPL Cash Dividend,2.40\n")
+	require.Len(t, rows, 5)
+
+	buy := rows[0]
+	require.NoError(t, buy.err)
+	assert.Equal(t, SideBuy, buy.side)
+	assert.Equal(t, "AAPL", buy.asset)
+	assert.True(t, dec("10").Equal(buy.amount))
+	assert.Equal(t, "USD", buy.quoteAsset)
+	assert.True(t, dec("1855").Equal(buy.quoteAmount))
+	assert.True(t, dec("1").Equal(buy.fee))
+	assert.Equal(t, time.Date(2024, 1, 10, 10, 30, 0, 0, time.UTC), buy.at)
+
+	assert.Equal(t, SideSell, rows[1].side)
+	assert.True(t, dec("4").Equal(rows[1].amount))
+
+	fx := rows[2]
+	assert.Equal(t, "EUR", fx.asset)
+	assert.Equal(t, "USD", fx.quoteAsset)
+
+	assert.Equal(t, entity.TransactionTypeDeposit, rows[3].txType)
+	assert.True(t, dec("5000").Equal(rows[3].amount))
+	assert.Equal(t, entity.TransactionTypeIncome, rows[4].txType)
+	assert.Equal(t, IncomeDividend, rows[4].income)
+}
+
+func TestParseGeneric(t *testing.T) {
+	content := "when,what,qty,kind,ref\n" +
+		"01/02/2024,btc,0.5,buy,a1\n" +
+		"02/02/2024,eth,-2,withdrawal,a2\n" +
+		"03/02/2024,eth,2,swap,a3\n" +
+		"2024-02-04,eth,2,deposit,a4\n" +
+		"05/02/2024,sol,0.1,Staking,a5\n"
+	mapping := &apiv1.ImportColumnMapping{
+		Timestamp:       "when",
+		Asset:           "What",
+		Amount:          "qty",
+		Type:            proto.String("kind"),
+		ExternalId:      proto.String("ref"),
+		TimestampLayout: proto.String("02/01/2006"),
+	}
+	rows, err := parseImport(apiv1.ImportFormat_IMPORT_FORMAT_GENERIC, []byte(content), mapping)
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+
+	require.NoError(t, rows[0].err)
+	assert.Equal(t, "a1", rows[0].externalID)
+	assert.Equal(t, SideBuy, rows[0].side)
+	assert.Equal(t, "BTC", rows[0].asset)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), rows[0].at)
+	assert.Equal(t, entity.TransactionTypeWithdrawal, rows[1].txType)
+	assert.True(t, dec("2").Equal(rows[1].amount))
+	assert.ErrorContains(t, rows[2].err, `unknown transaction type "swap"`)
+	assert.ErrorContains(t, rows[3].err, "invalid timestamp")
+	require.NoError(t, rows[4].err)
+	assert.Equal(t, entity.TransactionTypeIncome, rows[4].txType)
+	assert.Equal(t, IncomeStaking, rows[4].income)
+
+	_, err = parseImport(apiv1.ImportFormat_IMPORT_FORMAT_GENERIC, []byte(content), nil)
+	assert.ErrorContains(t, err, "column mapping is required")
+	_, err = parseImport(apiv1.ImportFormat_IMPORT_FORMAT_GENERIC, []byte(content), &apiv1.ImportColumnMapping{Timestamp: "when", Asset: "what", Amount: "amount"})
+	assert.ErrorContains(t, err, `missing column "amount"`)
+}
+
+func TestParseDecimal(t *testing.T) {
+	for in, want := range map[string]string{
+		"1,234.5":    "1234.5",
+		"-$1,000":    "-1000",
+		"12,345,678": "12345678",
+		"€ 0.5":      "0.5",
+		"":           "0",
+	} {
+		got, err := parseDecimal(in)
+		require.NoError(t, err, in)
+		assert.True(t, dec(want).Equal(got), "%s: got %s", in, got)
+	}
+	for _, in := range []string{"0,5", "1,5", "1,23", "1234,567", ",123", "1,234,5"} {
+		_, err := parseDecimal(in)
+		assert.ErrorContains(t, err, "commas may only separate thousands", in)
+	}
+}
+
+// importStore knows one account and the external IDs imported into it.
+type importStore struct {
+	Store
+	imported map[string]bool
+	created  []*entity.Transaction
+}
+
+func (s *importStore) GetAccount(_ context.Context, id string) (*entity.Account, error) {
+	return &entity.Account{ID: id}, nil
+}
+
+func (s *importStore) ListTransactions(_ context.Context, opts ListTransactionsOpts) ([]*entity.Transaction, string, error) {
+	var txs []*entity.Transaction
+	for _, id := range opts.ExternalIDs {
+		if s.imported[id] {
+			txs = append(txs, &entity.Transaction{ExternalID: id})
+		}
+	}
+	return txs, "", nil
+}
+
+func (s *importStore) CreateTransactions(_ context.Context, txs []*entity.Transaction) (int, error) {
+	for _, t := range txs {
+		t.ID = "tx-" + t.ExternalID
+		s.created = append(s.created, t)
+	}
+	return len(txs), nil
+}
+
+// fakeMarketData knows a few assets, their identifiers and latest prices.
+type fakeMarketData struct {
+	assets      []*entity.Asset
+	identifiers []*entity.AssetIdentifier
+	prices      []*entity.StoredPrice
+}
+
+func (m *fakeMarketData) GetAsset(_ context.Context, id string) (*entity.Asset, error) {
+	for _, a := range m.assets {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, store.ErrNotFound
+}
+
+func (m *fakeMarketData) ListAssets(_ context.Context, opts marketdata.ListAssetsOpts) ([]*entity.Asset, string, error) {
+	var found []*entity.Asset
+	for _, a := range m.assets {
+		if want := opts.Identifier; want != nil {
+			for _, i := range m.identifiers {
+				if i.AssetID == a.ID && i.Kind == want.Kind && i.Value == want.Value {
+					found = append(found, a)
+				}
+			}
+		} else if opts.Type != entity.AssetTypeUnspecified {
+			if a.Type == opts.Type {
+				found = append(found, a)
+			}
+		} else if strings.EqualFold(a.Symbol, opts.Symbol) {
+			found = append(found, a)
+		}
+	}
+	return found, "", nil
+}
+
+func (m *fakeMarketData) GetPriceAt(_ context.Context, assetID, baseAssetID string, at time.Time) (*entity.StoredPrice, error) {
+	var found *entity.StoredPrice
+	for _, p := range m.prices {
+		if p.AssetID != assetID || baseAssetID != "" && p.BaseAssetID != baseAssetID || p.Timestamp.After(at) {
+			continue
+		}
+		if found == nil || p.Timestamp.After(found.Timestamp) {
+			found = p
+		}
+	}
+	if found == nil {
+		return nil, store.ErrNotFound
+	}
+	return found, nil
+}
+
+func TestImportTransactions(t *testing.T) {
+	ctx := context.Background()
+	s := &importStore{imported: map[string]bool{"old": true}}
+	h := NewHandler(s, &fakeMarketData{assets: []*entity.Asset{
+		{ID: "btc", Symbol: "BTC"},
+		{ID: "usdt", Symbol: "USDT"},
+		{ID: "xyz1", Symbol: "XYZ"},
+		{ID: "xyz2", Symbol: "XYZ"},
+	}}, nil, TaxConfig{}, ImportConfig{}, nil)
+
+	content := "time,asset,amount,type,quote,total,id\n" +
+		"2024-01-01,BTC,0.1,buy,USDT,4000,new\n" +
+		"2024-01-01,BTC,0.1,buy,USDT,4000,new\n" +
+		"2024-01-02,BTC,0.1,sell,USDT,4100,old\n" +
+		"2024-01-03,DOGE,100,deposit,,,unknown\n" +
+		"2024-01-03,XYZ,100,deposit,,,ambiguous\n"
+	req := &apiv1.ImportTransactionsRequest{
+		AccountId: "acc",
+		Format:    apiv1.ImportFormat_IMPORT_FORMAT_GENERIC,
+		Content:   []byte(content),
+		Mapping: &apiv1.ImportColumnMapping{
+			Timestamp: "time", Asset: "asset", Amount: "amount",
+			Type: proto.String("type"), QuoteAsset: proto.String("quote"), QuoteAmount: proto.String("total"), ExternalId: proto.String("id"),
+		},
+		DryRun: true,
+	}
+
+	resp, err := h.ImportTransactions(ctx, connect.NewRequest(req))
+	require.NoError(t, err)
+	assert.False(t, resp.Msg.Committed)
+	assert.EqualValues(t, 1, resp.Msg.NewCount)
+	assert.EqualValues(t, 2, resp.Msg.DuplicateCount)
+	assert.EqualValues(t, 2, resp.Msg.InvalidCount)
+	assert.Empty(t, s.created)
+
+	rows := resp.Msg.Rows
+	require.Len(t, rows, 5)
+	assert.Equal(t, apiv1.ImportRowStatus_IMPORT_ROW_STATUS_NEW, rows[0].Status)
+	assert.Equal(t, "btc", rows[0].Transaction.GetAssetId())
+	assert.Equal(t, map[string]string{
+		"side":           "buy",
+		"amount":         "0.1",
+		"quote_asset_id": "usdt",
+		"cost":           "4000",
+		"executed_at":    "2024-01-01T00:00:00Z",
+		"source":         "csv",
+	}, rows[0].Transaction.Data)
+	assert.Equal(t, apiv1.ImportRowStatus_IMPORT_ROW_STATUS_DUPLICATE, rows[1].Status)
+	assert.Equal(t, apiv1.ImportRowStatus_IMPORT_ROW_STATUS_DUPLICATE, rows[2].Status)
+	assert.Equal(t, `unknown asset "DOGE"`, rows[3].GetError())
+	assert.Equal(t, `asset symbol "XYZ" is ambiguous`, rows[4].GetError())
+
+	// Invalid rows fail the import unless skipped.
+	req.DryRun = false
+	_, err = h.ImportTransactions(ctx, connect.NewRequest(req))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 invalid rows, first at line 5")
+	assert.Empty(t, s.created)
+
+	req.SkipInvalid = true
+	resp, err = h.ImportTransactions(ctx, connect.NewRequest(req))
+	require.NoError(t, err)
+	assert.True(t, resp.Msg.Committed)
+	require.Len(t, s.created, 1)
+	assert.Equal(t, "new", s.created[0].ExternalID)
+	assert.Equal(t, "tx-new", resp.Msg.Rows[0].Transaction.Id)
+}
+
+func TestImportTransactions_Identifiers(t *testing.T) {
+	// Binance codes resolve through Binance identifiers before symbols.
+	h := NewHandler(&importStore{}, &fakeMarketData{
+		assets: []*entity.Asset{
+			{ID: "btc", Symbol: "BTC"},
+			{ID: "btc-bep2", Symbol: "BTC"},
+			{ID: "usdt", Symbol: "USDT"},
+		},
+		identifiers: []*entity.AssetIdentifier{
+			{AssetID: "btc", Kind: entity.IdentifierKindBinance, Value: "BTC"},
+		},
+	}, nil, TaxConfig{}, ImportConfig{}, nil)
+
+	resp, err := h.ImportTransactions(context.Background(), connect.NewRequest(&apiv1.ImportTransactionsRequest{
+		AccountId: "acc",
+		Format:    apiv1.ImportFormat_IMPORT_FORMAT_BINANCE_TRADES,
+		Content: []byte("Date(UTC),Pair,Side,Price,Executed,Amount,Fee\n" +
+			"2024-03-01 10:15:00,BTCUSDT,BUY,61000,0.001BTC,61USDT,0.000001BTC\n"),
+		DryRun: true,
+	}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Rows, 1)
+	row := resp.Msg.Rows[0]
+	require.Equal(t, apiv1.ImportRowStatus_IMPORT_ROW_STATUS_NEW, row.Status, row.GetError())
+	assert.Equal(t, "btc", row.Transaction.GetAssetId())
+	assert.Equal(t, "usdt", row.Transaction.Data["quote_asset_id"])
+}
+
+func TestImportTransactions_TooLarge(t *testing.T) {
+	s := &importStore{}
+	h := NewHandler(s, &fakeMarketData{}, nil, TaxConfig{}, ImportConfig{MaxBytes: 64}, nil)
+
+	_, err := h.ImportTransactions(context.Background(), connect.NewRequest(&apiv1.ImportTransactionsRequest{
+		AccountId: "acc",
+		Format:    apiv1.ImportFormat_IMPORT_FORMAT_BINANCE_TRADES,
+		Content:   []byte(strings.Repeat("2024-03-01 10:15:00,BTCUSDT,BUY,61000,0.001BTC,61USDT,0.000001BTC\n", 2)),
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	assert.Empty(t, s.created)
+}
@@ -0,0 +1,191 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/shopspring/decimal"
+)
+
+// dividendExternalIDPrefix prefixes the external IDs of dividend income,
+// followed by the asset and the ex-date.
+const dividendExternalIDPrefix = "dividend:"
+
+// RunDividendIncome records the income of stored dividends now and then
+// every interval until ctx is done.
+func (h *Handler) RunDividendIncome(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := h.RecordDividendIncome(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			h.log.Error("Failed to record dividend income", slog.Any("error", err))
+		} else if n > 0 {
+			h.log.Info("Recorded dividend income", "transactions", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RecordDividendIncome records an income transaction in the dividend's
+// currency for each account that held the asset on the ex-date of a
+// dividend that went ex by now, for the units it held then. Tax is withheld
+// at the rate configured for the currency. Each dividend is recorded all or
+// nothing, once. It returns how many transactions it recorded.
+func (h *Handler) RecordDividendIncome(ctx context.Context, now time.Time) (int, error) {
+	dividends, err := h.store.ListUnrecordedDividends(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	recorded := 0
+	for _, d := range dividends {
+		txs, err := h.dividendTransactions(ctx, d)
+		if err != nil {
+			return recorded, fmt.Errorf("dividend of asset %s on %s: %w", d.AssetID, d.ExDate.Format(time.DateOnly), err)
+		}
+		n, err := h.store.RecordDividend(ctx, d, txs)
+		recorded += n
+		if err != nil {
+			return recorded, fmt.Errorf("dividend of asset %s on %s: %w", d.AssetID, d.ExDate.Format(time.DateOnly), err)
+		}
+	}
+	return recorded, nil
+}
+
+// dividendTransactions returns the income transactions of d, one for each
+// account with units of the asset on the ex-date.
+func (h *Handler) dividendTransactions(ctx context.Context, d *entity.Dividend) ([]*entity.Transaction, error) {
+	units, accountIDs, err := h.unitsOnExDate(ctx, d.AssetID, d.ExDate)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := h.withholdingRate(ctx, d.CurrencyAssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var txs []*entity.Transaction
+	for _, accountID := range accountIDs {
+		held := units[accountID]
+		if !held.IsPositive() {
+			continue
+		}
+		// The amount is what was received, after the tax withheld.
+		gross := held.Mul(d.PerShare)
+		withheld := gross.Mul(rate).Round(convertedPlaces)
+		amount := gross.Sub(withheld)
+		data := map[string]string{
+			"income":          IncomeDividend,
+			"amount":          amount.String(),
+			"value":           amount.String(),
+			"value_asset_id":  d.CurrencyAssetID,
+			"executed_at":     d.ExDate.UTC().Format(time.RFC3339),
+			"source_asset_id": d.AssetID,
+			"per_share":       d.PerShare.String(),
+			"units":           held.String(),
+		}
+		if withheld.IsPositive() {
+			data["withholding_tax"] = withheld.String()
+		}
+		txs = append(txs, &entity.Transaction{
+			Type:       entity.TransactionTypeIncome,
+			Status:     entity.TransactionStatusCompleted,
+			AccountID:  accountID,
+			AssetID:    d.CurrencyAssetID,
+			ExternalID: dividendExternalIDPrefix + d.AssetID + ":" + d.ExDate.Format(time.DateOnly),
+			Data:       data,
+		})
+	}
+	return txs, nil
+}
+
+// unitsOnExDate returns the units of an asset each account held at the
+// start of exDate: what it holds now less what its transactions added since.
+// An account whose holdings of the asset were all created from exDate on,
+// with no transaction of it before, did not hold it. Accounts are in the
+// order of their first holding.
+func (h *Handler) unitsOnExDate(ctx context.Context, assetID string, exDate time.Time) (map[string]decimal.Decimal, []string, error) {
+	units := make(map[string]decimal.Decimal)
+	heldBefore := make(map[string]bool)
+	var accountIDs []string
+	for token := ""; ; {
+		holdings, next, err := h.store.ListHoldings(ctx, ListHoldingsOpts{AssetID: assetID, PageSize: exportPageSize, PageToken: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, holding := range holdings {
+			if _, ok := units[holding.AccountID]; !ok {
+				accountIDs = append(accountIDs, holding.AccountID)
+			}
+			units[holding.AccountID] = units[holding.AccountID].Add(amountToDecimal(holding.Amount, holding.Decimals))
+			if holding.CreatedAt.Before(exDate) {
+				heldBefore[holding.AccountID] = true
+			}
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+
+	for _, accountID := range accountIDs {
+		for token := ""; ; {
+			txs, next, err := h.store.ListTransactions(ctx, ListTransactionsOpts{
+				AccountID: accountID,
+				AssetID:   assetID,
+				PageSize:  exportPageSize,
+				PageToken: token,
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, t := range txs {
+				if t.Status != entity.TransactionStatusCompleted {
+					continue
+				}
+				if TransactionTime(t).Before(exDate) {
+					heldBefore[accountID] = true
+					continue
+				}
+				for _, leg := range transactionLegs(t) {
+					if leg.leg == legBase && leg.assetID == assetID {
+						units[accountID] = units[accountID].Sub(leg.amount)
+					}
+				}
+			}
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		if !heldBefore[accountID] {
+			delete(units, accountID)
+		}
+	}
+	return units, accountIDs, nil
+}
+
+// withholdingRate returns the rate of tax withheld from dividends paid in
+// the currency asset, zero unless one is configured for its code.
+func (h *Handler) withholdingRate(ctx context.Context, currencyAssetID string) (decimal.Decimal, error) {
+	if len(h.tax.DividendWithholding) == 0 {
+		return decimal.Zero, nil
+	}
+	currency, err := h.marketData.GetAsset(ctx, currencyAssetID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	for code, rate := range h.tax.DividendWithholding {
+		if strings.EqualFold(code, currency.Symbol) {
+			return decimal.NewFromFloat(rate), nil
+		}
+	}
+	return decimal.Zero, nil
+}
@@ -0,0 +1,113 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dividendStore keeps dividends and the transactions recorded for them on
+// top of a ledger.
+type dividendStore struct {
+	ledgerStore
+	dividends []*entity.Dividend
+	recorded  map[*entity.Dividend]bool
+}
+
+func (s *dividendStore) ListTransactions(_ context.Context, opts ListTransactionsOpts) ([]*entity.Transaction, string, error) {
+	var found []*entity.Transaction
+	for _, t := range s.transactions {
+		if t.AccountID == opts.AccountID && (opts.AssetID == "" || t.AssetID == opts.AssetID) {
+			found = append(found, t)
+		}
+	}
+	return found, "", nil
+}
+
+func (s *dividendStore) ListUnrecordedDividends(_ context.Context, before time.Time) ([]*entity.Dividend, error) {
+	var found []*entity.Dividend
+	for _, d := range s.dividends {
+		if !s.recorded[d] && d.ExDate.Before(before) {
+			found = append(found, d)
+		}
+	}
+	return found, nil
+}
+
+func (s *dividendStore) RecordDividend(_ context.Context, d *entity.Dividend, txs []*entity.Transaction) (int, error) {
+	created := 0
+	for _, t := range txs {
+		if !slices.ContainsFunc(s.transactions, func(stored *entity.Transaction) bool {
+			return stored.AccountID == t.AccountID && stored.ExternalID == t.ExternalID
+		}) {
+			t.ID = fmt.Sprintf("t%d", len(s.transactions)+1)
+			s.transactions = append(s.transactions, t)
+			created++
+		}
+	}
+	s.recorded[d] = true
+	return created, nil
+}
+
+func TestRecordDividendIncome(t *testing.T) {
+	exDate := time.Date(2025, time.May, 12, 0, 0, 0, 0, time.UTC)
+	january, june := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	trade := func(account, side, amount string, at time.Time) *entity.Transaction {
+		return &entity.Transaction{
+			Type: entity.TransactionTypeTrade, Status: entity.TransactionStatusCompleted, AccountID: account, AssetID: "aapl",
+			Data: map[string]string{"side": side, "amount": amount, "executed_at": at.Format(time.RFC3339)},
+		}
+	}
+	s := &dividendStore{
+		ledgerStore: ledgerStore{
+			holdings: []*entity.Holding{
+				{ID: "h1", AccountID: "a1", AssetID: "aapl", Amount: 10, CreatedAt: january},
+				{ID: "h2", AccountID: "a2", AssetID: "aapl", Amount: 0, CreatedAt: january},
+				{ID: "h3", AccountID: "a3", AssetID: "aapl", Amount: 3, CreatedAt: june},
+				{ID: "h4", AccountID: "a4", AssetID: "aapl", Amount: 2, CreatedAt: june},
+			},
+			transactions: []*entity.Transaction{
+				trade("a1", SideBuy, "4", exDate.AddDate(0, 0, 8)),
+				trade("a2", SideSell, "5", exDate.AddDate(0, 0, 1)),
+				// Imported after the ex-date, bought before it.
+				trade("a4", SideBuy, "2", exDate.AddDate(0, -1, 0)),
+			},
+		},
+		dividends: []*entity.Dividend{{AssetID: "aapl", CurrencyAssetID: "usd", ExDate: exDate, PerShare: dec("0.25")}},
+		recorded:  map[*entity.Dividend]bool{},
+	}
+	h := NewHandler(s, newFXMarketData(), nil, TaxConfig{DividendWithholding: map[string]float64{"usd": 0.15}}, ImportConfig{},
+		slog.New(slog.DiscardHandler))
+
+	n, err := h.RecordDividendIncome(context.Background(), exDate.AddDate(0, 0, 30))
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	// a1 held 6 before buying 4, a2 sold its 5 after the ex-date, a3 bought
+	// after it and a4 before it.
+	income := s.transactions[3:]
+	require.Len(t, income, 3)
+	assert.Equal(t, []string{"a1", "a2", "a4"}, []string{income[0].AccountID, income[1].AccountID, income[2].AccountID})
+	assert.Equal(t, entity.TransactionTypeIncome, income[0].Type)
+	assert.Equal(t, "usd", income[0].AssetID)
+	assert.Equal(t, "dividend:aapl:2025-05-12", income[0].ExternalID)
+	assert.Equal(t, map[string]string{
+		"income": "dividend", "amount": "1.275", "value": "1.275", "value_asset_id": "usd",
+		"executed_at": "2025-05-12T00:00:00Z", "source_asset_id": "aapl", "per_share": "0.25",
+		"units": "6", "withholding_tax": "0.225",
+	}, income[0].Data)
+	assert.Equal(t, "5", income[1].Data["units"])
+	assert.Equal(t, "0.425", income[2].Data["amount"])
+
+	// A dividend is recorded once.
+	n, err = h.RecordDividendIncome(context.Background(), exDate.AddDate(0, 0, 30))
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
@@ -0,0 +1,527 @@
+package portfolio
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// exportPageSize is how many records an export reads per query, so memory
+// use does not grow with the ledger.
+const exportPageSize = 500
+
+// exportChunkSize is how much output is buffered before it is sent.
+const exportChunkSize = 32 << 10
+
+// Legs of an exported transaction.
+const (
+	legBase  = "base"  // The transaction's asset
+	legQuote = "quote" // What a trade paid or received for it
+	legFee   = "fee"
+)
+
+// TransactionTime is when t was executed: its "executed_at" data field, set
+// for transactions recorded after the fact, or else its creation time.
+func TransactionTime(t *entity.Transaction) time.Time {
+	if at, err := time.Parse(time.RFC3339, t.Data["executed_at"]); err == nil {
+		return at
+	}
+	return t.CreatedAt
+}
+
+// exportLeg is an asset movement of a transaction. Amounts into the account
+// are positive.
+type exportLeg struct {
+	leg     string
+	assetID string
+	amount  decimal.Decimal
+}
+
+// transactionLegs splits t into its asset movements, read from the data
+// fields that trades and imports record. Fields that are missing or
+// malformed leave their leg out.
+func transactionLegs(t *entity.Transaction) []exportLeg {
+	var legs []exportLeg
+	side := t.Data["side"]
+	if amount, err := parseDecimal(t.Data["amount"]); err == nil && !amount.IsZero() && t.AssetID != "" {
+		switch {
+		case t.Type == entity.TransactionTypeWithdrawal, t.Type == entity.TransactionTypeTrade && side == SideSell:
+			amount = amount.Abs().Neg()
+		case t.Type == entity.TransactionTypeDeposit, t.Type == entity.TransactionTypeIncome, t.Type == entity.TransactionTypeTrade && side == SideBuy:
+			amount = amount.Abs()
+		}
+		legs = append(legs, exportLeg{leg: legBase, assetID: t.AssetID, amount: amount})
+	}
+
+	quoteAssetID := t.Data["quote_asset_id"]
+	if t.Type == entity.TransactionTypeTrade && quoteAssetID != "" {
+		var quote decimal.Decimal
+		var err error
+		switch side {
+		case SideBuy:
+			quote, err = parseDecimal(t.Data["cost"])
+			quote = quote.Abs().Neg()
+		case SideSell:
+			quote, err = parseDecimal(t.Data["proceeds"])
+			quote = quote.Abs()
+		}
+		if err == nil && !quote.IsZero() {
+			legs = append(legs, exportLeg{leg: legQuote, assetID: quoteAssetID, amount: quote})
+		}
+	}
+
+	if fee, err := parseDecimal(t.Data["fee"]); err == nil && !fee.IsZero() {
+		feeAssetID := t.Data["fee_asset_id"]
+		if feeAssetID == "" {
+			feeAssetID = quoteAssetID
+		}
+		if feeAssetID == "" {
+			feeAssetID = t.AssetID
+		}
+		if feeAssetID != "" {
+			legs = append(legs, exportLeg{leg: legFee, assetID: feeAssetID, amount: fee.Abs().Neg()})
+		}
+	}
+	return legs
+}
+
+// valuedHolding is a holding with its value and cost in the quote asset.
+type valuedHolding struct {
+	*entity.Holding
+	amount    decimal.Decimal
+	price     decimal.Decimal
+	value     decimal.Decimal
+	costBasis decimal.Decimal
+	priced    bool // price and value are known
+	costKnown bool // costBasis is known for the whole amount
+}
+
+func (v *valuedHolding) unrealized() (decimal.Decimal, bool) {
+	return v.value.Sub(v.costBasis), v.priced && v.costKnown
+}
+
+// exportSummary totals an export. Cost basis and unrealized P&L cover the
+// holdings whose cost and price are both known; realized P&L covers sells
+// that recorded their gain in an asset convertible to the quote asset.
+type exportSummary struct {
+	value        decimal.Decimal
+	costBasis    decimal.Decimal
+	unrealized   decimal.Decimal
+	realized     decimal.Decimal
+	transactions int
+}
+
+// export is a portfolio export with its holdings loaded and valued. The
+// transactions are read page by page as the file is written.
+type export struct {
+	h            *Handler
+	portfolio    *entity.Portfolio
+	format       apiv1.ExportFormat
+	from, to     *time.Time
+	quoteAssetID string
+	asOf         time.Time
+	rates        *rates
+	accountIDs   []string                    // In order of their first holding
+	holdings     map[string][]*valuedHolding // By account ID
+	assets       map[string]*entity.Asset    // Nil for unknown assets
+}
+
+// newExport validates req and loads the portfolio's holdings, so errors
+// surface before any output is written.
+func (h *Handler) newExport(ctx context.Context, req *apiv1.ExportPortfolioRequest) (*export, error) {
+	if req.PortfolioId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("portfolio ID is required"))
+	}
+	switch req.Format {
+	case apiv1.ExportFormat_EXPORT_FORMAT_CSV, apiv1.ExportFormat_EXPORT_FORMAT_JSONL, apiv1.ExportFormat_EXPORT_FORMAT_OFX:
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("format is required"))
+	}
+
+	e := &export{
+		h:            h,
+		format:       req.Format,
+		quoteAssetID: req.GetQuoteAssetId(),
+		asOf:         time.Now().UTC(),
+		rates:        newRates(h.marketData),
+		holdings:     make(map[string][]*valuedHolding),
+		assets:       make(map[string]*entity.Asset),
+	}
+	if req.From != nil {
+		from := req.From.AsTime()
+		e.from = &from
+	}
+	if req.To != nil {
+		to := req.To.AsTime()
+		e.to = &to
+	}
+	if e.from != nil && e.to != nil && !e.from.Before(*e.to) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("from must be before to"))
+	}
+
+	var err error
+	if e.portfolio, err = h.store.GetPortfolio(ctx, req.PortfolioId); err != nil {
+		return nil, toConnectError(err)
+	}
+	if e.quoteAssetID == "" {
+		if e.quoteAssetID, err = h.defaultCurrency(ctx, cmp.Or(auth.OwnerID(ctx, ""), e.portfolio.UserID)); err != nil {
+			return nil, toConnectError(err)
+		}
+	}
+	if e.format == apiv1.ExportFormat_EXPORT_FORMAT_OFX && e.quoteAssetID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("OFX exports require a quote asset or a default currency"))
+	}
+	if e.quoteAssetID != "" {
+		if e.assets[e.quoteAssetID], err = h.marketData.GetAsset(ctx, e.quoteAssetID); err != nil {
+			return nil, toConnectError(err)
+		}
+	}
+	if err := e.loadHoldings(ctx); err != nil {
+		return nil, toConnectError(err)
+	}
+	return e, nil
+}
+
+func (e *export) loadHoldings(ctx context.Context) error {
+	var holdings []*valuedHolding
+	for token := ""; ; {
+		page, next, err := e.h.store.ListHoldings(ctx, ListHoldingsOpts{PortfolioID: e.portfolio.ID, PageSize: exportPageSize, PageToken: token})
+		if err != nil {
+			return err
+		}
+		for _, holding := range page {
+			v := &valuedHolding{Holding: holding, amount: amountToDecimal(holding.Amount, holding.Decimals)}
+			holdings = append(holdings, v)
+			if _, ok := e.holdings[holding.AccountID]; !ok {
+				e.accountIDs = append(e.accountIDs, holding.AccountID)
+			}
+			e.holdings[holding.AccountID] = append(e.holdings[holding.AccountID], v)
+			if err := e.loadAsset(ctx, holding.AssetID); err != nil {
+				return err
+			}
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	if e.quoteAssetID == "" {
+		return nil
+	}
+
+	// Lots are private to the owner of their account, so members may see
+	// holdings without cost.
+	lots := make(map[string][]*entity.Lot)
+	for token := ""; ; {
+		page, next, err := e.h.store.ListLots(ctx, ListLotsOpts{PortfolioID: e.portfolio.ID, PageSize: exportPageSize, PageToken: token})
+		if err != nil {
+			return err
+		}
+		for _, lot := range page {
+			lots[lot.HoldingID] = append(lots[lot.HoldingID], lot)
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+
+	// Holdings are valued at today's rates, and lots cost what they did in
+	// the quote asset on the day they were acquired.
+	for _, v := range holdings {
+		var err error
+		if v.price, v.priced, err = e.rates.rate(ctx, v.AssetID, e.quoteAssetID, e.asOf); err != nil {
+			return err
+		}
+		v.value = v.amount.Mul(v.price)
+
+		covered := decimal.Zero
+		v.costKnown = len(lots[v.ID]) > 0
+		for _, lot := range lots[v.ID] {
+			acquiredAt := lot.AcquiredAt
+			if acquiredAt.IsZero() {
+				acquiredAt = lot.CreatedAt
+			}
+			cost, ok, err := e.rates.convert(ctx, amountToDecimal(lot.CostBasis, lot.CostDecimals), lot.CostAssetID, e.quoteAssetID, acquiredAt)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				v.costKnown = false
+				break
+			}
+			covered = covered.Add(amountToDecimal(lot.Amount, lot.Decimals))
+			v.costBasis = v.costBasis.Add(cost)
+		}
+		if !covered.Equal(v.amount) {
+			v.costKnown = false
+		}
+	}
+	return nil
+}
+
+// loadAsset caches the asset with id for symbols and names.
+func (e *export) loadAsset(ctx context.Context, id string) error {
+	if _, ok := e.assets[id]; ok || id == "" {
+		return nil
+	}
+	asset, err := e.h.marketData.GetAsset(ctx, id)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+	e.assets[id] = asset
+	return nil
+}
+
+// symbol returns the symbol of a loaded asset, or "" if it is unknown.
+func (e *export) symbol(assetID string) string {
+	if a := e.assets[assetID]; a != nil {
+		return a.Symbol
+	}
+	return ""
+}
+
+// realizedGain returns the gain a sell recorded, in the quote asset at the
+// rate of the day it was executed.
+func (e *export) realizedGain(ctx context.Context, t *entity.Transaction) (decimal.NullDecimal, error) {
+	if e.quoteAssetID == "" || t.Data["quote_asset_id"] == "" || t.Data["realized_gain"] == "" {
+		return decimal.NullDecimal{}, nil
+	}
+	gain, err := parseDecimal(t.Data["realized_gain"])
+	if err != nil {
+		return decimal.NullDecimal{}, nil
+	}
+	gain, ok, err := e.rates.convert(ctx, gain, t.Data["quote_asset_id"], e.quoteAssetID, TransactionTime(t))
+	if err != nil || !ok {
+		return decimal.NullDecimal{}, err
+	}
+	return decimal.NewNullDecimal(gain), nil
+}
+
+// exportEncoder writes one export format. Each account's holdings are
+// passed both before and after its transactions; formats use one or the
+// other.
+type exportEncoder interface {
+	begin() error
+	startAccount(accountID string, holdings []*valuedHolding) error
+	transaction(t *entity.Transaction, legs []exportLeg, gain decimal.NullDecimal) error
+	endAccount(accountID string, holdings []*valuedHolding) error
+	end(s *exportSummary) error
+}
+
+// write writes the export to w. The transactions of each account are those
+// in the date range; the accounts of other users are private, so a shared
+// portfolio exports only their holdings.
+func (e *export) write(ctx context.Context, w io.Writer) error {
+	var enc exportEncoder
+	switch e.format {
+	case apiv1.ExportFormat_EXPORT_FORMAT_CSV:
+		enc = newCSVEncoder(w, e)
+	case apiv1.ExportFormat_EXPORT_FORMAT_JSONL:
+		enc = newJSONLEncoder(w, e)
+	default:
+		enc = newOFXEncoder(w, e)
+	}
+
+	summary := &exportSummary{}
+	for _, holdings := range e.holdings {
+		for _, v := range holdings {
+			summary.value = summary.value.Add(v.value)
+			if pnl, ok := v.unrealized(); ok {
+				summary.costBasis = summary.costBasis.Add(v.costBasis)
+				summary.unrealized = summary.unrealized.Add(pnl)
+			}
+		}
+	}
+
+	if err := enc.begin(); err != nil {
+		return err
+	}
+	for _, accountID := range e.accountIDs {
+		holdings := e.holdings[accountID]
+		if err := enc.startAccount(accountID, holdings); err != nil {
+			return err
+		}
+		for token := ""; ; {
+			page, next, err := e.h.store.ListTransactions(ctx, ListTransactionsOpts{
+				AccountID:   accountID,
+				PortfolioID: e.portfolio.ID,
+				From:        e.from,
+				To:          e.to,
+				PageSize:    exportPageSize,
+				PageToken:   token,
+			})
+			if errors.Is(err, store.ErrNotFound) || errors.Is(err, store.ErrPermissionDenied) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			for _, t := range page {
+				legs := transactionLegs(t)
+				for _, leg := range legs {
+					if err := e.loadAsset(ctx, leg.assetID); err != nil {
+						return err
+					}
+				}
+				gain, err := e.realizedGain(ctx, t)
+				if err != nil {
+					return err
+				}
+				if gain.Valid {
+					summary.realized = summary.realized.Add(gain.Decimal)
+				}
+				summary.transactions++
+				if err := enc.transaction(t, legs, gain); err != nil {
+					return err
+				}
+			}
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		if err := enc.endAccount(accountID, holdings); err != nil {
+			return err
+		}
+	}
+	return enc.end(summary)
+}
+
+// enumName returns the lower-case name of a protobuf enum value without its
+// prefix, e.g. "trade" for TRANSACTION_TYPE_TRADE.
+func enumName(value fmt.Stringer, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(value.String(), prefix))
+}
+
+func amountToDecimal(amount int64, decimals uint32) decimal.Decimal {
+	return decimal.New(amount, -int32(decimals))
+}
+
+// chunkWriter buffers output into chunks of at least exportChunkSize.
+type chunkWriter struct {
+	buf  []byte
+	send func([]byte) error
+}
+
+func (c *chunkWriter) Write(p []byte) (int, error) {
+	c.buf = append(c.buf, p...)
+	if len(c.buf) >= exportChunkSize {
+		if err := c.flush(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (c *chunkWriter) flush() error {
+	if len(c.buf) == 0 {
+		return nil
+	}
+	err := c.send(c.buf)
+	c.buf = c.buf[:0]
+	return err
+}
+
+func (h *Handler) ExportPortfolio(ctx context.Context, req *connect.Request[apiv1.ExportPortfolioRequest], stream *connect.ServerStream[apiv1.ExportChunk]) error {
+	e, err := h.newExport(ctx, req.Msg)
+	if err != nil {
+		return err
+	}
+
+	w := &chunkWriter{send: func(data []byte) error {
+		return stream.Send(&apiv1.ExportChunk{Data: data})
+	}}
+	if err := e.write(ctx, w); err != nil {
+		return toConnectError(err)
+	}
+	return w.flush()
+}
+
+// exportFiles maps formats to the content type and extension of their
+// download.
+var exportFiles = map[apiv1.ExportFormat]struct{ contentType, ext string }{
+	apiv1.ExportFormat_EXPORT_FORMAT_CSV:   {"text/csv; charset=utf-8", "csv"},
+	apiv1.ExportFormat_EXPORT_FORMAT_JSONL: {"application/x-ndjson", "jsonl"},
+	apiv1.ExportFormat_EXPORT_FORMAT_OFX:   {"application/x-ofx", "ofx"},
+}
+
+// ServeExport serves ExportPortfolio as a file download for
+// GET /api/v1/portfolios/{portfolio_id}/export. The query takes format
+// (csv, jsonl or ofx; csv by default), from and to as RFC 3339 times or
+// dates, and quote_asset_id. Errors are written in the Connect JSON format;
+// one after the download started cuts it short.
+func (h *Handler) ServeExport(w http.ResponseWriter, r *http.Request) {
+	errWriter := connect.NewErrorWriter()
+	req, err := exportRequestFromQuery(r)
+	if err != nil {
+		_ = errWriter.Write(w, r, connect.NewError(connect.CodeInvalidArgument, err))
+		return
+	}
+	e, err := h.newExport(r.Context(), req)
+	if err != nil {
+		_ = errWriter.Write(w, r, err)
+		return
+	}
+
+	file := exportFiles[req.Format]
+	w.Header().Set("Content-Type", file.contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="portfolio-%s.%s"`, e.portfolio.ID, file.ext))
+	bw := bufio.NewWriterSize(w, exportChunkSize)
+	if err = e.write(r.Context(), bw); err == nil {
+		err = bw.Flush()
+	}
+	if err != nil {
+		h.log.Error("Failed to export portfolio", slog.String("portfolio_id", e.portfolio.ID), slog.Any("error", err))
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func exportRequestFromQuery(r *http.Request) (*apiv1.ExportPortfolioRequest, error) {
+	query := r.URL.Query()
+	req := &apiv1.ExportPortfolioRequest{PortfolioId: r.PathValue("portfolio_id")}
+
+	switch format := strings.ToLower(query.Get("format")); format {
+	case "", "csv":
+		req.Format = apiv1.ExportFormat_EXPORT_FORMAT_CSV
+	case "jsonl":
+		req.Format = apiv1.ExportFormat_EXPORT_FORMAT_JSONL
+	case "ofx":
+		req.Format = apiv1.ExportFormat_EXPORT_FORMAT_OFX
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+
+	for name, dst := range map[string]**timestamppb.Timestamp{"from": &req.From, "to": &req.To} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			if at, err = time.Parse(time.DateOnly, value); err != nil {
+				return nil, fmt.Errorf("invalid %s %q: use an RFC 3339 time or a date", name, value)
+			}
+		}
+		*dst = timestamppb.New(at)
+	}
+	if quote := query.Get("quote_asset_id"); quote != "" {
+		req.QuoteAssetId = &quote
+	}
+	return req, nil
+}
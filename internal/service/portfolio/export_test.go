@@ -0,0 +1,357 @@
+package portfolio
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/api/v1/apiv1connect"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// exportStore holds one portfolio. Transactions are listed a page of one at
+// a time, so exports have to follow page tokens.
+type exportStore struct {
+	Store
+	holdings     []*entity.Holding
+	lots         []*entity.Lot
+	transactions []*entity.Transaction
+	listed       []ListTransactionsOpts
+}
+
+func (s *exportStore) GetPortfolio(_ context.Context, id string) (*entity.Portfolio, error) {
+	if id != "p1" {
+		return nil, store.ErrNotFound
+	}
+	return &entity.Portfolio{ID: "p1", Name: "Main"}, nil
+}
+
+func (s *exportStore) ListHoldings(context.Context, ListHoldingsOpts) ([]*entity.Holding, string, error) {
+	return s.holdings, "", nil
+}
+
+func (s *exportStore) ListLots(context.Context, ListLotsOpts) ([]*entity.Lot, string, error) {
+	return s.lots, "", nil
+}
+
+func (s *exportStore) ListTransactions(_ context.Context, opts ListTransactionsOpts) ([]*entity.Transaction, string, error) {
+	s.listed = append(s.listed, opts)
+	var matching []*entity.Transaction
+	for _, t := range s.transactions {
+		if t.AccountID == opts.AccountID {
+			matching = append(matching, t)
+		}
+	}
+	i := 0
+	if opts.PageToken != "" {
+		i = int(opts.PageToken[0] - '0')
+	}
+	if i >= len(matching) {
+		return nil, "", nil
+	}
+	next := ""
+	if i+1 < len(matching) {
+		next = string(rune('0' + i + 1))
+	}
+	return matching[i : i+1], next, nil
+}
+
+func newExportHandler() (*Handler, *exportStore) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := &exportStore{
+		holdings: []*entity.Holding{
+			{ID: "h1", AccountID: "a1", AssetID: "btc", Amount: 15, Decimals: 1},
+			{ID: "h2", AccountID: "a1", AssetID: "usd", Amount: 100000, Decimals: 2},
+		},
+		lots: []*entity.Lot{
+			{ID: "l1", HoldingID: "h1", Amount: 15, Decimals: 1, CostBasis: 30000, CostDecimals: 0, CostAssetID: "usd",
+				AcquiredAt: time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)},
+		},
+		transactions: []*entity.Transaction{
+			{ID: "t1", AccountID: "a1", AssetID: "usd", Type: entity.TransactionTypeDeposit, Status: entity.TransactionStatusCompleted,
+				CreatedAt: at, Data: map[string]string{"amount": "5000"}},
+			{ID: "t2", AccountID: "a1", AssetID: "btc", Type: entity.TransactionTypeTrade, Status: entity.TransactionStatusCompleted,
+				ExternalID: "x2", CreatedAt: at.Add(time.Hour), Data: map[string]string{
+					"side": "buy", "amount": "2", "price": "20000", "quote_asset_id": "usd", "cost": "40000",
+					"fee": "10", "fee_asset_id": "usd", "executed_at": "2024-04-01T10:00:00Z",
+				}},
+			{ID: "t3", AccountID: "a1", AssetID: "btc", Type: entity.TransactionTypeTrade, Status: entity.TransactionStatusCompleted,
+				CreatedAt: at.Add(2 * time.Hour), Data: map[string]string{
+					"side": "sell", "amount": "0.5", "price": "30000", "quote_asset_id": "usd", "proceeds": "15000",
+					"cost_basis": "10000", "realized_gain": "5000",
+				}},
+		},
+	}
+	md := &fakeMarketData{
+		assets: []*entity.Asset{{ID: "btc", Symbol: "BTC", Name: "Bitcoin"}, {ID: "usd", Symbol: "USD", Name: "US Dollar"}},
+		prices: []*entity.StoredPrice{{AssetID: "btc", BaseAssetID: "usd", Last: 3000000, Decimals: 2}},
+	}
+	return NewHandler(s, md, nil, TaxConfig{}, ImportConfig{}, slog.New(slog.DiscardHandler)), s
+}
+
+func TestTransactionLegs(t *testing.T) {
+	_, s := newExportHandler()
+	legs := transactionLegs(s.transactions[1])
+	require.Len(t, legs, 3)
+	assert.Equal(t, exportLeg{leg: legBase, assetID: "btc", amount: dec("2")}, legs[0])
+	assert.Equal(t, legQuote, legs[1].leg)
+	assert.True(t, dec("-40000").Equal(legs[1].amount))
+	assert.Equal(t, "usd", legs[2].assetID)
+	assert.True(t, dec("-10").Equal(legs[2].amount))
+
+	legs = transactionLegs(s.transactions[2])
+	require.Len(t, legs, 2)
+	assert.True(t, dec("-0.5").Equal(legs[0].amount))
+	assert.True(t, dec("15000").Equal(legs[1].amount))
+
+	legs = transactionLegs(&entity.Transaction{Type: entity.TransactionTypeIncome, AssetID: "usd", Data: map[string]string{"amount": "-2.5", "withholding_tax": "0.5"}})
+	assert.Equal(t, []exportLeg{{leg: legBase, assetID: "usd", amount: dec("2.5")}}, legs)
+
+	assert.Empty(t, transactionLegs(&entity.Transaction{Type: entity.TransactionTypeDeposit, AssetID: "btc", Data: map[string]string{"amount": "lots"}}))
+}
+
+func TestTransactionTime(t *testing.T) {
+	_, s := newExportHandler()
+	assert.Equal(t, time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC), TransactionTime(s.transactions[1]))
+	assert.Equal(t, s.transactions[0].CreatedAt, TransactionTime(s.transactions[0]))
+}
+
+func exportTo(t *testing.T, h *Handler, req *apiv1.ExportPortfolioRequest) string {
+	t.Helper()
+	e, err := h.newExport(context.Background(), req)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, e.write(context.Background(), &buf))
+	return buf.String()
+}
+
+func TestExportCSV(t *testing.T) {
+	h, s := newExportHandler()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	out := exportTo(t, h, &apiv1.ExportPortfolioRequest{
+		PortfolioId:  "p1",
+		Format:       apiv1.ExportFormat_EXPORT_FORMAT_CSV,
+		From:         timestamppb.New(from),
+		QuoteAssetId: proto.String("usd"),
+	})
+
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 1+2+1+3+2+1)
+	assert.Equal(t, csvExportHeader, records[0])
+
+	row := func(r []string) map[string]string {
+		m := make(map[string]string)
+		for i, name := range csvExportHeader {
+			m[name] = r[i]
+		}
+		return m
+	}
+
+	btc := row(records[1])
+	assert.Equal(t, "holding", btc["section"])
+	assert.Equal(t, "BTC", btc["symbol"])
+	assert.Equal(t, "1.5", btc["amount"])
+	assert.Equal(t, "30000", btc["price"])
+	assert.Equal(t, "45000", btc["value"])
+	assert.Equal(t, "30000", btc["cost_basis"])
+	assert.Equal(t, "15000", btc["unrealized_pnl"])
+	usd := row(records[2])
+	assert.Equal(t, "1000", usd["value"])
+	assert.Empty(t, usd["cost_basis"], "the cash holding has no lots")
+
+	deposit := row(records[3])
+	assert.Equal(t, "deposit", deposit["type"])
+	assert.Equal(t, "2024-05-01T12:00:00Z", deposit["date"])
+	buy := row(records[4])
+	assert.Equal(t, "2024-04-01T10:00:00Z", buy["date"])
+	assert.Equal(t, "trade", buy["type"])
+	assert.Equal(t, "base", buy["leg"])
+	assert.Equal(t, "20000", buy["price"])
+	assert.Equal(t, "x2", buy["external_id"])
+	assert.Equal(t, "-40000", row(records[5])["amount"])
+	assert.Equal(t, "fee", row(records[6])["leg"])
+	sell := row(records[7])
+	assert.Equal(t, "-0.5", sell["amount"])
+	assert.Equal(t, "5000", sell["realized_pnl"])
+
+	summary := row(records[9])
+	assert.Equal(t, "summary", summary["section"])
+	assert.Equal(t, "46000", summary["value"])
+	assert.Equal(t, "30000", summary["cost_basis"])
+	assert.Equal(t, "15000", summary["unrealized_pnl"])
+	assert.Equal(t, "5000", summary["realized_pnl"])
+
+	// Transactions of the portfolio's holdings are read page by page, with
+	// the date range.
+	require.Len(t, s.listed, 3)
+	assert.Equal(t, "p1", s.listed[0].PortfolioID)
+	assert.Equal(t, "2", s.listed[2].PageToken)
+	assert.Equal(t, from, *s.listed[0].From)
+	assert.Nil(t, s.listed[0].To)
+}
+
+func TestExportJSONL(t *testing.T) {
+	h, _ := newExportHandler()
+	out := exportTo(t, h, &apiv1.ExportPortfolioRequest{PortfolioId: "p1", Format: apiv1.ExportFormat_EXPORT_FORMAT_JSONL})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 1+2+3+1)
+	var records []map[string]any
+	for _, line := range lines {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	assert.Equal(t, "portfolio", records[0]["record"])
+	assert.Equal(t, "Main", records[0]["name"])
+	assert.Equal(t, "holding", records[1]["record"])
+	assert.NotContains(t, records[1], "value", "holdings are not valued without a quote asset")
+
+	buy := records[4]
+	assert.Equal(t, "transaction", buy["record"])
+	assert.Equal(t, "2024-04-01T10:00:00Z", buy["executed_at"])
+	assert.Equal(t, []any{
+		map[string]any{"leg": "base", "asset_id": "btc", "symbol": "BTC", "amount": "2"},
+		map[string]any{"leg": "quote", "asset_id": "usd", "symbol": "USD", "amount": "-40000"},
+		map[string]any{"leg": "fee", "asset_id": "usd", "symbol": "USD", "amount": "-10"},
+	}, buy["legs"])
+
+	assert.Equal(t, map[string]any{"record": "summary", "transactions": float64(3)}, records[6])
+}
+
+func TestExportJSONL_DefaultCurrency(t *testing.T) {
+	h, _ := newExportHandler()
+	md := h.marketData.(*fakeMarketData)
+	md.assets = append(md.assets, &entity.Asset{ID: "eur", Symbol: "EUR", Type: entity.AssetTypeForex})
+	md.prices = append(md.prices, dailyPrices("usd", "eur", 9, 1, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Now())...)
+	raw, _ := json.Marshal(map[string]string{"default_currency": "EUR"})
+	h.users = fakeUsers{"u1": {ID: "u1", Preferences: raw}}
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "u1"})
+
+	e, err := h.newExport(ctx, &apiv1.ExportPortfolioRequest{PortfolioId: "p1", Format: apiv1.ExportFormat_EXPORT_FORMAT_JSONL})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, e.write(ctx, &buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var summary map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &summary))
+
+	// Values, cost and the realized gain are converted from dollars.
+	assert.Equal(t, "eur", summary["quote_asset_id"])
+	assert.Equal(t, "41400", summary["value"])
+	assert.Equal(t, "27000", summary["cost_basis"])
+	assert.Equal(t, "13500", summary["unrealized_pnl"])
+	assert.Equal(t, "4500", summary["realized_pnl"])
+
+	// OFX needs no quote asset with a default currency.
+	_, err = h.newExport(ctx, &apiv1.ExportPortfolioRequest{PortfolioId: "p1", Format: apiv1.ExportFormat_EXPORT_FORMAT_OFX})
+	assert.NoError(t, err)
+}
+
+func TestExportOFX(t *testing.T) {
+	h, _ := newExportHandler()
+	_, err := h.newExport(context.Background(), &apiv1.ExportPortfolioRequest{PortfolioId: "p1", Format: apiv1.ExportFormat_EXPORT_FORMAT_OFX})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	out := exportTo(t, h, &apiv1.ExportPortfolioRequest{
+		PortfolioId:  "p1",
+		Format:       apiv1.ExportFormat_EXPORT_FORMAT_OFX,
+		QuoteAssetId: proto.String("usd"),
+	})
+	assert.True(t, strings.HasPrefix(out, "<?xml"))
+
+	// The file is well-formed XML.
+	decoder := xml.NewDecoder(strings.NewReader(out))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	assert.Contains(t, out, "<CURDEF>USD</CURDEF>")
+	assert.Contains(t, out, "<INVBANKTRAN><STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20240501120000.000[0:GMT]</DTPOSTED><TRNAMT>5000</TRNAMT>")
+	assert.Contains(t, out, "<BUYOTHER><INVBUY><INVTRAN><FITID>t2</FITID>")
+	assert.Contains(t, out, "<UNITS>2</UNITS><UNITPRICE>20000</UNITPRICE><FEES>10</FEES><TOTAL>-40010</TOTAL>")
+	assert.Contains(t, out, "<SELLOTHER><INVSELL>")
+	assert.Contains(t, out, "<UNITS>1.5</UNITS><UNITPRICE>30000</UNITPRICE><MKTVAL>45000</MKTVAL>")
+	assert.Contains(t, out, "<AVAILCASH>1000</AVAILCASH>")
+	assert.Contains(t, out, "<SECNAME>Bitcoin</SECNAME><TICKER>BTC</TICKER>")
+}
+
+func TestServeExport(t *testing.T) {
+	h, s := newExportHandler()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/portfolios/{portfolio_id}/export", h.ServeExport)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/api/v1/portfolios/p1/export?format=jsonl&from=2024-01-01&to=2024-06-01T00:00:00Z")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="portfolio-p1.jsonl"`, resp.Header.Get("Content-Disposition"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 7, strings.Count(string(body), "\n"))
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), *s.listed[0].To)
+
+	for query, status := range map[string]int{
+		"/api/v1/portfolios/p1/export?format=xlsx":    http.StatusBadRequest,
+		"/api/v1/portfolios/p1/export?from=yesterday": http.StatusBadRequest,
+		"/api/v1/portfolios/p2/export":                http.StatusNotFound,
+	} {
+		resp, err := http.Get(srv.URL + query)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, status, resp.StatusCode, query)
+	}
+}
+
+func TestExportPortfolio(t *testing.T) {
+	h, _ := newExportHandler()
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewPortfolioServiceHandler(h))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := apiv1connect.NewPortfolioServiceClient(srv.Client(), srv.URL)
+
+	stream, err := client.ExportPortfolio(context.Background(), connect.NewRequest(&apiv1.ExportPortfolioRequest{
+		PortfolioId: "p1",
+		Format:      apiv1.ExportFormat_EXPORT_FORMAT_CSV,
+	}))
+	require.NoError(t, err)
+	var out bytes.Buffer
+	for stream.Receive() {
+		out.Write(stream.Msg().Data)
+	}
+	require.NoError(t, stream.Err())
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, records, 10)
+
+	stream, err = client.ExportPortfolio(context.Background(), connect.NewRequest(&apiv1.ExportPortfolioRequest{PortfolioId: "p1"}))
+	require.NoError(t, err)
+	assert.False(t, stream.Receive())
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(stream.Err()))
+}
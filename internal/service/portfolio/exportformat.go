@@ -0,0 +1,425 @@
+package portfolio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/shopspring/decimal"
+)
+
+// optionalDecimal formats d, or returns "" if it is not known.
+func optionalDecimal(d decimal.Decimal, known bool) string {
+	if !known {
+		return ""
+	}
+	return d.String()
+}
+
+func transactionTypeName(t entity.TransactionType) string {
+	return enumName(apiv1.TransactionType(t), "TRANSACTION_TYPE_")
+}
+
+func transactionStatusName(s entity.TransactionStatus) string {
+	return enumName(apiv1.TransactionStatus(s), "TRANSACTION_STATUS_")
+}
+
+// --- CSV ---
+
+// csvExportHeader lists the columns of CSV exports. Holdings, transaction
+// legs and the summary share them, leaving blank what does not apply; the
+// section column tells them apart.
+var csvExportHeader = []string{
+	"section", "date", "id", "account_id", "asset_id", "symbol", "type", "status", "leg",
+	"amount", "quote_asset_id", "price", "value", "cost_basis", "unrealized_pnl", "realized_pnl", "external_id",
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+	e *export
+}
+
+func newCSVEncoder(w io.Writer, e *export) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(w), e: e}
+}
+
+func (c *csvEncoder) begin() error {
+	return c.w.Write(csvExportHeader)
+}
+
+func (c *csvEncoder) startAccount(_ string, holdings []*valuedHolding) error {
+	for _, v := range holdings {
+		pnl, pnlKnown := v.unrealized()
+		err := c.w.Write([]string{
+			"holding", c.e.asOf.Format(time.RFC3339), v.ID, v.AccountID, v.AssetID, c.e.symbol(v.AssetID), "", "", "",
+			v.amount.String(), c.e.quoteAssetID,
+			optionalDecimal(v.price, v.priced),
+			optionalDecimal(v.value, v.priced),
+			optionalDecimal(v.costBasis, v.costKnown),
+			optionalDecimal(pnl, pnlKnown),
+			"", "",
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *csvEncoder) transaction(t *entity.Transaction, legs []exportLeg, gain decimal.NullDecimal) error {
+	at := TransactionTime(t).UTC().Format(time.RFC3339)
+	for _, leg := range legs {
+		var quoteAssetID, price, realized string
+		if leg.leg == legBase {
+			quoteAssetID, price = t.Data["quote_asset_id"], t.Data["price"]
+			realized = optionalDecimal(gain.Decimal, gain.Valid)
+		}
+		err := c.w.Write([]string{
+			"transaction", at, t.ID, t.AccountID, leg.assetID, c.e.symbol(leg.assetID),
+			transactionTypeName(t.Type), transactionStatusName(t.Status), leg.leg,
+			leg.amount.String(), quoteAssetID, price, "", "", "", realized, t.ExternalID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *csvEncoder) endAccount(string, []*valuedHolding) error {
+	return nil
+}
+
+func (c *csvEncoder) end(s *exportSummary) error {
+	known := c.e.quoteAssetID != ""
+	err := c.w.Write([]string{
+		"summary", c.e.asOf.Format(time.RFC3339), c.e.portfolio.ID, "", "", "", "", "", "",
+		"", c.e.quoteAssetID, "",
+		optionalDecimal(s.value, known),
+		optionalDecimal(s.costBasis, known),
+		optionalDecimal(s.unrealized, known),
+		optionalDecimal(s.realized, known),
+		"",
+	})
+	if err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// --- JSON Lines ---
+
+type jsonlPortfolio struct {
+	Record       string     `json:"record"`
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	QuoteAssetID string     `json:"quote_asset_id,omitempty"`
+	AsOf         time.Time  `json:"as_of"`
+	From         *time.Time `json:"from,omitempty"`
+	To           *time.Time `json:"to,omitempty"`
+}
+
+type jsonlHolding struct {
+	Record        string `json:"record"`
+	ID            string `json:"id"`
+	AccountID     string `json:"account_id"`
+	AssetID       string `json:"asset_id"`
+	Symbol        string `json:"symbol,omitempty"`
+	Amount        string `json:"amount"`
+	Price         string `json:"price,omitempty"`
+	Value         string `json:"value,omitempty"`
+	CostBasis     string `json:"cost_basis,omitempty"`
+	UnrealizedPnL string `json:"unrealized_pnl,omitempty"`
+}
+
+type jsonlLeg struct {
+	Leg     string `json:"leg"`
+	AssetID string `json:"asset_id"`
+	Symbol  string `json:"symbol,omitempty"`
+	Amount  string `json:"amount"`
+}
+
+type jsonlTransaction struct {
+	Record      string            `json:"record"`
+	ID          string            `json:"id"`
+	AccountID   string            `json:"account_id"`
+	Type        string            `json:"type"`
+	Status      string            `json:"status"`
+	ExecutedAt  time.Time         `json:"executed_at"`
+	ExternalID  string            `json:"external_id,omitempty"`
+	Legs        []jsonlLeg        `json:"legs"`
+	RealizedPnL string            `json:"realized_pnl,omitempty"`
+	Data        map[string]string `json:"data,omitempty"`
+}
+
+type jsonlSummary struct {
+	Record        string `json:"record"`
+	QuoteAssetID  string `json:"quote_asset_id,omitempty"`
+	Value         string `json:"value,omitempty"`
+	CostBasis     string `json:"cost_basis,omitempty"`
+	UnrealizedPnL string `json:"unrealized_pnl,omitempty"`
+	RealizedPnL   string `json:"realized_pnl,omitempty"`
+	Transactions  int    `json:"transactions"`
+}
+
+type jsonlEncoder struct {
+	enc *json.Encoder
+	e   *export
+}
+
+func newJSONLEncoder(w io.Writer, e *export) *jsonlEncoder {
+	return &jsonlEncoder{enc: json.NewEncoder(w), e: e}
+}
+
+func (j *jsonlEncoder) begin() error {
+	return j.enc.Encode(jsonlPortfolio{
+		Record:       "portfolio",
+		ID:           j.e.portfolio.ID,
+		Name:         j.e.portfolio.Name,
+		QuoteAssetID: j.e.quoteAssetID,
+		AsOf:         j.e.asOf,
+		From:         j.e.from,
+		To:           j.e.to,
+	})
+}
+
+func (j *jsonlEncoder) startAccount(_ string, holdings []*valuedHolding) error {
+	for _, v := range holdings {
+		pnl, pnlKnown := v.unrealized()
+		err := j.enc.Encode(jsonlHolding{
+			Record:        "holding",
+			ID:            v.ID,
+			AccountID:     v.AccountID,
+			AssetID:       v.AssetID,
+			Symbol:        j.e.symbol(v.AssetID),
+			Amount:        v.amount.String(),
+			Price:         optionalDecimal(v.price, v.priced),
+			Value:         optionalDecimal(v.value, v.priced),
+			CostBasis:     optionalDecimal(v.costBasis, v.costKnown),
+			UnrealizedPnL: optionalDecimal(pnl, pnlKnown),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *jsonlEncoder) transaction(t *entity.Transaction, legs []exportLeg, gain decimal.NullDecimal) error {
+	record := jsonlTransaction{
+		Record:     "transaction",
+		ID:         t.ID,
+		AccountID:  t.AccountID,
+		Type:       transactionTypeName(t.Type),
+		Status:     transactionStatusName(t.Status),
+		ExecutedAt: TransactionTime(t).UTC(),
+		ExternalID: t.ExternalID,
+		Legs:       make([]jsonlLeg, 0, len(legs)),
+		Data:       t.Data,
+	}
+	for _, leg := range legs {
+		record.Legs = append(record.Legs, jsonlLeg{
+			Leg:     leg.leg,
+			AssetID: leg.assetID,
+			Symbol:  j.e.symbol(leg.assetID),
+			Amount:  leg.amount.String(),
+		})
+	}
+	record.RealizedPnL = optionalDecimal(gain.Decimal, gain.Valid)
+	return j.enc.Encode(record)
+}
+
+func (j *jsonlEncoder) endAccount(string, []*valuedHolding) error {
+	return nil
+}
+
+func (j *jsonlEncoder) end(s *exportSummary) error {
+	known := j.e.quoteAssetID != ""
+	return j.enc.Encode(jsonlSummary{
+		Record:        "summary",
+		QuoteAssetID:  j.e.quoteAssetID,
+		Value:         optionalDecimal(s.value, known),
+		CostBasis:     optionalDecimal(s.costBasis, known),
+		UnrealizedPnL: optionalDecimal(s.unrealized, known),
+		RealizedPnL:   optionalDecimal(s.realized, known),
+		Transactions:  s.transactions,
+	})
+}
+
+// --- OFX ---
+
+// ofxIDType names asset IDs as security identifiers in OFX files.
+const ofxIDType = "GREEDYEYE"
+
+// ofxEncoder writes an OFX 2.2 file with an investment statement per
+// account, in the quote asset as currency. Trades are BUYOTHER and
+// SELLOTHER; deposits and withdrawals are TRANSFERs, or bank transactions
+// when they move the quote asset. Other transaction types have no OFX
+// equivalent and are left out.
+type ofxEncoder struct {
+	w      io.Writer
+	e      *export
+	err    error
+	assets []string // Securities to describe in the security list
+	listed map[string]bool
+}
+
+func newOFXEncoder(w io.Writer, e *export) *ofxEncoder {
+	return &ofxEncoder{w: w, e: e, listed: make(map[string]bool)}
+}
+
+// printf writes to the file unless an earlier write failed.
+func (o *ofxEncoder) printf(format string, args ...any) {
+	if o.err == nil {
+		_, o.err = fmt.Fprintf(o.w, format, args...)
+	}
+}
+
+func ofxTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.000") + "[0:GMT]"
+}
+
+func ofxText(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func (o *ofxEncoder) secID(assetID string) string {
+	if !o.listed[assetID] {
+		o.listed[assetID] = true
+		o.assets = append(o.assets, assetID)
+	}
+	return fmt.Sprintf("<SECID><UNIQUEID>%s</UNIQUEID><UNIQUEIDTYPE>%s</UNIQUEIDTYPE></SECID>", ofxText(assetID), ofxIDType)
+}
+
+func (o *ofxEncoder) begin() error {
+	o.printf("<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"no\"?>\n")
+	o.printf("<?OFX OFXHEADER=\"200\" VERSION=\"220\" SECURITY=\"NONE\" OLDFILEUID=\"NONE\" NEWFILEUID=\"NONE\"?>\n")
+	o.printf("<OFX>\n<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>"+
+		"<DTSERVER>%s</DTSERVER><LANGUAGE>ENG</LANGUAGE></SONRS></SIGNONMSGSRSV1>\n", ofxTime(o.e.asOf))
+	o.printf("<INVSTMTMSGSRSV1>\n")
+	return o.err
+}
+
+func (o *ofxEncoder) startAccount(accountID string, _ []*valuedHolding) error {
+	start := time.Unix(0, 0)
+	if o.e.from != nil {
+		start = *o.e.from
+	}
+	end := o.e.asOf
+	if o.e.to != nil {
+		end = *o.e.to
+	}
+	o.printf("<INVSTMTTRNRS><TRNUID>%s</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n", ofxText(accountID))
+	o.printf("<INVSTMTRS><DTASOF>%s</DTASOF><CURDEF>%s</CURDEF>", ofxTime(o.e.asOf), ofxText(o.e.symbol(o.e.quoteAssetID)))
+	o.printf("<INVACCTFROM><BROKERID>greedy-eye</BROKERID><ACCTID>%s</ACCTID></INVACCTFROM>\n", ofxText(accountID))
+	o.printf("<INVTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>\n", ofxTime(start), ofxTime(end))
+	return o.err
+}
+
+func (o *ofxEncoder) transaction(t *entity.Transaction, legs []exportLeg, _ decimal.NullDecimal) error {
+	var base, quote, fee *exportLeg
+	for i := range legs {
+		switch legs[i].leg {
+		case legBase:
+			base = &legs[i]
+		case legQuote:
+			quote = &legs[i]
+		case legFee:
+			fee = &legs[i]
+		}
+	}
+	if base == nil {
+		return nil
+	}
+	invTran := fmt.Sprintf("<INVTRAN><FITID>%s</FITID><DTTRADE>%s</DTTRADE></INVTRAN>", ofxText(t.ID), ofxTime(TransactionTime(t)))
+
+	switch t.Type {
+	case entity.TransactionTypeTrade:
+		if quote == nil || base.amount.IsZero() {
+			return nil
+		}
+		total := quote.amount
+		var fees string
+		if fee != nil && fee.assetID == quote.assetID {
+			total = total.Add(fee.amount)
+			fees = fmt.Sprintf("<FEES>%s</FEES>", fee.amount.Neg())
+		}
+		price, err := parseDecimal(t.Data["price"])
+		if err != nil || price.IsZero() {
+			price = quote.amount.Div(base.amount).Abs()
+		}
+		tag, inner := "BUYOTHER", "INVBUY"
+		if base.amount.IsNegative() {
+			tag, inner = "SELLOTHER", "INVSELL"
+		}
+		o.printf("<%s><%s>%s%s<UNITS>%s</UNITS><UNITPRICE>%s</UNITPRICE>%s<TOTAL>%s</TOTAL>"+
+			"<SUBACCTSEC>CASH</SUBACCTSEC><SUBACCTFUND>CASH</SUBACCTFUND></%s></%s>\n",
+			tag, inner, invTran, o.secID(base.assetID), base.amount, price, fees, total, inner, tag)
+	case entity.TransactionTypeDeposit, entity.TransactionTypeWithdrawal, entity.TransactionTypeIncome:
+		if base.assetID == o.e.quoteAssetID {
+			trnType := "CREDIT"
+			if base.amount.IsNegative() {
+				trnType = "DEBIT"
+			}
+			o.printf("<INVBANKTRAN><STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT>"+
+				"<FITID>%s</FITID></STMTTRN><SUBACCTFUND>CASH</SUBACCTFUND></INVBANKTRAN>\n",
+				trnType, ofxTime(TransactionTime(t)), base.amount, ofxText(t.ID))
+			break
+		}
+		action := "IN"
+		if base.amount.IsNegative() {
+			action = "OUT"
+		}
+		o.printf("<TRANSFER>%s%s<SUBACCTSEC>CASH</SUBACCTSEC><UNITS>%s</UNITS><TFERACTION>%s</TFERACTION>"+
+			"<POSTYPE>LONG</POSTYPE></TRANSFER>\n",
+			invTran, o.secID(base.assetID), base.amount, action)
+	}
+	return o.err
+}
+
+func (o *ofxEncoder) endAccount(_ string, holdings []*valuedHolding) error {
+	o.printf("</INVTRANLIST>\n<INVPOSLIST>\n")
+	for _, v := range holdings {
+		if v.AssetID == o.e.quoteAssetID {
+			continue
+		}
+		o.printf("<POSOTHER><INVPOS>%s<HELDINACCT>CASH</HELDINACCT><POSTYPE>LONG</POSTYPE>"+
+			"<UNITS>%s</UNITS><UNITPRICE>%s</UNITPRICE><MKTVAL>%s</MKTVAL><DTPRICEASOF>%s</DTPRICEASOF></INVPOS></POSOTHER>\n",
+			o.secID(v.AssetID), v.amount, v.price, v.value, ofxTime(o.e.asOf))
+	}
+	o.printf("</INVPOSLIST>\n")
+
+	cash := decimal.Zero
+	for _, v := range holdings {
+		if v.AssetID == o.e.quoteAssetID {
+			cash = cash.Add(v.amount)
+		}
+	}
+	o.printf("<INVBAL><AVAILCASH>%s</AVAILCASH><MARGINBALANCE>0</MARGINBALANCE><SHORTBALANCE>0</SHORTBALANCE></INVBAL>\n", cash)
+	o.printf("</INVSTMTRS></INVSTMTTRNRS>\n")
+	return o.err
+}
+
+func (o *ofxEncoder) end(*exportSummary) error {
+	o.printf("</INVSTMTMSGSRSV1>\n<SECLISTMSGSRSV1><SECLIST>\n")
+	for _, id := range o.assets {
+		name, symbol := id, ""
+		if a := o.e.assets[id]; a != nil {
+			name, symbol = a.Name, a.Symbol
+		}
+		o.printf("<OTHERINFO><SECINFO><SECID><UNIQUEID>%s</UNIQUEID><UNIQUEIDTYPE>%s</UNIQUEIDTYPE></SECID>"+
+			"<SECNAME>%s</SECNAME><TICKER>%s</TICKER></SECINFO></OTHERINFO>\n",
+			ofxText(id), ofxIDType, ofxText(name), ofxText(symbol))
+	}
+	o.printf("</SECLIST></SECLISTMSGSRSV1>\n</OFX>\n")
+	return o.err
+}
@@ -0,0 +1,209 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/foxcool/greedy-eye/internal/service/settings"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+)
+
+// pivotCurrency is the currency exchange rates are crossed through when no
+// rate between two others is stored; the ECB publishes every rate against
+// the euro.
+const pivotCurrency = "EUR"
+
+// convertedPlaces is how many decimal places converted values keep.
+const convertedPlaces = 8
+
+// maxRateAge is how old a stored exchange rate may be and still convert a
+// value. The ECB publishes on business days, so a week spans its holidays;
+// a value with no rate that recent is left unconverted, as one with none.
+const maxRateAge = 7 * 24 * time.Hour
+
+// rates converts values between assets at the prices stored for a time,
+// so each value can be converted at the rate of the day it arose. Lookups
+// are cached for the life of a request.
+type rates struct {
+	marketData MarketDataStore
+	pivot      *string // Asset ID of the pivot currency once looked up, "" if none
+	cache      map[rateKey]rateValue
+}
+
+type rateKey struct {
+	from, to string
+	at       time.Time
+}
+
+type rateValue struct {
+	rate decimal.Decimal
+	ok   bool
+}
+
+func newRates(marketData MarketDataStore) *rates {
+	return &rates{marketData: marketData, cache: make(map[rateKey]rateValue)}
+}
+
+// convert returns amount of asset from in asset to at the rate of at, and
+// false if no rate is known.
+func (r *rates) convert(ctx context.Context, amount decimal.Decimal, from, to string, at time.Time) (decimal.Decimal, bool, error) {
+	if from == to {
+		return amount, true, nil
+	}
+	rate, ok, err := r.rate(ctx, from, to, at)
+	if err != nil || !ok {
+		return decimal.Zero, false, err
+	}
+	return amount.Mul(rate).Round(convertedPlaces), true, nil
+}
+
+// rate returns the price of from in to at at. Besides a price of the pair
+// or of its inverse, rates are crossed through the pivot currency, and an
+// asset priced in neither is converted through the asset it is priced in,
+// such as a stock through the currency it trades in.
+func (r *rates) rate(ctx context.Context, from, to string, at time.Time) (decimal.Decimal, bool, error) {
+	if from == to {
+		return decimal.NewFromInt(1), true, nil
+	}
+	key := rateKey{from: from, to: to, at: at}
+	if cached, ok := r.cache[key]; ok {
+		return cached.rate, cached.ok, nil
+	}
+	rate, ok, err := r.cross(ctx, from, to, at)
+	if err == nil && !ok {
+		rate, ok, err = r.viaQuote(ctx, from, to, at)
+	}
+	if err != nil {
+		return decimal.Zero, false, err
+	}
+	r.cache[key] = rateValue{rate: rate, ok: ok}
+	return rate, ok, nil
+}
+
+// viaQuote converts from through the asset it was last priced in. Currencies
+// convert only at exchange rates, which must be recent, not through an old
+// price in another currency.
+func (r *rates) viaQuote(ctx context.Context, from, to string, at time.Time) (decimal.Decimal, bool, error) {
+	asset, err := r.marketData.GetAsset(ctx, from)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return decimal.Zero, false, err
+	}
+	if err == nil && asset.Type == entity.AssetTypeForex {
+		return decimal.Zero, false, nil
+	}
+	price, err := r.marketData.GetPriceAt(ctx, from, "", at)
+	if errors.Is(err, store.ErrNotFound) {
+		return decimal.Zero, false, nil
+	}
+	if err != nil {
+		return decimal.Zero, false, err
+	}
+	quote, ok, err := r.cross(ctx, price.BaseAssetID, to, at)
+	if err != nil || !ok {
+		return decimal.Zero, false, err
+	}
+	return amountToDecimal(price.Last, price.Decimals).Mul(quote), true, nil
+}
+
+// cross returns the rate of a pair from a price of it or of its inverse,
+// or else crossed through the pivot currency.
+func (r *rates) cross(ctx context.Context, from, to string, at time.Time) (decimal.Decimal, bool, error) {
+	if from == to {
+		return decimal.NewFromInt(1), true, nil
+	}
+	if rate, ok, err := r.pair(ctx, from, to, at); err != nil || ok {
+		return rate, ok, err
+	}
+	pivot, err := r.pivotID(ctx)
+	if err != nil || pivot == "" || pivot == from || pivot == to {
+		return decimal.Zero, false, err
+	}
+	in, ok, err := r.pair(ctx, from, pivot, at)
+	if err != nil || !ok {
+		return decimal.Zero, false, err
+	}
+	out, ok, err := r.pair(ctx, pivot, to, at)
+	if err != nil || !ok {
+		return decimal.Zero, false, err
+	}
+	return in.Mul(out), true, nil
+}
+
+// pair returns the rate of a pair from a price of it or of its inverse,
+// at most maxRateAge old.
+func (r *rates) pair(ctx context.Context, from, to string, at time.Time) (decimal.Decimal, bool, error) {
+	price, err := r.marketData.GetPriceAt(ctx, from, to, at)
+	if err == nil && price.Last > 0 && fresh(price, at) {
+		return amountToDecimal(price.Last, price.Decimals), true, nil
+	}
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return decimal.Zero, false, err
+	}
+	price, err = r.marketData.GetPriceAt(ctx, to, from, at)
+	if err == nil && price.Last > 0 && fresh(price, at) {
+		return decimal.NewFromInt(1).Div(amountToDecimal(price.Last, price.Decimals)), true, nil
+	}
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return decimal.Zero, false, err
+	}
+	return decimal.Zero, false, nil
+}
+
+// fresh reports whether a rate stored at or before at is recent enough to
+// convert a value at at.
+func fresh(price *entity.StoredPrice, at time.Time) bool {
+	return at.Sub(price.Timestamp) <= maxRateAge
+}
+
+func (r *rates) pivotID(ctx context.Context) (string, error) {
+	if r.pivot == nil {
+		id, err := currencyAssetID(ctx, r.marketData, pivotCurrency)
+		if err != nil {
+			return "", err
+		}
+		r.pivot = &id
+	}
+	return *r.pivot, nil
+}
+
+// currencyAssetID returns the ID of the asset with symbol code, preferring
+// a forex asset, then a cryptocurrency, or "" if there is none.
+func currencyAssetID(ctx context.Context, marketData MarketDataStore, code string) (string, error) {
+	assets, _, err := marketData.ListAssets(ctx, marketdata.ListAssetsOpts{Symbol: code})
+	if err != nil {
+		return "", err
+	}
+	if len(assets) == 0 {
+		return "", nil
+	}
+	i := slices.IndexFunc(assets, func(a *entity.Asset) bool { return a.Type == entity.AssetTypeForex })
+	if i < 0 {
+		i = max(slices.IndexFunc(assets, func(a *entity.Asset) bool { return a.Type == entity.AssetTypeCryptocurrency }), 0)
+	}
+	return assets[i].ID, nil
+}
+
+// defaultCurrency returns the asset of userID's default_currency
+// preference, or "" if the user has none or it names no stored asset.
+func (h *Handler) defaultCurrency(ctx context.Context, userID string) (string, error) {
+	if h.users == nil || userID == "" {
+		return "", nil
+	}
+	user, err := h.users.GetUser(ctx, userID)
+	if errors.Is(err, store.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	prefs, err := settings.ParsePreferences(user.Preferences)
+	if err != nil || prefs.DefaultCurrency == "" {
+		return "", nil
+	}
+	return currencyAssetID(ctx, h.marketData, prefs.DefaultCurrency)
+}
@@ -0,0 +1,123 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeUsers holds users by ID.
+type fakeUsers map[string]*entity.User
+
+func (u fakeUsers) GetUser(_ context.Context, id string) (*entity.User, error) {
+	if user, ok := u[id]; ok {
+		return user, nil
+	}
+	return nil, store.ErrNotFound
+}
+
+// fxDay is noon UTC of a day in 2024.
+func fxDay(month time.Month, day int) time.Time {
+	return time.Date(2024, month, day, 12, 0, 0, 0, time.UTC)
+}
+
+// dailyPrices prices an asset at last every day from from until to, as
+// exchange rates are published.
+func dailyPrices(asset, base string, last int64, decimals uint32, from, to time.Time) []*entity.StoredPrice {
+	var prices []*entity.StoredPrice
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		prices = append(prices, &entity.StoredPrice{AssetID: asset, BaseAssetID: base, Last: last, Decimals: decimals, Timestamp: day})
+	}
+	return prices
+}
+
+// newFXMarketData prices dollars and pounds in euros, as ECB rates are
+// stored, every day since January 1 2024 with new rates from June 1, and a
+// stock in dollars on January 1.
+func newFXMarketData() *fakeMarketData {
+	january, june := fxDay(time.January, 1), fxDay(time.June, 1)
+	prices := []*entity.StoredPrice{
+		{AssetID: "aapl", BaseAssetID: "usd", Last: 2000000, Decimals: 4, Timestamp: january},
+	}
+	prices = append(prices, dailyPrices("usd", "eur", 9000, 4, january, june)...)
+	prices = append(prices, dailyPrices("usd", "eur", 9200, 4, june, time.Now())...)
+	prices = append(prices, dailyPrices("gbp", "eur", 12000, 4, january, june)...)
+	prices = append(prices, dailyPrices("gbp", "eur", 11500, 4, june, time.Now())...)
+	return &fakeMarketData{
+		assets: []*entity.Asset{
+			{ID: "eur", Symbol: "EUR", Type: entity.AssetTypeForex},
+			{ID: "usd", Symbol: "USD", Type: entity.AssetTypeForex},
+			{ID: "gbp", Symbol: "GBP", Type: entity.AssetTypeForex},
+			{ID: "aapl", Symbol: "AAPL", Type: entity.AssetTypeStock},
+			{ID: "btc", Symbol: "BTC", Type: entity.AssetTypeCryptocurrency},
+		},
+		prices: prices,
+	}
+}
+
+func TestRates(t *testing.T) {
+	ctx := context.Background()
+	r := newRates(newFXMarketData())
+	march, july := fxDay(time.March, 1), fxDay(time.July, 1)
+
+	for _, tc := range []struct {
+		name     string
+		from, to string
+		at       time.Time
+		want     string
+	}{
+		{"Same asset", "btc", "btc", march, "1"},
+		{"Stored pair", "usd", "eur", march, "0.9"},
+		{"Rate of the day", "usd", "eur", july, "0.92"},
+		{"Inverse", "eur", "gbp", march, "0.83333333"},
+		{"Crossed through euros", "gbp", "usd", march, "1.33333333"},
+		{"Through the asset's quote", "aapl", "eur", march, "180"},
+		{"Through the quote and crossed", "aapl", "gbp", july, "160"},
+	} {
+		value, ok, err := r.convert(ctx, dec("1"), tc.from, tc.to, tc.at)
+		require.NoError(t, err, tc.name)
+		require.True(t, ok, tc.name)
+		assert.Equal(t, tc.want, value.String(), tc.name)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		from, to string
+		at       time.Time
+	}{
+		{"Before any rate", "usd", "eur", fxDay(time.January, 1).Add(-time.Hour)},
+		{"Unpriced asset", "btc", "eur", march},
+		{"Unknown asset", "xyz", "eur", march},
+		{"Rate over a week old", "usd", "eur", time.Now().AddDate(0, 0, 8)},
+		{"Quote's rate over a week old", "aapl", "eur", time.Now().AddDate(0, 0, 8)},
+	} {
+		_, ok, err := r.convert(ctx, dec("1"), tc.from, tc.to, tc.at)
+		require.NoError(t, err, tc.name)
+		assert.False(t, ok, tc.name)
+	}
+}
+
+func TestDefaultCurrency(t *testing.T) {
+	ctx := context.Background()
+	prefs := func(v map[string]string) json.RawMessage {
+		raw, _ := json.Marshal(v)
+		return raw
+	}
+	h := NewHandler(nil, newFXMarketData(), fakeUsers{
+		"u1": {ID: "u1", Preferences: prefs(map[string]string{"default_currency": "GBP"})},
+		"u2": {ID: "u2", Preferences: prefs(map[string]string{"default_currency": "RUB"})},
+		"u3": {ID: "u3"},
+	}, TaxConfig{}, ImportConfig{}, nil)
+
+	for userID, want := range map[string]string{"u1": "gbp", "u2": "", "u3": "", "gone": "", "": ""} {
+		currency, err := h.defaultCurrency(ctx, userID)
+		require.NoError(t, err, userID)
+		assert.Equal(t, want, currency, userID)
+	}
+}
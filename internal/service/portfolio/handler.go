@@ -4,13 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 
 	"connectrpc.com/connect"
 	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
 	"github.com/foxcool/greedy-eye/internal/api/v1/apiv1connect"
+	"github.com/foxcool/greedy-eye/internal/auth"
 	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/secrets"
 	"github.com/foxcool/greedy-eye/internal/store"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -19,12 +25,16 @@ import (
 // Handler implements apiv1connect.PortfolioServiceHandler.
 type Handler struct {
 	apiv1connect.UnimplementedPortfolioServiceHandler
-	store Store
-	log   *slog.Logger
+	store      Store
+	marketData MarketDataStore
+	users      UserStore
+	tax        TaxConfig
+	imports    ImportConfig
+	log        *slog.Logger
 }
 
-func NewHandler(store Store, log *slog.Logger) *Handler {
-	return &Handler{store: store, log: log}
+func NewHandler(store Store, marketData MarketDataStore, users UserStore, tax TaxConfig, imports ImportConfig, log *slog.Logger) *Handler {
+	return &Handler{store: store, marketData: marketData, users: users, tax: tax, imports: imports, log: log}
 }
 
 // --- Portfolio CRUD ---
@@ -35,6 +45,7 @@ func (h *Handler) CreatePortfolio(ctx context.Context, req *connect.Request[apiv
 	}
 
 	p := portfolioFromProto(req.Msg.Portfolio)
+	p.UserID = auth.OwnerID(ctx, p.UserID)
 	created, err := h.store.CreatePortfolio(ctx, p)
 	if err != nil {
 		return nil, toConnectError(err)
@@ -115,14 +126,89 @@ func (h *Handler) ListPortfolios(ctx context.Context, req *connect.Request[apiv1
 	}), nil
 }
 
-// --- Portfolio business logic (stubs) ---
+// --- Portfolio sharing ---
+
+func (h *Handler) InvitePortfolioMember(ctx context.Context, req *connect.Request[apiv1.InvitePortfolioMemberRequest]) (*connect.Response[apiv1.PortfolioMember], error) {
+	if req.Msg.PortfolioId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("portfolio ID is required"))
+	}
+	if req.Msg.Email == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email is required"))
+	}
+	if req.Msg.Role == apiv1.PortfolioRole_PORTFOLIO_ROLE_UNSPECIFIED {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("role is required"))
+	}
+
+	m, err := h.store.InvitePortfolioMember(ctx, &entity.PortfolioMember{
+		PortfolioID: req.Msg.PortfolioId,
+		Email:       req.Msg.Email,
+		Role:        entity.PortfolioRole(req.Msg.Role),
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(portfolioMemberToProto(m)), nil
+}
+
+func (h *Handler) AcceptPortfolioInvitation(ctx context.Context, req *connect.Request[apiv1.AcceptPortfolioInvitationRequest]) (*connect.Response[apiv1.PortfolioMember], error) {
+	if req.Msg.PortfolioId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("portfolio ID is required"))
+	}
+	userID := auth.OwnerID(ctx, "")
+	if userID == "" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("only an authenticated user can accept an invitation"))
+	}
+
+	m, err := h.store.AcceptPortfolioInvitation(ctx, req.Msg.PortfolioId, userID)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(portfolioMemberToProto(m)), nil
+}
+
+func (h *Handler) RevokePortfolioMember(ctx context.Context, req *connect.Request[apiv1.RevokePortfolioMemberRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.PortfolioId == "" || req.Msg.UserId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("portfolio ID and user ID are required"))
+	}
+
+	if err := h.store.RevokePortfolioMember(ctx, req.Msg.PortfolioId, req.Msg.UserId); err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (h *Handler) ListPortfolioMembers(ctx context.Context, req *connect.Request[apiv1.ListPortfolioMembersRequest]) (*connect.Response[apiv1.ListPortfolioMembersResponse], error) {
+	opts := ListPortfolioMembersOpts{
+		PortfolioID: req.Msg.GetPortfolioId(),
+		UserID:      req.Msg.GetUserId(),
+	}
+	if opts.PortfolioID == "" && opts.UserID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("portfolio ID or user ID is required"))
+	}
+
+	members, err := h.store.ListPortfolioMembers(ctx, opts)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	protoMembers := make([]*apiv1.PortfolioMember, 0, len(members))
+	for _, m := range members {
+		protoMembers = append(protoMembers, portfolioMemberToProto(m))
+	}
 
-func (h *Handler) CalculatePortfolioValue(ctx context.Context, req *connect.Request[apiv1.CalculatePortfolioValueRequest]) (*connect.Response[apiv1.PortfolioValueResponse], error) {
-	// TODO: Implement with MarketDataStore for prices
-	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("CalculatePortfolioValue not implemented"))
+	return connect.NewResponse(&apiv1.ListPortfolioMembersResponse{PortfolioMembers: protoMembers}), nil
 }
 
+// --- Portfolio business logic (stubs) ---
+
 func (h *Handler) GetPortfolioPerformance(ctx context.Context, req *connect.Request[apiv1.GetPortfolioPerformanceRequest]) (*connect.Response[apiv1.PortfolioPerformanceResponse], error) {
+	// Portfolios the caller cannot read are not found, as elsewhere.
+	if _, err := h.store.GetPortfolio(ctx, req.Msg.PortfolioId); err != nil {
+		return nil, toConnectError(err)
+	}
 	// TODO: Implement
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("GetPortfolioPerformance not implemented"))
 }
@@ -209,6 +295,85 @@ func (h *Handler) ListHoldings(ctx context.Context, req *connect.Request[apiv1.L
 	}), nil
 }
 
+// --- Lot CRUD ---
+
+func (h *Handler) CreateLot(ctx context.Context, req *connect.Request[apiv1.CreateLotRequest]) (*connect.Response[apiv1.Lot], error) {
+	if req.Msg.Lot == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("lot is required"))
+	}
+
+	lot := lotFromProto(req.Msg.Lot)
+	created, err := h.store.CreateLot(ctx, lot)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(lotToProto(created)), nil
+}
+
+func (h *Handler) GetLot(ctx context.Context, req *connect.Request[apiv1.GetLotRequest]) (*connect.Response[apiv1.Lot], error) {
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("lot ID is required"))
+	}
+
+	lot, err := h.store.GetLot(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(lotToProto(lot)), nil
+}
+
+func (h *Handler) UpdateLot(ctx context.Context, req *connect.Request[apiv1.UpdateLotRequest]) (*connect.Response[apiv1.Lot], error) {
+	if req.Msg.Lot == nil || req.Msg.Lot.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("lot with ID is required"))
+	}
+
+	var fields []string
+	if req.Msg.UpdateMask != nil {
+		fields = req.Msg.UpdateMask.Paths
+	}
+
+	lot := lotFromProto(req.Msg.Lot)
+	updated, err := h.store.UpdateLot(ctx, lot, fields)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(lotToProto(updated)), nil
+}
+
+func (h *Handler) ListLots(ctx context.Context, req *connect.Request[apiv1.ListLotsRequest]) (*connect.Response[apiv1.ListLotsResponse], error) {
+	opts := ListLotsOpts{}
+	if req.Msg.HoldingId != nil {
+		opts.HoldingID = *req.Msg.HoldingId
+	}
+	if req.Msg.PortfolioId != nil {
+		opts.PortfolioID = *req.Msg.PortfolioId
+	}
+	if req.Msg.PageSize != nil {
+		opts.PageSize = int(*req.Msg.PageSize)
+	}
+	if req.Msg.PageToken != nil {
+		opts.PageToken = *req.Msg.PageToken
+	}
+
+	lots, nextPageToken, err := h.store.ListLots(ctx, opts)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	protoLots := make([]*apiv1.Lot, 0, len(lots))
+	for _, l := range lots {
+		protoLots = append(protoLots, lotToProto(l))
+	}
+
+	return connect.NewResponse(&apiv1.ListLotsResponse{
+		Lots:          protoLots,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
 // --- Account CRUD ---
 
 func (h *Handler) CreateAccount(ctx context.Context, req *connect.Request[apiv1.CreateAccountRequest]) (*connect.Response[apiv1.Account], error) {
@@ -217,6 +382,12 @@ func (h *Handler) CreateAccount(ctx context.Context, req *connect.Request[apiv1.
 	}
 
 	account := accountFromProto(req.Msg.Account)
+	account.UserID = auth.OwnerID(ctx, account.UserID)
+	for k, v := range account.Secrets {
+		if v == secrets.Redacted {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("data.%s: a value is required", k))
+		}
+	}
 	created, err := h.store.CreateAccount(ctx, account)
 	if err != nil {
 		return nil, toConnectError(err)
@@ -249,6 +420,11 @@ func (h *Handler) UpdateAccount(ctx context.Context, req *connect.Request[apiv1.
 	}
 
 	account := accountFromProto(req.Msg.Account)
+	if slices.Contains(fields, "data") {
+		if err := h.keepRedactedSecrets(ctx, account); err != nil {
+			return nil, err
+		}
+	}
 	updated, err := h.store.UpdateAccount(ctx, account, fields)
 	if err != nil {
 		return nil, toConnectError(err)
@@ -257,6 +433,30 @@ func (h *Handler) UpdateAccount(ctx context.Context, req *connect.Request[apiv1.
 	return connect.NewResponse(accountToProto(updated)), nil
 }
 
+// keepRedactedSecrets replaces secrets sent back as the redaction placeholder
+// with their stored values, so clients can update data they read without
+// knowing the secrets.
+func (h *Handler) keepRedactedSecrets(ctx context.Context, account *entity.Account) error {
+	var existing *entity.Account
+	for k, v := range account.Secrets {
+		if v != secrets.Redacted {
+			continue
+		}
+		if existing == nil {
+			var err error
+			if existing, err = h.store.GetAccount(ctx, account.ID); err != nil {
+				return toConnectError(err)
+			}
+		}
+		stored, ok := existing.Secrets[k]
+		if !ok {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("data.%s: no stored value to keep", k))
+		}
+		account.Secrets[k] = stored
+	}
+	return nil
+}
+
 func (h *Handler) DeleteAccount(ctx context.Context, req *connect.Request[apiv1.DeleteAccountRequest]) (*connect.Response[emptypb.Empty], error) {
 	if req.Msg.Id == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("account ID is required"))
@@ -382,6 +582,81 @@ func (h *Handler) ListTransactions(ctx context.Context, req *connect.Request[api
 	}), nil
 }
 
+// ImportTransactions records the transactions of an exchange or broker
+// export. Rows already imported into the account, or repeated in the file,
+// are reported as duplicates and skipped.
+func (h *Handler) ImportTransactions(ctx context.Context, req *connect.Request[apiv1.ImportTransactionsRequest]) (*connect.Response[apiv1.ImportTransactionsResponse], error) {
+	if req.Msg.AccountId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("account ID is required"))
+	}
+	if len(req.Msg.Content) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("content is required"))
+	}
+	if limit := h.imports.maxBytes(); len(req.Msg.Content) > limit {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("content is %d bytes, more than the limit of %d", len(req.Msg.Content), limit))
+	}
+	if _, err := h.store.GetAccount(ctx, req.Msg.AccountId); err != nil {
+		return nil, toConnectError(err)
+	}
+
+	rows, err := parseImport(req.Msg.Format, req.Msg.Content, req.Msg.Mapping)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("parse import: %w", err))
+	}
+
+	imported, err := h.prepareImport(ctx, req.Msg.AccountId, formatSource[req.Msg.Format], rows)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	resp := &apiv1.ImportTransactionsResponse{}
+	var txs []*entity.Transaction
+	for _, r := range imported {
+		if r.status == apiv1.ImportRowStatus_IMPORT_ROW_STATUS_NEW {
+			txs = append(txs, r.tx)
+		}
+	}
+	countStatuses(resp, imported)
+
+	if !req.Msg.DryRun {
+		if resp.InvalidCount > 0 && !req.Msg.SkipInvalid {
+			r := firstInvalid(imported)
+			return nil, connect.NewError(connect.CodeFailedPrecondition,
+				fmt.Errorf("%d invalid rows, first at line %d: %w", resp.InvalidCount, r.line, r.err))
+		}
+		if _, err := h.store.CreateTransactions(ctx, txs); err != nil {
+			return nil, toConnectError(err)
+		}
+		// Rows imported concurrently by someone else are not created again.
+		for _, r := range imported {
+			if r.status == apiv1.ImportRowStatus_IMPORT_ROW_STATUS_NEW && r.tx.ID == "" {
+				r.status = apiv1.ImportRowStatus_IMPORT_ROW_STATUS_DUPLICATE
+			}
+		}
+		countStatuses(resp, imported)
+		resp.Committed = true
+	}
+
+	resp.Rows = make([]*apiv1.ImportedRow, 0, len(imported))
+	for _, r := range imported {
+		row := &apiv1.ImportedRow{
+			Line:       int32(r.line),
+			Status:     r.status,
+			ExternalId: r.externalID,
+		}
+		if r.tx != nil {
+			row.Transaction = transactionToProto(r.tx)
+		}
+		if r.err != nil {
+			row.Error = proto.String(r.err.Error())
+		}
+		resp.Rows = append(resp.Rows, row)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
 // --- Converters ---
 
 func toConnectError(err error) error {
@@ -394,6 +669,9 @@ func toConnectError(err error) error {
 	if errors.Is(err, store.ErrConstraint) {
 		return connect.NewError(connect.CodeFailedPrecondition, err)
 	}
+	if errors.Is(err, store.ErrPermissionDenied) {
+		return connect.NewError(connect.CodePermissionDenied, err)
+	}
 	return connect.NewError(connect.CodeInternal, err)
 }
 
@@ -437,6 +715,20 @@ func portfolioToProto(p *entity.Portfolio) *apiv1.Portfolio {
 	return result
 }
 
+func portfolioMemberToProto(m *entity.PortfolioMember) *apiv1.PortfolioMember {
+	return &apiv1.PortfolioMember{
+		Id:          m.ID,
+		PortfolioId: m.PortfolioID,
+		UserId:      m.UserID,
+		Email:       m.Email,
+		Role:        apiv1.PortfolioRole(m.Role),
+		Status:      apiv1.PortfolioMemberStatus(m.Status),
+		InvitedBy:   m.InvitedBy,
+		CreatedAt:   timestamppb.New(m.CreatedAt),
+		UpdatedAt:   timestamppb.New(m.UpdatedAt),
+	}
+}
+
 func holdingFromProto(h *apiv1.Holding) *entity.Holding {
 	result := &entity.Holding{
 		ID:        h.Id,
@@ -467,13 +759,47 @@ func holdingToProto(h *entity.Holding) *apiv1.Holding {
 	return result
 }
 
+func lotFromProto(l *apiv1.Lot) *entity.Lot {
+	result := &entity.Lot{
+		ID:           l.Id,
+		HoldingID:    l.HoldingId,
+		Amount:       l.Amount,
+		Decimals:     l.Decimals,
+		CostBasis:    l.CostBasis,
+		CostDecimals: l.CostDecimals,
+		CostAssetID:  l.CostAssetId,
+	}
+	if l.AcquiredAt != nil {
+		result.AcquiredAt = l.AcquiredAt.AsTime()
+	}
+	return result
+}
+
+func lotToProto(l *entity.Lot) *apiv1.Lot {
+	return &apiv1.Lot{
+		Id:           l.ID,
+		HoldingId:    l.HoldingID,
+		Amount:       l.Amount,
+		Decimals:     l.Decimals,
+		CostBasis:    l.CostBasis,
+		CostDecimals: l.CostDecimals,
+		CostAssetId:  l.CostAssetID,
+		AcquiredAt:   timestamppb.New(l.AcquiredAt),
+		CreatedAt:    timestamppb.New(l.CreatedAt),
+		UpdatedAt:    timestamppb.New(l.UpdatedAt),
+	}
+}
+
+// accountFromProto splits data into plain fields and secrets by field name.
 func accountFromProto(a *apiv1.Account) *entity.Account {
+	data, secretValues := secrets.Split(a.Data)
 	result := &entity.Account{
-		ID:     a.Id,
-		UserID: a.UserId,
-		Name:   a.Name,
-		Type:   entity.AccountType(a.Type),
-		Data:   a.Data,
+		ID:      a.Id,
+		UserID:  a.UserId,
+		Name:    a.Name,
+		Type:    entity.AccountType(a.Type),
+		Data:    data,
+		Secrets: secretValues,
 	}
 	if a.Description != nil {
 		result.Description = *a.Description
@@ -481,13 +807,19 @@ func accountFromProto(a *apiv1.Account) *entity.Account {
 	return result
 }
 
+// accountToProto returns secrets redacted; they never leave the process.
 func accountToProto(a *entity.Account) *apiv1.Account {
+	data := make(map[string]string, len(a.Data)+len(a.Secrets))
+	maps.Copy(data, a.Data)
+	for k := range a.Secrets {
+		data[k] = secrets.Redacted
+	}
 	result := &apiv1.Account{
 		Id:        a.ID,
 		UserId:    a.UserID,
 		Name:      a.Name,
 		Type:      apiv1.AccountType(a.Type),
-		Data:      a.Data,
+		Data:      data,
 		CreatedAt: timestamppb.New(a.CreatedAt),
 		UpdatedAt: timestamppb.New(a.UpdatedAt),
 	}
@@ -499,16 +831,18 @@ func accountToProto(a *entity.Account) *apiv1.Account {
 
 func transactionFromProto(t *apiv1.Transaction) *entity.Transaction {
 	return &entity.Transaction{
-		ID:        t.Id,
-		Type:      entity.TransactionType(t.Type),
-		Status:    entity.TransactionStatus(t.Status),
-		AccountID: t.AccountId,
-		Data:      t.Data,
+		ID:         t.Id,
+		Type:       entity.TransactionType(t.Type),
+		Status:     entity.TransactionStatus(t.Status),
+		AccountID:  t.AccountId,
+		AssetID:    t.GetAssetId(),
+		ExternalID: t.GetExternalId(),
+		Data:       t.Data,
 	}
 }
 
 func transactionToProto(t *entity.Transaction) *apiv1.Transaction {
-	return &apiv1.Transaction{
+	result := &apiv1.Transaction{
 		Id:        t.ID,
 		Type:      apiv1.TransactionType(t.Type),
 		Status:    apiv1.TransactionStatus(t.Status),
@@ -517,4 +851,11 @@ func transactionToProto(t *entity.Transaction) *apiv1.Transaction {
 		CreatedAt: timestamppb.New(t.CreatedAt),
 		UpdatedAt: timestamppb.New(t.UpdatedAt),
 	}
+	if t.AssetID != "" {
+		result.AssetId = &t.AssetID
+	}
+	if t.ExternalID != "" {
+		result.ExternalId = &t.ExternalID
+	}
+	return result
 }
@@ -0,0 +1,428 @@
+package portfolio
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Kinds of income, stored in the "income" data field of income
+// transactions. Staking rewards come in through CSV imports for now: the
+// Binance and Moralis adapters are stubs that fetch no account history, so
+// they have no reward entries to classify yet.
+const (
+	IncomeDividend = "dividend"
+	IncomeStaking  = "staking"
+	IncomeInterest = "interest"
+	IncomeAirdrop  = "airdrop"
+)
+
+// defaultLookbackMonths is how much history income is projected from by
+// default.
+const defaultLookbackMonths = 12
+
+// yieldPlaces is how many decimal places projected yields keep.
+const yieldPlaces = 6
+
+// isIncome reports whether t records income: an income transaction or, as
+// recorded before there was a type for it, an extended transaction with an
+// "income" data field.
+func isIncome(t *entity.Transaction) bool {
+	return t.Type == entity.TransactionTypeIncome ||
+		t.Type == entity.TransactionTypeExtended && t.Data["income"] != ""
+}
+
+// incomeSource returns the asset that paid t: the holding it was paid on,
+// or else the asset received.
+func incomeSource(t *entity.Transaction) string {
+	return cmp.Or(t.Data["source_asset_id"], t.AssetID)
+}
+
+// incomeSum totals income in the summary's currency.
+type incomeSum struct {
+	income, withholding decimal.Decimal
+	count               int32
+}
+
+func (s *incomeSum) add(income, withholding decimal.Decimal) {
+	s.income = s.income.Add(income)
+	s.withholding = s.withholding.Add(withholding)
+	s.count++
+}
+
+// heldAsset is the portfolio's current holdings of an asset.
+type heldAsset struct {
+	value  decimal.Decimal
+	priced bool
+}
+
+// incomeSummary collects the income of a portfolio's accounts in the period
+// and in the lookback that the projection is based on.
+type incomeSummary struct {
+	h              *Handler
+	currency       string
+	rates          *rates
+	from, to       time.Time
+	lookbackFrom   time.Time
+	now            time.Time
+	lookbackMonths int
+	portfolioID    string
+	accountIDs     []string              // In order of their first holding
+	held           map[string]*heldAsset // By asset ID
+	total          incomeSum
+	assets         map[string]*incomeSum      // By source asset ID
+	accounts       map[string]*incomeSum      // By account ID
+	months         map[time.Time]*incomeSum   // By first day of the month
+	trailing       map[string]decimal.Decimal // Lookback income by source asset ID
+	symbols        map[string]string
+	unvalued       int32
+}
+
+// GetIncomeSummary totals the completed income of the portfolio's holdings
+// in a period: income in a holding's account of its asset or paid on it,
+// valued at the rate of the day each was received. The projection expects
+// the next year to pay what the lookback did, scaled to a year, for the
+// assets the portfolio still holds, and relates it to their current value.
+func (h *Handler) GetIncomeSummary(ctx context.Context, req *connect.Request[apiv1.GetIncomeSummaryRequest]) (*connect.Response[apiv1.IncomeSummary], error) {
+	if req.Msg.PortfolioId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("portfolio ID is required"))
+	}
+	now := time.Now().UTC()
+	to := now
+	if req.Msg.To != nil {
+		to = req.Msg.To.AsTime()
+	}
+	from := to.AddDate(-1, 0, 0)
+	if req.Msg.From != nil {
+		from = req.Msg.From.AsTime()
+	}
+	if !from.Before(to) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("from must be before to"))
+	}
+	lookback := defaultLookbackMonths
+	if req.Msg.LookbackMonths != nil {
+		if *req.Msg.LookbackMonths < 1 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("lookback must be at least a month"))
+		}
+		lookback = int(*req.Msg.LookbackMonths)
+	}
+
+	portfolio, err := h.store.GetPortfolio(ctx, req.Msg.PortfolioId)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	currency := req.Msg.GetCurrencyAssetId()
+	if currency == "" {
+		if currency, err = h.defaultCurrency(ctx, cmp.Or(auth.OwnerID(ctx, ""), portfolio.UserID)); err != nil {
+			return nil, toConnectError(err)
+		}
+		if currency == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("currency is required without a default currency"))
+		}
+	} else if _, err := h.marketData.GetAsset(ctx, currency); err != nil {
+		return nil, toConnectError(err)
+	}
+
+	s := &incomeSummary{
+		h:              h,
+		currency:       currency,
+		rates:          newRates(h.marketData),
+		from:           from,
+		to:             to,
+		lookbackFrom:   now.AddDate(0, -lookback, 0),
+		now:            now,
+		lookbackMonths: lookback,
+		portfolioID:    portfolio.ID,
+		held:           map[string]*heldAsset{},
+		assets:         map[string]*incomeSum{},
+		accounts:       map[string]*incomeSum{},
+		months:         map[time.Time]*incomeSum{},
+		trailing:       map[string]decimal.Decimal{},
+		symbols:        map[string]string{},
+	}
+	if err := s.loadHoldings(ctx, portfolio.ID); err != nil {
+		return nil, toConnectError(err)
+	}
+	for _, accountID := range s.accountIDs {
+		if err := s.loadAccount(ctx, accountID); err != nil {
+			return nil, toConnectError(err)
+		}
+	}
+	resp, err := s.finish(ctx, portfolio.ID)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// loadHoldings values the portfolio's holdings now, by asset, and lists
+// their accounts.
+func (s *incomeSummary) loadHoldings(ctx context.Context, portfolioID string) error {
+	for token := ""; ; {
+		holdings, next, err := s.h.store.ListHoldings(ctx, ListHoldingsOpts{PortfolioID: portfolioID, PageSize: exportPageSize, PageToken: token})
+		if err != nil {
+			return err
+		}
+		for _, holding := range holdings {
+			if !slices.Contains(s.accountIDs, holding.AccountID) {
+				s.accountIDs = append(s.accountIDs, holding.AccountID)
+			}
+			if holding.Amount <= 0 {
+				continue
+			}
+			held, ok := s.held[holding.AssetID]
+			if !ok {
+				held = &heldAsset{priced: true}
+				s.held[holding.AssetID] = held
+			}
+			value, ok, err := s.rates.convert(ctx, amountToDecimal(holding.Amount, holding.Decimals), holding.AssetID, s.currency, s.now)
+			if err != nil {
+				return err
+			}
+			held.value = held.value.Add(value)
+			held.priced = held.priced && ok
+		}
+		if next == "" {
+			return nil
+		}
+		token = next
+	}
+}
+
+// loadAccount adds the income of an account in the period or the lookback.
+func (s *incomeSummary) loadAccount(ctx context.Context, accountID string) error {
+	from, to := minTime(s.from, s.lookbackFrom), maxTime(s.to, s.now)
+	for token := ""; ; {
+		page, next, err := s.h.store.ListTransactions(ctx, ListTransactionsOpts{
+			AccountID:   accountID,
+			PortfolioID: s.portfolioID,
+			Status:      entity.TransactionStatusCompleted,
+			From:        &from,
+			To:          &to,
+			PageSize:    exportPageSize,
+			PageToken:   token,
+		})
+		if errors.Is(err, store.ErrNotFound) || errors.Is(err, store.ErrPermissionDenied) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, t := range page {
+			if !isIncome(t) {
+				continue
+			}
+			if err := s.add(ctx, t); err != nil {
+				return err
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		token = next
+	}
+}
+
+func (s *incomeSummary) add(ctx context.Context, t *entity.Transaction) error {
+	at := TransactionTime(t)
+	inPeriod := !at.Before(s.from) && at.Before(s.to)
+	inLookback := !at.Before(s.lookbackFrom) && at.Before(s.now)
+	if !inPeriod && !inLookback {
+		return nil
+	}
+	income, withholding, ok, err := s.value(ctx, t, at)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if inPeriod {
+			s.unvalued++
+		}
+		return nil
+	}
+	source := incomeSource(t)
+	if inLookback {
+		s.trailing[source] = s.trailing[source].Add(income)
+	}
+	if !inPeriod {
+		return nil
+	}
+	month := time.Date(at.UTC().Year(), at.UTC().Month(), 1, 0, 0, 0, 0, time.UTC)
+	s.total.add(income, withholding)
+	sum(s.assets, source).add(income, withholding)
+	sum(s.accounts, t.AccountID).add(income, withholding)
+	sum(s.months, month).add(income, withholding)
+	return nil
+}
+
+// value returns the income t received and the tax withheld from it in the
+// summary's currency at the rate of at. Income is worth its recorded value,
+// or else the amount received; the tax withheld is in the asset received.
+func (s *incomeSummary) value(ctx context.Context, t *entity.Transaction, at time.Time) (decimal.Decimal, decimal.Decimal, bool, error) {
+	amount, _ := dataDecimal(t, "amount")
+	withholding, _ := dataDecimal(t, "withholding_tax")
+	amount, withholding = amount.Abs(), withholding.Abs()
+	from, income := t.AssetID, amount
+	if value, ok := dataDecimal(t, "value"); ok && t.Data["value_asset_id"] != "" {
+		if amount.IsPositive() {
+			withholding = withholding.Mul(value.Abs()).Div(amount)
+		}
+		from, income = t.Data["value_asset_id"], value.Abs()
+	}
+	if from == "" {
+		return decimal.Zero, decimal.Zero, false, nil
+	}
+	rate, ok, err := s.rates.rate(ctx, from, s.currency, at)
+	if err != nil || !ok {
+		return decimal.Zero, decimal.Zero, false, err
+	}
+	return income.Mul(rate).Round(convertedPlaces), withholding.Mul(rate).Round(convertedPlaces), true, nil
+}
+
+func (s *incomeSummary) finish(ctx context.Context, portfolioID string) (*apiv1.IncomeSummary, error) {
+	resp := &apiv1.IncomeSummary{
+		PortfolioId:     portfolioID,
+		CurrencyAssetId: s.currency,
+		From:            timestamppb.New(s.from),
+		To:              timestamppb.New(s.to),
+		Income:          s.total.income.String(),
+		WithholdingTax:  s.total.withholding.String(),
+		UnvaluedCount:   s.unvalued,
+	}
+	for _, id := range sortedByIncome(s.assets, func(v *incomeSum) decimal.Decimal { return v.income }) {
+		symbol, err := s.symbol(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		a := s.assets[id]
+		resp.Assets = append(resp.Assets, &apiv1.AssetIncome{
+			AssetId:        id,
+			Symbol:         symbol,
+			Income:         a.income.String(),
+			WithholdingTax: a.withholding.String(),
+			Count:          a.count,
+		})
+	}
+	for _, id := range s.accountIDs {
+		if a, ok := s.accounts[id]; ok {
+			resp.Accounts = append(resp.Accounts, &apiv1.AccountIncome{
+				AccountId:      id,
+				Income:         a.income.String(),
+				WithholdingTax: a.withholding.String(),
+				Count:          a.count,
+			})
+		}
+	}
+	for _, month := range slices.SortedFunc(maps.Keys(s.months), time.Time.Compare) {
+		m := s.months[month]
+		resp.Months = append(resp.Months, &apiv1.MonthlyIncome{
+			Month:          timestamppb.New(month),
+			Income:         m.income.String(),
+			WithholdingTax: m.withholding.String(),
+			Count:          m.count,
+		})
+	}
+
+	var err error
+	resp.Projection, err = s.project(ctx)
+	return resp, err
+}
+
+// project scales the lookback's income of each asset still held to a year.
+func (s *incomeSummary) project(ctx context.Context) (*apiv1.IncomeProjection, error) {
+	p := &apiv1.IncomeProjection{LookbackMonths: int32(s.lookbackMonths)}
+	value := decimal.Zero
+	for _, held := range s.held {
+		if held.priced {
+			value = value.Add(held.value)
+		}
+	}
+	annual := map[string]decimal.Decimal{}
+	for id, income := range s.trailing {
+		if _, ok := s.held[id]; ok {
+			annual[id] = income.Mul(decimal.NewFromInt(12)).Div(decimal.NewFromInt(int64(s.lookbackMonths))).Round(convertedPlaces)
+		}
+	}
+	total := decimal.Zero
+	for _, id := range sortedByIncome(annual, func(v decimal.Decimal) decimal.Decimal { return v }) {
+		symbol, err := s.symbol(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		y := &apiv1.AssetYield{AssetId: id, Symbol: symbol, AnnualIncome: annual[id].String()}
+		if held := s.held[id]; held.priced {
+			y.Value = proto.String(held.value.String())
+			if held.value.IsPositive() {
+				y.Yield = proto.String(annual[id].Div(held.value).Round(yieldPlaces).String())
+			}
+		}
+		total = total.Add(annual[id])
+		p.Assets = append(p.Assets, y)
+	}
+	p.AnnualIncome = total.String()
+	p.PortfolioValue = value.String()
+	if value.IsPositive() {
+		p.Yield = proto.String(total.Div(value).Round(yieldPlaces).String())
+	}
+	return p, nil
+}
+
+// symbol returns the symbol of an asset, or "" if it is unknown.
+func (s *incomeSummary) symbol(ctx context.Context, id string) (string, error) {
+	if symbol, ok := s.symbols[id]; ok {
+		return symbol, nil
+	}
+	asset, err := s.h.marketData.GetAsset(ctx, id)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return "", err
+	}
+	symbol := ""
+	if asset != nil {
+		symbol = asset.Symbol
+	}
+	s.symbols[id] = symbol
+	return symbol, nil
+}
+
+func sum[K comparable](sums map[K]*incomeSum, key K) *incomeSum {
+	s, ok := sums[key]
+	if !ok {
+		s = &incomeSum{}
+		sums[key] = s
+	}
+	return s
+}
+
+// sortedByIncome returns the keys of m by income, largest first, then by
+// key.
+func sortedByIncome[V any](m map[string]V, income func(V) decimal.Decimal) []string {
+	return slices.SortedFunc(maps.Keys(m), func(a, b string) int {
+		return cmp.Or(income(m[b]).Cmp(income(m[a])), cmp.Compare(a, b))
+	})
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,138 @@
+package portfolio
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// incomeStore holds portfolio p1 and filters transactions like the postgres
+// store.
+type incomeStore struct {
+	taxStore
+	holdings []*entity.Holding
+}
+
+func (s *incomeStore) GetPortfolio(_ context.Context, id string) (*entity.Portfolio, error) {
+	if id != "p1" {
+		return nil, store.ErrNotFound
+	}
+	return &entity.Portfolio{ID: "p1", UserID: "u1"}, nil
+}
+
+func (s *incomeStore) ListHoldings(context.Context, ListHoldingsOpts) ([]*entity.Holding, string, error) {
+	return s.holdings, "", nil
+}
+
+func TestGetIncomeSummary(t *testing.T) {
+	now := time.Now().UTC()
+	daysAgo := func(days int) string { return now.AddDate(0, 0, -days).Format(time.RFC3339) }
+	income := func(id, account, asset string, data map[string]string) *entity.Transaction {
+		return &entity.Transaction{ID: id, AccountID: account, AssetID: asset, Type: entity.TransactionTypeIncome,
+			Status: entity.TransactionStatusCompleted, Data: data}
+	}
+	s := &incomeStore{holdings: []*entity.Holding{
+		{ID: "h1", AccountID: "a1", AssetID: "aapl", Amount: 10},
+		{ID: "h2", AccountID: "a1", AssetID: "usd", Amount: 100000, Decimals: 2},
+		{ID: "h3", AccountID: "a2", AssetID: "btc", Amount: 1},
+	}}
+	s.transactions = []*entity.Transaction{
+		income("t1", "a1", "usd", map[string]string{"income": "dividend", "amount": "17", "withholding_tax": "3",
+			"source_asset_id": "aapl", "executed_at": daysAgo(60)}),
+		income("t2", "a1", "usd", map[string]string{"income": "dividend", "amount": "17", "withholding_tax": "3",
+			"source_asset_id": "aapl", "executed_at": daysAgo(150)}),
+		// Valued in euros when received; pounds are not held.
+		income("t3", "a1", "gbp", map[string]string{"income": "interest", "amount": "10", "value": "11.5",
+			"value_asset_id": "eur", "executed_at": daysAgo(30)}),
+		// Recorded as extended before there was an income type; bitcoin has no price.
+		{ID: "t4", AccountID: "a2", AssetID: "btc", Type: entity.TransactionTypeExtended, Status: entity.TransactionStatusCompleted,
+			Data: map[string]string{"income": "staking", "amount": "0.01", "executed_at": daysAgo(10)}},
+		{ID: "t5", AccountID: "a1", AssetID: "usd", Type: entity.TransactionTypeDeposit, Status: entity.TransactionStatusCompleted,
+			Data: map[string]string{"amount": "1000", "executed_at": daysAgo(10)}},
+		income("t6", "a1", "usd", map[string]string{"income": "dividend", "amount": "17", "source_asset_id": "aapl",
+			"executed_at": daysAgo(420)}),
+	}
+	h := NewHandler(s, newFXMarketData(), nil, TaxConfig{}, ImportConfig{}, slog.New(slog.DiscardHandler))
+
+	resp, err := h.GetIncomeSummary(context.Background(), connect.NewRequest(&apiv1.GetIncomeSummaryRequest{
+		PortfolioId:     "p1",
+		CurrencyAssetId: proto.String("eur"),
+	}))
+	require.NoError(t, err)
+	summary := resp.Msg
+	assert.Equal(t, "eur", summary.CurrencyAssetId)
+	// Dollars at 0.92 and the interest's recorded value.
+	assert.Equal(t, "42.78", summary.Income)
+	assert.Equal(t, "5.52", summary.WithholdingTax)
+	assert.Equal(t, int32(1), summary.UnvaluedCount)
+
+	require.Len(t, summary.Assets, 2)
+	assert.Equal(t, "aapl", summary.Assets[0].AssetId)
+	assert.Equal(t, "AAPL", summary.Assets[0].Symbol)
+	assert.Equal(t, "31.28", summary.Assets[0].Income)
+	assert.Equal(t, "5.52", summary.Assets[0].WithholdingTax)
+	assert.Equal(t, int32(2), summary.Assets[0].Count)
+	assert.Equal(t, "gbp", summary.Assets[1].AssetId)
+	assert.Equal(t, "11.5", summary.Assets[1].Income)
+
+	require.Len(t, summary.Accounts, 1)
+	assert.Equal(t, "a1", summary.Accounts[0].AccountId)
+	assert.Equal(t, int32(3), summary.Accounts[0].Count)
+
+	var count int32
+	for i, m := range summary.Months {
+		if i > 0 {
+			assert.True(t, summary.Months[i-1].Month.AsTime().Before(m.Month.AsTime()))
+		}
+		assert.Equal(t, 1, m.Month.AsTime().Day())
+		count += m.Count
+	}
+	assert.Equal(t, int32(3), count)
+
+	// The dividends of the last year on $1840 of Apple in a portfolio worth
+	// $2760 with dollars and unpriced bitcoin.
+	p := summary.Projection
+	assert.Equal(t, int32(12), p.LookbackMonths)
+	assert.Equal(t, "31.28", p.AnnualIncome)
+	assert.Equal(t, "2760", p.PortfolioValue)
+	assert.Equal(t, "0.011333", p.GetYield())
+	require.Len(t, p.Assets, 1)
+	assert.Equal(t, "1840", p.Assets[0].GetValue())
+	assert.Equal(t, "0.017", p.Assets[0].GetYield())
+
+	// Three months hold one dividend, paid four times a year.
+	resp, err = h.GetIncomeSummary(context.Background(), connect.NewRequest(&apiv1.GetIncomeSummaryRequest{
+		PortfolioId:     "p1",
+		From:            timestamppb.New(now.AddDate(0, 0, -45)),
+		CurrencyAssetId: proto.String("eur"),
+		LookbackMonths:  proto.Int32(3),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "11.5", resp.Msg.Income)
+	assert.Equal(t, "62.56", resp.Msg.Projection.AnnualIncome)
+	assert.Equal(t, "0.034", resp.Msg.Projection.Assets[0].GetYield())
+
+	for name, tc := range map[string]struct {
+		req  *apiv1.GetIncomeSummaryRequest
+		code connect.Code
+	}{
+		"no portfolio":      {&apiv1.GetIncomeSummaryRequest{}, connect.CodeInvalidArgument},
+		"unknown portfolio": {&apiv1.GetIncomeSummaryRequest{PortfolioId: "p2", CurrencyAssetId: proto.String("eur")}, connect.CodeNotFound},
+		"no currency":       {&apiv1.GetIncomeSummaryRequest{PortfolioId: "p1"}, connect.CodeInvalidArgument},
+		"empty period":      {&apiv1.GetIncomeSummaryRequest{PortfolioId: "p1", From: timestamppb.New(now.Add(time.Hour))}, connect.CodeInvalidArgument},
+		"no lookback":       {&apiv1.GetIncomeSummaryRequest{PortfolioId: "p1", LookbackMonths: proto.Int32(0)}, connect.CodeInvalidArgument},
+	} {
+		_, err := h.GetIncomeSummary(context.Background(), connect.NewRequest(tc.req))
+		assert.Equal(t, tc.code, connect.CodeOf(err), name)
+	}
+}
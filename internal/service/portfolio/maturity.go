@@ -0,0 +1,193 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/bond"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/shopspring/decimal"
+)
+
+// maturityExternalIDPrefix prefixes the external IDs of the sells that
+// redeem a matured bond, followed by the lot or holding redeemed.
+const maturityExternalIDPrefix = "bond-maturity:"
+
+// RunBondMaturities redeems matured bonds now and then every interval until
+// ctx is done.
+func (h *Handler) RunBondMaturities(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := h.RedeemMaturedBonds(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			h.log.Error("Failed to redeem matured bonds", slog.Any("error", err))
+		} else if n > 0 {
+			h.log.Info("Redeemed matured bonds", "holdings", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RedeemMaturedBonds turns every holding of a bond that matured by now into
+// cash. Each is sold at face value for the bond's currency on its maturity
+// date, lot by lot so that gains are reported, its final coupon is recorded
+// as interest, and both are added to a holding of the currency in the same
+// account and portfolio. Each holding is redeemed all or nothing, so a run
+// cut short is not recorded twice. It returns how many holdings it
+// redeemed.
+func (h *Handler) RedeemMaturedBonds(ctx context.Context, now time.Time) (int, error) {
+	redeemed := 0
+	for token := ""; ; {
+		assets, next, err := h.marketData.ListAssets(ctx, marketdata.ListAssetsOpts{
+			Type:      entity.AssetTypeBond,
+			PageSize:  exportPageSize,
+			PageToken: token,
+		})
+		if err != nil {
+			return redeemed, err
+		}
+		for _, asset := range assets {
+			if asset.Bond == nil || !bond.Matured(asset.Bond, now) {
+				continue
+			}
+			n, err := h.redeemBond(ctx, asset)
+			redeemed += n
+			if err != nil {
+				return redeemed, fmt.Errorf("redeem bond %s: %w", asset.ID, err)
+			}
+		}
+		if next == "" {
+			return redeemed, nil
+		}
+		token = next
+	}
+}
+
+func (h *Handler) redeemBond(ctx context.Context, asset *entity.Asset) (int, error) {
+	redeemed := 0
+	for token := ""; ; {
+		holdings, next, err := h.store.ListHoldings(ctx, ListHoldingsOpts{AssetID: asset.ID, PageSize: exportPageSize, PageToken: token})
+		if err != nil {
+			return redeemed, err
+		}
+		for _, holding := range holdings {
+			if holding.Amount <= 0 {
+				continue
+			}
+			if err := h.redeemHolding(ctx, asset.Bond, holding); err != nil {
+				return redeemed, fmt.Errorf("holding %s: %w", holding.ID, err)
+			}
+			redeemed++
+		}
+		if next == "" {
+			return redeemed, nil
+		}
+		token = next
+	}
+}
+
+func (h *Handler) redeemHolding(ctx context.Context, terms *entity.BondTerms, holding *entity.Holding) error {
+	units := amountToDecimal(holding.Amount, holding.Decimals)
+	r := &Redemption{HoldingID: holding.ID, CashAssetID: terms.CurrencyAssetID}
+	remaining := units
+	for token := ""; ; {
+		lots, next, err := h.store.ListLots(ctx, ListLotsOpts{HoldingID: holding.ID, PageSize: exportPageSize, PageToken: token})
+		if err != nil {
+			return err
+		}
+		for _, lot := range lots {
+			if lot.Amount <= 0 || !remaining.IsPositive() {
+				continue
+			}
+			amount := decimal.Min(amountToDecimal(lot.Amount, lot.Decimals), remaining)
+			r.Transactions = append(r.Transactions, redemptionSale(terms, holding, lot, amount))
+			r.LotIDs = append(r.LotIDs, lot.ID)
+			remaining = remaining.Sub(amount)
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	if remaining.IsPositive() {
+		r.Transactions = append(r.Transactions, redemptionSale(terms, holding, nil, remaining))
+	}
+
+	// The last coupon is paid with the face value; accrued interest is zero
+	// from the maturity date, so it is not in the price.
+	r.Cash = units.Mul(terms.FaceValue)
+	if coupon := units.Mul(finalCoupon(terms)); coupon.IsPositive() {
+		r.Cash = r.Cash.Add(coupon)
+		executedAt := terms.MaturityDate.UTC().Format(time.RFC3339)
+		r.Transactions = append(r.Transactions, &entity.Transaction{
+			Type:       entity.TransactionTypeIncome,
+			Status:     entity.TransactionStatusCompleted,
+			AccountID:  holding.AccountID,
+			AssetID:    terms.CurrencyAssetID,
+			ExternalID: maturityExternalIDPrefix + holding.ID + ":coupon",
+			Data: map[string]string{
+				"income":          IncomeInterest,
+				"amount":          coupon.String(),
+				"value":           coupon.String(),
+				"value_asset_id":  terms.CurrencyAssetID,
+				"executed_at":     executedAt,
+				"source_asset_id": holding.AssetID,
+			},
+		})
+	}
+
+	if err := h.store.RedeemHolding(ctx, r); err != nil {
+		return fmt.Errorf("redeem: %w", err)
+	}
+	return nil
+}
+
+// finalCoupon returns the coupon a unit of the bond pays on its maturity
+// date, zero for a zero-coupon bond.
+func finalCoupon(terms *entity.BondTerms) decimal.Decimal {
+	flows := bond.CashFlows(terms, terms.MaturityDate.AddDate(0, 0, -1))
+	if len(flows) == 0 {
+		return decimal.Zero
+	}
+	return flows[len(flows)-1].Coupon
+}
+
+// redemptionSale returns the sale of amount units of a bond, from lot if it
+// is set, at face value on its maturity date.
+func redemptionSale(terms *entity.BondTerms, holding *entity.Holding, lot *entity.Lot, amount decimal.Decimal) *entity.Transaction {
+	proceeds := amount.Mul(terms.FaceValue)
+	data := map[string]string{
+		"side":           SideSell,
+		"amount":         amount.String(),
+		"price":          terms.FaceValue.String(),
+		"quote_asset_id": terms.CurrencyAssetID,
+		"proceeds":       proceeds.String(),
+		"executed_at":    terms.MaturityDate.UTC().Format(time.RFC3339),
+	}
+	externalID := maturityExternalIDPrefix + holding.ID
+	if lot != nil {
+		externalID = maturityExternalIDPrefix + lot.ID
+		data["lot_id"] = lot.ID
+		data["acquired_at"] = lot.AcquiredAt.UTC().Format(time.RFC3339)
+		if lot.CostAssetID == terms.CurrencyAssetID {
+			costBasis := amountToDecimal(lot.CostBasis, lot.CostDecimals)
+			data["cost_basis"] = costBasis.String()
+			data["realized_gain"] = proceeds.Sub(costBasis).String()
+		}
+	}
+	return &entity.Transaction{
+		Type:       entity.TransactionTypeTrade,
+		Status:     entity.TransactionStatusCompleted,
+		AccountID:  holding.AccountID,
+		AssetID:    holding.AssetID,
+		ExternalID: externalID,
+		Data:       data,
+	}
+}
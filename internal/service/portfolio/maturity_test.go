@@ -0,0 +1,173 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ledgerStore holds holdings, lots and transactions in memory. External IDs
+// are unique per account, and cash is credited in whole units.
+type ledgerStore struct {
+	Store
+	holdings     []*entity.Holding
+	lots         []*entity.Lot
+	transactions []*entity.Transaction
+}
+
+func (s *ledgerStore) ListHoldings(_ context.Context, opts ListHoldingsOpts) ([]*entity.Holding, string, error) {
+	var found []*entity.Holding
+	for _, h := range s.holdings {
+		if (opts.AccountID == "" || h.AccountID == opts.AccountID) && (opts.AssetID == "" || h.AssetID == opts.AssetID) {
+			copied := *h
+			found = append(found, &copied)
+		}
+	}
+	return found, "", nil
+}
+
+func (s *ledgerStore) ListLots(_ context.Context, opts ListLotsOpts) ([]*entity.Lot, string, error) {
+	var found []*entity.Lot
+	for _, l := range s.lots {
+		if l.HoldingID == opts.HoldingID {
+			copied := *l
+			found = append(found, &copied)
+		}
+	}
+	return found, "", nil
+}
+
+func (s *ledgerStore) RedeemHolding(_ context.Context, r *Redemption) error {
+	bond := s.holding(r.HoldingID)
+	if bond == nil {
+		return store.ErrNotFound
+	}
+	if bond.Amount <= 0 {
+		return nil
+	}
+	bond.Amount = 0
+	for _, l := range s.lots {
+		if slices.Contains(r.LotIDs, l.ID) {
+			l.Amount, l.CostBasis = 0, 0
+		}
+	}
+	for _, t := range r.Transactions {
+		if !slices.ContainsFunc(s.transactions, func(stored *entity.Transaction) bool {
+			return stored.AccountID == t.AccountID && stored.ExternalID == t.ExternalID
+		}) {
+			t.ID = fmt.Sprintf("t%d", len(s.transactions)+1)
+			s.transactions = append(s.transactions, t)
+		}
+	}
+	for _, h := range s.holdings {
+		if h.AccountID == bond.AccountID && h.PortfolioID == bond.PortfolioID && h.AssetID == r.CashAssetID {
+			h.Amount = amountToDecimal(h.Amount, h.Decimals).Add(r.Cash).Shift(int32(h.Decimals)).IntPart()
+			return nil
+		}
+	}
+	s.holdings = append(s.holdings, &entity.Holding{
+		ID: fmt.Sprintf("h%d", len(s.holdings)+1), AccountID: bond.AccountID, PortfolioID: bond.PortfolioID,
+		AssetID: r.CashAssetID, Amount: r.Cash.IntPart(),
+	})
+	return nil
+}
+
+func (s *ledgerStore) holding(id string) *entity.Holding {
+	for _, h := range s.holdings {
+		if h.ID == id {
+			return h
+		}
+	}
+	return nil
+}
+
+func TestRedeemMaturedBonds(t *testing.T) {
+	maturity := time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC)
+	md := newFXMarketData()
+	md.assets = append(md.assets,
+		&entity.Asset{ID: "corp25", Type: entity.AssetTypeBond, Bond: &entity.BondTerms{
+			FaceValue: dec("1000"), CurrencyAssetID: "usd", CouponRate: dec("0.04"), CouponFrequency: 2,
+			MaturityDate: maturity, DayCount: entity.DayCount30360,
+		}},
+		&entity.Asset{ID: "corp30", Type: entity.AssetTypeBond, Bond: &entity.BondTerms{
+			FaceValue: dec("1000"), CurrencyAssetID: "usd", MaturityDate: maturity.AddDate(5, 0, 0),
+		}},
+	)
+	s := &ledgerStore{
+		holdings: []*entity.Holding{
+			{ID: "h1", AccountID: "a1", PortfolioID: "p1", AssetID: "corp25", Amount: 3},
+			{ID: "h2", AccountID: "a1", PortfolioID: "p1", AssetID: "usd", Amount: 50000, Decimals: 2},
+			{ID: "h3", AccountID: "a2", AssetID: "corp25", Amount: 1},
+			{ID: "h4", AccountID: "a1", PortfolioID: "p1", AssetID: "corp30", Amount: 5},
+		},
+		lots: []*entity.Lot{
+			{ID: "l1", HoldingID: "h1", Amount: 2, CostBasis: 1900, CostAssetID: "usd",
+				AcquiredAt: time.Date(2023, time.January, 10, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	// A run from before redemptions were atomic recorded the sale of the lot
+	// and stopped.
+	s.transactions = []*entity.Transaction{{ID: "t0", AccountID: "a1", ExternalID: "bond-maturity:l1"}}
+	h := NewHandler(s, md, nil, TaxConfig{}, ImportConfig{}, slog.New(slog.DiscardHandler))
+
+	n, err := h.RedeemMaturedBonds(context.Background(), maturity.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	assert.Zero(t, s.holding("h1").Amount)
+	assert.Zero(t, s.holding("h3").Amount)
+	assert.Equal(t, int64(5), s.holding("h4").Amount, "not matured")
+	assert.Zero(t, s.lots[0].Amount)
+	// $500 plus three bonds of $1000 and their final coupons of $20.
+	assert.Equal(t, int64(356000), s.holding("h2").Amount)
+	cash := s.holding("h5")
+	require.NotNil(t, cash, "a new cash holding for the second account")
+	assert.Equal(t, entity.Holding{ID: "h5", AccountID: "a2", AssetID: "usd", Amount: 1020}, *cash)
+
+	// The lot's sale is not recorded twice; the unlotted bond and the other
+	// account's are sold at face value on the maturity date, and each
+	// holding's final coupon is recorded as interest.
+	require.Len(t, s.transactions, 5)
+	sale := s.transactions[1]
+	assert.Equal(t, "bond-maturity:h1", sale.ExternalID)
+	assert.Equal(t, entity.TransactionTypeTrade, sale.Type)
+	assert.Equal(t, map[string]string{
+		"side": "sell", "amount": "1", "price": "1000", "quote_asset_id": "usd", "proceeds": "1000",
+		"executed_at": "2025-06-15T00:00:00Z",
+	}, sale.Data)
+	assert.Equal(t, maturity, TransactionTime(sale))
+	coupon := s.transactions[2]
+	assert.Equal(t, "bond-maturity:h1:coupon", coupon.ExternalID)
+	assert.Equal(t, entity.TransactionTypeIncome, coupon.Type)
+	assert.Equal(t, map[string]string{
+		"income": "interest", "amount": "60", "value": "60", "value_asset_id": "usd",
+		"executed_at": "2025-06-15T00:00:00Z", "source_asset_id": "corp25",
+	}, coupon.Data)
+	assert.Equal(t, "a2", s.transactions[3].AccountID)
+
+	n, err = h.RedeemMaturedBonds(context.Background(), maturity.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Len(t, s.transactions, 5)
+}
+
+func TestRedemptionOfLot(t *testing.T) {
+	terms := &entity.BondTerms{FaceValue: dec("1000"), CurrencyAssetID: "usd", MaturityDate: time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC)}
+	lot := &entity.Lot{ID: "l1", Amount: 2, CostBasis: 1900, CostAssetID: "usd", AcquiredAt: time.Date(2023, time.January, 10, 0, 0, 0, 0, time.UTC)}
+
+	sale := redemptionSale(terms, &entity.Holding{ID: "h1", AccountID: "a1", AssetID: "corp"}, lot, dec("2"))
+	assert.Equal(t, "bond-maturity:l1", sale.ExternalID)
+	data := sale.Data
+	assert.Equal(t, "l1", data["lot_id"])
+	assert.Equal(t, "2023-01-10T00:00:00Z", data["acquired_at"])
+	assert.Equal(t, "1900", data["cost_basis"])
+	assert.Equal(t, "100", data["realized_gain"])
+}
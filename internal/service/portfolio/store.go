@@ -2,8 +2,11 @@ package portfolio
 
 import (
 	"context"
+	"time"
 
 	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/shopspring/decimal"
 )
 
 // Store defines the data access contract for PortfolioService.
@@ -15,6 +18,12 @@ type Store interface {
 	DeletePortfolio(ctx context.Context, id string) error
 	ListPortfolios(ctx context.Context, opts ListPortfoliosOpts) ([]*entity.Portfolio, string, error)
 
+	// Portfolio members
+	InvitePortfolioMember(ctx context.Context, m *entity.PortfolioMember) (*entity.PortfolioMember, error)
+	AcceptPortfolioInvitation(ctx context.Context, portfolioID, userID string) (*entity.PortfolioMember, error)
+	RevokePortfolioMember(ctx context.Context, portfolioID, userID string) error
+	ListPortfolioMembers(ctx context.Context, opts ListPortfolioMembersOpts) ([]*entity.PortfolioMember, error)
+
 	// Accounts
 	CreateAccount(ctx context.Context, a *entity.Account) (*entity.Account, error)
 	GetAccount(ctx context.Context, id string) (*entity.Account, error)
@@ -29,11 +38,72 @@ type Store interface {
 	DeleteHolding(ctx context.Context, id string) error
 	ListHoldings(ctx context.Context, opts ListHoldingsOpts) ([]*entity.Holding, string, error)
 
+	// Lots
+	CreateLot(ctx context.Context, l *entity.Lot) (*entity.Lot, error)
+	GetLot(ctx context.Context, id string) (*entity.Lot, error)
+	UpdateLot(ctx context.Context, l *entity.Lot, fields []string) (*entity.Lot, error)
+	DeleteLot(ctx context.Context, id string) error
+	ListLots(ctx context.Context, opts ListLotsOpts) ([]*entity.Lot, string, error)
+
 	// Transactions
 	CreateTransaction(ctx context.Context, t *entity.Transaction) (*entity.Transaction, error)
 	GetTransaction(ctx context.Context, id string) (*entity.Transaction, error)
 	UpdateTransaction(ctx context.Context, t *entity.Transaction, fields []string) (*entity.Transaction, error)
+	CreateTransactions(ctx context.Context, txs []*entity.Transaction) (int, error)
 	ListTransactions(ctx context.Context, opts ListTransactionsOpts) ([]*entity.Transaction, string, error)
+
+	// Bond maturities
+	RedeemHolding(ctx context.Context, r *Redemption) error
+
+	// Withdrawals
+	ApplyWithdrawalStep(ctx context.Context, w *WithdrawalStep) error
+
+	// Dividends
+	// ListUnrecordedDividends returns the dividends that went ex before
+	// before and whose income is not recorded yet, oldest first.
+	ListUnrecordedDividends(ctx context.Context, before time.Time) ([]*entity.Dividend, error)
+	// RecordDividend creates the income transactions of a dividend, skipping
+	// those whose external ID their account already has, and marks the
+	// dividend recorded, all or nothing. It returns how many it created.
+	RecordDividend(ctx context.Context, d *entity.Dividend, txs []*entity.Transaction) (int, error)
+}
+
+// Redemption turns a holding into cash all or nothing: the holding and its
+// lots are emptied, the transactions recording it are created, skipping
+// those whose external ID the account already has, and Cash is added to the
+// account's holding of CashAssetID in the holding's portfolio, created if
+// there is none. A holding that is already empty is left alone.
+type Redemption struct {
+	HoldingID    string
+	LotIDs       []string
+	Transactions []*entity.Transaction
+	CashAssetID  string
+	Cash         decimal.Decimal
+}
+
+// WithdrawalStep takes units out of a holding all or nothing: Amount, in the
+// holding's decimals, is removed from the holding, Lot, if any, is set to the
+// amount and cost basis it keeps, and Transaction, if any, records the sale.
+type WithdrawalStep struct {
+	HoldingID   string
+	Amount      int64
+	Lot         *entity.Lot
+	Transaction *entity.Transaction
+}
+
+// MarketDataStore is the subset of marketdata.Store that transaction import,
+// valuation and reports need to resolve symbols and convert values.
+type MarketDataStore interface {
+	GetAsset(ctx context.Context, id string) (*entity.Asset, error)
+	ListAssets(ctx context.Context, opts marketdata.ListAssetsOpts) ([]*entity.Asset, string, error)
+	// GetPriceAt returns the last price of an asset at or before at, in
+	// baseAssetID or, if it is empty, in any asset.
+	GetPriceAt(ctx context.Context, assetID, baseAssetID string, at time.Time) (*entity.StoredPrice, error)
+}
+
+// UserStore reads the preferences that pick a user's default currency.
+type UserStore interface {
+	GetUser(ctx context.Context, id string) (*entity.User, error)
 }
 
 // ListPortfoliosOpts contains options for listing portfolios.
@@ -43,6 +113,12 @@ type ListPortfoliosOpts struct {
 	PageToken string
 }
 
+// ListPortfolioMembersOpts contains options for listing portfolio members.
+type ListPortfolioMembersOpts struct {
+	PortfolioID string
+	UserID      string
+}
+
 // ListAccountsOpts contains options for listing accounts.
 type ListAccountsOpts struct {
 	UserID    string
@@ -60,12 +136,24 @@ type ListHoldingsOpts struct {
 	PageToken   string
 }
 
+// ListLotsOpts contains options for listing lots.
+type ListLotsOpts struct {
+	HoldingID   string
+	PortfolioID string
+	PageSize    int
+	PageToken   string
+}
+
 // ListTransactionsOpts contains options for listing transactions.
 type ListTransactionsOpts struct {
-	AccountID string
-	AssetID   string
-	Type      entity.TransactionType
-	Status    entity.TransactionStatus
-	PageSize  int
-	PageToken string
+	AccountID   string
+	AssetID     string
+	PortfolioID string // Touching a holding of the portfolio, see the postgres store
+	ExternalIDs []string
+	Type        entity.TransactionType
+	Status      entity.TransactionStatus
+	From        *time.Time // Executed at or after, see TransactionTime
+	To          *time.Time // Executed before
+	PageSize    int
+	PageToken   string
 }
@@ -0,0 +1,736 @@
+package portfolio
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TaxConfig sets the jurisdictions tax reports can follow and the tax
+// withheld from dividends.
+type TaxConfig struct {
+	// DefaultJurisdiction applies when a request names none.
+	DefaultJurisdiction string                     `koanf:"defaultJurisdiction"`
+	Jurisdictions       map[string]TaxJurisdiction `koanf:"jurisdictions"`
+	// DividendWithholding is the rate of tax withheld from dividends paid in
+	// a currency, by its ISO 4217 code; none where unset.
+	DividendWithholding map[string]float64 `koanf:"dividendWithholding"`
+}
+
+// TaxJurisdiction is how a tax authority counts tax years and holding
+// periods.
+type TaxJurisdiction struct {
+	// LongTermMonths is how long an asset must be held for its disposal to
+	// be long-term. Zero makes no distinction.
+	LongTermMonths int `koanf:"longTermMonths"`
+	// YearStart is the first day of the tax year as "MM-DD", January 1 if
+	// empty.
+	YearStart string `koanf:"yearStart"`
+	// Timezone is the IANA zone that dates are counted in, UTC if empty.
+	Timezone string `koanf:"timezone"`
+	// LotMethod matches sells that recorded no lot to what the account
+	// acquired before: LotMethodFIFO if empty, LotMethodLIFO or
+	// LotMethodAverage.
+	LotMethod string `koanf:"lotMethod"`
+}
+
+// Lot methods of a jurisdiction.
+const (
+	LotMethodFIFO    = "fifo"    // Oldest lots first
+	LotMethodLIFO    = "lifo"    // Newest lots first
+	LotMethodAverage = "average" // Every lot in proportion, as one pool
+)
+
+// Validate checks every jurisdiction and withholding rate and that the
+// default jurisdiction exists.
+func (c TaxConfig) Validate() error {
+	for name, j := range c.Jurisdictions {
+		if _, _, err := j.year(2000); err != nil {
+			return fmt.Errorf("jurisdiction %q: %w", name, err)
+		}
+		switch j.LotMethod {
+		case "", LotMethodFIFO, LotMethodLIFO, LotMethodAverage:
+		default:
+			return fmt.Errorf("jurisdiction %q: unknown lot method %q", name, j.LotMethod)
+		}
+	}
+	for code, rate := range c.DividendWithholding {
+		if rate < 0 || rate >= 1 {
+			return fmt.Errorf("dividend withholding of %s: rate %v is not in [0, 1)", code, rate)
+		}
+	}
+	if _, ok := c.Jurisdictions[c.DefaultJurisdiction]; c.DefaultJurisdiction != "" && !ok {
+		return fmt.Errorf("default jurisdiction %q is not configured", c.DefaultJurisdiction)
+	}
+	return nil
+}
+
+// year returns the bounds of a tax year, [start, end), in the
+// jurisdiction's time zone.
+func (j TaxJurisdiction) year(year int) (time.Time, time.Time, error) {
+	if j.LongTermMonths < 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("negative long-term months %d", j.LongTermMonths)
+	}
+	loc := time.UTC
+	if j.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(j.Timezone); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("timezone: %w", err)
+		}
+	}
+	month, day := time.January, 1
+	if j.YearStart != "" {
+		first, err := time.Parse("01-02", j.YearStart)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("year start %q is not MM-DD", j.YearStart)
+		}
+		month, day = first.Month(), first.Day()
+	}
+	start := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	return start, start.AddDate(1, 0, 0), nil
+}
+
+// holdingPeriod classifies the disposal of an asset held from acquired to
+// disposed. It is long-term if disposed on a later day than LongTermMonths
+// after acquired; days are counted in loc.
+func (j TaxJurisdiction) holdingPeriod(loc *time.Location, acquired, disposed time.Time) apiv1.HoldingPeriod {
+	if j.LongTermMonths == 0 || acquired.IsZero() {
+		return apiv1.HoldingPeriod_HOLDING_PERIOD_UNSPECIFIED
+	}
+	a, d := acquired.In(loc), disposed.In(loc)
+	threshold := time.Date(a.Year(), a.Month()+time.Month(j.LongTermMonths), a.Day(), 0, 0, 0, 0, loc)
+	if time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc).After(threshold) {
+		return apiv1.HoldingPeriod_HOLDING_PERIOD_LONG_TERM
+	}
+	return apiv1.HoldingPeriod_HOLDING_PERIOD_SHORT_TERM
+}
+
+// taxTotal sums a tax report in one currency.
+type taxTotal struct {
+	proceeds, costBasis decimal.Decimal
+	gains               map[apiv1.HoldingPeriod]decimal.Decimal
+	income              decimal.Decimal
+}
+
+// taxReport collects the disposals and income of a user's accounts.
+type taxReport struct {
+	h            *Handler
+	jurisdiction TaxJurisdiction
+	loc          *time.Location
+	start, end   time.Time
+	report       *apiv1.TaxReport
+	currency     string // Asset ID values are reported in, "" to keep their own
+	rates        *rates
+	totals       map[string]*taxTotal     // By currency asset ID
+	assets       map[string]*entity.Asset // Nil for unknown assets
+}
+
+// load reads the completed transactions of every account of userID in the
+// tax year.
+func (r *taxReport) load(ctx context.Context, userID string) error {
+	for token := ""; ; {
+		accounts, next, err := r.h.store.ListAccounts(ctx, ListAccountsOpts{
+			UserID:    userID,
+			PageSize:  exportPageSize,
+			PageToken: token,
+		})
+		if err != nil {
+			return err
+		}
+		for _, a := range accounts {
+			if err := r.loadAccount(ctx, a.ID); err != nil {
+				return err
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		token = next
+	}
+}
+
+// loadAccount reports the disposals and income of an account in the tax
+// year. Its transactions before are read too, for the lots they acquired.
+func (r *taxReport) loadAccount(ctx context.Context, accountID string) error {
+	var txs []*entity.Transaction
+	for token := ""; ; {
+		page, next, err := r.h.store.ListTransactions(ctx, ListTransactionsOpts{
+			AccountID: accountID,
+			Status:    entity.TransactionStatusCompleted,
+			To:        &r.end,
+			PageSize:  exportPageSize,
+			PageToken: token,
+		})
+		if err != nil {
+			return err
+		}
+		txs = append(txs, page...)
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	slices.SortStableFunc(txs, func(a, b *entity.Transaction) int {
+		return cmp.Or(TransactionTime(a).Compare(TransactionTime(b)), a.CreatedAt.Compare(b.CreatedAt))
+	})
+
+	pools := make(map[string]*lotPool) // By asset ID
+	for _, t := range txs {
+		sell := t.Type == entity.TransactionTypeTrade && t.Data["side"] == SideSell
+		var sold []taxLot
+		for _, leg := range transactionLegs(t) {
+			pool, ok := pools[leg.assetID]
+			if !ok {
+				pool = &lotPool{}
+				pools[leg.assetID] = pool
+			}
+			if leg.amount.IsPositive() {
+				*pool = append(*pool, acquisition(t, leg))
+				continue
+			}
+			parts := pool.take(leg.amount.Neg(), r.jurisdiction.LotMethod)
+			if sell && leg.leg == legBase {
+				sold = parts
+			}
+		}
+		if TransactionTime(t).Before(r.start) {
+			continue
+		}
+		var err error
+		switch {
+		case sell:
+			err = r.addDisposal(ctx, t, sold)
+		case isIncome(t):
+			err = r.addIncome(ctx, t)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// taxLot is units of an asset an account acquired at one time and cost.
+type taxLot struct {
+	amount     decimal.Decimal
+	acquiredAt time.Time
+	cost       decimal.Decimal // Of amount, in currency
+	currency   string
+	costKnown  bool
+	lotID      string // Of a stored lot a sale recorded
+}
+
+// split removes amount, at most all of l, from l and returns it as a lot
+// with its share of the cost.
+func (l *taxLot) split(amount decimal.Decimal) taxLot {
+	part := *l
+	if amount.LessThan(l.amount) {
+		part.amount = amount
+		part.cost = l.cost.Mul(amount).Div(l.amount).Round(convertedPlaces)
+	}
+	l.amount = l.amount.Sub(part.amount)
+	l.cost = l.cost.Sub(part.cost)
+	return part
+}
+
+// acquisition returns the lot leg, a movement into the account, acquired:
+// at what a buy paid, at the value of income, or, for what a sale received
+// and income without a value, at its own amount. Deposits are of unknown
+// cost.
+func acquisition(t *entity.Transaction, leg exportLeg) *taxLot {
+	l := &taxLot{amount: leg.amount, acquiredAt: TransactionTime(t)}
+	switch {
+	case leg.leg == legQuote, isIncome(t) && t.Data["value_asset_id"] == "":
+		l.cost, l.currency, l.costKnown = leg.amount, leg.assetID, true
+	case isIncome(t):
+		if value, ok := dataDecimal(t, "value"); ok {
+			l.cost, l.currency, l.costKnown = value.Abs(), t.Data["value_asset_id"], true
+		}
+	case t.Type == entity.TransactionTypeTrade && t.Data["side"] == SideBuy && t.Data["quote_asset_id"] != "":
+		cost, ok := dataDecimal(t, "cost")
+		if !ok {
+			var price decimal.Decimal
+			if price, ok = dataDecimal(t, "price"); ok {
+				cost = leg.amount.Mul(price)
+			}
+		}
+		if ok {
+			l.cost, l.currency, l.costKnown = cost.Abs(), t.Data["quote_asset_id"], true
+		}
+	}
+	return l
+}
+
+// lotPool holds the lots of an asset an account still holds, oldest first.
+type lotPool []*taxLot
+
+// take removes amount from the pool by a lot method and returns the parts
+// of lots it consumed. Units beyond the pool's are left out.
+func (p *lotPool) take(amount decimal.Decimal, method string) []taxLot {
+	var parts []taxLot
+	if method == LotMethodAverage {
+		total := decimal.Zero
+		for _, l := range *p {
+			total = total.Add(l.amount)
+		}
+		taken := decimal.Min(amount, total)
+		left := taken
+		for i, l := range *p {
+			share := left
+			if i < len(*p)-1 {
+				share = decimal.Min(l.amount.Mul(taken).Div(total), left)
+			}
+			part := l.split(share)
+			parts = append(parts, part)
+			left = left.Sub(part.amount)
+		}
+		*p = slices.DeleteFunc(*p, func(l *taxLot) bool { return !l.amount.IsPositive() })
+		return parts
+	}
+	for left := amount; left.IsPositive() && len(*p) > 0; {
+		i := 0
+		if method == LotMethodLIFO {
+			i = len(*p) - 1
+		}
+		part := (*p)[i].split(left)
+		parts = append(parts, part)
+		left = left.Sub(part.amount)
+		if !(*p)[i].amount.IsPositive() {
+			*p = slices.Delete(*p, i, i+1)
+		}
+	}
+	return parts
+}
+
+// dataDecimal returns the decimal data field key of t, if it is set and
+// valid.
+func dataDecimal(t *entity.Transaction, key string) (decimal.Decimal, bool) {
+	if t.Data[key] == "" {
+		return decimal.Zero, false
+	}
+	d, err := parseDecimal(t.Data[key])
+	return d, err == nil
+}
+
+// addDisposal reports a sell. A sell that consumed a stored lot recorded
+// its acquisition date and cost basis, or the realized gain it follows
+// from; any other is reported per lot matched, a disposal for each or, by
+// the average method, one for the pool, with the units no lot covered
+// reported without a cost basis. Proceeds are shared by units. In a report
+// currency, proceeds are converted at the rate of the day of the sale and
+// cost bases at that of the day of the purchase, so gains include what the
+// exchange rate moved.
+func (r *taxReport) addDisposal(ctx context.Context, t *entity.Transaction, matched []taxLot) error {
+	amount, ok := dataDecimal(t, "amount")
+	if !ok || t.AssetID == "" {
+		r.h.log.Warn("Tax report skips a sell without asset or amount", "transaction_id", t.ID)
+		return nil
+	}
+	proceeds, ok := dataDecimal(t, "proceeds")
+	if !ok {
+		price, _ := dataDecimal(t, "price")
+		proceeds = amount.Mul(price)
+	}
+	if err := r.loadAsset(ctx, t.AssetID); err != nil {
+		return err
+	}
+
+	amount, proceeds = amount.Abs(), proceeds.Abs()
+	disposedAt := TransactionTime(t)
+	saleCurrency := t.Data["quote_asset_id"]
+	lots, pooled := matched, r.jurisdiction.LotMethod == LotMethodAverage
+	if recorded := taxLotRecorded(t, amount, proceeds); recorded != nil {
+		lots, pooled = []taxLot{*recorded}, false
+	}
+
+	currency := saleCurrency
+	if r.currency != "" && currency != "" && currency != r.currency {
+		converted, ok, err := r.rates.convert(ctx, proceeds, currency, r.currency, disposedAt)
+		if err != nil {
+			return err
+		}
+		if ok {
+			proceeds, currency = converted, r.currency
+		} else {
+			r.h.log.Warn("Tax report keeps a sell in its own currency without a rate", "transaction_id", t.ID)
+		}
+	}
+
+	var parts []taxLot
+	covered := decimal.Zero
+	for _, l := range lots {
+		part, err := r.costIn(ctx, l, currency, disposedAt)
+		if err != nil {
+			return err
+		}
+		parts = append(parts, part)
+		covered = covered.Add(l.amount)
+	}
+	if pooled && len(parts) > 0 {
+		// The pool has no acquisition date.
+		pool := taxLot{currency: currency, costKnown: true}
+		for _, part := range parts {
+			pool.amount = pool.amount.Add(part.amount)
+			pool.cost = pool.cost.Add(part.cost)
+			pool.costKnown = pool.costKnown && part.costKnown
+		}
+		parts = []taxLot{pool}
+	}
+	if rest := amount.Sub(covered); rest.IsPositive() {
+		parts = append(parts, taxLot{amount: rest})
+	}
+
+	shared := decimal.Zero
+	for i, part := range parts {
+		share := proceeds.Sub(shared)
+		if i < len(parts)-1 {
+			share = proceeds.Mul(part.amount).Div(amount).Round(convertedPlaces)
+		}
+		shared = shared.Add(share)
+		r.addDisposalLot(t, disposedAt, currency, part, share)
+	}
+	return nil
+}
+
+// taxLotRecorded returns the lot a sell recorded consuming, in its own
+// currency, or nil if it recorded none.
+func taxLotRecorded(t *entity.Transaction, amount, proceeds decimal.Decimal) *taxLot {
+	l := &taxLot{amount: amount, currency: t.Data["quote_asset_id"], lotID: t.Data["lot_id"]}
+	if at, err := time.Parse(time.RFC3339, t.Data["acquired_at"]); err == nil {
+		l.acquiredAt = at
+	}
+	l.cost, l.costKnown = dataDecimal(t, "cost_basis")
+	if !l.costKnown {
+		if gain, ok := dataDecimal(t, "realized_gain"); ok {
+			l.cost, l.costKnown = proceeds.Sub(gain), true
+		}
+	}
+	if l.lotID == "" && l.acquiredAt.IsZero() && !l.costKnown {
+		return nil
+	}
+	return l
+}
+
+// costIn returns l with its cost in currency, converted at the rate of the
+// day it was acquired, or of the sale if that is unknown.
+func (r *taxReport) costIn(ctx context.Context, l taxLot, currency string, disposedAt time.Time) (taxLot, error) {
+	if !l.costKnown || l.currency == currency {
+		return l, nil
+	}
+	if l.currency == "" || currency == "" {
+		l.costKnown = false
+		return l, nil
+	}
+	var err error
+	l.cost, l.costKnown, err = r.rates.convert(ctx, l.cost, l.currency, currency, cmp.Or(l.acquiredAt, disposedAt))
+	l.currency = currency
+	return l, err
+}
+
+// addDisposalLot reports the units of a sell from one lot.
+func (r *taxReport) addDisposalLot(t *entity.Transaction, disposedAt time.Time, currency string, l taxLot, proceeds decimal.Decimal) {
+	d := &apiv1.TaxDisposal{
+		TransactionId:   t.ID,
+		AccountId:       t.AccountID,
+		AssetId:         t.AssetID,
+		Symbol:          r.symbol(t.AssetID),
+		Amount:          l.amount.String(),
+		DisposedAt:      timestamppb.New(disposedAt),
+		CurrencyAssetId: currency,
+		Proceeds:        proceeds.String(),
+	}
+	if l.lotID != "" {
+		d.LotId = &l.lotID
+	}
+	if !l.acquiredAt.IsZero() {
+		d.AcquiredAt = timestamppb.New(l.acquiredAt)
+	}
+	d.HoldingPeriod = r.jurisdiction.holdingPeriod(r.loc, l.acquiredAt, disposedAt)
+
+	total := r.total(d.CurrencyAssetId)
+	total.proceeds = total.proceeds.Add(proceeds)
+	if l.costKnown {
+		gain := proceeds.Sub(l.cost)
+		d.CostBasis = proto.String(l.cost.String())
+		d.Gain = proto.String(gain.String())
+		total.costBasis = total.costBasis.Add(l.cost)
+		total.gains[d.HoldingPeriod] = total.gains[d.HoldingPeriod].Add(gain)
+	} else {
+		r.report.IncompleteCount++
+	}
+	r.report.Disposals = append(r.report.Disposals, d)
+}
+
+// addIncome reports an income event, valued if its value was recorded. In a
+// report currency, it is valued at the rate of the day it was received,
+// from its recorded value or else from the amount received.
+func (r *taxReport) addIncome(ctx context.Context, t *entity.Transaction) error {
+	amount, _ := dataDecimal(t, "amount")
+	if err := r.loadAsset(ctx, t.AssetID); err != nil {
+		return err
+	}
+	receivedAt := TransactionTime(t)
+	income := &apiv1.TaxIncome{
+		TransactionId: t.ID,
+		AccountId:     t.AccountID,
+		AssetId:       t.AssetID,
+		Symbol:        r.symbol(t.AssetID),
+		Amount:        amount.Abs().String(),
+		Kind:          t.Data["income"],
+		ReceivedAt:    timestamppb.New(receivedAt),
+	}
+	value, valued := dataDecimal(t, "value")
+	currency := t.Data["value_asset_id"]
+	valued = valued && currency != ""
+	if r.currency != "" && currency != r.currency {
+		// Income without a value is worth what was received.
+		from, worth := currency, value
+		if !valued {
+			from, worth = t.AssetID, amount.Abs()
+		}
+		if from != "" {
+			converted, ok, err := r.rates.convert(ctx, worth, from, r.currency, receivedAt)
+			if err != nil {
+				return err
+			}
+			if ok {
+				value, currency, valued = converted, r.currency, true
+			}
+		}
+	}
+	if valued {
+		income.Value = proto.String(value.String())
+		income.CurrencyAssetId = &currency
+		total := r.total(currency)
+		total.income = total.income.Add(value)
+	}
+	r.report.Income = append(r.report.Income, income)
+	return nil
+}
+
+func (r *taxReport) total(currency string) *taxTotal {
+	total, ok := r.totals[currency]
+	if !ok {
+		total = &taxTotal{gains: map[apiv1.HoldingPeriod]decimal.Decimal{}}
+		r.totals[currency] = total
+	}
+	return total
+}
+
+// loadAsset caches the asset with id for its symbol.
+func (r *taxReport) loadAsset(ctx context.Context, id string) error {
+	if _, ok := r.assets[id]; ok || id == "" {
+		return nil
+	}
+	asset, err := r.h.marketData.GetAsset(ctx, id)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+	r.assets[id] = asset
+	return nil
+}
+
+func (r *taxReport) symbol(assetID string) string {
+	if a := r.assets[assetID]; a != nil {
+		return a.Symbol
+	}
+	return ""
+}
+
+// finish orders the report and adds its totals.
+func (r *taxReport) finish() *apiv1.TaxReport {
+	slices.SortStableFunc(r.report.Disposals, func(a, b *apiv1.TaxDisposal) int {
+		return a.DisposedAt.AsTime().Compare(b.DisposedAt.AsTime())
+	})
+	slices.SortStableFunc(r.report.Income, func(a, b *apiv1.TaxIncome) int {
+		return a.ReceivedAt.AsTime().Compare(b.ReceivedAt.AsTime())
+	})
+	for _, currency := range slices.Sorted(maps.Keys(r.totals)) {
+		t := r.totals[currency]
+		r.report.Totals = append(r.report.Totals, &apiv1.TaxTotal{
+			CurrencyAssetId: currency,
+			Proceeds:        t.proceeds.String(),
+			CostBasis:       t.costBasis.String(),
+			ShortTermGain:   t.gains[apiv1.HoldingPeriod_HOLDING_PERIOD_SHORT_TERM].String(),
+			LongTermGain:    t.gains[apiv1.HoldingPeriod_HOLDING_PERIOD_LONG_TERM].String(),
+			OtherGain:       t.gains[apiv1.HoldingPeriod_HOLDING_PERIOD_UNSPECIFIED].String(),
+			Income:          t.income.String(),
+		})
+	}
+	return r.report
+}
+
+// --- Tax report RPC ---
+
+func (h *Handler) GenerateTaxReport(ctx context.Context, req *connect.Request[apiv1.GenerateTaxReportRequest]) (*connect.Response[apiv1.TaxReport], error) {
+	if req.Msg.TaxYear < 1 || req.Msg.TaxYear > 9999 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tax year is required"))
+	}
+	userID := auth.OwnerID(ctx, req.Msg.GetUserId())
+	if userID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("user ID is required"))
+	}
+	if p, ok := auth.FromContext(ctx); ok && p.UserID != userID && !p.HasScope(auth.ScopeAdmin) {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("tax reports of other users require the admin scope"))
+	}
+	name := h.tax.DefaultJurisdiction
+	if req.Msg.Jurisdiction != nil {
+		name = *req.Msg.Jurisdiction
+	}
+	jurisdiction, ok := h.tax.Jurisdictions[name]
+	if !ok {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown jurisdiction %q", name))
+	}
+	start, end, err := jurisdiction.year(int(req.Msg.TaxYear))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("jurisdiction %q: %w", name, err))
+	}
+	currency := req.Msg.GetCurrencyAssetId()
+	if currency == "" {
+		if currency, err = h.defaultCurrency(ctx, userID); err != nil {
+			return nil, toConnectError(err)
+		}
+	} else if _, err := h.marketData.GetAsset(ctx, currency); err != nil {
+		return nil, toConnectError(err)
+	}
+
+	r := &taxReport{
+		h:            h,
+		jurisdiction: jurisdiction,
+		loc:          start.Location(),
+		start:        start,
+		end:          end,
+		report: &apiv1.TaxReport{
+			UserId:       userID,
+			TaxYear:      req.Msg.TaxYear,
+			Jurisdiction: name,
+			PeriodStart:  timestamppb.New(start),
+			PeriodEnd:    timestamppb.New(end),
+		},
+		currency: currency,
+		rates:    newRates(h.marketData),
+		totals:   map[string]*taxTotal{},
+		assets:   map[string]*entity.Asset{},
+	}
+	if err := r.load(ctx, userID); err != nil {
+		return nil, toConnectError(err)
+	}
+	report := r.finish()
+
+	if req.Msg.IncludeCsv {
+		var buf bytes.Buffer
+		if err := writeTaxCSV(&buf, report, r.loc); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		report.Csv = buf.Bytes()
+	}
+	return connect.NewResponse(report), nil
+}
+
+// --- Tax report CSV ---
+
+var taxCSVHeader = []string{
+	"section", "date", "transaction_id", "account_id", "asset_id", "symbol", "amount", "kind",
+	"lot_id", "acquired_at", "holding_period", "currency_asset_id", "proceeds", "cost_basis", "gain", "value",
+}
+
+// writeTaxCSV writes a report as one row per disposal and income event,
+// then disposal totals by currency and holding period and income totals by
+// currency. Dates are days in loc.
+func writeTaxCSV(w io.Writer, report *apiv1.TaxReport, loc *time.Location) error {
+	date := func(ts *timestamppb.Timestamp) string {
+		if ts == nil {
+			return ""
+		}
+		return ts.AsTime().In(loc).Format(time.DateOnly)
+	}
+	period := func(p apiv1.HoldingPeriod) string {
+		return enumName(p, "HOLDING_PERIOD_")
+	}
+
+	type totalKey struct {
+		currency string
+		period   apiv1.HoldingPeriod
+	}
+	type disposalTotal struct {
+		proceeds, costBasis, gain decimal.Decimal
+	}
+	totals := map[totalKey]*disposalTotal{}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(taxCSVHeader); err != nil {
+		return err
+	}
+	for _, d := range report.Disposals {
+		if err := cw.Write([]string{
+			"disposal", date(d.DisposedAt), d.TransactionId, d.AccountId, d.AssetId, d.Symbol, d.Amount, "",
+			d.GetLotId(), date(d.AcquiredAt), period(d.HoldingPeriod), d.CurrencyAssetId, d.Proceeds, d.GetCostBasis(), d.GetGain(), "",
+		}); err != nil {
+			return err
+		}
+		key := totalKey{d.CurrencyAssetId, d.HoldingPeriod}
+		t, ok := totals[key]
+		if !ok {
+			t = &disposalTotal{}
+			totals[key] = t
+		}
+		proceeds, _ := decimal.NewFromString(d.Proceeds)
+		t.proceeds = t.proceeds.Add(proceeds)
+		if d.CostBasis != nil {
+			costBasis, _ := decimal.NewFromString(d.GetCostBasis())
+			gain, _ := decimal.NewFromString(d.GetGain())
+			t.costBasis = t.costBasis.Add(costBasis)
+			t.gain = t.gain.Add(gain)
+		}
+	}
+	for _, i := range report.Income {
+		if err := cw.Write([]string{
+			"income", date(i.ReceivedAt), i.TransactionId, i.AccountId, i.AssetId, i.Symbol, i.Amount, i.Kind,
+			"", "", "", i.GetCurrencyAssetId(), "", "", "", i.GetValue(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	keys := slices.SortedFunc(maps.Keys(totals), func(a, b totalKey) int {
+		return cmp.Or(cmp.Compare(a.currency, b.currency), cmp.Compare(a.period, b.period))
+	})
+	for _, key := range keys {
+		t := totals[key]
+		if err := cw.Write([]string{
+			"disposal_total", "", "", "", "", "", "", "",
+			"", "", period(key.period), key.currency, t.proceeds.String(), t.costBasis.String(), t.gain.String(), "",
+		}); err != nil {
+			return err
+		}
+	}
+	for _, t := range report.Totals {
+		if t.Income == "0" {
+			continue
+		}
+		if err := cw.Write([]string{
+			"income_total", "", "", "", "", "", "", "",
+			"", "", "", t.CurrencyAssetId, "", "", "", t.Income,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,374 @@
+package portfolio
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// taxStore holds the accounts of user u1 and filters transactions like the
+// postgres store.
+type taxStore struct {
+	Store
+	transactions []*entity.Transaction
+}
+
+func (s *taxStore) ListAccounts(_ context.Context, opts ListAccountsOpts) ([]*entity.Account, string, error) {
+	if opts.UserID != "u1" {
+		return nil, "", nil
+	}
+	if opts.PageToken == "" {
+		return []*entity.Account{{ID: "a1", UserID: "u1"}}, "2", nil
+	}
+	return []*entity.Account{{ID: "a2", UserID: "u1"}}, "", nil
+}
+
+func (s *taxStore) ListTransactions(_ context.Context, opts ListTransactionsOpts) ([]*entity.Transaction, string, error) {
+	var matching []*entity.Transaction
+	for _, t := range s.transactions {
+		at := TransactionTime(t)
+		if t.AccountID == opts.AccountID && t.Status == opts.Status &&
+			(opts.From == nil || !at.Before(*opts.From)) && (opts.To == nil || at.Before(*opts.To)) {
+			matching = append(matching, t)
+		}
+	}
+	return matching, "", nil
+}
+
+var testTaxConfig = TaxConfig{
+	DefaultJurisdiction: "us",
+	Jurisdictions: map[string]TaxJurisdiction{
+		"us": {LongTermMonths: 12},
+		"uk": {YearStart: "04-06", Timezone: "Europe/London"},
+	},
+}
+
+func TestTaxJurisdiction_Year(t *testing.T) {
+	start, end, err := TaxJurisdiction{}.year(2024)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), end)
+
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+	start, end, err = testTaxConfig.Jurisdictions["uk"].year(2024)
+	require.NoError(t, err)
+	assert.True(t, start.Equal(time.Date(2024, 4, 6, 0, 0, 0, 0, london)))
+	assert.True(t, end.Equal(time.Date(2025, 4, 6, 0, 0, 0, 0, london)))
+	// London is on summer time in April.
+	assert.Equal(t, time.Date(2024, 4, 5, 23, 0, 0, 0, time.UTC), start.UTC())
+}
+
+func TestTaxJurisdiction_HoldingPeriod(t *testing.T) {
+	us := testTaxConfig.Jurisdictions["us"]
+	acquired := time.Date(2023, 1, 15, 18, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, apiv1.HoldingPeriod_HOLDING_PERIOD_SHORT_TERM,
+		us.holdingPeriod(time.UTC, acquired, time.Date(2024, 1, 15, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, apiv1.HoldingPeriod_HOLDING_PERIOD_LONG_TERM,
+		us.holdingPeriod(time.UTC, acquired, time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, apiv1.HoldingPeriod_HOLDING_PERIOD_UNSPECIFIED,
+		us.holdingPeriod(time.UTC, time.Time{}, time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, apiv1.HoldingPeriod_HOLDING_PERIOD_UNSPECIFIED,
+		testTaxConfig.Jurisdictions["uk"].holdingPeriod(time.UTC, acquired, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestTaxConfig_Validate(t *testing.T) {
+	assert.NoError(t, testTaxConfig.Validate())
+	assert.NoError(t, TaxConfig{}.Validate())
+	assert.Error(t, TaxConfig{DefaultJurisdiction: "fr"}.Validate())
+	assert.Error(t, TaxConfig{Jurisdictions: map[string]TaxJurisdiction{"x": {YearStart: "April"}}}.Validate())
+	assert.Error(t, TaxConfig{Jurisdictions: map[string]TaxJurisdiction{"x": {Timezone: "Mars/Base"}}}.Validate())
+	assert.Error(t, TaxConfig{Jurisdictions: map[string]TaxJurisdiction{"x": {LongTermMonths: -1}}}.Validate())
+	assert.Error(t, TaxConfig{Jurisdictions: map[string]TaxJurisdiction{"x": {LotMethod: "hifo"}}}.Validate())
+}
+
+func newTaxHandler() *Handler {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := &taxStore{transactions: []*entity.Transaction{
+		// Long-term sale of a lot, by a withdrawal rule.
+		{ID: "t1", AccountID: "a1", AssetID: "btc", Type: entity.TransactionTypeTrade, Status: entity.TransactionStatusCompleted,
+			CreatedAt: at, Data: map[string]string{
+				"side": "sell", "amount": "0.5", "price": "60000", "quote_asset_id": "usd", "proceeds": "30000",
+				"lot_id": "l1", "acquired_at": "2022-03-01T00:00:00Z", "cost_basis": "10000", "realized_gain": "20000",
+			}},
+		// Short-term sale that recorded only its gain.
+		{ID: "t2", AccountID: "a2", AssetID: "eth", Type: entity.TransactionTypeTrade, Status: entity.TransactionStatusCompleted,
+			CreatedAt: at.Add(-24 * time.Hour), Data: map[string]string{
+				"side": "sell", "amount": "2", "quote_asset_id": "usd", "proceeds": "6000",
+				"lot_id": "l2", "acquired_at": "2024-02-01T00:00:00Z", "realized_gain": "-500",
+			}},
+		// Imported sale without a lot.
+		{ID: "t3", AccountID: "a1", AssetID: "btc", Type: entity.TransactionTypeTrade, Status: entity.TransactionStatusCompleted,
+			CreatedAt: at, Data: map[string]string{
+				"side": "sell", "amount": "0.1", "price": "50000", "quote_asset_id": "usd",
+				"executed_at": "2024-03-01T09:00:00Z", "source": "binance",
+			}},
+		{ID: "t4", AccountID: "a2", AssetID: "eth", Type: entity.TransactionTypeIncome, Status: entity.TransactionStatusCompleted,
+			CreatedAt: at.Add(time.Hour), Data: map[string]string{
+				"income": "staking", "amount": "0.05", "value": "150", "value_asset_id": "usd",
+			}},
+		{ID: "t5", AccountID: "a2", AssetID: "xyz", Type: entity.TransactionTypeExtended, Status: entity.TransactionStatusCompleted,
+			CreatedAt: at.Add(2 * time.Hour), Data: map[string]string{"income": "airdrop", "amount": "100"}},
+		// Not reported: a buy, an untagged extended transaction, a failed
+		// sale and a sale in another year.
+		{ID: "t6", AccountID: "a1", AssetID: "btc", Type: entity.TransactionTypeTrade, Status: entity.TransactionStatusCompleted,
+			CreatedAt: at, Data: map[string]string{"side": "buy", "amount": "1", "quote_asset_id": "usd", "cost": "60000"}},
+		{ID: "t7", AccountID: "a1", AssetID: "btc", Type: entity.TransactionTypeExtended, Status: entity.TransactionStatusCompleted,
+			CreatedAt: at, Data: map[string]string{"amount": "1"}},
+		{ID: "t8", AccountID: "a1", AssetID: "btc", Type: entity.TransactionTypeTrade, Status: entity.TransactionStatusFailed,
+			CreatedAt: at, Data: map[string]string{"side": "sell", "amount": "1", "quote_asset_id": "usd", "proceeds": "60000"}},
+		{ID: "t9", AccountID: "a1", AssetID: "btc", Type: entity.TransactionTypeTrade, Status: entity.TransactionStatusCompleted,
+			CreatedAt: at, Data: map[string]string{
+				"side": "sell", "amount": "1", "quote_asset_id": "usd", "proceeds": "40000", "executed_at": "2023-12-31T23:59:59Z",
+			}},
+	}}
+	md := &fakeMarketData{assets: []*entity.Asset{{ID: "btc", Symbol: "BTC"}, {ID: "eth", Symbol: "ETH"}, {ID: "usd", Symbol: "USD"}}}
+	return NewHandler(s, md, nil, testTaxConfig, ImportConfig{}, slog.New(slog.DiscardHandler))
+}
+
+func TestGenerateTaxReport(t *testing.T) {
+	h := newTaxHandler()
+	resp, err := h.GenerateTaxReport(context.Background(), connect.NewRequest(&apiv1.GenerateTaxReportRequest{
+		UserId:     proto.String("u1"),
+		TaxYear:    2024,
+		IncludeCsv: true,
+	}))
+	require.NoError(t, err)
+	report := resp.Msg
+
+	assert.Equal(t, "us", report.Jurisdiction)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), report.PeriodStart.AsTime())
+
+	require.Len(t, report.Disposals, 3)
+	imported, short, long := report.Disposals[0], report.Disposals[1], report.Disposals[2]
+	assert.Equal(t, "t3", imported.TransactionId)
+	assert.Equal(t, "5000", imported.Proceeds)
+	assert.Nil(t, imported.CostBasis)
+	assert.Nil(t, imported.Gain)
+	assert.Nil(t, imported.AcquiredAt)
+	assert.Equal(t, apiv1.HoldingPeriod_HOLDING_PERIOD_UNSPECIFIED, imported.HoldingPeriod)
+
+	assert.Equal(t, "t2", short.TransactionId)
+	assert.Equal(t, "ETH", short.Symbol)
+	assert.Equal(t, "6500", short.GetCostBasis())
+	assert.Equal(t, "-500", short.GetGain())
+	assert.Equal(t, apiv1.HoldingPeriod_HOLDING_PERIOD_SHORT_TERM, short.HoldingPeriod)
+
+	assert.Equal(t, "t1", long.TransactionId)
+	assert.Equal(t, "l1", long.GetLotId())
+	assert.Equal(t, "2022-03-01T00:00:00Z", long.AcquiredAt.AsTime().Format(time.RFC3339))
+	assert.Equal(t, "20000", long.GetGain())
+	assert.Equal(t, apiv1.HoldingPeriod_HOLDING_PERIOD_LONG_TERM, long.HoldingPeriod)
+	assert.EqualValues(t, 1, report.IncompleteCount)
+
+	require.Len(t, report.Income, 2)
+	assert.Equal(t, "staking", report.Income[0].Kind)
+	assert.Equal(t, "150", report.Income[0].GetValue())
+	assert.Equal(t, "airdrop", report.Income[1].Kind)
+	assert.Nil(t, report.Income[1].Value)
+
+	require.Len(t, report.Totals, 1)
+	total := report.Totals[0]
+	assert.Equal(t, "usd", total.CurrencyAssetId)
+	assert.Equal(t, "41000", total.Proceeds)
+	assert.Equal(t, "16500", total.CostBasis)
+	assert.Equal(t, "-500", total.ShortTermGain)
+	assert.Equal(t, "20000", total.LongTermGain)
+	assert.Equal(t, "0", total.OtherGain)
+	assert.Equal(t, "150", total.Income)
+
+	rows, err := csv.NewReader(bytes.NewReader(report.Csv)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 1+3+2+3+1)
+	assert.Equal(t, taxCSVHeader, rows[0])
+	assert.Equal(t, []string{
+		"disposal", "2024-06-01", "t1", "a1", "btc", "BTC", "0.5", "",
+		"l1", "2022-03-01", "long_term", "usd", "30000", "10000", "20000", "",
+	}, rows[3])
+	assert.Equal(t, []string{
+		"income", "2024-06-01", "t4", "a2", "eth", "ETH", "0.05", "staking",
+		"", "", "", "usd", "", "", "", "150",
+	}, rows[4])
+	assert.Equal(t, []string{"disposal_total", "", "", "", "", "", "", "", "", "", "unspecified", "usd", "5000", "0", "0", ""}, rows[6])
+	assert.Equal(t, []string{"disposal_total", "", "", "", "", "", "", "", "", "", "long_term", "usd", "30000", "10000", "20000", ""}, rows[8])
+	assert.Equal(t, []string{"income_total", "", "", "", "", "", "", "", "", "", "", "usd", "", "", "", "150"}, rows[9])
+}
+
+func TestGenerateTaxReport_Jurisdiction(t *testing.T) {
+	h := newTaxHandler()
+	resp, err := h.GenerateTaxReport(context.Background(), connect.NewRequest(&apiv1.GenerateTaxReportRequest{
+		UserId:       proto.String("u1"),
+		TaxYear:      2023,
+		Jurisdiction: proto.String("uk"),
+	}))
+	require.NoError(t, err)
+	// The UK 2023 tax year runs to April 5, 2024.
+	require.Len(t, resp.Msg.Disposals, 2)
+	assert.Equal(t, "t9", resp.Msg.Disposals[0].TransactionId)
+	assert.Equal(t, "t3", resp.Msg.Disposals[1].TransactionId)
+	assert.Empty(t, resp.Msg.Csv)
+}
+
+func TestGenerateTaxReport_Currency(t *testing.T) {
+	h := newTaxHandler()
+	md := h.marketData.(*fakeMarketData)
+	md.assets = append(md.assets, &entity.Asset{ID: "eur", Symbol: "EUR", Type: entity.AssetTypeForex})
+	rise := time.Date(2024, 5, 20, 15, 0, 0, 0, time.UTC)
+	md.prices = dailyPrices("usd", "eur", 9000, 4, time.Date(2022, 2, 28, 15, 0, 0, 0, time.UTC), rise)
+	md.prices = append(md.prices, dailyPrices("usd", "eur", 9200, 4, rise, time.Now())...)
+	md.prices = append(md.prices, &entity.StoredPrice{AssetID: "xyz", BaseAssetID: "usd", Last: 5, Decimals: 1, Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)})
+
+	resp, err := h.GenerateTaxReport(context.Background(), connect.NewRequest(&apiv1.GenerateTaxReportRequest{
+		UserId:          proto.String("u1"),
+		TaxYear:         2024,
+		CurrencyAssetId: proto.String("eur"),
+	}))
+	require.NoError(t, err)
+	report := resp.Msg
+
+	// Proceeds convert at the rate of the sale and cost at that of the
+	// purchase, so the gain includes the dollar's rise.
+	require.Len(t, report.Disposals, 3)
+	imported, short, long := report.Disposals[0], report.Disposals[1], report.Disposals[2]
+	assert.Equal(t, "eur", imported.CurrencyAssetId)
+	assert.Equal(t, "4500", imported.Proceeds)
+	assert.Equal(t, "5520", short.Proceeds)
+	assert.Equal(t, "5850", short.GetCostBasis())
+	assert.Equal(t, "-330", short.GetGain())
+	assert.Equal(t, "27600", long.Proceeds)
+	assert.Equal(t, "9000", long.GetCostBasis())
+	assert.Equal(t, "18600", long.GetGain())
+
+	// Income without a value is worth what was received on the day.
+	require.Len(t, report.Income, 2)
+	assert.Equal(t, "138", report.Income[0].GetValue())
+	assert.Equal(t, "46", report.Income[1].GetValue())
+	assert.Equal(t, "eur", report.Income[1].GetCurrencyAssetId())
+
+	require.Len(t, report.Totals, 1)
+	total := report.Totals[0]
+	assert.Equal(t, "eur", total.CurrencyAssetId)
+	assert.Equal(t, "37620", total.Proceeds)
+	assert.Equal(t, "14850", total.CostBasis)
+	assert.Equal(t, "184", total.Income)
+
+	// The user's default currency applies unless another is asked for.
+	raw, _ := json.Marshal(map[string]string{"default_currency": "EUR"})
+	h.users = fakeUsers{"u1": {ID: "u1", Preferences: raw}}
+	resp, err = h.GenerateTaxReport(context.Background(), connect.NewRequest(&apiv1.GenerateTaxReportRequest{UserId: proto.String("u1"), TaxYear: 2024}))
+	require.NoError(t, err)
+	assert.Equal(t, "37620", resp.Msg.Totals[0].Proceeds)
+
+	_, err = h.GenerateTaxReport(context.Background(), connect.NewRequest(&apiv1.GenerateTaxReportRequest{
+		UserId: proto.String("u1"), TaxYear: 2024, CurrencyAssetId: proto.String("xyz"),
+	}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestGenerateTaxReport_Invalid(t *testing.T) {
+	h := newTaxHandler()
+	for name, req := range map[string]*apiv1.GenerateTaxReportRequest{
+		"no year":              {UserId: proto.String("u1")},
+		"no user":              {TaxYear: 2024},
+		"unknown jurisdiction": {UserId: proto.String("u1"), TaxYear: 2024, Jurisdiction: proto.String("fr")},
+	} {
+		_, err := h.GenerateTaxReport(context.Background(), connect.NewRequest(req))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), name)
+	}
+}
+
+func TestGenerateTaxReport_LotMatching(t *testing.T) {
+	trade := func(id, asset, side, amount, total, at string) *entity.Transaction {
+		field := map[string]string{SideBuy: "cost", SideSell: "proceeds"}[side]
+		return &entity.Transaction{ID: id, AccountID: "a1", AssetID: asset, Type: entity.TransactionTypeTrade,
+			Status: entity.TransactionStatusCompleted, Data: map[string]string{
+				"side": side, "amount": amount, "quote_asset_id": "usd", field: total, "executed_at": at,
+			}}
+	}
+	s := &taxStore{transactions: []*entity.Transaction{
+		trade("t1", "btc", SideBuy, "1", "20000", "2023-01-10T00:00:00Z"),
+		trade("t2", "btc", SideBuy, "1", "40000", "2024-02-01T00:00:00Z"),
+		trade("t3", "btc", SideSell, "1.5", "90000", "2024-06-01T00:00:00Z"),
+		{ID: "t4", AccountID: "a1", AssetID: "eth", Type: entity.TransactionTypeIncome, Status: entity.TransactionStatusCompleted,
+			Data: map[string]string{"income": "staking", "amount": "0.1", "value": "300", "value_asset_id": "usd", "executed_at": "2024-01-01T00:00:00Z"}},
+		// Only 0.1 of the 0.3 sold was acquired in the account.
+		trade("t5", "eth", SideSell, "0.3", "900", "2024-03-01T00:00:00Z"),
+	}}
+	md := &fakeMarketData{assets: []*entity.Asset{{ID: "btc", Symbol: "BTC"}, {ID: "eth", Symbol: "ETH"}, {ID: "usd", Symbol: "USD"}}}
+	h := NewHandler(s, md, nil, TaxConfig{Jurisdictions: map[string]TaxJurisdiction{
+		"fifo":    {LongTermMonths: 12},
+		"lifo":    {LongTermMonths: 12, LotMethod: LotMethodLIFO},
+		"average": {LotMethod: LotMethodAverage},
+	}}, ImportConfig{}, slog.New(slog.DiscardHandler))
+	report := func(jurisdiction string) *apiv1.TaxReport {
+		resp, err := h.GenerateTaxReport(context.Background(), connect.NewRequest(&apiv1.GenerateTaxReportRequest{
+			UserId: proto.String("u1"), TaxYear: 2024, Jurisdiction: proto.String(jurisdiction),
+		}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+	type row struct{ tx, amount, acquired, proceeds, cost, gain string }
+	rows := func(r *apiv1.TaxReport) []row {
+		var rows []row
+		for _, d := range r.Disposals {
+			acquired := ""
+			if d.AcquiredAt != nil {
+				acquired = d.AcquiredAt.AsTime().Format(time.DateOnly)
+			}
+			rows = append(rows, row{d.TransactionId, d.Amount, acquired, d.Proceeds, d.GetCostBasis(), d.GetGain()})
+		}
+		return rows
+	}
+
+	fifo := report("fifo")
+	assert.Equal(t, []row{
+		{"t5", "0.1", "2024-01-01", "300", "300", "0"},
+		{"t5", "0.2", "", "600", "", ""},
+		{"t3", "1", "2023-01-10", "60000", "20000", "40000"},
+		{"t3", "0.5", "2024-02-01", "30000", "20000", "10000"},
+	}, rows(fifo))
+	assert.Equal(t, apiv1.HoldingPeriod_HOLDING_PERIOD_LONG_TERM, fifo.Disposals[2].HoldingPeriod)
+	assert.Equal(t, apiv1.HoldingPeriod_HOLDING_PERIOD_SHORT_TERM, fifo.Disposals[3].HoldingPeriod)
+	assert.EqualValues(t, 1, fifo.IncompleteCount)
+	assert.Equal(t, "90900", fifo.Totals[0].Proceeds)
+	assert.Equal(t, "40300", fifo.Totals[0].CostBasis)
+
+	assert.Equal(t, []row{
+		{"t5", "0.1", "2024-01-01", "300", "300", "0"},
+		{"t5", "0.2", "", "600", "", ""},
+		{"t3", "1", "2024-02-01", "60000", "40000", "20000"},
+		{"t3", "0.5", "2023-01-10", "30000", "10000", "20000"},
+	}, rows(report("lifo")))
+
+	// The pool of both lots cost 30000 a unit.
+	assert.Equal(t, []row{
+		{"t5", "0.1", "", "300", "300", "0"},
+		{"t5", "0.2", "", "600", "", ""},
+		{"t3", "1.5", "", "90000", "45000", "45000"},
+	}, rows(report("average")))
+}
+
+func TestGenerateTaxReport_OtherUser(t *testing.T) {
+	h := newTaxHandler()
+	req := connect.NewRequest(&apiv1.GenerateTaxReportRequest{UserId: proto.String("u1"), TaxYear: 2024})
+
+	_, err := h.GenerateTaxReport(auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "u2"}), req)
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	resp, err := h.GenerateTaxReport(auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "u2", Scopes: []string{auth.ScopeAdmin}}), req)
+	require.NoError(t, err)
+	assert.Len(t, resp.Msg.Disposals, 3)
+}
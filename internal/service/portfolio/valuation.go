@@ -0,0 +1,139 @@
+package portfolio
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/bond"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// valueDecimals is the precision of calculated portfolio values.
+const valueDecimals = 8
+
+// CalculatePortfolioValue values the portfolio's current holdings at the
+// prices and exchange rates of a time, in the quote asset or else the
+// caller's default currency. Bonds are worth their clean price plus the
+// interest they have accrued. Held assets without a price are reported and
+// left out of the total.
+func (h *Handler) CalculatePortfolioValue(ctx context.Context, req *connect.Request[apiv1.CalculatePortfolioValueRequest]) (*connect.Response[apiv1.PortfolioValueResponse], error) {
+	if req.Msg.PortfolioId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("portfolio ID is required"))
+	}
+	at := time.Now().UTC()
+	if req.Msg.AtTime != nil {
+		t := req.Msg.AtTime.AsTime()
+		if t.After(at) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at_time must not be in the future"))
+		}
+		at = t
+	}
+
+	portfolio, err := h.store.GetPortfolio(ctx, req.Msg.PortfolioId)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	quote := req.Msg.QuoteAssetId
+	if quote == "" {
+		if quote, err = h.defaultCurrency(ctx, cmp.Or(auth.OwnerID(ctx, ""), portfolio.UserID)); err != nil {
+			return nil, toConnectError(err)
+		}
+		if quote == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("quote asset is required without a default currency"))
+		}
+	} else if _, err := h.marketData.GetAsset(ctx, quote); err != nil {
+		return nil, toConnectError(err)
+	}
+
+	rates := newRates(h.marketData)
+	bonds := &accruals{marketData: h.marketData, terms: map[string]*entity.BondTerms{}}
+	total := decimal.Zero
+	var unpriced []string
+	for token := ""; ; {
+		holdings, next, err := h.store.ListHoldings(ctx, ListHoldingsOpts{PortfolioID: portfolio.ID, PageSize: exportPageSize, PageToken: token})
+		if err != nil {
+			return nil, toConnectError(err)
+		}
+		for _, holding := range holdings {
+			amount := amountToDecimal(holding.Amount, holding.Decimals)
+			value, ok, err := rates.convert(ctx, amount, holding.AssetID, quote, at)
+			if err != nil {
+				return nil, toConnectError(err)
+			}
+			if !ok {
+				if !slices.Contains(unpriced, holding.AssetID) {
+					unpriced = append(unpriced, holding.AssetID)
+				}
+				continue
+			}
+			total = total.Add(value)
+
+			interest, currency, err := bonds.interest(ctx, holding.AssetID, amount, at)
+			if err != nil {
+				return nil, toConnectError(err)
+			}
+			if interest.IsZero() {
+				continue
+			}
+			if interest, ok, err = rates.convert(ctx, interest, currency, quote, at); err != nil {
+				return nil, toConnectError(err)
+			}
+			if !ok {
+				if !slices.Contains(unpriced, currency) {
+					unpriced = append(unpriced, currency)
+				}
+				continue
+			}
+			total = total.Add(interest)
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+
+	return connect.NewResponse(&apiv1.PortfolioValueResponse{
+		PortfolioId:      portfolio.ID,
+		QuoteAssetId:     quote,
+		TotalValueAmount: total.Shift(valueDecimals).Round(0).IntPart(),
+		Decimals:         valueDecimals,
+		CalculationTime:  timestamppb.New(at),
+		UnpricedAssetIds: unpriced,
+	}), nil
+}
+
+// accruals computes the interest held bonds have accrued, looking up the
+// terms of each asset once.
+type accruals struct {
+	marketData MarketDataStore
+	terms      map[string]*entity.BondTerms // By asset ID; nil for other assets
+}
+
+// interest returns the interest amount units of the asset have accrued by
+// at and the asset it is paid in; zero if the asset is not a bond.
+func (a *accruals) interest(ctx context.Context, assetID string, amount decimal.Decimal, at time.Time) (decimal.Decimal, string, error) {
+	terms, ok := a.terms[assetID]
+	if !ok {
+		asset, err := a.marketData.GetAsset(ctx, assetID)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			return decimal.Zero, "", err
+		}
+		if asset != nil {
+			terms = asset.Bond
+		}
+		a.terms[assetID] = terms
+	}
+	if terms == nil {
+		return decimal.Zero, "", nil
+	}
+	return bond.AccruedInterest(terms, at).Mul(amount).Round(convertedPlaces), terms.CurrencyAssetID, nil
+}
@@ -0,0 +1,87 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCalculatePortfolioValue(t *testing.T) {
+	s := &exportStore{holdings: []*entity.Holding{
+		{ID: "h1", AccountID: "a1", AssetID: "aapl", Amount: 15, Decimals: 1},
+		{ID: "h2", AccountID: "a1", AssetID: "usd", Amount: 100000, Decimals: 2},
+		{ID: "h3", AccountID: "a1", AssetID: "btc", Amount: 1},
+	}}
+	raw, _ := json.Marshal(map[string]string{"default_currency": "EUR"})
+	h := NewHandler(s, newFXMarketData(), fakeUsers{"u1": {ID: "u1", Preferences: raw}}, TaxConfig{}, ImportConfig{}, slog.New(slog.DiscardHandler))
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "u1"})
+
+	// 1.5 shares at $200 and $1000 are $1300, worth €1170 in March and
+	// €1196 now; bitcoin has no price.
+	resp, err := h.CalculatePortfolioValue(ctx, connect.NewRequest(&apiv1.CalculatePortfolioValueRequest{
+		PortfolioId: "p1",
+		AtTime:      timestamppb.New(fxDay(time.March, 1)),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "eur", resp.Msg.QuoteAssetId)
+	assert.Equal(t, int64(1170_00000000), resp.Msg.TotalValueAmount)
+	assert.EqualValues(t, valueDecimals, resp.Msg.Decimals)
+	assert.Equal(t, fxDay(time.March, 1), resp.Msg.CalculationTime.AsTime())
+	assert.Equal(t, []string{"btc"}, resp.Msg.UnpricedAssetIds)
+
+	resp, err = h.CalculatePortfolioValue(ctx, connect.NewRequest(&apiv1.CalculatePortfolioValueRequest{PortfolioId: "p1"}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1196_00000000), resp.Msg.TotalValueAmount)
+	assert.WithinDuration(t, time.Now(), resp.Msg.CalculationTime.AsTime(), time.Minute)
+
+	resp, err = h.CalculatePortfolioValue(ctx, connect.NewRequest(&apiv1.CalculatePortfolioValueRequest{PortfolioId: "p1", QuoteAssetId: "usd"}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1300_00000000), resp.Msg.TotalValueAmount)
+
+	for name, tc := range map[string]struct {
+		ctx  context.Context
+		req  *apiv1.CalculatePortfolioValueRequest
+		code connect.Code
+	}{
+		"no portfolio":        {ctx, &apiv1.CalculatePortfolioValueRequest{}, connect.CodeInvalidArgument},
+		"future time":         {ctx, &apiv1.CalculatePortfolioValueRequest{PortfolioId: "p1", AtTime: timestamppb.New(time.Now().Add(time.Hour))}, connect.CodeInvalidArgument},
+		"no default currency": {context.Background(), &apiv1.CalculatePortfolioValueRequest{PortfolioId: "p1"}, connect.CodeInvalidArgument},
+		"unknown quote":       {ctx, &apiv1.CalculatePortfolioValueRequest{PortfolioId: "p1", QuoteAssetId: "xyz"}, connect.CodeNotFound},
+		"unknown portfolio":   {ctx, &apiv1.CalculatePortfolioValueRequest{PortfolioId: "p2"}, connect.CodeNotFound},
+	} {
+		_, err := h.CalculatePortfolioValue(tc.ctx, connect.NewRequest(tc.req))
+		assert.Equal(t, tc.code, connect.CodeOf(err), name)
+	}
+}
+
+func TestCalculatePortfolioValue_AccruedInterest(t *testing.T) {
+	md := newFXMarketData()
+	md.assets = append(md.assets, &entity.Asset{ID: "ust", Type: entity.AssetTypeBond, Bond: &entity.BondTerms{
+		FaceValue: dec("100"), CurrencyAssetID: "usd", CouponRate: dec("0.05"), CouponFrequency: 2,
+		MaturityDate: time.Date(2030, time.June, 15, 0, 0, 0, 0, time.UTC), DayCount: entity.DayCount30360,
+	}})
+	md.prices = append(md.prices, &entity.StoredPrice{AssetID: "ust", BaseAssetID: "usd", Last: 9800, Decimals: 2, Timestamp: fxDay(time.January, 1)})
+	s := &exportStore{holdings: []*entity.Holding{{ID: "h1", AccountID: "a1", AssetID: "ust", Amount: 10}}}
+	h := NewHandler(s, md, nil, TaxConfig{}, ImportConfig{}, slog.New(slog.DiscardHandler))
+
+	// Ten bonds at $98 clean have accrued 76 days of 30/360 since the
+	// December coupon, $1.05555556 each.
+	resp, err := h.CalculatePortfolioValue(context.Background(), connect.NewRequest(&apiv1.CalculatePortfolioValueRequest{
+		PortfolioId:  "p1",
+		QuoteAssetId: "usd",
+		AtTime:       timestamppb.New(fxDay(time.March, 1)),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(990_55555560), resp.Msg.TotalValueAmount)
+	assert.Empty(t, resp.Msg.UnpricedAssetIds)
+}
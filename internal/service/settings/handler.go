@@ -0,0 +1,287 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/api/v1/apiv1connect"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Handler implements apiv1connect.SettingsServiceHandler.
+type Handler struct {
+	apiv1connect.UnimplementedSettingsServiceHandler
+	store      Store
+	marketData MarketDataStore
+	log        *slog.Logger
+}
+
+func NewHandler(store Store, marketData MarketDataStore, log *slog.Logger) *Handler {
+	return &Handler{store: store, marketData: marketData, log: log}
+}
+
+// --- User CRUD ---
+
+func (h *Handler) CreateUser(ctx context.Context, req *connect.Request[apiv1.CreateUserRequest]) (*connect.Response[apiv1.User], error) {
+	if req.Msg.User == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("user is required"))
+	}
+	// Users are provisioned by admins; everyone else manages only themselves.
+	if p, ok := auth.FromContext(ctx); ok && !p.HasScope(auth.ScopeAdmin) {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("creating users requires the admin scope"))
+	}
+	if err := validateEmail(req.Msg.User.Email); err != nil {
+		return nil, err
+	}
+
+	u := &entity.User{Email: req.Msg.User.Email, Name: req.Msg.User.Name}
+	prefs, err := h.encodePreferences(ctx, preferencesFromProto(req.Msg.User.Preferences))
+	if err != nil {
+		return nil, err
+	}
+	u.Preferences = prefs
+
+	created, err := h.store.CreateUser(ctx, u)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(h.userToProto(created)), nil
+}
+
+func (h *Handler) GetUser(ctx context.Context, req *connect.Request[apiv1.GetUserRequest]) (*connect.Response[apiv1.User], error) {
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("user ID is required"))
+	}
+
+	u, err := h.store.GetUser(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(h.userToProto(u)), nil
+}
+
+func (h *Handler) GetUserByEmail(ctx context.Context, req *connect.Request[apiv1.GetUserByEmailRequest]) (*connect.Response[apiv1.User], error) {
+	if req.Msg.Email == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email is required"))
+	}
+
+	u, err := h.store.GetUserByEmail(ctx, req.Msg.Email)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(h.userToProto(u)), nil
+}
+
+func (h *Handler) UpdateUser(ctx context.Context, req *connect.Request[apiv1.UpdateUserRequest]) (*connect.Response[apiv1.User], error) {
+	if req.Msg.User == nil || req.Msg.User.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("user with ID is required"))
+	}
+
+	var fields []string
+	if req.Msg.UpdateMask != nil {
+		fields = req.Msg.UpdateMask.Paths
+	}
+
+	u := &entity.User{ID: req.Msg.User.Id, Email: req.Msg.User.Email, Name: req.Msg.User.Name}
+	for _, field := range fields {
+		switch field {
+		case "email":
+			if err := validateEmail(u.Email); err != nil {
+				return nil, err
+			}
+		case "preferences":
+			prefs, err := h.encodePreferences(ctx, preferencesFromProto(req.Msg.User.Preferences))
+			if err != nil {
+				return nil, err
+			}
+			u.Preferences = prefs
+		}
+	}
+
+	updated, err := h.store.UpdateUser(ctx, u, fields)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(h.userToProto(updated)), nil
+}
+
+func (h *Handler) DeleteUser(ctx context.Context, req *connect.Request[apiv1.DeleteUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("user ID is required"))
+	}
+
+	if err := h.store.DeleteUser(ctx, req.Msg.Id); err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (h *Handler) ListUsers(ctx context.Context, req *connect.Request[apiv1.ListUsersRequest]) (*connect.Response[apiv1.ListUsersResponse], error) {
+	opts := ListUsersOpts{}
+	if req.Msg.PageSize != nil {
+		opts.PageSize = int(*req.Msg.PageSize)
+	}
+	if req.Msg.PageToken != nil {
+		opts.PageToken = *req.Msg.PageToken
+	}
+
+	users, nextPageToken, err := h.store.ListUsers(ctx, opts)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	protoUsers := make([]*apiv1.User, 0, len(users))
+	for _, u := range users {
+		protoUsers = append(protoUsers, h.userToProto(u))
+	}
+
+	return connect.NewResponse(&apiv1.ListUsersResponse{
+		Users:         protoUsers,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+// --- Preferences ---
+
+func (h *Handler) UpdateUserPreferences(ctx context.Context, req *connect.Request[apiv1.UpdateUserPreferencesRequest]) (*connect.Response[apiv1.User], error) {
+	if req.Msg.UserId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("user ID is required"))
+	}
+	if req.Msg.Preferences == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("preferences are required"))
+	}
+
+	u, err := h.store.GetUser(ctx, req.Msg.UserId)
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	prefs, err := ParsePreferences(u.Preferences)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	var paths []string
+	if req.Msg.UpdateMask != nil {
+		paths = req.Msg.UpdateMask.Paths
+	}
+	if err := prefs.merge(req.Msg.Preferences, paths); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if u.Preferences, err = h.encodePreferences(ctx, prefs); err != nil {
+		return nil, err
+	}
+
+	updated, err := h.store.UpdateUser(ctx, u, []string{"preferences"})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	return connect.NewResponse(h.userToProto(updated)), nil
+}
+
+// encodePreferences validates p and marshals it for storage.
+func (h *Handler) encodePreferences(ctx context.Context, p *Preferences) (json.RawMessage, error) {
+	errs := p.validate()
+	if len(errs) == 0 && p.DefaultCurrency != "" {
+		ok, err := h.reportingCurrency(ctx, p.DefaultCurrency)
+		if err != nil {
+			return nil, toConnectError(err)
+		}
+		if !ok {
+			errs = append(errs, fmt.Sprintf("default_currency: no forex asset or priced cryptocurrency for %q", p.DefaultCurrency))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("invalid preferences: %s", strings.Join(errs, "; ")))
+	}
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return raw, nil
+}
+
+// reportingCurrency reports whether values can be reported in code: it is
+// the symbol of a forex asset, or of a cryptocurrency with a stored price to
+// convert values through.
+func (h *Handler) reportingCurrency(ctx context.Context, code string) (bool, error) {
+	assets, _, err := h.marketData.ListAssets(ctx, marketdata.ListAssetsOpts{Symbol: code})
+	if err != nil {
+		return false, err
+	}
+	for _, a := range assets {
+		switch a.Type {
+		case entity.AssetTypeForex:
+			return true, nil
+		case entity.AssetTypeCryptocurrency:
+			_, err := h.marketData.GetPriceAt(ctx, a.ID, "", time.Now())
+			if err == nil {
+				return true, nil
+			}
+			if !errors.Is(err, store.ErrNotFound) {
+				return false, err
+			}
+		}
+	}
+	return false, nil
+}
+
+func validateEmail(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid email %q", email))
+	}
+	return nil
+}
+
+// --- Converters ---
+
+func toConnectError(err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	if errors.Is(err, store.ErrInvalidArgument) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if errors.Is(err, store.ErrConstraint) {
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+// userToProto converts a stored user. Preferences that no longer decode are
+// logged and returned empty rather than failing the read.
+func (h *Handler) userToProto(u *entity.User) *apiv1.User {
+	prefs, err := ParsePreferences(u.Preferences)
+	if err != nil {
+		h.log.Warn("Failed to decode user preferences", slog.String("user_id", u.ID), slog.Any("error", err))
+		prefs = &Preferences{}
+	}
+	return &apiv1.User{
+		Id:          u.ID,
+		Email:       u.Email,
+		Name:        u.Name,
+		Preferences: preferencesToProto(prefs),
+		CreatedAt:   timestamppb.New(u.CreatedAt),
+		UpdatedAt:   timestamppb.New(u.UpdatedAt),
+	}
+}
@@ -0,0 +1,195 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+)
+
+// Preference values as stored in the users.preferences JSON column.
+const (
+	RiskConservative = "conservative"
+	RiskModerate     = "moderate"
+	RiskAggressive   = "aggressive"
+
+	ChannelTelegram = "telegram"
+	ChannelEmail    = "email"
+)
+
+// Preferences is the typed form of a user's preferences. Empty fields fall
+// back to system defaults.
+type Preferences struct {
+	DefaultCurrency      string   `json:"default_currency,omitempty"`
+	RiskTolerance        string   `json:"risk_tolerance,omitempty"`
+	Timezone             string   `json:"timezone,omitempty"`
+	NotificationChannels []string `json:"notification_channels,omitempty"`
+}
+
+// legacyPreferences holds the flat string flags written by the old user
+// service before notification channels became a list.
+type legacyPreferences struct {
+	TelegramNotifications string `json:"telegram_notifications"`
+	EmailNotifications    string `json:"email_notifications"`
+}
+
+// ParsePreferences decodes stored preferences. Unknown keys are ignored.
+func ParsePreferences(raw json.RawMessage) (*Preferences, error) {
+	p := &Preferences{}
+	if len(raw) == 0 {
+		return p, nil
+	}
+	if err := json.Unmarshal(raw, p); err != nil {
+		return nil, fmt.Errorf("decode preferences: %w", err)
+	}
+	if p.NotificationChannels == nil {
+		var legacy legacyPreferences
+		if err := json.Unmarshal(raw, &legacy); err == nil {
+			if legacy.TelegramNotifications == "true" {
+				p.NotificationChannels = append(p.NotificationChannels, ChannelTelegram)
+			}
+			if legacy.EmailNotifications == "true" {
+				p.NotificationChannels = append(p.NotificationChannels, ChannelEmail)
+			}
+		}
+	}
+	return p, nil
+}
+
+// validate returns one message per invalid field. Whether a currency has a
+// stored asset is checked by the handler.
+func (p *Preferences) validate() []string {
+	var errs []string
+	if p.DefaultCurrency != "" && !isCurrencyCode(p.DefaultCurrency) {
+		errs = append(errs, fmt.Sprintf("default_currency: %q is not a currency code", p.DefaultCurrency))
+	}
+	switch p.RiskTolerance {
+	case "", RiskConservative, RiskModerate, RiskAggressive:
+	default:
+		errs = append(errs, fmt.Sprintf("risk_tolerance: unsupported value %q", p.RiskTolerance))
+	}
+	if p.Timezone != "" {
+		if _, err := time.LoadLocation(p.Timezone); err != nil || p.Timezone == "Local" {
+			errs = append(errs, fmt.Sprintf("timezone: unknown time zone %q", p.Timezone))
+		}
+	}
+	seen := map[string]bool{}
+	for _, ch := range p.NotificationChannels {
+		switch {
+		case ch != ChannelTelegram && ch != ChannelEmail:
+			errs = append(errs, fmt.Sprintf("notification_channels: unsupported channel %q", ch))
+		case seen[ch]:
+			errs = append(errs, fmt.Sprintf("notification_channels: duplicate channel %q", ch))
+		}
+		seen[ch] = true
+	}
+	return errs
+}
+
+// isCurrencyCode reports whether code has the form of an ISO 4217 code or
+// a cryptocurrency ticker: two to ten upper-case letters or digits, starting
+// with a letter.
+func isCurrencyCode(code string) bool {
+	return len(code) >= 2 && len(code) <= 10 && code[0] >= 'A' && code[0] <= 'Z' &&
+		!strings.ContainsFunc(code, func(r rune) bool { return (r < 'A' || r > 'Z') && (r < '0' || r > '9') })
+}
+
+// merge applies update to p. With no paths only the fields set in update are
+// changed; with paths exactly those fields are replaced, unset ones cleared.
+func (p *Preferences) merge(update *apiv1.UserPreferences, paths []string) error {
+	from := preferencesFromProto(update)
+	if len(paths) == 0 {
+		if update.DefaultCurrency != nil {
+			p.DefaultCurrency = from.DefaultCurrency
+		}
+		if update.RiskTolerance != apiv1.RiskTolerance_RISK_TOLERANCE_UNSPECIFIED {
+			p.RiskTolerance = from.RiskTolerance
+		}
+		if update.Timezone != nil {
+			p.Timezone = from.Timezone
+		}
+		if len(update.NotificationChannels) > 0 {
+			p.NotificationChannels = from.NotificationChannels
+		}
+		return nil
+	}
+	for _, path := range paths {
+		switch path {
+		case "default_currency":
+			p.DefaultCurrency = from.DefaultCurrency
+		case "risk_tolerance":
+			p.RiskTolerance = from.RiskTolerance
+		case "timezone":
+			p.Timezone = from.Timezone
+		case "notification_channels":
+			p.NotificationChannels = from.NotificationChannels
+		default:
+			return fmt.Errorf("unsupported update_mask path %q", path)
+		}
+	}
+	return nil
+}
+
+// --- Converters ---
+
+var riskToleranceNames = map[apiv1.RiskTolerance]string{
+	apiv1.RiskTolerance_RISK_TOLERANCE_CONSERVATIVE: RiskConservative,
+	apiv1.RiskTolerance_RISK_TOLERANCE_MODERATE:     RiskModerate,
+	apiv1.RiskTolerance_RISK_TOLERANCE_AGGRESSIVE:   RiskAggressive,
+}
+
+var channelNames = map[apiv1.NotificationChannel]string{
+	apiv1.NotificationChannel_NOTIFICATION_CHANNEL_TELEGRAM: ChannelTelegram,
+	apiv1.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL:    ChannelEmail,
+}
+
+// preferencesFromProto converts without validating; unknown enum values map
+// to their number so validate reports them.
+func preferencesFromProto(p *apiv1.UserPreferences) *Preferences {
+	result := &Preferences{}
+	if p == nil {
+		return result
+	}
+	result.DefaultCurrency = strings.ToUpper(strings.TrimSpace(p.GetDefaultCurrency()))
+	result.Timezone = strings.TrimSpace(p.GetTimezone())
+	if p.RiskTolerance != apiv1.RiskTolerance_RISK_TOLERANCE_UNSPECIFIED {
+		name, ok := riskToleranceNames[p.RiskTolerance]
+		if !ok {
+			name = p.RiskTolerance.String()
+		}
+		result.RiskTolerance = name
+	}
+	for _, ch := range p.NotificationChannels {
+		name, ok := channelNames[ch]
+		if !ok {
+			name = ch.String()
+		}
+		result.NotificationChannels = append(result.NotificationChannels, name)
+	}
+	return result
+}
+
+func preferencesToProto(p *Preferences) *apiv1.UserPreferences {
+	result := &apiv1.UserPreferences{}
+	if p.DefaultCurrency != "" {
+		result.DefaultCurrency = &p.DefaultCurrency
+	}
+	if p.Timezone != "" {
+		result.Timezone = &p.Timezone
+	}
+	for enum, name := range riskToleranceNames {
+		if name == p.RiskTolerance {
+			result.RiskTolerance = enum
+		}
+	}
+	for _, ch := range p.NotificationChannels {
+		for enum, name := range channelNames {
+			if name == ch {
+				result.NotificationChannels = append(result.NotificationChannels, enum)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,138 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParsePreferences_LegacyFlags(t *testing.T) {
+	p, err := ParsePreferences(json.RawMessage(`{"default_currency":"EUR","telegram_notifications":"true","email_notifications":"false"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", p.DefaultCurrency)
+	assert.Equal(t, []string{ChannelTelegram}, p.NotificationChannels)
+}
+
+func TestPreferences_Validate(t *testing.T) {
+	valid := &Preferences{
+		DefaultCurrency:      "JPY",
+		RiskTolerance:        RiskModerate,
+		Timezone:             "Europe/Berlin",
+		NotificationChannels: []string{ChannelTelegram, ChannelEmail},
+	}
+	assert.Empty(t, valid.validate())
+	assert.Empty(t, (&Preferences{}).validate())
+	assert.Empty(t, (&Preferences{DefaultCurrency: "USDT"}).validate())
+
+	invalid := &Preferences{
+		DefaultCurrency:      "Euro",
+		RiskTolerance:        "reckless",
+		Timezone:             "Mars/Olympus",
+		NotificationChannels: []string{ChannelEmail, ChannelEmail, "sms"},
+	}
+	assert.Equal(t, []string{
+		`default_currency: "Euro" is not a currency code`,
+		`risk_tolerance: unsupported value "reckless"`,
+		`timezone: unknown time zone "Mars/Olympus"`,
+		`notification_channels: duplicate channel "email"`,
+		`notification_channels: unsupported channel "sms"`,
+	}, invalid.validate())
+}
+
+func TestPreferences_Merge(t *testing.T) {
+	existing := func() *Preferences {
+		return &Preferences{DefaultCurrency: "USD", RiskTolerance: RiskConservative, Timezone: "UTC"}
+	}
+	update := &apiv1.UserPreferences{
+		DefaultCurrency:      proto.String("eur"),
+		NotificationChannels: []apiv1.NotificationChannel{apiv1.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL},
+	}
+
+	// Without a mask only fields set in the update change.
+	p := existing()
+	require.NoError(t, p.merge(update, nil))
+	assert.Equal(t, &Preferences{
+		DefaultCurrency:      "EUR",
+		RiskTolerance:        RiskConservative,
+		Timezone:             "UTC",
+		NotificationChannels: []string{ChannelEmail},
+	}, p)
+
+	// A masked field that is unset is cleared.
+	p = existing()
+	require.NoError(t, p.merge(update, []string{"default_currency", "timezone"}))
+	assert.Equal(t, &Preferences{DefaultCurrency: "EUR", RiskTolerance: RiskConservative}, p)
+
+	assert.Error(t, existing().merge(update, []string{"language"}))
+}
+
+func TestPreferences_ProtoRoundTrip(t *testing.T) {
+	p := &Preferences{
+		DefaultCurrency:      "ETH",
+		RiskTolerance:        RiskAggressive,
+		Timezone:             "Asia/Tokyo",
+		NotificationChannels: []string{ChannelEmail, ChannelTelegram},
+	}
+	assert.Equal(t, p, preferencesFromProto(preferencesToProto(p)))
+}
+
+// currencyAssets holds assets by symbol and the IDs of those with prices.
+type currencyAssets struct {
+	assets []*entity.Asset
+	priced []string
+}
+
+func (c currencyAssets) ListAssets(_ context.Context, opts marketdata.ListAssetsOpts) ([]*entity.Asset, string, error) {
+	var found []*entity.Asset
+	for _, a := range c.assets {
+		if a.Symbol == opts.Symbol && (opts.Type == entity.AssetTypeUnspecified || a.Type == opts.Type) {
+			found = append(found, a)
+		}
+	}
+	return found, "", nil
+}
+
+func (c currencyAssets) GetPriceAt(_ context.Context, assetID, _ string, at time.Time) (*entity.StoredPrice, error) {
+	if !slices.Contains(c.priced, assetID) {
+		return nil, store.ErrNotFound
+	}
+	return &entity.StoredPrice{AssetID: assetID, Timestamp: at}, nil
+}
+
+func TestHandler_EncodePreferences(t *testing.T) {
+	h := NewHandler(nil, currencyAssets{
+		assets: []*entity.Asset{
+			{ID: "usd", Symbol: "USD", Type: entity.AssetTypeForex},
+			{ID: "chf", Symbol: "CHF", Type: entity.AssetTypeForex},
+			{ID: "btc", Symbol: "BTC", Type: entity.AssetTypeCryptocurrency},
+			{ID: "doge", Symbol: "DOGE", Type: entity.AssetTypeCryptocurrency},
+			{ID: "sek", Symbol: "SEK", Type: entity.AssetTypeStock},
+		},
+		priced: []string{"btc", "sek"},
+	}, nil)
+
+	for _, code := range []string{"CHF", "BTC"} {
+		raw, err := h.encodePreferences(context.Background(), &Preferences{DefaultCurrency: code})
+		require.NoError(t, err, code)
+		assert.JSONEq(t, `{"default_currency":"`+code+`"}`, string(raw))
+	}
+
+	// A cryptocurrency needs a price to convert through; other assets do not
+	// count as currencies.
+	for _, code := range []string{"DOGE", "SEK", "JPY"} {
+		_, err := h.encodePreferences(context.Background(), &Preferences{DefaultCurrency: code})
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), code)
+		assert.ErrorContains(t, err, `default_currency: no forex asset or priced cryptocurrency for "`+code+`"`)
+	}
+}
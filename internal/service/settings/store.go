@@ -2,8 +2,10 @@ package settings
 
 import (
 	"context"
+	"time"
 
 	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
 )
 
 // Store defines the data access contract for SettingsService (user management).
@@ -16,6 +18,13 @@ type Store interface {
 	ListUsers(ctx context.Context, opts ListUsersOpts) ([]*entity.User, string, error)
 }
 
+// MarketDataStore is the subset of marketdata.Store the handler needs to
+// check that a default currency has a stored asset to report in.
+type MarketDataStore interface {
+	ListAssets(ctx context.Context, opts marketdata.ListAssetsOpts) ([]*entity.Asset, string, error)
+	GetPriceAt(ctx context.Context, assetID, baseAssetID string, at time.Time) (*entity.StoredPrice, error)
+}
+
 // ListUsersOpts contains options for listing users.
 type ListUsersOpts struct {
 	PageSize  int
@@ -4,7 +4,8 @@ import "errors"
 
 // Store error definitions.
 var (
-	ErrNotFound        = errors.New("not found")
-	ErrInvalidArgument = errors.New("invalid argument")
-	ErrConstraint      = errors.New("constraint violation")
+	ErrNotFound         = errors.New("not found")
+	ErrInvalidArgument  = errors.New("invalid argument")
+	ErrConstraint       = errors.New("constraint violation")
+	ErrPermissionDenied = errors.New("permission denied")
 )
@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/audit"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AuditStore implements audit.Store using PostgreSQL. Events are only ever
+// inserted.
+type AuditStore struct {
+	pool *pgxpool.Pool
+}
+
+// Compile-time interface implementation check.
+var _ audit.Store = (*AuditStore)(nil)
+
+func NewAuditStore(pool *pgxpool.Pool) *AuditStore {
+	return &AuditStore{pool: pool}
+}
+
+const auditEventColumns = `id, uuid, created_at, actor_type, actor_id, credential_id, owner_id, procedure,
+		resource_type, resource_id, field_paths, changes, request_id, client_ip`
+
+func (s *AuditStore) CreateAuditEvent(ctx context.Context, e *entity.AuditEvent) error {
+	if e == nil {
+		return fmt.Errorf("%w: audit event is required", store.ErrInvalidArgument)
+	}
+	if e.ActorType == "" {
+		return fmt.Errorf("%w: actor_type is required", store.ErrInvalidArgument)
+	}
+
+	fieldPaths := e.FieldPaths
+	if fieldPaths == nil {
+		fieldPaths = []string{}
+	}
+	fieldPathsJSON, err := json.Marshal(fieldPaths)
+	if err != nil {
+		return fmt.Errorf("failed to marshal field paths: %w", err)
+	}
+	changes := e.Changes
+	if changes == nil {
+		changes = map[string]entity.AuditChange{}
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal changes: %w", err)
+	}
+
+	e.ID = uuid.New().String()
+	e.CreatedAt = time.Now()
+
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO audit_events (uuid, created_at, actor_type, actor_id, credential_id, owner_id, procedure,
+			resource_type, resource_id, field_paths, changes, request_id, client_ip)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		e.ID, e.CreatedAt, e.ActorType, e.ActorID, nullableString(e.CredentialID), nullableString(e.OwnerID),
+		nullableString(e.Procedure), e.ResourceType, nullableString(e.ResourceID), fieldPathsJSON, changesJSON,
+		nullableString(e.RequestID), nullableString(e.ClientIP))
+	if err != nil {
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+	return nil
+}
+
+// ListAuditEvents lists events newest first. Callers without the admin scope
+// only see events they caused or that changed their data.
+func (s *AuditStore) ListAuditEvents(ctx context.Context, opts audit.ListAuditEventsOpts) ([]*entity.AuditEvent, string, error) {
+	limit := opts.PageSize
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	args := []any{}
+	argIdx := 1
+	whereClauses := []string{}
+
+	filters := []struct {
+		column, value string
+	}{
+		{"actor_id", opts.ActorID},
+		{"resource_type", opts.ResourceType},
+		{"resource_id", opts.ResourceID},
+		{"procedure", opts.Procedure},
+	}
+	for _, f := range filters {
+		if f.value == "" {
+			continue
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("%s = $%d", f.column, argIdx))
+		args = append(args, f.value)
+		argIdx++
+	}
+
+	if opts.From != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("created_at >= $%d", argIdx))
+		args = append(args, *opts.From)
+		argIdx++
+	}
+
+	if opts.To != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("created_at <= $%d", argIdx))
+		args = append(args, *opts.To)
+		argIdx++
+	}
+
+	if userID := tenantUserID(ctx); userID != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("(actor_id = $%d OR owner_id = $%d)", argIdx, argIdx))
+		args = append(args, userID)
+		argIdx++
+	}
+
+	if opts.PageToken != "" {
+		decoded, err := base64.StdEncoding.DecodeString(opts.PageToken)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: invalid page token", store.ErrInvalidArgument)
+		}
+		lastID, err := strconv.ParseInt(string(decoded), 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: invalid page token", store.ErrInvalidArgument)
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("id < $%d", argIdx))
+		args = append(args, lastID)
+		argIdx++
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM audit_events
+		%s
+		ORDER BY id DESC
+		LIMIT $%d`,
+		auditEventColumns, whereClause, argIdx)
+	args = append(args, limit+1)
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list audit events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*entity.AuditEvent, 0, limit)
+	internalIDs := make([]int64, 0, limit)
+	for rows.Next() {
+		id, e, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		events = append(events, e)
+		internalIDs = append(internalIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	// Events are ordered by insertion, so the token is the internal ID of
+	// the last one returned.
+	var nextPageToken string
+	if len(events) > limit {
+		events = events[:limit]
+		nextPageToken = base64.StdEncoding.EncodeToString([]byte(strconv.FormatInt(internalIDs[limit-1], 10)))
+	}
+
+	return events, nextPageToken, nil
+}
+
+func scanAuditEvent(row pgx.Row) (int64, *entity.AuditEvent, error) {
+	var (
+		id                                                          int64
+		e                                                           entity.AuditEvent
+		credentialID, ownerID, procedure, resourceID, requestID, ip *string
+		fieldPathsJSON, changesJSON                                 []byte
+	)
+	err := row.Scan(&id, &e.ID, &e.CreatedAt, &e.ActorType, &e.ActorID, &credentialID, &ownerID, &procedure,
+		&e.ResourceType, &resourceID, &fieldPathsJSON, &changesJSON, &requestID, &ip)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	for _, f := range []struct {
+		dst *string
+		src *string
+	}{
+		{&e.CredentialID, credentialID},
+		{&e.OwnerID, ownerID},
+		{&e.Procedure, procedure},
+		{&e.ResourceID, resourceID},
+		{&e.RequestID, requestID},
+		{&e.ClientIP, ip},
+	} {
+		if f.src != nil {
+			*f.dst = *f.src
+		}
+	}
+
+	if err := json.Unmarshal(fieldPathsJSON, &e.FieldPaths); err != nil {
+		return 0, nil, fmt.Errorf("failed to unmarshal field paths: %w", err)
+	}
+	if err := json.Unmarshal(changesJSON, &e.Changes); err != nil {
+		return 0, nil, fmt.Errorf("failed to unmarshal changes: %w", err)
+	}
+	return id, &e, nil
+}
+
+// auditBefore loads the state a change starts from, but only when the change
+// is audited. Lookup errors are left for the change itself to report.
+func auditBefore[T any](ctx context.Context, get func(context.Context, string) (*T, error), id string) *T {
+	if !audit.Enabled(ctx) {
+		return nil
+	}
+	v, err := get(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return v
+}
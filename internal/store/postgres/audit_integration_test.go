@@ -0,0 +1,88 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/foxcool/greedy-eye/internal/audit"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditEvents(t *testing.T) {
+	pool := getTestPool(t)
+	ctx := context.Background()
+
+	keys, err := secrets.NewKeyring(map[uint32][]byte{1: make([]byte, 32)})
+	require.NoError(t, err)
+	portfolios := NewPortfolioStore(pool, keys)
+	auditStore := NewAuditStore(pool)
+	settings := NewSettingsStore(pool)
+
+	alice, err := settings.CreateUser(ctx, &entity.User{Email: "alice@example.com", Name: "Alice"})
+	require.NoError(t, err)
+	bob, err := settings.CreateUser(ctx, &entity.User{Email: "bob@example.com", Name: "Bob"})
+	require.NoError(t, err)
+
+	// Changes made by a background process on Alice's behalf.
+	sysCtx := audit.WithTask(audit.WithSystemActor(ctx, auditStore, slog.New(slog.DiscardHandler), audit.ActorAutomation), "task-1", alice.ID)
+	account, err := portfolios.CreateAccount(sysCtx, &entity.Account{
+		UserID: alice.ID, Name: "Binance", Type: entity.AccountTypeExchange,
+		Secrets: map[string]string{"api_secret": "s3cr3t"},
+	})
+	require.NoError(t, err)
+	_, err = portfolios.UpdateAccount(sysCtx, &entity.Account{ID: account.ID, Name: "Binance main"}, []string{"name"})
+	require.NoError(t, err)
+	require.NoError(t, portfolios.DeleteAccount(sysCtx, account.ID))
+
+	// Unaudited changes leave no trace.
+	_, err = portfolios.CreatePortfolio(ctx, &entity.Portfolio{UserID: bob.ID, Name: "Bob's"})
+	require.NoError(t, err)
+
+	admin := auth.WithPrincipal(ctx, &auth.Principal{UserID: alice.ID, Scopes: []string{auth.ScopeAdmin}})
+	events, _, err := auditStore.ListAuditEvents(admin, audit.ListAuditEventsOpts{ResourceID: account.ID})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	// Newest first.
+	deleted, updated, created := events[0], events[1], events[2]
+	assert.Equal(t, entity.ActorTypeSystem, created.ActorType)
+	assert.Equal(t, audit.ActorAutomation, created.ActorID)
+	assert.Equal(t, "task-1", created.RequestID)
+	assert.Equal(t, alice.ID, created.OwnerID)
+	assert.Equal(t, "account", created.ResourceType)
+	assert.Equal(t, map[string]any{"api_secret": secrets.Redacted}, created.Changes["Secrets"].After)
+	assert.Equal(t, entity.AuditChange{Before: "Binance", After: "Binance main"}, updated.Changes["Name"])
+	assert.Equal(t, "Binance main", deleted.Changes["Name"].Before)
+	assert.Nil(t, deleted.Changes["Name"].After)
+
+	t.Run("pagination", func(t *testing.T) {
+		page, token, err := auditStore.ListAuditEvents(admin, audit.ListAuditEventsOpts{PageSize: 2})
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		require.NotEmpty(t, token)
+		rest, token, err := auditStore.ListAuditEvents(admin, audit.ListAuditEventsOpts{PageSize: 2, PageToken: token})
+		require.NoError(t, err)
+		assert.Len(t, rest, 1)
+		assert.Empty(t, token)
+		assert.Equal(t, created.ID, rest[0].ID)
+	})
+
+	t.Run("tenant", func(t *testing.T) {
+		asAlice := auth.WithPrincipal(ctx, &auth.Principal{UserID: alice.ID, Scopes: []string{auth.ScopeRead}})
+		own, _, err := auditStore.ListAuditEvents(asAlice, audit.ListAuditEventsOpts{})
+		require.NoError(t, err)
+		assert.Len(t, own, 3)
+
+		asBob := auth.WithPrincipal(ctx, &auth.Principal{UserID: bob.ID, Scopes: []string{auth.ScopeRead}})
+		others, _, err := auditStore.ListAuditEvents(asBob, audit.ListAuditEventsOpts{})
+		require.NoError(t, err)
+		assert.Empty(t, others)
+	})
+}
@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/audit"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AuthStore implements auth.Store using PostgreSQL.
+type AuthStore struct {
+	pool *pgxpool.Pool
+}
+
+// Compile-time interface implementation check.
+var _ auth.Store = (*AuthStore)(nil)
+
+func NewAuthStore(pool *pgxpool.Pool) *AuthStore {
+	return &AuthStore{pool: pool}
+}
+
+const apiKeyColumns = `k.uuid, u.uuid, k.name, k.prefix, k.key_hash, k.scopes, k.expires_at, k.last_used_at, k.created_at`
+
+func (s *AuthStore) CreateAPIKey(ctx context.Context, k *entity.APIKey) (*entity.APIKey, error) {
+	if k == nil {
+		return nil, fmt.Errorf("%w: API key is required", store.ErrInvalidArgument)
+	}
+	if !isValidUUID(k.UserID) {
+		return nil, fmt.Errorf("%w: invalid user ID format", store.ErrInvalidArgument)
+	}
+	if k.Name == "" || len(k.Hash) == 0 {
+		return nil, fmt.Errorf("%w: name and key hash are required", store.ErrInvalidArgument)
+	}
+
+	scopesJSON, err := json.Marshal(k.Scopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal scopes: %w", err)
+	}
+
+	k.ID = uuid.New().String()
+	query := `
+		INSERT INTO api_keys (uuid, name, prefix, key_hash, scopes, expires_at, created_at, user_id)
+		SELECT $1, $2, $3, $4, $5, $6, NOW(), id
+		FROM users WHERE uuid = $7
+		RETURNING created_at`
+
+	err = s.pool.QueryRow(ctx, query, k.ID, k.Name, k.Prefix, k.Hash, scopesJSON, k.ExpiresAt, k.UserID).Scan(&k.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: user with ID %s", store.ErrNotFound, k.UserID)
+		}
+		if isConstraintError(err) {
+			return nil, fmt.Errorf("%w: %v", store.ErrConstraint, err)
+		}
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	audit.RecordChange(ctx, "api_key", k.ID, nil, k)
+	return k, nil
+}
+
+func (s *AuthStore) GetAPIKeyByHash(ctx context.Context, hash []byte) (*entity.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id
+		WHERE k.key_hash = $1`
+
+	k, err := scanAPIKey(s.pool.QueryRow(ctx, query, hash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: API key", store.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return k, nil
+}
+
+func (s *AuthStore) ListAPIKeys(ctx context.Context, userID string) ([]*entity.APIKey, error) {
+	if !isValidUUID(userID) {
+		return nil, fmt.Errorf("%w: invalid user ID format", store.ErrInvalidArgument)
+	}
+
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id
+		WHERE u.uuid = $1
+		ORDER BY k.created_at, k.id`
+
+	rows, err := s.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*entity.APIKey
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (s *AuthStore) DeleteAPIKey(ctx context.Context, userID, id string) error {
+	if !isValidUUID(id) || !isValidUUID(userID) {
+		return fmt.Errorf("%w: invalid API key ID format", store.ErrInvalidArgument)
+	}
+
+	result, err := s.pool.Exec(ctx, `
+		DELETE FROM api_keys
+		WHERE uuid = $1 AND user_id = (SELECT id FROM users WHERE uuid = $2)`,
+		id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete API key: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: API key with ID %s", store.ErrNotFound, id)
+	}
+
+	audit.RecordChange(ctx, "api_key", id, nil, nil)
+	return nil
+}
+
+func (s *AuthStore) TouchAPIKey(ctx context.Context, id string, at time.Time) error {
+	if _, err := s.pool.Exec(ctx, "UPDATE api_keys SET last_used_at = $2 WHERE uuid = $1", id, at); err != nil {
+		return fmt.Errorf("failed to update API key last use: %w", err)
+	}
+	return nil
+}
+
+func (s *AuthStore) CreateSigningKey(ctx context.Context, k *entity.SigningKey) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO signing_keys (kid, private_key, created_at, expires_at)
+		VALUES ($1, $2, $3, $4)`,
+		k.ID, k.PrivateKey, k.CreatedAt, k.ExpiresAt)
+	if err != nil {
+		if isConstraintError(err) {
+			return fmt.Errorf("%w: %v", store.ErrConstraint, err)
+		}
+		return fmt.Errorf("failed to create signing key: %w", err)
+	}
+	return nil
+}
+
+func (s *AuthStore) ListSigningKeys(ctx context.Context) ([]*entity.SigningKey, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT kid, private_key, created_at, expires_at
+		FROM signing_keys
+		ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*entity.SigningKey
+	for rows.Next() {
+		var k entity.SigningKey
+		if err := rows.Scan(&k.ID, &k.PrivateKey, &k.CreatedAt, &k.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan signing key: %w", err)
+		}
+		keys = append(keys, &k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate signing keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (s *AuthStore) DeleteSigningKeysExpiredBefore(ctx context.Context, t time.Time) error {
+	if _, err := s.pool.Exec(ctx, "DELETE FROM signing_keys WHERE expires_at < $1", t); err != nil {
+		return fmt.Errorf("failed to delete expired signing keys: %w", err)
+	}
+	return nil
+}
+
+func scanAPIKey(row pgx.Row) (*entity.APIKey, error) {
+	var k entity.APIKey
+	var scopesJSON []byte
+
+	if err := row.Scan(
+		&k.ID,
+		&k.UserID,
+		&k.Name,
+		&k.Prefix,
+		&k.Hash,
+		&scopesJSON,
+		&k.ExpiresAt,
+		&k.LastUsedAt,
+		&k.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(scopesJSON, &k.Scopes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scopes: %w", err)
+	}
+
+	return &k, nil
+}
@@ -0,0 +1,998 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/audit"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/automation"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AutomationStore implements automation.Store using PostgreSQL.
+type AutomationStore struct {
+	pool *pgxpool.Pool
+}
+
+// Compile-time interface implementation check.
+var _ automation.Store = (*AutomationStore)(nil)
+
+func NewAutomationStore(pool *pgxpool.Pool) *AutomationStore {
+	return &AutomationStore{pool: pool}
+}
+
+// --- Rule methods ---
+
+const ruleColumns = `r.uuid, p.uuid, u.uuid, r.name, r.description, r.rule_type, r.status,
+		r.configuration, r.schedule, r.created_at, r.updated_at`
+
+const ruleJoins = `
+		FROM rules r
+		JOIN portfolios p ON r.portfolio_id = p.id
+		JOIN users u ON r.user_id = u.id`
+
+func (s *AutomationStore) CreateRule(ctx context.Context, r *entity.Rule) (*entity.Rule, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w: rule is required", store.ErrInvalidArgument)
+	}
+	if r.Name == "" {
+		return nil, fmt.Errorf("%w: rule name is required", store.ErrInvalidArgument)
+	}
+	if r.RuleType == "" {
+		return nil, fmt.Errorf("%w: rule_type is required", store.ErrInvalidArgument)
+	}
+	if r.PortfolioID == "" {
+		return nil, fmt.Errorf("%w: portfolio_id is required", store.ErrInvalidArgument)
+	}
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: user_id is required", store.ErrInvalidArgument)
+	}
+
+	portfolioInternalID, err := s.getPortfolioInternalID(ctx, r.PortfolioID, entity.PortfolioRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	userInternalID, err := s.getUserInternalID(ctx, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	configJSON, scheduleJSON, err := marshalRuleJSON(r)
+	if err != nil {
+		return nil, err
+	}
+
+	r.ID = uuid.New().String()
+	if r.Status == entity.RuleStatusUnknown {
+		r.Status = entity.RuleStatusActive
+	}
+
+	query := `
+		INSERT INTO rules (uuid, portfolio_id, user_id, name, description, rule_type, status, configuration, schedule, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+		RETURNING created_at, updated_at`
+
+	err = s.pool.QueryRow(ctx, query,
+		r.ID,
+		portfolioInternalID,
+		userInternalID,
+		r.Name,
+		nullableString(r.Description),
+		r.RuleType,
+		ruleStatusToString(r.Status),
+		configJSON,
+		scheduleJSON,
+	).Scan(&r.CreatedAt, &r.UpdatedAt)
+	if err != nil {
+		if isConstraintError(err) {
+			return nil, fmt.Errorf("%w: %v", store.ErrConstraint, err)
+		}
+		return nil, fmt.Errorf("failed to create rule: %w", err)
+	}
+
+	audit.RecordChange(ctx, "rule", r.ID, nil, r)
+	return r, nil
+}
+
+func (s *AutomationStore) GetRule(ctx context.Context, id string) (*entity.Rule, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: rule ID is required", store.ErrInvalidArgument)
+	}
+	if !isValidUUID(id) {
+		return nil, fmt.Errorf("%w: invalid rule ID format", store.ErrInvalidArgument)
+	}
+
+	filter, args := portfolioFilter(ctx, "r.portfolio_id", entity.PortfolioRoleViewer, []any{id})
+	query := "SELECT " + ruleColumns + ruleJoins + " WHERE r.uuid = $1 AND " + filter
+
+	r, err := scanRule(s.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: rule with ID %s", store.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get rule: %w", err)
+	}
+
+	return r, nil
+}
+
+func (s *AutomationStore) UpdateRule(ctx context.Context, r *entity.Rule, fields []string) (*entity.Rule, error) {
+	if r == nil || r.ID == "" {
+		return nil, fmt.Errorf("%w: rule with ID is required", store.ErrInvalidArgument)
+	}
+	if !isValidUUID(r.ID) {
+		return nil, fmt.Errorf("%w: invalid rule ID format", store.ErrInvalidArgument)
+	}
+
+	setClauses := []string{"updated_at = NOW()"}
+	args := []any{r.ID}
+	argIdx := 2
+
+	for _, field := range fields {
+		switch field {
+		case "name":
+			if r.Name == "" {
+				return nil, fmt.Errorf("%w: rule name cannot be empty", store.ErrInvalidArgument)
+			}
+			setClauses = append(setClauses, fmt.Sprintf("name = $%d", argIdx))
+			args = append(args, r.Name)
+			argIdx++
+		case "description":
+			setClauses = append(setClauses, fmt.Sprintf("description = $%d", argIdx))
+			args = append(args, nullableString(r.Description))
+			argIdx++
+		case "status":
+			setClauses = append(setClauses, fmt.Sprintf("status = $%d", argIdx))
+			args = append(args, ruleStatusToString(r.Status))
+			argIdx++
+		case "configuration":
+			configJSON, err := json.Marshal(r.Configuration)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal configuration: %w", err)
+			}
+			setClauses = append(setClauses, fmt.Sprintf("configuration = $%d", argIdx))
+			args = append(args, configJSON)
+			argIdx++
+		case "schedule":
+			_, scheduleJSON, err := marshalRuleJSON(r)
+			if err != nil {
+				return nil, err
+			}
+			setClauses = append(setClauses, fmt.Sprintf("schedule = $%d", argIdx))
+			args = append(args, scheduleJSON)
+			argIdx++
+		}
+	}
+
+	before := auditBefore(ctx, s.GetRule, r.ID)
+	filter, args := portfolioFilter(ctx, "portfolio_id", entity.PortfolioRoleEditor, args)
+	query := fmt.Sprintf(`
+		UPDATE rules
+		SET %s
+		WHERE uuid = $1 AND %s`,
+		strings.Join(setClauses, ", "), filter)
+
+	result, err := s.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update rule: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, deniedOrNotFound(ctx, s.GetRule, r.ID, fmt.Errorf("%w: rule with ID %s", store.ErrNotFound, r.ID))
+	}
+
+	after, err := s.GetRule(ctx, r.ID)
+	if err != nil {
+		return nil, err
+	}
+	audit.RecordChange(ctx, "rule", r.ID, before, after)
+	return after, nil
+}
+
+func (s *AutomationStore) DeleteRule(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: rule ID is required", store.ErrInvalidArgument)
+	}
+	if !isValidUUID(id) {
+		return fmt.Errorf("%w: invalid rule ID format", store.ErrInvalidArgument)
+	}
+
+	before := auditBefore(ctx, s.GetRule, id)
+	filter, args := portfolioFilter(ctx, "portfolio_id", entity.PortfolioRoleEditor, []any{id})
+	result, err := s.pool.Exec(ctx, "DELETE FROM rules WHERE uuid = $1 AND "+filter, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete rule: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return deniedOrNotFound(ctx, s.GetRule, id, fmt.Errorf("%w: rule with ID %s", store.ErrNotFound, id))
+	}
+
+	audit.RecordChange(ctx, "rule", id, before, nil)
+	return nil
+}
+
+func (s *AutomationStore) ListRules(ctx context.Context, opts automation.ListRulesOpts) ([]*entity.Rule, string, error) {
+	limit := opts.PageSize
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	args := []any{}
+	argIdx := 1
+	whereClauses := []string{}
+
+	if opts.UserID != "" {
+		if !isValidUUID(opts.UserID) {
+			return nil, "", fmt.Errorf("%w: invalid user ID format", store.ErrInvalidArgument)
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("u.uuid = $%d", argIdx))
+		args = append(args, opts.UserID)
+		argIdx++
+	}
+
+	if opts.PortfolioID != "" {
+		if !isValidUUID(opts.PortfolioID) {
+			return nil, "", fmt.Errorf("%w: invalid portfolio ID format", store.ErrInvalidArgument)
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("p.uuid = $%d", argIdx))
+		args = append(args, opts.PortfolioID)
+		argIdx++
+	}
+
+	if opts.RuleType != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("r.rule_type = $%d", argIdx))
+		args = append(args, opts.RuleType)
+		argIdx++
+	}
+
+	if opts.Status != entity.RuleStatusUnknown {
+		whereClauses = append(whereClauses, fmt.Sprintf("r.status = $%d", argIdx))
+		args = append(args, ruleStatusToString(opts.Status))
+		argIdx++
+	}
+
+	var filter string
+	filter, args = portfolioFilter(ctx, "r.portfolio_id", entity.PortfolioRoleViewer, args)
+	whereClauses = append(whereClauses, filter)
+	argIdx = len(args) + 1
+
+	if opts.PageToken != "" {
+		decoded, err := base64.StdEncoding.DecodeString(opts.PageToken)
+		if err == nil && isValidUUID(string(decoded)) {
+			whereClauses = append(whereClauses, fmt.Sprintf("r.uuid > $%d", argIdx))
+			args = append(args, string(decoded))
+			argIdx++
+		}
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		%s
+		%s
+		ORDER BY r.uuid
+		LIMIT $%d`,
+		ruleColumns, ruleJoins, whereClause, argIdx)
+	args = append(args, limit+1)
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := make([]*entity.Rule, 0, limit)
+	for rows.Next() {
+		r, err := scanRule(rows)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to scan rule: %w", err)
+		}
+		rules = append(rules, r)
+	}
+
+	var nextPageToken string
+	if len(rules) > limit {
+		lastItem := rules[limit-1]
+		rules = rules[:limit]
+		nextPageToken = base64.StdEncoding.EncodeToString([]byte(lastItem.ID))
+	}
+
+	return rules, nextPageToken, nil
+}
+
+// --- Rule execution methods ---
+
+const ruleExecutionColumns = `e.uuid, r.uuid, p.uuid, u.uuid, e.status, e.started_at, e.completed_at,
+		e.error_message, e.created_transaction_ids, e.affected_holding_ids, e.summary,
+		e.dry_run, e.execution_context, e.cancel_requested, cb.uuid`
+
+const ruleExecutionJoins = `
+		FROM rule_executions e
+		JOIN rules r ON e.rule_id = r.id
+		JOIN portfolios p ON r.portfolio_id = p.id
+		JOIN users u ON r.user_id = u.id
+		LEFT JOIN users cb ON e.created_by = cb.id`
+
+func (s *AutomationStore) CreateRuleExecution(ctx context.Context, e *entity.RuleExecution) (*entity.RuleExecution, error) {
+	if e == nil {
+		return nil, fmt.Errorf("%w: rule execution is required", store.ErrInvalidArgument)
+	}
+	if e.RuleID == "" {
+		return nil, fmt.Errorf("%w: rule_id is required", store.ErrInvalidArgument)
+	}
+
+	ruleInternalID, err := s.getRuleInternalID(ctx, e.RuleID, entity.PortfolioRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	txIDsJSON, holdingIDsJSON, summaryJSON, err := marshalRuleExecutionJSON(e)
+	if err != nil {
+		return nil, err
+	}
+
+	e.ID = uuid.New().String()
+	if e.Status == entity.ExecutionStatusUnknown {
+		e.Status = entity.ExecutionStatusPending
+	}
+	if e.StartedAt.IsZero() {
+		e.StartedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO rule_executions (uuid, rule_id, status, started_at, completed_at, error_message, created_transaction_ids, affected_holding_ids, summary, dry_run, execution_context, created_by, heartbeat_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, (SELECT id FROM users WHERE uuid = $12), NOW())`
+
+	_, err = s.pool.Exec(ctx, query,
+		e.ID,
+		ruleInternalID,
+		executionStatusToString(e.Status),
+		e.StartedAt,
+		e.CompletedAt,
+		nullableString(e.ErrorMessage),
+		txIDsJSON,
+		holdingIDsJSON,
+		summaryJSON,
+		e.DryRun,
+		nullableString(e.ExecutionContext),
+		nullableString(e.CreatedBy),
+	)
+	if err != nil {
+		if isConstraintError(err) {
+			return nil, fmt.Errorf("%w: %v", store.ErrConstraint, err)
+		}
+		return nil, fmt.Errorf("failed to create rule execution: %w", err)
+	}
+
+	after, err := s.GetRuleExecution(ctx, e.ID)
+	if err != nil {
+		return nil, err
+	}
+	audit.RecordChange(ctx, "rule_execution", e.ID, nil, after)
+	return after, nil
+}
+
+func (s *AutomationStore) GetRuleExecution(ctx context.Context, id string) (*entity.RuleExecution, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: rule execution ID is required", store.ErrInvalidArgument)
+	}
+	if !isValidUUID(id) {
+		return nil, fmt.Errorf("%w: invalid rule execution ID format", store.ErrInvalidArgument)
+	}
+
+	filter, args := portfolioFilter(ctx, "r.portfolio_id", entity.PortfolioRoleViewer, []any{id})
+	query := "SELECT " + ruleExecutionColumns + ruleExecutionJoins + " WHERE e.uuid = $1 AND " + filter
+
+	e, err := scanRuleExecution(s.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: rule execution with ID %s", store.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get rule execution: %w", err)
+	}
+
+	return e, nil
+}
+
+func (s *AutomationStore) UpdateRuleExecution(ctx context.Context, e *entity.RuleExecution, fields []string) (*entity.RuleExecution, error) {
+	if e == nil || e.ID == "" {
+		return nil, fmt.Errorf("%w: rule execution with ID is required", store.ErrInvalidArgument)
+	}
+	if !isValidUUID(e.ID) {
+		return nil, fmt.Errorf("%w: invalid rule execution ID format", store.ErrInvalidArgument)
+	}
+
+	txIDsJSON, holdingIDsJSON, summaryJSON, err := marshalRuleExecutionJSON(e)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every update is a sign of life for crash recovery.
+	setClauses := []string{"heartbeat_at = NOW()"}
+	args := []any{e.ID}
+	argIdx := 2
+
+	for _, field := range fields {
+		switch field {
+		case "status":
+			setClauses = append(setClauses, fmt.Sprintf("status = $%d", argIdx))
+			args = append(args, executionStatusToString(e.Status))
+			argIdx++
+		case "completed_at":
+			setClauses = append(setClauses, fmt.Sprintf("completed_at = $%d", argIdx))
+			args = append(args, e.CompletedAt)
+			argIdx++
+		case "error_message":
+			setClauses = append(setClauses, fmt.Sprintf("error_message = $%d", argIdx))
+			args = append(args, nullableString(e.ErrorMessage))
+			argIdx++
+		case "created_transaction_ids":
+			setClauses = append(setClauses, fmt.Sprintf("created_transaction_ids = $%d", argIdx))
+			args = append(args, txIDsJSON)
+			argIdx++
+		case "affected_holding_ids":
+			setClauses = append(setClauses, fmt.Sprintf("affected_holding_ids = $%d", argIdx))
+			args = append(args, holdingIDsJSON)
+			argIdx++
+		case "execution_summary", "summary":
+			setClauses = append(setClauses, fmt.Sprintf("summary = $%d", argIdx))
+			args = append(args, summaryJSON)
+			argIdx++
+		}
+	}
+
+	before := auditBefore(ctx, s.GetRuleExecution, e.ID)
+	filter, args := portfolioFilter(ctx, executionPortfolio, entity.PortfolioRoleEditor, args)
+	query := fmt.Sprintf(`
+		UPDATE rule_executions
+		SET %s
+		WHERE uuid = $1 AND %s`,
+		strings.Join(setClauses, ", "), filter)
+
+	result, err := s.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update rule execution: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, deniedOrNotFound(ctx, s.GetRuleExecution, e.ID,
+			fmt.Errorf("%w: rule execution with ID %s", store.ErrNotFound, e.ID))
+	}
+
+	after, err := s.GetRuleExecution(ctx, e.ID)
+	if err != nil {
+		return nil, err
+	}
+	audit.RecordChange(ctx, "rule_execution", e.ID, before, after)
+	return after, nil
+}
+
+func (s *AutomationStore) ListRuleExecutions(ctx context.Context, opts automation.ListRuleExecutionsOpts) ([]*entity.RuleExecution, string, error) {
+	limit := opts.PageSize
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	args := []any{}
+	argIdx := 1
+	whereClauses := []string{}
+
+	filters := []struct {
+		column, value, name string
+	}{
+		{"r.uuid", opts.RuleID, "rule"},
+		{"p.uuid", opts.PortfolioID, "portfolio"},
+		{"u.uuid", opts.UserID, "user"},
+	}
+	for _, f := range filters {
+		if f.value == "" {
+			continue
+		}
+		if !isValidUUID(f.value) {
+			return nil, "", fmt.Errorf("%w: invalid %s ID format", store.ErrInvalidArgument, f.name)
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("%s = $%d", f.column, argIdx))
+		args = append(args, f.value)
+		argIdx++
+	}
+
+	if opts.Status != entity.ExecutionStatusUnknown {
+		whereClauses = append(whereClauses, fmt.Sprintf("e.status = $%d", argIdx))
+		args = append(args, executionStatusToString(opts.Status))
+		argIdx++
+	}
+
+	if opts.From != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("e.started_at >= $%d", argIdx))
+		args = append(args, *opts.From)
+		argIdx++
+	}
+
+	if opts.To != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("e.started_at <= $%d", argIdx))
+		args = append(args, *opts.To)
+		argIdx++
+	}
+
+	var filter string
+	filter, args = portfolioFilter(ctx, "r.portfolio_id", entity.PortfolioRoleViewer, args)
+	whereClauses = append(whereClauses, filter)
+	argIdx = len(args) + 1
+
+	if opts.PageToken != "" {
+		decoded, err := base64.StdEncoding.DecodeString(opts.PageToken)
+		if err == nil && isValidUUID(string(decoded)) {
+			whereClauses = append(whereClauses, fmt.Sprintf("e.uuid > $%d", argIdx))
+			args = append(args, string(decoded))
+			argIdx++
+		}
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		%s
+		%s
+		ORDER BY e.uuid
+		LIMIT $%d`,
+		ruleExecutionColumns, ruleExecutionJoins, whereClause, argIdx)
+	args = append(args, limit+1)
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list rule executions: %w", err)
+	}
+	defer rows.Close()
+
+	executions := make([]*entity.RuleExecution, 0, limit)
+	for rows.Next() {
+		e, err := scanRuleExecution(rows)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to scan rule execution: %w", err)
+		}
+		executions = append(executions, e)
+	}
+
+	var nextPageToken string
+	if len(executions) > limit {
+		lastItem := executions[limit-1]
+		executions = executions[:limit]
+		nextPageToken = base64.StdEncoding.EncodeToString([]byte(lastItem.ID))
+	}
+
+	return executions, nextPageToken, nil
+}
+
+// --- Execution queue methods ---
+
+// ClaimRuleExecution moves the oldest pending execution to in_progress and
+// assigns it to workerID. Concurrent workers never claim the same row.
+func (s *AutomationStore) ClaimRuleExecution(ctx context.Context, workerID string) (*entity.RuleExecution, error) {
+	if workerID == "" {
+		return nil, fmt.Errorf("%w: worker ID is required", store.ErrInvalidArgument)
+	}
+
+	query := `
+		UPDATE rule_executions
+		SET status = 'in_progress', started_at = NOW(), worker_id = $1, heartbeat_at = NOW()
+		WHERE id = (
+			SELECT id FROM rule_executions
+			WHERE status = 'pending'
+			ORDER BY started_at, id
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING uuid`
+
+	var id string
+	err := s.pool.QueryRow(ctx, query, workerID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: no pending rule executions", store.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to claim rule execution: %w", err)
+	}
+
+	return s.GetRuleExecution(ctx, id)
+}
+
+// HeartbeatRuleExecution records that workerID is still running the execution
+// and reports whether cancellation was requested. ErrNotFound means the worker
+// no longer owns the execution.
+func (s *AutomationStore) HeartbeatRuleExecution(ctx context.Context, id, workerID string) (bool, error) {
+	if !isValidUUID(id) {
+		return false, fmt.Errorf("%w: invalid rule execution ID format", store.ErrInvalidArgument)
+	}
+
+	query := `
+		UPDATE rule_executions
+		SET heartbeat_at = NOW()
+		WHERE uuid = $1 AND worker_id = $2 AND status = 'in_progress'
+		RETURNING cancel_requested`
+
+	var cancelRequested bool
+	err := s.pool.QueryRow(ctx, query, id, workerID).Scan(&cancelRequested)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, fmt.Errorf("%w: rule execution %s is not running on worker %s", store.ErrNotFound, id, workerID)
+		}
+		return false, fmt.Errorf("failed to heartbeat rule execution: %w", err)
+	}
+
+	return cancelRequested, nil
+}
+
+// CancelRuleExecution cancels a pending execution immediately and flags a
+// running one so its worker stops at the next checkpoint.
+func (s *AutomationStore) CancelRuleExecution(ctx context.Context, id, reason string) (*entity.RuleExecution, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: rule execution ID is required", store.ErrInvalidArgument)
+	}
+	if !isValidUUID(id) {
+		return nil, fmt.Errorf("%w: invalid rule execution ID format", store.ErrInvalidArgument)
+	}
+
+	before := auditBefore(ctx, s.GetRuleExecution, id)
+	filter, args := portfolioFilter(ctx, executionPortfolio, entity.PortfolioRoleEditor, []any{id, nullableString(reason)})
+	query := `
+		UPDATE rule_executions
+		SET cancel_requested = TRUE,
+			error_message = COALESCE($2, error_message),
+			status = CASE WHEN status = 'pending' THEN 'cancelled' ELSE status END,
+			completed_at = CASE WHEN status = 'pending' THEN NOW() ELSE completed_at END
+		WHERE uuid = $1 AND status IN ('pending', 'in_progress') AND ` + filter
+
+	result, err := s.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel rule execution: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		e, err := s.GetRuleExecution(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if e.Status == entity.ExecutionStatusPending || e.Status == entity.ExecutionStatusInProgress {
+			return nil, fmt.Errorf("%w: your portfolio role does not permit this", store.ErrPermissionDenied)
+		}
+		return nil, fmt.Errorf("%w: rule execution %s is already %s", store.ErrConstraint, id, executionStatusToString(e.Status))
+	}
+
+	after, err := s.GetRuleExecution(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	audit.RecordChange(ctx, "rule_execution", id, before, after)
+	return after, nil
+}
+
+// RecoverRuleExecutions resolves in-progress executions whose worker stopped
+// reporting before staleBefore. Dry runs and executions that never saved
+// their plan, which happens before the first step is applied, are queued
+// again. The others are failed for manual review: a step may have changed a
+// holding without being recorded, so replaying the plan is unsafe.
+func (s *AutomationStore) RecoverRuleExecutions(ctx context.Context, staleBefore time.Time) (int, int, error) {
+	query := `
+		UPDATE rule_executions
+		SET status = CASE
+				WHEN cancel_requested THEN 'cancelled'
+				WHEN dry_run OR summary->'progress' IS NULL THEN 'pending'
+				ELSE 'failed'
+			END,
+			completed_at = CASE
+				WHEN cancel_requested OR NOT (dry_run OR summary->'progress' IS NULL) THEN NOW()
+				ELSE NULL
+			END,
+			error_message = CASE
+				WHEN NOT cancel_requested AND NOT (dry_run OR summary->'progress' IS NULL)
+				THEN 'execution interrupted while applying its plan; steps may have been applied beyond those in the execution summary, review holdings before running it again'
+				ELSE error_message
+			END,
+			worker_id = NULL
+		WHERE status = 'in_progress' AND (heartbeat_at IS NULL OR heartbeat_at < $1)
+		RETURNING status`
+
+	rows, err := s.pool.Query(ctx, query, staleBefore)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to recover rule executions: %w", err)
+	}
+	defer rows.Close()
+
+	var requeued, failed int
+	for rows.Next() {
+		var status string
+		if err := rows.Scan(&status); err != nil {
+			return 0, 0, fmt.Errorf("failed to scan recovered rule execution: %w", err)
+		}
+		if status == "pending" {
+			requeued++
+		} else {
+			failed++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, fmt.Errorf("failed to recover rule executions: %w", err)
+	}
+
+	return requeued, failed, nil
+}
+
+// --- Helper methods ---
+
+// getRuleInternalID resolves a rule in a portfolio the caller holds at least
+// role on.
+func (s *AutomationStore) getRuleInternalID(ctx context.Context, uuid string, role entity.PortfolioRole) (int64, error) {
+	if !isValidUUID(uuid) {
+		return 0, fmt.Errorf("%w: invalid rule ID format", store.ErrInvalidArgument)
+	}
+
+	filter, args := portfolioFilter(ctx, "portfolio_id", role, []any{uuid})
+	var id int64
+	err := s.pool.QueryRow(ctx, "SELECT id FROM rules WHERE uuid = $1 AND "+filter, args...).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, deniedOrNotFound(ctx, s.GetRule, uuid, fmt.Errorf("%w: rule not found", store.ErrNotFound))
+		}
+		return 0, fmt.Errorf("failed to get rule: %w", err)
+	}
+	return id, nil
+}
+
+// getPortfolioInternalID resolves a portfolio the caller holds at least role
+// on. Callers who can view it but lack the role get ErrPermissionDenied.
+func (s *AutomationStore) getPortfolioInternalID(ctx context.Context, uuid string, role entity.PortfolioRole) (int64, error) {
+	if !isValidUUID(uuid) {
+		return 0, fmt.Errorf("%w: invalid portfolio ID format", store.ErrInvalidArgument)
+	}
+
+	filter, args := portfolioFilter(ctx, "id", role, []any{uuid})
+	var id int64
+	err := s.pool.QueryRow(ctx, "SELECT id FROM portfolios WHERE uuid = $1 AND "+filter, args...).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if role > entity.PortfolioRoleViewer {
+				if _, err := s.getPortfolioInternalID(ctx, uuid, entity.PortfolioRoleViewer); err == nil {
+					return 0, fmt.Errorf("%w: your portfolio role does not permit this", store.ErrPermissionDenied)
+				}
+			}
+			return 0, fmt.Errorf("%w: portfolio not found", store.ErrNotFound)
+		}
+		return 0, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+	return id, nil
+}
+
+func (s *AutomationStore) getUserInternalID(ctx context.Context, uuid string) (int64, error) {
+	if !isValidUUID(uuid) {
+		return 0, fmt.Errorf("%w: invalid user ID format", store.ErrInvalidArgument)
+	}
+
+	if owner := tenantUserID(ctx); owner != "" && owner != uuid {
+		return 0, fmt.Errorf("%w: user not found", store.ErrNotFound)
+	}
+
+	var id int64
+	err := s.pool.QueryRow(ctx, "SELECT id FROM users WHERE uuid = $1", uuid).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%w: user not found", store.ErrNotFound)
+		}
+		return 0, fmt.Errorf("failed to get user: %w", err)
+	}
+	return id, nil
+}
+
+func scanRule(row pgx.Row) (*entity.Rule, error) {
+	var r entity.Rule
+	var description *string
+	var status string
+	var configJSON, scheduleJSON []byte
+
+	if err := row.Scan(
+		&r.ID,
+		&r.PortfolioID,
+		&r.UserID,
+		&r.Name,
+		&description,
+		&r.RuleType,
+		&status,
+		&configJSON,
+		&scheduleJSON,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if description != nil {
+		r.Description = *description
+	}
+	r.Status = stringToRuleStatus(status)
+	if err := json.Unmarshal(configJSON, &r.Configuration); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal configuration: %w", err)
+	}
+	if scheduleJSON != nil {
+		if err := json.Unmarshal(scheduleJSON, &r.Schedule); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal schedule: %w", err)
+		}
+	}
+
+	return &r, nil
+}
+
+func scanRuleExecution(row pgx.Row) (*entity.RuleExecution, error) {
+	var e entity.RuleExecution
+	var status string
+	var errorMessage *string
+	var txIDsJSON, holdingIDsJSON, summaryJSON []byte
+	var executionContext, createdBy *string
+
+	if err := row.Scan(
+		&e.ID,
+		&e.RuleID,
+		&e.PortfolioID,
+		&e.UserID,
+		&status,
+		&e.StartedAt,
+		&e.CompletedAt,
+		&errorMessage,
+		&txIDsJSON,
+		&holdingIDsJSON,
+		&summaryJSON,
+		&e.DryRun,
+		&executionContext,
+		&e.CancelRequested,
+		&createdBy,
+	); err != nil {
+		return nil, err
+	}
+
+	e.Status = stringToExecutionStatus(status)
+	if executionContext != nil {
+		e.ExecutionContext = *executionContext
+	}
+	if errorMessage != nil {
+		e.ErrorMessage = *errorMessage
+	}
+	if createdBy != nil {
+		e.CreatedBy = *createdBy
+	}
+	if err := json.Unmarshal(txIDsJSON, &e.CreatedTransactionIDs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal created_transaction_ids: %w", err)
+	}
+	if err := json.Unmarshal(holdingIDsJSON, &e.AffectedHoldingIDs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal affected_holding_ids: %w", err)
+	}
+	if err := json.Unmarshal(summaryJSON, &e.Summary); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal summary: %w", err)
+	}
+
+	return &e, nil
+}
+
+func marshalRuleJSON(r *entity.Rule) (configJSON, scheduleJSON []byte, err error) {
+	config := r.Configuration
+	if config == nil {
+		config = map[string]any{}
+	}
+	configJSON, err = json.Marshal(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	if r.Schedule != nil {
+		scheduleJSON, err = json.Marshal(r.Schedule)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal schedule: %w", err)
+		}
+	}
+	return configJSON, scheduleJSON, nil
+}
+
+func marshalRuleExecutionJSON(e *entity.RuleExecution) (txIDsJSON, holdingIDsJSON, summaryJSON []byte, err error) {
+	txIDs := e.CreatedTransactionIDs
+	if txIDs == nil {
+		txIDs = []string{}
+	}
+	holdingIDs := e.AffectedHoldingIDs
+	if holdingIDs == nil {
+		holdingIDs = []string{}
+	}
+	summary := e.Summary
+	if summary == nil {
+		summary = map[string]any{}
+	}
+
+	if txIDsJSON, err = json.Marshal(txIDs); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal created_transaction_ids: %w", err)
+	}
+	if holdingIDsJSON, err = json.Marshal(holdingIDs); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal affected_holding_ids: %w", err)
+	}
+	if summaryJSON, err = json.Marshal(summary); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal summary: %w", err)
+	}
+	return txIDsJSON, holdingIDsJSON, summaryJSON, nil
+}
+
+func ruleStatusToString(s entity.RuleStatus) string {
+	switch s {
+	case entity.RuleStatusActive:
+		return "active"
+	case entity.RuleStatusPaused:
+		return "paused"
+	case entity.RuleStatusDisabled:
+		return "disabled"
+	case entity.RuleStatusError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+func stringToRuleStatus(s string) entity.RuleStatus {
+	switch s {
+	case "active":
+		return entity.RuleStatusActive
+	case "paused":
+		return entity.RuleStatusPaused
+	case "disabled":
+		return entity.RuleStatusDisabled
+	case "error":
+		return entity.RuleStatusError
+	default:
+		return entity.RuleStatusUnknown
+	}
+}
+
+func executionStatusToString(s entity.ExecutionStatus) string {
+	switch s {
+	case entity.ExecutionStatusPending:
+		return "pending"
+	case entity.ExecutionStatusInProgress:
+		return "in_progress"
+	case entity.ExecutionStatusCompleted:
+		return "completed"
+	case entity.ExecutionStatusFailed:
+		return "failed"
+	case entity.ExecutionStatusCancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+func stringToExecutionStatus(s string) entity.ExecutionStatus {
+	switch s {
+	case "pending":
+		return entity.ExecutionStatusPending
+	case "in_progress":
+		return entity.ExecutionStatusInProgress
+	case "completed":
+		return entity.ExecutionStatusCompleted
+	case "failed":
+		return entity.ExecutionStatusFailed
+	case "cancelled":
+		return entity.ExecutionStatusCancelled
+	default:
+		return entity.ExecutionStatusUnknown
+	}
+}
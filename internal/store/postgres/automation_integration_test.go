@@ -0,0 +1,156 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRuleExecutionQueue runs the execution queue the way workers do: they
+// claim pending executions concurrently, report heartbeats, are asked to
+// cancel, and recovery resolves those whose heartbeats stopped.
+func TestRuleExecutionQueue(t *testing.T) {
+	pool := getTestPool(t)
+	ctx := context.Background()
+	portfolios := NewPortfolioStore(pool, nil)
+	rules := NewAutomationStore(pool)
+
+	usd := createTestAsset(t, NewMarketDataStore(pool), "Dollar")
+	user, err := NewSettingsStore(pool).CreateUser(ctx, &entity.User{Email: "alice@example.com", Name: "Alice"})
+	require.NoError(t, err)
+	p, err := portfolios.CreatePortfolio(ctx, &entity.Portfolio{UserID: user.ID, Name: "Main"})
+	require.NoError(t, err)
+	rule, err := rules.CreateRule(ctx, &entity.Rule{
+		Name:          "Withdraw",
+		RuleType:      "monthly_withdrawal",
+		PortfolioID:   p.ID,
+		UserID:        user.ID,
+		Configuration: map[string]any{"amount": "10", "currency_asset_id": usd.ID},
+	})
+	require.NoError(t, err)
+
+	// Executions are claimed oldest first.
+	start := time.Now().Add(-time.Hour)
+	pending := make([]*entity.RuleExecution, 4)
+	for i := range pending {
+		pending[i], err = rules.CreateRuleExecution(ctx, &entity.RuleExecution{
+			RuleID: rule.ID, DryRun: i == 0, StartedAt: start.Add(time.Duration(i) * time.Minute),
+		})
+		require.NoError(t, err)
+	}
+
+	// Each execution goes to exactly one worker, however many claim at once.
+	var mu sync.Mutex
+	workers := map[string]string{}
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func(workerID string) {
+			defer wg.Done()
+			for {
+				e, err := rules.ClaimRuleExecution(ctx, workerID)
+				if errors.Is(err, store.ErrNotFound) {
+					return
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.Equal(t, entity.ExecutionStatusInProgress, e.Status)
+				mu.Lock()
+				assert.NotContains(t, workers, e.ID, "execution claimed twice")
+				workers[e.ID] = workerID
+				mu.Unlock()
+			}
+		}(fmt.Sprintf("worker-%d", w))
+	}
+	wg.Wait()
+	require.Len(t, workers, len(pending))
+	for _, e := range pending {
+		require.Contains(t, workers, e.ID)
+	}
+
+	t.Run("Heartbeat", func(t *testing.T) {
+		e := pending[0]
+		cancelled, err := rules.HeartbeatRuleExecution(ctx, e.ID, workers[e.ID])
+		require.NoError(t, err)
+		assert.False(t, cancelled)
+
+		_, err = rules.HeartbeatRuleExecution(ctx, e.ID, "other-worker")
+		assert.ErrorIs(t, err, store.ErrNotFound)
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		// A running execution is flagged and its worker told on its next heartbeat.
+		running := pending[1]
+		got, err := rules.CancelRuleExecution(ctx, running.ID, "user request")
+		require.NoError(t, err)
+		assert.Equal(t, entity.ExecutionStatusInProgress, got.Status)
+		assert.True(t, got.CancelRequested)
+		cancelled, err := rules.HeartbeatRuleExecution(ctx, running.ID, workers[running.ID])
+		require.NoError(t, err)
+		assert.True(t, cancelled)
+
+		// A pending one is cancelled at once and never claimed.
+		queued, err := rules.CreateRuleExecution(ctx, &entity.RuleExecution{RuleID: rule.ID})
+		require.NoError(t, err)
+		got, err = rules.CancelRuleExecution(ctx, queued.ID, "")
+		require.NoError(t, err)
+		assert.Equal(t, entity.ExecutionStatusCancelled, got.Status)
+		assert.NotNil(t, got.CompletedAt)
+		_, err = rules.ClaimRuleExecution(ctx, "worker-0")
+		assert.ErrorIs(t, err, store.ErrNotFound)
+
+		_, err = rules.CancelRuleExecution(ctx, queued.ID, "")
+		assert.ErrorIs(t, err, store.ErrConstraint)
+	})
+
+	t.Run("Recover", func(t *testing.T) {
+		// The dry run, the cancelled run and a run that saved its plan stop
+		// reporting; the last execution keeps its heartbeat.
+		_, err := pool.Exec(ctx, `UPDATE rule_executions SET summary = '{"progress": {"completed_steps": 1}}' WHERE uuid = $1`,
+			pending[2].ID)
+		require.NoError(t, err)
+		for _, e := range pending[:3] {
+			_, err := pool.Exec(ctx, "UPDATE rule_executions SET heartbeat_at = NOW() - INTERVAL '1 hour' WHERE uuid = $1", e.ID)
+			require.NoError(t, err)
+		}
+
+		requeued, failed, err := rules.RecoverRuleExecutions(ctx, time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, requeued)
+		assert.Equal(t, 2, failed)
+
+		statuses := []entity.ExecutionStatus{
+			entity.ExecutionStatusPending,
+			entity.ExecutionStatusCancelled,
+			entity.ExecutionStatusFailed,
+			entity.ExecutionStatusInProgress,
+		}
+		for i, e := range pending {
+			got, err := rules.GetRuleExecution(ctx, e.ID)
+			require.NoError(t, err)
+			assert.Equal(t, statuses[i], got.Status, "execution %d", i)
+		}
+		got, err := rules.GetRuleExecution(ctx, pending[2].ID)
+		require.NoError(t, err)
+		assert.Contains(t, got.ErrorMessage, "review holdings")
+
+		// The failed run's worker no longer owns it; the requeued dry run
+		// is claimed again.
+		_, err = rules.HeartbeatRuleExecution(ctx, pending[2].ID, workers[pending[2].ID])
+		assert.ErrorIs(t, err, store.ErrNotFound)
+		claimed, err := rules.ClaimRuleExecution(ctx, "worker-9")
+		require.NoError(t, err)
+		assert.Equal(t, pending[0].ID, claimed.ID)
+	})
+}
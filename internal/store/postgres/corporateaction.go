@@ -0,0 +1,554 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var corporateActionTypes = map[entity.CorporateActionType]string{
+	entity.CorporateActionTypeSplit:     "split",
+	entity.CorporateActionTypeMigration: "migration",
+	entity.CorporateActionTypeDelisting: "delisting",
+}
+
+func stringToCorporateActionType(s string) entity.CorporateActionType {
+	for t, name := range corporateActionTypes {
+		if name == s {
+			return t
+		}
+	}
+	return entity.CorporateActionTypeUnspecified
+}
+
+var corporateActionStatuses = map[entity.CorporateActionStatus]string{
+	entity.CorporateActionStatusPending:  "pending",
+	entity.CorporateActionStatusApplied:  "applied",
+	entity.CorporateActionStatusReverted: "reverted",
+}
+
+func stringToCorporateActionStatus(s string) entity.CorporateActionStatus {
+	for status, name := range corporateActionStatuses {
+		if name == s {
+			return status
+		}
+	}
+	return entity.CorporateActionStatusUnspecified
+}
+
+const corporateActionColumns = `ca.id, ca.uuid, a.uuid, na.uuid, ca.type, ca.status, ca.effective_at, ca.new_units, ca.old_units,
+	ca.note, ca.applied_at, ca.reverted_at, ca.created_at, ca.updated_at`
+
+const corporateActionTables = `corporate_actions ca
+	JOIN assets a ON a.id = ca.asset_id
+	LEFT JOIN assets na ON na.id = ca.new_asset_id`
+
+// scanCorporateAction scans corporateActionColumns, returning the internal
+// ID for pagination.
+func scanCorporateAction(row pgx.Row) (int64, *entity.CorporateAction, error) {
+	var (
+		internalID int64
+		a          entity.CorporateAction
+		newAssetID *string
+		actionType string
+		status     string
+	)
+	err := row.Scan(&internalID, &a.ID, &a.AssetID, &newAssetID, &actionType, &status, &a.EffectiveAt,
+		&a.NewUnits, &a.OldUnits, &a.Note, &a.AppliedAt, &a.RevertedAt, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return 0, nil, err
+	}
+	if newAssetID != nil {
+		a.NewAssetID = *newAssetID
+	}
+	a.Type = stringToCorporateActionType(actionType)
+	a.Status = stringToCorporateActionStatus(status)
+	return internalID, &a, nil
+}
+
+// CreateCorporateAction records a pending corporate action.
+func (s *MarketDataStore) CreateCorporateAction(ctx context.Context, a *entity.CorporateAction) (*entity.CorporateAction, error) {
+	if a == nil {
+		return nil, fmt.Errorf("%w: corporate action is required", store.ErrInvalidArgument)
+	}
+	actionType, ok := corporateActionTypes[a.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: corporate action type is required", store.ErrInvalidArgument)
+	}
+	assetInternalID, err := s.getAssetInternalID(ctx, a.AssetID)
+	if err != nil {
+		return nil, err
+	}
+	var newAssetInternalID *int64
+	if a.NewAssetID != "" {
+		id, err := s.getAssetInternalID(ctx, a.NewAssetID)
+		if err != nil {
+			return nil, err
+		}
+		newAssetInternalID = &id
+	}
+
+	a.ID = uuid.New().String()
+	a.Status = entity.CorporateActionStatusPending
+	err = s.pool.QueryRow(ctx, `
+		INSERT INTO corporate_actions (uuid, asset_id, new_asset_id, type, status, effective_at, new_units, old_units, note, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+		RETURNING created_at, updated_at`,
+		a.ID, assetInternalID, newAssetInternalID, actionType, corporateActionStatuses[a.Status],
+		a.EffectiveAt, a.NewUnits, a.OldUnits, a.Note,
+	).Scan(&a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create corporate action: %w", err)
+	}
+	return a, nil
+}
+
+// GetCorporateAction retrieves a corporate action by ID.
+func (s *MarketDataStore) GetCorporateAction(ctx context.Context, id string) (*entity.CorporateAction, error) {
+	if !isValidUUID(id) {
+		return nil, fmt.Errorf("%w: invalid corporate action ID format", store.ErrInvalidArgument)
+	}
+
+	_, a, err := scanCorporateAction(s.pool.QueryRow(ctx, `
+		SELECT `+corporateActionColumns+`
+		FROM `+corporateActionTables+`
+		WHERE ca.uuid = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: corporate action with ID %s", store.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get corporate action: %w", err)
+	}
+	return a, nil
+}
+
+// ListCorporateActions lists corporate actions, newest first.
+func (s *MarketDataStore) ListCorporateActions(ctx context.Context, opts marketdata.ListCorporateActionsOpts) ([]*entity.CorporateAction, string, error) {
+	limit := opts.PageSize
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	args := []any{}
+	argIdx := 1
+	whereClauses := []string{}
+
+	if opts.AssetID != "" {
+		assetInternalID, err := s.getAssetInternalID(ctx, opts.AssetID)
+		if err != nil {
+			return nil, "", err
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("(ca.asset_id = $%d OR ca.new_asset_id = $%d)", argIdx, argIdx))
+		args = append(args, assetInternalID)
+		argIdx++
+	}
+
+	if opts.Status != entity.CorporateActionStatusUnspecified {
+		whereClauses = append(whereClauses, fmt.Sprintf("ca.status = $%d", argIdx))
+		args = append(args, corporateActionStatuses[opts.Status])
+		argIdx++
+	}
+
+	if opts.PageToken != "" {
+		decoded, err := base64.StdEncoding.DecodeString(opts.PageToken)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: invalid page token", store.ErrInvalidArgument)
+		}
+		lastID, err := strconv.ParseInt(string(decoded), 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: invalid page token", store.ErrInvalidArgument)
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("ca.id < $%d", argIdx))
+		args = append(args, lastID)
+		argIdx++
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		%s
+		ORDER BY ca.id DESC
+		LIMIT $%d`,
+		corporateActionColumns, corporateActionTables, whereClause, argIdx)
+	args = append(args, limit+1)
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list corporate actions: %w", err)
+	}
+	defer rows.Close()
+
+	actions := make([]*entity.CorporateAction, 0, limit)
+	internalIDs := make([]int64, 0, limit)
+	for rows.Next() {
+		id, a, err := scanCorporateAction(rows)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to scan corporate action: %w", err)
+		}
+		actions = append(actions, a)
+		internalIDs = append(internalIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("failed to list corporate actions: %w", err)
+	}
+
+	var nextPageToken string
+	if len(actions) > limit {
+		actions = actions[:limit]
+		nextPageToken = base64.StdEncoding.EncodeToString([]byte(strconv.FormatInt(internalIDs[limit-1], 10)))
+	}
+	return actions, nextPageToken, nil
+}
+
+// DeleteCorporateAction deletes a corporate action unless it is applied.
+func (s *MarketDataStore) DeleteCorporateAction(ctx context.Context, id string) error {
+	if !isValidUUID(id) {
+		return fmt.Errorf("%w: invalid corporate action ID format", store.ErrInvalidArgument)
+	}
+
+	var status string
+	err := s.pool.QueryRow(ctx, `
+		WITH deleted AS (
+			DELETE FROM corporate_actions WHERE uuid = $1 AND status <> $2
+			RETURNING status
+		)
+		SELECT status FROM deleted
+		UNION ALL
+		SELECT status FROM corporate_actions WHERE uuid = $1
+		LIMIT 1`,
+		id, corporateActionStatuses[entity.CorporateActionStatusApplied]).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: corporate action with ID %s", store.ErrNotFound, id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete corporate action: %w", err)
+	}
+	if stringToCorporateActionStatus(status) == entity.CorporateActionStatusApplied {
+		return fmt.Errorf("%w: corporate action is applied; revert it first", store.ErrConstraint)
+	}
+	return nil
+}
+
+// adjustedTable is a table corporate actions change. The columns they
+// change are recorded in corporate_action_adjustments before and after.
+type adjustedTable struct {
+	name    string
+	target  string // Adjustment target
+	columns []string
+	touch   bool // Has updated_at
+}
+
+var (
+	adjustedHoldings = adjustedTable{"holdings", "holding", []string{"amount", "asset_id"}, true}
+	adjustedLots     = adjustedTable{"lots", "lot", []string{"amount", "cost_basis", "cost_asset_id"}, true}
+	adjustedPrices   = adjustedTable{"prices", "price", []string{"last", "open", "high", "low", "close", "volume"}, false}
+	adjustedTables   = []adjustedTable{adjustedHoldings, adjustedLots, adjustedPrices}
+)
+
+// snapshot returns a JSONB object of the table's adjusted columns of alias.
+func (t adjustedTable) snapshot(alias string) string {
+	pairs := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		pairs[i] = fmt.Sprintf("'%s', %s.%s", c, alias, c)
+	}
+	return "jsonb_build_object(" + strings.Join(pairs, ", ") + ")"
+}
+
+// adjust applies sets, assignments referring to the row as r, to the rows
+// of the table matching where, recording each for action $1. args are
+// bound from $2.
+func (t adjustedTable) adjust(ctx context.Context, tx pgx.Tx, actionID int64, where string, sets []string, args ...any) (int, error) {
+	if t.touch {
+		sets = append(sets, "updated_at = NOW()")
+	}
+	query := fmt.Sprintf(`
+		WITH old AS (
+			SELECT r.* FROM %[1]s r WHERE %[2]s FOR UPDATE
+		), changed AS (
+			UPDATE %[1]s r SET %[3]s FROM old WHERE r.id = old.id
+			RETURNING r.*
+		)
+		INSERT INTO corporate_action_adjustments (action_id, target, target_id, before, after)
+		SELECT $1, '%[4]s', old.id, %[5]s, %[6]s
+		FROM old JOIN changed ON changed.id = old.id`,
+		t.name, where, strings.Join(sets, ", "), t.target, t.snapshot("old"), t.snapshot("changed"))
+	result, err := tx.Exec(ctx, query, append([]any{actionID}, args...)...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to adjust %s: %w", t.name, err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// checkUnchanged locks the rows action adjusted and fails if any no longer
+// holds the values the action left.
+func (t adjustedTable) checkUnchanged(ctx context.Context, tx pgx.Tx, actionID int64) error {
+	rows, err := tx.Query(ctx, fmt.Sprintf(`
+		SELECT %s <> a.after
+		FROM corporate_action_adjustments a
+		JOIN %s r ON r.id = a.target_id
+		WHERE a.action_id = $1 AND a.target = '%s'
+		FOR UPDATE OF r`,
+		t.snapshot("r"), t.name, t.target), actionID)
+	if err != nil {
+		return fmt.Errorf("failed to check %s: %w", t.name, err)
+	}
+	changed, err := pgx.CollectRows(rows, pgx.RowTo[bool])
+	if err != nil {
+		return fmt.Errorf("failed to check %s: %w", t.name, err)
+	}
+	var n int
+	for _, c := range changed {
+		if c {
+			n++
+		}
+	}
+	if n > 0 {
+		return fmt.Errorf("%w: %d %s changed since the action was applied", store.ErrConstraint, n, t.name)
+	}
+	return nil
+}
+
+// restore sets the rows action adjusted back to their recorded values. Rows
+// deleted since are skipped.
+func (t adjustedTable) restore(ctx context.Context, tx pgx.Tx, actionID int64) error {
+	sets := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		sets[i] = fmt.Sprintf("%s = (a.before->>'%s')::bigint", c, c)
+	}
+	if t.touch {
+		sets = append(sets, "updated_at = NOW()")
+	}
+	_, err := tx.Exec(ctx, fmt.Sprintf(`
+		UPDATE %s r SET %s
+		FROM corporate_action_adjustments a
+		WHERE a.action_id = $1 AND a.target = '%s' AND r.id = a.target_id`,
+		t.name, strings.Join(sets, ", "), t.target), actionID)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s: %w", t.name, err)
+	}
+	return nil
+}
+
+// scaled rounds expr multiplied by mul and divided by div, all bigint.
+func scaled(expr, mul, div string) string {
+	return fmt.Sprintf("ROUND(%s::numeric * %s / %s)::bigint", expr, mul, div)
+}
+
+// ApplyCorporateAction adjusts every holding of the action's asset, with
+// its lots, and lots costed in it. Splits also reprice the asset and assets
+// quoted in it before the effective time; migrations move holdings and
+// costs to the new asset. Migrations and delistings mark the asset
+// delisted. The action must be pending or reverted and in effect.
+func (s *MarketDataStore) ApplyCorporateAction(ctx context.Context, id string) (*entity.CorporateAction, *entity.CorporateActionAdjustments, error) {
+	if !isValidUUID(id) {
+		return nil, nil, fmt.Errorf("%w: invalid corporate action ID format", store.ErrInvalidArgument)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		actionID, assetID  int64
+		newAssetID         *int64
+		actionType, status string
+		effectiveAt        time.Time
+		newUnits, oldUnits int64
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT id, asset_id, new_asset_id, type, status, effective_at, new_units, old_units
+		FROM corporate_actions WHERE uuid = $1
+		FOR UPDATE`, id,
+	).Scan(&actionID, &assetID, &newAssetID, &actionType, &status, &effectiveAt, &newUnits, &oldUnits)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, fmt.Errorf("%w: corporate action with ID %s", store.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get corporate action: %w", err)
+	}
+	if stringToCorporateActionStatus(status) == entity.CorporateActionStatusApplied {
+		return nil, nil, fmt.Errorf("%w: corporate action is already applied", store.ErrConstraint)
+	}
+	if effectiveAt.After(time.Now()) {
+		return nil, nil, fmt.Errorf("%w: corporate action takes effect at %s", store.ErrConstraint, effectiveAt.UTC().Format(time.RFC3339))
+	}
+
+	adjusted := &entity.CorporateActionAdjustments{}
+	t := stringToCorporateActionType(actionType)
+	if t == entity.CorporateActionTypeSplit || t == entity.CorporateActionTypeMigration {
+		target := assetID
+		if newAssetID != nil {
+			target = *newAssetID
+		}
+		// Lots go first, while their holdings still hold the asset. A lot
+		// keeps its total cost basis, so its cost per unit follows the ratio.
+		held := "r.holding_id IN (SELECT id FROM holdings WHERE asset_id = $2)"
+		adjusted.Lots, err = adjustedLots.adjust(ctx, tx, actionID, held+" OR r.cost_asset_id = $2", []string{
+			"amount = CASE WHEN " + held + " THEN " + scaled("r.amount", "$3::bigint", "$4::bigint") + " ELSE r.amount END",
+			"cost_basis = CASE WHEN r.cost_asset_id = $2 THEN " + scaled("r.cost_basis", "$3::bigint", "$4::bigint") + " ELSE r.cost_basis END",
+			"cost_asset_id = CASE WHEN r.cost_asset_id = $2 THEN $5::bigint ELSE r.cost_asset_id END",
+		}, assetID, newUnits, oldUnits, target)
+		if err != nil {
+			return nil, nil, err
+		}
+		adjusted.Holdings, err = adjustedHoldings.adjust(ctx, tx, actionID, "r.asset_id = $2", []string{
+			"amount = " + scaled("r.amount", "$3::bigint", "$4::bigint"),
+			"asset_id = $5::bigint",
+		}, assetID, newUnits, oldUnits, target)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if t == entity.CorporateActionTypeSplit {
+		// Earlier prices of the asset are per old unit, so they divide by
+		// the ratio and volumes multiply; prices quoted in it multiply.
+		mul := "(CASE WHEN r.asset_id = $2 THEN $4::bigint ELSE $3::bigint END)"
+		div := "(CASE WHEN r.asset_id = $2 THEN $3::bigint ELSE $4::bigint END)"
+		sets := make([]string, len(adjustedPrices.columns))
+		for i, c := range adjustedPrices.columns {
+			if c == "volume" {
+				sets[i] = "volume = CASE WHEN r.asset_id = $2 THEN " + scaled("r.volume", "$3::bigint", "$4::bigint") + " ELSE r.volume END"
+			} else {
+				sets[i] = c + " = " + scaled("r."+c, mul, div)
+			}
+		}
+		adjusted.Prices, err = adjustedPrices.adjust(ctx, tx, actionID,
+			"(r.asset_id = $2 OR r.base_asset_id = $2) AND r.timestamp < $5", sets,
+			assetID, newUnits, oldUnits, effectiveAt)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if t == entity.CorporateActionTypeMigration || t == entity.CorporateActionTypeDelisting {
+		delisted, err := json.Marshal(entity.AssetMetadata{
+			Value:     effectiveAt.UTC().Format(time.DateOnly),
+			Source:    entity.MetadataSourceCorporateAction,
+			UpdatedAt: time.Now(),
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+		_, err = tx.Exec(ctx, `
+			WITH old AS (
+				SELECT id, metadata FROM assets WHERE id = $2 FOR UPDATE
+			), changed AS (
+				UPDATE assets r SET metadata = r.metadata || jsonb_build_object($3::text, $4::jsonb), updated_at = NOW()
+				FROM old WHERE r.id = old.id
+				RETURNING r.id, r.metadata
+			)
+			INSERT INTO corporate_action_adjustments (action_id, target, target_id, before, after)
+			SELECT $1, 'asset', old.id, jsonb_build_object($3::text, old.metadata->$3::text), jsonb_build_object($3::text, changed.metadata->$3::text)
+			FROM old JOIN changed ON changed.id = old.id`,
+			actionID, assetID, entity.MetadataDelistedAt, delisted)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to mark asset delisted: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE corporate_actions
+		SET status = $2, applied_at = NOW(), reverted_at = NULL, updated_at = NOW()
+		WHERE id = $1`,
+		actionID, corporateActionStatuses[entity.CorporateActionStatusApplied])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update corporate action: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit corporate action: %w", err)
+	}
+
+	action, err := s.GetCorporateAction(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return action, adjusted, nil
+}
+
+// RevertCorporateAction restores every row applying the action changed to
+// its recorded value. It fails if any of them changed since, as restoring
+// it would undo later changes.
+func (s *MarketDataStore) RevertCorporateAction(ctx context.Context, id string) (*entity.CorporateAction, error) {
+	if !isValidUUID(id) {
+		return nil, fmt.Errorf("%w: invalid corporate action ID format", store.ErrInvalidArgument)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		actionID int64
+		status   string
+	)
+	err = tx.QueryRow(ctx, `SELECT id, status FROM corporate_actions WHERE uuid = $1 FOR UPDATE`, id).Scan(&actionID, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: corporate action with ID %s", store.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get corporate action: %w", err)
+	}
+	if stringToCorporateActionStatus(status) != entity.CorporateActionStatusApplied {
+		return nil, fmt.Errorf("%w: corporate action is not applied", store.ErrConstraint)
+	}
+
+	for _, t := range adjustedTables {
+		if err := t.checkUnchanged(ctx, tx, actionID); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range adjustedTables {
+		if err := t.restore(ctx, tx, actionID); err != nil {
+			return nil, err
+		}
+	}
+	// Only the delisting mark is restored on assets, as enrichment may have
+	// changed other metadata since.
+	_, err = tx.Exec(ctx, `
+		UPDATE assets r SET
+			metadata = CASE WHEN jsonb_typeof(a.before->$2::text) = 'null' THEN r.metadata - $2::text
+				ELSE jsonb_set(r.metadata, ARRAY[$2::text], a.before->$2::text) END,
+			updated_at = NOW()
+		FROM corporate_action_adjustments a
+		WHERE a.action_id = $1 AND a.target = 'asset' AND r.id = a.target_id`,
+		actionID, entity.MetadataDelistedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore assets: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM corporate_action_adjustments WHERE action_id = $1`, actionID); err != nil {
+		return nil, fmt.Errorf("failed to delete adjustments: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE corporate_actions
+		SET status = $2, reverted_at = NOW(), updated_at = NOW()
+		WHERE id = $1`,
+		actionID, corporateActionStatuses[entity.CorporateActionStatusReverted])
+	if err != nil {
+		return nil, fmt.Errorf("failed to update corporate action: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit corporate action: %w", err)
+	}
+
+	return s.GetCorporateAction(ctx, id)
+}
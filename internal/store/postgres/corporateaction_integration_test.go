@@ -0,0 +1,193 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/secrets"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorporateActions(t *testing.T) {
+	pool := getTestPool(t)
+	ctx := context.Background()
+	s := NewMarketDataStore(pool)
+	keys, err := secrets.NewKeyring(map[uint32][]byte{1: make([]byte, 32)})
+	require.NoError(t, err)
+	portfolios := NewPortfolioStore(pool, keys)
+
+	usd := createTestAsset(t, s, "Dollar")
+	aapl := createTestAsset(t, s, "Apple")
+	matic := createTestAsset(t, s, "Matic")
+	pol := createTestAsset(t, s, "Pol")
+
+	user, err := NewSettingsStore(pool).CreateUser(ctx, &entity.User{Email: "alice@example.com", Name: "Alice"})
+	require.NoError(t, err)
+	account, err := portfolios.CreateAccount(ctx, &entity.Account{UserID: user.ID, Name: "Broker", Type: entity.AccountTypeBroker})
+	require.NoError(t, err)
+	shares, err := portfolios.CreateHolding(ctx, &entity.Holding{Amount: 10, AssetID: aapl.ID, AccountID: account.ID})
+	require.NoError(t, err)
+	lot, err := portfolios.CreateLot(ctx, &entity.Lot{
+		HoldingID: shares.ID, Amount: 10, CostBasis: 150000, CostDecimals: 2, CostAssetID: usd.ID, AcquiredAt: time.Now().AddDate(0, -1, 0),
+	})
+	require.NoError(t, err)
+	tokens, err := portfolios.CreateHolding(ctx, &entity.Holding{Amount: 5, AssetID: matic.ID, AccountID: account.ID})
+	require.NoError(t, err)
+
+	volume := int64(1000)
+	before, err := s.CreatePrice(ctx, &entity.StoredPrice{
+		SourceID: "test", AssetID: aapl.ID, BaseAssetID: usd.ID, Interval: "1d", Decimals: 2,
+		Last: 20000, Volume: &volume, Timestamp: time.Now().AddDate(0, 0, -2),
+	})
+	require.NoError(t, err)
+	after, err := s.CreatePrice(ctx, &entity.StoredPrice{
+		SourceID: "test", AssetID: aapl.ID, BaseAssetID: usd.ID, Interval: "1d", Decimals: 2,
+		Last: 5100, Timestamp: time.Now(),
+	})
+	require.NoError(t, err)
+
+	prices := func() map[string]*entity.StoredPrice {
+		t.Helper()
+		res, _, err := s.ListPriceHistory(ctx, marketdata.ListPriceHistoryOpts{AssetID: aapl.ID, BaseAssetID: usd.ID})
+		require.NoError(t, err)
+		byID := make(map[string]*entity.StoredPrice)
+		for _, p := range res {
+			byID[p.ID] = p
+		}
+		return byID
+	}
+
+	split, err := s.CreateCorporateAction(ctx, &entity.CorporateAction{
+		AssetID: aapl.ID, Type: entity.CorporateActionTypeSplit, NewUnits: 4, OldUnits: 1,
+		EffectiveAt: time.Now().AddDate(0, 0, -1), Note: "4-for-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.CorporateActionStatusPending, split.Status)
+
+	t.Run("Apply split", func(t *testing.T) {
+		action, adjusted, err := s.ApplyCorporateAction(ctx, split.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.CorporateActionStatusApplied, action.Status)
+		assert.NotNil(t, action.AppliedAt)
+		assert.Equal(t, entity.CorporateActionAdjustments{Holdings: 1, Lots: 1, Prices: 1}, *adjusted)
+
+		h, err := portfolios.GetHolding(ctx, shares.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(40), h.Amount)
+		l, err := portfolios.GetLot(ctx, lot.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(40), l.Amount)
+		assert.Equal(t, int64(150000), l.CostBasis)
+
+		// Only prices before the split are per old share.
+		p := prices()
+		assert.Equal(t, int64(5000), p[before.ID].Last)
+		assert.Equal(t, int64(4000), *p[before.ID].Volume)
+		assert.Equal(t, int64(5100), p[after.ID].Last)
+
+		_, _, err = s.ApplyCorporateAction(ctx, split.ID)
+		assert.ErrorIs(t, err, store.ErrConstraint)
+		assert.ErrorIs(t, s.DeleteCorporateAction(ctx, split.ID), store.ErrConstraint)
+	})
+
+	t.Run("Revert split", func(t *testing.T) {
+		action, err := s.RevertCorporateAction(ctx, split.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.CorporateActionStatusReverted, action.Status)
+		assert.NotNil(t, action.RevertedAt)
+
+		h, err := portfolios.GetHolding(ctx, shares.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), h.Amount)
+		p := prices()
+		assert.Equal(t, int64(20000), p[before.ID].Last)
+		assert.Equal(t, int64(1000), *p[before.ID].Volume)
+
+		_, err = s.RevertCorporateAction(ctx, split.ID)
+		assert.ErrorIs(t, err, store.ErrConstraint)
+	})
+
+	t.Run("Revert refuses later changes", func(t *testing.T) {
+		_, _, err := s.ApplyCorporateAction(ctx, split.ID)
+		require.NoError(t, err)
+		_, err = portfolios.UpdateHolding(ctx, &entity.Holding{ID: shares.ID, Amount: 44}, []string{"amount"})
+		require.NoError(t, err)
+
+		_, err = s.RevertCorporateAction(ctx, split.ID)
+		assert.ErrorIs(t, err, store.ErrConstraint)
+		h, err := portfolios.GetHolding(ctx, shares.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(44), h.Amount)
+	})
+
+	t.Run("Migration", func(t *testing.T) {
+		migration, err := s.CreateCorporateAction(ctx, &entity.CorporateAction{
+			AssetID: matic.ID, NewAssetID: pol.ID, Type: entity.CorporateActionTypeMigration, NewUnits: 1, OldUnits: 1,
+			EffectiveAt: time.Date(2024, 9, 4, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		_, adjusted, err := s.ApplyCorporateAction(ctx, migration.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, adjusted.Holdings)
+
+		h, err := portfolios.GetHolding(ctx, tokens.ID)
+		require.NoError(t, err)
+		assert.Equal(t, pol.ID, h.AssetID)
+		assert.Equal(t, int64(5), h.Amount)
+		asset, err := s.GetAsset(ctx, matic.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "2024-09-04", asset.Metadata[entity.MetadataDelistedAt].Value)
+		assert.Equal(t, entity.MetadataSourceCorporateAction, asset.Metadata[entity.MetadataDelistedAt].Source)
+
+		listed, _, err := s.ListCorporateActions(ctx, marketdata.ListCorporateActionsOpts{AssetID: pol.ID})
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, migration.ID, listed[0].ID)
+		assert.Equal(t, pol.ID, listed[0].NewAssetID)
+
+		_, err = s.RevertCorporateAction(ctx, migration.ID)
+		require.NoError(t, err)
+		h, err = portfolios.GetHolding(ctx, tokens.ID)
+		require.NoError(t, err)
+		assert.Equal(t, matic.ID, h.AssetID)
+		asset, err = s.GetAsset(ctx, matic.ID)
+		require.NoError(t, err)
+		assert.NotContains(t, asset.Metadata, entity.MetadataDelistedAt)
+
+		require.NoError(t, s.DeleteCorporateAction(ctx, migration.ID))
+		_, err = s.GetCorporateAction(ctx, migration.ID)
+		assert.ErrorIs(t, err, store.ErrNotFound)
+	})
+
+	t.Run("Not yet effective", func(t *testing.T) {
+		delisting, err := s.CreateCorporateAction(ctx, &entity.CorporateAction{
+			AssetID: aapl.ID, Type: entity.CorporateActionTypeDelisting, EffectiveAt: time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+		_, _, err = s.ApplyCorporateAction(ctx, delisting.ID)
+		assert.ErrorIs(t, err, store.ErrConstraint)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		listed, next, err := s.ListCorporateActions(ctx, marketdata.ListCorporateActionsOpts{AssetID: aapl.ID, PageSize: 1})
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, entity.CorporateActionTypeDelisting, listed[0].Type)
+		listed, _, err = s.ListCorporateActions(ctx, marketdata.ListCorporateActionsOpts{AssetID: aapl.ID, PageToken: next})
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, split.ID, listed[0].ID)
+
+		listed, _, err = s.ListCorporateActions(ctx, marketdata.ListCorporateActionsOpts{Status: entity.CorporateActionStatusApplied})
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "4-for-1", listed[0].Note)
+	})
+}
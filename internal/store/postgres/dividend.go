@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/audit"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/shopspring/decimal"
+)
+
+// CreateDividends stores dividends not stored before, one per asset and
+// ex-date whatever their source, and returns how many it stored.
+func (s *MarketDataStore) CreateDividends(ctx context.Context, dividends []*entity.Dividend) (int, error) {
+	stored := 0
+	for _, d := range dividends {
+		if d == nil || !d.PerShare.IsPositive() || d.ExDate.IsZero() {
+			return stored, fmt.Errorf("%w: dividend needs a positive amount per share and an ex-date", store.ErrInvalidArgument)
+		}
+		assetInternalID, err := s.getAssetInternalID(ctx, d.AssetID)
+		if err != nil {
+			return stored, err
+		}
+		currencyInternalID, err := s.getAssetInternalID(ctx, d.CurrencyAssetID)
+		if err != nil {
+			return stored, err
+		}
+		decimals := max(-d.PerShare.Exponent(), 0)
+		tag, err := s.pool.Exec(ctx, `
+			INSERT INTO dividends (source_id, ex_date, per_share, decimals, asset_id, currency_asset_id, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, NOW())
+			ON CONFLICT (asset_id, ex_date) DO NOTHING`,
+			d.Source, d.ExDate, d.PerShare.Shift(decimals).IntPart(), decimals, assetInternalID, currencyInternalID)
+		if err != nil {
+			return stored, fmt.Errorf("failed to store dividend: %w", err)
+		}
+		stored += int(tag.RowsAffected())
+	}
+	return stored, nil
+}
+
+// ListUnrecordedDividends returns the dividends that went ex before before
+// and whose income is not recorded yet, oldest first.
+func (s *PortfolioStore) ListUnrecordedDividends(ctx context.Context, before time.Time) ([]*entity.Dividend, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT d.source_id, a.uuid, c.uuid, d.ex_date, d.per_share, d.decimals
+		FROM dividends d
+		JOIN assets a ON a.id = d.asset_id
+		JOIN assets c ON c.id = d.currency_asset_id
+		WHERE d.recorded_at IS NULL AND d.ex_date < $1
+		ORDER BY d.ex_date, d.id`, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dividends: %w", err)
+	}
+	defer rows.Close()
+
+	var dividends []*entity.Dividend
+	for rows.Next() {
+		var d entity.Dividend
+		var perShare int64
+		var decimals int32
+		if err := rows.Scan(&d.Source, &d.AssetID, &d.CurrencyAssetID, &d.ExDate, &perShare, &decimals); err != nil {
+			return nil, fmt.Errorf("failed to scan dividend: %w", err)
+		}
+		d.ExDate = d.ExDate.UTC()
+		d.PerShare = decimal.New(perShare, -decimals)
+		dividends = append(dividends, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list dividends: %w", err)
+	}
+	return dividends, nil
+}
+
+// RecordDividend creates the income transactions of a dividend and marks it
+// recorded, in one database transaction. Transactions whose external ID
+// their account already has are skipped. It returns how many it created.
+func (s *PortfolioStore) RecordDividend(ctx context.Context, d *entity.Dividend, txs []*entity.Transaction) (int, error) {
+	assetInternalID, err := s.getAssetInternalID(ctx, d.AssetID)
+	if err != nil {
+		return 0, err
+	}
+
+	dbTx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	created, err := s.insertNewTransactions(ctx, dbTx, txs)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := dbTx.Exec(ctx, "UPDATE dividends SET recorded_at = NOW() WHERE asset_id = $1 AND ex_date = $2",
+		assetInternalID, d.ExDate); err != nil {
+		return 0, fmt.Errorf("failed to mark dividend recorded: %w", err)
+	}
+	if err := dbTx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit dividend: %w", err)
+	}
+
+	if !audit.Enabled(ctx) {
+		return len(created), nil
+	}
+	// Each transaction is recorded for the owner of its account, so they
+	// see it among their own events; without one only admins do.
+	for _, t := range created {
+		var ownerID string
+		_ = s.pool.QueryRow(ctx, `
+			SELECT u.uuid FROM accounts a JOIN users u ON u.id = a.user_id WHERE a.uuid = $1`,
+			t.AccountID).Scan(&ownerID)
+		audit.RecordChange(audit.WithTask(ctx, t.ExternalID, ownerID), "transaction", t.ID, nil, t)
+	}
+	return len(created), nil
+}
@@ -0,0 +1,81 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/secrets"
+	"github.com/foxcool/greedy-eye/internal/service/portfolio"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDividends(t *testing.T) {
+	pool := getTestPool(t)
+	ctx := context.Background()
+	s := NewMarketDataStore(pool)
+	keys, err := secrets.NewKeyring(map[uint32][]byte{1: make([]byte, 32)})
+	require.NoError(t, err)
+	portfolios := NewPortfolioStore(pool, keys)
+
+	usd := createTestAsset(t, s, "Dollar")
+	aapl := createTestAsset(t, s, "Apple")
+	user, err := NewSettingsStore(pool).CreateUser(ctx, &entity.User{Email: "alice@example.com", Name: "Alice"})
+	require.NoError(t, err)
+	account, err := portfolios.CreateAccount(ctx, &entity.Account{UserID: user.ID, Name: "Broker", Type: entity.AccountTypeBroker})
+	require.NoError(t, err)
+
+	exDate := time.Date(2025, time.May, 12, 0, 0, 0, 0, time.UTC)
+	dividend := &entity.Dividend{
+		Source: "test", AssetID: aapl.ID, CurrencyAssetID: usd.ID, ExDate: exDate, PerShare: decimal.RequireFromString("0.25"),
+	}
+	stored, err := s.CreateDividends(ctx, []*entity.Dividend{dividend})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored)
+
+	// One dividend per asset and ex-date, whatever its source.
+	other := *dividend
+	other.Source = "other"
+	stored, err = s.CreateDividends(ctx, []*entity.Dividend{&other})
+	require.NoError(t, err)
+	assert.Zero(t, stored)
+
+	pending, err := portfolios.ListUnrecordedDividends(ctx, exDate)
+	require.NoError(t, err)
+	assert.Empty(t, pending, "not ex yet")
+	pending, err = portfolios.ListUnrecordedDividends(ctx, exDate.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, dividend, pending[0])
+
+	income := func() []*entity.Transaction {
+		return []*entity.Transaction{{
+			Type: entity.TransactionTypeIncome, Status: entity.TransactionStatusCompleted,
+			AccountID: account.ID, AssetID: usd.ID, ExternalID: "dividend:" + aapl.ID + ":2025-05-12",
+			Data: map[string]string{"income": "dividend", "amount": "2.5"},
+		}}
+	}
+	recorded, err := portfolios.RecordDividend(ctx, pending[0], income())
+	require.NoError(t, err)
+	assert.Equal(t, 1, recorded)
+
+	txs, _, err := portfolios.ListTransactions(ctx, portfolio.ListTransactionsOpts{AccountID: account.ID})
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, usd.ID, txs[0].AssetID)
+	assert.Equal(t, "2.5", txs[0].Data["amount"])
+
+	pending, err = portfolios.ListUnrecordedDividends(ctx, exDate.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// Recording it again adds nothing.
+	recorded, err = portfolios.RecordDividend(ctx, dividend, income())
+	require.NoError(t, err)
+	assert.Zero(t, recorded)
+}
@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/foxcool/greedy-eye/internal/entity"
 	"github.com/foxcool/greedy-eye/internal/service/marketdata"
@@ -15,6 +17,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
 )
 
 const defaultPageSize = 20
@@ -31,6 +34,106 @@ func NewMarketDataStore(pool *pgxpool.Pool) *MarketDataStore {
 	return &MarketDataStore{pool: pool}
 }
 
+const assetColumns = `uuid, symbol, name, type, tags, metadata, last_enriched_at, bond, created_at, updated_at`
+
+func scanAsset(row pgx.Row) (*entity.Asset, error) {
+	var asset entity.Asset
+	var typeStr string
+	var tagsJSON, metadataJSON, bondJSON []byte
+	if err := row.Scan(
+		&asset.ID,
+		&asset.Symbol,
+		&asset.Name,
+		&typeStr,
+		&tagsJSON,
+		&metadataJSON,
+		&asset.LastEnrichedAt,
+		&bondJSON,
+		&asset.CreatedAt,
+		&asset.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	asset.Type = stringToAssetType(typeStr)
+	if err := json.Unmarshal(tagsJSON, &asset.Tags); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+	}
+	if err := json.Unmarshal(metadataJSON, &asset.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	if bondJSON != nil {
+		var terms bondTermsJSON
+		if err := json.Unmarshal(bondJSON, &terms); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal bond terms: %w", err)
+		}
+		asset.Bond = terms.entity()
+	}
+	return &asset, nil
+}
+
+func marshalAssetMetadata(metadata map[string]entity.AssetMetadata) ([]byte, error) {
+	if metadata == nil {
+		metadata = map[string]entity.AssetMetadata{}
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	return metadataJSON, nil
+}
+
+// bondTermsJSON is how bond terms are stored in assets.bond.
+type bondTermsJSON struct {
+	FaceValue       decimal.Decimal `json:"face_value"`
+	CurrencyAssetID string          `json:"currency_asset_id"`
+	CouponRate      decimal.Decimal `json:"coupon_rate"`
+	CouponFrequency int             `json:"coupon_frequency"`
+	IssueDate       *time.Time      `json:"issue_date,omitempty"`
+	MaturityDate    time.Time       `json:"maturity_date"`
+	DayCount        string          `json:"day_count,omitempty"`
+}
+
+// marshalBondTerms returns the stored form of terms, nil (NULL) if there
+// are none.
+func marshalBondTerms(terms *entity.BondTerms) ([]byte, error) {
+	if terms == nil {
+		return nil, nil
+	}
+	stored := bondTermsJSON{
+		FaceValue:       terms.FaceValue,
+		CurrencyAssetID: terms.CurrencyAssetID,
+		CouponRate:      terms.CouponRate,
+		CouponFrequency: terms.CouponFrequency,
+		MaturityDate:    terms.MaturityDate.UTC(),
+		DayCount:        dayCountToString(terms.DayCount),
+	}
+	if !terms.IssueDate.IsZero() {
+		issued := terms.IssueDate.UTC()
+		stored.IssueDate = &issued
+	}
+	bondJSON, err := json.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bond terms: %w", err)
+	}
+	return bondJSON, nil
+}
+
+func (b bondTermsJSON) entity() *entity.BondTerms {
+	terms := &entity.BondTerms{
+		FaceValue:       b.FaceValue,
+		CurrencyAssetID: b.CurrencyAssetID,
+		CouponRate:      b.CouponRate,
+		CouponFrequency: b.CouponFrequency,
+		MaturityDate:    b.MaturityDate,
+		DayCount:        stringToDayCount(b.DayCount),
+	}
+	if b.IssueDate != nil {
+		terms.IssueDate = *b.IssueDate
+	}
+	return terms
+}
+
 // CreateAsset creates a new asset in the database.
 func (s *MarketDataStore) CreateAsset(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
 	if asset == nil {
@@ -48,10 +151,18 @@ func (s *MarketDataStore) CreateAsset(ctx context.Context, asset *entity.Asset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal tags: %w", err)
 	}
+	metadataJSON, err := marshalAssetMetadata(asset.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	bondJSON, err := marshalBondTerms(asset.Bond)
+	if err != nil {
+		return nil, err
+	}
 
 	query := `
-		INSERT INTO assets (uuid, symbol, name, type, tags, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		INSERT INTO assets (uuid, symbol, name, type, tags, metadata, last_enriched_at, bond, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
 		RETURNING created_at, updated_at`
 
 	err = s.pool.QueryRow(ctx, query,
@@ -60,6 +171,9 @@ func (s *MarketDataStore) CreateAsset(ctx context.Context, asset *entity.Asset)
 		asset.Name,
 		assetTypeToString(asset.Type),
 		tagsJSON,
+		metadataJSON,
+		asset.LastEnrichedAt,
+		bondJSON,
 	).Scan(&asset.CreatedAt, &asset.UpdatedAt)
 	if err != nil {
 		if isConstraintError(err) {
@@ -81,23 +195,11 @@ func (s *MarketDataStore) GetAsset(ctx context.Context, id string) (*entity.Asse
 	}
 
 	query := `
-		SELECT uuid, symbol, name, type, tags, created_at, updated_at
+		SELECT ` + assetColumns + `
 		FROM assets
 		WHERE uuid = $1`
 
-	var asset entity.Asset
-	var typeStr string
-	var tagsJSON []byte
-
-	err := s.pool.QueryRow(ctx, query, id).Scan(
-		&asset.ID,
-		&asset.Symbol,
-		&asset.Name,
-		&typeStr,
-		&tagsJSON,
-		&asset.CreatedAt,
-		&asset.UpdatedAt,
-	)
+	asset, err := scanAsset(s.pool.QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("%w: asset with ID %s", store.ErrNotFound, id)
@@ -105,12 +207,7 @@ func (s *MarketDataStore) GetAsset(ctx context.Context, id string) (*entity.Asse
 		return nil, fmt.Errorf("failed to get asset: %w", err)
 	}
 
-	asset.Type = stringToAssetType(typeStr)
-	if err := json.Unmarshal(tagsJSON, &asset.Tags); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
-	}
-
-	return &asset, nil
+	return asset, nil
 }
 
 // UpdateAsset updates an asset with the specified fields.
@@ -149,6 +246,26 @@ func (s *MarketDataStore) UpdateAsset(ctx context.Context, asset *entity.Asset,
 			setClauses = append(setClauses, fmt.Sprintf("tags = $%d", argIdx))
 			args = append(args, tagsJSON)
 			argIdx++
+		case "metadata":
+			metadataJSON, err := marshalAssetMetadata(asset.Metadata)
+			if err != nil {
+				return nil, err
+			}
+			setClauses = append(setClauses, fmt.Sprintf("metadata = $%d", argIdx))
+			args = append(args, metadataJSON)
+			argIdx++
+		case "last_enriched_at":
+			setClauses = append(setClauses, fmt.Sprintf("last_enriched_at = $%d", argIdx))
+			args = append(args, asset.LastEnrichedAt)
+			argIdx++
+		case "bond":
+			bondJSON, err := marshalBondTerms(asset.Bond)
+			if err != nil {
+				return nil, err
+			}
+			setClauses = append(setClauses, fmt.Sprintf("bond = $%d", argIdx))
+			args = append(args, bondJSON)
+			argIdx++
 		}
 	}
 
@@ -156,22 +273,10 @@ func (s *MarketDataStore) UpdateAsset(ctx context.Context, asset *entity.Asset,
 		UPDATE assets
 		SET %s
 		WHERE uuid = $1
-		RETURNING uuid, symbol, name, type, tags, created_at, updated_at`,
+		RETURNING `+assetColumns,
 		strings.Join(setClauses, ", "))
 
-	var result entity.Asset
-	var typeStr string
-	var tagsJSON []byte
-
-	err := s.pool.QueryRow(ctx, query, args...).Scan(
-		&result.ID,
-		&result.Symbol,
-		&result.Name,
-		&typeStr,
-		&tagsJSON,
-		&result.CreatedAt,
-		&result.UpdatedAt,
-	)
+	result, err := scanAsset(s.pool.QueryRow(ctx, query, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("%w: asset with ID %s", store.ErrNotFound, asset.ID)
@@ -179,12 +284,7 @@ func (s *MarketDataStore) UpdateAsset(ctx context.Context, asset *entity.Asset,
 		return nil, fmt.Errorf("failed to update asset: %w", err)
 	}
 
-	result.Type = stringToAssetType(typeStr)
-	if err := json.Unmarshal(tagsJSON, &result.Tags); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
-	}
-
-	return &result, nil
+	return result, nil
 }
 
 // DeleteAsset deletes an asset by ID.
@@ -233,6 +333,12 @@ func (s *MarketDataStore) ListAssets(ctx context.Context, opts marketdata.ListAs
 		}
 	}
 
+	if opts.Symbol != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("UPPER(symbol) = UPPER($%d)", argIdx))
+		args = append(args, opts.Symbol)
+		argIdx++
+	}
+
 	// Handle tags filtering using JSONB @> operator
 	if len(opts.Tags) > 0 {
 		tagsJSON, err := json.Marshal(opts.Tags)
@@ -244,13 +350,44 @@ func (s *MarketDataStore) ListAssets(ctx context.Context, opts marketdata.ListAs
 		argIdx++
 	}
 
+	// ?| also uses the GIN index on tags
+	if len(opts.AnyTags) > 0 {
+		whereClauses = append(whereClauses, fmt.Sprintf("tags ?| $%d::text[]", argIdx))
+		args = append(args, opts.AnyTags)
+		argIdx++
+	}
+
+	if opts.Type != entity.AssetTypeUnspecified {
+		whereClauses = append(whereClauses, fmt.Sprintf("type = $%d", argIdx))
+		args = append(args, assetTypeToString(opts.Type))
+		argIdx++
+	}
+
+	if ident := opts.Identifier; ident != nil {
+		identClauses := []string{fmt.Sprintf("i.value = $%d", argIdx)}
+		args = append(args, ident.Value)
+		argIdx++
+		if ident.Kind != entity.IdentifierKindUnspecified {
+			identClauses = append(identClauses, fmt.Sprintf("i.kind = $%d", argIdx))
+			args = append(args, identifierKinds[ident.Kind])
+			argIdx++
+		}
+		if ident.Chain != "" {
+			identClauses = append(identClauses, fmt.Sprintf("i.chain = $%d", argIdx))
+			args = append(args, ident.Chain)
+			argIdx++
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf(
+			"id IN (SELECT i.asset_id FROM asset_identifiers i WHERE %s)", strings.Join(identClauses, " AND ")))
+	}
+
 	whereClause := ""
 	if len(whereClauses) > 0 {
 		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
 	}
 
 	query := fmt.Sprintf(`
-		SELECT uuid, symbol, name, type, tags, created_at, updated_at
+		SELECT `+assetColumns+`
 		FROM assets
 		%s
 		ORDER BY uuid
@@ -266,28 +403,11 @@ func (s *MarketDataStore) ListAssets(ctx context.Context, opts marketdata.ListAs
 
 	assets := make([]*entity.Asset, 0, limit)
 	for rows.Next() {
-		var asset entity.Asset
-		var typeStr string
-		var tagsJSON []byte
-
-		if err := rows.Scan(
-			&asset.ID,
-			&asset.Symbol,
-			&asset.Name,
-			&typeStr,
-			&tagsJSON,
-			&asset.CreatedAt,
-			&asset.UpdatedAt,
-		); err != nil {
+		asset, err := scanAsset(rows)
+		if err != nil {
 			return nil, "", fmt.Errorf("failed to scan asset: %w", err)
 		}
-
-		asset.Type = stringToAssetType(typeStr)
-		if err := json.Unmarshal(tagsJSON, &asset.Tags); err != nil {
-			return nil, "", fmt.Errorf("failed to unmarshal tags: %w", err)
-		}
-
-		assets = append(assets, &asset)
+		assets = append(assets, asset)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -305,6 +425,264 @@ func (s *MarketDataStore) ListAssets(ctx context.Context, opts marketdata.ListAs
 	return assets, nextPageToken, nil
 }
 
+// assetSearchVector is the text search document of an asset; the
+// asset_search index is built on the same expression.
+const assetSearchVector = `to_tsvector('simple', name || ' ' || symbol)`
+
+// SearchAssets returns assets matching a query, best first, or if asked
+// the assets most similar to it when none match. Pages are offsets into the ranking,
+// so results may shift between pages as assets change.
+func (s *MarketDataStore) SearchAssets(ctx context.Context, opts marketdata.SearchAssetsOpts) ([]*entity.Asset, string, error) {
+	query := strings.TrimSpace(opts.Query)
+	if query == "" {
+		return nil, "", fmt.Errorf("%w: query is required", store.ErrInvalidArgument)
+	}
+	limit := opts.PageSize
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	var offset int
+	if opts.PageToken != "" {
+		decoded, err := base64.StdEncoding.DecodeString(opts.PageToken)
+		if err == nil {
+			offset, err = strconv.Atoi(string(decoded))
+		}
+		if err != nil || offset < 0 {
+			return nil, "", fmt.Errorf("%w: invalid page token", store.ErrInvalidArgument)
+		}
+	}
+
+	// $1 is the query, $2 a LIKE pattern for symbols starting with it
+	args := []any{query, likePrefix(strings.ToLower(query))}
+	identifierMatch := "id IN (SELECT i.asset_id FROM asset_identifiers i WHERE i.value IN ($1, UPPER($1), LOWER($1)))"
+	matches := []string{"LOWER(symbol) = LOWER($1)", "LOWER(symbol) LIKE $2", identifierMatch}
+	textRank := "0"
+	if tsQuery := prefixTSQuery(query); tsQuery != "" {
+		tsArg := fmt.Sprintf("to_tsquery('simple', $%d)", len(args)+1)
+		matches = append(matches, assetSearchVector+" @@ "+tsArg)
+		textRank = "ts_rank(" + assetSearchVector + ", " + tsArg + ")"
+		args = append(args, tsQuery)
+	}
+	filters, args, err := searchAssetFilters(opts, args)
+	if err != nil {
+		return nil, "", err
+	}
+	argIdx := len(args) + 1
+
+	sql := fmt.Sprintf(`
+		SELECT `+assetColumns+`
+		FROM assets
+		WHERE (%s)%s
+		ORDER BY
+			CASE
+				WHEN LOWER(symbol) = LOWER($1) THEN 0
+				WHEN %s THEN 1
+				WHEN LOWER(symbol) LIKE $2 THEN 2
+				ELSE 3
+			END,
+			%s DESC, LOWER(symbol), uuid
+		LIMIT $%d OFFSET $%d`,
+		strings.Join(matches, " OR "), filters, identifierMatch, textRank, argIdx, argIdx+1)
+	args = append(args, limit+1, offset) // Fetch one extra to detect next page
+
+	assets, err := s.queryAssets(ctx, sql, args)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Nothing starts with the query, so it may be misspelled: rank assets
+	// by trigram similarity instead. Tokens are only issued while matches
+	// remain, so an empty later page also means the fallback is paging.
+	if len(assets) == 0 && opts.Similar {
+		filters, args, err = searchAssetFilters(opts, []any{query})
+		if err != nil {
+			return nil, "", err
+		}
+		argIdx = len(args) + 1
+		sql = fmt.Sprintf(`
+			SELECT `+assetColumns+`
+			FROM assets
+			WHERE (name %% $1 OR symbol %% $1)%s
+			ORDER BY GREATEST(similarity(name, $1), similarity(symbol, $1)) DESC, LOWER(symbol), uuid
+			LIMIT $%d OFFSET $%d`, filters, argIdx, argIdx+1)
+		args = append(args, limit+1, offset)
+		if assets, err = s.queryAssets(ctx, sql, args); err != nil {
+			return nil, "", err
+		}
+	}
+
+	var nextPageToken string
+	if len(assets) > limit {
+		assets = assets[:limit]
+		nextPageToken = base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(offset + limit)))
+	}
+	return assets, nextPageToken, nil
+}
+
+// searchAssetFilters appends the type and tags filters of a search to
+// args, returning them as conditions to add to its WHERE clause.
+func searchAssetFilters(opts marketdata.SearchAssetsOpts, args []any) (string, []any, error) {
+	var filters string
+	if opts.Type != entity.AssetTypeUnspecified {
+		args = append(args, assetTypeToString(opts.Type))
+		filters += fmt.Sprintf(" AND type = $%d", len(args))
+	}
+	if len(opts.Tags) > 0 {
+		tagsJSON, err := json.Marshal(opts.Tags)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to marshal tags filter: %w", err)
+		}
+		args = append(args, string(tagsJSON))
+		filters += fmt.Sprintf(" AND tags @> $%d::jsonb", len(args))
+	}
+	return filters, args, nil
+}
+
+// queryAssets runs a query selecting assetColumns.
+func (s *MarketDataStore) queryAssets(ctx context.Context, sql string, args []any) ([]*entity.Asset, error) {
+	rows, err := s.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search assets: %w", err)
+	}
+	defer rows.Close()
+
+	var assets []*entity.Asset
+	for rows.Next() {
+		asset, err := scanAsset(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan asset: %w", err)
+		}
+		assets = append(assets, asset)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate assets: %w", err)
+	}
+	return assets, nil
+}
+
+// likePrefix returns a LIKE pattern matching strings that start with s.
+func likePrefix(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s) + "%"
+}
+
+// prefixTSQuery turns the words of a search into a text search query
+// matching documents with words starting with each of them, or "" if it
+// has no words.
+func prefixTSQuery(query string) string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// identifierKinds names identifier kinds in the database.
+var identifierKinds = map[entity.IdentifierKind]string{
+	entity.IdentifierKindCoinGecko: "coingecko",
+	entity.IdentifierKindBinance:   "binance",
+	entity.IdentifierKindISIN:      "isin",
+	entity.IdentifierKindCUSIP:     "cusip",
+	entity.IdentifierKindFIGI:      "figi",
+	entity.IdentifierKindContract:  "contract",
+	entity.IdentifierKindTicker:    "ticker",
+}
+
+func stringToIdentifierKind(s string) entity.IdentifierKind {
+	for kind, name := range identifierKinds {
+		if name == s {
+			return kind
+		}
+	}
+	return entity.IdentifierKindUnspecified
+}
+
+// CreateAssetIdentifier maps an asset to an identifier. An identifier that
+// already maps to an asset is a constraint error.
+func (s *MarketDataStore) CreateAssetIdentifier(ctx context.Context, ident *entity.AssetIdentifier) (*entity.AssetIdentifier, error) {
+	if ident == nil {
+		return nil, fmt.Errorf("%w: identifier is required", store.ErrInvalidArgument)
+	}
+	kind, ok := identifierKinds[ident.Kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: identifier kind is required", store.ErrInvalidArgument)
+	}
+	if ident.Value == "" {
+		return nil, fmt.Errorf("%w: identifier value is required", store.ErrInvalidArgument)
+	}
+	assetInternalID, err := s.getAssetInternalID(ctx, ident.AssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	ident.ID = uuid.New().String()
+	err = s.pool.QueryRow(ctx, `
+		INSERT INTO asset_identifiers (uuid, asset_id, kind, chain, value, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING created_at`,
+		ident.ID, assetInternalID, kind, ident.Chain, ident.Value,
+	).Scan(&ident.CreatedAt)
+	if err != nil {
+		if isConstraintError(err) {
+			return nil, fmt.Errorf("%w: %s identifier %q already maps to an asset", store.ErrConstraint, kind, ident.Value)
+		}
+		return nil, fmt.Errorf("failed to create asset identifier: %w", err)
+	}
+	return ident, nil
+}
+
+// DeleteAssetIdentifier deletes an asset identifier by ID.
+func (s *MarketDataStore) DeleteAssetIdentifier(ctx context.Context, id string) error {
+	if !isValidUUID(id) {
+		return fmt.Errorf("%w: invalid identifier ID format", store.ErrInvalidArgument)
+	}
+
+	result, err := s.pool.Exec(ctx, `DELETE FROM asset_identifiers WHERE uuid = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete asset identifier: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: asset identifier with ID %s", store.ErrNotFound, id)
+	}
+	return nil
+}
+
+// ListAssetIdentifiers returns the identifiers of an asset by kind, chain
+// and value.
+func (s *MarketDataStore) ListAssetIdentifiers(ctx context.Context, assetID string) ([]*entity.AssetIdentifier, error) {
+	assetInternalID, err := s.getAssetInternalID(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT uuid, kind, chain, value, created_at
+		FROM asset_identifiers
+		WHERE asset_id = $1
+		ORDER BY kind, chain, value`,
+		assetInternalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list asset identifiers: %w", err)
+	}
+	defer rows.Close()
+
+	var identifiers []*entity.AssetIdentifier
+	for rows.Next() {
+		ident := entity.AssetIdentifier{AssetID: assetID}
+		var kind string
+		if err := rows.Scan(&ident.ID, &kind, &ident.Chain, &ident.Value, &ident.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan asset identifier: %w", err)
+		}
+		ident.Kind = stringToIdentifierKind(kind)
+		identifiers = append(identifiers, &ident)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate asset identifiers: %w", err)
+	}
+	return identifiers, nil
+}
+
 // CreatePrice creates a new price record.
 func (s *MarketDataStore) CreatePrice(ctx context.Context, price *entity.StoredPrice) (*entity.StoredPrice, error) {
 	if price == nil {
@@ -330,8 +708,8 @@ func (s *MarketDataStore) CreatePrice(ctx context.Context, price *entity.StoredP
 	}
 
 	query := `
-		INSERT INTO prices (uuid, source_id, asset_id, base_asset_id, interval, decimals, last, open, high, low, close, volume, timestamp)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		INSERT INTO prices (uuid, source_id, asset_id, base_asset_id, interval, decimals, last, open, high, low, close, volume, timestamp, quarantine)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NULLIF($14, ''))
 		RETURNING timestamp`
 
 	err = s.pool.QueryRow(ctx, query,
@@ -348,6 +726,7 @@ func (s *MarketDataStore) CreatePrice(ctx context.Context, price *entity.StoredP
 		price.Close,
 		price.Volume,
 		price.Timestamp,
+		price.Quarantine,
 	).Scan(&price.Timestamp)
 	if err != nil {
 		if isConstraintError(err) {
@@ -398,7 +777,7 @@ func (s *MarketDataStore) GetLatestPrice(ctx context.Context, assetID, baseAsset
 		FROM prices p
 		JOIN assets a ON p.asset_id = a.id
 		JOIN assets ba ON p.base_asset_id = ba.id
-		WHERE p.asset_id = $1 AND p.base_asset_id = $2 %s
+		WHERE p.asset_id = $1 AND p.base_asset_id = $2 AND p.quarantine IS NULL %s
 		ORDER BY p.timestamp DESC
 		LIMIT 1`, sourceFilter)
 
@@ -428,6 +807,63 @@ func (s *MarketDataStore) GetLatestPrice(ctx context.Context, assetID, baseAsset
 	return &price, nil
 }
 
+// GetPriceAt returns the last price of an asset at or before at, in
+// baseAssetID or, if it is empty, in whatever asset it was last priced in.
+func (s *MarketDataStore) GetPriceAt(ctx context.Context, assetID, baseAssetID string, at time.Time) (*entity.StoredPrice, error) {
+	if assetID == "" || at.IsZero() {
+		return nil, fmt.Errorf("%w: asset_id and time are required", store.ErrInvalidArgument)
+	}
+
+	assetInternalID, err := s.getAssetInternalID(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	args := []any{assetInternalID, at}
+	baseFilter := ""
+	if baseAssetID != "" {
+		baseAssetInternalID, err := s.getAssetInternalID(ctx, baseAssetID)
+		if err != nil {
+			return nil, err
+		}
+		baseFilter = "AND p.base_asset_id = $3"
+		args = append(args, baseAssetInternalID)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT p.uuid, p.source_id, a.uuid, ba.uuid, p.interval, p.decimals, p.last, p.open, p.high, p.low, p.close, p.volume, p.timestamp
+		FROM prices p
+		JOIN assets a ON p.asset_id = a.id
+		JOIN assets ba ON p.base_asset_id = ba.id
+		WHERE p.asset_id = $1 AND p.timestamp <= $2 AND p.quarantine IS NULL %s
+		ORDER BY p.timestamp DESC
+		LIMIT 1`, baseFilter)
+
+	var price entity.StoredPrice
+	err = s.pool.QueryRow(ctx, query, args...).Scan(
+		&price.ID,
+		&price.SourceID,
+		&price.AssetID,
+		&price.BaseAssetID,
+		&price.Interval,
+		&price.Decimals,
+		&price.Last,
+		&price.Open,
+		&price.High,
+		&price.Low,
+		&price.Close,
+		&price.Volume,
+		&price.Timestamp,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: price not found", store.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get price: %w", err)
+	}
+
+	return &price, nil
+}
+
 // ListPriceHistory returns prices for an asset/base in a time range with pagination.
 func (s *MarketDataStore) ListPriceHistory(ctx context.Context, opts marketdata.ListPriceHistoryOpts) ([]*entity.StoredPrice, string, error) {
 	if opts.AssetID == "" || opts.BaseAssetID == "" {
@@ -451,6 +887,9 @@ func (s *MarketDataStore) ListPriceHistory(ctx context.Context, opts marketdata.
 	args := []any{assetInternalID, baseAssetInternalID}
 	argIdx := 3
 	whereClauses := []string{"p.asset_id = $1", "p.base_asset_id = $2"}
+	if !opts.IncludeQuarantined {
+		whereClauses = append(whereClauses, "p.quarantine IS NULL")
+	}
 
 	if opts.SourceID != "" {
 		whereClauses = append(whereClauses, fmt.Sprintf("p.source_id = $%d", argIdx))
@@ -458,6 +897,12 @@ func (s *MarketDataStore) ListPriceHistory(ctx context.Context, opts marketdata.
 		argIdx++
 	}
 
+	if opts.Interval != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("p.interval = $%d", argIdx))
+		args = append(args, opts.Interval)
+		argIdx++
+	}
+
 	if opts.From != nil {
 		whereClauses = append(whereClauses, fmt.Sprintf("p.timestamp >= $%d", argIdx))
 		args = append(args, *opts.From)
@@ -484,12 +929,13 @@ func (s *MarketDataStore) ListPriceHistory(ctx context.Context, opts marketdata.
 	}
 
 	query := fmt.Sprintf(`
-		SELECT p.uuid, p.source_id, a.uuid, ba.uuid, p.interval, p.decimals, p.last, p.open, p.high, p.low, p.close, p.volume, p.timestamp
+		SELECT p.uuid, p.source_id, a.uuid, ba.uuid, p.interval, p.decimals, p.last, p.open, p.high, p.low, p.close, p.volume, p.timestamp,
+			COALESCE(p.quarantine, '')
 		FROM prices p
 		JOIN assets a ON p.asset_id = a.id
 		JOIN assets ba ON p.base_asset_id = ba.id
 		WHERE %s
-		ORDER BY p.timestamp
+		ORDER BY p.timestamp, p.source_id
 		LIMIT $%d`,
 		strings.Join(whereClauses, " AND "), argIdx)
 	args = append(args, limit+1)
@@ -517,6 +963,7 @@ func (s *MarketDataStore) ListPriceHistory(ctx context.Context, opts marketdata.
 			&price.Close,
 			&price.Volume,
 			&price.Timestamp,
+			&price.Quarantine,
 		); err != nil {
 			return nil, "", fmt.Errorf("failed to scan price: %w", err)
 		}
@@ -538,6 +985,149 @@ func (s *MarketDataStore) ListPriceHistory(ctx context.Context, opts marketdata.
 	return prices, nextPageToken, nil
 }
 
+// ListDailyCloses returns the last price of each UTC day in the range for
+// each asset, ordered by asset and time.
+func (s *MarketDataStore) ListDailyCloses(ctx context.Context, opts marketdata.DailyClosesOpts) ([]*entity.StoredPrice, error) {
+	if len(opts.AssetIDs) == 0 {
+		return nil, nil
+	}
+	for _, id := range opts.AssetIDs {
+		if !isValidUUID(id) {
+			return nil, fmt.Errorf("%w: invalid asset ID format", store.ErrInvalidArgument)
+		}
+	}
+
+	var baseInternalID int64
+	if opts.BaseAssetID != "" {
+		var err error
+		if baseInternalID, err = s.getAssetInternalID(ctx, opts.BaseAssetID); err != nil {
+			return nil, err
+		}
+	} else {
+		err := s.pool.QueryRow(ctx, `
+			SELECT p.base_asset_id
+			FROM prices p
+			JOIN assets a ON p.asset_id = a.id
+			WHERE a.uuid = $1 AND p.timestamp >= $2 AND p.timestamp < $3 AND p.quarantine IS NULL
+			GROUP BY p.base_asset_id
+			ORDER BY COUNT(*) DESC, p.base_asset_id
+			LIMIT 1`,
+			opts.AssetIDs[0], opts.From, opts.To,
+		).Scan(&baseInternalID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find base asset: %w", err)
+		}
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT DISTINCT ON (p.asset_id, (p.timestamp AT TIME ZONE 'UTC')::date)
+			p.uuid, p.source_id, a.uuid, ba.uuid, p.interval, p.decimals, p.last, p.open, p.high, p.low, p.close, p.volume, p.timestamp
+		FROM prices p
+		JOIN assets a ON p.asset_id = a.id
+		JOIN assets ba ON p.base_asset_id = ba.id
+		WHERE a.uuid = ANY($1::uuid[]) AND p.base_asset_id = $2 AND p.timestamp >= $3 AND p.timestamp < $4
+			AND p.quarantine IS NULL
+		ORDER BY p.asset_id, (p.timestamp AT TIME ZONE 'UTC')::date, p.timestamp DESC, p.source_id`,
+		opts.AssetIDs, baseInternalID, opts.From, opts.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daily closes: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []*entity.StoredPrice
+	for rows.Next() {
+		var price entity.StoredPrice
+		if err := rows.Scan(
+			&price.ID,
+			&price.SourceID,
+			&price.AssetID,
+			&price.BaseAssetID,
+			&price.Interval,
+			&price.Decimals,
+			&price.Last,
+			&price.Open,
+			&price.High,
+			&price.Low,
+			&price.Close,
+			&price.Volume,
+			&price.Timestamp,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan price: %w", err)
+		}
+		prices = append(prices, &price)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate prices: %w", err)
+	}
+
+	return prices, nil
+}
+
+// ListLatestPrices returns the last price of each source of a pair in the
+// range, quarantined or not as opts asks, ordered by source.
+func (s *MarketDataStore) ListLatestPrices(ctx context.Context, opts marketdata.LatestPricesOpts) ([]*entity.StoredPrice, error) {
+	if opts.AssetID == "" || opts.BaseAssetID == "" {
+		return nil, fmt.Errorf("%w: asset_id and base_asset_id are required", store.ErrInvalidArgument)
+	}
+
+	assetInternalID, err := s.getAssetInternalID(ctx, opts.AssetID)
+	if err != nil {
+		return nil, err
+	}
+	baseAssetInternalID, err := s.getAssetInternalID(ctx, opts.BaseAssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT DISTINCT ON (p.source_id)
+			p.uuid, p.source_id, a.uuid, ba.uuid, p.interval, p.decimals, p.last, p.open, p.high, p.low, p.close, p.volume, p.timestamp,
+			COALESCE(p.quarantine, '')
+		FROM prices p
+		JOIN assets a ON p.asset_id = a.id
+		JOIN assets ba ON p.base_asset_id = ba.id
+		WHERE p.asset_id = $1 AND p.base_asset_id = $2 AND p.timestamp >= $3 AND p.timestamp <= $4
+			AND (p.quarantine IS NOT NULL) = $5
+		ORDER BY p.source_id, p.timestamp DESC`,
+		assetInternalID, baseAssetInternalID, opts.From, opts.To, opts.Quarantined)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list latest prices: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []*entity.StoredPrice
+	for rows.Next() {
+		var price entity.StoredPrice
+		if err := rows.Scan(
+			&price.ID,
+			&price.SourceID,
+			&price.AssetID,
+			&price.BaseAssetID,
+			&price.Interval,
+			&price.Decimals,
+			&price.Last,
+			&price.Open,
+			&price.High,
+			&price.Low,
+			&price.Close,
+			&price.Volume,
+			&price.Timestamp,
+			&price.Quarantine,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan price: %w", err)
+		}
+		prices = append(prices, &price)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate prices: %w", err)
+	}
+
+	return prices, nil
+}
+
 // DeletePrice deletes a price record by ID.
 func (s *MarketDataStore) DeletePrice(ctx context.Context, id string) error {
 	if id == "" {
@@ -686,3 +1276,33 @@ func stringToAssetType(s string) entity.AssetType {
 		return entity.AssetTypeUnspecified
 	}
 }
+
+func dayCountToString(d entity.DayCount) string {
+	switch d {
+	case entity.DayCount30360:
+		return "30/360"
+	case entity.DayCountActualActual:
+		return "actual/actual"
+	case entity.DayCountActual360:
+		return "actual/360"
+	case entity.DayCountActual365Fixed:
+		return "actual/365f"
+	default:
+		return ""
+	}
+}
+
+func stringToDayCount(s string) entity.DayCount {
+	switch s {
+	case "30/360":
+		return entity.DayCount30360
+	case "actual/actual":
+		return entity.DayCountActualActual
+	case "actual/360":
+		return entity.DayCountActual360
+	case "actual/365f":
+		return entity.DayCountActual365Fixed
+	default:
+		return entity.DayCountUnspecified
+	}
+}
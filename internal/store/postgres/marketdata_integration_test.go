@@ -14,6 +14,7 @@ import (
 	"github.com/foxcool/greedy-eye/internal/service/marketdata"
 	"github.com/foxcool/greedy-eye/internal/store"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -184,6 +185,54 @@ func TestUpdateAsset(t *testing.T) {
 		assert.Equal(t, entity.AssetTypeStock, res.Type)
 	})
 
+	t.Run("Update metadata", func(t *testing.T) {
+		enrichedAt := time.Now().UTC().Truncate(time.Microsecond)
+		updated := &entity.Asset{
+			ID: asset.ID,
+			Metadata: map[string]entity.AssetMetadata{
+				entity.MetadataWebsite: {Value: "https://example.org", Source: "coingecko", UpdatedAt: enrichedAt},
+			},
+			LastEnrichedAt: &enrichedAt,
+		}
+		res, err := s.UpdateAsset(context.Background(), updated, []string{"metadata", "last_enriched_at"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.org", res.Metadata[entity.MetadataWebsite].Value)
+		assert.Equal(t, "coingecko", res.Metadata[entity.MetadataWebsite].Source)
+		require.NotNil(t, res.LastEnrichedAt)
+		assert.True(t, enrichedAt.Equal(*res.LastEnrichedAt))
+
+		got, err := s.GetAsset(context.Background(), asset.ID)
+		require.NoError(t, err)
+		assert.Equal(t, res.Metadata, got.Metadata)
+	})
+
+	t.Run("Update bond terms", func(t *testing.T) {
+		usd := createTestAsset(t, s, "TestUpdateAssetDollar")
+		terms := &entity.BondTerms{
+			FaceValue:       decimal.RequireFromString("1000"),
+			CurrencyAssetID: usd.ID,
+			CouponRate:      decimal.RequireFromString("0.0425"),
+			CouponFrequency: 2,
+			IssueDate:       time.Date(2024, time.February, 15, 0, 0, 0, 0, time.UTC),
+			MaturityDate:    time.Date(2034, time.February, 15, 0, 0, 0, 0, time.UTC),
+			DayCount:        entity.DayCountActualActual,
+		}
+		res, err := s.UpdateAsset(context.Background(), &entity.Asset{ID: asset.ID, Type: entity.AssetTypeBond, Bond: terms}, []string{"type", "bond"})
+		require.NoError(t, err)
+		require.NotNil(t, res.Bond)
+		assert.True(t, terms.FaceValue.Equal(res.Bond.FaceValue))
+		assert.True(t, terms.CouponRate.Equal(res.Bond.CouponRate))
+		assert.Equal(t, usd.ID, res.Bond.CurrencyAssetID)
+		assert.Equal(t, 2, res.Bond.CouponFrequency)
+		assert.True(t, terms.IssueDate.Equal(res.Bond.IssueDate))
+		assert.True(t, terms.MaturityDate.Equal(res.Bond.MaturityDate))
+		assert.Equal(t, entity.DayCountActualActual, res.Bond.DayCount)
+
+		res, err = s.UpdateAsset(context.Background(), &entity.Asset{ID: asset.ID}, []string{"bond"})
+		require.NoError(t, err)
+		assert.Nil(t, res.Bond)
+	})
+
 	t.Run("Update non-existent asset", func(t *testing.T) {
 		updated := &entity.Asset{
 			ID:   uuid.New().String(),
@@ -244,6 +293,22 @@ func TestListAssets(t *testing.T) {
 		assert.Contains(t, res[0].Tags, "ListAsset2")
 	})
 
+	t.Run("Filter by any tag and type", func(t *testing.T) {
+		res, _, err := s.ListAssets(context.Background(), marketdata.ListAssetsOpts{
+			AnyTags: []string{"ListAsset1", "ListAsset3"},
+			Type:    entity.AssetTypeCryptocurrency,
+		})
+		require.NoError(t, err)
+		assert.Len(t, res, 2)
+
+		res, _, err = s.ListAssets(context.Background(), marketdata.ListAssetsOpts{
+			AnyTags: []string{"ListAsset1"},
+			Type:    entity.AssetTypeStock,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
 	t.Run("Pagination", func(t *testing.T) {
 		res, nextToken, err := s.ListAssets(context.Background(), marketdata.ListAssetsOpts{
 			PageSize: 2,
@@ -262,6 +327,165 @@ func TestListAssets(t *testing.T) {
 	})
 }
 
+func TestAssetIdentifiers(t *testing.T) {
+	pool := getTestPool(t)
+	s := NewMarketDataStore(pool)
+	eth := createTestAsset(t, s, "IdentEthToken")
+	poly := createTestAsset(t, s, "IdentPolyToken")
+	address := "0x" + strings.ReplaceAll(uuid.NewString(), "-", "") + "00000000"
+	isin := uuid.NewString()[:12]
+
+	created, err := s.CreateAssetIdentifier(context.Background(), &entity.AssetIdentifier{
+		AssetID: eth.ID, Kind: entity.IdentifierKindContract, Chain: "ethereum", Value: address,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.ID)
+	assert.NotZero(t, created.CreatedAt)
+	_, err = s.CreateAssetIdentifier(context.Background(), &entity.AssetIdentifier{
+		AssetID: eth.ID, Kind: entity.IdentifierKindISIN, Value: isin,
+	})
+	require.NoError(t, err)
+	_, err = s.CreateAssetIdentifier(context.Background(), &entity.AssetIdentifier{
+		AssetID: poly.ID, Kind: entity.IdentifierKindContract, Chain: "polygon-pos", Value: address,
+	})
+	require.NoError(t, err)
+
+	t.Run("Identifier maps to one asset", func(t *testing.T) {
+		_, err := s.CreateAssetIdentifier(context.Background(), &entity.AssetIdentifier{
+			AssetID: poly.ID, Kind: entity.IdentifierKindISIN, Value: isin,
+		})
+		assert.ErrorIs(t, err, store.ErrConstraint)
+	})
+
+	t.Run("List identifiers", func(t *testing.T) {
+		identifiers, err := s.ListAssetIdentifiers(context.Background(), eth.ID)
+		require.NoError(t, err)
+		require.Len(t, identifiers, 2)
+		assert.Equal(t, entity.IdentifierKindContract, identifiers[0].Kind)
+		assert.Equal(t, "ethereum", identifiers[0].Chain)
+		assert.Equal(t, entity.IdentifierKindISIN, identifiers[1].Kind)
+	})
+
+	t.Run("Filter assets by identifier", func(t *testing.T) {
+		res, _, err := s.ListAssets(context.Background(), marketdata.ListAssetsOpts{
+			Identifier: &entity.AssetIdentifier{Kind: entity.IdentifierKindContract, Value: address},
+		})
+		require.NoError(t, err)
+		assert.Len(t, res, 2)
+
+		res, _, err = s.ListAssets(context.Background(), marketdata.ListAssetsOpts{
+			Identifier: &entity.AssetIdentifier{Kind: entity.IdentifierKindContract, Chain: "polygon-pos", Value: address},
+		})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, poly.ID, res[0].ID)
+	})
+
+	t.Run("Delete identifier", func(t *testing.T) {
+		require.NoError(t, s.DeleteAssetIdentifier(context.Background(), created.ID))
+		assert.ErrorIs(t, s.DeleteAssetIdentifier(context.Background(), created.ID), store.ErrNotFound)
+	})
+
+	t.Run("Identifiers go with their asset", func(t *testing.T) {
+		require.NoError(t, s.DeleteAsset(context.Background(), poly.ID))
+		res, _, err := s.ListAssets(context.Background(), marketdata.ListAssetsOpts{
+			Identifier: &entity.AssetIdentifier{Kind: entity.IdentifierKindContract, Value: address},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestSearchAssets(t *testing.T) {
+	pool := getTestPool(t)
+	s := NewMarketDataStore(pool)
+	// Unique words keep other tests' assets out of the results.
+	word := "Srch" + strings.ReplaceAll(uuid.NewString(), "-", "")[:8]
+	exact := createTestAsset(t, s, word)
+	prefixed := createTestAsset(t, s, word+"Cash")
+	named := createTestAsset(t, s, "Wrapped "+word)
+	stock, err := s.CreateAsset(context.Background(), &entity.Asset{
+		Symbol: "SRCHSTK", Name: word + " Holdings", Type: entity.AssetTypeStock,
+	})
+	require.NoError(t, err)
+	isin := strings.ToUpper(strings.ReplaceAll(uuid.NewString(), "-", "")[:12])
+	_, err = s.CreateAssetIdentifier(context.Background(), &entity.AssetIdentifier{
+		AssetID: stock.ID, Kind: entity.IdentifierKindISIN, Value: isin,
+	})
+	require.NoError(t, err)
+
+	ids := func(assets []*entity.Asset) []string {
+		var ids []string
+		for _, a := range assets {
+			ids = append(ids, a.ID)
+		}
+		return ids
+	}
+
+	t.Run("Exact symbol first", func(t *testing.T) {
+		res, _, err := s.SearchAssets(context.Background(), marketdata.SearchAssetsOpts{Query: strings.ToLower(word)})
+		require.NoError(t, err)
+		require.Len(t, res, 4)
+		assert.Equal(t, []string{exact.ID, prefixed.ID}, ids(res[:2]))
+		assert.ElementsMatch(t, []string{named.ID, stock.ID}, ids(res[2:]))
+	})
+
+	t.Run("Identifier", func(t *testing.T) {
+		res, _, err := s.SearchAssets(context.Background(), marketdata.SearchAssetsOpts{Query: strings.ToLower(isin)})
+		require.NoError(t, err)
+		assert.Equal(t, []string{stock.ID}, ids(res))
+	})
+
+	t.Run("Name prefix", func(t *testing.T) {
+		res, _, err := s.SearchAssets(context.Background(), marketdata.SearchAssetsOpts{Query: "wrapp " + word[:6]})
+		require.NoError(t, err)
+		assert.Equal(t, []string{named.ID}, ids(res))
+	})
+
+	t.Run("Misspelled", func(t *testing.T) {
+		// Swapped letters match no prefix, so assets are ranked by similarity.
+		typo := word[:len(word)-2] + word[len(word)-1:] + word[len(word)-2:len(word)-1]
+		res, _, err := s.SearchAssets(context.Background(), marketdata.SearchAssetsOpts{Query: typo})
+		require.NoError(t, err)
+		assert.Empty(t, res)
+
+		res, _, err = s.SearchAssets(context.Background(), marketdata.SearchAssetsOpts{Query: typo, Similar: true})
+		require.NoError(t, err)
+		require.NotEmpty(t, res)
+		assert.Equal(t, exact.ID, res[0].ID)
+
+		res, _, err = s.SearchAssets(context.Background(), marketdata.SearchAssetsOpts{Query: typo, Type: entity.AssetTypeStock, Similar: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{stock.ID}, ids(res))
+	})
+
+	t.Run("Filter by type and tags", func(t *testing.T) {
+		res, _, err := s.SearchAssets(context.Background(), marketdata.SearchAssetsOpts{Query: word, Type: entity.AssetTypeStock})
+		require.NoError(t, err)
+		assert.Equal(t, []string{stock.ID}, ids(res))
+
+		res, _, err = s.SearchAssets(context.Background(), marketdata.SearchAssetsOpts{Query: word, Tags: []string{word + "Cash"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{prefixed.ID}, ids(res))
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		res, nextToken, err := s.SearchAssets(context.Background(), marketdata.SearchAssetsOpts{Query: word, PageSize: 3})
+		require.NoError(t, err)
+		assert.Len(t, res, 3)
+		require.NotEmpty(t, nextToken)
+
+		res2, nextToken, err := s.SearchAssets(context.Background(), marketdata.SearchAssetsOpts{Query: word, PageSize: 3, PageToken: nextToken})
+		require.NoError(t, err)
+		assert.Len(t, res2, 1)
+		assert.Empty(t, nextToken)
+		assert.NotContains(t, ids(res), res2[0].ID)
+
+		_, _, err = s.SearchAssets(context.Background(), marketdata.SearchAssetsOpts{Query: word, PageToken: "bogus"})
+		assert.ErrorIs(t, err, store.ErrInvalidArgument)
+	})
+}
+
 func TestCreatePrice(t *testing.T) {
 	pool := getTestPool(t)
 	s := NewMarketDataStore(pool)
@@ -343,6 +567,45 @@ func TestGetLatestPrice(t *testing.T) {
 	})
 }
 
+func TestGetPriceAt(t *testing.T) {
+	pool := getTestPool(t)
+	s := NewMarketDataStore(pool)
+	usd := createTestAsset(t, s, "PriceAtDollar")
+	eur := createTestAsset(t, s, "PriceAtEuro")
+	gbp := createTestAsset(t, s, "PriceAtPound")
+
+	day := func(d int) time.Time { return time.Date(2025, time.April, d, 14, 0, 0, 0, time.UTC) }
+	for _, p := range []*entity.StoredPrice{
+		{SourceID: "ecb", AssetID: usd.ID, BaseAssetID: eur.ID, Interval: "1d", Decimals: 4, Last: 8801, Timestamp: day(14)},
+		{SourceID: "ecb", AssetID: usd.ID, BaseAssetID: eur.ID, Interval: "1d", Decimals: 4, Last: 8861, Timestamp: day(15)},
+		{SourceID: "other", AssetID: usd.ID, BaseAssetID: gbp.ID, Interval: "1d", Decimals: 4, Last: 7500, Timestamp: day(16)},
+	} {
+		_, err := s.CreatePrice(context.Background(), p)
+		require.NoError(t, err)
+	}
+
+	t.Run("Last price before the time", func(t *testing.T) {
+		res, err := s.GetPriceAt(context.Background(), usd.ID, eur.ID, day(15).Add(-time.Minute))
+		require.NoError(t, err)
+		assert.EqualValues(t, 8801, res.Last)
+
+		res, err = s.GetPriceAt(context.Background(), usd.ID, eur.ID, day(20))
+		require.NoError(t, err)
+		assert.EqualValues(t, 8861, res.Last)
+	})
+
+	t.Run("Any base", func(t *testing.T) {
+		res, err := s.GetPriceAt(context.Background(), usd.ID, "", day(20))
+		require.NoError(t, err)
+		assert.Equal(t, gbp.ID, res.BaseAssetID)
+	})
+
+	t.Run("Nothing before the time", func(t *testing.T) {
+		_, err := s.GetPriceAt(context.Background(), usd.ID, eur.ID, day(1))
+		assert.ErrorIs(t, err, store.ErrNotFound)
+	})
+}
+
 func TestListPriceHistory(t *testing.T) {
 	pool := getTestPool(t)
 	s := NewMarketDataStore(pool)
@@ -398,6 +661,67 @@ func TestListPriceHistory(t *testing.T) {
 	})
 }
 
+func TestListDailyCloses(t *testing.T) {
+	pool := getTestPool(t)
+	s := NewMarketDataStore(pool)
+	asset := createTestAsset(t, s, "DailyAsset")
+	other := createTestAsset(t, s, "DailyOtherAsset")
+	usd := createTestAsset(t, s, "DailyUSD")
+	eur := createTestAsset(t, s, "DailyEUR")
+
+	day := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -3)
+	addPrice := func(assetID, baseAssetID string, at time.Time, last int64) {
+		_, err := s.CreatePrice(context.Background(), &entity.StoredPrice{
+			SourceID: "exchange", AssetID: assetID, BaseAssetID: baseAssetID,
+			Interval: "latest", Decimals: 2, Last: last, Timestamp: at,
+		})
+		require.NoError(t, err)
+	}
+	addPrice(asset.ID, usd.ID, day.Add(time.Hour), 100)
+	addPrice(asset.ID, usd.ID, day.Add(20*time.Hour), 110)
+	addPrice(asset.ID, usd.ID, day.AddDate(0, 0, 1).Add(time.Hour), 120)
+	addPrice(asset.ID, eur.ID, day.Add(time.Hour), 90)
+	addPrice(other.ID, usd.ID, day.Add(2*time.Hour), 50)
+
+	t.Run("Last price a day in the most used base", func(t *testing.T) {
+		res, err := s.ListDailyCloses(context.Background(), marketdata.DailyClosesOpts{
+			AssetIDs: []string{asset.ID, other.ID},
+			From:     day,
+			To:       day.AddDate(0, 0, 3),
+		})
+		require.NoError(t, err)
+		require.Len(t, res, 3)
+		var lasts []int64
+		for _, p := range res {
+			assert.Equal(t, usd.ID, p.BaseAssetID)
+			lasts = append(lasts, p.Last)
+		}
+		assert.ElementsMatch(t, []int64{110, 120, 50}, lasts)
+	})
+
+	t.Run("Given base", func(t *testing.T) {
+		res, err := s.ListDailyCloses(context.Background(), marketdata.DailyClosesOpts{
+			AssetIDs:    []string{asset.ID, other.ID},
+			BaseAssetID: eur.ID,
+			From:        day,
+			To:          day.AddDate(0, 0, 3),
+		})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.EqualValues(t, 90, res[0].Last)
+	})
+
+	t.Run("No prices", func(t *testing.T) {
+		res, err := s.ListDailyCloses(context.Background(), marketdata.DailyClosesOpts{
+			AssetIDs: []string{usd.ID},
+			From:     day,
+			To:       day.AddDate(0, 0, 3),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+}
+
 func TestDeletePrice(t *testing.T) {
 	pool := getTestPool(t)
 	s := NewMarketDataStore(pool)
@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/foxcool/greedy-eye/internal/audit"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/portfolio"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// RedeemHolding applies a bond redemption in one database transaction. The
+// holding's row is locked first, so concurrent runs redeem it once.
+func (s *PortfolioStore) RedeemHolding(ctx context.Context, r *portfolio.Redemption) error {
+	if r == nil || r.HoldingID == "" || r.CashAssetID == "" {
+		return fmt.Errorf("%w: holding and cash asset are required", store.ErrInvalidArgument)
+	}
+	if !isValidUUID(r.HoldingID) {
+		return fmt.Errorf("%w: invalid holding ID format", store.ErrInvalidArgument)
+	}
+	cashAssetID, err := s.getAssetInternalID(ctx, r.CashAssetID)
+	if err != nil {
+		return err
+	}
+
+	holdingBefore := auditBefore(ctx, s.GetHolding, r.HoldingID)
+	lotsBefore := make(map[string]*entity.Lot, len(r.LotIDs))
+	for _, id := range r.LotIDs {
+		lotsBefore[id] = auditBefore(ctx, s.GetLot, id)
+	}
+
+	dbTx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	var holdingID, accountID int64
+	var portfolioID *int64
+	var amount int64
+	filter, args := holdingFilter(ctx, holdingOwner, "portfolio_id", entity.PortfolioRoleEditor, []any{r.HoldingID})
+	err = dbTx.QueryRow(ctx, `
+		SELECT id, account_id, portfolio_id, amount FROM holdings
+		WHERE uuid = $1 AND `+filter+`
+		FOR UPDATE`, args...).Scan(&holdingID, &accountID, &portfolioID, &amount)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: holding with ID %s", store.ErrNotFound, r.HoldingID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock holding: %w", err)
+	}
+	if amount <= 0 {
+		return nil
+	}
+
+	if _, err := dbTx.Exec(ctx, `
+		UPDATE lots SET amount = 0, cost_basis = 0, updated_at = NOW()
+		WHERE holding_id = $1 AND uuid = ANY($2)`, holdingID, r.LotIDs); err != nil {
+		return fmt.Errorf("failed to empty lots: %w", err)
+	}
+	if _, err := dbTx.Exec(ctx, "UPDATE holdings SET amount = 0, updated_at = NOW() WHERE id = $1", holdingID); err != nil {
+		return fmt.Errorf("failed to empty holding: %w", err)
+	}
+
+	created, err := s.insertNewTransactions(ctx, dbTx, r.Transactions)
+	if err != nil {
+		return err
+	}
+
+	cash, err := s.creditHolding(ctx, dbTx, accountID, portfolioID, cashAssetID, r.Cash)
+	if err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit redemption: %w", err)
+	}
+
+	if !audit.Enabled(ctx) {
+		return nil
+	}
+	audit.RecordChange(ctx, "holding", r.HoldingID, holdingBefore, auditBefore(ctx, s.GetHolding, r.HoldingID))
+	for _, id := range r.LotIDs {
+		audit.RecordChange(ctx, "lot", id, lotsBefore[id], auditBefore(ctx, s.GetLot, id))
+	}
+	for _, t := range created {
+		audit.RecordChange(ctx, "transaction", t.ID, nil, t)
+	}
+	if after := auditBefore(ctx, s.GetHolding, cash.id); after != nil {
+		var before *entity.Holding
+		if !cash.created {
+			copied := *after
+			copied.Amount, copied.Decimals = cash.amount, cash.decimals
+			before = &copied
+		}
+		audit.RecordChange(ctx, "holding", cash.id, before, after)
+	}
+	return nil
+}
+
+// credit is a holding credited by creditHolding, with its amount before.
+type credit struct {
+	id       string
+	created  bool
+	amount   int64
+	decimals uint32
+}
+
+// creditHolding adds amount to the account's holding of an asset in a
+// portfolio within dbTx, creating the holding if there is none.
+func (s *PortfolioStore) creditHolding(ctx context.Context, dbTx pgx.Tx, accountID int64, portfolioID *int64, assetID int64, amount decimal.Decimal) (credit, error) {
+	decimals := uint32(max(-amount.Exponent(), 0))
+
+	var id int64
+	var c credit
+	err := dbTx.QueryRow(ctx, `
+		SELECT id, uuid, amount, decimals FROM holdings
+		WHERE account_id = $1 AND asset_id = $2 AND portfolio_id IS NOT DISTINCT FROM $3
+		ORDER BY id
+		LIMIT 1
+		FOR UPDATE`, accountID, assetID, portfolioID).Scan(&id, &c.id, &c.amount, &c.decimals)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c = credit{id: uuid.New().String(), created: true}
+		if _, err := dbTx.Exec(ctx, `
+			INSERT INTO holdings (uuid, amount, decimals, asset_id, account_id, portfolio_id, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())`,
+			c.id, amount.Shift(int32(decimals)).IntPart(), decimals, assetID, accountID, portfolioID); err != nil {
+			return credit{}, fmt.Errorf("failed to create cash holding: %w", err)
+		}
+		return c, nil
+	}
+	if err != nil {
+		return credit{}, fmt.Errorf("failed to lock cash holding: %w", err)
+	}
+
+	total := decimal.New(c.amount, -int32(c.decimals)).Add(amount)
+	decimals = max(decimals, c.decimals)
+	if _, err := dbTx.Exec(ctx, "UPDATE holdings SET amount = $2, decimals = $3, updated_at = NOW() WHERE id = $1",
+		id, total.Shift(int32(decimals)).IntPart(), decimals); err != nil {
+		return credit{}, fmt.Errorf("failed to credit cash holding: %w", err)
+	}
+	return c, nil
+}
+
+// insertNewTransactions creates txs within dbTx, skipping those whose
+// external ID their account already has, and returns those it created.
+func (s *PortfolioStore) insertNewTransactions(ctx context.Context, dbTx pgx.Tx, txs []*entity.Transaction) ([]*entity.Transaction, error) {
+	assetIDs := make(map[string]int64)
+	for _, t := range txs {
+		if _, ok := assetIDs[t.AssetID]; ok || t.AssetID == "" {
+			continue
+		}
+		id, err := s.getAssetInternalID(ctx, t.AssetID)
+		if err != nil {
+			return nil, err
+		}
+		assetIDs[t.AssetID] = id
+	}
+
+	var created []*entity.Transaction
+	for _, t := range txs {
+		var assetID *int64
+		if id, ok := assetIDs[t.AssetID]; ok {
+			assetID = &id
+		}
+		dataJSON, err := json.Marshal(t.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal data: %w", err)
+		}
+		id := uuid.New().String()
+		err = dbTx.QueryRow(ctx, `
+			INSERT INTO transactions (uuid, type, status, account_id, asset_transactions, data, external_id, created_at, updated_at)
+			VALUES ($1, $2, $3, (SELECT id FROM accounts WHERE uuid = $4), $5, $6, $7, NOW(), NOW())
+			ON CONFLICT (account_id, external_id) DO NOTHING
+			RETURNING created_at, updated_at`,
+			id, transactionTypeToString(t.Type), transactionStatusToString(t.Status),
+			t.AccountID, assetID, dataJSON, nullableString(t.ExternalID),
+		).Scan(&t.CreatedAt, &t.UpdatedAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create transaction: %w", err)
+		}
+		t.ID = id
+		created = append(created, t)
+	}
+	return created, nil
+}
@@ -0,0 +1,102 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/secrets"
+	"github.com/foxcool/greedy-eye/internal/service/portfolio"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedeemHolding(t *testing.T) {
+	pool := getTestPool(t)
+	ctx := context.Background()
+	s := NewMarketDataStore(pool)
+	keys, err := secrets.NewKeyring(map[uint32][]byte{1: make([]byte, 32)})
+	require.NoError(t, err)
+	portfolios := NewPortfolioStore(pool, keys)
+
+	usd := createTestAsset(t, s, "Dollar")
+	corp := createTestAsset(t, s, "Corp 2025")
+	user, err := NewSettingsStore(pool).CreateUser(ctx, &entity.User{Email: "alice@example.com", Name: "Alice"})
+	require.NoError(t, err)
+	account, err := portfolios.CreateAccount(ctx, &entity.Account{UserID: user.ID, Name: "Broker", Type: entity.AccountTypeBroker})
+	require.NoError(t, err)
+	bond, err := portfolios.CreateHolding(ctx, &entity.Holding{Amount: 3, AssetID: corp.ID, AccountID: account.ID})
+	require.NoError(t, err)
+	cash, err := portfolios.CreateHolding(ctx, &entity.Holding{Amount: 50000, Decimals: 2, AssetID: usd.ID, AccountID: account.ID})
+	require.NoError(t, err)
+	lot, err := portfolios.CreateLot(ctx, &entity.Lot{
+		HoldingID: bond.ID, Amount: 3, CostBasis: 2900, CostAssetID: usd.ID,
+		AcquiredAt: time.Date(2023, time.January, 10, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	redemption := func() *portfolio.Redemption {
+		return &portfolio.Redemption{
+			HoldingID: bond.ID,
+			LotIDs:    []string{lot.ID},
+			Transactions: []*entity.Transaction{
+				{
+					Type: entity.TransactionTypeTrade, Status: entity.TransactionStatusCompleted,
+					AccountID: account.ID, AssetID: corp.ID, ExternalID: "bond-maturity:" + lot.ID,
+					Data: map[string]string{"side": "sell", "amount": "3"},
+				},
+				{
+					Type: entity.TransactionTypeIncome, Status: entity.TransactionStatusCompleted,
+					AccountID: account.ID, AssetID: usd.ID, ExternalID: "bond-maturity:" + bond.ID + ":coupon",
+					Data: map[string]string{"income": "interest", "amount": "60"},
+				},
+			},
+			CashAssetID: usd.ID,
+			Cash:        decimal.RequireFromString("3060"),
+		}
+	}
+	require.NoError(t, portfolios.RedeemHolding(ctx, redemption()))
+
+	got, err := portfolios.GetHolding(ctx, bond.ID)
+	require.NoError(t, err)
+	assert.Zero(t, got.Amount)
+	gotLot, err := portfolios.GetLot(ctx, lot.ID)
+	require.NoError(t, err)
+	assert.Zero(t, gotLot.Amount)
+	assert.Zero(t, gotLot.CostBasis)
+	got, err = portfolios.GetHolding(ctx, cash.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "3560", decimal.New(got.Amount, -int32(got.Decimals)).String())
+	txs, _, err := portfolios.ListTransactions(ctx, portfolio.ListTransactionsOpts{AccountID: account.ID})
+	require.NoError(t, err)
+	assert.Len(t, txs, 2)
+
+	t.Run("Redeemed once", func(t *testing.T) {
+		require.NoError(t, portfolios.RedeemHolding(ctx, redemption()))
+		got, err := portfolios.GetHolding(ctx, cash.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "3560", decimal.New(got.Amount, -int32(got.Decimals)).String())
+		txs, _, err := portfolios.ListTransactions(ctx, portfolio.ListTransactionsOpts{AccountID: account.ID})
+		require.NoError(t, err)
+		assert.Len(t, txs, 2)
+	})
+
+	t.Run("New cash holding", func(t *testing.T) {
+		other, err := portfolios.CreateAccount(ctx, &entity.Account{UserID: user.ID, Name: "Bank", Type: entity.AccountTypeBank})
+		require.NoError(t, err)
+		held, err := portfolios.CreateHolding(ctx, &entity.Holding{Amount: 1, AssetID: corp.ID, AccountID: other.ID})
+		require.NoError(t, err)
+		require.NoError(t, portfolios.RedeemHolding(ctx, &portfolio.Redemption{
+			HoldingID: held.ID, CashAssetID: usd.ID, Cash: decimal.RequireFromString("1020"),
+		}))
+
+		holdings, _, err := portfolios.ListHoldings(ctx, portfolio.ListHoldingsOpts{AccountID: other.ID, AssetID: usd.ID})
+		require.NoError(t, err)
+		require.Len(t, holdings, 1)
+		assert.Equal(t, int64(1020), holdings[0].Amount)
+	})
+}
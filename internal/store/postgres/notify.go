@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/foxcool/greedy-eye/internal/pubsub"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// maxNotifyPayload is the largest payload Postgres accepts in NOTIFY.
+const maxNotifyPayload = 8000
+
+// NotifyBroker relays events between replicas with LISTEN/NOTIFY.
+type NotifyBroker struct {
+	pool    *pgxpool.Pool
+	channel string
+}
+
+// Compile-time interface implementation check.
+var _ pubsub.Broker = (*NotifyBroker)(nil)
+
+func NewNotifyBroker(pool *pgxpool.Pool, channel string) *NotifyBroker {
+	return &NotifyBroker{pool: pool, channel: channel}
+}
+
+func (b *NotifyBroker) Publish(ctx context.Context, msg []byte) error {
+	if len(msg) >= maxNotifyPayload {
+		return fmt.Errorf("%w: event of %d bytes exceeds the NOTIFY payload limit", store.ErrInvalidArgument, len(msg))
+	}
+
+	if _, err := b.pool.Exec(ctx, "SELECT pg_notify($1, $2)", b.channel, string(msg)); err != nil {
+		return fmt.Errorf("failed to notify: %w", err)
+	}
+	return nil
+}
+
+// Listen holds a pooled connection for the lifetime of the subscription.
+func (b *NotifyBroker) Listen(ctx context.Context, handle func(msg []byte)) error {
+	conn, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	// The connection is still subscribed; drop it rather than return it to the pool.
+	defer func() {
+		_ = conn.Conn().Close(context.Background())
+		conn.Release()
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{b.channel}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for notification: %w", err)
+		}
+		handle([]byte(n.Payload))
+	}
+}
@@ -7,8 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/foxcool/greedy-eye/internal/audit"
 	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/secrets"
 	"github.com/foxcool/greedy-eye/internal/service/portfolio"
 	"github.com/foxcool/greedy-eye/internal/store"
 	"github.com/google/uuid"
@@ -19,13 +22,16 @@ import (
 // PortfolioStore implements portfolio.Store using PostgreSQL.
 type PortfolioStore struct {
 	pool *pgxpool.Pool
+	keys *secrets.Keyring
 }
 
 // Compile-time interface implementation check.
 var _ portfolio.Store = (*PortfolioStore)(nil)
 
-func NewPortfolioStore(pool *pgxpool.Pool) *PortfolioStore {
-	return &PortfolioStore{pool: pool}
+// NewPortfolioStore returns a store that encrypts account secrets with keys.
+// Without keys, accounts cannot hold secrets.
+func NewPortfolioStore(pool *pgxpool.Pool, keys *secrets.Keyring) *PortfolioStore {
+	return &PortfolioStore{pool: pool, keys: keys}
 }
 
 // --- Portfolio methods ---
@@ -72,6 +78,7 @@ func (s *PortfolioStore) CreatePortfolio(ctx context.Context, p *entity.Portfoli
 		return nil, fmt.Errorf("failed to create portfolio: %w", err)
 	}
 
+	audit.RecordChange(ctx, "portfolio", p.ID, nil, p)
 	return p, nil
 }
 
@@ -83,17 +90,18 @@ func (s *PortfolioStore) GetPortfolio(ctx context.Context, id string) (*entity.P
 		return nil, fmt.Errorf("%w: invalid portfolio ID format", store.ErrInvalidArgument)
 	}
 
+	filter, args := portfolioFilter(ctx, "p.id", entity.PortfolioRoleViewer, []any{id})
 	query := `
 		SELECT p.uuid, u.uuid, p.name, p.description, p.data, p.created_at, p.updated_at
 		FROM portfolios p
 		JOIN users u ON p.user_id = u.id
-		WHERE p.uuid = $1`
+		WHERE p.uuid = $1 AND ` + filter
 
 	var p entity.Portfolio
 	var description *string
 	var dataJSON []byte
 
-	err := s.pool.QueryRow(ctx, query, id).Scan(
+	err := s.pool.QueryRow(ctx, query, args...).Scan(
 		&p.ID,
 		&p.UserID,
 		&p.Name,
@@ -152,12 +160,14 @@ func (s *PortfolioStore) UpdatePortfolio(ctx context.Context, p *entity.Portfoli
 		}
 	}
 
+	before := auditBefore(ctx, s.GetPortfolio, p.ID)
+	filter, args := portfolioFilter(ctx, "id", entity.PortfolioRoleEditor, args)
 	query := fmt.Sprintf(`
 		UPDATE portfolios
 		SET %s
-		WHERE uuid = $1
+		WHERE uuid = $1 AND %s
 		RETURNING uuid, name, description, data, created_at, updated_at`,
-		strings.Join(setClauses, ", "))
+		strings.Join(setClauses, ", "), filter)
 
 	var result entity.Portfolio
 	var description *string
@@ -174,7 +184,8 @@ func (s *PortfolioStore) UpdatePortfolio(ctx context.Context, p *entity.Portfoli
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("%w: portfolio with ID %s", store.ErrNotFound, p.ID)
+			return nil, deniedOrNotFound(ctx, s.GetPortfolio, p.ID,
+				fmt.Errorf("%w: portfolio with ID %s", store.ErrNotFound, p.ID))
 		}
 		return nil, fmt.Errorf("failed to update portfolio: %w", err)
 	}
@@ -193,6 +204,7 @@ func (s *PortfolioStore) UpdatePortfolio(ctx context.Context, p *entity.Portfoli
 	}
 	result.UserID = full.UserID
 
+	audit.RecordChange(ctx, "portfolio", result.ID, before, &result)
 	return &result, nil
 }
 
@@ -204,7 +216,9 @@ func (s *PortfolioStore) DeletePortfolio(ctx context.Context, id string) error {
 		return fmt.Errorf("%w: invalid portfolio ID format", store.ErrInvalidArgument)
 	}
 
-	result, err := s.pool.Exec(ctx, "DELETE FROM portfolios WHERE uuid = $1", id)
+	before := auditBefore(ctx, s.GetPortfolio, id)
+	filter, args := portfolioFilter(ctx, "id", entity.PortfolioRoleOwner, []any{id})
+	result, err := s.pool.Exec(ctx, "DELETE FROM portfolios WHERE uuid = $1 AND "+filter, args...)
 	if err != nil {
 		if isConstraintError(err) {
 			return fmt.Errorf("%w: cannot delete portfolio due to existing dependencies", store.ErrConstraint)
@@ -213,9 +227,10 @@ func (s *PortfolioStore) DeletePortfolio(ctx context.Context, id string) error {
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("%w: portfolio with ID %s", store.ErrNotFound, id)
+		return deniedOrNotFound(ctx, s.GetPortfolio, id, fmt.Errorf("%w: portfolio with ID %s", store.ErrNotFound, id))
 	}
 
+	audit.RecordChange(ctx, "portfolio", id, before, nil)
 	return nil
 }
 
@@ -239,6 +254,11 @@ func (s *PortfolioStore) ListPortfolios(ctx context.Context, opts portfolio.List
 		argIdx++
 	}
 
+	var filter string
+	filter, args = portfolioFilter(ctx, "p.id", entity.PortfolioRoleViewer, args)
+	whereClauses = append(whereClauses, filter)
+	argIdx = len(args) + 1
+
 	if opts.PageToken != "" {
 		decoded, err := base64.StdEncoding.DecodeString(opts.PageToken)
 		if err == nil && isValidUUID(string(decoded)) {
@@ -307,6 +327,258 @@ func (s *PortfolioStore) ListPortfolios(ctx context.Context, opts portfolio.List
 	return portfolios, nextPageToken, nil
 }
 
+// --- Portfolio member methods ---
+
+// InvitePortfolioMember invites the user with m.Email to the portfolio as a
+// pending member. Inviting an existing member changes their role instead.
+func (s *PortfolioStore) InvitePortfolioMember(ctx context.Context, m *entity.PortfolioMember) (*entity.PortfolioMember, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%w: portfolio member is required", store.ErrInvalidArgument)
+	}
+	if m.Email == "" {
+		return nil, fmt.Errorf("%w: email is required", store.ErrInvalidArgument)
+	}
+	if m.Role < entity.PortfolioRoleViewer || m.Role > entity.PortfolioRoleOwner {
+		return nil, fmt.Errorf("%w: role is required", store.ErrInvalidArgument)
+	}
+
+	portfolioInternalID, err := s.getPortfolioInternalID(ctx, m.PortfolioID, entity.PortfolioRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invitees are looked up across tenants: the caller names them by email.
+	var userInternalID, creatorID int64
+	err = s.pool.QueryRow(ctx, `
+		SELECT u.id, p.user_id
+		FROM users u, portfolios p
+		WHERE u.email = $1 AND p.id = $2`,
+		m.Email, portfolioInternalID).Scan(&userInternalID, &creatorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: user with email %s", store.ErrNotFound, m.Email)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if userInternalID == creatorID {
+		return nil, fmt.Errorf("%w: the portfolio's creator is already its owner", store.ErrInvalidArgument)
+	}
+
+	before, err := s.getPortfolioMember(ctx, portfolioInternalID, userInternalID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO portfolio_members (uuid, portfolio_id, user_id, role, status, invited_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, (SELECT id FROM users WHERE uuid = $6), NOW(), NOW())
+		ON CONFLICT (portfolio_id, user_id) DO UPDATE
+		SET role = EXCLUDED.role, invited_by = EXCLUDED.invited_by, updated_at = NOW()`,
+		uuid.New().String(),
+		portfolioInternalID,
+		userInternalID,
+		portfolioRoleToString(m.Role),
+		portfolioMemberStatusToString(entity.PortfolioMemberStatusPending),
+		nullableString(tenantUserID(ctx)),
+	)
+	if err != nil {
+		if isConstraintError(err) {
+			return nil, fmt.Errorf("%w: %v", store.ErrConstraint, err)
+		}
+		return nil, fmt.Errorf("failed to invite portfolio member: %w", err)
+	}
+
+	result, err := s.getPortfolioMember(ctx, portfolioInternalID, userInternalID)
+	if err != nil {
+		return nil, err
+	}
+	audit.RecordChange(ctx, "portfolio_member", result.ID, before, result)
+	return result, nil
+}
+
+// AcceptPortfolioInvitation activates the user's membership of the portfolio.
+func (s *PortfolioStore) AcceptPortfolioInvitation(ctx context.Context, portfolioID, userID string) (*entity.PortfolioMember, error) {
+	if !isValidUUID(portfolioID) {
+		return nil, fmt.Errorf("%w: invalid portfolio ID format", store.ErrInvalidArgument)
+	}
+	userInternalID, err := s.getUserInternalID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invitees cannot view the portfolio until they accept, so it is looked
+	// up through the invitation itself.
+	var portfolioInternalID int64
+	err = s.pool.QueryRow(ctx, `
+		SELECT pm.portfolio_id
+		FROM portfolio_members pm
+		JOIN portfolios p ON p.id = pm.portfolio_id
+		WHERE p.uuid = $1 AND pm.user_id = $2`,
+		portfolioID, userInternalID).Scan(&portfolioInternalID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: invitation to portfolio %s", store.ErrNotFound, portfolioID)
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	before, err := s.getPortfolioMember(ctx, portfolioInternalID, userInternalID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.pool.Exec(ctx, `
+		UPDATE portfolio_members
+		SET status = $3, updated_at = NOW()
+		WHERE portfolio_id = $1 AND user_id = $2`,
+		portfolioInternalID, userInternalID,
+		portfolioMemberStatusToString(entity.PortfolioMemberStatusActive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	result, err := s.getPortfolioMember(ctx, portfolioInternalID, userInternalID)
+	if err != nil {
+		return nil, err
+	}
+	audit.RecordChange(ctx, "portfolio_member", result.ID, before, result)
+	return result, nil
+}
+
+// RevokePortfolioMember removes the user's membership or invitation. Owners
+// may remove anyone; other users only themselves.
+func (s *PortfolioStore) RevokePortfolioMember(ctx context.Context, portfolioID, userID string) error {
+	if !isValidUUID(portfolioID) {
+		return fmt.Errorf("%w: invalid portfolio ID format", store.ErrInvalidArgument)
+	}
+	if !isValidUUID(userID) {
+		return fmt.Errorf("%w: invalid user ID format", store.ErrInvalidArgument)
+	}
+	if caller := tenantUserID(ctx); caller != "" && caller != userID {
+		if _, err := s.getPortfolioInternalID(ctx, portfolioID, entity.PortfolioRoleOwner); err != nil {
+			return err
+		}
+	}
+
+	var portfolioInternalID, userInternalID int64
+	err := s.pool.QueryRow(ctx, `
+		SELECT pm.portfolio_id, pm.user_id
+		FROM portfolio_members pm
+		JOIN portfolios p ON p.id = pm.portfolio_id
+		JOIN users u ON u.id = pm.user_id
+		WHERE p.uuid = $1 AND u.uuid = $2`,
+		portfolioID, userID).Scan(&portfolioInternalID, &userInternalID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: member %s of portfolio %s", store.ErrNotFound, userID, portfolioID)
+		}
+		return fmt.Errorf("failed to get portfolio member: %w", err)
+	}
+
+	before, err := s.getPortfolioMember(ctx, portfolioInternalID, userInternalID)
+	if err != nil {
+		return err
+	}
+	_, err = s.pool.Exec(ctx, "DELETE FROM portfolio_members WHERE portfolio_id = $1 AND user_id = $2",
+		portfolioInternalID, userInternalID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke portfolio member: %w", err)
+	}
+
+	audit.RecordChange(ctx, "portfolio_member", before.ID, before, nil)
+	return nil
+}
+
+// ListPortfolioMembers lists members of portfolios the caller can view, and
+// the caller's own memberships and invitations.
+func (s *PortfolioStore) ListPortfolioMembers(ctx context.Context, opts portfolio.ListPortfolioMembersOpts) ([]*entity.PortfolioMember, error) {
+	if opts.PortfolioID == "" && opts.UserID == "" {
+		return nil, fmt.Errorf("%w: portfolio_id or user_id is required", store.ErrInvalidArgument)
+	}
+
+	args := []any{}
+	whereClauses := []string{}
+
+	if opts.PortfolioID != "" {
+		if !isValidUUID(opts.PortfolioID) {
+			return nil, fmt.Errorf("%w: invalid portfolio ID format", store.ErrInvalidArgument)
+		}
+		args = append(args, opts.PortfolioID)
+		whereClauses = append(whereClauses, fmt.Sprintf("p.uuid = $%d", len(args)))
+	}
+	if opts.UserID != "" {
+		if !isValidUUID(opts.UserID) {
+			return nil, fmt.Errorf("%w: invalid user ID format", store.ErrInvalidArgument)
+		}
+		args = append(args, opts.UserID)
+		whereClauses = append(whereClauses, fmt.Sprintf("u.uuid = $%d", len(args)))
+	}
+
+	var viewable, own string
+	viewable, args = portfolioFilter(ctx, "pm.portfolio_id", entity.PortfolioRoleViewer, args)
+	own, args = tenantFilter(ctx, "pm.user_id", args)
+	whereClauses = append(whereClauses, "("+viewable+" OR "+own+")")
+
+	rows, err := s.pool.Query(ctx, portfolioMemberSelect+`
+		WHERE `+strings.Join(whereClauses, " AND ")+`
+		ORDER BY pm.created_at, pm.id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list portfolio members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*entity.PortfolioMember
+	for rows.Next() {
+		m, err := scanPortfolioMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list portfolio members: %w", err)
+	}
+	return members, nil
+}
+
+const portfolioMemberSelect = `
+	SELECT pm.uuid, p.uuid, u.uuid, u.email, pm.role, pm.status, inv.uuid, pm.created_at, pm.updated_at
+	FROM portfolio_members pm
+	JOIN portfolios p ON p.id = pm.portfolio_id
+	JOIN users u ON u.id = pm.user_id
+	LEFT JOIN users inv ON inv.id = pm.invited_by`
+
+// getPortfolioMember reads a membership without tenant scoping; callers
+// check access first.
+func (s *PortfolioStore) getPortfolioMember(ctx context.Context, portfolioInternalID, userInternalID int64) (*entity.PortfolioMember, error) {
+	row := s.pool.QueryRow(ctx, portfolioMemberSelect+`
+		WHERE pm.portfolio_id = $1 AND pm.user_id = $2`,
+		portfolioInternalID, userInternalID)
+	m, err := scanPortfolioMember(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: portfolio member", store.ErrNotFound)
+	}
+	return m, err
+}
+
+func scanPortfolioMember(row pgx.Row) (*entity.PortfolioMember, error) {
+	var m entity.PortfolioMember
+	var role, status string
+	var invitedBy *string
+	err := row.Scan(&m.ID, &m.PortfolioID, &m.UserID, &m.Email, &role, &status, &invitedBy, &m.CreatedAt, &m.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan portfolio member: %w", err)
+	}
+	m.Role = stringToPortfolioRole(role)
+	m.Status = stringToPortfolioMemberStatus(status)
+	if invitedBy != nil {
+		m.InvitedBy = *invitedBy
+	}
+	return &m, nil
+}
+
 // --- Account methods ---
 
 func (s *PortfolioStore) CreateAccount(ctx context.Context, a *entity.Account) (*entity.Account, error) {
@@ -333,10 +605,14 @@ func (s *PortfolioStore) CreateAccount(ctx context.Context, a *entity.Account) (
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal data: %w", err)
 	}
+	sealed, err := s.sealSecrets(a.ID, a.Secrets)
+	if err != nil {
+		return nil, err
+	}
 
 	query := `
-		INSERT INTO accounts (uuid, user_id, name, description, type, data, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		INSERT INTO accounts (uuid, user_id, name, description, type, data, secrets, secrets_key, secrets_key_version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
 		RETURNING created_at, updated_at`
 
 	err = s.pool.QueryRow(ctx, query,
@@ -346,6 +622,9 @@ func (s *PortfolioStore) CreateAccount(ctx context.Context, a *entity.Account) (
 		nullableString(a.Description),
 		accountTypeToString(a.Type),
 		dataJSON,
+		sealed.ciphertext,
+		sealed.dataKey,
+		sealed.keyVersion,
 	).Scan(&a.CreatedAt, &a.UpdatedAt)
 	if err != nil {
 		if isConstraintError(err) {
@@ -354,6 +633,7 @@ func (s *PortfolioStore) CreateAccount(ctx context.Context, a *entity.Account) (
 		return nil, fmt.Errorf("failed to create account: %w", err)
 	}
 
+	audit.RecordChange(ctx, "account", a.ID, nil, a)
 	return a, nil
 }
 
@@ -365,24 +645,29 @@ func (s *PortfolioStore) GetAccount(ctx context.Context, id string) (*entity.Acc
 		return nil, fmt.Errorf("%w: invalid account ID format", store.ErrInvalidArgument)
 	}
 
+	filter, args := tenantFilter(ctx, "a.user_id", []any{id})
 	query := `
-		SELECT a.uuid, u.uuid, a.name, a.description, a.type, a.data, a.created_at, a.updated_at
+		SELECT a.uuid, u.uuid, a.name, a.description, a.type, a.data, a.secrets, a.secrets_key, a.secrets_key_version, a.created_at, a.updated_at
 		FROM accounts a
 		JOIN users u ON a.user_id = u.id
-		WHERE a.uuid = $1`
+		WHERE a.uuid = $1 AND ` + filter
 
 	var a entity.Account
 	var description *string
 	var typeStr string
 	var dataJSON []byte
+	var sealed sealedSecrets
 
-	err := s.pool.QueryRow(ctx, query, id).Scan(
+	err := s.pool.QueryRow(ctx, query, args...).Scan(
 		&a.ID,
 		&a.UserID,
 		&a.Name,
 		&description,
 		&typeStr,
 		&dataJSON,
+		&sealed.ciphertext,
+		&sealed.dataKey,
+		&sealed.keyVersion,
 		&a.CreatedAt,
 		&a.UpdatedAt,
 	)
@@ -400,6 +685,9 @@ func (s *PortfolioStore) GetAccount(ctx context.Context, id string) (*entity.Acc
 	if err := json.Unmarshal(dataJSON, &a.Data); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal data: %w", err)
 	}
+	if err := s.loadSecrets(&a, sealed); err != nil {
+		return nil, err
+	}
 
 	return &a, nil
 }
@@ -431,21 +719,32 @@ func (s *PortfolioStore) UpdateAccount(ctx context.Context, a *entity.Account, f
 			args = append(args, accountTypeToString(a.Type))
 			argIdx++
 		case "data":
+			// Data and secrets come from the same API field and are replaced together.
 			dataJSON, err := json.Marshal(a.Data)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal data: %w", err)
 			}
-			setClauses = append(setClauses, fmt.Sprintf("data = $%d", argIdx))
-			args = append(args, dataJSON)
-			argIdx++
+			sealed, err := s.sealSecrets(a.ID, a.Secrets)
+			if err != nil {
+				return nil, err
+			}
+			setClauses = append(setClauses,
+				fmt.Sprintf("data = $%d", argIdx),
+				fmt.Sprintf("secrets = $%d", argIdx+1),
+				fmt.Sprintf("secrets_key = $%d", argIdx+2),
+				fmt.Sprintf("secrets_key_version = $%d", argIdx+3))
+			args = append(args, dataJSON, sealed.ciphertext, sealed.dataKey, sealed.keyVersion)
+			argIdx += 4
 		}
 	}
 
+	before := auditBefore(ctx, s.GetAccount, a.ID)
+	filter, args := tenantFilter(ctx, "user_id", args)
 	query := fmt.Sprintf(`
 		UPDATE accounts
 		SET %s
-		WHERE uuid = $1`,
-		strings.Join(setClauses, ", "))
+		WHERE uuid = $1 AND %s`,
+		strings.Join(setClauses, ", "), filter)
 
 	result, err := s.pool.Exec(ctx, query, args...)
 	if err != nil {
@@ -456,7 +755,12 @@ func (s *PortfolioStore) UpdateAccount(ctx context.Context, a *entity.Account, f
 		return nil, fmt.Errorf("%w: account with ID %s", store.ErrNotFound, a.ID)
 	}
 
-	return s.GetAccount(ctx, a.ID)
+	after, err := s.GetAccount(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	audit.RecordChange(ctx, "account", a.ID, before, after)
+	return after, nil
 }
 
 func (s *PortfolioStore) DeleteAccount(ctx context.Context, id string) error {
@@ -467,7 +771,9 @@ func (s *PortfolioStore) DeleteAccount(ctx context.Context, id string) error {
 		return fmt.Errorf("%w: invalid account ID format", store.ErrInvalidArgument)
 	}
 
-	result, err := s.pool.Exec(ctx, "DELETE FROM accounts WHERE uuid = $1", id)
+	before := auditBefore(ctx, s.GetAccount, id)
+	filter, args := tenantFilter(ctx, "user_id", []any{id})
+	result, err := s.pool.Exec(ctx, "DELETE FROM accounts WHERE uuid = $1 AND "+filter, args...)
 	if err != nil {
 		if isConstraintError(err) {
 			return fmt.Errorf("%w: cannot delete account due to existing dependencies", store.ErrConstraint)
@@ -479,6 +785,7 @@ func (s *PortfolioStore) DeleteAccount(ctx context.Context, id string) error {
 		return fmt.Errorf("%w: account with ID %s", store.ErrNotFound, id)
 	}
 
+	audit.RecordChange(ctx, "account", id, before, nil)
 	return nil
 }
 
@@ -502,6 +809,11 @@ func (s *PortfolioStore) ListAccounts(ctx context.Context, opts portfolio.ListAc
 		argIdx++
 	}
 
+	var filter string
+	filter, args = tenantFilter(ctx, "a.user_id", args)
+	whereClauses = append(whereClauses, filter)
+	argIdx = len(args) + 1
+
 	if opts.Type != entity.AccountTypeUnspecified {
 		whereClauses = append(whereClauses, fmt.Sprintf("a.type = $%d", argIdx))
 		args = append(args, accountTypeToString(opts.Type))
@@ -523,7 +835,7 @@ func (s *PortfolioStore) ListAccounts(ctx context.Context, opts portfolio.ListAc
 	}
 
 	query := fmt.Sprintf(`
-		SELECT a.uuid, u.uuid, a.name, a.description, a.type, a.data, a.created_at, a.updated_at
+		SELECT a.uuid, u.uuid, a.name, a.description, a.type, a.data, a.secrets, a.secrets_key, a.secrets_key_version, a.created_at, a.updated_at
 		FROM accounts a
 		JOIN users u ON a.user_id = u.id
 		%s
@@ -544,6 +856,7 @@ func (s *PortfolioStore) ListAccounts(ctx context.Context, opts portfolio.ListAc
 		var description *string
 		var typeStr string
 		var dataJSON []byte
+		var sealed sealedSecrets
 
 		if err := rows.Scan(
 			&a.ID,
@@ -552,6 +865,9 @@ func (s *PortfolioStore) ListAccounts(ctx context.Context, opts portfolio.ListAc
 			&description,
 			&typeStr,
 			&dataJSON,
+			&sealed.ciphertext,
+			&sealed.dataKey,
+			&sealed.keyVersion,
 			&a.CreatedAt,
 			&a.UpdatedAt,
 		); err != nil {
@@ -565,6 +881,9 @@ func (s *PortfolioStore) ListAccounts(ctx context.Context, opts portfolio.ListAc
 		if err := json.Unmarshal(dataJSON, &a.Data); err != nil {
 			return nil, "", fmt.Errorf("failed to unmarshal data: %w", err)
 		}
+		if err := s.loadSecrets(&a, sealed); err != nil {
+			return nil, "", err
+		}
 
 		accounts = append(accounts, &a)
 	}
@@ -597,14 +916,14 @@ func (s *PortfolioStore) CreateHolding(ctx context.Context, h *entity.Holding) (
 		return nil, err
 	}
 
-	accountInternalID, err := s.getAccountInternalID(ctx, h.AccountID)
+	accountInternalID, err := s.getAccountInternalID(ctx, h.AccountID, entity.PortfolioRoleOwner)
 	if err != nil {
 		return nil, err
 	}
 
 	var portfolioInternalID *int64
 	if h.PortfolioID != "" {
-		id, err := s.getPortfolioInternalID(ctx, h.PortfolioID)
+		id, err := s.getPortfolioInternalID(ctx, h.PortfolioID, entity.PortfolioRoleEditor)
 		if err != nil {
 			return nil, err
 		}
@@ -633,6 +952,7 @@ func (s *PortfolioStore) CreateHolding(ctx context.Context, h *entity.Holding) (
 		return nil, fmt.Errorf("failed to create holding: %w", err)
 	}
 
+	audit.RecordChange(ctx, "holding", h.ID, nil, h)
 	return h, nil
 }
 
@@ -644,18 +964,19 @@ func (s *PortfolioStore) GetHolding(ctx context.Context, id string) (*entity.Hol
 		return nil, fmt.Errorf("%w: invalid holding ID format", store.ErrInvalidArgument)
 	}
 
+	filter, args := holdingFilter(ctx, "acc.user_id", "h.portfolio_id", entity.PortfolioRoleViewer, []any{id})
 	query := `
 		SELECT h.uuid, h.amount, h.decimals, a.uuid, acc.uuid, p.uuid, h.created_at, h.updated_at
 		FROM holdings h
 		JOIN assets a ON h.asset_id = a.id
 		JOIN accounts acc ON h.account_id = acc.id
 		LEFT JOIN portfolios p ON h.portfolio_id = p.id
-		WHERE h.uuid = $1`
+		WHERE h.uuid = $1 AND ` + filter
 
 	var h entity.Holding
 	var portfolioID *string
 
-	err := s.pool.QueryRow(ctx, query, id).Scan(
+	err := s.pool.QueryRow(ctx, query, args...).Scan(
 		&h.ID,
 		&h.Amount,
 		&h.Decimals,
@@ -706,7 +1027,7 @@ func (s *PortfolioStore) UpdateHolding(ctx context.Context, h *entity.Holding, f
 				setClauses = append(setClauses, fmt.Sprintf("portfolio_id = $%d", argIdx))
 				args = append(args, nil)
 			} else {
-				portfolioInternalID, err := s.getPortfolioInternalID(ctx, h.PortfolioID)
+				portfolioInternalID, err := s.getPortfolioInternalID(ctx, h.PortfolioID, entity.PortfolioRoleEditor)
 				if err != nil {
 					return nil, err
 				}
@@ -717,11 +1038,13 @@ func (s *PortfolioStore) UpdateHolding(ctx context.Context, h *entity.Holding, f
 		}
 	}
 
+	before := auditBefore(ctx, s.GetHolding, h.ID)
+	filter, args := holdingFilter(ctx, holdingOwner, "portfolio_id", entity.PortfolioRoleEditor, args)
 	query := fmt.Sprintf(`
 		UPDATE holdings
 		SET %s
-		WHERE uuid = $1`,
-		strings.Join(setClauses, ", "))
+		WHERE uuid = $1 AND %s`,
+		strings.Join(setClauses, ", "), filter)
 
 	result, err := s.pool.Exec(ctx, query, args...)
 	if err != nil {
@@ -729,10 +1052,15 @@ func (s *PortfolioStore) UpdateHolding(ctx context.Context, h *entity.Holding, f
 	}
 
 	if result.RowsAffected() == 0 {
-		return nil, fmt.Errorf("%w: holding with ID %s", store.ErrNotFound, h.ID)
+		return nil, deniedOrNotFound(ctx, s.GetHolding, h.ID, fmt.Errorf("%w: holding with ID %s", store.ErrNotFound, h.ID))
 	}
 
-	return s.GetHolding(ctx, h.ID)
+	after, err := s.GetHolding(ctx, h.ID)
+	if err != nil {
+		return nil, err
+	}
+	audit.RecordChange(ctx, "holding", h.ID, before, after)
+	return after, nil
 }
 
 func (s *PortfolioStore) DeleteHolding(ctx context.Context, id string) error {
@@ -743,15 +1071,18 @@ func (s *PortfolioStore) DeleteHolding(ctx context.Context, id string) error {
 		return fmt.Errorf("%w: invalid holding ID format", store.ErrInvalidArgument)
 	}
 
-	result, err := s.pool.Exec(ctx, "DELETE FROM holdings WHERE uuid = $1", id)
+	before := auditBefore(ctx, s.GetHolding, id)
+	filter, args := holdingFilter(ctx, holdingOwner, "portfolio_id", entity.PortfolioRoleEditor, []any{id})
+	result, err := s.pool.Exec(ctx, "DELETE FROM holdings WHERE uuid = $1 AND "+filter, args...)
 	if err != nil {
 		return fmt.Errorf("failed to delete holding: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("%w: holding with ID %s", store.ErrNotFound, id)
+		return deniedOrNotFound(ctx, s.GetHolding, id, fmt.Errorf("%w: holding with ID %s", store.ErrNotFound, id))
 	}
 
+	audit.RecordChange(ctx, "holding", id, before, nil)
 	return nil
 }
 
@@ -766,7 +1097,7 @@ func (s *PortfolioStore) ListHoldings(ctx context.Context, opts portfolio.ListHo
 	whereClauses := []string{}
 
 	if opts.PortfolioID != "" {
-		portfolioInternalID, err := s.getPortfolioInternalID(ctx, opts.PortfolioID)
+		portfolioInternalID, err := s.getPortfolioInternalID(ctx, opts.PortfolioID, entity.PortfolioRoleViewer)
 		if err != nil {
 			return nil, "", err
 		}
@@ -776,7 +1107,7 @@ func (s *PortfolioStore) ListHoldings(ctx context.Context, opts portfolio.ListHo
 	}
 
 	if opts.AccountID != "" {
-		accountInternalID, err := s.getAccountInternalID(ctx, opts.AccountID)
+		accountInternalID, err := s.getAccountInternalID(ctx, opts.AccountID, entity.PortfolioRoleViewer)
 		if err != nil {
 			return nil, "", err
 		}
@@ -795,6 +1126,11 @@ func (s *PortfolioStore) ListHoldings(ctx context.Context, opts portfolio.ListHo
 		argIdx++
 	}
 
+	var filter string
+	filter, args = holdingFilter(ctx, "acc.user_id", "h.portfolio_id", entity.PortfolioRoleViewer, args)
+	whereClauses = append(whereClauses, filter)
+	argIdx = len(args) + 1
+
 	if opts.PageToken != "" {
 		decoded, err := base64.StdEncoding.DecodeString(opts.PageToken)
 		if err == nil && isValidUUID(string(decoded)) {
@@ -862,6 +1198,285 @@ func (s *PortfolioStore) ListHoldings(ctx context.Context, opts portfolio.ListHo
 	return holdings, nextPageToken, nil
 }
 
+// --- Lot methods ---
+
+func (s *PortfolioStore) CreateLot(ctx context.Context, l *entity.Lot) (*entity.Lot, error) {
+	if l == nil {
+		return nil, fmt.Errorf("%w: lot is required", store.ErrInvalidArgument)
+	}
+	if l.HoldingID == "" {
+		return nil, fmt.Errorf("%w: holding_id is required", store.ErrInvalidArgument)
+	}
+	if l.CostAssetID == "" {
+		return nil, fmt.Errorf("%w: cost_asset_id is required", store.ErrInvalidArgument)
+	}
+	if l.Amount < 0 || l.CostBasis < 0 {
+		return nil, fmt.Errorf("%w: amount and cost_basis must not be negative", store.ErrInvalidArgument)
+	}
+
+	holdingInternalID, err := s.getHoldingInternalID(ctx, l.HoldingID, entity.PortfolioRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	costAssetInternalID, err := s.getAssetInternalID(ctx, l.CostAssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	l.ID = uuid.New().String()
+	if l.AcquiredAt.IsZero() {
+		l.AcquiredAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO lots (uuid, holding_id, amount, decimals, cost_basis, cost_decimals, cost_asset_id, acquired_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+		RETURNING acquired_at, created_at, updated_at`
+
+	err = s.pool.QueryRow(ctx, query,
+		l.ID,
+		holdingInternalID,
+		l.Amount,
+		l.Decimals,
+		l.CostBasis,
+		l.CostDecimals,
+		costAssetInternalID,
+		l.AcquiredAt,
+	).Scan(&l.AcquiredAt, &l.CreatedAt, &l.UpdatedAt)
+	if err != nil {
+		if isConstraintError(err) {
+			return nil, fmt.Errorf("%w: %v", store.ErrConstraint, err)
+		}
+		return nil, fmt.Errorf("failed to create lot: %w", err)
+	}
+
+	audit.RecordChange(ctx, "lot", l.ID, nil, l)
+	return l, nil
+}
+
+func (s *PortfolioStore) GetLot(ctx context.Context, id string) (*entity.Lot, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: lot ID is required", store.ErrInvalidArgument)
+	}
+	if !isValidUUID(id) {
+		return nil, fmt.Errorf("%w: invalid lot ID format", store.ErrInvalidArgument)
+	}
+
+	filter, args := holdingFilter(ctx, "acc.user_id", "h.portfolio_id", entity.PortfolioRoleViewer, []any{id})
+	query := `
+		SELECT l.uuid, h.uuid, l.amount, l.decimals, l.cost_basis, l.cost_decimals, a.uuid, l.acquired_at, l.created_at, l.updated_at
+		FROM lots l
+		JOIN holdings h ON l.holding_id = h.id
+		JOIN accounts acc ON h.account_id = acc.id
+		JOIN assets a ON l.cost_asset_id = a.id
+		WHERE l.uuid = $1 AND ` + filter
+
+	var l entity.Lot
+	err := s.pool.QueryRow(ctx, query, args...).Scan(
+		&l.ID,
+		&l.HoldingID,
+		&l.Amount,
+		&l.Decimals,
+		&l.CostBasis,
+		&l.CostDecimals,
+		&l.CostAssetID,
+		&l.AcquiredAt,
+		&l.CreatedAt,
+		&l.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: lot with ID %s", store.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get lot: %w", err)
+	}
+
+	return &l, nil
+}
+
+func (s *PortfolioStore) UpdateLot(ctx context.Context, l *entity.Lot, fields []string) (*entity.Lot, error) {
+	if l == nil || l.ID == "" {
+		return nil, fmt.Errorf("%w: lot with ID is required", store.ErrInvalidArgument)
+	}
+	if !isValidUUID(l.ID) {
+		return nil, fmt.Errorf("%w: invalid lot ID format", store.ErrInvalidArgument)
+	}
+
+	setClauses := []string{"updated_at = NOW()"}
+	args := []any{l.ID}
+	argIdx := 2
+
+	for _, field := range fields {
+		switch field {
+		case "amount":
+			setClauses = append(setClauses, fmt.Sprintf("amount = $%d", argIdx))
+			args = append(args, l.Amount)
+			argIdx++
+		case "decimals":
+			setClauses = append(setClauses, fmt.Sprintf("decimals = $%d", argIdx))
+			args = append(args, l.Decimals)
+			argIdx++
+		case "cost_basis":
+			setClauses = append(setClauses, fmt.Sprintf("cost_basis = $%d", argIdx))
+			args = append(args, l.CostBasis)
+			argIdx++
+		case "cost_decimals":
+			setClauses = append(setClauses, fmt.Sprintf("cost_decimals = $%d", argIdx))
+			args = append(args, l.CostDecimals)
+			argIdx++
+		case "acquired_at":
+			setClauses = append(setClauses, fmt.Sprintf("acquired_at = $%d", argIdx))
+			args = append(args, l.AcquiredAt)
+			argIdx++
+		}
+	}
+
+	before := auditBefore(ctx, s.GetLot, l.ID)
+	filter, args := holdingFilter(ctx, lotOwner, lotPortfolio, entity.PortfolioRoleEditor, args)
+	query := fmt.Sprintf(`
+		UPDATE lots
+		SET %s
+		WHERE uuid = $1 AND %s`,
+		strings.Join(setClauses, ", "), filter)
+
+	result, err := s.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update lot: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, deniedOrNotFound(ctx, s.GetLot, l.ID, fmt.Errorf("%w: lot with ID %s", store.ErrNotFound, l.ID))
+	}
+
+	after, err := s.GetLot(ctx, l.ID)
+	if err != nil {
+		return nil, err
+	}
+	audit.RecordChange(ctx, "lot", l.ID, before, after)
+	return after, nil
+}
+
+func (s *PortfolioStore) DeleteLot(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: lot ID is required", store.ErrInvalidArgument)
+	}
+	if !isValidUUID(id) {
+		return fmt.Errorf("%w: invalid lot ID format", store.ErrInvalidArgument)
+	}
+
+	before := auditBefore(ctx, s.GetLot, id)
+	filter, args := holdingFilter(ctx, lotOwner, lotPortfolio, entity.PortfolioRoleEditor, []any{id})
+	result, err := s.pool.Exec(ctx, "DELETE FROM lots WHERE uuid = $1 AND "+filter, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete lot: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return deniedOrNotFound(ctx, s.GetLot, id, fmt.Errorf("%w: lot with ID %s", store.ErrNotFound, id))
+	}
+
+	audit.RecordChange(ctx, "lot", id, before, nil)
+	return nil
+}
+
+func (s *PortfolioStore) ListLots(ctx context.Context, opts portfolio.ListLotsOpts) ([]*entity.Lot, string, error) {
+	limit := opts.PageSize
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	args := []any{}
+	argIdx := 1
+	whereClauses := []string{}
+
+	if opts.HoldingID != "" {
+		holdingInternalID, err := s.getHoldingInternalID(ctx, opts.HoldingID, entity.PortfolioRoleViewer)
+		if err != nil {
+			return nil, "", err
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("l.holding_id = $%d", argIdx))
+		args = append(args, holdingInternalID)
+		argIdx++
+	}
+
+	if opts.PortfolioID != "" {
+		portfolioInternalID, err := s.getPortfolioInternalID(ctx, opts.PortfolioID, entity.PortfolioRoleViewer)
+		if err != nil {
+			return nil, "", err
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("h.portfolio_id = $%d", argIdx))
+		args = append(args, portfolioInternalID)
+		argIdx++
+	}
+
+	var filter string
+	filter, args = holdingFilter(ctx, "acc.user_id", "h.portfolio_id", entity.PortfolioRoleViewer, args)
+	whereClauses = append(whereClauses, filter)
+	argIdx = len(args) + 1
+
+	if opts.PageToken != "" {
+		decoded, err := base64.StdEncoding.DecodeString(opts.PageToken)
+		if err == nil && isValidUUID(string(decoded)) {
+			whereClauses = append(whereClauses, fmt.Sprintf("l.uuid > $%d", argIdx))
+			args = append(args, string(decoded))
+			argIdx++
+		}
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT l.uuid, h.uuid, l.amount, l.decimals, l.cost_basis, l.cost_decimals, a.uuid, l.acquired_at, l.created_at, l.updated_at
+		FROM lots l
+		JOIN holdings h ON l.holding_id = h.id
+		JOIN accounts acc ON h.account_id = acc.id
+		JOIN assets a ON l.cost_asset_id = a.id
+		%s
+		ORDER BY l.uuid
+		LIMIT $%d`,
+		whereClause, argIdx)
+	args = append(args, limit+1)
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list lots: %w", err)
+	}
+	defer rows.Close()
+
+	lots := make([]*entity.Lot, 0, limit)
+	for rows.Next() {
+		var l entity.Lot
+		if err := rows.Scan(
+			&l.ID,
+			&l.HoldingID,
+			&l.Amount,
+			&l.Decimals,
+			&l.CostBasis,
+			&l.CostDecimals,
+			&l.CostAssetID,
+			&l.AcquiredAt,
+			&l.CreatedAt,
+			&l.UpdatedAt,
+		); err != nil {
+			return nil, "", fmt.Errorf("failed to scan lot: %w", err)
+		}
+		lots = append(lots, &l)
+	}
+
+	var nextPageToken string
+	if len(lots) > limit {
+		lastItem := lots[limit-1]
+		lots = lots[:limit]
+		nextPageToken = base64.StdEncoding.EncodeToString([]byte(lastItem.ID))
+	}
+
+	return lots, nextPageToken, nil
+}
+
 // --- Transaction methods ---
 
 func (s *PortfolioStore) CreateTransaction(ctx context.Context, t *entity.Transaction) (*entity.Transaction, error) {
@@ -875,7 +1490,7 @@ func (s *PortfolioStore) CreateTransaction(ctx context.Context, t *entity.Transa
 		return nil, fmt.Errorf("%w: transaction type is required", store.ErrInvalidArgument)
 	}
 
-	accountInternalID, err := s.getAccountInternalID(ctx, t.AccountID)
+	accountInternalID, err := s.getAccountInternalID(ctx, t.AccountID, entity.PortfolioRoleEditor)
 	if err != nil {
 		return nil, err
 	}
@@ -898,10 +1513,13 @@ func (s *PortfolioStore) CreateTransaction(ctx context.Context, t *entity.Transa
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal data: %w", err)
 	}
+	if err := s.checkTransactionHolding(ctx, accountInternalID, assetInternalID, dataJSON); err != nil {
+		return nil, err
+	}
 
 	query := `
-		INSERT INTO transactions (uuid, type, status, account_id, asset_transactions, data, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		INSERT INTO transactions (uuid, type, status, account_id, asset_transactions, data, external_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
 		RETURNING created_at, updated_at`
 
 	err = s.pool.QueryRow(ctx, query,
@@ -911,6 +1529,7 @@ func (s *PortfolioStore) CreateTransaction(ctx context.Context, t *entity.Transa
 		accountInternalID,
 		assetInternalID,
 		dataJSON,
+		nullableString(t.ExternalID),
 	).Scan(&t.CreatedAt, &t.UpdatedAt)
 	if err != nil {
 		if isConstraintError(err) {
@@ -919,9 +1538,102 @@ func (s *PortfolioStore) CreateTransaction(ctx context.Context, t *entity.Transa
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
+	audit.RecordChange(ctx, "transaction", t.ID, nil, t)
 	return t, nil
 }
 
+// CreateTransactions records transactions of one account all or nothing.
+// Transactions whose external ID the account already has are skipped and
+// keep an empty ID. It returns how many were created.
+func (s *PortfolioStore) CreateTransactions(ctx context.Context, txs []*entity.Transaction) (int, error) {
+	if len(txs) == 0 {
+		return 0, nil
+	}
+	accountID := txs[0].AccountID
+	for _, t := range txs {
+		if t.AccountID != accountID {
+			return 0, fmt.Errorf("%w: transactions must belong to one account", store.ErrInvalidArgument)
+		}
+		if t.Type == entity.TransactionTypeUnspecified {
+			return 0, fmt.Errorf("%w: transaction type is required", store.ErrInvalidArgument)
+		}
+	}
+
+	accountInternalID, err := s.getAccountInternalID(ctx, accountID, entity.PortfolioRoleEditor)
+	if err != nil {
+		return 0, err
+	}
+	assetInternalIDs := make(map[string]int64)
+	for _, t := range txs {
+		if _, ok := assetInternalIDs[t.AssetID]; ok || t.AssetID == "" {
+			continue
+		}
+		id, err := s.getAssetInternalID(ctx, t.AssetID)
+		if err != nil {
+			return 0, err
+		}
+		assetInternalIDs[t.AssetID] = id
+	}
+
+	dbTx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	query := `
+		INSERT INTO transactions (uuid, type, status, account_id, asset_transactions, data, external_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		ON CONFLICT (account_id, external_id) DO NOTHING
+		RETURNING created_at, updated_at`
+
+	var created []*entity.Transaction
+	for _, t := range txs {
+		var assetInternalID *int64
+		if id, ok := assetInternalIDs[t.AssetID]; ok {
+			assetInternalID = &id
+		}
+		if t.Status == entity.TransactionStatusUnspecified {
+			t.Status = entity.TransactionStatusPending
+		}
+		dataJSON, err := json.Marshal(t.Data)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal data: %w", err)
+		}
+		if err := s.checkTransactionHolding(ctx, accountInternalID, assetInternalID, dataJSON); err != nil {
+			return 0, err
+		}
+
+		id := uuid.New().String()
+		err = dbTx.QueryRow(ctx, query,
+			id,
+			transactionTypeToString(t.Type),
+			transactionStatusToString(t.Status),
+			accountInternalID,
+			assetInternalID,
+			dataJSON,
+			nullableString(t.ExternalID),
+		).Scan(&t.CreatedAt, &t.UpdatedAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to create transaction: %w", err)
+		}
+		t.ID = id
+		created = append(created, t)
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transactions: %w", err)
+	}
+
+	for _, t := range created {
+		audit.RecordChange(ctx, "transaction", t.ID, nil, t)
+	}
+	return len(created), nil
+}
+
 func (s *PortfolioStore) GetTransaction(ctx context.Context, id string) (*entity.Transaction, error) {
 	if id == "" {
 		return nil, fmt.Errorf("%w: transaction ID is required", store.ErrInvalidArgument)
@@ -930,25 +1642,27 @@ func (s *PortfolioStore) GetTransaction(ctx context.Context, id string) (*entity
 		return nil, fmt.Errorf("%w: invalid transaction ID format", store.ErrInvalidArgument)
 	}
 
+	filter, args := transactionFilter(ctx, "acc.user_id", "t.account_id", "t.asset_transactions", "t.data", entity.PortfolioRoleViewer, []any{id})
 	query := `
-		SELECT t.uuid, t.type, t.status, acc.uuid, a.uuid, t.data, t.created_at, t.updated_at
+		SELECT t.uuid, t.type, t.status, acc.uuid, a.uuid, t.data, t.external_id, t.created_at, t.updated_at
 		FROM transactions t
 		JOIN accounts acc ON t.account_id = acc.id
 		LEFT JOIN assets a ON t.asset_transactions = a.id
-		WHERE t.uuid = $1`
+		WHERE t.uuid = $1 AND ` + filter
 
 	var t entity.Transaction
 	var typeStr, statusStr string
-	var assetID *string
+	var assetID, externalID *string
 	var dataJSON []byte
 
-	err := s.pool.QueryRow(ctx, query, id).Scan(
+	err := s.pool.QueryRow(ctx, query, args...).Scan(
 		&t.ID,
 		&typeStr,
 		&statusStr,
 		&t.AccountID,
 		&assetID,
 		&dataJSON,
+		&externalID,
 		&t.CreatedAt,
 		&t.UpdatedAt,
 	)
@@ -964,6 +1678,9 @@ func (s *PortfolioStore) GetTransaction(ctx context.Context, id string) (*entity
 	if assetID != nil {
 		t.AssetID = *assetID
 	}
+	if externalID != nil {
+		t.ExternalID = *externalID
+	}
 	if err := json.Unmarshal(dataJSON, &t.Data); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal data: %w", err)
 	}
@@ -1000,11 +1717,14 @@ func (s *PortfolioStore) UpdateTransaction(ctx context.Context, t *entity.Transa
 		}
 	}
 
+	before := auditBefore(ctx, s.GetTransaction, t.ID)
+	filter, args := transactionFilter(ctx, transactionOwner, "transactions.account_id", "transactions.asset_transactions",
+		"transactions.data", entity.PortfolioRoleEditor, args)
 	query := fmt.Sprintf(`
 		UPDATE transactions
 		SET %s
-		WHERE uuid = $1`,
-		strings.Join(setClauses, ", "))
+		WHERE uuid = $1 AND %s`,
+		strings.Join(setClauses, ", "), filter)
 
 	result, err := s.pool.Exec(ctx, query, args...)
 	if err != nil {
@@ -1012,12 +1732,23 @@ func (s *PortfolioStore) UpdateTransaction(ctx context.Context, t *entity.Transa
 	}
 
 	if result.RowsAffected() == 0 {
-		return nil, fmt.Errorf("%w: transaction with ID %s", store.ErrNotFound, t.ID)
+		return nil, deniedOrNotFound(ctx, s.GetTransaction, t.ID, fmt.Errorf("%w: transaction with ID %s", store.ErrNotFound, t.ID))
 	}
 
-	return s.GetTransaction(ctx, t.ID)
+	after, err := s.GetTransaction(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	audit.RecordChange(ctx, "transaction", t.ID, before, after)
+	return after, nil
 }
 
+// transactionTime is when transaction t was executed, matching
+// portfolio.TransactionTime. Malformed executed_at values fall back to the
+// creation time rather than failing the query.
+const transactionTime = `(CASE WHEN t.data->>'executed_at' ~ '^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$'
+	THEN (t.data->>'executed_at')::timestamptz ELSE t.created_at END)`
+
 func (s *PortfolioStore) ListTransactions(ctx context.Context, opts portfolio.ListTransactionsOpts) ([]*entity.Transaction, string, error) {
 	limit := opts.PageSize
 	if limit <= 0 {
@@ -1029,7 +1760,7 @@ func (s *PortfolioStore) ListTransactions(ctx context.Context, opts portfolio.Li
 	whereClauses := []string{}
 
 	if opts.AccountID != "" {
-		accountInternalID, err := s.getAccountInternalID(ctx, opts.AccountID)
+		accountInternalID, err := s.getAccountInternalID(ctx, opts.AccountID, entity.PortfolioRoleViewer)
 		if err != nil {
 			return nil, "", err
 		}
@@ -1048,6 +1779,12 @@ func (s *PortfolioStore) ListTransactions(ctx context.Context, opts portfolio.Li
 		argIdx++
 	}
 
+	if len(opts.ExternalIDs) > 0 {
+		whereClauses = append(whereClauses, fmt.Sprintf("t.external_id = ANY($%d)", argIdx))
+		args = append(args, opts.ExternalIDs)
+		argIdx++
+	}
+
 	if opts.Type != entity.TransactionTypeUnspecified {
 		whereClauses = append(whereClauses, fmt.Sprintf("t.type = $%d", argIdx))
 		args = append(args, transactionTypeToString(opts.Type))
@@ -1060,6 +1797,34 @@ func (s *PortfolioStore) ListTransactions(ctx context.Context, opts portfolio.Li
 		argIdx++
 	}
 
+	if opts.From != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("%s >= $%d", transactionTime, argIdx))
+		args = append(args, *opts.From)
+		argIdx++
+	}
+
+	if opts.To != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("%s < $%d", transactionTime, argIdx))
+		args = append(args, *opts.To)
+		argIdx++
+	}
+
+	if opts.PortfolioID != "" {
+		portfolioInternalID, err := s.getPortfolioInternalID(ctx, opts.PortfolioID, entity.PortfolioRoleViewer)
+		if err != nil {
+			return nil, "", err
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("EXISTS (SELECT 1 FROM holdings hh WHERE %s AND hh.portfolio_id = $%d)",
+			transactionHolding("t.account_id", "t.asset_transactions", "t.data"), argIdx))
+		args = append(args, portfolioInternalID)
+		argIdx++
+	}
+
+	var filter string
+	filter, args = transactionFilter(ctx, "acc.user_id", "t.account_id", "t.asset_transactions", "t.data", entity.PortfolioRoleViewer, args)
+	whereClauses = append(whereClauses, filter)
+	argIdx = len(args) + 1
+
 	if opts.PageToken != "" {
 		decoded, err := base64.StdEncoding.DecodeString(opts.PageToken)
 		if err == nil && isValidUUID(string(decoded)) {
@@ -1075,7 +1840,7 @@ func (s *PortfolioStore) ListTransactions(ctx context.Context, opts portfolio.Li
 	}
 
 	query := fmt.Sprintf(`
-		SELECT t.uuid, t.type, t.status, acc.uuid, a.uuid, t.data, t.created_at, t.updated_at
+		SELECT t.uuid, t.type, t.status, acc.uuid, a.uuid, t.data, t.external_id, t.created_at, t.updated_at
 		FROM transactions t
 		JOIN accounts acc ON t.account_id = acc.id
 		LEFT JOIN assets a ON t.asset_transactions = a.id
@@ -1095,7 +1860,7 @@ func (s *PortfolioStore) ListTransactions(ctx context.Context, opts portfolio.Li
 	for rows.Next() {
 		var t entity.Transaction
 		var typeStr, statusStr string
-		var assetID *string
+		var assetID, externalID *string
 		var dataJSON []byte
 
 		if err := rows.Scan(
@@ -1105,6 +1870,7 @@ func (s *PortfolioStore) ListTransactions(ctx context.Context, opts portfolio.Li
 			&t.AccountID,
 			&assetID,
 			&dataJSON,
+			&externalID,
 			&t.CreatedAt,
 			&t.UpdatedAt,
 		); err != nil {
@@ -1116,6 +1882,9 @@ func (s *PortfolioStore) ListTransactions(ctx context.Context, opts portfolio.Li
 		if assetID != nil {
 			t.AssetID = *assetID
 		}
+		if externalID != nil {
+			t.ExternalID = *externalID
+		}
 		if err := json.Unmarshal(dataJSON, &t.Data); err != nil {
 			return nil, "", fmt.Errorf("failed to unmarshal data: %w", err)
 		}
@@ -1140,6 +1909,10 @@ func (s *PortfolioStore) getUserInternalID(ctx context.Context, uuid string) (in
 		return 0, fmt.Errorf("%w: invalid user ID format", store.ErrInvalidArgument)
 	}
 
+	if owner := tenantUserID(ctx); owner != "" && owner != uuid {
+		return 0, fmt.Errorf("%w: user not found", store.ErrNotFound)
+	}
+
 	var id int64
 	err := s.pool.QueryRow(ctx, "SELECT id FROM users WHERE uuid = $1", uuid).Scan(&id)
 	if err != nil {
@@ -1151,13 +1924,16 @@ func (s *PortfolioStore) getUserInternalID(ctx context.Context, uuid string) (in
 	return id, nil
 }
 
-func (s *PortfolioStore) getAccountInternalID(ctx context.Context, uuid string) (int64, error) {
+// getAccountInternalID resolves an account the caller owns or reaches
+// through a portfolio they hold at least role on.
+func (s *PortfolioStore) getAccountInternalID(ctx context.Context, uuid string, role entity.PortfolioRole) (int64, error) {
 	if !isValidUUID(uuid) {
 		return 0, fmt.Errorf("%w: invalid account ID format", store.ErrInvalidArgument)
 	}
 
+	filter, args := accountFilter(ctx, "user_id", "accounts.id", role, []any{uuid})
 	var id int64
-	err := s.pool.QueryRow(ctx, "SELECT id FROM accounts WHERE uuid = $1", uuid).Scan(&id)
+	err := s.pool.QueryRow(ctx, "SELECT id FROM accounts WHERE uuid = $1 AND "+filter, args...).Scan(&id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return 0, fmt.Errorf("%w: account not found", store.ErrNotFound)
@@ -1167,16 +1943,56 @@ func (s *PortfolioStore) getAccountInternalID(ctx context.Context, uuid string)
 	return id, nil
 }
 
-func (s *PortfolioStore) getPortfolioInternalID(ctx context.Context, uuid string) (int64, error) {
+// checkTransactionHolding refuses a transaction a member of a shared
+// portfolio records in another user's account unless it touches a holding
+// of a portfolio they hold the editor role on.
+func (s *PortfolioStore) checkTransactionHolding(ctx context.Context, accountInternalID int64, assetInternalID *int64, dataJSON []byte) error {
+	if tenantUserID(ctx) == "" {
+		return nil
+	}
+	filter, args := transactionFilter(ctx, "(SELECT user_id FROM accounts WHERE id = $1)", "$1::bigint", "$2::bigint", "$3::jsonb",
+		entity.PortfolioRoleEditor, []any{accountInternalID, assetInternalID, dataJSON})
+	var ok bool
+	if err := s.pool.QueryRow(ctx, "SELECT "+filter, args...).Scan(&ok); err != nil {
+		return fmt.Errorf("failed to check transaction access: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: members record only transactions of the shared portfolio's holdings", store.ErrPermissionDenied)
+	}
+	return nil
+}
+
+// getHoldingInternalID resolves a holding the caller owns or reaches through
+// a portfolio they hold at least role on.
+func (s *PortfolioStore) getHoldingInternalID(ctx context.Context, uuid string, role entity.PortfolioRole) (int64, error) {
+	if !isValidUUID(uuid) {
+		return 0, fmt.Errorf("%w: invalid holding ID format", store.ErrInvalidArgument)
+	}
+
+	filter, args := holdingFilter(ctx, holdingOwner, "portfolio_id", role, []any{uuid})
+	var id int64
+	err := s.pool.QueryRow(ctx, "SELECT id FROM holdings WHERE uuid = $1 AND "+filter, args...).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%w: holding not found", store.ErrNotFound)
+		}
+		return 0, fmt.Errorf("failed to get holding: %w", err)
+	}
+	return id, nil
+}
+
+// getPortfolioInternalID resolves a portfolio the caller holds at least role on.
+func (s *PortfolioStore) getPortfolioInternalID(ctx context.Context, uuid string, role entity.PortfolioRole) (int64, error) {
 	if !isValidUUID(uuid) {
 		return 0, fmt.Errorf("%w: invalid portfolio ID format", store.ErrInvalidArgument)
 	}
 
+	filter, args := portfolioFilter(ctx, "id", role, []any{uuid})
 	var id int64
-	err := s.pool.QueryRow(ctx, "SELECT id FROM portfolios WHERE uuid = $1", uuid).Scan(&id)
+	err := s.pool.QueryRow(ctx, "SELECT id FROM portfolios WHERE uuid = $1 AND "+filter, args...).Scan(&id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return 0, fmt.Errorf("%w: portfolio not found", store.ErrNotFound)
+			return 0, deniedOrNotFound(ctx, s.GetPortfolio, uuid, fmt.Errorf("%w: portfolio not found", store.ErrNotFound))
 		}
 		return 0, fmt.Errorf("failed to get portfolio: %w", err)
 	}
@@ -1236,6 +2052,54 @@ func stringToAccountType(s string) entity.AccountType {
 	}
 }
 
+func portfolioRoleToString(r entity.PortfolioRole) string {
+	switch r {
+	case entity.PortfolioRoleViewer:
+		return "viewer"
+	case entity.PortfolioRoleEditor:
+		return "editor"
+	case entity.PortfolioRoleOwner:
+		return "owner"
+	default:
+		return "unspecified"
+	}
+}
+
+func stringToPortfolioRole(s string) entity.PortfolioRole {
+	switch s {
+	case "viewer":
+		return entity.PortfolioRoleViewer
+	case "editor":
+		return entity.PortfolioRoleEditor
+	case "owner":
+		return entity.PortfolioRoleOwner
+	default:
+		return entity.PortfolioRoleUnspecified
+	}
+}
+
+func portfolioMemberStatusToString(s entity.PortfolioMemberStatus) string {
+	switch s {
+	case entity.PortfolioMemberStatusPending:
+		return "pending"
+	case entity.PortfolioMemberStatusActive:
+		return "active"
+	default:
+		return "unspecified"
+	}
+}
+
+func stringToPortfolioMemberStatus(s string) entity.PortfolioMemberStatus {
+	switch s {
+	case "pending":
+		return entity.PortfolioMemberStatusPending
+	case "active":
+		return entity.PortfolioMemberStatusActive
+	default:
+		return entity.PortfolioMemberStatusUnspecified
+	}
+}
+
 func transactionTypeToString(t entity.TransactionType) string {
 	switch t {
 	case entity.TransactionTypeExtended:
@@ -1248,6 +2112,8 @@ func transactionTypeToString(t entity.TransactionType) string {
 		return "deposit"
 	case entity.TransactionTypeWithdrawal:
 		return "withdrawal"
+	case entity.TransactionTypeIncome:
+		return "income"
 	default:
 		return "unspecified"
 	}
@@ -1265,6 +2131,8 @@ func stringToTransactionType(s string) entity.TransactionType {
 		return entity.TransactionTypeDeposit
 	case "withdrawal":
 		return entity.TransactionTypeWithdrawal
+	case "income":
+		return entity.TransactionTypeIncome
 	default:
 		return entity.TransactionTypeUnspecified
 	}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/foxcool/greedy-eye/internal/store"
+)
+
+// ListPriceSourceStats counts the prices of each pair, interval and source
+// in the range. A source's last price is looked for before the end of the
+// range without a lower bound, so sources that stopped before it still
+// show up.
+func (s *MarketDataStore) ListPriceSourceStats(ctx context.Context, opts marketdata.PriceQualityOpts) ([]*entity.PriceSourceStats, error) {
+	where, args, err := s.priceQualityFilter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, fmt.Sprintf(`
+		SELECT a.uuid, ba.uuid, p.interval, p.source_id,
+			COUNT(*) FILTER (WHERE p.timestamp >= $1),
+			COUNT(*) FILTER (WHERE p.timestamp >= $1 AND p.quarantine IS NOT NULL),
+			MAX(p.timestamp) FILTER (WHERE p.quarantine IS NULL)
+		FROM prices p
+		JOIN assets a ON p.asset_id = a.id
+		JOIN assets ba ON p.base_asset_id = ba.id
+		WHERE p.timestamp < $2 %s
+		GROUP BY a.uuid, ba.uuid, p.interval, p.source_id
+		ORDER BY a.uuid, ba.uuid, p.interval, p.source_id`, where), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price source stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []*entity.PriceSourceStats
+	for rows.Next() {
+		var st entity.PriceSourceStats
+		var last *time.Time
+		if err := rows.Scan(&st.AssetID, &st.BaseAssetID, &st.Interval, &st.SourceID, &st.Count, &st.Quarantined, &last); err != nil {
+			return nil, fmt.Errorf("failed to scan price source stats: %w", err)
+		}
+		if last != nil {
+			st.Last = *last
+		}
+		stats = append(stats, &st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate price source stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// ListPriceGaps compares each unquarantined price in the range with the one
+// before it of the same pair, whatever its source.
+func (s *MarketDataStore) ListPriceGaps(ctx context.Context, opts marketdata.PriceQualityOpts) ([]*entity.PriceGap, error) {
+	if opts.Interval == "" || opts.MinSpacing <= 0 {
+		return nil, fmt.Errorf("%w: interval and spacing are required", store.ErrInvalidArgument)
+	}
+	where, args, err := s.priceQualityFilter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, opts.MinSpacing.Seconds())
+
+	rows, err := s.pool.Query(ctx, fmt.Sprintf(`
+		SELECT asset, base, interval, prev, ts
+		FROM (
+			SELECT a.uuid AS asset, ba.uuid AS base, p.interval, p.timestamp AS ts,
+				LAG(p.timestamp) OVER (PARTITION BY p.asset_id, p.base_asset_id ORDER BY p.timestamp) AS prev
+			FROM prices p
+			JOIN assets a ON p.asset_id = a.id
+			JOIN assets ba ON p.base_asset_id = ba.id
+			WHERE p.timestamp >= $1 AND p.timestamp < $2 AND p.quarantine IS NULL %s
+		) s
+		WHERE EXTRACT(EPOCH FROM ts - prev) > $%d
+		ORDER BY asset, base, prev`, where, len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price gaps: %w", err)
+	}
+	defer rows.Close()
+
+	var gaps []*entity.PriceGap
+	for rows.Next() {
+		var gap entity.PriceGap
+		if err := rows.Scan(&gap.AssetID, &gap.BaseAssetID, &gap.Interval, &gap.From, &gap.To); err != nil {
+			return nil, fmt.Errorf("failed to scan price gap: %w", err)
+		}
+		gaps = append(gaps, &gap)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate price gaps: %w", err)
+	}
+
+	return gaps, nil
+}
+
+// priceQualityFilter returns the conditions on prices p for the filters of
+// opts, after the range bounds as $1 and $2.
+func (s *MarketDataStore) priceQualityFilter(ctx context.Context, opts marketdata.PriceQualityOpts) (string, []any, error) {
+	if opts.From.IsZero() || !opts.From.Before(opts.To) {
+		return "", nil, fmt.Errorf("%w: range is empty", store.ErrInvalidArgument)
+	}
+	args := []any{opts.From, opts.To}
+	var clauses []string
+	if opts.AssetID != "" {
+		id, err := s.getAssetInternalID(ctx, opts.AssetID)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, id)
+		clauses = append(clauses, fmt.Sprintf("AND p.asset_id = $%d", len(args)))
+	}
+	if opts.BaseAssetID != "" {
+		id, err := s.getAssetInternalID(ctx, opts.BaseAssetID)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, id)
+		clauses = append(clauses, fmt.Sprintf("AND p.base_asset_id = $%d", len(args)))
+	}
+	if opts.Interval != "" {
+		args = append(args, opts.Interval)
+		clauses = append(clauses, fmt.Sprintf("AND p.interval = $%d", len(args)))
+	}
+	return strings.Join(clauses, " "), args, nil
+}
@@ -0,0 +1,100 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/marketdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceQuality(t *testing.T) {
+	ctx := context.Background()
+	pool := getTestPool(t)
+	s := NewMarketDataStore(pool)
+	asset := createTestAsset(t, s, "QualityAsset")
+	base := createTestAsset(t, s, "QualityBase")
+
+	day := func(d int) time.Time { return time.Date(2025, time.May, d, 20, 0, 0, 0, time.UTC) }
+	price := func(source string, last int64, at time.Time, quarantine string) *entity.StoredPrice {
+		return &entity.StoredPrice{SourceID: source, AssetID: asset.ID, BaseAssetID: base.ID, Interval: "1d",
+			Decimals: 2, Last: last, Timestamp: at, Quarantine: quarantine}
+	}
+	for _, p := range []*entity.StoredPrice{
+		price("old", 10000, day(1).Add(-10*time.Hour), ""),
+		price("yahoo", 10100, day(1), ""),
+		price("yahoo", 10200, day(2), ""),
+		price("yahoo", 10300, day(3), ""),
+		price("yahoo", 10400, day(7), ""),
+		price("yahoo", 104000, day(8), entity.PriceQuarantineJump),
+	} {
+		_, err := s.CreatePrice(ctx, p)
+		require.NoError(t, err)
+	}
+
+	t.Run("Readers skip quarantined prices", func(t *testing.T) {
+		at, err := s.GetPriceAt(ctx, asset.ID, base.ID, day(9))
+		require.NoError(t, err)
+		assert.Equal(t, day(7), at.Timestamp.UTC())
+
+		latest, err := s.GetLatestPrice(ctx, asset.ID, base.ID, "")
+		require.NoError(t, err)
+		assert.EqualValues(t, 10400, latest.Last)
+
+		history, _, err := s.ListPriceHistory(ctx, marketdata.ListPriceHistoryOpts{AssetID: asset.ID, BaseAssetID: base.ID})
+		require.NoError(t, err)
+		assert.Len(t, history, 5)
+
+		history, _, err = s.ListPriceHistory(ctx, marketdata.ListPriceHistoryOpts{AssetID: asset.ID, BaseAssetID: base.ID, IncludeQuarantined: true})
+		require.NoError(t, err)
+		require.Len(t, history, 6)
+		assert.Equal(t, entity.PriceQuarantineJump, history[5].Quarantine)
+	})
+
+	t.Run("Latest price of each source", func(t *testing.T) {
+		opts := marketdata.LatestPricesOpts{AssetID: asset.ID, BaseAssetID: base.ID, From: day(1).Add(-24 * time.Hour), To: day(10)}
+		latest, err := s.ListLatestPrices(ctx, opts)
+		require.NoError(t, err)
+		require.Len(t, latest, 2)
+		assert.Equal(t, "old", latest[0].SourceID)
+		assert.Equal(t, day(7), latest[1].Timestamp.UTC())
+
+		opts.Quarantined = true
+		latest, err = s.ListLatestPrices(ctx, opts)
+		require.NoError(t, err)
+		require.Len(t, latest, 1)
+		assert.Equal(t, day(8), latest[0].Timestamp.UTC())
+	})
+
+	t.Run("Source stats", func(t *testing.T) {
+		stats, err := s.ListPriceSourceStats(ctx, marketdata.PriceQualityOpts{AssetID: asset.ID, From: day(2), To: day(10)})
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+		assert.Equal(t, "old", stats[0].SourceID)
+		assert.Zero(t, stats[0].Count)
+		assert.Equal(t, day(1).Add(-10*time.Hour), stats[0].Last.UTC())
+		assert.Equal(t, "yahoo", stats[1].SourceID)
+		assert.Equal(t, 4, stats[1].Count)
+		assert.Equal(t, 1, stats[1].Quarantined)
+		assert.Equal(t, day(7), stats[1].Last.UTC())
+	})
+
+	t.Run("Gaps", func(t *testing.T) {
+		gaps, err := s.ListPriceGaps(ctx, marketdata.PriceQualityOpts{
+			AssetID:    asset.ID,
+			Interval:   "1d",
+			From:       day(1).Add(-24 * time.Hour),
+			To:         day(10),
+			MinSpacing: 36 * time.Hour,
+		})
+		require.NoError(t, err)
+		require.Len(t, gaps, 1)
+		assert.Equal(t, day(3), gaps[0].From.UTC())
+		assert.Equal(t, day(7), gaps[0].To.UTC())
+	})
+}
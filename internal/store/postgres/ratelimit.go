@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/ratelimit"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RateLimitStore implements ratelimit.Store using PostgreSQL, so all
+// replicas share the same buckets. Refills are computed with the database
+// clock.
+type RateLimitStore struct {
+	pool *pgxpool.Pool
+}
+
+// Compile-time interface implementation check.
+var _ ratelimit.Store = (*RateLimitStore)(nil)
+
+func NewRateLimitStore(pool *pgxpool.Pool) *RateLimitStore {
+	return &RateLimitStore{pool: pool}
+}
+
+// refilledTokens is the token count of bucket b now, given rate $2 and
+// burst $3.
+const refilledTokens = `LEAST($3::float8, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at)::float8 * $2::float8)`
+
+func (s *RateLimitStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (bool, time.Duration, error) {
+	burst := float64(max(limit.Burst, 1))
+
+	// The update only happens when a token is available, so a missing row
+	// means the bucket is empty.
+	var tokens float64
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO rate_limit_buckets AS b (key, tokens, updated_at)
+		VALUES ($1, $3::float8 - 1, NOW())
+		ON CONFLICT (key) DO UPDATE
+		SET tokens = `+refilledTokens+` - 1, updated_at = NOW()
+		WHERE `+refilledTokens+` >= 1
+		RETURNING tokens`,
+		key, limit.Rate, burst).Scan(&tokens)
+	if err == nil {
+		return true, 0, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return false, 0, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+
+	var wait float64
+	err = s.pool.QueryRow(ctx, `
+		SELECT (1 - `+refilledTokens+`) / $2::float8
+		FROM rate_limit_buckets b
+		WHERE key = $1`,
+		key, limit.Rate, burst).Scan(&wait)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to read rate limit bucket: %w", err)
+	}
+	return false, time.Duration(max(wait, 0) * float64(time.Second)), nil
+}
+
+func (s *RateLimitStore) Prune(ctx context.Context, idle time.Duration) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM rate_limit_buckets WHERE updated_at < NOW() - make_interval(secs => $1)`, idle.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to prune rate limit buckets: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitStore(t *testing.T) {
+	pool := getTestPool(t)
+	ctx := context.Background()
+	s := NewRateLimitStore(pool)
+	limit := ratelimit.Limit{Rate: 0.1, Burst: 2}
+
+	for range 2 {
+		ok, _, err := s.Take(ctx, "read:key:1", limit)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+	ok, retryAfter, err := s.Take(ctx, "read:key:1", limit)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.InDelta(t, 10*time.Second, retryAfter, float64(time.Second))
+
+	// Replicas sharing the table share the bucket.
+	ok, _, err = NewRateLimitStore(pool).Take(ctx, "read:key:1", limit)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, _, err = s.Take(ctx, "read:key:2", limit)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, s.Prune(ctx, 0))
+	ok, _, err = s.Take(ctx, "read:key:1", limit)
+	require.NoError(t, err)
+	assert.True(t, ok, "a pruned bucket starts full")
+}
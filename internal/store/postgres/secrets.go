@@ -0,0 +1,212 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/secrets"
+	"github.com/foxcool/greedy-eye/internal/store"
+)
+
+// sealedSecrets are the encrypted secret columns of an account row. All
+// fields are nil when the account has no secrets.
+type sealedSecrets struct {
+	ciphertext []byte
+	dataKey    []byte
+	keyVersion *int32
+}
+
+// sealSecrets encrypts account secrets, bound to the account ID so sealed
+// values cannot be moved between rows.
+func (s *PortfolioStore) sealSecrets(accountID string, values map[string]string) (sealedSecrets, error) {
+	if len(values) == 0 {
+		return sealedSecrets{}, nil
+	}
+	if s.keys == nil {
+		return sealedSecrets{}, fmt.Errorf("%w: storing account secrets requires a configured master key", store.ErrInvalidArgument)
+	}
+
+	plaintext, err := json.Marshal(values)
+	if err != nil {
+		return sealedSecrets{}, fmt.Errorf("failed to marshal secrets: %w", err)
+	}
+	sealed, err := s.keys.Seal(plaintext, []byte(accountID))
+	if err != nil {
+		return sealedSecrets{}, fmt.Errorf("failed to encrypt secrets: %w", err)
+	}
+
+	version := int32(sealed.KeyVersion)
+	return sealedSecrets{ciphertext: sealed.Ciphertext, dataKey: sealed.DataKey, keyVersion: &version}, nil
+}
+
+func (s *PortfolioStore) openSecrets(accountID string, sealed sealedSecrets) (map[string]string, error) {
+	if sealed.ciphertext == nil {
+		return nil, nil
+	}
+	if s.keys == nil || sealed.keyVersion == nil {
+		return nil, fmt.Errorf("account %s has secrets but no master key is configured", accountID)
+	}
+
+	plaintext, err := s.keys.Open(&secrets.Sealed{
+		KeyVersion: uint32(*sealed.keyVersion),
+		DataKey:    sealed.dataKey,
+		Ciphertext: sealed.ciphertext,
+	}, []byte(accountID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets of account %s: %w", accountID, err)
+	}
+
+	var values map[string]string
+	if err := json.Unmarshal(plaintext, &values); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal secrets: %w", err)
+	}
+	return values, nil
+}
+
+// loadSecrets opens the sealed secrets of account a and moves any secret
+// fields still stored in plain data, from before they were encrypted, over
+// to them, so they are redacted like the rest until SealPlaintextSecrets
+// encrypts them. Sealed values win over plain ones.
+func (s *PortfolioStore) loadSecrets(a *entity.Account, sealed sealedSecrets) error {
+	values, err := s.openSecrets(a.ID, sealed)
+	if err != nil {
+		return err
+	}
+	plain, legacy := secrets.Split(a.Data)
+	if len(legacy) > 0 {
+		a.Data = plain
+		if values == nil {
+			values = make(map[string]string, len(legacy))
+		}
+		for k, v := range legacy {
+			if _, ok := values[k]; !ok {
+				values[k] = v
+			}
+		}
+	}
+	a.Secrets = values
+	return nil
+}
+
+// SealPlaintextSecrets encrypts secret fields left in the plain data of
+// accounts created before secrets were encrypted, removing them from the
+// data, and returns how many accounts it changed. Without a master key it
+// does nothing; reads redact such fields regardless.
+func (s *PortfolioStore) SealPlaintextSecrets(ctx context.Context) (int, error) {
+	if s.keys == nil {
+		return 0, nil
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, uuid, data, secrets, secrets_key, secrets_key_version
+		FROM accounts
+		WHERE data IS NOT NULL AND data <> '{}'::jsonb`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list account data: %w", err)
+	}
+
+	type plainAccount struct {
+		id      int64
+		account entity.Account
+		data    []byte
+		sealed  sealedSecrets
+	}
+	var found []plainAccount
+	for rows.Next() {
+		var p plainAccount
+		if err := rows.Scan(&p.id, &p.account.ID, &p.data, &p.sealed.ciphertext, &p.sealed.dataKey, &p.sealed.keyVersion); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan account data: %w", err)
+		}
+		if err := json.Unmarshal(p.data, &p.account.Data); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to unmarshal data of account %s: %w", p.account.ID, err)
+		}
+		if _, legacy := secrets.Split(p.account.Data); len(legacy) > 0 {
+			found = append(found, p)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to list account data: %w", err)
+	}
+
+	for i, p := range found {
+		a := p.account
+		if err := s.loadSecrets(&a, p.sealed); err != nil {
+			return i, err
+		}
+		dataJSON, err := json.Marshal(a.Data)
+		if err != nil {
+			return i, fmt.Errorf("failed to marshal data: %w", err)
+		}
+		sealed, err := s.sealSecrets(a.ID, a.Secrets)
+		if err != nil {
+			return i, err
+		}
+		// Comparing the data keeps a concurrent update intact.
+		if _, err := s.pool.Exec(ctx, `
+			UPDATE accounts SET data = $2, secrets = $3, secrets_key = $4, secrets_key_version = $5
+			WHERE id = $1 AND data = $6`,
+			p.id, dataJSON, sealed.ciphertext, sealed.dataKey, sealed.keyVersion, p.data); err != nil {
+			return i, fmt.Errorf("failed to store sealed secrets: %w", err)
+		}
+	}
+
+	return len(found), nil
+}
+
+// RotateAccountSecrets rewraps the data keys of all account secrets that are
+// not wrapped with the current master key, and returns how many it rewrapped.
+// Old master keys can be removed from the configuration afterwards.
+func (s *PortfolioStore) RotateAccountSecrets(ctx context.Context) (int, error) {
+	if s.keys == nil {
+		return 0, nil
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, secrets_key, secrets_key_version
+		FROM accounts
+		WHERE secrets IS NOT NULL AND secrets_key_version <> $1`,
+		int32(s.keys.CurrentVersion()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list account secrets: %w", err)
+	}
+
+	type staleKey struct {
+		id      int64
+		dataKey []byte
+		version int32
+	}
+	var stale []staleKey
+	for rows.Next() {
+		var k staleKey
+		if err := rows.Scan(&k.id, &k.dataKey, &k.version); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan account secrets: %w", err)
+		}
+		stale = append(stale, k)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to list account secrets: %w", err)
+	}
+
+	for i, k := range stale {
+		rewrapped, _, err := s.keys.Rewrap(&secrets.Sealed{KeyVersion: uint32(k.version), DataKey: k.dataKey})
+		if err != nil {
+			return i, fmt.Errorf("failed to rewrap secrets of account %d: %w", k.id, err)
+		}
+		// The version check keeps a concurrent update's fresh key intact.
+		if _, err := s.pool.Exec(ctx, `
+			UPDATE accounts SET secrets_key = $2, secrets_key_version = $3
+			WHERE id = $1 AND secrets_key_version = $4`,
+			k.id, rewrapped.DataKey, int32(rewrapped.KeyVersion), k.version); err != nil {
+			return i, fmt.Errorf("failed to store rewrapped secrets: %w", err)
+		}
+	}
+
+	return len(stale), nil
+}
@@ -0,0 +1,126 @@
+//go:build integration
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/secrets"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountSecrets(t *testing.T) {
+	pool := getTestPool(t)
+	ctx := context.Background()
+
+	oldKeys, err := secrets.NewKeyring(map[uint32][]byte{1: bytes.Repeat([]byte{1}, 32)})
+	require.NoError(t, err)
+	s := NewPortfolioStore(pool, oldKeys)
+
+	user, err := NewSettingsStore(pool).CreateUser(ctx, &entity.User{Email: "alice@example.com", Name: "Alice"})
+	require.NoError(t, err)
+
+	created, err := s.CreateAccount(ctx, &entity.Account{
+		UserID:  user.ID,
+		Name:    "Binance",
+		Type:    entity.AccountTypeExchange,
+		Data:    map[string]string{"exchange": "binance"},
+		Secrets: map[string]string{"api_key": "AK", "api_secret": "s3cr3t"},
+	})
+	require.NoError(t, err)
+
+	t.Run("stored encrypted", func(t *testing.T) {
+		var data, sealed []byte
+		err := pool.QueryRow(ctx, "SELECT data, secrets FROM accounts WHERE uuid = $1", created.ID).Scan(&data, &sealed)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "s3cr3t")
+		assert.NotContains(t, string(sealed), "s3cr3t")
+	})
+
+	t.Run("decrypted on read", func(t *testing.T) {
+		got, err := s.GetAccount(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"exchange": "binance"}, got.Data)
+		assert.Equal(t, map[string]string{"api_key": "AK", "api_secret": "s3cr3t"}, got.Secrets)
+	})
+
+	t.Run("update replaces secrets", func(t *testing.T) {
+		updated, err := s.UpdateAccount(ctx, &entity.Account{
+			ID:      created.ID,
+			Data:    map[string]string{"exchange": "binance"},
+			Secrets: map[string]string{"api_key": "AK2"},
+		}, []string{"data"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"api_key": "AK2"}, updated.Secrets)
+	})
+
+	t.Run("rotation", func(t *testing.T) {
+		newKeys, err := secrets.NewKeyring(map[uint32][]byte{
+			1: bytes.Repeat([]byte{1}, 32),
+			2: bytes.Repeat([]byte{2}, 32),
+		})
+		require.NoError(t, err)
+		rotated := NewPortfolioStore(pool, newKeys)
+
+		n, err := rotated.RotateAccountSecrets(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		n, err = rotated.RotateAccountSecrets(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+
+		onlyNew, err := secrets.NewKeyring(map[uint32][]byte{2: bytes.Repeat([]byte{2}, 32)})
+		require.NoError(t, err)
+		got, err := NewPortfolioStore(pool, onlyNew).GetAccount(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "AK2", got.Secrets["api_key"])
+	})
+
+	t.Run("no master key", func(t *testing.T) {
+		plain := NewPortfolioStore(pool, nil)
+		_, err := plain.CreateAccount(ctx, &entity.Account{
+			UserID: user.ID, Name: "Kraken", Type: entity.AccountTypeExchange,
+			Secrets: map[string]string{"api_key": "AK"},
+		})
+		assert.ErrorIs(t, err, store.ErrInvalidArgument)
+
+		_, err = plain.GetAccount(ctx, created.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("plaintext secrets from before encryption", func(t *testing.T) {
+		legacy, err := s.CreateAccount(ctx, &entity.Account{
+			UserID: user.ID, Name: "Legacy", Type: entity.AccountTypeWallet,
+			Data: map[string]string{"address": "0xabc", "public_key": "pub"},
+		})
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, `UPDATE accounts SET data = data || '{"api_key": "AK", "seed": "words"}' WHERE uuid = $1`, legacy.ID)
+		require.NoError(t, err)
+
+		// Redacted on read even without a master key.
+		got, err := NewPortfolioStore(pool, nil).GetAccount(ctx, legacy.ID)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"address": "0xabc", "public_key": "pub"}, got.Data)
+		assert.Equal(t, map[string]string{"api_key": "AK", "seed": "words"}, got.Secrets)
+
+		n, err := s.SealPlaintextSecrets(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		n, err = s.SealPlaintextSecrets(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+
+		var data []byte
+		require.NoError(t, pool.QueryRow(ctx, "SELECT data FROM accounts WHERE uuid = $1", legacy.ID).Scan(&data))
+		assert.NotContains(t, string(data), "AK")
+		got, err = s.GetAccount(ctx, legacy.ID)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"api_key": "AK", "seed": "words"}, got.Secrets)
+	})
+}
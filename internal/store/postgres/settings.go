@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/foxcool/greedy-eye/internal/audit"
 	"github.com/foxcool/greedy-eye/internal/entity"
 	"github.com/foxcool/greedy-eye/internal/service/settings"
 	"github.com/foxcool/greedy-eye/internal/store"
@@ -63,6 +64,7 @@ func (s *SettingsStore) CreateUser(ctx context.Context, u *entity.User) (*entity
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	audit.RecordChange(ctx, "user", u.ID, nil, u)
 	return u, nil
 }
 
@@ -74,13 +76,14 @@ func (s *SettingsStore) GetUser(ctx context.Context, id string) (*entity.User, e
 		return nil, fmt.Errorf("%w: invalid user ID format", store.ErrInvalidArgument)
 	}
 
+	filter, args := tenantFilter(ctx, "id", []any{id})
 	query := `
 		SELECT uuid, email, name, preferences, created_at, updated_at
 		FROM users
-		WHERE uuid = $1`
+		WHERE uuid = $1 AND ` + filter
 
 	var u entity.User
-	err := s.pool.QueryRow(ctx, query, id).Scan(
+	err := s.pool.QueryRow(ctx, query, args...).Scan(
 		&u.ID,
 		&u.Email,
 		&u.Name,
@@ -103,13 +106,14 @@ func (s *SettingsStore) GetUserByEmail(ctx context.Context, email string) (*enti
 		return nil, fmt.Errorf("%w: email is required", store.ErrInvalidArgument)
 	}
 
+	filter, args := tenantFilter(ctx, "id", []any{email})
 	query := `
 		SELECT uuid, email, name, preferences, created_at, updated_at
 		FROM users
-		WHERE email = $1`
+		WHERE email = $1 AND ` + filter
 
 	var u entity.User
-	err := s.pool.QueryRow(ctx, query, email).Scan(
+	err := s.pool.QueryRow(ctx, query, args...).Scan(
 		&u.ID,
 		&u.Email,
 		&u.Name,
@@ -156,12 +160,14 @@ func (s *SettingsStore) UpdateUser(ctx context.Context, u *entity.User, fields [
 		}
 	}
 
+	before := auditBefore(ctx, s.GetUser, u.ID)
+	filter, args := tenantFilter(ctx, "id", args)
 	query := fmt.Sprintf(`
 		UPDATE users
 		SET %s
-		WHERE uuid = $1
+		WHERE uuid = $1 AND %s
 		RETURNING uuid, email, name, preferences, created_at, updated_at`,
-		strings.Join(setClauses, ", "))
+		strings.Join(setClauses, ", "), filter)
 
 	var result entity.User
 	err := s.pool.QueryRow(ctx, query, args...).Scan(
@@ -182,6 +188,7 @@ func (s *SettingsStore) UpdateUser(ctx context.Context, u *entity.User, fields [
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
+	audit.RecordChange(ctx, "user", result.ID, before, &result)
 	return &result, nil
 }
 
@@ -193,7 +200,9 @@ func (s *SettingsStore) DeleteUser(ctx context.Context, id string) error {
 		return fmt.Errorf("%w: invalid user ID format", store.ErrInvalidArgument)
 	}
 
-	result, err := s.pool.Exec(ctx, "DELETE FROM users WHERE uuid = $1", id)
+	before := auditBefore(ctx, s.GetUser, id)
+	filter, args := tenantFilter(ctx, "id", []any{id})
+	result, err := s.pool.Exec(ctx, "DELETE FROM users WHERE uuid = $1 AND "+filter, args...)
 	if err != nil {
 		if isConstraintError(err) {
 			return fmt.Errorf("%w: cannot delete user due to existing dependencies", store.ErrConstraint)
@@ -205,6 +214,7 @@ func (s *SettingsStore) DeleteUser(ctx context.Context, id string) error {
 		return fmt.Errorf("%w: user with ID %s", store.ErrNotFound, id)
 	}
 
+	audit.RecordChange(ctx, "user", id, before, nil)
 	return nil
 }
 
@@ -218,6 +228,11 @@ func (s *SettingsStore) ListUsers(ctx context.Context, opts settings.ListUsersOp
 	argIdx := 1
 	whereClauses := []string{}
 
+	var filter string
+	filter, args = tenantFilter(ctx, "id", args)
+	whereClauses = append(whereClauses, filter)
+	argIdx = len(args) + 1
+
 	if opts.PageToken != "" {
 		decoded, err := base64.StdEncoding.DecodeString(opts.PageToken)
 		if err == nil && isValidUUID(string(decoded)) {
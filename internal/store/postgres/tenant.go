@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/store"
+)
+
+// tenantUserID returns the user whose rows a request may touch, or "" when
+// the request is unscoped: background work without a principal, or an
+// admin. Assets and prices are shared reference data and never scoped.
+func tenantUserID(ctx context.Context) string {
+	p, ok := auth.FromContext(ctx)
+	if !ok || p.HasScope(auth.ScopeAdmin) {
+		return ""
+	}
+	return p.UserID
+}
+
+// tenantFilter returns an SQL condition limiting ownerExpr, an expression
+// yielding an internal users.id, to the request's user, with its argument
+// appended to args. Unscoped requests get "TRUE". Rows owned by other users
+// are thus indistinguishable from missing ones.
+func tenantFilter(ctx context.Context, ownerExpr string, args []any) (string, []any) {
+	userID := tenantUserID(ctx)
+	if userID == "" {
+		return "TRUE", args
+	}
+	args = append(args, userID)
+	return fmt.Sprintf("%s = (SELECT id FROM users WHERE uuid = $%d)", ownerExpr, len(args)), args
+}
+
+// Owner expressions for tables that reference their user indirectly, usable
+// in UPDATE and DELETE statements on the table itself.
+const (
+	holdingOwner     = "(SELECT user_id FROM accounts WHERE accounts.id = holdings.account_id)"
+	lotOwner         = "(SELECT acc.user_id FROM holdings hh JOIN accounts acc ON acc.id = hh.account_id WHERE hh.id = lots.holding_id)"
+	transactionOwner = "(SELECT user_id FROM accounts WHERE accounts.id = transactions.account_id)"
+)
+
+// lotPortfolio is the portfolio of a lot's holding, usable in UPDATE and
+// DELETE statements on lots.
+const lotPortfolio = "(SELECT portfolio_id FROM holdings WHERE holdings.id = lots.holding_id)"
+
+// executionPortfolio is the portfolio of a rule execution, usable in UPDATE
+// statements on rule_executions.
+const executionPortfolio = "(SELECT portfolio_id FROM rules WHERE rules.id = rule_executions.rule_id)"
+
+// portfolioFilter returns an SQL condition limiting portfolioExpr, an
+// expression yielding an internal portfolios.id, to portfolios the request's
+// user created or is an active member of with at least role. Unscoped
+// requests get "TRUE".
+func portfolioFilter(ctx context.Context, portfolioExpr string, role entity.PortfolioRole, args []any) (string, []any) {
+	userID := tenantUserID(ctx)
+	if userID == "" {
+		return "TRUE", args
+	}
+
+	var roles []string
+	for r := role; r <= entity.PortfolioRoleOwner; r++ {
+		roles = append(roles, portfolioRoleToString(r))
+	}
+	args = append(args, userID, roles)
+	user := fmt.Sprintf("(SELECT id FROM users WHERE uuid = $%d)", len(args)-1)
+	return fmt.Sprintf(`%s IN (
+			SELECT id FROM portfolios WHERE user_id = %s
+			UNION ALL
+			SELECT portfolio_id FROM portfolio_members
+			WHERE user_id = %s AND status = 'active' AND role = ANY($%d)
+		)`, portfolioExpr, user, user, len(args)), args
+}
+
+// holdingFilter returns an SQL condition limiting holdings to those in
+// accounts the request's user owns, or in portfolios they hold at least role
+// on. ownerExpr yields the account's users.id, portfolioExpr the holding's
+// portfolios.id.
+func holdingFilter(ctx context.Context, ownerExpr, portfolioExpr string, role entity.PortfolioRole, args []any) (string, []any) {
+	if tenantUserID(ctx) == "" {
+		return "TRUE", args
+	}
+	owner, args := tenantFilter(ctx, ownerExpr, args)
+	member, args := portfolioFilter(ctx, portfolioExpr, role, args)
+	return "(" + owner + " OR " + member + ")", args
+}
+
+// accountFilter returns an SQL condition limiting accounts to those the
+// request's user owns, or that have a holding in a portfolio they hold at
+// least role on. Members of a shared portfolio thus name its accounts when
+// listing its holdings and transactions, though they do not reach the
+// accounts themselves. ownerExpr yields the account's users.id, accountExpr
+// its accounts.id.
+func accountFilter(ctx context.Context, ownerExpr, accountExpr string, role entity.PortfolioRole, args []any) (string, []any) {
+	if tenantUserID(ctx) == "" {
+		return "TRUE", args
+	}
+	owner, args := tenantFilter(ctx, ownerExpr, args)
+	member, args := portfolioFilter(ctx, "hh.portfolio_id", role, args)
+	return fmt.Sprintf("(%s OR EXISTS (SELECT 1 FROM holdings hh WHERE hh.account_id = %s AND %s))", owner, accountExpr, member), args
+}
+
+// transactionHolding returns an SQL condition that holding hh is one a
+// transaction touches: a holding in its account of its asset, of the asset
+// its trade was quoted in, or of the asset its income was paid on.
+// accountExpr yields the transaction's accounts.id, assetExpr its assets.id
+// and dataExpr its data.
+func transactionHolding(accountExpr, assetExpr, dataExpr string) string {
+	return fmt.Sprintf(`hh.account_id = %s AND (hh.asset_id = %s OR hh.asset_id IN (
+			SELECT id FROM assets WHERE uuid::text IN (%s->>'quote_asset_id', %s->>'source_asset_id')))`,
+		accountExpr, assetExpr, dataExpr, dataExpr)
+}
+
+// transactionFilter returns an SQL condition limiting transactions to those
+// of accounts the request's user owns, or that touch a holding in a
+// portfolio they hold at least role on. Members of a shared portfolio thus
+// reach the transactions of its holdings, not every transaction of their
+// accounts. ownerExpr yields the account's users.id; accountExpr, assetExpr
+// and dataExpr are as for transactionHolding.
+func transactionFilter(ctx context.Context, ownerExpr, accountExpr, assetExpr, dataExpr string, role entity.PortfolioRole, args []any) (string, []any) {
+	if tenantUserID(ctx) == "" {
+		return "TRUE", args
+	}
+	owner, args := tenantFilter(ctx, ownerExpr, args)
+	member, args := portfolioFilter(ctx, "hh.portfolio_id", role, args)
+	return fmt.Sprintf("(%s OR EXISTS (SELECT 1 FROM holdings hh WHERE %s AND %s))",
+		owner, transactionHolding(accountExpr, assetExpr, dataExpr), member), args
+}
+
+// deniedOrNotFound explains a scoped change that matched no row: a caller
+// who can view the row lacks the role to change it; to anyone else the row
+// does not exist.
+func deniedOrNotFound[T any](ctx context.Context, get func(context.Context, string) (*T, error), id string, notFound error) error {
+	if tenantUserID(ctx) != "" {
+		if _, err := get(ctx, id); err == nil {
+			return fmt.Errorf("%w: your portfolio role does not permit this", store.ErrPermissionDenied)
+		}
+	}
+	return notFound
+}
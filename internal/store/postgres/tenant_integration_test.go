@@ -0,0 +1,778 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/foxcool/greedy-eye/internal/api/v1"
+	"github.com/foxcool/greedy-eye/internal/api/v1/apiv1connect"
+	"github.com/foxcool/greedy-eye/internal/auth"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/pubsub"
+	"github.com/foxcool/greedy-eye/internal/service/automation"
+	"github.com/foxcool/greedy-eye/internal/service/portfolio"
+	"github.com/foxcool/greedy-eye/internal/service/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// asPrincipal attaches a fixed principal to every request, standing in for
+// the authentication interceptor.
+type asPrincipal struct{ p *auth.Principal }
+
+func (i asPrincipal) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return next(auth.WithPrincipal(ctx, i.p), req)
+	}
+}
+
+func (i asPrincipal) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i asPrincipal) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		return next(auth.WithPrincipal(ctx, i.p), conn)
+	}
+}
+
+// victim is everything one user owns, created without a principal.
+type victim struct {
+	user      *entity.User
+	portfolio *entity.Portfolio
+	account   *entity.Account
+	holding   *entity.Holding
+	lot       *entity.Lot
+	tx        *entity.Transaction
+	rule      *entity.Rule
+	execution *entity.RuleExecution
+}
+
+func createVictim(t *testing.T, users *SettingsStore, portfolios *PortfolioStore, rules *AutomationStore, assetID string) *victim {
+	t.Helper()
+	ctx := context.Background()
+	v := &victim{}
+
+	var err error
+	v.user, err = users.CreateUser(ctx, &entity.User{Email: "alice@example.com", Name: "Alice"})
+	require.NoError(t, err)
+	v.portfolio, err = portfolios.CreatePortfolio(ctx, &entity.Portfolio{UserID: v.user.ID, Name: "Main"})
+	require.NoError(t, err)
+	v.account, err = portfolios.CreateAccount(ctx, &entity.Account{UserID: v.user.ID, Name: "Exchange", Type: entity.AccountTypeExchange})
+	require.NoError(t, err)
+	v.holding, err = portfolios.CreateHolding(ctx, &entity.Holding{
+		Amount: 100, Decimals: 0, AssetID: assetID, AccountID: v.account.ID, PortfolioID: v.portfolio.ID,
+	})
+	require.NoError(t, err)
+	v.lot, err = portfolios.CreateLot(ctx, &entity.Lot{
+		HoldingID: v.holding.ID, Amount: 100, CostBasis: 1000, CostAssetID: assetID, AcquiredAt: time.Now(),
+	})
+	require.NoError(t, err)
+	v.tx, err = portfolios.CreateTransaction(ctx, &entity.Transaction{
+		Type: entity.TransactionTypeDeposit, Status: entity.TransactionStatusCompleted, AccountID: v.account.ID, AssetID: assetID,
+	})
+	require.NoError(t, err)
+	v.rule, err = rules.CreateRule(ctx, &entity.Rule{
+		Name:          "Withdraw",
+		RuleType:      "monthly_withdrawal",
+		PortfolioID:   v.portfolio.ID,
+		UserID:        v.user.ID,
+		Status:        entity.RuleStatusActive,
+		Configuration: map[string]any{"amount": "10", "currency_asset_id": assetID},
+	})
+	require.NoError(t, err)
+	v.execution, err = rules.CreateRuleExecution(ctx, &entity.RuleExecution{RuleID: v.rule.ID})
+	require.NoError(t, err)
+
+	return v
+}
+
+// TestTenantIsolation calls every portfolio, automation and settings RPC as
+// one user against another user's data. Each call must fail as if the data
+// did not exist, and list calls must not return it.
+func TestTenantIsolation(t *testing.T) {
+	pool := getTestPool(t)
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	users := NewSettingsStore(pool)
+	portfolios := NewPortfolioStore(pool, nil)
+	rules := NewAutomationStore(pool)
+	marketData := NewMarketDataStore(pool)
+
+	asset := createTestAsset(t, marketData, "Bitcoin")
+	alice := createVictim(t, users, portfolios, rules, asset.ID)
+	bob, err := users.CreateUser(ctx, &entity.User{Email: "bob@example.com", Name: "Bob"})
+	require.NoError(t, err)
+	// A member and income of Alice's for the lists and reports to leave out.
+	carol, err := users.CreateUser(ctx, &entity.User{Email: "carol@example.com", Name: "Carol"})
+	require.NoError(t, err)
+	_, err = portfolios.InvitePortfolioMember(ctx, &entity.PortfolioMember{
+		PortfolioID: alice.portfolio.ID, UserID: carol.ID, Role: entity.PortfolioRoleViewer, InvitedBy: alice.user.ID,
+	})
+	require.NoError(t, err)
+	_, err = portfolios.CreateTransaction(ctx, &entity.Transaction{
+		Type: entity.TransactionTypeIncome, Status: entity.TransactionStatusCompleted, AccountID: alice.account.ID, AssetID: asset.ID,
+		Data: map[string]string{"income": portfolio.IncomeStaking, "amount": "1"},
+	})
+	require.NoError(t, err)
+
+	events := pubsub.NewHub(log)
+	defer events.Close()
+
+	opts := connect.WithInterceptors(asPrincipal{&auth.Principal{
+		UserID: bob.ID,
+		Scopes: []string{auth.ScopeRead, auth.ScopeWrite},
+		Method: auth.MethodSession,
+	}})
+	mux := http.NewServeMux()
+	tax := portfolio.TaxConfig{DefaultJurisdiction: "us", Jurisdictions: map[string]portfolio.TaxJurisdiction{"us": {}}}
+	mux.Handle(apiv1connect.NewPortfolioServiceHandler(portfolio.NewHandler(portfolios, marketData, users, tax, portfolio.ImportConfig{}, log), opts))
+	mux.Handle(apiv1connect.NewAutomationServiceHandler(automation.NewHandler(rules, portfolios, marketData, events, log), opts))
+	mux.Handle(apiv1connect.NewSettingsServiceHandler(settings.NewHandler(users, marketData, log), opts))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	pc := apiv1connect.NewPortfolioServiceClient(srv.Client(), srv.URL)
+	ac := apiv1connect.NewAutomationServiceClient(srv.Client(), srv.URL)
+	sc := apiv1connect.NewSettingsServiceClient(srv.Client(), srv.URL)
+
+	mask := func(paths ...string) *fieldmaskpb.FieldMask { return &fieldmaskpb.FieldMask{Paths: paths} }
+	now := time.Now()
+
+	notFound := []struct {
+		name string
+		call func() error
+	}{
+		// PortfolioService
+		{"GetPortfolio", func() error {
+			_, err := pc.GetPortfolio(ctx, connect.NewRequest(&apiv1.GetPortfolioRequest{Id: alice.portfolio.ID}))
+			return err
+		}},
+		{"UpdatePortfolio", func() error {
+			_, err := pc.UpdatePortfolio(ctx, connect.NewRequest(&apiv1.UpdatePortfolioRequest{
+				Portfolio: &apiv1.Portfolio{Id: alice.portfolio.ID, Name: "Mine"}, UpdateMask: mask("name"),
+			}))
+			return err
+		}},
+		{"DeletePortfolio", func() error {
+			_, err := pc.DeletePortfolio(ctx, connect.NewRequest(&apiv1.DeletePortfolioRequest{Id: alice.portfolio.ID}))
+			return err
+		}},
+		{"ListPortfolios", func() error {
+			_, err := pc.ListPortfolios(ctx, connect.NewRequest(&apiv1.ListPortfoliosRequest{UserId: &alice.user.ID}))
+			return err
+		}},
+		{"CreatePortfolio", func() error {
+			_, err := pc.CreatePortfolio(ctx, connect.NewRequest(&apiv1.CreatePortfolioRequest{
+				Portfolio: &apiv1.Portfolio{UserId: alice.user.ID, Name: "Planted"},
+			}))
+			return err
+		}},
+		{"InvitePortfolioMember", func() error {
+			_, err := pc.InvitePortfolioMember(ctx, connect.NewRequest(&apiv1.InvitePortfolioMemberRequest{
+				PortfolioId: alice.portfolio.ID, Email: bob.Email, Role: apiv1.PortfolioRole_PORTFOLIO_ROLE_OWNER,
+			}))
+			return err
+		}},
+		{"AcceptPortfolioInvitation", func() error {
+			_, err := pc.AcceptPortfolioInvitation(ctx, connect.NewRequest(&apiv1.AcceptPortfolioInvitationRequest{
+				PortfolioId: alice.portfolio.ID,
+			}))
+			return err
+		}},
+		{"RevokePortfolioMember", func() error {
+			_, err := pc.RevokePortfolioMember(ctx, connect.NewRequest(&apiv1.RevokePortfolioMemberRequest{
+				PortfolioId: alice.portfolio.ID, UserId: alice.user.ID,
+			}))
+			return err
+		}},
+		{"CreateAccount", func() error {
+			_, err := pc.CreateAccount(ctx, connect.NewRequest(&apiv1.CreateAccountRequest{
+				Account: &apiv1.Account{UserId: alice.user.ID, Name: "Planted"},
+			}))
+			return err
+		}},
+		{"GetAccount", func() error {
+			_, err := pc.GetAccount(ctx, connect.NewRequest(&apiv1.GetAccountRequest{Id: alice.account.ID}))
+			return err
+		}},
+		{"UpdateAccount", func() error {
+			_, err := pc.UpdateAccount(ctx, connect.NewRequest(&apiv1.UpdateAccountRequest{
+				Account: &apiv1.Account{Id: alice.account.ID, Name: "Mine"}, UpdateMask: mask("name"),
+			}))
+			return err
+		}},
+		{"DeleteAccount", func() error {
+			_, err := pc.DeleteAccount(ctx, connect.NewRequest(&apiv1.DeleteAccountRequest{Id: alice.account.ID}))
+			return err
+		}},
+		{"ListAccounts", func() error {
+			_, err := pc.ListAccounts(ctx, connect.NewRequest(&apiv1.ListAccountsRequest{UserId: &alice.user.ID}))
+			return err
+		}},
+		{"CreateHolding", func() error {
+			_, err := pc.CreateHolding(ctx, connect.NewRequest(&apiv1.CreateHoldingRequest{
+				Holding: &apiv1.Holding{Amount: 1, AssetId: asset.ID, AccountId: alice.account.ID},
+			}))
+			return err
+		}},
+		{"GetHolding", func() error {
+			_, err := pc.GetHolding(ctx, connect.NewRequest(&apiv1.GetHoldingRequest{Id: alice.holding.ID}))
+			return err
+		}},
+		{"UpdateHolding", func() error {
+			_, err := pc.UpdateHolding(ctx, connect.NewRequest(&apiv1.UpdateHoldingRequest{
+				Holding: &apiv1.Holding{Id: alice.holding.ID, Amount: 0}, UpdateMask: mask("amount"),
+			}))
+			return err
+		}},
+		{"ListHoldings", func() error {
+			_, err := pc.ListHoldings(ctx, connect.NewRequest(&apiv1.ListHoldingsRequest{PortfolioId: &alice.portfolio.ID}))
+			return err
+		}},
+		{"CreateLot", func() error {
+			_, err := pc.CreateLot(ctx, connect.NewRequest(&apiv1.CreateLotRequest{
+				Lot: &apiv1.Lot{HoldingId: alice.holding.ID, Amount: 1, CostAssetId: asset.ID, AcquiredAt: timestamppb.New(now)},
+			}))
+			return err
+		}},
+		{"GetLot", func() error {
+			_, err := pc.GetLot(ctx, connect.NewRequest(&apiv1.GetLotRequest{Id: alice.lot.ID}))
+			return err
+		}},
+		{"UpdateLot", func() error {
+			_, err := pc.UpdateLot(ctx, connect.NewRequest(&apiv1.UpdateLotRequest{
+				Lot: &apiv1.Lot{Id: alice.lot.ID, Amount: 0}, UpdateMask: mask("amount"),
+			}))
+			return err
+		}},
+		{"ListLots", func() error {
+			_, err := pc.ListLots(ctx, connect.NewRequest(&apiv1.ListLotsRequest{HoldingId: &alice.holding.ID}))
+			return err
+		}},
+		{"CreateTransaction", func() error {
+			_, err := pc.CreateTransaction(ctx, connect.NewRequest(&apiv1.CreateTransactionRequest{
+				Transaction: &apiv1.Transaction{Type: apiv1.TransactionType_TRANSACTION_TYPE_DEPOSIT, AccountId: alice.account.ID},
+			}))
+			return err
+		}},
+		{"GetTransaction", func() error {
+			_, err := pc.GetTransaction(ctx, connect.NewRequest(&apiv1.GetTransactionRequest{Id: alice.tx.ID}))
+			return err
+		}},
+		{"UpdateTransaction", func() error {
+			_, err := pc.UpdateTransaction(ctx, connect.NewRequest(&apiv1.UpdateTransactionRequest{
+				Transaction: &apiv1.Transaction{Id: alice.tx.ID, Status: apiv1.TransactionStatus_TRANSACTION_STATUS_FAILED},
+				UpdateMask:  mask("status"),
+			}))
+			return err
+		}},
+		{"ListTransactions", func() error {
+			_, err := pc.ListTransactions(ctx, connect.NewRequest(&apiv1.ListTransactionsRequest{AccountId: &alice.account.ID}))
+			return err
+		}},
+		{"CalculatePortfolioValue", func() error {
+			_, err := pc.CalculatePortfolioValue(ctx, connect.NewRequest(&apiv1.CalculatePortfolioValueRequest{
+				PortfolioId: alice.portfolio.ID, QuoteAssetId: asset.ID,
+			}))
+			return err
+		}},
+		{"GetPortfolioPerformance", func() error {
+			_, err := pc.GetPortfolioPerformance(ctx, connect.NewRequest(&apiv1.GetPortfolioPerformanceRequest{PortfolioId: alice.portfolio.ID}))
+			return err
+		}},
+		{"GetIncomeSummary", func() error {
+			_, err := pc.GetIncomeSummary(ctx, connect.NewRequest(&apiv1.GetIncomeSummaryRequest{PortfolioId: alice.portfolio.ID}))
+			return err
+		}},
+		{"ImportTransactions", func() error {
+			_, err := pc.ImportTransactions(ctx, connect.NewRequest(&apiv1.ImportTransactionsRequest{
+				AccountId: alice.account.ID,
+				Format:    apiv1.ImportFormat_IMPORT_FORMAT_BINANCE_TRADES,
+				Content:   []byte("Date(UTC),Pair,Side,Price,Executed,Amount,Fee\n"),
+			}))
+			return err
+		}},
+		{"ExportPortfolio", func() error {
+			stream, err := pc.ExportPortfolio(ctx, connect.NewRequest(&apiv1.ExportPortfolioRequest{
+				PortfolioId: alice.portfolio.ID, Format: apiv1.ExportFormat_EXPORT_FORMAT_CSV,
+			}))
+			if err != nil {
+				return err
+			}
+			defer stream.Close()
+			for stream.Receive() {
+			}
+			return stream.Err()
+		}},
+
+		// AutomationService
+		{"GetRule", func() error {
+			_, err := ac.GetRule(ctx, connect.NewRequest(&apiv1.GetRuleRequest{Id: alice.rule.ID}))
+			return err
+		}},
+		{"UpdateRule", func() error {
+			_, err := ac.UpdateRule(ctx, connect.NewRequest(&apiv1.UpdateRuleRequest{
+				Rule: &apiv1.Rule{Id: alice.rule.ID, Name: "Mine"}, UpdateMask: mask("name"),
+			}))
+			return err
+		}},
+		{"DeleteRule", func() error {
+			_, err := ac.DeleteRule(ctx, connect.NewRequest(&apiv1.DeleteRuleRequest{Id: alice.rule.ID}))
+			return err
+		}},
+		{"ExecuteRule", func() error {
+			_, err := ac.ExecuteRule(ctx, connect.NewRequest(&apiv1.ExecuteRuleRequest{RuleId: alice.rule.ID, DryRun: true}))
+			return err
+		}},
+		{"ExecuteRuleAsync", func() error {
+			_, err := ac.ExecuteRuleAsync(ctx, connect.NewRequest(&apiv1.ExecuteRuleAsyncRequest{RuleId: alice.rule.ID, DryRun: true}))
+			return err
+		}},
+		{"CancelRuleExecution", func() error {
+			_, err := ac.CancelRuleExecution(ctx, connect.NewRequest(&apiv1.CancelRuleExecutionRequest{ExecutionId: alice.execution.ID}))
+			return err
+		}},
+		{"SimulateRule", func() error {
+			_, err := ac.SimulateRule(ctx, connect.NewRequest(&apiv1.SimulateRuleRequest{RuleId: alice.rule.ID}))
+			return err
+		}},
+		{"BacktestRule", func() error {
+			_, err := ac.BacktestRule(ctx, connect.NewRequest(&apiv1.BacktestRuleRequest{
+				RuleId: alice.rule.ID, From: timestamppb.New(now.AddDate(-1, 0, 0)), To: timestamppb.New(now),
+			}))
+			return err
+		}},
+		{"EnableRule", func() error {
+			_, err := ac.EnableRule(ctx, connect.NewRequest(&apiv1.EnableRuleRequest{RuleId: alice.rule.ID}))
+			return err
+		}},
+		{"DisableRule", func() error {
+			_, err := ac.DisableRule(ctx, connect.NewRequest(&apiv1.DisableRuleRequest{RuleId: alice.rule.ID}))
+			return err
+		}},
+		{"PauseRule", func() error {
+			_, err := ac.PauseRule(ctx, connect.NewRequest(&apiv1.PauseRuleRequest{RuleId: alice.rule.ID}))
+			return err
+		}},
+		{"ResumeRule", func() error {
+			_, err := ac.ResumeRule(ctx, connect.NewRequest(&apiv1.ResumeRuleRequest{RuleId: alice.rule.ID}))
+			return err
+		}},
+		{"CreateRuleExecution", func() error {
+			_, err := ac.CreateRuleExecution(ctx, connect.NewRequest(&apiv1.CreateRuleExecutionRequest{
+				RuleExecution: &apiv1.RuleExecution{RuleId: alice.rule.ID},
+			}))
+			return err
+		}},
+		{"GetRuleExecution", func() error {
+			_, err := ac.GetRuleExecution(ctx, connect.NewRequest(&apiv1.GetRuleExecutionRequest{Id: alice.execution.ID}))
+			return err
+		}},
+		{"UpdateRuleExecution", func() error {
+			_, err := ac.UpdateRuleExecution(ctx, connect.NewRequest(&apiv1.UpdateRuleExecutionRequest{
+				RuleExecution: &apiv1.RuleExecution{Id: alice.execution.ID, Status: apiv1.ExecutionStatus_EXECUTION_STATUS_FAILED},
+				UpdateMask:    mask("status"),
+			}))
+			return err
+		}},
+		{"WatchRuleExecution", func() error {
+			stream, err := ac.WatchRuleExecution(ctx, connect.NewRequest(&apiv1.WatchRuleExecutionRequest{Id: alice.execution.ID}))
+			if err != nil {
+				return err
+			}
+			defer stream.Close()
+			for stream.Receive() {
+			}
+			return stream.Err()
+		}},
+
+		// SettingsService
+		{"GetUser", func() error {
+			_, err := sc.GetUser(ctx, connect.NewRequest(&apiv1.GetUserRequest{Id: alice.user.ID}))
+			return err
+		}},
+		{"GetUserByEmail", func() error {
+			_, err := sc.GetUserByEmail(ctx, connect.NewRequest(&apiv1.GetUserByEmailRequest{Email: alice.user.Email}))
+			return err
+		}},
+		{"UpdateUser", func() error {
+			_, err := sc.UpdateUser(ctx, connect.NewRequest(&apiv1.UpdateUserRequest{
+				User: &apiv1.User{Id: alice.user.ID, Name: "Mallory"}, UpdateMask: mask("name"),
+			}))
+			return err
+		}},
+		{"DeleteUser", func() error {
+			_, err := sc.DeleteUser(ctx, connect.NewRequest(&apiv1.DeleteUserRequest{Id: alice.user.ID}))
+			return err
+		}},
+		{"UpdateUserPreferences", func() error {
+			_, err := sc.UpdateUserPreferences(ctx, connect.NewRequest(&apiv1.UpdateUserPreferencesRequest{
+				UserId: alice.user.ID, Preferences: &apiv1.UserPreferences{},
+			}))
+			return err
+		}},
+	}
+
+	for _, tc := range notFound {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.call()
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err), err.Error())
+		})
+	}
+
+	t.Run("CreateRule", func(t *testing.T) {
+		// Rule validation reports the portfolio as missing.
+		_, err := ac.CreateRule(ctx, connect.NewRequest(&apiv1.CreateRuleRequest{
+			Rule: &apiv1.Rule{Name: "Planted", RuleType: "monthly_withdrawal", PortfolioId: alice.portfolio.ID},
+		}))
+		require.Error(t, err)
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("ValidateRule", func(t *testing.T) {
+		cfg, err := structpb.NewStruct(map[string]any{"amount": "10", "currency_asset_id": asset.ID})
+		require.NoError(t, err)
+		resp, err := ac.ValidateRule(ctx, connect.NewRequest(&apiv1.ValidateRuleRequest{Rule: &apiv1.Rule{
+			Name: "Probe", RuleType: "monthly_withdrawal", UserId: bob.ID, PortfolioId: alice.portfolio.ID, Configuration: cfg,
+		}}))
+		require.NoError(t, err)
+		assert.False(t, resp.Msg.Valid)
+		assert.Contains(t, resp.Msg.ValidationErrors, "portfolio_id: portfolio "+alice.portfolio.ID+" does not exist")
+	})
+
+	t.Run("ListRuleTypes", func(t *testing.T) {
+		// Rule types are the same for everyone and carry no user's data.
+		resp, err := ac.ListRuleTypes(ctx, connect.NewRequest(&apiv1.ListRuleTypesRequest{}))
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.Msg.RuleTypes)
+	})
+
+	t.Run("GenerateTaxReport", func(t *testing.T) {
+		_, err := pc.GenerateTaxReport(ctx, connect.NewRequest(&apiv1.GenerateTaxReportRequest{
+			UserId: &alice.user.ID, TaxYear: int32(now.Year()),
+		}))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		own, err := pc.GenerateTaxReport(ctx, connect.NewRequest(&apiv1.GenerateTaxReportRequest{TaxYear: int32(now.Year())}))
+		require.NoError(t, err)
+		assert.Equal(t, bob.ID, own.Msg.UserId)
+		assert.Empty(t, own.Msg.Disposals)
+		assert.Empty(t, own.Msg.Income)
+	})
+
+	t.Run("CreateUser", func(t *testing.T) {
+		_, err := sc.CreateUser(ctx, connect.NewRequest(&apiv1.CreateUserRequest{
+			User: &apiv1.User{Email: "eve@example.com", Name: "Eve"},
+		}))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("lists omit other users' data", func(t *testing.T) {
+		portfolioList, err := pc.ListPortfolios(ctx, connect.NewRequest(&apiv1.ListPortfoliosRequest{}))
+		require.NoError(t, err)
+		assert.Empty(t, portfolioList.Msg.Portfolios)
+
+		accountList, err := pc.ListAccounts(ctx, connect.NewRequest(&apiv1.ListAccountsRequest{}))
+		require.NoError(t, err)
+		assert.Empty(t, accountList.Msg.Accounts)
+
+		holdingList, err := pc.ListHoldings(ctx, connect.NewRequest(&apiv1.ListHoldingsRequest{}))
+		require.NoError(t, err)
+		assert.Empty(t, holdingList.Msg.Holdings)
+
+		lotList, err := pc.ListLots(ctx, connect.NewRequest(&apiv1.ListLotsRequest{}))
+		require.NoError(t, err)
+		assert.Empty(t, lotList.Msg.Lots)
+
+		txList, err := pc.ListTransactions(ctx, connect.NewRequest(&apiv1.ListTransactionsRequest{}))
+		require.NoError(t, err)
+		assert.Empty(t, txList.Msg.Transactions)
+
+		ruleList, err := ac.ListRules(ctx, connect.NewRequest(&apiv1.ListRulesRequest{UserId: &alice.user.ID}))
+		require.NoError(t, err)
+		assert.Empty(t, ruleList.Msg.Rules)
+
+		execList, err := ac.ListRuleExecutions(ctx, connect.NewRequest(&apiv1.ListRuleExecutionsRequest{RuleId: &alice.rule.ID}))
+		require.NoError(t, err)
+		assert.Empty(t, execList.Msg.RuleExecutions)
+
+		memberList, err := pc.ListPortfolioMembers(ctx, connect.NewRequest(&apiv1.ListPortfolioMembersRequest{PortfolioId: &alice.portfolio.ID}))
+		require.NoError(t, err)
+		assert.Empty(t, memberList.Msg.PortfolioMembers)
+		memberList, err = pc.ListPortfolioMembers(ctx, connect.NewRequest(&apiv1.ListPortfolioMembersRequest{UserId: &carol.ID}))
+		require.NoError(t, err)
+		assert.Empty(t, memberList.Msg.PortfolioMembers)
+
+		userList, err := sc.ListUsers(ctx, connect.NewRequest(&apiv1.ListUsersRequest{}))
+		require.NoError(t, err)
+		require.Len(t, userList.Msg.Users, 1)
+		assert.Equal(t, bob.ID, userList.Msg.Users[0].Id)
+	})
+
+	t.Run("victim data is intact", func(t *testing.T) {
+		p, err := portfolios.GetPortfolio(ctx, alice.portfolio.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Main", p.Name)
+
+		h, err := portfolios.GetHolding(ctx, alice.holding.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), h.Amount)
+
+		r, err := rules.GetRule(ctx, alice.rule.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.RuleStatusActive, r.Status)
+
+		e, err := rules.GetRuleExecution(ctx, alice.execution.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ExecutionStatusPending, e.Status)
+
+		u, err := users.GetUser(ctx, alice.user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", u.Name)
+	})
+
+	t.Run("own data is visible", func(t *testing.T) {
+		created, err := pc.CreatePortfolio(ctx, connect.NewRequest(&apiv1.CreatePortfolioRequest{
+			Portfolio: &apiv1.Portfolio{Name: "Bob's"},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, bob.ID, created.Msg.UserId)
+
+		got, err := pc.GetPortfolio(ctx, connect.NewRequest(&apiv1.GetPortfolioRequest{Id: created.Msg.Id}))
+		require.NoError(t, err)
+		assert.Equal(t, "Bob's", got.Msg.Name)
+	})
+}
+
+// TestPortfolioSharing walks a member through an invitation and role change,
+// checking what each role may do with the shared portfolio, its holdings,
+// lots and transactions, and its rules.
+func TestPortfolioSharing(t *testing.T) {
+	pool := getTestPool(t)
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	users := NewSettingsStore(pool)
+	portfolios := NewPortfolioStore(pool, nil)
+	rules := NewAutomationStore(pool)
+	marketData := NewMarketDataStore(pool)
+
+	asset := createTestAsset(t, marketData, "Bitcoin")
+	alice := createVictim(t, users, portfolios, rules, asset.ID)
+	bob, err := users.CreateUser(ctx, &entity.User{Email: "bob@example.com", Name: "Bob"})
+	require.NoError(t, err)
+
+	events := pubsub.NewHub(log)
+	defer events.Close()
+
+	clientsFor := func(userID string) (apiv1connect.PortfolioServiceClient, apiv1connect.AutomationServiceClient) {
+		opts := connect.WithInterceptors(asPrincipal{&auth.Principal{
+			UserID: userID,
+			Scopes: []string{auth.ScopeRead, auth.ScopeWrite},
+			Method: auth.MethodSession,
+		}})
+		mux := http.NewServeMux()
+		mux.Handle(apiv1connect.NewPortfolioServiceHandler(portfolio.NewHandler(portfolios, marketData, users, portfolio.TaxConfig{}, portfolio.ImportConfig{}, log), opts))
+		mux.Handle(apiv1connect.NewAutomationServiceHandler(automation.NewHandler(rules, portfolios, marketData, events, log), opts))
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+		return apiv1connect.NewPortfolioServiceClient(srv.Client(), srv.URL),
+			apiv1connect.NewAutomationServiceClient(srv.Client(), srv.URL)
+	}
+	ownerPC, _ := clientsFor(alice.user.ID)
+	pc, ac := clientsFor(bob.ID)
+
+	// A transaction in Alice's account of an asset the portfolio does not
+	// hold.
+	other := createTestAsset(t, marketData, "Ether")
+	private, err := portfolios.CreateTransaction(ctx, &entity.Transaction{
+		Type: entity.TransactionTypeDeposit, Status: entity.TransactionStatusCompleted, AccountID: alice.account.ID, AssetID: other.ID,
+	})
+	require.NoError(t, err)
+
+	mask := func(paths ...string) *fieldmaskpb.FieldMask { return &fieldmaskpb.FieldMask{Paths: paths} }
+	invite := func(role apiv1.PortfolioRole) *apiv1.PortfolioMember {
+		t.Helper()
+		resp, err := ownerPC.InvitePortfolioMember(ctx, connect.NewRequest(&apiv1.InvitePortfolioMemberRequest{
+			PortfolioId: alice.portfolio.ID, Email: bob.Email, Role: role,
+		}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+	getPortfolio := func() error {
+		_, err := pc.GetPortfolio(ctx, connect.NewRequest(&apiv1.GetPortfolioRequest{Id: alice.portfolio.ID}))
+		return err
+	}
+	updatePortfolio := func() error {
+		_, err := pc.UpdatePortfolio(ctx, connect.NewRequest(&apiv1.UpdatePortfolioRequest{
+			Portfolio: &apiv1.Portfolio{Id: alice.portfolio.ID, Name: "Shared"}, UpdateMask: mask("name"),
+		}))
+		return err
+	}
+	updateHolding := func() error {
+		_, err := pc.UpdateHolding(ctx, connect.NewRequest(&apiv1.UpdateHoldingRequest{
+			Holding: &apiv1.Holding{Id: alice.holding.ID, Amount: 90}, UpdateMask: mask("amount"),
+		}))
+		return err
+	}
+	updateLot := func() error {
+		_, err := pc.UpdateLot(ctx, connect.NewRequest(&apiv1.UpdateLotRequest{
+			Lot: &apiv1.Lot{Id: alice.lot.ID, Amount: 90}, UpdateMask: mask("amount"),
+		}))
+		return err
+	}
+	updateTransaction := func() error {
+		_, err := pc.UpdateTransaction(ctx, connect.NewRequest(&apiv1.UpdateTransactionRequest{
+			Transaction: &apiv1.Transaction{Id: alice.tx.ID, Status: apiv1.TransactionStatus_TRANSACTION_STATUS_COMPLETED},
+			UpdateMask:  mask("status"),
+		}))
+		return err
+	}
+	createRule := func() error {
+		cfg, err := structpb.NewStruct(map[string]any{"amount": "10", "currency_asset_id": asset.ID})
+		require.NoError(t, err)
+		_, err = ac.CreateRule(ctx, connect.NewRequest(&apiv1.CreateRuleRequest{Rule: &apiv1.Rule{
+			Name: "Bob's withdrawal", RuleType: "monthly_withdrawal", PortfolioId: alice.portfolio.ID, Configuration: cfg,
+		}}))
+		return err
+	}
+	executeRule := func() (*apiv1.ExecuteRuleAsyncResponse, error) {
+		resp, err := ac.ExecuteRuleAsync(ctx, connect.NewRequest(&apiv1.ExecuteRuleAsyncRequest{RuleId: alice.rule.ID, DryRun: true}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+	deletePortfolio := func() error {
+		_, err := pc.DeletePortfolio(ctx, connect.NewRequest(&apiv1.DeletePortfolioRequest{Id: alice.portfolio.ID}))
+		return err
+	}
+
+	t.Run("pending invitation grants nothing", func(t *testing.T) {
+		m := invite(apiv1.PortfolioRole_PORTFOLIO_ROLE_VIEWER)
+		assert.Equal(t, bob.ID, m.UserId)
+		assert.Equal(t, alice.user.ID, m.InvitedBy)
+		assert.Equal(t, apiv1.PortfolioMemberStatus_PORTFOLIO_MEMBER_STATUS_PENDING, m.Status)
+
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(getPortfolio()))
+
+		list, err := pc.ListPortfolioMembers(ctx, connect.NewRequest(&apiv1.ListPortfolioMembersRequest{UserId: &bob.ID}))
+		require.NoError(t, err)
+		require.Len(t, list.Msg.PortfolioMembers, 1)
+		assert.Equal(t, alice.portfolio.ID, list.Msg.PortfolioMembers[0].PortfolioId)
+	})
+
+	t.Run("viewer reads but cannot change", func(t *testing.T) {
+		accepted, err := pc.AcceptPortfolioInvitation(ctx, connect.NewRequest(&apiv1.AcceptPortfolioInvitationRequest{
+			PortfolioId: alice.portfolio.ID,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, apiv1.PortfolioMemberStatus_PORTFOLIO_MEMBER_STATUS_ACTIVE, accepted.Msg.Status)
+
+		require.NoError(t, getPortfolio())
+		_, err = pc.GetHolding(ctx, connect.NewRequest(&apiv1.GetHoldingRequest{Id: alice.holding.ID}))
+		require.NoError(t, err)
+		_, err = ac.GetRule(ctx, connect.NewRequest(&apiv1.GetRuleRequest{Id: alice.rule.ID}))
+		require.NoError(t, err)
+
+		portfolioList, err := pc.ListPortfolios(ctx, connect.NewRequest(&apiv1.ListPortfoliosRequest{}))
+		require.NoError(t, err)
+		assert.Len(t, portfolioList.Msg.Portfolios, 1)
+		execList, err := ac.ListRuleExecutions(ctx, connect.NewRequest(&apiv1.ListRuleExecutionsRequest{RuleId: &alice.rule.ID}))
+		require.NoError(t, err)
+		assert.Len(t, execList.Msg.RuleExecutions, 1)
+
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(updatePortfolio()))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(updateHolding()))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(updateLot()))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(updateTransaction()))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(createRule()))
+		_, err = executeRule()
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		// Lots follow their holding's portfolio, transactions the holdings
+		// they touch.
+		_, err = pc.GetLot(ctx, connect.NewRequest(&apiv1.GetLotRequest{Id: alice.lot.ID}))
+		require.NoError(t, err)
+		lotList, err := pc.ListLots(ctx, connect.NewRequest(&apiv1.ListLotsRequest{HoldingId: &alice.holding.ID}))
+		require.NoError(t, err)
+		assert.Len(t, lotList.Msg.Lots, 1)
+		_, err = pc.GetTransaction(ctx, connect.NewRequest(&apiv1.GetTransactionRequest{Id: alice.tx.ID}))
+		require.NoError(t, err)
+		txList, err := pc.ListTransactions(ctx, connect.NewRequest(&apiv1.ListTransactionsRequest{AccountId: &alice.account.ID}))
+		require.NoError(t, err)
+		require.Len(t, txList.Msg.Transactions, 1)
+		assert.Equal(t, alice.tx.ID, txList.Msg.Transactions[0].Id)
+		_, err = pc.GetTransaction(ctx, connect.NewRequest(&apiv1.GetTransactionRequest{Id: private.ID}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+		// The accounts themselves stay private to their owner.
+		_, err = pc.GetAccount(ctx, connect.NewRequest(&apiv1.GetAccountRequest{Id: alice.account.ID}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("editor changes and executes", func(t *testing.T) {
+		m := invite(apiv1.PortfolioRole_PORTFOLIO_ROLE_EDITOR)
+		assert.Equal(t, apiv1.PortfolioMemberStatus_PORTFOLIO_MEMBER_STATUS_ACTIVE, m.Status, "a role change keeps the membership active")
+
+		require.NoError(t, updatePortfolio())
+		require.NoError(t, updateHolding())
+		require.NoError(t, updateLot())
+		require.NoError(t, updateTransaction())
+		require.NoError(t, createRule())
+
+		_, err := pc.CreateTransaction(ctx, connect.NewRequest(&apiv1.CreateTransactionRequest{Transaction: &apiv1.Transaction{
+			Type: apiv1.TransactionType_TRANSACTION_TYPE_DEPOSIT, AccountId: alice.account.ID, AssetId: &asset.ID,
+		}}))
+		require.NoError(t, err)
+		_, err = pc.CreateTransaction(ctx, connect.NewRequest(&apiv1.CreateTransactionRequest{Transaction: &apiv1.Transaction{
+			Type: apiv1.TransactionType_TRANSACTION_TYPE_DEPOSIT, AccountId: alice.account.ID, AssetId: &other.ID,
+		}}))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		queued, err := executeRule()
+		require.NoError(t, err)
+		exec, err := ac.GetRuleExecution(ctx, connect.NewRequest(&apiv1.GetRuleExecutionRequest{Id: queued.ExecutionId}))
+		require.NoError(t, err)
+		assert.Equal(t, bob.ID, exec.Msg.GetCreatedBy())
+
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(deletePortfolio()))
+		_, err = pc.InvitePortfolioMember(ctx, connect.NewRequest(&apiv1.InvitePortfolioMemberRequest{
+			PortfolioId: alice.portfolio.ID, Email: alice.user.Email, Role: apiv1.PortfolioRole_PORTFOLIO_ROLE_VIEWER,
+		}))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("revoked member loses access", func(t *testing.T) {
+		_, err := ownerPC.RevokePortfolioMember(ctx, connect.NewRequest(&apiv1.RevokePortfolioMemberRequest{
+			PortfolioId: alice.portfolio.ID, UserId: bob.ID,
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(getPortfolio()))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(updateHolding()))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(updateLot()))
+		_, err = pc.GetTransaction(ctx, connect.NewRequest(&apiv1.GetTransactionRequest{Id: alice.tx.ID}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+		list, err := ownerPC.ListPortfolioMembers(ctx, connect.NewRequest(&apiv1.ListPortfolioMembersRequest{
+			PortfolioId: &alice.portfolio.ID,
+		}))
+		require.NoError(t, err)
+		assert.Empty(t, list.Msg.PortfolioMembers)
+	})
+}
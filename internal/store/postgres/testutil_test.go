@@ -39,9 +39,20 @@ func getTestPool(t *testing.T) *pgxpool.Pool {
 
 	// Truncate in order: child tables first (those with foreign keys to others).
 	testDB.MustTruncate(t,
+		"audit_events",
+		"rate_limit_buckets",
+		"api_keys",
+		"signing_keys",
+		"rule_executions",
+		"rules",
+		"corporate_action_adjustments",
+		"corporate_actions",
 		"transactions",
+		"lots",
 		"holdings",
+		"dividends",
 		"prices",
+		"portfolio_members",
 		"portfolios",
 		"accounts",
 		"assets",
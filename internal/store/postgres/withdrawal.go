@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/foxcool/greedy-eye/internal/audit"
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/service/portfolio"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/jackc/pgx/v5"
+)
+
+// ApplyWithdrawalStep applies a step of a withdrawal in one database
+// transaction. The holding's row is locked first, so concurrent steps on it
+// take their units one after the other.
+func (s *PortfolioStore) ApplyWithdrawalStep(ctx context.Context, w *portfolio.WithdrawalStep) error {
+	if w == nil || w.HoldingID == "" {
+		return fmt.Errorf("%w: holding is required", store.ErrInvalidArgument)
+	}
+	if !isValidUUID(w.HoldingID) {
+		return fmt.Errorf("%w: invalid holding ID format", store.ErrInvalidArgument)
+	}
+	if w.Lot != nil && !isValidUUID(w.Lot.ID) {
+		return fmt.Errorf("%w: invalid lot ID format", store.ErrInvalidArgument)
+	}
+
+	holdingBefore := auditBefore(ctx, s.GetHolding, w.HoldingID)
+	var lotBefore *entity.Lot
+	if w.Lot != nil {
+		lotBefore = auditBefore(ctx, s.GetLot, w.Lot.ID)
+	}
+
+	dbTx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	var holdingID int64
+	filter, args := holdingFilter(ctx, holdingOwner, "portfolio_id", entity.PortfolioRoleEditor, []any{w.HoldingID})
+	err = dbTx.QueryRow(ctx, `
+		SELECT id FROM holdings
+		WHERE uuid = $1 AND `+filter+`
+		FOR UPDATE`, args...).Scan(&holdingID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return deniedOrNotFound(ctx, s.GetHolding, w.HoldingID, fmt.Errorf("%w: holding with ID %s", store.ErrNotFound, w.HoldingID))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock holding: %w", err)
+	}
+
+	if _, err := dbTx.Exec(ctx, "UPDATE holdings SET amount = amount - $2, updated_at = NOW() WHERE id = $1",
+		holdingID, w.Amount); err != nil {
+		return fmt.Errorf("failed to update holding: %w", err)
+	}
+	if w.Lot != nil {
+		tag, err := dbTx.Exec(ctx, `
+			UPDATE lots SET amount = $3, cost_basis = $4, updated_at = NOW()
+			WHERE holding_id = $1 AND uuid = $2`, holdingID, w.Lot.ID, w.Lot.Amount, w.Lot.CostBasis)
+		if err != nil {
+			return fmt.Errorf("failed to update lot: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("%w: lot with ID %s in holding %s", store.ErrNotFound, w.Lot.ID, w.HoldingID)
+		}
+	}
+
+	var created []*entity.Transaction
+	if w.Transaction != nil {
+		if created, err = s.insertNewTransactions(ctx, dbTx, []*entity.Transaction{w.Transaction}); err != nil {
+			return err
+		}
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit withdrawal step: %w", err)
+	}
+
+	if !audit.Enabled(ctx) {
+		return nil
+	}
+	audit.RecordChange(ctx, "holding", w.HoldingID, holdingBefore, auditBefore(ctx, s.GetHolding, w.HoldingID))
+	if w.Lot != nil {
+		audit.RecordChange(ctx, "lot", w.Lot.ID, lotBefore, auditBefore(ctx, s.GetLot, w.Lot.ID))
+	}
+	for _, t := range created {
+		audit.RecordChange(ctx, "transaction", t.ID, nil, t)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/foxcool/greedy-eye/internal/entity"
+	"github.com/foxcool/greedy-eye/internal/secrets"
+	"github.com/foxcool/greedy-eye/internal/service/portfolio"
+	"github.com/foxcool/greedy-eye/internal/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyWithdrawalStep(t *testing.T) {
+	pool := getTestPool(t)
+	ctx := context.Background()
+	s := NewMarketDataStore(pool)
+	keys, err := secrets.NewKeyring(map[uint32][]byte{1: make([]byte, 32)})
+	require.NoError(t, err)
+	portfolios := NewPortfolioStore(pool, keys)
+
+	usd := createTestAsset(t, s, "Dollar")
+	btc := createTestAsset(t, s, "Bitcoin")
+	user, err := NewSettingsStore(pool).CreateUser(ctx, &entity.User{Email: "alice@example.com", Name: "Alice"})
+	require.NoError(t, err)
+	account, err := portfolios.CreateAccount(ctx, &entity.Account{UserID: user.ID, Name: "Exchange", Type: entity.AccountTypeExchange})
+	require.NoError(t, err)
+	holding, err := portfolios.CreateHolding(ctx, &entity.Holding{Amount: 2_00000000, Decimals: 8, AssetID: btc.ID, AccountID: account.ID})
+	require.NoError(t, err)
+	lot, err := portfolios.CreateLot(ctx, &entity.Lot{
+		HoldingID: holding.ID, Amount: 2_00000000, Decimals: 8, CostBasis: 1600_00, CostDecimals: 2, CostAssetID: usd.ID,
+		AcquiredAt: time.Date(2023, time.January, 10, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	sale := func() *entity.Transaction {
+		return &entity.Transaction{
+			Type: entity.TransactionTypeTrade, Status: entity.TransactionStatusCompleted,
+			AccountID: account.ID, AssetID: btc.ID,
+			Data: map[string]string{"side": "sell", "amount": "0.5", "lot_id": lot.ID},
+		}
+	}
+	kept := *lot
+	kept.Amount, kept.CostBasis = 1_50000000, 1200_00
+	w := &portfolio.WithdrawalStep{HoldingID: holding.ID, Amount: 50000000, Lot: &kept, Transaction: sale()}
+	require.NoError(t, portfolios.ApplyWithdrawalStep(ctx, w))
+	assert.NotEmpty(t, w.Transaction.ID)
+
+	got, err := portfolios.GetHolding(ctx, holding.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_50000000), got.Amount)
+	gotLot, err := portfolios.GetLot(ctx, lot.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_50000000), gotLot.Amount)
+	assert.Equal(t, int64(1200_00), gotLot.CostBasis)
+	txs, _, err := portfolios.ListTransactions(ctx, portfolio.ListTransactionsOpts{AccountID: account.ID})
+	require.NoError(t, err)
+	assert.Len(t, txs, 1)
+
+	t.Run("All or nothing", func(t *testing.T) {
+		missing := kept
+		missing.ID = uuid.New().String()
+		err := portfolios.ApplyWithdrawalStep(ctx, &portfolio.WithdrawalStep{
+			HoldingID: holding.ID, Amount: 50000000, Lot: &missing, Transaction: sale(),
+		})
+		require.ErrorIs(t, err, store.ErrNotFound)
+
+		got, err := portfolios.GetHolding(ctx, holding.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1_50000000), got.Amount)
+		txs, _, err := portfolios.ListTransactions(ctx, portfolio.ListTransactionsOpts{AccountID: account.ID})
+		require.NoError(t, err)
+		assert.Len(t, txs, 1)
+	})
+}
@@ -1,5 +1,9 @@
 schema "public" {}
 
+extension "pg_trgm" {
+  schema = schema.public
+}
+
 table "users" {
   schema = schema.public
 
@@ -43,7 +47,7 @@ table "users" {
   }
 }
 
-table "accounts" {
+table "api_keys" {
   schema = schema.public
 
   column "id" {
@@ -55,30 +59,34 @@ table "accounts" {
     type = uuid
     null = false
   }
-  column "created_at" {
-    type = timestamptz
-    null = false
-  }
-  column "updated_at" {
-    type = timestamptz
-    null = false
-  }
   column "name" {
     type = character_varying
     null = false
   }
-  column "description" {
+  column "prefix" {
     type = character_varying
-    null = true
+    null = false
   }
-  column "type" {
-    type = character_varying
+  column "key_hash" {
+    type = bytea
     null = false
   }
-  column "data" {
+  column "scopes" {
     type = jsonb
     null = false
   }
+  column "expires_at" {
+    type = timestamptz
+    null = true
+  }
+  column "last_used_at" {
+    type = timestamptz
+    null = true
+  }
+  column "created_at" {
+    type = timestamptz
+    null = false
+  }
   column "user_id" {
     type = bigint
     null = false
@@ -88,15 +96,29 @@ table "accounts" {
     columns = [column.id]
   }
 
-  foreign_key "accounts_users_accounts" {
+  index "api_keys_uuid_key" {
+    columns = [column.uuid]
+    unique  = true
+  }
+
+  index "api_keys_key_hash_key" {
+    columns = [column.key_hash]
+    unique  = true
+  }
+
+  index "api_key_user_id" {
+    columns = [column.user_id]
+  }
+
+  foreign_key "api_keys_users_api_keys" {
     columns     = [column.user_id]
     ref_columns = [table.users.column.id]
     on_update   = NO_ACTION
-    on_delete   = NO_ACTION
+    on_delete   = CASCADE
   }
 }
 
-table "assets" {
+table "signing_keys" {
   schema = schema.public
 
   column "id" {
@@ -104,32 +126,20 @@ table "assets" {
     null = false
     identity {}
   }
-  column "uuid" {
-    type = uuid
-    null = false
-  }
-  column "created_at" {
-    type = timestamptz
-    null = false
-  }
-  column "updated_at" {
-    type = timestamptz
-    null = false
-  }
-  column "symbol" {
+  column "kid" {
     type = character_varying
     null = false
   }
-  column "name" {
-    type = character_varying
+  column "private_key" {
+    type = bytea
     null = false
   }
-  column "type" {
-    type = character_varying
+  column "created_at" {
+    type = timestamptz
     null = false
   }
-  column "tags" {
-    type = jsonb
+  column "expires_at" {
+    type = timestamptz
     null = false
   }
 
@@ -137,13 +147,13 @@ table "assets" {
     columns = [column.id]
   }
 
-  index "asset_tags" {
-    columns = [column.tags]
-    type    = GIN
+  index "signing_keys_kid_key" {
+    columns = [column.kid]
+    unique  = true
   }
 }
 
-table "portfolios" {
+table "accounts" {
   schema = schema.public
 
   column "id" {
@@ -171,10 +181,26 @@ table "portfolios" {
     type = character_varying
     null = true
   }
+  column "type" {
+    type = character_varying
+    null = false
+  }
   column "data" {
     type = jsonb
     null = false
   }
+  column "secrets" {
+    type = bytea
+    null = true
+  }
+  column "secrets_key" {
+    type = bytea
+    null = true
+  }
+  column "secrets_key_version" {
+    type = integer
+    null = true
+  }
   column "user_id" {
     type = bigint
     null = false
@@ -184,7 +210,7 @@ table "portfolios" {
     columns = [column.id]
   }
 
-  foreign_key "portfolios_users_portfolios" {
+  foreign_key "accounts_users_accounts" {
     columns     = [column.user_id]
     ref_columns = [table.users.column.id]
     on_update   = NO_ACTION
@@ -192,7 +218,7 @@ table "portfolios" {
   }
 }
 
-table "holdings" {
+table "assets" {
   schema = schema.public
 
   column "id" {
@@ -212,24 +238,33 @@ table "holdings" {
     type = timestamptz
     null = false
   }
-  column "amount" {
-    type = bigint
+  column "symbol" {
+    type = character_varying
     null = false
   }
-  column "decimals" {
-    type = bigint
+  column "name" {
+    type = character_varying
     null = false
   }
-  column "account_id" {
-    type = bigint
+  column "type" {
+    type = character_varying
     null = false
   }
-  column "asset_id" {
-    type = bigint
+  column "tags" {
+    type = jsonb
     null = false
   }
-  column "portfolio_id" {
-    type = bigint
+  column "metadata" {
+    type    = jsonb
+    null    = false
+    default = "{}"
+  }
+  column "last_enriched_at" {
+    type = timestamptz
+    null = true
+  }
+  column "bond" {
+    type = jsonb
     null = true
   }
 
@@ -237,29 +272,39 @@ table "holdings" {
     columns = [column.id]
   }
 
-  foreign_key "holdings_accounts_holdings" {
-    columns     = [column.account_id]
-    ref_columns = [table.accounts.column.id]
-    on_update   = NO_ACTION
-    on_delete   = NO_ACTION
+  index "asset_tags" {
+    columns = [column.tags]
+    type    = GIN
   }
 
-  foreign_key "holdings_assets_holdings" {
-    columns     = [column.asset_id]
-    ref_columns = [table.assets.column.id]
-    on_update   = NO_ACTION
-    on_delete   = NO_ACTION
+  index "asset_search" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, (((name)::text || ' '::text) || (symbol)::text))"
+    }
   }
 
-  foreign_key "holdings_portfolios_holdings" {
-    columns     = [column.portfolio_id]
-    ref_columns = [table.portfolios.column.id]
-    on_update   = NO_ACTION
-    on_delete   = SET_NULL
+  index "asset_symbol_lower" {
+    on {
+      expr = "lower((symbol)::text)"
+      ops  = text_pattern_ops
+    }
+  }
+
+  index "asset_trgm" {
+    type = GIN
+    on {
+      column = column.name
+      ops    = gin_trgm_ops
+    }
+    on {
+      column = column.symbol
+      ops    = gin_trgm_ops
+    }
   }
 }
 
-table "prices" {
+table "asset_identifiers" {
   schema = schema.public
 
   column "id" {
@@ -271,43 +316,108 @@ table "prices" {
     type = uuid
     null = false
   }
-  column "source_id" {
+  column "kind" {
     type = character_varying
     null = false
   }
-  column "interval" {
+  column "chain" {
+    type    = character_varying
+    null    = false
+    default = ""
+  }
+  column "value" {
     type = character_varying
     null = false
   }
-  column "decimals" {
-    type = bigint
+  column "created_at" {
+    type = timestamptz
     null = false
   }
-  column "last" {
+  column "asset_id" {
     type = bigint
     null = false
   }
-  column "open" {
-    type = bigint
-    null = true
+
+  primary_key {
+    columns = [column.id]
   }
-  column "high" {
+
+  index "asset_identifiers_uuid_key" {
+    columns = [column.uuid]
+    unique  = true
+  }
+
+  index "asset_identifier_kind_chain_value" {
+    columns = [column.kind, column.chain, column.value]
+    unique  = true
+  }
+
+  index "asset_identifier_value" {
+    columns = [column.value]
+  }
+
+  index "asset_identifier_asset_id" {
+    columns = [column.asset_id]
+  }
+
+  foreign_key "asset_identifiers_assets_identifiers" {
+    columns     = [column.asset_id]
+    ref_columns = [table.assets.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+}
+
+table "corporate_actions" {
+  schema = schema.public
+
+  column "id" {
     type = bigint
-    null = true
+    null = false
+    identity {}
   }
-  column "low" {
+  column "uuid" {
+    type = uuid
+    null = false
+  }
+  column "type" {
+    type = character_varying
+    null = false
+  }
+  column "status" {
+    type = character_varying
+    null = false
+  }
+  column "effective_at" {
+    type = timestamptz
+    null = false
+  }
+  column "new_units" {
     type = bigint
-    null = true
+    null = false
   }
-  column "close" {
+  column "old_units" {
     type = bigint
+    null = false
+  }
+  column "note" {
+    type    = character_varying
+    null    = false
+    default = ""
+  }
+  column "applied_at" {
+    type = timestamptz
     null = true
   }
-  column "volume" {
-    type = bigint
+  column "reverted_at" {
+    type = timestamptz
     null = true
   }
-  column "timestamp" {
+  column "created_at" {
+    type = timestamptz
+    null = false
+  }
+  column "updated_at" {
     type = timestamptz
     null = false
   }
@@ -315,36 +425,44 @@ table "prices" {
     type = bigint
     null = false
   }
-  column "base_asset_id" {
+  column "new_asset_id" {
     type = bigint
-    null = false
+    null = true
   }
 
   primary_key {
     columns = [column.id]
   }
 
-  index "price_asset_id_timestamp" {
-    columns = [column.asset_id, column.timestamp]
+  index "corporate_actions_uuid_key" {
+    columns = [column.uuid]
     unique  = true
   }
 
-  foreign_key "prices_assets_prices" {
+  index "corporate_action_asset_id" {
+    columns = [column.asset_id]
+  }
+
+  index "corporate_action_new_asset_id" {
+    columns = [column.new_asset_id]
+  }
+
+  foreign_key "corporate_actions_assets_corporate_actions" {
     columns     = [column.asset_id]
     ref_columns = [table.assets.column.id]
     on_update   = NO_ACTION
-    on_delete   = NO_ACTION
+    on_delete   = CASCADE
   }
 
-  foreign_key "prices_assets_prices_base" {
-    columns     = [column.base_asset_id]
+  foreign_key "corporate_actions_assets_migrations" {
+    columns     = [column.new_asset_id]
     ref_columns = [table.assets.column.id]
     on_update   = NO_ACTION
     on_delete   = NO_ACTION
   }
 }
 
-table "transactions" {
+table "corporate_action_adjustments" {
   schema = schema.public
 
   column "id" {
@@ -352,54 +470,814 @@ table "transactions" {
     null = false
     identity {}
   }
-  column "uuid" {
-    type = uuid
-    null = false
-  }
-  column "type" {
+  column "target" {
     type = character_varying
     null = false
   }
-  column "status" {
-    type = character_varying
+  column "target_id" {
+    type = bigint
     null = false
   }
-  column "data" {
+  column "before" {
     type = jsonb
     null = false
   }
-  column "created_at" {
-    type = timestamptz
-    null = false
-  }
-  column "updated_at" {
-    type = timestamptz
+  column "after" {
+    type = jsonb
     null = false
   }
-  column "account_id" {
+  column "action_id" {
     type = bigint
     null = false
   }
-  column "asset_transactions" {
-    type = bigint
-    null = true
-  }
 
   primary_key {
     columns = [column.id]
   }
 
-  foreign_key "transactions_accounts_transactions" {
-    columns     = [column.account_id]
-    ref_columns = [table.accounts.column.id]
-    on_update   = NO_ACTION
-    on_delete   = NO_ACTION
+  index "corporate_action_adjustment_action_id_target" {
+    columns = [column.action_id, column.target]
   }
 
-  foreign_key "transactions_assets_transactions" {
-    columns     = [column.asset_transactions]
-    ref_columns = [table.assets.column.id]
+  foreign_key "corporate_action_adjustments_corporate_actions_adjustments" {
+    columns     = [column.action_id]
+    ref_columns = [table.corporate_actions.column.id]
     on_update   = NO_ACTION
-    on_delete   = SET_NULL
+    on_delete   = CASCADE
+  }
+}
+
+table "portfolios" {
+  schema = schema.public
+
+  column "id" {
+    type = bigint
+    null = false
+    identity {}
+  }
+  column "uuid" {
+    type = uuid
+    null = false
+  }
+  column "created_at" {
+    type = timestamptz
+    null = false
+  }
+  column "updated_at" {
+    type = timestamptz
+    null = false
+  }
+  column "name" {
+    type = character_varying
+    null = false
+  }
+  column "description" {
+    type = character_varying
+    null = true
+  }
+  column "data" {
+    type = jsonb
+    null = false
+  }
+  column "user_id" {
+    type = bigint
+    null = false
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "portfolios_users_portfolios" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    on_update   = NO_ACTION
+    on_delete   = NO_ACTION
+  }
+}
+
+table "portfolio_members" {
+  schema = schema.public
+
+  column "id" {
+    type = bigint
+    null = false
+    identity {}
+  }
+  column "uuid" {
+    type = uuid
+    null = false
+  }
+  column "role" {
+    type = character_varying
+    null = false
+  }
+  column "status" {
+    type = character_varying
+    null = false
+  }
+  column "created_at" {
+    type = timestamptz
+    null = false
+  }
+  column "updated_at" {
+    type = timestamptz
+    null = false
+  }
+  column "portfolio_id" {
+    type = bigint
+    null = false
+  }
+  column "user_id" {
+    type = bigint
+    null = false
+  }
+  column "invited_by" {
+    type = bigint
+    null = true
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  index "portfolio_members_uuid_key" {
+    columns = [column.uuid]
+    unique  = true
+  }
+
+  index "portfolio_member_portfolio_id_user_id" {
+    columns = [column.portfolio_id, column.user_id]
+    unique  = true
+  }
+
+  index "portfolio_member_user_id" {
+    columns = [column.user_id]
+  }
+
+  foreign_key "portfolio_members_portfolios_members" {
+    columns     = [column.portfolio_id]
+    ref_columns = [table.portfolios.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+
+  foreign_key "portfolio_members_users_memberships" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+
+  foreign_key "portfolio_members_users_invitations" {
+    columns     = [column.invited_by]
+    ref_columns = [table.users.column.id]
+    on_update   = NO_ACTION
+    on_delete   = SET_NULL
+  }
+}
+
+table "holdings" {
+  schema = schema.public
+
+  column "id" {
+    type = bigint
+    null = false
+    identity {}
+  }
+  column "uuid" {
+    type = uuid
+    null = false
+  }
+  column "created_at" {
+    type = timestamptz
+    null = false
+  }
+  column "updated_at" {
+    type = timestamptz
+    null = false
+  }
+  column "amount" {
+    type = bigint
+    null = false
+  }
+  column "decimals" {
+    type = bigint
+    null = false
+  }
+  column "account_id" {
+    type = bigint
+    null = false
+  }
+  column "asset_id" {
+    type = bigint
+    null = false
+  }
+  column "portfolio_id" {
+    type = bigint
+    null = true
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "holdings_accounts_holdings" {
+    columns     = [column.account_id]
+    ref_columns = [table.accounts.column.id]
+    on_update   = NO_ACTION
+    on_delete   = NO_ACTION
+  }
+
+  foreign_key "holdings_assets_holdings" {
+    columns     = [column.asset_id]
+    ref_columns = [table.assets.column.id]
+    on_update   = NO_ACTION
+    on_delete   = NO_ACTION
+  }
+
+  foreign_key "holdings_portfolios_holdings" {
+    columns     = [column.portfolio_id]
+    ref_columns = [table.portfolios.column.id]
+    on_update   = NO_ACTION
+    on_delete   = SET_NULL
+  }
+}
+
+table "prices" {
+  schema = schema.public
+
+  column "id" {
+    type = bigint
+    null = false
+    identity {}
+  }
+  column "uuid" {
+    type = uuid
+    null = false
+  }
+  column "source_id" {
+    type = character_varying
+    null = false
+  }
+  column "interval" {
+    type = character_varying
+    null = false
+  }
+  column "decimals" {
+    type = bigint
+    null = false
+  }
+  column "last" {
+    type = bigint
+    null = false
+  }
+  column "open" {
+    type = bigint
+    null = true
+  }
+  column "high" {
+    type = bigint
+    null = true
+  }
+  column "low" {
+    type = bigint
+    null = true
+  }
+  column "close" {
+    type = bigint
+    null = true
+  }
+  column "volume" {
+    type = bigint
+    null = true
+  }
+  column "timestamp" {
+    type = timestamptz
+    null = false
+  }
+  column "quarantine" {
+    type = character_varying
+    null = true
+  }
+  column "asset_id" {
+    type = bigint
+    null = false
+  }
+  column "base_asset_id" {
+    type = bigint
+    null = false
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  index "price_asset_id_timestamp" {
+    columns = [column.asset_id, column.timestamp]
+    unique  = true
+  }
+
+  foreign_key "prices_assets_prices" {
+    columns     = [column.asset_id]
+    ref_columns = [table.assets.column.id]
+    on_update   = NO_ACTION
+    on_delete   = NO_ACTION
+  }
+
+  foreign_key "prices_assets_prices_base" {
+    columns     = [column.base_asset_id]
+    ref_columns = [table.assets.column.id]
+    on_update   = NO_ACTION
+    on_delete   = NO_ACTION
+  }
+}
+
+table "dividends" {
+  schema = schema.public
+
+  column "id" {
+    type = bigint
+    null = false
+    identity {}
+  }
+  column "source_id" {
+    type = character_varying
+    null = false
+  }
+  column "ex_date" {
+    type = timestamptz
+    null = false
+  }
+  column "per_share" {
+    type = bigint
+    null = false
+  }
+  column "decimals" {
+    type = bigint
+    null = false
+  }
+  column "recorded_at" {
+    type = timestamptz
+    null = true
+  }
+  column "created_at" {
+    type = timestamptz
+    null = false
+  }
+  column "asset_id" {
+    type = bigint
+    null = false
+  }
+  column "currency_asset_id" {
+    type = bigint
+    null = false
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  index "dividend_asset_id_ex_date" {
+    columns = [column.asset_id, column.ex_date]
+    unique  = true
+  }
+
+  index "dividend_unrecorded" {
+    columns = [column.ex_date]
+    where   = "recorded_at IS NULL"
+  }
+
+  foreign_key "dividends_assets_dividends" {
+    columns     = [column.asset_id]
+    ref_columns = [table.assets.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+
+  foreign_key "dividends_assets_currency" {
+    columns     = [column.currency_asset_id]
+    ref_columns = [table.assets.column.id]
+    on_update   = NO_ACTION
+    on_delete   = NO_ACTION
+  }
+}
+
+table "transactions" {
+  schema = schema.public
+
+  column "id" {
+    type = bigint
+    null = false
+    identity {}
+  }
+  column "uuid" {
+    type = uuid
+    null = false
+  }
+  column "type" {
+    type = character_varying
+    null = false
+  }
+  column "status" {
+    type = character_varying
+    null = false
+  }
+  column "data" {
+    type = jsonb
+    null = false
+  }
+  column "created_at" {
+    type = timestamptz
+    null = false
+  }
+  column "updated_at" {
+    type = timestamptz
+    null = false
+  }
+  column "account_id" {
+    type = bigint
+    null = false
+  }
+  column "asset_transactions" {
+    type = bigint
+    null = true
+  }
+  column "external_id" {
+    type = character_varying
+    null = true
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  index "transaction_account_id_external_id" {
+    columns = [column.account_id, column.external_id]
+    unique  = true
+  }
+
+  foreign_key "transactions_accounts_transactions" {
+    columns     = [column.account_id]
+    ref_columns = [table.accounts.column.id]
+    on_update   = NO_ACTION
+    on_delete   = NO_ACTION
+  }
+
+  foreign_key "transactions_assets_transactions" {
+    columns     = [column.asset_transactions]
+    ref_columns = [table.assets.column.id]
+    on_update   = NO_ACTION
+    on_delete   = SET_NULL
+  }
+}
+
+table "lots" {
+  schema = schema.public
+
+  column "id" {
+    type = bigint
+    null = false
+    identity {}
+  }
+  column "uuid" {
+    type = uuid
+    null = false
+  }
+  column "created_at" {
+    type = timestamptz
+    null = false
+  }
+  column "updated_at" {
+    type = timestamptz
+    null = false
+  }
+  column "amount" {
+    type = bigint
+    null = false
+  }
+  column "decimals" {
+    type = bigint
+    null = false
+  }
+  column "cost_basis" {
+    type = bigint
+    null = false
+  }
+  column "cost_decimals" {
+    type = bigint
+    null = false
+  }
+  column "acquired_at" {
+    type = timestamptz
+    null = false
+  }
+  column "holding_id" {
+    type = bigint
+    null = false
+  }
+  column "cost_asset_id" {
+    type = bigint
+    null = false
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  index "lot_holding_id_acquired_at" {
+    columns = [column.holding_id, column.acquired_at]
+  }
+
+  foreign_key "lots_holdings_lots" {
+    columns     = [column.holding_id]
+    ref_columns = [table.holdings.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+
+  foreign_key "lots_assets_lots" {
+    columns     = [column.cost_asset_id]
+    ref_columns = [table.assets.column.id]
+    on_update   = NO_ACTION
+    on_delete   = NO_ACTION
+  }
+}
+
+table "rules" {
+  schema = schema.public
+
+  column "id" {
+    type = bigint
+    null = false
+    identity {}
+  }
+  column "uuid" {
+    type = uuid
+    null = false
+  }
+  column "created_at" {
+    type = timestamptz
+    null = false
+  }
+  column "updated_at" {
+    type = timestamptz
+    null = false
+  }
+  column "name" {
+    type = character_varying
+    null = false
+  }
+  column "description" {
+    type = character_varying
+    null = true
+  }
+  column "rule_type" {
+    type = character_varying
+    null = false
+  }
+  column "status" {
+    type = character_varying
+    null = false
+  }
+  column "configuration" {
+    type = jsonb
+    null = false
+  }
+  column "schedule" {
+    type = jsonb
+    null = true
+  }
+  column "portfolio_id" {
+    type = bigint
+    null = false
+  }
+  column "user_id" {
+    type = bigint
+    null = false
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "rules_portfolios_rules" {
+    columns     = [column.portfolio_id]
+    ref_columns = [table.portfolios.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+
+  foreign_key "rules_users_rules" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    on_update   = NO_ACTION
+    on_delete   = NO_ACTION
+  }
+}
+
+table "rule_executions" {
+  schema = schema.public
+
+  column "id" {
+    type = bigint
+    null = false
+    identity {}
+  }
+  column "uuid" {
+    type = uuid
+    null = false
+  }
+  column "status" {
+    type = character_varying
+    null = false
+  }
+  column "started_at" {
+    type = timestamptz
+    null = false
+  }
+  column "completed_at" {
+    type = timestamptz
+    null = true
+  }
+  column "error_message" {
+    type = character_varying
+    null = true
+  }
+  column "created_transaction_ids" {
+    type = jsonb
+    null = false
+  }
+  column "affected_holding_ids" {
+    type = jsonb
+    null = false
+  }
+  column "summary" {
+    type = jsonb
+    null = false
+  }
+  column "dry_run" {
+    type    = boolean
+    null    = false
+    default = false
+  }
+  column "execution_context" {
+    type = character_varying
+    null = true
+  }
+  column "cancel_requested" {
+    type    = boolean
+    null    = false
+    default = false
+  }
+  column "worker_id" {
+    type = character_varying
+    null = true
+  }
+  column "heartbeat_at" {
+    type = timestamptz
+    null = true
+  }
+  column "rule_id" {
+    type = bigint
+    null = false
+  }
+  column "created_by" {
+    type = bigint
+    null = true
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  index "rule_execution_rule_id_started_at" {
+    columns = [column.rule_id, column.started_at]
+  }
+
+  index "rule_execution_status_heartbeat_at" {
+    columns = [column.status, column.heartbeat_at]
+  }
+
+  foreign_key "rule_executions_rules_executions" {
+    columns     = [column.rule_id]
+    ref_columns = [table.rules.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+
+  foreign_key "rule_executions_users_executions" {
+    columns     = [column.created_by]
+    ref_columns = [table.users.column.id]
+    on_update   = NO_ACTION
+    on_delete   = SET_NULL
+  }
+}
+
+table "audit_events" {
+  schema = schema.public
+
+  column "id" {
+    type = bigint
+    null = false
+    identity {}
+  }
+  column "uuid" {
+    type = uuid
+    null = false
+  }
+  column "created_at" {
+    type = timestamptz
+    null = false
+  }
+  column "actor_type" {
+    type = character_varying
+    null = false
+  }
+  column "actor_id" {
+    type = character_varying
+    null = false
+  }
+  column "credential_id" {
+    type = character_varying
+    null = true
+  }
+  column "owner_id" {
+    type = character_varying
+    null = true
+  }
+  column "procedure" {
+    type = character_varying
+    null = true
+  }
+  column "resource_type" {
+    type = character_varying
+    null = false
+  }
+  column "resource_id" {
+    type = character_varying
+    null = true
+  }
+  column "field_paths" {
+    type = jsonb
+    null = false
+  }
+  column "changes" {
+    type = jsonb
+    null = false
+  }
+  column "request_id" {
+    type = character_varying
+    null = true
+  }
+  column "client_ip" {
+    type = character_varying
+    null = true
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  index "audit_events_uuid_key" {
+    columns = [column.uuid]
+    unique  = true
+  }
+
+  index "audit_event_actor_id" {
+    columns = [column.actor_id]
+  }
+
+  index "audit_event_owner_id" {
+    columns = [column.owner_id]
+  }
+
+  index "audit_event_resource" {
+    columns = [column.resource_type, column.resource_id]
+  }
+}
+
+table "rate_limit_buckets" {
+  schema = schema.public
+
+  column "key" {
+    type = character_varying
+    null = false
+  }
+  column "tokens" {
+    type = double_precision
+    null = false
+  }
+  column "updated_at" {
+    type = timestamptz
+    null = false
+  }
+
+  primary_key {
+    columns = [column.key]
+  }
+
+  index "rate_limit_bucket_updated_at" {
+    columns = [column.updated_at]
   }
 }